@@ -0,0 +1,144 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/cmd/cencli/e2e/lib"
+	"github.com/censys/cencli/internal/pkg/clients/censys/fakeserver"
+)
+
+const (
+	// If not set to true, the tests against the fake API server will be skipped.
+	enableFakeServerTestsEnvVar = "CENCLI_ENABLE_FAKE_E2E_TESTS"
+)
+
+// fakeServerCase runs the CLI against a fake API server.
+type fakeServerCase struct {
+	name     string
+	opts     []fakeserver.Option
+	args     []string
+	exitCode int
+	assert   func(t *testing.T, stdout, stderr []byte, srv *fakeserver.Server)
+}
+
+func TestFakeServer(t *testing.T) {
+	if os.Getenv(enableFakeServerTestsEnvVar) != "true" {
+		t.Skip("Fake server tests are disabled. Set " + enableFakeServerTestsEnvVar + " to 'true' to run these tests")
+		return
+	}
+
+	binaryPath := lib.FindBinary()
+	require.NotEmpty(t, binaryPath, "Binary not built; run 'make censys' first")
+
+	cases := []fakeServerCase{
+		{
+			name: "search",
+			args: []string{"search", "host.services.port: 53", "-O", "json"},
+			assert: func(t *testing.T, stdout, _ []byte, _ *fakeserver.Server) {
+				var hits []map[string]any
+				require.NoError(t, json.Unmarshal(stdout, &hits))
+				assert.Len(t, hits, 3)
+			},
+		},
+		{
+			name: "view",
+			args: []string{"view", "8.8.8.8", "-O", "json"},
+			assert: func(t *testing.T, stdout, _ []byte, _ *fakeserver.Server) {
+				assert.Contains(t, string(stdout), `"dns.google"`)
+			},
+		},
+		{
+			name: "aggregate",
+			args: []string{"aggregate", "*", "host.services.port", "-O", "json"},
+			assert: func(t *testing.T, stdout, _ []byte, _ *fakeserver.Server) {
+				assert.Contains(t, string(stdout), `"443"`)
+			},
+		},
+		{
+			name: "history",
+			args: []string{"history", "8.8.8.8", "--duration", "7d", "-O", "json"},
+			assert: func(t *testing.T, _, _ []byte, srv *fakeserver.Server) {
+				require.NotEmpty(t, srv.Requests())
+				assert.Equal(t, "/v3/global/asset/host/8.8.8.8/timeline", srv.Requests()[0].Path)
+			},
+		},
+		{
+			name: "credits",
+			args: []string{"credits", "-O", "json"},
+			assert: func(t *testing.T, stdout, _ []byte, _ *fakeserver.Server) {
+				assert.Contains(t, string(stdout), "1000")
+			},
+		},
+		{
+			name: "retries server errors",
+			opts: []fakeserver.Option{fakeserver.WithFault(fakeserver.Fault{
+				Endpoint: "/v3/global/search",
+				Status:   http.StatusServiceUnavailable,
+				Count:    1,
+			})},
+			args: []string{"search", "*", "-O", "json", "--meta-json"},
+			assert: func(t *testing.T, _, stderr []byte, srv *fakeserver.Server) {
+				assert.Len(t, srv.Requests(), 2)
+				assert.Contains(t, string(stderr), `"retries":1`)
+			},
+		},
+		{
+			name: "dry run plans the requests without sending them",
+			args: []string{"search", "host.services.port: 53", "--fields", "host.ip", "--page-size", "10", "--dry-run"},
+			assert: func(t *testing.T, stdout, _ []byte, srv *fakeserver.Server) {
+				assert.Empty(t, srv.Requests())
+				assert.Contains(t, string(stdout), "POST "+srv.URL+"/v3/global/search/query")
+				assert.Contains(t, string(stdout), `"query": "host.services.port: 53"`)
+				assert.Contains(t, string(stdout), `"page_size": 10`)
+				assert.Contains(t, string(stdout), "Dry run: 1 request not sent (1 search).")
+			},
+		},
+		{
+			name:     "fails on persistent errors",
+			opts:     []fakeserver.Option{fakeserver.WithFault(fakeserver.Fault{Status: http.StatusInternalServerError})},
+			args:     []string{"view", "8.8.8.8"},
+			exitCode: 1,
+			assert: func(t *testing.T, _, stderr []byte, _ *fakeserver.Server) {
+				assert.NotEmpty(t, stderr)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := fakeserver.New(tc.opts...)
+			defer srv.Close()
+
+			dataDir := t.TempDir()
+			env := append(lib.E2EEnvVars(dataDir), "CENCLI_API_URL="+srv.URL)
+			require.NoError(t, configureFakeAuth(binaryPath, env))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			cmd := exec.CommandContext(ctx, binaryPath, tc.args...)
+			cmd.Env = append(os.Environ(), env...)
+
+			result := lib.RunCommand(cmd)
+			require.NoError(t, result.Error)
+			require.Equal(t, tc.exitCode, result.ExitCode, "unexpected exit code, stderr: %s", string(result.Stderr))
+			tc.assert(t, result.Stdout, result.Stderr, srv)
+		})
+	}
+}
+
+// configureFakeAuth stores a personal access token, which the fake server
+// accepts whatever its value.
+func configureFakeAuth(binaryPath string, env []string) error {
+	cmd := exec.Command(binaryPath, "config", "auth", "add", "--value", "fake-token", "--name", "fake")
+	cmd.Env = append(os.Environ(), env...)
+	return cmd.Run()
+}
@@ -3,37 +3,56 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/censys/cencli/internal/command"
+	aliascmd "github.com/censys/cencli/internal/command/alias"
+	plugincmd "github.com/censys/cencli/internal/command/plugin"
 	"github.com/censys/cencli/internal/command/root"
+	setupcmd "github.com/censys/cencli/internal/command/setup"
 	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/appdirs"
 	client "github.com/censys/cencli/internal/pkg/clients/censys"
 	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
 	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/metrics"
+	"github.com/censys/cencli/internal/pkg/selfupdate"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
 	"github.com/censys/cencli/internal/store"
+	"github.com/censys/cencli/internal/version"
 )
 
-func dataDir() (string, error) {
-	if override := os.Getenv("CENCLI_DATA_DIR"); override != "" {
-		if err := os.MkdirAll(override, 0o700); err != nil {
-			return "", err
-		}
-		return override, nil
+const (
+	// updateNoticeTimeout bounds the release check behind the new release notice.
+	updateNoticeTimeout = 5 * time.Second
+	// updateNoticeWait is how long to wait for the check after the command finishes.
+	updateNoticeWait = 500 * time.Millisecond
+)
+
+func appDirs() (appdirs.Dirs, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return appdirs.Dirs{}, err
+	}
+	dirs := appdirs.Resolve(os.Getenv, home)
+	// move files out of the legacy ~/.config/cencli layout (once)
+	moves, err := dirs.MigrateLegacy()
+	for _, m := range moves {
+		formatter.Println(formatter.Stderr, fmt.Sprintf("Moved %s to %s", m.From, m.To))
 	}
-	dir, err := os.UserHomeDir()
 	if err != nil {
-		return "", err
+		return appdirs.Dirs{}, err
 	}
-	dir = filepath.Join(dir, ".config", "cencli")
-	if err := os.MkdirAll(dir, 0o700); err != nil {
-		return "", err
+	if err := dirs.Ensure(); err != nil {
+		return appdirs.Dirs{}, err
 	}
-	return dir, nil
+	return dirs, nil
 }
 
 func main() {
@@ -41,31 +60,52 @@ func main() {
 }
 
 func run() int {
-	dir, err := dataDir()
+	// enable ANSI escape sequences on Windows consoles
+	restoreConsole := term.EnableANSI()
+	defer restoreConsole()
+	// write the output held back by --redact
+	defer formatter.FlushRedaction()
+
+	dirs, err := appDirs()
 	if err != nil {
 		formatter.PrintError(err, nil)
 		return 1
 	}
 
-	ds, err := store.New(dir)
+	ds, report, err := store.Open(dirs.Data)
 	if err != nil {
 		formatter.PrintError(err, nil)
 		return 1
 	}
+	for _, warning := range report.Warnings() {
+		formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render("Warning: "+warning))
+	}
 
-	cfg, err := config.New(dir)
+	cfg, err := config.New(dirs.Config)
 	if err != nil {
 		formatter.PrintError(err, nil)
 		return 1
 	}
 
-	commandCtx := command.NewCommandContext(cfg, ds)
+	collector := metrics.NewCollector()
+	connect := func(ctx context.Context) (client.Client, error) {
+		sdkCtx, sdkCancel := context.WithTimeout(ctx, 5*time.Second)
+		defer sdkCancel()
+		newClient := client.NewCensysSDK
+		if cfg.APIFlavor == config.APIFlavorLegacy {
+			newClient = client.NewLegacyClient
+		}
+		sdkClient, err := newClient(sdkCtx, ds, cfg.APIURL, cfg.Timeouts.HTTP, cfg.Transport, cfg.RetryStrategy, cfg.Debug)
+		if err != nil {
+			return nil, err
+		}
+		return client.NewInstrumentedClient(sdkClient, collector), nil
+	}
+	commandCtx := command.NewCommandContext(cfg, ds, command.WithSessionRecording(), command.WithAppDirs(dirs), command.WithMetrics(collector),
+		command.WithCensysClientFactory(connect))
 
 	// Build client and app services (optional to allow config/init before auth)
-	sdkCtx, sdkCancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer sdkCancel()
-	sdkClient, err := client.NewCensysSDK(sdkCtx, ds, cfg.Timeouts.HTTP, cfg.RetryStrategy, cfg.Debug)
-	if err != nil {
+	if cli, err := connect(context.Background()); err != nil {
 		if errors.Is(err, authdom.ErrAuthNotFound) {
 			// user hasn't configured enough to initialize the client
 		} else {
@@ -73,7 +113,7 @@ func run() int {
 			return 1
 		}
 	} else {
-		commandCtx.SetCensysClient(sdkClient)
+		commandCtx.SetCensysClient(cli)
 	}
 
 	rootCmd, err := command.RootCommandToCobra(root.NewRootCommand(commandCtx))
@@ -86,10 +126,106 @@ func run() int {
 	sigCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
 	defer stop()
 
-	cmd, err := rootCmd.ExecuteContextC(sigCtx)
+	// Aliases are expanded before plugins are dispatched, so that an alias can
+	// run a plugin.
+	args, aliasErr := aliascmd.Expand(rootCmd, cfg.Aliases, os.Args[1:])
+	if aliasErr != nil {
+		formatter.PrintError(aliasErr, nil)
+		return formatter.ExitCode(aliasErr)
+	}
+	rootCmd.SetArgs(args)
+
+	// On the first run in a terminal, walk through the setup before the command.
+	if setupcmd.ShouldRunOnFirstRun(sigCtx, commandCtx, rootCmd, args) {
+		if setupErr := setupcmd.RunWizard(sigCtx, commandCtx, rootCmd, setupcmd.WizardOptions{FirstRun: true}); setupErr != nil {
+			formatter.PrintError(setupErr, nil)
+			return formatter.ExitCode(setupErr)
+		}
+	}
+
+	notice := startUpdateNotice(sigCtx, cfg, dirs, args)
+
+	// External plugins (cencli-<name> on PATH) are dispatched before cobra,
+	// since they are not registered as commands.
+	if code, handled, pluginErr := plugincmd.Dispatch(sigCtx, commandCtx, rootCmd, dirs, args); handled {
+		if pluginErr != nil {
+			formatter.PrintError(pluginErr, nil)
+		}
+		return code
+	}
+
+	traceCtx, finishTracing := command.StartTracing(sigCtx, cfg)
+	cmd, err := rootCmd.ExecuteContextC(traceCtx)
+	// A token refused as expired or revoked can be replaced in a terminal,
+	// after which the command runs once more with the new one.
+	if setupcmd.ShouldReauth(commandCtx, cmd, err) {
+		if ok, reauthErr := setupcmd.Reauth(sigCtx, commandCtx); reauthErr != nil {
+			formatter.PrintError(reauthErr, nil)
+		} else if ok {
+			if retryCmd, buildErr := command.RootCommandToCobra(root.NewRootCommand(commandCtx)); buildErr != nil {
+				err = buildErr
+			} else {
+				retryCmd.SetArgs(args)
+				cmd, err = retryCmd.ExecuteContextC(traceCtx)
+			}
+		}
+	}
+	commandCtx.FinishMemo()
+	err = commandCtx.FinishDryRun(err)
+	err = commandCtx.FinishEnvelope(err)
+	finishTracing(cmd, err)
+	// recorded even if the command was interrupted
+	commandCtx.RecordUsage(context.Background(), collector.Snapshot(), err)
+	// cfg is re-unmarshaled after flag parsing, so this reflects --metrics-file
+	if cfg.MetricsFile != "" {
+		if writeErr := collector.WriteFile(cfg.MetricsFile); writeErr != nil {
+			formatter.PrintError(writeErr, nil)
+		}
+	}
+	printUpdateNotice(notice, cfg)
 	if err != nil {
 		formatter.PrintError(err, cmd)
+		if hint := setupcmd.AuthFailureHint(cfg, err); hint != "" {
+			formatter.Println(formatter.Stderr, styles.GlobalStyles.Comment.Render(hint))
+		}
 		return formatter.ExitCode(err)
 	}
 	return 0
 }
+
+// updateNoticeSkipCommands never show the new release notice: it would be
+// redundant, or end up in generated completion scripts.
+var updateNoticeSkipCommands = map[string]struct{}{
+	"update":           {},
+	"completion":       {},
+	"__complete":       {},
+	"__completeNoDesc": {},
+}
+
+// startUpdateNotice starts the daily check for a newer release alongside the
+// command, or returns nil when the notice is disabled or would not be seen.
+func startUpdateNotice(ctx context.Context, cfg *config.Config, dirs appdirs.Dirs, args []string) *selfupdate.Notice {
+	if !cfg.UpdateNotice || !selfupdate.IsRelease(version.Version) || !formatter.StderrIsTTY() {
+		return nil
+	}
+	if len(args) > 0 {
+		if _, skip := updateNoticeSkipCommands[args[0]]; skip {
+			return nil
+		}
+	}
+	client := selfupdate.NewClient(&http.Client{Timeout: updateNoticeTimeout}, selfupdate.DefaultReleasesURL)
+	return selfupdate.StartNotice(ctx, client, dirs.Cache, version.Version, time.Now)
+}
+
+// printUpdateNotice prints the new release notice, if any. It waits briefly
+// for a check that is still running; a slow check is retried on the next run.
+func printUpdateNotice(notice *selfupdate.Notice, cfg *config.Config) {
+	// cfg is re-unmarshaled after flag parsing, so this reflects --quiet
+	if notice == nil || cfg.Quiet {
+		return
+	}
+	if msg := notice.Pending(updateNoticeWait); msg != "" {
+		formatter.Println(formatter.Stderr, styles.GlobalStyles.Comment.Render(msg))
+		notice.MarkShown()
+	}
+}
@@ -45,6 +45,21 @@ func (m *MockCenseyeService) EXPECT() *MockCenseyeServiceMockRecorder {
 	return m.recorder
 }
 
+// CountValues mocks base method.
+func (m *MockCenseyeService) CountValues(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], collectionID mo.Option[identifiers.CollectionID], pairs []censeye.FieldValue) (censeye.CountValuesResult, cenclierrors.CencliError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountValues", ctx, orgID, collectionID, pairs)
+	ret0, _ := ret[0].(censeye.CountValuesResult)
+	ret1, _ := ret[1].(cenclierrors.CencliError)
+	return ret0, ret1
+}
+
+// CountValues indicates an expected call of CountValues.
+func (mr *MockCenseyeServiceMockRecorder) CountValues(ctx, orgID, collectionID, pairs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountValues", reflect.TypeOf((*MockCenseyeService)(nil).CountValues), ctx, orgID, collectionID, pairs)
+}
+
 // InvestigateHost mocks base method.
 func (m *MockCenseyeService) InvestigateHost(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], host *assets.Host, rarityMin, rarityMax uint64) (censeye.InvestigateHostResult, cenclierrors.CencliError) {
 	m.ctrl.T.Helper()
@@ -59,3 +74,33 @@ func (mr *MockCenseyeServiceMockRecorder) InvestigateHost(ctx, orgID, host, rari
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvestigateHost", reflect.TypeOf((*MockCenseyeService)(nil).InvestigateHost), ctx, orgID, host, rarityMin, rarityMax)
 }
+
+// InvestigateHostInCollection mocks base method.
+func (m *MockCenseyeService) InvestigateHostInCollection(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], collectionID identifiers.CollectionID, host *assets.Host, rarityMin, rarityMax uint64) (censeye.InvestigateHostResult, cenclierrors.CencliError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvestigateHostInCollection", ctx, orgID, collectionID, host, rarityMin, rarityMax)
+	ret0, _ := ret[0].(censeye.InvestigateHostResult)
+	ret1, _ := ret[1].(cenclierrors.CencliError)
+	return ret0, ret1
+}
+
+// InvestigateHostInCollection indicates an expected call of InvestigateHostInCollection.
+func (mr *MockCenseyeServiceMockRecorder) InvestigateHostInCollection(ctx, orgID, collectionID, host, rarityMin, rarityMax any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvestigateHostInCollection", reflect.TypeOf((*MockCenseyeService)(nil).InvestigateHostInCollection), ctx, orgID, collectionID, host, rarityMin, rarityMax)
+}
+
+// RunGadgets mocks base method.
+func (m *MockCenseyeService) RunGadgets(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], host *assets.Host, gadgets []censeye.Gadget, rarityMin, rarityMax uint64) (censeye.GadgetResult, cenclierrors.CencliError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunGadgets", ctx, orgID, host, gadgets, rarityMin, rarityMax)
+	ret0, _ := ret[0].(censeye.GadgetResult)
+	ret1, _ := ret[1].(cenclierrors.CencliError)
+	return ret0, ret1
+}
+
+// RunGadgets indicates an expected call of RunGadgets.
+func (mr *MockCenseyeServiceMockRecorder) RunGadgets(ctx, orgID, host, gadgets, rarityMin, rarityMax any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunGadgets", reflect.TypeOf((*MockCenseyeService)(nil).RunGadgets), ctx, orgID, host, gadgets, rarityMin, rarityMax)
+}
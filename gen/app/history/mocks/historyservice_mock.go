@@ -76,6 +76,21 @@ func (mr *MockHistoryServiceMockRecorder) GetHostHistory(ctx, orgID, host, fromT
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostHistory", reflect.TypeOf((*MockHistoryService)(nil).GetHostHistory), ctx, orgID, host, fromTime, toTime)
 }
 
+// GetHostSnapshots mocks base method.
+func (m *MockHistoryService) GetHostSnapshots(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], host assets.HostID, groups []history.HostEventGroup) (history.HostSnapshotsResult, cenclierrors.CencliError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostSnapshots", ctx, orgID, host, groups)
+	ret0, _ := ret[0].(history.HostSnapshotsResult)
+	ret1, _ := ret[1].(cenclierrors.CencliError)
+	return ret0, ret1
+}
+
+// GetHostSnapshots indicates an expected call of GetHostSnapshots.
+func (mr *MockHistoryServiceMockRecorder) GetHostSnapshots(ctx, orgID, host, groups any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostSnapshots", reflect.TypeOf((*MockHistoryService)(nil).GetHostSnapshots), ctx, orgID, host, groups)
+}
+
 // GetWebPropertyHistory mocks base method.
 func (m *MockHistoryService) GetWebPropertyHistory(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], webPropertyID assets.WebPropertyID, fromTime, toTime time.Time) (history.WebPropertyHistoryResult, cenclierrors.CencliError) {
 	m.ctrl.T.Helper()
@@ -42,6 +42,21 @@ func (m *MockSearchService) EXPECT() *MockSearchServiceMockRecorder {
 	return m.recorder
 }
 
+// Preflight mocks base method.
+func (m *MockSearchService) Preflight(ctx context.Context, params search.Params) (search.PreflightResult, cenclierrors.CencliError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preflight", ctx, params)
+	ret0, _ := ret[0].(search.PreflightResult)
+	ret1, _ := ret[1].(cenclierrors.CencliError)
+	return ret0, ret1
+}
+
+// Preflight indicates an expected call of Preflight.
+func (mr *MockSearchServiceMockRecorder) Preflight(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preflight", reflect.TypeOf((*MockSearchService)(nil).Preflight), ctx, params)
+}
+
 // Search mocks base method.
 func (m *MockSearchService) Search(ctx context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
 	m.ctrl.T.Helper()
@@ -61,6 +61,21 @@ func (mr *MockViewServiceMockRecorder) GetCertificates(ctx, orgID, certificateID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificates", reflect.TypeOf((*MockViewService)(nil).GetCertificates), ctx, orgID, certificateIDs)
 }
 
+// GetCertificatesInCollection mocks base method.
+func (m *MockViewService) GetCertificatesInCollection(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], collectionID identifiers.CollectionID, certificateIDs []assets.CertificateID) (view.CertificatesResult, cenclierrors.CencliError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificatesInCollection", ctx, orgID, collectionID, certificateIDs)
+	ret0, _ := ret[0].(view.CertificatesResult)
+	ret1, _ := ret[1].(cenclierrors.CencliError)
+	return ret0, ret1
+}
+
+// GetCertificatesInCollection indicates an expected call of GetCertificatesInCollection.
+func (mr *MockViewServiceMockRecorder) GetCertificatesInCollection(ctx, orgID, collectionID, certificateIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificatesInCollection", reflect.TypeOf((*MockViewService)(nil).GetCertificatesInCollection), ctx, orgID, collectionID, certificateIDs)
+}
+
 // GetHosts mocks base method.
 func (m *MockViewService) GetHosts(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], hostIDs []assets.HostID, atTime mo.Option[time.Time]) (view.HostsResult, cenclierrors.CencliError) {
 	m.ctrl.T.Helper()
@@ -76,6 +91,21 @@ func (mr *MockViewServiceMockRecorder) GetHosts(ctx, orgID, hostIDs, atTime any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHosts", reflect.TypeOf((*MockViewService)(nil).GetHosts), ctx, orgID, hostIDs, atTime)
 }
 
+// GetHostsInCollection mocks base method.
+func (m *MockViewService) GetHostsInCollection(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], collectionID identifiers.CollectionID, hostIDs []assets.HostID) (view.HostsResult, cenclierrors.CencliError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostsInCollection", ctx, orgID, collectionID, hostIDs)
+	ret0, _ := ret[0].(view.HostsResult)
+	ret1, _ := ret[1].(cenclierrors.CencliError)
+	return ret0, ret1
+}
+
+// GetHostsInCollection indicates an expected call of GetHostsInCollection.
+func (mr *MockViewServiceMockRecorder) GetHostsInCollection(ctx, orgID, collectionID, hostIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostsInCollection", reflect.TypeOf((*MockViewService)(nil).GetHostsInCollection), ctx, orgID, collectionID, hostIDs)
+}
+
 // GetWebProperties mocks base method.
 func (m *MockViewService) GetWebProperties(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], webPropertyIDs []assets.WebPropertyID, atTime mo.Option[time.Time]) (view.WebPropertiesResult, cenclierrors.CencliError) {
 	m.ctrl.T.Helper()
@@ -90,3 +120,18 @@ func (mr *MockViewServiceMockRecorder) GetWebProperties(ctx, orgID, webPropertyI
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebProperties", reflect.TypeOf((*MockViewService)(nil).GetWebProperties), ctx, orgID, webPropertyIDs, atTime)
 }
+
+// GetWebPropertiesInCollection mocks base method.
+func (m *MockViewService) GetWebPropertiesInCollection(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], collectionID identifiers.CollectionID, webPropertyIDs []assets.WebPropertyID) (view.WebPropertiesResult, cenclierrors.CencliError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebPropertiesInCollection", ctx, orgID, collectionID, webPropertyIDs)
+	ret0, _ := ret[0].(view.WebPropertiesResult)
+	ret1, _ := ret[1].(cenclierrors.CencliError)
+	return ret0, ret1
+}
+
+// GetWebPropertiesInCollection indicates an expected call of GetWebPropertiesInCollection.
+func (mr *MockViewServiceMockRecorder) GetWebPropertiesInCollection(ctx, orgID, collectionID, webPropertyIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebPropertiesInCollection", reflect.TypeOf((*MockViewService)(nil).GetWebPropertiesInCollection), ctx, orgID, collectionID, webPropertyIDs)
+}
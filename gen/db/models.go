@@ -21,3 +21,55 @@ type Global struct {
 	CreatedAt   string
 	LastUsedAt  string
 }
+
+type Session struct {
+	ID        int64
+	Name      string
+	StartedAt string
+	EndedAt   string
+}
+
+type SessionEntry struct {
+	ID        int64
+	SessionID int64
+	Kind      string
+	Command   string
+	Query     string
+	Note      string
+	Digest    string
+	Response  string
+	CreatedAt string
+}
+
+type WatchSeen struct {
+	ID          int64
+	Watch       string
+	Value       string
+	FirstSeenAt string
+}
+
+type UsageEvent struct {
+	ID              int64
+	Command         string
+	Query           string
+	StartedAt       string
+	DurationMs      int64
+	Failed          int64
+	Requests        int64
+	RequestErrors   int64
+	SearchRequests  int64
+	SearchLatencyMs int64
+	PagesFetched    int64
+	CacheHits       int64
+	CacheMisses     int64
+}
+
+type Note struct {
+	ID        int64
+	Asset     string
+	AssetType string
+	Text      string
+	Author    string
+	Session   string
+	CreatedAt string
+}
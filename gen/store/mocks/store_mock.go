@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/censys/cencli/internal/store (interfaces: Store,AuthsStore,GlobalsStore)
+// Source: github.com/censys/cencli/internal/store (interfaces: Store,AuthsStore,GlobalsStore,WatchesStore,SessionsStore,UsageStore,NotesStore)
 //
 // Generated by this command:
 //
-//	mockgen -destination=../../gen/store/mocks/store_mock.go -package=mocks github.com/censys/cencli/internal/store Store,AuthsStore,GlobalsStore
+//	mockgen -destination=../../gen/store/mocks/store_mock.go -package=mocks github.com/censys/cencli/internal/store Store,AuthsStore,GlobalsStore,WatchesStore,SessionsStore,UsageStore,NotesStore
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	store "github.com/censys/cencli/internal/store"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,36 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddNote mocks base method.
+func (m *MockStore) AddNote(ctx context.Context, note *store.Note) (*store.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNote", ctx, note)
+	ret0, _ := ret[0].(*store.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddNote indicates an expected call of AddNote.
+func (mr *MockStoreMockRecorder) AddNote(ctx, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNote", reflect.TypeOf((*MockStore)(nil).AddNote), ctx, note)
+}
+
+// AddSessionEntry mocks base method.
+func (m *MockStore) AddSessionEntry(ctx context.Context, sessionID int64, entry *store.SessionEntry) (*store.SessionEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSessionEntry", ctx, sessionID, entry)
+	ret0, _ := ret[0].(*store.SessionEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSessionEntry indicates an expected call of AddSessionEntry.
+func (mr *MockStoreMockRecorder) AddSessionEntry(ctx, sessionID, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSessionEntry", reflect.TypeOf((*MockStore)(nil).AddSessionEntry), ctx, sessionID, entry)
+}
+
 // AddValueForAuth mocks base method.
 func (m *MockStore) AddValueForAuth(ctx context.Context, name, description, value string) (*store.ValueForAuth, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +102,49 @@ func (mr *MockStoreMockRecorder) AddValueForGlobal(ctx, name, description, value
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddValueForGlobal", reflect.TypeOf((*MockStore)(nil).AddValueForGlobal), ctx, name, description, value)
 }
 
+// ClearUsage mocks base method.
+func (m *MockStore) ClearUsage(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearUsage", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearUsage indicates an expected call of ClearUsage.
+func (mr *MockStoreMockRecorder) ClearUsage(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearUsage", reflect.TypeOf((*MockStore)(nil).ClearUsage), ctx)
+}
+
+// DeleteNote mocks base method.
+func (m *MockStore) DeleteNote(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNote", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNote indicates an expected call of DeleteNote.
+func (mr *MockStoreMockRecorder) DeleteNote(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockStore)(nil).DeleteNote), ctx, id)
+}
+
+// DeleteSession mocks base method.
+func (m *MockStore) DeleteSession(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockStoreMockRecorder) DeleteSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), ctx, id)
+}
+
 // DeleteValueForAuth mocks base method.
 func (m *MockStore) DeleteValueForAuth(ctx context.Context, id int64) (*store.ValueForAuth, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +175,36 @@ func (mr *MockStoreMockRecorder) DeleteValueForGlobal(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteValueForGlobal", reflect.TypeOf((*MockStore)(nil).DeleteValueForGlobal), ctx, id)
 }
 
+// EndActiveSession mocks base method.
+func (m *MockStore) EndActiveSession(ctx context.Context) (*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndActiveSession", ctx)
+	ret0, _ := ret[0].(*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndActiveSession indicates an expected call of EndActiveSession.
+func (mr *MockStoreMockRecorder) EndActiveSession(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndActiveSession", reflect.TypeOf((*MockStore)(nil).EndActiveSession), ctx)
+}
+
+// GetActiveSession mocks base method.
+func (m *MockStore) GetActiveSession(ctx context.Context) (*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSession", ctx)
+	ret0, _ := ret[0].(*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSession indicates an expected call of GetActiveSession.
+func (mr *MockStoreMockRecorder) GetActiveSession(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSession", reflect.TypeOf((*MockStore)(nil).GetActiveSession), ctx)
+}
+
 // GetLastUsedAuthByName mocks base method.
 func (m *MockStore) GetLastUsedAuthByName(ctx context.Context, name string) (*store.ValueForAuth, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +235,51 @@ func (mr *MockStoreMockRecorder) GetLastUsedGlobalByName(ctx, name any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastUsedGlobalByName", reflect.TypeOf((*MockStore)(nil).GetLastUsedGlobalByName), ctx, name)
 }
 
+// GetSessionByName mocks base method.
+func (m *MockStore) GetSessionByName(ctx context.Context, name string) (*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByName", ctx, name)
+	ret0, _ := ret[0].(*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByName indicates an expected call of GetSessionByName.
+func (mr *MockStoreMockRecorder) GetSessionByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByName", reflect.TypeOf((*MockStore)(nil).GetSessionByName), ctx, name)
+}
+
+// GetSessionEntries mocks base method.
+func (m *MockStore) GetSessionEntries(ctx context.Context, sessionID int64) ([]*store.SessionEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionEntries", ctx, sessionID)
+	ret0, _ := ret[0].([]*store.SessionEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionEntries indicates an expected call of GetSessionEntries.
+func (mr *MockStoreMockRecorder) GetSessionEntries(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionEntries", reflect.TypeOf((*MockStore)(nil).GetSessionEntries), ctx, sessionID)
+}
+
+// GetUsageSince mocks base method.
+func (m *MockStore) GetUsageSince(ctx context.Context, since time.Time) ([]*store.UsageEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsageSince", ctx, since)
+	ret0, _ := ret[0].([]*store.UsageEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsageSince indicates an expected call of GetUsageSince.
+func (mr *MockStoreMockRecorder) GetUsageSince(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsageSince", reflect.TypeOf((*MockStore)(nil).GetUsageSince), ctx, since)
+}
+
 // GetValuesForAuth mocks base method.
 func (m *MockStore) GetValuesForAuth(ctx context.Context, name string) ([]*store.ValueForAuth, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +310,125 @@ func (mr *MockStoreMockRecorder) GetValuesForGlobal(ctx, name any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValuesForGlobal", reflect.TypeOf((*MockStore)(nil).GetValuesForGlobal), ctx, name)
 }
 
+// GetWatchSeen mocks base method.
+func (m *MockStore) GetWatchSeen(ctx context.Context, watch string) ([]*store.WatchSeenValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchSeen", ctx, watch)
+	ret0, _ := ret[0].([]*store.WatchSeenValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchSeen indicates an expected call of GetWatchSeen.
+func (mr *MockStoreMockRecorder) GetWatchSeen(ctx, watch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchSeen", reflect.TypeOf((*MockStore)(nil).GetWatchSeen), ctx, watch)
+}
+
+// ImportSession mocks base method.
+func (m *MockStore) ImportSession(ctx context.Context, session *store.Session, entries []*store.SessionEntry) (*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSession", ctx, session, entries)
+	ret0, _ := ret[0].(*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSession indicates an expected call of ImportSession.
+func (mr *MockStoreMockRecorder) ImportSession(ctx, session, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSession", reflect.TypeOf((*MockStore)(nil).ImportSession), ctx, session, entries)
+}
+
+// ListNotes mocks base method.
+func (m *MockStore) ListNotes(ctx context.Context, filter store.NotesFilter) ([]*store.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotes", ctx, filter)
+	ret0, _ := ret[0].([]*store.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotes indicates an expected call of ListNotes.
+func (mr *MockStoreMockRecorder) ListNotes(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotes", reflect.TypeOf((*MockStore)(nil).ListNotes), ctx, filter)
+}
+
+// ListSessions mocks base method.
+func (m *MockStore) ListSessions(ctx context.Context) ([]*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx)
+	ret0, _ := ret[0].([]*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockStoreMockRecorder) ListSessions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockStore)(nil).ListSessions), ctx)
+}
+
+// MarkWatchSeen mocks base method.
+func (m *MockStore) MarkWatchSeen(ctx context.Context, watch, value string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWatchSeen", ctx, watch, value)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkWatchSeen indicates an expected call of MarkWatchSeen.
+func (mr *MockStoreMockRecorder) MarkWatchSeen(ctx, watch, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWatchSeen", reflect.TypeOf((*MockStore)(nil).MarkWatchSeen), ctx, watch, value)
+}
+
+// RecordUsage mocks base method.
+func (m *MockStore) RecordUsage(ctx context.Context, event *store.UsageEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordUsage", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordUsage indicates an expected call of RecordUsage.
+func (mr *MockStoreMockRecorder) RecordUsage(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordUsage", reflect.TypeOf((*MockStore)(nil).RecordUsage), ctx, event)
+}
+
+// ResetWatch mocks base method.
+func (m *MockStore) ResetWatch(ctx context.Context, watch string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetWatch", ctx, watch)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetWatch indicates an expected call of ResetWatch.
+func (mr *MockStoreMockRecorder) ResetWatch(ctx, watch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetWatch", reflect.TypeOf((*MockStore)(nil).ResetWatch), ctx, watch)
+}
+
+// StartSession mocks base method.
+func (m *MockStore) StartSession(ctx context.Context, name string) (*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSession", ctx, name)
+	ret0, _ := ret[0].(*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSession indicates an expected call of StartSession.
+func (mr *MockStoreMockRecorder) StartSession(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSession", reflect.TypeOf((*MockStore)(nil).StartSession), ctx, name)
+}
+
 // UpdateAuthLastUsedAtToNow mocks base method.
 func (m *MockStore) UpdateAuthLastUsedAtToNow(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -384,3 +652,366 @@ func (mr *MockGlobalsStoreMockRecorder) UpdateGlobalLastUsedAtToNow(ctx, id any)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGlobalLastUsedAtToNow", reflect.TypeOf((*MockGlobalsStore)(nil).UpdateGlobalLastUsedAtToNow), ctx, id)
 }
+
+// MockWatchesStore is a mock of WatchesStore interface.
+type MockWatchesStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatchesStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockWatchesStoreMockRecorder is the mock recorder for MockWatchesStore.
+type MockWatchesStoreMockRecorder struct {
+	mock *MockWatchesStore
+}
+
+// NewMockWatchesStore creates a new mock instance.
+func NewMockWatchesStore(ctrl *gomock.Controller) *MockWatchesStore {
+	mock := &MockWatchesStore{ctrl: ctrl}
+	mock.recorder = &MockWatchesStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatchesStore) EXPECT() *MockWatchesStoreMockRecorder {
+	return m.recorder
+}
+
+// GetWatchSeen mocks base method.
+func (m *MockWatchesStore) GetWatchSeen(ctx context.Context, watch string) ([]*store.WatchSeenValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchSeen", ctx, watch)
+	ret0, _ := ret[0].([]*store.WatchSeenValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchSeen indicates an expected call of GetWatchSeen.
+func (mr *MockWatchesStoreMockRecorder) GetWatchSeen(ctx, watch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchSeen", reflect.TypeOf((*MockWatchesStore)(nil).GetWatchSeen), ctx, watch)
+}
+
+// MarkWatchSeen mocks base method.
+func (m *MockWatchesStore) MarkWatchSeen(ctx context.Context, watch, value string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWatchSeen", ctx, watch, value)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkWatchSeen indicates an expected call of MarkWatchSeen.
+func (mr *MockWatchesStoreMockRecorder) MarkWatchSeen(ctx, watch, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWatchSeen", reflect.TypeOf((*MockWatchesStore)(nil).MarkWatchSeen), ctx, watch, value)
+}
+
+// ResetWatch mocks base method.
+func (m *MockWatchesStore) ResetWatch(ctx context.Context, watch string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetWatch", ctx, watch)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetWatch indicates an expected call of ResetWatch.
+func (mr *MockWatchesStoreMockRecorder) ResetWatch(ctx, watch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetWatch", reflect.TypeOf((*MockWatchesStore)(nil).ResetWatch), ctx, watch)
+}
+
+// MockSessionsStore is a mock of SessionsStore interface.
+type MockSessionsStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionsStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionsStoreMockRecorder is the mock recorder for MockSessionsStore.
+type MockSessionsStoreMockRecorder struct {
+	mock *MockSessionsStore
+}
+
+// NewMockSessionsStore creates a new mock instance.
+func NewMockSessionsStore(ctrl *gomock.Controller) *MockSessionsStore {
+	mock := &MockSessionsStore{ctrl: ctrl}
+	mock.recorder = &MockSessionsStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionsStore) EXPECT() *MockSessionsStoreMockRecorder {
+	return m.recorder
+}
+
+// AddSessionEntry mocks base method.
+func (m *MockSessionsStore) AddSessionEntry(ctx context.Context, sessionID int64, entry *store.SessionEntry) (*store.SessionEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSessionEntry", ctx, sessionID, entry)
+	ret0, _ := ret[0].(*store.SessionEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSessionEntry indicates an expected call of AddSessionEntry.
+func (mr *MockSessionsStoreMockRecorder) AddSessionEntry(ctx, sessionID, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSessionEntry", reflect.TypeOf((*MockSessionsStore)(nil).AddSessionEntry), ctx, sessionID, entry)
+}
+
+// DeleteSession mocks base method.
+func (m *MockSessionsStore) DeleteSession(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockSessionsStoreMockRecorder) DeleteSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockSessionsStore)(nil).DeleteSession), ctx, id)
+}
+
+// EndActiveSession mocks base method.
+func (m *MockSessionsStore) EndActiveSession(ctx context.Context) (*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndActiveSession", ctx)
+	ret0, _ := ret[0].(*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndActiveSession indicates an expected call of EndActiveSession.
+func (mr *MockSessionsStoreMockRecorder) EndActiveSession(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndActiveSession", reflect.TypeOf((*MockSessionsStore)(nil).EndActiveSession), ctx)
+}
+
+// GetActiveSession mocks base method.
+func (m *MockSessionsStore) GetActiveSession(ctx context.Context) (*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSession", ctx)
+	ret0, _ := ret[0].(*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSession indicates an expected call of GetActiveSession.
+func (mr *MockSessionsStoreMockRecorder) GetActiveSession(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSession", reflect.TypeOf((*MockSessionsStore)(nil).GetActiveSession), ctx)
+}
+
+// GetSessionByName mocks base method.
+func (m *MockSessionsStore) GetSessionByName(ctx context.Context, name string) (*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByName", ctx, name)
+	ret0, _ := ret[0].(*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByName indicates an expected call of GetSessionByName.
+func (mr *MockSessionsStoreMockRecorder) GetSessionByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByName", reflect.TypeOf((*MockSessionsStore)(nil).GetSessionByName), ctx, name)
+}
+
+// GetSessionEntries mocks base method.
+func (m *MockSessionsStore) GetSessionEntries(ctx context.Context, sessionID int64) ([]*store.SessionEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionEntries", ctx, sessionID)
+	ret0, _ := ret[0].([]*store.SessionEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionEntries indicates an expected call of GetSessionEntries.
+func (mr *MockSessionsStoreMockRecorder) GetSessionEntries(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionEntries", reflect.TypeOf((*MockSessionsStore)(nil).GetSessionEntries), ctx, sessionID)
+}
+
+// ImportSession mocks base method.
+func (m *MockSessionsStore) ImportSession(ctx context.Context, session *store.Session, entries []*store.SessionEntry) (*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSession", ctx, session, entries)
+	ret0, _ := ret[0].(*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSession indicates an expected call of ImportSession.
+func (mr *MockSessionsStoreMockRecorder) ImportSession(ctx, session, entries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSession", reflect.TypeOf((*MockSessionsStore)(nil).ImportSession), ctx, session, entries)
+}
+
+// ListSessions mocks base method.
+func (m *MockSessionsStore) ListSessions(ctx context.Context) ([]*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx)
+	ret0, _ := ret[0].([]*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockSessionsStoreMockRecorder) ListSessions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockSessionsStore)(nil).ListSessions), ctx)
+}
+
+// StartSession mocks base method.
+func (m *MockSessionsStore) StartSession(ctx context.Context, name string) (*store.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSession", ctx, name)
+	ret0, _ := ret[0].(*store.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSession indicates an expected call of StartSession.
+func (mr *MockSessionsStoreMockRecorder) StartSession(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSession", reflect.TypeOf((*MockSessionsStore)(nil).StartSession), ctx, name)
+}
+
+// MockUsageStore is a mock of UsageStore interface.
+type MockUsageStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsageStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockUsageStoreMockRecorder is the mock recorder for MockUsageStore.
+type MockUsageStoreMockRecorder struct {
+	mock *MockUsageStore
+}
+
+// NewMockUsageStore creates a new mock instance.
+func NewMockUsageStore(ctrl *gomock.Controller) *MockUsageStore {
+	mock := &MockUsageStore{ctrl: ctrl}
+	mock.recorder = &MockUsageStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsageStore) EXPECT() *MockUsageStoreMockRecorder {
+	return m.recorder
+}
+
+// ClearUsage mocks base method.
+func (m *MockUsageStore) ClearUsage(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearUsage", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearUsage indicates an expected call of ClearUsage.
+func (mr *MockUsageStoreMockRecorder) ClearUsage(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearUsage", reflect.TypeOf((*MockUsageStore)(nil).ClearUsage), ctx)
+}
+
+// GetUsageSince mocks base method.
+func (m *MockUsageStore) GetUsageSince(ctx context.Context, since time.Time) ([]*store.UsageEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsageSince", ctx, since)
+	ret0, _ := ret[0].([]*store.UsageEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsageSince indicates an expected call of GetUsageSince.
+func (mr *MockUsageStoreMockRecorder) GetUsageSince(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsageSince", reflect.TypeOf((*MockUsageStore)(nil).GetUsageSince), ctx, since)
+}
+
+// RecordUsage mocks base method.
+func (m *MockUsageStore) RecordUsage(ctx context.Context, event *store.UsageEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordUsage", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordUsage indicates an expected call of RecordUsage.
+func (mr *MockUsageStoreMockRecorder) RecordUsage(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordUsage", reflect.TypeOf((*MockUsageStore)(nil).RecordUsage), ctx, event)
+}
+
+// MockNotesStore is a mock of NotesStore interface.
+type MockNotesStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotesStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockNotesStoreMockRecorder is the mock recorder for MockNotesStore.
+type MockNotesStoreMockRecorder struct {
+	mock *MockNotesStore
+}
+
+// NewMockNotesStore creates a new mock instance.
+func NewMockNotesStore(ctrl *gomock.Controller) *MockNotesStore {
+	mock := &MockNotesStore{ctrl: ctrl}
+	mock.recorder = &MockNotesStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotesStore) EXPECT() *MockNotesStoreMockRecorder {
+	return m.recorder
+}
+
+// AddNote mocks base method.
+func (m *MockNotesStore) AddNote(ctx context.Context, note *store.Note) (*store.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNote", ctx, note)
+	ret0, _ := ret[0].(*store.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddNote indicates an expected call of AddNote.
+func (mr *MockNotesStoreMockRecorder) AddNote(ctx, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNote", reflect.TypeOf((*MockNotesStore)(nil).AddNote), ctx, note)
+}
+
+// DeleteNote mocks base method.
+func (m *MockNotesStore) DeleteNote(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNote", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNote indicates an expected call of DeleteNote.
+func (mr *MockNotesStoreMockRecorder) DeleteNote(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockNotesStore)(nil).DeleteNote), ctx, id)
+}
+
+// ListNotes mocks base method.
+func (m *MockNotesStore) ListNotes(ctx context.Context, filter store.NotesFilter) ([]*store.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotes", ctx, filter)
+	ret0, _ := ret[0].([]*store.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotes indicates an expected call of ListNotes.
+func (mr *MockNotesStoreMockRecorder) ListNotes(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotes", reflect.TypeOf((*MockNotesStore)(nil).ListNotes), ctx, filter)
+}
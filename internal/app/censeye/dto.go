@@ -9,7 +9,9 @@ import (
 
 type InvestigateHostResult struct {
 	Entries []ReportEntry
-	Meta    *responsemeta.ResponseMeta
+	// Annotations are the notes of the gadgets, if any ran.
+	Annotations []Annotation
+	Meta        *responsemeta.ResponseMeta
 }
 
 // reportEntry represents a single rule and its analysis results.
@@ -18,6 +20,8 @@ type ReportEntry struct {
 	Query       string `json:"query"`
 	Interesting bool   `json:"interesting"`
 	SearchURL   string `json:"search_url,omitempty"`
+	// Gadget is the gadget that derived the query, if not the generic rules.
+	Gadget string `json:"gadget,omitempty"`
 }
 
 type fieldValuePair struct {
@@ -66,6 +70,7 @@ func buildReportEntries(
 	counts []float64,
 	rarityMin,
 	rarityMax uint64,
+	searchURL func(query string) string,
 ) []ReportEntry {
 	entries := make([]ReportEntry, 0, len(rules))
 	for i, rule := range rules {
@@ -80,17 +85,21 @@ func buildReportEntries(
 				Count:       int64(count),
 				Query:       cenqlQuery,
 				Interesting: count >= rarityMin && count <= rarityMax,
-				SearchURL:   toSearchURL(cenqlQuery),
+				SearchURL:   searchURL(cenqlQuery),
 			}
 			entries = append(entries, entry)
 		}
 	}
-	// sort by count descending
+	sortEntries(entries)
+	return entries
+}
+
+// sortEntries sorts entries by count descending, then query.
+func sortEntries(entries []ReportEntry) {
 	sort.Slice(entries, func(i, j int) bool {
 		if entries[i].Count == entries[j].Count {
 			return entries[i].Query < entries[j].Query
 		}
 		return entries[i].Count > entries[j].Count
 	})
-	return entries
 }
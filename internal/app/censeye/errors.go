@@ -1,6 +1,11 @@
 package censeye
 
-import "github.com/censys/cencli/internal/pkg/cenclierrors"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
 
 type CompileRulesError interface {
 	cenclierrors.CencliError
@@ -19,3 +24,46 @@ func (e *compileRulesError) Error() string { return e.err.Error() }
 func (e *compileRulesError) Title() string { return "Compile Rules Error" }
 
 func (e *compileRulesError) ShouldPrintUsage() bool { return true }
+
+type UnknownGadgetError interface {
+	cenclierrors.CencliError
+}
+
+type unknownGadgetError struct{ name string }
+
+var _ UnknownGadgetError = &unknownGadgetError{}
+
+func newUnknownGadgetError(name string) UnknownGadgetError {
+	return &unknownGadgetError{name: name}
+}
+
+func (e *unknownGadgetError) Error() string {
+	return fmt.Sprintf("unknown gadget %q (available: %s)", e.name, strings.Join(GadgetNames(), ", "))
+}
+
+func (e *unknownGadgetError) Title() string { return "Unknown Gadget" }
+
+func (e *unknownGadgetError) ShouldPrintUsage() bool { return true }
+
+type GadgetConfigError interface {
+	cenclierrors.CencliError
+}
+
+type gadgetConfigError struct {
+	name string
+	err  error
+}
+
+var _ GadgetConfigError = &gadgetConfigError{}
+
+func newGadgetConfigError(name string, err error) GadgetConfigError {
+	return &gadgetConfigError{name: name, err: err}
+}
+
+func (e *gadgetConfigError) Error() string {
+	return fmt.Sprintf("gadget %s is not configured: %v", e.name, e.err)
+}
+
+func (e *gadgetConfigError) Title() string { return "Gadget Not Configured" }
+
+func (e *gadgetConfigError) ShouldPrintUsage() bool { return false }
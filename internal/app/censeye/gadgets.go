@@ -0,0 +1,117 @@
+package censeye
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+const (
+	// maxGadgetQueries bounds the queries the gadgets can add to a report, as
+	// each costs a search request.
+	maxGadgetQueries = 25
+	// countConcurrency is the number of queries counted with searches at once.
+	countConcurrency = 4
+)
+
+// Gadget analyzes a host document for what the generic rules cannot turn into
+// pivots, such as the files listed by an open directory. Gadgets are ported
+// from censeye-ng.
+type Gadget interface {
+	// Name is the name the gadget is enabled with, e.g. "od".
+	Name() string
+	// Analyze returns the pivot queries and annotations derived from host.
+	Analyze(ctx context.Context, host *assets.Host) (GadgetFindings, error)
+}
+
+// GadgetFindings is what a gadget derived from a host.
+type GadgetFindings struct {
+	// Queries are CenQL queries, which are counted and added to the report.
+	Queries []string
+	// Annotations are notes about the host shown alongside the report.
+	Annotations []string
+}
+
+// Annotation is a note a gadget made about a host.
+type Annotation struct {
+	Gadget string `json:"gadget"`
+	Text   string `json:"text"`
+}
+
+// GadgetResult is the result of running gadgets on a host.
+type GadgetResult struct {
+	Entries     []ReportEntry
+	Annotations []Annotation
+}
+
+// GadgetOptions configures the gadgets that need more than the host document.
+type GadgetOptions struct {
+	// VirusTotalAPIKey is required by the vt gadget.
+	VirusTotalAPIKey string
+	// VirusTotalURL overrides the VirusTotal API base URL, for tests.
+	VirusTotalURL string
+	// HTTPClient is used for lookups outside of Censys.
+	HTTPClient *http.Client
+}
+
+// gadgetRegistry maps gadget names to their constructors.
+var gadgetRegistry = map[string]func(GadgetOptions) (Gadget, error){
+	openDirGadgetName:    func(GadgetOptions) (Gadget, error) { return openDirGadget{}, nil },
+	nobblerGadgetName:    func(GadgetOptions) (Gadget, error) { return nobblerGadget{}, nil },
+	virusTotalGadgetName: newVirusTotalGadget,
+}
+
+// GadgetNames returns the names of the available gadgets, sorted.
+func GadgetNames() []string {
+	names := make([]string, 0, len(gadgetRegistry))
+	for name := range gadgetRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewGadgets instantiates the named gadgets. Names are case-insensitive and
+// duplicates are ignored.
+func NewGadgets(names []string, opts GadgetOptions) ([]Gadget, cenclierrors.CencliError) {
+	var gadgets []Gadget
+	var seen []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || slices.Contains(seen, name) {
+			continue
+		}
+		seen = append(seen, name)
+		newGadget, ok := gadgetRegistry[name]
+		if !ok {
+			return nil, newUnknownGadgetError(name)
+		}
+		gadget, err := newGadget(opts)
+		if err != nil {
+			return nil, newGadgetConfigError(name, err)
+		}
+		gadgets = append(gadgets, gadget)
+	}
+	return gadgets, nil
+}
+
+// WithGadgets returns the result with the entries and annotations of the
+// gadgets added. Entries stay sorted by count descending.
+func (r InvestigateHostResult) WithGadgets(g GadgetResult) InvestigateHostResult {
+	entries := make([]ReportEntry, 0, len(r.Entries)+len(g.Entries))
+	entries = append(entries, r.Entries...)
+	for _, entry := range g.Entries {
+		if !slices.ContainsFunc(entries, func(e ReportEntry) bool { return e.Query == entry.Query }) {
+			entries = append(entries, entry)
+		}
+	}
+	sortEntries(entries)
+	r.Entries = entries
+	r.Annotations = append(r.Annotations, g.Annotations...)
+	return r
+}
@@ -0,0 +1,175 @@
+package censeye
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/searchurl"
+	"github.com/censys/censys-sdk-go/models/components"
+)
+
+const openDirBody = `<html><head><title>Index of /</title></head><body><h1>Index of /</h1>
+<a href="?C=N;O=D">Name</a> <a href="/">Parent Directory</a>
+<a href="beacon.exe">beacon.exe</a>
+<a href="tools/">tools/</a>
+<a href="my%20notes.txt">my notes.txt</a>
+<a href="https://example.com/">elsewhere</a>
+<a href="beacon.exe">beacon.exe</a>
+</body></html>`
+
+func hostWithServices(services ...components.Service) *assets.Host {
+	return &assets.Host{Host: components.Host{IP: strPtr("1.2.3.4"), Services: services}}
+}
+
+func TestOpenDirGadget(t *testing.T) {
+	host := hostWithServices(
+		components.Service{Port: intPtr(8080), Endpoints: []components.EndpointScanState{
+			{Path: strPtr("/"), HTTP: &components.HTTP{Body: strPtr(openDirBody)}},
+		}},
+		components.Service{Port: intPtr(80), Endpoints: []components.EndpointScanState{
+			{HTTP: &components.HTTP{Body: strPtr(`<a href="index.html">home</a>`)}},
+		}},
+	)
+	findings, err := openDirGadget{}.Analyze(context.Background(), host)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`host.services.endpoints.http.body: "beacon.exe"`,
+		`host.services.endpoints.http.body: "tools"`,
+		`host.services.endpoints.http.body: "my notes.txt"`,
+	}, findings.Queries)
+	assert.Equal(t, []string{"open directory on port 8080 at / listing 3 files"}, findings.Annotations)
+}
+
+func TestNobblerGadget(t *testing.T) {
+	host := hostWithServices(
+		components.Service{Protocol: strPtr("UNKNOWN"), BannerHex: strPtr("DEADBEEF0102030405060708090A0B0C0D0E0F10")},
+		components.Service{Protocol: strPtr("HTTP"), BannerHex: strPtr("485454502f312e31")},
+		components.Service{Protocol: strPtr("UNKNOWN")},
+	)
+	findings, err := nobblerGadget{}.Analyze(context.Background(), host)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`host.services:(protocol="UNKNOWN" and banner_hex="deadbeef*")`,
+		`host.services:(protocol="UNKNOWN" and banner_hex="deadbeef01020304*")`,
+		`host.services:(protocol="UNKNOWN" and banner_hex="deadbeef0102030405060708090a0b0c*")`,
+	}, findings.Queries)
+	assert.Empty(t, findings.Annotations)
+}
+
+func TestVirusTotalGadget(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "vt-key", r.Header.Get("x-apikey"))
+		switch r.URL.Path {
+		case "/api/v3/ip_addresses/1.2.3.4":
+			_, _ = w.Write([]byte(`{"data": {"attributes": {"reputation": -5,
+				"last_analysis_stats": {"malicious": 3, "suspicious": 1, "harmless": 50, "undetected": 40}}}}`))
+		case "/api/v3/ip_addresses/5.6.7.8":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	gadgets, cerr := NewGadgets([]string{"vt"}, GadgetOptions{VirusTotalAPIKey: "vt-key", VirusTotalURL: srv.URL})
+	require.Nil(t, cerr)
+	require.Len(t, gadgets, 1)
+	vt := gadgets[0]
+
+	findings, err := vt.Analyze(context.Background(), hostWithServices())
+	require.NoError(t, err)
+	assert.Empty(t, findings.Queries)
+	assert.Equal(t, []string{
+		"VirusTotal: 3/94 engines flag 1.2.3.4 as malicious, 1 as suspicious (reputation -5): https://www.virustotal.com/gui/ip-address/1.2.3.4",
+	}, findings.Annotations)
+
+	findings, err = vt.Analyze(context.Background(), &assets.Host{Host: components.Host{IP: strPtr("5.6.7.8")}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"VirusTotal has no report for 5.6.7.8"}, findings.Annotations)
+
+	_, err = vt.Analyze(context.Background(), &assets.Host{Host: components.Host{IP: strPtr("9.9.9.9")}})
+	require.ErrorContains(t, err, "401 Unauthorized")
+}
+
+func TestNewGadgets(t *testing.T) {
+	gadgets, err := NewGadgets([]string{"OD", " nobbler", "od", ""}, GadgetOptions{})
+	require.Nil(t, err)
+	require.Len(t, gadgets, 2)
+	assert.Equal(t, "od", gadgets[0].Name())
+	assert.Equal(t, "nobbler", gadgets[1].Name())
+
+	_, err = NewGadgets([]string{"nope"}, GadgetOptions{})
+	require.NotNil(t, err)
+	assert.Equal(t, `unknown gadget "nope" (available: nobbler, od, vt)`, err.Error())
+
+	_, err = NewGadgets([]string{"vt"}, GadgetOptions{})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "gadget vt is not configured")
+}
+
+// fakeGadget returns fixed findings, or an error.
+type fakeGadget struct {
+	name     string
+	findings GadgetFindings
+	err      error
+}
+
+func (g fakeGadget) Name() string { return g.name }
+
+func (g fakeGadget) Analyze(context.Context, *assets.Host) (GadgetFindings, error) {
+	return g.findings, g.err
+}
+
+func TestRunGadgets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockClient(ctrl)
+	counts := map[string]float64{"q1": 5, "q2": 1000, "q3": 1}
+	mockClient.EXPECT().Search(gomock.Any(), mo.None[string](), gomock.Any(), gomock.Nil(), mo.Some[int64](1), mo.None[string]()).
+		DoAndReturn(func(_ context.Context, _ mo.Option[string], query string, _ []string, _ mo.Option[int64], _ mo.Option[string]) (client.Result[components.SearchQueryResponse], client.ClientError) {
+			if query == "bad" {
+				return client.Result[components.SearchQueryResponse]{}, client.NewClientError(errors.New("invalid query"))
+			}
+			return client.Result[components.SearchQueryResponse]{Data: &components.SearchQueryResponse{TotalHits: counts[query]}}, nil
+		}).Times(4)
+
+	gadgets := []Gadget{
+		fakeGadget{name: "a", findings: GadgetFindings{Queries: []string{"q1", "q2"}, Annotations: []string{"note"}}},
+		fakeGadget{name: "b", findings: GadgetFindings{Queries: []string{"q1", "q3", "bad"}}},
+		fakeGadget{name: "c", err: errors.New("lookup failed")},
+	}
+	res, err := New(mockClient, searchurl.New("")).RunGadgets(context.Background(), mo.None[identifiers.OrganizationID](), hostWithServices(), gadgets, 2, 100)
+	require.Nil(t, err)
+	assert.Equal(t, []ReportEntry{
+		{Count: 1000, Query: "q2", Interesting: false, SearchURL: defaultSearchURL("q2"), Gadget: "a"},
+		{Count: 5, Query: "q1", Interesting: true, SearchURL: defaultSearchURL("q1"), Gadget: "a"},
+	}, res.Entries)
+	require.Len(t, res.Annotations, 3)
+	assert.Equal(t, Annotation{Gadget: "a", Text: "note"}, res.Annotations[0])
+	assert.Equal(t, Annotation{Gadget: "c", Text: "lookup failed"}, res.Annotations[1])
+	assert.Equal(t, "b", res.Annotations[2].Gadget)
+	assert.Contains(t, res.Annotations[2].Text, "failed to count bad")
+
+	merged := InvestigateHostResult{Entries: []ReportEntry{{Count: 50, Query: "q1"}, {Count: 10, Query: "other"}}}.WithGadgets(res)
+	assert.Equal(t, []string{"q2", "q1", "other"}, queriesOf(merged.Entries))
+	assert.Len(t, merged.Annotations, 3)
+}
+
+func queriesOf(entries []ReportEntry) []string {
+	queries := make([]string, 0, len(entries))
+	for _, e := range entries {
+		queries = append(queries, e.Query)
+	}
+	return queries
+}
@@ -0,0 +1,123 @@
+package censeye
+
+import (
+	"math"
+	"sort"
+)
+
+// histogramPercentiles are the percentiles reported by a histogram.
+var histogramPercentiles = []int{10, 25, 50, 75, 90}
+
+const (
+	// suggestedMinPercentile and suggestedMaxPercentile bound the suggested
+	// rarity range: the rarest queries below the first are often unique to
+	// the host, and the commonest half rarely leads to related infrastructure.
+	suggestedMinPercentile = 10
+	suggestedMaxPercentile = 50
+	// minSuggestedRarity is the lowest suggested rarity-min, since a query
+	// with a count of 1 only matches the host itself.
+	minSuggestedRarity = 2
+)
+
+// Histogram is the distribution of the counts of the queries of one or more
+// reports, with rarity bounds suggested from its percentiles.
+type Histogram struct {
+	// Queries is the number of counts in the histogram.
+	Queries int `json:"queries"`
+	// Buckets group the counts by order of magnitude, from the lowest to the
+	// highest non-empty bucket.
+	Buckets     []HistogramBucket `json:"buckets"`
+	Percentiles []Percentile      `json:"percentiles"`
+	// Suggested is empty when there are no counts.
+	Suggested *RarityBounds `json:"suggested,omitempty"`
+}
+
+// HistogramBucket is the number of queries with a count in [Min, Max].
+type HistogramBucket struct {
+	Min     int64 `json:"min"`
+	Max     int64 `json:"max"`
+	Queries int   `json:"queries"`
+}
+
+// Percentile is the count at or below which Percent percent of the queries fall.
+type Percentile struct {
+	Percent int   `json:"percent"`
+	Count   int64 `json:"count"`
+}
+
+// RarityBounds are a rarity-min and rarity-max to investigate hosts with.
+type RarityBounds struct {
+	RarityMin uint64 `json:"rarity_min"`
+	RarityMax uint64 `json:"rarity_max"`
+	// MinPercentile and MaxPercentile are the percentiles the bounds come from.
+	MinPercentile int `json:"min_percentile"`
+	MaxPercentile int `json:"max_percentile"`
+}
+
+// NewHistogram returns the histogram of the counts of entries.
+func NewHistogram(entries []ReportEntry) Histogram {
+	counts := make([]int64, 0, len(entries))
+	for _, e := range entries {
+		counts = append(counts, e.Count)
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i] < counts[j] })
+
+	h := Histogram{
+		Queries:     len(counts),
+		Buckets:     []HistogramBucket{},
+		Percentiles: []Percentile{},
+	}
+	if len(counts) == 0 {
+		return h
+	}
+	for _, count := range counts {
+		lo, hi := bucketBounds(count)
+		if n := len(h.Buckets); n > 0 && h.Buckets[n-1].Min == lo {
+			h.Buckets[n-1].Queries++
+			continue
+		}
+		// keep empty buckets between non-empty ones, so gaps show
+		for n := len(h.Buckets); n > 0 && h.Buckets[n-1].Max+1 < lo; n = len(h.Buckets) {
+			gapLo, gapHi := bucketBounds(h.Buckets[n-1].Max + 1)
+			h.Buckets = append(h.Buckets, HistogramBucket{Min: gapLo, Max: gapHi})
+		}
+		h.Buckets = append(h.Buckets, HistogramBucket{Min: lo, Max: hi, Queries: 1})
+	}
+	for _, p := range histogramPercentiles {
+		h.Percentiles = append(h.Percentiles, Percentile{Percent: p, Count: percentile(counts, p)})
+	}
+
+	rarityMin := uint64(max(percentile(counts, suggestedMinPercentile), minSuggestedRarity))
+	rarityMax := uint64(max(percentile(counts, suggestedMaxPercentile), 0))
+	h.Suggested = &RarityBounds{
+		RarityMin:     rarityMin,
+		RarityMax:     max(rarityMax, rarityMin),
+		MinPercentile: suggestedMinPercentile,
+		MaxPercentile: suggestedMaxPercentile,
+	}
+	return h
+}
+
+// bucketBounds returns the order-of-magnitude bucket of count: 1-9, 10-99,
+// 100-999, and so on.
+func bucketBounds(count int64) (int64, int64) {
+	if count < 10 {
+		return 1, 9
+	}
+	lo := int64(math.Pow10(int(math.Log10(float64(count)))))
+	// guard against rounding at the edges of a magnitude
+	for lo > count {
+		lo /= 10
+	}
+	for lo*10 <= count {
+		lo *= 10
+	}
+	return lo, lo*10 - 1
+}
+
+// percentile returns the nearest-rank percentile p of sorted, which must not
+// be empty.
+func percentile(sorted []int64, p int) int64 {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
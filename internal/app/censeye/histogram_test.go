@@ -0,0 +1,61 @@
+package censeye
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHistogram(t *testing.T) {
+	entriesWithCounts := func(counts ...int64) []ReportEntry {
+		entries := make([]ReportEntry, len(counts))
+		for i, count := range counts {
+			entries[i] = ReportEntry{Count: count}
+		}
+		return entries
+	}
+
+	t.Run("buckets, percentiles, and suggested bounds", func(t *testing.T) {
+		h := NewHistogram(entriesWithCounts(12000, 2, 3, 3, 40, 55, 90, 150, 800, 5000))
+		require.Equal(t, 10, h.Queries)
+		require.Equal(t, []HistogramBucket{
+			{Min: 1, Max: 9, Queries: 3},
+			{Min: 10, Max: 99, Queries: 3},
+			{Min: 100, Max: 999, Queries: 2},
+			{Min: 1000, Max: 9999, Queries: 1},
+			{Min: 10000, Max: 99999, Queries: 1},
+		}, h.Buckets)
+		require.Equal(t, []Percentile{
+			{Percent: 10, Count: 2},
+			{Percent: 25, Count: 3},
+			{Percent: 50, Count: 55},
+			{Percent: 75, Count: 800},
+			{Percent: 90, Count: 5000},
+		}, h.Percentiles)
+		require.Equal(t, &RarityBounds{RarityMin: 2, RarityMax: 55, MinPercentile: 10, MaxPercentile: 50}, h.Suggested)
+	})
+
+	t.Run("gaps between buckets are kept", func(t *testing.T) {
+		h := NewHistogram(entriesWithCounts(5, 100000))
+		require.Equal(t, []HistogramBucket{
+			{Min: 1, Max: 9, Queries: 1},
+			{Min: 10, Max: 99},
+			{Min: 100, Max: 999},
+			{Min: 1000, Max: 9999},
+			{Min: 10000, Max: 99999},
+			{Min: 100000, Max: 999999, Queries: 1},
+		}, h.Buckets)
+	})
+
+	t.Run("suggested rarity-min is at least 2", func(t *testing.T) {
+		h := NewHistogram(entriesWithCounts(1000))
+		require.Equal(t, &RarityBounds{RarityMin: 1000, RarityMax: 1000, MinPercentile: 10, MaxPercentile: 50}, h.Suggested)
+	})
+
+	t.Run("no counts", func(t *testing.T) {
+		h := NewHistogram(nil)
+		require.Zero(t, h.Queries)
+		require.Empty(t, h.Buckets)
+		require.Nil(t, h.Suggested)
+	})
+}
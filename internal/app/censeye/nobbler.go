@@ -0,0 +1,43 @@
+package censeye
+
+import (
+	"context"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/query"
+)
+
+const nobblerGadgetName = "nobbler"
+
+// nobblerPrefixes are the lengths, in bytes, of the banner prefixes pivoted
+// on: short prefixes find the protocol, long ones the implementation.
+var nobblerPrefixes = []int{4, 8, 16, 32}
+
+// nobblerGadget fingerprints services whose protocol Censys does not
+// recognize by the first bytes of their banner, which often identify custom
+// C2 and malware protocols.
+type nobblerGadget struct{}
+
+func (nobblerGadget) Name() string { return nobblerGadgetName }
+
+func (nobblerGadget) Analyze(_ context.Context, host *assets.Host) (GadgetFindings, error) {
+	var findings GadgetFindings
+	for _, svc := range host.ServicesWithProtocol("UNKNOWN") {
+		if svc.BannerHex == nil {
+			continue
+		}
+		banner := strings.ToLower(*svc.BannerHex)
+		for _, n := range nobblerPrefixes {
+			// a prefix as long as the banner is matched by the generic rules
+			if 2*n >= len(banner) {
+				break
+			}
+			findings.Queries = append(findings.Queries, query.Field(servicesPrefix).Has(query.And(
+				query.Field("protocol").Eq("UNKNOWN"),
+				query.Field("banner_hex").Eq(banner[:2*n]+"*"),
+			)).String())
+		}
+	}
+	return findings, nil
+}
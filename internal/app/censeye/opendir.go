@@ -0,0 +1,80 @@
+package censeye
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/query"
+)
+
+const (
+	openDirGadgetName = "od"
+	// maxOpenDirFiles is the number of files of a listing turned into queries.
+	maxOpenDirFiles = 10
+)
+
+var (
+	// openDirTitle matches the titles of the listings of Apache, nginx, and
+	// Python's http.server.
+	openDirTitle = regexp.MustCompile(`(?i)<title>\s*(index of|directory listing for) /`)
+	openDirHref  = regexp.MustCompile(`(?i)<a\s+href="([^"]+)"`)
+)
+
+// openDirGadget finds HTTP endpoints serving a directory listing, and pivots
+// on the names of the files listed: the same payloads are often staged on
+// several servers.
+type openDirGadget struct{}
+
+func (openDirGadget) Name() string { return openDirGadgetName }
+
+func (openDirGadget) Analyze(_ context.Context, host *assets.Host) (GadgetFindings, error) {
+	var findings GadgetFindings
+	for _, svc := range host.Services {
+		for _, ep := range svc.Endpoints {
+			if ep.HTTP == nil || ep.HTTP.Body == nil || !openDirTitle.MatchString(*ep.HTTP.Body) {
+				continue
+			}
+			files := openDirFiles(*ep.HTTP.Body)
+			path := "/"
+			if ep.Path != nil && *ep.Path != "" {
+				path = *ep.Path
+			}
+			port := 0
+			if svc.Port != nil {
+				port = *svc.Port
+			}
+			findings.Annotations = append(findings.Annotations,
+				fmt.Sprintf("open directory on port %d at %s listing %d files", port, path, len(files)))
+			for _, file := range files[:min(len(files), maxOpenDirFiles)] {
+				findings.Queries = append(findings.Queries, query.Field("host.services.endpoints.http.body").Match(file).String())
+			}
+		}
+	}
+	return findings, nil
+}
+
+// openDirFiles returns the names of the files and directories a listing
+// links to, leaving out sorting links and links outside of the directory.
+func openDirFiles(body string) []string {
+	var files []string
+	for _, m := range openDirHref.FindAllStringSubmatch(body, -1) {
+		href := m[1]
+		if strings.HasPrefix(href, "?") || strings.HasPrefix(href, "/") || strings.HasPrefix(href, "#") ||
+			strings.HasPrefix(href, "..") || strings.Contains(href, "://") {
+			continue
+		}
+		name, err := url.PathUnescape(strings.TrimSuffix(href, "/"))
+		if err != nil || name == "" || name == "." {
+			continue
+		}
+		if !slices.Contains(files, name) {
+			files = append(files, name)
+		}
+	}
+	return files
+}
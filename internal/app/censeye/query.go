@@ -1,16 +1,13 @@
 package censeye
 
 import (
-	"fmt"
-	"net/url"
 	"strings"
-)
 
-const (
-	servicesPrefix = "host.services"
-	platformURL    = "https://platform.censys.io/search?q="
+	"github.com/censys/cencli/internal/pkg/domain/query"
 )
 
+const servicesPrefix = "host.services"
+
 // toCenqlQuery converts field-value pairs into a CenQL query string.
 func toCenqlQuery(pairs []fieldValuePair) string {
 	if len(pairs) == 0 {
@@ -19,20 +16,14 @@ func toCenqlQuery(pairs []fieldValuePair) string {
 
 	// Single field-value pair
 	if len(pairs) == 1 {
-		return fmt.Sprintf("%s=%q", pairs[0].Field, pairs[0].Value)
+		return query.Field(pairs[0].Field).Eq(pairs[0].Value).String()
 	}
 
 	// Multiple field-value pairs
-	out := make([]string, len(pairs))
+	terms := make([]query.Query, len(pairs))
 	for i, pair := range pairs {
-		field := strings.TrimPrefix(pair.Field, servicesPrefix+".")
-		out[i] = fmt.Sprintf("%s=%q", field, pair.Value)
+		terms[i] = query.Field(strings.TrimPrefix(pair.Field, servicesPrefix+".")).Eq(pair.Value)
 	}
 
-	return fmt.Sprintf("%s:(%s)", servicesPrefix, strings.Join(out, " and "))
-}
-
-// toSearchURL converts a CenQL query into a Censys platform search URL.
-func toSearchURL(query string) string {
-	return platformURL + url.QueryEscape(query)
+	return query.Field(servicesPrefix).Has(query.And(terms...)).String()
 }
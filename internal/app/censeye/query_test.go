@@ -3,9 +3,19 @@ package censeye
 import (
 	"testing"
 
+	"github.com/samber/mo"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/searchurl"
 )
 
+// defaultSearchURL returns the link to a search for query with the default
+// template and no organization.
+func defaultSearchURL(query string) string {
+	return searchurl.New("").URL(query, mo.None[identifiers.OrganizationID]())
+}
+
 func TestToCenqlQuery(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -72,7 +82,7 @@ func TestToCenqlQuery(t *testing.T) {
 	}
 }
 
-func TestToSearchURL(t *testing.T) {
+func TestDefaultSearchURL(t *testing.T) {
 	testCases := []struct {
 		name     string
 		query    string
@@ -102,7 +112,7 @@ func TestToSearchURL(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := toSearchURL(tc.query)
+			result := defaultSearchURL(tc.query)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
@@ -123,7 +133,7 @@ func TestToCenqlQuery_RoundTrip(t *testing.T) {
 	assert.Contains(t, query, "and")
 
 	// Should be able to convert to URL
-	url := toSearchURL(query)
+	url := defaultSearchURL(query)
 	assert.Contains(t, url, "https://platform.censys.io/search?q=")
 	// Extract just the query parameter part after "?q="
 	queryPart := url[len("https://platform.censys.io/search?q="):]
@@ -0,0 +1,150 @@
+package censeye
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/mo"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	utilconvert "github.com/censys/cencli/internal/pkg/convertutil"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
+)
+
+// valueCountsBatchSize is the number of count conditions sent per value
+// counts request.
+const valueCountsBatchSize = 100
+
+// FieldValue is a field and a value to count the hosts of.
+type FieldValue struct {
+	Field string
+	Value string
+}
+
+// ValueCount is the number of hosts with a field set to a value.
+type ValueCount struct {
+	Field     string `json:"field"`
+	Value     string `json:"value"`
+	Count     int64  `json:"count"`
+	Query     string `json:"query"`
+	SearchURL string `json:"search_url"`
+}
+
+// CountValuesResult is the result of CountValues, in the order of the pairs.
+type CountValuesResult struct {
+	Counts []ValueCount
+	Meta   *responsemeta.ResponseMeta
+}
+
+func (s *censeyeService) CountValues(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	collectionID mo.Option[identifiers.CollectionID],
+	pairs []FieldValue,
+) (CountValuesResult, cenclierrors.CencliError) {
+	counts := make([]ValueCount, len(pairs))
+	for i, pair := range pairs {
+		query := toCenqlQuery([]fieldValuePair{{Field: pair.Field, Value: pair.Value}})
+		counts[i] = ValueCount{Field: pair.Field, Value: pair.Value, Query: query, SearchURL: s.urls.URL(query, orgID)}
+	}
+	var meta *responsemeta.ResponseMeta
+	var err cenclierrors.CencliError
+	if collectionID.IsPresent() {
+		meta, err = s.countInCollection(ctx, orgID, collectionID.MustGet(), counts)
+	} else {
+		meta, err = s.countValues(ctx, orgID, pairs, counts)
+	}
+	if err != nil {
+		return CountValuesResult{}, err
+	}
+	return CountValuesResult{Counts: counts, Meta: meta}, nil
+}
+
+// countValues counts the pairs with the threat hunting service, in batches.
+func (s *censeyeService) countValues(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	pairs []FieldValue,
+	counts []ValueCount,
+) (*responsemeta.ResponseMeta, cenclierrors.CencliError) {
+	var meta *responsemeta.ResponseMeta
+	var requests uint64
+	for start := 0; start < len(pairs); start += valueCountsBatchSize {
+		end := min(start+valueCountsBatchSize, len(pairs))
+		events.ReportMessage(ctx, events.StageProcess, fmt.Sprintf("Counting values %d-%d of %d...", start+1, end, len(pairs)))
+		conditions := make([]countCondition, 0, end-start)
+		for _, pair := range pairs[start:end] {
+			conditions = append(conditions, countCondition{FieldValuePairs: []fieldValuePair{{Field: pair.Field, Value: pair.Value}}})
+		}
+		res, err := s.getValueCounts(ctx, orgID, conditions, mo.None[string]())
+		if err != nil {
+			return nil, err
+		}
+		for i, count := range res.AndCountResults {
+			if start+i < end {
+				counts[start+i].Count = int64(count)
+			}
+		}
+		meta = res.Meta
+		requests++
+	}
+	if meta != nil && requests > 1 {
+		meta.PageCount = requests
+	}
+	return meta, nil
+}
+
+// countInCollection counts the hosts of a collection matching each query with
+// one-hit searches, as the threat hunting service cannot be scoped to a collection.
+func (s *censeyeService) countInCollection(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	collectionID identifiers.CollectionID,
+	counts []ValueCount,
+) (*responsemeta.ResponseMeta, cenclierrors.CencliError) {
+	events.ReportMessage(ctx, events.StageProcess, fmt.Sprintf("Counting %d values in collection...", len(counts)))
+	orgIDStr := utilconvert.OptionalString(orgID)
+	metas := make([]*responsemeta.ResponseMeta, len(counts))
+	errs := make([]cenclierrors.CencliError, len(counts))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(countConcurrency)
+	for i := range counts {
+		g.Go(func() error {
+			res, err := s.client.SearchCollection(gctx, collectionID.String(), orgIDStr, counts[i].Query, nil, mo.Some[int64](1), mo.None[string]())
+			if err != nil {
+				errs[i] = err
+				return err
+			}
+			counts[i].Count = int64(res.Data.TotalHits)
+			metas[i] = responsemeta.NewResponseMeta(res.Metadata.Request, res.Metadata.Response, res.Metadata.Latency, res.Metadata.Attempts)
+			return nil
+		})
+	}
+	if g.Wait() != nil {
+		if ctx.Err() != nil {
+			return nil, cenclierrors.ParseContextError(ctx.Err())
+		}
+		// the first error cancels the other searches, so report it rather than theirs
+		var first cenclierrors.CencliError
+		for _, err := range errs {
+			if err == nil {
+				continue
+			}
+			if !cenclierrors.IsInterrupted(err) {
+				return nil, err
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		return nil, first
+	}
+	meta := metas[len(metas)-1]
+	if meta != nil && len(metas) > 1 {
+		meta.PageCount = uint64(len(metas))
+	}
+	return meta, nil
+}
@@ -0,0 +1,97 @@
+package censeye
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/searchurl"
+	"github.com/censys/censys-sdk-go/models/components"
+)
+
+func TestCountValues(t *testing.T) {
+	ctx := context.Background()
+	none := mo.None[identifiers.OrganizationID]()
+
+	t.Run("threat hunting counts in batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		pairs := make([]FieldValue, valueCountsBatchSize+1)
+		for i := range pairs {
+			pairs[i] = FieldValue{Field: "host.services.port", Value: "22"}
+		}
+		pairs[valueCountsBatchSize] = FieldValue{Field: "host.services.protocol", Value: "SSH"}
+		first := make([]float64, valueCountsBatchSize)
+		for i := range first {
+			first[i] = 5
+		}
+		gomock.InOrder(
+			mockClient.EXPECT().GetValueCounts(gomock.Any(), mo.None[string](), mo.None[string](), gomock.Len(valueCountsBatchSize)).
+				Return(client.Result[components.ValueCountsResponse]{Data: &components.ValueCountsResponse{AndCountResults: first}}, nil),
+			mockClient.EXPECT().GetValueCounts(gomock.Any(), mo.None[string](), mo.None[string](), []components.CountCondition{
+				{FieldValuePairs: []components.FieldValuePair{{Field: "host.services.protocol", Value: "SSH"}}},
+			}).Return(client.Result[components.ValueCountsResponse]{Data: &components.ValueCountsResponse{AndCountResults: []float64{9000}}}, nil),
+		)
+
+		res, err := New(mockClient, searchurl.New("")).CountValues(ctx, none, mo.None[identifiers.CollectionID](), pairs)
+		require.Nil(t, err)
+		require.Len(t, res.Counts, valueCountsBatchSize+1)
+		assert.Equal(t, int64(5), res.Counts[0].Count)
+		assert.Equal(t, ValueCount{
+			Field:     "host.services.protocol",
+			Value:     "SSH",
+			Count:     9000,
+			Query:     `host.services.protocol="SSH"`,
+			SearchURL: defaultSearchURL(`host.services.protocol="SSH"`),
+		}, res.Counts[valueCountsBatchSize])
+		assert.Equal(t, uint64(2), res.Meta.PageCount)
+	})
+
+	t.Run("collection counts with searches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		collectionID := identifiers.NewCollectionID(uuid.MustParse("11111111-2222-3333-4444-555555555555"))
+		mockClient.EXPECT().SearchCollection(gomock.Any(), collectionID.String(), mo.None[string](), `host.services.port="22"`, gomock.Nil(), mo.Some[int64](1), mo.None[string]()).
+			Return(client.Result[components.SearchQueryResponse]{Data: &components.SearchQueryResponse{TotalHits: 12}}, nil)
+
+		res, err := New(mockClient, searchurl.New("")).CountValues(ctx, none, mo.Some(collectionID), []FieldValue{{Field: "host.services.port", Value: "22"}})
+		require.Nil(t, err)
+		require.Len(t, res.Counts, 1)
+		assert.Equal(t, int64(12), res.Counts[0].Count)
+	})
+
+	t.Run("links follow the template and organization", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		orgID := identifiers.NewOrganizationID(uuid.MustParse("11111111-2222-3333-4444-555555555555"))
+		mockClient.EXPECT().GetValueCounts(gomock.Any(), mo.Some(orgID.String()), mo.None[string](), gomock.Any()).
+			Return(client.Result[components.ValueCountsResponse]{Data: &components.ValueCountsResponse{AndCountResults: []float64{7}}}, nil)
+
+		urls := searchurl.New("https://censys.sso.example.com/{org_id}/search?q={query}")
+		res, err := New(mockClient, urls).CountValues(ctx, mo.Some(orgID), mo.None[identifiers.CollectionID](), []FieldValue{{Field: "host.services.port", Value: "22"}})
+		require.Nil(t, err)
+		require.Len(t, res.Counts, 1)
+		assert.Equal(t, "https://censys.sso.example.com/11111111-2222-3333-4444-555555555555/search?q=host.services.port%3D%2222%22", res.Counts[0].SearchURL)
+	})
+
+	t.Run("collection search errors are returned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		collectionID := identifiers.NewCollectionID(uuid.MustParse("11111111-2222-3333-4444-555555555555"))
+		mockClient.EXPECT().SearchCollection(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(client.Result[components.SearchQueryResponse]{}, client.NewClientError(errors.New("collection not found")))
+
+		_, err := New(mockClient, searchurl.New("")).CountValues(ctx, none, mo.Some(collectionID), []FieldValue{{Field: "host.services.port", Value: "22"}})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "collection not found")
+	})
+}
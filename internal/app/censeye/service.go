@@ -3,16 +3,19 @@ package censeye
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/samber/mo"
+	"golang.org/x/sync/errgroup"
 
-	"github.com/censys/cencli/internal/app/progress"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	client "github.com/censys/cencli/internal/pkg/clients/censys"
 	utilconvert "github.com/censys/cencli/internal/pkg/convertutil"
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/searchurl"
+	"github.com/censys/cencli/internal/pkg/ui/events"
 )
 
 //go:generate mockgen -destination=../../../gen/app/censeye/mocks/censeyeservice_mock.go -package=mocks -mock_names Service=MockCenseyeService . Service
@@ -26,13 +29,48 @@ type Service interface {
 		rarityMin uint64,
 		rarityMax uint64,
 	) (InvestigateHostResult, cenclierrors.CencliError)
+	// InvestigateHostInCollection is InvestigateHost, counting only the hosts
+	// of a collection, with a one-hit search of the collection per query.
+	InvestigateHostInCollection(
+		ctx context.Context,
+		orgID mo.Option[identifiers.OrganizationID],
+		collectionID identifiers.CollectionID,
+		host *assets.Host,
+		rarityMin uint64,
+		rarityMax uint64,
+	) (InvestigateHostResult, cenclierrors.CencliError)
+	// RunGadgets runs gadgets on a host and counts the queries they derive.
+	// Entries use the same rarity bounds as InvestigateHost. A gadget that
+	// fails is reported in an annotation instead of failing the others.
+	RunGadgets(
+		ctx context.Context,
+		orgID mo.Option[identifiers.OrganizationID],
+		host *assets.Host,
+		gadgets []Gadget,
+		rarityMin uint64,
+		rarityMax uint64,
+	) (GadgetResult, cenclierrors.CencliError)
+	// CountValues counts the hosts with each field set to its value, in the
+	// order of pairs. With a collection ID, only the hosts of the collection
+	// are counted.
+	CountValues(
+		ctx context.Context,
+		orgID mo.Option[identifiers.OrganizationID],
+		collectionID mo.Option[identifiers.CollectionID],
+		pairs []FieldValue,
+	) (CountValuesResult, cenclierrors.CencliError)
 }
 
 type censeyeService struct {
 	client client.Client
+	urls   searchurl.Builder
 }
 
-func New(client client.Client) Service { return &censeyeService{client: client} }
+// New returns a censeye service whose report entries link to searches
+// built by urls.
+func New(client client.Client, urls searchurl.Builder) Service {
+	return &censeyeService{client: client, urls: urls}
+}
 
 func (s *censeyeService) InvestigateHost(
 	ctx context.Context,
@@ -41,17 +79,11 @@ func (s *censeyeService) InvestigateHost(
 	rarityMin uint64,
 	rarityMax uint64,
 ) (InvestigateHostResult, cenclierrors.CencliError) {
-	// compile rules from host data
-	progress.ReportMessage(ctx, progress.StageProcess, "Compiling detection rules from host data...")
-	rules, compileErr := compileRulesForHost(host, &defaultCenseyeConfig)
-	if compileErr != nil {
-		return InvestigateHostResult{}, newCompileRulesError(compileErr)
+	filteredRules, err := compileHostRules(ctx, host)
+	if err != nil {
+		return InvestigateHostResult{}, err
 	}
 
-	// apply filters
-	progress.ReportMessage(ctx, progress.StageProcess, fmt.Sprintf("Applying filters (%d rules found)...", len(rules)))
-	filteredRules := applyFilters(rules, &defaultCenseyeConfig)
-
 	// prepare count conditions
 	countConditions := make([]countCondition, 0, len(filteredRules))
 	for _, rule := range filteredRules {
@@ -59,18 +91,63 @@ func (s *censeyeService) InvestigateHost(
 	}
 
 	// get value counts from threat hunting service
-	progress.ReportMessage(ctx, progress.StageProcess, fmt.Sprintf("Querying threat hunting service (%d conditions)...", len(filteredRules)))
+	events.ReportMessage(ctx, events.StageProcess, fmt.Sprintf("Querying threat hunting service (%d conditions)...", len(filteredRules)))
 	result, err := s.getValueCounts(ctx, orgID, countConditions, mo.None[string]())
 	if err != nil {
 		return InvestigateHostResult{}, err
 	}
 
 	// build report entries with configured rarity bounds
-	progress.ReportMessage(ctx, progress.StageProcess, fmt.Sprintf("Analyzing rarity (bounds: %d-%d)...", rarityMin, rarityMax))
-	entries := buildReportEntries(filteredRules, result.AndCountResults, rarityMin, rarityMax)
+	events.ReportMessage(ctx, events.StageProcess, fmt.Sprintf("Analyzing rarity (bounds: %d-%d)...", rarityMin, rarityMax))
+	entries := buildReportEntries(filteredRules, result.AndCountResults, rarityMin, rarityMax, func(query string) string {
+		return s.urls.URL(query, orgID)
+	})
 	return InvestigateHostResult{Entries: entries, Meta: result.Meta}, nil
 }
 
+func (s *censeyeService) InvestigateHostInCollection(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	collectionID identifiers.CollectionID,
+	host *assets.Host,
+	rarityMin uint64,
+	rarityMax uint64,
+) (InvestigateHostResult, cenclierrors.CencliError) {
+	rules, err := compileHostRules(ctx, host)
+	if err != nil {
+		return InvestigateHostResult{}, err
+	}
+	counts := make([]ValueCount, len(rules))
+	for i, rule := range rules {
+		counts[i].Query = toCenqlQuery(rule)
+	}
+	meta, err := s.countInCollection(ctx, orgID, collectionID, counts)
+	if err != nil {
+		return InvestigateHostResult{}, err
+	}
+	values := make([]float64, len(counts))
+	for i, count := range counts {
+		values[i] = float64(count.Count)
+	}
+	events.ReportMessage(ctx, events.StageProcess, fmt.Sprintf("Analyzing rarity (bounds: %d-%d)...", rarityMin, rarityMax))
+	entries := buildReportEntries(rules, values, rarityMin, rarityMax, func(query string) string {
+		return s.urls.URL(query, orgID)
+	})
+	return InvestigateHostResult{Entries: entries, Meta: meta}, nil
+}
+
+// compileHostRules compiles the rules of host, and filters out the ones
+// that are not worth counting.
+func compileHostRules(ctx context.Context, host *assets.Host) ([][]fieldValuePair, cenclierrors.CencliError) {
+	events.ReportMessage(ctx, events.StageProcess, "Compiling detection rules from host data...")
+	rules, compileErr := compileRulesForHost(host, &defaultCenseyeConfig)
+	if compileErr != nil {
+		return nil, newCompileRulesError(compileErr)
+	}
+	events.ReportMessage(ctx, events.StageProcess, fmt.Sprintf("Applying filters (%d rules found)...", len(rules)))
+	return applyFilters(rules, &defaultCenseyeConfig), nil
+}
+
 func (s *censeyeService) getValueCounts(
 	ctx context.Context,
 	orgID mo.Option[identifiers.OrganizationID],
@@ -87,3 +164,88 @@ func (s *censeyeService) getValueCounts(
 		AndCountResults: res.Data.GetAndCountResults(),
 	}, nil
 }
+
+func (s *censeyeService) RunGadgets(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	host *assets.Host,
+	gadgets []Gadget,
+	rarityMin uint64,
+	rarityMax uint64,
+) (GadgetResult, cenclierrors.CencliError) {
+	result := GadgetResult{Entries: []ReportEntry{}, Annotations: []Annotation{}}
+	type gadgetQuery struct{ gadget, query string }
+	var queries []gadgetQuery
+	for _, gadget := range gadgets {
+		events.ReportMessage(ctx, events.StageProcess, fmt.Sprintf("Running gadget %s...", gadget.Name()))
+		findings, err := gadget.Analyze(ctx, host)
+		if ctx.Err() != nil {
+			return GadgetResult{}, cenclierrors.ParseContextError(ctx.Err())
+		}
+		if err != nil {
+			result.Annotations = append(result.Annotations, Annotation{Gadget: gadget.Name(), Text: err.Error()})
+			continue
+		}
+		for _, text := range findings.Annotations {
+			result.Annotations = append(result.Annotations, Annotation{Gadget: gadget.Name(), Text: text})
+		}
+		for _, query := range findings.Queries {
+			if len(queries) == maxGadgetQueries {
+				break
+			}
+			if !slices.ContainsFunc(queries, func(q gadgetQuery) bool { return q.query == query }) {
+				queries = append(queries, gadgetQuery{gadget: gadget.Name(), query: query})
+			}
+		}
+	}
+	if len(queries) == 0 {
+		return result, nil
+	}
+
+	// count the queries with one-hit searches
+	events.ReportMessage(ctx, events.StageProcess, fmt.Sprintf("Counting %d gadget queries...", len(queries)))
+	orgIDStr := utilconvert.OptionalString(orgID)
+	counts := make([]int64, len(queries))
+	errs := make([]cenclierrors.CencliError, len(queries))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(countConcurrency)
+	for i, q := range queries {
+		g.Go(func() error {
+			res, err := s.client.Search(gctx, orgIDStr, q.query, nil, mo.Some[int64](1), mo.None[string]())
+			if err != nil {
+				errs[i] = err
+				return nil
+			}
+			counts[i] = int64(res.Data.TotalHits)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if ctx.Err() != nil {
+		return GadgetResult{}, cenclierrors.ParseContextError(ctx.Err())
+	}
+
+	for i, q := range queries {
+		if errs[i] != nil {
+			result.Annotations = append(result.Annotations, Annotation{
+				Gadget: q.gadget,
+				Text:   fmt.Sprintf("failed to count %s: %v", q.query, errs[i]),
+			})
+			continue
+		}
+		// like the generic rules, queries only matching the host are left out
+		if counts[i] <= 1 {
+			continue
+		}
+		count := uint64(counts[i])
+		result.Entries = append(result.Entries, ReportEntry{
+			Count:       counts[i],
+			Query:       q.query,
+			Interesting: count >= rarityMin && count <= rarityMax,
+			SearchURL:   s.urls.URL(q.query, orgID),
+			Gadget:      q.gadget,
+		})
+	}
+	sortEntries(result.Entries)
+	return result, nil
+}
@@ -20,6 +20,7 @@ import (
 	client "github.com/censys/cencli/internal/pkg/clients/censys"
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/searchurl"
 	"github.com/censys/censys-sdk-go/models/components"
 	"github.com/censys/censys-sdk-go/models/sdkerrors"
 )
@@ -528,7 +529,7 @@ func TestInvestigateHost(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockClient := tc.client(ctrl)
-			svc := New(mockClient)
+			svc := New(mockClient, searchurl.New(""))
 
 			ctx := context.Background()
 			if tc.ctx != nil {
@@ -540,3 +541,23 @@ func TestInvestigateHost(t *testing.T) {
 		})
 	}
 }
+
+func TestInvestigateHostInCollection(t *testing.T) {
+	originalConfig := defaultCenseyeConfig
+	defer func() { defaultCenseyeConfig = originalConfig }()
+	defaultCenseyeConfig = censeyeConfig{Filters: []string{"host.location.", "host.autonomous_system."}}
+
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockClient(ctrl)
+	collectionID := identifiers.NewCollectionID(uuid.MustParse("11111111-2222-3333-4444-555555555555"))
+	mockClient.EXPECT().SearchCollection(gomock.Any(), collectionID.String(), mo.None[string](), `host.ip="192.168.1.1"`, gomock.Nil(), mo.Some[int64](1), mo.None[string]()).
+		Return(client.Result[components.SearchQueryResponse]{Data: &components.SearchQueryResponse{TotalHits: 4}}, nil)
+
+	host := &assets.Host{Host: components.Host{IP: strPtr("192.168.1.1")}}
+	res, err := New(mockClient, searchurl.New("")).InvestigateHostInCollection(context.Background(), mo.None[identifiers.OrganizationID](), collectionID, host, 2, 10)
+	require.Nil(t, err)
+	require.Len(t, res.Entries, 1)
+	assert.Equal(t, int64(4), res.Entries[0].Count)
+	assert.True(t, res.Entries[0].Interesting)
+	assert.Equal(t, `host.ip="192.168.1.1"`, res.Entries[0].Query)
+}
@@ -0,0 +1,92 @@
+package censeye
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+const (
+	virusTotalGadgetName = "vt"
+	defaultVirusTotalURL = "https://www.virustotal.com"
+	virusTotalGUIURL     = "https://www.virustotal.com/gui/ip-address/"
+)
+
+// virusTotalGadget annotates a host with the verdicts of VirusTotal on its IP.
+// It adds no queries.
+type virusTotalGadget struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+func newVirusTotalGadget(opts GadgetOptions) (Gadget, error) {
+	if opts.VirusTotalAPIKey == "" {
+		return nil, errors.New("set censeye.virustotal-api-key (or CENCLI_CENSEYE_VIRUSTOTAL_API_KEY)")
+	}
+	g := virusTotalGadget{apiKey: opts.VirusTotalAPIKey, baseURL: opts.VirusTotalURL, client: opts.HTTPClient}
+	if g.baseURL == "" {
+		g.baseURL = defaultVirusTotalURL
+	}
+	if g.client == nil {
+		g.client = http.DefaultClient
+	}
+	return g, nil
+}
+
+func (virusTotalGadget) Name() string { return virusTotalGadgetName }
+
+// virusTotalIPReport is the part of a VirusTotal IP address report the gadget uses.
+type virusTotalIPReport struct {
+	Data struct {
+		Attributes struct {
+			LastAnalysisStats map[string]int `json:"last_analysis_stats"`
+			Reputation        int            `json:"reputation"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+func (g virusTotalGadget) Analyze(ctx context.Context, host *assets.Host) (GadgetFindings, error) {
+	if host.IP == nil || *host.IP == "" {
+		return GadgetFindings{}, nil
+	}
+	ip := *host.IP
+	endpoint := strings.TrimSuffix(g.baseURL, "/") + "/api/v3/ip_addresses/" + url.PathEscape(ip)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return GadgetFindings{}, err
+	}
+	req.Header.Set("x-apikey", g.apiKey)
+	req.Header.Set("Accept", "application/json")
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return GadgetFindings{}, fmt.Errorf("VirusTotal lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return GadgetFindings{Annotations: []string{"VirusTotal has no report for " + ip}}, nil
+	case resp.StatusCode != http.StatusOK:
+		return GadgetFindings{}, fmt.Errorf("VirusTotal lookup failed: %s", resp.Status)
+	}
+	var report virusTotalIPReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return GadgetFindings{}, fmt.Errorf("invalid VirusTotal response: %w", err)
+	}
+
+	stats := report.Data.Attributes.LastAnalysisStats
+	var engines int
+	for _, n := range stats {
+		engines += n
+	}
+	text := fmt.Sprintf("VirusTotal: %d/%d engines flag %s as malicious, %d as suspicious (reputation %d): %s",
+		stats["malicious"], engines, ip, stats["suspicious"], report.Data.Attributes.Reputation, virusTotalGUIURL+ip)
+	return GadgetFindings{Annotations: []string{text}}, nil
+}
@@ -0,0 +1,46 @@
+package certwatch
+
+import (
+	"time"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+)
+
+// Params bundles inputs for finding certificates that match a watch.
+type Params struct {
+	OrgID mo.Option[identifiers.OrganizationID]
+	// Domains are matched against certificate names (subject CN and SANs).
+	Domains []string
+	// Query, if set, is run instead of the query built from Domains and Since.
+	Query string
+	// Since restricts results to certificates added to the dataset at or after this time.
+	Since    mo.Option[time.Time]
+	PageSize mo.Option[uint64]
+	MaxPages uint64
+}
+
+// Result is the response from the certwatch service.
+type Result struct {
+	Meta *responsemeta.ResponseMeta
+	// Query is the Censys query that was run.
+	Query string
+	// TotalHits is the total number of certificates matching the query,
+	// which may be more than were fetched.
+	TotalHits    int64
+	Certificates []ObservedCertificate
+}
+
+// ObservedCertificate is a summary of a certificate matching a watch.
+type ObservedCertificate struct {
+	FingerprintSHA256 string   `json:"fingerprint_sha256"`
+	Names             []string `json:"names,omitempty"`
+	CommonName        string   `json:"common_name,omitempty"`
+	SubjectDN         string   `json:"subject_dn,omitempty"`
+	IssuerDN          string   `json:"issuer_dn,omitempty"`
+	NotBefore         string   `json:"not_before,omitempty"`
+	NotAfter          string   `json:"not_after,omitempty"`
+	AddedAt           string   `json:"added_at,omitempty"`
+}
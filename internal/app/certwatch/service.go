@@ -0,0 +1,160 @@
+package certwatch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	utilconvert "github.com/censys/cencli/internal/pkg/convertutil"
+	"github.com/censys/cencli/internal/pkg/domain/query"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
+)
+
+//go:generate mockgen -destination=../../../gen/app/certwatch/mocks/certwatchservice_mock.go -package=mocks -mock_names Service=MockCertWatchService . Service
+
+// Service finds certificates for domain watches.
+type Service interface {
+	// FindCertificates searches for certificates whose names match any of the
+	// given domains, or that match params.Query if set, and returns a summary
+	// of each one.
+	FindCertificates(ctx context.Context, params Params) (Result, cenclierrors.CencliError)
+}
+
+// fields limits the search response to what is needed to summarize a certificate.
+var fields = []string{
+	"cert.fingerprint_sha256",
+	"cert.names",
+	"cert.added_at",
+	"cert.parsed.subject_dn",
+	"cert.parsed.subject.common_name",
+	"cert.parsed.issuer_dn",
+	"cert.parsed.validity_period.not_before",
+	"cert.parsed.validity_period.not_after",
+}
+
+type certWatchService struct {
+	client client.Client
+}
+
+func New(client client.Client) Service {
+	return &certWatchService{client: client}
+}
+
+func (s *certWatchService) FindCertificates(ctx context.Context, params Params) (Result, cenclierrors.CencliError) {
+	if len(params.Domains) == 0 && params.Query == "" {
+		return Result{}, cenclierrors.NewCencliError(fmt.Errorf("at least one domain or a query is required"))
+	}
+	if params.MaxPages == 0 {
+		return Result{}, cenclierrors.NewCencliError(fmt.Errorf("max pages must be greater than 0"))
+	}
+	query := params.Query
+	if query == "" {
+		query = BuildQuery(params.Domains, params.Since)
+	}
+	orgID := utilconvert.OptionalString(params.OrgID)
+	pageSize := mo.None[int64]()
+	if params.PageSize.IsPresent() {
+		pageSize = mo.Some(int64(params.PageSize.MustGet()))
+	}
+
+	res := Result{Query: query}
+	seen := make(map[string]struct{})
+	pageToken := mo.None[string]()
+	start := time.Now()
+	var pages uint64
+	for pages < params.MaxPages {
+		if pages > 0 {
+			events.ReportPage(ctx, pages, params.MaxPages, fmt.Sprintf("Searching certificates (page %d/%d)...", pages+1, params.MaxPages))
+		}
+		page, err := s.client.Search(ctx, orgID, query, fields, pageSize, pageToken)
+		if err != nil {
+			return Result{}, err
+		}
+		pages++
+		res.Meta = responsemeta.NewResponseMeta(page.Metadata.Request, page.Metadata.Response, 0, page.Metadata.Attempts)
+		if page.Data == nil {
+			break
+		}
+		res.TotalHits = int64(page.Data.TotalHits)
+		for _, hit := range page.Data.Hits {
+			cert := hit.GetCertificateV1()
+			if cert == nil {
+				continue
+			}
+			observed := Summarize(cert.GetResource())
+			if observed.FingerprintSHA256 == "" {
+				continue
+			}
+			if _, dup := seen[observed.FingerprintSHA256]; dup {
+				continue
+			}
+			seen[observed.FingerprintSHA256] = struct{}{}
+			res.Certificates = append(res.Certificates, observed)
+		}
+		next := page.Data.GetNextPageToken()
+		if next == "" || len(page.Data.Hits) == 0 {
+			break
+		}
+		pageToken = mo.Some(next)
+	}
+	if res.Meta != nil {
+		res.Meta.Latency = time.Since(start)
+		res.Meta.PageCount = pages
+	}
+	return res, nil
+}
+
+// BuildQuery returns a Censys query matching certificates with a name containing
+// any of the given domains. A leading "*." is dropped, since a substring match on
+// the parent domain already covers its subdomains.
+func BuildQuery(domains []string, since mo.Option[time.Time]) string {
+	names := make([]query.Query, 0, len(domains))
+	for _, d := range domains {
+		d = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d)), "*.")
+		if d == "" {
+			continue
+		}
+		names = append(names, query.Field("cert.names").Match(d))
+	}
+	q := query.Or(names...)
+	if t, ok := since.Get(); ok {
+		q = q.And(query.Field("cert.added_at").Gte(t.UTC().Format(time.RFC3339)))
+	}
+	return q.String()
+}
+
+// Summarize returns the summary of a certificate.
+func Summarize(cert components.Certificate) ObservedCertificate {
+	res := ObservedCertificate{
+		FingerprintSHA256: deref(cert.FingerprintSha256),
+		Names:             cert.Names,
+		AddedAt:           deref(cert.AddedAt),
+	}
+	if parsed := cert.Parsed; parsed != nil {
+		res.SubjectDN = deref(parsed.SubjectDn)
+		res.IssuerDN = deref(parsed.IssuerDn)
+		if subject := parsed.Subject; subject != nil && len(subject.CommonName) > 0 {
+			res.CommonName = subject.CommonName[0]
+		}
+		if validity := parsed.ValidityPeriod; validity != nil {
+			res.NotBefore = deref(validity.NotBefore)
+			res.NotAfter = deref(validity.NotAfter)
+		}
+	}
+	return res
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
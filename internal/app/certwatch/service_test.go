@@ -0,0 +1,149 @@
+package certwatch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/censys-sdk-go/models/components"
+)
+
+func certHit(fingerprint string, names ...string) components.SearchQueryHit {
+	return components.SearchQueryHit{
+		CertificateV1: &components.CertificateAsset{
+			Resource: components.Certificate{
+				FingerprintSha256: &fingerprint,
+				Names:             names,
+			},
+		},
+	}
+}
+
+func searchPage(hits []components.SearchQueryHit, next string) client.Result[components.SearchQueryResponse] {
+	return client.Result[components.SearchQueryResponse]{
+		Metadata: client.Metadata{
+			Request:  &http.Request{Method: "POST", URL: &url.URL{Scheme: "https", Host: "api.censys.io"}},
+			Response: &http.Response{StatusCode: 200},
+		},
+		Data: &components.SearchQueryResponse{
+			Hits:          hits,
+			TotalHits:     3,
+			NextPageToken: next,
+		},
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("X", 3600))
+	testCases := []struct {
+		name    string
+		domains []string
+		since   mo.Option[time.Time]
+		want    string
+	}{
+		{
+			name:    "single domain",
+			domains: []string{"example.com"},
+			want:    `cert.names: "example.com"`,
+		},
+		{
+			name:    "multiple domains with wildcard and case",
+			domains: []string{"*.Example.com", "example.org"},
+			want:    `cert.names: "example.com" or cert.names: "example.org"`,
+		},
+		{
+			name:    "since is rendered in UTC",
+			domains: []string{"example.com"},
+			since:   mo.Some(since),
+			want:    `cert.names: "example.com" and cert.added_at >= "2025-01-02T02:04:05Z"`,
+		},
+		{
+			name:    "domains are grouped before since",
+			domains: []string{"example.com", "example.org"},
+			since:   mo.Some(since),
+			want:    `(cert.names: "example.com" or cert.names: "example.org") and cert.added_at >= "2025-01-02T02:04:05Z"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, BuildQuery(tc.domains, tc.since))
+		})
+	}
+}
+
+func TestFindCertificates(t *testing.T) {
+	t.Run("pages and deduplicates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		query := `cert.names: "example.com"`
+		gomock.InOrder(
+			mockClient.EXPECT().Search(gomock.Any(), mo.None[string](), query, fields, mo.Some[int64](2), mo.None[string]()).
+				Return(searchPage([]components.SearchQueryHit{certHit("aa", "example.com"), certHit("bb")}, "next"), nil),
+			mockClient.EXPECT().Search(gomock.Any(), mo.None[string](), query, fields, mo.Some[int64](2), mo.Some("next")).
+				Return(searchPage([]components.SearchQueryHit{certHit("bb"), certHit("cc")}, ""), nil),
+		)
+
+		res, err := New(mockClient).FindCertificates(context.Background(), Params{
+			Domains:  []string{"example.com"},
+			PageSize: mo.Some[uint64](2),
+			MaxPages: 5,
+		})
+		require.NoError(t, err)
+		require.Equal(t, query, res.Query)
+		require.Equal(t, int64(3), res.TotalHits)
+		require.Equal(t, uint64(2), res.Meta.PageCount)
+		require.Len(t, res.Certificates, 3)
+		require.Equal(t, []string{"example.com"}, res.Certificates[0].Names)
+		require.Equal(t, "cc", res.Certificates[2].FingerprintSHA256)
+	})
+
+	t.Run("stops at max pages", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		mockClient.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(searchPage([]components.SearchQueryHit{certHit("aa")}, "next"), nil).Times(1)
+
+		res, err := New(mockClient).FindCertificates(context.Background(), Params{
+			Domains:  []string{"example.com"},
+			MaxPages: 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Certificates, 1)
+	})
+
+	t.Run("runs the query instead of domains", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		query := `cert.parsed.issuer.organization: "Let's Encrypt"`
+		cn := "www.example.com"
+		hit := certHit("aa", cn)
+		hit.CertificateV1.Resource.Parsed = &components.CertificateParsed{
+			Subject: &components.DistinguishedName{CommonName: []string{cn}},
+		}
+		mockClient.EXPECT().Search(gomock.Any(), mo.None[string](), query, fields, mo.None[int64](), mo.None[string]()).
+			Return(searchPage([]components.SearchQueryHit{hit}, ""), nil)
+
+		res, err := New(mockClient).FindCertificates(context.Background(), Params{
+			Query:    query,
+			MaxPages: 1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, query, res.Query)
+		require.Len(t, res.Certificates, 1)
+		require.Equal(t, cn, res.Certificates[0].CommonName)
+	})
+
+	t.Run("requires a domain", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := New(mocks.NewMockClient(ctrl)).FindCertificates(context.Background(), Params{MaxPages: 1})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,45 @@
+package compare
+
+import (
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+)
+
+type CompareHostsResult struct {
+	Meta   *responsemeta.ResponseMeta
+	Report HostComparisonReport
+}
+
+// HostComparisonReport is the pairwise similarity report for a set of hosts.
+type HostComparisonReport struct {
+	// Hosts lists the compared hosts, in the order they were requested.
+	// Hosts that could not be found are omitted.
+	Hosts []string `json:"hosts"`
+	// Missing lists requested hosts that were not returned by the API.
+	Missing []string `json:"missing,omitempty"`
+	// Matrix holds the similarity score for each pair of hosts,
+	// indexed in the same order as Hosts. The diagonal is always 1.
+	Matrix [][]float64 `json:"matrix"`
+	// Pairs holds the detailed comparison for each unique pair of hosts,
+	// sorted by descending similarity.
+	Pairs []HostPair `json:"pairs"`
+}
+
+// HostPair describes what two hosts have in common.
+type HostPair struct {
+	HostA string `json:"host_a"`
+	HostB string `json:"host_b"`
+	// Similarity is the Jaccard index of the hosts' combined feature sets, in [0, 1].
+	Similarity         float64  `json:"similarity"`
+	SharedPorts        []int    `json:"shared_ports"`
+	SharedBannerHashes []string `json:"shared_banner_hashes"`
+	SharedJA4S         []string `json:"shared_ja4s"`
+	SharedJARM         []string `json:"shared_jarm"`
+	SharedCertificates []string `json:"shared_certificates"`
+	SharedSSHHostKeys  []string `json:"shared_ssh_host_keys"`
+}
+
+// SharedCount returns the total number of shared features between the two hosts.
+func (p HostPair) SharedCount() int {
+	return len(p.SharedPorts) + len(p.SharedBannerHashes) + len(p.SharedJA4S) +
+		len(p.SharedJARM) + len(p.SharedCertificates) + len(p.SharedSSHHostKeys)
+}
@@ -0,0 +1,36 @@
+package compare
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+type NotEnoughHostsError interface {
+	cenclierrors.CencliError
+}
+
+type notEnoughHostsError struct {
+	found   int
+	missing []string
+}
+
+var _ NotEnoughHostsError = &notEnoughHostsError{}
+
+func newNotEnoughHostsError(found int, missing []string) NotEnoughHostsError {
+	return &notEnoughHostsError{found: found, missing: missing}
+}
+
+func (e *notEnoughHostsError) Error() string {
+	return fmt.Sprintf("at least 2 hosts are required for a comparison, but only %d were found (missing: %s)",
+		e.found, strings.Join(e.missing, ", "))
+}
+
+func (e *notEnoughHostsError) Title() string {
+	return "Not Enough Hosts"
+}
+
+func (e *notEnoughHostsError) ShouldPrintUsage() bool {
+	return false
+}
@@ -0,0 +1,135 @@
+package compare
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// featureKind identifies a class of host feature that can be shared between hosts.
+type featureKind string
+
+const (
+	featurePort       featureKind = "port"
+	featureBanner     featureKind = "banner"
+	featureJA4S       featureKind = "ja4s"
+	featureJARM       featureKind = "jarm"
+	featureCert       featureKind = "cert"
+	featureSSHHostKey featureKind = "ssh_host_key"
+)
+
+// hostFeatures is the set of comparable features extracted from a single host.
+type hostFeatures map[featureKind]map[string]struct{}
+
+func (f hostFeatures) add(kind featureKind, value *string) {
+	if value == nil || *value == "" {
+		return
+	}
+	if f[kind] == nil {
+		f[kind] = make(map[string]struct{})
+	}
+	f[kind][*value] = struct{}{}
+}
+
+func (f hostFeatures) size() int {
+	n := 0
+	for _, values := range f {
+		n += len(values)
+	}
+	return n
+}
+
+// extractFeatures collects the comparable features of a host's services.
+func extractFeatures(host *assets.Host) hostFeatures {
+	features := make(hostFeatures)
+	for _, svc := range host.Services {
+		if svc.Port != nil {
+			port := strconv.Itoa(*svc.Port)
+			features.add(featurePort, &port)
+		}
+		features.add(featureBanner, svc.BannerHashSha256)
+		if svc.TLS != nil {
+			features.add(featureJA4S, svc.TLS.Ja4s)
+		}
+		if svc.Jarm != nil {
+			features.add(featureJARM, svc.Jarm.Fingerprint)
+		}
+		if svc.Cert != nil {
+			features.add(featureCert, svc.Cert.FingerprintSha256)
+		}
+		if svc.SSH != nil && svc.SSH.ServerHostKey != nil {
+			features.add(featureSSHHostKey, svc.SSH.ServerHostKey.FingerprintSha256)
+		}
+	}
+	return features
+}
+
+// compareHosts builds the pairwise report for the given hosts.
+func compareHosts(ids []string, hosts []*assets.Host) HostComparisonReport {
+	features := make([]hostFeatures, len(hosts))
+	for i, h := range hosts {
+		features[i] = extractFeatures(h)
+	}
+
+	report := HostComparisonReport{
+		Hosts:  ids,
+		Matrix: make([][]float64, len(hosts)),
+		Pairs:  []HostPair{},
+	}
+	for i := range hosts {
+		report.Matrix[i] = make([]float64, len(hosts))
+		report.Matrix[i][i] = 1
+	}
+	for i := 0; i < len(hosts); i++ {
+		for j := i + 1; j < len(hosts); j++ {
+			pair := comparePair(ids[i], ids[j], features[i], features[j])
+			report.Matrix[i][j] = pair.Similarity
+			report.Matrix[j][i] = pair.Similarity
+			report.Pairs = append(report.Pairs, pair)
+		}
+	}
+	sort.SliceStable(report.Pairs, func(i, j int) bool {
+		return report.Pairs[i].Similarity > report.Pairs[j].Similarity
+	})
+	return report
+}
+
+func comparePair(idA, idB string, a, b hostFeatures) HostPair {
+	pair := HostPair{
+		HostA:              idA,
+		HostB:              idB,
+		SharedBannerHashes: intersect(a[featureBanner], b[featureBanner]),
+		SharedJA4S:         intersect(a[featureJA4S], b[featureJA4S]),
+		SharedJARM:         intersect(a[featureJARM], b[featureJARM]),
+		SharedCertificates: intersect(a[featureCert], b[featureCert]),
+		SharedSSHHostKeys:  intersect(a[featureSSHHostKey], b[featureSSHHostKey]),
+		SharedPorts:        []int{},
+	}
+	for _, p := range intersect(a[featurePort], b[featurePort]) {
+		port, _ := strconv.Atoi(p)
+		pair.SharedPorts = append(pair.SharedPorts, port)
+	}
+	sort.Ints(pair.SharedPorts)
+
+	shared := pair.SharedCount()
+	union := a.size() + b.size() - shared
+	if union > 0 {
+		// round to keep the matrix readable
+		pair.Similarity = math.Round(float64(shared)/float64(union)*1e4) / 1e4
+	}
+	return pair
+}
+
+// intersect returns the sorted values present in both sets.
+func intersect(a, b map[string]struct{}) []string {
+	res := []string{}
+	for v := range a {
+		if _, ok := b[v]; ok {
+			res = append(res, v)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
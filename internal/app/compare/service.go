@@ -0,0 +1,90 @@
+package compare
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	utilconvert "github.com/censys/cencli/internal/pkg/convertutil"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
+)
+
+// MaxHosts is the maximum number of hosts that can be compared at once.
+// This matches the number of host IDs the API accepts in a single request.
+const MaxHosts = 100
+
+//go:generate mockgen -destination=../../../gen/app/compare/mocks/compareservice_mock.go -package=mocks -mock_names Service=MockCompareService . Service
+
+// Service provides host comparison capabilities.
+type Service interface {
+	// CompareHosts fetches the given hosts and reports which features each pair has in common.
+	CompareHosts(
+		ctx context.Context,
+		orgID mo.Option[identifiers.OrganizationID],
+		hostIDs []assets.HostID,
+	) (CompareHostsResult, cenclierrors.CencliError)
+}
+
+type compareService struct {
+	client client.Client
+}
+
+func New(client client.Client) Service {
+	return &compareService{client: client}
+}
+
+func (s *compareService) CompareHosts(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	hostIDs []assets.HostID,
+) (CompareHostsResult, cenclierrors.CencliError) {
+	if len(hostIDs) < 2 || len(hostIDs) > MaxHosts {
+		return CompareHostsResult{}, cenclierrors.NewCencliError(
+			fmt.Errorf("expected between 2 and %d hosts, got %d", MaxHosts, len(hostIDs)),
+		)
+	}
+	events.ReportMessage(ctx, events.StageFetch, fmt.Sprintf("Fetching %d hosts...", len(hostIDs)))
+	res, err := s.client.GetHosts(ctx, utilconvert.OptionalString(orgID), utilconvert.Stringify(hostIDs), mo.None[time.Time]())
+	if err != nil {
+		return CompareHostsResult{}, err
+	}
+	meta := responsemeta.NewResponseMeta(res.Metadata.Request, res.Metadata.Response, res.Metadata.Latency, res.Metadata.Attempts)
+
+	// index returned hosts by IP so the report follows the requested order
+	byIP := make(map[string]*assets.Host)
+	if res.Data != nil {
+		for _, h := range *res.Data {
+			if h.IP == nil {
+				continue
+			}
+			host := assets.NewHost(h)
+			byIP[assets.NormalizeIP(*h.IP)] = &host
+		}
+	}
+	var ids, missing []string
+	var hosts []*assets.Host
+	for _, id := range hostIDs {
+		host, ok := byIP[assets.NormalizeIP(id.String())]
+		if !ok {
+			missing = append(missing, id.String())
+			continue
+		}
+		ids = append(ids, id.String())
+		hosts = append(hosts, host)
+	}
+	if len(hosts) < 2 {
+		return CompareHostsResult{}, newNotEnoughHostsError(len(hosts), missing)
+	}
+
+	events.ReportMessage(ctx, events.StageProcess, fmt.Sprintf("Comparing %d hosts...", len(hosts)))
+	report := compareHosts(ids, hosts)
+	report.Missing = missing
+	return CompareHostsResult{Meta: meta, Report: report}, nil
+}
@@ -0,0 +1,151 @@
+package compare
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+)
+
+func strPtr(s string) *string { return &s }
+func intPtr(i int) *int       { return &i }
+
+func service(port int, banner, ja4s, jarm, cert string) components.Service {
+	svc := components.Service{Port: intPtr(port)}
+	if banner != "" {
+		svc.BannerHashSha256 = strPtr(banner)
+	}
+	if ja4s != "" {
+		svc.TLS = &components.TLS{Ja4s: strPtr(ja4s)}
+	}
+	if jarm != "" {
+		svc.Jarm = &components.JarmScan{Fingerprint: strPtr(jarm)}
+	}
+	if cert != "" {
+		svc.Cert = &components.Certificate{FingerprintSha256: strPtr(cert)}
+	}
+	return svc
+}
+
+func mustHostIDs(t *testing.T, raw ...string) []assets.HostID {
+	t.Helper()
+	ids := make([]assets.HostID, len(raw))
+	for i, r := range raw {
+		id, err := assets.NewHostID(r)
+		require.NoError(t, err)
+		ids[i] = id
+	}
+	return ids
+}
+
+func okMetadata() client.Metadata {
+	return client.Metadata{
+		Request:  &http.Request{Method: "POST", URL: &url.URL{Scheme: "https", Host: "api.censys.io"}},
+		Response: &http.Response{StatusCode: 200},
+		Latency:  10 * time.Millisecond,
+	}
+}
+
+func TestCompareService_CompareHosts(t *testing.T) {
+	hostA := components.Host{IP: strPtr("1.1.1.1"), Services: []components.Service{
+		service(443, "b1", "ja4-x", "jarm-1", "cert-1"),
+		service(22, "b2", "", "", ""),
+	}}
+	hostB := components.Host{IP: strPtr("2.2.2.2"), Services: []components.Service{
+		service(443, "b1", "ja4-x", "jarm-2", "cert-1"),
+		service(80, "b3", "", "", ""),
+	}}
+	hostC := components.Host{IP: strPtr("3.3.3.3"), Services: []components.Service{
+		service(8080, "b4", "", "", ""),
+	}}
+
+	t.Run("pairwise report in requested order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		mockClient.EXPECT().GetHosts(gomock.Any(), mo.None[string](), []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}, mo.None[time.Time]()).
+			Return(client.Result[[]components.Host]{
+				Data:     &[]components.Host{hostC, hostB, hostA},
+				Metadata: okMetadata(),
+			}, nil)
+
+		res, err := New(mockClient).CompareHosts(context.Background(), mo.None[identifiers.OrganizationID](), mustHostIDs(t, "1.1.1.1", "2.2.2.2", "3.3.3.3"))
+		require.Nil(t, err)
+		require.NotNil(t, res.Meta)
+
+		report := res.Report
+		require.Equal(t, []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}, report.Hosts)
+		require.Len(t, report.Pairs, 3)
+
+		top := report.Pairs[0]
+		require.Equal(t, "1.1.1.1", top.HostA)
+		require.Equal(t, "2.2.2.2", top.HostB)
+		require.Equal(t, []int{443}, top.SharedPorts)
+		require.Equal(t, []string{"b1"}, top.SharedBannerHashes)
+		require.Equal(t, []string{"ja4-x"}, top.SharedJA4S)
+		require.Empty(t, top.SharedJARM)
+		require.Equal(t, []string{"cert-1"}, top.SharedCertificates)
+		// 4 shared of (7 + 7 - 4) features
+		require.InDelta(t, 0.4, top.Similarity, 0.0001)
+
+		require.Equal(t, 1.0, report.Matrix[0][0])
+		require.Equal(t, top.Similarity, report.Matrix[0][1])
+		require.Equal(t, top.Similarity, report.Matrix[1][0])
+		require.Equal(t, 0.0, report.Matrix[0][2])
+	})
+
+	t.Run("missing hosts are reported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		mockClient.EXPECT().GetHosts(gomock.Any(), mo.None[string](), gomock.Any(), mo.None[time.Time]()).
+			Return(client.Result[[]components.Host]{
+				Data:     &[]components.Host{hostA, hostB},
+				Metadata: okMetadata(),
+			}, nil)
+
+		res, err := New(mockClient).CompareHosts(context.Background(), mo.None[identifiers.OrganizationID](), mustHostIDs(t, "1.1.1.1", "2.2.2.2", "9.9.9.9"))
+		require.Nil(t, err)
+		require.Equal(t, []string{"1.1.1.1", "2.2.2.2"}, res.Report.Hosts)
+		require.Equal(t, []string{"9.9.9.9"}, res.Report.Missing)
+	})
+
+	t.Run("hosts are matched however their IPs are written", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		hostV6 := components.Host{IP: strPtr("2001:db8::1"), Services: hostC.Services}
+		mockClient.EXPECT().GetHosts(gomock.Any(), mo.None[string](), []string{"1.1.1.1", "2001:DB8:0::1"}, mo.None[time.Time]()).
+			Return(client.Result[[]components.Host]{
+				Data:     &[]components.Host{hostA, hostV6},
+				Metadata: okMetadata(),
+			}, nil)
+
+		res, err := New(mockClient).CompareHosts(context.Background(), mo.None[identifiers.OrganizationID](), mustHostIDs(t, "::ffff:1.1.1.1", "2001:DB8:0::1"))
+		require.Nil(t, err)
+		require.Equal(t, []string{"1.1.1.1", "2001:DB8:0::1"}, res.Report.Hosts)
+		require.Empty(t, res.Report.Missing)
+	})
+
+	t.Run("fewer than two hosts found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		mockClient.EXPECT().GetHosts(gomock.Any(), mo.None[string](), gomock.Any(), mo.None[time.Time]()).
+			Return(client.Result[[]components.Host]{
+				Data:     &[]components.Host{hostA},
+				Metadata: okMetadata(),
+			}, nil)
+
+		_, err := New(mockClient).CompareHosts(context.Background(), mo.None[identifiers.OrganizationID](), mustHostIDs(t, "1.1.1.1", "9.9.9.9"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "9.9.9.9")
+	})
+}
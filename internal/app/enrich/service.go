@@ -10,7 +10,6 @@ import (
 	"github.com/samber/mo"
 	"golang.org/x/sync/errgroup"
 
-	"github.com/censys/cencli/internal/app/progress"
 	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	client "github.com/censys/cencli/internal/pkg/clients/censys"
@@ -18,6 +17,7 @@ import (
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
 )
 
 const (
@@ -127,7 +127,7 @@ func (s *enrichService) EnrichHosts(
 		if repMeta == nil {
 			repMeta = o.meta
 		}
-		progress.ReportMessage(ctx, progress.StageFetch, fmt.Sprintf("Enriched %d/%d host(s)...", successCount, total))
+		events.ReportBatch(ctx, events.StageFetch, uint64(successCount), uint64(total), fmt.Sprintf("Enriched %d/%d host(s)...", successCount, total))
 
 		if streamingMode {
 			if _, emitErr = streaming.EmitOrCollect(ctx, o.data, nil); emitErr != nil {
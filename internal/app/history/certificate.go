@@ -7,13 +7,13 @@ import (
 
 	"github.com/samber/mo"
 
-	"github.com/censys/cencli/internal/app/progress"
 	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	utilconvert "github.com/censys/cencli/internal/pkg/convertutil"
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
 	"github.com/censys/censys-sdk-go/models/components"
 )
 
@@ -63,9 +63,9 @@ func (s *historyService) GetCertificateHistory(
 		pages++
 		// Update progress with detailed pagination and observation count
 		if pages == 1 {
-			progress.ReportMessage(ctx, progress.StageFetch, fmt.Sprintf("Fetching certificate observations for %s (%s)...", certIDStr, dateRange))
+			events.ReportMessage(ctx, events.StageFetch, fmt.Sprintf("Fetching certificate observations for %s (%s)...", certIDStr, dateRange))
 		} else {
-			progress.ReportMessage(ctx, progress.StageFetch, fmt.Sprintf("Fetching certificate observations for %s (%s, page %d, %d observations so far)...", certIDStr, dateRange, pages, len(allRanges)))
+			events.ReportMessage(ctx, events.StageFetch, fmt.Sprintf("Fetching certificate observations for %s (%s, page %d, %d observations so far)...", certIDStr, dateRange, pages, len(allRanges)))
 		}
 
 		// fetch observations page
@@ -87,7 +87,7 @@ func (s *historyService) GetCertificateHistory(
 			}
 			// Otherwise, record the error, report it, and return partial results
 			firstError = err
-			progress.ReportError(ctx, progress.StageFetch, err)
+			events.ReportError(ctx, events.StageFetch, err)
 			break
 		}
 
@@ -7,13 +7,13 @@ import (
 
 	"github.com/samber/mo"
 
-	"github.com/censys/cencli/internal/app/progress"
 	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	utilconvert "github.com/censys/cencli/internal/pkg/convertutil"
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
 	"github.com/censys/censys-sdk-go/models/components"
 )
 
@@ -73,11 +73,11 @@ func (s *historyService) GetHostHistory(
 		pages++
 		// Update progress with detailed pagination and date range info
 		if pages == 1 {
-			progress.ReportMessage(ctx, progress.StageFetch, fmt.Sprintf("Fetching host timeline for %s (%s)...", hostIDStr, dateRange))
+			events.ReportMessage(ctx, events.StageFetch, fmt.Sprintf("Fetching host timeline for %s (%s)...", hostIDStr, dateRange))
 		} else {
 			// Show current scanning position
 			currentRangeEnd := currentToTime.Format("2006-01-02T15:04:05Z")
-			progress.ReportMessage(ctx, progress.StageFetch, fmt.Sprintf("Fetching host timeline for %s (page %d, scanning back to %s)...", hostIDStr, pages, currentRangeEnd))
+			events.ReportMessage(ctx, events.StageFetch, fmt.Sprintf("Fetching host timeline for %s (page %d, scanning back to %s)...", hostIDStr, pages, currentRangeEnd))
 		}
 
 		// fetch timeline page
@@ -89,22 +89,22 @@ func (s *historyService) GetHostHistory(
 			}
 			// Otherwise, record the error, report it, and return partial results
 			firstError = err
-			progress.ReportError(ctx, progress.StageFetch, err)
+			events.ReportError(ctx, events.StageFetch, err)
 			break
 		}
 
 		// store metadata from the last successful request
 		lastMeta = responsemeta.NewResponseMeta(res.Metadata.Request, res.Metadata.Response, res.Metadata.Latency, res.Metadata.Attempts)
 
-		events := res.Data.GetEvents()
-		if len(events) == 0 {
+		pageEvents := res.Data.GetEvents()
+		if len(pageEvents) == 0 {
 			// no more events available
 			break
 		}
 
 		// Either stream or accumulate events
-		for i := range events {
-			event := &events[i].Resource
+		for i := range pageEvents {
+			event := &pageEvents[i].Resource
 			var emitErr error
 			allEvents, emitErr = streaming.EmitOrCollect(ctx, event, allEvents)
 			if emitErr != nil {
@@ -121,7 +121,7 @@ func (s *historyService) GetHostHistory(
 		}
 
 		// if we got fewer than maxEventsPerPage events, we've reached the end
-		if len(events) < maxEventsPerPage {
+		if len(pageEvents) < maxEventsPerPage {
 			break
 		}
 
@@ -0,0 +1,194 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	utilconvert "github.com/censys/cencli/internal/pkg/convertutil"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
+	"github.com/censys/censys-sdk-go/models/components"
+)
+
+// HostEventTypes are the types of host timeline events, named after the
+// field of the event that holds them.
+var HostEventTypes = []string{
+	"endpoint_scanned",
+	"forward_dns_resolved",
+	"jarm_scanned",
+	"location_updated",
+	"reverse_dns_resolved",
+	"route_updated",
+	"service_scanned",
+	"whois_updated",
+}
+
+// HostEventType returns the type of a host timeline event, one of
+// HostEventTypes, or "" if the event has none.
+func HostEventType(event *components.HostTimelineEvent) string {
+	switch {
+	case event.EndpointScanned != nil:
+		return "endpoint_scanned"
+	case event.ForwardDNSResolved != nil:
+		return "forward_dns_resolved"
+	case event.JarmScanned != nil:
+		return "jarm_scanned"
+	case event.LocationUpdated != nil:
+		return "location_updated"
+	case event.ReverseDNSResolved != nil:
+		return "reverse_dns_resolved"
+	case event.RouteUpdated != nil:
+		return "route_updated"
+	case event.ServiceScanned != nil:
+		return "service_scanned"
+	case event.WhoisUpdated != nil:
+		return "whois_updated"
+	default:
+		return ""
+	}
+}
+
+// HostEventGroup is the timeline events of a host that happened at the same time.
+type HostEventGroup struct {
+	Time   time.Time
+	Events []*components.HostTimelineEvent
+}
+
+// GroupHostEvents groups events by their event time, in chronological
+// order. Only the events of the given types are kept, or every event if
+// types is empty. Events without a valid time are skipped.
+func GroupHostEvents(events []*components.HostTimelineEvent, types []string) []HostEventGroup {
+	keep := make(map[string]bool, len(types))
+	for _, t := range types {
+		keep[t] = true
+	}
+	byTime := make(map[time.Time]*HostEventGroup)
+	for _, event := range events {
+		if len(keep) > 0 && !keep[HostEventType(event)] {
+			continue
+		}
+		if event.EventTime == nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, *event.EventTime)
+		if err != nil {
+			continue
+		}
+		t = t.UTC()
+		group, ok := byTime[t]
+		if !ok {
+			group = &HostEventGroup{Time: t}
+			byTime[t] = group
+		}
+		group.Events = append(group.Events, event)
+	}
+	groups := make([]HostEventGroup, 0, len(byTime))
+	for _, group := range byTime {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Time.Before(groups[j].Time) })
+	return groups
+}
+
+// HostSnapshot is a host as it was at a point in time, with the timeline
+// events that happened at that time.
+type HostSnapshot struct {
+	Time   time.Time                       `json:"time"`
+	Events []*components.HostTimelineEvent `json:"events"`
+	Data   *components.Host                `json:"data"`
+	Exists bool                            `json:"exists"`
+}
+
+type HostSnapshotsResult struct {
+	Meta      *responsemeta.ResponseMeta
+	Snapshots []*HostSnapshot
+	// PartialError contains any error encountered after the first successful request.
+	// When present, the result contains partial data and the error should be reported to the user.
+	PartialError cenclierrors.CencliError
+}
+
+func (s *historyService) GetHostSnapshots(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	host assets.HostID,
+	groups []HostEventGroup,
+) (HostSnapshotsResult, cenclierrors.CencliError) {
+	start := time.Now()
+	orgIDStr := utilconvert.OptionalString(orgID)
+	hostIDStr := host.String()
+
+	var allSnapshots []*HostSnapshot
+	var lastMeta *responsemeta.ResponseMeta
+	var firstError cenclierrors.CencliError
+	requests := uint64(0)
+
+	finish := func(snapshots []*HostSnapshot, partialErr cenclierrors.CencliError) HostSnapshotsResult {
+		if lastMeta != nil {
+			lastMeta.Latency = time.Since(start)
+			lastMeta.PageCount = requests
+		}
+		return HostSnapshotsResult{
+			Meta:         lastMeta,
+			Snapshots:    snapshots,
+			PartialError: cenclierrors.ToPartialError(partialErr),
+		}
+	}
+
+	for i, group := range groups {
+		// Check for context cancellation
+		if err := ctx.Err(); err != nil {
+			contextErr := cenclierrors.ParseContextError(err)
+			// Return partial results with context error
+			if requests > 0 || streaming.IsStreaming(ctx) {
+				return finish(allSnapshots, contextErr), nil
+			}
+			return HostSnapshotsResult{}, contextErr
+		}
+
+		requests++
+		events.ReportMessage(ctx, events.StageFetch, fmt.Sprintf("Fetching host %s at %s (snapshot %d/%d)...",
+			hostIDStr, group.Time.Format(time.RFC3339), i+1, len(groups)))
+
+		snapshot := &HostSnapshot{
+			Time:   group.Time,
+			Events: group.Events,
+		}
+		if snapshot.Events == nil {
+			snapshot.Events = []*components.HostTimelineEvent{}
+		}
+		res, err := s.client.GetHosts(ctx, orgIDStr, []string{hostIDStr}, mo.Some(group.Time))
+		if err != nil {
+			// If this is the first request, return the error immediately
+			if requests == 1 {
+				return HostSnapshotsResult{}, err
+			}
+			// Otherwise, keep going: the snapshot is recorded as missing and the
+			// first error is reported with the results
+			if firstError == nil {
+				firstError = err
+				events.ReportError(ctx, events.StageFetch, err)
+			}
+		} else {
+			lastMeta = responsemeta.NewResponseMeta(res.Metadata.Request, res.Metadata.Response, res.Metadata.Latency, res.Metadata.Attempts)
+			if res.Data != nil && len(*res.Data) > 0 {
+				snapshot.Data = &(*res.Data)[0]
+				snapshot.Exists = true
+			}
+		}
+
+		var emitErr error
+		allSnapshots, emitErr = streaming.EmitOrCollect(ctx, snapshot, allSnapshots)
+		if emitErr != nil {
+			return finish(nil, cenclierrors.NewCencliError(emitErr)), nil
+		}
+	}
+	return finish(allSnapshots, firstError), nil
+}
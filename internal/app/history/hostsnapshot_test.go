@@ -0,0 +1,105 @@
+package history
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+)
+
+func TestGroupHostEvents(t *testing.T) {
+	service := &components.HostTimelineEvent{EventTime: strPtr("2024-01-15T12:00:00Z"), ServiceScanned: &components.ServiceScanned{}}
+	location := &components.HostTimelineEvent{EventTime: strPtr("2024-01-15T12:00:00Z"), LocationUpdated: &components.LocationUpdated{}}
+	earlier := &components.HostTimelineEvent{EventTime: strPtr("2024-01-10T10:00:00+02:00"), ServiceScanned: &components.ServiceScanned{}}
+	noTime := &components.HostTimelineEvent{ServiceScanned: &components.ServiceScanned{}}
+	events := []*components.HostTimelineEvent{service, location, earlier, noTime}
+
+	t.Run("groups by time in chronological order", func(t *testing.T) {
+		require.Equal(t, []HostEventGroup{
+			{Time: time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC), Events: []*components.HostTimelineEvent{earlier}},
+			{Time: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), Events: []*components.HostTimelineEvent{service, location}},
+		}, GroupHostEvents(events, nil))
+	})
+
+	t.Run("keeps the selected types", func(t *testing.T) {
+		require.Equal(t, []HostEventGroup{
+			{Time: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), Events: []*components.HostTimelineEvent{location}},
+		}, GroupHostEvents(events, []string{"location_updated"}))
+	})
+
+	t.Run("event types", func(t *testing.T) {
+		require.Equal(t, "service_scanned", HostEventType(service))
+		require.Equal(t, "location_updated", HostEventType(location))
+		require.Equal(t, "", HostEventType(&components.HostTimelineEvent{}))
+	})
+}
+
+func TestGetHostSnapshots(t *testing.T) {
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)
+	second := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	event := &components.HostTimelineEvent{EventTime: strPtr("2024-01-10T08:00:00Z")}
+	groups := []HostEventGroup{
+		{Time: before},
+		{Time: first, Events: []*components.HostTimelineEvent{event}},
+		{Time: second},
+	}
+	hostsAt := func(hosts ...components.Host) client.Result[[]components.Host] {
+		return client.Result[[]components.Host]{
+			Data: &hosts,
+			Metadata: client.Metadata{
+				Request:  &http.Request{Method: "POST", URL: &url.URL{Scheme: "https", Host: "api.censys.io"}},
+				Response: &http.Response{StatusCode: 200},
+				Attempts: 1,
+			},
+		}
+	}
+
+	t.Run("fetches the host at each time", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		gomock.InOrder(
+			mockClient.EXPECT().GetHosts(gomock.Any(), mo.None[string](), []string{"8.8.8.8"}, mo.Some(before)).Return(hostsAt(), nil),
+			mockClient.EXPECT().GetHosts(gomock.Any(), mo.None[string](), []string{"8.8.8.8"}, mo.Some(first)).Return(hostsAt(components.Host{IP: strPtr("8.8.8.8")}), nil),
+			mockClient.EXPECT().GetHosts(gomock.Any(), mo.None[string](), []string{"8.8.8.8"}, mo.Some(second)).Return(client.Result[[]components.Host]{}, client.NewClientError(context.DeadlineExceeded)),
+		)
+
+		res, err := New(mockClient).GetHostSnapshots(context.Background(), mo.None[identifiers.OrganizationID](), mustHostID("8.8.8.8"), groups)
+		require.NoError(t, err)
+		require.Len(t, res.Snapshots, 3)
+
+		require.Equal(t, before, res.Snapshots[0].Time)
+		require.False(t, res.Snapshots[0].Exists)
+		require.Empty(t, res.Snapshots[0].Events)
+
+		require.True(t, res.Snapshots[1].Exists)
+		require.Equal(t, "8.8.8.8", *res.Snapshots[1].Data.IP)
+		require.Equal(t, []*components.HostTimelineEvent{event}, res.Snapshots[1].Events)
+
+		// a failed snapshot after the first is recorded as missing
+		require.False(t, res.Snapshots[2].Exists)
+		require.Error(t, res.PartialError)
+		require.Equal(t, uint64(3), res.Meta.PageCount)
+	})
+
+	t.Run("the first failure is an error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		mockClient.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(client.Result[[]components.Host]{}, client.NewClientError(context.DeadlineExceeded))
+
+		_, err := New(mockClient).GetHostSnapshots(context.Background(), mo.None[identifiers.OrganizationID](), mustHostID("8.8.8.8"), groups)
+		var cencliErr cenclierrors.CencliError
+		require.ErrorAs(t, err, &cencliErr)
+	})
+}
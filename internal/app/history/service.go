@@ -24,6 +24,15 @@ type Service interface {
 		toTime time.Time,
 	) (HostHistoryResult, cenclierrors.CencliError)
 
+	// GetHostSnapshots fetches the host as it was at the time of each group of
+	// timeline events, one request per group.
+	GetHostSnapshots(
+		ctx context.Context,
+		orgID mo.Option[identifiers.OrganizationID],
+		host assets.HostID,
+		groups []HostEventGroup,
+	) (HostSnapshotsResult, cenclierrors.CencliError)
+
 	GetCertificateHistory(
 		ctx context.Context,
 		orgID mo.Option[identifiers.OrganizationID],
@@ -0,0 +1,95 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+)
+
+// ServiceHistory is when a service of a host was scanned within a timeline.
+type ServiceHistory struct {
+	Port              int    `json:"port"`
+	TransportProtocol string `json:"transport_protocol"`
+	Protocol          string `json:"protocol,omitempty"`
+	// FirstSeen is the time of the earliest scan of the service in the timeline.
+	FirstSeen time.Time `json:"first_seen"`
+	// LastChanged is the time of the latest scan of the service in the timeline.
+	LastChanged time.Time `json:"last_changed"`
+	// Events is the number of scans of the service in the timeline.
+	Events int `json:"events"`
+}
+
+// Key identifies the service across scans, by port and transport protocol.
+func (s ServiceHistory) Key() string {
+	return ServiceKey(s.Port, s.TransportProtocol)
+}
+
+// ServiceKey returns the key of the service on a port and transport
+// protocol. An empty transport protocol is treated as TCP.
+func ServiceKey(port int, transport string) string {
+	transport = strings.ToLower(transport)
+	if transport == "" {
+		transport = "tcp"
+	}
+	return fmt.Sprintf("%d/%s", port, transport)
+}
+
+// SummarizeServices returns the history of each service scanned in a host's
+// timeline, ordered by port. Events without a scan, port, or valid time are
+// skipped.
+func SummarizeServices(events []*components.HostTimelineEvent) []ServiceHistory {
+	byKey := make(map[string]*ServiceHistory)
+	for _, event := range events {
+		if event.ServiceScanned == nil || event.ServiceScanned.Scan == nil || event.EventTime == nil {
+			continue
+		}
+		scan := event.ServiceScanned.Scan
+		if scan.Port == nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, *event.EventTime)
+		if err != nil {
+			continue
+		}
+		t = t.UTC()
+		var transport string
+		if scan.TransportProtocol != nil {
+			transport = string(*scan.TransportProtocol)
+		}
+		key := ServiceKey(*scan.Port, transport)
+		svc, ok := byKey[key]
+		if !ok {
+			svc = &ServiceHistory{Port: *scan.Port, TransportProtocol: strings.ToLower(transport), FirstSeen: t, LastChanged: t}
+			if svc.TransportProtocol == "" {
+				svc.TransportProtocol = "tcp"
+			}
+			byKey[key] = svc
+		}
+		svc.Events++
+		if t.Before(svc.FirstSeen) {
+			svc.FirstSeen = t
+		}
+		if !t.Before(svc.LastChanged) {
+			svc.LastChanged = t
+			if scan.Protocol != nil {
+				svc.Protocol = *scan.Protocol
+			}
+		} else if svc.Protocol == "" && scan.Protocol != nil {
+			svc.Protocol = *scan.Protocol
+		}
+	}
+	res := make([]ServiceHistory, 0, len(byKey))
+	for _, svc := range byKey {
+		res = append(res, *svc)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Port != res[j].Port {
+			return res[i].Port < res[j].Port
+		}
+		return res[i].TransportProtocol < res[j].TransportProtocol
+	})
+	return res
+}
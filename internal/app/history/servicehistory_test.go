@@ -0,0 +1,43 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeServices(t *testing.T) {
+	scanned := func(at string, port int, transport components.ServiceScanTransportProtocol, protocol string) *components.HostTimelineEvent {
+		return &components.HostTimelineEvent{EventTime: strPtr(at), ServiceScanned: &components.ServiceScanned{Scan: &components.ServiceScan{
+			Port: &port, TransportProtocol: &transport, Protocol: strPtr(protocol),
+		}}}
+	}
+	events := []*components.HostTimelineEvent{
+		scanned("2024-01-15T12:00:00Z", 443, components.ServiceScanTransportProtocolTCP, "HTTP"),
+		scanned("2024-01-10T12:00:00Z", 443, components.ServiceScanTransportProtocolUnknown, "UNKNOWN"),
+		scanned("2024-01-12T12:00:00Z", 53, components.ServiceScanTransportProtocolUDP, "DNS"),
+		{EventTime: strPtr("2024-01-11T12:00:00Z"), LocationUpdated: &components.LocationUpdated{}},
+		{EventTime: strPtr("2024-01-11T12:00:00Z"), ServiceScanned: &components.ServiceScanned{}},
+		scanned("not a time", 22, components.ServiceScanTransportProtocolTCP, "SSH"),
+	}
+
+	got := SummarizeServices(events)
+	require.Equal(t, []ServiceHistory{
+		{
+			Port: 53, TransportProtocol: "udp", Protocol: "DNS",
+			FirstSeen:   time.Date(2024, 1, 12, 12, 0, 0, 0, time.UTC),
+			LastChanged: time.Date(2024, 1, 12, 12, 0, 0, 0, time.UTC),
+			Events:      1,
+		},
+		{
+			Port: 443, TransportProtocol: "tcp", Protocol: "HTTP",
+			FirstSeen:   time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+			LastChanged: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+			Events:      2,
+		},
+	}, got)
+	require.Equal(t, "443/tcp", got[1].Key())
+	require.Empty(t, SummarizeServices(nil))
+}
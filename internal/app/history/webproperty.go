@@ -7,13 +7,13 @@ import (
 
 	"github.com/samber/mo"
 
-	"github.com/censys/cencli/internal/app/progress"
 	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	utilconvert "github.com/censys/cencli/internal/pkg/convertutil"
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
 	"github.com/censys/censys-sdk-go/models/components"
 )
 
@@ -67,7 +67,7 @@ func (s *historyService) GetWebPropertyHistory(
 		totalRequests++
 		// Update progress with day-by-day info showing current date and progress
 		currentDate := current.Format("2006-01-02")
-		progress.ReportMessage(ctx, progress.StageFetch, fmt.Sprintf("Fetching web property history for %s (day %d/%d: %s)...", webPropIDStr, totalRequests, totalDays, currentDate))
+		events.ReportMessage(ctx, events.StageFetch, fmt.Sprintf("Fetching web property history for %s (day %d/%d: %s)...", webPropIDStr, totalRequests, totalDays, currentDate))
 
 		// fetch web property at this specific time
 		res, err := s.client.GetWebProperties(
@@ -89,7 +89,7 @@ func (s *historyService) GetWebPropertyHistory(
 			if firstError == nil {
 				firstError = err
 				// Report the first error so users are aware something went wrong
-				progress.ReportError(ctx, progress.StageFetch, err)
+				events.ReportError(ctx, events.StageFetch, err)
 			}
 			snapshot = &WebPropertySnapshot{
 				Time:   current,
@@ -15,6 +15,12 @@ type Result struct {
 	Meta      *responsemeta.ResponseMeta
 	Hits      []assets.Asset
 	TotalHits int64
+	// Pages is the number of pages that were fetched.
+	Pages uint64
+	// NextPageToken continues the search after the last page that was fetched.
+	// It is empty when there are no more pages. If a page failed after the first,
+	// it is the token of the failed page, so that resuming retries it.
+	NextPageToken string
 	// PartialError contains any error encountered after the first successful page.
 	// When present, the result contains partial data and the error should be reported to the user.
 	PartialError cenclierrors.CencliError
@@ -29,6 +35,31 @@ type Params struct {
 	Fields       []string
 	PageSize     mo.Option[uint64]
 	MaxPages     mo.Option[uint64]
+	// Limit stops the search once this many hits are collected. The last
+	// pages are requested with only as many hits as are still needed.
+	Limit mo.Option[uint64]
+	// PageToken starts the search at the page it identifies, as returned in a
+	// previous Result's NextPageToken.
+	PageToken mo.Option[string]
+	// EstimatedPages is the expected number of pages, when it is unbounded by
+	// MaxPages or below it. It is only used for progress reporting.
+	EstimatedPages mo.Option[uint64]
+}
+
+// PreflightResult is the response from a preflight request.
+type PreflightResult struct {
+	Meta      *responsemeta.ResponseMeta
+	TotalHits int64
+}
+
+// EstimatePages returns the number of pages needed to fetch totalHits
+// results with the given page size.
+func EstimatePages(totalHits int64, pageSize uint64) uint64 {
+	if totalHits <= 0 || pageSize == 0 {
+		return 0
+	}
+	hits := uint64(totalHits)
+	return (hits + pageSize - 1) / pageSize
 }
 
 func parseHits(hits []components.SearchQueryHit) []assets.Asset {
@@ -9,13 +9,13 @@ import (
 
 	"github.com/censys/censys-sdk-go/models/components"
 
-	"github.com/censys/cencli/internal/app/progress"
 	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	client "github.com/censys/cencli/internal/pkg/clients/censys"
 	utilconvert "github.com/censys/cencli/internal/pkg/convertutil"
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
 )
 
 //go:generate mockgen -destination=../../../gen/app/search/mocks/searchservice_mock.go -package=mocks -mock_names Service=MockSearchService . Service
@@ -23,6 +23,10 @@ import (
 // Service provides asset search capabilities.
 type Service interface {
 	Search(ctx context.Context, params Params) (Result, cenclierrors.CencliError)
+	// Preflight issues a single minimal request (page size 1) for the query
+	// and reports the total number of matching hits without fetching them.
+	// Pagination params are ignored.
+	Preflight(ctx context.Context, params Params) (PreflightResult, cenclierrors.CencliError)
 }
 
 type searchService struct {
@@ -37,8 +41,6 @@ func (s *searchService) Search(
 	ctx context.Context,
 	params Params,
 ) (Result, cenclierrors.CencliError) {
-	orgIDStr := utilconvert.OptionalString(params.OrgID)
-
 	// handle pagination invariants
 	if params.PageSize.IsPresent() && params.PageSize.MustGet() == 0 {
 		return Result{}, NewInvalidPaginationParamsError("page size must be greater than 0")
@@ -46,47 +48,92 @@ func (s *searchService) Search(
 	if params.MaxPages.IsPresent() && params.MaxPages.MustGet() == 0 {
 		return Result{}, NewInvalidPaginationParamsError("max pages must be greater than 0")
 	}
+	if params.Limit.IsPresent() && params.Limit.MustGet() == 0 {
+		return Result{}, NewInvalidPaginationParamsError("limit must be greater than 0")
+	}
+
+	searchFn := s.pageFetcher(ctx, params)
+	expectedPages := params.MaxPages
+	if estimated, ok := params.EstimatedPages.Get(); ok && (!expectedPages.IsPresent() || estimated < expectedPages.MustGet()) {
+		expectedPages = params.EstimatedPages
+	}
+	return s.searchWithPagination(ctx, searchFn, params.PageSize, params.PageToken, params.MaxPages, params.Limit, expectedPages)
+}
+
+func (s *searchService) Preflight(
+	ctx context.Context,
+	params Params,
+) (PreflightResult, cenclierrors.CencliError) {
+	result, err := s.pageFetcher(ctx, params)(mo.None[string](), mo.Some[int64](1))
+	if err != nil {
+		return PreflightResult{}, err
+	}
+	var meta *responsemeta.ResponseMeta
+	if result.Metadata.Request != nil || result.Metadata.Response != nil {
+		meta = responsemeta.NewResponseMeta(result.Metadata.Request, result.Metadata.Response, result.Metadata.Latency, uint64(result.Metadata.Attempts))
+		meta.PageCount = 1
+	}
+	var totalHits int64
+	if result.Data != nil {
+		totalHits = int64(result.Data.TotalHits)
+	}
+	return PreflightResult{Meta: meta, TotalHits: totalHits}, nil
+}
 
-	var searchFn func(mo.Option[string]) (client.Result[components.SearchQueryResponse], cenclierrors.CencliError)
+// pageFetcher returns a function that fetches a single page of results for params,
+// of the given size, searching within a collection if one is set.
+func (s *searchService) pageFetcher(
+	ctx context.Context,
+	params Params,
+) pageFetchFunc {
+	orgIDStr := utilconvert.OptionalString(params.OrgID)
 	if params.CollectionID.IsPresent() {
-		searchFn = func(pageToken mo.Option[string]) (client.Result[components.SearchQueryResponse], cenclierrors.CencliError) {
+		return func(pageToken mo.Option[string], pageSize mo.Option[int64]) (client.Result[components.SearchQueryResponse], cenclierrors.CencliError) {
 			return s.client.SearchCollection(
 				ctx,
 				params.CollectionID.MustGet().String(),
 				orgIDStr,
 				params.Query,
 				params.Fields,
-				toInt64(params.PageSize),
-				pageToken,
-			)
-		}
-	} else {
-		searchFn = func(pageToken mo.Option[string]) (client.Result[components.SearchQueryResponse], cenclierrors.CencliError) {
-			return s.client.Search(
-				ctx,
-				orgIDStr,
-				params.Query,
-				params.Fields,
-				toInt64(params.PageSize),
+				pageSize,
 				pageToken,
 			)
 		}
 	}
-
-	return s.searchWithPagination(ctx, searchFn, params.MaxPages)
+	return func(pageToken mo.Option[string], pageSize mo.Option[int64]) (client.Result[components.SearchQueryResponse], cenclierrors.CencliError) {
+		return s.client.Search(
+			ctx,
+			orgIDStr,
+			params.Query,
+			params.Fields,
+			pageSize,
+			pageToken,
+		)
+	}
 }
 
+// pageFetchFunc fetches the page of results identified by pageToken, or the
+// first page, with pageSize hits at most.
+type pageFetchFunc func(pageToken mo.Option[string], pageSize mo.Option[int64]) (client.Result[components.SearchQueryResponse], cenclierrors.CencliError)
+
 func (s *searchService) searchWithPagination(
 	ctx context.Context,
-	searchFn func(mo.Option[string]) (client.Result[components.SearchQueryResponse], cenclierrors.CencliError),
+	searchFn pageFetchFunc,
+	pageSize mo.Option[uint64],
+	pageToken mo.Option[string],
 	maxPages mo.Option[uint64],
+	limit mo.Option[uint64],
+	expectedPages mo.Option[uint64],
 ) (Result, cenclierrors.CencliError) {
 	var allHits []assets.Asset
+	// collected counts the hits, which are not kept when streaming
+	var collected uint64
 	var totalHits int64
 	var lastMeta *responsemeta.ResponseMeta
 	var pagesProcessed uint64
 	var firstError cenclierrors.CencliError
-	pageToken := mo.None[string]()
+	// nextPageToken is the token of the next page to fetch, or empty when done
+	var nextPageToken string
 
 	start := time.Now()
 
@@ -106,27 +153,31 @@ func (s *searchService) searchWithPagination(
 					lastMeta.PageCount = pagesProcessed
 				}
 				return Result{
-					Meta:         lastMeta,
-					Hits:         allHits, // empty if streaming
-					TotalHits:    totalHits,
-					PartialError: cenclierrors.ToPartialError(contextErr),
+					Meta:          lastMeta,
+					Hits:          allHits, // empty if streaming
+					TotalHits:     totalHits,
+					Pages:         pagesProcessed,
+					NextPageToken: pageToken.OrEmpty(),
+					PartialError:  cenclierrors.ToPartialError(contextErr),
 				}, nil
 			}
 			return Result{}, contextErr
 		}
 
 		// Report progress for pagination
-		s.reportSearchProgress(ctx, pagesProcessed, len(allHits), maxPages)
+		s.reportSearchProgress(ctx, pagesProcessed, len(allHits), expectedPages)
 
-		result, err := searchFn(pageToken)
+		result, err := searchFn(pageToken, toInt64(limitPageSize(pageSize, limit, collected)))
 		if err != nil {
 			// If this is the first page, return the error immediately
 			if pagesProcessed == 0 {
 				return Result{}, err
 			}
 			// Otherwise, record the error, report it, and return partial results
+			// that can be resumed from the failed page
 			firstError = err
-			progress.ReportError(ctx, progress.StageFetch, err)
+			nextPageToken = pageToken.OrEmpty()
+			events.ReportError(ctx, events.StageFetch, err)
 			break
 		}
 
@@ -136,10 +187,18 @@ func (s *searchService) searchWithPagination(
 
 		if result.Data == nil {
 			pagesProcessed++
+			nextPageToken = ""
 			break
 		}
 
 		pageHits := parseHits(result.Data.Hits)
+		lastPage := len(pageHits) == 0
+		// the API may return more hits than were asked for; those past the
+		// limit are dropped
+		if l, ok := limit.Get(); ok && collected+uint64(len(pageHits)) > l {
+			pageHits = pageHits[:l-collected]
+		}
+		collected += uint64(len(pageHits))
 
 		// Either stream (with asset type wrapping) or accumulate hits
 		if streaming.IsStreaming(ctx) {
@@ -154,6 +213,7 @@ func (s *searchService) searchWithPagination(
 						Meta:         lastMeta,
 						Hits:         nil,
 						TotalHits:    totalHits,
+						Pages:        pagesProcessed,
 						PartialError: cenclierrors.ToPartialError(cenclierrors.NewCencliError(emitErr)),
 					}, nil
 				}
@@ -165,14 +225,18 @@ func (s *searchService) searchWithPagination(
 		totalHits = int64(result.Data.TotalHits)
 		pagesProcessed++
 
-		nextPageToken := result.Data.GetNextPageToken()
-		if nextPageToken == "" || len(pageHits) == 0 {
+		nextPageToken = result.Data.GetNextPageToken()
+		if nextPageToken == "" || lastPage {
+			nextPageToken = ""
 			break
 		}
 
 		if maxPages.IsPresent() && pagesProcessed >= maxPages.MustGet() {
 			break
 		}
+		if limit.IsPresent() && collected >= limit.MustGet() {
+			break
+		}
 
 		pageToken = mo.Some(nextPageToken)
 	}
@@ -183,13 +247,26 @@ func (s *searchService) searchWithPagination(
 	}
 
 	return Result{
-		Meta:         lastMeta,
-		Hits:         allHits, // empty if streaming
-		TotalHits:    totalHits,
-		PartialError: cenclierrors.ToPartialError(firstError),
+		Meta:          lastMeta,
+		Hits:          allHits, // empty if streaming
+		TotalHits:     totalHits,
+		Pages:         pagesProcessed,
+		NextPageToken: nextPageToken,
+		PartialError:  cenclierrors.ToPartialError(firstError),
 	}, nil
 }
 
+// limitPageSize returns the size of the next page: pageSize, or fewer hits
+// if fewer are needed to reach limit, so that no more hits are fetched, and
+// paid for, than are returned. Without a page size, the API chooses it.
+func limitPageSize(pageSize, limit mo.Option[uint64], collected uint64) mo.Option[uint64] {
+	l, ok := limit.Get()
+	if !ok || !pageSize.IsPresent() {
+		return pageSize
+	}
+	return mo.Some(min(pageSize.MustGet(), l-collected))
+}
+
 func toInt64(pageSize mo.Option[uint64]) mo.Option[int64] {
 	res := mo.None[int64]()
 	if pageSize.IsPresent() {
@@ -198,18 +275,18 @@ func toInt64(pageSize mo.Option[uint64]) mo.Option[int64] {
 	return res
 }
 
-func (s *searchService) reportSearchProgress(ctx context.Context, page uint64, hitsCollected int, maxPages mo.Option[uint64]) {
+func (s *searchService) reportSearchProgress(ctx context.Context, page uint64, hitsCollected int, expectedPages mo.Option[uint64]) {
 	if page == 0 {
 		// First page, just show initial message
 		return
 	}
 
 	var msg string
-	if maxPages.IsPresent() {
-		msg = fmt.Sprintf("Fetching search results (page %d/%d, %d hits collected)...", page+1, maxPages.MustGet(), hitsCollected)
+	if expectedPages.IsPresent() {
+		msg = fmt.Sprintf("Fetching search results (page %d/%d, %d hits collected)...", page+1, expectedPages.MustGet(), hitsCollected)
 	} else {
 		msg = fmt.Sprintf("Fetching search results (page %d, %d hits collected)...", page+1, hitsCollected)
 	}
 
-	progress.ReportMessage(ctx, progress.StageFetch, msg)
+	events.ReportPage(ctx, page, expectedPages.OrEmpty(), msg)
 }
@@ -3,6 +3,7 @@ package search
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"testing"
@@ -370,6 +371,7 @@ func TestSearchService(t *testing.T) {
 				require.NoError(t, err)
 				require.Len(t, res.Hits, 2)
 				require.Equal(t, int64(2), res.TotalHits)
+				require.Empty(t, res.NextPageToken)
 			},
 		},
 		{
@@ -584,6 +586,8 @@ func TestSearchService(t *testing.T) {
 				require.NoError(t, err)
 				require.Len(t, res.Hits, 4) // Only 2 pages worth of results
 				require.Equal(t, int64(10), res.TotalHits)
+				require.Equal(t, uint64(2), res.Pages)
+				require.Equal(t, "token2", res.NextPageToken)
 			},
 		},
 		{
@@ -804,6 +808,9 @@ func TestSearchService(t *testing.T) {
 				require.Len(t, res.Hits, 1)
 				require.NotNil(t, res.PartialError)
 				require.Contains(t, res.PartialError.Error(), "network error")
+				// resuming retries the failed page
+				require.Equal(t, "token1", res.NextPageToken)
+				require.Equal(t, uint64(1), res.Pages)
 			},
 		},
 		{
@@ -882,6 +889,114 @@ func TestSearchService(t *testing.T) {
 	}
 }
 
+func TestSearchService_StartsAtPageToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockClient(ctrl)
+	mockClient.EXPECT().Search(
+		gomock.Any(),
+		mo.None[string](),
+		"query",
+		[]string(nil),
+		mo.Some(int64(1)),
+		mo.Some("token5"),
+	).Return(client.Result[components.SearchQueryResponse]{
+		Data: &components.SearchQueryResponse{
+			Hits: []components.SearchQueryHit{
+				{HostV1: &components.HostAssetWithMatchedServices{Resource: components.Host{IP: strPtr("127.0.0.5")}}},
+			},
+			TotalHits:     10,
+			NextPageToken: "token6",
+		},
+	}, nil)
+
+	res, err := New(mockClient).Search(context.Background(), Params{
+		Query:     "query",
+		PageSize:  mo.Some(uint64(1)),
+		MaxPages:  mo.Some(uint64(1)),
+		PageToken: mo.Some("token5"),
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Hits, 1)
+	require.Equal(t, "token6", res.NextPageToken)
+}
+
+func TestSearchService_Limit(t *testing.T) {
+	hits := func(from, n int) []components.SearchQueryHit {
+		res := make([]components.SearchQueryHit, n)
+		for i := range res {
+			res[i] = components.SearchQueryHit{HostV1: &components.HostAssetWithMatchedServices{
+				Resource: components.Host{IP: strPtr(fmt.Sprintf("127.0.0.%d", from+i))},
+			}}
+		}
+		return res
+	}
+
+	t.Run("the last page asks only for the hits still needed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		gomock.InOrder(
+			mockClient.EXPECT().Search(gomock.Any(), mo.None[string](), "query", []string(nil), mo.Some(int64(10)), mo.None[string]()).
+				Return(client.Result[components.SearchQueryResponse]{Data: &components.SearchQueryResponse{
+					Hits: hits(0, 10), TotalHits: 100, NextPageToken: "token1",
+				}}, nil),
+			mockClient.EXPECT().Search(gomock.Any(), mo.None[string](), "query", []string(nil), mo.Some(int64(5)), mo.Some("token1")).
+				Return(client.Result[components.SearchQueryResponse]{Data: &components.SearchQueryResponse{
+					Hits: hits(10, 5), TotalHits: 100, NextPageToken: "token2",
+				}}, nil),
+		)
+
+		res, err := New(mockClient).Search(context.Background(), Params{
+			Query:    "query",
+			PageSize: mo.Some(uint64(10)),
+			Limit:    mo.Some(uint64(15)),
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Hits, 15)
+		require.Equal(t, uint64(2), res.Pages)
+		require.Equal(t, "token2", res.NextPageToken)
+	})
+
+	t.Run("hits past the limit are dropped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		mockClient.EXPECT().Search(gomock.Any(), mo.None[string](), "query", []string(nil), mo.None[int64](), mo.None[string]()).
+			Return(client.Result[components.SearchQueryResponse]{Data: &components.SearchQueryResponse{
+				Hits: hits(0, 10), TotalHits: 100, NextPageToken: "token1",
+			}}, nil)
+
+		res, err := New(mockClient).Search(context.Background(), Params{Query: "query", Limit: mo.Some(uint64(3))})
+		require.NoError(t, err)
+		require.Len(t, res.Hits, 3)
+		require.Equal(t, "127.0.0.2", *res.Hits[2].(*assets.Host).IP)
+	})
+
+	t.Run("max pages still applies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		mockClient.EXPECT().Search(gomock.Any(), mo.None[string](), "query", []string(nil), mo.Some(int64(10)), mo.None[string]()).
+			Return(client.Result[components.SearchQueryResponse]{Data: &components.SearchQueryResponse{
+				Hits: hits(0, 10), TotalHits: 100, NextPageToken: "token1",
+			}}, nil)
+
+		res, err := New(mockClient).Search(context.Background(), Params{
+			Query:    "query",
+			PageSize: mo.Some(uint64(10)),
+			MaxPages: mo.Some(uint64(1)),
+			Limit:    mo.Some(uint64(50)),
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Hits, 10)
+	})
+
+	t.Run("a zero limit is rejected", func(t *testing.T) {
+		_, err := New(mocks.NewMockClient(gomock.NewController(t))).Search(context.Background(), Params{Query: "query", Limit: mo.Some(uint64(0))})
+		var paramsErr InvalidPaginationParamsError
+		require.ErrorAs(t, err, &paramsErr)
+	})
+}
+
 func TestSearchService_DeadlineBeforeFirstRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -953,3 +1068,82 @@ func TestSearchService_DeadlineExpiresBetweenPages(t *testing.T) {
 func strPtr[T ~string](v T) *T { return &v }
 
 func intPtr(v int) *int { return &v }
+
+func TestSearchService_Preflight(t *testing.T) {
+	collectionID := uuid.New()
+
+	t.Run("requests a single result and reports total hits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		mockClient.EXPECT().Search(
+			gomock.Any(),
+			mo.None[string](),
+			"query",
+			[]string(nil),
+			mo.Some[int64](1),
+			mo.None[string](),
+		).Return(client.Result[components.SearchQueryResponse]{
+			Metadata: client.Metadata{
+				Request:  &http.Request{Method: "POST", URL: &url.URL{Scheme: "https", Host: "api.censys.io"}},
+				Response: &http.Response{StatusCode: 200},
+			},
+			Data: &components.SearchQueryResponse{TotalHits: 4242},
+		}, nil)
+
+		svc := New(mockClient)
+		res, err := svc.Preflight(context.Background(), Params{
+			Query:    "query",
+			PageSize: mo.Some[uint64](100),
+			MaxPages: mo.Some[uint64](3),
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(4242), res.TotalHits)
+		require.NotNil(t, res.Meta)
+		require.Equal(t, uint64(1), res.Meta.PageCount)
+	})
+
+	t.Run("searches within collection", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		mockClient.EXPECT().SearchCollection(
+			gomock.Any(),
+			collectionID.String(),
+			mo.None[string](),
+			"query",
+			[]string(nil),
+			mo.Some[int64](1),
+			mo.None[string](),
+		).Return(client.Result[components.SearchQueryResponse]{
+			Data: &components.SearchQueryResponse{TotalHits: 7},
+		}, nil)
+
+		svc := New(mockClient)
+		res, err := svc.Preflight(context.Background(), Params{
+			Query:        "query",
+			CollectionID: mo.Some(identifiers.NewCollectionID(collectionID)),
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(7), res.TotalHits)
+		require.Nil(t, res.Meta)
+	})
+
+	t.Run("returns client error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		mockClient.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(client.Result[components.SearchQueryResponse]{}, client.NewClientError(errors.New("boom")))
+
+		svc := New(mockClient)
+		_, err := svc.Preflight(context.Background(), Params{Query: "query"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "boom")
+	})
+}
+
+func TestEstimatePages(t *testing.T) {
+	require.Equal(t, uint64(0), EstimatePages(0, 100))
+	require.Equal(t, uint64(1), EstimatePages(1, 100))
+	require.Equal(t, uint64(1), EstimatePages(100, 100))
+	require.Equal(t, uint64(2), EstimatePages(101, 100))
+	require.Equal(t, uint64(0), EstimatePages(10, 0))
+}
@@ -81,6 +81,25 @@ func (e *channelEmitter) Close(err error) {
 	})
 }
 
+// NewMapEmitter wraps an emitter so that fn is applied to each item before it is sent.
+// Close is passed through unchanged.
+func NewMapEmitter(inner Emitter, fn func(any) any) Emitter {
+	return &mapEmitter{inner: inner, fn: fn}
+}
+
+type mapEmitter struct {
+	inner Emitter
+	fn    func(any) any
+}
+
+func (e *mapEmitter) Emit(ctx context.Context, data any) error {
+	return e.inner.Emit(ctx, e.fn(data))
+}
+
+func (e *mapEmitter) Close(err error) {
+	e.inner.Close(err)
+}
+
 type emitterContextKey struct{}
 
 // WithEmitter attaches an emitter to the context.
@@ -363,3 +363,19 @@ func TestEmitOrCollect(t *testing.T) {
 		require.Equal(t, 2, result[1].Value)
 	})
 }
+
+func TestMapEmitter(t *testing.T) {
+	inner, ch := NewChannelEmitter(2)
+	emitter := NewMapEmitter(inner, func(v any) any {
+		return v.(string) + "!"
+	})
+
+	ctx := context.Background()
+	require.NoError(t, emitter.Emit(ctx, "hello"))
+	emitter.Close(nil)
+
+	item := <-ch
+	assert.Equal(t, "hello!", item.Data)
+	final := <-ch
+	assert.True(t, final.Done)
+}
@@ -0,0 +1,186 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	utilconvert "github.com/censys/cencli/internal/pkg/convertutil"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/query"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
+)
+
+// maxIDsPerCollectionSearch is the number of assets looked up by each search
+// of a collection, which keeps the query short.
+const maxIDsPerCollectionSearch = 50
+
+// The API has no lookup of assets within a collection, so the assets of a
+// collection are found by searching it for their identifiers. Assets that
+// are not in the collection are left out, as assets that do not exist are
+// by the lookups.
+
+func (s *viewService) GetHostsInCollection(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	collectionID identifiers.CollectionID,
+	hostIDs []assets.HostID,
+) (HostsResult, cenclierrors.CencliError) {
+	hosts, meta, partialErr, err := searchCollection(ctx, s, orgID, collectionID, hostIDs, "hosts",
+		func(id assets.HostID) query.Query { return query.Field("host.ip").Eq(id.String()) },
+		func(hit components.SearchQueryHit) (*assets.Host, bool) {
+			host := hit.GetHostV1()
+			if host == nil {
+				return nil, false
+			}
+			asset := assets.NewHostWithMatchedServices(host.GetResource(), host.GetMatchedServices())
+			return &asset, true
+		})
+	if err != nil {
+		return HostsResult{}, err
+	}
+	return HostsResult{Meta: meta, Hosts: hosts, PartialError: partialErr}, nil
+}
+
+func (s *viewService) GetCertificatesInCollection(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	collectionID identifiers.CollectionID,
+	certificateIDs []assets.CertificateID,
+) (CertificatesResult, cenclierrors.CencliError) {
+	certificates, meta, partialErr, err := searchCollection(ctx, s, orgID, collectionID, certificateIDs, "certificates",
+		func(id assets.CertificateID) query.Query {
+			return query.Field("cert.fingerprint_sha256").Eq(id.String())
+		},
+		func(hit components.SearchQueryHit) (*assets.Certificate, bool) {
+			cert := hit.GetCertificateV1()
+			if cert == nil {
+				return nil, false
+			}
+			asset := assets.NewCertificate(cert.GetResource())
+			return &asset, true
+		})
+	if err != nil {
+		return CertificatesResult{}, err
+	}
+	return CertificatesResult{Meta: meta, Certificates: certificates, PartialError: partialErr}, nil
+}
+
+func (s *viewService) GetWebPropertiesInCollection(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	collectionID identifiers.CollectionID,
+	webPropertyIDs []assets.WebPropertyID,
+) (WebPropertiesResult, cenclierrors.CencliError) {
+	webProperties, meta, partialErr, err := searchCollection(ctx, s, orgID, collectionID, webPropertyIDs, "web properties",
+		func(id assets.WebPropertyID) query.Query {
+			return query.Field("web.hostname").Eq(id.Hostname).And(query.Field("web.port").Eq(int(id.Port)))
+		},
+		func(hit components.SearchQueryHit) (*assets.WebProperty, bool) {
+			webProperty := hit.GetWebpropertyV1()
+			if webProperty == nil {
+				return nil, false
+			}
+			asset := assets.NewWebProperty(webProperty.GetResource())
+			return &asset, true
+		})
+	if err != nil {
+		return WebPropertiesResult{}, err
+	}
+	return WebPropertiesResult{Meta: meta, WebProperties: webProperties, PartialError: partialErr}, nil
+}
+
+// searchCollection searches the collection for the assets with the given
+// IDs, in batches, and returns them in the order of the IDs. As with the
+// lookups, an error after the first batch is returned as a partial error
+// with the assets found before it.
+func searchCollection[ID fmt.Stringer, T assets.Asset](
+	ctx context.Context,
+	s *viewService,
+	orgID mo.Option[identifiers.OrganizationID],
+	collectionID identifiers.CollectionID,
+	ids []ID,
+	noun string,
+	clause func(ID) query.Query,
+	convert func(components.SearchQueryHit) (T, bool),
+) ([]T, *responsemeta.ResponseMeta, cenclierrors.CencliError, cenclierrors.CencliError) {
+	start := time.Now()
+	orgIDStr := utilconvert.OptionalString(orgID)
+	batches := splitSlice(ids, maxIDsPerCollectionSearch)
+
+	var all []T
+	var lastMeta *responsemeta.ResponseMeta
+	var firstError cenclierrors.CencliError
+	batchesProcessed := 0
+	finish := func() {
+		if lastMeta != nil {
+			lastMeta.Latency = time.Since(start)
+			lastMeta.PageCount = uint64(batchesProcessed)
+		}
+	}
+
+	for batchNum, batch := range batches {
+		if err := ctx.Err(); err != nil {
+			contextErr := cenclierrors.ParseContextError(err)
+			if len(all) > 0 || streaming.IsStreaming(ctx) {
+				finish()
+				return all, lastMeta, cenclierrors.ToPartialError(contextErr), nil
+			}
+			return nil, nil, nil, contextErr
+		}
+
+		if len(batches) > 1 {
+			events.ReportBatch(ctx, events.StageFetch, uint64(batchNum), uint64(len(batches)),
+				fmt.Sprintf("Searching collection for %s batch %d/%d (%d %s)...", noun, batchNum+1, len(batches), len(batch), noun))
+		} else {
+			events.ReportMessage(ctx, events.StageFetch, fmt.Sprintf("Searching collection for %d %s...", len(ids), noun))
+		}
+
+		clauses := make([]query.Query, len(batch))
+		for i, id := range batch {
+			clauses[i] = clause(id)
+		}
+		res, err := s.client.SearchCollection(ctx, collectionID.String(), orgIDStr,
+			query.Or(clauses...).String(), nil, mo.Some(int64(len(batch))), mo.None[string]())
+		if err != nil {
+			if batchNum == 0 {
+				return nil, nil, nil, err
+			}
+			firstError = err
+			events.ReportError(ctx, events.StageFetch, err)
+			break
+		}
+		lastMeta = responsemeta.NewResponseMeta(res.Metadata.Request, res.Metadata.Response, res.Metadata.Latency, res.Metadata.Attempts)
+
+		found := make(map[string]T, len(batch))
+		for _, hit := range res.Data.Hits {
+			if asset, ok := convert(hit); ok {
+				found[assets.Identifier(asset)] = asset
+			}
+		}
+		for _, id := range batch {
+			asset, ok := found[id.String()]
+			if !ok {
+				continue
+			}
+			delete(found, id.String())
+			var emitErr error
+			all, emitErr = streaming.EmitOrCollect(ctx, asset, all)
+			if emitErr != nil {
+				finish()
+				return nil, lastMeta, cenclierrors.ToPartialError(cenclierrors.NewCencliError(emitErr)), nil
+			}
+		}
+		batchesProcessed++
+	}
+
+	finish()
+	return all, lastMeta, cenclierrors.ToPartialError(firstError), nil
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/samber/mo"
 
-	"github.com/censys/cencli/internal/app/progress"
 	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	client "github.com/censys/cencli/internal/pkg/clients/censys"
@@ -15,6 +14,7 @@ import (
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/ui/events"
 )
 
 const (
@@ -34,6 +34,12 @@ type Service interface {
 	GetHosts(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], hostIDs []assets.HostID, atTime mo.Option[time.Time]) (HostsResult, cenclierrors.CencliError)
 	GetCertificates(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], certificateIDs []assets.CertificateID) (CertificatesResult, cenclierrors.CencliError)
 	GetWebProperties(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], webPropertyIDs []assets.WebPropertyID, atTime mo.Option[time.Time]) (WebPropertiesResult, cenclierrors.CencliError)
+	// GetHostsInCollection, GetCertificatesInCollection, and
+	// GetWebPropertiesInCollection return the assets with the given IDs that
+	// are in a collection, as they are now.
+	GetHostsInCollection(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], collectionID identifiers.CollectionID, hostIDs []assets.HostID) (HostsResult, cenclierrors.CencliError)
+	GetCertificatesInCollection(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], collectionID identifiers.CollectionID, certificateIDs []assets.CertificateID) (CertificatesResult, cenclierrors.CencliError)
+	GetWebPropertiesInCollection(ctx context.Context, orgID mo.Option[identifiers.OrganizationID], collectionID identifiers.CollectionID, webPropertyIDs []assets.WebPropertyID) (WebPropertiesResult, cenclierrors.CencliError)
 }
 
 type viewService struct {
@@ -88,13 +94,13 @@ func (s *viewService) GetHosts(
 			if atTime.IsPresent() {
 				message = fmt.Sprintf("%s at %s", message, atTime.MustGet().Format(time.RFC3339))
 			}
-			progress.ReportMessage(ctx, progress.StageFetch, message+"...")
+			events.ReportBatch(ctx, events.StageFetch, uint64(batchNum), uint64(totalBatches), message+"...")
 		} else {
 			message := fmt.Sprintf("Fetching %d host(s)", len(hostIDs))
 			if atTime.IsPresent() {
 				message = fmt.Sprintf("%s at %s", message, atTime.MustGet().Format(time.RFC3339))
 			}
-			progress.ReportMessage(ctx, progress.StageFetch, message+"...")
+			events.ReportMessage(ctx, events.StageFetch, message+"...")
 		}
 
 		// convert ids and fetch
@@ -108,7 +114,7 @@ func (s *viewService) GetHosts(
 
 			// Otherwise, record the error, report it, and return partial results
 			firstError = err
-			progress.ReportError(ctx, progress.StageFetch, err)
+			events.ReportError(ctx, events.StageFetch, err)
 			break
 		}
 
@@ -188,9 +194,9 @@ func (s *viewService) GetCertificates(
 
 		// Report progress for batch fetches
 		if totalBatches > 1 {
-			progress.ReportMessage(ctx, progress.StageFetch, fmt.Sprintf("Fetching certificates batch %d/%d (%d certificates)...", batchNum+1, totalBatches, len(batch)))
+			events.ReportBatch(ctx, events.StageFetch, uint64(batchNum), uint64(totalBatches), fmt.Sprintf("Fetching certificates batch %d/%d (%d certificates)...", batchNum+1, totalBatches, len(batch)))
 		} else if len(certificateIDs) > 1 {
-			progress.ReportMessage(ctx, progress.StageFetch, fmt.Sprintf("Fetching %d certificates...", len(certificateIDs)))
+			events.ReportMessage(ctx, events.StageFetch, fmt.Sprintf("Fetching %d certificates...", len(certificateIDs)))
 		}
 
 		// convert ids and fetch
@@ -203,7 +209,7 @@ func (s *viewService) GetCertificates(
 			}
 			// Otherwise, record the error, report it, and return partial results
 			firstError = err
-			progress.ReportError(ctx, progress.StageFetch, err)
+			events.ReportError(ctx, events.StageFetch, err)
 			break
 		}
 
@@ -288,13 +294,13 @@ func (s *viewService) GetWebProperties(
 			if atTime.IsPresent() {
 				message = fmt.Sprintf("%s at %s", message, atTime.MustGet().Format(time.RFC3339))
 			}
-			progress.ReportMessage(ctx, progress.StageFetch, message+"...")
+			events.ReportBatch(ctx, events.StageFetch, uint64(batchNum), uint64(totalBatches), message+"...")
 		} else if len(webPropertyIDs) > 1 {
 			message := fmt.Sprintf("Fetching %d web properties", len(webPropertyIDs))
 			if atTime.IsPresent() {
 				message = fmt.Sprintf("%s at %s", message, atTime.MustGet().Format(time.RFC3339))
 			}
-			progress.ReportMessage(ctx, progress.StageFetch, message+"...")
+			events.ReportMessage(ctx, events.StageFetch, message+"...")
 		}
 
 		// convert ids and fetch
@@ -307,7 +313,7 @@ func (s *viewService) GetWebProperties(
 			}
 			// Otherwise, record the error, report it, and return partial results
 			firstError = err
-			progress.ReportError(ctx, progress.StageFetch, err)
+			events.ReportError(ctx, events.StageFetch, err)
 			break
 		}
 
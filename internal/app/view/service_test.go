@@ -699,3 +699,68 @@ func TestViewService_GetWebProperties_Batching(t *testing.T) {
 		assert.Equal(t, uint64(1), res.Meta.PageCount)
 	})
 }
+
+func TestViewService_InCollection(t *testing.T) {
+	collectionID := identifiers.NewCollectionID(uuid.MustParse("11111111-2222-3333-4444-555555555555"))
+	none := mo.None[identifiers.OrganizationID]()
+
+	t.Run("hosts are searched for and kept in order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		first, _ := assets.NewHostID("8.8.8.8")
+		second, _ := assets.NewHostID("1.1.1.1")
+		missing, _ := assets.NewHostID("10.0.0.1")
+		mockClient.EXPECT().SearchCollection(gomock.Any(), collectionID.String(), mo.None[string](),
+			`host.ip="8.8.8.8" or host.ip="10.0.0.1" or host.ip="1.1.1.1"`, gomock.Nil(), mo.Some[int64](3), mo.None[string]()).
+			Return(client.Result[components.SearchQueryResponse]{Data: &components.SearchQueryResponse{Hits: []components.SearchQueryHit{
+				{HostV1: &components.HostAssetWithMatchedServices{Resource: components.Host{IP: strPtr("1.1.1.1")}}},
+				{HostV1: &components.HostAssetWithMatchedServices{Resource: components.Host{IP: strPtr("8.8.8.8")}}},
+			}}}, nil)
+
+		res, err := New(mockClient).GetHostsInCollection(context.Background(), none, collectionID, []assets.HostID{first, missing, second})
+		require.Nil(t, err)
+		require.Len(t, res.Hosts, 2)
+		assert.Equal(t, "8.8.8.8", *res.Hosts[0].IP)
+		assert.Equal(t, "1.1.1.1", *res.Hosts[1].IP)
+		assert.Nil(t, res.PartialError)
+	})
+
+	t.Run("web properties match hostname and port", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		id, _ := assets.NewWebPropertyID("example.com", 443)
+		port := 443
+		mockClient.EXPECT().SearchCollection(gomock.Any(), collectionID.String(), mo.None[string](),
+			`web.hostname="example.com" and web.port=443`, gomock.Nil(), mo.Some[int64](1), mo.None[string]()).
+			Return(client.Result[components.SearchQueryResponse]{Data: &components.SearchQueryResponse{Hits: []components.SearchQueryHit{
+				{WebpropertyV1: &components.WebpropertyAsset{Resource: components.Webproperty{Hostname: strPtr("example.com"), Port: &port}}},
+			}}}, nil)
+
+		res, err := New(mockClient).GetWebPropertiesInCollection(context.Background(), none, collectionID, []assets.WebPropertyID{id})
+		require.Nil(t, err)
+		require.Len(t, res.WebProperties, 1)
+	})
+
+	t.Run("errors after the first batch are partial", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockClient(ctrl)
+		ids := make([]assets.CertificateID, maxIDsPerCollectionSearch+1)
+		for i := range ids {
+			ids[i], _ = assets.NewCertificateFingerprint(fmt.Sprintf("%064x", i))
+		}
+		gomock.InOrder(
+			mockClient.EXPECT().SearchCollection(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Nil(), mo.Some[int64](maxIDsPerCollectionSearch), gomock.Any()).
+				Return(client.Result[components.SearchQueryResponse]{Data: &components.SearchQueryResponse{Hits: []components.SearchQueryHit{
+					{CertificateV1: &components.CertificateAsset{Resource: components.Certificate{FingerprintSha256: strPtr(ids[0].String())}}},
+				}}}, nil),
+			mockClient.EXPECT().SearchCollection(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Nil(), mo.Some[int64](1), gomock.Any()).
+				Return(client.Result[components.SearchQueryResponse]{}, client.NewClientError(errors.New("boom"))),
+		)
+
+		res, err := New(mockClient).GetCertificatesInCollection(context.Background(), none, collectionID, ids)
+		require.Nil(t, err)
+		require.Len(t, res.Certificates, 1)
+		require.NotNil(t, res.PartialError)
+		assert.Contains(t, res.PartialError.Error(), "boom")
+	})
+}
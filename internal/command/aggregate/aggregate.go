@@ -3,13 +3,13 @@ package aggregate
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/samber/mo"
 	"github.com/spf13/cobra"
 
 	"github.com/censys/cencli/internal/app/aggregate"
+	"github.com/censys/cencli/internal/app/search"
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
@@ -33,6 +33,8 @@ type Command struct {
 	*command.BaseCommand
 	// services the command uses
 	aggregateSvc aggregate.Service
+	// searchSvc runs the follow-up searches of the buckets of --interactive
+	searchSvc search.Service
 	// flags the command uses
 	flags aggregateCommandFlags
 	// state - populated by PreRun (through flags, args, etc.)
@@ -44,8 +46,23 @@ type Command struct {
 	countByLevel  mo.Option[aggregate.CountByLevel]
 	filterByQuery bool
 	interactive   bool
+	// allOrgs aggregates within each organization
+	allOrgs bool
+	// explain prints the parsed query instead of running it
+	explain bool
+	// orgResults are the results of each organization, merged into
+	// orgBuckets, with --all-orgs
+	orgResults []command.OrgResult[aggregate.Result]
+	orgBuckets []orgBucket
+	// queries are the queries of --query-file, aggregated concurrency at once
+	// into matrix
+	queries     []string
+	concurrency int
+	matrix      matrix
 	// result stores the fetched aggregation data for rendering
 	result aggregate.Result
+	// ctx is the context of Run, for the follow-up searches of --interactive
+	ctx context.Context
 }
 
 type aggregateCommandFlags struct {
@@ -55,6 +72,10 @@ type aggregateCommandFlags struct {
 	countByLevel  flags.StringFlag
 	filterByQuery flags.BoolFlag
 	interactive   flags.BoolFlag
+	allOrgs       flags.BoolFlag
+	explain       flags.BoolFlag
+	queryFile     flags.FileFlag
+	concurrency   flags.IntegerFlag
 }
 
 var _ command.Command = (*Command)(nil)
@@ -66,7 +87,7 @@ func NewAggregateCommand(cmdContext *command.Context) *Command {
 }
 
 func (c *Command) Use() string {
-	return fmt.Sprintf("%s <query> <field>", cmdName)
+	return fmt.Sprintf("%s {<query> | --query-file <file>} <field>", cmdName)
 }
 
 func (c *Command) Short() string {
@@ -74,11 +95,15 @@ func (c *Command) Short() string {
 }
 
 func (c *Command) Long() string {
-	return `Aggregate results for a Platform search query. This functionality is equivalent to the Report Builder in the Platform web UI.`
+	return `Aggregate results for a Platform search query. This functionality is equivalent to the Report Builder in the Platform web UI.
+
+With --interactive, the buckets are shown in a table: pressing Enter on a bucket searches the hits of the query that have the value of the bucket, and shows a sample of them below the table.
+
+With --query-file, the aggregation is run for each query of the file (one per line), and the results are combined into a matrix of buckets by query, to compare queries side by side.`
 }
 
 func (c *Command) Args() command.PositionalArgs {
-	return command.ExactArgs(2)
+	return args
 }
 
 func (c *Command) Examples() []string {
@@ -86,6 +111,9 @@ func (c *Command) Examples() []string {
 		`"host.services.protocol=SSH" "host.services.port"`,
 		`-c <your-collection-id> "host.services.protocol=HTTP" "host.location.country"`,
 		`"host.services.protocol=HTTP" "host.location.country" --output-format json`,
+		`--all-orgs "host.services.protocol=RDP" "host.location.country"`,
+		`--explain "host.services.protocol=SSH host.location.country: Germany" "host.services.port"`,
+		`--query-file queries.txt "host.services.port"`,
 	}
 }
 
@@ -137,20 +165,50 @@ func (c *Command) Init() error {
 		"interactive",
 		"i",
 		false,
-		"display results in an interactive table (TUI)",
+		"display results in an interactive table (TUI); press Enter on a bucket to search its hits",
+	)
+	c.flags.allOrgs = command.NewAllOrgsFlag(c.Flags())
+	c.flags.explain = command.NewExplainFlag(c.Flags())
+	c.flags.queryFile = flags.NewFileFlag(
+		c.Flags(),
+		false,
+		queryFileFlagName,
+		"",
+		"file to read queries from, one per line, to aggregate each and compare them (use '-' for stdin)",
+	)
+	c.flags.concurrency = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"concurrency",
+		"",
+		mo.Some(int64(defaultQueryFileConcurrency)),
+		"number of queries to aggregate at once with --query-file",
+		mo.Some(int64(1)),
+		mo.Some(int64(maxQueryFileConcurrency)),
 	)
 	return nil
 }
 
 func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
 	var err cenclierrors.CencliError
+	// args have already been validated
+	if err := c.parseQueryFileFlags(cmd); err != nil {
+		return err
+	}
+	if len(c.queries) > 0 {
+		c.field = args[0]
+	} else {
+		c.query = args[0]
+		c.field = args[1]
+	}
+	// --explain only parses the query, so nothing else is needed
+	if c.explain, err = c.flags.explain.Value(); err != nil || c.explain {
+		return err
+	}
 	c.aggregateSvc, err = c.AggregateService()
 	if err != nil {
 		return err
 	}
-	// args have already been validated
-	c.query = args[0]
-	c.field = args[1]
 	// validate orgID (if present)
 	c.orgID, err = c.flags.orgID.Value()
 	if err != nil {
@@ -190,10 +248,21 @@ func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliE
 	if err != nil {
 		return err
 	}
-	return nil
+	allOrgs, err := c.ParseAllOrgsFlag(cmd, c.flags.allOrgs, allOrgsConflicts...)
+	if err != nil {
+		return err
+	}
+	c.allOrgs = allOrgs
+	if c.interactive {
+		c.searchSvc, err = c.SearchService()
+	}
+	return err
 }
 
 func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	if c.explain {
+		return c.ExplainQuery(c.query)
+	}
 	logger := c.Logger(cmdName).With(
 		"orgID_set", c.orgID.IsPresent(),
 		"collectionID_set", c.collectionID.IsPresent(),
@@ -202,12 +271,20 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 		"numBuckets", c.numBuckets,
 		"countByLevel_set", c.countByLevel.IsPresent(),
 		"filterByQuery", c.filterByQuery,
+		"queries", len(c.queries),
 	)
+	c.ctx = cmd.Context()
 	err := c.WithProgress(
 		cmd.Context(),
 		logger,
 		"Fetching aggregation results...",
 		func(pctx context.Context) cenclierrors.CencliError {
+			if c.allOrgs {
+				return c.fetchAllOrgs(pctx)
+			}
+			if len(c.queries) > 0 {
+				return c.fetchQueries(pctx)
+			}
 			var fetchErr cenclierrors.CencliError
 			c.result, fetchErr = c.fetchAggregateResult(pctx)
 			return fetchErr
@@ -218,6 +295,20 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 		return err
 	}
 
+	if c.allOrgs {
+		if err := c.PrintData(c, c.orgBuckets); err != nil {
+			return err
+		}
+		return command.ReportOrgErrors(c.orgResults)
+	}
+
+	if len(c.queries) > 0 {
+		if err := c.PrintData(c, c.matrix); err != nil {
+			return err
+		}
+		return c.reportQueryErrors()
+	}
+
 	// Print response metadata
 	c.PrintAppResponseMeta(c.result.Meta)
 
@@ -250,6 +341,12 @@ func (c *Command) RenderShort() cenclierrors.CencliError {
 	if c.interactive {
 		return c.showInteractiveTable(c.result)
 	}
+	if c.allOrgs {
+		return c.showOrgsRawTable()
+	}
+	if len(c.queries) > 0 {
+		return c.showMatrixRawTable()
+	}
 	// Default: show raw table
 	return c.showRawTable(c.result)
 }
@@ -279,12 +376,16 @@ func (c *Command) showInteractiveTable(result aggregate.Result) cenclierrors.Cen
 		[]string{"count", c.field},
 		func(bucket aggregate.Bucket) []string {
 			return []string{
-				strconv.FormatUint(bucket.Count, 10),
+				formatter.FormatUint(bucket.Count),
 				bucket.Key,
 			}
 		},
 		table.WithColumnWidths[aggregate.Bucket]([]int{15, len(c.field) + 5}),
 		table.WithTitle[aggregate.Bucket](title),
+		table.WithSelectDescription[aggregate.Bucket]("show sample hits"),
+		table.WithDetailFunc(func(bucket aggregate.Bucket) (string, error) {
+			return c.sampleBucket(c.ctx, bucket)
+		}),
 	)
 	if err := tbl.Run(result.Buckets); err != nil {
 		return cenclierrors.NewCencliError(
@@ -304,12 +405,13 @@ func (c *Command) showRawTable(result aggregate.Result) cenclierrors.CencliError
 		{
 			Title: "Count",
 			String: func(b aggregate.Bucket) string {
-				return strconv.FormatUint(b.Count, 10)
+				return formatter.FormatUint(b.Count)
 			},
 			Style: func(s string, b aggregate.Bucket) string {
 				return styles.NewStyle(styles.ColorOffWhite).Render(s)
 			},
 			AlignRight: true,
+			NoTruncate: true,
 		},
 		{
 			Title: c.field,
@@ -326,6 +428,7 @@ func (c *Command) showRawTable(result aggregate.Result) cenclierrors.CencliError
 		columns,
 		rawtable.WithHeaderStyle[aggregate.Bucket](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
 		rawtable.WithStylesDisabled[aggregate.Bucket](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[aggregate.Bucket](formatter.TableWidth()),
 	)
 
 	fmt.Fprintf(formatter.Stdout, "\n=== Aggregation Results ===\n\n")
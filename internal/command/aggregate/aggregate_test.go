@@ -2,27 +2,48 @@ package aggregate
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/censys/censys-sdk-go/models/components"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	aggregatemocks "github.com/censys/cencli/gen/app/aggregate/mocks"
+	searchmocks "github.com/censys/cencli/gen/app/search/mocks"
 	storemocks "github.com/censys/cencli/gen/store/mocks"
 	"github.com/censys/cencli/internal/app/aggregate"
+	"github.com/censys/cencli/internal/app/search"
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/config"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
 	"github.com/censys/cencli/internal/pkg/formatter"
 	"github.com/censys/cencli/internal/store"
 )
 
 func TestAggregateCommand(t *testing.T) {
+	queryDir := t.TempDir()
+	queryFile := filepath.Join(queryDir, "queries.txt")
+	require.NoError(t, os.WriteFile(queryFile, []byte("# by country\nhost.location.country=Germany\n\nhost.location.country=France\n"), 0o600))
+	emptyQueryFile := filepath.Join(queryDir, "empty.txt")
+	require.NoError(t, os.WriteFile(emptyQueryFile, []byte("# nothing yet\n"), 0o600))
+	bucketsForQuery := func(svc *aggregatemocks.MockAggregateService, query string, buckets []aggregate.Bucket, err cenclierrors.CencliError) {
+		svc.EXPECT().Aggregate(
+			gomock.Any(),
+			gomock.Cond(func(p aggregate.Params) bool { return p.Query == query && p.Field == "host.services.port" }),
+		).Return(aggregate.Result{Buckets: buckets}, err)
+	}
+
 	testCases := []struct {
 		name    string
 		store   func(ctrl *gomock.Controller) store.Store
@@ -55,10 +76,25 @@ func TestAggregateCommand(t *testing.T) {
 				require.NoError(t, err)
 				require.Contains(t, stdout, "80")
 				require.Contains(t, stdout, "443")
-				require.Contains(t, stdout, "1000")
+				require.Contains(t, stdout, "1,000")
 				require.Contains(t, stdout, "800")
 			},
 		},
+		{
+			name: "success - --explain prints the query without aggregating",
+			store: func(ctrl *gomock.Controller) store.Store {
+				return storemocks.NewMockStore(ctrl)
+			},
+			service: func(ctrl *gomock.Controller) aggregate.Service {
+				return aggregatemocks.NewMockAggregateService(ctrl)
+			},
+			args: []string{"--explain", "host.services.protocol=SSH", "host.services.port"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "host.services.protocol = SSH  (exact match)")
+				require.NotContains(t, stdout, "host.services.port")
+			},
+		},
 		{
 			name: "success - with org ID flag",
 			store: func(ctrl *gomock.Controller) store.Store {
@@ -444,7 +480,7 @@ func TestAggregateCommand(t *testing.T) {
 			assert: func(t *testing.T, stdout, stderr string, err error) {
 				require.NoError(t, err)
 				require.Contains(t, stdout, "max")
-				require.Contains(t, stdout, "10000")
+				require.Contains(t, stdout, "10,000")
 			},
 		},
 
@@ -582,7 +618,7 @@ func TestAggregateCommand(t *testing.T) {
 				require.Contains(t, stdout, "query:")
 				require.Contains(t, stdout, "80")
 				require.Contains(t, stdout, "443")
-				require.Contains(t, stdout, "1000")
+				require.Contains(t, stdout, "1,000")
 				require.Contains(t, stdout, "800")
 				// Should NOT be JSON format
 				require.NotContains(t, stdout, `"key"`)
@@ -668,8 +704,160 @@ func TestAggregateCommand(t *testing.T) {
 				require.Contains(t, stdout, "filtered: false")
 			},
 		},
+		{
+			name: "success - all orgs merges buckets",
+			store: func(ctrl *gomock.Controller) store.Store {
+				st := storemocks.NewMockStore(ctrl)
+				st.EXPECT().GetValuesForGlobal(gomock.Any(), config.OrgIDGlobalName).Return([]*store.ValueForGlobal{
+					{Value: "aaaaaaaa-0000-0000-0000-000000000000", Description: "Team A"},
+					{Value: "bbbbbbbb-0000-0000-0000-000000000000"},
+				}, nil)
+				return st
+			},
+			service: func(ctrl *gomock.Controller) aggregate.Service {
+				mockSvc := aggregatemocks.NewMockAggregateService(ctrl)
+				mockSvc.EXPECT().Aggregate(gomock.Any(), gomock.Cond(func(p aggregate.Params) bool {
+					return p.OrgID.MustGet().String() == "aaaaaaaa-0000-0000-0000-000000000000"
+				})).Return(aggregate.Result{Buckets: []aggregate.Bucket{{Key: "US", Count: 5}}}, nil)
+				mockSvc.EXPECT().Aggregate(gomock.Any(), gomock.Cond(func(p aggregate.Params) bool {
+					return p.OrgID.MustGet().String() == "bbbbbbbb-0000-0000-0000-000000000000"
+				})).Return(aggregate.Result{Buckets: []aggregate.Bucket{{Key: "DE", Count: 9}, {Key: "US", Count: 1}}}, nil)
+				return mockSvc
+			},
+			args: []string{"--all-orgs", "-O", "json", "host.services.protocol=RDP", "host.location.country"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.JSONEq(t, `[
+					{"org_id": "bbbbbbbb-0000-0000-0000-000000000000", "key": "DE", "count": 9},
+					{"org_id": "aaaaaaaa-0000-0000-0000-000000000000", "org_name": "Team A", "key": "US", "count": 5},
+					{"org_id": "bbbbbbbb-0000-0000-0000-000000000000", "key": "US", "count": 1}
+				]`, stdout)
+			},
+		},
+		{
+			name: "error - all orgs conflicts with interactive",
+			store: func(ctrl *gomock.Controller) store.Store {
+				return storemocks.NewMockStore(ctrl)
+			},
+			service: func(ctrl *gomock.Controller) aggregate.Service {
+				return aggregatemocks.NewMockAggregateService(ctrl)
+			},
+			args: []string{"--all-orgs", "-i", "host.services.protocol=RDP", "host.location.country"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cannot use --all-orgs and --interactive flags together")
+			},
+		},
+		{
+			name: "success - query file renders a matrix of buckets by query",
+			store: func(ctrl *gomock.Controller) store.Store {
+				return storemocks.NewMockStore(ctrl)
+			},
+			service: func(ctrl *gomock.Controller) aggregate.Service {
+				mockSvc := aggregatemocks.NewMockAggregateService(ctrl)
+				bucketsForQuery(mockSvc, "host.location.country=Germany", []aggregate.Bucket{{Key: "22", Count: 5}, {Key: "443", Count: 2}}, nil)
+				bucketsForQuery(mockSvc, "host.location.country=France", []aggregate.Bucket{{Key: "443", Count: 7}}, nil)
+				return mockSvc
+			},
+			args: []string{"--query-file", queryFile, "host.services.port"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Q1   host.location.country=Germany")
+				require.Contains(t, stdout, "Q2   host.location.country=France")
+				require.Regexp(t, `host\.services\.port\s+Q1\s+Q2\s+Total`, stdout)
+				require.Regexp(t, `443\s+\|\s+2\s+\|\s+7\s+\|\s+9`, stdout)
+				require.Regexp(t, `22\s+\|\s+5\s+\|\s+0\s+\|\s+5`, stdout)
+				require.Less(t, strings.Index(stdout, "443"), strings.Index(stdout, "22 "))
+			},
+		},
+		{
+			name: "success - query file json keeps partial results",
+			store: func(ctrl *gomock.Controller) store.Store {
+				return storemocks.NewMockStore(ctrl)
+			},
+			service: func(ctrl *gomock.Controller) aggregate.Service {
+				mockSvc := aggregatemocks.NewMockAggregateService(ctrl)
+				bucketsForQuery(mockSvc, "host.location.country=Germany", []aggregate.Bucket{{Key: "22", Count: 5}}, nil)
+				bucketsForQuery(mockSvc, "host.location.country=France", nil, cenclierrors.NewCencliError(errors.New("boom")))
+				return mockSvc
+			},
+			args: []string{"--query-file", queryFile, "--concurrency", "1", "-O", "json", "host.services.port"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var m matrix
+				require.NoError(t, json.Unmarshal([]byte(stdout), &m))
+				require.Equal(t, "host.services.port", m.Field)
+				require.Len(t, m.Queries, 2)
+				require.Equal(t, "boom", m.Queries[1].Error)
+				require.Equal(t, []matrixRow{{Key: "22", Counts: []uint64{5, 0}, Total: 5}}, m.Rows)
+				require.Contains(t, stderr, "Query Q2 (host.location.country=France) failed: boom")
+			},
+		},
+		{
+			name: "error - query file every query failed",
+			store: func(ctrl *gomock.Controller) store.Store {
+				return storemocks.NewMockStore(ctrl)
+			},
+			service: func(ctrl *gomock.Controller) aggregate.Service {
+				mockSvc := aggregatemocks.NewMockAggregateService(ctrl)
+				bucketsForQuery(mockSvc, "host.location.country=Germany", nil, cenclierrors.NewCencliError(errors.New("boom")))
+				bucketsForQuery(mockSvc, "host.location.country=France", nil, cenclierrors.NewCencliError(errors.New("boom")))
+				return mockSvc
+			},
+			args: []string{"--query-file", queryFile, "host.services.port"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var failed AllQueriesFailedError
+				require.ErrorAs(t, err, &failed)
+				require.Contains(t, err.Error(), "all 2 queries failed")
+			},
+		},
+		{
+			name: "error - query file takes only the field",
+			store: func(ctrl *gomock.Controller) store.Store {
+				return storemocks.NewMockStore(ctrl)
+			},
+			service: func(ctrl *gomock.Controller) aggregate.Service {
+				return aggregatemocks.NewMockAggregateService(ctrl)
+			},
+			args: []string{"--query-file", queryFile, "host.services.protocol=RDP", "host.services.port"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "accepts 1 arg(s), received 2")
+			},
+		},
+		{
+			name: "error - query file without queries",
+			store: func(ctrl *gomock.Controller) store.Store {
+				return storemocks.NewMockStore(ctrl)
+			},
+			service: func(ctrl *gomock.Controller) aggregate.Service {
+				return aggregatemocks.NewMockAggregateService(ctrl)
+			},
+			args: []string{"--query-file", emptyQueryFile, "host.services.port"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var noQueries NoQueriesError
+				require.ErrorAs(t, err, &noQueries)
+			},
+		},
+		{
+			name: "error - query file conflicts with interactive",
+			store: func(ctrl *gomock.Controller) store.Store {
+				return storemocks.NewMockStore(ctrl)
+			},
+			service: func(ctrl *gomock.Controller) aggregate.Service {
+				return aggregatemocks.NewMockAggregateService(ctrl)
+			},
+			args: []string{"--query-file", queryFile, "-i", "host.services.port"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cannot use --query-file and --interactive flags together")
+			},
+		},
 	}
 
+	// Write counts with the default thousands separator, whatever the locale
+	// of the environment
+	t.Setenv("LC_ALL", "C")
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tempDir := t.TempDir()
@@ -694,3 +882,37 @@ func TestAggregateCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestDrillDownQuery(t *testing.T) {
+	require.Equal(t, `(host.services.protocol=RDP) and host.services.port="3389"`,
+		drillDownQuery("host.services.protocol=RDP", "host.services.port", "3389"))
+	require.Equal(t, `host.location.country="United States"`,
+		drillDownQuery("*", "host.location.country", "United States"))
+	require.Equal(t, `host.services.banner="say \"hi\""`,
+		drillDownQuery("", "host.services.banner", `say "hi"`))
+}
+
+func TestSampleBucket(t *testing.T) {
+	ip, rdp, ssh := "10.0.0.1", 3389, 22
+	ctrl := gomock.NewController(t)
+	searchSvc := searchmocks.NewMockSearchService(ctrl)
+	searchSvc.EXPECT().Search(gomock.Any(), gomock.Cond(func(p search.Params) bool {
+		return p.Query == `(host.services.protocol=RDP) and host.services.port="3389"` &&
+			p.PageSize.OrElse(0) == drillDownSampleSize && p.MaxPages.OrElse(0) == 1
+	})).Return(search.Result{
+		TotalHits: 120,
+		Hits: []assets.Asset{
+			&assets.Host{Host: components.Host{
+				IP:       &ip,
+				Services: []components.Service{{Port: &rdp}, {Port: &ssh}},
+			}},
+		},
+	}, nil)
+
+	c := &Command{searchSvc: searchSvc, query: "host.services.protocol=RDP", field: "host.services.port"}
+	sample, err := c.sampleBucket(context.Background(), aggregate.Bucket{Key: "3389", Count: 120})
+	require.NoError(t, err)
+	require.Contains(t, sample, `(host.services.protocol=RDP) and host.services.port="3389"`)
+	require.Contains(t, sample, "120 hits, showing 1")
+	require.Contains(t, sample, "10.0.0.1  ports: 22, 3389")
+}
@@ -0,0 +1,98 @@
+package aggregate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/app/aggregate"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+// allOrgsConflicts are the flags that cannot be combined with --all-orgs:
+// collections belong to a single organization, and the interactive table has
+// no organization column.
+var allOrgsConflicts = []string{"collection-id", "interactive"}
+
+// orgBucket is a bucket of an organization, with --all-orgs.
+type orgBucket struct {
+	OrgID   string `json:"org_id"`
+	OrgName string `json:"org_name,omitempty"`
+	aggregate.Bucket
+}
+
+// fetchAllOrgs aggregates within each organization, and merges their buckets
+// into c.orgBuckets, highest count first.
+func (c *Command) fetchAllOrgs(ctx context.Context) cenclierrors.CencliError {
+	orgs, err := c.AccessibleOrgs(ctx)
+	if err != nil {
+		return err
+	}
+	c.orgResults = command.RunForOrgs(ctx, orgs, func(ctx context.Context, org command.Org) (aggregate.Result, cenclierrors.CencliError) {
+		params := c.buildAggregateParams()
+		params.OrgID = mo.Some(org.ID)
+		return c.aggregateSvc.Aggregate(ctx, params)
+	})
+	c.orgBuckets = nil
+	for _, res := range c.orgResults {
+		for _, bucket := range res.Result.Buckets {
+			c.orgBuckets = append(c.orgBuckets, orgBucket{OrgID: res.Org.ID.String(), OrgName: res.Org.Name, Bucket: bucket})
+		}
+	}
+	sort.SliceStable(c.orgBuckets, func(i, j int) bool { return c.orgBuckets[i].Count > c.orgBuckets[j].Count })
+	return nil
+}
+
+// showOrgsRawTable renders the buckets of every organization as a table.
+func (c *Command) showOrgsRawTable() cenclierrors.CencliError {
+	if len(c.orgBuckets) == 0 {
+		fmt.Fprintf(formatter.Stdout, "\nNo results found.\n")
+		return nil
+	}
+
+	columns := []rawtable.Column[orgBucket]{
+		{
+			Title:      "Count",
+			String:     func(b orgBucket) string { return formatter.FormatUint(b.Count) },
+			AlignRight: true,
+			NoTruncate: true,
+		},
+		{
+			Title: "Organization",
+			String: func(b orgBucket) string {
+				if b.OrgName != "" {
+					return b.OrgName
+				}
+				return b.OrgID
+			},
+			Style: func(s string, _ orgBucket) string {
+				return styles.GlobalStyles.Signature.Render(s)
+			},
+		},
+		{
+			Title:  c.field,
+			String: func(b orgBucket) string { return b.Key },
+			Style: func(s string, _ orgBucket) string {
+				return styles.NewStyle(styles.ColorTeal).Render(s)
+			},
+		},
+	}
+
+	tbl := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[orgBucket](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[orgBucket](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[orgBucket](formatter.TableWidth()),
+	)
+
+	fmt.Fprintf(formatter.Stdout, "\n=== Aggregation Results ===\n\n")
+	fmt.Fprintf(formatter.Stdout, "%s\n\n", c.buildTableTitle())
+	fmt.Fprint(formatter.Stdout, tbl.Render(c.orgBuckets))
+	return nil
+}
@@ -0,0 +1,240 @@
+package aggregate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/aggregate"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+const (
+	queryFileFlagName = "query-file"
+
+	defaultQueryFileConcurrency = 4
+	maxQueryFileConcurrency     = 16
+)
+
+// queryFileConflicts are the flags that cannot be combined with
+// --query-file: they work on the buckets of a single query.
+var queryFileConflicts = []string{"interactive", "all-orgs", "explain"}
+
+// queryResult is the aggregation of one query of --query-file.
+type queryResult struct {
+	Query   string             `json:"query"`
+	Buckets []aggregate.Bucket `json:"buckets"`
+	Error   string             `json:"error,omitempty"`
+
+	err cenclierrors.CencliError
+}
+
+// matrixRow is the count of a bucket key for each query of --query-file, in
+// the order of the queries. Queries without the key count 0.
+type matrixRow struct {
+	Key    string   `json:"key"`
+	Counts []uint64 `json:"counts"`
+	Total  uint64   `json:"total"`
+}
+
+// matrix is the output of --query-file: the bucket keys of every query,
+// against the queries.
+type matrix struct {
+	Field   string        `json:"field"`
+	Queries []queryResult `json:"queries"`
+	Rows    []matrixRow   `json:"rows"`
+}
+
+// args validates the positional arguments: the field alone with
+// --query-file, otherwise the query and the field.
+func args(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed(queryFileFlagName) {
+		return command.ExactArgs(1)(cmd, args)
+	}
+	return command.ExactArgs(2)(cmd, args)
+}
+
+// parseQueryFileFlags parses --query-file and --concurrency. Lines of the
+// file that are blank or start with # are skipped.
+func (c *Command) parseQueryFileFlags(cmd *cobra.Command) cenclierrors.CencliError {
+	if !c.flags.queryFile.IsSet() {
+		return nil
+	}
+	for _, name := range queryFileConflicts {
+		if cmd.Flags().Changed(name) {
+			return flags.NewConflictingFlagsError(queryFileFlagName, name)
+		}
+	}
+	lines, err := c.flags.queryFile.Lines(cmd)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		c.queries = append(c.queries, line)
+	}
+	if len(c.queries) == 0 {
+		return newNoQueriesError()
+	}
+	concurrency, err := c.flags.concurrency.Value()
+	if err != nil {
+		return err
+	}
+	c.concurrency = int(concurrency.OrElse(defaultQueryFileConcurrency))
+	return nil
+}
+
+// fetchQueries aggregates each query of --query-file, c.concurrency at
+// once, and builds c.matrix from their buckets. A query failing does not
+// stop the others.
+func (c *Command) fetchQueries(ctx context.Context) cenclierrors.CencliError {
+	results := make([]queryResult, len(c.queries))
+	sem := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
+	for i, query := range c.queries {
+		results[i].Query = query
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i].err = cenclierrors.ParseContextError(ctx.Err())
+				return
+			}
+			defer func() { <-sem }()
+			params := c.buildAggregateParams()
+			params.Query = query
+			res, err := c.aggregateSvc.Aggregate(ctx, params)
+			results[i].Buckets, results[i].err = res.Buckets, err
+		}()
+	}
+	wg.Wait()
+	c.matrix = buildMatrix(c.field, results)
+	return nil
+}
+
+// buildMatrix lays out the buckets of results as rows of counts per query,
+// highest total first.
+func buildMatrix(field string, results []queryResult) matrix {
+	m := matrix{Field: field, Queries: results, Rows: []matrixRow{}}
+	rows := map[string]*matrixRow{}
+	var keys []string
+	for i := range results {
+		if results[i].err != nil {
+			results[i].Error = results[i].err.Error()
+		}
+		if results[i].Buckets == nil {
+			results[i].Buckets = []aggregate.Bucket{}
+		}
+		for _, bucket := range results[i].Buckets {
+			row, ok := rows[bucket.Key]
+			if !ok {
+				row = &matrixRow{Key: bucket.Key, Counts: make([]uint64, len(results))}
+				rows[bucket.Key] = row
+				keys = append(keys, bucket.Key)
+			}
+			row.Counts[i] += bucket.Count
+			row.Total += bucket.Count
+		}
+	}
+	for _, key := range keys {
+		m.Rows = append(m.Rows, *rows[key])
+	}
+	sort.SliceStable(m.Rows, func(i, j int) bool {
+		if m.Rows[i].Total != m.Rows[j].Total {
+			return m.Rows[i].Total > m.Rows[j].Total
+		}
+		return m.Rows[i].Key < m.Rows[j].Key
+	})
+	return m
+}
+
+// reportQueryErrors prints the error of each query that failed to stderr.
+// It returns an error if the command was interrupted or every query failed,
+// so that partial results are still printed.
+func (c *Command) reportQueryErrors() cenclierrors.CencliError {
+	var failed int
+	var first cenclierrors.CencliError
+	for i, res := range c.matrix.Queries {
+		if res.err == nil {
+			continue
+		}
+		if cenclierrors.IsInterrupted(res.err) {
+			return res.err
+		}
+		failed++
+		if first == nil {
+			first = res.err
+		}
+		formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render(
+			fmt.Sprintf("Query Q%d (%s) failed: %v", i+1, res.Query, res.err)))
+	}
+	if failed > 0 && failed == len(c.matrix.Queries) {
+		return newAllQueriesFailedError(failed, first)
+	}
+	return nil
+}
+
+// showMatrixRawTable renders the matrix as a table with a column per query,
+// labeled Q1, Q2, and so on, after a legend of the queries.
+func (c *Command) showMatrixRawTable() cenclierrors.CencliError {
+	fmt.Fprintf(formatter.Stdout, "\n=== Aggregation Results ===\n\n")
+	for i, res := range c.matrix.Queries {
+		fmt.Fprintf(formatter.Stdout, "Q%-3d %s\n", i+1, res.Query)
+	}
+	if len(c.matrix.Rows) == 0 {
+		fmt.Fprintf(formatter.Stdout, "\nNo results found.\n")
+		return nil
+	}
+	fmt.Fprintln(formatter.Stdout)
+
+	columns := []rawtable.Column[matrixRow]{
+		{
+			Title:  c.field,
+			String: func(r matrixRow) string { return r.Key },
+			Style: func(s string, _ matrixRow) string {
+				return styles.NewStyle(styles.ColorTeal).Render(s)
+			},
+		},
+	}
+	for i := range c.matrix.Queries {
+		columns = append(columns, rawtable.Column[matrixRow]{
+			Title:      fmt.Sprintf("Q%d", i+1),
+			String:     func(r matrixRow) string { return formatter.FormatUint(r.Counts[i]) },
+			AlignRight: true,
+			NoTruncate: true,
+		})
+	}
+	columns = append(columns, rawtable.Column[matrixRow]{
+		Title:  "Total",
+		String: func(r matrixRow) string { return strconv.FormatUint(r.Total, 10) },
+		Style: func(s string, _ matrixRow) string {
+			return styles.NewStyle(styles.ColorOffWhite).Render(s)
+		},
+		AlignRight: true,
+		NoTruncate: true,
+	})
+
+	tbl := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[matrixRow](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[matrixRow](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[matrixRow](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, tbl.Render(c.matrix.Rows))
+	return nil
+}
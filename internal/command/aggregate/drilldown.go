@@ -0,0 +1,71 @@
+package aggregate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/app/aggregate"
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/query"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+// drillDownSampleSize is the number of hits shown for a bucket of the
+// interactive table.
+const drillDownSampleSize = 5
+
+// drillDownQuery returns q constrained to the hits whose field has the
+// value of a bucket, such as (host.services.protocol=RDP) and
+// host.services.port="3389".
+func drillDownQuery(q, field, key string) string {
+	clause := query.Field(field).Eq(key)
+	if q := strings.TrimSpace(q); q != "" && q != "*" {
+		return query.Raw(q).And(clause).String()
+	}
+	return clause.String()
+}
+
+// sampleBucket searches the hits of bucket, and renders the first of them
+// for the interactive table.
+func (c *Command) sampleBucket(ctx context.Context, bucket aggregate.Bucket) (string, error) {
+	query := drillDownQuery(c.query, c.field, bucket.Key)
+	result, err := c.searchSvc.Search(ctx, search.Params{
+		OrgID:        c.orgID,
+		CollectionID: c.collectionID,
+		Query:        query,
+		PageSize:     mo.Some[uint64](drillDownSampleSize),
+		MaxPages:     mo.Some[uint64](1),
+	})
+	if err != nil {
+		return "", err
+	}
+	return renderSample(query, result), nil
+}
+
+// renderSample renders the query of a bucket, its number of hits, and a line
+// per sample hit.
+func renderSample(query string, result search.Result) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\n%s hits", query, formatter.FormatInt(result.TotalHits))
+	if len(result.Hits) < int(result.TotalHits) {
+		fmt.Fprintf(&sb, ", showing %d", len(result.Hits))
+	}
+	for _, hit := range result.Hits {
+		sb.WriteString("\n  " + assets.Identifier(hit))
+		if host, ok := hit.(*assets.Host); ok {
+			if ports := host.Ports(); len(ports) > 0 {
+				strs := make([]string, len(ports))
+				for i, port := range ports {
+					strs[i] = strconv.Itoa(port)
+				}
+				sb.WriteString("  ports: " + strings.Join(strs, ", "))
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,48 @@
+package aggregate
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// NoQueriesError is returned when --query-file has no queries.
+type NoQueriesError interface{ cenclierrors.CencliError }
+
+type noQueriesError struct{}
+
+var _ NoQueriesError = &noQueriesError{}
+
+func newNoQueriesError() NoQueriesError { return &noQueriesError{} }
+
+func (e *noQueriesError) Error() string {
+	return "--query-file has no queries; write one query per line"
+}
+
+func (e *noQueriesError) Title() string { return "No Queries" }
+
+func (e *noQueriesError) ShouldPrintUsage() bool { return false }
+
+// AllQueriesFailedError is returned with --query-file when every query failed.
+type AllQueriesFailedError interface{ cenclierrors.CencliError }
+
+type allQueriesFailedError struct {
+	count int
+	first cenclierrors.CencliError
+}
+
+var _ AllQueriesFailedError = &allQueriesFailedError{}
+
+func newAllQueriesFailedError(count int, first cenclierrors.CencliError) AllQueriesFailedError {
+	return &allQueriesFailedError{count: count, first: first}
+}
+
+func (e *allQueriesFailedError) Error() string {
+	return fmt.Sprintf("all %d queries failed, the first with: %v", e.count, e.first)
+}
+
+func (e *allQueriesFailedError) Title() string { return "All Queries Failed" }
+
+func (e *allQueriesFailedError) ShouldPrintUsage() bool { return false }
+
+func (e *allQueriesFailedError) Unwrap() error { return e.first }
@@ -0,0 +1,89 @@
+package alias
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/alias"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+type addCommand struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*addCommand)(nil)
+
+func newAddCommand(cmdContext *command.Context) *addCommand {
+	return &addCommand{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *addCommand) Use() string   { return "add <name> <command line...>" }
+func (c *addCommand) Short() string { return "Add or replace an alias" }
+func (c *addCommand) Long() string {
+	return `Add an alias, or replace the alias with the same name.
+
+The command line is given as one quoted argument, or as several arguments after --,
+so that its flags are not taken as flags of this command. Use {1}, {2}, and so on
+for the arguments given to the alias.`
+}
+
+func (c *addCommand) Examples() []string {
+	return []string{
+		`rdp "search 'host.services.protocol=RDP and host.location.country={1}' --max-pages 5"`,
+		`-- ssh search "host.services.protocol=SSH and host.ip: {1}" -O json`,
+	}
+}
+
+func (c *addCommand) Args() command.PositionalArgs { return command.MinimumArgs(2) }
+
+func (c *addCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *addCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *addCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *addCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	name := args[0]
+	expansion := args[1]
+	if len(args) > 2 {
+		expansion = alias.Join(args[1:])
+	}
+	if err := alias.ValidateName(name); err != nil {
+		return newInvalidAliasError(name, err)
+	}
+	if isBuiltin(cmd.Root(), name) {
+		return newInvalidAliasError(name, fmt.Errorf("it would be shadowed by the built-in command %q", name))
+	}
+	if words, err := alias.Split(expansion); err != nil {
+		return newInvalidAliasError(name, err)
+	} else if len(words) == 0 {
+		return newInvalidAliasError(name, fmt.Errorf("the command line is empty"))
+	}
+
+	aliases := maps.Clone(c.Config().Aliases)
+	if aliases == nil {
+		aliases = map[string]string{}
+	}
+	_, replaced := aliases[name]
+	aliases[name] = expansion
+	if err := c.Config().SaveAliases(aliases); err != nil {
+		return err
+	}
+	if replaced {
+		formatter.Printf(formatter.Stdout, "✅ Replaced alias [%s]: %s\n", name, expansion)
+	} else {
+		formatter.Printf(formatter.Stdout, "✅ Added alias [%s]: %s\n", name, expansion)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package alias
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// Command is the parent alias command that groups alias-related subcommands.
+type Command struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*Command)(nil)
+
+// NewAliasCommand creates a new alias command with all subcommands.
+func NewAliasCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return "alias"
+}
+
+func (c *Command) Short() string {
+	return "Manage command aliases"
+}
+
+func (c *Command) Long() string {
+	return `Manage command aliases, short names for the command lines you run often.
+
+Aliases are kept in the alias section of the config file. "censys <name> [args...]"
+runs the command line of the alias, split into words as a shell would, with {1}, {2},
+and so on replaced by the arguments that follow the alias; the arguments that fill
+no placeholder are appended. For example, with the alias
+
+  rdp: search 'host.services.protocol=RDP and host.location.country={1}' --max-pages 5
+
+"censys rdp Germany -O json" runs
+
+  censys search 'host.services.protocol=RDP and host.location.country=Germany' --max-pages 5 -O json
+
+Built-in commands always take precedence over aliases with the same name, and an
+alias cannot run another alias.`
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	return c.AddSubCommands(
+		newListCommand(c.Context),
+		newAddCommand(c.Context),
+		newRemoveCommand(c.Context),
+	)
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	// Parent command shows help when run without subcommands
+	if err := cmd.Help(); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package alias
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func TestExpand(t *testing.T) {
+	root := &cobra.Command{Use: "censys"}
+	root.AddCommand(&cobra.Command{Use: "search", Run: func(*cobra.Command, []string) {}})
+	aliases := map[string]string{
+		"rdp":    `search 'host.services.protocol=RDP and host.location.country={1}' --max-pages 5`,
+		"search": "search --max-pages 100",
+		"broken": "search 'x",
+	}
+
+	got, err := Expand(root, aliases, []string{"rdp", "Germany", "-O", "json"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"search", "host.services.protocol=RDP and host.location.country=Germany", "--max-pages", "5", "-O", "json"}, got)
+
+	got, err = Expand(root, aliases, []string{"search", "q"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"search", "q"}, got, "built-in commands take precedence")
+
+	got, err = Expand(root, aliases, []string{"--debug", "rdp"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"--debug", "rdp"}, got)
+
+	got, err = Expand(root, aliases, []string{"missing"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"missing"}, got)
+
+	_, err = Expand(root, aliases, []string{"rdp"})
+	require.EqualError(t, err, `alias "rdp": needs 1 argument(s) for {1} to {1}, got 0`)
+	_, err = Expand(root, aliases, []string{"broken"})
+	require.EqualError(t, err, `alias "broken": unterminated single quote`)
+}
+
+func TestAliasCommands(t *testing.T) {
+	defer viper.Reset()
+	viper.Reset()
+	dir := t.TempDir()
+	cfg, cfgErr := config.New(dir)
+	require.NoError(t, cfgErr)
+	ctx := command.NewCommandContext(cfg, storemocks.NewMockStore(gomock.NewController(t)))
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		var stdout bytes.Buffer
+		formatter.Stdout = &stdout
+		formatter.Stderr = &bytes.Buffer{}
+		root, cerr := command.RootCommandToCobra(NewAliasCommand(ctx))
+		require.NoError(t, cerr)
+		root.SetArgs(args)
+		execErr := root.Execute()
+		return stdout.String(), execErr
+	}
+
+	out, err := run(t, "list")
+	require.NoError(t, err)
+	require.Contains(t, out, "No aliases")
+
+	out, err = run(t, "add", "rdp", "search 'host.services.protocol=RDP and host.location.country={1}'")
+	require.NoError(t, err)
+	require.Contains(t, out, "Added alias [rdp]")
+
+	out, err = run(t, "add", "--", "ssh", "search", "host.services.protocol=SSH and host.ip: {1}", "-O", "json")
+	require.NoError(t, err)
+	require.Contains(t, out, `Added alias [ssh]: search 'host.services.protocol=SSH and host.ip: {1}' -O json`)
+
+	out, err = run(t, "add", "rdp", "search 'host.services.protocol=RDP'")
+	require.NoError(t, err)
+	require.Contains(t, out, "Replaced alias [rdp]")
+
+	_, err = run(t, "add", "RDP", "search x")
+	require.ErrorContains(t, err, "invalid alias name")
+	_, err = run(t, "add", "list", "search x")
+	require.ErrorContains(t, err, `it would be shadowed by the built-in command "list"`)
+	_, err = run(t, "add", "bad", "search 'x")
+	require.ErrorContains(t, err, "unterminated single quote")
+
+	out, err = run(t, "list", "-O", "json")
+	require.NoError(t, err)
+	var entries []Entry
+	require.NoError(t, json.Unmarshal([]byte(out), &entries))
+	require.Equal(t, []Entry{
+		{Name: "rdp", Expansion: "search 'host.services.protocol=RDP'"},
+		{Name: "ssh", Expansion: "search 'host.services.protocol=SSH and host.ip: {1}' -O json"},
+	}, entries)
+
+	out, err = run(t, "remove", "rdp")
+	require.NoError(t, err)
+	require.Contains(t, out, "Removed alias [rdp]")
+	_, err = run(t, "remove", "rdp")
+	require.ErrorContains(t, err, `no alias named "rdp"`)
+
+	// the aliases are kept in the config file
+	viper.Reset()
+	reloaded, cfgErr := config.New(dir)
+	require.NoError(t, cfgErr)
+	require.Equal(t, map[string]string{"ssh": "search 'host.services.protocol=SSH and host.ip: {1}' -O json"}, reloaded.Aliases)
+}
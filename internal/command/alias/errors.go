@@ -0,0 +1,66 @@
+package alias
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// InvalidAliasError is returned when an alias cannot be added or expanded.
+type InvalidAliasError interface {
+	cenclierrors.CencliError
+}
+
+type invalidAliasError struct {
+	name string
+	err  error
+}
+
+var _ InvalidAliasError = &invalidAliasError{}
+
+func newInvalidAliasError(name string, err error) InvalidAliasError {
+	return &invalidAliasError{name: name, err: err}
+}
+
+func (e *invalidAliasError) Error() string {
+	return fmt.Sprintf("alias %q: %v", e.name, e.err)
+}
+
+func (e *invalidAliasError) Title() string {
+	return "Invalid Alias"
+}
+
+func (e *invalidAliasError) ShouldPrintUsage() bool {
+	return false
+}
+
+func (e *invalidAliasError) Unwrap() error {
+	return e.err
+}
+
+// AliasNotFoundError is returned when removing an alias that does not exist.
+type AliasNotFoundError interface {
+	cenclierrors.CencliError
+}
+
+type aliasNotFoundError struct {
+	name string
+}
+
+var _ AliasNotFoundError = &aliasNotFoundError{}
+
+func newAliasNotFoundError(name string) AliasNotFoundError {
+	return &aliasNotFoundError{name: name}
+}
+
+func (e *aliasNotFoundError) Error() string {
+	return fmt.Sprintf("no alias named %q; run \"censys alias list\" to see the aliases", e.name)
+}
+
+func (e *aliasNotFoundError) Title() string {
+	return "Alias Not Found"
+}
+
+func (e *aliasNotFoundError) ShouldPrintUsage() bool {
+	return false
+}
@@ -0,0 +1,40 @@
+package alias
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/pkg/alias"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// Expand replaces the alias that args start with, if any, with its command
+// line. Only the first argument is considered, and only when it is not a
+// flag and does not resolve to a built-in command. Args that do not start
+// with an alias are returned as they are.
+func Expand(root *cobra.Command, aliases map[string]string, args []string) ([]string, cenclierrors.CencliError) {
+	if len(args) == 0 || len(aliases) == 0 {
+		return args, nil
+	}
+	name := args[0]
+	expansion, ok := aliases[name]
+	if !ok || strings.HasPrefix(name, "-") || isBuiltin(root, name) {
+		return args, nil
+	}
+	expanded, err := alias.Expand(expansion, args[1:])
+	if err != nil {
+		return nil, newInvalidAliasError(name, err)
+	}
+	return expanded, nil
+}
+
+// isBuiltin returns true if name is a command of root. The help command is
+// added lazily by cobra, so it is not found by root.Find.
+func isBuiltin(root *cobra.Command, name string) bool {
+	if name == "help" {
+		return true
+	}
+	found, _, err := root.Find([]string{name})
+	return err == nil && found != root
+}
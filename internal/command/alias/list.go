@@ -0,0 +1,88 @@
+package alias
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+// Entry is an alias and the command line it runs.
+type Entry struct {
+	Name      string `json:"name" yaml:"name"`
+	Expansion string `json:"expansion" yaml:"expansion"`
+}
+
+type listCommand struct {
+	*command.BaseCommand
+	// result stored for rendering
+	entries []Entry
+}
+
+var _ command.Command = (*listCommand)(nil)
+
+func newListCommand(cmdContext *command.Context) *listCommand {
+	return &listCommand{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *listCommand) Use() string   { return "list" }
+func (c *listCommand) Short() string { return "List the aliases of the config file" }
+
+func (c *listCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *listCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *listCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *listCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *listCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	c.entries = []Entry{}
+	for name, expansion := range c.Config().Aliases {
+		c.entries = append(c.entries, Entry{Name: name, Expansion: expansion})
+	}
+	sort.Slice(c.entries, func(i, j int) bool { return c.entries[i].Name < c.entries[j].Name })
+	return c.PrintData(c, c.entries)
+}
+
+func (c *listCommand) RenderShort() cenclierrors.CencliError {
+	if len(c.entries) == 0 {
+		formatter.Println(formatter.Stdout, styles.GlobalStyles.Comment.Render(
+			`No aliases. Add one with "censys alias add <name> <command line>".`,
+		))
+		return nil
+	}
+	tbl := rawtable.New(
+		[]rawtable.Column[Entry]{
+			{
+				Title:      "Name",
+				String:     func(e Entry) string { return e.Name },
+				Style:      func(s string, _ Entry) string { return styles.GlobalStyles.Signature.Render(s) },
+				Priority:   2,
+				NoTruncate: true,
+			},
+			{
+				Title:    "Expansion",
+				String:   func(e Entry) string { return e.Expansion },
+				Priority: 1,
+			},
+		},
+		rawtable.WithHeaderStyle[Entry](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[Entry](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[Entry](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, tbl.Render(c.entries))
+	return nil
+}
@@ -0,0 +1,52 @@
+package alias
+
+import (
+	"maps"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+type removeCommand struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*removeCommand)(nil)
+
+func newRemoveCommand(cmdContext *command.Context) *removeCommand {
+	return &removeCommand{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *removeCommand) Use() string   { return "remove <name>" }
+func (c *removeCommand) Short() string { return "Remove an alias" }
+
+func (c *removeCommand) Args() command.PositionalArgs { return command.ExactArgs(1) }
+
+func (c *removeCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *removeCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *removeCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *removeCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	name := args[0]
+	if _, ok := c.Config().Aliases[name]; !ok {
+		return newAliasNotFoundError(name)
+	}
+	aliases := maps.Clone(c.Config().Aliases)
+	delete(aliases, name)
+	if err := c.Config().SaveAliases(aliases); err != nil {
+		return err
+	}
+	formatter.Printf(formatter.Stdout, "✅ Removed alias [%s]\n", name)
+	return nil
+}
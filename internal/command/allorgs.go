@@ -0,0 +1,245 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/store"
+)
+
+const (
+	// AllOrgsFlagName is the name of the flag that runs a command against
+	// every organization.
+	AllOrgsFlagName = "all-orgs"
+	// OrgIDKey and OrgNameKey are the fields that the organization of a result
+	// is attached under in data output, with --all-orgs.
+	OrgIDKey   = "org_id"
+	OrgNameKey = "org_name"
+	// allOrgsConcurrency is the number of organizations a command runs against at once.
+	allOrgsConcurrency = 4
+)
+
+// NewAllOrgsFlag adds --all-orgs to fs.
+func NewAllOrgsFlag(fs *pflag.FlagSet) flags.BoolFlag {
+	return flags.NewBoolFlag(fs, AllOrgsFlagName, "", false,
+		"run against every organization added with `config org-id add`, labeling each result with its organization")
+}
+
+// CheckAllOrgsConflicts returns an error if any of the named flags is set
+// alongside --all-orgs, or if streaming is enabled, as results are merged
+// once every organization is done.
+func CheckAllOrgsConflicts(cmd *cobra.Command, streaming bool, names ...string) cenclierrors.CencliError {
+	for _, name := range append([]string{"org-id"}, names...) {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			return flags.NewConflictingFlagsError(AllOrgsFlagName, name)
+		}
+	}
+	if streaming {
+		return flags.NewConflictingFlagsError(AllOrgsFlagName, "streaming")
+	}
+	return nil
+}
+
+// ParseAllOrgsFlag returns whether --all-orgs is set with flag, after
+// checking that it is not combined with the named flags (see
+// CheckAllOrgsConflicts).
+func (c *Context) ParseAllOrgsFlag(cmd *cobra.Command, flag flags.BoolFlag, conflicts ...string) (bool, cenclierrors.CencliError) {
+	allOrgs, err := flag.Value()
+	if err != nil || !allOrgs {
+		return false, err
+	}
+	if err := CheckAllOrgsConflicts(cmd, c.config.Streaming, conflicts...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Org is an organization a command runs against with --all-orgs.
+type Org struct {
+	ID   identifiers.OrganizationID
+	Name string
+}
+
+// String returns the name and ID of the organization, or the ID if it has no name.
+func (o Org) String() string {
+	if o.Name == "" {
+		return o.ID.String()
+	}
+	return fmt.Sprintf("%s (%s)", o.Name, o.ID)
+}
+
+// Attach returns item as a map with the ID and name of the organization set.
+func (o Org) Attach(item any) any {
+	obj, ok := toObject(item)
+	if !ok {
+		return item
+	}
+	obj[OrgIDKey] = o.ID.String()
+	if o.Name != "" {
+		obj[OrgNameKey] = o.Name
+	}
+	return obj
+}
+
+// AccessibleOrgs returns the organizations whose IDs were added with
+// `config org-id add`, as the API has no way to list them. Each is named
+// after its details from the organizations service, or the description it
+// was added with if they cannot be fetched.
+func (c *Context) AccessibleOrgs(ctx context.Context) ([]Org, cenclierrors.CencliError) {
+	if c.store == nil {
+		return nil, newNoOrgsError()
+	}
+	values, err := c.store.GetValuesForGlobal(ctx, config.OrgIDGlobalName)
+	if err != nil && !errors.Is(err, store.ErrGlobalNotFound) {
+		return nil, cenclierrors.NewCencliError(err)
+	}
+	var orgs []Org
+	seen := make(map[uuid.UUID]bool)
+	for _, v := range values {
+		id, parseErr := uuid.Parse(v.Value)
+		if parseErr != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		orgs = append(orgs, Org{ID: identifiers.NewOrganizationID(id), Name: v.Description})
+	}
+	if len(orgs) == 0 {
+		return nil, newNoOrgsError()
+	}
+	orgSvc, svcErr := c.OrganizationsService()
+	if svcErr != nil {
+		return orgs, nil
+	}
+	names := RunForOrgs(ctx, orgs, func(ctx context.Context, org Org) (string, cenclierrors.CencliError) {
+		details, err := orgSvc.GetOrganizationDetails(ctx, org.ID)
+		if err != nil {
+			return "", err
+		}
+		return details.Data.Name, nil
+	})
+	for i, res := range names {
+		if res.Err == nil && res.Result != "" {
+			orgs[i].Name = res.Result
+		} else if res.Err != nil {
+			c.logger.Debug("failed to fetch organization details", "orgID", res.Org.ID.String(), "error", res.Err)
+		}
+	}
+	return orgs, nil
+}
+
+// OrgResult is the result of running a command against one organization.
+type OrgResult[T any] struct {
+	Org    Org
+	Result T
+	// Err is the error the organization failed with, if any.
+	Err cenclierrors.CencliError
+}
+
+// RunForOrgs calls fn for each organization, at most allOrgsConcurrency at
+// once. An organization failing does not stop the others: its error is kept
+// in its result. Results are in the order of orgs.
+func RunForOrgs[T any](
+	ctx context.Context,
+	orgs []Org,
+	fn func(ctx context.Context, org Org) (T, cenclierrors.CencliError),
+) []OrgResult[T] {
+	results := make([]OrgResult[T], len(orgs))
+	sem := make(chan struct{}, allOrgsConcurrency)
+	var wg sync.WaitGroup
+	for i, org := range orgs {
+		results[i].Org = org
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i].Err = cenclierrors.ParseContextError(ctx.Err())
+				return
+			}
+			defer func() { <-sem }()
+			results[i].Result, results[i].Err = fn(ctx, org)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// ReportOrgErrors prints the error of each organization that failed to
+// stderr. It returns an error if the command was interrupted or every
+// organization failed, so that partial results are still printed.
+func ReportOrgErrors[T any](results []OrgResult[T]) cenclierrors.CencliError {
+	var failed int
+	var first cenclierrors.CencliError
+	for _, res := range results {
+		if res.Err == nil {
+			continue
+		}
+		if cenclierrors.IsInterrupted(res.Err) {
+			return res.Err
+		}
+		failed++
+		if first == nil {
+			first = res.Err
+		}
+		formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render(
+			fmt.Sprintf("Organization %s failed: %v", res.Org, res.Err)))
+	}
+	if failed > 0 && failed == len(results) {
+		return newAllOrgsFailedError(failed, first)
+	}
+	return nil
+}
+
+// NoOrgsError is returned with --all-orgs when no organization IDs were added.
+type NoOrgsError interface{ cenclierrors.CencliError }
+
+type noOrgsError struct{}
+
+var _ NoOrgsError = &noOrgsError{}
+
+func newNoOrgsError() NoOrgsError { return &noOrgsError{} }
+
+func (e *noOrgsError) Error() string {
+	return "no organization IDs to run against; add them with `censys config org-id add`"
+}
+
+func (e *noOrgsError) Title() string { return "No Organizations" }
+
+func (e *noOrgsError) ShouldPrintUsage() bool { return false }
+
+// AllOrgsFailedError is returned with --all-orgs when every organization failed.
+type AllOrgsFailedError interface{ cenclierrors.CencliError }
+
+type allOrgsFailedError struct {
+	count int
+	first cenclierrors.CencliError
+}
+
+var _ AllOrgsFailedError = &allOrgsFailedError{}
+
+func newAllOrgsFailedError(count int, first cenclierrors.CencliError) AllOrgsFailedError {
+	return &allOrgsFailedError{count: count, first: first}
+}
+
+func (e *allOrgsFailedError) Error() string {
+	return fmt.Sprintf("all %d organizations failed, the first with: %v", e.count, e.first)
+}
+
+func (e *allOrgsFailedError) Title() string { return "All Organizations Failed" }
+
+func (e *allOrgsFailedError) ShouldPrintUsage() bool { return false }
+
+func (e *allOrgsFailedError) Unwrap() error { return e.first }
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/input"
+)
+
+// InputRecords returns the records of an input file flag (a file, or - for
+// stdin): one asset or NDJSON object per line, or a JSON array.
+func InputRecords(cmd *cobra.Command, file flags.FileFlag) ([]input.Record, cenclierrors.CencliError) {
+	// blank lines are kept so that errors report the line numbers of the file
+	lines, err := file.Lines(cmd, input.WithLeaveBlanks())
+	if err != nil {
+		return nil, err
+	}
+	return input.ParseRecords(lines)
+}
+
+// RawAssets returns the raw asset strings of an input file flag when it is
+// set, and otherwise those of the first positional argument, which may list
+// several assets separated by commas or whitespace.
+func RawAssets(cmd *cobra.Command, file flags.FileFlag, args []string) ([]string, cenclierrors.CencliError) {
+	if file.IsSet() {
+		records, err := InputRecords(cmd, file)
+		if err != nil {
+			return nil, err
+		}
+		return input.RecordValues(records), nil
+	}
+	if len(args) == 0 {
+		return nil, assets.NewNoAssetsError()
+	}
+	return input.SplitString(args[0]), nil
+}
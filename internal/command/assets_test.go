@@ -0,0 +1,61 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/flags"
+)
+
+func TestRawAssets(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected []string
+		errMsg   string
+	}{
+		{
+			name:     "positional argument",
+			args:     []string{"1.1.1.1, 8.8.8.8"},
+			expected: []string{"1.1.1.1", "8.8.8.8"},
+		},
+		{
+			name:     "input file",
+			args:     []string{"--input-file", "-"},
+			stdin:    "1.1.1.1\n\n{\"asset\": \"8.8.8.8\"}\n",
+			expected: []string{"1.1.1.1", "8.8.8.8"},
+		},
+		{
+			name:   "input file error reports the line of the file",
+			args:   []string{"--input-file", "-"},
+			stdin:  "1.1.1.1\n\n{\"asset\": \n",
+			errMsg: "line 3",
+		},
+		{
+			name:   "no assets",
+			errMsg: "at least one asset",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			file := flags.NewFileFlag(cmd.Flags(), false, "input-file", "", "input file")
+			require.NoError(t, cmd.Flags().Parse(tc.args))
+			cmd.SetIn(strings.NewReader(tc.stdin))
+
+			raw, err := RawAssets(cmd, file, cmd.Flags().Args())
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, strings.ToLower(err.Error()), tc.errMsg)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tc.expected, raw)
+		})
+	}
+}
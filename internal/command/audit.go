@@ -0,0 +1,76 @@
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/pkg/audit"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+)
+
+// auditFileName is the audit log in the data directory, unless audit.file is set.
+const auditFileName = "audit.jsonl"
+
+// AuditLogPath returns the path of the audit log, or "" if there is none.
+func (c *Context) AuditLogPath() string {
+	if c.config.Audit.File != "" {
+		return c.config.Audit.File
+	}
+	if c.dirs.Data == "" {
+		return ""
+	}
+	return filepath.Join(c.dirs.Data, auditFileName)
+}
+
+// startAudit wraps the client so that every API call of the command is
+// appended to the audit log, with audit.enabled. It wraps the client first,
+// so that results answered from the memo and requests planned by a dry run,
+// which are never sent, are not recorded.
+func (c *Context) startAudit(cobraCmd *cobra.Command) cenclierrors.CencliError {
+	if !c.config.Audit.Enabled || c.censysClient == nil || c.auditing {
+		return nil
+	}
+	path := c.AuditLogPath()
+	if path == "" {
+		return newAuditLogError(path, fmt.Errorf("audit.file is not set"))
+	}
+	log, err := audit.Open(path)
+	if err != nil {
+		return newAuditLogError(path, err)
+	}
+	command := strings.Join(strings.Fields(cobraCmd.CommandPath())[1:], " ")
+	c.censysClient = client.NewAuditClient(c.censysClient, log, command)
+	c.auditing = true
+	return nil
+}
+
+// AuditLogError is returned when the audit log cannot be opened.
+type AuditLogError interface{ cenclierrors.CencliError }
+
+type auditLogError struct {
+	path string
+	err  error
+}
+
+var _ AuditLogError = &auditLogError{}
+
+func newAuditLogError(path string, err error) AuditLogError {
+	return &auditLogError{path: path, err: err}
+}
+
+func (e *auditLogError) Error() string {
+	if e.path == "" {
+		return fmt.Sprintf("failed to open the audit log: %v", e.err)
+	}
+	return fmt.Sprintf("failed to open the audit log %s: %v", e.path, e.err)
+}
+
+func (e *auditLogError) Title() string { return "Audit Log Unavailable" }
+
+func (e *auditLogError) ShouldPrintUsage() bool { return false }
+
+func (e *auditLogError) Unwrap() error { return e.err }
@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// Command is the parent audit command that groups audit log subcommands.
+type Command struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*Command)(nil)
+
+// NewAuditCommand creates a new audit command with all subcommands.
+func NewAuditCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return "audit"
+}
+
+func (c *Command) Short() string {
+	return "Show and verify the audit log of API calls"
+}
+
+func (c *Command) Long() string {
+	return `Show and verify the audit log of API calls.
+
+With audit.enabled set in the config, every API call is appended to the audit log
+(audit.file, or audit.jsonl in the data directory): its time, command, operation,
+endpoint, organization, the SHA-256 of its query or asset IDs, and its status. Each
+entry holds the hash of the entry before it, so that changing, removing, or
+inserting an entry breaks the chain, which "censys audit verify" detects.`
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	return c.AddSubCommands(
+		newShowCommand(c.Context),
+		newVerifyCommand(c.Context),
+	)
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	// Parent command shows help when run without subcommands
+	if err := cmd.Help(); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/audit"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func TestAuditCommands(t *testing.T) {
+	defer viper.Reset()
+	viper.Reset()
+	dir := t.TempDir()
+	cfg, cfgErr := config.New(dir)
+	require.NoError(t, cfgErr)
+	path := filepath.Join(dir, "audit.jsonl")
+	viper.Set("audit.file", path)
+	ctx := command.NewCommandContext(cfg, storemocks.NewMockStore(gomock.NewController(t)))
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		var stdout bytes.Buffer
+		formatter.Stdout = &stdout
+		formatter.Stderr = &bytes.Buffer{}
+		root, cerr := command.RootCommandToCobra(NewAuditCommand(ctx))
+		require.NoError(t, cerr)
+		root.SetArgs(args)
+		execErr := root.Execute()
+		return stdout.String(), execErr
+	}
+
+	_, err := run(t, "show")
+	require.ErrorContains(t, err, "there is no audit log at "+path)
+	_, err = run(t, "verify")
+	require.ErrorContains(t, err, "there is no audit log at "+path)
+
+	log, err := audit.Open(path)
+	require.NoError(t, err)
+	for _, op := range []string{"search", "aggregate", "get_hosts"} {
+		require.NoError(t, log.Append(audit.Entry{Command: op, Operation: op, Status: 200}))
+	}
+
+	out, err := run(t, "show")
+	require.NoError(t, err)
+	require.Contains(t, out, "aggregate")
+	require.Contains(t, out, "get_hosts")
+
+	out, err = run(t, "show", "-n", "1", "-O", "json")
+	require.NoError(t, err)
+	var entries []audit.Entry
+	require.NoError(t, json.Unmarshal([]byte(out), &entries))
+	require.Len(t, entries, 1)
+	require.Equal(t, "get_hosts", entries[0].Operation)
+
+	out, err = run(t, "verify")
+	require.NoError(t, err)
+	require.Contains(t, out, "Verified 3 entries of "+path)
+	require.Contains(t, out, entries[0].Hash)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(data), `"aggregate"`, `"search"`, 1)), 0o600))
+	_, err = run(t, "verify")
+	require.EqualError(t, err, "the audit log "+path+" was modified at line 2: its hash does not match its content")
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// NoAuditLogError is returned when there is no audit log to show or verify.
+type NoAuditLogError interface{ cenclierrors.CencliError }
+
+type noAuditLogError struct {
+	path string
+}
+
+var _ NoAuditLogError = &noAuditLogError{}
+
+func newNoAuditLogError(path string) NoAuditLogError {
+	return &noAuditLogError{path: path}
+}
+
+func (e *noAuditLogError) Error() string {
+	if e.path == "" {
+		return "there is no audit log; set audit.file and audit.enabled in the config to record API calls"
+	}
+	return fmt.Sprintf("there is no audit log at %s; set audit.enabled in the config to record API calls", e.path)
+}
+
+func (e *noAuditLogError) Title() string { return "No Audit Log" }
+
+func (e *noAuditLogError) ShouldPrintUsage() bool { return false }
+
+// ReadAuditLogError is returned when the audit log cannot be read.
+type ReadAuditLogError interface{ cenclierrors.CencliError }
+
+type readAuditLogError struct {
+	path string
+	err  error
+}
+
+var _ ReadAuditLogError = &readAuditLogError{}
+
+func newReadAuditLogError(path string, err error) ReadAuditLogError {
+	return &readAuditLogError{path: path, err: err}
+}
+
+func (e *readAuditLogError) Error() string {
+	return fmt.Sprintf("failed to read the audit log %s: %v", e.path, e.err)
+}
+
+func (e *readAuditLogError) Title() string { return "Invalid Audit Log" }
+
+func (e *readAuditLogError) ShouldPrintUsage() bool { return false }
+
+func (e *readAuditLogError) Unwrap() error { return e.err }
+
+// TamperedAuditLogError is returned by verify when the chain of the audit
+// log is broken.
+type TamperedAuditLogError interface{ cenclierrors.CencliError }
+
+type tamperedAuditLogError struct {
+	path string
+	err  error
+}
+
+var _ TamperedAuditLogError = &tamperedAuditLogError{}
+
+func newTamperedAuditLogError(path string, err error) TamperedAuditLogError {
+	return &tamperedAuditLogError{path: path, err: err}
+}
+
+func (e *tamperedAuditLogError) Error() string {
+	return fmt.Sprintf("the audit log %s was modified at %v", e.path, e.err)
+}
+
+func (e *tamperedAuditLogError) Title() string { return "Audit Log Tampered" }
+
+func (e *tamperedAuditLogError) ShouldPrintUsage() bool { return false }
+
+func (e *tamperedAuditLogError) Unwrap() error { return e.err }
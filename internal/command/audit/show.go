@@ -0,0 +1,195 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/audit"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+const defaultShowLimit = 20
+
+type showCommand struct {
+	*command.BaseCommand
+	flags showCommandFlags
+	// state - populated by PreRun
+	limit int64
+	// result stored for rendering
+	entries []audit.Entry
+}
+
+type showCommandFlags struct {
+	limit flags.IntegerFlag
+}
+
+var _ command.Command = (*showCommand)(nil)
+
+func newShowCommand(cmdContext *command.Context) *showCommand {
+	return &showCommand{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *showCommand) Use() string   { return "show" }
+func (c *showCommand) Short() string { return "Show the last entries of the audit log" }
+func (c *showCommand) Long() string {
+	return `Show the last entries of the audit log, oldest first.
+
+Use --output-format json to see every field of the entries, including their hashes.`
+}
+
+func (c *showCommand) Examples() []string {
+	return []string{
+		"",
+		"--limit 0 -O json | jq -r 'select(.status >= 400)'",
+	}
+}
+
+func (c *showCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *showCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *showCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *showCommand) Init() error {
+	c.flags.limit = flags.NewIntegerFlag(c.Flags(), false, "limit", "n", mo.Some(int64(defaultShowLimit)),
+		"number of entries to show, the most recent ones (0 for all)", mo.Some(int64(0)), mo.None[int64]())
+	return nil
+}
+
+func (c *showCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	limit, err := c.flags.limit.Value()
+	if err != nil {
+		return err
+	}
+	c.limit = limit.OrElse(defaultShowLimit)
+	return nil
+}
+
+func (c *showCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	path := c.AuditLogPath()
+	entries, err := readLog(path)
+	if err != nil {
+		return err
+	}
+	if c.limit > 0 && int64(len(entries)) > c.limit {
+		entries = entries[int64(len(entries))-c.limit:]
+	}
+	c.entries = entries
+	return c.PrintData(c, c.entries)
+}
+
+// readLog returns the entries of the audit log at path.
+func readLog(path string) ([]audit.Entry, cenclierrors.CencliError) {
+	if path == "" {
+		return nil, newNoAuditLogError(path)
+	}
+	entries, err := audit.Read(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, newNoAuditLogError(path)
+	}
+	if err != nil {
+		return nil, newReadAuditLogError(path, err)
+	}
+	if entries == nil {
+		entries = []audit.Entry{}
+	}
+	return entries, nil
+}
+
+func (c *showCommand) RenderShort() cenclierrors.CencliError {
+	if len(c.entries) == 0 {
+		formatter.Println(formatter.Stdout, styles.GlobalStyles.Comment.Render("The audit log is empty."))
+		return nil
+	}
+	tbl := rawtable.New(
+		[]rawtable.Column[audit.Entry]{
+			{
+				Title:      "#",
+				String:     func(e audit.Entry) string { return strconv.FormatUint(e.Seq, 10) },
+				Style:      func(s string, _ audit.Entry) string { return styles.GlobalStyles.Comment.Render(s) },
+				AlignRight: true,
+				Priority:   3,
+			},
+			{
+				Title: "Time",
+				String: func(e audit.Entry) string {
+					return formatter.FormatLongTime(e.Time)
+				},
+				Priority:   6,
+				NoTruncate: true,
+			},
+			{
+				Title:    "Command",
+				String:   func(e audit.Entry) string { return e.Command },
+				Style:    func(s string, _ audit.Entry) string { return styles.GlobalStyles.Signature.Render(s) },
+				Priority: 4,
+			},
+			{
+				Title:    "Operation",
+				String:   func(e audit.Entry) string { return e.Operation },
+				Priority: 5,
+			},
+			{
+				Title: "Org",
+				String: func(e audit.Entry) string {
+					if e.OrgID == "" {
+						return "-"
+					}
+					return e.OrgID
+				},
+				Priority: 1,
+			},
+			{
+				Title:      "Status",
+				String:     statusSummary,
+				Style:      styleStatus,
+				Priority:   6,
+				NoTruncate: true,
+			},
+			{
+				Title:      "Duration",
+				String:     func(e audit.Entry) string { return fmt.Sprintf("%dms", e.DurationMS) },
+				AlignRight: true,
+				Priority:   2,
+			},
+		},
+		rawtable.WithHeaderStyle[audit.Entry](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[audit.Entry](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[audit.Entry](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, tbl.Render(c.entries))
+	return nil
+}
+
+// statusSummary is the HTTP status of a call, or "error" for a call that
+// failed without one.
+func statusSummary(e audit.Entry) string {
+	switch {
+	case e.Status > 0:
+		return strconv.Itoa(e.Status)
+	case e.Error != "":
+		return "error"
+	}
+	return "-"
+}
+
+func styleStatus(s string, e audit.Entry) string {
+	if e.Error != "" || e.Status >= 400 {
+		return styles.GlobalStyles.Danger.Render(s)
+	}
+	return styles.GlobalStyles.Primary.Render(s)
+}
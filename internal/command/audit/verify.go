@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"errors"
+	"io/fs"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/audit"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+type verifyCommand struct {
+	*command.BaseCommand
+	// result stored for rendering
+	result audit.Verification
+}
+
+var _ command.Command = (*verifyCommand)(nil)
+
+func newVerifyCommand(cmdContext *command.Context) *verifyCommand {
+	return &verifyCommand{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *verifyCommand) Use() string   { return "verify" }
+func (c *verifyCommand) Short() string { return "Check that the audit log was not modified" }
+func (c *verifyCommand) Long() string {
+	return `Check the hash chain of the audit log, and fail at the first entry that was changed,
+removed, or inserted.
+
+Entries removed from the end of the log leave no trace in the chain. To detect that too,
+record the head of the chain that this command prints, e.g. in a ticket or another
+system, and check that it is still in the log later:
+
+  censys audit show --limit 0 -O json | jq -r .hash | grep <head>`
+}
+
+func (c *verifyCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *verifyCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *verifyCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *verifyCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *verifyCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	path := c.AuditLogPath()
+	if path == "" {
+		return newNoAuditLogError(path)
+	}
+	res, err := audit.Verify(path)
+	if err != nil {
+		var broken *audit.BrokenChainError
+		switch {
+		case errors.As(err, &broken):
+			return newTamperedAuditLogError(path, err)
+		case errors.Is(err, fs.ErrNotExist):
+			return newNoAuditLogError(path)
+		}
+		return newReadAuditLogError(path, err)
+	}
+	c.result = res
+	return c.PrintData(c, c.result)
+}
+
+func (c *verifyCommand) RenderShort() cenclierrors.CencliError {
+	if c.result.Entries == 0 {
+		formatter.Println(formatter.Stdout, styles.GlobalStyles.Comment.Render("The audit log is empty."))
+		return nil
+	}
+	formatter.Printf(formatter.Stdout, "✅ Verified %d entries of %s\n", c.result.Entries, c.AuditLogPath())
+	formatter.Printf(formatter.Stdout, "%s %s\n",
+		styles.GlobalStyles.Secondary.Render("Head:"), styles.GlobalStyles.Signature.Render(c.result.Head))
+	return nil
+}
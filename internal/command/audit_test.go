@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/audit"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+)
+
+func TestStartAudit(t *testing.T) {
+	defer viper.Reset()
+	ctx := context.Background()
+
+	newContext := func(t *testing.T, enabled bool) (*Context, *mocks.MockClient, string) {
+		t.Helper()
+		viper.Reset()
+		dir := t.TempDir()
+		cfg, err := config.New(dir)
+		require.NoError(t, err)
+		cfg.Audit.Enabled = enabled
+		cfg.Audit.File = filepath.Join(dir, "logs", "audit.jsonl")
+		inner := mocks.NewMockClient(gomock.NewController(t))
+		c := NewCommandContext(cfg, nil)
+		c.SetCensysClient(inner)
+		return c, inner, cfg.Audit.File
+	}
+	root := &cobra.Command{Use: "censys"}
+	cmd := &cobra.Command{Use: "credits"}
+	root.AddCommand(cmd)
+
+	t.Run("disabled", func(t *testing.T) {
+		c, inner, _ := newContext(t, false)
+		require.NoError(t, c.startAudit(cmd))
+		require.Same(t, inner, c.censysClient)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		c, inner, path := newContext(t, true)
+		inner.EXPECT().GetUserCreditDetails(ctx).Return(client.Result[components.UserCredits]{}, nil)
+		require.NoError(t, c.startAudit(cmd))
+		require.NoError(t, c.startAudit(cmd), "the client is wrapped once")
+
+		_, err := c.censysClient.GetUserCreditDetails(ctx)
+		require.NoError(t, err)
+		entries, readErr := audit.Read(path)
+		require.NoError(t, readErr)
+		require.Len(t, entries, 1)
+		require.Equal(t, "credits", entries[0].Command)
+	})
+}
@@ -0,0 +1,213 @@
+package banners
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+const cmdName = "banners"
+
+type Command struct {
+	*command.BaseCommand
+	// services the command uses
+	viewSvc view.Service
+	// flags the command uses
+	flags bannersCommandFlags
+	// state - populated by PreRun (through flags, args, etc.)
+	hostIDs []assets.HostID
+	orgID   mo.Option[identifiers.OrganizationID]
+	atTime  mo.Option[time.Time]
+	// result stored for rendering
+	meta         *responsemeta.ResponseMeta
+	banners      []banner
+	partialError cenclierrors.CencliError
+}
+
+type bannersCommandFlags struct {
+	orgID     flags.OrgIDFlag
+	inputFile flags.FileFlag
+	atTime    flags.TimestampFlag
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewBannersCommand(cmdContext *command.Context) *Command {
+	return &Command{
+		BaseCommand: command.NewBaseCommand(cmdContext),
+	}
+}
+
+func (c *Command) Use() string {
+	return fmt.Sprintf("%s <host...>", cmdName)
+}
+
+func (c *Command) Short() string {
+	return "Print the service banners of hosts"
+}
+
+func (c *Command) Long() string {
+	return `Print the service banners of hosts.
+
+Fetches the hosts and prints only their service banners, one line per banner line,
+prefixed with the service's ip:port/transport, protocol, and banner SHA-256. Banners
+that are only available hex-encoded are decoded, and control characters are escaped
+so that every line of output can be searched with grep.
+
+Use --output-format json for structured output, or --streaming for one JSON object
+per banner.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"8.8.8.8",
+		"8.8.8.8,1.1.1.1 | grep -i openssh",
+		"--input-file hosts.txt",
+		"--input-file hosts.txt -S # one JSON object per banner",
+		"8.8.8.8 --at-time 2025-09-15T14:30:00Z",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.RangeArgs(0, 1)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *Command) SupportsStreaming() bool {
+	return true
+}
+
+func (c *Command) Init() error {
+	c.flags.inputFile = flags.NewFileFlag(c.Flags(), false, "input-file", "i", "file to read the hosts from, one per line as plain text or JSON objects with an \"asset\" field. Overrides the positional argument.")
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.atTime = flags.NewTimestampFlag(c.Flags(), false, "at-time", "", mo.None[time.Time](), "print the banners as of this time")
+	c.flags.atTime.AddAlias("at", "a", "Alias for --at-time")
+	return nil
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.atTime, err = c.flags.atTime.Value(c.Config().DefaultTZ, c.Now())
+	if err != nil {
+		return err
+	}
+	c.orgID, err = c.flags.orgID.Value()
+	if err != nil {
+		return err
+	}
+	rawAssets, err := command.RawAssets(cmd, c.flags.inputFile, args)
+	if err != nil {
+		return err
+	}
+	classifier := assets.NewAssetClassifier(rawAssets...)
+	assetType, err := classifier.AssetType()
+	if err != nil {
+		return err
+	}
+	if assetType != assets.AssetTypeHost {
+		return newNotHostError(assetType)
+	}
+	c.hostIDs = classifier.HostIDs()
+
+	svc, err := c.ViewService()
+	if err != nil {
+		return err
+	}
+	c.viewSvc = svc
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(cmdName).With(
+		"orgID_set", c.orgID.IsPresent(),
+		"count", len(c.hostIDs),
+	)
+
+	// Set up streaming output (no-op for non-streaming formats)
+	ctx, stopStreaming := c.WithStreamingOutput(cmd.Context(), logger)
+	defer stopStreaming(nil)
+	ctx = withBannerStreaming(ctx)
+
+	err := c.WithProgress(
+		ctx,
+		logger,
+		"Fetching hosts...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			result, fetchErr := c.viewSvc.GetHosts(pctx, c.orgID, c.hostIDs, c.atTime)
+			if fetchErr != nil {
+				return fetchErr
+			}
+			c.meta = result.Meta
+			c.partialError = result.PartialError
+			c.banners = []banner{}
+			for _, host := range result.Hosts {
+				c.banners = append(c.banners, extractBanners(host)...)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		logger.Debug("fetch failed", "error", err)
+		return err
+	}
+
+	c.PrintAppResponseMeta(c.meta)
+
+	if renderErr := c.PrintData(c, c.banners); renderErr != nil {
+		return renderErr
+	}
+
+	if c.partialError != nil {
+		formatter.PrintError(c.partialError, cmd)
+	}
+	return nil
+}
+
+// RenderShort prints one tab-separated line per banner line:
+// ip:port/transport, protocol, banner SHA-256, and the banner line.
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	var sb strings.Builder
+	for _, b := range c.banners {
+		prefix := strings.Join([]string{
+			styles.GlobalStyles.Signature.Render(b.location()),
+			styles.GlobalStyles.Primary.Render(orDash(b.Protocol)),
+			styles.GlobalStyles.Comment.Render(orDash(b.BannerHashSha256)),
+		}, "\t")
+		for _, line := range b.lines() {
+			sb.WriteString(prefix)
+			sb.WriteString("\t")
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+	formatter.Printf(formatter.Stdout, "%s", sb.String())
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,168 @@
+package banners
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	viewmocks "github.com/censys/cencli/gen/app/view/mocks"
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func testHost() *assets.Host {
+	tcp := components.ServiceTransportProtocolTCP
+	return &assets.Host{Host: components.Host{
+		IP: ptr("10.0.0.1"),
+		Services: []components.Service{
+			{
+				Port:              ptr(22),
+				Protocol:          ptr("SSH"),
+				TransportProtocol: &tcp,
+				Banner:            ptr("SSH-2.0-OpenSSH_8.9p1\r\n"),
+				BannerHashSha256:  ptr("abc123"),
+			},
+			{
+				Port:              ptr(80),
+				Protocol:          ptr("HTTP"),
+				TransportProtocol: &tcp,
+				// "HTTP/1.1 200 OK\r\nServer: nginx\x01"
+				BannerHex: ptr("485454502f312e3120323030204f4b0d0a5365727665723a206e67696e7801"),
+			},
+			{
+				Port:     ptr(443),
+				Protocol: ptr("HTTP"),
+			},
+		},
+	}}
+}
+
+func TestBannersCommand(t *testing.T) {
+	testCases := []struct {
+		name    string
+		service func(ctrl *gomock.Controller) view.Service
+		args    []string
+		assert  func(t *testing.T, stdout string, err error)
+	}{
+		{
+			name: "text output",
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				hostID, _ := assets.NewHostID("10.0.0.1")
+				ms.EXPECT().GetHosts(gomock.Any(), mo.None[identifiers.OrganizationID](), []assets.HostID{hostID}, mo.None[time.Time]()).
+					Return(view.HostsResult{Hosts: []*assets.Host{testHost()}}, nil)
+				return ms
+			},
+			args: []string{"10.0.0.1"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, strings.Join([]string{
+					"10.0.0.1:22/tcp\tSSH\tabc123\tSSH-2.0-OpenSSH_8.9p1",
+					"10.0.0.1:80/tcp\tHTTP\t-\tHTTP/1.1 200 OK",
+					"10.0.0.1:80/tcp\tHTTP\t-\tServer: nginx\\x01",
+				}, "\n")+"\n", stdout)
+			},
+		},
+		{
+			name: "json output",
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(view.HostsResult{Hosts: []*assets.Host{testHost()}}, nil)
+				return ms
+			},
+			args: []string{"10.0.0.1", "-O", "json"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				var out []banner
+				require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+				require.Len(t, out, 2)
+				require.Equal(t, banner{
+					IP:                "10.0.0.1",
+					Port:              22,
+					TransportProtocol: "tcp",
+					Protocol:          "SSH",
+					Banner:            "SSH-2.0-OpenSSH_8.9p1\r\n",
+					BannerHashSha256:  "abc123",
+				}, out[0])
+				require.Equal(t, "HTTP/1.1 200 OK\r\nServer: nginx\x01", out[1].Banner)
+			},
+		},
+		{
+			name: "streams one object per banner",
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, _ mo.Option[identifiers.OrganizationID], _ []assets.HostID, _ mo.Option[time.Time]) (view.HostsResult, cenclierrors.CencliError) {
+						require.NoError(t, streaming.Emit(ctx, testHost()))
+						return view.HostsResult{}, nil
+					})
+				return ms
+			},
+			args: []string{"10.0.0.1", "--streaming"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				lines := strings.Split(strings.TrimSpace(stdout), "\n")
+				require.Len(t, lines, 2)
+				var first banner
+				require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+				require.Equal(t, 22, first.Port)
+			},
+		},
+		{
+			name: "rejects non-host assets",
+			service: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			args: []string{"platform.censys.io:443"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var notHost NotHostError
+				require.ErrorAs(t, err, &notHost)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithViewService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewBannersCommand(cmdContext))
+			require.NoError(t, err)
+			// banners defines its own --output-format, so only bind the streaming global flag
+			rootCmd.PersistentFlags().BoolP(config.StreamingFlagName, "S", false, "")
+			require.NoError(t, viper.BindPFlag(config.StreamingFlagName, rootCmd.PersistentFlags().Lookup(config.StreamingFlagName)))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), cmdErr)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
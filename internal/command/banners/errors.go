@@ -0,0 +1,31 @@
+package banners
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// NotHostError is returned when the assets are not hosts. Only hosts have service banners.
+type NotHostError interface {
+	cenclierrors.CencliError
+}
+
+type notHostError struct {
+	assetType assets.AssetType
+}
+
+var _ NotHostError = &notHostError{}
+
+func newNotHostError(assetType assets.AssetType) NotHostError {
+	return &notHostError{assetType: assetType}
+}
+
+func (e *notHostError) Error() string {
+	return fmt.Sprintf("banners only supports hosts, got %s assets", e.assetType)
+}
+
+func (e *notHostError) Title() string { return "Unsupported Asset Type" }
+
+func (e *notHostError) ShouldPrintUsage() bool { return true }
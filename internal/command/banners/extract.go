@@ -0,0 +1,125 @@
+package banners
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// banner is a single service banner and the service it was read from.
+type banner struct {
+	IP                string `json:"ip"`
+	Port              int    `json:"port"`
+	TransportProtocol string `json:"transport_protocol,omitempty"`
+	Protocol          string `json:"protocol,omitempty"`
+	Banner            string `json:"banner"`
+	BannerHashSha256  string `json:"banner_hash_sha256,omitempty"`
+	ScanTime          string `json:"scan_time,omitempty"`
+}
+
+// extractBanners returns the banners of every service of host that has one.
+// Services that only carry the hex-encoded banner are decoded.
+func extractBanners(host *assets.Host) []banner {
+	if host == nil {
+		return nil
+	}
+	ip := host.GetIP()
+	var res []banner
+	for _, svc := range host.GetServices() {
+		text, ok := assets.ServiceBanner(svc)
+		if !ok {
+			continue
+		}
+		b := banner{
+			Port:             deref(svc.GetPort()),
+			Protocol:         deref(svc.GetProtocol()),
+			Banner:           text,
+			BannerHashSha256: deref(svc.GetBannerHashSha256()),
+			ScanTime:         deref(svc.GetScanTime()),
+		}
+		if ip != nil {
+			b.IP = *ip
+		}
+		if transport := svc.GetTransportProtocol(); transport != nil {
+			b.TransportProtocol = string(*transport)
+		}
+		res = append(res, b)
+	}
+	return res
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// location returns the ip:port/transport label of the service.
+func (b banner) location() string {
+	loc := fmt.Sprintf("%s:%d", b.IP, b.Port)
+	if b.TransportProtocol != "" {
+		loc += "/" + strings.ToLower(b.TransportProtocol)
+	}
+	return loc
+}
+
+// lines splits the banner into printable lines. Control characters other than
+// tabs are escaped so that every banner line stays on one output line.
+func (b banner) lines() []string {
+	raw := strings.Split(strings.TrimRight(b.Banner, "\r\n"), "\n")
+	out := make([]string, len(raw))
+	for i, line := range raw {
+		out[i] = escapeControl(strings.TrimSuffix(line, "\r"))
+	}
+	return out
+}
+
+func escapeControl(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if r != '\t' && (unicode.IsControl(r) || r == unicode.ReplacementChar) {
+			fmt.Fprintf(&sb, "\\x%02x", r)
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// bannerEmitter streams the banners of each streamed host instead of the host.
+type bannerEmitter struct {
+	inner streaming.Emitter
+}
+
+func (e *bannerEmitter) Emit(ctx context.Context, data any) error {
+	host, ok := data.(*assets.Host)
+	if !ok {
+		return nil
+	}
+	for _, b := range extractBanners(host) {
+		if err := e.inner.Emit(ctx, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *bannerEmitter) Close(err error) {
+	e.inner.Close(err)
+}
+
+// withBannerStreaming wraps the streaming emitter in ctx (if any) so that one
+// item is streamed per banner.
+func withBannerStreaming(ctx context.Context) context.Context {
+	emitter, ok := streaming.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return streaming.WithEmitter(ctx, &bannerEmitter{inner: emitter})
+}
@@ -7,9 +7,12 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/datetime"
 	"github.com/censys/cencli/internal/pkg/formatter"
 	applog "github.com/censys/cencli/internal/pkg/log"
 	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
+	"github.com/censys/cencli/internal/pkg/ui/form"
 )
 
 // BaseCommand is what each Command implementation must embed.
@@ -72,6 +75,8 @@ func (b *BaseCommand) Examples() []string { return []string{} }
 
 func (b *BaseCommand) Long() string { return "" }
 
+func (b *BaseCommand) Hidden() bool { return false }
+
 func (b *BaseCommand) DefaultOutputType() OutputType {
 	return OutputTypeData
 }
@@ -104,6 +109,37 @@ func (b *BaseCommand) init(cmd Command) {
 		// Update color settings after config is re-unmarshaled to respect command-line flags
 		b.Context.updateColorSettings()
 
+		// Mask addresses and identifiers in all output with --redact
+		if err := b.Context.startRedaction(cobraCmd.Context()); err != nil {
+			return err
+		}
+
+		// Fit tables to the terminal unless --wide is set
+		formatter.SetWide(b.config.Wide)
+
+		// Write json and yaml in canonical form with --stable
+		formatter.SetStable(b.config.Stable)
+
+		// Never prompt with --non-interactive, and answer prompts with --yes
+		term.SetNonInteractive(b.config.NonInteractive)
+		form.SetAssumeYes(b.config.Yes)
+
+		// Use the color theme of the command
+		if err := b.Context.applyTheme(cobraCmd); err != nil {
+			return err
+		}
+
+		// Render human-readable timestamps in the configured timezone
+		datetime.SetDisplayTimeZone(b.config.DefaultTZ)
+
+		// Write numbers and timestamps of human-readable output in the locale
+		formatter.SetLocale(b.config.Locale)
+
+		// Pin the current time with --now, for reproducible relative times
+		if err := b.Context.pinClock(); err != nil {
+			return err
+		}
+
 		// Validate streaming mode for conflicts and support
 		if err := validateStreamingMode(cobraCmd, cmd, b.config.Streaming); err != nil {
 			return err
@@ -121,6 +157,20 @@ func (b *BaseCommand) init(cmd Command) {
 
 		// set the logger
 		b.SetLogger(applog.New(b.Config().Debug, nil))
+
+		// Hold the data output for its envelope with --envelope
+		b.Context.startEnvelope()
+
+		// Audit, memoize, plan and scope-check the API calls of the command
+		if err := b.Context.decorateClient(cobraCmd); err != nil {
+			return err
+		}
+
+		// Save the raw API responses of the command with --save-raw
+		b.Context.startSaveRaw(cobraCmd, args)
+
+		b.Context.startSessionRecording(cobraCmd, cmd, args)
+		b.Context.startUsage(cobraCmd, cmd, args)
 		return nil
 	}
 }
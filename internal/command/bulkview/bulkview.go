@@ -0,0 +1,290 @@
+package bulkview
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+const cmdName = "bulk-view"
+
+type Command struct {
+	*command.BaseCommand
+	// services the command uses
+	viewSvc view.Service
+	// flags the command uses
+	flags bulkViewCommandFlags
+	// state - populated by PreRun (through flags, args, etc.)
+	hostIDs []assets.HostID
+	orgID   mo.Option[identifiers.OrganizationID]
+	atTime  mo.Option[time.Time]
+	columns []column
+	cell    string
+	csv     bool
+	// result stored for rendering
+	meta         *responsemeta.ResponseMeta
+	matrix       matrix
+	partialError cenclierrors.CencliError
+}
+
+type bulkViewCommandFlags struct {
+	orgID     flags.OrgIDFlag
+	inputFile flags.FileFlag
+	atTime    flags.TimestampFlag
+	ports     flags.StringSliceFlag
+	cell      flags.StringFlag
+	csv       flags.BoolFlag
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewBulkViewCommand(cmdContext *command.Context) *Command {
+	return &Command{
+		BaseCommand: command.NewBaseCommand(cmdContext),
+	}
+}
+
+func (c *Command) Use() string {
+	return fmt.Sprintf("%s <host...>", cmdName)
+}
+
+func (c *Command) Short() string {
+	return "Compare the services of hosts in a port matrix"
+}
+
+func (c *Command) Long() string {
+	return `Compare the services of hosts in a port matrix.
+
+Fetches the hosts and prints one row per host and one column per port or protocol of
+interest given with --ports, such as 22,80,443,RDP. Without --ports, there is a
+column for every port of any host. Each cell shows the protocols of the host's
+services on the port (or, in a protocol column, their ports), or their banner hashes
+with --cell banner-hash.
+
+Use --csv to write the matrix as CSV, e.g. for a spreadsheet, or --output-format json
+for the services in each cell.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"8.8.8.8,1.1.1.1 --ports 53,443",
+		"--input-file hosts.txt --ports 22,80,443,3389",
+		"--input-file hosts.txt --ports 22,SSH,53/udp",
+		"--input-file hosts.txt --ports 22,443 --cell banner-hash --csv > matrix.csv",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.RangeArgs(0, 1)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	c.flags.inputFile = flags.NewFileFlag(c.Flags(), false, "input-file", "i", "file to read the hosts from, one per line as plain text or JSON objects with an \"asset\" field. Overrides the positional argument.")
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.atTime = flags.NewTimestampFlag(c.Flags(), false, "at-time", "", mo.None[time.Time](), "compare the hosts as of this time")
+	c.flags.atTime.AddAlias("at", "a", "Alias for --at-time")
+	c.flags.ports = flags.NewStringSliceFlag(c.Flags(), false, "ports", "p", []string{},
+		"the columns of the matrix: ports (e.g. 443 or 53/udp) or protocols (e.g. SSH); defaults to every port of any host")
+	c.flags.cell = flags.NewStringFlag(c.Flags(), false, "cell", "", cellPresence,
+		fmt.Sprintf("what each cell shows: %s (the protocols or ports of its services) or %s", cellPresence, cellBannerHash))
+	c.flags.csv = flags.NewBoolFlag(c.Flags(), "csv", "", false, "write the matrix as CSV")
+	return nil
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.atTime, err = c.flags.atTime.Value(c.Config().DefaultTZ, c.Now())
+	if err != nil {
+		return err
+	}
+	c.orgID, err = c.flags.orgID.Value()
+	if err != nil {
+		return err
+	}
+	if err := c.parseMatrixFlags(cmd); err != nil {
+		return err
+	}
+	rawAssets, err := command.RawAssets(cmd, c.flags.inputFile, args)
+	if err != nil {
+		return err
+	}
+	classifier := assets.NewAssetClassifier(rawAssets...)
+	assetType, err := classifier.AssetType()
+	if err != nil {
+		return err
+	}
+	if assetType != assets.AssetTypeHost {
+		return newNotHostError(assetType)
+	}
+	c.hostIDs = classifier.HostIDs()
+
+	svc, err := c.ViewService()
+	if err != nil {
+		return err
+	}
+	c.viewSvc = svc
+	return nil
+}
+
+// parseMatrixFlags parses --ports, --cell, and --csv.
+func (c *Command) parseMatrixFlags(cmd *cobra.Command) cenclierrors.CencliError {
+	ports, err := c.flags.ports.Value()
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool)
+	for _, raw := range ports {
+		col, ok := parseColumn(raw)
+		if !ok {
+			return newInvalidColumnError(raw)
+		}
+		if !seen[col.Name] {
+			seen[col.Name] = true
+			c.columns = append(c.columns, col)
+		}
+	}
+	cellMode, err := c.flags.cell.Value()
+	if err != nil {
+		return err
+	}
+	c.cell = strings.ToLower(strings.TrimSpace(cellMode))
+	if c.cell != cellPresence && c.cell != cellBannerHash {
+		return cenclierrors.NewUsageError(fmt.Errorf("unsupported --cell %q; use %s or %s", cellMode, cellPresence, cellBannerHash))
+	}
+	if c.csv, err = c.flags.csv.Value(); err != nil {
+		return err
+	}
+	if c.csv && cmd.Flags().Changed(formatter.OutputFormatFlagName) {
+		return flags.NewConflictingFlagsError("csv", formatter.OutputFormatFlagName)
+	}
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(cmdName).With(
+		"orgID_set", c.orgID.IsPresent(),
+		"count", len(c.hostIDs),
+		"columns", len(c.columns),
+	)
+
+	err := c.WithProgress(
+		cmd.Context(),
+		logger,
+		"Fetching hosts...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			result, fetchErr := c.viewSvc.GetHosts(pctx, c.orgID, c.hostIDs, c.atTime)
+			if fetchErr != nil {
+				return fetchErr
+			}
+			c.meta = result.Meta
+			c.partialError = result.PartialError
+			c.matrix = buildMatrix(c.hostIDs, result.Hosts, c.columns, c.cell)
+			return nil
+		},
+	)
+	if err != nil {
+		logger.Debug("fetch failed", "error", err)
+		return err
+	}
+
+	c.PrintAppResponseMeta(c.meta)
+
+	if c.csv {
+		err = c.renderCSV()
+	} else {
+		err = c.PrintData(c, c.matrix)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.partialError != nil {
+		c.ReportPartialError(c.partialError, cmd)
+	}
+	return nil
+}
+
+// renderCSV writes the matrix as CSV, with full banner hashes. Several
+// services in a cell are separated by semicolons.
+func (c *Command) renderCSV() cenclierrors.CencliError {
+	w := csv.NewWriter(formatter.Stdout)
+	return cenclierrors.NewCencliError(w.WriteAll(c.matrix.csvRows()))
+}
+
+// RenderShort prints the matrix as a table, with a count of the hosts with
+// services in each column.
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	if len(c.matrix.Columns) == 0 {
+		fmt.Fprintf(formatter.Stdout, "\nNo services found.\n")
+		return nil
+	}
+	columns := []rawtable.Column[row]{{
+		Title: "Host",
+		String: func(r row) string {
+			if !r.Found {
+				return r.Host + " (not found)"
+			}
+			return r.Host
+		},
+		Style: func(s string, r row) string {
+			if !r.Found {
+				return styles.GlobalStyles.Comment.Render(s)
+			}
+			return styles.GlobalStyles.Signature.Render(s)
+		},
+		Priority:   len(c.matrix.Columns) + 1,
+		NoTruncate: true,
+	}}
+	counts := c.matrix.presentCount()
+	for i, name := range c.matrix.Columns {
+		columns = append(columns, rawtable.Column[row]{
+			Title: fmt.Sprintf("%s (%d)", name, counts[i]),
+			String: func(r row) string {
+				if text := r.Cells[i].text(c.cell, false); text != "" {
+					return text
+				}
+				return "-"
+			},
+			Style: func(s string, r row) string {
+				if !r.Cells[i].Present {
+					return styles.GlobalStyles.Comment.Render(s)
+				}
+				return styles.GlobalStyles.Primary.Render(s)
+			},
+			Priority: len(c.matrix.Columns) - i,
+		})
+	}
+	tbl := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[row](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[row](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[row](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, tbl.Render(c.matrix.Rows))
+	fmt.Fprintf(formatter.Stdout, "\n%s\n", styles.GlobalStyles.Comment.Render(c.matrix.summary()))
+	return nil
+}
@@ -0,0 +1,179 @@
+package bulkview
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	viewmocks "github.com/censys/cencli/gen/app/view/mocks"
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+const sshHash = "0123456789abcdef0123456789abcdef"
+
+func testHosts() []*assets.Host {
+	tcp := components.ServiceTransportProtocolTCP
+	udp := components.ServiceTransportProtocolUDP
+	return []*assets.Host{
+		{Host: components.Host{
+			IP: ptr("10.0.0.1"),
+			Services: []components.Service{
+				{Port: ptr(22), Protocol: ptr("SSH"), TransportProtocol: &tcp, BannerHashSha256: ptr(sshHash)},
+				{Port: ptr(80), Protocol: ptr("HTTP"), TransportProtocol: &tcp},
+				{Port: ptr(53), Protocol: ptr("DNS"), TransportProtocol: &udp},
+			},
+		}},
+		{Host: components.Host{
+			IP: ptr("10.0.0.2"),
+			Services: []components.Service{
+				{Port: ptr(2222), Protocol: ptr("SSH"), TransportProtocol: &tcp},
+			},
+		}},
+	}
+}
+
+func TestBulkViewCommand(t *testing.T) {
+	hosts := func(ctrl *gomock.Controller) view.Service {
+		ms := viewmocks.NewMockViewService(ctrl)
+		ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Len(3), gomock.Any()).
+			Return(view.HostsResult{Hosts: testHosts()}, nil)
+		return ms
+	}
+	noCalls := func(ctrl *gomock.Controller) view.Service { return viewmocks.NewMockViewService(ctrl) }
+
+	testCases := []struct {
+		name    string
+		service func(ctrl *gomock.Controller) view.Service
+		args    []string
+		assert  func(t *testing.T, stdout string, err error)
+	}{
+		{
+			name:    "short output",
+			service: hosts,
+			args:    []string{"10.0.0.1,10.0.0.2,10.0.0.3", "--ports", "22,80,ssh"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				lines := strings.Split(strings.TrimSpace(stdout), "\n")
+				require.Regexp(t, `^Host\s+22 \(1\)\s+80 \(1\)\s+SSH \(2\)$`, lines[0])
+				require.Regexp(t, `^10\.0\.0\.1\s+\| SSH\s+\| HTTP\s+\| 22\s*$`, lines[2])
+				require.Regexp(t, `^10\.0\.0\.2\s+\| -\s+\| -\s+\| 2222\s*$`, lines[3])
+				require.Regexp(t, `^10\.0\.0\.3 \(not found\)\s+\| -\s+\| -\s+\| -\s*$`, lines[4])
+				require.Equal(t, "2 of 3 hosts found, 3 columns", lines[len(lines)-1])
+			},
+		},
+		{
+			name:    "csv output with banner hashes",
+			service: hosts,
+			args:    []string{"10.0.0.1,10.0.0.2,10.0.0.3", "--ports", "22,53/udp", "--cell", "banner-hash", "--csv"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				records, csvErr := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+				require.NoError(t, csvErr)
+				require.Equal(t, [][]string{
+					{"host", "found", "22", "53/udp"},
+					{"10.0.0.1", "true", sshHash, "DNS"},
+					{"10.0.0.2", "true", "", ""},
+					{"10.0.0.3", "false", "", ""},
+				}, records)
+			},
+		},
+		{
+			name:    "json output defaults to every port",
+			service: hosts,
+			args:    []string{"10.0.0.1,10.0.0.2,10.0.0.3", "-O", "json"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				var m matrix
+				require.NoError(t, json.Unmarshal([]byte(stdout), &m))
+				require.Equal(t, []string{"22", "53", "80", "2222"}, m.Columns)
+				require.Len(t, m.Rows, 3)
+				require.Equal(t, []service{{Port: 53, Transport: "udp", Protocol: "DNS"}}, m.Rows[0].Cells[1].Services)
+				require.False(t, m.Rows[2].Found)
+			},
+		},
+		{
+			name:    "invalid column",
+			service: noCalls,
+			args:    []string{"10.0.0.1", "--ports", "22/sctp"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var columnErr InvalidColumnError
+				require.ErrorAs(t, err, &columnErr)
+			},
+		},
+		{
+			name:    "invalid cell",
+			service: noCalls,
+			args:    []string{"10.0.0.1", "--cell", "banner"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.ErrorContains(t, err, `unsupported --cell "banner"`)
+			},
+		},
+		{
+			name:    "csv conflicts with output format",
+			service: noCalls,
+			args:    []string{"10.0.0.1", "--csv", "-O", "json"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var conflictErr flags.ConflictingFlagsError
+				require.ErrorAs(t, err, &conflictErr)
+			},
+		},
+		{
+			name:    "rejects non-host assets",
+			service: noCalls,
+			args:    []string{"platform.censys.io:443"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var notHost NotHostError
+				require.ErrorAs(t, err, &notHost)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithViewService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewBulkViewCommand(cmdContext))
+			require.NoError(t, err)
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), cmdErr)
+		})
+	}
+}
+
+func TestParseColumn(t *testing.T) {
+	col, ok := parseColumn("53/UDP")
+	require.True(t, ok)
+	require.Equal(t, column{Name: "53/udp", Port: 53, Transport: "udp"}, col)
+	col, ok = parseColumn("rdp")
+	require.True(t, ok)
+	require.Equal(t, column{Name: "RDP", Protocol: "RDP"}, col)
+	for _, raw := range []string{"", "0", "65536", "SSH/tcp", "22/sctp", "a b"} {
+		_, ok := parseColumn(raw)
+		require.False(t, ok, raw)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,54 @@
+package bulkview
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// NotHostError is returned when the assets are not hosts. Only hosts have services.
+type NotHostError interface {
+	cenclierrors.CencliError
+}
+
+type notHostError struct {
+	assetType assets.AssetType
+}
+
+var _ NotHostError = &notHostError{}
+
+func newNotHostError(assetType assets.AssetType) NotHostError {
+	return &notHostError{assetType: assetType}
+}
+
+func (e *notHostError) Error() string {
+	return fmt.Sprintf("bulk-view only supports hosts, got %s assets", e.assetType)
+}
+
+func (e *notHostError) Title() string { return "Unsupported Asset Type" }
+
+func (e *notHostError) ShouldPrintUsage() bool { return true }
+
+// InvalidColumnError is returned when a --ports value is neither a port nor a protocol.
+type InvalidColumnError interface {
+	cenclierrors.CencliError
+}
+
+type invalidColumnError struct {
+	value string
+}
+
+var _ InvalidColumnError = &invalidColumnError{}
+
+func newInvalidColumnError(value string) InvalidColumnError {
+	return &invalidColumnError{value: value}
+}
+
+func (e *invalidColumnError) Error() string {
+	return fmt.Sprintf("invalid --ports value %q: use a port (443), a port and transport (53/udp), or a protocol (SSH)", e.value)
+}
+
+func (e *invalidColumnError) Title() string { return "Invalid Column" }
+
+func (e *invalidColumnError) ShouldPrintUsage() bool { return true }
@@ -0,0 +1,263 @@
+package bulkview
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// Values of --cell.
+const (
+	cellPresence   = "presence"
+	cellBannerHash = "banner-hash"
+)
+
+// shortHashLength is how much of a banner hash is shown in short output.
+const shortHashLength = 12
+
+// column selects the services shown in a column of the matrix: those on a
+// port (and transport, if given), or those with a protocol.
+type column struct {
+	Name      string
+	Port      int
+	Transport string
+	Protocol  string
+}
+
+// parseColumn parses a --ports value: a port, a port and transport such as
+// 53/udp, or a protocol such as SSH.
+func parseColumn(raw string) (column, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return column{}, false
+	}
+	portPart, transport, hasTransport := strings.Cut(raw, "/")
+	if port, err := strconv.Atoi(portPart); err == nil {
+		if port < 1 || port > 65535 {
+			return column{}, false
+		}
+		col := column{Name: strconv.Itoa(port), Port: port}
+		if hasTransport {
+			transport = strings.ToLower(transport)
+			if transport != "tcp" && transport != "udp" && transport != "quic" {
+				return column{}, false
+			}
+			col.Name += "/" + transport
+			col.Transport = transport
+		}
+		return col, true
+	}
+	if hasTransport {
+		return column{}, false
+	}
+	for _, r := range raw {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return column{}, false
+		}
+	}
+	return column{Name: strings.ToUpper(raw), Protocol: strings.ToUpper(raw)}, true
+}
+
+// matches reports whether a service belongs in the column.
+func (c column) matches(s service) bool {
+	if c.Protocol != "" {
+		return strings.EqualFold(s.Protocol, c.Protocol)
+	}
+	return s.Port == c.Port && (c.Transport == "" || strings.EqualFold(s.Transport, c.Transport))
+}
+
+// matrix is the services of each host, in the columns of interest.
+type matrix struct {
+	Columns []string `json:"columns"`
+	Rows    []row    `json:"rows"`
+	// cell is how cells are rendered in short and CSV output
+	cell string
+}
+
+// row is a host of the matrix.
+type row struct {
+	Host string `json:"host"`
+	// Found is false for a host that has no data.
+	Found bool   `json:"found"`
+	Cells []cell `json:"cells"`
+}
+
+// cell is the services of a host in a column.
+type cell struct {
+	Column   string    `json:"column"`
+	Present  bool      `json:"present"`
+	Services []service `json:"services"`
+}
+
+// service is a service of a host, as shown in a cell.
+type service struct {
+	Port             int    `json:"port"`
+	Transport        string `json:"transport_protocol,omitempty"`
+	Protocol         string `json:"protocol,omitempty"`
+	BannerHashSha256 string `json:"banner_hash_sha256,omitempty"`
+}
+
+// hostServices returns the services of host.
+func hostServices(host *assets.Host) []service {
+	var res []service
+	for _, svc := range host.GetServices() {
+		s := service{Port: deref(svc.GetPort()), Protocol: deref(svc.GetProtocol()), BannerHashSha256: deref(svc.GetBannerHashSha256())}
+		if transport := svc.GetTransportProtocol(); transport != nil {
+			s.Transport = strings.ToLower(string(*transport))
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+// buildMatrix builds the matrix of the requested hosts, in order. Hosts that
+// were not returned are kept as rows that were not found. Without columns,
+// there is a column for each port of any host.
+func buildMatrix(requested []assets.HostID, hosts []*assets.Host, columns []column, cellMode string) matrix {
+	byIP := make(map[string][]service, len(hosts))
+	for _, host := range hosts {
+		if host == nil || host.IP == nil {
+			continue
+		}
+		byIP[assets.NormalizeIP(*host.IP)] = hostServices(host)
+	}
+	if len(columns) == 0 {
+		columns = portColumns(byIP)
+	}
+	m := matrix{Columns: make([]string, len(columns)), Rows: make([]row, 0, len(requested)), cell: cellMode}
+	for i, col := range columns {
+		m.Columns[i] = col.Name
+	}
+	for _, id := range requested {
+		services, found := byIP[assets.NormalizeIP(id.String())]
+		r := row{Host: id.String(), Found: found, Cells: make([]cell, len(columns))}
+		for i, col := range columns {
+			c := cell{Column: col.Name, Services: []service{}}
+			for _, s := range services {
+				if col.matches(s) {
+					c.Services = append(c.Services, s)
+				}
+			}
+			c.Present = len(c.Services) > 0
+			r.Cells[i] = c
+		}
+		m.Rows = append(m.Rows, r)
+	}
+	return m
+}
+
+// portColumns returns a column for each port of the hosts, in ascending order.
+func portColumns(byIP map[string][]service) []column {
+	seen := make(map[int]bool)
+	var ports []int
+	for _, services := range byIP {
+		for _, s := range services {
+			if s.Port > 0 && !seen[s.Port] {
+				seen[s.Port] = true
+				ports = append(ports, s.Port)
+			}
+		}
+	}
+	sort.Ints(ports)
+	res := make([]column, len(ports))
+	for i, port := range ports {
+		res[i] = column{Name: strconv.Itoa(port), Port: port}
+	}
+	return res
+}
+
+// text renders a cell: the protocols (or, in a protocol column, the ports)
+// of its services, or their banner hashes with --cell banner-hash. Hashes are
+// shortened unless full is set. Services without a banner hash are shown as
+// present. An empty cell is "".
+func (c cell) text(cellMode string, full bool) string {
+	parts := make([]string, 0, len(c.Services))
+	for _, s := range c.Services {
+		var part string
+		switch {
+		case cellMode == cellBannerHash && s.BannerHashSha256 != "":
+			part = s.BannerHashSha256
+			if !full && len(part) > shortHashLength {
+				part = part[:shortHashLength]
+			}
+		case !isPortColumn(c.Column):
+			part = strconv.Itoa(s.Port)
+			if s.Transport != "" && s.Transport != "tcp" {
+				part += "/" + s.Transport
+			}
+		case s.Protocol != "":
+			part = s.Protocol
+		default:
+			part = "open"
+		}
+		if !contains(parts, part) {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+func isPortColumn(name string) bool {
+	portPart, _, _ := strings.Cut(name, "/")
+	_, err := strconv.Atoi(portPart)
+	return err == nil
+}
+
+// csvRows returns the matrix as CSV rows, with a header row. Hosts that were
+// not found have empty cells.
+func (m matrix) csvRows() [][]string {
+	header := append([]string{"host", "found"}, m.Columns...)
+	rows := [][]string{header}
+	for _, r := range m.Rows {
+		record := []string{r.Host, strconv.FormatBool(r.Found)}
+		for _, c := range r.Cells {
+			record = append(record, c.text(m.cell, true))
+		}
+		rows = append(rows, record)
+	}
+	return rows
+}
+
+// presentCount returns the number of hosts with services in each column.
+func (m matrix) presentCount() []int {
+	counts := make([]int, len(m.Columns))
+	for _, r := range m.Rows {
+		for i, c := range r.Cells {
+			if c.Present {
+				counts[i]++
+			}
+		}
+	}
+	return counts
+}
+
+// summary describes the matrix in one line.
+func (m matrix) summary() string {
+	var found int
+	for _, r := range m.Rows {
+		if r.Found {
+			found++
+		}
+	}
+	return fmt.Sprintf("%d of %d hosts found, %d columns", found, len(m.Rows), len(m.Columns))
+}
+
+func contains(values []string, v string) bool {
+	for _, existing := range values {
+		if existing == v {
+			return true
+		}
+	}
+	return false
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
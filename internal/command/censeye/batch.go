@@ -0,0 +1,188 @@
+package censeye
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/ui/events"
+)
+
+const (
+	defaultBatchConcurrency = 4
+	maxBatchConcurrency     = 20
+)
+
+// hostReport is the result of investigating one host in batch mode.
+// Exactly one of Pivots or Error is meaningful.
+type hostReport struct {
+	Host string `json:"host"`
+	// ResolvedFrom is the domain the host was resolved from, if any.
+	ResolvedFrom string `json:"resolved_from,omitempty"`
+	// Pivots holds the interesting queries (within the rarity bounds).
+	Pivots []censeye.ReportEntry `json:"pivots"`
+	// Queries is the total number of queries generated for the host.
+	Queries int `json:"queries"`
+	// Annotations are the notes of the gadgets, if any ran.
+	Annotations []censeye.Annotation `json:"annotations,omitempty"`
+	Error       string               `json:"error,omitempty"`
+	// entries holds every query, for the short output
+	entries []censeye.ReportEntry
+	err     cenclierrors.CencliError
+}
+
+// batchOutcome carries a host's report from a worker to the collector.
+type batchOutcome struct {
+	index  int
+	report hostReport
+}
+
+func newHostReport(host string, res censeye.InvestigateHostResult, err cenclierrors.CencliError) hostReport {
+	report := hostReport{Host: host, Pivots: []censeye.ReportEntry{}}
+	if err != nil {
+		report.Error = err.Error()
+		report.err = err
+		return report
+	}
+	report.entries = res.Entries
+	report.Annotations = res.Annotations
+	report.Queries = len(res.Entries)
+	for _, entry := range res.Entries {
+		if entry.Interesting {
+			report.Pivots = append(report.Pivots, entry)
+		}
+	}
+	return report
+}
+
+// runBatch investigates every host, printing one report per host. A host that
+// fails to investigate does not stop the others; its error is recorded in its
+// report and summarized on stderr once all hosts are done.
+func (c *Command) runBatch(ctx context.Context, logger *slog.Logger) cenclierrors.CencliError {
+	// Reports are emitted as they complete in streaming mode. The emitter is kept
+	// out of the workers' context so the hosts they fetch are not streamed too.
+	streamCtx, stopStreaming := c.WithStreamingOutput(ctx, logger)
+	defer stopStreaming(nil)
+
+	var failed int
+	err := c.WithProgress(
+		ctx,
+		logger,
+		fmt.Sprintf("Investigating %d hosts...", len(c.batchHosts)),
+		func(pctx context.Context) cenclierrors.CencliError {
+			var batchErr cenclierrors.CencliError
+			c.reports, failed, batchErr = c.investigateAll(pctx, streamCtx)
+			return batchErr
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	var data any = c.reports
+	if c.histogram {
+		data = batchHistogramOutput{Reports: c.reports, Histogram: c.batchHistogram()}
+	}
+	if err := c.PrintData(c, data); err != nil {
+		return err
+	}
+
+	switch {
+	case failed == 0:
+		return nil
+	case failed == len(c.batchHosts):
+		return newBatchFailedError(failed, len(c.batchHosts))
+	default:
+		c.ReportPartialError(cenclierrors.ToPartialError(newBatchFailedError(failed, len(c.batchHosts))), nil)
+		return nil
+	}
+}
+
+// investigateAll investigates the batch hosts with bounded concurrency. Reports
+// are emitted to streamCtx as they arrive when streaming, otherwise they are
+// returned in input order. It returns the number of hosts that failed.
+func (c *Command) investigateAll(ctx context.Context, streamCtx context.Context) ([]hostReport, int, cenclierrors.CencliError) {
+	total := len(c.batchHosts)
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	g, gctx := errgroup.WithContext(runCtx)
+	g.SetLimit(int(c.concurrency))
+	outCh := make(chan batchOutcome, c.concurrency)
+
+	go func() {
+		for i, host := range c.batchHosts {
+			g.Go(func() error {
+				if err := gctx.Err(); err != nil {
+					return err
+				}
+				res, err := c.investigate(gctx, host)
+				report := newHostReport(host, res, err)
+				report.ResolvedFrom = c.resolvedFrom[host]
+				select {
+				case outCh <- batchOutcome{index: i, report: report}:
+				case <-gctx.Done():
+				}
+				return nil
+			})
+		}
+		_ = g.Wait()
+		close(outCh)
+	}()
+
+	ordered := make([]hostReport, total)
+	var done, failed int
+	var emitErr error
+	for o := range outCh {
+		done++
+		if o.report.err != nil {
+			failed++
+			events.ReportError(ctx, events.StageProcess, fmt.Errorf("%s: %w", o.report.Host, o.report.err))
+		}
+		events.ReportBatch(ctx, events.StageProcess, uint64(done), uint64(total), fmt.Sprintf("Investigated %d/%d hosts...", done, total))
+		ordered[o.index] = o.report
+		if streaming.IsStreaming(streamCtx) {
+			if emitErr = streaming.Emit(streamCtx, o.report); emitErr != nil {
+				cancel()
+				break
+			}
+		}
+	}
+	// Drain any buffered outcomes after an early break so the workers can wind down.
+	for range outCh {
+	}
+
+	if emitErr != nil {
+		return nil, failed, cenclierrors.NewCencliError(emitErr)
+	}
+	if done < total {
+		return nil, failed, cenclierrors.ParseContextError(ctx.Err())
+	}
+	if streaming.IsStreaming(streamCtx) {
+		return nil, failed, nil
+	}
+	return ordered, failed, nil
+}
+
+// renderBatch renders each host's results, or its error, one after another.
+func (c *Command) renderBatch() cenclierrors.CencliError {
+	for _, report := range c.reports {
+		if report.err != nil {
+			fmt.Fprintf(formatter.Stdout, "\n=== CensEye Results for %s ===\n\nError: %s\n", c.hostLabel(report.Host), report.Error)
+			continue
+		}
+		fmt.Fprint(formatter.Stdout, renderTableOutput(c.hostLabel(report.Host), report.entries))
+		fmt.Fprint(formatter.Stdout, renderPivots(report.entries))
+		fmt.Fprint(formatter.Stdout, renderAnnotations(report.Annotations))
+	}
+	if c.histogram {
+		fmt.Fprint(formatter.Stdout, "\n"+renderHistogram(c.batchHistogram()))
+	}
+	return nil
+}
@@ -3,21 +3,27 @@ package censeye
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/samber/mo"
 	"github.com/spf13/cobra"
 
 	"github.com/censys/cencli/internal/app/censeye"
-	"github.com/censys/cencli/internal/app/progress"
+	"github.com/censys/cencli/internal/app/search"
 	"github.com/censys/cencli/internal/app/view"
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
 	"github.com/censys/cencli/internal/pkg/input"
+	"github.com/censys/cencli/internal/pkg/resolve"
+	"github.com/censys/cencli/internal/pkg/styles"
 	"github.com/censys/cencli/internal/pkg/tape"
+	"github.com/censys/cencli/internal/pkg/ui/events"
 )
 
 const (
@@ -30,32 +36,55 @@ const (
 // Command implements the `censeye` CLI command.
 // It analyzes a single host, compiles field-value rules, retrieves counts
 // from the threat hunting service, and prints queries along with a rarity
-// indicator based on configurable bounds.
+// indicator based on configurable bounds. With --batch, it analyzes many
+// hosts concurrently and prints one report per host.
 type Command struct {
 	*command.BaseCommand
 	// services the command uses
 	censeyeSvc censeye.Service
 	viewSvc    view.Service
+	searchSvc  search.Service
 	// flags the command uses
 	flags censeyeCommandFlags
 	// state parsed from flags/args
-	orgID       mo.Option[identifiers.OrganizationID]
-	rarityMin   uint64
-	rarityMax   uint64
-	interactive bool
-	includeURL  bool
-	hostID      string
+	orgID mo.Option[identifiers.OrganizationID]
+	// collectionID limits the host and the counts to a collection
+	collectionID mo.Option[identifiers.CollectionID]
+	rarityMin    uint64
+	rarityMax    uint64
+	interactive  bool
+	explore      bool
+	includeURL   bool
+	histogram    bool
+	hostID       string
+	batch        bool
+	batchHosts   []string
+	concurrency  int64
+	gadgets      []censeye.Gadget
+	// resolvedFrom maps the IP of a host to the domain it was resolved from
+	resolvedFrom map[string]string
+	// unresolved holds the domains in batchHosts that could not be resolved
+	unresolved map[string]cenclierrors.CencliError
 	// result stored for rendering
 	result censeye.InvestigateHostResult
+	// reports stored for rendering in batch mode
+	reports []hostReport
 }
 
 type censeyeCommandFlags struct {
-	orgID       flags.OrgIDFlag
-	inputFile   flags.FileFlag
-	rarityMin   flags.IntegerFlag
-	rarityMax   flags.IntegerFlag
-	interactive flags.BoolFlag
-	includeURL  flags.BoolFlag
+	orgID        flags.OrgIDFlag
+	collectionID flags.UUIDFlag
+	inputFile    flags.FileFlag
+	rarityMin    flags.IntegerFlag
+	rarityMax    flags.IntegerFlag
+	interactive  flags.BoolFlag
+	explore      flags.BoolFlag
+	includeURL   flags.BoolFlag
+	histogram    flags.BoolFlag
+	batch        flags.BoolFlag
+	concurrency  flags.IntegerFlag
+	gadgets      flags.StringSliceFlag
+	resolve      command.ResolveFlags
 }
 
 var _ command.Command = (*Command)(nil)
@@ -80,14 +109,29 @@ func (c *Command) Long() string {
 func (c *Command) Examples() []string {
 	return []string{
 		"8.8.8.8",
+		"censys.com  # resolves the domain and investigates its host",
 		"--rarity-min 2 --rarity-max 25 1.1.1.1",
 		"--interactive 192.168.1.1",
+		"--explore 192.168.1.1",
 		"--output-format json --include-url 192.168.1.1",
+		"--histogram 1.1.1.1  # suggest rarity bounds from the distribution of counts",
+		"--batch --input-file hosts.txt --output-format json",
+		"--batch -S --input-file hosts.txt  # one NDJSON object per host",
+		"--gadgets od,nobbler 1.1.1.1  # add pivots from open directories and unknown banners",
+		"--collection-id <your-collection-id> 1.1.1.1  # count only the hosts of a collection",
 	}
 }
 
 func (c *Command) Init() error {
 	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.collectionID = flags.NewUUIDFlag(
+		c.Flags(),
+		false,
+		command.CollectionIDFlagName,
+		"c",
+		mo.None[uuid.UUID](),
+		"investigate a host of a collection, counting only the hosts of the collection (optional)",
+	)
 	c.flags.inputFile = flags.NewFileFlag(
 		c.Flags(),
 		false,
@@ -122,6 +166,13 @@ func (c *Command) Init() error {
 		false,
 		"display results in an interactive table (TUI)",
 	)
+	c.flags.explore = flags.NewBoolFlag(
+		c.Flags(),
+		"explore",
+		"x",
+		false,
+		"explore pivots interactively: search a query, then run censeye on a matching host (TUI)",
+	)
 	c.flags.includeURL = flags.NewBoolFlag(
 		c.Flags(),
 		"include-url",
@@ -129,6 +180,39 @@ func (c *Command) Init() error {
 		false,
 		"include a Platform search URL in the output",
 	)
+	c.flags.histogram = flags.NewBoolFlag(
+		c.Flags(),
+		histogramFlagName,
+		"",
+		false,
+		"show the distribution of counts and suggest rarity bounds from its percentiles",
+	)
+	c.flags.batch = flags.NewBoolFlag(
+		c.Flags(),
+		"batch",
+		"b",
+		false,
+		"investigate every provided host and print one report per host",
+	)
+	c.flags.concurrency = flags.NewIntegerFlag(
+		c.Flags(),
+		false, // not required
+		"concurrency",
+		"",
+		mo.Some(int64(defaultBatchConcurrency)),
+		"number of hosts to investigate at once with --batch",
+		mo.Some(int64(1)),
+		mo.Some(int64(maxBatchConcurrency)),
+	)
+	c.flags.gadgets = flags.NewStringSliceFlag(
+		c.Flags(),
+		false, // not required
+		gadgetsFlagName,
+		"",
+		nil,
+		fmt.Sprintf("gadgets to run on the host, overriding censeye.gadgets (%s)", strings.Join(censeye.GadgetNames(), ", ")),
+	)
+	c.flags.resolve = command.NewResolveFlags(c.Flags(), true)
 	return nil
 }
 
@@ -140,6 +224,13 @@ func (c *Command) SupportedOutputTypes() []command.OutputType {
 	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
 }
 
+// SupportsStreaming reports whether reports can be streamed, which is only
+// the case in batch mode.
+func (c *Command) SupportsStreaming() bool {
+	batch, err := c.flags.batch.Value()
+	return err == nil && batch
+}
+
 func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
 	var err cenclierrors.CencliError
 	c.orgID, err = c.flags.orgID.Value()
@@ -149,21 +240,34 @@ func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliE
 	// validate the hostID
 	var providedAssets []string
 	if c.flags.inputFile.IsSet() {
-		lines, err := c.flags.inputFile.Lines(cmd)
+		records, err := command.InputRecords(cmd, c.flags.inputFile)
 		if err != nil {
 			return err
 		}
-		providedAssets = lines
+		providedAssets = input.RecordValues(records)
 	} else {
 		providedAssets = args
 	}
 	if len(providedAssets) == 0 {
 		return assets.NewNoAssetsError()
 	}
-	if len(providedAssets) > 1 {
-		return assets.NewTooManyAssetsError(len(providedAssets), 1)
+	c.batch, err = c.flags.batch.Value()
+	if err != nil {
+		return err
+	}
+	if c.batch {
+		if err := c.parseBatchFlags(providedAssets); err != nil {
+			return err
+		}
+	} else {
+		if len(providedAssets) > 1 {
+			return assets.NewTooManyAssetsError(len(providedAssets), 1)
+		}
+		c.hostID = providedAssets[0]
+	}
+	if err := c.resolveHosts(cmd.Context()); err != nil {
+		return err
 	}
-	c.hostID = providedAssets[0]
 	// validate rarity flags
 	minVal, err := c.flags.rarityMin.Value()
 	if err != nil {
@@ -191,11 +295,39 @@ func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliE
 	if err != nil {
 		return err
 	}
+	if c.batch && c.interactive {
+		return flags.NewConflictingFlagsError("batch", "interactive")
+	}
+	c.explore, err = c.flags.explore.Value()
+	if err != nil {
+		return err
+	}
+	if c.batch && c.explore {
+		return flags.NewConflictingFlagsError("batch", "explore")
+	}
+	if c.explore {
+		if err := c.validateExplore(); err != nil {
+			return err
+		}
+	}
 	// validate includeURL (if present)
 	c.includeURL, err = c.flags.includeURL.Value()
 	if err != nil {
 		return err
 	}
+	c.histogram, err = c.flags.histogram.Value()
+	if err != nil {
+		return err
+	}
+	if err := c.validateHistogram(); err != nil {
+		return err
+	}
+	if err := c.parseGadgets(cmd); err != nil {
+		return err
+	}
+	if err := c.parseCollectionIDFlag(cmd); err != nil {
+		return err
+	}
 	// resolve services
 	err = c.resolveServices()
 	if err != nil {
@@ -205,6 +337,10 @@ func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliE
 }
 
 func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	if c.batch {
+		logger := c.Logger(cmdName).With("hosts", len(c.batchHosts), "concurrency", c.concurrency)
+		return c.runBatch(cmd.Context(), logger)
+	}
 	logger := c.Logger(cmdName).With("hostID", c.hostID)
 
 	if err := c.WithProgress(
@@ -212,17 +348,7 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 		logger,
 		c.fetchMessage(),
 		func(pctx context.Context) cenclierrors.CencliError {
-			asset, fetchErr := c.fetchAsset(pctx, c.hostID)
-			if fetchErr != nil {
-				return fetchErr
-			}
-			host, ok := asset.(*assets.Host)
-			if !ok {
-				return cenclierrors.NewCencliError(fmt.Errorf("expected host asset, got %T", asset))
-			}
-
-			progress.ReportMessage(pctx, progress.StageProcess, "Investigating host...")
-			res, investigateErr := c.censeyeSvc.InvestigateHost(pctx, c.orgID, host, c.rarityMin, c.rarityMax)
+			res, investigateErr := c.investigate(pctx, c.hostID)
 			if investigateErr != nil {
 				return investigateErr
 			}
@@ -236,13 +362,172 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 	// Print response metadata
 	c.PrintAppResponseMeta(c.result.Meta)
 
+	if c.explore {
+		return c.newExplorer(logger).run(cmd.Context(), c.hostID, c.result.Entries)
+	}
+	if len(c.gadgets) > 0 {
+		out := gadgetOutput{Entries: c.result.Entries, Annotations: c.result.Annotations}
+		if out.Annotations == nil {
+			out.Annotations = []censeye.Annotation{}
+		}
+		if c.histogram {
+			histogram := censeye.NewHistogram(c.result.Entries)
+			out.Histogram = &histogram
+		}
+		return c.PrintData(c, out)
+	}
+	if c.histogram {
+		return c.PrintData(c, histogramOutput{
+			Entries:   c.result.Entries,
+			Histogram: censeye.NewHistogram(c.result.Entries),
+		})
+	}
 	return c.PrintData(c, c.result.Entries)
 }
 
+// investigate fetches a host and runs censeye on it.
+func (c *Command) investigate(ctx context.Context, hostID string) (censeye.InvestigateHostResult, cenclierrors.CencliError) {
+	if err, ok := c.unresolved[hostID]; ok {
+		return censeye.InvestigateHostResult{}, err
+	}
+	asset, err := c.fetchAsset(ctx, hostID)
+	if err != nil {
+		return censeye.InvestigateHostResult{}, err
+	}
+	host, ok := asset.(*assets.Host)
+	if !ok {
+		return censeye.InvestigateHostResult{}, cenclierrors.NewCencliError(fmt.Errorf("expected host asset, got %T", asset))
+	}
+	if !c.batch {
+		events.ReportMessage(ctx, events.StageProcess, "Investigating host...")
+	}
+	var res censeye.InvestigateHostResult
+	if collectionID, ok := c.collectionID.Get(); ok {
+		res, err = c.censeyeSvc.InvestigateHostInCollection(ctx, c.orgID, collectionID, host, c.rarityMin, c.rarityMax)
+	} else {
+		res, err = c.censeyeSvc.InvestigateHost(ctx, c.orgID, host, c.rarityMin, c.rarityMax)
+	}
+	if err != nil || len(c.gadgets) == 0 {
+		return res, err
+	}
+	gadgetRes, err := c.censeyeSvc.RunGadgets(ctx, c.orgID, host, c.gadgets, c.rarityMin, c.rarityMax)
+	if err != nil {
+		return censeye.InvestigateHostResult{}, err
+	}
+	return res.WithGadgets(gadgetRes), nil
+}
+
+// parseBatchFlags collects the hosts to investigate in batch mode. Each line of
+// the input file, or each comma-separated value of the argument, is a host.
+// Hosts are not validated here so that a bad one is reported in its own result
+// instead of aborting the run.
+func (c *Command) parseBatchFlags(provided []string) cenclierrors.CencliError {
+	for _, p := range provided {
+		for _, host := range input.SplitString(p) {
+			if host = strings.TrimSpace(host); host != "" {
+				c.batchHosts = append(c.batchHosts, host)
+			}
+		}
+	}
+	if len(c.batchHosts) == 0 {
+		return assets.NewNoAssetsError()
+	}
+	concurrency, err := c.flags.concurrency.Value()
+	if err != nil {
+		return err
+	}
+	c.concurrency = concurrency.OrElse(defaultBatchConcurrency)
+	return nil
+}
+
+// resolveHosts replaces the domains given as hosts with the IPs they resolve
+// to, unless --no-resolve is set. A domain investigated on its own uses its
+// first IP; in batch mode, every IP is investigated, and a domain that cannot
+// be resolved is reported in its own result.
+func (c *Command) resolveHosts(ctx context.Context) cenclierrors.CencliError {
+	enabled, err := c.flags.resolve.Value(true)
+	if err != nil || !enabled {
+		return err
+	}
+	c.resolvedFrom = make(map[string]string)
+	if !c.batch {
+		domain, ok := resolve.BareDomain(c.hostID)
+		if !ok {
+			return nil
+		}
+		ips, err := c.ResolveDomain(ctx, domain)
+		if err != nil {
+			return err
+		}
+		if len(ips) > 1 && !c.Config().Quiet {
+			formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render(fmt.Sprintf(
+				"Warning: %s resolves to %d addresses; investigating %s. Use --batch to investigate all of them.",
+				domain, len(ips), ips[0])))
+		}
+		c.hostID = ips[0]
+		c.resolvedFrom[c.hostID] = domain
+		return nil
+	}
+	hosts := make([]string, 0, len(c.batchHosts))
+	for _, host := range c.batchHosts {
+		domain, ok := resolve.BareDomain(host)
+		if !ok {
+			hosts = append(hosts, host)
+			continue
+		}
+		ips, err := c.ResolveDomain(ctx, domain)
+		if err != nil {
+			if c.unresolved == nil {
+				c.unresolved = make(map[string]cenclierrors.CencliError)
+			}
+			c.unresolved[host] = err
+			hosts = append(hosts, host)
+			continue
+		}
+		for _, ip := range ips {
+			if _, exists := c.resolvedFrom[ip]; !exists {
+				c.resolvedFrom[ip] = domain
+				hosts = append(hosts, ip)
+			}
+		}
+	}
+	c.batchHosts = hosts
+	return nil
+}
+
+// hostLabel returns host, with the domain it was resolved from if any.
+func (c *Command) hostLabel(host string) string {
+	if domain, ok := c.resolvedFrom[host]; ok {
+		return fmt.Sprintf("%s (resolved from %s)", host, domain)
+	}
+	return host
+}
+
+// validateExplore checks that --explore can be used, and resolves the search
+// service it needs. The explorer is a TUI, so it needs a terminal and short output.
+func (c *Command) validateExplore() cenclierrors.CencliError {
+	if c.interactive {
+		return flags.NewConflictingFlagsError("explore", "interactive")
+	}
+	if c.Config().OutputFormat != formatter.OutputFormatShort {
+		return flags.NewConflictingFlagsError("explore", formatter.OutputFormatFlagName)
+	}
+	if !formatter.StdoutIsTTY() {
+		return newExploreRequiresTerminalError()
+	}
+	var err cenclierrors.CencliError
+	c.searchSvc, err = c.SearchService()
+	return err
+}
+
 // RenderShort renders the censeye results as a human-readable table.
-// If the interactive flag is set, displays an interactive TUI table.
+// In batch mode, renders each host in turn. If the interactive flag is set,
+// displays an interactive TUI table.
 // Otherwise, displays a static styled table with pivots.
 func (c *Command) RenderShort() cenclierrors.CencliError {
+	if c.batch {
+		return c.renderBatch()
+	}
 	if c.interactive {
 		return c.showInteractiveTable(c.result)
 	}
@@ -263,6 +548,25 @@ func (c *Command) resolveServices() cenclierrors.CencliError {
 	return nil
 }
 
+// parseCollectionIDFlag parses --collection-id. Gadgets and the explorer
+// search the whole dataset, so they cannot be combined with it, and the
+// gadgets of censeye.gadgets are not run.
+func (c *Command) parseCollectionIDFlag(cmd *cobra.Command) cenclierrors.CencliError {
+	collectionID, err := c.flags.collectionID.Value()
+	if err != nil || !collectionID.IsPresent() {
+		return err
+	}
+	if err := command.CheckCollectionConflicts(cmd, map[string]string{
+		gadgetsFlagName: "gadget queries are counted across the whole dataset",
+		"explore":       "the explorer searches the whole dataset",
+	}); err != nil {
+		return err
+	}
+	c.collectionID = mo.Some(identifiers.NewCollectionID(collectionID.MustGet()))
+	c.gadgets = nil
+	return nil
+}
+
 // fetchAsset fetches an asset from the view service.
 func (c *Command) fetchAsset(ctx context.Context, arg string) (assets.Asset, cenclierrors.CencliError) {
 	parsed := input.SplitString(arg)
@@ -279,12 +583,17 @@ func (c *Command) fetchAsset(ctx context.Context, arg string) (assets.Asset, cen
 			return nil, assets.NewTooManyAssetsError(len(hostIDs), 1)
 		}
 		hostID := hostIDs[0]
-		hosts, err := c.viewSvc.GetHosts(ctx, c.orgID, []assets.HostID{hostID}, mo.None[time.Time]())
+		var hosts view.HostsResult
+		if collectionID, ok := c.collectionID.Get(); ok {
+			hosts, err = c.viewSvc.GetHostsInCollection(ctx, c.orgID, collectionID, []assets.HostID{hostID})
+		} else {
+			hosts, err = c.viewSvc.GetHosts(ctx, c.orgID, []assets.HostID{hostID}, mo.None[time.Time]())
+		}
 		if err != nil {
 			return nil, err
 		}
 		if len(hosts.Hosts) == 0 {
-			return nil, newHostNotFoundError(hostID.String())
+			return nil, newHostNotFoundError(hostID.String(), c.collectionID)
 		}
 		result = hosts.Hosts[0]
 	default:
@@ -295,7 +604,7 @@ func (c *Command) fetchAsset(ctx context.Context, arg string) (assets.Asset, cen
 
 // fetchMessage returns a contextual message for the fetch stage indicating input source.
 func (c *Command) fetchMessage() string {
-	baseMsg := fmt.Sprintf("Fetching host %s", c.hostID)
+	baseMsg := fmt.Sprintf("Fetching host %s", c.hostLabel(c.hostID))
 	if c.flags.inputFile.IsSet() {
 		value, _ := c.flags.inputFile.Value()
 		if value == input.StdInSentinel {
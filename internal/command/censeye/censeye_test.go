@@ -2,10 +2,15 @@ package censeye
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,15 +25,18 @@ import (
 	censeyemocks "github.com/censys/cencli/gen/app/censeye/mocks"
 	viewmocks "github.com/censys/cencli/gen/app/view/mocks"
 	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/app/view"
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	client "github.com/censys/cencli/internal/pkg/clients/censys"
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
 	"github.com/censys/cencli/internal/pkg/flags"
 	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/term"
 )
 
 func TestCenseyeCommand(t *testing.T) {
@@ -464,6 +472,37 @@ func TestCenseyeCommand(t *testing.T) {
 				require.Contains(t, err.Error(), "invalid uuid")
 			},
 		},
+		{
+			name: "error - explore conflicts with interactive",
+			viewSvc: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				return censeyemocks.NewMockCenseyeService(ctrl)
+			},
+			args: []string{"8.8.8.8", "--explore", "--interactive"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				var cencliErr flags.ConflictingFlagsError
+				require.ErrorAs(t, err, &cencliErr)
+				require.Contains(t, err.Error(), "explore")
+			},
+		},
+		{
+			name: "error - explore requires a terminal",
+			viewSvc: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				return censeyemocks.NewMockCenseyeService(ctrl)
+			},
+			args: []string{"8.8.8.8", "--explore"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				var cencliErr ExploreRequiresTerminalError
+				require.ErrorAs(t, err, &cencliErr)
+			},
+		},
 		{
 			name: "error - no argument",
 			viewSvc: func(ctrl *gomock.Controller) view.Service {
@@ -822,6 +861,126 @@ func TestCenseyeCommand(t *testing.T) {
 				require.ErrorAs(t, err, &tooManyErr)
 			},
 		},
+		{
+			name: "success - gadgets add entries and annotations",
+			viewSvc: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				hostID, _ := assets.NewHostID("8.8.8.8")
+				host := &assets.Host{Host: components.Host{IP: strPtr("8.8.8.8")}}
+				ms.EXPECT().GetHosts(gomock.Any(), mo.None[identifiers.OrganizationID](), []assets.HostID{hostID}, mo.None[time.Time]()).
+					Return(view.HostsResult{Hosts: []*assets.Host{host}}, nil)
+				return ms
+			},
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				ms := censeyemocks.NewMockCenseyeService(ctrl)
+				ms.EXPECT().InvestigateHost(gomock.Any(), mo.None[identifiers.OrganizationID](), gomock.Any(), uint64(2), uint64(100)).
+					Return(censeye.InvestigateHostResult{Entries: []censeye.ReportEntry{{Count: 10, Query: "services.port=80", Interesting: true}}}, nil)
+				ms.EXPECT().RunGadgets(gomock.Any(), mo.None[identifiers.OrganizationID](), gomock.Any(), gomock.Len(2), uint64(2), uint64(100)).
+					DoAndReturn(func(_ context.Context, _ mo.Option[identifiers.OrganizationID], _ *assets.Host, gadgets []censeye.Gadget, _, _ uint64) (censeye.GadgetResult, cenclierrors.CencliError) {
+						require.Equal(t, "od", gadgets[0].Name())
+						require.Equal(t, "nobbler", gadgets[1].Name())
+						return censeye.GadgetResult{
+							Entries:     []censeye.ReportEntry{{Count: 20, Query: `host.services.endpoints.http.body: "payload.exe"`, Interesting: true, Gadget: "od"}},
+							Annotations: []censeye.Annotation{{Gadget: "od", Text: "open directory on port 80 at / listing 1 files"}},
+						}, nil
+					})
+				return ms
+			},
+			args: []string{"--gadgets", "od,nobbler", "--output-format", "json", "8.8.8.8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var out struct {
+					Entries     []censeye.ReportEntry `json:"entries"`
+					Annotations []censeye.Annotation  `json:"annotations"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+				require.Len(t, out.Entries, 2)
+				require.Equal(t, "od", out.Entries[0].Gadget)
+				require.Equal(t, "services.port=80", out.Entries[1].Query)
+				require.Equal(t, []censeye.Annotation{{Gadget: "od", Text: "open directory on port 80 at / listing 1 files"}}, out.Annotations)
+			},
+		},
+		{
+			name: "error - unknown gadget",
+			viewSvc: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				return censeyemocks.NewMockCenseyeService(ctrl)
+			},
+			args: []string{"--gadgets", "shodan", "8.8.8.8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				var gadgetErr censeye.UnknownGadgetError
+				require.ErrorAs(t, err, &gadgetErr)
+				require.Contains(t, err.Error(), "available: nobbler, od, vt")
+			},
+		},
+		{
+			name: "error - vt gadget without an API key",
+			viewSvc: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				return censeyemocks.NewMockCenseyeService(ctrl)
+			},
+			args: []string{"--gadgets", "vt", "8.8.8.8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				var gadgetErr censeye.GadgetConfigError
+				require.ErrorAs(t, err, &gadgetErr)
+				require.Contains(t, err.Error(), "censeye.virustotal-api-key")
+			},
+		},
+		{
+			name: "success - collection id scopes the host and the counts",
+			viewSvc: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				hostID, _ := assets.NewHostID("8.8.8.8")
+				ms.EXPECT().GetHostsInCollection(gomock.Any(), mo.None[identifiers.OrganizationID](), testCollectionID, []assets.HostID{hostID}).
+					Return(view.HostsResult{Hosts: []*assets.Host{{Host: components.Host{IP: strPtr("8.8.8.8")}}}}, nil)
+				return ms
+			},
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				ms := censeyemocks.NewMockCenseyeService(ctrl)
+				ms.EXPECT().InvestigateHostInCollection(gomock.Any(), mo.None[identifiers.OrganizationID](), testCollectionID, gomock.Any(), uint64(defaultRarityMin), uint64(defaultRarityMax)).
+					Return(censeye.InvestigateHostResult{Entries: []censeye.ReportEntry{{Count: 3, Query: `host.ip="8.8.8.8"`, Interesting: true}}}, nil)
+				return ms
+			},
+			args: []string{"--collection-id", testCollectionID.String(), "8.8.8.8", "--output-format", "json"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, `host.ip=\"8.8.8.8\"`)
+			},
+		},
+		{
+			name: "error - host not in collection",
+			viewSvc: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				ms.EXPECT().GetHostsInCollection(gomock.Any(), gomock.Any(), testCollectionID, gomock.Any()).Return(view.HostsResult{}, nil)
+				return ms
+			},
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				return censeyemocks.NewMockCenseyeService(ctrl)
+			},
+			args: []string{"--collection-id", testCollectionID.String(), "8.8.8.8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.EqualError(t, err, "host 8.8.8.8 not found in collection "+testCollectionID.String())
+			},
+		},
+		{
+			name: "error - gadgets are not supported in collections",
+			viewSvc: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				return censeyemocks.NewMockCenseyeService(ctrl)
+			},
+			args: []string{"--collection-id", testCollectionID.String(), "--gadgets", "od", "8.8.8.8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.EqualError(t, err, "--collection-id cannot be used with --gadgets: gadget queries are counted across the whole dataset")
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -860,3 +1019,348 @@ func TestCenseyeCommand(t *testing.T) {
 }
 
 func strPtr(s string) *string { return &s }
+
+var testCollectionID = identifiers.NewCollectionID(uuid.MustParse("11111111-2222-3333-4444-555555555555"))
+
+func TestExplorer(t *testing.T) {
+	entries := func(queries ...string) []censeye.ReportEntry {
+		res := make([]censeye.ReportEntry, len(queries))
+		for i, q := range queries {
+			res[i] = censeye.ReportEntry{Query: q, Count: int64(10 - i)}
+		}
+		return res
+	}
+
+	type queryStep struct {
+		query  string
+		action exploreAction
+	}
+	type hostStep struct {
+		ip     string
+		action exploreAction
+	}
+
+	newScripted := func(t *testing.T, queries []queryStep, hosts []hostStep, titles *[]string) *explorer {
+		return &explorer{
+			search: func(_ context.Context, query string) ([]exploreHost, cenclierrors.CencliError) {
+				if query == "bad" {
+					return nil, cenclierrors.NewCencliError(errors.New("search failed"))
+				}
+				return []exploreHost{{IP: "2.2.2.2"}, {IP: "3.3.3.3"}}, nil
+			},
+			investigate: func(_ context.Context, hostID string) ([]censeye.ReportEntry, cenclierrors.CencliError) {
+				return entries("q-" + hostID), nil
+			},
+			chooseQuery: func(title string, _ []censeye.ReportEntry) (censeye.ReportEntry, exploreAction, error) {
+				require.NotEmpty(t, queries, "unexpected query prompt: %s", title)
+				*titles = append(*titles, title)
+				step := queries[0]
+				queries = queries[1:]
+				return censeye.ReportEntry{Query: step.query}, step.action, nil
+			},
+			chooseHost: func(title string, _ []exploreHost) (exploreHost, exploreAction, error) {
+				require.NotEmpty(t, hosts, "unexpected host prompt: %s", title)
+				*titles = append(*titles, title)
+				step := hosts[0]
+				hosts = hosts[1:]
+				return exploreHost{IP: step.ip}, step.action, nil
+			},
+			reportError: func(err cenclierrors.CencliError) { *titles = append(*titles, "error: "+err.Error()) },
+		}
+	}
+
+	t.Run("pivots build a breadcrumb trail", func(t *testing.T) {
+		var titles []string
+		e := newScripted(t,
+			[]queryStep{{"a", exploreSelect}, {"b", exploreSelect}, {"", exploreBack}, {"", exploreQuit}},
+			[]hostStep{{"2.2.2.2", exploreSelect}, {"", exploreBack}},
+			&titles,
+		)
+		require.NoError(t, e.run(context.Background(), "1.1.1.1", entries("a")))
+		sep := " " + term.Glyph("›", ">") + " "
+		require.Equal(t, []string{
+			"1.1.1.1",
+			"1.1.1.1" + sep + "a",
+			"1.1.1.1" + sep + "a" + sep + "2.2.2.2",
+			"1.1.1.1" + sep + "a" + sep + "2.2.2.2" + sep + "b",
+			"1.1.1.1" + sep + "a" + sep + "2.2.2.2",
+			"1.1.1.1",
+		}, titles)
+	})
+
+	t.Run("backing out of the first host exits", func(t *testing.T) {
+		var titles []string
+		e := newScripted(t, []queryStep{{"", exploreBack}}, nil, &titles)
+		require.NoError(t, e.run(context.Background(), "1.1.1.1", nil))
+		require.Len(t, titles, 1)
+	})
+
+	t.Run("quitting from the hosts table exits", func(t *testing.T) {
+		var titles []string
+		e := newScripted(t, []queryStep{{"a", exploreSelect}}, []hostStep{{"", exploreQuit}}, &titles)
+		require.NoError(t, e.run(context.Background(), "1.1.1.1", nil))
+		require.Len(t, titles, 2)
+	})
+
+	t.Run("search errors are reported and exploring continues", func(t *testing.T) {
+		var titles []string
+		e := newScripted(t, []queryStep{{"bad", exploreSelect}, {"", exploreQuit}}, nil, &titles)
+		require.NoError(t, e.run(context.Background(), "1.1.1.1", nil))
+		require.Equal(t, []string{"1.1.1.1", "error: search failed", "1.1.1.1"}, titles)
+	})
+
+	t.Run("table errors end the explorer", func(t *testing.T) {
+		e := newScripted(t, nil, nil, new([]string))
+		e.chooseQuery = func(string, []censeye.ReportEntry) (censeye.ReportEntry, exploreAction, error) {
+			return censeye.ReportEntry{}, exploreQuit, errors.New("no tty")
+		}
+		err := e.run(context.Background(), "1.1.1.1", nil)
+		var cencliErr ExploreError
+		require.ErrorAs(t, err, &cencliErr)
+	})
+}
+
+func TestExploreHosts(t *testing.T) {
+	asn := 15169
+	hits := []assets.Asset{
+		&assets.Host{Host: components.Host{
+			IP:               strPtr("8.8.8.8"),
+			AutonomousSystem: &components.Routing{Asn: &asn, Name: strPtr("GOOGLE")},
+			Location:         &components.Location{Country: strPtr("United States")},
+		}},
+		&assets.Host{Host: components.Host{IP: strPtr("1.1.1.1")}},
+		&assets.Host{},
+	}
+	require.Equal(t, []exploreHost{
+		{IP: "8.8.8.8", ASN: "AS15169 GOOGLE", Country: "United States"},
+		{IP: "1.1.1.1"},
+	}, exploreHosts(hits))
+}
+
+func TestCenseyeCommand_Batch(t *testing.T) {
+	// 10.0.0.9 does not exist; every other host has one interesting query
+	newServices := func(t *testing.T, ctrl *gomock.Controller) (view.Service, censeye.Service) {
+		viewSvc := viewmocks.NewMockViewService(ctrl)
+		viewSvc.EXPECT().GetHosts(gomock.Any(), mo.None[identifiers.OrganizationID](), gomock.Any(), mo.None[time.Time]()).DoAndReturn(
+			func(ctx context.Context, _ mo.Option[identifiers.OrganizationID], hostIDs []assets.HostID, _ mo.Option[time.Time]) (view.HostsResult, cenclierrors.CencliError) {
+				// hosts fetched for a batch are never streamed themselves
+				require.False(t, streaming.IsStreaming(ctx))
+				require.Len(t, hostIDs, 1)
+				if hostIDs[0].String() == "10.0.0.9" {
+					return view.HostsResult{}, nil
+				}
+				return view.HostsResult{Hosts: []*assets.Host{{Host: components.Host{IP: strPtr(hostIDs[0].String())}}}}, nil
+			}).AnyTimes()
+		censeyeSvc := censeyemocks.NewMockCenseyeService(ctrl)
+		censeyeSvc.EXPECT().InvestigateHost(gomock.Any(), mo.None[identifiers.OrganizationID](), gomock.Any(), uint64(2), uint64(100)).DoAndReturn(
+			func(_ context.Context, _ mo.Option[identifiers.OrganizationID], host *assets.Host, _, _ uint64) (censeye.InvestigateHostResult, cenclierrors.CencliError) {
+				return censeye.InvestigateHostResult{Entries: []censeye.ReportEntry{
+					{Count: 1000, Query: "common"},
+					{Count: 5, Query: "ip=" + *host.IP, Interesting: true},
+				}}, nil
+			}).AnyTimes()
+		return viewSvc, censeyeSvc
+	}
+
+	execute := func(t *testing.T, stdin string, args ...string) (string, string, error) {
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+
+		var stdout, stderr bytes.Buffer
+		formatter.Stdout = &stdout
+		formatter.Stderr = &stderr
+
+		ctrl := gomock.NewController(t)
+		viewSvc, censeyeSvc := newServices(t, ctrl)
+		cmdContext := command.NewCommandContext(cfg, nil, command.WithViewService(viewSvc), command.WithCenseyeService(censeyeSvc))
+		rootCmd, err := command.RootCommandToCobra(NewCenseyeCommand(cmdContext))
+		require.NoError(t, err)
+		// censeye defines its own --output-format, so only bind the streaming global flag
+		rootCmd.PersistentFlags().BoolP(config.StreamingFlagName, "S", false, "")
+		require.NoError(t, viper.BindPFlag(config.StreamingFlagName, rootCmd.PersistentFlags().Lookup(config.StreamingFlagName)))
+		rootCmd.SetIn(strings.NewReader(stdin))
+		rootCmd.SetArgs(args)
+		cmdErr := rootCmd.Execute()
+		return stdout.String(), stderr.String(), cmdErr
+	}
+
+	t.Run("json reports in input order with per-host errors", func(t *testing.T) {
+		stdout, stderr, err := execute(t, "10.0.0.1\n10.0.0.9\n\nnot-a-host\n10.0.0.2\n",
+			"--batch", "--input-file", "-", "--output-format", "json", "--concurrency", "2")
+		require.NoError(t, err)
+
+		var reports []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(stdout), &reports))
+		require.Len(t, reports, 4)
+		for i, host := range []string{"10.0.0.1", "10.0.0.9", "not-a-host", "10.0.0.2"} {
+			require.Equal(t, host, reports[i]["host"])
+		}
+		require.Equal(t, float64(2), reports[0]["queries"])
+		require.Len(t, reports[0]["pivots"], 1)
+		require.NotContains(t, reports[0], "error")
+		require.Contains(t, reports[1]["error"], "not found")
+		require.Empty(t, reports[1]["pivots"])
+		require.NotEmpty(t, reports[2]["error"])
+		require.Contains(t, stderr, "2 of 4 host(s) failed")
+	})
+
+	t.Run("streaming prints one NDJSON object per host", func(t *testing.T) {
+		stdout, _, err := execute(t, "", "10.0.0.1,10.0.0.2,10.0.0.3", "--batch", "--streaming")
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		require.Len(t, lines, 3)
+		var hosts []string
+		for _, line := range lines {
+			var report struct {
+				Host   string                `json:"host"`
+				Pivots []censeye.ReportEntry `json:"pivots"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(line), &report))
+			require.Equal(t, []censeye.ReportEntry{{Count: 5, Query: "ip=" + report.Host, Interesting: true}}, report.Pivots)
+			hosts = append(hosts, report.Host)
+		}
+		require.ElementsMatch(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, hosts)
+	})
+
+	t.Run("short output renders each host", func(t *testing.T) {
+		stdout, _, err := execute(t, "", "10.0.0.1,10.0.0.9", "--batch")
+		require.NoError(t, err)
+		require.Contains(t, stdout, "CensEye Results for 10.0.0.1")
+		require.Contains(t, stdout, "ip=10.0.0.1")
+		require.Contains(t, stdout, "Error: host 10.0.0.9 not found")
+	})
+
+	t.Run("every host failing is an error", func(t *testing.T) {
+		stdout, _, err := execute(t, "", "10.0.0.9", "--batch", "--output-format", "json")
+		var batchErr BatchFailedError
+		require.ErrorAs(t, err, &batchErr)
+		require.Contains(t, stdout, "not found")
+	})
+
+	t.Run("streaming requires batch", func(t *testing.T) {
+		_, _, err := execute(t, "", "10.0.0.1", "--streaming")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not support streaming")
+	})
+
+	t.Run("batch conflicts with interactive", func(t *testing.T) {
+		_, _, err := execute(t, "", "10.0.0.1", "--batch", "--interactive")
+		var conflictErr flags.ConflictingFlagsError
+		require.ErrorAs(t, err, &conflictErr)
+	})
+
+	t.Run("histogram covers every host", func(t *testing.T) {
+		stdout, _, err := execute(t, "", "10.0.0.1,10.0.0.2,10.0.0.9", "--batch", "--histogram", "--output-format", "json")
+		require.NoError(t, err)
+
+		var out struct {
+			Reports   []map[string]any  `json:"reports"`
+			Histogram censeye.Histogram `json:"histogram"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+		require.Len(t, out.Reports, 3)
+		require.Equal(t, 4, out.Histogram.Queries)
+		require.Equal(t, &censeye.RarityBounds{RarityMin: 5, RarityMax: 5, MinPercentile: 10, MaxPercentile: 50}, out.Histogram.Suggested)
+
+		stdout, _, err = execute(t, "", "10.0.0.1,10.0.0.2", "--batch", "--histogram")
+		require.NoError(t, err)
+		require.Contains(t, stdout, "Distribution of counts (4 queries):")
+		require.Regexp(t, `1000-9999 \| \S+ 2\n`, stdout)
+		require.Contains(t, stdout, "Suggested bounds: --rarity-min 5 --rarity-max 5 (p10 to p50)")
+	})
+
+	t.Run("histogram conflicts with streaming", func(t *testing.T) {
+		_, _, err := execute(t, "", "10.0.0.1", "--batch", "--histogram", "--streaming")
+		var conflictErr flags.ConflictingFlagsError
+		require.ErrorAs(t, err, &conflictErr)
+	})
+
+	t.Run("concurrency is bounded", func(t *testing.T) {
+		_, _, err := execute(t, "", "10.0.0.1", "--batch", "--concurrency", "50")
+		var intErr flags.IntegerFlagInvalidValueError
+		require.ErrorAs(t, err, &intErr)
+	})
+}
+
+func TestCenseyeCommand_Resolve(t *testing.T) {
+	// example.org resolves to 10.0.0.1 and 10.0.0.2; other domains do not resolve
+	doh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") == "example.org" && r.URL.Query().Get("type") == "1" {
+			_, _ = w.Write([]byte(`{"Status": 0, "Answer": [{"type": 1, "data": "10.0.0.2"}, {"type": 1, "data": "10.0.0.1"}]}`))
+			return
+		}
+		if r.URL.Query().Get("name") == "example.org" {
+			_, _ = w.Write([]byte(`{"Status": 0}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"Status": 3}`))
+	}))
+	defer doh.Close()
+
+	execute := func(t *testing.T, args ...string) (string, string, []string, error) {
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+		viper.Set("dns.resolver", "doh")
+		viper.Set("dns.doh-url", doh.URL)
+
+		var stdout, stderr bytes.Buffer
+		formatter.Stdout = &stdout
+		formatter.Stderr = &stderr
+
+		ctrl := gomock.NewController(t)
+		var mu sync.Mutex
+		var fetched []string
+		viewSvc := viewmocks.NewMockViewService(ctrl)
+		viewSvc.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Len(1), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ mo.Option[identifiers.OrganizationID], hostIDs []assets.HostID, _ mo.Option[time.Time]) (view.HostsResult, cenclierrors.CencliError) {
+				mu.Lock()
+				fetched = append(fetched, hostIDs[0].String())
+				mu.Unlock()
+				return view.HostsResult{Hosts: []*assets.Host{{Host: components.Host{IP: strPtr(hostIDs[0].String())}}}}, nil
+			}).AnyTimes()
+		censeyeSvc := censeyemocks.NewMockCenseyeService(ctrl)
+		censeyeSvc.EXPECT().InvestigateHost(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(censeye.InvestigateHostResult{Entries: []censeye.ReportEntry{{Count: 5, Query: "q", Interesting: true}}}, nil).AnyTimes()
+		cmdContext := command.NewCommandContext(cfg, nil, command.WithViewService(viewSvc), command.WithCenseyeService(censeyeSvc))
+		rootCmd, err := command.RootCommandToCobra(NewCenseyeCommand(cmdContext))
+		require.NoError(t, err)
+		rootCmd.SetArgs(args)
+		cmdErr := rootCmd.Execute()
+		sort.Strings(fetched)
+		return stdout.String(), stderr.String(), fetched, cmdErr
+	}
+
+	t.Run("a domain is resolved to its first host", func(t *testing.T) {
+		stdout, stderr, fetched, err := execute(t, "example[.]org")
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.0.0.1"}, fetched)
+		require.Contains(t, stderr, "Resolved example.org to 10.0.0.1, 10.0.0.2")
+		require.Contains(t, stderr, "example.org resolves to 2 addresses; investigating 10.0.0.1")
+		require.Contains(t, stdout, "CensEye Results for 10.0.0.1 (resolved from example.org)")
+	})
+
+	t.Run("batch investigates every host of a domain", func(t *testing.T) {
+		stdout, _, fetched, err := execute(t, "example.org,missing.example,10.0.0.3", "--batch", "--output-format", "json")
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, fetched)
+		var reports []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(stdout), &reports))
+		require.Len(t, reports, 4)
+		require.Equal(t, "10.0.0.1", reports[0]["host"])
+		require.Equal(t, "example.org", reports[0]["resolved_from"])
+		require.Equal(t, "example.org", reports[1]["resolved_from"])
+		require.Equal(t, "missing.example", reports[2]["host"])
+		require.Contains(t, reports[2]["error"], "failed to resolve missing.example")
+		require.NotContains(t, reports[3], "resolved_from")
+	})
+
+	t.Run("no-resolve treats a domain as a web property", func(t *testing.T) {
+		_, _, fetched, err := execute(t, "example.org", "--no-resolve")
+		var unsupportedErr ErrorAssetTypeNotSupportedError
+		require.ErrorAs(t, err, &unsupportedErr)
+		require.Empty(t, fetched)
+	})
+}
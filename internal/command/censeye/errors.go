@@ -3,8 +3,11 @@ package censeye
 import (
 	"fmt"
 
+	"github.com/samber/mo"
+
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 )
 
 type (
@@ -53,15 +56,80 @@ type (
 	HostNotFoundError interface{ cenclierrors.CencliError }
 	hostNotFoundError struct {
 		hostID string
+		// collectionID is set if the host was looked up in a collection
+		collectionID mo.Option[identifiers.CollectionID]
 	}
 )
 
-func newHostNotFoundError(hostID string) HostNotFoundError { return &hostNotFoundError{hostID: hostID} }
+func newHostNotFoundError(hostID string, collectionID mo.Option[identifiers.CollectionID]) HostNotFoundError {
+	return &hostNotFoundError{hostID: hostID, collectionID: collectionID}
+}
 
 func (e *hostNotFoundError) Error() string {
+	if collectionID, ok := e.collectionID.Get(); ok {
+		return fmt.Sprintf("host %s not found in collection %s", e.hostID, collectionID)
+	}
 	return fmt.Sprintf("host %s not found", e.hostID)
 }
 
 func (e *hostNotFoundError) Title() string { return "Host Not Found" }
 
 func (e *hostNotFoundError) ShouldPrintUsage() bool { return false }
+
+// ExploreRequiresTerminalError is returned when --explore is used without a terminal.
+type (
+	ExploreRequiresTerminalError interface{ cenclierrors.CencliError }
+	exploreRequiresTerminalError struct{}
+)
+
+func newExploreRequiresTerminalError() ExploreRequiresTerminalError {
+	return &exploreRequiresTerminalError{}
+}
+
+func (e *exploreRequiresTerminalError) Error() string {
+	return "--explore is interactive and requires stdout to be a terminal"
+}
+
+func (e *exploreRequiresTerminalError) Title() string { return "Terminal Required" }
+
+func (e *exploreRequiresTerminalError) ShouldPrintUsage() bool { return false }
+
+// ExploreError is returned when the pivot explorer TUI fails.
+type (
+	ExploreError interface{ cenclierrors.CencliError }
+	exploreError struct {
+		err error
+	}
+)
+
+func newExploreError(err error) ExploreError { return &exploreError{err: err} }
+
+func (e *exploreError) Error() string {
+	return fmt.Sprintf("failed to display pivot explorer: %v", e.err)
+}
+
+func (e *exploreError) Title() string { return "Pivot Explorer Failed" }
+
+func (e *exploreError) ShouldPrintUsage() bool { return false }
+
+// BatchFailedError summarizes the hosts that failed in batch mode. Each host's
+// error is also recorded in its own report.
+type (
+	BatchFailedError interface{ cenclierrors.CencliError }
+	batchFailedError struct {
+		failed int
+		total  int
+	}
+)
+
+func newBatchFailedError(failed, total int) BatchFailedError {
+	return &batchFailedError{failed: failed, total: total}
+}
+
+func (e *batchFailedError) Error() string {
+	return fmt.Sprintf("%d of %d host(s) failed to investigate; see the error field of each report", e.failed, e.total)
+}
+
+func (e *batchFailedError) Title() string { return "Some Hosts Failed" }
+
+func (e *batchFailedError) ShouldPrintUsage() bool { return false }
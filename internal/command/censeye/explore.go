@@ -0,0 +1,262 @@
+package censeye
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/term"
+	"github.com/censys/cencli/internal/pkg/ui/table"
+)
+
+// explorePageSize is the number of hosts fetched when running a pivot query.
+const explorePageSize = 100
+
+// exploreFields limits pivot searches to what is shown in the hosts table.
+var exploreFields = []string{
+	"host.ip",
+	"host.autonomous_system.asn",
+	"host.autonomous_system.name",
+	"host.location.country",
+}
+
+// exploreAction is what the user chose to do in an explorer table.
+type exploreAction int
+
+const (
+	exploreQuit exploreAction = iota
+	exploreBack
+	exploreSelect
+)
+
+// exploreHost is a host returned by a pivot query.
+type exploreHost struct {
+	IP      string
+	ASN     string
+	Country string
+}
+
+// pivotFrame is one step of the breadcrumb trail: a host that was
+// investigated, and the query that led to it (empty for the first host).
+type pivotFrame struct {
+	hostID  string
+	via     string
+	entries []censeye.ReportEntry
+}
+
+// explorer lets the user pivot interactively: pick a query, pick a host
+// matching it, and investigate that host, building a trail of pivots.
+// The choose functions display a table and return the user's choice;
+// they are fields so that tests can script the navigation.
+type explorer struct {
+	search      func(ctx context.Context, query string) ([]exploreHost, cenclierrors.CencliError)
+	investigate func(ctx context.Context, hostID string) ([]censeye.ReportEntry, cenclierrors.CencliError)
+	chooseQuery func(title string, entries []censeye.ReportEntry) (censeye.ReportEntry, exploreAction, error)
+	chooseHost  func(title string, hosts []exploreHost) (exploreHost, exploreAction, error)
+	// reportError shows an error without leaving the explorer
+	reportError func(err cenclierrors.CencliError)
+}
+
+// newExplorer returns an explorer backed by the command's services and the table TUI.
+func (c *Command) newExplorer(logger *slog.Logger) *explorer {
+	return &explorer{
+		search: func(ctx context.Context, query string) ([]exploreHost, cenclierrors.CencliError) {
+			var hosts []exploreHost
+			err := c.WithProgress(ctx, logger, fmt.Sprintf("Searching %s...", query), func(pctx context.Context) cenclierrors.CencliError {
+				res, searchErr := c.searchSvc.Search(pctx, search.Params{
+					OrgID:    c.orgID,
+					Query:    query,
+					Fields:   exploreFields,
+					PageSize: mo.Some[uint64](explorePageSize),
+					MaxPages: mo.Some[uint64](1),
+				})
+				if searchErr != nil {
+					return searchErr
+				}
+				hosts = exploreHosts(res.Hits)
+				return nil
+			})
+			return hosts, err
+		},
+		investigate: func(ctx context.Context, hostID string) ([]censeye.ReportEntry, cenclierrors.CencliError) {
+			var entries []censeye.ReportEntry
+			err := c.WithProgress(ctx, logger, fmt.Sprintf("Investigating host %s...", hostID), func(pctx context.Context) cenclierrors.CencliError {
+				res, investigateErr := c.investigate(pctx, hostID)
+				if investigateErr != nil {
+					return investigateErr
+				}
+				entries = res.Entries
+				return nil
+			})
+			return entries, err
+		},
+		chooseQuery: chooseQuery,
+		chooseHost:  chooseHost,
+		reportError: func(err cenclierrors.CencliError) { formatter.PrintError(err, nil) },
+	}
+}
+
+// run starts exploring from the given host and returns when the user quits,
+// or backs out of the first host.
+func (e *explorer) run(ctx context.Context, hostID string, entries []censeye.ReportEntry) cenclierrors.CencliError {
+	trail := []pivotFrame{{hostID: hostID, entries: entries}}
+	for len(trail) > 0 {
+		frame := trail[len(trail)-1]
+		entry, action, err := e.chooseQuery(breadcrumb(trail, ""), sortByCount(frame.entries))
+		if err != nil {
+			return newExploreError(err)
+		}
+		switch action {
+		case exploreQuit:
+			return nil
+		case exploreBack:
+			trail = trail[:len(trail)-1]
+			continue
+		}
+
+		hosts, searchErr := e.search(ctx, entry.Query)
+		if searchErr != nil {
+			e.reportError(searchErr)
+			continue
+		}
+		next, quit, pickErr := e.pickHost(ctx, breadcrumb(trail, entry.Query), entry.Query, hosts)
+		if pickErr != nil {
+			return pickErr
+		}
+		if quit {
+			return nil
+		}
+		if next != nil {
+			trail = append(trail, *next)
+		}
+	}
+	return nil
+}
+
+// pickHost shows the hosts matching a query until the user investigates one
+// (returned as the next frame), goes back (nil frame), or quits.
+func (e *explorer) pickHost(ctx context.Context, title, query string, hosts []exploreHost) (*pivotFrame, bool, cenclierrors.CencliError) {
+	for {
+		host, action, err := e.chooseHost(title, hosts)
+		if err != nil {
+			return nil, false, newExploreError(err)
+		}
+		switch action {
+		case exploreQuit:
+			return nil, true, nil
+		case exploreBack:
+			return nil, false, nil
+		}
+		entries, investigateErr := e.investigate(ctx, host.IP)
+		if investigateErr != nil {
+			e.reportError(investigateErr)
+			continue
+		}
+		return &pivotFrame{hostID: host.IP, via: query, entries: entries}, false, nil
+	}
+}
+
+// breadcrumb renders the trail of pivots, e.g. "1.1.1.1 › <query> › 2.2.2.2",
+// optionally followed by the query currently being explored.
+func breadcrumb(trail []pivotFrame, query string) string {
+	sep := " " + term.Glyph("›", ">") + " "
+	parts := make([]string, 0, 2*len(trail)+1)
+	for _, f := range trail {
+		if f.via != "" {
+			parts = append(parts, f.via)
+		}
+		parts = append(parts, f.hostID)
+	}
+	if query != "" {
+		parts = append(parts, query)
+	}
+	return strings.Join(parts, sep)
+}
+
+// exploreHosts extracts the hosts from search hits.
+func exploreHosts(hits []assets.Asset) []exploreHost {
+	res := make([]exploreHost, 0, len(hits))
+	for _, hit := range hits {
+		h, ok := hit.(*assets.Host)
+		if !ok || h.IP == nil {
+			continue
+		}
+		host := exploreHost{IP: *h.IP}
+		if as := h.AutonomousSystem; as != nil {
+			var parts []string
+			if as.Asn != nil {
+				parts = append(parts, "AS"+strconv.Itoa(*as.Asn))
+			}
+			if as.Name != nil {
+				parts = append(parts, *as.Name)
+			}
+			host.ASN = strings.Join(parts, " ")
+		}
+		if loc := h.Location; loc != nil && loc.Country != nil {
+			host.Country = *loc.Country
+		}
+		res = append(res, host)
+	}
+	return res
+}
+
+// chooseQuery shows the queries for a host and waits for the user to pick one.
+func chooseQuery(title string, entries []censeye.ReportEntry) (censeye.ReportEntry, exploreAction, error) {
+	var chosen censeye.ReportEntry
+	action := exploreQuit
+	tbl := table.NewTable[censeye.ReportEntry](
+		[]string{"Count", "!", "Query"},
+		func(entry censeye.ReportEntry) []string {
+			indicator := " "
+			if entry.Interesting {
+				indicator = "*"
+			}
+			return []string{formatter.FormatInt(entry.Count), indicator, entry.Query}
+		},
+		table.WithColumnWidths[censeye.ReportEntry]([]int{15, 3, 80}),
+		table.WithTitle[censeye.ReportEntry](title),
+		table.WithSelectFunc[censeye.ReportEntry](func(entry censeye.ReportEntry) {
+			chosen, action = entry, exploreSelect
+		}),
+		table.WithSelectDescription[censeye.ReportEntry]("search for matching hosts"),
+		table.WithKeyActions([]table.KeyAction[censeye.ReportEntry]{{
+			Key:         "b",
+			Description: "go back",
+			Action:      func(censeye.ReportEntry) { action = exploreBack },
+		}}),
+	)
+	err := tbl.Run(entries)
+	return chosen, action, err
+}
+
+// chooseHost shows the hosts matching a query and waits for the user to pick one.
+func chooseHost(title string, hosts []exploreHost) (exploreHost, exploreAction, error) {
+	var chosen exploreHost
+	action := exploreQuit
+	tbl := table.NewTable[exploreHost](
+		[]string{"IP", "Autonomous System", "Country"},
+		func(h exploreHost) []string { return []string{h.IP, h.ASN, h.Country} },
+		table.WithColumnWidths[exploreHost]([]int{40, 45, 20}),
+		table.WithTitle[exploreHost](fmt.Sprintf("%s (%d hosts)", title, len(hosts))),
+		table.WithSelectFunc[exploreHost](func(h exploreHost) {
+			chosen, action = h, exploreSelect
+		}),
+		table.WithSelectDescription[exploreHost]("run censeye on the host"),
+		table.WithKeyActions([]table.KeyAction[exploreHost]{{
+			Key:         "b",
+			Description: "go back",
+			Action:      func(exploreHost) { action = exploreBack },
+		}}),
+	)
+	err := tbl.Run(hosts)
+	return chosen, action, err
+}
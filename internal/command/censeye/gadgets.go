@@ -0,0 +1,53 @@
+package censeye
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+const gadgetsFlagName = "gadgets"
+
+// gadgetOutput is the data output of a single host when gadgets are enabled.
+type gadgetOutput struct {
+	Entries     []censeye.ReportEntry `json:"entries"`
+	Annotations []censeye.Annotation  `json:"annotations"`
+	Histogram   *censeye.Histogram    `json:"histogram,omitempty"`
+}
+
+// parseGadgets instantiates the gadgets named by --gadgets, or by the
+// censeye.gadgets config key if the flag is not set.
+func (c *Command) parseGadgets(cmd *cobra.Command) cenclierrors.CencliError {
+	names := c.Config().Censeye.Gadgets
+	if cmd.Flags().Changed(gadgetsFlagName) {
+		var err cenclierrors.CencliError
+		if names, err = c.flags.gadgets.Value(); err != nil {
+			return err
+		}
+	}
+	var err cenclierrors.CencliError
+	c.gadgets, err = censeye.NewGadgets(names, censeye.GadgetOptions{
+		VirusTotalAPIKey: c.Config().Censeye.VirusTotalAPIKey,
+		HTTPClient:       &http.Client{Timeout: c.Config().Timeouts.HTTP},
+	})
+	return err
+}
+
+// renderAnnotations renders the notes of the gadgets.
+func renderAnnotations(annotations []censeye.Annotation) string {
+	if len(annotations) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("Gadgets:\n")
+	for _, a := range annotations {
+		sb.WriteString(fmt.Sprintf("  - [%s] %s\n", a.Gadget, a.Text))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
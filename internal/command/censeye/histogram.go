@@ -0,0 +1,113 @@
+package censeye
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
+)
+
+const (
+	histogramFlagName = "histogram"
+	// histogramBarWidth is the width of the bar of the largest bucket.
+	histogramBarWidth = 40
+)
+
+// histogramOutput is the data output of a single host with --histogram.
+type histogramOutput struct {
+	Entries   []censeye.ReportEntry `json:"entries"`
+	Histogram censeye.Histogram     `json:"histogram"`
+}
+
+// batchHistogramOutput is the data output of --batch with --histogram. The
+// histogram covers the queries of every host that was investigated.
+type batchHistogramOutput struct {
+	Reports   []hostReport      `json:"reports"`
+	Histogram censeye.Histogram `json:"histogram"`
+}
+
+// validateHistogram checks that --histogram can be used with the other flags.
+// The histogram is printed with the report, so it needs the whole result at once.
+func (c *Command) validateHistogram() cenclierrors.CencliError {
+	if !c.histogram {
+		return nil
+	}
+	if c.interactive {
+		return flags.NewConflictingFlagsError(histogramFlagName, "interactive")
+	}
+	if c.explore {
+		return flags.NewConflictingFlagsError(histogramFlagName, "explore")
+	}
+	if c.Config().Streaming {
+		return flags.NewConflictingFlagsError(histogramFlagName, "streaming")
+	}
+	return nil
+}
+
+// batchHistogram returns the histogram of the queries of every host of the batch.
+func (c *Command) batchHistogram() censeye.Histogram {
+	var entries []censeye.ReportEntry
+	for _, report := range c.reports {
+		entries = append(entries, report.entries...)
+	}
+	return censeye.NewHistogram(entries)
+}
+
+// renderHistogram renders a histogram as a bar chart, followed by its
+// percentiles and the suggested rarity bounds.
+func renderHistogram(h censeye.Histogram) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Distribution of counts (%d queries):\n", h.Queries))
+	if h.Queries == 0 {
+		sb.WriteString("  No queries to suggest rarity bounds from.\n\n")
+		return sb.String()
+	}
+
+	labels := make([]string, len(h.Buckets))
+	var labelWidth, largest int
+	for i, b := range h.Buckets {
+		labels[i] = fmt.Sprintf("%d-%d", b.Min, b.Max)
+		labelWidth = max(labelWidth, len(labels[i]))
+		largest = max(largest, b.Queries)
+	}
+	bar := term.Glyph("█", "#")
+	barStyle := styles.NewStyle(styles.ColorTeal)
+	for i, b := range h.Buckets {
+		width := b.Queries * histogramBarWidth / largest
+		if width == 0 && b.Queries > 0 {
+			width = 1
+		}
+		sb.WriteString(fmt.Sprintf("  %*s | ", labelWidth, labels[i]))
+		if width > 0 {
+			sb.WriteString(renderStyled(barStyle, strings.Repeat(bar, width)) + " ")
+		}
+		sb.WriteString(fmt.Sprintf("%d\n", b.Queries))
+	}
+
+	percentiles := make([]string, len(h.Percentiles))
+	for i, p := range h.Percentiles {
+		percentiles[i] = fmt.Sprintf("p%d=%d", p.Percent, p.Count)
+	}
+	sb.WriteString(fmt.Sprintf("Percentiles: %s\n", strings.Join(percentiles, " ")))
+
+	s := h.Suggested
+	suggestion := fmt.Sprintf("--rarity-min %d --rarity-max %d", s.RarityMin, s.RarityMax)
+	sb.WriteString(fmt.Sprintf("Suggested bounds: %s (p%d to p%d)\n\n",
+		renderStyled(styles.NewStyle(styles.ColorOrange), suggestion), s.MinPercentile, s.MaxPercentile))
+	return sb.String()
+}
+
+// renderStyled renders s with style when stdout is a terminal.
+func renderStyled(style lipgloss.Style, s string) string {
+	if formatter.StdoutIsTTY() {
+		return style.Render(s)
+	}
+	return s
+}
@@ -3,7 +3,6 @@ package censeye
 import (
 	"fmt"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/censys/cencli/internal/app/censeye"
@@ -25,19 +24,12 @@ func (c *Command) showInteractiveTable(result censeye.InvestigateHostResult) cen
 	}
 
 	// Sort entries in ascending order by count for interactive display
-	entries := make([]censeye.ReportEntry, len(result.Entries))
-	copy(entries, result.Entries)
-	sort.Slice(entries, func(i, j int) bool {
-		if entries[i].Count == entries[j].Count {
-			return entries[i].Query < entries[j].Query
-		}
-		return entries[i].Count < entries[j].Count
-	})
+	entries := sortByCount(result.Entries)
 
 	tbl := table.NewTable[censeye.ReportEntry](
 		[]string{"Count", "!", "Query"},
 		func(entry censeye.ReportEntry) []string {
-			count := strconv.FormatInt(entry.Count, 10)
+			count := formatter.FormatInt(entry.Count)
 			indicator := " "
 			if entry.Interesting {
 				indicator = "*"
@@ -45,7 +37,7 @@ func (c *Command) showInteractiveTable(result censeye.InvestigateHostResult) cen
 			return []string{count, indicator, entry.Query}
 		},
 		table.WithColumnWidths[censeye.ReportEntry]([]int{15, 3, 80}),
-		table.WithTitle[censeye.ReportEntry](fmt.Sprintf("CensEye Results for %s", c.hostID)),
+		table.WithTitle[censeye.ReportEntry](fmt.Sprintf("CensEye Results for %s", c.hostLabel(c.hostID))),
 		table.WithSelectFunc[censeye.ReportEntry](func(entry censeye.ReportEntry) {
 			if entry.SearchURL != "" {
 				_ = browser.Open(entry.SearchURL)
@@ -64,14 +56,28 @@ func (c *Command) showInteractiveTable(result censeye.InvestigateHostResult) cen
 	return nil
 }
 
+// sortByCount returns a copy of entries sorted in ascending order by count, then query.
+func sortByCount(entries []censeye.ReportEntry) []censeye.ReportEntry {
+	res := make([]censeye.ReportEntry, len(entries))
+	copy(res, entries)
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count == res[j].Count {
+			return res[i].Query < res[j].Query
+		}
+		return res[i].Count < res[j].Count
+	})
+	return res
+}
+
 // showRawTable renders a non-interactive table with all results, followed by a pivots section
 // and a summary line showing how many queries fell within the rarity bounds.
 func (c *Command) showRawTable(result censeye.InvestigateHostResult) cenclierrors.CencliError {
-	output := renderTableOutput(c.hostID, result.Entries)
+	output := renderTableOutput(c.hostLabel(c.hostID), result.Entries)
 	fmt.Fprint(formatter.Stdout, output)
 	// render pivots output
 	pivotsOutput := renderPivots(result.Entries)
 	fmt.Fprint(formatter.Stdout, pivotsOutput)
+	fmt.Fprint(formatter.Stdout, renderAnnotations(result.Annotations))
 	// summary line
 	var interesting int
 	for _, e := range result.Entries {
@@ -81,6 +87,9 @@ func (c *Command) showRawTable(result censeye.InvestigateHostResult) cenclierror
 	}
 	fmt.Fprintf(formatter.Stdout, "Found %d interesting of %d within [%d,%d].\n",
 		interesting, len(result.Entries), c.rarityMin, c.rarityMax)
+	if c.histogram {
+		fmt.Fprint(formatter.Stdout, "\n"+renderHistogram(censeye.NewHistogram(result.Entries)))
+	}
 	return nil
 }
 
@@ -100,12 +109,13 @@ func renderTableOutput(hostID string, entries []censeye.ReportEntry) string {
 		{
 			Title: "Count",
 			String: func(e censeye.ReportEntry) string {
-				return fmt.Sprintf("%d", e.Count)
+				return formatter.FormatInt(e.Count)
 			},
 			Style: func(s string, e censeye.ReportEntry) string {
 				return styles.NewStyle(styles.ColorOffWhite).Render(s)
 			},
 			AlignRight: true,
+			NoTruncate: true,
 		},
 		{
 			Title: "Query",
@@ -120,10 +130,13 @@ func renderTableOutput(hostID string, entries []censeye.ReportEntry) string {
 				val := renderLink(e.Query, e.SearchURL)
 				if e.Interesting {
 					val = "* " + val
-					return styles.NewStyle(styles.ColorOrange).Render(val)
+					return styles.GlobalStyles.Interesting.Render(val)
 				}
-				return styles.NewStyle(styles.ColorTeal).Render(val)
+				return styles.GlobalStyles.Uninteresting.Render(val)
 			},
+			// the styled cell is rendered from the query itself, so it cannot be truncated
+			Priority:   1,
+			NoTruncate: true,
 		},
 	}
 
@@ -131,6 +144,7 @@ func renderTableOutput(hostID string, entries []censeye.ReportEntry) string {
 		columns,
 		rawtable.WithHeaderStyle[censeye.ReportEntry](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
 		rawtable.WithStylesDisabled[censeye.ReportEntry](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[censeye.ReportEntry](formatter.TableWidth()),
 	)
 
 	sb.WriteString(table.Render(entries))
@@ -0,0 +1,65 @@
+package certs
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// Command is the parent certs command that groups certificate-related subcommands.
+type Command struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*Command)(nil)
+
+// NewCertsCommand creates a new certs command with all subcommands.
+func NewCertsCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return "certs"
+}
+
+func (c *Command) Short() string {
+	return "Monitor certificates issued for your domains and report expiring certificates"
+}
+
+func (c *Command) Long() string {
+	return `Monitor certificates issued for your domains and report expiring certificates.
+
+To look up individual certificates by fingerprint, use: censys view <sha256>`
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	return c.AddSubCommands(
+		newWatchCommand(c.Context),
+		newExpiringCommand(c.Context),
+	)
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	// Parent command shows help when run without subcommands
+	if err := cmd.Help(); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package certs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+type NotifyError interface {
+	cenclierrors.CencliError
+}
+
+type notifyError struct {
+	err error
+}
+
+var _ NotifyError = &notifyError{}
+
+func newNotifyError(err error) NotifyError {
+	return &notifyError{err: err}
+}
+
+func (e *notifyError) Error() string {
+	return fmt.Sprintf("%s; new certificates will be reported again on the next run", e.err)
+}
+
+func (e *notifyError) Title() string { return "Notification Failed" }
+
+func (e *notifyError) ShouldPrintUsage() bool { return false }
+
+func (e *notifyError) Unwrap() error { return e.err }
+
+type IntervalTooShortError interface {
+	cenclierrors.CencliError
+}
+
+type intervalTooShortError struct {
+	interval time.Duration
+}
+
+var _ IntervalTooShortError = &intervalTooShortError{}
+
+func newIntervalTooShortError(interval time.Duration) IntervalTooShortError {
+	return &intervalTooShortError{interval: interval}
+}
+
+func (e *intervalTooShortError) Error() string {
+	return fmt.Sprintf("--interval must be at least 1m, got %s", e.interval)
+}
+
+func (e *intervalTooShortError) Title() string { return "Invalid Interval" }
+
+func (e *intervalTooShortError) ShouldPrintUsage() bool { return true }
+
+type StoreUnavailableError interface {
+	cenclierrors.CencliError
+}
+
+type storeUnavailableError struct{}
+
+var _ StoreUnavailableError = &storeUnavailableError{}
+
+func newStoreUnavailableError() StoreUnavailableError {
+	return &storeUnavailableError{}
+}
+
+func (e *storeUnavailableError) Error() string {
+	return "the local store is required to remember reported certificates, but it is not available"
+}
+
+func (e *storeUnavailableError) Title() string { return "Store Unavailable" }
+
+func (e *storeUnavailableError) ShouldPrintUsage() bool { return false }
+
+type NoCertificatesError interface {
+	cenclierrors.CencliError
+}
+
+type noCertificatesError struct{}
+
+var _ NoCertificatesError = &noCertificatesError{}
+
+func newNoCertificatesError() NoCertificatesError {
+	return &noCertificatesError{}
+}
+
+func (e *noCertificatesError) Error() string {
+	return "provide certificate fingerprints as an argument or with --input-file, or a search with --query"
+}
+
+func (e *noCertificatesError) Title() string { return "No Certificates" }
+
+func (e *noCertificatesError) ShouldPrintUsage() bool { return true }
+
+type QueryAndFingerprintsError interface {
+	cenclierrors.CencliError
+}
+
+type queryAndFingerprintsError struct{}
+
+var _ QueryAndFingerprintsError = &queryAndFingerprintsError{}
+
+func newQueryAndFingerprintsError() QueryAndFingerprintsError {
+	return &queryAndFingerprintsError{}
+}
+
+func (e *queryAndFingerprintsError) Error() string {
+	return "cannot use --query and certificate fingerprints together"
+}
+
+func (e *queryAndFingerprintsError) Title() string { return "Conflicting Arguments" }
+
+func (e *queryAndFingerprintsError) ShouldPrintUsage() bool { return true }
+
+type NotCertificateError interface {
+	cenclierrors.CencliError
+}
+
+type notCertificateError struct {
+	assetType assets.AssetType
+}
+
+var _ NotCertificateError = &notCertificateError{}
+
+func newNotCertificateError(assetType assets.AssetType) NotCertificateError {
+	return &notCertificateError{assetType: assetType}
+}
+
+func (e *notCertificateError) Error() string {
+	return fmt.Sprintf("expected certificate SHA-256 fingerprints, got %s assets", e.assetType)
+}
+
+func (e *notCertificateError) Title() string { return "Unsupported Asset Type" }
+
+func (e *notCertificateError) ShouldPrintUsage() bool { return true }
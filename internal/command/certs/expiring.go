@@ -0,0 +1,376 @@
+package certs
+
+import (
+	"context"
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/certwatch"
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/input"
+)
+
+const (
+	expiringCmdName = "certs expiring"
+
+	defaultExpiringWithin = 30 * 24 * time.Hour
+)
+
+// expiringCommand implements `certs expiring`, which reports the certificates
+// from a search or a list of fingerprints that expire within a time window.
+type expiringCommand struct {
+	*command.BaseCommand
+	// services the command uses
+	certWatchSvc certwatch.Service
+	viewSvc      view.Service
+	// flags the command uses
+	flags expiringCommandFlags
+	// state - populated by PreRun
+	orgID          mo.Option[identifiers.OrganizationID]
+	query          string
+	fingerprints   []assets.CertificateID
+	within         time.Duration
+	includeExpired bool
+	pageSize       uint64
+	maxPages       uint64
+	csv            bool
+	// now is replaced in tests
+	now func() time.Time
+	// report stores the result for rendering
+	report ExpiryReport
+}
+
+type expiringCommandFlags struct {
+	query          flags.StringFlag
+	inputFile      flags.FileFlag
+	orgID          flags.OrgIDFlag
+	within         flags.HumanDurationFlag
+	includeExpired flags.BoolFlag
+	pageSize       flags.IntegerFlag
+	maxPages       flags.IntegerFlag
+	csv            flags.BoolFlag
+}
+
+// ExpiryReport lists the certificates that expire within a window, soonest first.
+type ExpiryReport struct {
+	// Query is the Censys query that was run, if any.
+	Query string `json:"query,omitempty"`
+	// Checked is the number of certificates that were fetched.
+	Checked int `json:"checked"`
+	// ExpiringWithin is the window, e.g. "30d".
+	ExpiringWithin string                `json:"expiring_within"`
+	Certificates   []ExpiringCertificate `json:"certificates"`
+}
+
+// ExpiringCertificate is a row of the expiry report.
+type ExpiringCertificate struct {
+	FingerprintSHA256 string   `json:"fingerprint_sha256"`
+	CommonName        string   `json:"common_name,omitempty"`
+	Names             []string `json:"names,omitempty"`
+	IssuerDN          string   `json:"issuer_dn,omitempty"`
+	NotAfter          string   `json:"not_after"`
+	// DaysLeft is negative for certificates that have already expired.
+	DaysLeft int `json:"days_left"`
+
+	notAfter time.Time
+}
+
+var _ command.Command = (*expiringCommand)(nil)
+
+func newExpiringCommand(cmdContext *command.Context) *expiringCommand {
+	return &expiringCommand{
+		BaseCommand: command.NewBaseCommand(cmdContext),
+		now:         cmdContext.Now,
+	}
+}
+
+func (c *expiringCommand) Use() string { return "expiring [sha256...]" }
+
+func (c *expiringCommand) Short() string {
+	return "Report certificates that expire soon"
+}
+
+func (c *expiringCommand) Long() string {
+	return `Report the certificates that expire within a time window, soonest first, with their
+common name, names (SANs), issuer, expiry date, and days left.
+
+Certificates come from a search (--query) or from a list of SHA-256 fingerprints given
+as an argument or with --input-file. Only the certificates that the search or lookup
+returns are checked, so narrow the query to the certificates you care about.
+
+Use --csv to write the report as CSV, e.g. for a spreadsheet.`
+}
+
+func (c *expiringCommand) Examples() []string {
+	return []string{
+		`--query 'cert.names: "example.com"'`,
+		`--query 'cert.names: "example.com"' --expiring-within 2w --csv > expiring.csv`,
+		"--input-file fingerprints.txt --expiring-within 90d",
+		"--input-file fingerprints.txt --include-expired",
+	}
+}
+
+func (c *expiringCommand) Args() command.PositionalArgs { return command.RangeArgs(0, 1) }
+
+func (c *expiringCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *expiringCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *expiringCommand) Init() error {
+	c.flags.query = flags.NewStringFlag(c.Flags(), false, "query", "", "", "search for the certificates to check (e.g. 'cert.names: \"example.com\"')")
+	c.flags.inputFile = flags.NewFileFlag(c.Flags(), false, "input-file", "i", "file to read certificate fingerprints from, one per line. Overrides the positional argument.")
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.within = flags.NewHumanDurationFlag(c.Flags(), false, "expiring-within", "w", mo.Some(defaultExpiringWithin), "report certificates that expire within this window (e.g. 30d, 2w). Defaults to 30d")
+	c.flags.includeExpired = flags.NewBoolFlag(c.Flags(), "include-expired", "", false, "also report certificates that have already expired")
+	c.flags.pageSize = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"page-size",
+		"n",
+		mo.Some[int64](defaultWatchPageSize),
+		"number of certificates to fetch per page (with --query)",
+		mo.Some[int64](1),
+		mo.None[int64](),
+	)
+	c.flags.maxPages = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"max-pages",
+		"p",
+		mo.Some[int64](defaultWatchMaxPages),
+		"maximum number of pages to fetch (with --query)",
+		mo.Some[int64](1),
+		mo.None[int64](),
+	)
+	c.flags.csv = flags.NewBoolFlag(c.Flags(), "csv", "", false, "write the report as CSV")
+	return nil
+}
+
+func (c *expiringCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.orgID, err = c.flags.orgID.Value(); err != nil {
+		return err
+	}
+	within, err := c.flags.within.Value()
+	if err != nil {
+		return err
+	}
+	c.within = within.OrElse(defaultExpiringWithin)
+	if c.includeExpired, err = c.flags.includeExpired.Value(); err != nil {
+		return err
+	}
+	pageSize, err := c.flags.pageSize.Value()
+	if err != nil {
+		return err
+	}
+	c.pageSize = uint64(pageSize.OrElse(defaultWatchPageSize))
+	maxPages, err := c.flags.maxPages.Value()
+	if err != nil {
+		return err
+	}
+	c.maxPages = uint64(maxPages.OrElse(defaultWatchMaxPages))
+	if c.csv, err = c.flags.csv.Value(); err != nil {
+		return err
+	}
+	if c.csv && cmd.Flags().Changed(formatter.OutputFormatFlagName) {
+		return flags.NewConflictingFlagsError("csv", formatter.OutputFormatFlagName)
+	}
+
+	query, err := c.flags.query.Value()
+	if err != nil {
+		return err
+	}
+	c.query = strings.TrimSpace(query)
+	if c.query != "" {
+		if c.flags.inputFile.IsSet() {
+			return flags.NewConflictingFlagsError("query", "input-file")
+		}
+		if len(args) > 0 {
+			return newQueryAndFingerprintsError()
+		}
+		c.certWatchSvc, err = c.CertWatchService()
+		return err
+	}
+
+	if err := c.parseFingerprints(cmd, args); err != nil {
+		return err
+	}
+	c.viewSvc, err = c.ViewService()
+	return err
+}
+
+// parseFingerprints reads the certificate fingerprints from --input-file or the argument.
+func (c *expiringCommand) parseFingerprints(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var raw []string
+	switch {
+	case c.flags.inputFile.IsSet():
+		records, err := command.InputRecords(cmd, c.flags.inputFile)
+		if err != nil {
+			return err
+		}
+		raw = input.RecordValues(records)
+	case len(args) > 0:
+		raw = input.SplitString(args[0])
+	default:
+		return newNoCertificatesError()
+	}
+	classifier := assets.NewAssetClassifier(raw...)
+	assetType, err := classifier.AssetType()
+	if err != nil {
+		return err
+	}
+	if assetType != assets.AssetTypeCertificate {
+		return newNotCertificateError(assetType)
+	}
+	c.fingerprints = classifier.CertificateIDs()
+	return nil
+}
+
+func (c *expiringCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(expiringCmdName).With(
+		"query_set", c.query != "",
+		"fingerprints", len(c.fingerprints),
+		"within", c.within,
+	)
+
+	var certs []certwatch.ObservedCertificate
+	err := c.WithProgress(
+		cmd.Context(),
+		logger,
+		"Fetching certificates...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			var fetchErr cenclierrors.CencliError
+			certs, fetchErr = c.fetchCertificates(pctx)
+			return fetchErr
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	c.report = buildExpiryReport(certs, c.now(), c.within, c.includeExpired)
+	c.report.Query = c.query
+	logger.Debug("expiry checked", "checked", c.report.Checked, "expiring", len(c.report.Certificates))
+
+	if c.csv {
+		return c.renderCSV()
+	}
+	return c.PrintData(c, c.report)
+}
+
+// fetchCertificates runs the search or looks up the fingerprints.
+func (c *expiringCommand) fetchCertificates(ctx context.Context) ([]certwatch.ObservedCertificate, cenclierrors.CencliError) {
+	if c.query != "" {
+		result, err := c.certWatchSvc.FindCertificates(ctx, certwatch.Params{
+			OrgID:    c.orgID,
+			Query:    c.query,
+			PageSize: mo.Some(c.pageSize),
+			MaxPages: c.maxPages,
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.PrintAppResponseMeta(result.Meta)
+		return result.Certificates, nil
+	}
+
+	result, err := c.viewSvc.GetCertificates(ctx, c.orgID, c.fingerprints)
+	if err != nil {
+		return nil, err
+	}
+	c.PrintAppResponseMeta(result.Meta)
+	if result.PartialError != nil {
+		return nil, result.PartialError
+	}
+	certs := make([]certwatch.ObservedCertificate, 0, len(result.Certificates))
+	for _, cert := range result.Certificates {
+		certs = append(certs, certwatch.Summarize(cert.Certificate))
+	}
+	return certs, nil
+}
+
+// buildExpiryReport keeps the certificates that expire between now and now+within
+// (or before now+within, if includeExpired), sorted by soonest expiry.
+// Certificates without a parseable expiry date are skipped.
+func buildExpiryReport(certs []certwatch.ObservedCertificate, now time.Time, within time.Duration, includeExpired bool) ExpiryReport {
+	report := ExpiryReport{
+		Checked:        len(certs),
+		ExpiringWithin: flags.FormatHumanDuration(within),
+		Certificates:   []ExpiringCertificate{},
+	}
+	deadline := now.Add(within)
+	for _, cert := range certs {
+		notAfter, err := time.Parse(time.RFC3339, cert.NotAfter)
+		if err != nil {
+			continue
+		}
+		if notAfter.After(deadline) || (!includeExpired && notAfter.Before(now)) {
+			continue
+		}
+		report.Certificates = append(report.Certificates, ExpiringCertificate{
+			FingerprintSHA256: cert.FingerprintSHA256,
+			CommonName:        cert.CommonName,
+			Names:             cert.Names,
+			IssuerDN:          cert.IssuerDN,
+			NotAfter:          cert.NotAfter,
+			DaysLeft:          daysLeft(now, notAfter),
+			notAfter:          notAfter,
+		})
+	}
+	sort.SliceStable(report.Certificates, func(i, j int) bool {
+		return report.Certificates[i].notAfter.Before(report.Certificates[j].notAfter)
+	})
+	return report
+}
+
+// daysLeft returns the number of whole days until notAfter, rounded down,
+// so that a certificate expiring later today has 0 days left.
+func daysLeft(now, notAfter time.Time) int {
+	d := notAfter.Sub(now)
+	days := int(d / (24 * time.Hour))
+	if d < 0 && d%(24*time.Hour) != 0 {
+		days--
+	}
+	return days
+}
+
+// expiryCSVHeader is the header row of the CSV report.
+var expiryCSVHeader = []string{"fingerprint_sha256", "common_name", "names", "issuer_dn", "not_after", "days_left"}
+
+// renderCSV writes the report as CSV. Names are separated by semicolons.
+func (c *expiringCommand) renderCSV() cenclierrors.CencliError {
+	w := csv.NewWriter(formatter.Stdout)
+	rows := [][]string{expiryCSVHeader}
+	for _, cert := range c.report.Certificates {
+		rows = append(rows, []string{
+			cert.FingerprintSHA256,
+			cert.CommonName,
+			strings.Join(cert.Names, ";"),
+			cert.IssuerDN,
+			cert.NotAfter,
+			strconv.Itoa(cert.DaysLeft),
+		})
+	}
+	return cenclierrors.NewCencliError(w.WriteAll(rows))
+}
+
+func (c *expiringCommand) RenderShort() cenclierrors.CencliError {
+	formatter.Println(formatter.Stdout, renderExpiryReport(c.report, c.within))
+	return nil
+}
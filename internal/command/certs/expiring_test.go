@@ -0,0 +1,180 @@
+package certs
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	certwatchmocks "github.com/censys/cencli/gen/app/certwatch/mocks"
+	viewmocks "github.com/censys/cencli/gen/app/view/mocks"
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/app/certwatch"
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+var expiringNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func observed(fp, cn, notAfter string) certwatch.ObservedCertificate {
+	return certwatch.ObservedCertificate{
+		FingerprintSHA256: fp,
+		CommonName:        cn,
+		Names:             []string{cn, "alt." + cn},
+		IssuerDN:          "C=US, O=Let's Encrypt, CN=R3",
+		NotAfter:          notAfter,
+	}
+}
+
+func runExpiring(t *testing.T, opts []command.ContextOpts, args ...string) (string, error) {
+	t.Helper()
+	viper.Reset()
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	ctrl := gomock.NewController(t)
+	cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), opts...)
+	cmd := newExpiringCommand(cmdContext)
+	cmd.now = func() time.Time { return expiringNow }
+	rootCmd, err := command.RootCommandToCobra(cmd)
+	require.NoError(t, err)
+	rootCmd.SetArgs(args)
+	cmdErr := rootCmd.Execute()
+	return stdout.String(), cmdErr
+}
+
+func queryService(t *testing.T, certs ...certwatch.ObservedCertificate) command.ContextOpts {
+	ms := certwatchmocks.NewMockCertWatchService(gomock.NewController(t))
+	ms.EXPECT().FindCertificates(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, params certwatch.Params) (certwatch.Result, error) {
+			require.Equal(t, `cert.names: "example.com"`, params.Query)
+			require.Empty(t, params.Domains)
+			return certwatch.Result{Query: params.Query, Certificates: certs}, nil
+		})
+	return command.WithCertWatchService(ms)
+}
+
+func TestExpiringCommand(t *testing.T) {
+	certs := []certwatch.ObservedCertificate{
+		observed("later", "later.example.com", "2025-06-20T00:00:00Z"),
+		observed("expired", "old.example.com", "2025-05-01T00:00:00Z"),
+		observed("soon", "soon.example.com", "2025-06-03T00:00:00Z"),
+		observed("far", "far.example.com", "2026-01-01T00:00:00Z"),
+		observed("unknown", "unknown.example.com", ""),
+	}
+
+	t.Run("reports certificates in the window, soonest first", func(t *testing.T) {
+		stdout, err := runExpiring(t, []command.ContextOpts{queryService(t, certs...)},
+			"--query", `cert.names: "example.com"`, "-O", "json")
+		require.NoError(t, err)
+		var report ExpiryReport
+		require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+		require.Equal(t, 5, report.Checked)
+		require.Equal(t, "30d", report.ExpiringWithin)
+		require.Len(t, report.Certificates, 2)
+		require.Equal(t, "soon", report.Certificates[0].FingerprintSHA256)
+		require.Equal(t, 1, report.Certificates[0].DaysLeft)
+		require.Equal(t, "later", report.Certificates[1].FingerprintSHA256)
+		require.Equal(t, 18, report.Certificates[1].DaysLeft)
+	})
+
+	t.Run("include expired and a custom window", func(t *testing.T) {
+		stdout, err := runExpiring(t, []command.ContextOpts{queryService(t, certs...)},
+			"--query", `cert.names: "example.com"`, "--expiring-within", "1w", "--include-expired", "-O", "json")
+		require.NoError(t, err)
+		var report ExpiryReport
+		require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+		require.Len(t, report.Certificates, 2)
+		require.Equal(t, "expired", report.Certificates[0].FingerprintSHA256)
+		require.Equal(t, -32, report.Certificates[0].DaysLeft)
+		require.Equal(t, "soon", report.Certificates[1].FingerprintSHA256)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		stdout, err := runExpiring(t, []command.ContextOpts{queryService(t, certs...)},
+			"--query", `cert.names: "example.com"`, "--csv")
+		require.NoError(t, err)
+		records, csvErr := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+		require.NoError(t, csvErr)
+		require.Equal(t, [][]string{
+			expiryCSVHeader,
+			{"soon", "soon.example.com", "soon.example.com;alt.soon.example.com", "C=US, O=Let's Encrypt, CN=R3", "2025-06-03T00:00:00Z", "1"},
+			{"later", "later.example.com", "later.example.com;alt.later.example.com", "C=US, O=Let's Encrypt, CN=R3", "2025-06-20T00:00:00Z", "18"},
+		}, records)
+	})
+
+	t.Run("short output", func(t *testing.T) {
+		stdout, err := runExpiring(t, []command.ContextOpts{queryService(t, certs...)},
+			"--query", `cert.names: "example.com"`)
+		require.NoError(t, err)
+		require.Contains(t, stdout, "2 certificate(s) expire within 30d (5 checked)")
+		require.Less(t, strings.Index(stdout, "soon.example.com"), strings.Index(stdout, "later.example.com"))
+	})
+
+	t.Run("fingerprints", func(t *testing.T) {
+		fp := strings.Repeat("a", 64)
+		ms := viewmocks.NewMockViewService(gomock.NewController(t))
+		certID, err := assets.NewCertificateFingerprint(fp)
+		require.NoError(t, err)
+		ms.EXPECT().GetCertificates(gomock.Any(), mo.None[identifiers.OrganizationID](), []assets.CertificateID{certID}).
+			Return(view.CertificatesResult{Certificates: []*assets.Certificate{{Certificate: components.Certificate{
+				FingerprintSha256: &fp,
+				Parsed: &components.CertificateParsed{
+					Subject:        &components.DistinguishedName{CommonName: []string{"www.example.com"}},
+					ValidityPeriod: &components.ValidityPeriod{NotAfter: strPtr("2025-06-10T00:00:00Z")},
+				},
+			}}}}, nil)
+
+		stdout, cmdErr := runExpiring(t, []command.ContextOpts{command.WithViewService(ms)}, fp, "-O", "json")
+		require.NoError(t, cmdErr)
+		var report ExpiryReport
+		require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+		require.Len(t, report.Certificates, 1)
+		require.Equal(t, "www.example.com", report.Certificates[0].CommonName)
+		require.Equal(t, 8, report.Certificates[0].DaysLeft)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := runExpiring(t, nil)
+		var noCerts NoCertificatesError
+		require.ErrorAs(t, err, &noCerts)
+
+		_, err = runExpiring(t, nil, "8.8.8.8")
+		var notCert NotCertificateError
+		require.ErrorAs(t, err, &notCert)
+
+		_, err = runExpiring(t, nil, "--query", "cert.names: x", strings.Repeat("a", 64))
+		var both QueryAndFingerprintsError
+		require.ErrorAs(t, err, &both)
+
+		_, err = runExpiring(t, nil, "--query", "cert.names: x", "--csv", "-O", "json")
+		require.ErrorContains(t, err, "cannot use --csv and --output-format flags together")
+	})
+}
+
+func TestDaysLeft(t *testing.T) {
+	require.Equal(t, 0, daysLeft(expiringNow, expiringNow.Add(23*time.Hour)))
+	require.Equal(t, 1, daysLeft(expiringNow, expiringNow.Add(24*time.Hour)))
+	require.Equal(t, -1, daysLeft(expiringNow, expiringNow.Add(-time.Hour)))
+	require.Equal(t, -1, daysLeft(expiringNow, expiringNow.Add(-24*time.Hour)))
+}
+
+func strPtr(s string) *string {
+	return &s
+}
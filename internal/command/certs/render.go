@@ -0,0 +1,89 @@
+package certs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/censys/cencli/internal/app/certwatch"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+// maxListedNames is the number of certificate names shown per row before truncating.
+const maxListedNames = 3
+
+// renderReport renders a watch report as a table of new certificates.
+func renderReport(report WatchReport, domains []string) string {
+	var sb strings.Builder
+	watched := strings.Join(domains, ", ")
+	if len(report.New) == 0 {
+		sb.WriteString(styles.GlobalStyles.Comment.Render(
+			fmt.Sprintf("No new certificates for %s (%d checked)", watched, report.Checked),
+		))
+		return sb.String()
+	}
+
+	sb.WriteString(styles.GlobalStyles.Warning.Render(
+		fmt.Sprintf("%d new certificate(s) for %s (%d checked)", len(report.New), watched, report.Checked),
+	))
+	sb.WriteString("\n\n")
+
+	columns := []rawtable.Column[certwatch.ObservedCertificate]{
+		{Title: "SHA-256", String: func(c certwatch.ObservedCertificate) string { return c.FingerprintSHA256 }, Priority: 2, NoTruncate: true},
+		{Title: "Names", String: func(c certwatch.ObservedCertificate) string { return listNames(c.Names) }, Priority: 1},
+		{Title: "Issuer", String: func(c certwatch.ObservedCertificate) string { return c.IssuerDN }},
+		{Title: "Not Before", String: func(c certwatch.ObservedCertificate) string { return c.NotBefore }, Priority: 1},
+	}
+	table := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[certwatch.ObservedCertificate](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[certwatch.ObservedCertificate](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[certwatch.ObservedCertificate](formatter.TableWidth()),
+	)
+	sb.WriteString(table.Render(report.New))
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func listNames(names []string) string {
+	if len(names) <= maxListedNames {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(names[:maxListedNames], ", "), len(names)-maxListedNames)
+}
+
+// renderExpiryReport renders an expiry report as a table, soonest expiry first.
+func renderExpiryReport(report ExpiryReport, within time.Duration) string {
+	var sb strings.Builder
+	if len(report.Certificates) == 0 {
+		sb.WriteString(styles.GlobalStyles.Comment.Render(
+			fmt.Sprintf("No certificates expire within %s (%d checked)", flags.FormatHumanDuration(within), report.Checked),
+		))
+		return sb.String()
+	}
+
+	sb.WriteString(styles.GlobalStyles.Warning.Render(
+		fmt.Sprintf("%d certificate(s) expire within %s (%d checked)", len(report.Certificates), flags.FormatHumanDuration(within), report.Checked),
+	))
+	sb.WriteString("\n\n")
+
+	columns := []rawtable.Column[ExpiringCertificate]{
+		{Title: "Days Left", AlignRight: true, String: func(c ExpiringCertificate) string { return strconv.Itoa(c.DaysLeft) }, Priority: 3, NoTruncate: true},
+		{Title: "Not After", String: func(c ExpiringCertificate) string { return c.NotAfter }, Priority: 2},
+		{Title: "Common Name", String: func(c ExpiringCertificate) string { return c.CommonName }, Priority: 2},
+		{Title: "Names", String: func(c ExpiringCertificate) string { return listNames(c.Names) }, Priority: 1},
+		{Title: "Issuer", String: func(c ExpiringCertificate) string { return c.IssuerDN }},
+		{Title: "SHA-256", String: func(c ExpiringCertificate) string { return c.FingerprintSHA256 }, Priority: 1, NoTruncate: true},
+	}
+	table := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[ExpiringCertificate](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[ExpiringCertificate](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[ExpiringCertificate](formatter.TableWidth()),
+	)
+	sb.WriteString(table.Render(report.Certificates))
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,345 @@
+package certs
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/certwatch"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/notify"
+)
+
+const (
+	watchCmdName = "certs watch"
+
+	defaultWatchMaxPages = 5
+	defaultWatchPageSize = 100
+)
+
+// watchCommand implements `certs watch`, which reports certificates matching
+// a set of domains that have not been reported by a previous run.
+type watchCommand struct {
+	*command.BaseCommand
+	// services the command uses
+	certWatchSvc certwatch.Service
+	// flags the command uses
+	flags watchCommandFlags
+	// state - populated by PreRun
+	params    certwatch.Params
+	watch     string
+	interval  mo.Option[time.Duration]
+	reset     bool
+	notifiers []notify.Notifier
+	// report stores the latest report for rendering
+	report WatchReport
+}
+
+type watchCommandFlags struct {
+	domains  flags.StringSliceFlag
+	orgID    flags.OrgIDFlag
+	since    flags.TimestampFlag
+	pageSize flags.IntegerFlag
+	maxPages flags.IntegerFlag
+	interval flags.DurationFlag
+	webhook  flags.StringFlag
+	exec     flags.StringFlag
+	reset    flags.BoolFlag
+}
+
+// WatchReport is the result of a single watch run.
+type WatchReport struct {
+	// Watch identifies the set of watched domains in the local store.
+	Watch string `json:"watch"`
+	// Query is the Censys query that was run.
+	Query string `json:"query"`
+	// Checked is the number of matching certificates that were fetched.
+	Checked int `json:"checked"`
+	// New lists the certificates not reported by a previous run.
+	New []certwatch.ObservedCertificate `json:"new"`
+}
+
+var _ command.Command = (*watchCommand)(nil)
+
+func newWatchCommand(cmdContext *command.Context) *watchCommand {
+	return &watchCommand{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *watchCommand) Use() string { return "watch" }
+
+func (c *watchCommand) Short() string {
+	return "Report newly observed certificates for one or more domains"
+}
+
+func (c *watchCommand) Long() string {
+	return `Search for certificates whose names (subject CN or SANs) contain any of the given
+domains and report only those that have not been reported before.
+
+Fingerprints that have been reported are remembered in the local store, keyed by the
+set of watched domains, so running the same watch again (e.g. from cron) only reports
+new certificates. The first run reports every matching certificate; use --reset to
+start over.
+
+Use --interval to keep running and check again periodically until interrupted.
+When new certificates are found, the report can also be POSTed as JSON to --webhook
+and/or piped as JSON to the stdin of an --exec command. A run whose notification
+fails does not remember its certificates, so they are reported again next time.`
+}
+
+func (c *watchCommand) Examples() []string {
+	return []string{
+		"--domain example.com",
+		"--domain example.com --domain example.org --since 2025-01-01",
+		"--domain example.com --interval 1h --webhook https://hooks.example.com/certs",
+		`--domain example.com --exec 'jq -r ".new[].fingerprint_sha256" >> new-certs.txt'`,
+	}
+}
+
+func (c *watchCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *watchCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *watchCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *watchCommand) Init() error {
+	c.flags.domains = flags.NewStringSliceFlag(c.Flags(), true, "domain", "d", nil, "domain to watch (repeatable or comma-separated)")
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.since = flags.NewTimestampFlag(c.Flags(), false, "since", "", mo.None[time.Time](), "only consider certificates added to Censys at or after this time")
+	c.flags.pageSize = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"page-size",
+		"n",
+		mo.Some[int64](defaultWatchPageSize),
+		"number of certificates to fetch per page",
+		mo.Some[int64](1),
+		mo.None[int64](),
+	)
+	c.flags.maxPages = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"max-pages",
+		"p",
+		mo.Some[int64](defaultWatchMaxPages),
+		"maximum number of pages to fetch per check",
+		mo.Some[int64](1),
+		mo.None[int64](),
+	)
+	c.flags.interval = flags.NewDurationFlag(c.Flags(), false, "interval", "", mo.None[time.Duration](), "check again at this interval until interrupted (e.g. 30m)")
+	c.flags.webhook = flags.NewStringFlag(c.Flags(), false, "webhook", "", "", "URL to POST the report to when new certificates are found")
+	c.flags.exec = flags.NewStringFlag(c.Flags(), false, "exec", "", "", "shell command to pipe the report to when new certificates are found")
+	c.flags.reset = flags.NewBoolFlag(c.Flags(), "reset", "", false, "forget previously reported certificates for these domains before checking")
+	return nil
+}
+
+func (c *watchCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	domains, err := c.flags.domains.Value()
+	if err != nil {
+		return err
+	}
+	domains = normalizeDomains(domains)
+	if len(domains) == 0 {
+		return flags.NewRequiredFlagNotSetError("domain")
+	}
+	orgID, err := c.flags.orgID.Value()
+	if err != nil {
+		return err
+	}
+	since, err := c.flags.since.Value(c.Config().DefaultTZ, c.Now())
+	if err != nil {
+		return err
+	}
+	pageSize, err := c.flags.pageSize.Value()
+	if err != nil {
+		return err
+	}
+	maxPages, err := c.flags.maxPages.Value()
+	if err != nil {
+		return err
+	}
+	c.interval, err = c.flags.interval.Value()
+	if err != nil {
+		return err
+	}
+	if interval, ok := c.interval.Get(); ok && interval < time.Minute {
+		return newIntervalTooShortError(interval)
+	}
+	c.reset, err = c.flags.reset.Value()
+	if err != nil {
+		return err
+	}
+	if err := c.parseNotifierFlags(); err != nil {
+		return err
+	}
+
+	c.params = certwatch.Params{
+		OrgID:    orgID,
+		Domains:  domains,
+		Since:    since,
+		PageSize: mo.Some(uint64(pageSize.OrElse(defaultWatchPageSize))),
+		MaxPages: uint64(maxPages.OrElse(defaultWatchMaxPages)),
+	}
+	c.watch = watchKey(domains)
+
+	if c.Store() == nil {
+		return newStoreUnavailableError()
+	}
+	c.certWatchSvc, err = c.CertWatchService()
+	return err
+}
+
+func (c *watchCommand) parseNotifierFlags() cenclierrors.CencliError {
+	webhook, err := c.flags.webhook.Value()
+	if err != nil {
+		return err
+	}
+	if webhook = strings.TrimSpace(webhook); webhook != "" {
+		c.notifiers = append(c.notifiers, notify.NewWebhook(webhook, nil))
+	}
+	execCmd, err := c.flags.exec.Value()
+	if err != nil {
+		return err
+	}
+	if execCmd = strings.TrimSpace(execCmd); execCmd != "" {
+		c.notifiers = append(c.notifiers, notify.NewExec(execCmd))
+	}
+	return nil
+}
+
+func (c *watchCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(watchCmdName).With(
+		"watch", c.watch,
+		"interval", c.interval.OrEmpty(),
+		"notifiers", len(c.notifiers),
+	)
+	ctx := cmd.Context()
+
+	if c.reset {
+		removed, err := c.Store().ResetWatch(ctx, c.watch)
+		if err != nil {
+			return cenclierrors.NewCencliError(err)
+		}
+		logger.Debug("reset watch", "removed", removed)
+	}
+
+	for {
+		err := c.check(ctx, logger)
+		if err == nil {
+			if renderErr := c.PrintData(c, c.report); renderErr != nil {
+				return renderErr
+			}
+		}
+		interval, repeat := c.interval.Get()
+		if !repeat {
+			return err
+		}
+		if err != nil {
+			// keep watching through transient failures
+			if ctx.Err() != nil {
+				return nil
+			}
+			formatter.PrintError(err, cmd)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// check runs a single watch iteration, populating c.report.
+// Certificates are only remembered once every notifier has succeeded.
+func (c *watchCommand) check(ctx context.Context, logger *slog.Logger) cenclierrors.CencliError {
+	var result certwatch.Result
+	err := c.WithProgress(
+		ctx,
+		logger,
+		"Searching certificates...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			var fetchErr cenclierrors.CencliError
+			result, fetchErr = c.certWatchSvc.FindCertificates(pctx, c.params)
+			return fetchErr
+		},
+	)
+	if err != nil {
+		return err
+	}
+	c.PrintAppResponseMeta(result.Meta)
+
+	seenValues, storeErr := c.Store().GetWatchSeen(ctx, c.watch)
+	if storeErr != nil {
+		return cenclierrors.NewCencliError(storeErr)
+	}
+	seen := make(map[string]struct{}, len(seenValues))
+	for _, v := range seenValues {
+		seen[v.Value] = struct{}{}
+	}
+
+	report := WatchReport{
+		Watch:   c.watch,
+		Query:   result.Query,
+		Checked: len(result.Certificates),
+		New:     []certwatch.ObservedCertificate{},
+	}
+	for _, cert := range result.Certificates {
+		if _, ok := seen[cert.FingerprintSHA256]; !ok {
+			report.New = append(report.New, cert)
+		}
+	}
+	logger.Debug("watch checked", "checked", report.Checked, "new", len(report.New))
+
+	if len(report.New) > 0 {
+		for _, n := range c.notifiers {
+			if notifyErr := n.Notify(ctx, report); notifyErr != nil {
+				return newNotifyError(notifyErr)
+			}
+		}
+		for _, cert := range report.New {
+			if _, markErr := c.Store().MarkWatchSeen(ctx, c.watch, cert.FingerprintSHA256); markErr != nil {
+				return cenclierrors.NewCencliError(markErr)
+			}
+		}
+	}
+	c.report = report
+	return nil
+}
+
+func (c *watchCommand) RenderShort() cenclierrors.CencliError {
+	formatter.Println(formatter.Stdout, renderReport(c.report, c.params.Domains))
+	return nil
+}
+
+// normalizeDomains lowercases, trims, and deduplicates domains, sorted for a stable watch key.
+func normalizeDomains(domains []string) []string {
+	set := make(map[string]struct{}, len(domains))
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if d != "" {
+			set[d] = struct{}{}
+		}
+	}
+	res := make([]string, 0, len(set))
+	for d := range set {
+		res = append(res, d)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// watchKey identifies a set of watched domains in the local store.
+func watchKey(domains []string) string {
+	return "certs:" + strings.Join(domains, ",")
+}
@@ -0,0 +1,179 @@
+package certs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	certwatchmocks "github.com/censys/cencli/gen/app/certwatch/mocks"
+	"github.com/censys/cencli/internal/app/certwatch"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/store"
+)
+
+func watchResult(fingerprints ...string) certwatch.Result {
+	res := certwatch.Result{
+		Meta:  &responsemeta.ResponseMeta{Method: "POST", URL: "https://127.0.0.1", Status: 200},
+		Query: `cert.names: "example.com"`,
+	}
+	for _, fp := range fingerprints {
+		res.Certificates = append(res.Certificates, certwatch.ObservedCertificate{
+			FingerprintSHA256: fp,
+			Names:             []string{"example.com", "www.example.com"},
+			IssuerDN:          "C=US, O=Let's Encrypt, CN=R3",
+		})
+	}
+	return res
+}
+
+// runWatch executes `certs watch` against s with a service returning result.
+func runWatch(t *testing.T, s store.Store, result certwatch.Result, args ...string) (string, error) {
+	t.Helper()
+	viper.Reset()
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	ctrl := gomock.NewController(t)
+	ms := certwatchmocks.NewMockCertWatchService(ctrl)
+	ms.EXPECT().FindCertificates(gomock.Any(), gomock.Any()).Return(result, nil).AnyTimes()
+
+	cmdContext := command.NewCommandContext(cfg, s, command.WithCertWatchService(ms))
+	rootCmd, err := command.RootCommandToCobra(newWatchCommand(cmdContext))
+	require.NoError(t, err)
+	rootCmd.SetArgs(args)
+	cmdErr := rootCmd.Execute()
+	return stdout.String(), cmdErr
+}
+
+func decodeReport(t *testing.T, stdout string) WatchReport {
+	t.Helper()
+	var report WatchReport
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	return report
+}
+
+func TestWatchCommand(t *testing.T) {
+	t.Run("reports only new certificates across runs", func(t *testing.T) {
+		s, err := store.New(t.TempDir())
+		require.NoError(t, err)
+
+		stdout, err := runWatch(t, s, watchResult("aa", "bb"), "--domain", "Example.com", "-O", "json")
+		require.NoError(t, err)
+		report := decodeReport(t, stdout)
+		require.Equal(t, "certs:example.com", report.Watch)
+		require.Equal(t, 2, report.Checked)
+		require.Len(t, report.New, 2)
+
+		stdout, err = runWatch(t, s, watchResult("aa", "bb", "cc"), "--domain", "example.com", "-O", "json")
+		require.NoError(t, err)
+		report = decodeReport(t, stdout)
+		require.Equal(t, 3, report.Checked)
+		require.Len(t, report.New, 1)
+		require.Equal(t, "cc", report.New[0].FingerprintSHA256)
+
+		stdout, err = runWatch(t, s, watchResult("aa", "bb", "cc"), "--domain", "example.com")
+		require.NoError(t, err)
+		require.Contains(t, stdout, "No new certificates for example.com (3 checked)")
+	})
+
+	t.Run("watches are keyed by domain set", func(t *testing.T) {
+		s, err := store.New(t.TempDir())
+		require.NoError(t, err)
+
+		_, err = runWatch(t, s, watchResult("aa"), "--domain", "example.com")
+		require.NoError(t, err)
+		stdout, err := runWatch(t, s, watchResult("aa"), "--domain", "example.org,example.com", "-O", "json")
+		require.NoError(t, err)
+		report := decodeReport(t, stdout)
+		require.Equal(t, "certs:example.com,example.org", report.Watch)
+		require.Len(t, report.New, 1)
+	})
+
+	t.Run("reset forgets reported certificates", func(t *testing.T) {
+		s, err := store.New(t.TempDir())
+		require.NoError(t, err)
+
+		_, err = runWatch(t, s, watchResult("aa"), "--domain", "example.com")
+		require.NoError(t, err)
+		stdout, err := runWatch(t, s, watchResult("aa"), "--domain", "example.com", "--reset")
+		require.NoError(t, err)
+		require.Contains(t, stdout, "1 new certificate(s) for example.com")
+	})
+
+	t.Run("webhook receives the report", func(t *testing.T) {
+		s, err := store.New(t.TempDir())
+		require.NoError(t, err)
+
+		var received []WatchReport
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var report WatchReport
+			require.NoError(t, json.Unmarshal(body, &report))
+			received = append(received, report)
+		}))
+		defer server.Close()
+
+		_, err = runWatch(t, s, watchResult("aa"), "--domain", "example.com", "--webhook", server.URL)
+		require.NoError(t, err)
+		_, err = runWatch(t, s, watchResult("aa"), "--domain", "example.com", "--webhook", server.URL)
+		require.NoError(t, err)
+
+		require.Len(t, received, 1, "webhook should only be called when there are new certificates")
+		require.Equal(t, "aa", received[0].New[0].FingerprintSHA256)
+	})
+
+	t.Run("failed notification does not mark certificates", func(t *testing.T) {
+		s, err := store.New(t.TempDir())
+		require.NoError(t, err)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		_, err = runWatch(t, s, watchResult("aa"), "--domain", "example.com", "--webhook", server.URL)
+		var notifyErr NotifyError
+		require.ErrorAs(t, err, &notifyErr)
+
+		stdout, err := runWatch(t, s, watchResult("aa"), "--domain", "example.com", "-O", "json")
+		require.NoError(t, err)
+		require.Len(t, decodeReport(t, stdout).New, 1)
+	})
+
+	t.Run("interval below minimum", func(t *testing.T) {
+		s, err := store.New(t.TempDir())
+		require.NoError(t, err)
+
+		_, err = runWatch(t, s, watchResult(), "--domain", "example.com", "--interval", "10s")
+		var intervalErr IntervalTooShortError
+		require.ErrorAs(t, err, &intervalErr)
+	})
+
+	t.Run("store is required", func(t *testing.T) {
+		_, err := runWatch(t, nil, watchResult(), "--domain", "example.com")
+		var storeErr StoreUnavailableError
+		require.ErrorAs(t, err, &storeErr)
+	})
+}
+
+func TestNotifyErrorUnwraps(t *testing.T) {
+	inner := errors.New("boom")
+	var err cenclierrors.CencliError = newNotifyError(inner)
+	require.ErrorIs(t, err, inner)
+}
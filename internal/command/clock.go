@@ -0,0 +1,45 @@
+package command
+
+import (
+	"time"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/datetime"
+	"github.com/censys/cencli/internal/pkg/flags"
+)
+
+// nowFlagName is the name of the hidden --now flag.
+const nowFlagName = "now"
+
+// WithClock sets the clock that commands read the current time from.
+func WithClock(now func() time.Time) ContextOpts {
+	return func(c *Context) { c.clock = now }
+}
+
+// Now returns the current time that commands should use as the reference
+// for relative times and default time windows: the time pinned with --now
+// (or CENCLI_NOW) if set, otherwise the time of the clock of the context.
+func (c *Context) Now() time.Time {
+	if t, ok := c.pinnedNow.Get(); ok {
+		return t
+	}
+	return c.clock()
+}
+
+// pinClock pins the current time to the value of --now, so that relative
+// times resolve the same way on every run. The value is parsed like any
+// timestamp flag, so it can itself be relative to the clock.
+func (c *Context) pinClock() cenclierrors.CencliError {
+	c.pinnedNow = mo.None[time.Time]()
+	if c.config.Now == "" {
+		return nil
+	}
+	t, err := datetime.ParseAt(c.config.Now, c.config.DefaultTZ, c.clock())
+	if err != nil {
+		return flags.NewInvalidTimestampFlagError(nowFlagName, c.config.Now)
+	}
+	c.pinnedNow = mo.Some(t)
+	return nil
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func TestClock(t *testing.T) {
+	clock := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	// run runs a command with args and returns the time it read from the context
+	run := func(t *testing.T, args ...string) (time.Time, error) {
+		t.Helper()
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+
+		cmdContext := NewCommandContext(cfg, nil, WithClock(func() time.Time { return clock }))
+		var now time.Time
+		cmd := newTestCommand(cmdContext)
+		cmd.runFn = func(*cobra.Command, []string) cenclierrors.CencliError {
+			now = cmdContext.Now()
+			return nil
+		}
+		rootCmd, cerr := RootCommandToCobra(cmd)
+		require.NoError(t, cerr)
+		require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+		formatter.Stdout = &bytes.Buffer{}
+		formatter.Stderr = &bytes.Buffer{}
+		rootCmd.SetArgs(args)
+		return now, rootCmd.Execute()
+	}
+
+	t.Run("uses the clock", func(t *testing.T) {
+		now, err := run(t)
+		require.NoError(t, err)
+		assert.Equal(t, clock, now)
+	})
+
+	t.Run("--now pins the time", func(t *testing.T) {
+		now, err := run(t, "--now", "2024-06-01T08:30:00Z")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 6, 1, 8, 30, 0, 0, time.UTC), now)
+	})
+
+	t.Run("--now can be relative to the clock", func(t *testing.T) {
+		now, err := run(t, "--now", "yesterday")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), now)
+	})
+
+	t.Run("CENCLI_NOW pins the time", func(t *testing.T) {
+		t.Setenv("CENCLI_NOW", "2024-06-01")
+		now, err := run(t)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), now)
+	})
+
+	t.Run("invalid --now", func(t *testing.T) {
+		_, err := run(t, "--now", "not a time")
+		require.ErrorContains(t, err, "now")
+	})
+}
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// CollectionIDFlagName is the name of the flag that scopes a command to a
+// collection.
+const CollectionIDFlagName = "collection-id"
+
+// CheckCollectionConflicts returns an error if one of the flags of
+// unsupported is set alongside --collection-id. unsupported maps each flag
+// to the reason it cannot be scoped to a collection.
+func CheckCollectionConflicts(cmd *cobra.Command, unsupported map[string]string) cenclierrors.CencliError {
+	if f := cmd.Flags().Lookup(CollectionIDFlagName); f == nil || !f.Changed {
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(unsupported)) {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			return NewCollectionNotSupportedError(name, unsupported[name])
+		}
+	}
+	return nil
+}
+
+// CollectionNotSupportedError is returned when a flag cannot be combined
+// with --collection-id, as the API cannot scope it to a collection.
+type CollectionNotSupportedError interface{ cenclierrors.CencliError }
+
+type collectionNotSupportedError struct {
+	flag   string
+	reason string
+}
+
+var _ CollectionNotSupportedError = &collectionNotSupportedError{}
+
+func NewCollectionNotSupportedError(flag, reason string) CollectionNotSupportedError {
+	return &collectionNotSupportedError{flag: flag, reason: reason}
+}
+
+func (e *collectionNotSupportedError) Error() string {
+	return fmt.Sprintf("--%s cannot be used with --%s: %s", CollectionIDFlagName, e.flag, e.reason)
+}
+
+func (e *collectionNotSupportedError) Title() string { return "Not Supported in Collections" }
+
+func (e *collectionNotSupportedError) ShouldPrintUsage() bool { return true }
@@ -29,6 +29,10 @@ type Command interface {
 	// Examples returns the examples for the command.
 	// Not required to implement.
 	Examples() []string
+	// Hidden reports whether the command is left out of help and completions,
+	// e.g. for developer commands.
+	// Not required to implement.
+	Hidden() bool
 	// Args returns the positional argument function for the command.
 	// Must be implemented.
 	Args() PositionalArgs
@@ -133,6 +137,7 @@ func toCobra(cmd Command) (*cobra.Command, error) {
 		return nil, fmt.Errorf("Short() is empty")
 	}
 	cobraCmd.Long = cmd.Long()
+	cobraCmd.Hidden = cmd.Hidden()
 
 	if args := cmd.Args(); args == nil {
 		return nil, fmt.Errorf("Args() is nil")
@@ -0,0 +1,159 @@
+package compare
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/compare"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/input"
+)
+
+const cmdName = "compare"
+
+// Command implements the `compare` CLI command.
+// It fetches two or more hosts and reports the features each pair has in common.
+type Command struct {
+	*command.BaseCommand
+	// services the command uses
+	compareSvc compare.Service
+	// flags the command uses
+	flags compareCommandFlags
+	// state parsed from flags/args
+	orgID   mo.Option[identifiers.OrganizationID]
+	hostIDs []assets.HostID
+	// result stored for rendering
+	result compare.CompareHostsResult
+}
+
+type compareCommandFlags struct {
+	orgID     flags.OrgIDFlag
+	inputFile flags.FileFlag
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewCompareCommand(ctx *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *Command) Use() string { return cmdName + " <host> <host> [host...]" }
+
+func (c *Command) Short() string {
+	return "Compare hosts and report shared ports, banners, fingerprints, and certificates"
+}
+
+func (c *Command) Long() string {
+	return fmt.Sprintf(`Compare two or more hosts (up to %d) and produce a pairwise similarity report.
+
+For each pair of hosts, the report lists shared open ports, identical banner hashes,
+matching JA4S and JARM fingerprints, shared certificates, and shared SSH host keys.
+Similarity is the Jaccard index of the two hosts' combined feature sets.
+
+Use --output-format json for the full report, including a similarity matrix.`, compare.MaxHosts)
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"1.1.1.1 1.0.0.1",
+		"1.1.1.1,1.0.0.1,8.8.8.8",
+		"--input-file hosts.txt",
+		"1.1.1.1 1.0.0.1 --output-format json",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs { return command.RangeArgs(0, compare.MaxHosts) }
+
+func (c *Command) Init() error {
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.inputFile = flags.NewFileFlag(c.Flags(), false, "input-file", "i", "file to read the hosts from. Overrides the positional arguments.")
+	return nil
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.orgID, err = c.flags.orgID.Value()
+	if err != nil {
+		return err
+	}
+	rawAssets, err := c.gatherRawAssets(cmd, args)
+	if err != nil {
+		return err
+	}
+	classifier := assets.NewAssetClassifier(rawAssets...)
+	assetType, err := classifier.AssetType()
+	if err != nil {
+		return err
+	}
+	if assetType != assets.AssetTypeHost {
+		return newAssetTypeNotSupportedError(assetType)
+	}
+	c.hostIDs = classifier.HostIDs()
+	if len(c.hostIDs) < 2 {
+		return newTooFewHostsError(len(c.hostIDs))
+	}
+	if len(c.hostIDs) > compare.MaxHosts {
+		return assets.NewTooManyAssetsError(len(c.hostIDs), compare.MaxHosts)
+	}
+	c.compareSvc, err = c.CompareService()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// gatherRawAssets returns raw asset strings from file, stdin, or positional args.
+func (c *Command) gatherRawAssets(cmd *cobra.Command, args []string) ([]string, cenclierrors.CencliError) {
+	if c.flags.inputFile.IsSet() {
+		records, err := command.InputRecords(cmd, c.flags.inputFile)
+		if err != nil {
+			return nil, err
+		}
+		return input.RecordValues(records), nil
+	}
+	var parts []string
+	for _, arg := range args {
+		parts = append(parts, input.SplitString(arg)...)
+	}
+	return parts, nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(cmdName).With("count", len(c.hostIDs))
+
+	err := c.WithProgress(
+		cmd.Context(),
+		logger,
+		fmt.Sprintf("Comparing %d hosts...", len(c.hostIDs)),
+		func(pctx context.Context) cenclierrors.CencliError {
+			var compareErr cenclierrors.CencliError
+			c.result, compareErr = c.compareSvc.CompareHosts(pctx, c.orgID, c.hostIDs)
+			return compareErr
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	c.PrintAppResponseMeta(c.result.Meta)
+	return c.PrintData(c, c.result.Report)
+}
+
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	return c.showReport(c.result.Report)
+}
@@ -0,0 +1,134 @@
+package compare
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/samber/mo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	comparemocks "github.com/censys/cencli/gen/app/compare/mocks"
+	"github.com/censys/cencli/internal/app/compare"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func sampleResult() compare.CompareHostsResult {
+	return compare.CompareHostsResult{
+		Meta: &responsemeta.ResponseMeta{Method: "POST", URL: "https://127.0.0.1", Status: 200},
+		Report: compare.HostComparisonReport{
+			Hosts:  []string{"1.1.1.1", "2.2.2.2"},
+			Matrix: [][]float64{{1, 0.5}, {0.5, 1}},
+			Pairs: []compare.HostPair{{
+				HostA:              "1.1.1.1",
+				HostB:              "2.2.2.2",
+				Similarity:         0.5,
+				SharedPorts:        []int{443},
+				SharedBannerHashes: []string{},
+				SharedJA4S:         []string{"t13d"},
+				SharedJARM:         []string{},
+				SharedCertificates: []string{"abc"},
+				SharedSSHHostKeys:  []string{},
+			}},
+		},
+	}
+}
+
+func TestCompareCommand(t *testing.T) {
+	hostA, _ := assets.NewHostID("1.1.1.1")
+	hostB, _ := assets.NewHostID("2.2.2.2")
+
+	testCases := []struct {
+		name    string
+		service func(ctrl *gomock.Controller) compare.Service
+		args    []string
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name: "short output",
+			service: func(ctrl *gomock.Controller) compare.Service {
+				ms := comparemocks.NewMockCompareService(ctrl)
+				ms.EXPECT().CompareHosts(gomock.Any(), mo.None[identifiers.OrganizationID](), []assets.HostID{hostA, hostB}).Return(sampleResult(), nil)
+				return ms
+			},
+			args: []string{"1.1.1.1", "2.2.2.2"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Comparison of 2 hosts")
+				require.Contains(t, stdout, "0.50")
+				require.Contains(t, stdout, "1.1.1.1 <-> 2.2.2.2")
+				require.Contains(t, stdout, "ports: 443")
+				require.Contains(t, stdout, "ja4s: t13d")
+				require.NotContains(t, stdout, "jarm:")
+			},
+		},
+		{
+			name: "json output with comma separated hosts",
+			service: func(ctrl *gomock.Controller) compare.Service {
+				ms := comparemocks.NewMockCompareService(ctrl)
+				ms.EXPECT().CompareHosts(gomock.Any(), mo.None[identifiers.OrganizationID](), []assets.HostID{hostA, hostB}).Return(sampleResult(), nil)
+				return ms
+			},
+			args: []string{"1.1.1.1,2.2.2.2", "-O", "json"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var report compare.HostComparisonReport
+				require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+				require.Equal(t, [][]float64{{1, 0.5}, {0.5, 1}}, report.Matrix)
+				require.Len(t, report.Pairs, 1)
+			},
+		},
+		{
+			name: "duplicate hosts count once",
+			service: func(ctrl *gomock.Controller) compare.Service {
+				return comparemocks.NewMockCompareService(ctrl)
+			},
+			args: []string{"1.1.1.1", "1.1.1.1"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				var tooFew TooFewHostsError
+				require.ErrorAs(t, err, &tooFew)
+			},
+		},
+		{
+			name: "non-host assets are rejected",
+			service: func(ctrl *gomock.Controller) compare.Service {
+				return comparemocks.NewMockCompareService(ctrl)
+			},
+			args: []string{"example.com", "example.org"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				var unsupported AssetTypeNotSupportedError
+				require.ErrorAs(t, err, &unsupported)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, nil, command.WithCompareService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewCompareCommand(cmdContext))
+			require.NoError(t, err)
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
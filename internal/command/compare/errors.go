@@ -0,0 +1,52 @@
+package compare
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+type TooFewHostsError interface {
+	cenclierrors.CencliError
+}
+
+type tooFewHostsError struct {
+	provided int
+}
+
+var _ TooFewHostsError = &tooFewHostsError{}
+
+func newTooFewHostsError(provided int) TooFewHostsError {
+	return &tooFewHostsError{provided: provided}
+}
+
+func (e *tooFewHostsError) Error() string {
+	return fmt.Sprintf("%d unique host(s) provided, at least 2 are required", e.provided)
+}
+
+func (e *tooFewHostsError) Title() string { return "Too Few Hosts" }
+
+func (e *tooFewHostsError) ShouldPrintUsage() bool { return true }
+
+type AssetTypeNotSupportedError interface {
+	cenclierrors.CencliError
+}
+
+type assetTypeNotSupportedError struct {
+	assetType assets.AssetType
+}
+
+var _ AssetTypeNotSupportedError = &assetTypeNotSupportedError{}
+
+func newAssetTypeNotSupportedError(assetType assets.AssetType) AssetTypeNotSupportedError {
+	return &assetTypeNotSupportedError{assetType: assetType}
+}
+
+func (e *assetTypeNotSupportedError) Error() string {
+	return fmt.Sprintf("only hosts can be compared, got %s assets", e.assetType)
+}
+
+func (e *assetTypeNotSupportedError) Title() string { return "Unsupported Asset Type" }
+
+func (e *assetTypeNotSupportedError) ShouldPrintUsage() bool { return true }
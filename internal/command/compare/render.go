@@ -0,0 +1,96 @@
+package compare
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/censys/cencli/internal/app/compare"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+// showReport renders a table with one row per host pair, followed by
+// the shared values of each pair that has anything in common.
+func (c *Command) showReport(report compare.HostComparisonReport) cenclierrors.CencliError {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n=== Comparison of %d hosts ===\n\n", len(report.Hosts)))
+
+	countColumn := func(title string, count func(compare.HostPair) int) rawtable.Column[compare.HostPair] {
+		return rawtable.Column[compare.HostPair]{
+			Title:      title,
+			String:     func(p compare.HostPair) string { return strconv.Itoa(count(p)) },
+			AlignRight: true,
+		}
+	}
+	columns := []rawtable.Column[compare.HostPair]{
+		{Title: "Host A", String: func(p compare.HostPair) string { return p.HostA }, Priority: 2, NoTruncate: true},
+		{Title: "Host B", String: func(p compare.HostPair) string { return p.HostB }, Priority: 2, NoTruncate: true},
+		{
+			Title:  "Similarity",
+			String: func(p compare.HostPair) string { return fmt.Sprintf("%.2f", p.Similarity) },
+			Style: func(s string, p compare.HostPair) string {
+				if p.SharedCount() > 0 {
+					return styles.NewStyle(styles.ColorOrange).Render(s)
+				}
+				return styles.NewStyle(styles.ColorGray).Render(s)
+			},
+			AlignRight: true,
+			Priority:   1,
+		},
+		countColumn("Ports", func(p compare.HostPair) int { return len(p.SharedPorts) }),
+		countColumn("Banners", func(p compare.HostPair) int { return len(p.SharedBannerHashes) }),
+		countColumn("JA4S", func(p compare.HostPair) int { return len(p.SharedJA4S) }),
+		countColumn("JARM", func(p compare.HostPair) int { return len(p.SharedJARM) }),
+		countColumn("Certs", func(p compare.HostPair) int { return len(p.SharedCertificates) }),
+		countColumn("SSH Keys", func(p compare.HostPair) int { return len(p.SharedSSHHostKeys) }),
+	}
+	table := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[compare.HostPair](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[compare.HostPair](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[compare.HostPair](formatter.TableWidth()),
+	)
+	sb.WriteString(table.Render(report.Pairs))
+	sb.WriteString("\n")
+
+	for _, p := range report.Pairs {
+		if p.SharedCount() == 0 {
+			continue
+		}
+		sb.WriteString(styles.GlobalStyles.Signature.Render(fmt.Sprintf("%s <-> %s", p.HostA, p.HostB)))
+		sb.WriteString("\n")
+		if len(p.SharedPorts) > 0 {
+			ports := make([]string, len(p.SharedPorts))
+			for i, port := range p.SharedPorts {
+				ports[i] = strconv.Itoa(port)
+			}
+			writeShared(&sb, "ports", ports)
+		}
+		writeShared(&sb, "banner hashes", p.SharedBannerHashes)
+		writeShared(&sb, "ja4s", p.SharedJA4S)
+		writeShared(&sb, "jarm", p.SharedJARM)
+		writeShared(&sb, "certificates", p.SharedCertificates)
+		writeShared(&sb, "ssh host keys", p.SharedSSHHostKeys)
+		sb.WriteString("\n")
+	}
+
+	if len(report.Missing) > 0 {
+		sb.WriteString(styles.GlobalStyles.Warning.Render("Not found: " + strings.Join(report.Missing, ", ")))
+		sb.WriteString("\n")
+	}
+	fmt.Fprint(formatter.Stdout, sb.String())
+	return nil
+}
+
+func writeShared(sb *strings.Builder, label string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("  %s: %s\n",
+		styles.GlobalStyles.Info.Render(label),
+		styles.GlobalStyles.Secondary.Render(strings.Join(values, ", ")),
+	))
+}
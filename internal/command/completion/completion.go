@@ -2,6 +2,7 @@ package completion
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 
@@ -51,25 +52,25 @@ func (c *Command) Examples() []string {
 }
 
 func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
-	switch args[0] {
+	if err := Generate(cmd.Root(), args[0], cmd.OutOrStdout()); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
+
+// Generate writes the completion script of root for shell (bash, zsh, fish,
+// or powershell) to w.
+func Generate(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
 	case "bash":
-		if err := cmd.Root().GenBashCompletion(cmd.OutOrStdout()); err != nil {
-			return cenclierrors.NewCencliError(err)
-		}
+		return root.GenBashCompletion(w)
 	case "zsh":
-		if err := cmd.Root().GenZshCompletion(cmd.OutOrStdout()); err != nil {
-			return cenclierrors.NewCencliError(err)
-		}
+		return root.GenZshCompletion(w)
 	case "fish":
-		if err := cmd.Root().GenFishCompletion(cmd.OutOrStdout(), true); err != nil {
-			return cenclierrors.NewCencliError(err)
-		}
+		return root.GenFishCompletion(w, true)
 	case "powershell":
-		if err := cmd.Root().GenPowerShellCompletionWithDesc(cmd.OutOrStdout()); err != nil {
-			return cenclierrors.NewCencliError(err)
-		}
+		return root.GenPowerShellCompletionWithDesc(w)
 	default:
-		return cenclierrors.NewCencliError(fmt.Errorf("invalid shell %q", args[0]))
+		return fmt.Errorf("invalid shell %q", shell)
 	}
-	return nil
 }
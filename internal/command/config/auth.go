@@ -12,6 +12,7 @@ import (
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	"github.com/censys/cencli/internal/pkg/flags"
 	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/term"
 	"github.com/censys/cencli/internal/store"
 )
 
@@ -50,6 +51,7 @@ func (c *authCommand) Init() error {
 		newAddAuthCommand(c.Context),
 		newDeleteAuthCommand(c.Context),
 		newActivateAuthCommand(c.Context),
+		newStatusAuthCommand(c.Context),
 	)
 	return err
 }
@@ -84,7 +86,8 @@ func (c *authCommand) Run(cmd *cobra.Command, args []string) cenclierrors.Cencli
 		return nil
 	}
 
-	if c.accessible {
+	// the table is interactive, so it is only shown when the user can use it
+	if c.accessible || !term.Interactive() {
 		return c.printTable(cmd, values)
 	}
 	return c.runTable(cmd, values)
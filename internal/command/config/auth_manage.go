@@ -188,6 +188,9 @@ func (c *addAuthCommand) Run(cmd *cobra.Command, args []string) cenclierrors.Cen
 		if errors.Is(err, form.ErrUserAborted) {
 			return nil
 		}
+		if errors.Is(err, form.ErrNonInteractive) {
+			return form.NewNonInteractiveError("adding a token", "pass the token with --value or --value-file")
+		}
 		return cenclierrors.NewCencliError(err)
 	}
 
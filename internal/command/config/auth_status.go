@@ -0,0 +1,211 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/store"
+)
+
+type statusAuthCommand struct {
+	*command.BaseCommand
+	check bool
+	flags statusAuthCommandFlags
+}
+
+type statusAuthCommandFlags struct {
+	check flags.BoolFlag
+}
+
+var _ command.Command = (*statusAuthCommand)(nil)
+
+func newStatusAuthCommand(ctx *command.Context) *statusAuthCommand {
+	return &statusAuthCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *statusAuthCommand) Use() string { return "status" }
+func (c *statusAuthCommand) Short() string {
+	return "Show the active personal access token, and check that the API accepts it"
+}
+
+func (c *statusAuthCommand) Long() string {
+	return "Show the active personal access token: when it was added and last used, and when it expires if the token says so.\n\n" +
+		"With --check, also send a request that uses no credits to check that the API accepts the token. " +
+		"The command fails if it does not, telling an expired or revoked token apart from one without access to the organization."
+}
+
+func (c *statusAuthCommand) Examples() []string {
+	return []string{"", "--check"}
+}
+
+func (c *statusAuthCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *statusAuthCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *statusAuthCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *statusAuthCommand) Init() error {
+	c.flags.check = flags.NewBoolFlag(
+		c.Flags(),
+		"check",
+		"",
+		false,
+		"check that the API accepts the token, with a request that uses no credits",
+	)
+	return nil
+}
+
+func (c *statusAuthCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.check, err = c.flags.check.Value()
+	return err
+}
+
+func (c *statusAuthCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	auth, err := c.Store().GetLastUsedAuthByName(cmd.Context(), config.AuthName)
+	if err != nil {
+		if errors.Is(err, store.ErrAuthNotFound) {
+			return newNoActiveTokenError()
+		}
+		return cenclierrors.NewCencliError(fmt.Errorf("failed to get last used auth: %w", err))
+	}
+
+	now := c.Now()
+	formatter.Printf(formatter.Stdout, "🔑 Active personal access token [%s] (id %d)\n", auth.Description, auth.ID)
+	formatter.Printf(formatter.Stdout, "  Added:     %s\n", formatter.FormatLongTime(auth.CreatedAt))
+	formatter.Printf(formatter.Stdout, "  Last used: %s\n", formatter.FormatLongTime(auth.LastUsedAt))
+	if expiry, ok := authdom.TokenExpiry(auth.Value); ok {
+		formatter.Printf(formatter.Stdout, "  Expires:   %s (%s)\n", formatter.FormatLongTime(expiry), relativeDays(expiry, now))
+	} else {
+		formatter.Printf(formatter.Stdout, "  Expires:   %s\n", styles.GlobalStyles.Comment.Render("unknown (the token does not say)"))
+	}
+	if !c.check {
+		return nil
+	}
+
+	svc, serr := c.CreditsService()
+	if serr != nil {
+		return serr
+	}
+	cerr := c.WithProgress(
+		cmd.Context(),
+		c.Logger("auth-status"),
+		"Checking the token...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			_, err := svc.GetUserCreditDetails(pctx)
+			return err
+		},
+	)
+	if cerr != nil {
+		if failure, ok := client.AuthFailureOf(cerr); ok {
+			return newTokenRefusedError(failure)
+		}
+		return cerr
+	}
+	formatter.Println(formatter.Stdout, "✅ The API accepted the token")
+	return nil
+}
+
+// relativeDays describes t relative to now in whole days, e.g. "in 3 days".
+func relativeDays(t, now time.Time) string {
+	d := t.Sub(now)
+	if d < 0 {
+		days := int(-d.Hours() / 24)
+		if days == 0 {
+			return "expired today"
+		}
+		return fmt.Sprintf("expired %d %s ago", days, pluralDays(days))
+	}
+	days := int(d.Hours() / 24)
+	if days == 0 {
+		return "expires today"
+	}
+	return fmt.Sprintf("in %d %s", days, pluralDays(days))
+}
+
+func pluralDays(n int) string {
+	if n == 1 {
+		return "day"
+	}
+	return "days"
+}
+
+type NoActiveTokenError interface {
+	cenclierrors.CencliError
+}
+
+type noActiveTokenError struct{}
+
+var _ NoActiveTokenError = &noActiveTokenError{}
+
+func newNoActiveTokenError() NoActiveTokenError {
+	return &noActiveTokenError{}
+}
+
+func (e *noActiveTokenError) Error() string {
+	return "no personal access token is configured; add one with `censys config auth add`"
+}
+
+func (e *noActiveTokenError) Title() string {
+	return "No Personal Access Token"
+}
+
+func (e *noActiveTokenError) ShouldPrintUsage() bool {
+	return false
+}
+
+// TokenRefusedError is returned by `config auth status --check` when the API
+// refuses the active token.
+type TokenRefusedError interface {
+	cenclierrors.CencliError
+	Failure() client.AuthFailure
+}
+
+type tokenRefusedError struct {
+	failure client.AuthFailure
+}
+
+var _ TokenRefusedError = &tokenRefusedError{}
+
+func newTokenRefusedError(failure client.AuthFailure) TokenRefusedError {
+	return &tokenRefusedError{failure: failure}
+}
+
+func (e *tokenRefusedError) Error() string {
+	if e.failure == client.AuthFailurePermission {
+		return "the API accepted the token, but it has no access to the organization; " +
+			"check the organization ID with `censys config org-id` and the permissions of the token"
+	}
+	return "the API refused the token: it has expired or was revoked; add a new one with `censys config auth add`"
+}
+
+func (e *tokenRefusedError) Title() string {
+	if e.failure == client.AuthFailurePermission {
+		return "Token Lacks Permission"
+	}
+	return "Token Expired or Revoked"
+}
+
+func (e *tokenRefusedError) ShouldPrintUsage() bool {
+	return false
+}
+
+func (e *tokenRefusedError) Failure() client.AuthFailure {
+	return e.failure
+}
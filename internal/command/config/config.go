@@ -30,6 +30,8 @@ func (c *Command) Init() error {
 		newAuthCommand(c.Context),
 		newOrganizationIDCommand(c.Context),
 		newPrintCommand(c.Context),
+		newValidateCommand(c.Context),
+		newListCommand(c.Context),
 	)
 }
 
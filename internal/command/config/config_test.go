@@ -2,15 +2,29 @@ package config
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/censys/censys-sdk-go/models/sdkerrors"
+	"github.com/samber/mo"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	creditsmocks "github.com/censys/cencli/gen/app/credits/mocks"
 	storemocks "github.com/censys/cencli/gen/store/mocks"
+	appcredits "github.com/censys/cencli/internal/app/credits"
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/config"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
 	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/ui/form"
+	"github.com/censys/cencli/internal/store"
 )
 
 func TestConfig_HelpShows(t *testing.T) {
@@ -31,3 +45,255 @@ func TestConfig_HelpShows(t *testing.T) {
 	// Execute should succeed and print help
 	require.NoError(t, root.Execute())
 }
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		args    []string
+		assert  func(t *testing.T, stdout string, err error)
+	}{
+		{
+			name:    "valid file",
+			content: "search:\n  page-size: 50\n",
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "is valid")
+			},
+		},
+		{
+			name:    "prints all problems",
+			content: "serach:\n  page-size: 50\nsearch:\n  max-pages: 0\ntimeouts:\n  http: 30\n",
+			assert: func(t *testing.T, stdout string, err error) {
+				require.ErrorContains(t, err, "found 2 problems in")
+				require.Contains(t, stdout, `serach  unknown key; did you mean "search"?`)
+				require.Contains(t, stdout, "timeouts.http  missing unit in duration")
+			},
+		},
+		{
+			name:    "json output",
+			content: "dns:\n  resolver: bind\n",
+			args:    []string{"-O", "json"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var problemsErr ConfigProblemsError
+				require.ErrorAs(t, err, &problemsErr)
+				var result validateResult
+				require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+				require.False(t, result.Valid)
+				require.Equal(t, []config.Problem{{Key: "dns.resolver", Message: `must be system or doh, got "bind"`}}, result.Problems)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o644))
+
+			ctrl := gomock.NewController(t)
+			ctx := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl))
+			root, cerr := command.RootCommandToCobra(NewConfigCommand(ctx))
+			require.NoError(t, cerr)
+
+			root.SetArgs(append([]string{"validate", path}, tc.args...))
+			cmdErr := root.Execute()
+			tc.assert(t, stdout.String(), cmdErr)
+		})
+	}
+}
+
+func TestConfig_List(t *testing.T) {
+	viper.Reset()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("search:\n  page-size: 50\n"), 0o644))
+	t.Setenv("CENCLI_SEARCH_MAX_PAGES", "3")
+	cfg, err := config.New(dir)
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	ctrl := gomock.NewController(t)
+	ctx := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl))
+	root, cerr := command.RootCommandToCobra(NewConfigCommand(ctx))
+	require.NoError(t, cerr)
+
+	root.SetArgs([]string{"list", "--show-source", "-O", "json"})
+	require.NoError(t, root.Execute())
+
+	var settings []config.Setting
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &settings))
+	byKey := make(map[string]config.Setting)
+	for _, s := range settings {
+		byKey[s.Key] = s
+	}
+	require.Equal(t, config.SourceEnv, byKey["search.max-pages"].Source)
+	require.Equal(t, config.SourceFile, byKey["search.page-size"].Source)
+	require.Equal(t, "CENCLI_SEARCH_PAGE_SIZE", byKey["search.page-size"].EnvVar)
+	require.Contains(t, byKey, "templates.host.path")
+}
+
+func TestConfig_AuthAdd_NonInteractive(t *testing.T) {
+	viper.Reset()
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl))
+
+	root, cerr := command.RootCommandToCobra(NewConfigCommand(ctx))
+	require.NoError(t, cerr)
+
+	// tests have no terminal, so the token cannot be prompted for
+	root.SetArgs([]string{"auth", "add"})
+	cmdErr := root.Execute()
+	var nonInteractiveErr form.NonInteractiveError
+	require.ErrorAs(t, cmdErr, &nonInteractiveErr)
+	require.ErrorContains(t, cmdErr, "--value or --value-file")
+}
+
+func TestConfig_AuthStatus(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1750161600}`)) // 2025-06-17T12:00:00Z
+	jwt := "eyJhbGciOiJIUzI1NiJ9." + claims + ".sig"
+	activeToken := func(value string) func(ctrl *gomock.Controller) store.Store {
+		return func(ctrl *gomock.Controller) store.Store {
+			mockStore := storemocks.NewMockStore(ctrl)
+			mockStore.EXPECT().GetLastUsedAuthByName(gomock.Any(), config.AuthName).Return(&store.ValueForAuth{
+				ID: 3, Name: config.AuthName, Description: "work", Value: value, CreatedAt: now, LastUsedAt: now,
+			}, nil)
+			return mockStore
+		}
+	}
+	refusedBy := func(err error) func(ctrl *gomock.Controller) appcredits.Service {
+		return func(ctrl *gomock.Controller) appcredits.Service {
+			mockSvc := creditsmocks.NewMockCreditsService(ctrl)
+			mockSvc.EXPECT().GetUserCreditDetails(gomock.Any()).Return(appcredits.UserCreditDetailsResult{}, err)
+			return mockSvc
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		store   func(ctrl *gomock.Controller) store.Store
+		service func(ctrl *gomock.Controller) appcredits.Service
+		args    []string
+		assert  func(t *testing.T, stdout string, err error)
+	}{
+		{
+			name:  "expiry from the token",
+			store: activeToken(jwt),
+			args:  []string{"auth", "status"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "[work] (id 3)")
+				require.Contains(t, stdout, "(in 16 days)")
+				require.NotContains(t, stdout, "accepted")
+			},
+		},
+		{
+			name:  "token without an expiry",
+			store: activeToken("censys_opaque"),
+			args:  []string{"auth", "status"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "unknown (the token does not say)")
+			},
+		},
+		{
+			name: "no token",
+			store: func(ctrl *gomock.Controller) store.Store {
+				mockStore := storemocks.NewMockStore(ctrl)
+				mockStore.EXPECT().GetLastUsedAuthByName(gomock.Any(), config.AuthName).Return(nil, store.ErrAuthNotFound)
+				return mockStore
+			},
+			args: []string{"auth", "status", "--check"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var noTokenErr NoActiveTokenError
+				require.ErrorAs(t, err, &noTokenErr)
+			},
+		},
+		{
+			name:  "check accepted",
+			store: activeToken(jwt),
+			service: func(ctrl *gomock.Controller) appcredits.Service {
+				mockSvc := creditsmocks.NewMockCreditsService(ctrl)
+				mockSvc.EXPECT().GetUserCreditDetails(gomock.Any()).Return(appcredits.UserCreditDetailsResult{}, nil)
+				return mockSvc
+			},
+			args: []string{"auth", "status", "--check"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "The API accepted the token")
+			},
+		},
+		{
+			name:  "check refused as expired",
+			store: activeToken(jwt),
+			service: refusedBy(client.NewClientError(&sdkerrors.AuthenticationError{Error_: &components.AuthenticationErrorDetail{
+				Code: mo.Some[int64](401).ToPointer(),
+			}})),
+			args: []string{"auth", "status", "--check"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var refusedErr TokenRefusedError
+				require.ErrorAs(t, err, &refusedErr)
+				require.Equal(t, client.AuthFailureExpired, refusedErr.Failure())
+				require.ErrorContains(t, err, "censys config auth add")
+			},
+		},
+		{
+			name:  "check refused for permission",
+			store: activeToken(jwt),
+			service: refusedBy(client.NewClientError(&sdkerrors.ErrorModel{
+				Status: mo.Some[int64](403).ToPointer(),
+				Detail: mo.Some("no access to this organization").ToPointer(),
+			})),
+			args: []string{"auth", "status", "--check"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var refusedErr TokenRefusedError
+				require.ErrorAs(t, err, &refusedErr)
+				require.Equal(t, client.AuthFailurePermission, refusedErr.Failure())
+				require.Equal(t, "Token Lacks Permission", refusedErr.Title())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			opts := []command.ContextOpts{command.WithClock(func() time.Time { return now })}
+			if tc.service != nil {
+				opts = append(opts, command.WithCreditsService(tc.service(ctrl)))
+			}
+			ctx := command.NewCommandContext(cfg, tc.store(ctrl), opts...)
+			root, cerr := command.RootCommandToCobra(NewConfigCommand(ctx))
+			require.NoError(t, cerr)
+
+			root.SetArgs(tc.args)
+			execErr := root.Execute()
+			tc.assert(t, stdout.String(), execErr)
+		})
+	}
+}
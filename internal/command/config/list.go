@@ -0,0 +1,164 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+type listCommand struct {
+	*command.BaseCommand
+	flags      listCommandFlags
+	showSource bool
+	// result stored for rendering
+	settings []config.Setting
+}
+
+type listCommandFlags struct {
+	showSource flags.BoolFlag
+}
+
+var _ command.Command = (*listCommand)(nil)
+
+func newListCommand(ctx *command.Context) *listCommand {
+	return &listCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *listCommand) Use() string   { return "list" }
+func (c *listCommand) Short() string { return "List every config key and its effective value" }
+func (c *listCommand) Long() string {
+	return `List every config key with its effective value and the environment variable
+that sets it.
+
+Each key can be set by a flag (for global flags), an environment variable, the
+config file, or its default, in that order of precedence. Use --show-source to see
+which one each value came from, e.g. to check the settings of a container.
+
+Values of secrets, such as forward.splunk.token, are redacted.`
+}
+
+func (c *listCommand) Examples() []string {
+	return []string{
+		"--show-source",
+		"--show-source -O json | jq '.[] | select(.source == \"env\")'",
+	}
+}
+
+func (c *listCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *listCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *listCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *listCommand) Init() error {
+	c.flags.showSource = flags.NewBoolFlag(c.Flags(), "show-source", "", false,
+		"show where each value came from: a flag, an environment variable, the config file, or the default")
+	return nil
+}
+
+func (c *listCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.showSource, err = c.flags.showSource.Value()
+	return err
+}
+
+func (c *listCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	c.settings = config.Settings()
+	if !c.showSource {
+		for i := range c.settings {
+			c.settings[i].Source, c.settings[i].Origin = "", ""
+		}
+	}
+	return c.PrintData(c, c.settings)
+}
+
+func (c *listCommand) RenderShort() cenclierrors.CencliError {
+	columns := []rawtable.Column[config.Setting]{
+		{
+			Title:  "Key",
+			String: func(s config.Setting) string { return s.Key },
+			Style: func(v string, _ config.Setting) string {
+				return styles.GlobalStyles.Primary.Render(v)
+			},
+			Priority:   4,
+			NoTruncate: true,
+		},
+		{
+			Title:    "Value",
+			String:   func(s config.Setting) string { return formatValue(s.Value) },
+			Priority: 3,
+		},
+	}
+	if c.showSource {
+		columns = append(columns, rawtable.Column[config.Setting]{
+			Title: "Source",
+			String: func(s config.Setting) string {
+				if s.Origin == "" {
+					return s.Source
+				}
+				return fmt.Sprintf("%s (%s)", s.Source, s.Origin)
+			},
+			Style: func(v string, s config.Setting) string {
+				if s.Source == config.SourceDefault {
+					return styles.GlobalStyles.Comment.Render(v)
+				}
+				return styles.GlobalStyles.Secondary.Render(v)
+			},
+			Priority: 2,
+		})
+	}
+	columns = append(columns, rawtable.Column[config.Setting]{
+		Title:  "Environment Variable",
+		String: func(s config.Setting) string { return s.EnvVar },
+		Style: func(v string, _ config.Setting) string {
+			return styles.GlobalStyles.Comment.Render(v)
+		},
+		Priority: 1,
+	})
+	tbl := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[config.Setting](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[config.Setting](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[config.Setting](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, tbl.Render(c.settings))
+	return nil
+}
+
+// formatValue renders a config value on one line: scalars as they are, and
+// lists and sections as JSON.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	case time.Duration:
+		return v.String()
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
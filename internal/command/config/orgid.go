@@ -12,6 +12,7 @@ import (
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	"github.com/censys/cencli/internal/pkg/flags"
 	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/term"
 	"github.com/censys/cencli/internal/store"
 )
 
@@ -88,7 +89,8 @@ func (c *organizationIDCommand) Run(cmd *cobra.Command, args []string) cenclierr
 		return nil
 	}
 
-	if c.accessible {
+	// the table is interactive, so it is only shown when the user can use it
+	if c.accessible || !term.Interactive() {
 		return c.printTable(cmd, values)
 	}
 	return c.runTable(cmd, values)
@@ -193,6 +193,9 @@ func (c *addOrganizationIDCommand) Run(cmd *cobra.Command, args []string) cencli
 		if errors.Is(err, form.ErrUserAborted) {
 			return nil
 		}
+		if errors.Is(err, form.ErrNonInteractive) {
+			return form.NewNonInteractiveError("adding an organization ID", "pass the organization ID with --value or --value-file")
+		}
 		return cenclierrors.NewCencliError(err)
 	}
 
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
+)
+
+type validateCommand struct {
+	*command.BaseCommand
+	// state - populated by PreRun
+	path   string
+	loaded bool
+	// result stored for rendering
+	result validateResult
+}
+
+// validateResult is the outcome of checking a config file.
+type validateResult struct {
+	File     string           `json:"file"`
+	Valid    bool             `json:"valid"`
+	Problems []config.Problem `json:"problems"`
+}
+
+var _ command.Command = (*validateCommand)(nil)
+
+func newValidateCommand(ctx *command.Context) *validateCommand {
+	return &validateCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *validateCommand) Use() string   { return "validate [file]" }
+func (c *validateCommand) Short() string { return "Check a config file for problems" }
+func (c *validateCommand) Long() string {
+	return `Check a config file for problems and print all of them at once: unknown keys
+(usually typos, which would otherwise be ignored), values of the wrong type, and
+values out of range, such as a page size of 0 or a URL without a scheme.
+
+Checks the loaded config file by default, including that the templates it refers
+to are readable. Pass the path of another file to check it before installing it.
+
+Exits with a non-zero status if any problem is found.`
+}
+
+func (c *validateCommand) Examples() []string {
+	return []string{
+		"# check the loaded config file",
+		"./config.yaml # check a file before installing it",
+	}
+}
+
+func (c *validateCommand) Args() command.PositionalArgs { return command.RangeArgs(0, 1) }
+
+func (c *validateCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *validateCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *validateCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	if len(args) == 1 {
+		c.path = args[0]
+		return nil
+	}
+	c.path = config.FilePath()
+	if c.path == "" {
+		return cenclierrors.NewUsageError(errors.New("no config file is loaded; pass the path of a file to check"))
+	}
+	c.loaded = true
+	return nil
+}
+
+func (c *validateCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	problems, err := config.CheckFile(c.path)
+	if err != nil {
+		return cenclierrors.NewCencliError(fmt.Errorf("failed to read config file: %w", err))
+	}
+	if c.loaded {
+		// files referred to by the loaded config can disappear after startup
+		for _, problem := range c.Config().Validate() {
+			problems = append(problems, config.Problem{Message: problem})
+		}
+	}
+	c.result = validateResult{File: c.path, Valid: len(problems) == 0, Problems: problems}
+	if c.result.Problems == nil {
+		c.result.Problems = []config.Problem{}
+	}
+
+	if err := c.PrintData(c, c.result); err != nil {
+		return err
+	}
+	if !c.result.Valid {
+		return newConfigProblemsError(c.path, len(problems))
+	}
+	return nil
+}
+
+func (c *validateCommand) RenderShort() cenclierrors.CencliError {
+	var out strings.Builder
+	if c.result.Valid {
+		fmt.Fprintf(&out, "%s %s is valid\n", styles.GlobalStyles.Secondary.Render(term.Glyph("✓", "+")), c.result.File)
+		fmt.Fprint(formatter.Stdout, out.String())
+		return nil
+	}
+	for _, p := range c.result.Problems {
+		symbol := styles.GlobalStyles.Danger.Render(term.Glyph("✗", "x"))
+		if p.Key == "" {
+			fmt.Fprintf(&out, "  %s %s\n", symbol, p.Message)
+			continue
+		}
+		fmt.Fprintf(&out, "  %s %s  %s\n", symbol, styles.GlobalStyles.Primary.Render(p.Key), p.Message)
+	}
+	fmt.Fprint(formatter.Stdout, out.String())
+	return nil
+}
+
+// ConfigProblemsError is returned when a config file has problems, so that
+// the command exits with a non-zero status.
+type ConfigProblemsError interface {
+	cenclierrors.CencliError
+}
+
+type configProblemsError struct {
+	path  string
+	count int
+}
+
+var _ ConfigProblemsError = &configProblemsError{}
+
+func newConfigProblemsError(path string, count int) ConfigProblemsError {
+	return &configProblemsError{path: path, count: count}
+}
+
+func (e *configProblemsError) Error() string {
+	noun := "problems"
+	if e.count == 1 {
+		noun = "problem"
+	}
+	return fmt.Sprintf("found %d %s in %s", e.count, noun, e.path)
+}
+
+func (e *configProblemsError) Title() string {
+	return "Invalid Config"
+}
+
+func (e *configProblemsError) ShouldPrintUsage() bool {
+	return false
+}
@@ -5,12 +5,17 @@ import (
 	"errors"
 	"log/slog"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/samber/mo"
+	"github.com/spf13/cobra"
 
 	"github.com/censys/cencli/internal/app/aggregate"
 	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/app/certwatch"
+	"github.com/censys/cencli/internal/app/compare"
 	"github.com/censys/cencli/internal/app/credits"
 	"github.com/censys/cencli/internal/app/enrich"
 	"github.com/censys/cencli/internal/app/history"
@@ -19,12 +24,18 @@ import (
 	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/app/view"
 	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/appdirs"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
 	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/metrics"
+	"github.com/censys/cencli/internal/pkg/scope"
+	"github.com/censys/cencli/internal/pkg/searchurl"
 	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/tree"
 	"github.com/censys/cencli/internal/store"
 )
 
@@ -33,9 +44,44 @@ type Context struct {
 	config              *config.Config
 	store               store.Store
 	censysClient        client.Client
+	connect             ClientFactory
 	logger              *slog.Logger
+	dirs                appdirs.Dirs
 	colorDisabledStdout bool
 	colorDisabledStderr bool
+	// recordSessions enables recording command output into the active session
+	recordSessions bool
+	// invocation is the running command, recorded into the active session by PrintData
+	invocation *invocation
+	// usage is the running command, recorded as a usage event by RecordUsage
+	usage *usageRun
+	// clock returns the current time, unless it is pinned with --now
+	clock     func() time.Time
+	pinnedNow mo.Option[time.Time]
+	// metrics collects the API calls of the run, for the attempts on the response meta line
+	metrics *metrics.Collector
+	// forwarder forwards the data printed by PrintData, while a command forwards its results
+	forwarder *forwarder
+	// dryRunPlan collects the requests of the command with --dry-run
+	dryRunPlan *client.DryRunPlan
+	// envelope holds the data output of the command with --envelope
+	envelope *envelopeOutput
+	// auditing is set once the client appends its calls to the audit log
+	auditing bool
+	// memo remembers the API results of the command, so it never fetches identical data twice
+	memo *client.Memo
+	// decoratedFor is the command the client was decorated for by
+	// decorateClient, so that a reconnected client is decorated again
+	decoratedFor *cobra.Command
+	// dataPrinted is set once the command writes data, so that it is not
+	// run again after re-authentication
+	dataPrinted atomic.Bool
+	// scope restricts the assets the command queries to scope.file
+	scope *scope.Scope
+	// scopeWarned holds the violations of the scope already printed with
+	// --out-of-scope warn; it is nil when violations fail the command
+	scopeWarned map[string]bool
+	scopeMu     sync.Mutex
 	// services
 	viewSvc      view.Service
 	enrichSvc    enrich.Service
@@ -45,6 +91,8 @@ type Context struct {
 	censeyeSvc   censeye.Service
 	creditsSvc   credits.Service
 	orgSvc       organizations.Service
+	compareSvc   compare.Service
+	certWatchSvc certwatch.Service
 }
 
 // ContextOpts are functional options for configuring Context
@@ -55,7 +103,7 @@ func NewCommandContext(
 	st store.Store,
 	opts ...ContextOpts,
 ) *Context {
-	c := &Context{config: cfg, store: st, logger: slog.Default()}
+	c := &Context{config: cfg, store: st, logger: slog.Default(), clock: time.Now}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -89,12 +137,77 @@ func (c *Context) updateColorSettings() {
 func (c *Context) Config() *config.Config { return c.config }
 func (c *Context) Store() store.Store     { return c.store }
 
+// Dirs returns the directories cencli stores files in.
+// They are empty unless the context was created with WithAppDirs.
+func (c *Context) Dirs() appdirs.Dirs { return c.dirs }
+
+// WithAppDirs records the directories cencli stores files in.
+func WithAppDirs(dirs appdirs.Dirs) ContextOpts {
+	return func(c *Context) { c.dirs = dirs }
+}
+
 // SetLogger sets the logger used by commands created with this context.
 func (c *Context) SetLogger(l *slog.Logger) { c.logger = l }
 
 // SetClient sets the Context's client so that it can be used to initialize services.
 func (c *Context) SetCensysClient(cli client.Client) { c.censysClient = cli }
 
+// ClientFactory builds the client from the stored credentials.
+type ClientFactory func(ctx context.Context) (client.Client, error)
+
+// WithCensysClientFactory sets how the client is built, so that it can be
+// built again once the stored credentials change.
+func WithCensysClientFactory(connect ClientFactory) ContextOpts {
+	return func(c *Context) { c.connect = connect }
+}
+
+// ReconnectCensysClient builds the client again with the factory of
+// WithCensysClientFactory, e.g. once a token or organization ID is added,
+// and drops the services built with the previous client. The new client is
+// decorated like the previous one, so that its calls are still audited and
+// scope-checked. The client is unset if no token is stored.
+func (c *Context) ReconnectCensysClient(ctx context.Context) cenclierrors.CencliError {
+	if c.connect == nil {
+		return nil
+	}
+	cli, err := c.connect(ctx)
+	if err != nil && !errors.Is(err, authdom.ErrAuthNotFound) {
+		return cenclierrors.NewCencliError(err)
+	}
+	c.censysClient = cli
+	// the decorators of the previous client are applied to this one too
+	c.auditing, c.memo, c.dryRunPlan, c.scope, c.scopeWarned = false, nil, nil, nil, nil
+	if c.decoratedFor != nil {
+		if err := c.decorateClient(c.decoratedFor); err != nil {
+			return err
+		}
+	}
+	c.viewSvc, c.enrichSvc, c.searchSvc, c.aggregateSvc, c.historySvc = nil, nil, nil, nil, nil
+	c.censeyeSvc, c.creditsSvc, c.orgSvc, c.compareSvc, c.certWatchSvc = nil, nil, nil, nil, nil
+	return nil
+}
+
+// decorateClient wraps the client so that its calls are appended to the
+// audit log, answered from the memo, planned by a dry run and checked
+// against scope.file, as configured. Each decorator wraps the client once.
+func (c *Context) decorateClient(cobraCmd *cobra.Command) cenclierrors.CencliError {
+	c.decoratedFor = cobraCmd
+	// Append every API call to the audit log with audit.enabled
+	if err := c.startAudit(cobraCmd); err != nil {
+		return err
+	}
+	// Remember API results, so identical lookups are made once
+	c.startMemo()
+	// Plan the requests of the command instead of sending them with --dry-run
+	c.startDryRun()
+	// Refuse requests for assets out of scope.file
+	return c.startScope()
+}
+
+// DataPrinted returns true once the command wrote data to stdout or
+// forwarded it, so that running it again would repeat that data.
+func (c *Context) DataPrinted() bool { return c.dataPrinted.Load() }
+
 // HasOrgID returns true if the context has a configured organization ID.
 func (c *Context) HasOrgID() bool {
 	return c.censysClient != nil && c.censysClient.HasOrgID()
@@ -155,11 +268,34 @@ func (c *Context) WithProgress(
 }
 
 func (c *Context) PrintData(cmd Command, data any) cenclierrors.CencliError {
+	// A dry run prints its planned requests instead of the empty results
+	if c.dryRunPlanned() {
+		return nil
+	}
+
+	c.recordSessionEntry(context.Background(), data)
+
+	// Streamed items are forwarded as they are emitted
+	if c.forwarder != nil && !c.config.Streaming {
+		c.dataPrinted.Store(true)
+		if err := c.forwarder.forward(data); err != nil {
+			return err
+		}
+	}
+
 	// Streaming formats are handled by WithStreamingOutput - nothing to do here
 	if c.config.Streaming {
 		return nil
 	}
 
+	// With --envelope, the data is printed with the metadata and partial
+	// error of the command once it finishes
+	if c.envelope != nil {
+		c.envelope.data = append(c.envelope.data, data)
+		return nil
+	}
+
+	c.dataPrinted.Store(true)
 	switch c.config.OutputFormat {
 	case formatter.OutputFormatShort:
 		if c.colorDisabledStdout {
@@ -183,6 +319,12 @@ func (c *Context) PrintYAML(data any) cenclierrors.CencliError {
 	return cenclierrors.NewCencliError(formatter.PrintYAML(data, !c.colorDisabledStdout))
 }
 
+// PrintTree shows data in the interactive tree view, or prints it as YAML
+// without a terminal.
+func (c *Context) PrintTree(data any, opts ...tree.Option) cenclierrors.CencliError {
+	return formatter.PrintTree(data, !c.colorDisabledStdout, opts...)
+}
+
 // PrintDataWithTemplate renders data through a template and writes the result to stdout.
 func (c *Context) PrintDataWithTemplate(entity config.TemplateEntity, data any) cenclierrors.CencliError {
 	templateConfig, err := c.config.GetTemplate(entity)
@@ -192,13 +334,45 @@ func (c *Context) PrintDataWithTemplate(entity config.TemplateEntity, data any)
 	return formatter.PrintDataWithTemplate(templateConfig.Path, !c.colorDisabledStdout, data)
 }
 
+// WithMetrics sets the collector of the API calls of the run, so that the
+// response meta line counts the retries of every request.
+func WithMetrics(collector *metrics.Collector) ContextOpts {
+	return func(c *Context) { c.metrics = collector }
+}
+
 // PrintAppResponseMeta renders application-level response metadata to stderr.
-// If the quiet flag is set, this is a no-op.
-// If the debug flag is set, this will also print the headers.
+// If the meta-json flag is set, the metadata is printed as a JSON line, even if
+// the quiet flag is set. Otherwise, if the quiet flag is set, this is a no-op.
+// If the debug flag is set, this will also print the headers. With --dry-run,
+// nothing is printed, as no response was received. With --envelope, the
+// metadata is also recorded in the envelope.
 func (c *Context) PrintAppResponseMeta(meta *responsemeta.ResponseMeta) {
-	if !c.config.Quiet && meta != nil {
-		formatter.PrintAppResponseMeta(styles.GlobalStyles, meta, c.config.Debug, !c.colorDisabledStderr)
+	if meta == nil || c.dryRunPlanned() {
+		return
+	}
+	if c.metrics != nil {
+		// the meta only has the retries of the last request
+		if snap := c.metrics.Snapshot(); snap.Requests > 0 {
+			withTotals := *meta
+			withTotals.Attempts = snap.Requests + snap.Retries
+			withTotals.RetryCount = snap.Retries
+			meta = &withTotals
+		}
+	}
+	// recorded for the envelope even with --quiet
+	if c.envelope != nil {
+		c.envelope.meta = meta
 	}
+	if c.config.Quiet && !c.config.MetaJSON {
+		return
+	}
+	if c.config.MetaJSON {
+		if err := formatter.PrintAppResponseMetaJSON(meta, c.config.Debug); err != nil {
+			c.logger.Debug("failed to print response meta", "error", err)
+		}
+		return
+	}
+	formatter.PrintAppResponseMeta(styles.GlobalStyles, meta, c.config.Debug, !c.colorDisabledStderr)
 }
 
 // WithStreamingOutput sets up streaming output infrastructure when streaming mode is enabled.
@@ -236,6 +410,7 @@ func (c *Context) WithStreamingOutput(
 				logger.Debug("streaming item error", "error", item.Err)
 				continue
 			}
+			c.dataPrinted.Store(true)
 			if err := formatter.WriteNDJSONItem(formatter.Stdout, item.Data, !c.colorDisabledStdout); err != nil {
 				logger.Debug("failed to write streaming item", "error", err)
 			}
@@ -253,6 +428,12 @@ func (c *Context) WithStreamingOutput(
 	return ctx, stop
 }
 
+// SearchURLs returns the builder of the links to Censys platform searches,
+// which follows search.url-template.
+func (c *Context) SearchURLs() searchurl.Builder {
+	return searchurl.New(c.config.Search.URLTemplate)
+}
+
 // =====================
 // Service-specific
 // =====================
@@ -330,7 +511,7 @@ func (c *Context) CenseyeService() (censeye.Service, cenclierrors.CencliError) {
 		return nil, client.NewCensysClientNotConfiguredError()
 	}
 	// Memoize
-	c.censeyeSvc = censeye.New(c.censysClient)
+	c.censeyeSvc = censeye.New(c.censysClient, c.SearchURLs())
 	return c.censeyeSvc, nil
 }
 
@@ -422,3 +603,44 @@ func (c *Context) OrganizationsService() (organizations.Service, cenclierrors.Ce
 func WithOrganizationsService(svc organizations.Service) ContextOpts {
 	return func(c *Context) { c.orgSvc = svc }
 }
+
+// CompareService attempts to provide a CompareService to the caller.
+// If it is not already set and is unable to be instantiated, it will return an error.
+func (c *Context) CompareService() (compare.Service, cenclierrors.CencliError) {
+	if c.compareSvc != nil {
+		return c.compareSvc, nil
+	}
+	if c.censysClient == nil {
+		return nil, client.NewCensysClientNotConfiguredError()
+	}
+	// Memoize the service instance since it's stateless and thread-safe for reuse
+	c.compareSvc = compare.New(c.censysClient)
+	return c.compareSvc, nil
+}
+
+// WithCompareService injects an instantiated CompareService to the Context.
+// This should only be used in tests; in the app the service is instantiated on demand.
+func WithCompareService(svc compare.Service) ContextOpts {
+	return func(c *Context) { c.compareSvc = svc }
+}
+
+// CertWatchService attempts to provide a CertWatchService to the caller.
+// If it is not already set and is unable to be instantiated, it will return an error.
+func (c *Context) CertWatchService() (certwatch.Service, cenclierrors.CencliError) {
+	if c.certWatchSvc != nil {
+		return c.certWatchSvc, nil
+	}
+	if c.censysClient == nil {
+		return nil, client.NewCensysClientNotConfiguredError()
+	}
+	// Memoize the service instance since it's stateless and thread-safe for reuse
+	c.certWatchSvc = certwatch.New(c.censysClient)
+	return c.certWatchSvc, nil
+}
+
+// WithCertWatchService injects an instantiated CertWatchService to the Context.
+// This should only be used in tests, as in the application,
+// the CertWatchService will be instantiated on demand.
+func WithCertWatchService(svc certwatch.Service) ContextOpts {
+	return func(c *Context) { c.certWatchSvc = svc }
+}
@@ -0,0 +1,94 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/audit"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
+)
+
+func TestReconnectCensysClient(t *testing.T) {
+	ctx := context.Background()
+	first := mocks.NewMockClient(gomock.NewController(t))
+	second := mocks.NewMockClient(gomock.NewController(t))
+	var next client.Client
+	var connectErr error
+	c := NewCommandContext(&config.Config{}, nil, WithCensysClientFactory(func(context.Context) (client.Client, error) {
+		return next, connectErr
+	}))
+	c.SetCensysClient(first)
+	svc, err := c.ViewService()
+	require.NoError(t, err)
+
+	next = second
+	require.NoError(t, c.ReconnectCensysClient(ctx))
+	require.Same(t, second, c.censysClient)
+	rebuilt, err := c.ViewService()
+	require.NoError(t, err)
+	require.NotSame(t, svc, rebuilt, "services are built again with the new client")
+
+	next, connectErr = nil, authdom.ErrAuthNotFound
+	require.NoError(t, c.ReconnectCensysClient(ctx))
+	require.Nil(t, c.censysClient)
+
+	connectErr = errors.New("invalid api-url")
+	require.EqualError(t, c.ReconnectCensysClient(ctx), "invalid api-url")
+}
+
+func TestReconnectCensysClientKeepsDecorators(t *testing.T) {
+	defer viper.Reset()
+	viper.Reset()
+	ctx := context.Background()
+	none := mo.None[string]()
+	noTime := mo.None[time.Time]()
+
+	dir := t.TempDir()
+	cfg, cfgErr := config.New(dir)
+	require.NoError(t, cfgErr)
+	cfg.Audit.Enabled = true
+	cfg.Audit.File = filepath.Join(dir, "audit.jsonl")
+	cfg.Scope.File = filepath.Join(dir, "scope.txt")
+	require.NoError(t, os.WriteFile(cfg.Scope.File, []byte("198.51.100.0/24\n"), 0o600))
+
+	first := mocks.NewMockClient(gomock.NewController(t))
+	second := mocks.NewMockClient(gomock.NewController(t))
+	c := NewCommandContext(cfg, nil, WithCensysClientFactory(func(context.Context) (client.Client, error) {
+		return second, nil
+	}))
+	c.SetCensysClient(first)
+	root := &cobra.Command{Use: "censys"}
+	cmd := &cobra.Command{Use: "view"}
+	root.AddCommand(cmd)
+	require.NoError(t, c.decorateClient(cmd))
+
+	// the token is replaced after the first run, and the command runs again
+	require.NoError(t, c.ReconnectCensysClient(ctx))
+	require.NoError(t, c.decorateClient(cmd), "the retried command does not wrap the client twice")
+
+	second.EXPECT().GetHosts(ctx, none, []string{"198.51.100.1"}, noTime).
+		Return(client.Result[[]components.Host]{}, nil)
+	_, err := c.censysClient.GetHosts(ctx, none, []string{"198.51.100.1"}, noTime)
+	require.NoError(t, err)
+	_, err = c.censysClient.GetHosts(ctx, none, []string{"203.0.113.5"}, noTime)
+	require.EqualError(t, err, "203.0.113.5 is out of scope: no allowed range covers it")
+
+	entries, readErr := audit.Read(cfg.Audit.File)
+	require.NoError(t, readErr)
+	require.Len(t, entries, 1)
+	require.Equal(t, "view", entries[0].Command)
+}
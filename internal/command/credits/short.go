@@ -29,7 +29,7 @@ func (c *Command) showUserCredits(result credits.UserCreditDetailsResult) cencli
 	// Resets At
 	if data.ResetsAt.IsPresent() {
 		resetTime := data.ResetsAt.MustGet()
-		resetStr := fmt.Sprintf("(resets %s)", resetTime.Format("2006-01-02"))
+		resetStr := fmt.Sprintf("(resets %s)", formatter.FormatDate(resetTime))
 		fmt.Fprintf(&out, " %s", styles.GlobalStyles.Comment.Render(resetStr))
 	}
 
@@ -0,0 +1,515 @@
+package dev
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/clients/censys/fakeserver"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/events"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+	"github.com/censys/cencli/internal/pkg/ui/tree"
+)
+
+const (
+	defaultBenchmarkHosts    = 10000
+	defaultBenchmarkServices = 10
+	defaultBenchmarkPageSize = 100
+	defaultBenchmarkCount    = 3
+)
+
+// Benchmark scenarios, in the order they run.
+const (
+	scenarioSearch    = "search"
+	scenarioView      = "view"
+	scenarioJSON      = "json"
+	scenarioJSONColor = "json-color"
+	scenarioYAML      = "yaml"
+	scenarioTree      = "tree"
+)
+
+var benchmarkScenarios = []string{scenarioSearch, scenarioView, scenarioJSON, scenarioJSONColor, scenarioYAML, scenarioTree}
+
+type benchmarkCommand struct {
+	*command.BaseCommand
+	// flags the command uses
+	flags benchmarkCommandFlags
+	// state - populated by PreRun
+	scenarios []string
+	hosts     int
+	services  int
+	pageSize  int
+	count     int
+	latency   time.Duration
+	// result - populated by Run
+	report *benchmarkReport
+}
+
+type benchmarkCommandFlags struct {
+	scenarios flags.StringSliceFlag
+	hosts     flags.IntegerFlag
+	services  flags.IntegerFlag
+	pageSize  flags.IntegerFlag
+	count     flags.IntegerFlag
+	latency   flags.DurationFlag
+}
+
+// benchmarkReport is the data output of the command.
+type benchmarkReport struct {
+	Hosts    int               `json:"hosts"`
+	Services int               `json:"services"`
+	PageSize int               `json:"page_size"`
+	Count    int               `json:"count"`
+	Results  []benchmarkResult `json:"results"`
+}
+
+// benchmarkResult is the fastest of the runs of a scenario.
+type benchmarkResult struct {
+	Scenario string `json:"scenario"`
+	// Items is the number of hosts the scenario handles in a run.
+	Items int `json:"items"`
+	// Requests is the number of API requests of a run.
+	Requests       int     `json:"requests"`
+	Seconds        float64 `json:"seconds"`
+	ItemsPerSecond float64 `json:"items_per_second"`
+	// Bytes is the size of the output of a formatting scenario.
+	Bytes       int64   `json:"bytes,omitempty"`
+	MBPerSecond float64 `json:"mb_per_second,omitempty"`
+	// Allocs and AllocBytes count the heap allocations of a run.
+	Allocs     uint64 `json:"allocs"`
+	AllocBytes uint64 `json:"alloc_bytes"`
+}
+
+var _ command.Command = (*benchmarkCommand)(nil)
+
+func newBenchmarkCommand(cmdContext *command.Context) *benchmarkCommand {
+	return &benchmarkCommand{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *benchmarkCommand) Use() string {
+	return "benchmark"
+}
+
+func (c *benchmarkCommand) Short() string {
+	return "Measure search, view, formatting, and the tree view on a large synthetic dataset"
+}
+
+func (c *benchmarkCommand) Long() string {
+	return fmt.Sprintf(`Measure the throughput and allocations of the CLI on a large synthetic
+dataset served by a local fake of the API, as a baseline for performance work.
+
+The scenarios are:
+  search      paginate a search over every host, --page-size hits a page
+  view        look up every host by IP, in batches of 100
+  json        write the hosts as JSON
+  json-color  write the hosts as colored JSON
+  yaml        write the hosts as YAML
+  tree        parse the hosts into the tree view
+
+Each scenario runs --count times and the fastest run is reported. The fake
+server runs in this process, so the allocations of search and view include
+those of the server. No credentials or credits are used. Use -O json to
+save a baseline to compare later runs with. Scenarios: %v.`, benchmarkScenarios)
+}
+
+func (c *benchmarkCommand) Examples() []string {
+	return []string{
+		"",
+		"--scenario search --scenario view --latency 20ms",
+		"--hosts 100000 --services 50 --scenario tree",
+		"-O json > baseline.json",
+	}
+}
+
+func (c *benchmarkCommand) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *benchmarkCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *benchmarkCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort, command.OutputTypeData}
+}
+
+func (c *benchmarkCommand) Init() error {
+	c.flags.scenarios = flags.NewStringSliceFlag(
+		c.Flags(),
+		false,
+		"scenario",
+		"s",
+		nil,
+		fmt.Sprintf("run this scenario (repeatable; default all of %v)", benchmarkScenarios),
+	)
+	c.flags.hosts = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"hosts",
+		"",
+		mo.Some[int64](defaultBenchmarkHosts),
+		"number of hosts of the dataset",
+		mo.Some[int64](1),
+		mo.Some[int64](1<<24-2),
+	)
+	c.flags.services = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"services",
+		"",
+		mo.Some[int64](defaultBenchmarkServices),
+		"number of services of each host",
+		mo.Some[int64](0),
+		mo.None[int64](),
+	)
+	c.flags.pageSize = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"page-size",
+		"",
+		mo.Some[int64](defaultBenchmarkPageSize),
+		"number of hits of each page of the search scenario",
+		mo.Some[int64](1),
+		mo.None[int64](),
+	)
+	c.flags.count = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"count",
+		"n",
+		mo.Some[int64](defaultBenchmarkCount),
+		"number of runs of each scenario",
+		mo.Some[int64](1),
+		mo.None[int64](),
+	)
+	c.flags.latency = flags.NewDurationFlag(
+		c.Flags(),
+		false,
+		"latency",
+		"",
+		mo.Some(time.Duration(0)),
+		"delay each response of the fake server, to simulate the network",
+	)
+	return nil
+}
+
+func (c *benchmarkCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.scenarios, err = c.flags.scenarios.Value(); err != nil {
+		return err
+	}
+	for _, name := range c.scenarios {
+		if !slices.Contains(benchmarkScenarios, name) {
+			return newUnknownScenarioError(name, benchmarkScenarios)
+		}
+	}
+	if len(c.scenarios) == 0 {
+		c.scenarios = benchmarkScenarios
+	}
+	for _, f := range []struct {
+		flag flags.IntegerFlag
+		dst  *int
+	}{
+		{c.flags.hosts, &c.hosts},
+		{c.flags.services, &c.services},
+		{c.flags.pageSize, &c.pageSize},
+		{c.flags.count, &c.count},
+	} {
+		v, err := f.flag.Value()
+		if err != nil {
+			return err
+		}
+		*f.dst = int(v.MustGet())
+	}
+	latency, err := c.flags.latency.Value()
+	if err != nil {
+		return err
+	}
+	c.latency = latency.MustGet()
+	return nil
+}
+
+func (c *benchmarkCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	srv := fakeserver.New(
+		fakeserver.WithFixtures(fakeserver.SyntheticFixtures(c.hosts, c.services)),
+		fakeserver.WithLatency(c.latency),
+	)
+	defer srv.Close()
+	// the fake server accepts any token; requests are not retried, so that
+	// each run makes the same requests
+	sdk, sdkErr := client.NewCensysSDKWithToken("benchmark", "", srv.URL, 0,
+		config.DefaultTransportConfig(), config.RetryStrategy{MaxAttempts: 1}, false)
+	if sdkErr != nil {
+		return cenclierrors.NewCencliError(sdkErr)
+	}
+	b := &benchmark{
+		srv:       srv,
+		searchSvc: search.New(sdk),
+		viewSvc:   view.New(sdk),
+		hostIDs:   make([]assets.HostID, c.hosts),
+		pageSize:  c.pageSize,
+	}
+	for i := range b.hostIDs {
+		id, err := assets.NewHostID(fakeserver.SyntheticHostIP(i))
+		if err != nil {
+			return cenclierrors.NewCencliError(err)
+		}
+		b.hostIDs[i] = id
+	}
+
+	c.report = &benchmarkReport{Hosts: c.hosts, Services: c.services, PageSize: c.pageSize, Count: c.count, Results: []benchmarkResult{}}
+	err := c.WithProgress(
+		cmd.Context(),
+		c.Logger("dev-benchmark"),
+		fmt.Sprintf("Generating %d hosts...", c.hosts),
+		func(pctx context.Context) cenclierrors.CencliError {
+			// the formatting scenarios work on the hosts as view returns them
+			if err := b.load(pctx); err != nil {
+				return err
+			}
+			for i, name := range c.scenarios {
+				events.ReportBatch(pctx, events.StageProcess, uint64(i), uint64(len(c.scenarios)),
+					fmt.Sprintf("Running %s (%d/%d)...", name, i+1, len(c.scenarios)))
+				result, err := b.run(pctx, name, c.count)
+				if err != nil {
+					return err
+				}
+				c.report.Results = append(c.report.Results, result)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return c.PrintData(c, c.report)
+}
+
+func (c *benchmarkCommand) RenderShort() cenclierrors.CencliError {
+	r := c.report
+	formatter.Printf(formatter.Stdout, "%d hosts with %d services, fastest of %d runs\n\n", r.Hosts, r.Services, r.Count)
+	tbl := rawtable.New(
+		[]rawtable.Column[benchmarkResult]{
+			{
+				Title:      "Scenario",
+				String:     func(res benchmarkResult) string { return res.Scenario },
+				Style:      func(s string, _ benchmarkResult) string { return styles.GlobalStyles.Signature.Render(s) },
+				NoTruncate: true,
+			},
+			{
+				Title:      "Time",
+				String:     func(res benchmarkResult) string { return formatBenchmarkSeconds(res.Seconds) },
+				AlignRight: true,
+			},
+			{
+				Title:      "Hosts/s",
+				String:     func(res benchmarkResult) string { return strconv.FormatFloat(res.ItemsPerSecond, 'f', 0, 64) },
+				AlignRight: true,
+			},
+			{
+				Title: "MB/s",
+				String: func(res benchmarkResult) string {
+					if res.Bytes == 0 {
+						return "-"
+					}
+					return strconv.FormatFloat(res.MBPerSecond, 'f', 1, 64)
+				},
+				AlignRight: true,
+			},
+			{
+				Title:      "Requests",
+				String:     func(res benchmarkResult) string { return strconv.Itoa(res.Requests) },
+				AlignRight: true,
+			},
+			{
+				Title:      "Allocs/host",
+				String:     func(res benchmarkResult) string { return strconv.FormatUint(res.Allocs/uint64(max(res.Items, 1)), 10) },
+				AlignRight: true,
+			},
+			{
+				Title:      "Alloc MB",
+				String:     func(res benchmarkResult) string { return strconv.FormatFloat(float64(res.AllocBytes)/1e6, 'f', 1, 64) },
+				AlignRight: true,
+			},
+		},
+		rawtable.WithHeaderStyle[benchmarkResult](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[benchmarkResult](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[benchmarkResult](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, tbl.Render(r.Results))
+	return nil
+}
+
+// formatBenchmarkSeconds formats a duration in seconds, rounded to the
+// microsecond.
+func formatBenchmarkSeconds(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Microsecond).String()
+}
+
+// benchmark runs the scenarios against a fake server.
+type benchmark struct {
+	srv       *fakeserver.Server
+	searchSvc search.Service
+	viewSvc   view.Service
+	hostIDs   []assets.HostID
+	pageSize  int
+	// hosts are the hosts of the dataset, and hostsJSON their JSON, which
+	// the formatting scenarios start from
+	hosts     []*assets.Host
+	hostsJSON []byte
+}
+
+// load fetches the hosts of the dataset for the formatting scenarios.
+func (b *benchmark) load(ctx context.Context) cenclierrors.CencliError {
+	res, err := b.viewSvc.GetHosts(ctx, mo.None[identifiers.OrganizationID](), b.hostIDs, mo.None[time.Time]())
+	if err != nil {
+		return err
+	}
+	b.hosts = res.Hosts
+	data, jsonErr := json.Marshal(b.hosts)
+	if jsonErr != nil {
+		return cenclierrors.NewCencliError(jsonErr)
+	}
+	b.hostsJSON = data
+	return nil
+}
+
+// run runs the scenario name count times, and returns the fastest run.
+func (b *benchmark) run(ctx context.Context, name string, count int) (benchmarkResult, cenclierrors.CencliError) {
+	var best benchmarkResult
+	for i := range count {
+		result, err := b.measure(ctx, name)
+		if err != nil {
+			return benchmarkResult{}, err
+		}
+		if i == 0 || result.Seconds < best.Seconds {
+			best = result
+		}
+	}
+	return best, nil
+}
+
+// measure runs the scenario name once.
+func (b *benchmark) measure(ctx context.Context, name string) (benchmarkResult, cenclierrors.CencliError) {
+	fn := b.scenario(name)
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	requests := len(b.srv.Requests())
+	start := time.Now()
+
+	written, err := fn(ctx)
+	if err != nil {
+		return benchmarkResult{}, err
+	}
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	result := benchmarkResult{
+		Scenario:       name,
+		Items:          len(b.hostIDs),
+		Requests:       len(b.srv.Requests()) - requests,
+		Seconds:        elapsed.Seconds(),
+		ItemsPerSecond: float64(len(b.hostIDs)) / elapsed.Seconds(),
+		Bytes:          written,
+		Allocs:         after.Mallocs - before.Mallocs,
+		AllocBytes:     after.TotalAlloc - before.TotalAlloc,
+	}
+	if written > 0 {
+		result.MBPerSecond = float64(written) / 1e6 / elapsed.Seconds()
+	}
+	return result, nil
+}
+
+// scenario returns the function of the scenario name, which returns the
+// number of bytes it wrote, if any.
+func (b *benchmark) scenario(name string) func(context.Context) (int64, cenclierrors.CencliError) {
+	switch name {
+	case scenarioSearch:
+		return func(ctx context.Context) (int64, cenclierrors.CencliError) {
+			pages := (len(b.hostIDs) + b.pageSize - 1) / b.pageSize
+			res, err := b.searchSvc.Search(ctx, search.Params{
+				Query:    "*",
+				PageSize: mo.Some(uint64(b.pageSize)),
+				MaxPages: mo.Some(uint64(pages)),
+			})
+			if err != nil {
+				return 0, err
+			}
+			if len(res.Hits) != len(b.hostIDs) {
+				return 0, cenclierrors.NewCencliError(fmt.Errorf("search returned %d of %d hosts", len(res.Hits), len(b.hostIDs)))
+			}
+			return 0, nil
+		}
+	case scenarioView:
+		return func(ctx context.Context) (int64, cenclierrors.CencliError) {
+			res, err := b.viewSvc.GetHosts(ctx, mo.None[identifiers.OrganizationID](), b.hostIDs, mo.None[time.Time]())
+			if err != nil {
+				return 0, err
+			}
+			if len(res.Hosts) != len(b.hostIDs) {
+				return 0, cenclierrors.NewCencliError(fmt.Errorf("view returned %d of %d hosts", len(res.Hosts), len(b.hostIDs)))
+			}
+			return 0, nil
+		}
+	case scenarioJSON, scenarioJSONColor:
+		colored := name == scenarioJSONColor
+		return func(context.Context) (int64, cenclierrors.CencliError) {
+			w := &countingWriter{}
+			if err := formatter.WriteJSON(w, b.hosts, colored); err != nil {
+				return 0, cenclierrors.NewCencliError(err)
+			}
+			return w.n, nil
+		}
+	case scenarioYAML:
+		return func(context.Context) (int64, cenclierrors.CencliError) {
+			w := &countingWriter{}
+			if err := formatter.WriteYAML(w, b.hosts, false); err != nil {
+				return 0, cenclierrors.NewCencliError(err)
+			}
+			return w.n, nil
+		}
+	default:
+		return func(context.Context) (int64, cenclierrors.CencliError) {
+			var data any
+			dec := json.NewDecoder(bytes.NewReader(b.hostsJSON))
+			if err := dec.Decode(&data); err != nil {
+				return 0, cenclierrors.NewCencliError(err)
+			}
+			tree.NodeCount(data)
+			return 0, nil
+		}
+	}
+}
+
+// countingWriter discards what is written to it, and counts its size.
+type countingWriter struct {
+	n int64
+}
+
+var _ io.Writer = (*countingWriter)(nil)
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,43 @@
+package dev
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchmarkCommand(t *testing.T) {
+	t.Run("runs the selected scenarios against the fake server", func(t *testing.T) {
+		stdout, err := runDev(t, "benchmark", "--hosts", "250", "--services", "3", "--page-size", "100", "--count", "1",
+			"--scenario", "search", "--scenario", "view", "--scenario", "json", "--scenario", "tree")
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(stdout, "250 hosts with 3 services, fastest of 1 runs\n"), stdout)
+		rows := map[string][]string{}
+		for _, line := range strings.Split(stdout, "\n") {
+			cells := strings.Split(line, "|")
+			if len(cells) > 1 {
+				for i := range cells {
+					cells[i] = strings.TrimSpace(cells[i])
+				}
+				rows[cells[0]] = cells
+			}
+		}
+		require.Len(t, rows, 4)
+		// search fetches 3 pages, and view 3 batches of 100 hosts
+		assert.Equal(t, "3", rows["search"][4])
+		assert.Equal(t, "3", rows["view"][4])
+		assert.Equal(t, "0", rows["json"][4])
+		assert.NotEqual(t, "-", rows["json"][3], "formatting scenarios report their throughput in bytes")
+		assert.Equal(t, "-", rows["tree"][3])
+	})
+
+	t.Run("rejects unknown scenarios", func(t *testing.T) {
+		_, err := runDev(t, "benchmark", "--scenario", "whois")
+		var unknown UnknownScenarioError
+		require.ErrorAs(t, err, &unknown)
+		assert.Contains(t, err.Error(), `unknown scenario "whois"`)
+	})
+}
@@ -0,0 +1,69 @@
+// Package dev implements the hidden dev command, which groups tools for
+// contributors to cencli. Its subcommands are not part of the documented CLI.
+package dev
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/tape"
+)
+
+// Command is the parent dev command that groups developer subcommands.
+type Command struct {
+	*command.BaseCommand
+	// root records the tapes of the root command, which cannot be created here
+	root tape.Recordable
+}
+
+var _ command.Command = (*Command)(nil)
+
+// NewDevCommand creates a new dev command. root is the root command, whose
+// tapes are recorded by dev tape along with those of the other commands.
+func NewDevCommand(cmdContext *command.Context, root tape.Recordable) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext), root: root}
+}
+
+func (c *Command) Use() string {
+	return "dev"
+}
+
+func (c *Command) Short() string {
+	return "Tools for cencli contributors"
+}
+
+func (c *Command) Hidden() bool {
+	return true
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	return c.AddSubCommands(
+		newTapeCommand(c.Context, c.root),
+		newBenchmarkCommand(c.Context),
+	)
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	// Parent command shows help when run without subcommands
+	if err := cmd.Help(); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package dev
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// UnknownTapeCommandError is returned when --command names a command that
+// has no tapes.
+type (
+	UnknownTapeCommandError interface{ cenclierrors.CencliError }
+	unknownTapeCommandError struct {
+		name      string
+		available []string
+	}
+)
+
+func newUnknownTapeCommandError(name string, available []string) UnknownTapeCommandError {
+	return &unknownTapeCommandError{name: name, available: available}
+}
+
+func (e *unknownTapeCommandError) Error() string {
+	return fmt.Sprintf("%q has no tapes; available commands: %s", e.name, strings.Join(e.available, ", "))
+}
+
+func (e *unknownTapeCommandError) Title() string          { return "Unknown Command" }
+func (e *unknownTapeCommandError) ShouldPrintUsage() bool { return true }
+
+// TapeFailedError is returned when recording a tape fails.
+type (
+	TapeFailedError interface{ cenclierrors.CencliError }
+	tapeFailedError struct {
+		name string
+		err  error
+	}
+)
+
+func newTapeFailedError(name string, err error) TapeFailedError {
+	return &tapeFailedError{name: name, err: err}
+}
+
+func (e *tapeFailedError) Error() string {
+	return fmt.Sprintf("failed to record tape %s: %v", e.name, e.err)
+}
+
+func (e *tapeFailedError) Unwrap() error { return e.err }
+
+func (e *tapeFailedError) Title() string          { return "Recording Failed" }
+func (e *tapeFailedError) ShouldPrintUsage() bool { return false }
+
+// UnknownScenarioError is returned when --scenario names a scenario that
+// the benchmark does not have.
+type (
+	UnknownScenarioError interface{ cenclierrors.CencliError }
+	unknownScenarioError struct {
+		name      string
+		available []string
+	}
+)
+
+func newUnknownScenarioError(name string, available []string) UnknownScenarioError {
+	return &unknownScenarioError{name: name, available: available}
+}
+
+func (e *unknownScenarioError) Error() string {
+	return fmt.Sprintf("unknown scenario %q; available scenarios: %s", e.name, strings.Join(e.available, ", "))
+}
+
+func (e *unknownScenarioError) Title() string          { return "Unknown Scenario" }
+func (e *unknownScenarioError) ShouldPrintUsage() bool { return true }
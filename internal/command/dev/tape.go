@@ -0,0 +1,349 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/command/aggregate"
+	"github.com/censys/cencli/internal/command/censeye"
+	"github.com/censys/cencli/internal/command/enrich"
+	"github.com/censys/cencli/internal/command/history"
+	"github.com/censys/cencli/internal/command/search"
+	"github.com/censys/cencli/internal/command/view"
+	"github.com/censys/cencli/internal/pkg/appdirs"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/clients/censys/fakeserver"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/tape"
+	"github.com/censys/cencli/internal/pkg/ui/events"
+)
+
+const (
+	defaultOutputDir   = "examples"
+	defaultVHS         = "vhs"
+	defaultTapeTimeout = 2 * time.Minute
+
+	// rootTapes names the tapes of the root command, which are written to
+	// the output directory itself rather than a subdirectory.
+	rootTapes = "root"
+)
+
+// tapeCommands are the commands that have tapes, in recording order.
+var tapeCommands = []string{rootTapes, "search", "view", "aggregate", "censeye", "history", "enrich"}
+
+type tapeCommand struct {
+	*command.BaseCommand
+	root tape.Recordable
+	// flags the command uses
+	flags tapeCommandFlags
+	// state - populated by PreRun
+	commands   []string
+	outputDir  string
+	binary     string
+	vhs        string
+	fakeServer bool
+	parallel   int
+	timeout    time.Duration
+	// result - populated by Run
+	recorded []string
+}
+
+type tapeCommandFlags struct {
+	commands   flags.StringSliceFlag
+	outputDir  flags.StringFlag
+	binary     flags.StringFlag
+	vhs        flags.StringFlag
+	fakeServer flags.BoolFlag
+	parallel   flags.IntegerFlag
+	timeout    flags.DurationFlag
+}
+
+var _ command.Command = (*tapeCommand)(nil)
+
+func newTapeCommand(cmdContext *command.Context, root tape.Recordable) *tapeCommand {
+	return &tapeCommand{BaseCommand: command.NewBaseCommand(cmdContext), root: root}
+}
+
+func (c *tapeCommand) Use() string {
+	return "tape"
+}
+
+func (c *tapeCommand) Short() string {
+	return "Regenerate the demo recordings in examples/"
+}
+
+func (c *tapeCommand) Long() string {
+	return `Regenerate the demo recordings (GIFs) of the commands that have them, with VHS.
+
+Each command describes its tapes; this types them into a terminal recorded by
+VHS, running this binary (or --binary). With --fake-server, the commands run
+against a local fake of the API with a throwaway token instead of your
+account, so recordings can be regenerated without credentials or credits.
+The fake server only serves search, view, aggregate, host history, and
+credits.`
+}
+
+func (c *tapeCommand) Examples() []string {
+	return []string{
+		"",
+		"--command search --command view",
+		"--fake-server --parallel 4",
+		"--binary ./bin/censys --output-dir /tmp/examples",
+	}
+}
+
+func (c *tapeCommand) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *tapeCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *tapeCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *tapeCommand) Init() error {
+	c.flags.commands = flags.NewStringSliceFlag(
+		c.Flags(),
+		false,
+		"command",
+		"c",
+		nil,
+		fmt.Sprintf("record the tapes of this command (repeatable; default all of %v)", tapeCommands),
+	)
+	c.flags.outputDir = flags.NewStringFlag(
+		c.Flags(),
+		false,
+		"output-dir",
+		"",
+		defaultOutputDir,
+		"directory to write the recordings to, one subdirectory per command",
+	)
+	c.flags.binary = flags.NewStringFlag(
+		c.Flags(),
+		false,
+		"binary",
+		"",
+		"",
+		"CLI binary to record (default: this binary)",
+	)
+	c.flags.vhs = flags.NewStringFlag(
+		c.Flags(),
+		false,
+		"vhs",
+		"",
+		defaultVHS,
+		"VHS binary to record with",
+	)
+	c.flags.fakeServer = flags.NewBoolFlag(
+		c.Flags(),
+		"fake-server",
+		"",
+		false,
+		"run the recorded commands against a local fake API server",
+	)
+	c.flags.parallel = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"parallel",
+		"j",
+		mo.Some[int64](1),
+		"number of tapes to record at once",
+		mo.Some[int64](1),
+		mo.None[int64](),
+	)
+	c.flags.timeout = flags.NewDurationFlag(
+		c.Flags(),
+		false,
+		"timeout",
+		"",
+		mo.Some(defaultTapeTimeout),
+		"maximum time to record a single tape",
+	)
+	return nil
+}
+
+func (c *tapeCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.commands, err = c.flags.commands.Value(); err != nil {
+		return err
+	}
+	for _, name := range c.commands {
+		if !slices.Contains(tapeCommands, name) {
+			return newUnknownTapeCommandError(name, tapeCommands)
+		}
+	}
+	if len(c.commands) == 0 {
+		c.commands = tapeCommands
+	}
+	if c.outputDir, err = c.flags.outputDir.Value(); err != nil {
+		return err
+	}
+	if c.binary, err = c.flags.binary.Value(); err != nil {
+		return err
+	}
+	if c.binary == "" {
+		exe, exeErr := os.Executable()
+		if exeErr != nil {
+			return cenclierrors.NewCencliError(fmt.Errorf("failed to locate this binary: %w", exeErr))
+		}
+		c.binary = exe
+	} else if abs, absErr := filepath.Abs(c.binary); absErr == nil {
+		c.binary = abs
+	}
+	if c.vhs, err = c.flags.vhs.Value(); err != nil {
+		return err
+	}
+	if c.fakeServer, err = c.flags.fakeServer.Value(); err != nil {
+		return err
+	}
+	parallel, err := c.flags.parallel.Value()
+	if err != nil {
+		return err
+	}
+	c.parallel = int(parallel.MustGet())
+	timeout, err := c.flags.timeout.Value()
+	if err != nil {
+		return err
+	}
+	c.timeout = timeout.MustGet()
+	return nil
+}
+
+func (c *tapeCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	env := map[string]string{"FORCE_COLOR": "1"}
+	if c.fakeServer {
+		srv := fakeserver.New()
+		defer srv.Close()
+		fakeEnv, err := c.fakeServerEnv(cmd.Context(), srv.URL)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(fakeEnv[appdirs.EnvDataDir])
+		for key, value := range fakeEnv {
+			env[key] = value
+		}
+	}
+
+	recorder, recErr := tape.NewTapeRecorder(c.vhs, c.binary, env)
+	if recErr != nil {
+		return cenclierrors.NewCencliError(recErr)
+	}
+
+	type job struct {
+		tape      tape.Tape
+		outputDir string
+	}
+	var jobs []job
+	for _, name := range c.commands {
+		outputDir := filepath.Join(c.outputDir, name)
+		if name == rootTapes {
+			outputDir = c.outputDir
+		}
+		for _, t := range c.recordable(name).Tapes(recorder) {
+			jobs = append(jobs, job{tape: t, outputDir: outputDir})
+		}
+	}
+
+	c.recorded = make([]string, len(jobs))
+	err := c.WithProgress(
+		cmd.Context(),
+		c.Logger("dev-tape"),
+		fmt.Sprintf("Recording %d tapes...", len(jobs)),
+		func(pctx context.Context) cenclierrors.CencliError {
+			var done atomic.Uint64
+			g, gctx := errgroup.WithContext(pctx)
+			g.SetLimit(c.parallel)
+			for i, j := range jobs {
+				g.Go(func() error {
+					tctx, cancel := context.WithTimeout(gctx, c.timeout)
+					defer cancel()
+					if err := recorder.CreateTape(tctx, j.tape, j.outputDir); err != nil {
+						return newTapeFailedError(j.tape.Name, err)
+					}
+					c.recorded[i] = filepath.Join(j.outputDir, j.tape.Name+".gif")
+					n := done.Add(1)
+					events.ReportBatch(pctx, events.StageProcess, n, uint64(len(jobs)), fmt.Sprintf("Recorded %s (%d/%d)", j.tape.Name, n, len(jobs)))
+					return nil
+				})
+			}
+			if err := g.Wait(); err != nil {
+				if cerr, ok := err.(cenclierrors.CencliError); ok {
+					return cerr
+				}
+				return cenclierrors.NewCencliError(err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return c.PrintData(c, c.recorded)
+}
+
+func (c *tapeCommand) RenderShort() cenclierrors.CencliError {
+	for _, path := range c.recorded {
+		formatter.Println(formatter.Stdout, path)
+	}
+	return nil
+}
+
+// recordable returns the command that describes the tapes of name. Tapes only
+// use the recorder, so the commands are created without a context.
+func (c *tapeCommand) recordable(name string) tape.Recordable {
+	switch name {
+	case "search":
+		return search.NewSearchCommand(nil)
+	case "view":
+		return view.NewViewCommand(nil)
+	case "aggregate":
+		return aggregate.NewAggregateCommand(nil)
+	case "censeye":
+		return censeye.NewCenseyeCommand(nil)
+	case "history":
+		return history.NewHistoryCommand(nil)
+	case "enrich":
+		return enrich.NewEnrichCommand(nil)
+	default:
+		return c.root
+	}
+}
+
+// fakeServerEnv returns the environment that points the recorded binary at
+// the fake server at url, with its own data directory holding a throwaway
+// token, which the fake server accepts whatever its value.
+func (c *tapeCommand) fakeServerEnv(ctx context.Context, url string) (map[string]string, cenclierrors.CencliError) {
+	dataDir, err := os.MkdirTemp("", "cencli-tape-*")
+	if err != nil {
+		return nil, cenclierrors.NewCencliError(fmt.Errorf("failed to create a data directory for the fake server: %w", err))
+	}
+	env := map[string]string{
+		appdirs.EnvDataDir: dataDir,
+		"CENCLI_API_URL":   url,
+	}
+	auth := exec.CommandContext(ctx, c.binary, "config", "auth", "add", "--value", "fake-token", "--name", "fake")
+	auth.Env = os.Environ()
+	for key, value := range env {
+		auth.Env = append(auth.Env, key+"="+value)
+	}
+	if out, err := auth.CombinedOutput(); err != nil {
+		_ = os.RemoveAll(dataDir)
+		return nil, cenclierrors.NewCencliError(fmt.Errorf("failed to store a token for the fake server: %w\n%s", err, out))
+	}
+	return env, nil
+}
@@ -0,0 +1,86 @@
+package dev
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/tape"
+)
+
+// fakeVHS writes the value of CENCLI_API_URL into the GIF it is asked to
+// record, and ignores any other invocation, such as storing a token.
+const fakeVHS = `#!/bin/sh
+if [ "$2" = "--output" ]; then
+	printf '%s' "$CENCLI_API_URL" > "$3"
+fi
+`
+
+type stubRoot struct{}
+
+func (stubRoot) Tapes(*tape.Recorder) []tape.Tape {
+	return []tape.Tape{tape.NewTape("cencli", tape.DefaultTapeConfig())}
+}
+
+func runDev(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	// the recorder exports its environment to VHS
+	for _, key := range []string{"PATH", "FORCE_COLOR", "CENCLI_API_URL", "CENCLI_DATA_DIR"} {
+		t.Setenv(key, os.Getenv(key))
+	}
+	viper.Reset()
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &bytes.Buffer{}
+	rootCmd, cerr := command.RootCommandToCobra(NewDevCommand(command.NewCommandContext(cfg, nil), stubRoot{}))
+	require.NoError(t, cerr)
+	rootCmd.SetArgs(args)
+	cmdErr := rootCmd.Execute()
+	return stdout.String(), cmdErr
+}
+
+func TestTapeCommand(t *testing.T) {
+	vhs := filepath.Join(t.TempDir(), "vhs")
+	require.NoError(t, os.WriteFile(vhs, []byte(fakeVHS), 0o755))
+
+	t.Run("records the tapes of the selected commands", func(t *testing.T) {
+		outputDir := t.TempDir()
+		stdout, err := runDev(t, "tape", "--vhs", vhs, "--binary", vhs, "--output-dir", outputDir,
+			"--command", "root", "--command", "search", "--parallel", "2")
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(outputDir, "cencli.gif"))
+		assert.FileExists(t, filepath.Join(outputDir, "search", "search.gif"))
+		assert.NoDirExists(t, filepath.Join(outputDir, "view"))
+		assert.Equal(t, filepath.Join(outputDir, "cencli.gif"), strings.Split(stdout, "\n")[0])
+	})
+
+	t.Run("records against the fake server", func(t *testing.T) {
+		outputDir := t.TempDir()
+		_, err := runDev(t, "tape", "--vhs", vhs, "--binary", vhs, "--output-dir", outputDir, "--command", "root", "--fake-server")
+		require.NoError(t, err)
+
+		gif, err := os.ReadFile(filepath.Join(outputDir, "cencli.gif"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(gif), "http://127.0.0.1:"), "expected the fake server URL, got %q", gif)
+	})
+
+	t.Run("rejects commands without tapes", func(t *testing.T) {
+		_, err := runDev(t, "tape", "--vhs", vhs, "--command", "whois")
+		var unknown UnknownTapeCommandError
+		require.ErrorAs(t, err, &unknown)
+		assert.Contains(t, err.Error(), `"whois" has no tapes`)
+	})
+}
@@ -0,0 +1,379 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	censys "github.com/censys/censys-sdk-go"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/appdirs"
+	clienthttp "github.com/censys/cencli/internal/pkg/clients/http"
+	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/selfupdate"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
+	"github.com/censys/cencli/internal/store"
+	appversion "github.com/censys/cencli/internal/version"
+)
+
+type checkStatus string
+
+const (
+	statusPass checkStatus = "pass"
+	statusWarn checkStatus = "warn"
+	statusFail checkStatus = "fail"
+	// statusSkip is used when a check depends on one that failed.
+	statusSkip checkStatus = "skip"
+)
+
+// checkResult is the outcome of a single diagnostic check.
+type checkResult struct {
+	Name   string      `json:"name"`
+	Status checkStatus `json:"status"`
+	Detail string      `json:"detail"`
+	// Hint suggests how to fix a failed or suspicious check.
+	Hint string `json:"hint,omitempty"`
+}
+
+const (
+	// probeTimeout bounds the unauthenticated request to the API.
+	probeTimeout = 10 * time.Second
+	// clock skew beyond these thresholds is reported
+	clockSkewWarn = 30 * time.Second
+	clockSkewFail = 5 * time.Minute
+	// builds older than this are reported as possibly outdated
+	staleBuildAge = 180 * 24 * time.Hour
+	releasesURL   = "https://github.com/censys/cencli/releases"
+)
+
+// probeResult is what an unauthenticated request to the API reveals.
+type probeResult struct {
+	StatusCode int
+	// ServerTime is the time reported in the Date header, if any.
+	ServerTime time.Time
+	// Proxy is the proxy the request was sent through, if any.
+	Proxy string
+}
+
+type probeFunc func(ctx context.Context, url string) (probeResult, error)
+
+// httpProbe sends a HEAD request to url using the same transport settings
+// (including proxy resolution) as the API client.
+func httpProbe(ctx context.Context, rawURL string) (probeResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return probeResult{}, err
+	}
+	var result probeResult
+	proxy, err := http.ProxyFromEnvironment(req)
+	if err != nil {
+		return probeResult{}, fmt.Errorf("invalid proxy configuration: %w", err)
+	}
+	if proxy != nil {
+		result.Proxy = proxy.Redacted()
+	}
+	userAgent := fmt.Sprintf("cencli/%s (doctor)", appversion.Version)
+	resp, err := clienthttp.New(probeTimeout, userAgent, nil).Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	result.StatusCode = resp.StatusCode
+	if date := resp.Header.Get("Date"); date != "" {
+		if t, err := http.ParseTime(date); err == nil {
+			result.ServerTime = t
+		}
+	}
+	return result, nil
+}
+
+// runChecks runs every check in order. Later checks may depend on the
+// results of earlier ones (e.g. the clock check uses the network probe).
+func (c *Command) runChecks(ctx context.Context) []checkResult {
+	results := []checkResult{
+		c.checkConfig(),
+		c.checkDataDirs(),
+		c.checkCredentials(ctx),
+	}
+	network, probe := c.checkNetwork(ctx)
+	results = append(results,
+		network,
+		c.checkAuthentication(ctx, network.Status),
+		c.checkClock(network.Status, probe),
+		c.checkVersion(),
+		c.checkTerminal(),
+	)
+	return results
+}
+
+func (c *Command) checkConfig() checkResult {
+	result := checkResult{Name: "config"}
+	if problems := c.Config().Validate(); len(problems) > 0 {
+		result.Status = statusFail
+		result.Detail = strings.Join(problems, "; ")
+		result.Hint = "Fix or remove the offending entries in the config file; they will prevent the next run from starting"
+		return result
+	}
+	result.Status = statusPass
+	if path := config.FilePath(); path != "" {
+		result.Detail = "loaded " + path
+	} else {
+		result.Detail = "using defaults"
+	}
+	return result
+}
+
+func (c *Command) checkDataDirs() checkResult {
+	result := checkResult{Name: "data directories"}
+	dirs := c.Dirs()
+	var checked []string
+	for _, dir := range []string{dirs.Config, dirs.Data, dirs.Cache} {
+		if dir == "" {
+			continue
+		}
+		if err := checkWritable(dir); err != nil {
+			result.Status = statusFail
+			result.Detail = err.Error()
+			result.Hint = fmt.Sprintf("Make sure %s is a directory you own and can write to, or point %s at one", dir, dirEnvVar(dirs, dir))
+			return result
+		}
+		checked = append(checked, dir)
+	}
+	if len(checked) == 0 {
+		result.Status = statusSkip
+		result.Detail = "directories are unknown"
+		return result
+	}
+	// the data directory holds access tokens
+	if runtime.GOOS != "windows" && dirs.Data != "" {
+		if info, err := os.Stat(dirs.Data); err == nil && info.Mode().Perm()&0o077 != 0 {
+			result.Status = statusWarn
+			result.Detail = fmt.Sprintf("%s is accessible by other users (mode %s) and contains your access tokens", dirs.Data, info.Mode().Perm())
+			result.Hint = fmt.Sprintf("Restrict it with: chmod 700 %s", dirs.Data)
+			return result
+		}
+	}
+	result.Status = statusPass
+	result.Detail = "writable: " + strings.Join(checked, ", ")
+	return result
+}
+
+// checkWritable returns an error if dir is not a directory that files can be created in.
+func checkWritable(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("cannot access %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	f, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s: %w", dir, err)
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+	return nil
+}
+
+// dirEnvVar returns the environment variable that overrides dir.
+func dirEnvVar(dirs appdirs.Dirs, dir string) string {
+	switch dir {
+	case dirs.Config:
+		return appdirs.EnvConfigDir
+	case dirs.Cache:
+		return appdirs.EnvCacheDir
+	default:
+		return appdirs.EnvDataDir
+	}
+}
+
+func (c *Command) checkCredentials(ctx context.Context) checkResult {
+	result := checkResult{Name: "credentials"}
+	auth, err := c.Store().GetLastUsedAuthByName(ctx, config.AuthName)
+	if err != nil {
+		result.Status = statusFail
+		if errors.Is(err, authdom.ErrAuthNotFound) {
+			result.Detail = "no personal access token is configured"
+			result.Hint = "Add one with: censys config auth add"
+		} else {
+			result.Detail = fmt.Sprintf("failed to read stored credentials: %v", err)
+			result.Hint = "Check that the data directory is readable"
+		}
+		return result
+	}
+	result.Status = statusPass
+	result.Detail = fmt.Sprintf("using personal access token %d", auth.ID)
+	if auth.Description != "" {
+		result.Detail += fmt.Sprintf(" (%s)", auth.Description)
+	}
+	if _, err := c.Store().GetLastUsedGlobalByName(ctx, config.OrgIDGlobalName); err == nil {
+		result.Detail += " with an organization ID"
+	} else if !errors.Is(err, store.ErrGlobalNotFound) {
+		result.Status = statusWarn
+		result.Detail += fmt.Sprintf("; failed to read the organization ID: %v", err)
+	}
+	return result
+}
+
+func (c *Command) checkNetwork(ctx context.Context) (checkResult, probeResult) {
+	result := checkResult{Name: "network"}
+	apiURL := censys.ServerList[0]
+	if c.Config().APIURL != "" {
+		apiURL = c.Config().APIURL
+	}
+	host := apiURL
+	if u, err := url.Parse(apiURL); err == nil {
+		host = u.Host
+	}
+	probe, err := c.probe(ctx, apiURL)
+	via := "directly"
+	if probe.Proxy != "" {
+		via = "via proxy " + probe.Proxy
+	}
+	if err != nil {
+		result.Status = statusFail
+		result.Detail = fmt.Sprintf("cannot reach %s %s: %v", host, via, err)
+		if probe.Proxy != "" {
+			result.Hint = "Check that the proxy in HTTPS_PROXY is reachable, or exclude the API with NO_PROXY"
+		} else {
+			result.Hint = "Check your network connection and firewall; set HTTPS_PROXY if you need a proxy"
+		}
+		return result, probe
+	}
+	result.Status = statusPass
+	result.Detail = fmt.Sprintf("reached %s %s (HTTP %d)", host, via, probe.StatusCode)
+	return result, probe
+}
+
+func (c *Command) checkAuthentication(ctx context.Context, network checkStatus) checkResult {
+	result := checkResult{Name: "authentication"}
+	switch {
+	case c.creditsSvc == nil:
+		result.Status = statusSkip
+		result.Detail = "no credentials to test"
+		return result
+	case network == statusFail:
+		result.Status = statusSkip
+		result.Detail = "the API is unreachable"
+		return result
+	}
+	if _, err := c.creditsSvc.GetUserCreditDetails(ctx); err != nil {
+		result.Status = statusFail
+		result.Detail = fmt.Sprintf("test API call failed: %v", err)
+		result.Hint = "The token may have expired or been revoked; add a new one with: censys config auth add"
+		return result
+	}
+	result.Status = statusPass
+	result.Detail = "the API accepted your personal access token"
+	return result
+}
+
+func (c *Command) checkClock(network checkStatus, probe probeResult) checkResult {
+	result := checkResult{Name: "clock"}
+	if network == statusFail || probe.ServerTime.IsZero() {
+		result.Status = statusSkip
+		result.Detail = "the API server time is unknown"
+		return result
+	}
+	skew := c.now().Sub(probe.ServerTime)
+	direction := "ahead of"
+	if skew < 0 {
+		skew = -skew
+		direction = "behind"
+	}
+	// the Date header has a resolution of one second
+	skew = skew.Round(time.Second)
+	result.Detail = fmt.Sprintf("local clock is %s %s the API server", skew, direction)
+	switch {
+	case skew > clockSkewFail:
+		result.Status = statusFail
+	case skew > clockSkewWarn:
+		result.Status = statusWarn
+	default:
+		result.Status = statusPass
+		return result
+	}
+	result.Hint = "Enable time synchronization (NTP); a skewed clock breaks TLS and makes timestamps misleading"
+	return result
+}
+
+func (c *Command) checkVersion() checkResult {
+	result := checkResult{Name: "version"}
+	info := appversion.BuildInfo()
+	if info.Version == "dev" {
+		result.Status = statusWarn
+		result.Detail = "development build; its age is unknown"
+		result.Hint = "Install a release from " + releasesURL
+		return result
+	}
+	built, err := time.Parse(time.RFC3339, info.Date)
+	if err != nil {
+		result.Status = statusPass
+		result.Detail = fmt.Sprintf("%s (build date unknown)", info.Version)
+		return result
+	}
+	age := c.now().Sub(built)
+	days := int(age.Hours() / 24)
+	result.Detail = fmt.Sprintf("%s, built %s (%d days ago)", info.Version, built.Format("2006-01-02"), days)
+	if latest := selfupdate.CachedLatest(c.Dirs().Cache); selfupdate.IsNewer(latest, info.Version) {
+		result.Status = statusWarn
+		result.Detail += fmt.Sprintf("; %s is available", latest)
+		result.Hint = "Upgrade with: censys update"
+		return result
+	}
+	if age > staleBuildAge {
+		result.Status = statusWarn
+		result.Hint = "Newer releases are likely available; check with: censys update --check"
+		return result
+	}
+	result.Status = statusPass
+	return result
+}
+
+func (c *Command) checkTerminal() checkResult {
+	result := checkResult{Name: "terminal"}
+	var details []string
+	if formatter.StdoutIsTTY() {
+		details = append(details, fmt.Sprintf("interactive (%d columns)", term.GetWidth()))
+	} else {
+		details = append(details, "not interactive")
+	}
+	if styles.ColorDisabled() || c.Config().NoColor {
+		details = append(details, "colors off")
+	} else {
+		details = append(details, "colors on")
+	}
+	if term.SupportsUnicode() {
+		details = append(details, "unicode")
+	} else {
+		details = append(details, "ASCII only")
+	}
+	result.Detail = strings.Join(details, ", ")
+	if !term.ANSISupported() {
+		result.Status = statusWarn
+		result.Hint = "This console does not support escape sequences; use Windows Terminal or a recent PowerShell for colors and interactive modes"
+		return result
+	}
+	result.Status = statusPass
+	return result
+}
+
+func countStatus(results []checkResult, status checkStatus) int {
+	n := 0
+	for _, r := range results {
+		if r.Status == status {
+			n++
+		}
+	}
+	return n
+}
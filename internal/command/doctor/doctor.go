@@ -0,0 +1,113 @@
+package doctor
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/credits"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+const cmdName = "doctor"
+
+type Command struct {
+	*command.BaseCommand
+	// services the command uses; nil when no credentials are configured
+	creditsSvc credits.Service
+	// probe sends a request to the API without credentials. Replaced in tests.
+	probe probeFunc
+	// now returns the local time. Replaced in tests.
+	now func() time.Time
+	// results stored for rendering
+	results []checkResult
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewDoctorCommand(cmdContext *command.Context) *Command {
+	return &Command{
+		BaseCommand: command.NewBaseCommand(cmdContext),
+		probe:       httpProbe,
+		now:         time.Now,
+	}
+}
+
+func (c *Command) Use() string {
+	return cmdName
+}
+
+func (c *Command) Short() string {
+	return "Diagnose problems with your cencli setup"
+}
+
+func (c *Command) Long() string {
+	return `Diagnose problems with your cencli setup.
+
+Checks the configuration, the data directories, your stored credentials (with a test
+API call), network access to the Censys API (including any proxy from HTTPS_PROXY),
+clock skew against the API server, the age of this build, and the capabilities of
+your terminal. Each failed or suspicious check comes with a hint on how to fix it.
+
+Exits with a non-zero status if any check fails. Warnings do not affect the exit status.`
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"# run all checks",
+		"--output-format json # attach the report to a bug report",
+	}
+}
+
+func (c *Command) Init() error {
+	return nil
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	// A missing client is reported by the credentials check rather than
+	// failing the command.
+	c.creditsSvc, _ = c.CreditsService()
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	err := c.WithProgress(
+		cmd.Context(),
+		c.Logger(cmdName),
+		"Running diagnostics...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			c.results = c.runChecks(pctx)
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := c.PrintData(c, c.results); err != nil {
+		return err
+	}
+
+	if failed := countStatus(c.results, statusFail); failed > 0 {
+		return newChecksFailedError(failed, len(c.results))
+	}
+	return nil
+}
+
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	return c.renderReport()
+}
@@ -0,0 +1,227 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	creditsmocks "github.com/censys/cencli/gen/app/credits/mocks"
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/app/credits"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/appdirs"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/store"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func storeWithAuth(ctrl *gomock.Controller) store.Store {
+	st := storemocks.NewMockStore(ctrl)
+	st.EXPECT().GetLastUsedAuthByName(gomock.Any(), config.AuthName).Return(&store.ValueForAuth{
+		ID:          3,
+		Name:        config.AuthName,
+		Description: "work laptop",
+		Value:       "censys_secret_token",
+	}, nil)
+	st.EXPECT().GetLastUsedGlobalByName(gomock.Any(), config.OrgIDGlobalName).Return(nil, store.ErrGlobalNotFound)
+	return st
+}
+
+func reachableProbe(serverTime time.Time) probeFunc {
+	return func(context.Context, string) (probeResult, error) {
+		return probeResult{StatusCode: 200, ServerTime: serverTime}, nil
+	}
+}
+
+func acceptingCredits(ctrl *gomock.Controller) credits.Service {
+	svc := creditsmocks.NewMockCreditsService(ctrl)
+	svc.EXPECT().GetUserCreditDetails(gomock.Any()).Return(credits.UserCreditDetailsResult{}, nil)
+	return svc
+}
+
+func TestDoctorCommand(t *testing.T) {
+	testCases := []struct {
+		name    string
+		store   func(ctrl *gomock.Controller) store.Store
+		service func(ctrl *gomock.Controller) credits.Service
+		probe   probeFunc
+		args    []string
+		assert  func(t *testing.T, results map[string]checkResult, stdout string, err cenclierrors.CencliError)
+	}{
+		{
+			name:    "healthy setup",
+			store:   storeWithAuth,
+			service: acceptingCredits,
+			probe:   reachableProbe(testNow.Add(-2 * time.Second)),
+			assert: func(t *testing.T, results map[string]checkResult, _ string, err cenclierrors.CencliError) {
+				require.NoError(t, err)
+				for _, name := range []string{"config", "data directories", "credentials", "network", "authentication", "clock"} {
+					require.Equal(t, statusPass, results[name].Status, "%s: %s", name, results[name].Detail)
+				}
+				require.Equal(t, "using personal access token 3 (work laptop)", results["credentials"].Detail)
+				require.NotContains(t, results["credentials"].Detail, "censys_secret_token")
+				require.Equal(t, "reached api.platform.censys.io directly (HTTP 200)", results["network"].Detail)
+				require.Equal(t, "local clock is 2s ahead of the API server", results["clock"].Detail)
+				// tests run a development build
+				require.Equal(t, statusWarn, results["version"].Status)
+			},
+		},
+		{
+			name: "no credentials",
+			store: func(ctrl *gomock.Controller) store.Store {
+				st := storemocks.NewMockStore(ctrl)
+				st.EXPECT().GetLastUsedAuthByName(gomock.Any(), config.AuthName).Return(nil, store.ErrAuthNotFound)
+				return st
+			},
+			probe: reachableProbe(testNow),
+			assert: func(t *testing.T, results map[string]checkResult, _ string, err cenclierrors.CencliError) {
+				var failedErr ChecksFailedError
+				require.ErrorAs(t, err, &failedErr)
+				require.Equal(t, statusFail, results["credentials"].Status)
+				require.Contains(t, results["credentials"].Hint, "censys config auth add")
+				require.Equal(t, statusSkip, results["authentication"].Status)
+			},
+		},
+		{
+			name:  "rejected token",
+			store: storeWithAuth,
+			service: func(ctrl *gomock.Controller) credits.Service {
+				svc := creditsmocks.NewMockCreditsService(ctrl)
+				svc.EXPECT().GetUserCreditDetails(gomock.Any()).Return(credits.UserCreditDetailsResult{}, cenclierrors.NewCencliError(errors.New("401 unauthorized")))
+				return svc
+			},
+			probe: reachableProbe(testNow),
+			assert: func(t *testing.T, results map[string]checkResult, _ string, err cenclierrors.CencliError) {
+				require.Error(t, err)
+				require.Equal(t, statusFail, results["authentication"].Status)
+				require.Contains(t, results["authentication"].Detail, "401 unauthorized")
+			},
+		},
+		{
+			name:  "unreachable through a proxy",
+			store: storeWithAuth,
+			service: func(ctrl *gomock.Controller) credits.Service {
+				// no test call is made when the API is unreachable
+				return creditsmocks.NewMockCreditsService(ctrl)
+			},
+			probe: func(context.Context, string) (probeResult, error) {
+				return probeResult{Proxy: "http://proxy.internal:3128"}, errors.New("connection refused")
+			},
+			assert: func(t *testing.T, results map[string]checkResult, _ string, err cenclierrors.CencliError) {
+				require.Error(t, err)
+				require.Equal(t, statusFail, results["network"].Status)
+				require.Contains(t, results["network"].Detail, "via proxy http://proxy.internal:3128")
+				require.Contains(t, results["network"].Hint, "HTTPS_PROXY")
+				require.Equal(t, statusSkip, results["authentication"].Status)
+				require.Equal(t, statusSkip, results["clock"].Status)
+			},
+		},
+		{
+			name:    "clock skew",
+			store:   storeWithAuth,
+			service: acceptingCredits,
+			probe:   reachableProbe(testNow.Add(10 * time.Minute)),
+			assert: func(t *testing.T, results map[string]checkResult, _ string, err cenclierrors.CencliError) {
+				require.Error(t, err)
+				require.Equal(t, statusFail, results["clock"].Status)
+				require.Equal(t, "local clock is 10m0s behind the API server", results["clock"].Detail)
+				require.Contains(t, results["clock"].Hint, "NTP")
+			},
+		},
+		{
+			name:    "short output",
+			store:   storeWithAuth,
+			service: acceptingCredits,
+			probe:   reachableProbe(testNow.Add(time.Minute)),
+			args:    []string{"--output-format", "short"},
+			assert: func(t *testing.T, _ map[string]checkResult, stdout string, err cenclierrors.CencliError) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Diagnostics")
+				require.Contains(t, stdout, "local clock is 1m0s behind the API server")
+				require.Contains(t, stdout, "Enable time synchronization (NTP)")
+				require.Contains(t, stdout, "6 passed, 2 warnings, 0 failed, 0 skipped")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			tempDir := t.TempDir()
+			cfg, cfgErr := config.New(tempDir)
+			require.NoError(t, cfgErr)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			dirs := appdirs.Dirs{Config: tempDir, Data: filepath.Join(tempDir, "data"), Cache: filepath.Join(tempDir, "cache")}
+			require.NoError(t, dirs.Ensure())
+			opts := []command.ContextOpts{command.WithAppDirs(dirs)}
+			if tc.service != nil {
+				opts = append(opts, command.WithCreditsService(tc.service(ctrl)))
+			}
+			cmdContext := command.NewCommandContext(cfg, tc.store(ctrl), opts...)
+			doctorCmd := NewDoctorCommand(cmdContext)
+			doctorCmd.probe = tc.probe
+			doctorCmd.now = func() time.Time { return testNow }
+			rootCmd, err := command.RootCommandToCobra(doctorCmd)
+			require.NoError(t, err)
+
+			args := tc.args
+			if args == nil {
+				args = []string{"--output-format", "json"}
+			}
+			rootCmd.SetArgs(args)
+			execErr := cenclierrors.NewCencliError(rootCmd.Execute())
+
+			results := map[string]checkResult{}
+			if tc.args == nil {
+				var list []checkResult
+				require.NoError(t, json.Unmarshal(stdout.Bytes(), &list), stdout.String())
+				for _, r := range list {
+					results[r.Name] = r
+				}
+			}
+			tc.assert(t, results, stdout.String(), execErr)
+		})
+	}
+}
+
+func TestCheckDataDirs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not enforced on Windows")
+	}
+
+	t.Run("data directory readable by others", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.Chmod(dir, 0o755))
+		cmd := NewDoctorCommand(command.NewCommandContext(&config.Config{}, nil, command.WithAppDirs(appdirs.Dirs{Data: dir})))
+		result := cmd.checkDataDirs()
+		require.Equal(t, statusWarn, result.Status)
+		require.Contains(t, result.Hint, "chmod 700")
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "data")
+		require.NoError(t, os.WriteFile(file, nil, 0o600))
+		cmd := NewDoctorCommand(command.NewCommandContext(&config.Config{}, nil, command.WithAppDirs(appdirs.Dirs{Data: file})))
+		result := cmd.checkDataDirs()
+		require.Equal(t, statusFail, result.Status)
+		require.Contains(t, result.Hint, appdirs.EnvDataDir)
+	})
+}
@@ -0,0 +1,28 @@
+package doctor
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// ChecksFailedError is returned when at least one diagnostic check fails,
+// so that the command exits with a non-zero status.
+type (
+	ChecksFailedError interface{ cenclierrors.CencliError }
+	checksFailedError struct {
+		failed int
+		total  int
+	}
+)
+
+func newChecksFailedError(failed, total int) ChecksFailedError {
+	return &checksFailedError{failed: failed, total: total}
+}
+
+func (e *checksFailedError) Error() string {
+	return fmt.Sprintf("%d of %d checks failed; see the hints above", e.failed, e.total)
+}
+
+func (e *checksFailedError) Title() string          { return "Diagnostics Failed" }
+func (e *checksFailedError) ShouldPrintUsage() bool { return false }
@@ -0,0 +1,60 @@
+package doctor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
+)
+
+func (c *Command) renderReport() cenclierrors.CencliError {
+	var out strings.Builder
+
+	out.WriteRune('\n')
+	out.WriteString(styles.GlobalStyles.Signature.Render("━━━ Diagnostics ━━━"))
+	out.WriteString("\n\n")
+
+	nameWidth := 0
+	for _, r := range c.results {
+		nameWidth = max(nameWidth, len(r.Name))
+	}
+	for _, r := range c.results {
+		symbol, style := statusSymbol(r.Status)
+		fmt.Fprintf(&out, "  %s %s  %s\n",
+			style.Render(symbol),
+			styles.GlobalStyles.Primary.Render(fmt.Sprintf("%-*s", nameWidth, r.Name)),
+			r.Detail,
+		)
+		if r.Hint != "" {
+			fmt.Fprintf(&out, "    %s\n", styles.GlobalStyles.Comment.Render(term.Glyph("→ ", "-> ")+r.Hint))
+		}
+	}
+
+	out.WriteRune('\n')
+	fmt.Fprintf(&out, "  %d passed, %d warnings, %d failed, %d skipped\n",
+		countStatus(c.results, statusPass),
+		countStatus(c.results, statusWarn),
+		countStatus(c.results, statusFail),
+		countStatus(c.results, statusSkip),
+	)
+	formatter.Println(formatter.Stdout, out.String())
+	return nil
+}
+
+func statusSymbol(status checkStatus) (string, lipgloss.Style) {
+	switch status {
+	case statusPass:
+		return term.Glyph("✓", "+"), styles.GlobalStyles.Secondary
+	case statusWarn:
+		return "!", styles.GlobalStyles.Warning
+	case statusFail:
+		return term.Glyph("✗", "x"), styles.GlobalStyles.Danger
+	default:
+		return "-", styles.GlobalStyles.Comment
+	}
+}
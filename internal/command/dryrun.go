@@ -0,0 +1,96 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+// startDryRun wraps the client with --dry-run, so that the requests of the
+// command are planned instead of sent. Services are created from the client
+// on demand, after this runs.
+func (c *Context) startDryRun() {
+	if !c.config.DryRun || c.censysClient == nil || c.dryRunPlan != nil {
+		return
+	}
+	c.dryRunPlan = client.NewDryRunPlan()
+	c.censysClient = client.NewDryRunClient(c.censysClient, c.dryRunPlan)
+}
+
+// dryRunPlanned reports whether a dry run planned a request, so that the
+// output of the command is made of empty results that stand in for the
+// responses.
+func (c *Context) dryRunPlanned() bool {
+	return c.dryRunPlan != nil && len(c.dryRunPlan.Requests()) > 0
+}
+
+// FinishDryRun prints the requests planned by a dry run, and returns the
+// error of the command. Once a request was planned, the error is dropped: it
+// comes from the empty results that stand in for the responses. It is a
+// no-op without --dry-run.
+func (c *Context) FinishDryRun(err error) error {
+	if c.dryRunPlan == nil {
+		return err
+	}
+	requests := c.dryRunPlan.Requests()
+	if len(requests) == 0 {
+		if err == nil {
+			formatter.Println(formatter.Stderr, "Dry run: the command makes no API requests.")
+		}
+		return err
+	}
+	if err != nil {
+		c.logger.Debug("dropping the error of a dry run", "error", err)
+	}
+	formatter.Printf(formatter.Stdout, "%s", renderDryRunPlan(requests))
+	return nil
+}
+
+// renderDryRunPlan renders each planned request as its method and URL,
+// followed by its body, and a summary of the number of requests.
+func renderDryRunPlan(requests []client.PlannedRequest) string {
+	var b strings.Builder
+	counts := map[string]int{}
+	var order, paginated []string
+	for _, req := range requests {
+		fmt.Fprintf(&b, "%s %s\n", req.Method, req.URL)
+		if len(req.Body) > 0 {
+			var indented bytes.Buffer
+			if json.Indent(&indented, req.Body, "", "  ") == nil {
+				b.Write(indented.Bytes())
+			} else {
+				b.Write(req.Body)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		if counts[req.Operation] == 0 {
+			order = append(order, req.Operation)
+			if req.Paginated {
+				paginated = append(paginated, req.Operation)
+			}
+		}
+		counts[req.Operation]++
+	}
+
+	operations := make([]string, len(order))
+	for i, op := range order {
+		operations[i] = fmt.Sprintf("%d %s", counts[op], op)
+	}
+	fmt.Fprintf(&b, "Dry run: %s not sent (%s).\n", pluralRequests(len(requests)), strings.Join(operations, ", "))
+	if len(paginated) > 0 {
+		fmt.Fprintf(&b, "Results of %s are fetched one page per request; each further page is one more request.\n", strings.Join(paginated, ", "))
+	}
+	return b.String()
+}
+
+func pluralRequests(n int) string {
+	if n == 1 {
+		return "1 request"
+	}
+	return fmt.Sprintf("%d requests", n)
+}
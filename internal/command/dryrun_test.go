@@ -0,0 +1,43 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+)
+
+func TestRenderDryRunPlan(t *testing.T) {
+	plan := renderDryRunPlan([]client.PlannedRequest{
+		{Operation: "search", Method: "POST", URL: "https://api.example/v3/global/search/query", Body: []byte(`{"query":"*","page_size":10}`), Paginated: true},
+		{Operation: "get_hosts", Method: "POST", URL: "https://api.example/v3/global/asset/host", Body: []byte(`{"host_ids":["1.1.1.1"]}`)},
+		{Operation: "get_hosts", Method: "POST", URL: "https://api.example/v3/global/asset/host", Body: []byte(`{"host_ids":["8.8.8.8"]}`)},
+		{Operation: "host_timeline", Method: "GET", URL: "https://api.example/v3/global/asset/host/1.1.1.1/timeline"},
+	})
+	assert.Equal(t, `POST https://api.example/v3/global/search/query
+{
+  "query": "*",
+  "page_size": 10
+}
+
+POST https://api.example/v3/global/asset/host
+{
+  "host_ids": [
+    "1.1.1.1"
+  ]
+}
+
+POST https://api.example/v3/global/asset/host
+{
+  "host_ids": [
+    "8.8.8.8"
+  ]
+}
+
+GET https://api.example/v3/global/asset/host/1.1.1.1/timeline
+
+Dry run: 4 requests not sent (1 search, 2 get_hosts, 1 host_timeline).
+Results of search are fetched one page per request; each further page is one more request.
+`, plan)
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/censys/cencli/internal/pkg/flags"
 	"github.com/censys/cencli/internal/pkg/formatter"
 	"github.com/censys/cencli/internal/pkg/formatter/short"
-	"github.com/censys/cencli/internal/pkg/input"
 	"github.com/censys/cencli/internal/pkg/tape"
 )
 
@@ -140,13 +139,10 @@ func (c *Command) parseOrgIDFlag() cenclierrors.CencliError {
 
 // gatherRawHosts returns raw host strings from file, stdin, or positional args.
 func (c *Command) gatherRawHosts(cmd *cobra.Command, args []string) ([]string, cenclierrors.CencliError) {
-	if c.flags.inputFile.IsSet() {
-		return c.flags.inputFile.Lines(cmd)
-	}
-	if len(args) == 0 {
+	if !c.flags.inputFile.IsSet() && len(args) == 0 {
 		return nil, NewNoHostsError()
 	}
-	return input.SplitString(args[0]), nil
+	return command.RawAssets(cmd, c.flags.inputFile, args)
 }
 
 // parseHostIDs validates each raw input as an IP, rejecting non-IPs with a clear error.
@@ -203,7 +199,7 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 
 	// If there was a partial error, print it to stderr after rendering the data
 	if c.result.PartialError != nil {
-		formatter.PrintError(c.result.PartialError, cmd)
+		c.ReportPartialError(c.result.PartialError, cmd)
 	}
 
 	return nil
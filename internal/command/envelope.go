@@ -0,0 +1,126 @@
+package command
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+// Envelope is the data output of a command with --envelope: its data, the
+// metadata of the responses it came from, and the error that left it
+// incomplete, if any, so that scripts can tell partial results apart
+// without reading stderr.
+type Envelope struct {
+	Data         any                     `json:"data"`
+	Meta         *responsemeta.Telemetry `json:"meta"`
+	PartialError *EnvelopeError          `json:"partial_error"`
+}
+
+// EnvelopeError is the error that left the data of an envelope incomplete.
+type EnvelopeError struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	// Interrupted is set when the command was interrupted, e.g. by Ctrl-C,
+	// rather than failed.
+	Interrupted bool `json:"interrupted"`
+	// RequestID is the ID of the failed request, if the API reported it.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// envelopeOutput holds the data output of the command until it finishes,
+// with --envelope.
+type envelopeOutput struct {
+	format   formatter.OutputFormat
+	data     []any
+	meta     *responsemeta.ResponseMeta
+	partials []cenclierrors.CencliError
+}
+
+// startEnvelope holds the data output of the command for FinishEnvelope
+// with --envelope. Only json and yaml output are wrapped: other formats are
+// not data, and streamed items are written as they arrive.
+func (c *Context) startEnvelope() {
+	if !c.config.Envelope || c.config.Streaming {
+		return
+	}
+	switch c.config.OutputFormat {
+	case formatter.OutputFormatJSON, formatter.OutputFormatYAML:
+		c.envelope = &envelopeOutput{format: c.config.OutputFormat}
+	}
+}
+
+// ReportPartialError prints an error that left the data of the command
+// incomplete, after the data is printed. With --envelope, it is also
+// recorded in the envelope.
+func (c *Context) ReportPartialError(err cenclierrors.CencliError, cmd *cobra.Command) {
+	if err == nil {
+		return
+	}
+	if c.envelope != nil {
+		c.envelope.partials = append(c.envelope.partials, err)
+	}
+	formatter.PrintError(err, cmd)
+}
+
+// FinishEnvelope prints the data of the command in its envelope, and returns
+// the error of the command. A partial error returned by the command, such as
+// the interruption of a search, is recorded in the envelope. It is a no-op
+// without --envelope, or if the command printed no data.
+func (c *Context) FinishEnvelope(err error) error {
+	if c.envelope == nil || len(c.envelope.data) == 0 {
+		return err
+	}
+	if err != nil && cenclierrors.IsPartial(err) {
+		var cencliErr cenclierrors.CencliError
+		if errors.As(err, &cencliErr) {
+			c.envelope.partials = append(c.envelope.partials, cencliErr)
+		}
+	}
+	if printErr := formatter.PrintByFormat(c.envelope.build(), c.envelope.format, !c.colorDisabledStdout); printErr != nil && err == nil {
+		return printErr
+	}
+	return err
+}
+
+// build returns the envelope of the output. The data of a command that
+// printed more than once is the list of each.
+func (e *envelopeOutput) build() Envelope {
+	env := Envelope{Data: e.data[0]}
+	if len(e.data) > 1 {
+		env.Data = e.data
+	}
+	if e.meta != nil {
+		telemetry := e.meta.Telemetry(false)
+		env.Meta = &telemetry
+	}
+	if len(e.partials) > 0 {
+		env.PartialError = &EnvelopeError{Title: e.partials[0].Title()}
+		messages := make([]string, len(e.partials))
+		for i, partial := range e.partials {
+			messages[i] = partialMessage(partial)
+			env.PartialError.Interrupted = env.PartialError.Interrupted || cenclierrors.IsInterrupted(partial)
+			if info, ok := client.ResponseInfoOf(partial); ok && env.PartialError.RequestID == "" {
+				env.PartialError.RequestID = info.RequestID
+			}
+		}
+		env.PartialError.Message = strings.Join(messages, "\n")
+	}
+	return env
+}
+
+// partialMessage returns the message of err, without the note that partial
+// errors add for humans.
+func partialMessage(err cenclierrors.CencliError) string {
+	if cenclierrors.IsPartial(err) {
+		if inner := errors.Unwrap(err); inner != nil {
+			return inner.Error()
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,90 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func TestEnvelope(t *testing.T) {
+	newContext := func(t *testing.T, cfg *config.Config) (*Context, *bytes.Buffer, *bytes.Buffer) {
+		t.Helper()
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		oldStdout, oldStderr := formatter.Stdout, formatter.Stderr
+		formatter.Stdout, formatter.Stderr = stdout, stderr
+		t.Cleanup(func() { formatter.Stdout, formatter.Stderr = oldStdout, oldStderr })
+		c := NewCommandContext(cfg, nil)
+		c.startEnvelope()
+		return c, stdout, stderr
+	}
+
+	t.Run("only wraps json and yaml output", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			cfg    config.Config
+			active bool
+		}{
+			{name: "json", cfg: config.Config{Envelope: true, OutputFormat: formatter.OutputFormatJSON}, active: true},
+			{name: "yaml", cfg: config.Config{Envelope: true, OutputFormat: formatter.OutputFormatYAML}, active: true},
+			{name: "short", cfg: config.Config{Envelope: true, OutputFormat: formatter.OutputFormatShort}},
+			{name: "streaming", cfg: config.Config{Envelope: true, OutputFormat: formatter.OutputFormatNDJSON, Streaming: true}},
+			{name: "without the flag", cfg: config.Config{OutputFormat: formatter.OutputFormatJSON}},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				c, _, _ := newContext(t, &tc.cfg)
+				assert.Equal(t, tc.active, c.envelope != nil)
+			})
+		}
+	})
+
+	t.Run("prints data and meta once the command finishes", func(t *testing.T) {
+		c, stdout, _ := newContext(t, &config.Config{Envelope: true, Quiet: true, OutputFormat: formatter.OutputFormatJSON})
+		require.NoError(t, c.PrintData(nil, map[string]string{"ip": "1.1.1.1"}))
+		c.PrintAppResponseMeta(&responsemeta.ResponseMeta{Method: "GET", Status: 200, PageCount: 2})
+		assert.Empty(t, stdout.String(), "nothing is printed before the command finishes")
+
+		require.NoError(t, c.FinishEnvelope(nil))
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &got))
+		assert.Equal(t, map[string]any{"ip": "1.1.1.1"}, got["data"])
+		meta, ok := got["meta"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "GET", meta["method"])
+		assert.InDelta(t, 2, meta["pages"], 0)
+		assert.Contains(t, got, "partial_error")
+		assert.Nil(t, got["partial_error"])
+	})
+
+	t.Run("records partial errors", func(t *testing.T) {
+		c, stdout, stderr := newContext(t, &config.Config{Envelope: true, OutputFormat: formatter.OutputFormatJSON})
+		require.NoError(t, c.PrintData(nil, []string{"a"}))
+		c.ReportPartialError(cenclierrors.ToPartialError(cenclierrors.NewCencliError(errors.New("page 3 failed"))), nil)
+		assert.Contains(t, stderr.String(), "page 3 failed", "the error is still printed")
+
+		interrupted := cenclierrors.ToPartialError(cenclierrors.NewInterruptedError())
+		err := c.FinishEnvelope(interrupted)
+		require.Equal(t, interrupted, err, "the error of the command is returned")
+
+		var got Envelope
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &got))
+		require.NotNil(t, got.PartialError)
+		assert.Equal(t, "page 3 failed\n"+cenclierrors.NewInterruptedError().Error(), got.PartialError.Message)
+		assert.True(t, got.PartialError.Interrupted)
+	})
+
+	t.Run("is a no-op without data", func(t *testing.T) {
+		c, stdout, _ := newContext(t, &config.Config{Envelope: true, OutputFormat: formatter.OutputFormatJSON})
+		failed := cenclierrors.NewCencliError(errors.New("boom"))
+		require.Equal(t, failed, c.FinishEnvelope(failed))
+		assert.Empty(t, stdout.String())
+	})
+}
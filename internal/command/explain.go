@@ -0,0 +1,81 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/cenql"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// ExplainFlagName is the name of the flag that explains a query instead of running it.
+const ExplainFlagName = "explain"
+
+// NewExplainFlag adds --explain to fs.
+func NewExplainFlag(fs *pflag.FlagSet) flags.BoolFlag {
+	return flags.NewBoolFlag(fs, ExplainFlagName, "", false,
+		"print how the query is parsed and what looks wrong in it, without running it (no API request is made)")
+}
+
+// ExplainQuery parses query locally and prints its boolean structure, the
+// fields it refers to, and the constructs that are likely mistakes. It
+// returns an error if the query does not parse.
+func (c *Context) ExplainQuery(query string) cenclierrors.CencliError {
+	root, err := cenql.Parse(query)
+	if err != nil {
+		return NewInvalidQueryError(err)
+	}
+	analysis := cenql.Analyze(root)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Query: %s\n\n", query)
+	b.WriteString(cenql.Explain(root))
+	fmt.Fprintf(&b, "\nFields (%d):\n", len(analysis.Fields))
+	for _, field := range analysis.Fields {
+		fmt.Fprintf(&b, "  %s  (%s)\n", field.Field, pluralTerms(field.Terms))
+	}
+	if analysis.FreeText > 0 {
+		fmt.Fprintf(&b, "  free text  (%s)\n", pluralTerms(analysis.FreeText))
+	}
+	fmt.Fprintf(&b, "\nWarnings (%d):\n", len(analysis.Warnings))
+	if len(analysis.Warnings) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, warning := range analysis.Warnings {
+		fmt.Fprintf(&b, "  %s\n", styles.GlobalStyles.Warning.Render(fmt.Sprintf("column %d: %s", warning.Offset+1, warning.Message)))
+	}
+	formatter.Printf(formatter.Stdout, "%s", b.String())
+	return nil
+}
+
+func pluralTerms(n int) string {
+	if n == 1 {
+		return "1 term"
+	}
+	return fmt.Sprintf("%d terms", n)
+}
+
+// InvalidQueryError is returned when a query that is parsed locally, as with
+// --explain, does not parse.
+type InvalidQueryError interface{ cenclierrors.CencliError }
+
+type invalidQueryError struct{ err error }
+
+var _ InvalidQueryError = &invalidQueryError{}
+
+func NewInvalidQueryError(err error) InvalidQueryError { return &invalidQueryError{err: err} }
+
+func (e *invalidQueryError) Error() string {
+	return fmt.Sprintf("the query does not parse: %v", e.err)
+}
+
+func (e *invalidQueryError) Title() string { return "Invalid Query" }
+
+func (e *invalidQueryError) ShouldPrintUsage() bool { return false }
+
+func (e *invalidQueryError) Unwrap() error { return e.err }
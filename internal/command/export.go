@@ -0,0 +1,507 @@
+package command
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/censys/cencli/internal/pkg/assetfiles"
+	"github.com/censys/cencli/internal/pkg/blobstore"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/esbulk"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/nmap"
+	"github.com/censys/cencli/internal/pkg/sqliteexport"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/targetlist"
+)
+
+const (
+	exportFormatFlagName      = "format"
+	exportOutputFlagName      = "output"
+	exportOutputAliasFlagName = "output-file"
+	exportOutputDirFlagName   = "output-dir"
+	exportAppendFlagName      = "append"
+	exportIndexFlagName       = "es-index"
+	targetStyleFlagName       = "target-style"
+	targetPortsFlagName       = "target-ports"
+	targetServicesFlagName    = "target-services"
+
+	// exportFormatJSON exports the results as a JSON array.
+	exportFormatJSON = "json"
+	// gzipSuffix is the suffix of --output destinations that are compressed.
+	gzipSuffix = ".gz"
+)
+
+// exportFormats are the values accepted by --format.
+var exportFormats = []string{sqliteexport.FormatName, esbulk.FormatName, exportFormatJSON, nmap.FormatXML, nmap.FormatGreppable, targetlist.FormatName}
+
+// targetListFlagNames are the flags of --format target-list.
+var targetListFlagNames = []string{targetStyleFlagName, targetPortsFlagName, targetServicesFlagName}
+
+// ExportFlags are the flags of commands that can export their results in
+// another format instead of printing them: --format, --output (or
+// --output-file), --output-dir, --append, --es-index, and the --target-*
+// flags of --format target-list.
+type ExportFlags struct {
+	format         flags.StringFlag
+	output         flags.StringFlag
+	outputDir      flags.StringFlag
+	append         flags.BoolFlag
+	index          flags.StringFlag
+	targetStyle    flags.StringFlag
+	targetPorts    flags.StringSliceFlag
+	targetServices flags.StringSliceFlag
+}
+
+// ExportTarget is where, and how, results are exported.
+type ExportTarget struct {
+	Format string
+	// Path is the file or storage URL (e.g. s3://bucket/key) to export to. It
+	// is empty when exporting to stdout.
+	Path string
+	// Dir is the directory to write one file per asset to, with --format
+	// json. It is empty when exporting to Path.
+	Dir    string
+	Append bool
+	// Index is the index of es-bulk documents.
+	Index string
+	// Targets configures target-list exports.
+	Targets targetlist.Options
+}
+
+// NewExportFlags adds the export flags to fs.
+func NewExportFlags(fs *pflag.FlagSet) ExportFlags {
+	return ExportFlags{
+		format: flags.NewStringFlag(fs, false, exportFormatFlagName, "", "",
+			fmt.Sprintf("export the results in this format (%s) instead of printing them; sqlite requires --output", strings.Join(exportFormats, "|"))),
+		output: flags.NewStringFlag(fs, false, exportOutputFlagName, "", "",
+			"file or s3://bucket/key to export the results to with --format; a .gz suffix compresses the export (es-bulk and json write to stdout by default)").
+			AddAlias(exportOutputAliasFlagName, "", "alias of --"+exportOutputFlagName),
+		outputDir: flags.NewStringFlag(fs, false, exportOutputDirFlagName, "", "",
+			fmt.Sprintf("directory to write each result to, as its own file, with --format %s; an %s manifest lists the files", exportFormatJSON, assetfiles.IndexName)),
+		append: flags.NewBoolFlag(fs, exportAppendFlagName, "", false,
+			"add to the --output file instead of replacing it"),
+		index: flags.NewStringFlag(fs, false, exportIndexFlagName, "", esbulk.DefaultIndex,
+			fmt.Sprintf("index of the documents with --format %s; %s is replaced with the asset type", esbulk.FormatName, esbulk.TypePlaceholder)),
+		targetStyle: flags.NewStringFlag(fs, false, targetStyleFlagName, "", string(targetlist.StyleIPPort),
+			fmt.Sprintf("how targets are written with --format %s: ip-port (host:port per service) or ip (addresses for nmap/masscan -iL)", targetlist.FormatName)),
+		targetPorts: flags.NewStringSliceFlag(fs, false, targetPortsFlagName, "", nil,
+			fmt.Sprintf("only write targets for services on these ports with --format %s", targetlist.FormatName)),
+		targetServices: flags.NewStringSliceFlag(fs, false, targetServicesFlagName, "", nil,
+			fmt.Sprintf("only write targets for services with these protocols (e.g. SSH,HTTP) with --format %s", targetlist.FormatName)),
+	}
+}
+
+// Value returns the export target, if the results should be exported.
+// Exporting cannot be combined with streaming or an explicit output format.
+func (f ExportFlags) Value(cmd *cobra.Command, streaming bool) (mo.Option[ExportTarget], cenclierrors.CencliError) {
+	none := mo.None[ExportTarget]()
+	format, err := f.format.Value()
+	if err != nil {
+		return none, err
+	}
+	output, err := f.output.Value()
+	if err != nil {
+		return none, err
+	}
+	outputDir, err := f.outputDir.Value()
+	if err != nil {
+		return none, err
+	}
+	appendMode, err := f.append.Value()
+	if err != nil {
+		return none, err
+	}
+	index, err := f.index.Value()
+	if err != nil {
+		return none, err
+	}
+	if format == "" {
+		if output != "" {
+			return none, newExportFlagError("--output requires --format")
+		}
+		if outputDir != "" {
+			return none, newExportFlagError(fmt.Sprintf("--%s requires --format %s", exportOutputDirFlagName, exportFormatJSON))
+		}
+		if appendMode {
+			return none, newExportFlagError("--append requires --format and --output")
+		}
+		if cmd.Flags().Changed(exportIndexFlagName) {
+			return none, newExportFlagError(fmt.Sprintf("--%s requires --format %s", exportIndexFlagName, esbulk.FormatName))
+		}
+		if err := checkTargetListFlags(cmd); err != nil {
+			return none, err
+		}
+		return none, nil
+	}
+	format = strings.ToLower(format)
+	var targets targetlist.Options
+	if format == targetlist.FormatName {
+		if targets, err = f.targetListOptions(); err != nil {
+			return none, err
+		}
+	} else if err := checkTargetListFlags(cmd); err != nil {
+		return none, err
+	}
+	switch format {
+	case sqliteexport.FormatName:
+		if output == "" {
+			return none, newExportFlagError(fmt.Sprintf("--format %s requires --output", format))
+		}
+		if cmd.Flags().Changed(exportIndexFlagName) {
+			return none, newExportFlagError(fmt.Sprintf("--%s requires --format %s", exportIndexFlagName, esbulk.FormatName))
+		}
+	case esbulk.FormatName:
+		if appendMode && output == "" {
+			return none, newExportFlagError("--append requires --output")
+		}
+		if strings.TrimSpace(index) == "" {
+			return none, newExportFlagError(fmt.Sprintf("--%s cannot be empty", exportIndexFlagName))
+		}
+	case exportFormatJSON, nmap.FormatXML:
+		if appendMode {
+			return none, newExportFlagError(fmt.Sprintf("--append cannot be used with --format %s", format))
+		}
+		if cmd.Flags().Changed(exportIndexFlagName) {
+			return none, newExportFlagError(fmt.Sprintf("--%s requires --format %s", exportIndexFlagName, esbulk.FormatName))
+		}
+	case nmap.FormatGreppable, targetlist.FormatName:
+		if appendMode && output == "" {
+			return none, newExportFlagError("--append requires --output")
+		}
+		if cmd.Flags().Changed(exportIndexFlagName) {
+			return none, newExportFlagError(fmt.Sprintf("--%s requires --format %s", exportIndexFlagName, esbulk.FormatName))
+		}
+	default:
+		return none, newExportFlagError(fmt.Sprintf("unsupported export format %q; supported formats: %s", format, strings.Join(exportFormats, ", ")))
+	}
+	// --redact masks text written to files, but not the values stored in
+	// databases or in the files of --output-dir
+	if formatter.Redacting() {
+		if format == sqliteexport.FormatName {
+			return none, newExportFlagError(fmt.Sprintf("--redact cannot be used with --format %s", format))
+		}
+		if outputDir != "" {
+			return none, newExportFlagError(fmt.Sprintf("--redact cannot be used with --%s", exportOutputDirFlagName))
+		}
+	}
+	if outputDir != "" {
+		if format != exportFormatJSON {
+			return none, newExportFlagError(fmt.Sprintf("--%s requires --format %s", exportOutputDirFlagName, exportFormatJSON))
+		}
+		if output != "" {
+			return none, flags.NewConflictingFlagsError(exportOutputDirFlagName, exportOutputFlagName)
+		}
+		if blobstore.IsRemote(outputDir) {
+			return none, newExportFlagError(fmt.Sprintf("--%s must be a local directory", exportOutputDirFlagName))
+		}
+	}
+	if blobstore.IsRemote(output) {
+		if _, err := blobstore.Parse(output); err != nil {
+			return none, newExportFlagError(fmt.Sprintf("invalid --%s: %v", exportOutputFlagName, err))
+		}
+	}
+	if appendMode && isStagedExport(output) {
+		return none, newExportFlagError("--append cannot be used with a compressed or remote --output")
+	}
+	if streaming {
+		return none, flags.NewConflictingFlagsError(exportFormatFlagName, "streaming")
+	}
+	if cmd.Flags().Changed(formatter.OutputFormatFlagName) {
+		return none, flags.NewConflictingFlagsError(exportFormatFlagName, formatter.OutputFormatFlagName)
+	}
+	return mo.Some(ExportTarget{Format: format, Path: output, Dir: outputDir, Append: appendMode, Index: index, Targets: targets}), nil
+}
+
+// targetListOptions parses the --target-* flags.
+func (f ExportFlags) targetListOptions() (targetlist.Options, cenclierrors.CencliError) {
+	var opts targetlist.Options
+	rawStyle, err := f.targetStyle.Value()
+	if err != nil {
+		return opts, err
+	}
+	style, parseErr := targetlist.ParseStyle(rawStyle)
+	if parseErr != nil {
+		return opts, newExportFlagError(parseErr.Error())
+	}
+	opts.Style = style
+	ports, err := f.targetPorts.Value()
+	if err != nil {
+		return opts, err
+	}
+	for _, raw := range ports {
+		port, convErr := strconv.Atoi(raw)
+		if convErr != nil || port < 1 || port > 65535 {
+			return opts, newExportFlagError(fmt.Sprintf("invalid --%s: %q is not a port", targetPortsFlagName, raw))
+		}
+		opts.Ports = append(opts.Ports, port)
+	}
+	if opts.Services, err = f.targetServices.Value(); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// checkTargetListFlags returns an error if a --target-* flag is set without
+// --format target-list.
+func checkTargetListFlags(cmd *cobra.Command) cenclierrors.CencliError {
+	for _, name := range targetListFlagNames {
+		if cmd.Flags().Changed(name) {
+			return newExportFlagError(fmt.Sprintf("--%s requires --format %s", name, targetlist.FormatName))
+		}
+	}
+	return nil
+}
+
+// ExportAssets exports items to target, recording the command and query that
+// produced them, and reports what was written to a file on stderr. Compressed
+// and remote exports are written to a temporary file first, then compressed
+// and uploaded.
+func (c *Context) ExportAssets(ctx context.Context, target ExportTarget, commandName, query string, items []assets.Asset) cenclierrors.CencliError {
+	if target.Dir != "" {
+		return c.exportAssetFiles(target.Dir, commandName, query, items)
+	}
+	path := target.Path
+	staged := isStagedExport(target.Path)
+	if staged {
+		dir, err := os.MkdirTemp("", "cencli-export-")
+		if err != nil {
+			return newExportError(target.Path, err)
+		}
+		defer os.RemoveAll(dir)
+		path = filepath.Join(dir, "export")
+	}
+	summary, err := writeExport(ctx, target, path, commandName, query, items, c.Now)
+	if err == nil && staged {
+		err = publishExport(ctx, target, path)
+	}
+	if err != nil {
+		return newExportError(target.Path, err)
+	}
+	if target.Path != "" && !c.config.Quiet {
+		formatter.Printf(formatter.Stderr, "%s\n", styles.GlobalStyles.Info.Render(
+			fmt.Sprintf("Exported %s to %s", summary, target.Path),
+		))
+	}
+	return nil
+}
+
+// exportAssetFiles writes each of items to its own file in dir, and reports
+// what was written on stderr.
+func (c *Context) exportAssetFiles(dir, commandName, query string, items []assets.Asset) cenclierrors.CencliError {
+	index, err := assetfiles.Write(dir, items, assetfiles.Options{Command: commandName, Query: query, Now: c.Now})
+	if err != nil {
+		return newExportError(dir, err)
+	}
+	if !c.config.Quiet {
+		summary := fmt.Sprintf("%d results", len(index.Assets))
+		if len(index.Assets) == 1 {
+			summary = "1 result"
+		}
+		formatter.Printf(formatter.Stderr, "%s\n", styles.GlobalStyles.Info.Render(
+			fmt.Sprintf("Exported %s to %s (see %s)", summary, dir, filepath.Join(dir, assetfiles.IndexName)),
+		))
+	}
+	return nil
+}
+
+// writeExport writes items in the format of target to the local file path, or
+// to stdout if path is empty, and returns a summary of what was written.
+func writeExport(ctx context.Context, target ExportTarget, path, commandName, query string, items []assets.Asset, now func() time.Time) (string, error) {
+	switch target.Format {
+	case esbulk.FormatName:
+		n, err := exportStream(path, target.Append, func(w io.Writer) (int, error) {
+			return esbulk.Write(w, items, esbulk.Options{Index: target.Index})
+		})
+		if n == 1 {
+			return "1 document", err
+		}
+		return fmt.Sprintf("%d documents", n), err
+	case exportFormatJSON:
+		n, err := exportStream(path, false, func(w io.Writer) (int, error) {
+			return writeJSONArray(w, items)
+		})
+		if n == 1 {
+			return "1 result", err
+		}
+		return fmt.Sprintf("%d results", n), err
+	case nmap.FormatXML, nmap.FormatGreppable:
+		opts := nmap.Options{Args: strings.TrimSpace("cencli " + commandName + " " + query), Now: now}
+		n, err := exportStream(path, target.Append, func(w io.Writer) (int, error) {
+			if target.Format == nmap.FormatXML {
+				return nmap.WriteXML(w, items, opts)
+			}
+			return nmap.WriteGreppable(w, items, opts)
+		})
+		if n == 1 {
+			return "1 host", err
+		}
+		return fmt.Sprintf("%d hosts", n), err
+	case targetlist.FormatName:
+		n, err := exportStream(path, target.Append, func(w io.Writer) (int, error) {
+			return targetlist.Write(w, items, target.Targets)
+		})
+		if n == 1 {
+			return "1 target", err
+		}
+		return fmt.Sprintf("%d targets", n), err
+	default:
+		s, err := sqliteexport.Export(ctx, path, items, sqliteexport.Options{
+			Append:  target.Append,
+			Command: commandName,
+			Query:   query,
+			Now:     now,
+		})
+		return s.String(), err
+	}
+}
+
+// exportStream writes an export to the file at path, or to stdout if path is
+// empty. Both are masked with --redact.
+func exportStream(path string, appendMode bool, write func(io.Writer) (int, error)) (int, error) {
+	if path == "" {
+		return write(formatter.Stdout)
+	}
+	mode := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if appendMode {
+		mode = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, mode, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	rw := formatter.RedactFile(f)
+	n, err := write(rw)
+	if flushErr := rw.Close(); err == nil {
+		err = flushErr
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
+// writeJSONArray writes items to w as an indented JSON array.
+func writeJSONArray(w io.Writer, items []assets.Asset) (int, error) {
+	if items == nil {
+		items = []assets.Asset{}
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// isStagedExport reports whether an export to path is written to a temporary
+// file first: when it is compressed or uploaded to object storage.
+func isStagedExport(path string) bool {
+	return path != "" && (blobstore.IsRemote(path) || strings.HasSuffix(strings.ToLower(path), gzipSuffix))
+}
+
+// publishExport compresses the export written to the temporary file path if
+// the target is compressed, then moves it to the target: a local file, or an
+// object uploaded with the credentials of the environment.
+func publishExport(ctx context.Context, target ExportTarget, path string) error {
+	compressed := strings.HasSuffix(strings.ToLower(target.Path), gzipSuffix)
+	if !blobstore.IsRemote(target.Path) {
+		return gzipFile(path, target.Path)
+	}
+	src, contentType := path, exportContentType(target.Format)
+	if compressed {
+		src, contentType = path+gzipSuffix, "application/gzip"
+		if err := gzipFile(path, src); err != nil {
+			return err
+		}
+	}
+	return blobstore.UploadFile(ctx, target.Path, src, blobstore.UploadOptions{ContentType: contentType})
+}
+
+func exportContentType(format string) string {
+	switch format {
+	case esbulk.FormatName:
+		return "application/x-ndjson"
+	case exportFormatJSON:
+		return "application/json"
+	case nmap.FormatXML:
+		return "application/xml"
+	case nmap.FormatGreppable, targetlist.FormatName:
+		return "text/plain"
+	default:
+		return "application/vnd.sqlite3"
+	}
+}
+
+// gzipFile writes the gzip-compressed contents of the file src to dst.
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	_, err = io.Copy(zw, in)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// ExportFlagError is returned when the export flags are used incorrectly.
+type ExportFlagError interface{ cenclierrors.CencliError }
+
+type exportFlagError struct{ reason string }
+
+var _ ExportFlagError = &exportFlagError{}
+
+func newExportFlagError(reason string) ExportFlagError { return &exportFlagError{reason: reason} }
+
+func (e *exportFlagError) Error() string          { return e.reason }
+func (e *exportFlagError) Title() string          { return "Invalid Export Flags" }
+func (e *exportFlagError) ShouldPrintUsage() bool { return true }
+
+// ExportError is returned when results cannot be exported.
+type ExportError interface{ cenclierrors.CencliError }
+
+type exportError struct {
+	path string
+	err  error
+}
+
+var _ ExportError = &exportError{}
+
+func newExportError(path string, err error) ExportError { return &exportError{path: path, err: err} }
+
+func (e *exportError) Error() string {
+	if e.path == "" {
+		return fmt.Sprintf("failed to export results: %v", e.err)
+	}
+	return fmt.Sprintf("failed to export results to %s: %v", e.path, e.err)
+}
+func (e *exportError) Title() string          { return "Export Failed" }
+func (e *exportError) ShouldPrintUsage() bool { return false }
+func (e *exportError) Unwrap() error          { return e.err }
@@ -0,0 +1,251 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/pflag"
+
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/kafka"
+	"github.com/censys/cencli/internal/pkg/splunk"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+const (
+	forwardFlagName = "forward"
+	topicFlagName   = "topic"
+)
+
+// forwardSinks are the values accepted by --forward.
+var forwardSinks = []string{splunk.SinkName, kafka.SinkName}
+
+// ForwardFlags are the flags of commands that can send their results to an
+// external sink as well as printing them: --forward and --topic.
+type ForwardFlags struct {
+	sink  flags.StringFlag
+	topic flags.StringFlag
+}
+
+// ForwardTarget is the sink results are forwarded to.
+type ForwardTarget struct {
+	Sink string
+	// Topic overrides the configured Kafka topic.
+	Topic string
+}
+
+// NewForwardFlags adds the forward flags to fs.
+func NewForwardFlags(fs *pflag.FlagSet) ForwardFlags {
+	return ForwardFlags{
+		sink: flags.NewStringFlag(fs, false, forwardFlagName, "", "",
+			fmt.Sprintf("also send each result to this sink (%s), configured in the forward section of the config", strings.Join(forwardSinks, "|"))),
+		topic: flags.NewStringFlag(fs, false, topicFlagName, "", "",
+			"Kafka topic to produce to with --forward kafka (overrides forward.kafka.topic)"),
+	}
+}
+
+// Value returns the sink to forward results to, if any.
+func (f ForwardFlags) Value() (mo.Option[ForwardTarget], cenclierrors.CencliError) {
+	none := mo.None[ForwardTarget]()
+	sink, err := f.sink.Value()
+	if err != nil {
+		return none, err
+	}
+	topic, err := f.topic.Value()
+	if err != nil {
+		return none, err
+	}
+	sink = strings.ToLower(strings.TrimSpace(sink))
+	if topic != "" && sink != kafka.SinkName {
+		return none, newForwardFlagError(fmt.Sprintf("--%s requires --%s %s", topicFlagName, forwardFlagName, kafka.SinkName))
+	}
+	switch sink {
+	case "":
+		return none, nil
+	case splunk.SinkName, kafka.SinkName:
+		if formatter.Redacting() {
+			return none, newForwardFlagError(fmt.Sprintf("--redact cannot be used with --%s", forwardFlagName))
+		}
+		return mo.Some(ForwardTarget{Sink: sink, Topic: topic}), nil
+	default:
+		return none, newForwardFlagError(fmt.Sprintf("unsupported sink %q; supported sinks: %s", sink, strings.Join(forwardSinks, ", ")))
+	}
+}
+
+// sink is an external destination that results are forwarded to.
+type sink interface {
+	// Add sends data, or queues it to be sent.
+	Add(ctx context.Context, data any) error
+	// Close sends the queued data.
+	Close(ctx context.Context) error
+	// Sent returns the number of results sent.
+	Sent() int
+}
+
+// StartForwarding sends the results of the command to target, as well as
+// printing them: the data given to PrintData, or each streamed item. The
+// returned stop function sends the remaining results and reports how many
+// were forwarded; it must be called once the results have been printed, and
+// is safe to call more than once. If target is None, this is a no-op.
+func (c *Context) StartForwarding(
+	ctx context.Context,
+	logger *slog.Logger,
+	target mo.Option[ForwardTarget],
+	commandName string,
+) (context.Context, func() cenclierrors.CencliError, cenclierrors.CencliError) {
+	noop := func() cenclierrors.CencliError { return nil }
+	t, ok := target.Get()
+	if !ok {
+		return ctx, noop, nil
+	}
+	s, describe, err := c.openSink(ctx, t, commandName)
+	if err != nil {
+		return ctx, noop, newForwardError(err)
+	}
+	c.forwarder = &forwarder{ctx: ctx, sink: s}
+	if emitter, ok := streaming.FromContext(ctx); ok {
+		ctx = streaming.WithEmitter(ctx, &forwardingEmitter{Emitter: emitter, sink: s})
+	}
+
+	var once sync.Once
+	var stopErr cenclierrors.CencliError
+	stop := func() cenclierrors.CencliError {
+		once.Do(func() {
+			c.forwarder = nil
+			if err := s.Close(ctx); err != nil {
+				logger.Debug("failed to forward results", "sink", t.Sink, "error", err)
+				stopErr = newForwardError(err)
+				return
+			}
+			if !c.config.Quiet {
+				formatter.Printf(formatter.Stderr, "%s\n", styles.GlobalStyles.Info.Render(describe(s.Sent())))
+			}
+		})
+		return stopErr
+	}
+	return ctx, stop, nil
+}
+
+// openSink opens the sink of target, and returns it with a function that
+// describes how many results were sent.
+func (c *Context) openSink(ctx context.Context, target ForwardTarget, commandName string) (sink, func(int) string, error) {
+	switch target.Sink {
+	case kafka.SinkName:
+		cfg := c.config.Forward.Kafka
+		topic := cfg.Topic
+		if target.Topic != "" {
+			topic = target.Topic
+		}
+		headers := map[string]string{
+			"cencli-command": commandName,
+			"cencli-run-at":  c.Now().UTC().Format(time.RFC3339),
+		}
+		if c.invocation != nil && c.invocation.query != "" {
+			headers["cencli-query"] = c.invocation.query
+		}
+		p, err := kafka.Start(ctx, kafka.Options{
+			Brokers: cfg.Brokers,
+			Topic:   topic,
+			Key:     cfg.Key,
+			Headers: headers,
+			Command: cfg.Command,
+			Args:    cfg.Args,
+		})
+		return p, func(n int) string {
+			return fmt.Sprintf("Produced %d messages to Kafka topic %s", n, topic)
+		}, err
+	default:
+		cfg := c.config.Forward.Splunk
+		fw, err := splunk.New(splunk.Options{
+			URL:        cfg.URL,
+			Token:      cfg.Token,
+			Index:      cfg.Index,
+			Source:     "cencli:" + strings.ReplaceAll(commandName, " ", ":"),
+			Sourcetype: cfg.Sourcetype,
+			BatchSize:  int(cfg.BatchSize),
+			MaxRetries: int(cfg.MaxRetries),
+			Client:     &http.Client{Timeout: c.config.Timeouts.HTTP},
+		})
+		return fw, func(n int) string {
+			return fmt.Sprintf("Forwarded %d events to Splunk", n)
+		}, err
+	}
+}
+
+// forwarder forwards the data printed by PrintData.
+type forwarder struct {
+	ctx  context.Context
+	sink sink
+}
+
+// forward sends each element of data, if it is a slice, or data itself.
+func (f *forwarder) forward(data any) cenclierrors.CencliError {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		if data == nil {
+			return nil
+		}
+		if err := f.sink.Add(f.ctx, data); err != nil {
+			return newForwardError(err)
+		}
+		return nil
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := f.sink.Add(f.ctx, v.Index(i).Interface()); err != nil {
+			return newForwardError(err)
+		}
+	}
+	return nil
+}
+
+// forwardingEmitter forwards each streamed item before passing it on.
+type forwardingEmitter struct {
+	streaming.Emitter
+	sink sink
+}
+
+func (e *forwardingEmitter) Emit(ctx context.Context, data any) error {
+	if err := e.sink.Add(ctx, data); err != nil {
+		return err
+	}
+	return e.Emitter.Emit(ctx, data)
+}
+
+// ForwardFlagError is returned when the forward flags are used incorrectly.
+type ForwardFlagError interface{ cenclierrors.CencliError }
+
+type forwardFlagError struct{ reason string }
+
+var _ ForwardFlagError = &forwardFlagError{}
+
+func newForwardFlagError(reason string) ForwardFlagError { return &forwardFlagError{reason: reason} }
+
+func (e *forwardFlagError) Error() string          { return e.reason }
+func (e *forwardFlagError) Title() string          { return "Invalid Forward Flags" }
+func (e *forwardFlagError) ShouldPrintUsage() bool { return true }
+
+// ForwardError is returned when results cannot be forwarded.
+type ForwardError interface{ cenclierrors.CencliError }
+
+type forwardError struct{ err error }
+
+var _ ForwardError = &forwardError{}
+
+func newForwardError(err error) ForwardError { return &forwardError{err: err} }
+
+func (e *forwardError) Error() string {
+	return fmt.Sprintf("failed to forward results: %v", e.err)
+}
+func (e *forwardError) Title() string          { return "Forwarding Failed" }
+func (e *forwardError) ShouldPrintUsage() bool { return false }
+func (e *forwardError) Unwrap() error          { return e.err }
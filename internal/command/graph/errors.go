@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/graph"
+)
+
+type NoSourcesError interface {
+	cenclierrors.CencliError
+}
+
+type noSourcesError struct{}
+
+var _ NoSourcesError = &noSourcesError{}
+
+func newNoSourcesError() NoSourcesError {
+	return &noSourcesError{}
+}
+
+func (e *noSourcesError) Error() string {
+	return "no sources given; name a recorded session with --session, or files to read"
+}
+
+func (e *noSourcesError) Title() string { return "No Sources" }
+
+func (e *noSourcesError) ShouldPrintUsage() bool { return true }
+
+type UnsupportedFormatError interface {
+	cenclierrors.CencliError
+}
+
+type unsupportedFormatError struct {
+	format string
+}
+
+var _ UnsupportedFormatError = &unsupportedFormatError{}
+
+func newUnsupportedFormatError(format string) UnsupportedFormatError {
+	return &unsupportedFormatError{format: format}
+}
+
+func (e *unsupportedFormatError) Error() string {
+	formats := make([]string, len(graph.Formats))
+	for i, f := range graph.Formats {
+		formats[i] = string(f)
+	}
+	return fmt.Sprintf("unsupported --format %q; use one of %s", e.format, strings.Join(formats, ", "))
+}
+
+func (e *unsupportedFormatError) Title() string { return "Unsupported Format" }
+
+func (e *unsupportedFormatError) ShouldPrintUsage() bool { return true }
+
+type StoreUnavailableError interface {
+	cenclierrors.CencliError
+}
+
+type storeUnavailableError struct{}
+
+var _ StoreUnavailableError = &storeUnavailableError{}
+
+func newStoreUnavailableError() StoreUnavailableError {
+	return &storeUnavailableError{}
+}
+
+func (e *storeUnavailableError) Error() string {
+	return "sessions are kept in the local store, but it is not available"
+}
+
+func (e *storeUnavailableError) Title() string { return "Store Unavailable" }
+
+func (e *storeUnavailableError) ShouldPrintUsage() bool { return false }
+
+type SessionNotFoundError interface {
+	cenclierrors.CencliError
+}
+
+type sessionNotFoundError struct {
+	name string
+}
+
+var _ SessionNotFoundError = &sessionNotFoundError{}
+
+func newSessionNotFoundError(name string) SessionNotFoundError {
+	return &sessionNotFoundError{name: name}
+}
+
+func (e *sessionNotFoundError) Error() string {
+	return fmt.Sprintf("no session named %q; use `censys session list` to see recorded sessions", e.name)
+}
+
+func (e *sessionNotFoundError) Title() string { return "Session Not Found" }
+
+func (e *sessionNotFoundError) ShouldPrintUsage() bool { return false }
+
+type SourceError interface {
+	cenclierrors.CencliError
+}
+
+type sourceError struct {
+	source string
+	err    error
+}
+
+var _ SourceError = &sourceError{}
+
+func newSourceError(source string, err error) SourceError {
+	return &sourceError{source: source, err: err}
+}
+
+func (e *sourceError) Error() string {
+	return fmt.Sprintf("failed to read %s: %v", e.source, e.err)
+}
+
+func (e *sourceError) Title() string { return "Graph Failed" }
+
+func (e *sourceError) ShouldPrintUsage() bool { return false }
+
+func (e *sourceError) Unwrap() error { return e.err }
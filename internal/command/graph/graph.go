@@ -0,0 +1,181 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/graph"
+	"github.com/censys/cencli/internal/store"
+)
+
+const cmdName = "graph"
+
+// Command implements the experimental `graph` CLI command. It builds a graph
+// of the assets and censeye pivots recorded in sessions and saved files, and
+// writes it for graph tools.
+type Command struct {
+	*command.BaseCommand
+	// flags the command uses
+	flags graphCommandFlags
+	// state - populated by PreRun (through flags, args, etc.)
+	sessions   []string
+	format     graph.Format
+	sharedOnly bool
+	// result stored for rendering
+	graph *graph.Graph
+}
+
+type graphCommandFlags struct {
+	sessions   flags.StringSliceFlag
+	format     flags.StringFlag
+	sharedOnly flags.BoolFlag
+}
+
+// document is the graph as printed with --output-format json or yaml.
+type document struct {
+	Nodes []graph.Node `json:"nodes"`
+	Edges []graph.Edge `json:"edges"`
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewGraphCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string { return fmt.Sprintf("%s [file...]", cmdName) }
+
+func (c *Command) Short() string {
+	return "Export assets and pivots as a graph (experimental)"
+}
+
+func (c *Command) Long() string {
+	return `Export the relationships between assets as a graph, to visualize infrastructure
+clusters in Graphviz, Gephi, yEd, Cytoscape, or any tool that reads DOT, GraphML,
+or Cytoscape JSON. This command is experimental: its output may change.
+
+Nodes are the hosts and web properties found in the sources, the certificates and
+fingerprints (JARM, banner hashes, and SSH host keys) they present, and the queries
+censeye pivoted on. Edges link each asset to its certificates, its fingerprints,
+and the interesting queries found from it, so assets that share a node are drawn
+together. Use --shared-only to keep only the nodes shared by several assets.
+
+Sources are the sessions given with --session and the files given as arguments: a
+session archive, a SQLite export, or JSON such as saved command output (e.g. of
+censeye --batch or view). The pivots of a single-host censeye run are only known
+from sessions, which record the host it ran on.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"--session incident-42 | dot -Tsvg > incident-42.svg",
+		"--format graphml incident-42.cencli-session.tar.gz > incident-42.graphml",
+		"--format cytoscape --shared-only pivots.json hosts.json > clusters.json",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs { return command.MinimumArgs(0) }
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	formats := make([]string, len(graph.Formats))
+	for i, f := range graph.Formats {
+		formats[i] = string(f)
+	}
+	c.flags.sessions = flags.NewStringSliceFlag(c.Flags(), false, "session", "", []string{}, "recorded session to read (repeatable)")
+	c.flags.format = flags.NewStringFlag(c.Flags(), false, "format", "", string(graph.FormatDOT),
+		"format of the graph: "+strings.Join(formats, ", "))
+	c.flags.sharedOnly = flags.NewBoolFlag(c.Flags(), "shared-only", "", false,
+		"only keep the certificates, fingerprints, and queries shared by several assets")
+	return nil
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.sessions, err = c.flags.sessions.Value(); err != nil {
+		return err
+	}
+	if len(c.sessions) == 0 && len(args) == 0 {
+		return newNoSourcesError()
+	}
+	rawFormat, err := c.flags.format.Value()
+	if err != nil {
+		return err
+	}
+	c.format = graph.Format(strings.ToLower(strings.TrimSpace(rawFormat)))
+	if !slices.Contains(graph.Formats, c.format) {
+		return newUnsupportedFormatError(rawFormat)
+	}
+	if c.sharedOnly, err = c.flags.sharedOnly.Value(); err != nil {
+		return err
+	}
+	if len(c.sessions) > 0 && c.Store() == nil {
+		return newStoreUnavailableError()
+	}
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	ctx := cmd.Context()
+	g := graph.New()
+	for _, name := range c.sessions {
+		session, getErr := c.Store().GetSessionByName(ctx, name)
+		if getErr != nil {
+			if errors.Is(getErr, store.ErrSessionNotFound) {
+				return newSessionNotFoundError(name)
+			}
+			return cenclierrors.NewCencliError(getErr)
+		}
+		entries, getErr := c.Store().GetSessionEntries(ctx, session.ID)
+		if getErr != nil {
+			return cenclierrors.NewCencliError(getErr)
+		}
+		for _, entry := range entries {
+			if entry.Response == "" {
+				continue
+			}
+			if addErr := g.AddResponse(entry.Command, []byte(entry.Response)); addErr != nil {
+				return newSourceError("session "+name, addErr)
+			}
+		}
+	}
+	for _, path := range args {
+		if addErr := g.AddFile(ctx, path); addErr != nil {
+			return newSourceError(path, addErr)
+		}
+	}
+	if c.sharedOnly {
+		g.SharedOnly()
+	}
+	c.graph = g
+	return c.PrintData(c, document{Nodes: g.Nodes(), Edges: g.Edges()})
+}
+
+// RenderShort writes the graph in --format to stdout.
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	var buf bytes.Buffer
+	if err := graph.Write(&buf, c.graph, c.format); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	formatter.Printf(formatter.Stdout, "%s", buf.String())
+	if nodes, _ := c.graph.Len(); nodes == 0 {
+		formatter.Printf(formatter.Stderr, "The sources hold no assets or pivots; the graph is empty.\n")
+	}
+	return nil
+}
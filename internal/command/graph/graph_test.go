@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/store"
+)
+
+// runGraph executes `graph <args>` against st.
+func runGraph(t *testing.T, st store.Store, args ...string) (string, string, error) {
+	t.Helper()
+	viper.Reset()
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	cmdContext := command.NewCommandContext(cfg, st)
+	rootCmd, cerr := command.RootCommandToCobra(NewGraphCommand(cmdContext))
+	require.NoError(t, cerr)
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs(args)
+	cmdErr := rootCmd.Execute()
+	return stdout.String(), stderr.String(), cmdErr
+}
+
+func TestGraphCommand(t *testing.T) {
+	ctx := context.Background()
+	st, err := store.New(t.TempDir())
+	require.NoError(t, err)
+
+	session, err := st.StartSession(ctx, "incident-42")
+	require.NoError(t, err)
+	for _, entry := range []*store.SessionEntry{
+		{
+			Command:  "censys view 10.0.0.1,10.0.0.2",
+			Response: `[{"ip":"10.0.0.1","services":[{"port":443,"cert":{"fingerprint_sha256":"aaa"}}]},{"ip":"10.0.0.2","services":[{"port":8443,"cert":{"fingerprint_sha256":"aaa"}},{"port":80,"banner_hash_sha256":"bh"}]}]`,
+		},
+		{
+			Command:  "censys censeye 10.0.0.1",
+			Response: `[{"count":3,"query":"q1","interesting":true},{"count":9000,"query":"q2","interesting":false}]`,
+		},
+		{Command: "censys search x"},
+	} {
+		entry.Kind = store.SessionEntryKindCommand
+		_, err = st.AddSessionEntry(ctx, session.ID, entry)
+		require.NoError(t, err)
+	}
+
+	dir := t.TempDir()
+	batch := filepath.Join(dir, "batch.json")
+	require.NoError(t, os.WriteFile(batch, []byte(`[{"host":"10.0.0.3","pivots":[{"count":3,"query":"q1","interesting":true}],"queries":4}]`), 0o600))
+
+	t.Run("dot from a session", func(t *testing.T) {
+		stdout, _, err := runGraph(t, st, "--session", "incident-42")
+		require.NoError(t, err)
+		require.Contains(t, stdout, "digraph cencli {")
+		require.Contains(t, stdout, `"host:10.0.0.2" -> "cert:aaa" [label="presents", port="8443"];`)
+		require.Contains(t, stdout, `"host:10.0.0.1" -> "query:q1" [label="pivot", count="3"];`)
+		require.NotContains(t, stdout, "q2")
+	})
+
+	t.Run("json with files and shared-only", func(t *testing.T) {
+		stdout, _, err := runGraph(t, st, "--session", "incident-42", batch, "--shared-only", "-O", "json")
+		require.NoError(t, err)
+		var doc struct {
+			Nodes []struct {
+				ID string `json:"id"`
+			} `json:"nodes"`
+			Edges []json.RawMessage `json:"edges"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &doc))
+		var ids []string
+		for _, n := range doc.Nodes {
+			ids = append(ids, n.ID)
+		}
+		require.Equal(t, []string{"host:10.0.0.1", "host:10.0.0.2", "host:10.0.0.3", "cert:aaa", "query:q1"}, ids)
+		require.Len(t, doc.Edges, 4)
+	})
+
+	t.Run("graphml", func(t *testing.T) {
+		stdout, _, err := runGraph(t, st, batch, "--format", "GraphML")
+		require.NoError(t, err)
+		require.Contains(t, stdout, `<graph id="cencli" edgedefault="directed">`)
+	})
+
+	t.Run("empty graph", func(t *testing.T) {
+		empty := filepath.Join(dir, "empty.json")
+		require.NoError(t, os.WriteFile(empty, []byte(`[]`), 0o600))
+		_, stderr, err := runGraph(t, st, empty, "--format", "cytoscape")
+		require.NoError(t, err)
+		require.Contains(t, stderr, "the graph is empty")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, _, err := runGraph(t, st)
+		var noSources NoSourcesError
+		require.ErrorAs(t, err, &noSources)
+
+		_, _, err = runGraph(t, st, batch, "--format", "svg")
+		var unsupported UnsupportedFormatError
+		require.ErrorAs(t, err, &unsupported)
+
+		_, _, err = runGraph(t, st, "--session", "missing")
+		var notFound SessionNotFoundError
+		require.ErrorAs(t, err, &notFound)
+
+		_, _, err = runGraph(t, nil, "--session", "incident-42")
+		var unavailable StoreUnavailableError
+		require.ErrorAs(t, err, &unavailable)
+
+		_, _, err = runGraph(t, st, filepath.Join(dir, "missing.json"))
+		var sourceErr SourceError
+		require.ErrorAs(t, err, &sourceErr)
+	})
+}
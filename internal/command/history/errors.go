@@ -29,3 +29,29 @@ func (e *invalidTimeWindowError) Title() string {
 func (e *invalidTimeWindowError) ShouldPrintUsage() bool {
 	return true
 }
+
+type TooManySnapshotsError interface {
+	cenclierrors.CencliError
+}
+
+type tooManySnapshotsError struct {
+	snapshots int
+	limit     int
+}
+
+func newTooManySnapshotsError(snapshots, limit int) TooManySnapshotsError {
+	return &tooManySnapshotsError{snapshots: snapshots, limit: limit}
+}
+
+func (e *tooManySnapshotsError) Error() string {
+	return fmt.Sprintf("%d snapshots would be fetched, more than --%s (%d); narrow the time window, select events with --%s, or raise --%s",
+		e.snapshots, maxSnapshotsFlagName, e.limit, eventTypeFlagName, maxSnapshotsFlagName)
+}
+
+func (e *tooManySnapshotsError) Title() string {
+	return "Too Many Snapshots"
+}
+
+func (e *tooManySnapshotsError) ShouldPrintUsage() bool {
+	return false
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/censys/cencli/internal/pkg/domain/assets"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/flags"
-	"github.com/censys/cencli/internal/pkg/formatter"
 	cmdutil "github.com/censys/cencli/internal/pkg/input"
 	"github.com/censys/cencli/internal/pkg/tape"
 )
@@ -35,15 +34,25 @@ type Command struct {
 	start     time.Time
 	end       time.Time
 	orgID     mo.Option[identifiers.OrganizationID]
+	forward   mo.Option[command.ForwardTarget]
+	// --at-events-only and its flags
+	atEventsOnly bool
+	eventTypes   []string
+	maxSnapshots int
+	// interactive shows the results in the tree view
+	interactive bool
 	// services
 	historySvc history.Service
 }
 
 type historyCommandFlags struct {
-	start    flags.TimestampFlag
-	end      flags.TimestampFlag
-	duration flags.HumanDurationFlag
-	orgID    flags.OrgIDFlag
+	start       flags.TimestampFlag
+	end         flags.TimestampFlag
+	duration    flags.HumanDurationFlag
+	orgID       flags.OrgIDFlag
+	forward     command.ForwardFlags
+	snapshots   snapshotFlags
+	interactive flags.BoolFlag
 }
 
 var _ command.Command = (*Command)(nil)
@@ -72,6 +81,8 @@ func (c *Command) Examples() []string {
 		"56a06a23... --start 2025-01-01T00:00:00Z --end 2025-01-31T00:00:00Z",
 		"example.com:443 --duration 7d",
 		"8.8.8.8 --duration 14d",
+		"8.8.8.8 --duration 30d --at-events-only --event-type service_scanned",
+		"8.8.8.8 --duration 30d --interactive",
 	}
 }
 
@@ -81,6 +92,9 @@ func (c *Command) Init() error {
 	c.flags.end = flags.NewTimestampFlag(c.Flags(), false, "end", "e", mo.None[time.Time](), "end time")
 	c.flags.duration = flags.NewHumanDurationFlag(c.Flags(), false, "duration", "d", mo.Some(7*24*time.Hour), "time window (e.g., 1d, 1w, 1y, 2h). Defaults to 7d")
 	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.forward = command.NewForwardFlags(c.Flags())
+	c.flags.snapshots = newSnapshotFlags(c)
+	c.flags.interactive = newInteractiveFlag(c)
 	return nil
 }
 
@@ -113,11 +127,11 @@ func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliE
 	c.assetID = c.assets.KnownAssetIDs()[0]
 
 	// resolve time window
-	startOpt, err := c.flags.start.Value(c.Config().DefaultTZ)
+	startOpt, err := c.flags.start.Value(c.Config().DefaultTZ, c.Now())
 	if err != nil {
 		return err
 	}
-	endOpt, err := c.flags.end.Value(c.Config().DefaultTZ)
+	endOpt, err := c.flags.end.Value(c.Config().DefaultTZ, c.Now())
 	if err != nil {
 		return err
 	}
@@ -125,7 +139,7 @@ func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliE
 	if err != nil {
 		return err
 	}
-	c.start, c.end, err = resolveTimeWindow(startOpt, endOpt, durationOpt)
+	c.start, c.end, err = resolveTimeWindow(startOpt, endOpt, durationOpt, c.Now())
 	if err != nil {
 		return err
 	}
@@ -136,6 +150,16 @@ func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliE
 	if err != nil {
 		return err
 	}
+	c.forward, err = c.flags.forward.Value()
+	if err != nil {
+		return err
+	}
+	if err := c.parseSnapshotFlags(cmd); err != nil {
+		return err
+	}
+	if err := c.parseInteractiveFlag(cmd); err != nil {
+		return err
+	}
 	// resolve required services
 	c.historySvc, err = c.HistoryService()
 	if err != nil {
@@ -152,13 +176,21 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 		"start", c.start.Format(time.RFC3339),
 		"end", c.end.Format(time.RFC3339),
 	)
+	if c.atEventsOnly {
+		return c.runSnapshots(cmd, logger)
+	}
 
 	// Set up streaming output (no-op for non-streaming formats)
 	ctx, stopStreaming := c.WithStreamingOutput(cmd.Context(), logger)
 	defer stopStreaming(nil)
+	ctx, stopForwarding, err := c.StartForwarding(ctx, logger, c.forward, cmdName)
+	if err != nil {
+		return err
+	}
+	defer stopForwarding()
 
 	var result any
-	err := c.WithProgress(
+	err = c.WithProgress(
 		ctx,
 		logger,
 		fmt.Sprintf("Fetching history for %s...", c.assetID),
@@ -189,41 +221,46 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 	case assets.AssetTypeHost:
 		hostResult := result.(history.HostHistoryResult)
 		c.PrintAppResponseMeta(hostResult.Meta)
-		if printErr := c.PrintData(c, hostResult.Events); printErr != nil {
+		if printErr := c.printHistory(hostResult.Events, c.hostTreeOptions(cmd.Context(), hostResult.Events)...); printErr != nil {
 			return printErr
 		}
 		partialError = hostResult.PartialError
 	case assets.AssetTypeCertificate:
 		certResult := result.(history.CertificateHistoryResult)
 		c.PrintAppResponseMeta(certResult.Meta)
-		if printErr := c.PrintData(c, certResult.Ranges); printErr != nil {
+		if printErr := c.printHistory(certResult.Ranges); printErr != nil {
 			return printErr
 		}
 		partialError = certResult.PartialError
 	case assets.AssetTypeWebProperty:
 		webPropResult := result.(history.WebPropertyHistoryResult)
 		c.PrintAppResponseMeta(webPropResult.Meta)
-		if printErr := c.PrintData(c, webPropResult.Snapshots); printErr != nil {
+		if printErr := c.printHistory(webPropResult.Snapshots); printErr != nil {
 			return printErr
 		}
 		partialError = webPropResult.PartialError
 	default:
 		return cenclierrors.NewCencliError(fmt.Errorf("unsupported asset type: %s", c.assetType))
 	}
+	if err := stopForwarding(); err != nil {
+		return err
+	}
 
 	// If there was a partial error, print it to stderr after rendering the data
 	if partialError != nil {
-		formatter.PrintError(partialError, cmd)
+		c.ReportPartialError(partialError, cmd)
 	}
 
 	return nil
 }
 
 // resolveTimeWindow determines the start and end times based on the provided flags.
+// The window ends at now if neither start nor end is set.
 func resolveTimeWindow(
 	startOpt mo.Option[time.Time],
 	endOpt mo.Option[time.Time],
 	durationOpt mo.Option[time.Duration],
+	now time.Time,
 ) (time.Time, time.Time, cenclierrors.CencliError) {
 	var start, end time.Time
 	var duration time.Duration
@@ -259,7 +296,7 @@ func resolveTimeWindow(
 		return end.Add(-duration), end, nil
 	default:
 		// neither is set, use now as end and calculate start
-		end = time.Now().UTC()
+		end = now.UTC()
 		return end.Add(-duration), end, nil
 	}
 }
@@ -2,8 +2,10 @@ package history
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 
 	historymocks "github.com/censys/cencli/gen/app/history/mocks"
 	historyapp "github.com/censys/cencli/internal/app/history"
+	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/config"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
@@ -475,3 +478,287 @@ func TestHistoryCommand_PartialError(t *testing.T) {
 		require.Contains(t, stderr.String(), "some data was successfully retrieved", "should include partial error message")
 	})
 }
+
+func TestHistoryCommand_Clock(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name       string
+		args       []string
+		start, end time.Time
+	}{
+		{
+			name:  "the default window ends at the time of the clock",
+			args:  []string{"8.8.8.8"},
+			start: now.Add(-7 * 24 * time.Hour),
+			end:   now,
+		},
+		{
+			name:  "relative times resolve against the clock",
+			args:  []string{"8.8.8.8", "--start", "3d ago", "--duration", "1d"},
+			start: now.Add(-3 * 24 * time.Hour),
+			end:   now.Add(-2 * 24 * time.Hour),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := historymocks.NewMockHistoryService(ctrl)
+			hostID, _ := assets.NewHostID("8.8.8.8")
+			ms.EXPECT().GetHostHistory(gomock.Any(), mo.None[identifiers.OrganizationID](), hostID, tc.start, tc.end).Return(
+				historyapp.HostHistoryResult{Events: []*components.HostTimelineEvent{}}, nil)
+
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+			formatter.Stdout = &bytes.Buffer{}
+			formatter.Stderr = &bytes.Buffer{}
+
+			cmdContext := command.NewCommandContext(cfg, nil,
+				command.WithHistoryService(ms),
+				command.WithClock(func() time.Time { return now }),
+			)
+			rootCmd, err := command.RootCommandToCobra(NewHistoryCommand(cmdContext))
+			require.NoError(t, err)
+			rootCmd.SetArgs(tc.args)
+			require.NoError(t, rootCmd.Execute())
+		})
+	}
+}
+
+func TestHistoryCommand_Streaming(t *testing.T) {
+	eventTime1Str := "2025-01-02T12:00:00Z"
+	eventTime2Str := "2025-01-05T12:00:00Z"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ms := historymocks.NewMockHistoryService(ctrl)
+	hostID, _ := assets.NewHostID("8.8.8.8")
+	ms.EXPECT().GetHostHistory(
+		gomock.Any(),
+		mo.None[identifiers.OrganizationID](),
+		hostID,
+		gomock.Any(),
+		gomock.Any(),
+	).DoAndReturn(func(ctx context.Context, _ mo.Option[identifiers.OrganizationID], _ assets.HostID, _, _ time.Time) (historyapp.HostHistoryResult, cenclierrors.CencliError) {
+		// the service emits each event instead of collecting them when streaming
+		require.True(t, streaming.IsStreaming(ctx))
+		for _, eventTime := range []*string{&eventTime1Str, &eventTime2Str} {
+			require.NoError(t, streaming.Emit(ctx, &components.HostTimelineEvent{EventTime: eventTime}))
+		}
+		return historyapp.HostHistoryResult{
+			Meta: &responsemeta.ResponseMeta{Method: "GET", URL: "https://127.0.0.1", Status: 200},
+		}, nil
+	})
+
+	tempDir := t.TempDir()
+	viper.Reset()
+	cfg, err := config.New(tempDir)
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	cmdContext := command.NewCommandContext(cfg, nil, command.WithHistoryService(ms))
+	rootCmd, err := command.RootCommandToCobra(NewHistoryCommand(cmdContext))
+	require.NoError(t, err)
+	require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+	rootCmd.SetArgs([]string{"8.8.8.8", "--start", "2025-01-01T00:00:00Z", "--end", "2025-01-08T00:00:00Z", "--streaming"})
+	require.NoError(t, rootCmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, lines, 2)
+	for i, want := range []string{eventTime1Str, eventTime2Str} {
+		var event components.HostTimelineEvent
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &event))
+		require.Equal(t, want, *event.EventTime)
+	}
+	require.Contains(t, stderr.String(), "200", "response metadata should be printed to stderr")
+}
+
+func TestHistoryCommand_AtEventsOnly(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	serviceTime := "2025-01-02T12:00:00Z"
+	locationTime := "2025-01-05T12:00:00Z"
+	events := []*components.HostTimelineEvent{
+		{EventTime: &locationTime, LocationUpdated: &components.LocationUpdated{}},
+		{EventTime: &serviceTime, ServiceScanned: &components.ServiceScanned{}},
+	}
+	hostID, _ := assets.NewHostID("8.8.8.8")
+
+	execute := func(t *testing.T, ms historyapp.Service, args ...string) (string, string, error) {
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+
+		var stdout, stderr bytes.Buffer
+		formatter.Stdout = &stdout
+		formatter.Stderr = &stderr
+
+		cmdContext := command.NewCommandContext(cfg, nil, command.WithHistoryService(ms))
+		rootCmd, err := command.RootCommandToCobra(NewHistoryCommand(cmdContext))
+		require.NoError(t, err)
+		rootCmd.SetArgs(append([]string{"--start", "2025-01-01T00:00:00Z", "--end", "2025-01-08T00:00:00Z"}, args...))
+		cmdErr := rootCmd.Execute()
+		return stdout.String(), stderr.String(), cmdErr
+	}
+	expectTimeline := func(ms *historymocks.MockHistoryService) {
+		ms.EXPECT().GetHostHistory(gomock.Any(), mo.None[identifiers.OrganizationID](), hostID, gomock.Any(), gomock.Any()).Return(
+			historyapp.HostHistoryResult{Events: events}, nil)
+	}
+
+	t.Run("fetches the host at the start and at each event", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ms := historymocks.NewMockHistoryService(ctrl)
+		expectTimeline(ms)
+		ms.EXPECT().GetHostSnapshots(gomock.Any(), mo.None[identifiers.OrganizationID](), hostID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ mo.Option[identifiers.OrganizationID], _ assets.HostID, groups []historyapp.HostEventGroup) (historyapp.HostSnapshotsResult, cenclierrors.CencliError) {
+				require.Len(t, groups, 3)
+				require.Equal(t, start, groups[0].Time)
+				require.Empty(t, groups[0].Events)
+				require.Equal(t, serviceTime, groups[1].Time.Format(time.RFC3339))
+				require.Equal(t, locationTime, groups[2].Time.Format(time.RFC3339))
+				snapshots := make([]*historyapp.HostSnapshot, len(groups))
+				for i, g := range groups {
+					snapshots[i] = &historyapp.HostSnapshot{Time: g.Time, Events: g.Events, Data: &components.Host{}, Exists: true}
+				}
+				return historyapp.HostSnapshotsResult{Snapshots: snapshots}, nil
+			})
+
+		stdout, _, err := execute(t, ms, "8.8.8.8", "--at-events-only")
+		require.NoError(t, err)
+		var snapshots []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(stdout), &snapshots))
+		require.Len(t, snapshots, 3)
+		require.Equal(t, "2025-01-01T00:00:00Z", snapshots[0]["time"])
+		require.Equal(t, true, snapshots[1]["exists"])
+		require.Len(t, snapshots[1]["events"], 1)
+	})
+
+	t.Run("event types select the events", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ms := historymocks.NewMockHistoryService(ctrl)
+		expectTimeline(ms)
+		ms.EXPECT().GetHostSnapshots(gomock.Any(), gomock.Any(), hostID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ mo.Option[identifiers.OrganizationID], _ assets.HostID, groups []historyapp.HostEventGroup) (historyapp.HostSnapshotsResult, cenclierrors.CencliError) {
+				require.Len(t, groups, 2)
+				require.Equal(t, locationTime, groups[1].Time.Format(time.RFC3339))
+				return historyapp.HostSnapshotsResult{}, nil
+			})
+
+		_, _, err := execute(t, ms, "8.8.8.8", "--at-events-only", "--event-type", "location_updated")
+		require.NoError(t, err)
+	})
+
+	t.Run("no matching events fetch no snapshots", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ms := historymocks.NewMockHistoryService(ctrl)
+		expectTimeline(ms)
+
+		stdout, stderr, err := execute(t, ms, "8.8.8.8", "--at-events-only", "--event-type", "whois_updated")
+		require.NoError(t, err)
+		require.JSONEq(t, "[]", stdout)
+		require.Contains(t, stderr, "no snapshots were fetched")
+	})
+
+	t.Run("too many snapshots", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ms := historymocks.NewMockHistoryService(ctrl)
+		expectTimeline(ms)
+
+		_, _, err := execute(t, ms, "8.8.8.8", "--at-events-only", "--max-snapshots", "2")
+		var tooManyErr TooManySnapshotsError
+		require.ErrorAs(t, err, &tooManyErr)
+		require.ErrorContains(t, err, "3 snapshots would be fetched")
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ms := historymocks.NewMockHistoryService(ctrl)
+
+		_, _, err := execute(t, ms, "8.8.8.8", "--at-events-only", "--event-type", "port_opened")
+		require.ErrorContains(t, err, `unsupported --event-type "port_opened"`)
+
+		_, _, err = execute(t, ms, "8.8.8.8", "--event-type", "service_scanned")
+		require.ErrorContains(t, err, "--event-type requires --at-events-only")
+
+		_, _, err = execute(t, ms, "example.com:443", "--at-events-only")
+		require.ErrorContains(t, err, "--at-events-only only supports hosts")
+	})
+}
+
+func TestHistoryCommand_Interactive(t *testing.T) {
+	eventTime := "2025-01-02T12:00:00Z"
+	event := &components.HostTimelineEvent{EventTime: &eventTime, ServiceScanned: &components.ServiceScanned{}}
+	hostID, _ := assets.NewHostID("8.8.8.8")
+
+	execute := func(t *testing.T, ms historyapp.Service, args ...string) (string, error) {
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+
+		var stdout bytes.Buffer
+		formatter.Stdout = &stdout
+		formatter.Stderr = &bytes.Buffer{}
+
+		cmdContext := command.NewCommandContext(cfg, nil, command.WithHistoryService(ms))
+		rootCmd, err := command.RootCommandToCobra(NewHistoryCommand(cmdContext))
+		require.NoError(t, err)
+		require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+		rootCmd.SetArgs(args)
+		cmdErr := rootCmd.Execute()
+		return stdout.String(), cmdErr
+	}
+
+	t.Run("prints yaml without a terminal", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ms := historymocks.NewMockHistoryService(ctrl)
+		ms.EXPECT().GetHostHistory(gomock.Any(), gomock.Any(), hostID, gomock.Any(), gomock.Any()).Return(
+			historyapp.HostHistoryResult{Events: []*components.HostTimelineEvent{event}}, nil)
+
+		stdout, err := execute(t, ms, "8.8.8.8", "--interactive")
+		require.NoError(t, err)
+		require.Contains(t, stdout, "event_time: \"2025-01-02T12:00:00Z\"")
+	})
+
+	t.Run("conflicting flags", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ms := historymocks.NewMockHistoryService(ctrl)
+
+		_, err := execute(t, ms, "8.8.8.8", "--interactive", "--at-events-only")
+		require.ErrorContains(t, err, "cannot use --interactive and --at-events-only flags together")
+
+		_, err = execute(t, ms, "8.8.8.8", "--interactive", "--streaming")
+		require.ErrorContains(t, err, "cannot use --interactive and --streaming flags together")
+	})
+
+	t.Run("fetches the host at the time of an event", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		ms := historymocks.NewMockHistoryService(ctrl)
+		ip := "8.8.8.8"
+		ms.EXPECT().GetHostSnapshots(gomock.Any(), mo.None[identifiers.OrganizationID](), hostID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ mo.Option[identifiers.OrganizationID], _ assets.HostID, groups []historyapp.HostEventGroup) (historyapp.HostSnapshotsResult, cenclierrors.CencliError) {
+				require.Len(t, groups, 1)
+				require.Equal(t, eventTime, groups[0].Time.Format(time.RFC3339))
+				require.Equal(t, []*components.HostTimelineEvent{event}, groups[0].Events)
+				return historyapp.HostSnapshotsResult{Snapshots: []*historyapp.HostSnapshot{{
+					Time: groups[0].Time, Data: &components.Host{IP: &ip}, Exists: true,
+				}}}, nil
+			})
+		ms.EXPECT().GetHostSnapshots(gomock.Any(), gomock.Any(), hostID, gomock.Any()).Return(
+			historyapp.HostSnapshotsResult{Snapshots: []*historyapp.HostSnapshot{{}}}, nil)
+
+		c := &Command{historySvc: ms, assets: assets.NewAssetClassifier("8.8.8.8"), assetID: "8.8.8.8"}
+		host, err := c.fetchSnapshot(context.Background(), event)
+		require.NoError(t, err)
+		require.Equal(t, &ip, host.IP)
+
+		_, err = c.fetchSnapshot(context.Background(), event)
+		require.ErrorContains(t, err, "8.8.8.8 was not found at 2025-01-02T12:00:00Z")
+
+		_, err = c.fetchSnapshot(context.Background(), &components.HostTimelineEvent{})
+		require.ErrorContains(t, err, "the event has no time")
+	})
+}
@@ -0,0 +1,98 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/history"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/ui/tree"
+	"github.com/censys/censys-sdk-go/models/components"
+)
+
+const (
+	interactiveFlagName = "interactive"
+	// snapshotKey fetches the host at the time of the selected event
+	snapshotKey = "s"
+)
+
+func newInteractiveFlag(c *Command) flags.BoolFlag {
+	return flags.NewBoolFlag(c.Flags(), interactiveFlagName, "i", false,
+		fmt.Sprintf("browse the results in an interactive tree; for hosts, press %s on an event to fetch the host at its time", snapshotKey))
+}
+
+// parseInteractiveFlag parses --interactive, which shows the results in the
+// tree view instead of printing them.
+func (c *Command) parseInteractiveFlag(cmd *cobra.Command) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.interactive, err = c.flags.interactive.Value()
+	if err != nil || !c.interactive {
+		return err
+	}
+	for _, name := range []string{atEventsOnlyFlagName, "forward"} {
+		if cmd.Flags().Changed(name) {
+			return flags.NewConflictingFlagsError(interactiveFlagName, name)
+		}
+	}
+	if c.Config().Streaming {
+		return flags.NewConflictingFlagsError(interactiveFlagName, "streaming")
+	}
+	return nil
+}
+
+// printHistory prints data, or shows it in the tree view with --interactive.
+func (c *Command) printHistory(data any, opts ...tree.Option) cenclierrors.CencliError {
+	if c.interactive {
+		return c.PrintTree(data, opts...)
+	}
+	return c.PrintData(c, data)
+}
+
+// hostTreeOptions labels the events of a host with their time and type, and
+// binds the key that fetches the host as it was at the time of an event.
+func (c *Command) hostTreeOptions(ctx context.Context, events []*components.HostTimelineEvent) []tree.Option {
+	labels := make([]string, len(events))
+	for i, event := range events {
+		if event.EventTime != nil {
+			labels[i] = *event.EventTime + " " + history.HostEventType(event)
+		}
+	}
+	return []tree.Option{
+		tree.WithItemLabels(labels),
+		tree.WithKeyActions([]tree.KeyAction{{
+			Key:         snapshotKey,
+			Description: "fetch host at event time",
+			Name:        "snapshot",
+			Load: func(index int) (any, error) {
+				return c.fetchSnapshot(ctx, events[index])
+			},
+		}}),
+	}
+}
+
+// fetchSnapshot returns the host as it was at the time of event.
+func (c *Command) fetchSnapshot(ctx context.Context, event *components.HostTimelineEvent) (*components.Host, error) {
+	if event.EventTime == nil {
+		return nil, fmt.Errorf("the event has no time")
+	}
+	at, err := time.Parse(time.RFC3339Nano, *event.EventTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event time %q: %w", *event.EventTime, err)
+	}
+	groups := []history.HostEventGroup{{Time: at.UTC(), Events: []*components.HostTimelineEvent{event}}}
+	result, fetchErr := c.historySvc.GetHostSnapshots(ctx, c.orgID, c.assets.HostIDs()[0], groups)
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if result.PartialError != nil {
+		return nil, result.PartialError
+	}
+	if len(result.Snapshots) == 0 || !result.Snapshots[0].Exists {
+		return nil, fmt.Errorf("%s was not found at %s", c.assetID, *event.EventTime)
+	}
+	return result.Snapshots[0].Data, nil
+}
@@ -0,0 +1,156 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/history"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+const (
+	atEventsOnlyFlagName = "at-events-only"
+	eventTypeFlagName    = "event-type"
+	maxSnapshotsFlagName = "max-snapshots"
+
+	defaultMaxSnapshots = 25
+	maxMaxSnapshots     = 500
+)
+
+type snapshotFlags struct {
+	atEventsOnly flags.BoolFlag
+	eventTypes   flags.StringSliceFlag
+	maxSnapshots flags.IntegerFlag
+}
+
+func newSnapshotFlags(c *Command) snapshotFlags {
+	return snapshotFlags{
+		atEventsOnly: flags.NewBoolFlag(c.Flags(), atEventsOnlyFlagName, "", false,
+			"for hosts, fetch the full host at the time of each timeline event instead of printing the events"),
+		eventTypes: flags.NewStringSliceFlag(c.Flags(), false, eventTypeFlagName, "", []string{},
+			fmt.Sprintf("with --at-events-only, only fetch snapshots for these event types (%s)", strings.Join(history.HostEventTypes, ", "))),
+		maxSnapshots: flags.NewIntegerFlag(c.Flags(), false, maxSnapshotsFlagName, "", mo.Some(int64(defaultMaxSnapshots)),
+			"with --at-events-only, the maximum number of snapshots to fetch, one request each",
+			mo.Some(int64(1)), mo.Some(int64(maxMaxSnapshots))),
+	}
+}
+
+// parseSnapshotFlags parses --at-events-only and the flags that go with it.
+func (c *Command) parseSnapshotFlags(cmd *cobra.Command) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.atEventsOnly, err = c.flags.snapshots.atEventsOnly.Value()
+	if err != nil {
+		return err
+	}
+	if !c.atEventsOnly {
+		for _, name := range []string{eventTypeFlagName, maxSnapshotsFlagName} {
+			if cmd.Flags().Changed(name) {
+				return cenclierrors.NewUsageError(fmt.Errorf("--%s requires --%s", name, atEventsOnlyFlagName))
+			}
+		}
+		return nil
+	}
+	if c.assetType != assets.AssetTypeHost {
+		return cenclierrors.NewUsageError(fmt.Errorf("--%s only supports hosts", atEventsOnlyFlagName))
+	}
+	c.eventTypes, err = c.flags.snapshots.eventTypes.Value()
+	if err != nil {
+		return err
+	}
+	for _, t := range c.eventTypes {
+		if !slices.Contains(history.HostEventTypes, t) {
+			return cenclierrors.NewUsageError(fmt.Errorf("unsupported --%s %q; use one of %s",
+				eventTypeFlagName, t, strings.Join(history.HostEventTypes, ", ")))
+		}
+	}
+	maxSnapshots, err := c.flags.snapshots.maxSnapshots.Value()
+	if err != nil {
+		return err
+	}
+	c.maxSnapshots = int(maxSnapshots.OrElse(defaultMaxSnapshots))
+	return nil
+}
+
+// runSnapshots implements --at-events-only: it fetches the timeline of the
+// host, then the host as it was at the start of the window and at the time
+// of each (selected) event, so that consecutive snapshots show the full
+// state before and after each change.
+func (c *Command) runSnapshots(cmd *cobra.Command, logger *slog.Logger) cenclierrors.CencliError {
+	hostID := c.assets.HostIDs()[0]
+
+	// the timeline is fetched without the streaming emitter, since only the
+	// snapshots are printed
+	var timeline history.HostHistoryResult
+	err := c.WithProgress(
+		cmd.Context(),
+		logger,
+		fmt.Sprintf("Fetching history for %s...", c.assetID),
+		func(pctx context.Context) cenclierrors.CencliError {
+			var fetchErr cenclierrors.CencliError
+			timeline, fetchErr = c.historySvc.GetHostHistory(pctx, c.orgID, hostID, c.start, c.end)
+			return fetchErr
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if timeline.PartialError != nil {
+		c.ReportPartialError(timeline.PartialError, cmd)
+	}
+
+	groups := history.GroupHostEvents(timeline.Events, c.eventTypes)
+	if len(groups) == 0 {
+		if !c.Config().Quiet {
+			formatter.Println(formatter.Stderr, "No matching events in the time window; no snapshots were fetched.")
+		}
+		return c.PrintData(c, []*history.HostSnapshot{})
+	}
+	// the host at the start of the window is the state before the first event
+	groups = append([]history.HostEventGroup{{Time: c.start}}, groups...)
+	if len(groups) > c.maxSnapshots {
+		return newTooManySnapshotsError(len(groups), c.maxSnapshots)
+	}
+
+	ctx, stopStreaming := c.WithStreamingOutput(cmd.Context(), logger)
+	defer stopStreaming(nil)
+	ctx, stopForwarding, err := c.StartForwarding(ctx, logger, c.forward, cmdName)
+	if err != nil {
+		return err
+	}
+	defer stopForwarding()
+
+	var result history.HostSnapshotsResult
+	err = c.WithProgress(
+		ctx,
+		logger,
+		fmt.Sprintf("Fetching %d snapshots of %s...", len(groups), c.assetID),
+		func(pctx context.Context) cenclierrors.CencliError {
+			var fetchErr cenclierrors.CencliError
+			result, fetchErr = c.historySvc.GetHostSnapshots(pctx, c.orgID, hostID, groups)
+			return fetchErr
+		},
+	)
+	if err != nil {
+		return err
+	}
+	c.PrintAppResponseMeta(result.Meta)
+	if err := c.PrintData(c, result.Snapshots); err != nil {
+		return err
+	}
+	if err := stopForwarding(); err != nil {
+		return err
+	}
+	if result.PartialError != nil {
+		c.ReportPartialError(result.PartialError, cmd)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package hunt
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+type LoadHuntsError interface {
+	cenclierrors.CencliError
+}
+
+type loadHuntsError struct {
+	err error
+}
+
+var _ LoadHuntsError = &loadHuntsError{}
+
+func newLoadHuntsError(err error) LoadHuntsError {
+	return &loadHuntsError{err: err}
+}
+
+func (e *loadHuntsError) Error() string {
+	return fmt.Sprintf("failed to load hunts: %v", e.err)
+}
+
+func (e *loadHuntsError) Title() string { return "Invalid Hunts" }
+
+func (e *loadHuntsError) ShouldPrintUsage() bool { return false }
+
+func (e *loadHuntsError) Unwrap() error { return e.err }
+
+type UnknownHuntError interface {
+	cenclierrors.CencliError
+}
+
+type unknownHuntError struct {
+	name string
+}
+
+var _ UnknownHuntError = &unknownHuntError{}
+
+func newUnknownHuntError(name string) UnknownHuntError {
+	return &unknownHuntError{name: name}
+}
+
+func (e *unknownHuntError) Error() string {
+	return fmt.Sprintf("no hunt is named %q; run `censys hunt list` to see the available hunts", e.name)
+}
+
+func (e *unknownHuntError) Title() string { return "Unknown Hunt" }
+
+func (e *unknownHuntError) ShouldPrintUsage() bool { return false }
@@ -0,0 +1,107 @@
+package hunt
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/hunt"
+)
+
+// huntsDir is the directory of org-specific hunts in the config directory,
+// used unless hunt.dir is set.
+const huntsDir = "hunts"
+
+// Command is the parent hunt command that groups hunt subcommands.
+type Command struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*Command)(nil)
+
+// NewHuntCommand creates a new hunt command with all subcommands.
+func NewHuntCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return "hunt"
+}
+
+func (c *Command) Short() string {
+	return "Run curated hunting queries"
+}
+
+func (c *Command) Long() string {
+	return `Run curated hunting queries.
+
+A library of parameterized search queries for common hunts is built in: open
+databases, exposed remote access services such as RDP and VNC, C2 frameworks by
+their JARM or JA4S fingerprints, and admin panels with default credentials. Each
+hunt can be narrowed with parameters, such as --country DE.
+
+Add org-specific hunts as YAML files in the hunts directory of the config
+directory, or in the directory set by hunt.dir. A hunt with the name of a
+built-in one replaces it.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"list",
+		"run exposed-rdp --country DE",
+		"run c2-jarm --jarm 07d14d16d21d21d07c42d41d00041d24a458a375eef0c576d23a7bab9a9fb1 -O short",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	return c.AddSubCommands(
+		newListCommand(c.Context),
+		newRunCommand(c.Context),
+	)
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	// Parent command shows help when run without subcommands
+	if err := cmd.Help(); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
+
+// localDir returns the directory of org-specific hunts, or "" if there is none.
+func localDir(ctx *command.Context) string {
+	if dir := ctx.Config().Hunt.Dir; dir != "" {
+		return dir
+	}
+	if configDir := ctx.Dirs().Config; configDir != "" {
+		return filepath.Join(configDir, huntsDir)
+	}
+	return ""
+}
+
+// loadLibrary returns the built-in and org-specific hunts.
+func loadLibrary(ctx *command.Context) (*hunt.Library, cenclierrors.CencliError) {
+	lib, err := hunt.Load(localDir(ctx))
+	if err != nil {
+		return nil, newLoadHuntsError(err)
+	}
+	return lib, nil
+}
@@ -0,0 +1,177 @@
+package hunt
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	searchmocks "github.com/censys/cencli/gen/app/search/mocks"
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+// runHunt runs the hunt command with args, with hunts from dir, and returns
+// its stdout, stderr, and error.
+func runHunt(t *testing.T, dir string, svc search.Service, args ...string) (string, string, error) {
+	t.Helper()
+	viper.Reset()
+	viper.Set("hunt.dir", dir)
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	if svc == nil {
+		svc = searchmocks.NewMockSearchService(ctrl)
+	}
+	cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(svc))
+	rootCmd, err := command.RootCommandToCobra(NewHuntCommand(cmdContext))
+	require.NoError(t, err)
+
+	rootCmd.SetArgs(args)
+	cmdErr := rootCmd.Execute()
+	return stdout.String(), stderr.String(), cmdErr
+}
+
+func TestHuntList(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "our-vpn.yaml"), []byte(`title: Our VPN gateways
+description: VPN gateways in our ranges
+tags: [c2]
+query: 'host.services.software.product: "{product}"'
+params:
+  - name: product
+    description: the product
+`), 0o600))
+
+	t.Run("short", func(t *testing.T) {
+		stdout, _, err := runHunt(t, dir, nil, "list")
+		require.NoError(t, err)
+		require.Contains(t, stdout, "exposed-rdp")
+		require.Regexp(t, `c2-jarm\s+\|.*\|\s+jarm\*, country, asn`, stdout)
+		require.Regexp(t, `our-vpn\s+\|\s+Our VPN gateways\s+\|\s+product\*\s+\|\s+`+regexp.QuoteMeta(filepath.Join(dir, "our-vpn.yaml")), stdout)
+	})
+
+	t.Run("by tag", func(t *testing.T) {
+		stdout, _, err := runHunt(t, dir, nil, "list", "--tag", "c2")
+		require.NoError(t, err)
+		require.Contains(t, stdout, "c2-cobalt-strike")
+		require.Contains(t, stdout, "our-vpn")
+		require.NotContains(t, stdout, "exposed-rdp")
+	})
+
+	t.Run("invalid local hunt", func(t *testing.T) {
+		bad := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(bad, "bad.yaml"), []byte("title: no query\n"), 0o600))
+		_, _, err := runHunt(t, bad, nil, "list")
+		var loadErr LoadHuntsError
+		require.ErrorAs(t, err, &loadErr)
+		require.ErrorContains(t, err, "query is required")
+	})
+}
+
+func TestHuntRun(t *testing.T) {
+	hostResult := search.Result{
+		Hits: []assets.Asset{
+			&assets.Host{Host: components.Host{IP: strPtr("192.0.2.1")}},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		args    []string
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name: "parameter flags narrow the query",
+			args: []string{"run", "exposed-rdp", "--country", "DE", "--max-pages", "2"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
+						require.Equal(t, `(host.services.protocol: "RDP") and (host.location.country_code: "DE")`, params.Query)
+						require.Equal(t, uint64(2), params.MaxPages.MustGet())
+						return hostResult, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, `"host"`)
+				require.Contains(t, stdout, "192.0.2.1")
+				require.Contains(t, stderr, `Query: (host.services.protocol: "RDP")`)
+			},
+		},
+		{
+			name: "--param",
+			args: []string{"run", "c2-jarm", "--param", "jarm=abc", "-O", "short"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
+						require.Equal(t, `host.services.jarm.fingerprint: "abc"`, params.Query)
+						return hostResult, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "192.0.2.1")
+			},
+		},
+		{
+			name: "missing required parameter",
+			args: []string{"run", "c2-jarm"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, `hunt c2-jarm requires the parameter "jarm"`)
+			},
+		},
+		{
+			name: "unknown parameter",
+			args: []string{"run", "exposed-vnc", "--param", "jarm=abc"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, `hunt exposed-vnc has no parameter "jarm"`)
+			},
+		},
+		{
+			name: "unknown hunt",
+			args: []string{"run", "exposed-rpd"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var unknownErr UnknownHuntError
+				require.ErrorAs(t, err, &unknownErr)
+				require.ErrorContains(t, err, "censys hunt list")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var svc search.Service
+			if tc.service != nil {
+				svc = tc.service(gomock.NewController(t))
+			}
+			stdout, stderr, err := runHunt(t, "", svc, tc.args...)
+			tc.assert(t, stdout, stderr, err)
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
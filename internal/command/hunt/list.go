@@ -0,0 +1,151 @@
+package hunt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/hunt"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+type listCommand struct {
+	*command.BaseCommand
+	flags listCommandFlags
+	// state - populated by PreRun
+	tag string
+	// result stored for rendering
+	hunts []hunt.Hunt
+}
+
+type listCommandFlags struct {
+	tag flags.StringFlag
+}
+
+var _ command.Command = (*listCommand)(nil)
+
+func newListCommand(ctx *command.Context) *listCommand {
+	return &listCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *listCommand) Use() string   { return "list" }
+func (c *listCommand) Short() string { return "List the available hunts" }
+func (c *listCommand) Long() string {
+	return `List the available hunts, built-in and org-specific, with their parameters.
+Required parameters are marked with *.
+
+Use --output-format json to see the query of each hunt.`
+}
+
+func (c *listCommand) Examples() []string {
+	return []string{
+		"",
+		"--tag c2",
+		"-O json | jq -r '.[].query'",
+	}
+}
+
+func (c *listCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *listCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *listCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *listCommand) Init() error {
+	c.flags.tag = flags.NewStringFlag(c.Flags(), false, "tag", "t", "", "only list hunts with this tag, e.g. c2")
+	return nil
+}
+
+func (c *listCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.tag, err = c.flags.tag.Value()
+	return err
+}
+
+func (c *listCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	lib, err := loadLibrary(c.Context)
+	if err != nil {
+		return err
+	}
+	c.hunts = []hunt.Hunt{}
+	for _, h := range lib.All() {
+		if c.tag == "" || hasTag(h, c.tag) {
+			c.hunts = append(c.hunts, h)
+		}
+	}
+	return c.PrintData(c, c.hunts)
+}
+
+func (c *listCommand) RenderShort() cenclierrors.CencliError {
+	if len(c.hunts) == 0 {
+		formatter.Println(formatter.Stdout, styles.GlobalStyles.Comment.Render(fmt.Sprintf("No hunts are tagged %q.", c.tag)))
+		return nil
+	}
+	tbl := rawtable.New(
+		[]rawtable.Column[hunt.Hunt]{
+			{
+				Title:      "Name",
+				String:     func(h hunt.Hunt) string { return h.Name },
+				Style:      func(s string, _ hunt.Hunt) string { return styles.GlobalStyles.Signature.Render(s) },
+				Priority:   4,
+				NoTruncate: true,
+			},
+			{
+				Title:    "Title",
+				String:   func(h hunt.Hunt) string { return h.Title },
+				Priority: 3,
+			},
+			{
+				Title:    "Parameters",
+				String:   paramSummary,
+				Style:    func(s string, _ hunt.Hunt) string { return styles.GlobalStyles.Primary.Render(s) },
+				Priority: 2,
+			},
+			{
+				Title:    "Source",
+				String:   func(h hunt.Hunt) string { return h.Source },
+				Style:    func(s string, _ hunt.Hunt) string { return styles.GlobalStyles.Comment.Render(s) },
+				Priority: 1,
+			},
+		},
+		rawtable.WithHeaderStyle[hunt.Hunt](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[hunt.Hunt](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[hunt.Hunt](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, tbl.Render(c.hunts))
+	return nil
+}
+
+// paramSummary lists the parameters of a hunt, with required ones marked.
+func paramSummary(h hunt.Hunt) string {
+	names := make([]string, len(h.Params))
+	for i, p := range h.Params {
+		names[i] = p.Name
+		if p.Required() {
+			names[i] += "*"
+		}
+	}
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+func hasTag(h hunt.Hunt, tag string) bool {
+	for _, t := range h.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
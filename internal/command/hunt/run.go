@@ -0,0 +1,284 @@
+package hunt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/formatter/short"
+	"github.com/censys/cencli/internal/pkg/hunt"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+const (
+	cmdName = "hunt"
+
+	defaultPageSize = 100
+	defaultMaxPages = 1
+)
+
+type runCommand struct {
+	*command.BaseCommand
+	// services the command uses
+	searchSvc search.Service
+	// flags the command uses
+	flags runCommandFlags
+	// state - populated by PreRun (through flags, args, etc.)
+	hunt     hunt.Hunt
+	query    string
+	orgID    mo.Option[identifiers.OrganizationID]
+	pageSize mo.Option[uint64]
+	maxPages mo.Option[uint64]
+	idsSep   mo.Option[byte]
+	// result stored for rendering
+	result search.Result
+}
+
+type runCommandFlags struct {
+	orgID    flags.OrgIDFlag
+	pageSize flags.IntegerFlag
+	maxPages flags.IntegerFlag
+	ids      command.IDsFlags
+	params   flags.StringSliceFlag
+	// byParam are the flags of the parameters of the hunts, such as --country,
+	// by parameter name
+	byParam map[string]flags.StringFlag
+}
+
+var _ command.Command = (*runCommand)(nil)
+
+func newRunCommand(ctx *command.Context) *runCommand {
+	return &runCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *runCommand) Use() string   { return "run <hunt>" }
+func (c *runCommand) Short() string { return "Run a hunt" }
+func (c *runCommand) Long() string {
+	return `Run a hunt: render its query with the given parameters and search for it.
+
+Parameters are given as flags, such as --country DE, or with --param name=value.
+Run ` + "`censys hunt list`" + ` to see the hunts and their parameters. The rendered query is
+printed to stderr, so that it can be refined and run with ` + "`censys search`" + `.`
+}
+
+func (c *runCommand) Examples() []string {
+	return []string{
+		"exposed-rdp --country DE",
+		"open-databases --param protocol=MONGODB --max-pages 5 -O short",
+		"c2-ja4s --ja4s t130200_1301_a56c5b993250 --port 443",
+	}
+}
+
+func (c *runCommand) Args() command.PositionalArgs { return command.ExactArgs(1) }
+
+func (c *runCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeData
+}
+
+func (c *runCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeTemplate, command.OutputTypeShort}
+}
+
+func (c *runCommand) Init() error {
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	defaultPS := int64(defaultPageSize)
+	if v := c.Config().Search.PageSize; v > 0 {
+		defaultPS = v
+	}
+	defaultMP := int64(defaultMaxPages)
+	if v := c.Config().Search.MaxPages; v != 0 {
+		defaultMP = v
+	}
+	c.flags.pageSize = flags.NewIntegerFlag(c.Flags(), false, "page-size", "n", mo.Some(defaultPS),
+		"number of results to return per page", mo.Some[int64](1), mo.None[int64]())
+	c.flags.maxPages = flags.NewIntegerFlag(c.Flags(), false, "max-pages", "p", mo.Some(defaultMP),
+		"maximum number of pages to fetch (-1 for all pages)", mo.None[int64](), mo.None[int64]())
+	c.flags.ids = command.NewIDsFlags(c.Flags())
+	c.flags.params = flags.NewStringSliceFlag(c.Flags(), false, "param", "P", []string{},
+		"a parameter of the hunt as name=value (repeatable)")
+
+	// every parameter of a hunt is also a flag; invalid local hunts are
+	// reported when a hunt is run, so only the built-in ones are used then
+	lib, err := hunt.Load(localDir(c.Context))
+	if err != nil {
+		if lib, err = hunt.Builtin(); err != nil {
+			return err
+		}
+	}
+	c.flags.byParam = make(map[string]flags.StringFlag)
+	for _, name := range lib.ParamNames() {
+		if c.Flags().Lookup(name) != nil {
+			// only --param can set parameters named like other flags
+			continue
+		}
+		c.flags.byParam[name] = flags.NewStringFlag(c.Flags(), false, name, "", "",
+			fmt.Sprintf("the %s parameter of the hunt (see hunt list)", name))
+	}
+	return nil
+}
+
+func (c *runCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	lib, err := loadLibrary(c.Context)
+	if err != nil {
+		return err
+	}
+	h, ok := lib.Get(args[0])
+	if !ok {
+		return newUnknownHuntError(args[0])
+	}
+	c.hunt = h
+
+	values, err := c.paramValues(cmd)
+	if err != nil {
+		return err
+	}
+	query, renderErr := h.Render(values)
+	if renderErr != nil {
+		return cenclierrors.NewUsageError(renderErr)
+	}
+	c.query = query
+
+	if c.orgID, err = c.flags.orgID.Value(); err != nil {
+		return err
+	}
+	if err := c.parsePaginationFlags(); err != nil {
+		return err
+	}
+	if c.idsSep, err = c.flags.ids.Value(cmd, c.Config().Streaming); err != nil {
+		return err
+	}
+
+	svc, err := c.SearchService()
+	if err != nil {
+		return err
+	}
+	c.searchSvc = svc
+	return nil
+}
+
+// paramValues returns the parameters given with --param and with the flag
+// of each parameter. The flags take precedence.
+func (c *runCommand) paramValues(cmd *cobra.Command) (map[string]string, cenclierrors.CencliError) {
+	values := make(map[string]string)
+	raw, err := c.flags.params.Value()
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range raw {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, cenclierrors.NewUsageError(fmt.Errorf("invalid --param %q; use name=value", param))
+		}
+		values[strings.TrimSpace(name)] = value
+	}
+	for name, flag := range c.flags.byParam {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		value, err := flag.Value()
+		if err != nil {
+			return nil, err
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// parsePaginationFlags parses --page-size and --max-pages.
+func (c *runCommand) parsePaginationFlags() cenclierrors.CencliError {
+	pageSize, err := c.flags.pageSize.Value()
+	if err != nil {
+		return err
+	}
+	if pageSize.IsPresent() {
+		c.pageSize = mo.Some(uint64(pageSize.MustGet()))
+	}
+	maxPages, err := c.flags.maxPages.Value()
+	if err != nil {
+		return err
+	}
+	if maxPages.IsPresent() {
+		switch v := maxPages.MustGet(); {
+		case v == -1:
+			c.maxPages = mo.None[uint64]()
+		case v <= 0:
+			return flags.NewIntegerFlagInvalidValueError("max-pages", v, "must be -1 or >= 1")
+		default:
+			c.maxPages = mo.Some(uint64(v))
+		}
+	}
+	return nil
+}
+
+func (c *runCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(cmdName).With(
+		"hunt", c.hunt.Name,
+		"orgID_set", c.orgID.IsPresent(),
+		"query", c.query,
+	)
+	if !c.Config().Quiet {
+		formatter.Printf(formatter.Stderr, "%s %s\n", styles.GlobalStyles.Comment.Render("Query:"), c.query)
+	}
+
+	err := c.WithProgress(
+		cmd.Context(),
+		logger,
+		fmt.Sprintf("Running hunt %s...", c.hunt.Name),
+		func(pctx context.Context) cenclierrors.CencliError {
+			var fetchErr cenclierrors.CencliError
+			c.result, fetchErr = c.searchSvc.Search(pctx, search.Params{
+				OrgID:    c.orgID,
+				Query:    c.query,
+				PageSize: c.pageSize,
+				MaxPages: c.maxPages,
+			})
+			return fetchErr
+		},
+	)
+	if err != nil {
+		logger.Debug("fetch failed", "error", err)
+		return err
+	}
+
+	c.PrintAppResponseMeta(c.result.Meta)
+	if sep, ok := c.idsSep.Get(); ok {
+		err = command.PrintIDs(c.result.Hits, sep)
+	} else {
+		err = c.PrintData(c, c.hits())
+	}
+	if err != nil {
+		return err
+	}
+	if c.result.PartialError != nil {
+		c.ReportPartialError(c.result.PartialError, cmd)
+	}
+	return nil
+}
+
+// hits wraps each hit with its type, as `search` does.
+func (c *runCommand) hits() []any {
+	data := make([]any, len(c.result.Hits))
+	for i, hit := range c.result.Hits {
+		data[i] = map[string]any{hit.AssetType().String(): hit}
+	}
+	return data
+}
+
+func (c *runCommand) RenderTemplate() cenclierrors.CencliError {
+	return c.PrintDataWithTemplate(config.TemplateEntitySearchResult, c.hits())
+}
+
+func (c *runCommand) RenderShort() cenclierrors.CencliError {
+	formatter.Println(formatter.Stdout, short.SearchHits(c.result.Hits))
+	return nil
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+const (
+	idsOnlyFlagName = "ids-only"
+	print0FlagName  = "print0"
+)
+
+// IDsFlags are the flags of commands that can print only the identifiers of
+// the assets they return, for piping into other commands: --ids-only and
+// --print0.
+type IDsFlags struct {
+	idsOnly flags.BoolFlag
+	print0  flags.BoolFlag
+}
+
+// NewIDsFlags adds the identifier flags to fs.
+func NewIDsFlags(fs *pflag.FlagSet) IDsFlags {
+	return IDsFlags{
+		idsOnly: flags.NewBoolFlag(fs, idsOnlyFlagName, "", false,
+			"print only the identifier of each asset (IP, hostname:port, or certificate fingerprint), one per line"),
+		print0: flags.NewBoolFlag(fs, print0FlagName, "", false,
+			"with --ids-only, end each identifier with a NUL byte instead of a newline, as xargs -0 expects"),
+	}
+}
+
+// Value returns the byte that ends each identifier, if only identifiers
+// should be printed. Printing identifiers cannot be combined with streaming
+// or an explicit output format.
+func (f IDsFlags) Value(cmd *cobra.Command, streaming bool) (mo.Option[byte], cenclierrors.CencliError) {
+	none := mo.None[byte]()
+	idsOnly, err := f.idsOnly.Value()
+	if err != nil {
+		return none, err
+	}
+	print0, err := f.print0.Value()
+	if err != nil {
+		return none, err
+	}
+	if !idsOnly {
+		if print0 {
+			return none, cenclierrors.NewUsageError(fmt.Errorf("--%s requires --%s", print0FlagName, idsOnlyFlagName))
+		}
+		return none, nil
+	}
+	if streaming {
+		return none, flags.NewConflictingFlagsError(idsOnlyFlagName, "streaming")
+	}
+	if cmd.Flags().Changed(formatter.OutputFormatFlagName) {
+		return none, flags.NewConflictingFlagsError(idsOnlyFlagName, formatter.OutputFormatFlagName)
+	}
+	if print0 {
+		return mo.Some[byte](0), nil
+	}
+	return mo.Some[byte]('\n'), nil
+}
+
+// PrintIDs prints the identifier of each asset to stdout, each followed by
+// sep (see assets.Identifier). Assets without an identifier are skipped.
+func PrintIDs(items []assets.Asset, sep byte) cenclierrors.CencliError {
+	w := bufio.NewWriter(formatter.Stdout)
+	for _, item := range items {
+		id := assets.Identifier(item)
+		if id == "" {
+			continue
+		}
+		if _, err := w.WriteString(id); err != nil {
+			return cenclierrors.NewCencliError(err)
+		}
+		if err := w.WriteByte(sep); err != nil {
+			return cenclierrors.NewCencliError(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
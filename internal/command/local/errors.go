@@ -0,0 +1,79 @@
+package local
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+type IndexUnavailableError interface {
+	cenclierrors.CencliError
+}
+
+type indexUnavailableError struct {
+	err error
+}
+
+var _ IndexUnavailableError = &indexUnavailableError{}
+
+func newIndexUnavailableError(err error) IndexUnavailableError {
+	return &indexUnavailableError{err: err}
+}
+
+func (e *indexUnavailableError) Error() string {
+	if e.err == nil {
+		return "the local index is kept in the cache directory, but it is not available"
+	}
+	return fmt.Sprintf("failed to open the local index: %v", e.err)
+}
+
+func (e *indexUnavailableError) Title() string { return "Local Index Unavailable" }
+
+func (e *indexUnavailableError) ShouldPrintUsage() bool { return false }
+
+func (e *indexUnavailableError) Unwrap() error { return e.err }
+
+type EmptyIndexError interface {
+	cenclierrors.CencliError
+}
+
+type emptyIndexError struct{}
+
+var _ EmptyIndexError = &emptyIndexError{}
+
+func newEmptyIndexError() EmptyIndexError {
+	return &emptyIndexError{}
+}
+
+func (e *emptyIndexError) Error() string {
+	return "the local index is empty; build it with `censys local index`"
+}
+
+func (e *emptyIndexError) Title() string { return "Empty Local Index" }
+
+func (e *emptyIndexError) ShouldPrintUsage() bool { return false }
+
+type SourceError interface {
+	cenclierrors.CencliError
+}
+
+type sourceError struct {
+	source string
+	err    error
+}
+
+var _ SourceError = &sourceError{}
+
+func newSourceError(source string, err error) SourceError {
+	return &sourceError{source: source, err: err}
+}
+
+func (e *sourceError) Error() string {
+	return fmt.Sprintf("failed to read %s: %v", e.source, e.err)
+}
+
+func (e *sourceError) Title() string { return "Indexing Failed" }
+
+func (e *sourceError) ShouldPrintUsage() bool { return false }
+
+func (e *sourceError) Unwrap() error { return e.err }
@@ -0,0 +1,149 @@
+package local
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/localindex"
+)
+
+type indexCommand struct {
+	*command.BaseCommand
+	flags indexCommandFlags
+}
+
+type indexCommandFlags struct {
+	noSessions flags.BoolFlag
+	rebuild    flags.BoolFlag
+}
+
+var _ command.Command = (*indexCommand)(nil)
+
+func newIndexCommand(ctx *command.Context) *indexCommand {
+	return &indexCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *indexCommand) Use() string { return "index [file...]" }
+
+func (c *indexCommand) Short() string {
+	return "Index recorded sessions, session archives, and exports"
+}
+
+func (c *indexCommand) Long() string {
+	return `Add assets to the local index: those recorded in the sessions of the local store,
+and those in the given files. A file is a session archive, a SQLite export
+(--format sqlite), or JSON, such as a --format json or es-bulk export, saved
+command output, or streamed NDJSON. Gzipped JSON is read too.
+
+An asset that is already indexed is replaced, so re-indexing picks up the latest
+copy. Use --rebuild to start from an empty index.`
+}
+
+func (c *indexCommand) Examples() []string {
+	return []string{
+		"results.db",
+		"--no-sessions incident-42.cencli-session.tar.gz hosts.json.gz",
+		"--rebuild",
+	}
+}
+
+func (c *indexCommand) Args() command.PositionalArgs { return command.MinimumArgs(0) }
+
+func (c *indexCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *indexCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *indexCommand) Init() error {
+	c.flags.noSessions = flags.NewBoolFlag(c.Flags(), "no-sessions", "", false, "do not index the sessions of the local store")
+	c.flags.rebuild = flags.NewBoolFlag(c.Flags(), "rebuild", "", false, "clear the index before indexing")
+	return nil
+}
+
+func (c *indexCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *indexCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	noSessions, err := c.flags.noSessions.Value()
+	if err != nil {
+		return err
+	}
+	rebuild, err := c.flags.rebuild.Value()
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	var docs []localindex.Document
+	sources := 0
+	if !noSessions && c.Store() != nil {
+		sessions, listErr := c.Store().ListSessions(ctx)
+		if listErr != nil {
+			return cenclierrors.NewCencliError(listErr)
+		}
+		for _, session := range sessions {
+			entries, getErr := c.Store().GetSessionEntries(ctx, session.ID)
+			if getErr != nil {
+				return cenclierrors.NewCencliError(getErr)
+			}
+			source := "session " + session.Name
+			for _, entry := range entries {
+				if entry.Response == "" {
+					continue
+				}
+				found, readErr := localindex.DocumentsFromJSON(source, []byte(entry.Response))
+				if readErr != nil {
+					return newSourceError(source, readErr)
+				}
+				docs = append(docs, found...)
+			}
+			sources++
+		}
+	}
+	for _, path := range args {
+		found, readErr := localindex.ReadFile(ctx, path)
+		if readErr != nil {
+			return newSourceError(path, readErr)
+		}
+		docs = append(docs, found...)
+		sources++
+	}
+
+	ix, err := openIndex(c.Context)
+	if err != nil {
+		return err
+	}
+	defer ix.Close()
+	if rebuild {
+		if clearErr := ix.Clear(ctx); clearErr != nil {
+			return newIndexUnavailableError(clearErr)
+		}
+	}
+	indexed, addErr := ix.Add(ctx, docs)
+	if addErr != nil {
+		return cenclierrors.NewCencliError(addErr)
+	}
+	total, countErr := ix.Count(ctx)
+	if countErr != nil {
+		return cenclierrors.NewCencliError(countErr)
+	}
+	formatter.Printf(formatter.Stdout, "✅ Indexed %s from %s; the index holds %s\n",
+		plural(indexed, "asset"), plural(sources, "source"), plural(total, "asset"))
+	return nil
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
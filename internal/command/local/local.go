@@ -0,0 +1,94 @@
+package local
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/localindex"
+)
+
+// Command is the parent local command that groups the local index subcommands.
+type Command struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*Command)(nil)
+
+// NewLocalCommand creates a new local command with all subcommands.
+func NewLocalCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return "local"
+}
+
+func (c *Command) Short() string {
+	return "Search assets you already fetched, offline"
+}
+
+func (c *Command) Long() string {
+	return `Search assets you already fetched, offline and without spending credits.
+
+"censys local index" builds a full-text index of the assets recorded in sessions,
+and of session archives and exports (SQLite, JSON, or es-bulk files). "censys local
+search" then searches their banners, HTML titles, certificate names and DNs,
+software, and host details.
+
+The index is kept in the cache directory and can be rebuilt at any time.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"index",
+		"index results.db incident-42.cencli-session.tar.gz",
+		`search "nginx 1.18"`,
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	return c.AddSubCommands(
+		newIndexCommand(c.Context),
+		newSearchCommand(c.Context),
+	)
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	// Parent command shows help when run without subcommands
+	if err := cmd.Help(); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
+
+// openIndex opens the local index in the cache directory.
+func openIndex(ctx *command.Context) (*localindex.Index, cenclierrors.CencliError) {
+	cacheDir := ctx.Dirs().Cache
+	if cacheDir == "" {
+		return nil, newIndexUnavailableError(nil)
+	}
+	ix, err := localindex.Open(filepath.Join(cacheDir, localindex.FileName))
+	if err != nil {
+		return nil, newIndexUnavailableError(err)
+	}
+	return ix, nil
+}
@@ -0,0 +1,82 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/appdirs"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/localindex"
+	"github.com/censys/cencli/internal/store"
+)
+
+// runLocal executes `local <args>` against st, with the index in cacheDir.
+func runLocal(t *testing.T, st store.Store, cacheDir string, args ...string) (string, error) {
+	t.Helper()
+	viper.Reset()
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	cmdContext := command.NewCommandContext(cfg, st, command.WithAppDirs(appdirs.Dirs{Cache: cacheDir}))
+	rootCmd, cerr := command.RootCommandToCobra(NewLocalCommand(cmdContext))
+	require.NoError(t, cerr)
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs(args)
+	cmdErr := rootCmd.Execute()
+	return stdout.String(), cmdErr
+}
+
+func TestLocalIndexAndSearch(t *testing.T) {
+	ctx := context.Background()
+	st, err := store.New(t.TempDir())
+	require.NoError(t, err)
+	cacheDir := t.TempDir()
+
+	_, err = runLocal(t, st, cacheDir, "search", "nginx")
+	var empty EmptyIndexError
+	require.ErrorAs(t, err, &empty)
+
+	session, err := st.StartSession(ctx, "incident-42")
+	require.NoError(t, err)
+	response := `[{"ip":"10.0.0.1","services":[{"port":80,"protocol":"HTTP","banner":"Server: nginx/1.18.0 (Ubuntu)"}]},` +
+		`{"ip":"10.0.0.2","services":[{"port":22,"protocol":"SSH","banner":"SSH-2.0-OpenSSH_9.6"}]}]`
+	_, err = st.AddSessionEntry(ctx, session.ID, &store.SessionEntry{
+		Kind: store.SessionEntryKindCommand, Command: "censys search x", Response: response,
+	})
+	require.NoError(t, err)
+
+	stdout, err := runLocal(t, st, cacheDir, "index")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Indexed 2 assets from 1 source; the index holds 2 assets")
+
+	stdout, err = runLocal(t, st, cacheDir, "search", "nginx 1.18")
+	require.NoError(t, err)
+	var hits []localindex.Hit
+	require.NoError(t, json.Unmarshal([]byte(stdout), &hits))
+	require.Len(t, hits, 1)
+	require.Equal(t, "10.0.0.1", hits[0].ID)
+	require.Equal(t, "session incident-42", hits[0].Source)
+
+	stdout, err = runLocal(t, st, cacheDir, "search", "openssh", "-O", "short")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "host 10.0.0.2 (session incident-42)")
+	require.Contains(t, stdout, "[OpenSSH]")
+
+	_, err = runLocal(t, st, cacheDir, "search", "openssh", "--type", "device")
+	require.ErrorContains(t, err, `unsupported --type "device"`)
+
+	_, err = runLocal(t, st, cacheDir, "index", "--no-sessions", "missing.json")
+	var sourceErr SourceError
+	require.ErrorAs(t, err, &sourceErr)
+}
@@ -0,0 +1,157 @@
+package local
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/localindex"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// assetTypes are the values accepted by --type.
+var assetTypes = []assets.AssetType{assets.AssetTypeHost, assets.AssetTypeCertificate, assets.AssetTypeWebProperty}
+
+type searchCommand struct {
+	*command.BaseCommand
+	flags searchCommandFlags
+	query localindex.Query
+	hits  []localindex.Hit
+}
+
+type searchCommandFlags struct {
+	limit     flags.IntegerFlag
+	assetType flags.StringFlag
+}
+
+var _ command.Command = (*searchCommand)(nil)
+
+func newSearchCommand(ctx *command.Context) *searchCommand {
+	return &searchCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *searchCommand) Use() string { return "search <query>" }
+
+func (c *searchCommand) Short() string {
+	return "Search the local index"
+}
+
+func (c *searchCommand) Long() string {
+	return `Search the assets in the local index, best match first. No request is made.
+
+Every term of the query must match, in any of the indexed text of an asset:
+banners, HTML titles, certificate names and DNs, software, and host details
+(IP, AS, location, reverse DNS, and service protocols). "Quoted phrases" match
+consecutive words, and a trailing * matches words with that prefix.
+
+Each hit has the asset as it was when indexed, where it was found, and a
+snippet of the best matching text.`
+}
+
+func (c *searchCommand) Examples() []string {
+	return []string{
+		`"nginx 1.18"`,
+		`"\"Apache Tomcat\"" --type host`,
+		`"CN=example*" -O short`,
+	}
+}
+
+func (c *searchCommand) Args() command.PositionalArgs { return command.ExactArgs(1) }
+
+func (c *searchCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeData
+}
+
+func (c *searchCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *searchCommand) Init() error {
+	c.flags.limit = flags.NewIntegerFlag(c.Flags(), false, "limit", "n", mo.Some[int64](localindex.DefaultLimit),
+		"maximum number of hits", mo.Some[int64](1), mo.None[int64]())
+	typeNames := make([]string, len(assetTypes))
+	for i, t := range assetTypes {
+		typeNames[i] = t.String()
+	}
+	c.flags.assetType = flags.NewStringFlag(c.Flags(), false, "type", "t", "",
+		fmt.Sprintf("only return assets of this type (%s)", strings.Join(typeNames, "|")))
+	return nil
+}
+
+func (c *searchCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	limit, err := c.flags.limit.Value()
+	if err != nil {
+		return err
+	}
+	rawType, err := c.flags.assetType.Value()
+	if err != nil {
+		return err
+	}
+	c.query = localindex.Query{Text: args[0], Limit: int(limit.OrElse(localindex.DefaultLimit))}
+	if rawType != "" {
+		for _, t := range assetTypes {
+			if strings.EqualFold(rawType, t.String()) {
+				c.query.Type = t
+			}
+		}
+		if c.query.Type == "" {
+			return cenclierrors.NewUsageError(fmt.Errorf("unsupported --type %q", rawType))
+		}
+	}
+	return nil
+}
+
+func (c *searchCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	ix, err := openIndex(c.Context)
+	if err != nil {
+		return err
+	}
+	defer ix.Close()
+	total, countErr := ix.Count(cmd.Context())
+	if countErr != nil {
+		return cenclierrors.NewCencliError(countErr)
+	}
+	if total == 0 {
+		return newEmptyIndexError()
+	}
+	hits, searchErr := ix.Search(cmd.Context(), c.query)
+	if searchErr != nil {
+		if errors.Is(searchErr, localindex.ErrEmptyQuery) {
+			return cenclierrors.NewUsageError(searchErr)
+		}
+		return cenclierrors.NewCencliError(searchErr)
+	}
+	c.hits = hits
+	return c.PrintData(c, c.hits)
+}
+
+func (c *searchCommand) RenderShort() cenclierrors.CencliError {
+	if len(c.hits) == 0 {
+		formatter.Println(formatter.Stdout, styles.GlobalStyles.Comment.Render("No indexed assets match the query."))
+		return nil
+	}
+	var sb strings.Builder
+	for i, hit := range c.hits {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(styles.GlobalStyles.Signature.Render(fmt.Sprintf("%s %s", hit.Type, hit.ID)))
+		sb.WriteString(styles.GlobalStyles.Comment.Render(fmt.Sprintf(" (%s)", hit.Source)))
+		sb.WriteString("\n")
+		for _, line := range strings.Split(hit.Snippet, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				sb.WriteString("  " + line + "\n")
+			}
+		}
+	}
+	formatter.Printf(formatter.Stdout, "%s", sb.String())
+	return nil
+}
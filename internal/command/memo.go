@@ -0,0 +1,34 @@
+package command
+
+import (
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+)
+
+// startMemo wraps the client so that the API results of the command are
+// remembered, up to memo-size results, and identical lookups are made once.
+// Services are created from the client on demand, after this runs.
+func (c *Context) startMemo() {
+	if c.config.MemoSize <= 0 || c.censysClient == nil || c.memo != nil {
+		return
+	}
+	c.memo = client.NewMemo(c.config.MemoSize)
+	c.censysClient = client.NewMemoClient(c.censysClient, c.memo)
+}
+
+// FinishMemo logs how many lookups of the command were answered from the
+// memo, at debug level. It is a no-op if no lookup was made.
+func (c *Context) FinishMemo() {
+	if c.memo == nil {
+		return
+	}
+	stats := c.memo.Stats()
+	if stats.Hits+stats.Misses == 0 {
+		return
+	}
+	c.logger.Debug("memoized API lookups",
+		"hits", stats.Hits,
+		"misses", stats.Misses,
+		"evictions", stats.Evictions,
+		"entries", stats.Entries,
+	)
+}
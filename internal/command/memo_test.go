@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	"github.com/censys/cencli/internal/config"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+)
+
+func TestStartMemo(t *testing.T) {
+	ctx := context.Background()
+	none := mo.None[string]()
+	noTime := mo.None[time.Time]()
+
+	t.Run("identical lookups are made once", func(t *testing.T) {
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().GetHosts(ctx, none, []string{"8.8.8.8"}, noTime).
+			Return(client.Result[[]components.Host]{}, nil).Times(1)
+
+		c := NewCommandContext(cfg, nil)
+		c.SetCensysClient(inner)
+		c.startMemo()
+		c.startMemo()
+		for range 2 {
+			_, err := c.censysClient.GetHosts(ctx, none, []string{"8.8.8.8"}, noTime)
+			require.NoError(t, err)
+		}
+		require.Equal(t, client.MemoStats{Hits: 1, Misses: 1, Entries: 1}, c.memo.Stats())
+		c.FinishMemo()
+	})
+
+	t.Run("memo-size 0 disables the memo", func(t *testing.T) {
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+		cfg.MemoSize = 0
+		inner := mocks.NewMockClient(gomock.NewController(t))
+
+		c := NewCommandContext(cfg, nil)
+		c.SetCensysClient(inner)
+		c.startMemo()
+		require.Nil(t, c.memo)
+		require.Same(t, inner, c.censysClient)
+		c.FinishMemo()
+	})
+}
@@ -0,0 +1,237 @@
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/store"
+)
+
+type addCommand struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*addCommand)(nil)
+
+func newAddCommand(ctx *command.Context) *addCommand {
+	return &addCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *addCommand) Use() string { return "add <asset> <text>" }
+
+func (c *addCommand) Short() string {
+	return "Add a note about a host, certificate, or web property"
+}
+
+func (c *addCommand) Long() string {
+	return `Add a note about a host, certificate, or web property. If a session is recording,
+the note is also added to the session.`
+}
+
+func (c *addCommand) Examples() []string {
+	return []string{
+		`1.2.3.4 "beacon every 60s"`,
+		`example.com:8443 "phishing kit login page"`,
+	}
+}
+
+func (c *addCommand) Args() command.PositionalArgs { return command.ExactArgs(2) }
+
+func (c *addCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *addCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *addCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *addCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	asset, assetType, err := canonicalAsset(args[0])
+	if err != nil {
+		return err
+	}
+	text := strings.TrimSpace(args[1])
+	if text == "" {
+		return cenclierrors.NewUsageError(errors.New("note must not be empty"))
+	}
+	note := &store.Note{Asset: asset, AssetType: assetType.String(), Text: text, Author: c.author()}
+
+	session, activeErr := st.GetActiveSession(cmd.Context())
+	switch {
+	case activeErr == nil:
+		note.Session = session.Name
+	case !errors.Is(activeErr, store.ErrNoActiveSession):
+		return cenclierrors.NewCencliError(activeErr)
+	}
+	note, addErr := st.AddNote(cmd.Context(), note)
+	if addErr != nil {
+		return cenclierrors.NewCencliError(addErr)
+	}
+	if session == nil {
+		formatter.Printf(formatter.Stdout, "✅ Added note %d to %s\n", note.ID, note.Asset)
+		return nil
+	}
+	if _, addErr := st.AddSessionEntry(cmd.Context(), session.ID, &store.SessionEntry{
+		Kind: store.SessionEntryKindNote,
+		Note: fmt.Sprintf("%s: %s", note.Asset, note.Text),
+	}); addErr != nil {
+		return cenclierrors.NewCencliError(addErr)
+	}
+	formatter.Printf(formatter.Stdout, "✅ Added note %d to %s and to session [%s]\n", note.ID, note.Asset, session.Name)
+	return nil
+}
+
+type listCommand struct {
+	*command.BaseCommand
+	flags struct {
+		asset   flags.StringFlag
+		search  flags.StringFlag
+		session flags.StringFlag
+	}
+	entries []Entry
+}
+
+var _ command.Command = (*listCommand)(nil)
+
+func newListCommand(ctx *command.Context) *listCommand {
+	return &listCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *listCommand) Use() string { return "list" }
+
+func (c *listCommand) Short() string {
+	return "List and search notes"
+}
+
+func (c *listCommand) Examples() []string {
+	return []string{
+		"--asset 1.2.3.4",
+		"--search beacon",
+		"--session incident-42 -O json",
+	}
+}
+
+func (c *listCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *listCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *listCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *listCommand) Init() error {
+	c.flags.asset = flags.NewStringFlag(c.Flags(), false, "asset", "a", "", "only list the notes about this host, certificate, or web property")
+	c.flags.search = flags.NewStringFlag(c.Flags(), false, "search", "s", "", "only list the notes whose text or author contains this, ignoring case")
+	c.flags.session = flags.NewStringFlag(c.Flags(), false, "session", "", "", "only list the notes added while this session was recording")
+	return nil
+}
+
+func (c *listCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *listCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	var filter store.NotesFilter
+	asset, err := c.flags.asset.Value()
+	if err != nil {
+		return err
+	}
+	if asset != "" {
+		if filter.Asset, _, err = canonicalAsset(asset); err != nil {
+			return err
+		}
+	}
+	if filter.Search, err = c.flags.search.Value(); err != nil {
+		return err
+	}
+	if filter.Session, err = c.flags.session.Value(); err != nil {
+		return err
+	}
+	notes, listErr := st.ListNotes(cmd.Context(), filter)
+	if listErr != nil {
+		return cenclierrors.NewCencliError(listErr)
+	}
+	c.entries = newEntries(notes)
+	return c.PrintData(c, c.entries)
+}
+
+func (c *listCommand) RenderShort() cenclierrors.CencliError {
+	formatter.Println(formatter.Stdout, renderList(c.entries))
+	return nil
+}
+
+type removeCommand struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*removeCommand)(nil)
+
+func newRemoveCommand(ctx *command.Context) *removeCommand {
+	return &removeCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *removeCommand) Use() string { return "rm <id>" }
+
+func (c *removeCommand) Short() string {
+	return "Remove a note"
+}
+
+func (c *removeCommand) Long() string {
+	return `Remove a note by its ID, as listed by "censys notes list". A copy of the note that was
+added to a session stays in the session.`
+}
+
+func (c *removeCommand) Args() command.PositionalArgs { return command.ExactArgs(1) }
+
+func (c *removeCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *removeCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *removeCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *removeCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	id, parseErr := strconv.ParseInt(args[0], 10, 64)
+	if parseErr != nil || id < 1 {
+		return cenclierrors.NewUsageError(fmt.Errorf("invalid note ID %q: must be a positive integer", args[0]))
+	}
+	if deleteErr := st.DeleteNote(cmd.Context(), id); deleteErr != nil {
+		if errors.Is(deleteErr, store.ErrNoteNotFound) {
+			return newNoteNotFoundError(args[0])
+		}
+		return cenclierrors.NewCencliError(deleteErr)
+	}
+	formatter.Printf(formatter.Stdout, "✅ Removed note %d\n", id)
+	return nil
+}
@@ -0,0 +1,80 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/store"
+)
+
+// Entry is a note as it is listed.
+type Entry struct {
+	ID        int64     `json:"id"`
+	Asset     string    `json:"asset"`
+	AssetType string    `json:"asset_type"`
+	Text      string    `json:"text"`
+	Author    string    `json:"author,omitempty"`
+	Session   string    `json:"session,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newEntries(notes []*store.Note) []Entry {
+	res := make([]Entry, len(notes))
+	for i, n := range notes {
+		res[i] = Entry{
+			ID:        n.ID,
+			Asset:     n.Asset,
+			AssetType: n.AssetType,
+			Text:      n.Text,
+			Author:    n.Author,
+			Session:   n.Session,
+			CreatedAt: n.CreatedAt,
+		}
+	}
+	return res
+}
+
+// canonicalAsset returns the ID that notes about the asset raw are kept
+// under, so that, e.g., ::ffff:1.2.3.4 and 1.2.3.4, or Example.com and
+// example.com:443, refer to the same asset.
+func canonicalAsset(raw string) (string, assets.AssetType, cenclierrors.CencliError) {
+	classifier := assets.NewAssetClassifier(raw)
+	assetType, err := classifier.AssetType()
+	if err != nil {
+		return "", assetType, err
+	}
+	switch assetType {
+	case assets.AssetTypeHost:
+		return assets.NormalizeIP(classifier.HostIDs()[0].String()), assetType, nil
+	case assets.AssetTypeCertificate:
+		return strings.ToLower(classifier.CertificateIDs()[0].String()), assetType, nil
+	default:
+		w := classifier.WebPropertyIDs()[0]
+		return fmt.Sprintf("%s:%d", strings.ToLower(w.Hostname), w.Port), assetType, nil
+	}
+}
+
+// author returns the name notes are added under: the author setting, or the
+// name of the current user.
+func (c *addCommand) author() string {
+	if author := strings.TrimSpace(c.Config().Author); author != "" {
+		return author
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// requireStore returns the store, or an error if it is not available.
+func requireStore(st store.Store) (store.Store, cenclierrors.CencliError) {
+	if st == nil {
+		return nil, newStoreUnavailableError()
+	}
+	return st, nil
+}
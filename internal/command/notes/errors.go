@@ -0,0 +1,49 @@
+package notes
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+type StoreUnavailableError interface {
+	cenclierrors.CencliError
+}
+
+type storeUnavailableError struct{}
+
+var _ StoreUnavailableError = &storeUnavailableError{}
+
+func newStoreUnavailableError() StoreUnavailableError {
+	return &storeUnavailableError{}
+}
+
+func (e *storeUnavailableError) Error() string {
+	return "notes are kept in the local store, but it is not available"
+}
+
+func (e *storeUnavailableError) Title() string { return "Store Unavailable" }
+
+func (e *storeUnavailableError) ShouldPrintUsage() bool { return false }
+
+type NoteNotFoundError interface {
+	cenclierrors.CencliError
+}
+
+type noteNotFoundError struct {
+	id string
+}
+
+var _ NoteNotFoundError = &noteNotFoundError{}
+
+func newNoteNotFoundError(id string) NoteNotFoundError {
+	return &noteNotFoundError{id: id}
+}
+
+func (e *noteNotFoundError) Error() string {
+	return fmt.Sprintf("no note with ID %s; use `censys notes list` to see the IDs of notes", e.id)
+}
+
+func (e *noteNotFoundError) Title() string { return "Note Not Found" }
+
+func (e *noteNotFoundError) ShouldPrintUsage() bool { return false }
@@ -0,0 +1,78 @@
+package notes
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// Command is the parent notes command that groups notes subcommands.
+type Command struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*Command)(nil)
+
+// NewNotesCommand creates a new notes command with all subcommands.
+func NewNotesCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return "notes"
+}
+
+func (c *Command) Short() string {
+	return "Annotate hosts, certificates, and web properties"
+}
+
+func (c *Command) Long() string {
+	return `Annotate hosts, certificates, and web properties with what you observed about them.
+
+Notes are kept in the local store with when they were added and by whom (the
+author setting, or the name of the current user). A note added while a session
+is recording is also added to the session, so it is included when the session
+is exported. Reports include the notes about each host.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		`add 1.2.3.4 "beacon every 60s"`,
+		"list --asset 1.2.3.4",
+		"list --search beacon",
+		"rm 3",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	return c.AddSubCommands(
+		newAddCommand(c.Context),
+		newListCommand(c.Context),
+		newRemoveCommand(c.Context),
+	)
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	// Parent command shows help when run without subcommands
+	if err := cmd.Help(); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package notes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/store"
+)
+
+// runNotes executes `notes <args>` against st.
+func runNotes(t *testing.T, st store.Store, args ...string) (string, error) {
+	t.Helper()
+	viper.Reset()
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	cmdContext := command.NewCommandContext(cfg, st)
+	rootCmd, cerr := command.RootCommandToCobra(NewNotesCommand(cmdContext))
+	require.NoError(t, cerr)
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs(args)
+	cmdErr := rootCmd.Execute()
+	return stdout.String(), cmdErr
+}
+
+func newStore(t *testing.T) store.Store {
+	t.Helper()
+	st, err := store.New(t.TempDir())
+	require.NoError(t, err)
+	return st
+}
+
+func listNotes(t *testing.T, st store.Store, args ...string) []Entry {
+	t.Helper()
+	stdout, err := runNotes(t, st, append([]string{"list", "-O", "json"}, args...)...)
+	require.NoError(t, err)
+	var entries []Entry
+	require.NoError(t, json.Unmarshal([]byte(stdout), &entries))
+	return entries
+}
+
+func TestNotes(t *testing.T) {
+	t.Setenv("CENCLI_AUTHOR", "alice")
+	st := newStore(t)
+
+	stdout, err := runNotes(t, st, "add", "::ffff:1.2.3.4", "beacon every 60s")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Added note 1 to 1.2.3.4")
+
+	_, err = st.StartSession(context.Background(), "incident-42")
+	require.NoError(t, err)
+	stdout, err = runNotes(t, st, "add", "Example.com", "phishing kit login page")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Added note 2 to example.com:443 and to session [incident-42]")
+
+	session, err := st.GetSessionByName(context.Background(), "incident-42")
+	require.NoError(t, err)
+	entries, err := st.GetSessionEntries(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, store.SessionEntryKindNote, entries[0].Kind)
+	require.Equal(t, "example.com:443: phishing kit login page", entries[0].Note)
+
+	all := listNotes(t, st)
+	require.Len(t, all, 2)
+	require.Equal(t, "1.2.3.4", all[0].Asset)
+	require.Equal(t, assets.AssetTypeHost.String(), all[0].AssetType)
+	require.Equal(t, "alice", all[0].Author)
+	require.Empty(t, all[0].Session)
+	require.Equal(t, "incident-42", all[1].Session)
+
+	byAsset := listNotes(t, st, "--asset", "1.2.3.4")
+	require.Len(t, byAsset, 1)
+	require.Equal(t, "beacon every 60s", byAsset[0].Text)
+
+	require.Len(t, listNotes(t, st, "--search", "PHISHING"), 1)
+	require.Len(t, listNotes(t, st, "--session", "incident-42"), 1)
+	require.Empty(t, listNotes(t, st, "--asset", "5.6.7.8"))
+
+	stdout, err = runNotes(t, st, "list")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "beacon every 60s")
+	require.Contains(t, stdout, "example.com:443")
+
+	stdout, err = runNotes(t, st, "rm", "1")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Removed note 1")
+	require.Len(t, listNotes(t, st), 1)
+
+	_, err = runNotes(t, st, "rm", "1")
+	var notFound NoteNotFoundError
+	require.ErrorAs(t, err, &notFound)
+}
+
+func TestNotesErrors(t *testing.T) {
+	st := newStore(t)
+
+	_, err := runNotes(t, st, "add", "not an asset", "text")
+	var invalid assets.InvalidAssetIDError
+	require.ErrorAs(t, err, &invalid)
+
+	_, err = runNotes(t, st, "add", "1.2.3.4", "  ")
+	require.ErrorContains(t, err, "note must not be empty")
+
+	_, err = runNotes(t, st, "rm", "abc")
+	require.ErrorContains(t, err, "must be a positive integer")
+
+	_, err = runNotes(t, nil, "list")
+	var unavailable StoreUnavailableError
+	require.ErrorAs(t, err, &unavailable)
+}
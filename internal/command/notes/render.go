@@ -0,0 +1,31 @@
+package notes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+func renderList(entries []Entry) string {
+	if len(entries) == 0 {
+		return styles.GlobalStyles.Comment.Render("No notes found. Add one with `censys notes add <asset> <text>`.")
+	}
+	columns := []rawtable.Column[Entry]{
+		{Title: "ID", String: func(e Entry) string { return fmt.Sprintf("%d", e.ID) }, Priority: 2, NoTruncate: true},
+		{Title: "Asset", String: func(e Entry) string { return e.Asset }, Priority: 2, NoTruncate: true},
+		{Title: "Added", String: func(e Entry) string { return formatter.FormatShortTime(e.CreatedAt) }},
+		{Title: "Author", String: func(e Entry) string { return e.Author }},
+		{Title: "Session", String: func(e Entry) string { return e.Session }},
+		{Title: "Note", String: func(e Entry) string { return e.Text }, Priority: 1},
+	}
+	table := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[Entry](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[Entry](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[Entry](formatter.TableWidth()),
+	)
+	return strings.TrimRight(table.Render(entries), "\n")
+}
@@ -63,7 +63,7 @@ func (c *Command) showOrgCredits(result appcredits.OrganizationCreditDetailsResu
 
 			if exp.ExpirationDate.IsPresent() {
 				expDate := exp.ExpirationDate.MustGet()
-				expStr := fmt.Sprintf("(expires %s)", expDate.Format("2006-01-02"))
+				expStr := fmt.Sprintf("(expires %s)", formatter.FormatDate(expDate))
 				fmt.Fprintf(&out, " %s", styles.GlobalStyles.Comment.Render(expStr))
 			}
 			out.WriteString("\n")
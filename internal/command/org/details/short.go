@@ -36,7 +36,7 @@ func (c *Command) showOrgDetails(result organizations.OrganizationDetailsResult)
 	if data.CreatedAt.IsPresent() {
 		createdLabel := fmt.Sprintf("%-8s", "Created:")
 		createdLabelStyled := styles.GlobalStyles.Primary.Render(createdLabel)
-		createdValue := styles.GlobalStyles.Comment.Render(data.CreatedAt.MustGet().Format("2006-01-02 15:04:05 MST"))
+		createdValue := styles.GlobalStyles.Comment.Render(formatter.FormatLongTime(data.CreatedAt.MustGet()))
 		fmt.Fprintf(&out, "  %s %s\n", createdLabelStyled, createdValue)
 	}
 
@@ -30,6 +30,8 @@ func (c *Command) showRawTable(result organizations.OrganizationMembersResult) c
 			Style: func(s string, m organizations.OrganizationMember) string {
 				return styles.NewStyle(styles.ColorTeal).Render(s)
 			},
+			Priority:   2,
+			NoTruncate: true,
 		},
 		{
 			Title:  "Name",
@@ -37,6 +39,7 @@ func (c *Command) showRawTable(result organizations.OrganizationMembersResult) c
 			Style: func(s string, m organizations.OrganizationMember) string {
 				return styles.NewStyle(styles.ColorOffWhite).Render(s)
 			},
+			Priority: 1,
 		},
 		{
 			Title: "Roles",
@@ -49,12 +52,13 @@ func (c *Command) showRawTable(result organizations.OrganizationMembersResult) c
 			Style: func(s string, m organizations.OrganizationMember) string {
 				return styles.NewStyle(styles.ColorSage).Render(s)
 			},
+			Priority: 1,
 		},
 		{
 			Title: "First Login",
 			String: func(m organizations.OrganizationMember) string {
 				if m.FirstLoginTime.IsPresent() {
-					return m.FirstLoginTime.MustGet().Format("2006-01-02 15:04")
+					return formatter.FormatShortTime(m.FirstLoginTime.MustGet())
 				}
 				return "Never"
 			},
@@ -66,7 +70,7 @@ func (c *Command) showRawTable(result organizations.OrganizationMembersResult) c
 			Title: "Last Login",
 			String: func(m organizations.OrganizationMember) string {
 				if m.LatestLoginTime.IsPresent() {
-					return m.LatestLoginTime.MustGet().Format("2006-01-02 15:04")
+					return formatter.FormatShortTime(m.LatestLoginTime.MustGet())
 				}
 				return "Never"
 			},
@@ -80,6 +84,7 @@ func (c *Command) showRawTable(result organizations.OrganizationMembersResult) c
 		columns,
 		rawtable.WithHeaderStyle[organizations.OrganizationMember](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
 		rawtable.WithStylesDisabled[organizations.OrganizationMember](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[organizations.OrganizationMember](formatter.TableWidth()),
 	)
 
 	title := styles.GlobalStyles.Signature.Bold(true).Render(fmt.Sprintf("Organization Members (%d)", len(result.Data.Members)))
@@ -110,11 +115,11 @@ func (c *Command) showInteractiveTable(result organizations.OrganizationMembersR
 			}
 			firstLogin := "Never"
 			if m.FirstLoginTime.IsPresent() {
-				firstLogin = m.FirstLoginTime.MustGet().Format("2006-01-02 15:04")
+				firstLogin = formatter.FormatShortTime(m.FirstLoginTime.MustGet())
 			}
 			lastLogin := "Never"
 			if m.LatestLoginTime.IsPresent() {
-				lastLogin = m.LatestLoginTime.MustGet().Format("2006-01-02 15:04")
+				lastLogin = formatter.FormatShortTime(m.LatestLoginTime.MustGet())
 			}
 			return []string{email, name, roles, firstLogin, lastLogin}
 		},
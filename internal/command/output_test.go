@@ -2,6 +2,7 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/samber/mo"
@@ -14,7 +15,10 @@ import (
 	storemocks "github.com/censys/cencli/gen/store/mocks"
 	"github.com/censys/cencli/internal/config"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
 	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/metrics"
+	"github.com/censys/cencli/internal/store"
 )
 
 // TestOutputFormatBinding tests that output format flag bindings work correctly
@@ -479,3 +483,99 @@ func TestMultipleCommandsSequentially(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestPrintAppResponseMeta(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{Status: 200, Headers: map[string]string{}, RetryCount: 1}
+	// run prints meta from a command run with args, with a collector that saw
+	// two requests and three retries
+	run := func(t *testing.T, args ...string) string {
+		t.Helper()
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+
+		collector := metrics.NewCollector()
+		collector.ObserveCall("search", 0, 3, 200, false)
+		collector.ObserveCall("search", 0, 2, 200, false)
+		cmdContext := NewCommandContext(cfg, nil, WithMetrics(collector))
+		cmd := newTestCommand(cmdContext)
+		cmd.runFn = func(*cobra.Command, []string) cenclierrors.CencliError {
+			cmdContext.PrintAppResponseMeta(meta)
+			return nil
+		}
+		rootCmd, cerr := RootCommandToCobra(cmd)
+		require.NoError(t, cerr)
+		require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+		var stderr bytes.Buffer
+		formatter.Stdout = &bytes.Buffer{}
+		formatter.Stderr = &stderr
+		rootCmd.SetArgs(args)
+		require.NoError(t, rootCmd.Execute())
+		return stderr.String()
+	}
+
+	t.Run("status line counts the retries of the run", func(t *testing.T) {
+		out := run(t)
+		assert.Contains(t, out, "retries: 3 (attempts: 5)")
+		assert.Contains(t, out, "credits: ~1")
+	})
+
+	t.Run("quiet", func(t *testing.T) {
+		assert.Empty(t, run(t, "--quiet"))
+	})
+
+	t.Run("meta-json prints even when quiet", func(t *testing.T) {
+		out := run(t, "--meta-json", "--quiet")
+		var telemetry responsemeta.Telemetry
+		require.NoError(t, json.Unmarshal([]byte(out), &telemetry))
+		assert.Equal(t, uint64(5), telemetry.Attempts)
+		assert.Equal(t, uint64(3), telemetry.Retries)
+		assert.Equal(t, 200, telemetry.Status)
+	})
+}
+
+func TestRedact(t *testing.T) {
+	run := func(t *testing.T, args ...string) (string, string) {
+		t.Helper()
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+		st, stErr := store.New(t.TempDir())
+		require.NoError(t, stErr)
+
+		cmd := newTestCommand(NewCommandContext(cfg, st))
+		cmd.runFn = func(*cobra.Command, []string) cenclierrors.CencliError {
+			if err := cmd.PrintData(cmd, map[string]any{"ip": "203.0.113.5", "org_id": "a7b3c9d2-1111-2222-3333-444455556666"}); err != nil {
+				return err
+			}
+			formatter.Println(formatter.Stderr, "warning: mail.example.com timed out")
+			return nil
+		}
+		rootCmd, cerr := RootCommandToCobra(cmd)
+		require.NoError(t, cerr)
+		require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+		var stdout, stderr bytes.Buffer
+		formatter.Stdout = &stdout
+		formatter.Stderr = &stderr
+		rootCmd.SetArgs(args)
+		require.NoError(t, rootCmd.Execute())
+		return stdout.String(), stderr.String()
+	}
+
+	t.Run("output is masked with --redact", func(t *testing.T) {
+		stdout, stderr := run(t, "--redact", "--output-format", "yaml")
+		assert.Contains(t, stdout, "ip: 203.")
+		assert.NotContains(t, stdout, "203.0.113.5")
+		assert.NotContains(t, stdout, "a7b3c9d2-1111-2222-3333-444455556666")
+		assert.NotContains(t, stderr, "mail.example.com")
+		assert.Contains(t, stderr, ".com timed out")
+	})
+
+	t.Run("output is kept without --redact", func(t *testing.T) {
+		stdout, stderr := run(t, "--output-format", "yaml")
+		assert.Contains(t, stdout, "203.0.113.5")
+		assert.Contains(t, stderr, "mail.example.com")
+	})
+}
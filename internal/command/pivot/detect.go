@@ -0,0 +1,95 @@
+package pivot
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/domain/query"
+)
+
+// fingerprintType is a kind of service fingerprint that can be pivoted on.
+type fingerprintType string
+
+const (
+	fingerprintJARM       fingerprintType = "jarm"
+	fingerprintJA4S       fingerprintType = "ja4s"
+	fingerprintJA4T       fingerprintType = "ja4t"
+	fingerprintBannerHash fingerprintType = "banner-hash"
+)
+
+// fingerprintTypes lists the supported types in the order they are detected.
+var fingerprintTypes = []fingerprintType{fingerprintJARM, fingerprintJA4S, fingerprintJA4T, fingerprintBannerHash}
+
+var (
+	// jarmPattern matches a JARM fingerprint: 30 hex characters of cipher and
+	// version choices followed by a 32 character truncated SHA-256.
+	jarmPattern = regexp.MustCompile(`^[0-9a-f]{62}$`)
+	// ja4sPattern matches a JA4S fingerprint, e.g. t130200_1301_234ea6891581.
+	ja4sPattern = regexp.MustCompile(`^[tqd][0-9a-z]{2}[0-9]{2}[0-9a-z]{2}_[0-9a-f]{4}_[0-9a-f]{12}$`)
+	// ja4tPattern matches a JA4T/JA4TS fingerprint, e.g. 65535_2-4-8-1-3_1460_7.
+	ja4tPattern = regexp.MustCompile(`^[0-9]+_[0-9-]+_[0-9]+_[0-9]+$`)
+	// bannerHashPattern matches a SHA-256 banner hash.
+	bannerHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+// field returns the host field that holds fingerprints of this type.
+func (t fingerprintType) field() string {
+	switch t {
+	case fingerprintJARM:
+		return "host.services.jarm.fingerprint"
+	case fingerprintJA4S:
+		return "host.services.tls.ja4s"
+	case fingerprintJA4T:
+		return "host.services.ja4tscan.fingerprint"
+	case fingerprintBannerHash:
+		return "host.services.banner_hash_sha256"
+	default:
+		return ""
+	}
+}
+
+func (t fingerprintType) matches(value string) bool {
+	switch t {
+	case fingerprintJARM:
+		return jarmPattern.MatchString(value)
+	case fingerprintJA4S:
+		return ja4sPattern.MatchString(value)
+	case fingerprintJA4T:
+		return ja4tPattern.MatchString(value)
+	case fingerprintBannerHash:
+		return bannerHashPattern.MatchString(value)
+	default:
+		return false
+	}
+}
+
+// parseFingerprintType parses a --type value.
+func parseFingerprintType(s string) (fingerprintType, bool) {
+	for _, t := range fingerprintTypes {
+		if strings.EqualFold(s, string(t)) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// detectFingerprintType returns the type of fingerprint by its shape.
+func detectFingerprintType(value string) (fingerprintType, bool) {
+	for _, t := range fingerprintTypes {
+		if t.matches(value) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// normalizeFingerprint trims the value and lowercases it; all supported
+// fingerprints are lowercase.
+func normalizeFingerprint(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// pivotQuery returns the CenQL query for hosts with a service with the fingerprint.
+func pivotQuery(t fingerprintType, value string) string {
+	return query.Field(t.field()).Eq(value).String()
+}
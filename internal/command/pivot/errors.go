@@ -0,0 +1,60 @@
+package pivot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+type (
+	InvalidFingerprintTypeError interface{ cenclierrors.CencliError }
+	invalidFingerprintTypeError struct {
+		value string
+	}
+)
+
+var _ InvalidFingerprintTypeError = &invalidFingerprintTypeError{}
+
+func newInvalidFingerprintTypeError(value string) InvalidFingerprintTypeError {
+	return &invalidFingerprintTypeError{value: value}
+}
+
+func (e *invalidFingerprintTypeError) Error() string {
+	types := make([]string, len(fingerprintTypes))
+	for i, t := range fingerprintTypes {
+		types[i] = string(t)
+	}
+	return fmt.Sprintf("invalid fingerprint type %q: must be one of %s", e.value, strings.Join(types, ", "))
+}
+
+func (e *invalidFingerprintTypeError) Title() string { return "Invalid Fingerprint Type" }
+
+func (e *invalidFingerprintTypeError) ShouldPrintUsage() bool { return true }
+
+type (
+	UnrecognizedFingerprintError interface{ cenclierrors.CencliError }
+	unrecognizedFingerprintError struct {
+		value    string
+		expected mo.Option[fingerprintType]
+	}
+)
+
+var _ UnrecognizedFingerprintError = &unrecognizedFingerprintError{}
+
+func newUnrecognizedFingerprintError(value string, expected mo.Option[fingerprintType]) UnrecognizedFingerprintError {
+	return &unrecognizedFingerprintError{value: value, expected: expected}
+}
+
+func (e *unrecognizedFingerprintError) Error() string {
+	if t, ok := e.expected.Get(); ok {
+		return fmt.Sprintf("%q is not a valid %s fingerprint", e.value, t)
+	}
+	return fmt.Sprintf("could not detect the type of fingerprint %q; use --type to set it", e.value)
+}
+
+func (e *unrecognizedFingerprintError) Title() string { return "Unrecognized Fingerprint" }
+
+func (e *unrecognizedFingerprintError) ShouldPrintUsage() bool { return true }
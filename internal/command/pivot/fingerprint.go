@@ -0,0 +1,343 @@
+package pivot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+const (
+	fingerprintCmdName = "pivot fingerprint"
+
+	defaultSampleSize = 10
+	defaultRarityMin  = 2
+	defaultRarityMax  = 100
+)
+
+// rarity classifies how many hosts share a fingerprint, relative to the rarity bounds.
+type rarity string
+
+const (
+	// rarityUnseen means no host has the fingerprint.
+	rarityUnseen rarity = "unseen"
+	// rarityRare means fewer hosts than the lower bound have the fingerprint.
+	rarityRare rarity = "rare"
+	// rarityInteresting means the number of hosts is within the bounds.
+	rarityInteresting rarity = "interesting"
+	// rarityCommon means more hosts than the upper bound have the fingerprint.
+	rarityCommon rarity = "common"
+)
+
+func classifyRarity(count int64, lower, upper uint64) rarity {
+	switch {
+	case count <= 0:
+		return rarityUnseen
+	case uint64(count) < lower:
+		return rarityRare
+	case uint64(count) > upper:
+		return rarityCommon
+	default:
+		return rarityInteresting
+	}
+}
+
+// fingerprintCommand implements `pivot fingerprint`, which searches for the
+// hosts that share a service fingerprint and reports how rare it is.
+type fingerprintCommand struct {
+	*command.BaseCommand
+	// services the command uses
+	searchSvc search.Service
+	// flags the command uses
+	flags fingerprintCommandFlags
+	// state - populated by PreRun
+	orgID       mo.Option[identifiers.OrganizationID]
+	fingerprint string
+	fpType      fingerprintType
+	sampleSize  uint64
+	rarityMin   uint64
+	rarityMax   uint64
+	idsSep      mo.Option[byte]
+	// result stored for rendering
+	result fingerprintPivot
+}
+
+type fingerprintCommandFlags struct {
+	orgID     flags.OrgIDFlag
+	fpType    flags.StringFlag
+	limit     flags.IntegerFlag
+	rarityMin flags.IntegerFlag
+	rarityMax flags.IntegerFlag
+	ids       command.IDsFlags
+}
+
+// fingerprintPivot is the result of a fingerprint pivot.
+type fingerprintPivot struct {
+	Fingerprint string `json:"fingerprint"`
+	Type        string `json:"type"`
+	Field       string `json:"field"`
+	Query       string `json:"query"`
+	SearchURL   string `json:"search_url"`
+	// Count is the number of hosts with the fingerprint.
+	Count       int64  `json:"count"`
+	Rarity      rarity `json:"rarity"`
+	Interesting bool   `json:"interesting"`
+	// Hosts is a sample of the hosts with the fingerprint.
+	Hosts []pivotHost `json:"hosts"`
+}
+
+// pivotHost summarizes a host with the fingerprint.
+type pivotHost struct {
+	IP string `json:"ip"`
+	// Services are the matching services, as port/protocol.
+	Services []string `json:"services,omitempty"`
+	Country  string   `json:"country,omitempty"`
+	ASN      int      `json:"asn,omitempty"`
+	ASName   string   `json:"as_name,omitempty"`
+}
+
+var _ command.Command = (*fingerprintCommand)(nil)
+
+func newFingerprintCommand(cmdContext *command.Context) *fingerprintCommand {
+	return &fingerprintCommand{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *fingerprintCommand) Use() string { return "fingerprint <jarm|ja4s|ja4t|banner-hash>" }
+
+func (c *fingerprintCommand) Short() string {
+	return "Find the hosts that share a JARM, JA4S, JA4T, or banner hash fingerprint"
+}
+
+func (c *fingerprintCommand) Long() string {
+	return `Find the hosts that share a service fingerprint and report how rare it is.
+
+The type of fingerprint is detected from its shape, and the search query is built
+for you:
+
+  jarm         62 hex characters            host.services.jarm.fingerprint
+  ja4s         e.g. t130200_1301_234ea6891581  host.services.tls.ja4s
+  ja4t         e.g. 65535_2-4-8-1-3_1460_7   host.services.ja4tscan.fingerprint
+  banner-hash  64 hex characters (SHA-256)  host.services.banner_hash_sha256
+
+Use --type when a value could be read as more than one type. The report shows the
+number of hosts with the fingerprint, whether that count is within the rarity bounds
+(as in censeye), and a sample of the hosts.`
+}
+
+func (c *fingerprintCommand) Examples() []string {
+	return []string{
+		"2ad2ad0002ad2ad0002ad2ad2ad2adce7a321e4956e8298ba917e9f2c22849",
+		"t130200_1301_234ea6891581",
+		"--type banner-hash 0f6b1a1c5e1f0e2d3c4b5a69788796a5b4c3d2e1f00112233445566778899aab",
+		"--limit 25 --rarity-max 50 t130200_1301_234ea6891581",
+		"--limit 100 --ids-only t130200_1301_234ea6891581 | censys censeye --batch --input-file -",
+	}
+}
+
+func (c *fingerprintCommand) Args() command.PositionalArgs { return command.ExactArgs(1) }
+
+func (c *fingerprintCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *fingerprintCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *fingerprintCommand) Init() error {
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	types := make([]string, len(fingerprintTypes))
+	for i, t := range fingerprintTypes {
+		types[i] = string(t)
+	}
+	c.flags.fpType = flags.NewStringFlag(
+		c.Flags(),
+		false,
+		"type",
+		"t",
+		"",
+		fmt.Sprintf("fingerprint type, detected from the value if not set (%s)", strings.Join(types, "|")),
+	)
+	c.flags.limit = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"limit",
+		"n",
+		mo.Some[int64](defaultSampleSize),
+		"number of hosts to show",
+		mo.Some[int64](1),
+		mo.None[int64](),
+	)
+	c.flags.rarityMin = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"rarity-min",
+		"m",
+		mo.Some[int64](defaultRarityMin),
+		"minimum host count for an interesting fingerprint",
+		mo.Some[int64](1),
+		mo.None[int64](),
+	)
+	c.flags.rarityMax = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"rarity-max",
+		"M",
+		mo.Some[int64](defaultRarityMax),
+		"maximum host count for an interesting fingerprint",
+		mo.Some[int64](1),
+		mo.None[int64](),
+	)
+	c.flags.ids = command.NewIDsFlags(c.Flags())
+	return nil
+}
+
+func (c *fingerprintCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.orgID, err = c.flags.orgID.Value(); err != nil {
+		return err
+	}
+	c.fingerprint = normalizeFingerprint(args[0])
+	rawType, err := c.flags.fpType.Value()
+	if err != nil {
+		return err
+	}
+	if rawType != "" {
+		t, ok := parseFingerprintType(rawType)
+		if !ok {
+			return newInvalidFingerprintTypeError(rawType)
+		}
+		if !t.matches(c.fingerprint) {
+			return newUnrecognizedFingerprintError(c.fingerprint, mo.Some(t))
+		}
+		c.fpType = t
+	} else {
+		t, ok := detectFingerprintType(c.fingerprint)
+		if !ok {
+			return newUnrecognizedFingerprintError(c.fingerprint, mo.None[fingerprintType]())
+		}
+		c.fpType = t
+	}
+
+	limit, err := c.flags.limit.Value()
+	if err != nil {
+		return err
+	}
+	c.sampleSize = uint64(limit.OrElse(defaultSampleSize))
+	rarityMin, err := c.flags.rarityMin.Value()
+	if err != nil {
+		return err
+	}
+	c.rarityMin = uint64(rarityMin.OrElse(defaultRarityMin))
+	rarityMax, err := c.flags.rarityMax.Value()
+	if err != nil {
+		return err
+	}
+	c.rarityMax = uint64(rarityMax.OrElse(defaultRarityMax))
+	if c.rarityMin > c.rarityMax {
+		return flags.NewIntegerFlagInvalidValueError("rarity-min", int64(c.rarityMin), "must be less than or equal to rarity-max")
+	}
+	if c.idsSep, err = c.flags.ids.Value(cmd, c.Config().Streaming); err != nil {
+		return err
+	}
+
+	c.searchSvc, err = c.SearchService()
+	return err
+}
+
+func (c *fingerprintCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	query := pivotQuery(c.fpType, c.fingerprint)
+	logger := c.Logger(fingerprintCmdName).With(
+		"type", c.fpType,
+		"orgID_set", c.orgID.IsPresent(),
+	)
+
+	var result search.Result
+	err := c.WithProgress(
+		cmd.Context(),
+		logger,
+		"Searching for hosts with the fingerprint...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			var fetchErr cenclierrors.CencliError
+			result, fetchErr = c.searchSvc.Search(pctx, search.Params{
+				OrgID:    c.orgID,
+				Query:    query,
+				PageSize: mo.Some(c.sampleSize),
+				MaxPages: mo.Some[uint64](1),
+			})
+			return fetchErr
+		},
+	)
+	if err != nil {
+		return err
+	}
+	c.PrintAppResponseMeta(result.Meta)
+	if sep, ok := c.idsSep.Get(); ok {
+		return command.PrintIDs(result.Hits, sep)
+	}
+
+	r := classifyRarity(result.TotalHits, c.rarityMin, c.rarityMax)
+	c.result = fingerprintPivot{
+		Fingerprint: c.fingerprint,
+		Type:        string(c.fpType),
+		Field:       c.fpType.field(),
+		Query:       query,
+		SearchURL:   c.SearchURLs().URL(query, c.orgID),
+		Count:       result.TotalHits,
+		Rarity:      r,
+		Interesting: r == rarityInteresting,
+		Hosts:       summarizeHosts(result.Hits),
+	}
+	return c.PrintData(c, c.result)
+}
+
+// summarizeHosts returns a summary of each host hit.
+func summarizeHosts(hits []assets.Asset) []pivotHost {
+	hosts := make([]pivotHost, 0, len(hits))
+	for _, hit := range hits {
+		host, ok := hit.(*assets.Host)
+		if !ok {
+			continue
+		}
+		h := pivotHost{IP: deref(host.GetIP())}
+		if loc := host.GetLocation(); loc != nil {
+			h.Country = deref(loc.GetCountry())
+		}
+		if as := host.GetAutonomousSystem(); as != nil {
+			h.ASN = deref(as.GetAsn())
+			h.ASName = deref(as.GetName())
+		}
+		for _, svc := range host.MatchedServices {
+			s := fmt.Sprintf("%d", deref(svc.GetPort()))
+			if protocol := deref(svc.GetProtocol()); protocol != "" {
+				s += "/" + protocol
+			}
+			h.Services = append(h.Services, s)
+		}
+		hosts = append(hosts, h)
+	}
+	return hosts
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func (c *fingerprintCommand) RenderShort() cenclierrors.CencliError {
+	formatter.Println(formatter.Stdout, renderPivot(c.result, c.rarityMin, c.rarityMax))
+	return nil
+}
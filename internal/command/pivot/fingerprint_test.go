@@ -0,0 +1,190 @@
+package pivot
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	searchmocks "github.com/censys/cencli/gen/app/search/mocks"
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+const (
+	testJARM = "2ad2ad0002ad2ad0002ad2ad2ad2adce7a321e4956e8298ba917e9f2c22849"
+	testJA4S = "t130200_1301_234ea6891581"
+	testJA4T = "65535_2-4-8-1-3_1460_7"
+	testHash = "0f6b1a1c5e1f0e2d3c4b5a69788796a5b4c3d2e1f00112233445566778899aab"
+)
+
+func TestDetectFingerprintType(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected fingerprintType
+		ok       bool
+	}{
+		{value: testJARM, expected: fingerprintJARM, ok: true},
+		{value: testJA4S, expected: fingerprintJA4S, ok: true},
+		{value: testJA4T, expected: fingerprintJA4T, ok: true},
+		{value: testHash, expected: fingerprintBannerHash, ok: true},
+		{value: "not-a-fingerprint"},
+		{value: testJARM[:40]},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, ok := detectFingerprintType(tc.value)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestClassifyRarity(t *testing.T) {
+	require.Equal(t, rarityUnseen, classifyRarity(0, 2, 100))
+	require.Equal(t, rarityRare, classifyRarity(1, 2, 100))
+	require.Equal(t, rarityInteresting, classifyRarity(2, 2, 100))
+	require.Equal(t, rarityInteresting, classifyRarity(100, 2, 100))
+	require.Equal(t, rarityCommon, classifyRarity(101, 2, 100))
+}
+
+func testHit() assets.Asset {
+	host := assets.NewHostWithMatchedServices(
+		components.Host{
+			IP:               ptr("10.0.0.1"),
+			Location:         &components.Location{Country: ptr("Germany")},
+			AutonomousSystem: &components.Routing{Asn: ptr(64500), Name: ptr("EXAMPLE-AS")},
+		},
+		[]components.MatchedService{{Port: ptr(443), Protocol: ptr("HTTP")}},
+	)
+	return &host
+}
+
+func TestFingerprintCommand(t *testing.T) {
+	testCases := []struct {
+		name    string
+		service func(ctrl *gomock.Controller) search.Service
+		args    []string
+		assert  func(t *testing.T, stdout string, err error)
+	}{
+		{
+			name: "detects type and reports rarity",
+			service: func(ctrl *gomock.Controller) search.Service {
+				ms := searchmocks.NewMockSearchService(ctrl)
+				ms.EXPECT().Search(gomock.Any(), search.Params{
+					OrgID:    mo.None[identifiers.OrganizationID](),
+					Query:    `host.services.tls.ja4s="` + testJA4S + `"`,
+					PageSize: mo.Some[uint64](10),
+					MaxPages: mo.Some[uint64](1),
+				}).Return(search.Result{Hits: []assets.Asset{testHit()}, TotalHits: 12}, nil)
+				return ms
+			},
+			args: []string{"fingerprint", " T130200_1301_234EA6891581 ", "-O", "json"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				var got fingerprintPivot
+				require.NoError(t, json.Unmarshal([]byte(stdout), &got))
+				require.Equal(t, testJA4S, got.Fingerprint)
+				require.Equal(t, "ja4s", got.Type)
+				require.Equal(t, int64(12), got.Count)
+				require.Equal(t, rarityInteresting, got.Rarity)
+				require.True(t, got.Interesting)
+				require.Equal(t, []pivotHost{{
+					IP:       "10.0.0.1",
+					Services: []string{"443/HTTP"},
+					Country:  "Germany",
+					ASN:      64500,
+					ASName:   "EXAMPLE-AS",
+				}}, got.Hosts)
+			},
+		},
+		{
+			name: "short output",
+			service: func(ctrl *gomock.Controller) search.Service {
+				ms := searchmocks.NewMockSearchService(ctrl)
+				ms.EXPECT().Search(gomock.Any(), gomock.Any()).
+					Return(search.Result{Hits: []assets.Asset{testHit()}, TotalHits: 500}, nil)
+				return ms
+			},
+			args: []string{"fingerprint", testJARM},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, `host.services.jarm.fingerprint="`+testJARM+`"`)
+				require.Contains(t, stdout, "common")
+				require.Contains(t, stdout, "AS64500 EXAMPLE-AS")
+				require.Contains(t, stdout, "Showing 1 of 500 hosts.")
+			},
+		},
+		{
+			name: "type override must match the value",
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			args: []string{"fingerprint", "--type", "jarm", testHash},
+			assert: func(t *testing.T, stdout string, err error) {
+				var target UnrecognizedFingerprintError
+				require.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			name: "unknown type",
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			args: []string{"fingerprint", "--type", "ja3", testHash},
+			assert: func(t *testing.T, stdout string, err error) {
+				var target InvalidFingerprintTypeError
+				require.ErrorAs(t, err, &target)
+			},
+		},
+		{
+			name: "undetectable value",
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			args: []string{"fingerprint", "nope"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var target UnrecognizedFingerprintError
+				require.ErrorAs(t, err, &target)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewPivotCommand(cmdContext))
+			require.NoError(t, err)
+			// pivot defines its own --output-format, so only bind the streaming global flag
+			rootCmd.PersistentFlags().BoolP(config.StreamingFlagName, "S", false, "")
+			require.NoError(t, viper.BindPFlag(config.StreamingFlagName, rootCmd.PersistentFlags().Lookup(config.StreamingFlagName)))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), cmdErr)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,102 @@
+package pivot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/formatter/short"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+// renderPivot renders the summary of a fingerprint pivot followed by a table of
+// the sample hosts.
+func renderPivot(p fingerprintPivot, rarityMin, rarityMax uint64) string {
+	var sb strings.Builder
+
+	label := func(s string) string { return styles.GlobalStyles.Secondary.Render(fmt.Sprintf("%-12s", s)) }
+	sb.WriteString(fmt.Sprintf("%s %s\n", label("Fingerprint"), styles.GlobalStyles.Signature.Render(p.Fingerprint)))
+	sb.WriteString(fmt.Sprintf("%s %s\n", label("Type"), p.Type))
+	sb.WriteString(fmt.Sprintf("%s %s\n", label("Query"), renderLink(p.Query, p.SearchURL)))
+	sb.WriteString(fmt.Sprintf("%s %s\n", label("Hosts"), short.FormatNumber(p.Count)))
+	sb.WriteString(fmt.Sprintf("%s %s %s\n",
+		label("Rarity"),
+		renderRarity(p.Rarity),
+		styles.GlobalStyles.Comment.Render(fmt.Sprintf("(interesting between %d and %d hosts)", rarityMin, rarityMax)),
+	))
+
+	if len(p.Hosts) == 0 {
+		return sb.String()
+	}
+
+	columns := []rawtable.Column[pivotHost]{
+		{
+			Title:  "IP",
+			String: func(h pivotHost) string { return h.IP },
+			Style: func(s string, h pivotHost) string {
+				return styles.GlobalStyles.Signature.Render(s)
+			},
+			Priority:   3,
+			NoTruncate: true,
+		},
+		{
+			Title:    "Services",
+			String:   func(h pivotHost) string { return strings.Join(h.Services, ", ") },
+			Priority: 2,
+		},
+		{
+			Title:  "Country",
+			String: func(h pivotHost) string { return h.Country },
+		},
+		{
+			Title: "AS",
+			String: func(h pivotHost) string {
+				if h.ASN == 0 {
+					return ""
+				}
+				if h.ASName == "" {
+					return fmt.Sprintf("AS%d", h.ASN)
+				}
+				return fmt.Sprintf("AS%d %s", h.ASN, h.ASName)
+			},
+			Priority: 1,
+		},
+	}
+	table := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[pivotHost](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[pivotHost](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[pivotHost](formatter.TableWidth()),
+	)
+	sb.WriteString("\n")
+	sb.WriteString(table.Render(p.Hosts))
+	if int64(len(p.Hosts)) < p.Count {
+		sb.WriteString(styles.GlobalStyles.Comment.Render(
+			fmt.Sprintf("\nShowing %d of %s hosts.", len(p.Hosts), short.FormatNumber(p.Count)),
+		))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func renderRarity(r rarity) string {
+	switch r {
+	case rarityInteresting:
+		return styles.GlobalStyles.Interesting.Render(string(r))
+	case rarityRare:
+		return styles.GlobalStyles.Uninteresting.Render(string(r))
+	default:
+		return string(r)
+	}
+}
+
+// renderLink renders a link with the given text and url.
+// if stdout is not a TTY, it returns the text without the link.
+func renderLink(text, url string) string {
+	if formatter.StdoutIsTTY() {
+		return term.RenderLink(url, text)
+	}
+	return text
+}
@@ -0,0 +1,83 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/appdirs"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
+	"github.com/censys/cencli/internal/pkg/plugin"
+)
+
+// Dispatch executes an external plugin if args name one and do not resolve to a built-in command.
+// It returns handled=false when no plugin applies and normal command execution should continue.
+// When handled is true, code is the exit code the process should exit with.
+func Dispatch(
+	ctx context.Context,
+	cmdContext *command.Context,
+	root *cobra.Command,
+	dirs appdirs.Dirs,
+	args []string,
+) (code int, handled bool, err cenclierrors.CencliError) {
+	p, ok := lookupPlugin(root, args, os.Getenv("PATH"))
+	if !ok {
+		return 0, false, nil
+	}
+	env, err := buildEnv(ctx, cmdContext, dirs)
+	if err != nil {
+		return 1, true, err
+	}
+	code, runErr := plugin.Run(ctx, p, args[1:], env)
+	if runErr != nil {
+		return code, true, newPluginExecError(p.Name, runErr)
+	}
+	return code, true, nil
+}
+
+// lookupPlugin returns the plugin that args refer to, if any.
+// Only the first argument is considered, and only when it is not a flag
+// and does not resolve to a built-in command.
+func lookupPlugin(root *cobra.Command, args []string, pathEnv string) (plugin.Plugin, bool) {
+	if len(args) == 0 {
+		return plugin.Plugin{}, false
+	}
+	name := args[0]
+	// help and completion commands are added lazily by cobra, so they are not found by root.Find
+	if strings.HasPrefix(name, "-") || strings.HasPrefix(name, "__") || name == "help" {
+		return plugin.Plugin{}, false
+	}
+	if found, _, err := root.Find(args); err == nil && found != root {
+		return plugin.Plugin{}, false
+	}
+	return plugin.Find(name, pathEnv)
+}
+
+func buildEnv(ctx context.Context, cmdContext *command.Context, dirs appdirs.Dirs) (plugin.Env, cenclierrors.CencliError) {
+	env := plugin.Env{ConfigDir: dirs.Config, DataDir: dirs.Data, CacheDir: dirs.Cache}
+	if exe, err := os.Executable(); err == nil {
+		env.Binary = exe
+	}
+	orgID, err := cmdContext.GetStoredOrgID(ctx)
+	if err != nil {
+		return env, err
+	}
+	if orgID.IsPresent() {
+		env.OrgID = orgID.MustGet().String()
+	}
+	auth, authErr := cmdContext.Store().GetLastUsedAuthByName(ctx, config.AuthName)
+	if authErr != nil {
+		if !errors.Is(authErr, authdom.ErrAuthNotFound) {
+			return env, cenclierrors.NewCencliError(authErr)
+		}
+	} else {
+		env.AuthToken = auth.Value
+	}
+	return env, nil
+}
@@ -0,0 +1,38 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+type PluginExecError interface {
+	cenclierrors.CencliError
+}
+
+type pluginExecError struct {
+	name string
+	err  error
+}
+
+var _ PluginExecError = &pluginExecError{}
+
+func newPluginExecError(name string, err error) PluginExecError {
+	return &pluginExecError{name: name, err: err}
+}
+
+func (e *pluginExecError) Error() string {
+	return fmt.Sprintf("failed to execute plugin %q: %v", e.name, e.err)
+}
+
+func (e *pluginExecError) Title() string {
+	return "Plugin Execution Failed"
+}
+
+func (e *pluginExecError) ShouldPrintUsage() bool {
+	return false
+}
+
+func (e *pluginExecError) Unwrap() error {
+	return e.err
+}
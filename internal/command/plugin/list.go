@@ -0,0 +1,76 @@
+package plugin
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/plugin"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+type listCommand struct {
+	*command.BaseCommand
+	// pathEnv is the PATH-formatted list of directories to search.
+	// Overridable for tests.
+	pathEnv string
+	// result stored for rendering
+	plugins []plugin.Plugin
+}
+
+var _ command.Command = (*listCommand)(nil)
+
+func newListCommand(cmdContext *command.Context) *listCommand {
+	return &listCommand{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *listCommand) Use() string   { return "list" }
+func (c *listCommand) Short() string { return "List plugins available on your PATH" }
+
+func (c *listCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *listCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *listCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *listCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	if c.pathEnv == "" {
+		c.pathEnv = os.Getenv("PATH")
+	}
+	return nil
+}
+
+func (c *listCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	c.plugins = plugin.Discover(c.pathEnv)
+	return c.PrintData(c, c.plugins)
+}
+
+func (c *listCommand) RenderShort() cenclierrors.CencliError {
+	if len(c.plugins) == 0 {
+		formatter.Println(formatter.Stdout, styles.GlobalStyles.Comment.Render(
+			"No plugins found. Plugins are executables on your PATH named \""+plugin.Prefix+"<name>\".",
+		))
+		return nil
+	}
+	var out strings.Builder
+	for _, p := range c.plugins {
+		out.WriteString(styles.GlobalStyles.Signature.Render(p.Name))
+		out.WriteString("  ")
+		out.WriteString(styles.GlobalStyles.Secondary.Render(p.Path))
+		out.WriteRune('\n')
+		for _, shadowed := range p.Shadowed {
+			out.WriteString(styles.GlobalStyles.Warning.Render("  warning: " + shadowed + " is shadowed by the plugin above"))
+			out.WriteRune('\n')
+		}
+	}
+	formatter.Printf(formatter.Stdout, "%s", out.String())
+	return nil
+}
@@ -0,0 +1,75 @@
+package plugin
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// Command is the parent plugin command that groups plugin-related subcommands.
+type Command struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*Command)(nil)
+
+// NewPluginCommand creates a new plugin command with all subcommands.
+func NewPluginCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return "plugin"
+}
+
+func (c *Command) Short() string {
+	return "Manage external plugins"
+}
+
+func (c *Command) Long() string {
+	return `Manage external plugins.
+
+Any executable on your PATH named "cencli-<name>" is available as "censys <name>".
+Built-in commands always take precedence over plugins with the same name.
+
+Plugins inherit stdin, stdout and stderr, and receive the following environment variables:
+  CENCLI_PLUGIN_API_VERSION  version of the plugin contract
+  CENCLI_PLUGIN_NAME         name the plugin was invoked as
+  CENCLI_BIN                 path of the invoking censys binary
+  CENCLI_CONFIG_DIR          censys config directory
+  CENCLI_DATA_DIR            censys data directory
+  CENCLI_CACHE_DIR           censys cache directory
+  CENCLI_ORG_ID              active organization ID (if configured)
+  CENCLI_AUTH_TOKEN          active personal access token (if configured)`
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	return c.AddSubCommands(
+		newListCommand(c.Context),
+	)
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	// Parent command shows help when run without subcommands
+	if err := cmd.Help(); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/plugin"
+)
+
+func TestPluginList(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable bit detection is not used on windows")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cencli-foo"), []byte("#!/bin/sh\n"), 0o755))
+
+	testCases := []struct {
+		name    string
+		pathEnv string
+		args    []string
+		assert  func(t *testing.T, stdout string)
+	}{
+		{
+			name:    "short - no plugins",
+			pathEnv: t.TempDir(),
+			args:    []string{},
+			assert: func(t *testing.T, stdout string) {
+				require.Contains(t, stdout, "No plugins found")
+			},
+		},
+		{
+			name:    "short - plugin found",
+			pathEnv: dir,
+			args:    []string{},
+			assert: func(t *testing.T, stdout string) {
+				require.Contains(t, stdout, "foo")
+				require.Contains(t, stdout, filepath.Join(dir, "cencli-foo"))
+			},
+		},
+		{
+			name:    "json",
+			pathEnv: dir,
+			args:    []string{"-O", "json"},
+			assert: func(t *testing.T, stdout string) {
+				var got []plugin.Plugin
+				require.NoError(t, json.Unmarshal([]byte(stdout), &got))
+				require.Len(t, got, 1)
+				require.Equal(t, "foo", got[0].Name)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+			ctrl := gomock.NewController(t)
+			ctx := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl))
+
+			list := newListCommand(ctx)
+			list.pathEnv = tc.pathEnv
+
+			root, cerr := command.RootCommandToCobra(list)
+			require.NoError(t, cerr)
+			root.SetArgs(tc.args)
+			require.NoError(t, root.Execute())
+			tc.assert(t, stdout.String())
+		})
+	}
+}
+
+func TestLookupPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable bit detection is not used on windows")
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"cencli-foo", "cencli-builtin"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0o755))
+	}
+	root := &cobra.Command{Use: "censys"}
+	root.AddCommand(&cobra.Command{Use: "builtin", Run: func(*cobra.Command, []string) {}})
+
+	p, ok := lookupPlugin(root, []string{"foo", "--bar"}, dir)
+	require.True(t, ok)
+	require.Equal(t, "foo", p.Name)
+
+	_, ok = lookupPlugin(root, []string{"builtin"}, dir)
+	require.False(t, ok, "built-in commands take precedence")
+
+	_, ok = lookupPlugin(root, []string{"--debug", "foo"}, dir)
+	require.False(t, ok)
+
+	_, ok = lookupPlugin(root, []string{"missing"}, dir)
+	require.False(t, ok)
+
+	_, ok = lookupPlugin(root, nil, dir)
+	require.False(t, ok)
+}
@@ -3,85 +3,149 @@ package command
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
 	"sync"
+	"time"
 
-	"github.com/censys/cencli/internal/app/progress"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/ui/events"
 	"github.com/censys/cencli/internal/pkg/ui/spinner"
 )
 
-// progressDisplay manages both spinner UI and debug logging for progress events.
-type progressDisplay struct {
-	handle         spinner.Handle
-	disableSpinner bool
-	logger         *slog.Logger
+// progressBarWidth is the number of cells of the bar shown in front of the
+// spinner message when the total number of pages or batches is known.
+const progressBarWidth = 20
+
+// progressRenderer displays progress events to the user.
+type progressRenderer interface {
+	render(event events.Event)
+	stop()
 }
 
-// newProgressDisplay creates a progress display that shows a spinner (if enabled) and logs events.
-// The spinner is only shown when disableSpinner is false (interactive mode).
-// Progress events are always logged at debug level regardless of spinner state.
-func newProgressDisplay(
-	ctx context.Context,
-	log *slog.Logger,
-	disableSpinner bool,
-	initialMessage string,
-	stopwatchAfterSeconds uint64,
-) progressDisplay {
-	handle := spinner.NewNoopHandle()
-	spinnerOpts := []spinner.ComponentOption{
+// spinnerRenderer shows the latest progress message next to a spinner,
+// prefixed with a progress bar when the total is known.
+type spinnerRenderer struct {
+	handle spinner.Handle
+}
+
+func newSpinnerRenderer(ctx context.Context, initialMessage string, stopwatchAfterSeconds uint64) spinnerRenderer {
+	opts := []spinner.ComponentOption{
 		spinner.WithStopwatch(stopwatchAfterSeconds),
 	}
 	if initialMessage != "" {
-		spinnerOpts = append(spinnerOpts, spinner.WithMessage(initialMessage))
+		opts = append(opts, spinner.WithMessage(initialMessage))
 	}
-	if !disableSpinner {
-		handle = spinner.StartWithHandle(ctx.Done(), false, spinnerOpts...)
+	return spinnerRenderer{handle: spinner.StartWithHandle(ctx.Done(), false, opts...)}
+}
+
+func (r spinnerRenderer) render(event events.Event) {
+	// retries and requests are only shown with --verbose
+	if event.Kind == events.KindRetry || event.Kind == events.KindRequest {
+		return
 	}
-	return progressDisplay{handle: handle, disableSpinner: disableSpinner, logger: log}
+	msg := eventMessage(event)
+	if bar := spinner.ProgressBar(event.Current, event.Total, progressBarWidth); bar != "" {
+		msg = bar + " " + msg
+	}
+	if msg != "" {
+		r.handle.SetMessage(msg)
+	}
+}
+
+func (r spinnerRenderer) stop() { r.handle.Stop() }
+
+// logRenderer prints every progress event as a line, for --verbose.
+type logRenderer struct {
+	w io.Writer
 }
 
-// render displays a progress event via spinner (if enabled) and always logs it.
-// Message priority: event.Message > stage name.
-func (d progressDisplay) render(event progress.Event) {
-	msg := event.Message
-	if msg == "" {
-		msg = string(event.Stage)
+func (r logRenderer) render(event events.Event) {
+	if line := logLine(event); line != "" {
+		fmt.Fprintln(r.w, line)
 	}
+}
 
-	d.logger.Debug("progress", "stage", event.Stage, "message", msg)
+func (logRenderer) stop() {}
 
-	// Update spinner if enabled
-	if !d.disableSpinner && msg != "" {
-		d.handle.SetMessage(msg)
+// noopRenderer discards progress events.
+type noopRenderer struct{}
+
+func (noopRenderer) render(events.Event) {}
+func (noopRenderer) stop()               {}
+
+// eventMessage returns the message of event, falling back to its stage name.
+func eventMessage(event events.Event) string {
+	if event.Message != "" {
+		return event.Message
+	}
+	return string(event.Stage)
+}
+
+// logLine formats event as a single line for the log renderer.
+func logLine(event events.Event) string {
+	switch event.Kind {
+	case events.KindRetry:
+		line := fmt.Sprintf("retrying request (attempt %d/%d) in %s", event.Current+1, event.Total, event.Delay.Round(time.Millisecond))
+		if event.Err != nil {
+			line += ": " + event.Err.Error()
+		}
+		return line
+	case events.KindRequest:
+		if event.Request == nil {
+			return ""
+		}
+		status := "failed"
+		if event.Request.Status != 0 {
+			status = fmt.Sprintf("%d", event.Request.Status)
+		}
+		return fmt.Sprintf("%s %s %s (%s)", event.Request.Method, event.Request.URL, status, event.Request.Latency.Round(time.Millisecond))
+	default:
+		if event.Message == "" && event.Total > 0 {
+			return fmt.Sprintf("%s %d/%d", event.Stage, event.Current, event.Total)
+		}
+		return event.Message
 	}
 }
 
-// stop terminates the spinner display.
-func (d progressDisplay) stop() { d.handle.Stop() }
+// newProgressRenderer chooses how progress is displayed: log lines with
+// --verbose, a spinner when stderr is a terminal and the spinner is enabled,
+// and nothing otherwise.
+func (c *Context) newProgressRenderer(ctx context.Context, initialMessage string) progressRenderer {
+	switch {
+	case c.config.Verbose:
+		return logRenderer{w: formatter.Stderr}
+	case c.config.Spinner.Disabled || c.config.Quiet || c.config.NonInteractive || !formatter.StderrIsTTY():
+		return noopRenderer{}
+	default:
+		return newSpinnerRenderer(ctx, initialMessage, c.config.Spinner.StartStopwatchAfterSeconds)
+	}
+}
 
 // startProgress sets up progress reporting infrastructure.
 // It creates a publisher, attaches it to the context, starts a goroutine to consume events,
 // and returns a new context and a stop function.
+// Progress events are always logged at debug level regardless of the renderer.
 func (c *Context) startProgress(
 	ctx context.Context,
 	logger *slog.Logger,
 	initialMessage string,
 ) (context.Context, func(error)) {
-	disableSpinner := c.config.Spinner.Disabled || c.config.Quiet
-
-	pub, events := progress.NewChannelPublisher(0)
-	display := newProgressDisplay(ctx, logger, disableSpinner, initialMessage, c.config.Spinner.StartStopwatchAfterSeconds)
+	pub, published := events.NewChannelPublisher(0)
+	renderer := c.newProgressRenderer(ctx, initialMessage)
 
-	derived := progress.WithPublisher(ctx, pub)
+	derived := events.WithPublisher(ctx, pub)
 
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		for event := range events {
+		for event := range published {
 			if event.Done {
 				break
 			}
-			display.render(event)
+			logger.Debug("progress", "stage", event.Stage, "kind", event.Kind, "message", eventMessage(event))
+			renderer.render(event)
 		}
 	}()
 
@@ -90,7 +154,7 @@ func (c *Context) startProgress(
 		once.Do(func() {
 			pub.Close(finalErr)
 			<-done
-			display.stop()
+			renderer.stop()
 		})
 	}
 
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/ui/events"
+)
+
+func TestLogLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		event events.Event
+		want  string
+	}{
+		{
+			name:  "message",
+			event: events.Event{Stage: events.StageFetch, Message: "Fetching hosts..."},
+			want:  "Fetching hosts...",
+		},
+		{
+			name:  "stage without message",
+			event: events.Event{Stage: events.StagePrepare},
+			want:  "",
+		},
+		{
+			name:  "batch without message",
+			event: events.Event{Stage: events.StageProcess, Kind: events.KindBatch, Current: 2, Total: 5},
+			want:  "process 2/5",
+		},
+		{
+			name:  "retry",
+			event: events.Event{Stage: events.StageFetch, Kind: events.KindRetry, Current: 1, Total: 3, Delay: 500 * time.Millisecond, Err: errors.New("rate limited")},
+			want:  "retrying request (attempt 2/3) in 500ms: rate limited",
+		},
+		{
+			name:  "request",
+			event: events.Event{Stage: events.StageFetch, Kind: events.KindRequest, Request: &events.Request{Method: "POST", URL: "https://api.example/v3/global/search/query", Status: 200, Latency: 1234567 * time.Microsecond}},
+			want:  "POST https://api.example/v3/global/search/query 200 (1.235s)",
+		},
+		{
+			name:  "request without response",
+			event: events.Event{Stage: events.StageFetch, Kind: events.KindRequest, Request: &events.Request{Method: "GET", URL: "https://api.example/v3/global/asset/host/1.1.1.1", Latency: 30 * time.Millisecond}},
+			want:  "GET https://api.example/v3/global/asset/host/1.1.1.1 failed (30ms)",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, logLine(tc.event))
+		})
+	}
+}
+
+func TestWithProgress_Verbose(t *testing.T) {
+	run := func(t *testing.T, args ...string) string {
+		t.Helper()
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+
+		cmdContext := NewCommandContext(cfg, nil)
+		cmd := newTestCommand(cmdContext)
+		cmd.runFn = func(cobraCmd *cobra.Command, _ []string) cenclierrors.CencliError {
+			return cmdContext.WithProgress(cobraCmd.Context(), slog.Default(), "Starting...", func(ctx context.Context) cenclierrors.CencliError {
+				events.ReportPage(ctx, 1, 2, "Fetching search results (page 2/2)...")
+				events.ReportRequest(ctx, events.Request{Method: "POST", URL: "https://api.example/v3/global/search/query", Status: 200, Latency: 12 * time.Millisecond})
+				return nil
+			})
+		}
+		rootCmd, cerr := RootCommandToCobra(cmd)
+		require.NoError(t, cerr)
+		require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+		var stderr bytes.Buffer
+		formatter.Stdout = &bytes.Buffer{}
+		formatter.Stderr = &stderr
+		rootCmd.SetArgs(args)
+		require.NoError(t, rootCmd.Execute())
+		return stderr.String()
+	}
+
+	t.Run("prints events as log lines", func(t *testing.T) {
+		assert.Equal(t, "Fetching search results (page 2/2)...\nPOST https://api.example/v3/global/search/query 200 (12ms)\n", run(t, "--verbose"))
+	})
+
+	t.Run("prints nothing without a terminal", func(t *testing.T) {
+		assert.Empty(t, run(t))
+	})
+}
@@ -0,0 +1,50 @@
+package rarity
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+type MissingPairError interface {
+	cenclierrors.CencliError
+}
+
+type missingPairError struct{}
+
+var _ MissingPairError = &missingPairError{}
+
+func newMissingPairError() MissingPairError {
+	return &missingPairError{}
+}
+
+func (e *missingPairError) Error() string {
+	return "provide a field and a value, or --input-file with one field=value per line"
+}
+
+func (e *missingPairError) Title() string { return "Missing Field and Value" }
+
+func (e *missingPairError) ShouldPrintUsage() bool { return true }
+
+type InvalidPairError interface {
+	cenclierrors.CencliError
+}
+
+type invalidPairError struct {
+	line int
+	raw  string
+}
+
+var _ InvalidPairError = &invalidPairError{}
+
+func newInvalidPairError(line int, raw string) InvalidPairError {
+	return &invalidPairError{line: line, raw: raw}
+}
+
+func (e *invalidPairError) Error() string {
+	return fmt.Sprintf("line %d: expected field=value, got %q", e.line, e.raw)
+}
+
+func (e *invalidPairError) Title() string { return "Invalid Field and Value" }
+
+func (e *invalidPairError) ShouldPrintUsage() bool { return false }
@@ -0,0 +1,222 @@
+package rarity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+const cmdName = "rarity"
+
+// Command implements the `rarity` command, which counts the hosts with a
+// field set to a value: the count behind each censeye query.
+type Command struct {
+	*command.BaseCommand
+	// services the command uses
+	censeyeSvc censeye.Service
+	// flags the command uses
+	flags rarityCommandFlags
+	// state parsed from flags/args
+	orgID        mo.Option[identifiers.OrganizationID]
+	collectionID mo.Option[identifiers.CollectionID]
+	pairs        []censeye.FieldValue
+	// result stored for rendering
+	result censeye.CountValuesResult
+}
+
+type rarityCommandFlags struct {
+	orgID        flags.OrgIDFlag
+	collectionID flags.UUIDFlag
+	inputFile    flags.FileFlag
+}
+
+var _ command.Command = (*Command)(nil)
+
+// NewRarityCommand constructs a new Command with the provided context.
+func NewRarityCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string { return cmdName + " <field> <value>" }
+
+func (c *Command) Short() string {
+	return "Count the hosts with a field set to a value"
+}
+
+func (c *Command) Long() string {
+	return `Count the hosts with a field set to a value, such as host.services.jarm.fingerprint,
+to tell how rare it is. This is the count censeye reports for each of its queries.
+
+Counts come from the threat hunting service, in one request for up to 100 pairs.
+With --collection-id, only the hosts of the collection are counted, with one search
+per pair. Use --input-file to count many pairs, one field=value per line.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"host.services.jarm.fingerprint 3fd3fd20d00000000043d3fd3fd43d684d61a135bd962c8dd9c541ddbaefa8",
+		`host.services.endpoints.http.html_title "Index of /"`,
+		"--input-file pairs.txt -O json  # one field=value per line",
+		"--collection-id <your-collection-id> host.services.port 3389",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs { return command.RangeArgs(0, 2) }
+
+func (c *Command) Init() error {
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.collectionID = flags.NewUUIDFlag(
+		c.Flags(),
+		false,
+		"collection-id",
+		"c",
+		mo.None[uuid.UUID](),
+		"only count the hosts of a collection (optional)",
+	)
+	c.flags.inputFile = flags.NewFileFlag(
+		c.Flags(),
+		false,
+		"input-file",
+		"i",
+		"file to read field=value pairs from, one per line. Overrides the positional arguments.",
+	)
+	return nil
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.orgID, err = c.flags.orgID.Value(); err != nil {
+		return err
+	}
+	collectionID, err := c.flags.collectionID.Value()
+	if err != nil {
+		return err
+	}
+	if collectionID.IsPresent() {
+		c.collectionID = mo.Some(identifiers.NewCollectionID(collectionID.MustGet()))
+	}
+	if c.flags.inputFile.IsSet() {
+		lines, err := c.flags.inputFile.Lines(cmd)
+		if err != nil {
+			return err
+		}
+		if c.pairs, err = parsePairs(lines); err != nil {
+			return err
+		}
+	} else {
+		if len(args) != 2 {
+			return newMissingPairError()
+		}
+		field, value := strings.TrimSpace(args[0]), args[1]
+		if field == "" {
+			return newMissingPairError()
+		}
+		c.pairs = []censeye.FieldValue{{Field: field, Value: value}}
+	}
+	c.censeyeSvc, err = c.CenseyeService()
+	return err
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(cmdName).With("pairs", len(c.pairs), "collectionID_set", c.collectionID.IsPresent())
+	err := c.WithProgress(
+		cmd.Context(),
+		logger,
+		fmt.Sprintf("Counting %d values...", len(c.pairs)),
+		func(pctx context.Context) cenclierrors.CencliError {
+			var countErr cenclierrors.CencliError
+			c.result, countErr = c.censeyeSvc.CountValues(pctx, c.orgID, c.collectionID, c.pairs)
+			return countErr
+		},
+	)
+	if err != nil {
+		return err
+	}
+	c.PrintAppResponseMeta(c.result.Meta)
+	return c.PrintData(c, c.result.Counts)
+}
+
+// RenderShort renders the counts as a table, in the order they were given.
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	columns := []rawtable.Column[censeye.ValueCount]{
+		{
+			Title:      "Count",
+			String:     func(v censeye.ValueCount) string { return strconv.FormatInt(v.Count, 10) },
+			AlignRight: true,
+			NoTruncate: true,
+		},
+		{
+			Title:  "Query",
+			String: func(v censeye.ValueCount) string { return v.Query },
+			Style: func(s string, v censeye.ValueCount) string {
+				if formatter.StdoutIsTTY() {
+					s = term.RenderLink(v.SearchURL, s)
+				}
+				return styles.NewStyle(styles.ColorTeal).Render(s)
+			},
+			Priority:   1,
+			NoTruncate: true,
+		},
+	}
+	table := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[censeye.ValueCount](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[censeye.ValueCount](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[censeye.ValueCount](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, table.Render(c.result.Counts))
+	return nil
+}
+
+// parsePairs parses field=value (or tab-separated) pairs, one per line.
+// Blank lines and lines starting with # are skipped. A quoted value is
+// unquoted, so the queries printed by censeye can be pasted as they are.
+func parsePairs(lines []string) ([]censeye.FieldValue, cenclierrors.CencliError) {
+	var pairs []censeye.FieldValue
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		field, value, ok := strings.Cut(line, "=")
+		if tabField, tabValue, tabOK := strings.Cut(line, "\t"); tabOK && (!ok || len(tabField) < len(field)) {
+			field, value, ok = tabField, tabValue, true
+		}
+		field = strings.TrimSpace(field)
+		if !ok || field == "" {
+			return nil, newInvalidPairError(i+1, line)
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		pairs = append(pairs, censeye.FieldValue{Field: field, Value: value})
+	}
+	if len(pairs) == 0 {
+		return nil, newMissingPairError()
+	}
+	return pairs, nil
+}
@@ -0,0 +1,132 @@
+package rarity
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	censeyemocks "github.com/censys/cencli/gen/app/censeye/mocks"
+	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func TestRarityCommand(t *testing.T) {
+	collectionID := uuid.MustParse("11111111-2222-3333-4444-555555555555")
+
+	testCases := []struct {
+		name       string
+		censeyeSvc func(ctrl *gomock.Controller) censeye.Service
+		setup      func(t *testing.T, dir string, args []string)
+		args       []string
+		assert     func(t *testing.T, stdout string, err error)
+	}{
+		{
+			name: "counts a field and value",
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				ms := censeyemocks.NewMockCenseyeService(ctrl)
+				ms.EXPECT().CountValues(gomock.Any(), mo.None[identifiers.OrganizationID](), mo.None[identifiers.CollectionID](),
+					[]censeye.FieldValue{{Field: "host.services.port", Value: "3389"}}).
+					Return(censeye.CountValuesResult{Counts: []censeye.ValueCount{
+						{Field: "host.services.port", Value: "3389", Count: 42, Query: `host.services.port="3389"`},
+					}}, nil)
+				return ms
+			},
+			args: []string{"host.services.port", "3389"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Count")
+				require.Contains(t, stdout, "42")
+				require.Contains(t, stdout, `host.services.port="3389"`)
+			},
+		},
+		{
+			name: "reads pairs from a file and scopes to a collection",
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				ms := censeyemocks.NewMockCenseyeService(ctrl)
+				ms.EXPECT().CountValues(gomock.Any(), mo.None[identifiers.OrganizationID](), mo.Some(identifiers.NewCollectionID(collectionID)),
+					[]censeye.FieldValue{
+						{Field: "host.services.port", Value: "22"},
+						{Field: "host.services.endpoints.http.html_title", Value: "Index of /"},
+						{Field: "host.services.software.vendor", Value: "a=b"},
+					}).
+					Return(censeye.CountValuesResult{Counts: []censeye.ValueCount{{Field: "host.services.port", Value: "22", Count: 7}}}, nil)
+				return ms
+			},
+			setup: func(t *testing.T, dir string, args []string) {
+				content := "# pairs\nhost.services.port=22\n\nhost.services.endpoints.http.html_title=\"Index of /\"\nhost.services.software.vendor\ta=b\n"
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "pairs.txt"), []byte(content), 0o600))
+				args[1] = filepath.Join(dir, "pairs.txt")
+			},
+			args: []string{"--input-file", "pairs.txt", "--collection-id", collectionID.String(), "-O", "json"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				var counts []censeye.ValueCount
+				require.NoError(t, json.Unmarshal([]byte(stdout), &counts))
+				require.Equal(t, []censeye.ValueCount{{Field: "host.services.port", Value: "22", Count: 7}}, counts)
+			},
+		},
+		{
+			name: "error - value missing",
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				return censeyemocks.NewMockCenseyeService(ctrl)
+			},
+			args: []string{"host.services.port"},
+			assert: func(t *testing.T, _ string, err error) {
+				var missingErr MissingPairError
+				require.ErrorAs(t, err, &missingErr)
+			},
+		},
+		{
+			name: "error - invalid line in file",
+			censeyeSvc: func(ctrl *gomock.Controller) censeye.Service {
+				return censeyemocks.NewMockCenseyeService(ctrl)
+			},
+			setup: func(t *testing.T, dir string, args []string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "pairs.txt"), []byte("host.services.port=22\nnonsense\n"), 0o600))
+				args[1] = filepath.Join(dir, "pairs.txt")
+			},
+			args: []string{"--input-file", "pairs.txt"},
+			assert: func(t *testing.T, _ string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), `line 2: expected field=value, got "nonsense"`)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			viper.Reset()
+			cfg, err := config.New(tempDir)
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			if tc.setup != nil {
+				tc.setup(t, tempDir, tc.args)
+			}
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, nil, command.WithCenseyeService(tc.censeyeSvc(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewRarityCommand(cmdContext))
+			require.NoError(t, err)
+			rootCmd.SetArgs(tc.args)
+
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), cmdErr)
+		})
+	}
+}
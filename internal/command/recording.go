@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/censys/cencli/internal/pkg/sessionarchive"
+	"github.com/censys/cencli/internal/store"
+)
+
+// unrecordedCommands are top-level commands whose output is never recorded in
+// a session, either because they manage sessions themselves (or, for local
+// and graph, only re-read what was recorded, and for notes, add their notes
+// to the session themselves) or because their output may contain secrets.
+var unrecordedCommands = map[string]struct{}{
+	"session":    {},
+	"notes":      {},
+	"local":      {},
+	"graph":      {},
+	"config":     {},
+	"completion": {},
+	"version":    {},
+	"help":       {},
+	"dev":        {},
+}
+
+// WithSessionRecording enables recording command output into the active session.
+func WithSessionRecording() ContextOpts {
+	return func(c *Context) { c.recordSessions = true }
+}
+
+// invocation is the command line of the running command, kept so that
+// its output can be recorded into the active session.
+type invocation struct {
+	command string
+	query   string
+}
+
+// startSessionRecording remembers the invocation so that PrintData can record
+// its output if a session is active. Commands listed in unrecordedCommands are
+// never recorded.
+func (c *Context) startSessionRecording(cobraCmd *cobra.Command, cmd Command, args []string) {
+	c.invocation = nil
+	if !isRecorded(cobraCmd) {
+		return
+	}
+	c.invocation = &invocation{
+		command: commandLine(cobraCmd, args),
+		query:   queryFromArgs(cmd.Use(), args),
+	}
+}
+
+// recordSessionEntry records data as the response of the current invocation
+// if a session is active. In streaming mode only the command is recorded.
+// Recording is best-effort and never fails the command.
+func (c *Context) recordSessionEntry(ctx context.Context, data any) {
+	if !c.recordSessions || c.invocation == nil || c.store == nil {
+		return
+	}
+	session, err := c.store.GetActiveSession(ctx)
+	if err != nil {
+		if !errors.Is(err, store.ErrNoActiveSession) {
+			c.logger.Debug("failed to look up active session", "error", err)
+		}
+		return
+	}
+	entry := &store.SessionEntry{
+		Kind:    store.SessionEntryKindCommand,
+		Command: c.invocation.command,
+		Query:   c.invocation.query,
+	}
+	if !c.config.Streaming {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			c.logger.Debug("failed to encode response for session", "error", err)
+		} else {
+			entry.Response = string(raw)
+			entry.Digest = sessionarchive.Digest(raw)
+		}
+	}
+	if _, err := c.store.AddSessionEntry(ctx, session.ID, entry); err != nil {
+		c.logger.Debug("failed to record session entry", "session", session.Name, "error", err)
+	}
+}
+
+// isRecorded returns false for commands listed in unrecordedCommands and their subcommands.
+func isRecorded(cobraCmd *cobra.Command) bool {
+	top := cobraCmd
+	for top.HasParent() && top.Parent().HasParent() {
+		top = top.Parent()
+	}
+	_, skip := unrecordedCommands[top.Name()]
+	return !skip
+}
+
+// commandLine reconstructs a shell-quoted command line from the parsed
+// command, listing explicitly set flags in a stable order.
+func commandLine(cobraCmd *cobra.Command, args []string) string {
+	parts := strings.Fields(cobraCmd.CommandPath())
+	cobraCmd.Flags().Visit(func(f *pflag.Flag) {
+		switch v := f.Value.(type) {
+		case pflag.SliceValue:
+			for _, item := range v.GetSlice() {
+				parts = append(parts, "--"+f.Name, item)
+			}
+		default:
+			if f.Value.Type() == "bool" && f.Value.String() == "true" {
+				parts = append(parts, "--"+f.Name)
+				return
+			}
+			parts = append(parts, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+	parts = append(parts, args...)
+	for i, p := range parts {
+		parts[i] = ShellQuote(p)
+	}
+	return strings.Join(parts, " ")
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_./:=@%+,-]+$`)
+
+// ShellQuote quotes s for a POSIX shell, unless it is safe as is.
+func ShellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// queryFromArgs returns the positional argument named <query> in use, if any.
+func queryFromArgs(use string, args []string) string {
+	for i, token := range strings.Fields(use)[1:] {
+		if token == "<query>" && i < len(args) {
+			return args[i]
+		}
+	}
+	return ""
+}
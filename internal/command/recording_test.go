@@ -0,0 +1,98 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/sessionarchive"
+	"github.com/censys/cencli/internal/store"
+)
+
+func TestSessionRecording(t *testing.T) {
+	run := func(t *testing.T, st store.Store, use string, opts []ContextOpts, args ...string) {
+		t.Helper()
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+
+		cmdContext := NewCommandContext(cfg, st, opts...)
+		cmd := newTestCommand(cmdContext)
+		cmd.useFn = func() string { return use }
+		cmd.argsFn = func() PositionalArgs { return cobra.ArbitraryArgs }
+		cmd.initFn = func(c Command) error {
+			c.Flags().Int("max-pages", 1, "")
+			c.Flags().StringSlice("fields", nil, "")
+			return nil
+		}
+		cmd.runFn = func(cobraCmd *cobra.Command, args []string) cenclierrors.CencliError {
+			return cmd.PrintData(cmd, map[string]any{"hits": []string{"1.1.1.1"}})
+		}
+		rootCmd, cerr := RootCommandToCobra(cmd)
+		require.NoError(t, cerr)
+		require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+		var stdout bytes.Buffer
+		formatter.Stdout = &stdout
+		formatter.Stderr = &bytes.Buffer{}
+		rootCmd.SetArgs(args)
+		require.NoError(t, rootCmd.Execute())
+	}
+
+	t.Run("records output while a session is active", func(t *testing.T) {
+		ctx := context.Background()
+		st, err := store.New(t.TempDir())
+		require.NoError(t, err)
+
+		// nothing is recorded without an active session
+		run(t, st, "search <query>", []ContextOpts{WithSessionRecording()}, "host.ip: 1.1.1.1")
+		session, err := st.StartSession(ctx, "incident")
+		require.NoError(t, err)
+		run(t, st, "search <query>", []ContextOpts{WithSessionRecording()},
+			"host.ip: 1.1.1.1", "--max-pages", "3", "--fields", "host.ip", "--fields", "host.dns")
+
+		entries, err := st.GetSessionEntries(ctx, session.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, store.SessionEntryKindCommand, entries[0].Kind)
+		assert.Equal(t, "search --fields host.ip --fields host.dns --max-pages=3 'host.ip: 1.1.1.1'", entries[0].Command)
+		assert.Equal(t, "host.ip: 1.1.1.1", entries[0].Query)
+		assert.Equal(t, `{"hits":["1.1.1.1"]}`, entries[0].Response)
+		assert.Equal(t, sessionarchive.Digest([]byte(entries[0].Response)), entries[0].Digest)
+	})
+
+	t.Run("recording must be enabled", func(t *testing.T) {
+		ctx := context.Background()
+		st, err := store.New(t.TempDir())
+		require.NoError(t, err)
+		session, err := st.StartSession(ctx, "incident")
+		require.NoError(t, err)
+
+		run(t, st, "search <query>", nil, "query")
+
+		entries, err := st.GetSessionEntries(ctx, session.ID)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestQueryFromArgs(t *testing.T) {
+	assert.Equal(t, "q", queryFromArgs("search <query>", []string{"q"}))
+	assert.Equal(t, "q", queryFromArgs("aggregate <query> <field>", []string{"q", "f"}))
+	assert.Equal(t, "", queryFromArgs("view <asset>", []string{"1.1.1.1"}))
+	assert.Equal(t, "", queryFromArgs("search <query>", nil))
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, "--max-pages=3", ShellQuote("--max-pages=3"))
+	assert.Equal(t, "'host.ip: 1.1.1.1'", ShellQuote("host.ip: 1.1.1.1"))
+	assert.Equal(t, `'it'\''s'`, ShellQuote("it's"))
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/redact"
+	"github.com/censys/cencli/internal/store"
+)
+
+// redactSaltBytes is the length of a generated salt.
+const redactSaltBytes = 32
+
+// startRedaction masks the output of the command with --redact. Masks made
+// without a secret salt can be reversed by masking every IPv4 address, so
+// unless redact.salt is set, a random salt is generated on the first use and
+// kept in the store, which gives the same masks from run to run.
+func (c *Context) startRedaction(ctx context.Context) cenclierrors.CencliError {
+	if !c.config.Redact.Enabled {
+		return nil
+	}
+	salt := c.config.Redact.Salt
+	if salt == "" {
+		var err error
+		if salt, err = c.storedRedactSalt(ctx); err != nil {
+			return cenclierrors.NewCencliError(fmt.Errorf("failed to load the salt of --redact (set redact.salt instead): %w", err))
+		}
+	}
+	formatter.EnableRedaction(redact.New(salt))
+	return nil
+}
+
+// storedRedactSalt returns the salt kept in the store, generating it if there
+// is none.
+func (c *Context) storedRedactSalt(ctx context.Context) (string, error) {
+	if c.store == nil {
+		return "", errors.New("no store")
+	}
+	stored, err := c.store.GetLastUsedGlobalByName(ctx, config.RedactSaltGlobalName)
+	if err == nil {
+		return stored.Value, nil
+	}
+	if !errors.Is(err, store.ErrGlobalNotFound) {
+		return "", err
+	}
+	raw := make([]byte, redactSaltBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	salt := hex.EncodeToString(raw)
+	if _, err := c.store.AddValueForGlobal(ctx, config.RedactSaltGlobalName, "generated for --redact", salt); err != nil {
+		return "", err
+	}
+	return salt, nil
+}
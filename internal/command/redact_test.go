@@ -0,0 +1,33 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/store"
+)
+
+func TestStoredRedactSalt(t *testing.T) {
+	ctx := context.Background()
+	st, err := store.New(t.TempDir())
+	require.NoError(t, err)
+	c := NewCommandContext(&config.Config{}, st)
+
+	salt, err := c.storedRedactSalt(ctx)
+	require.NoError(t, err)
+	assert.Len(t, salt, 2*redactSaltBytes)
+
+	again, err := c.storedRedactSalt(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, salt, again, "the generated salt is kept, so masks are the same from run to run")
+
+	other, err := store.New(t.TempDir())
+	require.NoError(t, err)
+	otherSalt, err := NewCommandContext(&config.Config{}, other).storedRedactSalt(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, salt, otherSalt)
+}
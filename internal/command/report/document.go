@@ -0,0 +1,248 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/app/history"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/censyscopy"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter/short"
+	"github.com/censys/cencli/internal/store"
+)
+
+// timeLayout is how the times of the report are written.
+const timeLayout = "2006-01-02 15:04 UTC"
+
+// document is the data a report template is rendered with.
+type document struct {
+	Title       string    `json:"title"`
+	GeneratedAt string    `json:"generated_at"`
+	Window      window    `json:"window"`
+	Summary     summary   `json:"summary"`
+	Sections    []section `json:"sections"`
+}
+
+// window is the part of the timelines of the hosts that the report covers.
+type window struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Length is the length of the window, e.g. 30d.
+	Length string `json:"length"`
+}
+
+type summary struct {
+	Hosts    int `json:"hosts"`
+	Found    int `json:"found"`
+	Failed   int `json:"failed"`
+	Services int `json:"services"`
+	// Changed is the number of services that changed within the window.
+	Changed int `json:"changed"`
+	Pivots  int `json:"pivots"`
+}
+
+// section is the part of the report about one host.
+type section struct {
+	Host string `json:"host"`
+	// Anchor is the ID of the section, which the table of contents links to.
+	Anchor string `json:"anchor"`
+	URL    string `json:"url"`
+	Found  bool   `json:"found"`
+	// Error is why the host could not be investigated, if it could not.
+	Error    string       `json:"error,omitempty"`
+	Overview []field      `json:"overview"`
+	Services []serviceRow `json:"services"`
+	// Pivots are the queries within the rarity bounds, and Queries the number
+	// of queries censeye generated for the host.
+	Pivots      []censeye.ReportEntry `json:"pivots"`
+	Queries     int                   `json:"queries"`
+	Annotations []censeye.Annotation  `json:"annotations,omitempty"`
+	// Events is the number of events in the timeline of the host within the window.
+	Events int `json:"events"`
+	// Notes are the notes kept about the host, oldest first.
+	Notes []note `json:"notes"`
+}
+
+// note is a note kept about a host, added with `censys notes add`.
+type note struct {
+	Text      string `json:"text"`
+	Author    string `json:"author,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// field is a labeled value of the overview of a host.
+type field struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// serviceRow is a service of a host, with when it was first seen and last
+// changed within the window, if it was scanned then.
+type serviceRow struct {
+	Port        int    `json:"port"`
+	Transport   string `json:"transport"`
+	Protocol    string `json:"protocol"`
+	FirstSeen   string `json:"first_seen,omitempty"`
+	LastChanged string `json:"last_changed,omitempty"`
+	Changed     bool   `json:"changed"`
+}
+
+// investigation is what was gathered about one host.
+type investigation struct {
+	hostID string
+	host   *assets.Host
+	// censeye and history are the results of censeye and of reading the
+	// timeline of the host. err is set if either failed.
+	censeye censeye.InvestigateHostResult
+	history history.HostHistoryResult
+	err     cenclierrors.CencliError
+	// notes are the notes kept about the host.
+	notes []*store.Note
+}
+
+// buildDocument assembles the report from the investigation of each host, in
+// the order the hosts were given.
+func buildDocument(title string, generatedAt, start, end time.Time, investigations []investigation) document {
+	doc := document{
+		Title:       title,
+		GeneratedAt: generatedAt.UTC().Format(timeLayout),
+		Window: window{
+			Start:  start.UTC().Format(timeLayout),
+			End:    end.UTC().Format(timeLayout),
+			Length: flags.FormatHumanDuration(end.Sub(start)),
+		},
+		Sections: make([]section, 0, len(investigations)),
+	}
+	for _, inv := range investigations {
+		s := newSection(inv)
+		doc.Summary.Hosts++
+		if s.Found {
+			doc.Summary.Found++
+		}
+		if s.Error != "" {
+			doc.Summary.Failed++
+		}
+		doc.Summary.Services += len(s.Services)
+		for _, svc := range s.Services {
+			if svc.Changed {
+				doc.Summary.Changed++
+			}
+		}
+		doc.Summary.Pivots += len(s.Pivots)
+		doc.Sections = append(doc.Sections, s)
+	}
+	return doc
+}
+
+func newSection(inv investigation) section {
+	s := section{
+		Host:     inv.hostID,
+		Anchor:   anchor(inv.hostID),
+		URL:      censyscopy.CensysHostLookupLink(inv.hostID).String(),
+		Found:    inv.host != nil,
+		Overview: []field{},
+		Services: []serviceRow{},
+		Pivots:   []censeye.ReportEntry{},
+		Notes:    make([]note, len(inv.notes)),
+	}
+	for i, n := range inv.notes {
+		s.Notes[i] = note{Text: n.Text, Author: n.Author, CreatedAt: n.CreatedAt.UTC().Format(timeLayout)}
+	}
+	switch {
+	case inv.host == nil:
+		s.Error = "host not found"
+		return s
+	case inv.err != nil:
+		s.Error = inv.err.Error()
+	}
+	s.Overview = overview(inv.host)
+	scanned := make(map[string]history.ServiceHistory)
+	for _, svc := range history.SummarizeServices(inv.history.Events) {
+		scanned[svc.Key()] = svc
+	}
+	for _, svc := range inv.host.Services {
+		row := serviceRow{Port: short.Val(svc.Port, 0), Protocol: short.Val(svc.Protocol, "UNKNOWN"), Transport: "tcp"}
+		if svc.TransportProtocol != nil {
+			row.Transport = strings.ToLower(string(*svc.TransportProtocol))
+		}
+		if h, ok := scanned[history.ServiceKey(row.Port, row.Transport)]; ok {
+			row.FirstSeen = h.FirstSeen.UTC().Format(timeLayout)
+			row.LastChanged = h.LastChanged.UTC().Format(timeLayout)
+			row.Changed = true
+		}
+		s.Services = append(s.Services, row)
+	}
+	sort.SliceStable(s.Services, func(i, j int) bool { return s.Services[i].Port < s.Services[j].Port })
+	for _, entry := range inv.censeye.Entries {
+		if entry.Interesting {
+			s.Pivots = append(s.Pivots, entry)
+		}
+	}
+	s.Queries = len(inv.censeye.Entries)
+	s.Annotations = inv.censeye.Annotations
+	s.Events = len(inv.history.Events)
+	return s
+}
+
+// overview returns the fields of a host that identify who runs it and where.
+func overview(host *assets.Host) []field {
+	var fields []field
+	add := func(label, value string) {
+		if value != "" {
+			fields = append(fields, field{Label: label, Value: value})
+		}
+	}
+	if as := host.AutonomousSystem; as != nil && (as.Asn != nil || as.Name != nil) {
+		add("ASN", fmt.Sprintf("AS%d (%s)", short.Val(as.Asn, 0), short.Val(as.Name, "")))
+	}
+	if host.Whois != nil && host.Whois.Organization != nil {
+		add("WHOIS Org", short.Val(host.Whois.Organization.Name, ""))
+	}
+	if loc := host.Location; loc != nil {
+		var parts []string
+		for _, p := range []*string{loc.City, loc.Province, loc.Country} {
+			if v := short.Val(p, ""); v != "" {
+				parts = append(parts, v)
+			}
+		}
+		add("Location", strings.Join(parts, ", "))
+	}
+	if dns := host.DNS; dns != nil && dns.ReverseDNS != nil {
+		add("Reverse DNS", strings.Join(dns.ReverseDNS.Names, ", "))
+	}
+	if os := host.OperatingSystem; os != nil {
+		add("Operating System", strings.TrimSpace(strings.Join([]string{short.Val(os.Vendor, ""), short.Val(os.Product, ""), short.Val(os.Version, "")}, " ")))
+	}
+	if len(host.Labels) > 0 {
+		labels := make([]string, 0, len(host.Labels))
+		for _, l := range host.Labels {
+			if l.Value != nil {
+				labels = append(labels, *l.Value)
+			}
+		}
+		add("Labels", strings.Join(labels, ", "))
+	}
+	if fields == nil {
+		return []field{}
+	}
+	return fields
+}
+
+// anchor returns the ID of the section of a host, e.g. host-10-0-0-1.
+func anchor(hostID string) string {
+	var b strings.Builder
+	b.WriteString("host-")
+	for _, r := range strings.ToLower(hostID) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
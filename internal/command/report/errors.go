@@ -0,0 +1,57 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// NotHostError is returned when the assets are not hosts. Reports are only
+// generated for hosts, as censeye only investigates hosts.
+type NotHostError interface {
+	cenclierrors.CencliError
+}
+
+type notHostError struct {
+	assetType assets.AssetType
+}
+
+var _ NotHostError = &notHostError{}
+
+func newNotHostError(assetType assets.AssetType) NotHostError {
+	return &notHostError{assetType: assetType}
+}
+
+func (e *notHostError) Error() string {
+	return fmt.Sprintf("report only supports hosts, got %s assets", e.assetType)
+}
+
+func (e *notHostError) Title() string { return "Unsupported Asset Type" }
+
+func (e *notHostError) ShouldPrintUsage() bool { return true }
+
+// HostsFailedError is returned when some hosts could not be investigated.
+// The report is still written, with the error of each in its section.
+type HostsFailedError interface {
+	cenclierrors.CencliError
+}
+
+type hostsFailedError struct {
+	failed int
+	total  int
+}
+
+var _ HostsFailedError = &hostsFailedError{}
+
+func newHostsFailedError(failed, total int) HostsFailedError {
+	return &hostsFailedError{failed: failed, total: total}
+}
+
+func (e *hostsFailedError) Error() string {
+	return fmt.Sprintf("%d of %d host(s) could not be investigated; see the section of each in the report", e.failed, e.total)
+}
+
+func (e *hostsFailedError) Title() string { return "Some Hosts Failed" }
+
+func (e *hostsFailedError) ShouldPrintUsage() bool { return false }
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+//go:embed templates
+var builtinTemplates embed.FS
+
+const (
+	formatMarkdown = "markdown"
+	formatHTML     = "html"
+)
+
+// formats are the values accepted by --format.
+var formats = []string{formatMarkdown, formatHTML}
+
+// builtinTemplateNames are the built-in templates by format.
+var builtinTemplateNames = map[string]string{
+	formatMarkdown: "templates/report.md.hbs",
+	formatHTML:     "templates/report.html.hbs",
+}
+
+// parseFormat returns the format of the report: --format if given, otherwise
+// html for an output file ending in .html or .htm, otherwise markdown.
+func parseFormat(raw, outputFile string) (string, cenclierrors.CencliError) {
+	if raw == "" {
+		switch strings.ToLower(filepath.Ext(outputFile)) {
+		case ".html", ".htm":
+			return formatHTML, nil
+		default:
+			return formatMarkdown, nil
+		}
+	}
+	format := strings.ToLower(strings.TrimSpace(raw))
+	if format == "md" {
+		format = formatMarkdown
+	}
+	if _, ok := builtinTemplateNames[format]; !ok {
+		return "", cenclierrors.NewUsageError(fmt.Errorf("unsupported --format %q; use %s", raw, strings.Join(formats, " or ")))
+	}
+	return format, nil
+}
+
+// loadTemplate returns the name and source of the template to render the
+// report with: the file at templatePath if set, otherwise the built-in
+// template of the format.
+func loadTemplate(templatePath, format string) (string, string, cenclierrors.CencliError) {
+	if templatePath != "" {
+		source, err := os.ReadFile(templatePath)
+		if err != nil {
+			return "", "", cenclierrors.NewCencliError(fmt.Errorf("failed to read template: %w", err))
+		}
+		return templatePath, string(source), nil
+	}
+	name := builtinTemplateNames[format]
+	source, err := builtinTemplates.ReadFile(name)
+	if err != nil {
+		return "", "", cenclierrors.NewCencliError(err)
+	}
+	return name, string(source), nil
+}
+
+// render renders the report through its template. Reports are never styled,
+// as they are documents to be shared rather than terminal output.
+func (c *Command) render() (string, cenclierrors.CencliError) {
+	name, source, err := loadTemplate(c.templatePath, c.format)
+	if err != nil {
+		return "", err
+	}
+	return formatter.RenderTemplate(name, source, false, c.document)
+}
@@ -0,0 +1,402 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/app/history"
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/input"
+	"github.com/censys/cencli/internal/pkg/ui/events"
+	"github.com/censys/cencli/internal/store"
+)
+
+const (
+	cmdName = "report"
+
+	defaultTitle         = "Investigation Report"
+	defaultHistoryWindow = 30 * 24 * time.Hour
+	defaultRarityMin     = 2
+	defaultRarityMax     = 100
+	// concurrency is the number of hosts investigated at once.
+	concurrency = 4
+)
+
+// Command implements the `report` CLI command. It views each host, runs
+// censeye on it, and reads its timeline over a window, then renders all of
+// it as a single Markdown or HTML document.
+type Command struct {
+	*command.BaseCommand
+	// services the command uses
+	viewSvc    view.Service
+	censeyeSvc censeye.Service
+	historySvc history.Service
+	// flags the command uses
+	flags reportCommandFlags
+	// state - populated by PreRun (through flags, args, etc.)
+	hostIDs       []assets.HostID
+	orgID         mo.Option[identifiers.OrganizationID]
+	historyWindow time.Duration
+	rarityMin     uint64
+	rarityMax     uint64
+	format        string
+	templatePath  string
+	title         string
+	outputFile    string
+	force         bool
+	// result stored for rendering
+	meta     *responsemeta.ResponseMeta
+	document document
+}
+
+type reportCommandFlags struct {
+	orgID         flags.OrgIDFlag
+	inputFile     flags.FileFlag
+	historyWindow flags.HumanDurationFlag
+	rarityMin     flags.IntegerFlag
+	rarityMax     flags.IntegerFlag
+	format        flags.StringFlag
+	template      flags.StringFlag
+	title         flags.StringFlag
+	outputFile    flags.StringFlag
+	force         flags.BoolFlag
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewReportCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string { return fmt.Sprintf("%s <host...>", cmdName) }
+
+func (c *Command) Short() string {
+	return "Generate an investigation report on a list of hosts"
+}
+
+func (c *Command) Long() string {
+	return `Generate a single investigation report on a list of hosts.
+
+Each host is viewed, investigated with censeye, and its timeline is read over the
+--history-window. The report has a summary and a table of contents, then a section
+per host with its overview, its services with when each was first seen and last
+changed within the window, and a table of its pivots: the censeye queries within
+the rarity bounds, linked to their search in the Censys Platform.
+
+Reports are written as Markdown, or as HTML with --format html or an --output-file
+ending in .html. Use --template to render the report with your own Handlebars
+template; --output-format json prints the data that templates are rendered with.
+A host that fails to be investigated is reported in its section without stopping
+the others.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"8.8.8.8,1.1.1.1",
+		"--input-file hosts.txt --output-file report.md",
+		"--input-file hosts.txt --output-file report.html --title \"Incident 42\"",
+		"--input-file hosts.txt --history-window 7d --rarity-max 25",
+		"--input-file hosts.txt --template brief.hbs -f brief.md",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs { return command.RangeArgs(0, 1) }
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	c.flags.inputFile = flags.NewFileFlag(c.Flags(), false, "input-file", "i", "file to read the hosts from, one per line as plain text or JSON objects with an \"asset\" field. Overrides the positional argument.")
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.historyWindow = flags.NewHumanDurationFlag(c.Flags(), false, "history-window", "", mo.Some(defaultHistoryWindow),
+		"how far back to read the timeline of each host")
+	c.flags.rarityMin = flags.NewIntegerFlag(c.Flags(), false, "rarity-min", "m", mo.Some(int64(defaultRarityMin)),
+		"minimum host count of a pivot", mo.Some(int64(1)), mo.None[int64]())
+	c.flags.rarityMax = flags.NewIntegerFlag(c.Flags(), false, "rarity-max", "M", mo.Some(int64(defaultRarityMax)),
+		"maximum host count of a pivot", mo.Some(int64(1)), mo.None[int64]())
+	c.flags.format = flags.NewStringFlag(c.Flags(), false, "format", "", "",
+		"format of the report: markdown or html (default: from the --output-file extension, otherwise markdown)")
+	c.flags.template = flags.NewStringFlag(c.Flags(), false, "template", "", "", "Handlebars template to render the report with, instead of the built-in one")
+	c.flags.title = flags.NewStringFlag(c.Flags(), false, "title", "", defaultTitle, "title of the report")
+	c.flags.outputFile = flags.NewStringFlag(c.Flags(), false, "output-file", "f", "", "file to write the report to (default: stdout)")
+	c.flags.force = flags.NewBoolFlag(c.Flags(), "force", "", false, "overwrite the output file if it exists")
+	return nil
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.orgID, err = c.flags.orgID.Value()
+	if err != nil {
+		return err
+	}
+	if err := c.parseReportFlags(cmd); err != nil {
+		return err
+	}
+	rawAssets, err := command.RawAssets(cmd, c.flags.inputFile, args)
+	if err != nil {
+		return err
+	}
+	classifier := assets.NewAssetClassifier(rawAssets...)
+	assetType, err := classifier.AssetType()
+	if err != nil {
+		return err
+	}
+	if assetType != assets.AssetTypeHost {
+		return newNotHostError(assetType)
+	}
+	c.hostIDs = classifier.HostIDs()
+	return c.resolveServices()
+}
+
+// parseReportFlags parses the flags of the window, the rarity bounds, and
+// how and where the report is written.
+func (c *Command) parseReportFlags(cmd *cobra.Command) cenclierrors.CencliError {
+	window, err := c.flags.historyWindow.Value()
+	if err != nil {
+		return err
+	}
+	c.historyWindow = window.OrElse(defaultHistoryWindow)
+	minVal, err := c.flags.rarityMin.Value()
+	if err != nil {
+		return err
+	}
+	maxVal, err := c.flags.rarityMax.Value()
+	if err != nil {
+		return err
+	}
+	c.rarityMin = uint64(minVal.OrElse(defaultRarityMin))
+	c.rarityMax = uint64(maxVal.OrElse(defaultRarityMax))
+	if c.rarityMin > c.rarityMax {
+		return flags.NewIntegerFlagInvalidValueError("rarity-min", int64(c.rarityMin), "must be less than or equal to rarity-max")
+	}
+	if c.title, err = c.flags.title.Value(); err != nil {
+		return err
+	}
+	if c.templatePath, err = c.flags.template.Value(); err != nil {
+		return err
+	}
+	if c.outputFile, err = c.flags.outputFile.Value(); err != nil {
+		return err
+	}
+	if c.outputFile == input.StdInSentinel {
+		c.outputFile = ""
+	}
+	if c.outputFile != "" && cmd.Flags().Changed(formatter.OutputFormatFlagName) {
+		return flags.NewConflictingFlagsError("output-file", formatter.OutputFormatFlagName)
+	}
+	if c.force, err = c.flags.force.Value(); err != nil {
+		return err
+	}
+	// fail before investigating rather than after
+	if c.outputFile != "" && !c.force {
+		if _, statErr := os.Stat(c.outputFile); statErr == nil {
+			return cenclierrors.NewUsageError(fmt.Errorf("%s already exists; use --force to overwrite it", c.outputFile))
+		}
+	}
+	rawFormat, err := c.flags.format.Value()
+	if err != nil {
+		return err
+	}
+	c.format, err = parseFormat(rawFormat, c.outputFile)
+	return err
+}
+
+func (c *Command) resolveServices() cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.viewSvc, err = c.ViewService(); err != nil {
+		return err
+	}
+	if c.censeyeSvc, err = c.CenseyeService(); err != nil {
+		return err
+	}
+	c.historySvc, err = c.HistoryService()
+	return err
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(cmdName).With(
+		"orgID_set", c.orgID.IsPresent(),
+		"hosts", len(c.hostIDs),
+		"format", c.format,
+	)
+	end := c.Now().UTC()
+	start := end.Add(-c.historyWindow)
+
+	var investigations []investigation
+	var partialErr cenclierrors.CencliError
+	err := c.WithProgress(
+		cmd.Context(),
+		logger,
+		"Fetching hosts...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			hosts, fetchErr := c.viewSvc.GetHosts(pctx, c.orgID, c.hostIDs, mo.None[time.Time]())
+			if fetchErr != nil {
+				return fetchErr
+			}
+			c.meta = hosts.Meta
+			partialErr = hosts.PartialError
+			investigations = c.newInvestigations(hosts.Hosts)
+			return c.investigateAll(pctx, investigations, start, end)
+		},
+	)
+	if err != nil {
+		logger.Debug("report failed", "error", err)
+		return err
+	}
+	c.addNotes(cmd.Context(), logger, investigations)
+	c.document = buildDocument(c.title, end, start, end, investigations)
+
+	c.PrintAppResponseMeta(c.meta)
+	if err := c.PrintData(c, c.document); err != nil {
+		return err
+	}
+	if partialErr != nil {
+		c.ReportPartialError(partialErr, cmd)
+	}
+
+	switch failed := c.document.Summary.Failed; {
+	case failed == 0:
+		return nil
+	case failed == len(investigations):
+		return newHostsFailedError(failed, len(investigations))
+	default:
+		c.ReportPartialError(cenclierrors.ToPartialError(newHostsFailedError(failed, len(investigations))), cmd)
+		return nil
+	}
+}
+
+// newInvestigations returns an investigation per requested host, in the
+// order they were given, with the host fetched, if it was found.
+func (c *Command) newInvestigations(hosts []*assets.Host) []investigation {
+	byIP := make(map[string]*assets.Host, len(hosts))
+	for _, host := range hosts {
+		if host != nil && host.IP != nil {
+			byIP[assets.NormalizeIP(*host.IP)] = host
+		}
+	}
+	investigations := make([]investigation, len(c.hostIDs))
+	for i, id := range c.hostIDs {
+		investigations[i] = investigation{hostID: id.String(), host: byIP[assets.NormalizeIP(id.String())]}
+	}
+	return investigations
+}
+
+// addNotes adds the notes kept in the local store about each host to its
+// investigation. Notes are optional: if they cannot be read, the report is
+// made without them.
+func (c *Command) addNotes(ctx context.Context, logger *slog.Logger, investigations []investigation) {
+	if c.Store() == nil {
+		return
+	}
+	notes, err := c.Store().ListNotes(ctx, store.NotesFilter{})
+	if err != nil {
+		logger.Debug("failed to read notes", "error", err)
+		return
+	}
+	byAsset := make(map[string][]*store.Note)
+	for _, note := range notes {
+		byAsset[note.Asset] = append(byAsset[note.Asset], note)
+	}
+	for i := range investigations {
+		investigations[i].notes = byAsset[assets.NormalizeIP(investigations[i].hostID)]
+	}
+}
+
+// investigateAll runs censeye on each found host and reads its timeline
+// between start and end, a few hosts at a time. A host that fails keeps its
+// error without stopping the others.
+func (c *Command) investigateAll(ctx context.Context, investigations []investigation, start, end time.Time) cenclierrors.CencliError {
+	var found int
+	for _, inv := range investigations {
+		if inv.host != nil {
+			found++
+		}
+	}
+	events.ReportMessage(ctx, events.StageProcess, fmt.Sprintf("Investigating %d hosts...", found))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for i := range investigations {
+		inv := &investigations[i]
+		if inv.host == nil {
+			continue
+		}
+		g.Go(func() error {
+			hostID, _ := assets.NewHostID(inv.hostID)
+			inv.censeye, inv.err = c.censeyeSvc.InvestigateHost(gctx, c.orgID, inv.host, c.rarityMin, c.rarityMax)
+			if inv.err != nil {
+				return nil
+			}
+			inv.history, inv.err = c.historySvc.GetHostHistory(gctx, c.orgID, hostID, start, end)
+			if inv.err == nil && inv.history.PartialError != nil {
+				inv.err = inv.history.PartialError
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return cenclierrors.ParseContextError(ctxErr)
+	}
+	return nil
+}
+
+// RenderShort renders the report through its template and writes it to
+// --output-file, or stdout.
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	rendered, err := c.render()
+	if err != nil {
+		return err
+	}
+	if c.outputFile == "" {
+		formatter.Printf(formatter.Stdout, "%s", rendered)
+		return nil
+	}
+	openFlags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !c.force {
+		openFlags |= os.O_EXCL
+	}
+	f, openErr := os.OpenFile(c.outputFile, openFlags, 0o600)
+	if openErr != nil {
+		if errors.Is(openErr, os.ErrExist) {
+			return cenclierrors.NewUsageError(fmt.Errorf("%s already exists; use --force to overwrite it", c.outputFile))
+		}
+		return cenclierrors.NewCencliError(openErr)
+	}
+	rw := formatter.RedactFile(f)
+	if _, writeErr := io.WriteString(rw, rendered); writeErr != nil {
+		f.Close()
+		return cenclierrors.NewCencliError(writeErr)
+	}
+	if flushErr := rw.Close(); flushErr != nil {
+		f.Close()
+		return cenclierrors.NewCencliError(flushErr)
+	}
+	if closeErr := f.Close(); closeErr != nil {
+		return cenclierrors.NewCencliError(closeErr)
+	}
+	formatter.Printf(formatter.Stdout, "✅ Wrote the report on %d hosts to %s\n", c.document.Summary.Hosts, c.outputFile)
+	return nil
+}
@@ -0,0 +1,253 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	censeyemocks "github.com/censys/cencli/gen/app/censeye/mocks"
+	historymocks "github.com/censys/cencli/gen/app/history/mocks"
+	viewmocks "github.com/censys/cencli/gen/app/view/mocks"
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/app/history"
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/store"
+)
+
+var now = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+type services struct {
+	view    view.Service
+	censeye censeye.Service
+	history history.Service
+}
+
+func TestReportCommand(t *testing.T) {
+	tcp := components.ServiceTransportProtocolTCP
+	hosts := func(ctrl *gomock.Controller) view.Service {
+		ms := viewmocks.NewMockViewService(ctrl)
+		ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Len(3), gomock.Any()).
+			Return(view.HostsResult{Hosts: []*assets.Host{
+				{Host: components.Host{
+					IP:               ptr("10.0.0.1"),
+					AutonomousSystem: &components.Routing{Asn: ptr(64500), Name: ptr("EXAMPLE")},
+					Services: []components.Service{
+						{Port: ptr(443), Protocol: ptr("HTTP"), TransportProtocol: &tcp},
+						{Port: ptr(22), Protocol: ptr("SSH"), TransportProtocol: &tcp},
+					},
+				}},
+				{Host: components.Host{IP: ptr("10.0.0.2")}},
+			}}, nil)
+		return ms
+	}
+	investigated := func(ctrl *gomock.Controller) censeye.Service {
+		ms := censeyemocks.NewMockCenseyeService(ctrl)
+		ms.EXPECT().InvestigateHost(gomock.Any(), gomock.Any(), gomock.Cond(func(h *assets.Host) bool { return *h.IP == "10.0.0.1" }), uint64(2), uint64(100)).
+			Return(censeye.InvestigateHostResult{Entries: []censeye.ReportEntry{
+				{Count: 5000, Query: `host.services.protocol="HTTP"`},
+				{Count: 7, Query: `host.services.banner_hash_sha256="ab|cd"`, Interesting: true, SearchURL: "https://platform.censys.io/search?q=x"},
+			}}, nil)
+		ms.EXPECT().InvestigateHost(gomock.Any(), gomock.Any(), gomock.Cond(func(h *assets.Host) bool { return *h.IP == "10.0.0.2" }), gomock.Any(), gomock.Any()).
+			Return(censeye.InvestigateHostResult{}, cenclierrors.NewCencliError(errors.New("rate limited")))
+		return ms
+	}
+	timeline := func(ctrl *gomock.Controller) history.Service {
+		ms := historymocks.NewMockHistoryService(ctrl)
+		ms.EXPECT().GetHostHistory(gomock.Any(), gomock.Any(), gomock.Any(), now.Add(-30*24*time.Hour), now).
+			Return(history.HostHistoryResult{Events: []*components.HostTimelineEvent{
+				{EventTime: ptr("2024-01-20T08:00:00Z"), ServiceScanned: &components.ServiceScanned{Scan: &components.ServiceScan{
+					Port: ptr(443), TransportProtocol: ptr(components.ServiceScanTransportProtocolTCP), Protocol: ptr("HTTP"),
+				}}},
+			}}, nil)
+		return ms
+	}
+	noCalls := func(ctrl *gomock.Controller) services {
+		return services{
+			view:    viewmocks.NewMockViewService(ctrl),
+			censeye: censeyemocks.NewMockCenseyeService(ctrl),
+			history: historymocks.NewMockHistoryService(ctrl),
+		}
+	}
+	investigation := func(ctrl *gomock.Controller) services {
+		return services{view: hosts(ctrl), censeye: investigated(ctrl), history: timeline(ctrl)}
+	}
+
+	testCases := []struct {
+		name     string
+		services func(ctrl *gomock.Controller) services
+		args     func(dir string) []string
+		assert   func(t *testing.T, dir, stdout, stderr string, err error)
+	}{
+		{
+			name:     "markdown report",
+			services: investigation,
+			args:     func(string) []string { return []string{"10.0.0.1,10.0.0.2,10.0.0.3", "--title", "Incident 42"} },
+			assert: func(t *testing.T, _, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "# Incident 42\n")
+				require.Contains(t, stdout, "| 3 | 2 | 2 | 2 | 1 | 1 |")
+				require.Contains(t, stdout, "- [10.0.0.1](#host-10-0-0-1)\n- [10.0.0.2](#host-10-0-0-2) (failed)\n- [10.0.0.3](#host-10-0-0-3) (failed)\n")
+				require.Contains(t, stdout, `<a id="host-10-0-0-1"></a>`)
+				require.Contains(t, stdout, "- **ASN:** AS64500 (EXAMPLE)")
+				require.Contains(t, stdout, "| 22/tcp | SSH | unchanged | unchanged |\n| 443/tcp | HTTP | 2024-01-20 08:00 UTC | 2024-01-20 08:00 UTC |")
+				require.Contains(t, stdout, "1 of 2 queries are within the rarity bounds.")
+				require.Contains(t, stdout, "| 7 | [`host.services.banner_hash_sha256=\"ab\\|cd\"`](https://platform.censys.io/search?q=x) |")
+				require.Contains(t, stdout, "> **Error:** rate limited\n\n### Services\n\nNo services.\n\n### Pivots\n\nNo queries.\n")
+				require.Contains(t, stdout, "> **Error:** host not found")
+				require.Contains(t, stdout, "### Notes\n\n- **"+now.Format(timeLayout)+" (alice):** beacon every 60s\n")
+				require.NotContains(t, stdout, "unrelated")
+				require.Contains(t, stderr, "2 of 3 host(s) could not be investigated")
+			},
+		},
+		{
+			name:     "html report to a file",
+			services: investigation,
+			args: func(dir string) []string {
+				return []string{"10.0.0.1,10.0.0.2,10.0.0.3", "-f", filepath.Join(dir, "report.html")}
+			},
+			assert: func(t *testing.T, dir, stdout, _ string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Wrote the report on 3 hosts to")
+				out, readErr := os.ReadFile(filepath.Join(dir, "report.html"))
+				require.NoError(t, readErr)
+				require.Contains(t, string(out), "<title>Investigation Report</title>")
+				require.Contains(t, string(out), `<li><a href="#host-10-0-0-1">10.0.0.1</a></li>`)
+				require.Contains(t, string(out), `<code>host.services.banner_hash_sha256=&quot;ab|cd&quot;</code>`)
+			},
+		},
+		{
+			name:     "json output is the data of the templates",
+			services: investigation,
+			args:     func(string) []string { return []string{"10.0.0.1,10.0.0.2,10.0.0.3", "-O", "json"} },
+			assert: func(t *testing.T, _, stdout, _ string, err error) {
+				require.NoError(t, err)
+				var doc document
+				require.NoError(t, json.Unmarshal([]byte(stdout), &doc))
+				require.Equal(t, "30d", doc.Window.Length)
+				require.Len(t, doc.Sections, 3)
+				require.Equal(t, 2, doc.Sections[0].Queries)
+				require.Equal(t, 1, doc.Sections[0].Events)
+				require.False(t, doc.Sections[2].Found)
+				require.Equal(t, []note{{Text: "beacon every 60s", Author: "alice", CreatedAt: now.Format(timeLayout)}}, doc.Sections[0].Notes)
+				require.Empty(t, doc.Sections[1].Notes)
+			},
+		},
+		{
+			name:     "custom template",
+			services: investigation,
+			args: func(dir string) []string {
+				path := filepath.Join(dir, "brief.hbs")
+				require.NoError(t, os.WriteFile(path, []byte("{{#each sections}}{{host}}: {{length pivots}}\n{{/each}}"), 0o600))
+				return []string{"10.0.0.1,10.0.0.2,10.0.0.3", "--template", path}
+			},
+			assert: func(t *testing.T, _, stdout, _ string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "10.0.0.1: 1\n10.0.0.2: 0\n10.0.0.3: 0\n", stdout)
+			},
+		},
+		{
+			name:     "refuses to overwrite before investigating",
+			services: noCalls,
+			args: func(dir string) []string {
+				path := filepath.Join(dir, "report.md")
+				require.NoError(t, os.WriteFile(path, nil, 0o600))
+				return []string{"10.0.0.1", "-f", path}
+			},
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "already exists; use --force to overwrite it")
+			},
+		},
+		{
+			name:     "output file conflicts with output format",
+			services: noCalls,
+			args:     func(dir string) []string { return []string{"10.0.0.1", "-f", filepath.Join(dir, "r.md"), "-O", "json"} },
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				var conflictErr flags.ConflictingFlagsError
+				require.ErrorAs(t, err, &conflictErr)
+			},
+		},
+		{
+			name:     "unsupported format",
+			services: noCalls,
+			args:     func(string) []string { return []string{"10.0.0.1", "--format", "pdf"} },
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, `unsupported --format "pdf"`)
+			},
+		},
+		{
+			name:     "rejects non-host assets",
+			services: noCalls,
+			args:     func(string) []string { return []string{"platform.censys.io:443"} },
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				var notHost NotHostError
+				require.ErrorAs(t, err, &notHost)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			dir := t.TempDir()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			svcs := tc.services(ctrl)
+			st := storemocks.NewMockStore(ctrl)
+			st.EXPECT().ListNotes(gomock.Any(), store.NotesFilter{}).Return([]*store.Note{
+				{ID: 1, Asset: "10.0.0.1", AssetType: "host", Text: "beacon every 60s", Author: "alice", CreatedAt: now},
+				{ID: 2, Asset: "10.0.0.9", AssetType: "host", Text: "unrelated", CreatedAt: now},
+			}, nil).AnyTimes()
+			cmdContext := command.NewCommandContext(cfg, st,
+				command.WithViewService(svcs.view),
+				command.WithCenseyeService(svcs.censeye),
+				command.WithHistoryService(svcs.history),
+				command.WithClock(func() time.Time { return now }),
+			)
+			rootCmd, err := command.RootCommandToCobra(NewReportCommand(cmdContext))
+			require.NoError(t, err)
+
+			rootCmd.SetArgs(tc.args(dir))
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, dir, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, tc := range []struct{ raw, outputFile, want string }{
+		{"", "", formatMarkdown},
+		{"", "report.HTML", formatHTML},
+		{"", "report.htm", formatHTML},
+		{"", "report.txt", formatMarkdown},
+		{"md", "report.html", formatMarkdown},
+		{"HTML", "", formatHTML},
+	} {
+		got, err := parseFormat(tc.raw, tc.outputFile)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, tc)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
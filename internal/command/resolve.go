@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/resolve"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+const (
+	resolveFlagName   = "resolve"
+	noResolveFlagName = "no-resolve"
+)
+
+// ResolvedFromKey is the field that the domain a host was resolved from is
+// attached under in data output.
+const ResolvedFromKey = "resolved_from"
+
+// ResolveFlags are the flags of commands that can resolve domains to the IPs
+// of their hosts: --resolve and --no-resolve.
+type ResolveFlags struct {
+	resolve   flags.BoolFlag
+	noResolve flags.BoolFlag
+}
+
+// NewResolveFlags adds the resolve flags to fs. resolveByDefault describes
+// whether the command resolves domains without --resolve, for the usage.
+func NewResolveFlags(fs *pflag.FlagSet, resolveByDefault bool) ResolveFlags {
+	resolveUsage := "resolve domains given without a port to their IPs, and use those hosts"
+	if resolveByDefault {
+		resolveUsage += " (default)"
+	}
+	return ResolveFlags{
+		resolve:   flags.NewBoolFlag(fs, resolveFlagName, "", false, resolveUsage),
+		noResolve: flags.NewBoolFlag(fs, noResolveFlagName, "", false, "do not resolve domains to IPs"),
+	}
+}
+
+// Value returns whether to resolve domains: true with --resolve, false with
+// --no-resolve, and resolveByDefault with neither.
+func (f ResolveFlags) Value(resolveByDefault bool) (bool, cenclierrors.CencliError) {
+	enabled, err := f.resolve.Value()
+	if err != nil {
+		return false, err
+	}
+	disabled, err := f.noResolve.Value()
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case enabled && disabled:
+		return false, flags.NewConflictingFlagsError(resolveFlagName, noResolveFlagName)
+	case enabled:
+		return true, nil
+	case disabled:
+		return false, nil
+	default:
+		return resolveByDefault, nil
+	}
+}
+
+// Resolution is a domain and the IPs it resolved to.
+type Resolution struct {
+	Domain string   `json:"domain"`
+	IPs    []string `json:"ips"`
+}
+
+// ResolveDomains replaces each bare domain in rawAssets (one without a port,
+// scheme, or path) with the IPs it resolves to. Other assets are kept as they
+// are. It stops at the first domain that cannot be resolved.
+func (c *Context) ResolveDomains(ctx context.Context, rawAssets []string) ([]string, []Resolution, cenclierrors.CencliError) {
+	res := make([]string, 0, len(rawAssets))
+	var resolutions []Resolution
+	for _, raw := range rawAssets {
+		domain, ok := resolve.BareDomain(raw)
+		if !ok {
+			res = append(res, raw)
+			continue
+		}
+		ips, err := c.ResolveDomain(ctx, domain)
+		if err != nil {
+			return nil, nil, err
+		}
+		res = append(res, ips...)
+		resolutions = append(resolutions, Resolution{Domain: domain, IPs: ips})
+	}
+	return res, resolutions, nil
+}
+
+// ResolveDomain resolves domain to its IPs with the resolver in the dns
+// section of the config, and notes the resolution on stderr unless --quiet
+// is set.
+func (c *Context) ResolveDomain(ctx context.Context, domain string) ([]string, cenclierrors.CencliError) {
+	if c.scope != nil {
+		if err := c.checkScope(c.scope.CheckHostname(domain)); err != nil {
+			return nil, err
+		}
+	}
+	resolver := resolve.Resolver{
+		Resolver: strings.ToLower(strings.TrimSpace(c.config.DNS.Resolver)),
+		DoHURL:   c.config.DNS.DoHURL,
+		HTTP:     &http.Client{Timeout: c.config.Timeouts.HTTP},
+	}
+	if err := resolver.Validate(); err != nil {
+		return nil, cenclierrors.NewUsageError(fmt.Errorf("dns.resolver: %w", err))
+	}
+	ips, err := resolver.LookupIP(ctx, domain)
+	if err != nil {
+		return nil, newResolveError(domain, err)
+	}
+	if !c.config.Quiet {
+		msg := fmt.Sprintf("Resolved %s to %s (%s)", domain, strings.Join(ips, ", "), resolver.Name())
+		formatter.Println(formatter.Stderr, styles.GlobalStyles.Comment.Render(msg))
+	}
+	return ips, nil
+}
+
+// ResolveError is returned when a domain cannot be resolved.
+type ResolveError interface{ cenclierrors.CencliError }
+
+type resolveError struct {
+	domain string
+	err    error
+}
+
+var _ ResolveError = &resolveError{}
+
+func newResolveError(domain string, err error) ResolveError {
+	return &resolveError{domain: domain, err: err}
+}
+
+func (e *resolveError) Error() string {
+	return fmt.Sprintf("failed to resolve %s: %v", e.domain, e.err)
+}
+func (e *resolveError) Title() string          { return "DNS Resolution Failed" }
+func (e *resolveError) ShouldPrintUsage() bool { return false }
+func (e *resolveError) Unwrap() error          { return e.err }
@@ -9,16 +9,39 @@ import (
 
 	"github.com/censys/cencli/internal/command"
 	aggregatecmd "github.com/censys/cencli/internal/command/aggregate"
+	aliascmd "github.com/censys/cencli/internal/command/alias"
+	auditcmd "github.com/censys/cencli/internal/command/audit"
+	bannerscmd "github.com/censys/cencli/internal/command/banners"
+	bulkviewcmd "github.com/censys/cencli/internal/command/bulkview"
 	censeyecmd "github.com/censys/cencli/internal/command/censeye"
+	certscmd "github.com/censys/cencli/internal/command/certs"
+	comparecmd "github.com/censys/cencli/internal/command/compare"
 	completioncmd "github.com/censys/cencli/internal/command/completion"
 	configcmd "github.com/censys/cencli/internal/command/config"
 	creditscmd "github.com/censys/cencli/internal/command/credits"
+	devcmd "github.com/censys/cencli/internal/command/dev"
+	doctorcmd "github.com/censys/cencli/internal/command/doctor"
 	enrichcmd "github.com/censys/cencli/internal/command/enrich"
+	graphcmd "github.com/censys/cencli/internal/command/graph"
 	historycmd "github.com/censys/cencli/internal/command/history"
+	huntcmd "github.com/censys/cencli/internal/command/hunt"
+	localcmd "github.com/censys/cencli/internal/command/local"
+	notescmd "github.com/censys/cencli/internal/command/notes"
 	orgcmd "github.com/censys/cencli/internal/command/org"
+	pivotcmd "github.com/censys/cencli/internal/command/pivot"
+	plugincmd "github.com/censys/cencli/internal/command/plugin"
+	raritycmd "github.com/censys/cencli/internal/command/rarity"
+	reportcmd "github.com/censys/cencli/internal/command/report"
 	searchcmd "github.com/censys/cencli/internal/command/search"
+	sessioncmd "github.com/censys/cencli/internal/command/session"
+	setupcmd "github.com/censys/cencli/internal/command/setup"
+	statscmd "github.com/censys/cencli/internal/command/stats"
+	updatecmd "github.com/censys/cencli/internal/command/update"
 	versioncmd "github.com/censys/cencli/internal/command/versioncmd"
 	"github.com/censys/cencli/internal/command/view"
+	webcmd "github.com/censys/cencli/internal/command/web"
+	webpropscmd "github.com/censys/cencli/internal/command/webprops"
+	whoiscmd "github.com/censys/cencli/internal/command/whois"
 	"github.com/censys/cencli/internal/config"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	"github.com/censys/cencli/internal/pkg/censyscopy"
@@ -64,16 +87,39 @@ func (c *Command) Init() error {
 
 	return c.AddSubCommands(
 		view.NewViewCommand(c.Context),
+		bannerscmd.NewBannersCommand(c.Context),
+		bulkviewcmd.NewBulkViewCommand(c.Context),
 		enrichcmd.NewEnrichCommand(c.Context),
 		configcmd.NewConfigCommand(c.Context),
+		setupcmd.NewSetupCommand(c.Context),
 		versioncmd.NewVersionCommand(c.Context),
+		updatecmd.NewUpdateCommand(c.Context),
 		completioncmd.NewCompletionCommand(c.Context),
 		historycmd.NewHistoryCommand(c.Context),
 		searchcmd.NewSearchCommand(c.Context),
 		aggregatecmd.NewAggregateCommand(c.Context),
 		censeyecmd.NewCenseyeCommand(c.Context),
+		pivotcmd.NewPivotCommand(c.Context),
 		creditscmd.NewCreditsCommand(c.Context),
+		doctorcmd.NewDoctorCommand(c.Context),
 		orgcmd.NewOrgCommand(c.Context),
+		plugincmd.NewPluginCommand(c.Context),
+		aliascmd.NewAliasCommand(c.Context),
+		auditcmd.NewAuditCommand(c.Context),
+		comparecmd.NewCompareCommand(c.Context),
+		certscmd.NewCertsCommand(c.Context),
+		sessioncmd.NewSessionCommand(c.Context),
+		notescmd.NewNotesCommand(c.Context),
+		localcmd.NewLocalCommand(c.Context),
+		whoiscmd.NewWhoisCommand(c.Context),
+		huntcmd.NewHuntCommand(c.Context),
+		webcmd.NewWebCommand(c.Context),
+		webpropscmd.NewWebPropsCommand(c.Context),
+		statscmd.NewStatsCommand(c.Context),
+		raritycmd.NewRarityCommand(c.Context),
+		reportcmd.NewReportCommand(c.Context),
+		graphcmd.NewGraphCommand(c.Context),
+		devcmd.NewDevCommand(c.Context, c),
 	)
 }
 
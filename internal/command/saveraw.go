@@ -0,0 +1,54 @@
+package command
+
+import (
+	"net/http"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/pkg/artifacts"
+	clienthttp "github.com/censys/cencli/internal/pkg/clients/http"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// artifactsDirName is the directory of the data directory that --save-raw
+// saves response bodies in.
+const artifactsDirName = "artifacts"
+
+// startSaveRaw saves the raw body of each API response of the command in the
+// artifact store with --save-raw, indexed under the command line.
+func (c *Context) startSaveRaw(cobraCmd *cobra.Command, args []string) {
+	if !c.config.SaveRaw {
+		return
+	}
+	if c.dirs.Data == "" {
+		c.logger.Debug("no data directory to save raw responses in")
+		return
+	}
+	store := artifacts.New(filepath.Join(c.dirs.Data, artifactsDirName))
+	command := commandLine(cobraCmd, args)
+	startedAt := c.Now().UTC()
+	logger := c.logger
+
+	// warn about the first failure only, since the next ones likely share its cause
+	var warnOnce sync.Once
+	record := func(req *http.Request, status int, body []byte) {
+		entry, err := store.Save(artifacts.Entry{
+			Command:   command,
+			StartedAt: startedAt,
+			Method:    req.Method,
+			URL:       req.URL.String(),
+			Status:    status,
+		}, body)
+		if err != nil {
+			warnOnce.Do(func() {
+				formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render("Warning: could not save raw response: "+err.Error()))
+			})
+			return
+		}
+		logger.Debug("saved raw response", "url", entry.URL, "sha256", entry.SHA256, "size", entry.Size)
+	}
+	cobraCmd.SetContext(clienthttp.WithResponseRecorder(cobraCmd.Context(), record))
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/appdirs"
+	"github.com/censys/cencli/internal/pkg/artifacts"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	clienthttp "github.com/censys/cencli/internal/pkg/clients/http"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func TestSaveRaw(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"result":{}}`)
+	}))
+	defer srv.Close()
+
+	run := func(t *testing.T, dataDir string, args ...string) {
+		t.Helper()
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+
+		cmdContext := NewCommandContext(cfg, nil, WithAppDirs(appdirs.Dirs{Data: dataDir}))
+		cmd := newTestCommand(cmdContext)
+		cmd.runFn = func(cobraCmd *cobra.Command, _ []string) cenclierrors.CencliError {
+			req, err := http.NewRequestWithContext(cobraCmd.Context(), http.MethodGet, srv.URL+"/v3/global/asset/host/1.1.1.1", nil)
+			require.NoError(t, err)
+			resp, err := clienthttp.New(time.Second, "cencli-test", nil).Do(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			require.NoError(t, err)
+			assert.Equal(t, `{"result":{}}`, string(body))
+			return nil
+		}
+		rootCmd, cerr := RootCommandToCobra(cmd)
+		require.NoError(t, cerr)
+		require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+		formatter.Stdout = &bytes.Buffer{}
+		formatter.Stderr = &bytes.Buffer{}
+		rootCmd.SetArgs(args)
+		require.NoError(t, rootCmd.Execute())
+	}
+
+	t.Run("saves and indexes each response", func(t *testing.T) {
+		dataDir := t.TempDir()
+		run(t, dataDir, "--save-raw")
+		run(t, dataDir, "--save-raw")
+
+		store := artifacts.New(filepath.Join(dataDir, artifactsDirName))
+		saved, err := os.ReadFile(store.ObjectPath(artifacts.Digest([]byte(`{"result":{}}`))))
+		require.NoError(t, err)
+		assert.Equal(t, `{"result":{}}`, string(saved))
+
+		index, err := os.ReadFile(filepath.Join(store.Dir(), "index.jsonl"))
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(index)), "\n")
+		require.Len(t, lines, 2)
+		var entry artifacts.Entry
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+		assert.Equal(t, "test --save-raw", entry.Command)
+		assert.Equal(t, http.MethodGet, entry.Method)
+		assert.Equal(t, srv.URL+"/v3/global/asset/host/1.1.1.1", entry.URL)
+		assert.Equal(t, http.StatusOK, entry.Status)
+		assert.Equal(t, artifacts.Digest([]byte(`{"result":{}}`)), entry.SHA256)
+	})
+
+	t.Run("saves nothing without the flag", func(t *testing.T) {
+		dataDir := t.TempDir()
+		run(t, dataDir)
+		assert.NoDirExists(t, filepath.Join(dataDir, artifactsDirName))
+	})
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/scope"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// startScope loads scope.file, if set, and wraps the client so that requests
+// for assets and queries out of scope fail, or only warn with
+// --out-of-scope warn. It wraps the client last, so that nothing out of
+// scope is planned by a dry run or answered from the memo.
+func (c *Context) startScope() cenclierrors.CencliError {
+	if c.config.Scope.File == "" || c.scope != nil {
+		return nil
+	}
+	s, err := scope.Load(c.config.Scope.File)
+	if err != nil {
+		return newScopeFileError(c.config.Scope.File, err)
+	}
+	c.scope = s
+	if c.config.Scope.OutOfScope == config.OutOfScopeWarn {
+		c.scopeWarned = map[string]bool{}
+	}
+	if c.censysClient != nil {
+		var warn func(error)
+		if c.scopeWarned != nil {
+			warn = c.warnOutOfScope
+		}
+		c.censysClient = client.NewScopeClient(c.censysClient, c.scope, warn)
+	}
+	return nil
+}
+
+// checkScope returns err, a violation of the scope, unless violations only
+// warn, in which case it is printed once and nil is returned.
+func (c *Context) checkScope(err error) cenclierrors.CencliError {
+	if err == nil {
+		return nil
+	}
+	if c.scopeWarned != nil {
+		c.warnOutOfScope(err)
+		return nil
+	}
+	var cerr cenclierrors.CencliError
+	if errors.As(err, &cerr) {
+		return cerr
+	}
+	return cenclierrors.NewCencliError(err)
+}
+
+// warnOutOfScope prints a violation of the scope to stderr, once per
+// violation, as pages of a search repeat the query.
+func (c *Context) warnOutOfScope(err error) {
+	c.scopeMu.Lock()
+	defer c.scopeMu.Unlock()
+	if c.scopeWarned[err.Error()] {
+		return
+	}
+	c.scopeWarned[err.Error()] = true
+	formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render(
+		fmt.Sprintf("Warning: %v (--out-of-scope warn)", err)))
+}
+
+// ScopeFileError is returned when scope.file cannot be read.
+type ScopeFileError interface{ cenclierrors.CencliError }
+
+type scopeFileError struct {
+	path string
+	err  error
+}
+
+var _ ScopeFileError = &scopeFileError{}
+
+func newScopeFileError(path string, err error) ScopeFileError {
+	return &scopeFileError{path: path, err: err}
+}
+
+func (e *scopeFileError) Error() string {
+	return fmt.Sprintf("failed to load scope.file %s: %v", e.path, e.err)
+}
+
+func (e *scopeFileError) Title() string { return "Invalid Scope File" }
+
+func (e *scopeFileError) ShouldPrintUsage() bool { return false }
+
+func (e *scopeFileError) Unwrap() error { return e.err }
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	"github.com/censys/cencli/internal/config"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func TestStartScope(t *testing.T) {
+	ctx := context.Background()
+	none := mo.None[string]()
+	noTime := mo.None[time.Time]()
+
+	newContext := func(t *testing.T, scopeFile string, mode config.OutOfScopeMode) (*Context, *mocks.MockClient) {
+		t.Helper()
+		viper.Reset()
+		dir := t.TempDir()
+		cfg, err := config.New(dir)
+		require.NoError(t, err)
+		if scopeFile != "" {
+			cfg.Scope.File = filepath.Join(dir, "scope.txt")
+			require.NoError(t, os.WriteFile(cfg.Scope.File, []byte(scopeFile), 0o600))
+		}
+		cfg.Scope.OutOfScope = mode
+		inner := mocks.NewMockClient(gomock.NewController(t))
+		c := NewCommandContext(cfg, nil)
+		c.SetCensysClient(inner)
+		return c, inner
+	}
+
+	t.Run("no scope file", func(t *testing.T) {
+		c, inner := newContext(t, "", config.OutOfScopeFail)
+		require.NoError(t, c.startScope())
+		require.Same(t, inner, c.censysClient)
+	})
+
+	t.Run("requests out of scope fail", func(t *testing.T) {
+		c, inner := newContext(t, "198.51.100.0/24\nexample.com\n", config.OutOfScopeFail)
+		inner.EXPECT().GetHosts(ctx, none, []string{"198.51.100.1"}, noTime).
+			Return(client.Result[[]components.Host]{}, nil)
+		require.NoError(t, c.startScope())
+
+		_, err := c.censysClient.GetHosts(ctx, none, []string{"198.51.100.1"}, noTime)
+		require.NoError(t, err)
+		_, err = c.censysClient.GetHosts(ctx, none, []string{"203.0.113.5"}, noTime)
+		require.EqualError(t, err, "203.0.113.5 is out of scope: no allowed range covers it")
+		_, cerr := c.ResolveDomain(ctx, "evil.org")
+		require.EqualError(t, cerr, "evil.org is out of scope: no allowed domain covers it")
+	})
+
+	t.Run("requests out of scope warn once", func(t *testing.T) {
+		c, inner := newContext(t, "198.51.100.0/24\n", config.OutOfScopeWarn)
+		inner.EXPECT().HostTimeline(ctx, none, "203.0.113.5", time.Time{}, time.Time{}).
+			Return(client.Result[components.HostTimeline]{}, nil).Times(2)
+		var stderr bytes.Buffer
+		formatter.Stderr = &stderr
+		require.NoError(t, c.startScope())
+
+		for range 2 {
+			_, err := c.censysClient.HostTimeline(ctx, none, "203.0.113.5", time.Time{}, time.Time{})
+			require.NoError(t, err)
+		}
+		require.Equal(t, 1, strings.Count(stderr.String(), "Warning: 203.0.113.5 is out of scope"))
+	})
+
+	t.Run("invalid scope file", func(t *testing.T) {
+		c, _ := newContext(t, "198.51.100.0/24\nexample.com/admin\n", config.OutOfScopeFail)
+		err := c.startScope()
+		require.ErrorContains(t, err, `line 2: "example.com/admin" is not an IP address`)
+		require.Equal(t, "Invalid Scope File", err.Title())
+	})
+}
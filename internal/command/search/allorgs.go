@@ -0,0 +1,106 @@
+package search
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/formatter/short"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// allOrgsConflicts are the flags that cannot be combined with --all-orgs:
+// collections belong to a single organization, pagination state cannot span
+// several searches, and the others print results before they are merged.
+var allOrgsConflicts = []string{
+	"collection-id", "all-pages", "count", "page-token", "emit-page-token", "token-file",
+	"group-by", "format", "forward", "ids-only",
+}
+
+// runAllOrgs implements --all-orgs: it runs the search against each
+// organization and prints the hits of all of them, each labeled with its
+// organization. An organization failing does not stop the others.
+func (c *Command) runAllOrgs(cmd *cobra.Command, logger *slog.Logger) cenclierrors.CencliError {
+	ctx := cmd.Context()
+	var xrefErr cenclierrors.CencliError
+	if c.xref, xrefErr = c.LoadXref(ctx, c.feeds); xrefErr != nil {
+		return xrefErr
+	}
+	err := c.WithProgress(
+		ctx,
+		logger,
+		"Fetching search results from each organization...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			orgs, err := c.AccessibleOrgs(pctx)
+			if err != nil {
+				return err
+			}
+			c.orgResults = command.RunForOrgs(pctx, orgs, func(ctx context.Context, org command.Org) (search.Result, cenclierrors.CencliError) {
+				params := c.searchParams()
+				params.OrgID = mo.Some(org.ID)
+				return c.searchSvc.Search(ctx, params)
+			})
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	var hits []assets.Asset
+	for _, res := range c.orgResults {
+		hits = append(hits, res.Result.Hits...)
+	}
+	c.result = search.Result{Hits: hits}
+	if err := c.PrintData(c, c.prepareSearchData()); err != nil {
+		return err
+	}
+	for _, res := range c.orgResults {
+		if res.Result.PartialError != nil {
+			c.ReportPartialError(res.Result.PartialError, cmd)
+		}
+	}
+	if err := command.ReportOrgErrors(c.orgResults); err != nil {
+		return err
+	}
+	return c.checkEmpty(len(hits) == 0)
+}
+
+// prepareOrgsData returns the hits of each organization, wrapped like
+// prepareSearchData and labeled with their organization.
+func (c *Command) prepareOrgsData() []any {
+	data := make([]any, 0, len(c.result.Hits))
+	for _, res := range c.orgResults {
+		for _, hit := range res.Result.Hits {
+			data = append(data, res.Org.Attach(c.wrapHit(hit)))
+		}
+	}
+	return data
+}
+
+// renderOrgsShort renders the hits of each organization in short format,
+// under a header naming the organization.
+func (c *Command) renderOrgsShort() string {
+	b := short.NewBlock()
+	for _, res := range c.orgResults {
+		if res.Err != nil {
+			continue
+		}
+		header := short.NewLine(short.WithLabelStyle(styles.GlobalStyles.Signature))
+		header.Write("Organization", res.Org.String())
+		header.Write("Hits", strconv.Itoa(len(res.Result.Hits)))
+		b.Separator()
+		b.Write(header.String())
+		if out := short.SearchHits(res.Result.Hits, c.shortOptions()...); out != "" {
+			b.Write(out)
+		}
+		b.Newline()
+	}
+	return b.String()
+}
@@ -0,0 +1,123 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/form"
+)
+
+// confirmFunc asks the user a yes/no question.
+// interactive is false when there is no terminal to ask on.
+type confirmFunc func(ctx context.Context, title string) (confirmed bool, interactive bool, err error)
+
+// allPagesDecision is the outcome of the --all-pages preflight.
+type allPagesDecision int
+
+const (
+	// allPagesFetch means the search should paginate to completion.
+	allPagesFetch allPagesDecision = iota
+	// allPagesEmpty means the query has no hits; the (empty) result is already set.
+	allPagesEmpty
+	// allPagesCancelled means the user declined to fetch all pages.
+	allPagesCancelled
+)
+
+// preflightAllPages runs the --all-pages preflight: it counts the matching hits,
+// reports the estimated number of pages, refuses to fetch more than
+// search.page-cap pages, and asks for confirmation if the estimate is above
+// the configured threshold.
+func (c *Command) preflightAllPages(ctx context.Context, logger *slog.Logger) (allPagesDecision, cenclierrors.CencliError) {
+	var preflight search.PreflightResult
+	err := c.WithProgress(
+		ctx,
+		logger,
+		"Counting search results...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			var fetchErr cenclierrors.CencliError
+			preflight, fetchErr = c.searchSvc.Preflight(pctx, c.searchParams())
+			return fetchErr
+		},
+	)
+	if err != nil {
+		return allPagesCancelled, err
+	}
+
+	pageSize := c.pageSize.OrElse(defaultPageSize)
+	pages := search.EstimatePages(preflight.TotalHits, pageSize)
+	logger.Debug("all-pages preflight", "totalHits", preflight.TotalHits, "estimatedPages", pages)
+
+	if preflight.TotalHits == 0 {
+		c.result = search.Result{Meta: preflight.Meta}
+		return allPagesEmpty, nil
+	}
+
+	if !c.Config().Quiet {
+		formatter.Printf(
+			formatter.Stderr,
+			"Query matches %d hits: an estimated %d page requests at %d results per page, after this counting request.\n",
+			preflight.TotalHits, pages, pageSize,
+		)
+	}
+	c.estimatedPages = mo.Some(pages)
+
+	// the cap holds even with --yes, so that no prompt setting makes the
+	// search unbounded
+	if pageCap, ok := c.pageCap.Get(); ok && pages > pageCap {
+		return allPagesCancelled, newPageCapError(pages, pageCap)
+	}
+
+	threshold := c.Config().Search.ConfirmPages
+	if form.AssumeYes() || threshold <= 0 || pages <= uint64(threshold) {
+		return allPagesFetch, nil
+	}
+
+	confirmed, interactive, confirmErr := c.confirm(ctx, fmt.Sprintf("Fetch all %d pages?", pages))
+	if confirmErr != nil {
+		if errors.Is(confirmErr, form.ErrUserAborted) {
+			return allPagesCancelled, nil
+		}
+		return allPagesCancelled, cenclierrors.NewCencliError(confirmErr)
+	}
+	if !interactive {
+		return allPagesCancelled, newConfirmationRequiredError(pages)
+	}
+	if !confirmed {
+		formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render("Search cancelled."))
+		return allPagesCancelled, nil
+	}
+	return allPagesFetch, nil
+}
+
+// terminalConfirm prompts on stderr when the user can be prompted.
+func terminalConfirm(ctx context.Context, title string) (bool, bool, error) {
+	confirmed, err := form.Confirm(ctx, title)
+	if errors.Is(err, form.ErrNonInteractive) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, true, err
+	}
+	return confirmed, true, nil
+}
+
+// warnPageCapReached warns when search.page-cap stopped the search before
+// the results ran out.
+func (c *Command) warnPageCapReached() {
+	pageCap, ok := c.pageCap.Get()
+	if !ok || c.Config().Quiet || c.result.NextPageToken == "" || c.result.Pages < pageCap || c.result.PartialError != nil {
+		return
+	}
+	formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render(fmt.Sprintf(
+		"Warning: stopped after %d pages, the limit of search.page-cap; raise it (or set it to 0) to fetch more, or continue with --token-file.",
+		pageCap,
+	)))
+}
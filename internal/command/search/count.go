@@ -0,0 +1,71 @@
+package search
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+)
+
+// countConflicts are the flags that have no effect with --count.
+var countConflicts = []string{"all-pages", "max-pages", "limit", "page-size", "fields"}
+
+// parseCountFlags parses --count and --fail-on-empty.
+func (c *Command) parseCountFlags() cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.count, err = c.flags.count.Value()
+	if err != nil {
+		return err
+	}
+	c.failOnEmpty, err = c.flags.failOnEmpty.Value()
+	if err != nil {
+		return err
+	}
+	if !c.count {
+		return nil
+	}
+	for _, name := range countConflicts {
+		if c.Flags().Changed(name) {
+			return flags.NewConflictingFlagsError("count", name)
+		}
+	}
+	if c.Config().Streaming {
+		return flags.NewConflictingFlagsError("count", "streaming")
+	}
+	return nil
+}
+
+// runCount implements --count: it makes a single page-size-1 request and
+// prints only the total number of matching hits.
+func (c *Command) runCount(ctx context.Context, logger *slog.Logger) cenclierrors.CencliError {
+	var preflight search.PreflightResult
+	err := c.WithProgress(
+		ctx,
+		logger,
+		"Counting search results...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			var fetchErr cenclierrors.CencliError
+			preflight, fetchErr = c.searchSvc.Preflight(pctx, c.searchParams())
+			return fetchErr
+		},
+	)
+	if err != nil {
+		return err
+	}
+	c.PrintAppResponseMeta(preflight.Meta)
+	c.totalHits = preflight.TotalHits
+	if err := c.PrintData(c, c.totalHits); err != nil {
+		return err
+	}
+	return c.checkEmpty(c.totalHits == 0)
+}
+
+// checkEmpty returns a NoResultsError if --fail-on-empty is set and nothing matched.
+func (c *Command) checkEmpty(empty bool) cenclierrors.CencliError {
+	if c.failOnEmpty && empty {
+		return newNoResultsError(c.query)
+	}
+	return nil
+}
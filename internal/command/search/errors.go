@@ -0,0 +1,203 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/term"
+)
+
+type ConfirmationRequiredError interface {
+	cenclierrors.CencliError
+}
+
+type confirmationRequiredError struct {
+	estimatedPages uint64
+}
+
+var _ ConfirmationRequiredError = &confirmationRequiredError{}
+
+func newConfirmationRequiredError(estimatedPages uint64) ConfirmationRequiredError {
+	return &confirmationRequiredError{estimatedPages: estimatedPages}
+}
+
+func (e *confirmationRequiredError) Error() string {
+	reason := "no terminal is available to confirm"
+	if term.NonInteractiveForced() {
+		reason = "--non-interactive is set"
+	}
+	return fmt.Sprintf(
+		"--all-pages would fetch an estimated %d pages and %s; re-run with --yes to proceed or use --max-pages to bound the search",
+		e.estimatedPages, reason,
+	)
+}
+
+func (e *confirmationRequiredError) Title() string { return "Confirmation Required" }
+
+func (e *confirmationRequiredError) ShouldPrintUsage() bool { return false }
+
+type PageCapError interface {
+	cenclierrors.CencliError
+}
+
+type pageCapError struct {
+	estimatedPages uint64
+	pageCap        uint64
+}
+
+var _ PageCapError = &pageCapError{}
+
+func newPageCapError(estimatedPages, pageCap uint64) PageCapError {
+	return &pageCapError{estimatedPages: estimatedPages, pageCap: pageCap}
+}
+
+func (e *pageCapError) Error() string {
+	return fmt.Sprintf(
+		"--all-pages would fetch an estimated %d pages, more than search.page-cap (%d); narrow the query, use --max-pages to fetch part of it, or raise search.page-cap (e.g. CENCLI_SEARCH_PAGE_CAP=%d)",
+		e.estimatedPages, e.pageCap, e.estimatedPages,
+	)
+}
+
+func (e *pageCapError) Title() string { return "Too Many Pages" }
+
+func (e *pageCapError) ShouldPrintUsage() bool { return false }
+
+type NoResultsError interface {
+	cenclierrors.CencliError
+}
+
+type noResultsError struct {
+	query string
+}
+
+var _ NoResultsError = &noResultsError{}
+
+func newNoResultsError(query string) NoResultsError {
+	return &noResultsError{query: query}
+}
+
+func (e *noResultsError) Error() string {
+	return fmt.Sprintf("no results matched the query %q", e.query)
+}
+
+func (e *noResultsError) Title() string { return "No Results" }
+
+func (e *noResultsError) ShouldPrintUsage() bool { return false }
+
+type EmptyPageTokenError interface {
+	cenclierrors.CencliError
+}
+
+type emptyPageTokenError struct{}
+
+var _ EmptyPageTokenError = &emptyPageTokenError{}
+
+func newEmptyPageTokenError() EmptyPageTokenError {
+	return &emptyPageTokenError{}
+}
+
+func (e *emptyPageTokenError) Error() string {
+	return "--page-token is empty; an empty token file means the previous search already fetched the last page"
+}
+
+func (e *emptyPageTokenError) Title() string { return "Empty Page Token" }
+
+func (e *emptyPageTokenError) ShouldPrintUsage() bool { return false }
+
+// NoCheckpointError is returned by --resume when no interrupted search of
+// the query was recorded.
+type NoCheckpointError interface {
+	cenclierrors.CencliError
+}
+
+type noCheckpointError struct {
+	query string
+}
+
+var _ NoCheckpointError = &noCheckpointError{}
+
+func newNoCheckpointError(query string) NoCheckpointError {
+	return &noCheckpointError{query: query}
+}
+
+func (e *noCheckpointError) Error() string {
+	return fmt.Sprintf("there is no interrupted search of %q to resume", e.query)
+}
+
+func (e *noCheckpointError) Title() string { return "Nothing to Resume" }
+
+func (e *noCheckpointError) ShouldPrintUsage() bool { return false }
+
+type RunNotFoundError interface {
+	cenclierrors.CencliError
+}
+
+type runNotFoundError struct {
+	runID string
+}
+
+var _ RunNotFoundError = &runNotFoundError{}
+
+func newRunNotFoundError(runID string) RunNotFoundError {
+	return &runNotFoundError{runID: runID}
+}
+
+func (e *runNotFoundError) Error() string {
+	if e.runID == lastRunID {
+		return "no search has been saved to refine yet; run a search first"
+	}
+	return fmt.Sprintf("no saved search run %q; only the most recent runs are kept, as set by search.saved-runs", e.runID)
+}
+
+func (e *runNotFoundError) Title() string { return "Run Not Found" }
+
+func (e *runNotFoundError) ShouldPrintUsage() bool { return false }
+
+type RunUnreadableError interface {
+	cenclierrors.CencliError
+}
+
+type runUnreadableError struct {
+	runID string
+	err   error
+}
+
+var _ RunUnreadableError = &runUnreadableError{}
+
+func newRunUnreadableError(runID string, err error) RunUnreadableError {
+	return &runUnreadableError{runID: runID, err: err}
+}
+
+func (e *runUnreadableError) Error() string {
+	return fmt.Sprintf("failed to read saved search run %s: %v", e.runID, e.err)
+}
+
+func (e *runUnreadableError) Title() string { return "Unreadable Run" }
+
+func (e *runUnreadableError) ShouldPrintUsage() bool { return false }
+
+func (e *runUnreadableError) Unwrap() error { return e.err }
+
+type InvalidFieldError interface {
+	cenclierrors.CencliError
+}
+
+type invalidFieldError struct {
+	err error
+}
+
+var _ InvalidFieldError = &invalidFieldError{}
+
+func newInvalidFieldError(err error) InvalidFieldError {
+	return &invalidFieldError{err: err}
+}
+
+func (e *invalidFieldError) Error() string {
+	return fmt.Sprintf("--fields: %v (use --no-field-check to send it anyway)", e.err)
+}
+
+func (e *invalidFieldError) Title() string { return "Invalid Field" }
+
+func (e *invalidFieldError) ShouldPrintUsage() bool { return false }
+
+func (e *invalidFieldError) Unwrap() error { return e.err }
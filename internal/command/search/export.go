@@ -0,0 +1,29 @@
+package search
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+)
+
+// exportConflicts are the flags that cannot be combined with --format. The
+// export always records which services matched, so --highlight has no effect.
+var exportConflicts = []string{"count", "group-by", "highlight"}
+
+// parseExportFlags parses --format, --output, and --append.
+func (c *Command) parseExportFlags(cmd *cobra.Command) cenclierrors.CencliError {
+	target, err := c.flags.export.Value(cmd, c.Config().Streaming)
+	if err != nil {
+		return err
+	}
+	if target.IsPresent() {
+		for _, name := range exportConflicts {
+			if c.Flags().Changed(name) {
+				return flags.NewConflictingFlagsError("format", name)
+			}
+		}
+	}
+	c.export = target
+	return nil
+}
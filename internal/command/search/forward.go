@@ -0,0 +1,27 @@
+package search
+
+import (
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+)
+
+// forwardConflicts are the flags that cannot be combined with --forward,
+// as they replace the hits with something else.
+var forwardConflicts = []string{"count", "group-by", "format"}
+
+// parseForwardFlag parses --forward.
+func (c *Command) parseForwardFlag() cenclierrors.CencliError {
+	sink, err := c.flags.forward.Value()
+	if err != nil {
+		return err
+	}
+	if sink.IsPresent() {
+		for _, name := range forwardConflicts {
+			if c.Flags().Changed(name) {
+				return flags.NewConflictingFlagsError("forward", name)
+			}
+		}
+	}
+	c.forward = sink
+	return nil
+}
@@ -0,0 +1,188 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/formatter/short"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// groupByConflicts are the flags that cannot be combined with --group-by.
+var groupByConflicts = []string{"count"}
+
+// noValueLabel is shown in short output for the group of hits without the field.
+const noValueLabel = "(no value)"
+
+// hitGroup is a bucket of search hits that share a value of the --group-by field.
+type hitGroup struct {
+	// Value is the field value, or nil for hits that do not have the field.
+	Value any   `json:"value"`
+	Count int   `json:"count"`
+	Hits  []any `json:"hits"`
+	// key identifies the group and orders groups with equal counts.
+	key    string
+	assets []assets.Asset
+}
+
+// parseGroupByFlag parses --group-by. Grouping needs every hit before anything
+// is printed, so it cannot be combined with streaming or templates.
+func (c *Command) parseGroupByFlag() cenclierrors.CencliError {
+	groupBy, err := c.flags.groupBy.Value()
+	if err != nil {
+		return err
+	}
+	c.groupBy = strings.TrimSpace(groupBy)
+	if c.groupBy == "" {
+		return nil
+	}
+	for _, name := range groupByConflicts {
+		if c.Flags().Changed(name) {
+			return flags.NewConflictingFlagsError("group-by", name)
+		}
+	}
+	if c.Config().Streaming {
+		return flags.NewConflictingFlagsError("group-by", "streaming")
+	}
+	if c.Config().OutputFormat == formatter.OutputFormatTemplate {
+		return flags.NewConflictingFlagsError("group-by", "output-format=template")
+	}
+	return nil
+}
+
+// groupHits buckets hits by the values of field, a dotted path such as
+// host.location.country. The asset prefix (host., cert., web.) is optional.
+// Hits with several values (e.g. host.services.port) appear in the group of
+// each distinct value. Groups are ordered by descending count, then by value,
+// with hits that do not have the field last.
+func groupHits(hits []assets.Asset, field string) []hitGroup {
+	var groups []*hitGroup
+	byKey := make(map[string]*hitGroup)
+	var missing *hitGroup
+
+	for _, hit := range hits {
+		values := fieldValues(hit, field)
+		if len(values) == 0 {
+			if missing == nil {
+				missing = &hitGroup{}
+			}
+			missing.assets = append(missing.assets, hit)
+			continue
+		}
+		seen := make(map[string]bool, len(values))
+		for _, v := range values {
+			key := v.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			g, ok := byKey[key]
+			if !ok {
+				g = &hitGroup{Value: v.Value(), key: key}
+				byKey[key] = g
+				groups = append(groups, g)
+			}
+			g.assets = append(g.assets, hit)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if len(groups[i].assets) != len(groups[j].assets) {
+			return len(groups[i].assets) > len(groups[j].assets)
+		}
+		return groups[i].key < groups[j].key
+	})
+	if missing != nil {
+		groups = append(groups, missing)
+	}
+
+	result := make([]hitGroup, len(groups))
+	for i, g := range groups {
+		g.Count = len(g.assets)
+		g.Hits = make([]any, len(g.assets))
+		for j, hit := range g.assets {
+			// wrap each hit with its type, as ungrouped results are
+			g.Hits[j] = map[string]any{hit.AssetType().String(): hit}
+		}
+		result[i] = *g
+	}
+	return result
+}
+
+// fieldValues returns the non-null values of field in hit, descending into arrays.
+func fieldValues(hit assets.Asset, field string) []gjson.Result {
+	raw, err := json.Marshal(hit)
+	if err != nil {
+		return nil
+	}
+	path := strings.TrimPrefix(field, assetFieldPrefix(hit.AssetType()))
+	var values []gjson.Result
+	collectValues(gjson.ParseBytes(raw), strings.Split(path, "."), &values)
+	return values
+}
+
+func collectValues(r gjson.Result, path []string, values *[]gjson.Result) {
+	if r.IsArray() {
+		for _, elem := range r.Array() {
+			collectValues(elem, path, values)
+		}
+		return
+	}
+	if len(path) == 0 {
+		if r.Exists() && r.Type != gjson.Null {
+			*values = append(*values, r)
+		}
+		return
+	}
+	if !r.IsObject() {
+		return
+	}
+	collectValues(r.Get(gjson.Escape(path[0])), path[1:], values)
+}
+
+// assetFieldPrefix returns the query field prefix for an asset type.
+func assetFieldPrefix(assetType assets.AssetType) string {
+	switch assetType {
+	case assets.AssetTypeHost:
+		return "host."
+	case assets.AssetTypeCertificate:
+		return "cert."
+	case assets.AssetTypeWebProperty:
+		return "web."
+	default:
+		return ""
+	}
+}
+
+// renderGroupsShort renders each group under a header with its value and count.
+func renderGroupsShort(field string, groups []hitGroup, opts ...short.SearchHitsOption) string {
+	var b strings.Builder
+	for i, g := range groups {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		label := noValueLabel
+		if g.Value != nil {
+			label = g.key
+		}
+		noun := "hits"
+		if g.Count == 1 {
+			noun = "hit"
+		}
+		b.WriteString(fmt.Sprintf("%s = %s %s\n",
+			styles.GlobalStyles.Secondary.Render(field),
+			styles.GlobalStyles.Signature.Render(label),
+			styles.GlobalStyles.Comment.Render(fmt.Sprintf("(%s %s)", short.FormatNumber(int64(g.Count)), noun)),
+		))
+		b.WriteString(short.SearchHits(g.assets, opts...))
+	}
+	return b.String()
+}
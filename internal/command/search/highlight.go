@@ -0,0 +1,105 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+)
+
+// matchedKey is added (as true) to each service of a host hit that matched
+// the query when --highlight is set.
+const matchedKey = "matched"
+
+// parseHighlightFlag parses --highlight, which has nothing to show with --count.
+func (c *Command) parseHighlightFlag() cenclierrors.CencliError {
+	highlight, err := c.flags.highlight.Value()
+	if err != nil {
+		return err
+	}
+	if highlight && c.count {
+		return flags.NewConflictingFlagsError("highlight", "count")
+	}
+	c.highlight = highlight
+	return nil
+}
+
+// wrapHit wraps a hit with its type, as search results are printed. With
+// --highlight, the services of host hits that matched the query are marked,
+// and hits found in a threat feed carry their matches.
+func (c *Command) wrapHit(hit assets.Asset) map[string]any {
+	return map[string]any{hit.AssetType().String(): c.annotateHit(hit)}
+}
+
+// annotateHit returns the hit with its matched services marked (with
+// --highlight) and its threat feed matches attached.
+func (c *Command) annotateHit(hit assets.Asset) any {
+	var annotated any = hit
+	if c.highlight {
+		annotated = annotateMatches(hit)
+	}
+	return command.AttachXref(annotated, c.xref.Match(hit))
+}
+
+// annotateMatches returns the hit with "matched": true set on each of its
+// services that matched the query. Hits other than hosts, and hosts without
+// matched services, are returned unchanged.
+func annotateMatches(hit assets.Asset) any {
+	host, ok := hit.(*assets.Host)
+	if !ok || len(host.MatchedServices) == 0 {
+		return hit
+	}
+	raw, err := json.Marshal(host)
+	if err != nil {
+		return hit
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return hit
+	}
+	services, _ := doc["services"].([]any)
+	for i, svc := range host.Services {
+		if i >= len(services) || !host.IsMatchedService(svc) {
+			continue
+		}
+		if m, ok := services[i].(map[string]any); ok {
+			m[matchedKey] = true
+		}
+	}
+	return doc
+}
+
+// annotatingEmitter annotates each streamed hit like wrapHit.
+type annotatingEmitter struct {
+	inner    streaming.Emitter
+	annotate func(assets.Asset) any
+}
+
+func (e *annotatingEmitter) Emit(ctx context.Context, data any) error {
+	if wrapped, ok := data.(map[string]any); ok {
+		for assetType, hit := range wrapped {
+			if asset, ok := hit.(assets.Asset); ok {
+				wrapped[assetType] = e.annotate(asset)
+			}
+		}
+	}
+	return e.inner.Emit(ctx, data)
+}
+
+func (e *annotatingEmitter) Close(err error) {
+	e.inner.Close(err)
+}
+
+// withAnnotatedStreaming wraps the streaming emitter in ctx (if any) so that
+// streamed hits are annotated like buffered ones.
+func (c *Command) withAnnotatedStreaming(ctx context.Context) context.Context {
+	emitter, ok := streaming.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return streaming.WithEmitter(ctx, &annotatingEmitter{inner: emitter, annotate: c.annotateHit})
+}
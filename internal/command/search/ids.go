@@ -0,0 +1,29 @@
+package search
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+)
+
+// idsConflicts are the flags that cannot be combined with --ids-only, as they
+// replace or annotate the hits.
+var idsConflicts = []string{"count", "group-by", "highlight", "format"}
+
+// parseIDsFlags parses --ids-only and --print0.
+func (c *Command) parseIDsFlags(cmd *cobra.Command) cenclierrors.CencliError {
+	sep, err := c.flags.ids.Value(cmd, c.Config().Streaming)
+	if err != nil {
+		return err
+	}
+	if sep.IsPresent() {
+		for _, name := range idsConflicts {
+			if c.Flags().Changed(name) {
+				return flags.NewConflictingFlagsError("ids-only", name)
+			}
+		}
+	}
+	c.idsSep = sep
+	return nil
+}
@@ -0,0 +1,168 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+const (
+	resumeFlagName = "resume"
+	// checkpointFileName is the file, in the data directory, that the
+	// checkpoint of the last interrupted search is kept in.
+	checkpointFileName = "search-checkpoint.json"
+)
+
+// resumeConflicts are the flags that cannot be combined with --resume, as
+// they either set the page to start at or do not paginate.
+var resumeConflicts = []string{"page-token", "all-pages", "count", "all-orgs"}
+
+// checkpoint is where an interrupted search stopped, so that --resume can
+// continue it from the page it did not finish.
+type checkpoint struct {
+	Query        string    `json:"query"`
+	OrgID        string    `json:"org_id,omitempty"`
+	CollectionID string    `json:"collection_id,omitempty"`
+	PageToken    string    `json:"page_token"`
+	Pages        uint64    `json:"pages"`
+	Hits         int       `json:"hits"`
+	TotalHits    int64     `json:"total_hits"`
+	StoppedAt    time.Time `json:"stopped_at"`
+}
+
+// checkpointPath returns the path of the checkpoint file, or an empty string
+// if there is no data directory to keep it in.
+func (c *Command) checkpointPath() string {
+	if dir := c.Dirs().Data; dir != "" {
+		return filepath.Join(dir, checkpointFileName)
+	}
+	return ""
+}
+
+// parseResumeFlag parses --resume: the search starts at the page the last
+// interrupted search of the same query stopped at, within its organization
+// and collection unless they are given.
+func (c *Command) parseResumeFlag() cenclierrors.CencliError {
+	resume, err := c.flags.resume.Value()
+	if err != nil || !resume {
+		return err
+	}
+	for _, name := range resumeConflicts {
+		if c.Flags().Changed(name) {
+			return flags.NewConflictingFlagsError(resumeFlagName, name)
+		}
+	}
+	cp, ok := c.loadCheckpoint()
+	if !ok || cp.Query != c.query {
+		return newNoCheckpointError(c.query)
+	}
+	c.pageToken = mo.Some(cp.PageToken)
+	if id, parseErr := uuid.Parse(cp.OrgID); parseErr == nil && !c.orgID.IsPresent() {
+		c.orgID = mo.Some(identifiers.NewOrganizationID(id))
+	}
+	if id, parseErr := uuid.Parse(cp.CollectionID); parseErr == nil && !c.collectionID.IsPresent() {
+		c.collectionID = mo.Some(identifiers.NewCollectionID(id))
+	}
+	c.resumed = true
+	return nil
+}
+
+// loadCheckpoint reads the checkpoint of the last interrupted search, if any.
+func (c *Command) loadCheckpoint() (checkpoint, bool) {
+	path := c.checkpointPath()
+	if path == "" {
+		return checkpoint{}, false
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return checkpoint{}, false
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(raw, &cp); err != nil || cp.PageToken == "" {
+		return checkpoint{}, false
+	}
+	return cp, true
+}
+
+// saveCheckpoint records where the search stopped. It reports whether the
+// checkpoint was saved.
+func (c *Command) saveCheckpoint() (bool, cenclierrors.CencliError) {
+	path := c.checkpointPath()
+	if path == "" || c.result.NextPageToken == "" {
+		return false, nil
+	}
+	cp := checkpoint{
+		Query:     c.query,
+		PageToken: c.result.NextPageToken,
+		Pages:     c.result.Pages,
+		Hits:      len(c.result.Hits),
+		TotalHits: c.result.TotalHits,
+		StoppedAt: c.Now().UTC(),
+	}
+	if id, ok := c.orgID.Get(); ok {
+		cp.OrgID = id.String()
+	}
+	if id, ok := c.collectionID.Get(); ok {
+		cp.CollectionID = id.String()
+	}
+	raw, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return false, cenclierrors.NewCencliError(err)
+	}
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		return false, cenclierrors.NewCencliError(fmt.Errorf("failed to write the search checkpoint: %w", err))
+	}
+	return true, nil
+}
+
+// clearCheckpoint removes the checkpoint once a resumed search is done.
+func (c *Command) clearCheckpoint() {
+	if path := c.checkpointPath(); path != "" {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			c.Logger(cmdName).Debug("failed to remove the search checkpoint", "error", err)
+		}
+	}
+}
+
+// interrupted reports how far an interrupted search got, once the results
+// it gathered are printed, and keeps a checkpoint to resume it from. It
+// returns the interruption marked as partial, for its dedicated exit code.
+func (c *Command) interrupted() cenclierrors.CencliError {
+	progress := fmt.Sprintf("Interrupted after %s: %d hits", pluralize(c.result.Pages, "page"), len(c.result.Hits))
+	if c.result.TotalHits > 0 {
+		progress += " of " + formatter.FormatInt(c.result.TotalHits)
+	}
+	formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render(progress+" (partial results)"))
+
+	saved, err := c.saveCheckpoint()
+	if err != nil {
+		formatter.PrintError(err, nil)
+	}
+	switch {
+	case saved:
+		formatter.Printf(formatter.Stderr, "Resume with: censys search --resume %s\n", command.ShellQuote(c.query))
+	case c.result.NextPageToken != "" && c.tokenFile == "" && !c.emitPageToken:
+		formatter.Printf(formatter.Stderr, "Resume with: censys search --page-token %s %s\n", c.result.NextPageToken, command.ShellQuote(c.query))
+	}
+	return c.result.PartialError
+}
+
+func pluralize(n uint64, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,70 @@
+package search
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+// pageTokenConflicts are the flags that cannot be combined with the page token flags.
+var pageTokenConflicts = []string{"all-pages", "count"}
+
+// parsePageTokenFlags parses --page-token, --emit-page-token, and --token-file.
+func (c *Command) parsePageTokenFlags() cenclierrors.CencliError {
+	token, err := c.flags.pageToken.Value()
+	if err != nil {
+		return err
+	}
+	if c.Flags().Changed("page-token") {
+		// An empty token is what --token-file records after the last page;
+		// starting from it would silently restart the search from the beginning.
+		if token == "" {
+			return newEmptyPageTokenError()
+		}
+		c.pageToken = mo.Some(token)
+	}
+	c.emitPageToken, err = c.flags.emitPageToken.Value()
+	if err != nil {
+		return err
+	}
+	c.tokenFile, err = c.flags.tokenFile.Value()
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{"page-token", "emit-page-token", "token-file"} {
+		if !c.Flags().Changed(name) {
+			continue
+		}
+		for _, conflict := range pageTokenConflicts {
+			if c.Flags().Changed(conflict) {
+				return flags.NewConflictingFlagsError(name, conflict)
+			}
+		}
+	}
+	return nil
+}
+
+// writePageToken reports the next page token on stderr (--emit-page-token) and
+// in the token file (--token-file). The token file is always written, and is
+// left empty when there are no more pages.
+func (c *Command) writePageToken() cenclierrors.CencliError {
+	token := c.result.NextPageToken
+	if c.emitPageToken {
+		if token == "" {
+			formatter.Println(formatter.Stderr, "next page token: none (no more pages)")
+		} else {
+			formatter.Printf(formatter.Stderr, "next page token: %s\n", token)
+		}
+	}
+	if c.tokenFile != "" {
+		if err := os.WriteFile(c.tokenFile, []byte(token), 0o600); err != nil {
+			return cenclierrors.NewCencliError(fmt.Errorf("failed to write page token to %s: %w", c.tokenFile, err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/cenql"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+const (
+	refineFlagName = "refine"
+	// lastRunID names the most recently saved run.
+	lastRunID = "last"
+	// runsDirName is the directory, in the cache directory, that the hits of
+	// recent searches are kept in for --refine.
+	runsDirName = "search-runs"
+	// runIDLayout names runs after the time they were saved, so that they
+	// sort in the order they were saved.
+	runIDLayout = "20060102-150405"
+)
+
+// refineConflicts are the flags that cannot be combined with --refine, as
+// they only apply to requests to the API.
+var refineConflicts = []string{
+	"org-id", "collection-id", "fields", "page-size", "max-pages", "limit", "all-pages",
+	"count", "page-token", "emit-page-token", "token-file", resumeFlagName, command.AllOrgsFlagName,
+}
+
+// savedRun is the hits of a search, kept so that --refine can filter them
+// without searching again.
+type savedRun struct {
+	ID      string    `json:"id"`
+	Query   string    `json:"query"`
+	SavedAt time.Time `json:"saved_at"`
+	// Hits are wrapped in their asset type, as in the output of search.
+	Hits []map[assets.AssetType]json.RawMessage `json:"hits"`
+}
+
+// runsDir returns the directory that runs are saved in, or an empty string
+// if there is no cache directory to keep them in.
+func (c *Command) runsDir() string {
+	if dir := c.Dirs().Cache; dir != "" {
+		return filepath.Join(dir, runsDirName)
+	}
+	return ""
+}
+
+// parseRefineFlag parses --refine: the query is matched locally against the
+// hits of a saved run instead of being sent to the API.
+func (c *Command) parseRefineFlag() cenclierrors.CencliError {
+	runID, err := c.flags.refine.Value()
+	if err != nil || runID == "" {
+		return err
+	}
+	for _, name := range refineConflicts {
+		if c.Flags().Changed(name) {
+			return flags.NewConflictingFlagsError(refineFlagName, name)
+		}
+	}
+	node, parseErr := cenql.Parse(c.query)
+	if parseErr != nil {
+		return command.NewInvalidQueryError(parseErr)
+	}
+	run, loadErr := c.loadRun(runID)
+	if loadErr != nil {
+		return loadErr
+	}
+	c.refine = mo.Some(refinement{run: run, filter: node})
+	return nil
+}
+
+// refinement is the run that --refine filters and the parsed query it filters with.
+type refinement struct {
+	run    savedRun
+	filter cenql.Node
+}
+
+// refineHits returns the hits of the refined run that match the query, or
+// emits them when streaming.
+func (c *Command) refineHits(ctx context.Context, r refinement) (search.Result, cenclierrors.CencliError) {
+	var result search.Result
+	for i, wrapped := range r.run.Hits {
+		doc := make(map[string]any, len(wrapped))
+		for assetType, raw := range wrapped {
+			var asset any
+			if err := json.Unmarshal(raw, &asset); err != nil {
+				return search.Result{}, newRunUnreadableError(r.run.ID, fmt.Errorf("hit %d: %w", i+1, err))
+			}
+			doc[assetType.String()] = asset
+		}
+		ok, err := cenql.Match(r.filter, doc)
+		if err != nil {
+			return search.Result{}, command.NewInvalidQueryError(err)
+		}
+		if !ok {
+			continue
+		}
+		hit, err := decodeHit(wrapped)
+		if err != nil {
+			return search.Result{}, newRunUnreadableError(r.run.ID, fmt.Errorf("hit %d: %w", i+1, err))
+		}
+		if streaming.IsStreaming(ctx) {
+			if err := streaming.Emit(ctx, map[string]any{hit.AssetType().String(): hit}); err != nil {
+				return search.Result{}, cenclierrors.NewCencliError(err)
+			}
+		} else {
+			result.Hits = append(result.Hits, hit)
+		}
+		result.TotalHits++
+	}
+	return result, nil
+}
+
+// decodeHit decodes a hit wrapped in its asset type.
+func decodeHit(wrapped map[assets.AssetType]json.RawMessage) (assets.Asset, error) {
+	for assetType, raw := range wrapped {
+		var asset assets.Asset
+		switch assetType {
+		case assets.AssetTypeHost:
+			asset = &assets.Host{}
+		case assets.AssetTypeCertificate:
+			asset = &assets.Certificate{}
+		case assets.AssetTypeWebProperty:
+			asset = &assets.WebProperty{}
+		default:
+			return nil, fmt.Errorf("unknown asset type %q", assetType)
+		}
+		if err := json.Unmarshal(raw, asset); err != nil {
+			return nil, err
+		}
+		return asset, nil
+	}
+	return nil, errors.New("the hit is empty")
+}
+
+// loadRun reads the run with the given ID, or the latest run for "last".
+func (c *Command) loadRun(runID string) (savedRun, cenclierrors.CencliError) {
+	dir := c.runsDir()
+	if dir == "" {
+		return savedRun{}, newRunNotFoundError(runID)
+	}
+	if runID == lastRunID {
+		ids := c.savedRunIDs()
+		if len(ids) == 0 {
+			return savedRun{}, newRunNotFoundError(runID)
+		}
+		runID = ids[len(ids)-1]
+	}
+	if strings.ContainsAny(runID, `/\`) || strings.HasPrefix(runID, ".") {
+		return savedRun{}, newRunNotFoundError(runID)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, runID+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return savedRun{}, newRunNotFoundError(runID)
+	} else if err != nil {
+		return savedRun{}, newRunUnreadableError(runID, err)
+	}
+	var run savedRun
+	if err := json.Unmarshal(raw, &run); err != nil {
+		return savedRun{}, newRunUnreadableError(runID, err)
+	}
+	return run, nil
+}
+
+// savedRunIDs returns the IDs of the saved runs, oldest first.
+func (c *Command) savedRunIDs() []string {
+	entries, err := os.ReadDir(c.runsDir())
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for _, entry := range entries {
+		if id, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// saveRun keeps the hits of the search for --refine, and removes the oldest
+// runs beyond search.saved-runs. It returns the ID of the run, if it was
+// saved. Saving is best-effort and never fails the search.
+func (c *Command) saveRun() mo.Option[string] {
+	keep := c.Config().Search.SavedRuns
+	dir := c.runsDir()
+	if dir == "" || keep <= 0 || len(c.result.Hits) == 0 {
+		return mo.None[string]()
+	}
+	logger := c.Logger(cmdName)
+
+	run := savedRun{Query: c.query, SavedAt: c.Now().UTC()}
+	if r, ok := c.refine.Get(); ok {
+		run.Query = fmt.Sprintf("(%s) and (%s)", r.run.Query, c.query)
+	}
+	for _, hit := range c.result.Hits {
+		raw, err := json.Marshal(hit)
+		if err != nil {
+			logger.Debug("failed to encode hit for --refine", "error", err)
+			return mo.None[string]()
+		}
+		run.Hits = append(run.Hits, map[assets.AssetType]json.RawMessage{hit.AssetType(): raw})
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		logger.Debug("failed to create the search runs directory", "error", err)
+		return mo.None[string]()
+	}
+	// runs saved within the same second get a suffix
+	base := run.SavedAt.Format(runIDLayout)
+	run.ID = base
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(dir, run.ID+".json")); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		run.ID = fmt.Sprintf("%s-%d", base, n)
+	}
+	raw, err := json.Marshal(run)
+	if err != nil {
+		logger.Debug("failed to encode run for --refine", "error", err)
+		return mo.None[string]()
+	}
+	if err := os.WriteFile(filepath.Join(dir, run.ID+".json"), raw, 0o600); err != nil {
+		logger.Debug("failed to save run for --refine", "error", err)
+		return mo.None[string]()
+	}
+
+	ids := c.savedRunIDs()
+	for len(ids) > int(keep) {
+		if err := os.Remove(filepath.Join(dir, ids[0]+".json")); err != nil {
+			logger.Debug("failed to remove old run", "run", ids[0], "error", err)
+		}
+		ids = ids[1:]
+	}
+	return mo.Some(run.ID)
+}
+
+// announceRun tells the user how to refine the saved run.
+func (c *Command) announceRun(runID string) {
+	if c.Config().Quiet {
+		return
+	}
+	formatter.Printf(formatter.Stderr, "Saved as run %s; search within it with: censys search --refine %s <query>\n", runID, runID)
+}
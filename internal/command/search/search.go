@@ -13,11 +13,13 @@ import (
 	"github.com/censys/cencli/internal/config"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/fieldcatalog"
 	"github.com/censys/cencli/internal/pkg/flags"
 	"github.com/censys/cencli/internal/pkg/formatter"
 	"github.com/censys/cencli/internal/pkg/formatter/short"
 	"github.com/censys/cencli/internal/pkg/styles"
 	"github.com/censys/cencli/internal/pkg/tape"
+	"github.com/censys/cencli/internal/pkg/xref"
 )
 
 const (
@@ -35,6 +37,8 @@ type Command struct {
 	*command.BaseCommand
 	// services the command uses
 	searchSvc search.Service
+	// confirm asks the user to confirm large --all-pages searches
+	confirm confirmFunc
 	// flags the command uses
 	flags searchCommandFlags
 	// state - populated by PreRun (through flags, args, etc.)
@@ -44,17 +48,67 @@ type Command struct {
 	orgID        mo.Option[identifiers.OrganizationID]
 	pageSize     mo.Option[uint64]
 	maxPages     mo.Option[uint64]
+	limit        mo.Option[uint64]
+	allPages     bool
+	count        bool
+	failOnEmpty  bool
+	groupBy      string
+	highlight    bool
+	feeds        []xref.Source
+	xref         *xref.Matcher
+	export       mo.Option[command.ExportTarget]
+	forward      mo.Option[command.ForwardTarget]
+	// idsSep ends each identifier with --ids-only
+	idsSep mo.Option[byte]
+	// pagination checkpointing
+	pageToken     mo.Option[string]
+	emitPageToken bool
+	tokenFile     string
+	// resumed is set when --resume continues an interrupted search
+	resumed bool
+	// estimatedPages is set by the --all-pages preflight
+	estimatedPages mo.Option[uint64]
+	// pageCap is search.page-cap, when it bounds an --all-pages search
+	pageCap mo.Option[uint64]
+	// allOrgs runs the search against each organization
+	allOrgs bool
+	// explain prints the parsed query instead of running it
+	explain bool
+	// refine filters the hits of a saved run instead of searching
+	refine mo.Option[refinement]
+	// orgResults are the results of each organization, with --all-orgs
+	orgResults []command.OrgResult[search.Result]
 	// result stores the search result for rendering
 	result search.Result
+	// totalHits stores the --count result for rendering
+	totalHits int64
 }
 
 // searchCommandFlags contains all flag handles used by the search command.
 type searchCommandFlags struct {
-	orgID        flags.OrgIDFlag
-	collectionID flags.UUIDFlag
-	fields       flags.StringSliceFlag
-	pageSize     flags.IntegerFlag
-	maxPages     flags.IntegerFlag
+	orgID         flags.OrgIDFlag
+	collectionID  flags.UUIDFlag
+	fields        flags.StringSliceFlag
+	noFieldCheck  flags.BoolFlag
+	pageSize      flags.IntegerFlag
+	maxPages      flags.IntegerFlag
+	limit         flags.IntegerFlag
+	allPages      flags.BoolFlag
+	count         flags.BoolFlag
+	failOnEmpty   flags.BoolFlag
+	groupBy       flags.StringFlag
+	highlight     flags.BoolFlag
+	xref          command.XrefFlags
+	export        command.ExportFlags
+	forward       command.ForwardFlags
+	ids           command.IDsFlags
+	pageToken     flags.StringFlag
+	emitPageToken flags.BoolFlag
+	tokenFile     flags.StringFlag
+	resume        flags.BoolFlag
+	allOrgs       flags.BoolFlag
+	explain       flags.BoolFlag
+	refine        flags.StringFlag
 }
 
 var _ command.Command = (*Command)(nil)
@@ -62,6 +116,7 @@ var _ command.Command = (*Command)(nil)
 func NewSearchCommand(cmdContext *command.Context) *Command {
 	return &Command{
 		BaseCommand: command.NewBaseCommand(cmdContext),
+		confirm:     terminalConfirm,
 	}
 }
 
@@ -101,6 +156,20 @@ func (c *Command) Examples() []string {
 		`--collection-id <your-collection-id> "host.services.protocol=SSH"`,
 		`--page-size 50 --max-pages 5 "cert.names=censys.com"`,
 		`--max-pages -1 "host.services.port: 443 and host.location.country: Germany"`,
+		`--limit 250 "host.services.protocol=SSH"`,
+		`--all-pages "host.services.protocol=MODBUS"`,
+		`--count "host.services.software.product=nginx"`,
+		`--group-by host.location.country --max-pages 3 "host.services.protocol=RDP"`,
+		`--page-token "$(cat token.txt)" --token-file token.txt "host.services.port=502"`,
+		`--format es-bulk --es-index scans-{type} "host.services.protocol=RDP" | curl -s -H 'Content-Type: application/x-ndjson' --data-binary @- localhost:9200/_bulk`,
+		`--format json --output-file s3://my-bucket/scans/rdp.json.gz "host.services.protocol=RDP"`,
+		`--max-pages -1 --format nmap-xml --output rdp.xml "host.services.protocol=RDP"`,
+		`--xref c2=https://example.com/c2-ips.txt -O short "host.services.protocol=HTTP"`,
+		`--max-pages -1 --format target-list --target-style ip --output targets.txt "host.services.protocol=RDP" && nmap -p 3389 -iL targets.txt`,
+		`--ids-only --print0 "web.hostname: example.com" | censys view --input-file -`,
+		`--all-orgs -O short "host.services.protocol=RDP"`,
+		`--explain "host.services: (port: 22 and protocol: SSH) or web.hostname: example.com"`,
+		`--refine last "host.services: (port: 22 and protocol: SSH)"`,
 	}
 }
 
@@ -121,7 +190,14 @@ func (c *Command) Init() error {
 		"fields",
 		"f",
 		[]string{},
-		"fields to return in response (optional)",
+		"fields to return in response, checked against the known fields; * matches within a segment, e.g. host.services.tls.* (optional)",
+	)
+	c.flags.noFieldCheck = flags.NewBoolFlag(
+		c.Flags(),
+		"no-field-check",
+		"",
+		false,
+		"send --fields as given, without checking them or expanding wildcards (for fields newer than this version)",
 	)
 	// Use config-backed defaults for pagination
 	defaultPS := int64(defaultPageSize)
@@ -152,6 +228,96 @@ func (c *Command) Init() error {
 		mo.None[int64](), // allow custom validation in PreRun (to support -1)
 		mo.None[int64](), // no maximum
 	)
+	c.flags.limit = flags.NewIntegerFlag(
+		c.Flags(),
+		false,
+		"limit",
+		"l",
+		mo.None[int64](),
+		"stop once this many hits are collected, fetching as many pages as needed (unless --max-pages is set) and no more hits than needed",
+		mo.Some[int64](1),
+		mo.None[int64](), // no maximum
+	)
+	c.flags.allPages = flags.NewBoolFlag(
+		c.Flags(),
+		"all-pages",
+		"",
+		false,
+		"count matching hits first, then fetch every page (asks for confirmation on large result sets)",
+	)
+	c.flags.count = flags.NewBoolFlag(
+		c.Flags(),
+		"count",
+		"",
+		false,
+		"only print the number of matching hits (a single minimal request)",
+	)
+	c.flags.failOnEmpty = flags.NewBoolFlag(
+		c.Flags(),
+		"fail-on-empty",
+		"",
+		false,
+		"exit with a non-zero status if the query matches nothing",
+	)
+	c.flags.pageToken = flags.NewStringFlag(
+		c.Flags(),
+		false,
+		"page-token",
+		"",
+		"",
+		"start the search at the page identified by this token (from --emit-page-token or --token-file)",
+	)
+	c.flags.emitPageToken = flags.NewBoolFlag(
+		c.Flags(),
+		"emit-page-token",
+		"",
+		false,
+		"print the token of the next page to stderr after the search",
+	)
+	c.flags.groupBy = flags.NewStringFlag(
+		c.Flags(),
+		false,
+		"group-by",
+		"g",
+		"",
+		"group the fetched hits by the values of a field, e.g. host.location.country",
+	)
+	c.flags.highlight = flags.NewBoolFlag(
+		c.Flags(),
+		"highlight",
+		"",
+		false,
+		"mark the services of host hits that matched the query",
+	)
+	c.flags.xref = command.NewXrefFlags(c.Flags())
+	c.flags.export = command.NewExportFlags(c.Flags())
+	c.flags.forward = command.NewForwardFlags(c.Flags())
+	c.flags.ids = command.NewIDsFlags(c.Flags())
+	c.flags.tokenFile = flags.NewStringFlag(
+		c.Flags(),
+		false,
+		"token-file",
+		"",
+		"",
+		"write the token of the next page to this file (empty when there are no more pages)",
+	)
+	c.flags.resume = flags.NewBoolFlag(
+		c.Flags(),
+		resumeFlagName,
+		"",
+		false,
+		"continue the last interrupted search of the query from the page it stopped at",
+	)
+	c.flags.allOrgs = command.NewAllOrgsFlag(c.Flags())
+	c.flags.explain = command.NewExplainFlag(c.Flags())
+	c.flags.refine = flags.NewStringFlag(
+		c.Flags(),
+		false,
+		refineFlagName,
+		"",
+		"",
+		"match the query locally against the hits of a previous search, by run ID or \"last\", instead of searching again",
+	)
 	return nil
 }
 
@@ -160,6 +326,11 @@ func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliE
 	// args have already been validated
 	c.query = args[0]
 
+	// --explain only parses the query, so nothing else is needed
+	var err cenclierrors.CencliError
+	if c.explain, err = c.flags.explain.Value(); err != nil || c.explain {
+		return err
+	}
 	if err := c.parseOrgIDFlag(); err != nil {
 		return err
 	}
@@ -172,11 +343,49 @@ func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliE
 	if err := c.parseFieldsFlag(); err != nil {
 		return err
 	}
+	if err := c.parseCountFlags(); err != nil {
+		return err
+	}
+	if err := c.parsePageTokenFlags(); err != nil {
+		return err
+	}
+	if err := c.parseResumeFlag(); err != nil {
+		return err
+	}
+	if err := c.parseGroupByFlag(); err != nil {
+		return err
+	}
+	if err := c.parseHighlightFlag(); err != nil {
+		return err
+	}
+	if err := c.parseXrefFlags(); err != nil {
+		return err
+	}
+	if err := c.parseExportFlags(cmd); err != nil {
+		return err
+	}
+	if err := c.parseForwardFlag(); err != nil {
+		return err
+	}
+	if err := c.parseIDsFlags(cmd); err != nil {
+		return err
+	}
+	allOrgs, err := c.ParseAllOrgsFlag(cmd, c.flags.allOrgs, allOrgsConflicts...)
+	if err != nil {
+		return err
+	}
+	c.allOrgs = allOrgs
+	if err := c.parseRefineFlag(); err != nil || c.refine.IsPresent() {
+		return err
+	}
 	return c.resolveSearchService()
 }
 
 // Run executes the command by calling the search service and rendering results.
 func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	if c.explain {
+		return c.ExplainQuery(c.query)
+	}
 	logger := c.Logger(cmdName).With(
 		"orgID_set", c.orgID.IsPresent(),
 		"collectionID_set", c.collectionID.IsPresent(),
@@ -185,7 +394,30 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 		"maxPages_set", c.maxPages.IsPresent(),
 		"query", c.query,
 	)
-	if !c.Config().Quiet && !c.maxPages.IsPresent() {
+	if c.count {
+		return c.runCount(cmd.Context(), logger)
+	}
+	if c.allOrgs {
+		return c.runAllOrgs(cmd, logger)
+	}
+	if c.allPages {
+		decision, err := c.preflightAllPages(cmd.Context(), logger)
+		if err != nil {
+			return err
+		}
+		switch decision {
+		case allPagesCancelled:
+			return nil
+		case allPagesEmpty:
+			c.PrintAppResponseMeta(c.result.Meta)
+			if !c.idsSep.IsPresent() {
+				if err := c.PrintData(c, c.prepareSearchData()); err != nil {
+					return err
+				}
+			}
+			return c.checkEmpty(true)
+		}
+	} else if !c.Config().Quiet && !c.maxPages.IsPresent() && !c.limit.IsPresent() && !c.refine.IsPresent() {
 		msg := styles.GlobalStyles.Warning.Render("Warning: fetching all pages (--max-pages=-1). This may take a while and increase API usage.")
 		formatter.Println(formatter.Stderr, msg)
 		logger.Debug("fetching all pages", "message", msg)
@@ -194,8 +426,22 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 	// Set up streaming output (no-op for non-streaming formats)
 	ctx, stopStreaming := c.WithStreamingOutput(cmd.Context(), logger)
 	defer stopStreaming(nil)
+	if !c.export.IsPresent() && !c.idsSep.IsPresent() {
+		var xrefErr cenclierrors.CencliError
+		if c.xref, xrefErr = c.LoadXref(ctx, c.feeds); xrefErr != nil {
+			return xrefErr
+		}
+	}
+	if c.highlight || c.xref != nil {
+		ctx = c.withAnnotatedStreaming(ctx)
+	}
+	ctx, stopForwarding, err := c.StartForwarding(ctx, logger, c.forward, cmdName)
+	if err != nil {
+		return err
+	}
+	defer stopForwarding()
 
-	err := c.WithProgress(
+	err = c.WithProgress(
 		ctx,
 		logger,
 		"Fetching search results...",
@@ -213,54 +459,142 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 	// Print response metadata
 	c.PrintAppResponseMeta(c.result.Meta)
 
-	// PrintData handles streaming vs buffered automatically
-	data := c.prepareSearchData()
-	if renderErr := c.PrintData(c, data); renderErr != nil {
-		return renderErr
+	// The hits gathered before an interrupt are still printed, so the
+	// command's context, which is cancelled by then, must not stop them.
+	interrupted := cenclierrors.IsInterrupted(c.result.PartialError)
+	printCtx := cmd.Context()
+	if interrupted {
+		printCtx = context.WithoutCancel(printCtx)
+	}
+	if err := c.printHits(printCtx); err != nil {
+		return err
+	}
+	if err := stopForwarding(); err != nil {
+		return err
 	}
 
 	// If there was a partial error, print it to stderr after rendering the data
-	if c.result.PartialError != nil {
-		formatter.PrintError(c.result.PartialError, cmd)
+	if c.result.PartialError != nil && !interrupted {
+		c.ReportPartialError(c.result.PartialError, cmd)
 	}
+	c.warnPageCapReached()
 
-	return nil
+	if err := c.writePageToken(); err != nil {
+		return err
+	}
+	if interrupted {
+		return c.interrupted()
+	}
+	if runID, ok := c.saveRun().Get(); ok {
+		c.announceRun(runID)
+	}
+	if c.resumed {
+		c.clearCheckpoint()
+	}
+
+	return c.checkEmpty(len(c.result.Hits) == 0)
+}
+
+// printHits exports the hits with --format, prints their identifiers with
+// --ids-only, or prints them in the output format.
+func (c *Command) printHits(ctx context.Context) cenclierrors.CencliError {
+	if target, ok := c.export.Get(); ok {
+		return c.ExportAssets(ctx, target, cmdName, c.query, c.result.Hits)
+	}
+	if sep, ok := c.idsSep.Get(); ok {
+		return command.PrintIDs(c.result.Hits, sep)
+	}
+	// PrintData handles streaming vs buffered automatically
+	return c.PrintData(c, c.prepareSearchData())
 }
 
 func (c *Command) fetchSearchResult(ctx context.Context) (search.Result, cenclierrors.CencliError) {
-	params := search.Params{
-		OrgID:        c.orgID,
-		CollectionID: c.collectionID,
-		Query:        c.query,
-		Fields:       c.fields,
-		PageSize:     c.pageSize,
-		MaxPages:     c.maxPages,
+	if r, ok := c.refine.Get(); ok {
+		return c.refineHits(ctx, r)
 	}
+	return c.searchSvc.Search(ctx, c.searchParams())
+}
 
-	return c.searchSvc.Search(ctx, params)
+func (c *Command) searchParams() search.Params {
+	return search.Params{
+		OrgID:          c.orgID,
+		CollectionID:   c.collectionID,
+		Query:          c.query,
+		Fields:         c.fields,
+		PageSize:       c.pageSize,
+		MaxPages:       c.maxPages,
+		Limit:          c.limit,
+		PageToken:      c.pageToken,
+		EstimatedPages: c.estimatedPages,
+	}
 }
 
 // prepareSearchData wraps each hit with its type to help differentiate in the output.
-func (c *Command) prepareSearchData() []any {
+// With --group-by, it returns the groups instead. With --all-orgs, each hit
+// is labeled with its organization.
+func (c *Command) prepareSearchData() any {
+	if c.allOrgs {
+		return c.prepareOrgsData()
+	}
+	if c.groupBy != "" {
+		groups := groupHits(c.result.Hits, c.groupBy)
+		for i := range groups {
+			for j, hit := range groups[i].assets {
+				groups[i].Hits[j] = c.wrapHit(hit)
+			}
+		}
+		return groups
+	}
 	data := make([]any, len(c.result.Hits))
 	for i, hit := range c.result.Hits {
-		data[i] = map[string]any{
-			hit.AssetType().String(): hit,
-		}
+		data[i] = c.wrapHit(hit)
 	}
 	return data
 }
 
+// shortOptions returns the options for rendering hits in short format.
+func (c *Command) shortOptions() []short.SearchHitsOption {
+	if c.highlight {
+		return []short.SearchHitsOption{short.WithMatchHighlight()}
+	}
+	return nil
+}
+
 // RenderTemplate renders search results using a handlebars template.
 func (c *Command) RenderTemplate() cenclierrors.CencliError {
+	if c.count {
+		return c.RenderShort()
+	}
 	data := c.prepareSearchData()
 	return c.PrintDataWithTemplate(config.TemplateEntitySearchResult, data)
 }
 
 // RenderShort renders search results in short format.
 func (c *Command) RenderShort() cenclierrors.CencliError {
-	output := short.SearchHits(c.result.Hits)
+	if c.count {
+		formatter.Println(formatter.Stdout, c.totalHits)
+		return nil
+	}
+	if c.groupBy != "" {
+		formatter.Println(formatter.Stdout, renderGroupsShort(c.groupBy, groupHits(c.result.Hits, c.groupBy), c.shortOptions()...))
+		return nil
+	}
+	var output string
+	if c.allOrgs {
+		output = c.renderOrgsShort()
+	} else {
+		output = short.SearchHits(c.result.Hits, c.shortOptions()...)
+	}
 	formatter.Println(formatter.Stdout, output)
+	if c.xref != nil {
+		matches := make([][]xref.Match, len(c.result.Hits))
+		for i, hit := range c.result.Hits {
+			matches[i] = c.xref.Match(hit)
+		}
+		if summary := short.XrefMatches(c.result.Hits, matches); summary != "" {
+			formatter.Printf(formatter.Stdout, "%s", summary)
+		}
+	}
 	return nil
 }
 
@@ -311,6 +645,25 @@ func (c *Command) parsePaginationFlags() cenclierrors.CencliError {
 	if err != nil {
 		return err
 	}
+	c.allPages, err = c.flags.allPages.Value()
+	if err != nil {
+		return err
+	}
+	limit, err := c.flags.limit.Value()
+	if err != nil {
+		return err
+	}
+	if c.allPages {
+		if c.Flags().Changed("max-pages") {
+			return flags.NewConflictingFlagsError("all-pages", "max-pages")
+		}
+		if limit.IsPresent() {
+			return flags.NewConflictingFlagsError("all-pages", "limit")
+		}
+		c.maxPages = mo.None[uint64]()
+		c.applyPageCap()
+		return nil
+	}
 	if maxPages.IsPresent() {
 		// Support -1 for unlimited pages; 0 and negatives (except -1) invalid
 		switch v := maxPages.MustGet(); {
@@ -323,16 +676,61 @@ func (c *Command) parsePaginationFlags() cenclierrors.CencliError {
 			c.maxPages = mo.Some(uint64(v))
 		}
 	}
+	if limit.IsPresent() {
+		c.applyLimit(uint64(limit.MustGet()))
+	}
 	return nil
 }
 
-// parseFieldsFlag parses the optional fields flag into c.fields.
+// applyPageCap bounds an --all-pages search to search.page-cap pages, so
+// that no confirmation or --yes can make it paginate without end. Only
+// raising the cap fetches more.
+func (c *Command) applyPageCap() {
+	limit := c.Config().Search.PageCap
+	if limit <= 0 {
+		return
+	}
+	c.pageCap = mo.Some(uint64(limit))
+	c.maxPages = c.pageCap
+}
+
+// applyLimit sets up --limit: pages are fetched until the limit is reached,
+// unless --max-pages is set, and the page size is lowered to the limit when
+// it is smaller, unless --page-size is set, so that a small limit is a
+// single small request. The number of pages is estimated for the progress.
+func (c *Command) applyLimit(limit uint64) {
+	c.limit = mo.Some(limit)
+	if !c.Flags().Changed("page-size") && c.pageSize.OrElse(defaultPageSize) > limit {
+		c.pageSize = mo.Some(limit)
+	}
+	if c.Flags().Changed("max-pages") {
+		return
+	}
+	c.maxPages = mo.None[uint64]()
+	c.estimatedPages = mo.Some(search.EstimatePages(int64(limit), c.pageSize.OrElse(defaultPageSize)))
+}
+
+// parseFieldsFlag parses the optional fields flag into c.fields. Unless
+// --no-field-check is set, the fields are checked against the field catalog
+// and their wildcards expanded, so that a typo fails before any request.
 func (c *Command) parseFieldsFlag() cenclierrors.CencliError {
 	var err cenclierrors.CencliError
 	c.fields, err = c.flags.fields.Value()
 	if err != nil {
 		return err
 	}
+	noFieldCheck, err := c.flags.noFieldCheck.Value()
+	if err != nil {
+		return err
+	}
+	if len(c.fields) == 0 || noFieldCheck {
+		return nil
+	}
+	expanded, expandErr := fieldcatalog.Default().Expand(c.fields)
+	if expandErr != nil {
+		return newInvalidFieldError(expandErr)
+	}
+	c.fields = expanded
 	return nil
 }
 
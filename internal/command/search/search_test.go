@@ -2,23 +2,41 @@ package search
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/samber/mo"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	orgmocks "github.com/censys/cencli/gen/app/organizations/mocks"
 	searchmocks "github.com/censys/cencli/gen/app/search/mocks"
 	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/app/organizations"
 	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/appdirs"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
 	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/xref"
 	"github.com/censys/cencli/internal/store"
 	"github.com/censys/censys-sdk-go/models/components"
 )
@@ -558,3 +576,2255 @@ func TestSearchCommand_Streaming(t *testing.T) {
 		// This test verifies that the command runs successfully with streaming mode
 	})
 }
+
+func TestSearchCommand_AllPages(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{
+		Method:  "POST",
+		URL:     "https://api.censys.io/v1/search",
+		Status:  200,
+		Latency: 100 * time.Millisecond,
+	}
+	hostResult := search.Result{
+		Meta: meta,
+		Hits: []assets.Asset{
+			&assets.Host{Host: components.Host{IP: strPtr("127.0.0.1")}},
+		},
+		TotalHits: 2500,
+	}
+
+	testCases := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		confirm confirmFunc
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name: "below threshold - fetches all pages without asking",
+			args: []string{"--all-pages", "--page-size", "500", "host.ip: 127.0.0.1"},
+			confirm: func(context.Context, string) (bool, bool, error) {
+				return false, true, errors.New("confirmation should not be requested")
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta, TotalHits: 2500}, nil)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
+						require.Equal(t, uint64(1000), params.MaxPages.MustGet(), "search.page-cap bounds the search")
+						require.Equal(t, uint64(5), params.EstimatedPages.MustGet())
+						return hostResult, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "127.0.0.1")
+				require.Contains(t, stderr, "Query matches 2500 hits: an estimated 5 page requests")
+				require.NotContains(t, stderr, "one per page request")
+				require.NotContains(t, stderr, "--max-pages=-1")
+			},
+		},
+		{
+			name: "above threshold - confirmed",
+			args: []string{"--all-pages", "host.ip: 127.0.0.1"},
+			confirm: func(_ context.Context, title string) (bool, bool, error) {
+				require.Equal(t, "Fetch all 25 pages?", title)
+				return true, true, nil
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta, TotalHits: 2500}, nil)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(hostResult, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "127.0.0.1")
+			},
+		},
+		{
+			name: "above threshold - declined",
+			args: []string{"--all-pages", "host.ip: 127.0.0.1"},
+			confirm: func(context.Context, string) (bool, bool, error) {
+				return false, true, nil
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta, TotalHits: 2500}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, stdout)
+				require.Contains(t, stderr, "Search cancelled.")
+			},
+		},
+		{
+			name: "above threshold - no terminal",
+			args: []string{"--all-pages", "host.ip: 127.0.0.1"},
+			confirm: func(context.Context, string) (bool, bool, error) {
+				return false, false, nil
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta, TotalHits: 2500}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var confirmErr ConfirmationRequiredError
+				require.ErrorAs(t, err, &confirmErr)
+				require.Contains(t, err.Error(), "--yes")
+			},
+		},
+		{
+			name: "above threshold - --yes skips confirmation",
+			args: []string{"--all-pages", "--yes", "host.ip: 127.0.0.1"},
+			confirm: func(context.Context, string) (bool, bool, error) {
+				return false, true, errors.New("confirmation should not be requested")
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta, TotalHits: 2500}, nil)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(hostResult, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "127.0.0.1")
+			},
+		},
+		{
+			name: "above the page cap - refused even with --yes",
+			args: []string{"--all-pages", "--yes", "host.ip: 127.0.0.1"},
+			env:  map[string]string{"CENCLI_SEARCH_PAGE_CAP": "20"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta, TotalHits: 2500}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var capErr PageCapError
+				require.ErrorAs(t, err, &capErr)
+				require.Contains(t, err.Error(), "more than search.page-cap (20)")
+				require.Empty(t, stdout)
+			},
+		},
+		{
+			name: "stopping at the page cap is a warning",
+			args: []string{"--all-pages", "--yes", "host.ip: 127.0.0.1"},
+			env:  map[string]string{"CENCLI_SEARCH_PAGE_CAP": "5"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta, TotalHits: 500}, nil)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
+						require.Equal(t, uint64(5), params.MaxPages.MustGet())
+						capped := hostResult
+						capped.Pages = 5
+						capped.NextPageToken = "next"
+						return capped, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stderr, "stopped after 5 pages, the limit of search.page-cap")
+			},
+		},
+		{
+			name: "no page cap - unbounded once raised to 0",
+			args: []string{"--all-pages", "--yes", "host.ip: 127.0.0.1"},
+			env:  map[string]string{"CENCLI_SEARCH_PAGE_CAP": "0"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta, TotalHits: 2500}, nil)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
+						require.False(t, params.MaxPages.IsPresent())
+						return hostResult, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "127.0.0.1")
+			},
+		},
+		{
+			name: "no hits - skips full search",
+			args: []string{"--all-pages", "host.ip: 127.0.0.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "[]", strings.TrimSpace(stdout))
+			},
+		},
+		{
+			name: "preflight error",
+			args: []string{"--all-pages", "host.ip: 127.0.0.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{}, cenclierrors.NewCencliError(errors.New("boom")))
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "boom")
+			},
+		},
+		{
+			name: "conflicts with --max-pages",
+			args: []string{"--all-pages", "--max-pages", "3", "host.ip: 127.0.0.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cannot use --all-pages and --max-pages flags together")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			tempDir := t.TempDir()
+			viper.Reset()
+			cfg, err := config.New(tempDir)
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			searchCmd := NewSearchCommand(cmdContext)
+			if tc.confirm != nil {
+				searchCmd.confirm = tc.confirm
+			}
+			rootCmd, err := command.RootCommandToCobra(searchCmd)
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_Limit(t *testing.T) {
+	testCases := []struct {
+		name   string
+		args   []string
+		want   mo.Option[search.Params]
+		assert func(t *testing.T, stderr string, err error)
+	}{
+		{
+			name: "a small limit is a single page of that size",
+			args: []string{"--limit", "20", "host.ip: 127.0.0.1"},
+			want: mo.Some(search.Params{
+				PageSize:       mo.Some(uint64(20)),
+				Limit:          mo.Some(uint64(20)),
+				EstimatedPages: mo.Some(uint64(1)),
+			}),
+		},
+		{
+			name: "a large limit fetches pages until it is reached",
+			args: []string{"--limit", "250", "host.ip: 127.0.0.1"},
+			want: mo.Some(search.Params{
+				PageSize:       mo.Some(uint64(defaultPageSize)),
+				Limit:          mo.Some(uint64(250)),
+				EstimatedPages: mo.Some(uint64(3)),
+			}),
+		},
+		{
+			name: "page size and max pages are kept when set",
+			args: []string{"--limit", "20", "--page-size", "50", "--max-pages", "2", "host.ip: 127.0.0.1"},
+			want: mo.Some(search.Params{
+				PageSize: mo.Some(uint64(50)),
+				MaxPages: mo.Some(uint64(2)),
+				Limit:    mo.Some(uint64(20)),
+			}),
+		},
+		{
+			name: "conflicts with --all-pages",
+			args: []string{"--limit", "20", "--all-pages", "host.ip: 127.0.0.1"},
+			assert: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "cannot use --all-pages and --limit flags together")
+			},
+		},
+		{
+			name: "conflicts with --count",
+			args: []string{"--limit", "20", "--count", "host.ip: 127.0.0.1"},
+			assert: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "cannot use --count and --limit flags together")
+			},
+		},
+		{
+			name: "must be positive",
+			args: []string{"--limit", "0", "host.ip: 127.0.0.1"},
+			assert: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "--limit was set with an invalid value: 0")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			mockSvc := searchmocks.NewMockSearchService(ctrl)
+			if want, ok := tc.want.Get(); ok {
+				want.Query = "host.ip: 127.0.0.1"
+				want.Fields = []string{}
+				mockSvc.EXPECT().Search(gomock.Any(), want).Return(search.Result{
+					Hits: []assets.Asset{&assets.Host{Host: components.Host{IP: strPtr("127.0.0.1")}}},
+				}, nil)
+			}
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(mockSvc))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			if tc.assert != nil {
+				tc.assert(t, stderr.String(), cmdErr)
+				return
+			}
+			require.NoError(t, cmdErr)
+			require.Contains(t, stdout.String(), "127.0.0.1")
+			require.NotContains(t, stderr.String(), "fetching all pages")
+		})
+	}
+}
+
+func TestSearchCommand_Count(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{
+		Method:  "POST",
+		URL:     "https://api.censys.io/v1/search",
+		Status:  200,
+		Latency: 100 * time.Millisecond,
+	}
+
+	testCases := []struct {
+		name    string
+		args    []string
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name: "prints total hits",
+			args: []string{"--count", "host.ip: 127.0.0.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, params search.Params) (search.PreflightResult, cenclierrors.CencliError) {
+						require.Equal(t, "host.ip: 127.0.0.1", params.Query)
+						return search.PreflightResult{Meta: meta, TotalHits: 2500}, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "2500", strings.TrimSpace(stdout))
+			},
+		},
+		{
+			name: "short output",
+			args: []string{"--count", "-O", "short", "host.ip: 127.0.0.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta, TotalHits: 7}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "7\n", stdout)
+			},
+		},
+		{
+			name: "zero hits with --fail-on-empty",
+			args: []string{"--count", "--fail-on-empty", "host.ip: 127.0.0.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Equal(t, "0", strings.TrimSpace(stdout))
+				var noResults NoResultsError
+				require.ErrorAs(t, err, &noResults)
+				require.Equal(t, 1, formatter.ExitCode(err))
+			},
+		},
+		{
+			name: "zero hits without --fail-on-empty",
+			args: []string{"--count", "host.ip: 127.0.0.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Preflight(gomock.Any(), gomock.Any()).Return(search.PreflightResult{Meta: meta}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "--fail-on-empty applies to regular searches",
+			args: []string{"--fail-on-empty", "host.ip: 127.0.0.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var noResults NoResultsError
+				require.ErrorAs(t, err, &noResults)
+			},
+		},
+		{
+			name: "conflicts with pagination flags",
+			args: []string{"--count", "--page-size", "10", "host.ip: 127.0.0.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cannot use --count and --page-size flags together")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_Fields(t *testing.T) {
+	expectFields := func(want []string) func(ctrl *gomock.Controller) search.Service {
+		return func(ctrl *gomock.Controller) search.Service {
+			mockSvc := searchmocks.NewMockSearchService(ctrl)
+			mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
+					require.Equal(t, want, params.Fields)
+					return search.Result{Hits: []assets.Asset{&assets.Host{Host: components.Host{IP: strPtr("127.0.0.1")}}}}, nil
+				})
+			return mockSvc
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		args    []string
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, err error)
+	}{
+		{
+			name:    "expands wildcards and unqualified fields",
+			args:    []string{"--fields", "host.ip,location.c?untry", "host.ip: 127.0.0.1"},
+			service: expectFields([]string{"host.ip", "host.location.country"}),
+			assert: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "rejects a typo before searching",
+			args: []string{"--fields", "host.ip,host.locaton.country", "host.ip: 127.0.0.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, err error) {
+				var invalid InvalidFieldError
+				require.ErrorAs(t, err, &invalid)
+				require.Contains(t, err.Error(), `unknown field "host.locaton.country"; did you mean host.location.country`)
+			},
+		},
+		{
+			name:    "--no-field-check sends the fields as given",
+			args:    []string{"--fields", "host.brand_new_field", "--no-field-check", "host.ip: 127.0.0.1"},
+			service: expectFields([]string{"host.brand_new_field"}),
+			assert: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			formatter.Stdout = &bytes.Buffer{}
+			formatter.Stderr = &bytes.Buffer{}
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			tc.assert(t, rootCmd.Execute())
+		})
+	}
+}
+
+func TestSearchCommand_AllOrgs(t *testing.T) {
+	orgA := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+	orgB := uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000")
+	storedOrgs := func(ctrl *gomock.Controller) store.Store {
+		st := storemocks.NewMockStore(ctrl)
+		st.EXPECT().GetValuesForGlobal(gomock.Any(), config.OrgIDGlobalName).Return([]*store.ValueForGlobal{
+			{Value: orgA.String(), Description: "team a"},
+			{Value: orgB.String()},
+			{Value: orgA.String()},
+		}, nil)
+		return st
+	}
+	orgService := func(ctrl *gomock.Controller) organizations.Service {
+		ms := orgmocks.NewMockOrganizationsService(ctrl)
+		ms.EXPECT().GetOrganizationDetails(gomock.Any(), identifiers.NewOrganizationID(orgA)).
+			Return(organizations.OrganizationDetailsResult{Data: organizations.OrganizationDetails{Name: "Acme"}}, nil)
+		ms.EXPECT().GetOrganizationDetails(gomock.Any(), identifiers.NewOrganizationID(orgB)).
+			Return(organizations.OrganizationDetailsResult{}, cenclierrors.NewCencliError(errors.New("forbidden")))
+		return ms
+	}
+	searchIn := func(ms *searchmocks.MockSearchService, org uuid.UUID, ip string, err cenclierrors.CencliError) {
+		orgID := mo.Some(identifiers.NewOrganizationID(org))
+		ms.EXPECT().Search(gomock.Any(), gomock.Cond(func(p search.Params) bool { return p.OrgID == orgID })).DoAndReturn(
+			func(_ context.Context, _ search.Params) (search.Result, cenclierrors.CencliError) {
+				if err != nil {
+					return search.Result{}, err
+				}
+				return search.Result{Hits: []assets.Asset{&assets.Host{Host: components.Host{IP: strPtr(ip)}}}}, nil
+			})
+	}
+
+	testCases := []struct {
+		name    string
+		store   func(ctrl *gomock.Controller) store.Store
+		orgSvc  func(ctrl *gomock.Controller) organizations.Service
+		service func(ctrl *gomock.Controller) search.Service
+		args    []string
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name:   "merges the hits of each organization",
+			store:  storedOrgs,
+			orgSvc: orgService,
+			service: func(ctrl *gomock.Controller) search.Service {
+				ms := searchmocks.NewMockSearchService(ctrl)
+				searchIn(ms, orgA, "10.0.0.1", nil)
+				searchIn(ms, orgB, "10.0.0.2", nil)
+				return ms
+			},
+			args: []string{"--all-orgs", "host.ip: 10.0.0.0/8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var hits []map[string]any
+				require.NoError(t, json.Unmarshal([]byte(stdout), &hits))
+				require.Len(t, hits, 2)
+				require.Equal(t, orgA.String(), hits[0]["org_id"])
+				require.Equal(t, "Acme", hits[0]["org_name"])
+				require.Equal(t, "10.0.0.1", hits[0]["host"].(map[string]any)["ip"])
+				require.Equal(t, orgB.String(), hits[1]["org_id"])
+				require.NotContains(t, hits[1], "org_name")
+			},
+		},
+		{
+			name:   "an organization failing does not stop the others",
+			store:  storedOrgs,
+			orgSvc: orgService,
+			service: func(ctrl *gomock.Controller) search.Service {
+				ms := searchmocks.NewMockSearchService(ctrl)
+				searchIn(ms, orgA, "", cenclierrors.NewCencliError(errors.New("no access")))
+				searchIn(ms, orgB, "10.0.0.2", nil)
+				return ms
+			},
+			args: []string{"--all-orgs", "-O", "short", "host.ip: 10.0.0.0/8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Organization: "+orgB.String()+"\nHits: 1\n")
+				require.Contains(t, stdout, "10.0.0.2")
+				require.Contains(t, stderr, "Organization Acme ("+orgA.String()+") failed: no access")
+			},
+		},
+		{
+			name:   "every organization failing is an error",
+			store:  storedOrgs,
+			orgSvc: orgService,
+			service: func(ctrl *gomock.Controller) search.Service {
+				ms := searchmocks.NewMockSearchService(ctrl)
+				searchIn(ms, orgA, "", cenclierrors.NewCencliError(errors.New("no access")))
+				searchIn(ms, orgB, "", cenclierrors.NewCencliError(errors.New("no access")))
+				return ms
+			},
+			args: []string{"--all-orgs", "host.ip: 10.0.0.0/8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var failedErr command.AllOrgsFailedError
+				require.ErrorAs(t, err, &failedErr)
+			},
+		},
+		{
+			name: "no organizations added",
+			store: func(ctrl *gomock.Controller) store.Store {
+				st := storemocks.NewMockStore(ctrl)
+				st.EXPECT().GetValuesForGlobal(gomock.Any(), config.OrgIDGlobalName).Return(nil, nil)
+				return st
+			},
+			orgSvc: func(ctrl *gomock.Controller) organizations.Service {
+				return orgmocks.NewMockOrganizationsService(ctrl)
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			args: []string{"--all-orgs", "host.ip: 10.0.0.0/8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var noOrgsErr command.NoOrgsError
+				require.ErrorAs(t, err, &noOrgsErr)
+			},
+		},
+		{
+			name: "conflicts with --org-id",
+			store: func(ctrl *gomock.Controller) store.Store {
+				return storemocks.NewMockStore(ctrl)
+			},
+			orgSvc: func(ctrl *gomock.Controller) organizations.Service {
+				return orgmocks.NewMockOrganizationsService(ctrl)
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			args: []string{"--all-orgs", "--org-id", orgA.String(), "host.ip: 10.0.0.0/8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cannot use --all-orgs and --org-id flags together")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, tc.store(ctrl),
+				command.WithSearchService(tc.service(ctrl)),
+				command.WithOrganizationsService(tc.orgSvc(ctrl)),
+			)
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_PageToken(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{Method: "POST", URL: "https://api.censys.io/v1/search", Status: 200}
+	hits := []assets.Asset{&assets.Host{Host: components.Host{IP: strPtr("127.0.0.1")}}}
+
+	testCases := []struct {
+		name    string
+		args    func(dir string) []string
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, dir, stdout, stderr string, err error)
+	}{
+		{
+			name: "starts at the page token and emits the next one",
+			args: func(string) []string {
+				return []string{"--page-token", "token5", "--emit-page-token", "host.ip: 127.0.0.1"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
+						require.Equal(t, "token5", params.PageToken.OrEmpty())
+						return search.Result{Meta: meta, Hits: hits, NextPageToken: "token6"}, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, _, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "127.0.0.1")
+				require.Contains(t, stderr, "next page token: token6")
+				require.NotContains(t, stdout, "token6")
+			},
+		},
+		{
+			name: "no page token by default",
+			args: func(string) []string { return []string{"--emit-page-token", "host.ip: 127.0.0.1"} },
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
+						require.False(t, params.PageToken.IsPresent())
+						return search.Result{Meta: meta, Hits: hits}, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, _, _, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stderr, "next page token: none")
+			},
+		},
+		{
+			name: "writes the token file",
+			args: func(dir string) []string {
+				return []string{"--token-file", filepath.Join(dir, "token"), "host.ip: 127.0.0.1"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: hits, NextPageToken: "token6"}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, dir, _, stderr string, err error) {
+				require.NoError(t, err)
+				raw, readErr := os.ReadFile(filepath.Join(dir, "token"))
+				require.NoError(t, readErr)
+				require.Equal(t, "token6", string(raw))
+				require.NotContains(t, stderr, "next page token")
+			},
+		},
+		{
+			name: "token file is emptied after the last page",
+			args: func(dir string) []string {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("token6"), 0o600))
+				return []string{"--page-token", "token6", "--token-file", filepath.Join(dir, "token"), "host.ip: 127.0.0.1"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: hits}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, dir, _, _ string, err error) {
+				require.NoError(t, err)
+				raw, readErr := os.ReadFile(filepath.Join(dir, "token"))
+				require.NoError(t, readErr)
+				require.Empty(t, raw)
+			},
+		},
+		{
+			name: "empty page token is rejected",
+			args: func(string) []string { return []string{"--page-token", "", "host.ip: 127.0.0.1"} },
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				var emptyErr EmptyPageTokenError
+				require.ErrorAs(t, err, &emptyErr)
+			},
+		},
+		{
+			name: "conflicts with --all-pages",
+			args: func(string) []string { return []string{"--page-token", "token5", "--all-pages", "host.ip: 127.0.0.1"} },
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cannot use --page-token and --all-pages flags together")
+			},
+		},
+		{
+			name: "conflicts with --count",
+			args: func(string) []string { return []string{"--emit-page-token", "--count", "host.ip: 127.0.0.1"} },
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cannot use --emit-page-token and --count flags together")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			dir := t.TempDir()
+			cfg, err := config.New(dir)
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args(dir))
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, dir, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_Interrupted(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{Method: "POST", URL: "https://api.censys.io/v1/search", Status: 200}
+	hits := []assets.Asset{
+		&assets.Host{Host: components.Host{IP: strPtr("127.0.0.1")}},
+		&assets.Host{Host: components.Host{IP: strPtr("127.0.0.2")}},
+	}
+	interrupted := search.Result{
+		Meta:          meta,
+		Hits:          hits,
+		TotalHits:     500,
+		Pages:         2,
+		NextPageToken: "token3",
+		PartialError:  cenclierrors.ToPartialError(cenclierrors.NewInterruptedError()),
+	}
+	writeCheckpoint := func(t *testing.T, dir, query string) {
+		raw, err := json.Marshal(checkpoint{Query: query, PageToken: "token3", Pages: 2, Hits: 2, TotalHits: 500})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, checkpointFileName), raw, 0o600))
+	}
+
+	testCases := []struct {
+		name    string
+		args    func(t *testing.T, dir string) []string
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, dir, stdout, stderr string, err error)
+	}{
+		{
+			name: "prints the partial results and saves a checkpoint",
+			args: func(*testing.T, string) []string { return []string{"--max-pages", "-1", "host.ip: 127.0.0.0/8"} },
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(interrupted, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, dir, stdout, stderr string, err error) {
+				require.True(t, cenclierrors.IsInterrupted(err))
+				require.Equal(t, formatter.ExitInterruptedPartial, formatter.ExitCode(err))
+				require.Contains(t, stdout, "127.0.0.1")
+				require.Contains(t, stdout, "127.0.0.2")
+				require.Contains(t, stderr, "Interrupted after 2 pages: 2 hits of 500 (partial results)")
+				require.Contains(t, stderr, "Resume with: censys search --resume 'host.ip: 127.0.0.0/8'")
+
+				raw, readErr := os.ReadFile(filepath.Join(dir, checkpointFileName))
+				require.NoError(t, readErr)
+				var cp checkpoint
+				require.NoError(t, json.Unmarshal(raw, &cp))
+				require.Equal(t, "host.ip: 127.0.0.0/8", cp.Query)
+				require.Equal(t, "token3", cp.PageToken)
+				require.Equal(t, uint64(2), cp.Pages)
+				require.Equal(t, 2, cp.Hits)
+			},
+		},
+		{
+			name: "resumes from the checkpoint and removes it",
+			args: func(t *testing.T, dir string) []string {
+				writeCheckpoint(t, dir, "host.ip: 127.0.0.0/8")
+				return []string{"--resume", "host.ip: 127.0.0.0/8"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
+						require.Equal(t, "token3", params.PageToken.OrEmpty())
+						return search.Result{Meta: meta, Hits: hits}, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, dir, stdout, _ string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "127.0.0.1")
+				require.NoFileExists(t, filepath.Join(dir, checkpointFileName))
+			},
+		},
+		{
+			name: "resume requires a checkpoint of the query",
+			args: func(t *testing.T, dir string) []string {
+				writeCheckpoint(t, dir, "host.ip: 10.0.0.0/8")
+				return []string{"--resume", "host.ip: 127.0.0.0/8"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, dir, _, _ string, err error) {
+				var noCheckpoint NoCheckpointError
+				require.ErrorAs(t, err, &noCheckpoint)
+				require.FileExists(t, filepath.Join(dir, checkpointFileName))
+			},
+		},
+		{
+			name: "resume conflicts with --page-token",
+			args: func(*testing.T, string) []string {
+				return []string{"--resume", "--page-token", "token5", "host.ip: 127.0.0.0/8"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "cannot use --resume and --page-token flags together")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			dir := t.TempDir()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl),
+				command.WithSearchService(tc.service(ctrl)),
+				command.WithAppDirs(appdirs.Dirs{Data: dir}),
+			)
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args(t, dir))
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, dir, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_GroupBy(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{
+		Method:  "POST",
+		URL:     "https://api.censys.io/v1/search",
+		Status:  200,
+		Latency: 100 * time.Millisecond,
+	}
+	host := func(ip, country string, ports ...int) assets.Asset {
+		h := &assets.Host{Host: components.Host{IP: strPtr(ip)}}
+		if country != "" {
+			h.Location = &components.Location{Country: strPtr(country)}
+		}
+		for _, port := range ports {
+			h.Services = append(h.Services, components.Service{Port: intPtr(port)})
+		}
+		return h
+	}
+	hits := []assets.Asset{
+		host("10.0.0.1", "Germany", 22, 443),
+		host("10.0.0.2", "United States", 443),
+		host("10.0.0.3", "Germany", 80),
+		host("10.0.0.4", ""),
+	}
+
+	testCases := []struct {
+		name    string
+		args    []string
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name: "groups hits in raw output",
+			args: []string{"--group-by", "host.location.country", "host.ip: 10.0.0.0/8"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: hits}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var groups []struct {
+					Value any              `json:"value"`
+					Count int              `json:"count"`
+					Hits  []map[string]any `json:"hits"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(stdout), &groups))
+				require.Len(t, groups, 3)
+				require.Equal(t, "Germany", groups[0].Value)
+				require.Equal(t, 2, groups[0].Count)
+				require.Len(t, groups[0].Hits, 2)
+				require.Contains(t, groups[0].Hits[0], "host")
+				require.Equal(t, "United States", groups[1].Value)
+				require.Equal(t, 1, groups[1].Count)
+				require.Nil(t, groups[2].Value)
+				require.Equal(t, 1, groups[2].Count)
+			},
+		},
+		{
+			name: "repeated fields put hits in every group",
+			args: []string{"--group-by", "services.port", "host.ip: 10.0.0.0/8"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: hits}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var groups []struct {
+					Value any `json:"value"`
+					Count int `json:"count"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(stdout), &groups))
+				require.Len(t, groups, 4)
+				require.Equal(t, float64(443), groups[0].Value)
+				require.Equal(t, 2, groups[0].Count)
+				require.Equal(t, float64(22), groups[1].Value)
+				require.Equal(t, float64(80), groups[2].Value)
+				require.Nil(t, groups[3].Value)
+			},
+		},
+		{
+			name: "short output has group headers",
+			args: []string{"--group-by", "host.location.country", "-O", "short", "host.ip: 10.0.0.0/8"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: hits}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				germany := strings.Index(stdout, "host.location.country = Germany (2 hits)")
+				us := strings.Index(stdout, "host.location.country = United States (1 hit)")
+				none := strings.Index(stdout, "host.location.country = (no value) (1 hit)")
+				require.True(t, germany >= 0 && us > germany && none > us, stdout)
+				require.Contains(t, stdout, "10.0.0.4")
+			},
+		},
+		{
+			name: "conflicts with --count",
+			args: []string{"--group-by", "host.location.country", "--count", "host.ip: 10.0.0.0/8"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "cannot use --group-by and --count flags together")
+			},
+		},
+		{
+			name: "conflicts with template output",
+			args: []string{"--group-by", "host.location.country", "-O", "template", "host.ip: 10.0.0.0/8"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "--group-by")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_Highlight(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{
+		Method:  "POST",
+		URL:     "https://api.censys.io/v1/search",
+		Status:  200,
+		Latency: 100 * time.Millisecond,
+	}
+	tcp := components.ServiceTransportProtocolTCP
+	hit := func() assets.Asset {
+		return &assets.Host{
+			Host: components.Host{
+				IP: strPtr("10.0.0.1"),
+				Services: []components.Service{
+					{Port: intPtr(22), Protocol: strPtr("SSH"), TransportProtocol: &tcp},
+					{Port: intPtr(443), Protocol: strPtr("HTTP"), TransportProtocol: &tcp},
+				},
+			},
+			MatchedServices: []components.MatchedService{
+				{Port: intPtr(443), Protocol: strPtr("HTTP"), TransportProtocol: strPtr(components.TransportProtocolTCP)},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		args    []string
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name: "marks matched services in raw output",
+			args: []string{"--highlight", "host.services.port: 443"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: []assets.Asset{hit()}}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var hits []struct {
+					Host struct {
+						Services []map[string]any `json:"services"`
+					} `json:"host"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(stdout), &hits))
+				require.Len(t, hits, 1)
+				require.Len(t, hits[0].Host.Services, 2)
+				require.NotContains(t, hits[0].Host.Services[0], "matched")
+				require.Equal(t, true, hits[0].Host.Services[1]["matched"])
+			},
+		},
+		{
+			name: "raw output is unchanged without --highlight",
+			args: []string{"host.services.port: 443"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: []assets.Asset{hit()}}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.NotContains(t, stdout, `"matched"`)
+			},
+		},
+		{
+			name: "marks matched services in short output",
+			args: []string{"--highlight", "-O", "short", "host.services.port: 443"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: []assets.Asset{hit()}}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Matched: 443/HTTP")
+				require.Contains(t, stdout, "HTTP 443/tcp [matched]")
+				require.NotContains(t, stdout, "SSH 22/tcp [matched]")
+			},
+		},
+		{
+			name: "marks streamed hits",
+			args: []string{"--highlight", "--streaming", "host.services.port: 443"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, _ search.Params) (search.Result, cenclierrors.CencliError) {
+						h := hit()
+						require.NoError(t, streaming.Emit(ctx, map[string]any{h.AssetType().String(): h}))
+						return search.Result{Meta: meta}, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, `"matched":true`)
+			},
+		},
+		{
+			name: "conflicts with --count",
+			args: []string{"--highlight", "--count", "host.services.port: 443"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "cannot use --highlight and --count flags together")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_Xref(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{
+		Method:  "POST",
+		URL:     "https://api.censys.io/v1/search",
+		Status:  200,
+		Latency: 100 * time.Millisecond,
+	}
+	hits := func() []assets.Asset {
+		return []assets.Asset{
+			&assets.Host{Host: components.Host{
+				IP: strPtr("198.51.100.7"),
+				Services: []components.Service{
+					{Port: intPtr(443), Protocol: strPtr("HTTP"), Jarm: &components.JarmScan{Fingerprint: strPtr("07d14d16d21d21d07c42d41d00041d24a458a375eef0c576d23a7bab9a9fb1")}},
+				},
+			}},
+			&assets.Host{Host: components.Host{IP: strPtr("192.0.2.1")}},
+		}
+	}
+	feed := filepath.Join(t.TempDir(), "c2.txt")
+	require.NoError(t, os.WriteFile(feed, []byte("# known C2\n198.51.100.0/24\n07D14D16D21D21D07C42D41D00041D24A458A375EEF0C576D23A7BAB9A9FB1,cobalt strike\n"), 0o600))
+	returnHits := func(ctrl *gomock.Controller) search.Service {
+		mockSvc := searchmocks.NewMockSearchService(ctrl)
+		mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: hits()}, nil)
+		return mockSvc
+	}
+
+	testCases := []struct {
+		name    string
+		args    []string
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name:    "attaches matches in raw output",
+			args:    []string{"--xref", "c2=" + feed, "host.services.port: 443"},
+			service: returnHits,
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var out []struct {
+					Host struct {
+						IP   string       `json:"ip"`
+						Xref []xref.Match `json:"xref"`
+					} `json:"host"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+				require.Len(t, out, 2)
+				require.Equal(t, []xref.Match{
+					{Feed: "c2", Indicator: "198.51.100.0/24", Field: "host.ip", Value: "198.51.100.7"},
+					{
+						Feed: "c2", Indicator: "07D14D16D21D21D07C42D41D00041D24A458A375EEF0C576D23A7BAB9A9FB1",
+						Field: "host.services.jarm.fingerprint", Value: "07d14d16d21d21d07c42d41d00041d24a458a375eef0c576d23a7bab9a9fb1", Port: 443,
+					},
+				}, out[0].Host.Xref)
+				require.Empty(t, out[1].Host.Xref)
+			},
+		},
+		{
+			name:    "lists matches in short output",
+			args:    []string{"--xref", feed, "-O", "short", "host.services.port: 443"},
+			service: returnHits,
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Threat Feed Matches")
+				require.Contains(t, stdout, "host 198.51.100.7")
+				require.Contains(t, stdout, "[c2.txt] 198.51.100.7 in host.ip, matching 198.51.100.0/24")
+				require.NotContains(t, stdout, "host 192.0.2.1\n  [")
+			},
+		},
+		{
+			name: "attaches matches to streamed hits",
+			args: []string{"--xref", feed, "--streaming", "host.services.port: 443"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, _ search.Params) (search.Result, cenclierrors.CencliError) {
+						h := hits()[0]
+						require.NoError(t, streaming.Emit(ctx, map[string]any{h.AssetType().String(): h}))
+						return search.Result{Meta: meta}, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, `"xref":[{"feed":"c2.txt"`)
+			},
+		},
+		{
+			name:    "missing feed file",
+			args:    []string{"--xref", filepath.Join(t.TempDir(), "missing.txt"), "host.services.port: 443"},
+			service: func(ctrl *gomock.Controller) search.Service { return searchmocks.NewMockSearchService(ctrl) },
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var feedErr command.XrefFeedError
+				require.ErrorAs(t, err, &feedErr)
+			},
+		},
+		{
+			name:    "conflicts with --no-xref",
+			args:    []string{"--xref", feed, "--no-xref", "host.services.port: 443"},
+			service: func(ctrl *gomock.Controller) search.Service { return searchmocks.NewMockSearchService(ctrl) },
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "cannot use --xref and --no-xref flags together")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_Export(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{
+		Method:  "POST",
+		URL:     "https://api.censys.io/v1/search",
+		Status:  200,
+		Latency: 100 * time.Millisecond,
+	}
+	tcp := components.ServiceTransportProtocolTCP
+	hit := &assets.Host{
+		Host: components.Host{
+			IP: strPtr("10.0.0.1"),
+			Services: []components.Service{
+				{Port: intPtr(22), Protocol: strPtr("SSH"), TransportProtocol: &tcp},
+				{Port: intPtr(443), Protocol: strPtr("HTTP"), TransportProtocol: &tcp},
+			},
+		},
+	}
+	noSearch := func(ctrl *gomock.Controller) search.Service {
+		return searchmocks.NewMockSearchService(ctrl)
+	}
+
+	testCases := []struct {
+		name    string
+		args    func(dbPath string) []string
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, dbPath, stdout, stderr string, err error)
+	}{
+		{
+			name: "exports hits instead of printing them",
+			args: func(dbPath string) []string {
+				return []string{"--format", "sqlite", "--output", dbPath, "host.services.port: 443"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: []assets.Asset{hit}}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, dbPath, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, stdout)
+				require.Contains(t, stderr, "Exported 1 host, 2 services to "+dbPath)
+				_, statErr := os.Stat(dbPath)
+				require.NoError(t, statErr)
+			},
+		},
+		{
+			name: "output requires format",
+			args: func(dbPath string) []string {
+				return []string{"--output", dbPath, "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "--output requires --format")
+			},
+		},
+		{
+			name: "sqlite requires output",
+			args: func(string) []string {
+				return []string{"--format", "sqlite", "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "--format sqlite requires --output")
+			},
+		},
+		{
+			name: "writes es-bulk actions to stdout",
+			args: func(string) []string {
+				return []string{"--format", "es-bulk", "--es-index", "scans-{type}", "host.services.port: 443"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: []assets.Asset{hit}}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, _, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				lines := strings.Split(strings.TrimSpace(stdout), "\n")
+				require.Len(t, lines, 2)
+				require.JSONEq(t, `{"index":{"_index":"scans-host","_id":"10.0.0.1"}}`, lines[0])
+				require.Contains(t, lines[1], `"ip":"10.0.0.1"`)
+				require.NotContains(t, stderr, "Exported")
+			},
+		},
+		{
+			name: "appends es-bulk actions to a file",
+			args: func(dbPath string) []string {
+				require.NoError(t, os.WriteFile(dbPath, []byte("existing\n"), 0o600))
+				return []string{"--format", "es-bulk", "--output", dbPath, "--append", "host.services.port: 443"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: []assets.Asset{hit}}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, path, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, stdout)
+				require.Contains(t, stderr, "Exported 1 document to "+path)
+				data, readErr := os.ReadFile(path)
+				require.NoError(t, readErr)
+				lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+				require.Len(t, lines, 3)
+				require.Equal(t, "existing", lines[0])
+				require.JSONEq(t, `{"index":{"_index":"censys-host","_id":"10.0.0.1"}}`, lines[1])
+			},
+		},
+		{
+			name: "exports compressed json with --output-file",
+			args: func(dbPath string) []string {
+				return []string{"--format", "json", "--output-file", dbPath + ".json.gz", "host.services.port: 443"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: []assets.Asset{hit}}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, dbPath, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, stdout)
+				require.Contains(t, stderr, "Exported 1 result to "+dbPath+".json.gz")
+				f, openErr := os.Open(dbPath + ".json.gz")
+				require.NoError(t, openErr)
+				defer f.Close()
+				zr, gzErr := gzip.NewReader(f)
+				require.NoError(t, gzErr)
+				var exported []map[string]any
+				require.NoError(t, json.NewDecoder(zr).Decode(&exported))
+				require.Len(t, exported, 1)
+				require.Equal(t, "10.0.0.1", exported[0]["ip"])
+			},
+		},
+		{
+			name: "exports nmap xml",
+			args: func(dbPath string) []string {
+				return []string{"--format", "nmap-xml", "--output", dbPath + ".xml", "host.services.port: 443"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: []assets.Asset{hit}}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, dbPath, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, stdout)
+				require.Contains(t, stderr, "Exported 1 host to "+dbPath+".xml")
+				data, readErr := os.ReadFile(dbPath + ".xml")
+				require.NoError(t, readErr)
+				require.Contains(t, string(data), `<nmaprun scanner="nmap" args="cencli search host.services.port: 443"`)
+				require.Contains(t, string(data), `<address addr="10.0.0.1" addrtype="ipv4"></address>`)
+			},
+		},
+		{
+			name: "append cannot be used with nmap xml",
+			args: func(dbPath string) []string {
+				return []string{"--format", "nmap-xml", "--output", dbPath, "--append", "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "--append cannot be used with --format nmap-xml")
+			},
+		},
+		{
+			name: "exports a target list of matched services",
+			args: func(string) []string {
+				return []string{"--format", "target-list", "--target-services", "http", "host.services.port: 443"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				matched := *hit
+				matched.MatchedServices = []components.MatchedService{{Port: intPtr(443)}}
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: []assets.Asset{&matched}}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, _, stdout, _ string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "10.0.0.1:443\n", stdout)
+			},
+		},
+		{
+			name: "target ports must be ports",
+			args: func(string) []string {
+				return []string{"--format", "target-list", "--target-ports", "https", "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, `invalid --target-ports: "https" is not a port`)
+			},
+		},
+		{
+			name: "target flags require target-list",
+			args: func(string) []string {
+				return []string{"--format", "json", "--target-style", "ip", "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "--target-style requires --format target-list")
+			},
+		},
+		{
+			name: "append cannot be used with a compressed output",
+			args: func(dbPath string) []string {
+				return []string{"--format", "es-bulk", "--output", dbPath + ".gz", "--append", "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "--append cannot be used with a compressed or remote --output")
+			},
+		},
+		{
+			name: "storage URL must name an object",
+			args: func(string) []string {
+				return []string{"--format", "json", "--output", "s3://scans/", "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, `invalid --output: "s3://scans/" does not name an object`)
+			},
+		},
+		{
+			name: "writes a file per hit with --output-dir",
+			args: func(dbPath string) []string {
+				return []string{"--format", "json", "--output-dir", filepath.Join(filepath.Dir(dbPath), "out"), "host.services.port: 443"}
+			},
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: []assets.Asset{hit}}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, dbPath, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, stdout)
+				dir := filepath.Join(filepath.Dir(dbPath), "out")
+				require.Contains(t, stderr, "Exported 1 result to "+dir)
+				data, readErr := os.ReadFile(filepath.Join(dir, "10.0.0.1.json"))
+				require.NoError(t, readErr)
+				require.Contains(t, string(data), `"ip": "10.0.0.1"`)
+				index, readErr := os.ReadFile(filepath.Join(dir, "index.json"))
+				require.NoError(t, readErr)
+				require.Contains(t, string(index), `"file": "10.0.0.1.json"`)
+				require.Contains(t, string(index), `"query": "host.services.port: 443"`)
+			},
+		},
+		{
+			name: "--output-dir requires --format json",
+			args: func(dbPath string) []string {
+				return []string{"--format", "sqlite", "--output", dbPath, "--output-dir", t.TempDir(), "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "--output-dir requires --format json")
+			},
+		},
+		{
+			name: "--output-dir conflicts with --output",
+			args: func(dbPath string) []string {
+				return []string{"--format", "json", "--output", dbPath, "--output-dir", t.TempDir(), "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "cannot use --output-dir and --output flags together")
+			},
+		},
+		{
+			name: "es-index requires es-bulk",
+			args: func(dbPath string) []string {
+				return []string{"--format", "sqlite", "--output", dbPath, "--es-index", "scans", "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "--es-index requires --format es-bulk")
+			},
+		},
+		{
+			name: "unsupported format",
+			args: func(dbPath string) []string {
+				return []string{"--format", "parquet", "--output", dbPath, "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, `unsupported export format "parquet"`)
+			},
+		},
+		{
+			name: "conflicts with --count",
+			args: func(dbPath string) []string {
+				return []string{"--format", "sqlite", "--output", dbPath, "--count", "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "cannot use --format and --count flags together")
+			},
+		},
+		{
+			name: "conflicts with an output format",
+			args: func(dbPath string) []string {
+				return []string{"--format", "sqlite", "--output", dbPath, "-O", "json", "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "cannot use --format and --output-format flags together")
+			},
+		},
+		{
+			name: "conflicts with streaming",
+			args: func(dbPath string) []string {
+				return []string{"--format", "sqlite", "--output", dbPath, "--streaming", "host.services.port: 443"}
+			},
+			service: noSearch,
+			assert: func(t *testing.T, _, _, _ string, err error) {
+				require.ErrorContains(t, err, "cannot use --format and --streaming flags together")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			dbPath := filepath.Join(t.TempDir(), "results.db")
+			rootCmd.SetArgs(tc.args(dbPath))
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, dbPath, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_ExportToS3(t *testing.T) {
+	var uploads []*http.Request
+	var bodies [][]byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		uploads = append(uploads, r)
+		bodies = append(bodies, body)
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_ENDPOINT_URL_S3", srv.URL)
+
+	viper.Reset()
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	ctrl := gomock.NewController(t)
+	mockSvc := searchmocks.NewMockSearchService(ctrl)
+	mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{
+		Meta: &responsemeta.ResponseMeta{Status: 200},
+		Hits: []assets.Asset{&assets.Host{Host: components.Host{IP: strPtr("10.0.0.1")}}},
+	}, nil)
+	cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(mockSvc))
+	rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+	require.NoError(t, err)
+	require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+	rootCmd.SetArgs([]string{"--format", "es-bulk", "--output-file", "s3://scans/daily/results.ndjson.gz", "host.services.port: 443"})
+	require.NoError(t, rootCmd.Execute())
+
+	require.Len(t, uploads, 1)
+	require.Equal(t, http.MethodPut, uploads[0].Method)
+	require.Equal(t, "/scans/daily/results.ndjson.gz", uploads[0].URL.Path)
+	require.Equal(t, "application/gzip", uploads[0].Header.Get("Content-Type"))
+	zr, gzErr := gzip.NewReader(bytes.NewReader(bodies[0]))
+	require.NoError(t, gzErr)
+	data, readErr := io.ReadAll(zr)
+	require.NoError(t, readErr)
+	require.Contains(t, string(data), `{"index":{"_index":"censys-host","_id":"10.0.0.1"}}`)
+	require.Contains(t, stderr.String(), "Exported 1 document to s3://scans/daily/results.ndjson.gz")
+}
+
+func TestSearchCommand_Forward(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{
+		Method:  "POST",
+		URL:     "https://api.censys.io/v1/search",
+		Status:  200,
+		Latency: 100 * time.Millisecond,
+	}
+	hits := []assets.Asset{
+		&assets.Host{Host: components.Host{IP: strPtr("10.0.0.1")}},
+		&assets.Host{Host: components.Host{IP: strPtr("10.0.0.2")}},
+	}
+
+	testCases := []struct {
+		name    string
+		args    []string
+		status  int
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, events []map[string]any, stdout, stderr string, err error)
+	}{
+		{
+			name:   "forwards each hit and still prints them",
+			args:   []string{"--forward", "splunk", "host.services.port: 443"},
+			status: http.StatusOK,
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: hits}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, events []map[string]any, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "10.0.0.2")
+				require.Contains(t, stderr, "Forwarded 2 events to Splunk")
+				require.Len(t, events, 2)
+				require.Equal(t, "cencli:search", events[0]["source"])
+				require.Equal(t, "censys:cencli", events[0]["sourcetype"])
+				require.Equal(t, map[string]any{"host": map[string]any{"ip": "10.0.0.1"}}, events[0]["event"])
+			},
+		},
+		{
+			name:   "forwards streamed hits",
+			args:   []string{"--forward", "splunk", "--streaming", "host.services.port: 443"},
+			status: http.StatusOK,
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, _ search.Params) (search.Result, cenclierrors.CencliError) {
+						for _, hit := range hits {
+							require.NoError(t, streaming.Emit(ctx, map[string]any{hit.AssetType().String(): hit}))
+						}
+						return search.Result{Meta: meta}, nil
+					})
+				return mockSvc
+			},
+			assert: func(t *testing.T, events []map[string]any, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 2)
+				require.Len(t, events, 2)
+				require.Equal(t, map[string]any{"host": map[string]any{"ip": "10.0.0.2"}}, events[1]["event"])
+			},
+		},
+		{
+			name:   "fails when the collector rejects the events",
+			args:   []string{"--forward", "splunk", "host.services.port: 443"},
+			status: http.StatusForbidden,
+			service: func(ctrl *gomock.Controller) search.Service {
+				mockSvc := searchmocks.NewMockSearchService(ctrl)
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: hits}, nil)
+				return mockSvc
+			},
+			assert: func(t *testing.T, _ []map[string]any, _, _ string, err error) {
+				require.ErrorContains(t, err, "failed to forward results: HEC returned 403 Forbidden")
+			},
+		},
+		{
+			name: "unsupported sink",
+			args: []string{"--forward", "syslog", "host.services.port: 443"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, _ []map[string]any, _, _ string, err error) {
+				require.ErrorContains(t, err, `unsupported sink "syslog"`)
+			},
+		},
+		{
+			name: "conflicts with --count",
+			args: []string{"--forward", "splunk", "--count", "host.services.port: 443"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, _ []map[string]any, _, _ string, err error) {
+				require.ErrorContains(t, err, "cannot use --forward and --count flags together")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var events []map[string]any
+			hec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "Splunk secret", r.Header.Get("Authorization"))
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				if tc.status != http.StatusOK {
+					w.WriteHeader(tc.status)
+					return
+				}
+				for _, line := range strings.Split(strings.TrimSpace(string(body)), "\n") {
+					var e map[string]any
+					require.NoError(t, json.Unmarshal([]byte(line), &e))
+					events = append(events, e)
+				}
+			}))
+			defer hec.Close()
+
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+			viper.Set("forward.splunk.url", hec.URL)
+			viper.Set("forward.splunk.token", "secret")
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, events, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_ForwardKafka(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kcat is a shell script")
+	}
+	meta := &responsemeta.ResponseMeta{Method: "POST", URL: "https://api.censys.io/v1/search", Status: 200}
+	hits := []assets.Asset{
+		&assets.Host{Host: components.Host{IP: strPtr("10.0.0.1")}},
+		&assets.Host{Host: components.Host{IP: strPtr("10.0.0.2")}},
+	}
+
+	testCases := []struct {
+		name   string
+		args   []string
+		search bool
+		assert func(t *testing.T, dir, stderr string, err error)
+	}{
+		{
+			name:   "produces a message per hit keyed by IP",
+			args:   []string{"--forward", "kafka", "--topic", "censys-hits", "host.services.port: 443"},
+			search: true,
+			assert: func(t *testing.T, dir, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stderr, "Produced 2 messages to Kafka topic censys-hits")
+				args, readErr := os.ReadFile(filepath.Join(dir, "args"))
+				require.NoError(t, readErr)
+				require.Contains(t, string(args), "-t\ncensys-hits\n")
+				require.Contains(t, string(args), "-H\ncencli-command=search\n")
+				require.Contains(t, string(args), "-H\ncencli-query=host.services.port: 443\n")
+				stdin, readErr := os.ReadFile(filepath.Join(dir, "stdin"))
+				require.NoError(t, readErr)
+				require.Equal(t, "10.0.0.1\t{\"host\":{\"ip\":\"10.0.0.1\"}}\n10.0.0.2\t{\"host\":{\"ip\":\"10.0.0.2\"}}\n", string(stdin))
+			},
+		},
+		{
+			name: "topic requires kafka",
+			args: []string{"--forward", "splunk", "--topic", "censys-hits", "host.services.port: 443"},
+			assert: func(t *testing.T, _, _ string, err error) {
+				require.ErrorContains(t, err, "--topic requires --forward kafka")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			kcat := filepath.Join(dir, "kcat")
+			script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > \"" + dir + "/args\"; cat > \"" + dir + "/stdin\"\n"
+			require.NoError(t, os.WriteFile(kcat, []byte(script), 0o755))
+
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+			viper.Set("forward.kafka.brokers", []string{"kafka:9092"})
+			viper.Set("forward.kafka.command", kcat)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			mockSvc := searchmocks.NewMockSearchService(ctrl)
+			if tc.search {
+				mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Meta: meta, Hits: hits}, nil)
+			}
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(mockSvc))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, dir, stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_IDsOnly(t *testing.T) {
+	meta := &responsemeta.ResponseMeta{
+		Method:  "POST",
+		URL:     "https://api.censys.io/v1/search",
+		Status:  200,
+		Latency: 100 * time.Millisecond,
+	}
+	hostIP := "192.0.2.1"
+	fingerprint := "abc123"
+	hostname := "example.com"
+	port := 443
+	result := search.Result{
+		Meta: meta,
+		Hits: []assets.Asset{
+			&assets.Host{Host: components.Host{IP: &hostIP}},
+			&assets.Certificate{Certificate: components.Certificate{FingerprintSha256: &fingerprint}},
+			&assets.WebProperty{Webproperty: components.Webproperty{Hostname: &hostname, Port: &port}},
+			&assets.Host{},
+		},
+	}
+	searching := func(ctrl *gomock.Controller) search.Service {
+		mockSvc := searchmocks.NewMockSearchService(ctrl)
+		mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(result, nil)
+		return mockSvc
+	}
+
+	testCases := []struct {
+		name    string
+		args    []string
+		service func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name:    "one identifier per line",
+			args:    []string{"--ids-only", "host.ip: 192.0.2.1"},
+			service: searching,
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "192.0.2.1\nabc123\nexample.com:443\n", stdout)
+			},
+		},
+		{
+			name:    "NUL-separated",
+			args:    []string{"--ids-only", "--print0", "host.ip: 192.0.2.1"},
+			service: searching,
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "192.0.2.1\x00abc123\x00example.com:443\x00", stdout)
+			},
+		},
+		{
+			name: "--print0 requires --ids-only",
+			args: []string{"--print0", "host.ip: 192.0.2.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "--print0 requires --ids-only")
+			},
+		},
+		{
+			name: "conflicts with output format",
+			args: []string{"--ids-only", "-O", "short", "host.ip: 192.0.2.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "cannot use --ids-only and --output-format flags together")
+			},
+		},
+		{
+			name: "conflicts with --group-by",
+			args: []string{"--ids-only", "--group-by", "host.location.country", "host.ip: 192.0.2.1"},
+			service: func(ctrl *gomock.Controller) search.Service {
+				return searchmocks.NewMockSearchService(ctrl)
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "cannot use --ids-only and --group-by flags together")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_Explain(t *testing.T) {
+	testCases := []struct {
+		name   string
+		args   []string
+		assert func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name: "prints the parse tree without searching",
+			args: []string{"--explain", "host.services: (port: 22 or port: 2222) and host.location.country: Germany"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, `Query: host.services: (port: 22 or port: 2222) and host.location.country: Germany
+
+all of (and):
+  host.services: one element matches
+    any of (or):
+      port: 22  (match)
+      port: 2222  (match)
+  host.location.country: Germany  (match)
+
+Fields (2):
+  host.location.country  (1 term)
+  host.services.port  (2 terms)
+
+Warnings (0):
+  none
+`, stdout)
+			},
+		},
+		{
+			name: "prints warnings with their column",
+			args: []string{"--explain", "--page-size", "5", "host.ip: 1.1.1.1 nginx"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "  free text  (1 term)\n")
+				require.Contains(t, stdout, "Warnings (1):\n  column 1: terms are written next to each other")
+			},
+		},
+		{
+			name: "query that does not parse",
+			args: []string{"--explain", "host.ip: (1.1.1.1"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var invalid command.InvalidQueryError
+				require.ErrorAs(t, err, &invalid)
+				require.Contains(t, err.Error(), "column 10: '(' is never closed")
+				require.NotEqual(t, 0, formatter.ExitCode(err))
+				require.Empty(t, stdout)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			// no service is set up: --explain must not make a request
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl))
+			rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
+func TestSearchCommand_Refine(t *testing.T) {
+	cacheDir := t.TempDir()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	hosts := []assets.Asset{
+		&assets.Host{Host: components.Host{
+			IP:       strPtr("10.0.0.1"),
+			Services: []components.Service{{Port: intPtr(22), Protocol: strPtr("SSH")}},
+		}},
+		&assets.Host{Host: components.Host{
+			IP:       strPtr("10.0.0.2"),
+			Services: []components.Service{{Port: intPtr(443), Protocol: strPtr("HTTP")}},
+		}},
+		&assets.Host{Host: components.Host{
+			IP:       strPtr("10.0.0.3"),
+			Services: []components.Service{{Port: intPtr(22), Protocol: strPtr("HTTP")}},
+		}},
+	}
+
+	run := func(t *testing.T, svc search.Service, args ...string) (string, string, error) {
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+
+		var stdout, stderr bytes.Buffer
+		formatter.Stdout = &stdout
+		formatter.Stderr = &stderr
+
+		ctrl := gomock.NewController(t)
+		opts := []command.ContextOpts{
+			command.WithAppDirs(appdirs.Dirs{Cache: cacheDir}),
+			command.WithClock(func() time.Time { return now }),
+		}
+		if svc != nil {
+			opts = append(opts, command.WithSearchService(svc))
+		}
+		cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), opts...)
+		rootCmd, err := command.RootCommandToCobra(NewSearchCommand(cmdContext))
+		require.NoError(t, err)
+		require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+		rootCmd.SetArgs(args)
+		cmdErr := rootCmd.Execute()
+		return stdout.String(), stderr.String(), cmdErr
+	}
+
+	t.Run("no run saved yet", func(t *testing.T) {
+		_, _, err := run(t, nil, "--refine", "last", "host.services.port: 22")
+		var notFound RunNotFoundError
+		require.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("a search saves its hits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := searchmocks.NewMockSearchService(ctrl)
+		svc.EXPECT().Search(gomock.Any(), gomock.Any()).Return(search.Result{Hits: hosts}, nil)
+
+		_, stderr, err := run(t, svc, "host.services.port: [1 to 1024]")
+		require.NoError(t, err)
+		require.Contains(t, stderr, "Saved as run 20250301-120000; search within it with: censys search --refine 20250301-120000 <query>")
+		require.FileExists(t, filepath.Join(cacheDir, runsDirName, "20250301-120000.json"))
+	})
+
+	t.Run("refines the saved hits without searching", func(t *testing.T) {
+		stdout, stderr, err := run(t, nil, "--refine", "20250301-120000", "host.services: (port: 22 and protocol: SSH)")
+		require.NoError(t, err)
+		var hits []map[string]map[string]any
+		require.NoError(t, json.Unmarshal([]byte(stdout), &hits))
+		require.Len(t, hits, 1)
+		require.Equal(t, "10.0.0.1", hits[0]["host"]["ip"])
+		// the refined hits are saved as a run of their own
+		require.Contains(t, stderr, "Saved as run 20250301-120000-2")
+
+		raw, err := os.ReadFile(filepath.Join(cacheDir, runsDirName, "20250301-120000-2.json"))
+		require.NoError(t, err)
+		var saved savedRun
+		require.NoError(t, json.Unmarshal(raw, &saved))
+		require.Equal(t, "(host.services.port: [1 to 1024]) and (host.services: (port: 22 and protocol: SSH))", saved.Query)
+	})
+
+	t.Run("last refers to the latest run", func(t *testing.T) {
+		stdout, _, err := run(t, nil, "--refine", "last", "-O", "short", "host.ip: 10.0.0.*")
+		require.NoError(t, err)
+		require.Contains(t, stdout, "10.0.0.1")
+		require.NotContains(t, stdout, "10.0.0.3")
+	})
+
+	t.Run("no matching hits with --fail-on-empty", func(t *testing.T) {
+		_, _, err := run(t, nil, "--refine", "20250301-120000", "--fail-on-empty", "host.services.port: 8080")
+		var noResults NoResultsError
+		require.ErrorAs(t, err, &noResults)
+	})
+
+	t.Run("unknown run", func(t *testing.T) {
+		_, _, err := run(t, nil, "--refine", "20240101-000000", "host.services.port: 22")
+		var notFound RunNotFoundError
+		require.ErrorAs(t, err, &notFound)
+		require.Contains(t, err.Error(), `no saved search run "20240101-000000"`)
+	})
+
+	t.Run("query that does not parse", func(t *testing.T) {
+		_, _, err := run(t, nil, "--refine", "last", "host.services.port: (22")
+		var invalid command.InvalidQueryError
+		require.ErrorAs(t, err, &invalid)
+	})
+
+	t.Run("conflicts with flags that only apply to searches", func(t *testing.T) {
+		_, _, err := run(t, nil, "--refine", "last", "--max-pages", "2", "host.services.port: 22")
+		require.ErrorContains(t, err, "cannot use --refine and --max-pages flags together")
+	})
+}
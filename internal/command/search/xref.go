@@ -0,0 +1,18 @@
+package search
+
+import (
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// parseXrefFlags parses --xref and --no-xref. Feeds are not loaded for
+// --count, which has no hits to cross-reference.
+func (c *Command) parseXrefFlags() cenclierrors.CencliError {
+	feeds, err := c.flags.xref.Value(c.Config().Xref)
+	if err != nil {
+		return err
+	}
+	if !c.count {
+		c.feeds = feeds
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/input"
+	"github.com/censys/cencli/internal/pkg/sessionarchive"
+	"github.com/censys/cencli/internal/store"
+	"github.com/censys/cencli/internal/version"
+)
+
+type exportCommand struct {
+	*command.BaseCommand
+	flags exportCommandFlags
+}
+
+type exportCommandFlags struct {
+	outputFile flags.StringFlag
+	force      flags.BoolFlag
+}
+
+var _ command.Command = (*exportCommand)(nil)
+
+func newExportCommand(ctx *command.Context) *exportCommand {
+	return &exportCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *exportCommand) Use() string { return "export [name]" }
+
+func (c *exportCommand) Short() string {
+	return "Export a session as a portable archive"
+}
+
+func (c *exportCommand) Long() string {
+	return fmt.Sprintf(`Export a session, defaulting to the active session, as a portable archive
+(a gzipped tarball) holding the recorded commands, queries, and notes along with the
+raw JSON responses. Share the archive with "censys session import".
+
+The archive is written to <name>%s unless --output-file is set;
+use "-" to write it to stdout.`, sessionarchive.FileExtension)
+}
+
+func (c *exportCommand) Examples() []string {
+	return []string{"incident-42", "incident-42 -f /tmp/incident.tar.gz", "incident-42 -f - | gzip -t"}
+}
+
+func (c *exportCommand) Args() command.PositionalArgs { return command.RangeArgs(0, 1) }
+
+func (c *exportCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *exportCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *exportCommand) Init() error {
+	c.flags.outputFile = flags.NewStringFlag(c.Flags(), false, "output-file", "f", "", "file to write the archive to (\"-\" for stdout)")
+	c.flags.force = flags.NewBoolFlag(c.Flags(), "force", "", false, "overwrite the output file if it exists")
+	return nil
+}
+
+func (c *exportCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *exportCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	outputFile, err := c.flags.outputFile.Value()
+	if err != nil {
+		return err
+	}
+	force, err := c.flags.force.Value()
+	if err != nil {
+		return err
+	}
+	session, err := resolveSession(cmd.Context(), st, optionalArg(args))
+	if err != nil {
+		return err
+	}
+	entries, getErr := st.GetSessionEntries(cmd.Context(), session.ID)
+	if getErr != nil {
+		return cenclierrors.NewCencliError(getErr)
+	}
+	archive := buildArchive(session, entries, c.Now())
+
+	if outputFile == "" {
+		outputFile = session.Name + sessionarchive.FileExtension
+	}
+	if outputFile == input.StdInSentinel {
+		return cenclierrors.NewCencliError(sessionarchive.Write(cmd.OutOrStdout(), archive))
+	}
+
+	openFlags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		openFlags |= os.O_EXCL
+	}
+	f, openErr := os.OpenFile(outputFile, openFlags, 0o600)
+	if openErr != nil {
+		if errors.Is(openErr, os.ErrExist) {
+			return cenclierrors.NewUsageError(fmt.Errorf("%s already exists; use --force to overwrite it", outputFile))
+		}
+		return cenclierrors.NewCencliError(openErr)
+	}
+	if writeErr := sessionarchive.Write(f, archive); writeErr != nil {
+		f.Close()
+		return cenclierrors.NewCencliError(writeErr)
+	}
+	if closeErr := f.Close(); closeErr != nil {
+		return cenclierrors.NewCencliError(closeErr)
+	}
+	formatter.Printf(formatter.Stdout, "✅ Exported session [%s] (%d entries) to %s\n", session.Name, len(entries), outputFile)
+	return nil
+}
+
+// buildArchive converts a stored session, exported at now, into an archive,
+// storing each distinct response once.
+func buildArchive(session *store.Session, entries []*store.SessionEntry, now time.Time) sessionarchive.Archive {
+	manifest := sessionarchive.Manifest{
+		Name:         session.Name,
+		StartedAt:    session.StartedAt,
+		ExportedAt:   now.UTC(),
+		ExportedWith: version.Version,
+		Entries:      make([]sessionarchive.Entry, len(entries)),
+	}
+	if !session.Active() {
+		endedAt := session.EndedAt
+		manifest.EndedAt = &endedAt
+	}
+	responses := make(map[string][]byte)
+	for i, e := range entries {
+		manifest.Entries[i] = sessionarchive.Entry{
+			Kind:       string(e.Kind),
+			Command:    e.Command,
+			Query:      e.Query,
+			Note:       e.Note,
+			Digest:     e.Digest,
+			RecordedAt: e.CreatedAt,
+		}
+		if e.Digest != "" {
+			responses[e.Digest] = []byte(e.Response)
+		}
+	}
+	return sessionarchive.Archive{Manifest: manifest, Responses: responses}
+}
+
+type importCommand struct {
+	*command.BaseCommand
+	flags importCommandFlags
+}
+
+type importCommandFlags struct {
+	name flags.StringFlag
+}
+
+var _ command.Command = (*importCommand)(nil)
+
+func newImportCommand(ctx *command.Context) *importCommand {
+	return &importCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *importCommand) Use() string { return "import <file>" }
+
+func (c *importCommand) Short() string {
+	return "Import a session archive exported by a teammate"
+}
+
+func (c *importCommand) Long() string {
+	return `Import a session archive written by "censys session export" so that it can be
+browsed with "censys session show" and "censys session replay". Use "-" to read the
+archive from stdin. Imported sessions are never recording.`
+}
+
+func (c *importCommand) Examples() []string {
+	return []string{
+		"incident-42" + sessionarchive.FileExtension,
+		"incident-42" + sessionarchive.FileExtension + " --name incident-42-alice",
+	}
+}
+
+func (c *importCommand) Args() command.PositionalArgs { return command.ExactArgs(1) }
+
+func (c *importCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *importCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *importCommand) Init() error {
+	c.flags.name = flags.NewStringFlag(c.Flags(), false, "name", "n", "", "import under this name instead of the name in the archive")
+	return nil
+}
+
+func (c *importCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *importCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	name, err := c.flags.name.Value()
+	if err != nil {
+		return err
+	}
+
+	path := args[0]
+	var r io.Reader
+	if path == input.StdInSentinel {
+		r = cmd.InOrStdin()
+	} else {
+		f, openErr := os.Open(path)
+		if openErr != nil {
+			return cenclierrors.NewCencliError(openErr)
+		}
+		defer f.Close()
+		r = f
+	}
+	archive, readErr := sessionarchive.Read(r)
+	if readErr != nil {
+		return newArchiveError(path, readErr)
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		name = archive.Manifest.Name
+	}
+	session := &store.Session{Name: name, StartedAt: archive.Manifest.StartedAt}
+	if archive.Manifest.EndedAt != nil {
+		session.EndedAt = *archive.Manifest.EndedAt
+	}
+	entries := make([]*store.SessionEntry, len(archive.Manifest.Entries))
+	for i, e := range archive.Manifest.Entries {
+		entries[i] = &store.SessionEntry{
+			Kind:      store.SessionEntryKind(e.Kind),
+			Command:   e.Command,
+			Query:     e.Query,
+			Note:      e.Note,
+			Digest:    e.Digest,
+			Response:  string(archive.Responses[e.Digest]),
+			CreatedAt: e.RecordedAt,
+		}
+	}
+	imported, importErr := st.ImportSession(cmd.Context(), session, entries)
+	if importErr != nil {
+		if errors.Is(importErr, store.ErrSessionExists) {
+			return newSessionExistsError(name)
+		}
+		return cenclierrors.NewCencliError(importErr)
+	}
+	formatter.Printf(formatter.Stdout, "✅ Imported session [%s] (%d entries)\n", imported.Name, len(entries))
+	return nil
+}
@@ -0,0 +1,189 @@
+package session
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/store"
+)
+
+type listCommand struct {
+	*command.BaseCommand
+	sessions []Summary
+}
+
+var _ command.Command = (*listCommand)(nil)
+
+func newListCommand(ctx *command.Context) *listCommand {
+	return &listCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *listCommand) Use() string { return "list" }
+
+func (c *listCommand) Short() string {
+	return "List recorded and imported sessions"
+}
+
+func (c *listCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *listCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *listCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *listCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *listCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	sessions, listErr := st.ListSessions(cmd.Context())
+	if listErr != nil {
+		return cenclierrors.NewCencliError(listErr)
+	}
+	c.sessions = make([]Summary, len(sessions))
+	for i, s := range sessions {
+		c.sessions[i] = newSummary(s)
+	}
+	return c.PrintData(c, c.sessions)
+}
+
+func (c *listCommand) RenderShort() cenclierrors.CencliError {
+	formatter.Println(formatter.Stdout, renderList(c.sessions))
+	return nil
+}
+
+type showCommand struct {
+	*command.BaseCommand
+	detail Detail
+}
+
+var _ command.Command = (*showCommand)(nil)
+
+func newShowCommand(ctx *command.Context) *showCommand {
+	return &showCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *showCommand) Use() string { return "show [name]" }
+
+func (c *showCommand) Short() string {
+	return "Show the commands and notes recorded in a session"
+}
+
+func (c *showCommand) Long() string {
+	return `Show the commands and notes recorded in a session, defaulting to the active session.
+Use "censys session replay <name> <index>" to print the response recorded for an entry.`
+}
+
+func (c *showCommand) Args() command.PositionalArgs { return command.RangeArgs(0, 1) }
+
+func (c *showCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *showCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *showCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *showCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	session, err := resolveSession(cmd.Context(), st, optionalArg(args))
+	if err != nil {
+		return err
+	}
+	entries, getErr := st.GetSessionEntries(cmd.Context(), session.ID)
+	if getErr != nil {
+		return cenclierrors.NewCencliError(getErr)
+	}
+	c.detail = Detail{Summary: newSummary(session), Entries: newEntries(entries)}
+	return c.PrintData(c, c.detail)
+}
+
+func (c *showCommand) RenderShort() cenclierrors.CencliError {
+	formatter.Println(formatter.Stdout, renderDetail(c.detail))
+	return nil
+}
+
+type replayCommand struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*replayCommand)(nil)
+
+func newReplayCommand(ctx *command.Context) *replayCommand {
+	return &replayCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *replayCommand) Use() string { return "replay <name> <index>" }
+
+func (c *replayCommand) Short() string {
+	return "Print the response recorded for a session entry"
+}
+
+func (c *replayCommand) Long() string {
+	return `Print the response recorded for a session entry, as the original command printed it
+in data output. No request is made, so replaying costs no credits and works offline.
+Entry indexes are listed by "censys session show".`
+}
+
+func (c *replayCommand) Examples() []string {
+	return []string{"incident-42 3", "incident-42 3 -O yaml"}
+}
+
+func (c *replayCommand) Args() command.PositionalArgs { return command.ExactArgs(2) }
+
+func (c *replayCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *replayCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	index, parseErr := strconv.Atoi(args[1])
+	if parseErr != nil || index < 1 {
+		return newInvalidEntryError(args[1], "must be a positive integer")
+	}
+	session, err := resolveSession(cmd.Context(), st, args[0])
+	if err != nil {
+		return err
+	}
+	entries, getErr := st.GetSessionEntries(cmd.Context(), session.ID)
+	if getErr != nil {
+		return cenclierrors.NewCencliError(getErr)
+	}
+	if index > len(entries) {
+		return newInvalidEntryError(args[1], "session has "+strconv.Itoa(len(entries))+" entries")
+	}
+	entry := entries[index-1]
+	switch {
+	case entry.Kind == store.SessionEntryKindNote:
+		return newInvalidEntryError(args[1], "entry is a note, not a command")
+	case entry.Response == "":
+		return newInvalidEntryError(args[1], "no response was recorded (the command was streamed)")
+	}
+	var data any
+	if decodeErr := json.Unmarshal([]byte(entry.Response), &data); decodeErr != nil {
+		return cenclierrors.NewCencliError(decodeErr)
+	}
+	return c.PrintData(c, data)
+}
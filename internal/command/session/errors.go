@@ -0,0 +1,139 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+type StoreUnavailableError interface {
+	cenclierrors.CencliError
+}
+
+type storeUnavailableError struct{}
+
+var _ StoreUnavailableError = &storeUnavailableError{}
+
+func newStoreUnavailableError() StoreUnavailableError {
+	return &storeUnavailableError{}
+}
+
+func (e *storeUnavailableError) Error() string {
+	return "sessions are kept in the local store, but it is not available"
+}
+
+func (e *storeUnavailableError) Title() string { return "Store Unavailable" }
+
+func (e *storeUnavailableError) ShouldPrintUsage() bool { return false }
+
+type SessionNotFoundError interface {
+	cenclierrors.CencliError
+}
+
+type sessionNotFoundError struct {
+	name string
+}
+
+var _ SessionNotFoundError = &sessionNotFoundError{}
+
+func newSessionNotFoundError(name string) SessionNotFoundError {
+	return &sessionNotFoundError{name: name}
+}
+
+func (e *sessionNotFoundError) Error() string {
+	return fmt.Sprintf("no session named %q; use `censys session list` to see recorded sessions", e.name)
+}
+
+func (e *sessionNotFoundError) Title() string { return "Session Not Found" }
+
+func (e *sessionNotFoundError) ShouldPrintUsage() bool { return false }
+
+type SessionExistsError interface {
+	cenclierrors.CencliError
+}
+
+type sessionExistsError struct {
+	name string
+}
+
+var _ SessionExistsError = &sessionExistsError{}
+
+func newSessionExistsError(name string) SessionExistsError {
+	return &sessionExistsError{name: name}
+}
+
+func (e *sessionExistsError) Error() string {
+	return fmt.Sprintf("a session named %q already exists; choose another name", e.name)
+}
+
+func (e *sessionExistsError) Title() string { return "Session Exists" }
+
+func (e *sessionExistsError) ShouldPrintUsage() bool { return false }
+
+type NoActiveSessionError interface {
+	cenclierrors.CencliError
+}
+
+type noActiveSessionError struct{}
+
+var _ NoActiveSessionError = &noActiveSessionError{}
+
+func newNoActiveSessionError() NoActiveSessionError {
+	return &noActiveSessionError{}
+}
+
+func (e *noActiveSessionError) Error() string {
+	return "no session is active; start one with `censys session start <name>` or pass a session name"
+}
+
+func (e *noActiveSessionError) Title() string { return "No Active Session" }
+
+func (e *noActiveSessionError) ShouldPrintUsage() bool { return false }
+
+type InvalidEntryError interface {
+	cenclierrors.CencliError
+}
+
+type invalidEntryError struct {
+	entry  string
+	reason string
+}
+
+var _ InvalidEntryError = &invalidEntryError{}
+
+func newInvalidEntryError(entry, reason string) InvalidEntryError {
+	return &invalidEntryError{entry: entry, reason: reason}
+}
+
+func (e *invalidEntryError) Error() string {
+	return fmt.Sprintf("invalid entry %q: %s", e.entry, e.reason)
+}
+
+func (e *invalidEntryError) Title() string { return "Invalid Entry" }
+
+func (e *invalidEntryError) ShouldPrintUsage() bool { return true }
+
+type ArchiveError interface {
+	cenclierrors.CencliError
+}
+
+type archiveError struct {
+	path string
+	err  error
+}
+
+var _ ArchiveError = &archiveError{}
+
+func newArchiveError(path string, err error) ArchiveError {
+	return &archiveError{path: path, err: err}
+}
+
+func (e *archiveError) Error() string {
+	return fmt.Sprintf("%s: %s", e.path, e.err)
+}
+
+func (e *archiveError) Title() string { return "Invalid Session Archive" }
+
+func (e *archiveError) ShouldPrintUsage() bool { return false }
+
+func (e *archiveError) Unwrap() error { return e.err }
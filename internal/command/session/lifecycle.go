@@ -0,0 +1,166 @@
+package session
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/store"
+)
+
+type startCommand struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*startCommand)(nil)
+
+func newStartCommand(ctx *command.Context) *startCommand {
+	return &startCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *startCommand) Use() string { return "start <name>" }
+
+func (c *startCommand) Short() string {
+	return "Start recording a new session"
+}
+
+func (c *startCommand) Long() string {
+	return "Start recording a new session. Any session that is already recording is stopped first."
+}
+
+func (c *startCommand) Args() command.PositionalArgs { return command.ExactArgs(1) }
+
+func (c *startCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *startCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *startCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *startCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return cenclierrors.NewUsageError(errors.New("session name must not be empty"))
+	}
+	session, startErr := st.StartSession(cmd.Context(), name)
+	if startErr != nil {
+		if errors.Is(startErr, store.ErrSessionExists) {
+			return newSessionExistsError(name)
+		}
+		return cenclierrors.NewCencliError(startErr)
+	}
+	formatter.Printf(formatter.Stdout, "✅ Recording session [%s]; stop with `censys session stop`\n", session.Name)
+	return nil
+}
+
+type stopCommand struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*stopCommand)(nil)
+
+func newStopCommand(ctx *command.Context) *stopCommand {
+	return &stopCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *stopCommand) Use() string { return "stop" }
+
+func (c *stopCommand) Short() string {
+	return "Stop recording the active session"
+}
+
+func (c *stopCommand) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *stopCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *stopCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *stopCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *stopCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	session, endErr := st.EndActiveSession(cmd.Context())
+	if endErr != nil {
+		if errors.Is(endErr, store.ErrNoActiveSession) {
+			return newNoActiveSessionError()
+		}
+		return cenclierrors.NewCencliError(endErr)
+	}
+	formatter.Printf(formatter.Stdout, "✅ Stopped recording session [%s]\n", session.Name)
+	return nil
+}
+
+type noteCommand struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*noteCommand)(nil)
+
+func newNoteCommand(ctx *command.Context) *noteCommand {
+	return &noteCommand{BaseCommand: command.NewBaseCommand(ctx)}
+}
+
+func (c *noteCommand) Use() string { return "note <text>" }
+
+func (c *noteCommand) Short() string {
+	return "Add a note to the active session"
+}
+
+func (c *noteCommand) Args() command.PositionalArgs { return command.ExactArgs(1) }
+
+func (c *noteCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *noteCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *noteCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *noteCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	st, err := requireStore(c.Store())
+	if err != nil {
+		return err
+	}
+	text := strings.TrimSpace(args[0])
+	if text == "" {
+		return cenclierrors.NewUsageError(errors.New("note must not be empty"))
+	}
+	session, err := resolveSession(cmd.Context(), st, "")
+	if err != nil {
+		return err
+	}
+	if _, addErr := st.AddSessionEntry(cmd.Context(), session.ID, &store.SessionEntry{
+		Kind: store.SessionEntryKindNote,
+		Note: text,
+	}); addErr != nil {
+		return cenclierrors.NewCencliError(addErr)
+	}
+	formatter.Printf(formatter.Stdout, "✅ Added note to session [%s]\n", session.Name)
+	return nil
+}
@@ -0,0 +1,82 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+// digestPrefixLen is the number of digest characters shown in tables.
+const digestPrefixLen = 12
+
+func renderList(sessions []Summary) string {
+	if len(sessions) == 0 {
+		return styles.GlobalStyles.Comment.Render("No sessions recorded. Start one with `censys session start <name>`.")
+	}
+	columns := []rawtable.Column[Summary]{
+		{Title: "Name", String: func(s Summary) string { return s.Name }, Priority: 1, NoTruncate: true},
+		{Title: "Status", String: func(s Summary) string { return status(s) }},
+		{Title: "Started", String: func(s Summary) string { return formatter.FormatShortTime(s.StartedAt) }},
+		{Title: "Ended", String: func(s Summary) string {
+			if s.EndedAt == nil {
+				return ""
+			}
+			return formatter.FormatShortTime(*s.EndedAt)
+		}},
+	}
+	table := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[Summary](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[Summary](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[Summary](formatter.TableWidth()),
+	)
+	return strings.TrimRight(table.Render(sessions), "\n")
+}
+
+func renderDetail(detail Detail) string {
+	var sb strings.Builder
+	sb.WriteString(styles.GlobalStyles.Signature.Render(fmt.Sprintf("Session %s", detail.Name)))
+	sb.WriteString(styles.GlobalStyles.Comment.Render(
+		fmt.Sprintf(" (%s, started %s)", status(detail.Summary), formatter.FormatShortTime(detail.StartedAt)),
+	))
+	sb.WriteString("\n\n")
+	if len(detail.Entries) == 0 {
+		sb.WriteString(styles.GlobalStyles.Comment.Render("Nothing recorded yet."))
+		return sb.String()
+	}
+	columns := []rawtable.Column[Entry]{
+		{Title: "#", String: func(e Entry) string { return fmt.Sprintf("%d", e.Index) }, Priority: 2, NoTruncate: true},
+		{Title: "Time", String: func(e Entry) string { return formatter.FormatShortTime(e.RecordedAt) }},
+		{Title: "Kind", String: func(e Entry) string { return e.Kind }},
+		{Title: "Entry", String: func(e Entry) string {
+			if e.Note != "" {
+				return e.Note
+			}
+			return e.Command
+		}, Priority: 1},
+		{Title: "Digest", String: func(e Entry) string {
+			if len(e.Digest) > digestPrefixLen {
+				return e.Digest[:digestPrefixLen]
+			}
+			return e.Digest
+		}},
+	}
+	table := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[Entry](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[Entry](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[Entry](formatter.TableWidth()),
+	)
+	sb.WriteString(table.Render(detail.Entries))
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func status(s Summary) string {
+	if s.Active {
+		return "recording"
+	}
+	return "stopped"
+}
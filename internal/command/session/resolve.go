@@ -0,0 +1,99 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/store"
+)
+
+// Summary describes a recorded session.
+type Summary struct {
+	Name      string     `json:"name"`
+	Active    bool       `json:"active"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+// Detail is a session along with its entries.
+type Detail struct {
+	Summary
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a recorded command or note. Index is 1-based and is how
+// entries are referred to by `session replay`.
+type Entry struct {
+	Index      int       `json:"index"`
+	Kind       string    `json:"kind"`
+	Command    string    `json:"command,omitempty"`
+	Query      string    `json:"query,omitempty"`
+	Note       string    `json:"note,omitempty"`
+	Digest     string    `json:"digest,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+func newSummary(s *store.Session) Summary {
+	summary := Summary{Name: s.Name, Active: s.Active(), StartedAt: s.StartedAt}
+	if !s.Active() {
+		endedAt := s.EndedAt
+		summary.EndedAt = &endedAt
+	}
+	return summary
+}
+
+func newEntries(entries []*store.SessionEntry) []Entry {
+	res := make([]Entry, len(entries))
+	for i, e := range entries {
+		res[i] = Entry{
+			Index:      i + 1,
+			Kind:       string(e.Kind),
+			Command:    e.Command,
+			Query:      e.Query,
+			Note:       e.Note,
+			Digest:     e.Digest,
+			RecordedAt: e.CreatedAt,
+		}
+	}
+	return res
+}
+
+// requireStore returns the store, or an error if it is not available.
+func requireStore(st store.Store) (store.Store, cenclierrors.CencliError) {
+	if st == nil {
+		return nil, newStoreUnavailableError()
+	}
+	return st, nil
+}
+
+// resolveSession returns the named session, or the active session if name is empty.
+func resolveSession(ctx context.Context, st store.Store, name string) (*store.Session, cenclierrors.CencliError) {
+	if name == "" {
+		session, err := st.GetActiveSession(ctx)
+		if err != nil {
+			if errors.Is(err, store.ErrNoActiveSession) {
+				return nil, newNoActiveSessionError()
+			}
+			return nil, cenclierrors.NewCencliError(err)
+		}
+		return session, nil
+	}
+	session, err := st.GetSessionByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, store.ErrSessionNotFound) {
+			return nil, newSessionNotFoundError(name)
+		}
+		return nil, cenclierrors.NewCencliError(err)
+	}
+	return session, nil
+}
+
+// optionalArg returns the first positional argument, or "" if there is none.
+func optionalArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
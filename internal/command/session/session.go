@@ -0,0 +1,85 @@
+package session
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// Command is the parent session command that groups session subcommands.
+type Command struct {
+	*command.BaseCommand
+}
+
+var _ command.Command = (*Command)(nil)
+
+// NewSessionCommand creates a new session command with all subcommands.
+func NewSessionCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return "session"
+}
+
+func (c *Command) Short() string {
+	return "Record, share, and browse investigation sessions"
+}
+
+func (c *Command) Long() string {
+	return `Record, share, and browse investigation sessions.
+
+While a session is active, every command that prints results is recorded into it
+along with its query and the raw JSON it printed. Add context with notes, then
+export the session as a portable archive that a teammate can import and browse
+offline, without spending credits to re-run anything.
+
+Configuration and session commands are never recorded.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"start incident-42",
+		`note "1.2.3.4 looks like the C2"`,
+		"export incident-42 -f incident-42.cencli-session.tar.gz",
+		"import incident-42.cencli-session.tar.gz",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	return c.AddSubCommands(
+		newStartCommand(c.Context),
+		newStopCommand(c.Context),
+		newNoteCommand(c.Context),
+		newListCommand(c.Context),
+		newShowCommand(c.Context),
+		newReplayCommand(c.Context),
+		newExportCommand(c.Context),
+		newImportCommand(c.Context),
+	)
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	// Parent command shows help when run without subcommands
+	if err := cmd.Help(); err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	return nil
+}
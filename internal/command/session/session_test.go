@@ -0,0 +1,186 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/sessionarchive"
+	"github.com/censys/cencli/internal/store"
+)
+
+// runSession executes `session <args>` against st.
+func runSession(t *testing.T, st store.Store, args ...string) (string, error) {
+	t.Helper()
+	viper.Reset()
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	cmdContext := command.NewCommandContext(cfg, st)
+	rootCmd, cerr := command.RootCommandToCobra(NewSessionCommand(cmdContext))
+	require.NoError(t, cerr)
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs(args)
+	cmdErr := rootCmd.Execute()
+	return stdout.String(), cmdErr
+}
+
+func newStore(t *testing.T) store.Store {
+	t.Helper()
+	st, err := store.New(t.TempDir())
+	require.NoError(t, err)
+	return st
+}
+
+// recordCommand adds a command entry to the active session, as PrintData would.
+func recordCommand(t *testing.T, st store.Store, commandLine, query, response string) {
+	t.Helper()
+	ctx := context.Background()
+	session, err := st.GetActiveSession(ctx)
+	require.NoError(t, err)
+	_, err = st.AddSessionEntry(ctx, session.ID, &store.SessionEntry{
+		Kind:     store.SessionEntryKindCommand,
+		Command:  commandLine,
+		Query:    query,
+		Digest:   sessionarchive.Digest([]byte(response)),
+		Response: response,
+	})
+	require.NoError(t, err)
+}
+
+func TestSessionLifecycle(t *testing.T) {
+	st := newStore(t)
+
+	_, err := runSession(t, st, "note", "too early")
+	var noActive NoActiveSessionError
+	require.ErrorAs(t, err, &noActive)
+
+	stdout, err := runSession(t, st, "start", "incident-42")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Recording session [incident-42]")
+
+	_, err = runSession(t, st, "start", "incident-42")
+	var exists SessionExistsError
+	require.ErrorAs(t, err, &exists)
+
+	recordCommand(t, st, "censys search 'host.ip: 1.1.1.1'", "host.ip: 1.1.1.1", `{"hits":[{"ip":"1.1.1.1"}]}`)
+	_, err = runSession(t, st, "note", "resolver, not the C2")
+	require.NoError(t, err)
+
+	stdout, err = runSession(t, st, "show", "-O", "json")
+	require.NoError(t, err)
+	var detail Detail
+	require.NoError(t, json.Unmarshal([]byte(stdout), &detail))
+	require.Equal(t, "incident-42", detail.Name)
+	require.True(t, detail.Active)
+	require.Len(t, detail.Entries, 2)
+	require.Equal(t, 1, detail.Entries[0].Index)
+	require.Equal(t, "host.ip: 1.1.1.1", detail.Entries[0].Query)
+	require.Equal(t, "resolver, not the C2", detail.Entries[1].Note)
+
+	stdout, err = runSession(t, st, "show", "incident-42")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Session incident-42")
+	require.Contains(t, stdout, "resolver, not the C2")
+
+	stdout, err = runSession(t, st, "stop")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Stopped recording session [incident-42]")
+	_, err = runSession(t, st, "stop")
+	require.ErrorAs(t, err, &noActive)
+
+	stdout, err = runSession(t, st, "list", "-O", "json")
+	require.NoError(t, err)
+	var sessions []Summary
+	require.NoError(t, json.Unmarshal([]byte(stdout), &sessions))
+	require.Len(t, sessions, 1)
+	require.False(t, sessions[0].Active)
+	require.NotNil(t, sessions[0].EndedAt)
+}
+
+func TestSessionReplay(t *testing.T) {
+	st := newStore(t)
+	_, err := runSession(t, st, "start", "incident-42")
+	require.NoError(t, err)
+	recordCommand(t, st, "censys view 1.1.1.1", "", `[{"ip":"1.1.1.1"}]`)
+	_, err = runSession(t, st, "note", "benign")
+	require.NoError(t, err)
+
+	stdout, err := runSession(t, st, "replay", "incident-42", "1", "-O", "json")
+	require.NoError(t, err)
+	var replayed []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(stdout), &replayed))
+	require.Equal(t, "1.1.1.1", replayed[0]["ip"])
+
+	var invalid InvalidEntryError
+	for _, index := range []string{"0", "x", "3", "2"} {
+		_, err = runSession(t, st, "replay", "incident-42", index)
+		require.ErrorAs(t, err, &invalid, index)
+	}
+
+	_, err = runSession(t, st, "replay", "missing", "1")
+	var notFound SessionNotFoundError
+	require.ErrorAs(t, err, &notFound)
+}
+
+func TestSessionExportImport(t *testing.T) {
+	source := newStore(t)
+	_, err := runSession(t, source, "start", "incident-42")
+	require.NoError(t, err)
+	recordCommand(t, source, "censys view 1.1.1.1", "", `[{"ip":"1.1.1.1"}]`)
+	recordCommand(t, source, "censys view 1.1.1.1", "", `[{"ip":"1.1.1.1"}]`)
+	_, err = runSession(t, source, "note", "seen twice")
+	require.NoError(t, err)
+
+	archivePath := filepath.Join(t.TempDir(), "incident.tar.gz")
+	stdout, err := runSession(t, source, "export", "incident-42", "-f", archivePath)
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Exported session [incident-42] (3 entries)")
+
+	_, err = runSession(t, source, "export", "incident-42", "-f", archivePath)
+	require.Error(t, err, "existing archives are not overwritten without --force")
+	_, err = runSession(t, source, "export", "incident-42", "-f", archivePath, "--force")
+	require.NoError(t, err)
+
+	_, err = runSession(t, source, "import", archivePath)
+	var exists SessionExistsError
+	require.ErrorAs(t, err, &exists)
+
+	teammate := newStore(t)
+	stdout, err = runSession(t, teammate, "import", archivePath, "--name", "from-alice")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Imported session [from-alice] (3 entries)")
+
+	stdout, err = runSession(t, teammate, "show", "from-alice", "-O", "json")
+	require.NoError(t, err)
+	var detail Detail
+	require.NoError(t, json.Unmarshal([]byte(stdout), &detail))
+	require.False(t, detail.Active)
+	require.Len(t, detail.Entries, 3)
+	require.Equal(t, "seen twice", detail.Entries[2].Note)
+
+	stdout, err = runSession(t, teammate, "replay", "from-alice", "2", "-O", "json")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "1.1.1.1")
+
+	_, err = runSession(t, teammate, "import", filepath.Join(t.TempDir(), "missing.tar.gz"))
+	require.Error(t, err)
+}
+
+func TestSessionStoreRequired(t *testing.T) {
+	_, err := runSession(t, nil, "list")
+	var unavailable StoreUnavailableError
+	require.ErrorAs(t, err, &unavailable)
+}
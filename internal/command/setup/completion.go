@@ -0,0 +1,123 @@
+package setup
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command/completion"
+)
+
+// completionMarker is the comment above the lines added to a shell startup
+// file, so they are added once.
+const completionMarker = "# censys shell completion"
+
+// completionInstall is where the completion script of a shell is written,
+// and the startup file that sources it, if the shell does not load it from
+// where it is written.
+type completionInstall struct {
+	shell  string
+	script string
+	rcFile string
+}
+
+// detectShell returns the shell of the user, from the path of $SHELL, or ""
+// if its completion cannot be installed.
+func detectShell(shellPath string) string {
+	switch filepath.Base(shellPath) {
+	case "bash":
+		return "bash"
+	case "zsh":
+		return "zsh"
+	case "fish":
+		return "fish"
+	}
+	return ""
+}
+
+// planCompletion returns where the completion of the executable name is
+// installed for shell, with the home and config directories of the user and
+// getenv.
+func planCompletion(name, shell, home, configDir string, getenv func(string) string) completionInstall {
+	switch shell {
+	case "bash":
+		return completionInstall{
+			shell:  shell,
+			script: filepath.Join(configDir, "completion.bash"),
+			rcFile: filepath.Join(home, ".bashrc"),
+		}
+	case "zsh":
+		zdotdir := getenv("ZDOTDIR")
+		if zdotdir == "" {
+			zdotdir = home
+		}
+		return completionInstall{
+			shell:  shell,
+			script: filepath.Join(configDir, "completion.zsh"),
+			rcFile: filepath.Join(zdotdir, ".zshrc"),
+		}
+	default:
+		// fish loads completions from its completions directory
+		xdgConfig := getenv("XDG_CONFIG_HOME")
+		if xdgConfig == "" {
+			xdgConfig = filepath.Join(home, ".config")
+		}
+		return completionInstall{
+			shell:  shell,
+			script: filepath.Join(xdgConfig, "fish", "completions", name+".fish"),
+		}
+	}
+}
+
+// install writes the completion script of root, and sources it from the
+// startup file of the shell, unless it already is.
+func (i completionInstall) install(root *cobra.Command) error {
+	var script bytes.Buffer
+	if err := completion.Generate(root, i.shell, &script); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(i.script), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(i.script, script.Bytes(), 0o644); err != nil {
+		return err
+	}
+	if i.rcFile == "" {
+		return nil
+	}
+	rc, err := os.ReadFile(i.rcFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if bytes.Contains(rc, []byte(i.script)) {
+		return nil
+	}
+	var lines []string
+	if len(rc) > 0 && !bytes.HasSuffix(rc, []byte("\n")) {
+		lines = append(lines, "")
+	}
+	lines = append(lines, completionMarker)
+	if i.shell == "zsh" {
+		// the script registers itself with compdef, which compinit defines
+		lines = append(lines, "(( $+functions[compdef] )) || { autoload -Uz compinit && compinit }")
+	}
+	lines = append(lines, fmt.Sprintf("source %s", shellQuote(i.script)))
+	f, err := os.OpenFile(i.rcFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(strings.Join(lines, "\n") + "\n"); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// shellQuote single-quotes s for bash and zsh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
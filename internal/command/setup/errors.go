@@ -0,0 +1,31 @@
+package setup
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// CompletionInstallError is returned when shell completion cannot be installed.
+type CompletionInstallError interface{ cenclierrors.CencliError }
+
+type completionInstallError struct {
+	shell string
+	err   error
+}
+
+var _ CompletionInstallError = &completionInstallError{}
+
+func newCompletionInstallError(shell string, err error) CompletionInstallError {
+	return &completionInstallError{shell: shell, err: err}
+}
+
+func (e *completionInstallError) Error() string {
+	return fmt.Sprintf("failed to install %s completion: %v; run `censys completion --help` to set it up by hand", e.shell, e.err)
+}
+
+func (e *completionInstallError) Title() string { return "Completion Not Installed" }
+
+func (e *completionInstallError) ShouldPrintUsage() bool { return false }
+
+func (e *completionInstallError) Unwrap() error { return e.err }
@@ -0,0 +1,116 @@
+package setup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/censyscopy"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/term"
+	"github.com/censys/cencli/internal/pkg/ui/form"
+)
+
+// reauthTokenName is the name of a token added after the API refused the
+// active one.
+const reauthTokenName = "reauth"
+
+// skipOnReauth are the commands that do not offer to replace a refused
+// token, as they manage tokens themselves.
+var skipOnReauth = map[string]bool{
+	"setup":  true,
+	"config": true,
+}
+
+// ShouldReauth returns true if the user should be offered to replace the
+// active token after cmd failed with err: the API refused the token as
+// expired or revoked, the user can be prompted in a terminal, and running
+// the command again would not repeat data it already wrote. With the legacy
+// API, the credentials of CENSYS_API_ID and CENSYS_API_SECRET take
+// precedence over a stored token, so replacing it would not help.
+func ShouldReauth(cmdContext *command.Context, cmd *cobra.Command, err error) bool {
+	if failure, ok := client.AuthFailureOf(err); !ok || failure != client.AuthFailureExpired {
+		return false
+	}
+	cfg := cmdContext.Config()
+	if cfg.NonInteractive || cfg.DryRun || !term.Interactive() || cmdContext.DataPrinted() {
+		return false
+	}
+	if cfg.APIFlavor == config.APIFlavorLegacy && client.LegacyCredentialsFromEnv() {
+		return false
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if skipOnReauth[c.Name()] {
+			return false
+		}
+	}
+	return true
+}
+
+// Reauth asks the user to add a new token in place of the one the API
+// refused, activates it, and reconnects the Censys client with it. It returns
+// false if the user declined, in which case the command is not run again.
+func Reauth(ctx context.Context, cmdContext *command.Context) (bool, cenclierrors.CencliError) {
+	confirmed, err := form.Confirm(ctx, "The API refused your token; it may have expired or been revoked. Add a new one and run the command again?")
+	if err != nil || !confirmed {
+		return false, nil
+	}
+
+	var value string
+	f := form.NewForm(huh.NewForm(huh.NewGroup(
+		huh.NewInput().
+			EchoMode(huh.EchoModePassword).
+			Title("Paste your new personal access token").
+			Description(censyscopy.DocumentationPAT(formatter.Stderr)).
+			Value(&value).
+			Validate(form.NonEmpty("token value cannot be empty")),
+	)).WithOutput(os.Stderr))
+	if err := f.RunWithContext(ctx); err != nil {
+		if errors.Is(err, form.ErrUserAborted) || errors.Is(err, form.ErrNonInteractive) {
+			return false, nil
+		}
+		return false, cenclierrors.NewCencliError(err)
+	}
+
+	st := cmdContext.Store()
+	rec, err := st.AddValueForAuth(ctx, config.AuthName, reauthTokenName, strings.TrimSpace(value))
+	if err != nil {
+		return false, cenclierrors.NewCencliError(fmt.Errorf("failed to add auth value: %w", err))
+	}
+	if err := st.UpdateAuthLastUsedAtToNow(ctx, rec.ID); err != nil {
+		return false, cenclierrors.NewCencliError(fmt.Errorf("failed to activate auth: %w", err))
+	}
+	formatter.Printf(formatter.Stderr, "✅ Added new personal access token [%s]\n", reauthTokenName)
+	if err := cmdContext.ReconnectCensysClient(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AuthFailureHint returns what to do about err if the API refused the
+// credentials of the request that caused it, or an empty string.
+func AuthFailureHint(cfg *config.Config, err error) string {
+	failure, ok := client.AuthFailureOf(err)
+	if !ok {
+		return ""
+	}
+	if failure == client.AuthFailureExpired && cfg.APIFlavor == config.APIFlavorLegacy && client.LegacyCredentialsFromEnv() {
+		return fmt.Sprintf("The API ID and secret of %s and %s may have expired or been revoked; update both variables.",
+			client.LegacyAPIIDEnv, client.LegacyAPISecretEnv)
+	}
+	if failure == client.AuthFailurePermission {
+		return "The token has no access to this resource or organization; " +
+			"check the organization ID with `censys config org-id` and the permissions of the token."
+	}
+	return "The token may have expired or been revoked; add a new one with `censys config auth add`, " +
+		"and check it with `censys config auth status --check`."
+}
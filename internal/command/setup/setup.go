@@ -0,0 +1,72 @@
+package setup
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+)
+
+type Command struct {
+	*command.BaseCommand
+	flags commandFlags
+	// state - populated by PreRun
+	accessible bool
+}
+
+type commandFlags struct {
+	accessible flags.BoolFlag
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewSetupCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string { return "setup" }
+func (c *Command) Short() string {
+	return "Set up your token, organization, output format, and shell completion"
+}
+
+func (c *Command) Long() string {
+	return `Walk through setting up the CLI: paste a personal access token, pick the default
+organization, choose the default output format and whether to use colors, and install
+shell completion for bash, zsh, or fish.
+
+The setup runs on its own the first time the CLI is run in a terminal. Each step is
+saved as it is completed; press Ctrl-C to stop, and run this command again to change
+your choices. To set up without prompts, e.g. in CI, see ` + "`censys config auth add --help`."
+}
+
+func (c *Command) Args() command.PositionalArgs { return command.ExactArgs(0) }
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort}
+}
+
+func (c *Command) Init() error {
+	c.flags.accessible = flags.NewBoolFlag(
+		c.Flags(),
+		"accessible",
+		"a",
+		false,
+		"enable accessible mode (non-redrawing)",
+	)
+	return nil
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.accessible, err = c.flags.accessible.Value()
+	return err
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	return RunWizard(cmd.Context(), c.Context, cmd.Root(), WizardOptions{Accessible: c.accessible})
+}
@@ -0,0 +1,125 @@
+package setup
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/sdkerrors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func TestDetectShell(t *testing.T) {
+	require.Equal(t, "zsh", detectShell("/bin/zsh"))
+	require.Equal(t, "bash", detectShell("/usr/local/bin/bash"))
+	require.Equal(t, "fish", detectShell("/opt/homebrew/bin/fish"))
+	require.Empty(t, detectShell("/bin/tcsh"))
+	require.Empty(t, detectShell(""))
+}
+
+func TestPlanCompletion(t *testing.T) {
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+
+	plan := planCompletion("censys", "zsh", "/home/u", "/home/u/.config/cencli", getenv)
+	require.Equal(t, completionInstall{shell: "zsh", script: "/home/u/.config/cencli/completion.zsh", rcFile: "/home/u/.zshrc"}, plan)
+	env["ZDOTDIR"] = "/home/u/.zsh"
+	require.Equal(t, "/home/u/.zsh/.zshrc", planCompletion("censys", "zsh", "/home/u", "/c", getenv).rcFile)
+
+	plan = planCompletion("censys", "bash", "/home/u", "/c", getenv)
+	require.Equal(t, completionInstall{shell: "bash", script: "/c/completion.bash", rcFile: "/home/u/.bashrc"}, plan)
+
+	plan = planCompletion("censys", "fish", "/home/u", "/c", getenv)
+	require.Equal(t, completionInstall{shell: "fish", script: "/home/u/.config/fish/completions/censys.fish"}, plan)
+	env["XDG_CONFIG_HOME"] = "/xdg"
+	require.Equal(t, "/xdg/fish/completions/censys.fish", planCompletion("censys", "fish", "/home/u", "/c", getenv).script)
+}
+
+func TestInstallCompletion(t *testing.T) {
+	root := &cobra.Command{Use: "censys"}
+	root.AddCommand(&cobra.Command{Use: "search", Run: func(*cobra.Command, []string) {}})
+	dir := t.TempDir()
+
+	t.Run("zsh", func(t *testing.T) {
+		plan := completionInstall{shell: "zsh", script: filepath.Join(dir, "cfg", "completion.zsh"), rcFile: filepath.Join(dir, ".zshrc")}
+		require.NoError(t, os.WriteFile(plan.rcFile, []byte("export EDITOR=vim"), 0o644))
+		require.NoError(t, plan.install(root))
+		require.NoError(t, plan.install(root), "installing again does not source the script twice")
+
+		script, err := os.ReadFile(plan.script)
+		require.NoError(t, err)
+		require.Contains(t, string(script), "#compdef censys")
+		rc, err := os.ReadFile(plan.rcFile)
+		require.NoError(t, err)
+		require.Equal(t, "export EDITOR=vim\n"+completionMarker+"\n"+
+			"(( $+functions[compdef] )) || { autoload -Uz compinit && compinit }\n"+
+			"source '"+plan.script+"'\n", string(rc))
+	})
+
+	t.Run("fish", func(t *testing.T) {
+		plan := completionInstall{shell: "fish", script: filepath.Join(dir, "fish", "completions", "censys.fish")}
+		require.NoError(t, plan.install(root))
+		script, err := os.ReadFile(plan.script)
+		require.NoError(t, err)
+		require.Contains(t, string(script), "complete -c censys")
+	})
+}
+
+func TestSetupCommand(t *testing.T) {
+	defer viper.Reset()
+	viper.Reset()
+	cfg, cfgErr := config.New(t.TempDir())
+	require.NoError(t, cfgErr)
+	require.True(t, cfg.FirstRun())
+	st := storemocks.NewMockStore(gomock.NewController(t))
+	st.EXPECT().GetLastUsedAuthByName(gomock.Any(), config.AuthName).Return(nil, authdom.ErrAuthNotFound)
+	ctx := command.NewCommandContext(cfg, st)
+
+	root, cerr := command.RootCommandToCobra(NewSetupCommand(ctx))
+	require.NoError(t, cerr)
+	// tests do not run in a terminal
+	require.False(t, ShouldRunOnFirstRun(context.Background(), ctx, root, nil))
+
+	var stderr bytes.Buffer
+	formatter.Stdout = &bytes.Buffer{}
+	formatter.Stderr = &stderr
+	root.SetArgs([]string{})
+	err := root.Execute()
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), "the setup needs input"), err.Error())
+	require.ErrorContains(t, err, "censys config auth add --value")
+}
+
+func TestReauth(t *testing.T) {
+	defer viper.Reset()
+	viper.Reset()
+	cfg, cfgErr := config.New(t.TempDir())
+	require.NoError(t, cfgErr)
+	ctx := command.NewCommandContext(cfg, storemocks.NewMockStore(gomock.NewController(t)))
+
+	expired := client.NewClientError(sdkerrors.NewSDKError("Unauthorized", 401, "", nil))
+	forbidden := client.NewClientError(sdkerrors.NewSDKError("Forbidden", 403, "", nil))
+	viewCmd := &cobra.Command{Use: "view"}
+
+	// tests do not run in a terminal
+	require.False(t, ShouldReauth(ctx, viewCmd, expired))
+	require.False(t, ShouldReauth(ctx, viewCmd, forbidden))
+	require.False(t, ShouldReauth(ctx, viewCmd, nil))
+
+	require.Contains(t, AuthFailureHint(cfg, expired), "censys config auth add")
+	require.Contains(t, AuthFailureHint(cfg, forbidden), "censys config org-id")
+	require.Empty(t, AuthFailureHint(cfg, client.NewClientError(sdkerrors.NewSDKError("Not Found", 404, "", nil))))
+}
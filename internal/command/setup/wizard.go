@@ -0,0 +1,350 @@
+package setup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/censyscopy"
+	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
+	"github.com/censys/cencli/internal/pkg/ui/form"
+	"github.com/censys/cencli/internal/store"
+)
+
+const (
+	// tokenName and orgName are the names of the token and organization ID
+	// added by the wizard, unless the organization has a name.
+	tokenName = "default"
+	orgName   = "default"
+	// enterOrgID and skipOrgID are the choices of the organization step
+	// besides the stored organization IDs.
+	enterOrgID = "enter"
+	skipOrgID  = "skip"
+)
+
+// skipOnFirstRun are the commands that run without the wizard on the first
+// run, as they need no setup or are how it is done by hand.
+var skipOnFirstRun = map[string]bool{
+	"setup":                         true,
+	"config":                        true,
+	"completion":                    true,
+	"version":                       true,
+	"update":                        true,
+	"help":                          true,
+	cobra.ShellCompRequestCmd:       true,
+	cobra.ShellCompNoDescRequestCmd: true,
+}
+
+// WizardOptions configure RunWizard.
+type WizardOptions struct {
+	// FirstRun is set when the wizard runs on the first run of the CLI,
+	// before the command that was run.
+	FirstRun bool
+	// Accessible prompts in accessible mode (non-redrawing).
+	Accessible bool
+}
+
+// ShouldRunOnFirstRun returns true if the wizard should run before the
+// command of args: on the first run of the CLI, in a terminal, before any
+// token is added, unless the command needs no setup.
+func ShouldRunOnFirstRun(ctx context.Context, cmdContext *command.Context, root *cobra.Command, args []string) bool {
+	cfg := cmdContext.Config()
+	if !cfg.FirstRun() || cfg.NonInteractive || !term.Interactive() {
+		return false
+	}
+	for _, arg := range args {
+		// flags are parsed after the wizard would run
+		if arg == "-h" || arg == "--help" || arg == "--non-interactive" || strings.HasPrefix(arg, "--non-interactive=") {
+			return false
+		}
+	}
+	cmd, _, err := root.Find(args)
+	if err != nil {
+		return false
+	}
+	for cmd.HasParent() && cmd.Parent() != root {
+		cmd = cmd.Parent()
+	}
+	if cmd != root && skipOnFirstRun[cmd.Name()] {
+		return false
+	}
+	_, err = cmdContext.Store().GetLastUsedAuthByName(ctx, config.AuthName)
+	return errors.Is(err, authdom.ErrAuthNotFound)
+}
+
+// RunWizard walks the user through adding a token, picking an organization,
+// choosing the output format and colors, and installing shell completion.
+// Each step is saved as it is completed, and the wizard stops at the step
+// the user aborts.
+func RunWizard(ctx context.Context, cmdContext *command.Context, root *cobra.Command, opts WizardOptions) cenclierrors.CencliError {
+	w := &wizard{cmdContext: cmdContext, root: root, opts: opts}
+	if opts.FirstRun {
+		formatter.Println(formatter.Stderr, styles.GlobalStyles.Signature.Render("Welcome to the Censys CLI!")+" "+
+			styles.GlobalStyles.Primary.Render("Let's get you set up; press Ctrl-C to skip and run `censys setup` later."))
+	}
+	for _, step := range []func(context.Context) error{w.token, w.organization, w.preferences, w.completion} {
+		err := step(ctx)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, form.ErrUserAborted) {
+			formatter.Println(formatter.Stderr, styles.GlobalStyles.Comment.Render("Setup stopped. Run `censys setup` to finish it."))
+			return nil
+		}
+		if errors.Is(err, form.ErrNonInteractive) {
+			return form.NewNonInteractiveError("the setup",
+				"add a token with `censys config auth add --value` and an organization ID with `censys config org-id add --value`")
+		}
+		var cencliErr cenclierrors.CencliError
+		if errors.As(err, &cencliErr) {
+			return cencliErr
+		}
+		return cenclierrors.NewCencliError(err)
+	}
+	formatter.Println(formatter.Stderr, "✅ You're all set. Run `censys --help` to see what you can do.")
+	return nil
+}
+
+type wizard struct {
+	cmdContext *command.Context
+	root       *cobra.Command
+	opts       WizardOptions
+}
+
+func (w *wizard) run(ctx context.Context, groups ...*huh.Group) error {
+	f := form.NewForm(huh.NewForm(groups...).WithOutput(os.Stderr), form.WithAccessible(w.opts.Accessible))
+	return f.RunWithContext(ctx)
+}
+
+// token adds a personal access token, or keeps the current one.
+func (w *wizard) token(ctx context.Context) error {
+	st := w.cmdContext.Store()
+	current, err := st.GetLastUsedAuthByName(ctx, config.AuthName)
+	if err != nil && !errors.Is(err, authdom.ErrAuthNotFound) {
+		return err
+	}
+	description := censyscopy.DocumentationPAT(formatter.Stderr)
+	validate := form.NonEmpty("token value cannot be empty")
+	if current != nil {
+		description += fmt.Sprintf("\nLeave empty to keep the current token [%s]", current.Description)
+		validate = func(string) error { return nil }
+	}
+	var value string
+	if err := w.run(ctx, huh.NewGroup(
+		huh.NewInput().
+			EchoMode(huh.EchoModePassword).
+			Title("Paste your personal access token").
+			Description(description).
+			Value(&value).
+			Validate(validate),
+	)); err != nil {
+		return err
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	rec, err := st.AddValueForAuth(ctx, config.AuthName, tokenName, value)
+	if err != nil {
+		return fmt.Errorf("failed to add auth value: %w", err)
+	}
+	if err := st.UpdateAuthLastUsedAtToNow(ctx, rec.ID); err != nil {
+		return fmt.Errorf("failed to activate auth: %w", err)
+	}
+	formatter.Printf(formatter.Stderr, "✅ Added new personal access token [%s]\n", tokenName)
+	return w.cmdContext.ReconnectCensysClient(ctx)
+}
+
+// organization activates a stored organization ID, or adds one that is
+// looked up with the token, so that a mistyped ID or a token without access
+// to the organization is caught here.
+func (w *wizard) organization(ctx context.Context) error {
+	st := w.cmdContext.Store()
+	stored, err := st.GetValuesForGlobal(ctx, config.OrgIDGlobalName)
+	if err != nil && !errors.Is(err, store.ErrGlobalNotFound) {
+		return err
+	}
+	choice := enterOrgID
+	options := make([]huh.Option[string], 0, len(stored)+2)
+	if active, err := st.GetLastUsedGlobalByName(ctx, config.OrgIDGlobalName); err == nil {
+		choice = strconv.FormatInt(active.ID, 10)
+	}
+	for _, v := range stored {
+		options = append(options, huh.NewOption(fmt.Sprintf("%s (%s)", v.Description, v.Value), strconv.FormatInt(v.ID, 10)))
+	}
+	options = append(options,
+		huh.NewOption("Enter an organization ID", enterOrgID),
+		huh.NewOption("Skip (commands run without an organization)", skipOrgID),
+	)
+	if err := w.run(ctx, huh.NewGroup(
+		huh.NewSelect[string]().
+			Title("Pick your default organization").
+			Description("Commands run against it unless --org-id is set").
+			Options(options...).
+			Value(&choice),
+	)); err != nil {
+		return err
+	}
+
+	switch choice {
+	case skipOrgID:
+		return nil
+	case enterOrgID:
+		return w.addOrganization(ctx)
+	}
+	id, err := strconv.ParseInt(choice, 10, 64)
+	if err != nil {
+		return err
+	}
+	if err := st.UpdateGlobalLastUsedAtToNow(ctx, id); err != nil {
+		return fmt.Errorf("failed to activate organization ID: %w", err)
+	}
+	return w.cmdContext.ReconnectCensysClient(ctx)
+}
+
+func (w *wizard) addOrganization(ctx context.Context) error {
+	var value string
+	// names of the organizations looked up, by ID, as the input is
+	// validated more than once
+	names := map[string]string{}
+	lookup := func(s string) error {
+		parsed, err := uuid.Parse(strings.TrimSpace(s))
+		if err != nil {
+			return errors.New("organization ID must be a valid UUID")
+		}
+		if _, ok := names[parsed.String()]; ok {
+			return nil
+		}
+		svc, cerr := w.cmdContext.OrganizationsService()
+		if cerr != nil {
+			// without a token, the organization cannot be looked up
+			names[parsed.String()] = ""
+			return nil
+		}
+		res, cerr := svc.GetOrganizationDetails(ctx, identifiers.NewOrganizationID(parsed))
+		if cerr != nil {
+			return fmt.Errorf("could not look up the organization: %v", cerr)
+		}
+		names[parsed.String()] = res.Data.Name
+		return nil
+	}
+	if err := w.run(ctx, huh.NewGroup(
+		huh.NewInput().
+			Title("Enter your organization ID").
+			Description(censyscopy.DocumentationOrgID(formatter.Stderr)).
+			Value(&value).
+			Validate(lookup),
+	)); err != nil {
+		return err
+	}
+	orgID := uuid.MustParse(strings.TrimSpace(value)).String()
+	name := names[orgID]
+	if name == "" {
+		name = orgName
+	}
+	st := w.cmdContext.Store()
+	rec, err := st.AddValueForGlobal(ctx, config.OrgIDGlobalName, name, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to add global value: %w", err)
+	}
+	if err := st.UpdateGlobalLastUsedAtToNow(ctx, rec.ID); err != nil {
+		return fmt.Errorf("failed to activate organization ID: %w", err)
+	}
+	formatter.Printf(formatter.Stderr, "✅ Added new organization ID [%s]\n", name)
+	return w.cmdContext.ReconnectCensysClient(ctx)
+}
+
+// preferences saves the output format and color preference to the config file.
+func (w *wizard) preferences(ctx context.Context) error {
+	cfg := w.cmdContext.Config()
+	outputFormat := cfg.OutputFormat
+	if outputFormat != formatter.OutputFormatYAML && outputFormat != formatter.OutputFormatTree {
+		outputFormat = formatter.OutputFormatJSON
+	}
+	colors := !cfg.NoColor
+	if err := w.run(ctx, huh.NewGroup(
+		huh.NewSelect[formatter.OutputFormat]().
+			Title("Pick your default output format").
+			Description("How commands such as search and view print their data, unless --output-format is set").
+			Options(
+				huh.NewOption("json", formatter.OutputFormatJSON),
+				huh.NewOption("yaml", formatter.OutputFormatYAML),
+				huh.NewOption("tree", formatter.OutputFormatTree),
+			).
+			Value(&outputFormat),
+		huh.NewConfirm().
+			Title("Use colors?").
+			Description("Colors are never used when the output is not a terminal").
+			Affirmative("Yes").
+			Negative("No").
+			Value(&colors),
+	)); err != nil {
+		return err
+	}
+	if err := cfg.SaveSettings(map[string]any{
+		formatter.OutputFormatFlagName: outputFormat.String(),
+		"no-color":                     !colors,
+	}); err != nil {
+		return err
+	}
+	formatter.Printf(formatter.Stderr, "✅ Saved your preferences to %s\n", config.FilePath())
+	return nil
+}
+
+// completion installs the shell completion of the CLI, for the shell of
+// $SHELL.
+func (w *wizard) completion(ctx context.Context) error {
+	shell := detectShell(os.Getenv("SHELL"))
+	if shell == "" {
+		formatter.Println(formatter.Stderr, styles.GlobalStyles.Comment.Render(
+			"Run `censys completion --help` to set up shell completion."))
+		return nil
+	}
+	install := true
+	if err := w.run(ctx, huh.NewGroup(
+		huh.NewConfirm().
+			Title(fmt.Sprintf("Install shell completion for %s?", shell)).
+			Description("Complete commands and flags with Tab").
+			Affirmative("Yes").
+			Negative("No").
+			Value(&install),
+	)); err != nil {
+		return err
+	}
+	if !install {
+		return nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return newCompletionInstallError(shell, err)
+	}
+	configDir := w.cmdContext.Dirs().Config
+	if configDir == "" {
+		configDir = filepath.Dir(config.FilePath())
+	}
+	plan := planCompletion(w.root.Name(), shell, home, configDir, os.Getenv)
+	if err := plan.install(w.root); err != nil {
+		return newCompletionInstallError(shell, err)
+	}
+	if plan.rcFile != "" {
+		formatter.Printf(formatter.Stderr, "✅ Installed %s completion, sourced from %s; open a new shell to use it\n", shell, plan.rcFile)
+	} else {
+		formatter.Printf(formatter.Stderr, "✅ Installed %s completion in %s; open a new shell to use it\n", shell, plan.script)
+	}
+	return nil
+}
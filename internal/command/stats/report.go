@@ -0,0 +1,162 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/censys/cencli/internal/pkg/datetime"
+	"github.com/censys/cencli/internal/store"
+)
+
+const (
+	// maxRows is the number of rows of each ranking in the report.
+	maxRows = 10
+	// dayFormat is how days are named in the report.
+	dayFormat = "2006-01-02"
+)
+
+// report is the data output of the command: the usage recorded since a
+// point in time, ranked by how often each command, query, and day was seen.
+type report struct {
+	Since       time.Time      `json:"since"`
+	Runs        int            `json:"runs"`
+	FailedRuns  int            `json:"failed_runs"`
+	Requests    int64          `json:"requests"`
+	Commands    []commandCount `json:"commands"`
+	Queries     []queryCount   `json:"queries"`
+	BusiestDays []dayCount     `json:"busiest_days"`
+	Search      searchStats    `json:"search"`
+	Cache       cacheStats     `json:"cache"`
+}
+
+type commandCount struct {
+	Command string `json:"command"`
+	Runs    int    `json:"runs"`
+	// AverageSeconds is the average wall-clock duration of a run.
+	AverageSeconds float64 `json:"average_seconds"`
+}
+
+type queryCount struct {
+	Query string `json:"query"`
+	Runs  int    `json:"runs"`
+}
+
+type dayCount struct {
+	Day      string `json:"day"`
+	Runs     int    `json:"runs"`
+	Requests int64  `json:"requests"`
+}
+
+type searchStats struct {
+	Requests int64 `json:"requests"`
+	// AverageLatencySeconds is the average latency of a search request,
+	// or 0 if there were none.
+	AverageLatencySeconds float64 `json:"average_latency_seconds"`
+}
+
+type cacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+	// HitRate is the fraction of lookups served from the cache, or null if
+	// nothing was looked up.
+	HitRate *float64 `json:"hit_rate"`
+}
+
+// summarize builds the report of events. Days are counted in the display
+// timezone.
+func summarize(since time.Time, events []*store.UsageEvent) *report {
+	r := &report{
+		Since:       since,
+		Commands:    []commandCount{},
+		Queries:     []queryCount{},
+		BusiestDays: []dayCount{},
+	}
+	type commandTotals struct {
+		runs     int
+		duration time.Duration
+	}
+	commands := make(map[string]*commandTotals)
+	queries := make(map[string]int)
+	days := make(map[string]*dayCount)
+	var searchLatency time.Duration
+	for _, e := range events {
+		r.Runs++
+		if e.Failed {
+			r.FailedRuns++
+		}
+		r.Requests += e.Requests
+
+		totals, ok := commands[e.Command]
+		if !ok {
+			totals = &commandTotals{}
+			commands[e.Command] = totals
+		}
+		totals.runs++
+		totals.duration += e.Duration
+
+		if e.Query != "" {
+			queries[e.Query]++
+		}
+
+		day := datetime.InDisplayTimeZone(e.StartedAt).Format(dayFormat)
+		dc, ok := days[day]
+		if !ok {
+			dc = &dayCount{Day: day}
+			days[day] = dc
+		}
+		dc.Runs++
+		dc.Requests += e.Requests
+
+		r.Search.Requests += e.SearchRequests
+		searchLatency += e.SearchLatency
+		r.Cache.Hits += e.CacheHits
+		r.Cache.Misses += e.CacheMisses
+	}
+
+	for name, totals := range commands {
+		r.Commands = append(r.Commands, commandCount{
+			Command:        name,
+			Runs:           totals.runs,
+			AverageSeconds: (totals.duration / time.Duration(totals.runs)).Seconds(),
+		})
+	}
+	sort.Slice(r.Commands, func(i, j int) bool {
+		if r.Commands[i].Runs != r.Commands[j].Runs {
+			return r.Commands[i].Runs > r.Commands[j].Runs
+		}
+		return r.Commands[i].Command < r.Commands[j].Command
+	})
+	r.Commands = r.Commands[:min(len(r.Commands), maxRows)]
+
+	for query, runs := range queries {
+		r.Queries = append(r.Queries, queryCount{Query: query, Runs: runs})
+	}
+	sort.Slice(r.Queries, func(i, j int) bool {
+		if r.Queries[i].Runs != r.Queries[j].Runs {
+			return r.Queries[i].Runs > r.Queries[j].Runs
+		}
+		return r.Queries[i].Query < r.Queries[j].Query
+	})
+	r.Queries = r.Queries[:min(len(r.Queries), maxRows)]
+
+	for _, dc := range days {
+		r.BusiestDays = append(r.BusiestDays, *dc)
+	}
+	sort.Slice(r.BusiestDays, func(i, j int) bool {
+		if r.BusiestDays[i].Runs != r.BusiestDays[j].Runs {
+			return r.BusiestDays[i].Runs > r.BusiestDays[j].Runs
+		}
+		// the most recent day first
+		return r.BusiestDays[i].Day > r.BusiestDays[j].Day
+	})
+	r.BusiestDays = r.BusiestDays[:min(len(r.BusiestDays), maxRows)]
+
+	if r.Search.Requests > 0 {
+		r.Search.AverageLatencySeconds = (searchLatency / time.Duration(r.Search.Requests)).Seconds()
+	}
+	if lookups := r.Cache.Hits + r.Cache.Misses; lookups > 0 {
+		rate := float64(r.Cache.Hits) / float64(lookups)
+		r.Cache.HitRate = &rate
+	}
+	return r
+}
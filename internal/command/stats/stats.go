@@ -0,0 +1,244 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+const (
+	cmdName = "stats"
+
+	defaultLast = 30 * 24 * time.Hour
+)
+
+type Command struct {
+	*command.BaseCommand
+	// flags the command uses
+	flags statsCommandFlags
+	// state - populated by PreRun
+	since time.Time
+	clear bool
+	// result - populated by Run
+	report *report
+}
+
+type statsCommandFlags struct {
+	last  flags.HumanDurationFlag
+	clear flags.BoolFlag
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewStatsCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return cmdName
+}
+
+func (c *Command) Short() string {
+	return "Summarize your local usage of cencli"
+}
+
+func (c *Command) Long() string {
+	return `Summarize how you have used cencli: the commands and queries you run most,
+your busiest days, the average latency of search requests, and how often
+cached data (such as --xref threat feeds) was used instead of fetching it.
+
+Each run of a command is recorded in the local store with its command,
+query, duration, and API request counts. This data never leaves your
+machine; runs older than a year are removed. Set usage-stats to false in the
+config file (or CENCLI_USAGE_STATS=false) to stop recording, and use --clear
+to remove what was recorded.
+
+The API does not report the credits used by each request, so credits are
+not part of these stats; use ` + "`censys credits`" + ` to check your balance.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"",
+		"--last 7d",
+		"-O json | jq '.queries'",
+		"--clear",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort, command.OutputTypeData}
+}
+
+func (c *Command) Init() error {
+	c.flags.last = flags.NewHumanDurationFlag(c.Flags(), false, "last", "l", mo.Some(defaultLast),
+		"only include runs from this long ago (e.g., 7d, 4w, 1y). Defaults to 30d")
+	c.flags.clear = flags.NewBoolFlag(c.Flags(), "clear", "", false, "remove all recorded usage instead of summarizing it")
+	return nil
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.clear, err = c.flags.clear.Value(); err != nil {
+		return err
+	}
+	if c.clear && cmd.Flags().Changed("last") {
+		return flags.NewConflictingFlagsError("clear", "last")
+	}
+	last, err := c.flags.last.Value()
+	if err != nil {
+		return err
+	}
+	c.since = c.Now().Add(-last.OrElse(defaultLast))
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(cmdName).With("since", c.since, "clear", c.clear)
+	ctx := cmd.Context()
+
+	if c.clear {
+		removed, err := c.Store().ClearUsage(ctx)
+		if err != nil {
+			return cenclierrors.NewCencliError(err)
+		}
+		logger.Debug("cleared usage", "removed", removed)
+		if !c.Config().Quiet {
+			formatter.Printf(formatter.Stderr, "Removed %d recorded runs.\n", removed)
+		}
+		return nil
+	}
+
+	events, err := c.Store().GetUsageSince(ctx, c.since)
+	if err != nil {
+		return cenclierrors.NewCencliError(err)
+	}
+	if !c.Config().UsageStats && !c.Config().Quiet {
+		formatter.Println(formatter.Stderr, styles.GlobalStyles.Comment.Render(
+			"Usage stats are disabled (usage-stats is false), so new runs are not recorded."))
+	}
+	c.report = summarize(c.since, events)
+	return c.PrintData(c, c.report)
+}
+
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	r := c.report
+	if r.Runs == 0 {
+		formatter.Println(formatter.Stdout, styles.GlobalStyles.Comment.Render(
+			fmt.Sprintf("No runs recorded since %s.", r.Since.Format(dayFormat))))
+		return nil
+	}
+	formatter.Printf(formatter.Stdout, "%s runs since %s (%s failed), %s API requests\n",
+		formatter.FormatInt(int64(r.Runs)), r.Since.Format(dayFormat), formatter.FormatInt(int64(r.FailedRuns)), formatter.FormatInt(r.Requests))
+
+	printSection("Most used commands:")
+	fmt.Fprint(formatter.Stdout, newTable([]rawtable.Column[commandCount]{
+		{
+			Title:      "Command",
+			String:     func(cc commandCount) string { return cc.Command },
+			Style:      func(s string, _ commandCount) string { return styles.GlobalStyles.Signature.Render(s) },
+			Priority:   3,
+			NoTruncate: true,
+		},
+		{
+			Title:    "Runs",
+			String:   func(cc commandCount) string { return formatter.FormatInt(int64(cc.Runs)) },
+			Priority: 2,
+		},
+		{
+			Title:    "Avg Duration",
+			String:   func(cc commandCount) string { return formatSeconds(cc.AverageSeconds) },
+			Priority: 1,
+		},
+	}).Render(r.Commands))
+
+	if len(r.Queries) > 0 {
+		printSection("Most used queries:")
+		fmt.Fprint(formatter.Stdout, newTable([]rawtable.Column[queryCount]{
+			{
+				Title:    "Runs",
+				String:   func(qc queryCount) string { return formatter.FormatInt(int64(qc.Runs)) },
+				Priority: 2,
+			},
+			{
+				Title:    "Query",
+				String:   func(qc queryCount) string { return qc.Query },
+				Style:    func(s string, _ queryCount) string { return styles.GlobalStyles.Primary.Render(s) },
+				Priority: 1,
+			},
+		}).Render(r.Queries))
+	}
+
+	printSection("Busiest days:")
+	fmt.Fprint(formatter.Stdout, newTable([]rawtable.Column[dayCount]{
+		{
+			Title:      "Day",
+			String:     func(dc dayCount) string { return dc.Day },
+			Priority:   3,
+			NoTruncate: true,
+		},
+		{
+			Title:    "Runs",
+			String:   func(dc dayCount) string { return formatter.FormatInt(int64(dc.Runs)) },
+			Priority: 2,
+		},
+		{
+			Title:    "Requests",
+			String:   func(dc dayCount) string { return formatter.FormatInt(dc.Requests) },
+			Priority: 1,
+		},
+	}).Render(r.BusiestDays))
+
+	formatter.Println(formatter.Stdout, "")
+	if r.Search.Requests > 0 {
+		formatter.Printf(formatter.Stdout, "%s %s over %s search requests\n",
+			styles.GlobalStyles.Comment.Render("Average search latency:"),
+			formatSeconds(r.Search.AverageLatencySeconds), formatter.FormatInt(r.Search.Requests))
+	} else {
+		formatter.Printf(formatter.Stdout, "%s no search requests\n", styles.GlobalStyles.Comment.Render("Average search latency:"))
+	}
+	if r.Cache.HitRate != nil {
+		formatter.Printf(formatter.Stdout, "%s %.0f%% (%s of %s lookups)\n",
+			styles.GlobalStyles.Comment.Render("Cache hit rate:"),
+			*r.Cache.HitRate*100, formatter.FormatInt(r.Cache.Hits), formatter.FormatInt(r.Cache.Hits+r.Cache.Misses))
+	} else {
+		formatter.Printf(formatter.Stdout, "%s no cached data was looked up\n", styles.GlobalStyles.Comment.Render("Cache hit rate:"))
+	}
+	return nil
+}
+
+// printSection prints the title of a table of the report.
+func printSection(title string) {
+	formatter.Printf(formatter.Stdout, "\n%s\n", styles.GlobalStyles.Comment.Render(title))
+}
+
+func newTable[T any](columns []rawtable.Column[T]) *rawtable.Table[T] {
+	return rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[T](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[T](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[T](formatter.TableWidth()),
+	)
+}
+
+// formatSeconds formats a duration in seconds, rounded to the millisecond.
+func formatSeconds(seconds float64) string {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Millisecond).String()
+}
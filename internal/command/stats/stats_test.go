@@ -0,0 +1,135 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/store"
+)
+
+func runStats(t *testing.T, st store.Store, args ...string) (string, string, error) {
+	t.Helper()
+	viper.Reset()
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	cmdContext := command.NewCommandContext(cfg, st)
+	rootCmd, err := command.RootCommandToCobra(NewStatsCommand(cmdContext))
+	require.NoError(t, err)
+
+	rootCmd.SetArgs(args)
+	cmdErr := rootCmd.Execute()
+	return stdout.String(), stderr.String(), cmdErr
+}
+
+func TestSummarize(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, time.UTC) }
+	events := []*store.UsageEvent{
+		{Command: "search", Query: "host.services.port: 22", StartedAt: day(14, 9), Duration: time.Second, Requests: 2, SearchRequests: 2, SearchLatency: 600 * time.Millisecond},
+		{Command: "search", Query: "host.services.port: 22", StartedAt: day(15, 9), Duration: 3 * time.Second, Requests: 1, SearchRequests: 1, SearchLatency: 300 * time.Millisecond, CacheHits: 3, CacheMisses: 1},
+		{Command: "aggregate", Query: "web.port: 443", StartedAt: day(15, 10), Duration: time.Second, Requests: 1, Failed: true},
+		{Command: "view", StartedAt: day(15, 11), Duration: time.Second, Requests: 1},
+	}
+
+	r := summarize(day(1, 0), events)
+	require.Equal(t, 4, r.Runs)
+	require.Equal(t, 1, r.FailedRuns)
+	require.Equal(t, int64(5), r.Requests)
+	require.Equal(t, []commandCount{
+		{Command: "search", Runs: 2, AverageSeconds: 2},
+		{Command: "aggregate", Runs: 1, AverageSeconds: 1},
+		{Command: "view", Runs: 1, AverageSeconds: 1},
+	}, r.Commands)
+	require.Equal(t, []queryCount{
+		{Query: "host.services.port: 22", Runs: 2},
+		{Query: "web.port: 443", Runs: 1},
+	}, r.Queries)
+	require.Equal(t, []dayCount{
+		{Day: "2026-10-15", Runs: 3, Requests: 3},
+		{Day: "2026-10-14", Runs: 1, Requests: 2},
+	}, r.BusiestDays)
+	require.Equal(t, searchStats{Requests: 3, AverageLatencySeconds: 0.3}, r.Search)
+	require.Equal(t, int64(3), r.Cache.Hits)
+	require.NotNil(t, r.Cache.HitRate)
+	require.InDelta(t, 0.75, *r.Cache.HitRate, 1e-9)
+
+	empty := summarize(day(1, 0), nil)
+	require.Zero(t, empty.Runs)
+	require.Nil(t, empty.Cache.HitRate)
+	require.Empty(t, empty.Commands)
+}
+
+func TestStatsCommand(t *testing.T) {
+	events := []*store.UsageEvent{
+		{Command: "search", Query: "host.services.port: 22", StartedAt: time.Now().Add(-time.Hour), Duration: time.Second, Requests: 1, SearchRequests: 1, SearchLatency: 250 * time.Millisecond},
+	}
+
+	t.Run("short", func(t *testing.T) {
+		st := storemocks.NewMockStore(gomock.NewController(t))
+		st.EXPECT().GetUsageSince(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, since time.Time) ([]*store.UsageEvent, error) {
+				require.WithinDuration(t, time.Now().Add(-defaultLast), since, time.Minute)
+				return events, nil
+			})
+		stdout, _, err := runStats(t, st)
+		require.NoError(t, err)
+		require.Contains(t, stdout, "1 runs since")
+		require.Contains(t, stdout, "Most used queries:")
+		require.Contains(t, stdout, "host.services.port: 22")
+		require.Contains(t, stdout, "Average search latency: 250ms over 1 search requests")
+		require.Contains(t, stdout, "Cache hit rate: no cached data was looked up")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		st := storemocks.NewMockStore(gomock.NewController(t))
+		st.EXPECT().GetUsageSince(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, since time.Time) ([]*store.UsageEvent, error) {
+				require.WithinDuration(t, time.Now().Add(-7*24*time.Hour), since, time.Minute)
+				return events, nil
+			})
+		stdout, _, err := runStats(t, st, "--last", "7d", "-O", "json")
+		require.NoError(t, err)
+		var r report
+		require.NoError(t, json.Unmarshal([]byte(stdout), &r))
+		require.Equal(t, 1, r.Runs)
+		require.Equal(t, []queryCount{{Query: "host.services.port: 22", Runs: 1}}, r.Queries)
+	})
+
+	t.Run("no runs", func(t *testing.T) {
+		st := storemocks.NewMockStore(gomock.NewController(t))
+		st.EXPECT().GetUsageSince(gomock.Any(), gomock.Any()).Return(nil, nil)
+		stdout, _, err := runStats(t, st)
+		require.NoError(t, err)
+		require.Contains(t, stdout, "No runs recorded since")
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		st := storemocks.NewMockStore(gomock.NewController(t))
+		st.EXPECT().ClearUsage(gomock.Any()).Return(int64(4), nil)
+		stdout, stderr, err := runStats(t, st, "--clear")
+		require.NoError(t, err)
+		require.Empty(t, stdout)
+		require.Contains(t, stderr, "Removed 4 recorded runs.")
+	})
+
+	t.Run("clear conflicts with last", func(t *testing.T) {
+		st := storemocks.NewMockStore(gomock.NewController(t))
+		_, _, err := runStats(t, st, "--clear", "--last", "7d")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// applyTheme switches the styles to the color theme of the running command:
+// the theme passed with --theme, or else the one configured for the command
+// in theme.commands, or else theme.name.
+func (c *Context) applyTheme(cobraCmd *cobra.Command) cenclierrors.CencliError {
+	name := c.config.Theme.Name
+	if f := cobraCmd.Flag(config.ThemeFlagName); f == nil || !f.Changed {
+		path := strings.Fields(cobraCmd.CommandPath())
+		if len(path) > 1 {
+			name = c.config.Theme.For(strings.Join(path[1:], " "))
+		}
+	}
+	theme, err := styles.ParseTheme(string(name))
+	if err != nil {
+		return cenclierrors.NewUsageError(fmt.Errorf("invalid --%s: %w", config.ThemeFlagName, err))
+	}
+	styles.SetTheme(theme)
+	return nil
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+func TestApplyTheme(t *testing.T) {
+	t.Cleanup(func() { styles.SetTheme(styles.ThemeDefault) })
+
+	// newCommand returns the command `censys <name> <sub>`, with --theme set
+	// to flag unless it is empty.
+	newCommand := func(t *testing.T, name, sub, flag string) *cobra.Command {
+		root := &cobra.Command{Use: "censys"}
+		root.PersistentFlags().String(config.ThemeFlagName, "", "")
+		parent := &cobra.Command{Use: name}
+		child := &cobra.Command{Use: sub}
+		root.AddCommand(parent)
+		parent.AddCommand(child)
+		if flag != "" {
+			require.NoError(t, root.PersistentFlags().Set(config.ThemeFlagName, flag))
+		}
+		return child
+	}
+
+	tests := []struct {
+		name      string
+		command   string
+		flag      string
+		flagValue styles.Theme
+		want      styles.Theme
+	}{
+		{name: "the theme of the subcommand", command: "members", want: styles.ThemeMonochrome},
+		{name: "the theme of the top-level command", command: "details", want: styles.ThemeColorblind},
+		{name: "the flag wins", command: "members", flag: "solarized", flagValue: styles.ThemeSolarized, want: styles.ThemeSolarized},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+			cfg.Theme = config.ThemeConfig{
+				Name:     styles.ThemeHighContrast,
+				Commands: map[string]styles.Theme{"org": styles.ThemeColorblind, "org members": styles.ThemeMonochrome},
+			}
+			if tc.flag != "" {
+				// --theme is bound to theme.name
+				cfg.Theme.Name = tc.flagValue
+			}
+			c := NewCommandContext(cfg, nil)
+			require.NoError(t, c.applyTheme(newCommand(t, "org", tc.command, tc.flag)))
+			require.Equal(t, tc.want, styles.ActiveTheme())
+		})
+	}
+
+	t.Run("other commands use the theme", func(t *testing.T) {
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+		cfg.Theme.Name = styles.ThemeHighContrast
+		c := NewCommandContext(cfg, nil)
+		require.NoError(t, c.applyTheme(newCommand(t, "search", "x", "")))
+		require.Equal(t, styles.ThemeHighContrast, styles.ActiveTheme())
+	})
+
+	t.Run("unknown themes are rejected", func(t *testing.T) {
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+		cfg.Theme.Name = "neon"
+		c := NewCommandContext(cfg, nil)
+		require.ErrorContains(t, c.applyTheme(newCommand(t, "search", "x", "neon")), `unknown theme "neon"`)
+	})
+}
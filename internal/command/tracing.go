@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/tracing"
+	"github.com/censys/cencli/internal/version"
+)
+
+// traceExportTimeout bounds sending the traces of the run to the collector.
+const traceExportTimeout = 5 * time.Second
+
+// StartTracing starts the span of the command when tracing is configured,
+// and returns the context to run the command in along with a function that
+// ends the span and exports the trace once the command is done. Exporting is
+// best-effort: a failure is reported as a warning and never changes the
+// exit code.
+func StartTracing(ctx context.Context, cfg *config.Config) (context.Context, func(cmd *cobra.Command, err error)) {
+	if !cfg.Tracing.Enabled() {
+		return ctx, func(*cobra.Command, error) {}
+	}
+	tracer := tracing.New()
+	ctx, span := tracing.Start(tracing.WithTracer(ctx, tracer), "cencli", tracing.KindInternal,
+		tracing.String("cencli.version", version.Version))
+
+	return ctx, func(cmd *cobra.Command, err error) {
+		if cmd != nil {
+			span.SetName(cmd.CommandPath())
+			span.SetAttributes(tracing.String("cencli.command", cmd.CommandPath()))
+		}
+		span.SetAttributes(tracing.Int("process.exit.code", int64(formatter.ExitCode(err))))
+		span.SetError(err)
+		span.End()
+
+		headers, headerErr := tracing.ParseHeaders(cfg.Tracing.Headers)
+		if headerErr != nil {
+			warnTracing(headerErr)
+			return
+		}
+		exportCtx, cancel := context.WithTimeout(context.Background(), traceExportTimeout)
+		defer cancel()
+		if exportErr := tracer.Export(exportCtx, tracing.ExportOptions{
+			Endpoint:       cfg.Tracing.Endpoint,
+			Headers:        headers,
+			ServiceName:    cfg.Tracing.ServiceName,
+			ServiceVersion: version.Version,
+			Client:         &http.Client{Timeout: traceExportTimeout},
+		}); exportErr != nil {
+			warnTracing(exportErr)
+		}
+	}
+}
+
+func warnTracing(err error) {
+	formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render("Warning: "+err.Error()))
+}
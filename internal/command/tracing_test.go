@@ -0,0 +1,63 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/tracing"
+)
+
+func TestStartTracing(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		ctx := context.Background()
+		cfg := &config.Config{}
+		traceCtx, finish := StartTracing(ctx, cfg)
+		assert.Equal(t, ctx, traceCtx)
+		finish(nil, nil)
+	})
+
+	t.Run("exports the span of the command", func(t *testing.T) {
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ = io.ReadAll(r.Body)
+		}))
+		defer server.Close()
+
+		cfg := &config.Config{Tracing: config.TracingConfig{Endpoint: server.URL, ServiceName: "cencli"}}
+		traceCtx, finish := StartTracing(context.Background(), cfg)
+		_, span := tracing.Start(traceCtx, "child", tracing.KindInternal)
+		require.NotNil(t, span)
+		span.End()
+		finish(&cobra.Command{Use: "view"}, nil)
+
+		assert.Contains(t, string(body), `"view"`)
+		assert.Contains(t, string(body), `"child"`)
+	})
+
+	t.Run("export failures are only warnings", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		var stderr bytes.Buffer
+		prev := formatter.Stderr
+		formatter.Stderr = &stderr
+		defer func() { formatter.Stderr = prev }()
+
+		cfg := &config.Config{Tracing: config.TracingConfig{Endpoint: server.URL}}
+		_, finish := StartTracing(context.Background(), cfg)
+		finish(nil, errors.New("boom"))
+		assert.Contains(t, stderr.String(), "Warning:")
+	})
+}
@@ -0,0 +1,101 @@
+package update
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+type InvalidChannelError interface {
+	cenclierrors.CencliError
+}
+
+type invalidChannelError struct {
+	channel string
+}
+
+var _ InvalidChannelError = &invalidChannelError{}
+
+func newInvalidChannelError(channel string) InvalidChannelError {
+	return &invalidChannelError{channel: channel}
+}
+
+func (e *invalidChannelError) Error() string {
+	return fmt.Sprintf("invalid value for --channel: %q (must be one of: stable, beta)", e.channel)
+}
+
+func (e *invalidChannelError) Title() string { return "Invalid Channel" }
+
+func (e *invalidChannelError) ShouldPrintUsage() bool { return true }
+
+type DevelopmentBuildError interface {
+	cenclierrors.CencliError
+}
+
+type developmentBuildError struct {
+	version string
+}
+
+var _ DevelopmentBuildError = &developmentBuildError{}
+
+func newDevelopmentBuildError(version string) DevelopmentBuildError {
+	return &developmentBuildError{version: version}
+}
+
+func (e *developmentBuildError) Error() string {
+	return fmt.Sprintf("this is a development build (%s) and cannot be updated; install a release from https://github.com/censys/cencli/releases", e.version)
+}
+
+func (e *developmentBuildError) Title() string { return "Development Build" }
+
+func (e *developmentBuildError) ShouldPrintUsage() bool { return false }
+
+type ManagedInstallError interface {
+	cenclierrors.CencliError
+}
+
+type managedInstallError struct {
+	manager string
+}
+
+var _ ManagedInstallError = &managedInstallError{}
+
+func newManagedInstallError(manager string) ManagedInstallError {
+	return &managedInstallError{manager: manager}
+}
+
+func (e *managedInstallError) Error() string {
+	if e.manager == "Homebrew" {
+		return "cencli was installed with Homebrew; upgrade it with: brew upgrade --cask cencli"
+	}
+	return fmt.Sprintf("cencli was installed with %s; upgrade it with %s instead", e.manager, e.manager)
+}
+
+func (e *managedInstallError) Title() string { return "Managed Installation" }
+
+func (e *managedInstallError) ShouldPrintUsage() bool { return false }
+
+type UpdateFailedError interface {
+	cenclierrors.CencliError
+}
+
+type updateFailedError struct {
+	step string
+	err  error
+}
+
+var _ UpdateFailedError = &updateFailedError{}
+
+func newUpdateFailedError(step string, err error) UpdateFailedError {
+	return &updateFailedError{step: step, err: err}
+}
+
+func (e *updateFailedError) Error() string {
+	return fmt.Sprintf("failed to %s: %s", e.step, e.err)
+}
+
+func (e *updateFailedError) Title() string { return "Update Failed" }
+
+func (e *updateFailedError) ShouldPrintUsage() bool { return false }
+
+func (e *updateFailedError) Unwrap() error { return e.err }
@@ -0,0 +1,278 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/selfupdate"
+	"github.com/censys/cencli/internal/pkg/styles"
+	appversion "github.com/censys/cencli/internal/version"
+)
+
+const cmdName = "update"
+
+// releaseSource finds and downloads releases. Implemented by *selfupdate.Client.
+type releaseSource interface {
+	Latest(ctx context.Context, channel selfupdate.Channel) (selfupdate.Release, error)
+	Download(ctx context.Context, asset selfupdate.Asset) ([]byte, error)
+}
+
+type Command struct {
+	*command.BaseCommand
+	// flags the command uses
+	flags updateCommandFlags
+	// state parsed from flags
+	channel   selfupdate.Channel
+	checkOnly bool
+	// dependencies, replaced in tests
+	releases   releaseSource
+	executable func() (string, error)
+	current    string
+	goos       string
+	goarch     string
+	// result stored for rendering
+	result updateResult
+}
+
+type updateCommandFlags struct {
+	channel flags.StringFlag
+	check   flags.BoolFlag
+}
+
+// updateResult describes what the command found and did.
+type updateResult struct {
+	Current         string `json:"current"`
+	Latest          string `json:"latest"`
+	Channel         string `json:"channel"`
+	UpdateAvailable bool   `json:"update_available"`
+	Updated         bool   `json:"updated"`
+	ReleaseURL      string `json:"release_url,omitempty"`
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewUpdateCommand(cmdContext *command.Context) *Command {
+	return &Command{
+		BaseCommand: command.NewBaseCommand(cmdContext),
+		releases:    selfupdate.NewClient(nil, selfupdate.DefaultReleasesURL),
+		executable:  os.Executable,
+		current:     appversion.Version,
+		goos:        runtime.GOOS,
+		goarch:      runtime.GOARCH,
+	}
+}
+
+func (c *Command) Use() string {
+	return cmdName
+}
+
+func (c *Command) Short() string {
+	return "Update cencli to the latest release"
+}
+
+func (c *Command) Long() string {
+	return `Update cencli to the latest release.
+
+Looks up the latest release on GitHub, downloads the archive for your platform,
+verifies it against the SHA-256 checksums published with the release, and replaces
+the running binary. The binary is replaced atomically, so a failed update leaves the
+current version in place.
+
+The stable channel only considers full releases; the beta channel also considers
+prereleases. Installations managed by Homebrew must be upgraded with Homebrew instead.
+
+cencli also prints a notice, at most once a day, when a newer release is available.
+Disable it by setting update-notice to false in the config file or
+CENCLI_UPDATE_NOTICE=false.`
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(0)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"# update to the latest stable release",
+		"--check # only report whether an update is available",
+		"--channel beta # include prereleases",
+	}
+}
+
+func (c *Command) Init() error {
+	c.flags.channel = flags.NewStringFlag(
+		c.Flags(),
+		false,
+		"channel",
+		"",
+		string(selfupdate.ChannelStable),
+		"release channel to update from (stable|beta)",
+	)
+	c.flags.check = flags.NewBoolFlag(
+		c.Flags(),
+		"check",
+		"",
+		false,
+		"only check whether an update is available",
+	)
+	return nil
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	rawChannel, err := c.flags.channel.Value()
+	if err != nil {
+		return err
+	}
+	channel, parseErr := selfupdate.ParseChannel(rawChannel)
+	if parseErr != nil {
+		return newInvalidChannelError(rawChannel)
+	}
+	c.channel = channel
+	c.checkOnly, err = c.flags.check.Value()
+	if err != nil {
+		return err
+	}
+	if !selfupdate.IsRelease(c.current) {
+		return newDevelopmentBuildError(c.current)
+	}
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(cmdName)
+
+	var release selfupdate.Release
+	err := c.WithProgress(
+		cmd.Context(),
+		logger,
+		"Checking for updates...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			var latestErr error
+			release, latestErr = c.releases.Latest(pctx, c.channel)
+			if latestErr != nil {
+				return newUpdateFailedError("check for updates", latestErr)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	c.result = updateResult{
+		Current:         selfupdate.Canonical(c.current),
+		Latest:          release.Version,
+		Channel:         string(c.channel),
+		UpdateAvailable: selfupdate.IsNewer(release.Version, c.current),
+		ReleaseURL:      release.URL,
+	}
+	if !c.result.UpdateAvailable || c.checkOnly {
+		return c.PrintData(c, c.result)
+	}
+
+	exe, err := c.resolveExecutable()
+	if err != nil {
+		return err
+	}
+
+	err = c.WithProgress(
+		cmd.Context(),
+		logger,
+		fmt.Sprintf("Downloading %s...", release.Version),
+		func(pctx context.Context) cenclierrors.CencliError {
+			return c.install(pctx, release, exe)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	c.result.Updated = true
+	return c.PrintData(c, c.result)
+}
+
+// resolveExecutable returns the path of the running binary, refusing to
+// replace binaries that a package manager owns.
+func (c *Command) resolveExecutable() (string, cenclierrors.CencliError) {
+	exe, err := c.executable()
+	if err != nil {
+		return "", newUpdateFailedError("locate the running binary", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	if manager := selfupdate.ManagedBy(exe); manager != "" {
+		return "", newManagedInstallError(manager)
+	}
+	return exe, nil
+}
+
+// install downloads the release archive for this platform, verifies its
+// checksum, and replaces exe with the binary it contains.
+func (c *Command) install(ctx context.Context, release selfupdate.Release, exe string) cenclierrors.CencliError {
+	archive, err := release.ArchiveFor(c.goos, c.goarch)
+	if err != nil {
+		return newUpdateFailedError("find a download", err)
+	}
+	checksumsAsset, ok := release.Asset(selfupdate.ChecksumsName)
+	if !ok {
+		return newUpdateFailedError("verify the download", errors.New("the release has no "+selfupdate.ChecksumsName))
+	}
+
+	checksums, err := c.releases.Download(ctx, checksumsAsset)
+	if err != nil {
+		return newUpdateFailedError("download "+checksumsAsset.Name, err)
+	}
+	data, err := c.releases.Download(ctx, archive)
+	if err != nil {
+		return newUpdateFailedError("download "+archive.Name, err)
+	}
+	if err := selfupdate.VerifyChecksum(data, archive.Name, checksums); err != nil {
+		return newUpdateFailedError("verify the download", err)
+	}
+	binary, err := selfupdate.ExtractBinary(archive.Name, data)
+	if err != nil {
+		return newUpdateFailedError("extract "+archive.Name, err)
+	}
+	if err := selfupdate.ReplaceExecutable(exe, binary); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return newUpdateFailedError("replace "+exe, fmt.Errorf("%w; re-run with permission to write to %s", err, filepath.Dir(exe)))
+		}
+		return newUpdateFailedError("replace "+exe, err)
+	}
+	return nil
+}
+
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	r := c.result
+	switch {
+	case r.Updated:
+		formatter.Printf(formatter.Stdout, "Updated cencli from %s to %s\n", r.Current, styles.GlobalStyles.Signature.Render(r.Latest))
+	case r.UpdateAvailable:
+		formatter.Printf(formatter.Stdout, "%s is available (you have %s). Run \"censys update\" to upgrade.\n", styles.GlobalStyles.Signature.Render(r.Latest), r.Current)
+	default:
+		formatter.Printf(formatter.Stdout, "cencli %s is up to date (latest on the %s channel: %s)\n", r.Current, r.Channel, r.Latest)
+		return nil
+	}
+	if r.ReleaseURL != "" {
+		formatter.Printf(formatter.Stdout, "Release notes: %s\n", styles.GlobalStyles.Comment.Render(r.ReleaseURL))
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/selfupdate"
+)
+
+// fakeReleases serves a single release with in-memory assets.
+type fakeReleases struct {
+	release  selfupdate.Release
+	content  map[string][]byte
+	channels []selfupdate.Channel
+}
+
+func (f *fakeReleases) Latest(_ context.Context, channel selfupdate.Channel) (selfupdate.Release, error) {
+	f.channels = append(f.channels, channel)
+	return f.release, nil
+}
+
+func (f *fakeReleases) Download(_ context.Context, asset selfupdate.Asset) ([]byte, error) {
+	data, ok := f.content[asset.Name]
+	if !ok {
+		return nil, fmt.Errorf("not found: %s", asset.Name)
+	}
+	return data, nil
+}
+
+func newFakeReleases(t *testing.T, version, binary string) *fakeReleases {
+	t.Helper()
+	archiveName := fmt.Sprintf("cencli_%s_linux_amd64.tar.gz", version[1:])
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "censys", Mode: 0o755, Size: int64(len(binary)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte(binary))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	sum := sha256.Sum256(buf.Bytes())
+
+	return &fakeReleases{
+		release: selfupdate.Release{
+			Version: version,
+			URL:     "https://github.com/censys/cencli/releases/tag/" + version,
+			Assets: []selfupdate.Asset{
+				{Name: archiveName},
+				{Name: selfupdate.ChecksumsName},
+			},
+		},
+		content: map[string][]byte{
+			archiveName:              buf.Bytes(),
+			selfupdate.ChecksumsName: []byte(hex.EncodeToString(sum[:]) + "  " + archiveName + "\n"),
+		},
+	}
+}
+
+func TestUpdateCommand(t *testing.T) {
+	testCases := []struct {
+		name     string
+		current  string
+		releases func(t *testing.T) *fakeReleases
+		exe      func(t *testing.T) string
+		args     []string
+		assert   func(t *testing.T, stdout, exe string, releases *fakeReleases, err cenclierrors.CencliError)
+	}{
+		{
+			name:    "up to date",
+			current: "1.2.0",
+			releases: func(t *testing.T) *fakeReleases {
+				return newFakeReleases(t, "v1.2.0", "new")
+			},
+			assert: func(t *testing.T, stdout, exe string, releases *fakeReleases, err cenclierrors.CencliError) {
+				require.NoError(t, err)
+				require.Equal(t, "cencli v1.2.0 is up to date (latest on the stable channel: v1.2.0)\n", stdout)
+				require.Equal(t, []selfupdate.Channel{selfupdate.ChannelStable}, releases.channels)
+				assertBinary(t, exe, "old")
+			},
+		},
+		{
+			name:    "check only",
+			current: "1.1.0",
+			releases: func(t *testing.T) *fakeReleases {
+				return newFakeReleases(t, "v1.2.0", "new")
+			},
+			args: []string{"--check", "--channel", "beta", "--output-format", "json"},
+			assert: func(t *testing.T, stdout, exe string, releases *fakeReleases, err cenclierrors.CencliError) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, `"update_available": true`)
+				require.Contains(t, stdout, `"updated": false`)
+				require.Equal(t, []selfupdate.Channel{selfupdate.ChannelBeta}, releases.channels)
+				assertBinary(t, exe, "old")
+			},
+		},
+		{
+			name:    "updates the binary",
+			current: "1.1.0",
+			releases: func(t *testing.T) *fakeReleases {
+				return newFakeReleases(t, "v1.2.0", "new")
+			},
+			assert: func(t *testing.T, stdout, exe string, _ *fakeReleases, err cenclierrors.CencliError) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Updated cencli from v1.1.0 to v1.2.0")
+				require.Contains(t, stdout, "Release notes: https://github.com/censys/cencli/releases/tag/v1.2.0")
+				assertBinary(t, exe, "new")
+			},
+		},
+		{
+			name:    "checksum mismatch",
+			current: "1.1.0",
+			releases: func(t *testing.T) *fakeReleases {
+				r := newFakeReleases(t, "v1.2.0", "new")
+				r.content[selfupdate.ChecksumsName] = []byte("0000  cencli_1.2.0_linux_amd64.tar.gz\n")
+				return r
+			},
+			assert: func(t *testing.T, _, exe string, _ *fakeReleases, err cenclierrors.CencliError) {
+				var updateErr UpdateFailedError
+				require.ErrorAs(t, err, &updateErr)
+				require.ErrorContains(t, err, "checksum mismatch")
+				assertBinary(t, exe, "old")
+			},
+		},
+		{
+			name:    "no checksums",
+			current: "1.1.0",
+			releases: func(t *testing.T) *fakeReleases {
+				r := newFakeReleases(t, "v1.2.0", "new")
+				r.release.Assets = r.release.Assets[:1]
+				return r
+			},
+			assert: func(t *testing.T, _, exe string, _ *fakeReleases, err cenclierrors.CencliError) {
+				require.ErrorContains(t, err, "the release has no checksums.txt")
+				assertBinary(t, exe, "old")
+			},
+		},
+		{
+			name:    "homebrew install",
+			current: "1.1.0",
+			releases: func(t *testing.T) *fakeReleases {
+				return newFakeReleases(t, "v1.2.0", "new")
+			},
+			exe: func(t *testing.T) string {
+				dir := filepath.Join(t.TempDir(), "Cellar", "cencli", "1.1.0")
+				require.NoError(t, os.MkdirAll(dir, 0o755))
+				exe := filepath.Join(dir, "censys")
+				require.NoError(t, os.WriteFile(exe, []byte("old"), 0o755))
+				return exe
+			},
+			assert: func(t *testing.T, _, exe string, _ *fakeReleases, err cenclierrors.CencliError) {
+				var managedErr ManagedInstallError
+				require.ErrorAs(t, err, &managedErr)
+				require.ErrorContains(t, err, "brew upgrade")
+				assertBinary(t, exe, "old")
+			},
+		},
+		{
+			name:    "development build",
+			current: "dev",
+			releases: func(t *testing.T) *fakeReleases {
+				return newFakeReleases(t, "v1.2.0", "new")
+			},
+			assert: func(t *testing.T, _, _ string, releases *fakeReleases, err cenclierrors.CencliError) {
+				var devErr DevelopmentBuildError
+				require.ErrorAs(t, err, &devErr)
+				require.Empty(t, releases.channels)
+			},
+		},
+		{
+			name:    "invalid channel",
+			current: "1.1.0",
+			releases: func(t *testing.T) *fakeReleases {
+				return newFakeReleases(t, "v1.2.0", "new")
+			},
+			args: []string{"--channel", "nightly"},
+			assert: func(t *testing.T, _, _ string, _ *fakeReleases, err cenclierrors.CencliError) {
+				var channelErr InvalidChannelError
+				require.ErrorAs(t, err, &channelErr)
+				require.ErrorContains(t, err, `"nightly"`)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, cfgErr := config.New(t.TempDir())
+			require.NoError(t, cfgErr)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			var exe string
+			if tc.exe != nil {
+				exe = tc.exe(t)
+			} else {
+				exe = filepath.Join(t.TempDir(), "censys")
+				require.NoError(t, os.WriteFile(exe, []byte("old"), 0o755))
+			}
+			releases := tc.releases(t)
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl))
+			updateCmd := NewUpdateCommand(cmdContext)
+			updateCmd.releases = releases
+			updateCmd.executable = func() (string, error) { return exe, nil }
+			updateCmd.current = tc.current
+			updateCmd.goos = "linux"
+			updateCmd.goarch = "amd64"
+			rootCmd, err := command.RootCommandToCobra(updateCmd)
+			require.NoError(t, err)
+
+			rootCmd.SetArgs(tc.args)
+			execErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), exe, releases, cenclierrors.NewCencliError(execErr))
+		})
+	}
+}
+
+func assertBinary(t *testing.T, exe, expected string) {
+	t.Helper()
+	content, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(content))
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/pkg/metrics"
+	"github.com/censys/cencli/internal/store"
+)
+
+// untrackedCommands are top-level commands whose runs are never recorded as
+// usage: stats only reports the usage, and the others are not analytics.
+var untrackedCommands = map[string]struct{}{
+	"stats":      {},
+	"completion": {},
+	"help":       {},
+	"dev":        {},
+}
+
+// searchOperations are the client operations counted as searches.
+var searchOperations = []string{"search", "search_collection"}
+
+// usageRun is the running command, recorded as a usage event once it finishes.
+type usageRun struct {
+	command     string
+	query       string
+	startedAt   time.Time
+	cacheHits   atomic.Int64
+	cacheMisses atomic.Int64
+}
+
+// startUsage remembers the running command so that RecordUsage can record it.
+func (c *Context) startUsage(cobraCmd *cobra.Command, cmd Command, args []string) {
+	c.usage = nil
+	path := strings.Fields(cobraCmd.CommandPath())
+	if len(path) < 2 {
+		return
+	}
+	if _, skip := untrackedCommands[path[1]]; skip {
+		return
+	}
+	c.usage = &usageRun{
+		command:   strings.Join(path[1:], " "),
+		query:     queryFromArgs(cmd.Use(), args),
+		startedAt: time.Now(),
+	}
+}
+
+// observeCache counts a lookup of locally cached data for the usage of the running command.
+func (c *Context) observeCache(hit bool) {
+	if c.usage == nil {
+		return
+	}
+	if hit {
+		c.usage.cacheHits.Add(1)
+	} else {
+		c.usage.cacheMisses.Add(1)
+	}
+}
+
+// RecordUsage records the run of the command as a usage event in the store,
+// with the API calls of snap, unless usage-stats is disabled. Usage is only
+// kept locally. Recording is best-effort and never fails the command.
+func (c *Context) RecordUsage(ctx context.Context, snap metrics.Snapshot, runErr error) {
+	if c.usage == nil || c.store == nil || !c.config.UsageStats {
+		return
+	}
+	event := &store.UsageEvent{
+		Command:       c.usage.command,
+		Query:         c.usage.query,
+		StartedAt:     c.usage.startedAt,
+		Duration:      time.Since(c.usage.startedAt),
+		Failed:        runErr != nil,
+		Requests:      int64(snap.Requests),
+		RequestErrors: int64(snap.Errors),
+		PagesFetched:  int64(snap.PagesFetched),
+		CacheHits:     c.usage.cacheHits.Load(),
+		CacheMisses:   c.usage.cacheMisses.Load(),
+	}
+	for _, name := range searchOperations {
+		if op, ok := snap.Operations[name]; ok {
+			event.SearchRequests += int64(op.Requests)
+			event.SearchLatency += time.Duration(op.Latency.Sum * float64(time.Second))
+		}
+	}
+	if err := c.store.RecordUsage(ctx, event); err != nil {
+		c.logger.Debug("failed to record usage", "error", err)
+	}
+}
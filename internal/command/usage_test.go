@@ -0,0 +1,110 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/metrics"
+	"github.com/censys/cencli/internal/store"
+)
+
+func TestRecordUsage(t *testing.T) {
+	// run runs "censys <sub> args..." and records its usage with snap
+	run := func(t *testing.T, st store.Store, sub string, snap metrics.Snapshot, runErr error, args ...string) {
+		t.Helper()
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+
+		cmdContext := NewCommandContext(cfg, st)
+		child := newTestCommand(cmdContext)
+		child.useFn = func() string { return sub }
+		child.argsFn = func() PositionalArgs { return cobra.ArbitraryArgs }
+		child.runFn = func(*cobra.Command, []string) cenclierrors.CencliError {
+			cmdContext.observeCache(true)
+			cmdContext.observeCache(false)
+			cmdContext.observeCache(true)
+			return nil
+		}
+		root := newTestCommand(cmdContext)
+		root.useFn = func() string { return "censys" }
+		root.initFn = func(c Command) error { return c.(*testCommand).AddSubCommands(child) }
+		rootCmd, cerr := RootCommandToCobra(root)
+		require.NoError(t, cerr)
+		require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+		formatter.Stdout = &bytes.Buffer{}
+		formatter.Stderr = &bytes.Buffer{}
+		rootCmd.SetArgs(append(strings.Fields(sub)[:1], args...))
+		require.NoError(t, rootCmd.Execute())
+		cmdContext.RecordUsage(context.Background(), snap, runErr)
+	}
+
+	snap := metrics.Snapshot{
+		Requests:     3,
+		Errors:       1,
+		PagesFetched: 2,
+		Operations: map[string]metrics.OperationSnapshot{
+			"search":    {Requests: 2, Latency: metrics.Histogram{Sum: 1.5, Count: 2}},
+			"get_hosts": {Requests: 1, Latency: metrics.Histogram{Sum: 0.2, Count: 1}},
+		},
+	}
+
+	t.Run("records the run", func(t *testing.T) {
+		st, err := store.New(t.TempDir())
+		require.NoError(t, err)
+		start := time.Now()
+
+		run(t, st, "search <query>", snap, errors.New("boom"), "host.ip: 1.1.1.1")
+
+		events, err := st.GetUsageSince(context.Background(), start.Add(-time.Minute))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		e := events[0]
+		assert.Equal(t, "search", e.Command)
+		assert.Equal(t, "host.ip: 1.1.1.1", e.Query)
+		assert.True(t, e.Failed)
+		assert.Equal(t, int64(3), e.Requests)
+		assert.Equal(t, int64(1), e.RequestErrors)
+		assert.Equal(t, int64(2), e.SearchRequests)
+		assert.Equal(t, 1500*time.Millisecond, e.SearchLatency)
+		assert.Equal(t, int64(2), e.PagesFetched)
+		assert.Equal(t, int64(2), e.CacheHits)
+		assert.Equal(t, int64(1), e.CacheMisses)
+	})
+
+	t.Run("stats is not recorded", func(t *testing.T) {
+		st, err := store.New(t.TempDir())
+		require.NoError(t, err)
+
+		run(t, st, "stats", metrics.Snapshot{}, nil)
+
+		events, err := st.GetUsageSince(context.Background(), time.Time{})
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("can be disabled", func(t *testing.T) {
+		st, err := store.New(t.TempDir())
+		require.NoError(t, err)
+		t.Setenv("CENCLI_USAGE_STATS", "false")
+
+		run(t, st, "search <query>", snap, nil, "host.ip: 1.1.1.1")
+
+		events, err := st.GetUsageSince(context.Background(), time.Time{})
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
@@ -0,0 +1,94 @@
+package versioncmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/censys/cencli/internal/pkg/selfupdate"
+	appversion "github.com/censys/cencli/internal/version"
+)
+
+const (
+	// goProxyURL is queried for the newest SDK release.
+	goProxyURL = "https://proxy.golang.org"
+)
+
+// compatibility reports how the build compares to the latest SDK and CLI releases.
+type compatibility struct {
+	// LatestSDK is the newest SDK release. The SDK is generated from the
+	// platform's API specification, so a newer SDK means the API has changed
+	// in ways this build may not support.
+	LatestSDK   string `json:"latest_sdk,omitempty"`
+	SDKOutdated bool   `json:"sdk_outdated"`
+	// LatestRelease is the newest CLI release on the channel matching this build.
+	LatestRelease   string   `json:"latest_release,omitempty"`
+	UpdateAvailable bool     `json:"update_available"`
+	Errors          []string `json:"errors,omitempty"`
+}
+
+// checkCompatibility looks up the latest SDK and CLI releases concurrently.
+// Lookup failures are recorded in the result rather than failing the command.
+func (c *Command) checkCompatibility(ctx context.Context, info appversion.Info) *compatibility {
+	var (
+		result             compatibility
+		sdkErr, releaseErr error
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		result.LatestSDK, sdkErr = c.latestSDK(gctx)
+		return nil
+	})
+	g.Go(func() error {
+		channel := selfupdate.ChannelStable
+		if selfupdate.IsPrerelease(info.Version) {
+			channel = selfupdate.ChannelBeta
+		}
+		var release selfupdate.Release
+		release, releaseErr = c.latestRelease(gctx, channel)
+		result.LatestRelease = release.Version
+		return nil
+	})
+	_ = g.Wait()
+
+	if sdkErr != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to look up the latest SDK: %v", sdkErr))
+	}
+	if releaseErr != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to look up the latest release: %v", releaseErr))
+	}
+	result.SDKOutdated = selfupdate.IsNewer(result.LatestSDK, info.SDK)
+	result.UpdateAvailable = selfupdate.IsNewer(result.LatestRelease, info.Version)
+	return &result
+}
+
+// latestModuleVersion asks the Go module proxy for the newest version of a module.
+func latestModuleVersion(ctx context.Context, client *http.Client, proxyURL, modulePath string) (string, error) {
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, proxyURL+"/"+escaped+"/@latest", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	var latest struct {
+		Version string `json:"Version"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&latest); err != nil {
+		return "", err
+	}
+	return latest.Version, nil
+}
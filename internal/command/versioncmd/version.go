@@ -1,21 +1,54 @@
 package versioncmd
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/spf13/cobra"
 
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/selfupdate"
 	appversion "github.com/censys/cencli/internal/version"
 )
 
 type Command struct {
 	*command.BaseCommand
+	// flags the command uses
+	flags versionCommandFlags
+	// state parsed from flags
+	check bool
+	raw   bool
+	// lookups used by --check, replaced in tests
+	latestSDK     func(ctx context.Context) (string, error)
+	latestRelease func(ctx context.Context, channel selfupdate.Channel) (selfupdate.Release, error)
+}
+
+type versionCommandFlags struct {
+	check flags.BoolFlag
+	raw   flags.BoolFlag
+}
+
+// versionReport is the build metadata, plus the compatibility check if requested.
+type versionReport struct {
+	appversion.Info
+	Compatibility *compatibility `json:"compatibility,omitempty"`
 }
 
 var _ command.Command = (*Command)(nil)
 
 func NewVersionCommand(cmdContext *command.Context) *Command {
-	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+	httpClient := &http.Client{Timeout: selfupdate.DefaultTimeout}
+	releases := selfupdate.NewClient(httpClient, selfupdate.DefaultReleasesURL)
+	return &Command{
+		BaseCommand: command.NewBaseCommand(cmdContext),
+		latestSDK: func(ctx context.Context) (string, error) {
+			return latestModuleVersion(ctx, httpClient, goProxyURL, appversion.SDKModule)
+		},
+		latestRelease: releases.Latest,
+	}
 }
 
 func (c *Command) Use() string {
@@ -26,6 +59,15 @@ func (c *Command) Short() string {
 	return "Print version information"
 }
 
+func (c *Command) Long() string {
+	return `Print version information: the CLI version, commit, build date, Go version,
+platform, and the version of the Censys SDK the CLI is built with.
+
+With --check, also look up the latest SDK release and the latest CLI release. The SDK
+is generated from the Censys Platform API specification, so a newer SDK means the API
+has changed since this build and some fields or endpoints may not be supported.`
+}
+
 func (c *Command) Args() command.PositionalArgs {
 	return command.RangeArgs(0, 0)
 }
@@ -38,15 +80,66 @@ func (c *Command) SupportedOutputTypes() []command.OutputType {
 	return []command.OutputType{command.OutputTypeData}
 }
 
+func (c *Command) Examples() []string {
+	return []string{
+		"--check # compare against the latest SDK and CLI releases",
+		"--raw # single-line JSON without colors, for scripts",
+	}
+}
+
 func (c *Command) Init() error {
+	c.flags.check = flags.NewBoolFlag(
+		c.Flags(),
+		"check",
+		"",
+		false,
+		"check whether newer SDK and CLI releases are available",
+	)
+	c.flags.raw = flags.NewBoolFlag(
+		c.Flags(),
+		"raw",
+		"",
+		false,
+		"print single-line JSON without colors",
+	)
 	return nil
 }
 
 func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.check, err = c.flags.check.Value()
+	if err != nil {
+		return err
+	}
+	c.raw, err = c.flags.raw.Value()
+	if err != nil {
+		return err
+	}
+	if c.raw && cmd.Flags().Changed("output-format") {
+		return flags.NewConflictingFlagsError("raw", "output-format")
+	}
 	return nil
 }
 
 func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
-	info := appversion.BuildInfo()
-	return c.PrintData(c, info)
+	report := versionReport{Info: appversion.BuildInfo()}
+	if c.check {
+		err := c.WithProgress(
+			cmd.Context(),
+			c.Logger("version"),
+			"Checking for newer releases...",
+			func(pctx context.Context) cenclierrors.CencliError {
+				report.Compatibility = c.checkCompatibility(pctx, report.Info)
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.raw {
+		return cenclierrors.NewCencliError(formatter.WriteNDJSONItem(formatter.Stdout, report, false))
+	}
+	return c.PrintData(c, report)
 }
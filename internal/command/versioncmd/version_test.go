@@ -2,15 +2,26 @@ package versioncmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	storemocks "github.com/censys/cencli/gen/store/mocks"
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
 	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/selfupdate"
+	appversion "github.com/censys/cencli/internal/version"
 )
 
 func TestVersion_PrintsJSONByDefault(t *testing.T) {
@@ -32,3 +43,134 @@ func TestVersion_PrintsJSONByDefault(t *testing.T) {
 	// Output is JSON object; just assert it contains version key string token
 	require.Contains(t, stdout.String(), "version")
 }
+
+func TestVersionCommand(t *testing.T) {
+	origVersion := appversion.Version
+	t.Cleanup(func() { appversion.Version = origVersion })
+
+	testCases := []struct {
+		name          string
+		version       string
+		latestSDK     func(ctx context.Context) (string, error)
+		latestRelease func(ctx context.Context, channel selfupdate.Channel) (selfupdate.Release, error)
+		args          []string
+		assert        func(t *testing.T, stdout string, err cenclierrors.CencliError)
+	}{
+		{
+			name:    "build metadata",
+			version: "1.2.0",
+			assert: func(t *testing.T, stdout string, err cenclierrors.CencliError) {
+				require.NoError(t, err)
+				var report map[string]any
+				require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+				for _, key := range []string{"version", "commit", "date", "go", "os", "arch", "sdk"} {
+					require.Contains(t, report, key)
+				}
+				require.NotContains(t, report, "compatibility")
+			},
+		},
+		{
+			name:    "raw",
+			version: "1.2.0",
+			args:    []string{"--raw"},
+			assert: func(t *testing.T, stdout string, err cenclierrors.CencliError) {
+				require.NoError(t, err)
+				require.Equal(t, 1, strings.Count(stdout, "\n"))
+				require.True(t, strings.HasPrefix(stdout, `{"version":"1.2.0",`))
+			},
+		},
+		{
+			name:    "raw conflicts with output format",
+			version: "1.2.0",
+			args:    []string{"--raw", "--output-format", "yaml"},
+			assert: func(t *testing.T, _ string, err cenclierrors.CencliError) {
+				var conflictErr flags.ConflictingFlagsError
+				require.ErrorAs(t, err, &conflictErr)
+			},
+		},
+		{
+			name:    "check finds newer releases",
+			version: "1.2.0",
+			latestSDK: func(context.Context) (string, error) {
+				return "v99.0.0", nil
+			},
+			latestRelease: func(_ context.Context, channel selfupdate.Channel) (selfupdate.Release, error) {
+				require.Equal(t, selfupdate.ChannelStable, channel)
+				return selfupdate.Release{Version: "v1.3.0"}, nil
+			},
+			args: []string{"--check", "--raw"},
+			assert: func(t *testing.T, stdout string, err cenclierrors.CencliError) {
+				require.NoError(t, err)
+				var report versionReport
+				require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+				require.Equal(t, &compatibility{
+					LatestSDK:       "v99.0.0",
+					SDKOutdated:     report.SDK != "unknown",
+					LatestRelease:   "v1.3.0",
+					UpdateAvailable: true,
+				}, report.Compatibility)
+			},
+		},
+		{
+			name:    "check failures are reported, not fatal",
+			version: "1.2.0-beta.1",
+			latestSDK: func(context.Context) (string, error) {
+				return "", errors.New("proxy unreachable")
+			},
+			latestRelease: func(_ context.Context, channel selfupdate.Channel) (selfupdate.Release, error) {
+				require.Equal(t, selfupdate.ChannelBeta, channel)
+				return selfupdate.Release{}, errors.New("rate limited")
+			},
+			args: []string{"--check", "--raw"},
+			assert: func(t *testing.T, stdout string, err cenclierrors.CencliError) {
+				require.NoError(t, err)
+				var report versionReport
+				require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+				require.False(t, report.Compatibility.SDKOutdated)
+				require.False(t, report.Compatibility.UpdateAvailable)
+				require.Equal(t, []string{
+					"failed to look up the latest SDK: proxy unreachable",
+					"failed to look up the latest release: rate limited",
+				}, report.Compatibility.Errors)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, cfgErr := config.New(t.TempDir())
+			require.NoError(t, cfgErr)
+			appversion.Version = tc.version
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			versionCmd := NewVersionCommand(command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl)))
+			versionCmd.latestSDK = tc.latestSDK
+			versionCmd.latestRelease = tc.latestRelease
+			rootCmd, err := command.RootCommandToCobra(versionCmd)
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+
+			rootCmd.SetArgs(tc.args)
+			execErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), cenclierrors.NewCencliError(execErr))
+		})
+	}
+}
+
+func TestLatestModuleVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// module paths are case-encoded for the proxy
+		require.Equal(t, "/github.com/!example/sdk/@latest", r.URL.Path)
+		_, _ = w.Write([]byte(`{"Version":"v0.26.0","Time":"2025-06-01T00:00:00Z"}`))
+	}))
+	defer srv.Close()
+
+	version, err := latestModuleVersion(context.Background(), srv.Client(), srv.URL, "github.com/Example/sdk")
+	require.NoError(t, err)
+	require.Equal(t, "v0.26.0", version)
+}
@@ -0,0 +1,99 @@
+package view
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/formatter/short"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// allOrgsConflicts are the flags that cannot be combined with --all-orgs, as
+// they act on the assets of a single organization as they are fetched, or,
+// for collections, belong to a single organization.
+var allOrgsConflicts = []string{"format", "forward", historyAnnotationsFlagName, command.CollectionIDFlagName}
+
+// fetchAllOrgs fetches the assets from each organization, and merges them
+// into c.result in the order of the organizations.
+func (c *Command) fetchAllOrgs(ctx context.Context) cenclierrors.CencliError {
+	orgs, err := c.AccessibleOrgs(ctx)
+	if err != nil {
+		return err
+	}
+	c.orgResults = command.RunForOrgs(ctx, orgs, func(ctx context.Context, org command.Org) (assetResult, cenclierrors.CencliError) {
+		return c.fetchAssetResult(ctx, mo.Some(org.ID))
+	})
+	merged := assetResult{Type: c.assetType}
+	for _, res := range c.orgResults {
+		merged.Hosts = append(merged.Hosts, res.Result.Hosts...)
+		merged.Certificates = append(merged.Certificates, res.Result.Certificates...)
+		merged.WebProperties = append(merged.WebProperties, res.Result.WebProperties...)
+	}
+	c.result = merged
+	return nil
+}
+
+// reportOrgErrors prints the partial error of each organization, then the
+// error of each organization that failed.
+func (c *Command) reportOrgErrors(cmd *cobra.Command) cenclierrors.CencliError {
+	for _, res := range c.orgResults {
+		if res.Result.PartialError != nil {
+			c.ReportPartialError(res.Result.PartialError, cmd)
+		}
+	}
+	return command.ReportOrgErrors(c.orgResults)
+}
+
+// orgsOutputData returns the assets of each organization, annotated like
+// outputData and labeled with their organization.
+func (c *Command) orgsOutputData() []any {
+	var data []any
+	for _, res := range c.orgResults {
+		for _, asset := range res.Result.Assets() {
+			var item any = asset
+			if c.hasAnnotations() {
+				item = c.annotate(asset)
+			}
+			data = append(data, res.Org.Attach(item))
+		}
+	}
+	return data
+}
+
+// renderOrgsShort renders the assets of each organization in short format,
+// under a header naming the organization.
+func (c *Command) renderOrgsShort() (string, cenclierrors.CencliError) {
+	var out strings.Builder
+	var offset int
+	for _, res := range c.orgResults {
+		if res.Err != nil {
+			continue
+		}
+		result := res.Result
+		result.Type = c.assetType
+		assessments := c.assessments
+		if assessments != nil {
+			assessments = assessments[offset : offset+len(result.Hosts)]
+		}
+		offset += len(result.Hosts)
+
+		header := short.NewLine(short.WithLabelStyle(styles.GlobalStyles.Signature))
+		header.Write("Organization", res.Org.String())
+		header.Write("Assets", strconv.Itoa(len(result.Assets())))
+		out.WriteString(short.Separator())
+		out.WriteString(header.String())
+		rendered, err := c.renderAssetsShort(result, assessments)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(rendered)
+		out.WriteString("\n")
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,64 @@
+package view
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/flags"
+)
+
+func newCollectionIDFlag(c *Command) flags.UUIDFlag {
+	return flags.NewUUIDFlag(c.Flags(), false, command.CollectionIDFlagName, "c", mo.None[uuid.UUID](),
+		"only return the assets that are in this collection, found by searching it (optional)")
+}
+
+// parseCollectionIDFlag parses --collection-id. Collections are searched as
+// they are now, so it cannot be combined with --at-time.
+func (c *Command) parseCollectionIDFlag() cenclierrors.CencliError {
+	collectionID, err := c.flags.collectionID.Value()
+	if err != nil || !collectionID.IsPresent() {
+		return err
+	}
+	if c.atTime.IsPresent() {
+		return command.NewCollectionNotSupportedError("at-time", "collections can only be searched as they are now")
+	}
+	c.collectionID = mo.Some(identifiers.NewCollectionID(collectionID.MustGet()))
+	return nil
+}
+
+// fetchAssetResultInCollection is fetchAssetResult for the assets of the
+// collection of --collection-id.
+func (c *Command) fetchAssetResultInCollection(
+	ctx context.Context,
+	orgID mo.Option[identifiers.OrganizationID],
+	collectionID identifiers.CollectionID,
+) (assetResult, cenclierrors.CencliError) {
+	switch c.assetType {
+	case assets.AssetTypeHost:
+		result, err := c.viewSvc.GetHostsInCollection(ctx, orgID, collectionID, c.assets.HostIDs())
+		if err != nil {
+			return assetResult{}, err
+		}
+		return assetResult{Type: assets.AssetTypeHost, Meta: result.Meta, Hosts: result.Hosts, PartialError: result.PartialError}, nil
+	case assets.AssetTypeCertificate:
+		result, err := c.viewSvc.GetCertificatesInCollection(ctx, orgID, collectionID, c.assets.CertificateIDs())
+		if err != nil {
+			return assetResult{}, err
+		}
+		return assetResult{Type: assets.AssetTypeCertificate, Meta: result.Meta, Certificates: result.Certificates, PartialError: result.PartialError}, nil
+	case assets.AssetTypeWebProperty:
+		result, err := c.viewSvc.GetWebPropertiesInCollection(ctx, orgID, collectionID, c.assets.WebPropertyIDs())
+		if err != nil {
+			return assetResult{}, err
+		}
+		return assetResult{Type: assets.AssetTypeWebProperty, Meta: result.Meta, WebProperties: result.WebProperties, PartialError: result.PartialError}, nil
+	default:
+		return assetResult{}, NewUnsupportedAssetTypeError(c.assetType, "no way to fetch this asset's data")
+	}
+}
@@ -56,3 +56,33 @@ func (e *unsupportedAssetTypeError) Title() string {
 func (e *unsupportedAssetTypeError) ShouldPrintUsage() bool {
 	return true
 }
+
+// RiskListError indicates that the list of known-bad JA4 fingerprints could not be read.
+type RiskListError interface {
+	cenclierrors.CencliError
+}
+
+type riskListError struct {
+	path string
+	err  error
+}
+
+func NewRiskListError(path string, err error) RiskListError {
+	return &riskListError{path: path, err: err}
+}
+
+func (e *riskListError) Error() string {
+	return fmt.Sprintf("failed to read risk.bad-ja4-file %s: %v", e.path, e.err)
+}
+
+func (e *riskListError) Title() string {
+	return "Invalid Risk Configuration"
+}
+
+func (e *riskListError) ShouldPrintUsage() bool {
+	return false
+}
+
+func (e *riskListError) Unwrap() error {
+	return e.err
+}
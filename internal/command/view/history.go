@@ -0,0 +1,151 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/app/history"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/formatter/short"
+)
+
+const (
+	historyAnnotationsFlagName = "history-annotations"
+	historyWindowFlagName      = "history-window"
+	// historyKey is the field that the service history of a host is attached under in data output.
+	historyKey = "history"
+	// defaultHistoryWindow is how far back the timeline of each host is read.
+	defaultHistoryWindow = 30 * 24 * time.Hour
+)
+
+// hostHistory is the history of the services of a host within a window of
+// its timeline.
+type hostHistory struct {
+	Start    time.Time                `json:"start"`
+	End      time.Time                `json:"end"`
+	Services []history.ServiceHistory `json:"services"`
+}
+
+// service returns the history of the service on port and transport, if it
+// was scanned within the window.
+func (h hostHistory) service(port int, transport string) (history.ServiceHistory, bool) {
+	key := history.ServiceKey(port, transport)
+	for _, svc := range h.Services {
+		if svc.Key() == key {
+			return svc, true
+		}
+	}
+	return history.ServiceHistory{}, false
+}
+
+// parseHistoryFlags parses --history-annotations and --history-window, and
+// resolves the history service when host history is requested.
+func (c *Command) parseHistoryFlags() cenclierrors.CencliError {
+	enabled, err := c.flags.historyAnnotations.Value()
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+	if c.assetType != assets.AssetTypeHost {
+		return NewUnsupportedAssetTypeError(c.assetType, "--history-annotations is only supported for hosts")
+	}
+	switch {
+	case c.export.IsPresent():
+		return flags.NewConflictingFlagsError(historyAnnotationsFlagName, "format")
+	case c.scoreOnly:
+		return flags.NewConflictingFlagsError(historyAnnotationsFlagName, scoreOnlyFlagName)
+	case c.Config().Streaming:
+		return flags.NewConflictingFlagsError(historyAnnotationsFlagName, "streaming")
+	case c.Config().OutputFormat == formatter.OutputFormatTemplate:
+		return flags.NewConflictingFlagsError(historyAnnotationsFlagName, "output-format=template")
+	}
+	window, err := c.flags.historyWindow.Value()
+	if err != nil {
+		return err
+	}
+	c.historyWindow = window.OrElse(defaultHistoryWindow)
+	c.historySvc, err = c.HistoryService()
+	return err
+}
+
+// historyEnabled reports whether the hosts are annotated with their history.
+func (c *Command) historyEnabled() bool {
+	return c.historySvc != nil
+}
+
+// fetchHostHistories reads the timeline of each fetched host within the
+// history window, which ends at --at-time or now, and summarizes it per service.
+func (c *Command) fetchHostHistories(ctx context.Context) cenclierrors.CencliError {
+	end := c.atTime.OrElse(c.Now()).UTC()
+	start := end.Add(-c.historyWindow)
+	c.histories = make(map[string]hostHistory, len(c.result.Hosts))
+	for _, host := range c.result.Hosts {
+		if host.IP == nil {
+			continue
+		}
+		hostID, parseErr := assets.NewHostID(*host.IP)
+		if parseErr != nil {
+			continue
+		}
+		res, err := c.historySvc.GetHostHistory(ctx, c.orgID, hostID, start, end)
+		if err != nil {
+			return err
+		}
+		if res.PartialError != nil && c.result.PartialError == nil {
+			c.result.PartialError = res.PartialError
+		}
+		c.histories[hostKey(*host.IP)] = hostHistory{
+			Start:    start,
+			End:      end,
+			Services: history.SummarizeServices(res.Events),
+		}
+	}
+	return nil
+}
+
+// hostHistoryOf returns the history of an asset, if it is a host with one.
+func (c *Command) hostHistoryOf(item any) (hostHistory, bool) {
+	if _, ok := item.(*assets.Host); !ok {
+		return hostHistory{}, false
+	}
+	key, ok := outputAssetKey(item)
+	if !ok {
+		return hostHistory{}, false
+	}
+	h, ok := c.histories[key]
+	return h, ok
+}
+
+// serviceHistoryNote renders when a service of a host was first seen and last
+// changed within the history window, for short output.
+func (c *Command) serviceHistoryNote(host *assets.Host, svc components.Service) (string, string) {
+	h, ok := c.hostHistoryOf(host)
+	if !ok {
+		return "", ""
+	}
+	var transport string
+	if svc.TransportProtocol != nil {
+		transport = string(*svc.TransportProtocol)
+	}
+	scanned, ok := h.service(short.Val(svc.Port, 0), transport)
+	if !ok {
+		return "History", fmt.Sprintf("unchanged in the last %s", flags.FormatHumanDuration(h.End.Sub(h.Start)))
+	}
+	note := fmt.Sprintf("first seen %s, last changed %s", formatHistoryTime(scanned.FirstSeen), formatHistoryTime(scanned.LastChanged))
+	if scanned.Events > 1 {
+		note += fmt.Sprintf(" (%d scans)", scanned.Events)
+	}
+	return "History", note
+}
+
+func formatHistoryTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 UTC")
+}
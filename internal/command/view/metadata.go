@@ -0,0 +1,162 @@
+package view
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/input"
+	"github.com/censys/cencli/internal/pkg/refang"
+	"github.com/censys/cencli/internal/pkg/xref"
+)
+
+// inputMetadataKey is the field that NDJSON input metadata is attached under in data output.
+const inputMetadataKey = "input"
+
+// inputMetadata maps a normalized asset ID to the NDJSON object it was read from.
+type inputMetadata map[string]map[string]any
+
+// newInputMetadata collects the metadata of records read from NDJSON lines.
+// When the same asset appears more than once, the first record wins.
+func newInputMetadata(records []input.Record) inputMetadata {
+	res := make(inputMetadata)
+	for _, r := range records {
+		if r.Metadata == nil {
+			continue
+		}
+		key, ok := inputAssetKey(r.Value)
+		if !ok {
+			continue
+		}
+		if _, exists := res[key]; !exists {
+			res[key] = r.Metadata
+		}
+	}
+	return res
+}
+
+// annotate returns item with its input metadata attached, or item unchanged
+// if it has no metadata.
+func (m inputMetadata) annotate(item any) any {
+	key, ok := outputAssetKey(item)
+	if !ok {
+		return item
+	}
+	meta, ok := m[key]
+	if !ok {
+		return item
+	}
+	return withField(item, inputMetadataKey, meta)
+}
+
+// withField returns item as a map with key set to value.
+func withField(item any, key string, value any) any {
+	obj, ok := item.(map[string]any)
+	if !ok {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return item
+		}
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return item
+		}
+	}
+	obj[key] = value
+	return obj
+}
+
+// hasAnnotations reports whether assets may need annotating: with input
+// metadata, the domain they were resolved from, threat feed matches, or the
+// history of their services, or, for hosts, whether sections are left out.
+func (c *Command) hasAnnotations() bool {
+	return len(c.metadata) > 0 || len(c.resolvedFrom) > 0 || c.xref != nil || c.histories != nil ||
+		c.sections.filters()
+}
+
+// annotate returns an asset with its input metadata, the domain it was
+// resolved from, its service history, and its threat feed matches attached,
+// or the asset unchanged if it has none of them. Only the sections of a host
+// that are printed are kept, though threat feeds are matched against all of
+// them.
+func (c *Command) annotate(item any) any {
+	var matches []xref.Match
+	if asset, ok := item.(assets.Asset); ok {
+		matches = c.xref.Match(asset)
+	}
+	annotated := c.metadata.annotate(c.sections.apply(item))
+	if key, ok := outputAssetKey(item); ok {
+		if domain, resolved := c.resolvedFrom[key]; resolved {
+			annotated = withField(annotated, command.ResolvedFromKey, domain)
+		}
+	}
+	if h, ok := c.hostHistoryOf(item); ok {
+		annotated = withField(annotated, historyKey, h)
+	}
+	return command.AttachXref(annotated, matches)
+}
+
+// annotateAll annotates each asset in a slice.
+func annotateAll[T any](annotate func(any) any, items []T) []any {
+	res := make([]any, len(items))
+	for i, item := range items {
+		res[i] = annotate(item)
+	}
+	return res
+}
+
+// withAnnotatedStreaming wraps the streaming emitter in ctx (if any) so that
+// streamed assets are annotated like buffered ones.
+func (c *Command) withAnnotatedStreaming(ctx context.Context) context.Context {
+	emitter, ok := streaming.FromContext(ctx)
+	if !ok || !c.hasAnnotations() {
+		return ctx
+	}
+	return streaming.WithEmitter(ctx, streaming.NewMapEmitter(emitter, c.annotate))
+}
+
+// inputAssetKey normalizes a user-supplied asset string so that it can be
+// matched against the ID of the returned asset.
+func inputAssetKey(raw string) (string, bool) {
+	if h, err := assets.NewHostID(raw); err == nil {
+		return hostKey(h.String()), true
+	}
+	if c, err := assets.NewCertificateFingerprint(raw); err == nil {
+		return strings.ToLower(c.String()), true
+	}
+	if w, err := assets.NewWebPropertyID(raw, assets.DefaultWebPropertyPort); err == nil {
+		return webPropertyKey(w.Hostname, w.Port), true
+	}
+	return "", false
+}
+
+// outputAssetKey returns the normalized ID of an asset returned by the view service.
+func outputAssetKey(item any) (string, bool) {
+	switch a := item.(type) {
+	case *assets.Host:
+		if a.IP != nil {
+			return hostKey(*a.IP), true
+		}
+	case *assets.Certificate:
+		if a.FingerprintSha256 != nil {
+			return strings.ToLower(*a.FingerprintSha256), true
+		}
+	case *assets.WebProperty:
+		if a.Hostname != nil && a.Port != nil {
+			return webPropertyKey(*a.Hostname, *a.Port), true
+		}
+	}
+	return "", false
+}
+
+func hostKey(ip string) string {
+	return assets.NormalizeIP(refang.RefangIP(ip))
+}
+
+func webPropertyKey(hostname string, port int) string {
+	hostname = assets.NormalizeIP(strings.ToLower(strings.Trim(hostname, "[]")))
+	return hostname + ":" + strconv.Itoa(port)
+}
@@ -0,0 +1,145 @@
+package view
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter/short"
+	"github.com/censys/cencli/internal/pkg/resolve"
+)
+
+const (
+	portsFlagName = "ports"
+	// defaultPortsValue selects assets.DefaultWebPropertyPorts.
+	defaultPortsValue = "default"
+)
+
+func newPortsFlag(c *Command) flags.StringSliceFlag {
+	return flags.NewStringSliceFlag(c.Flags(), false, portsFlagName, "", []string{},
+		fmt.Sprintf("view each domain given without a port as web properties on these ports, or on %s with --ports %s",
+			joinPorts(assets.DefaultWebPropertyPorts), defaultPortsValue))
+}
+
+// parsePortsFlag parses --ports into c.ports, without duplicates.
+func (c *Command) parsePortsFlag() cenclierrors.CencliError {
+	values, err := c.flags.ports.Value()
+	if err != nil {
+		return err
+	}
+	if len(values) == 1 && values[0] == defaultPortsValue {
+		c.ports = slices.Clone(assets.DefaultWebPropertyPorts)
+		return nil
+	}
+	c.ports = nil
+	for _, v := range values {
+		port, convErr := strconv.Atoi(v)
+		if convErr != nil || port <= 0 || port > 65535 {
+			return cenclierrors.NewUsageError(fmt.Errorf("invalid --%s value %q; use ports from 1 to 65535, or %s",
+				portsFlagName, v, defaultPortsValue))
+		}
+		if !slices.Contains(c.ports, port) {
+			c.ports = append(c.ports, port)
+		}
+	}
+	return nil
+}
+
+// checkPortsFlag checks that --ports is only used to view web properties.
+func (c *Command) checkPortsFlag() cenclierrors.CencliError {
+	if len(c.ports) > 0 && c.assetType != assets.AssetTypeWebProperty {
+		return NewUnsupportedAssetTypeError(c.assetType, fmt.Sprintf("--%s is only supported for web properties", portsFlagName))
+	}
+	return nil
+}
+
+// expandPorts replaces each domain in rawAssets that is given without a port
+// with its web properties on each of c.ports. The input metadata of the
+// domain is attached to each of them.
+func (c *Command) expandPorts(rawAssets []string) []string {
+	if len(c.ports) == 0 {
+		return rawAssets
+	}
+	expanded := make([]string, 0, len(rawAssets))
+	for _, raw := range rawAssets {
+		domain, ok := resolve.BareDomain(raw)
+		if !ok {
+			expanded = append(expanded, raw)
+			continue
+		}
+		meta, hasMeta := c.metadata[webPropertyKey(domain, assets.DefaultWebPropertyPort)]
+		for _, port := range c.ports {
+			expanded = append(expanded, assets.WebPropertyID{Hostname: domain, Port: port}.String())
+			if _, exists := c.metadata[webPropertyKey(domain, port)]; hasMeta && !exists {
+				c.metadata[webPropertyKey(domain, port)] = meta
+			}
+		}
+	}
+	return expanded
+}
+
+// webPropertyGroups merges webProperties by hostname, in the order the
+// hostnames and ports were given, with the ports they were not found on.
+func (c *Command) webPropertyGroups(webProperties []*assets.WebProperty) []short.WebPropertyGroup {
+	found := make(map[string]*assets.WebProperty, len(webProperties))
+	for _, wp := range webProperties {
+		if key, ok := outputAssetKey(wp); ok {
+			found[key] = wp
+		}
+	}
+	var groups []short.WebPropertyGroup
+	index := make(map[string]int)
+	for _, id := range c.assets.WebPropertyIDs() {
+		hostname := strings.ToLower(assets.NormalizeIP(id.Hostname))
+		i, ok := index[hostname]
+		if !ok {
+			i = len(groups)
+			index[hostname] = i
+			groups = append(groups, short.WebPropertyGroup{Hostname: id.Hostname})
+		}
+		if wp, ok := found[webPropertyKey(id.Hostname, id.Port)]; ok {
+			groups[i].WebProperties = append(groups[i].WebProperties, wp)
+		} else {
+			groups[i].Missing = append(groups[i].Missing, id.Port)
+		}
+	}
+	return groups
+}
+
+// groupWebProperties orders webProperties by hostname, in the order the
+// hostnames and ports were given, so that those of a hostname expanded with
+// --ports are listed together. Web properties that were not asked for are
+// left at the end.
+func (c *Command) groupWebProperties(webProperties []*assets.WebProperty) []*assets.WebProperty {
+	if len(c.ports) == 0 {
+		return webProperties
+	}
+	grouped := make([]*assets.WebProperty, 0, len(webProperties))
+	listed := make(map[*assets.WebProperty]bool, len(webProperties))
+	for _, g := range c.webPropertyGroups(webProperties) {
+		for _, wp := range g.WebProperties {
+			if !listed[wp] {
+				listed[wp] = true
+				grouped = append(grouped, wp)
+			}
+		}
+	}
+	for _, wp := range webProperties {
+		if !listed[wp] {
+			grouped = append(grouped, wp)
+		}
+	}
+	return grouped
+}
+
+func joinPorts(ports []int) string {
+	s := make([]string, len(ports))
+	for i, port := range ports {
+		s[i] = strconv.Itoa(port)
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,118 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/risk"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+const scoreOnlyFlagName = "score-only"
+
+// parseScoreOnlyFlag parses --score-only, and loads the risk scorer when
+// hosts are printed in short format or only their scores are printed.
+func (c *Command) parseScoreOnlyFlag() cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.scoreOnly, err = c.flags.scoreOnly.Value(); err != nil {
+		return err
+	}
+	if c.scoreOnly {
+		if c.assetType != assets.AssetTypeHost {
+			return NewUnsupportedAssetTypeError(c.assetType, "--score-only is only supported for hosts")
+		}
+		if c.export.IsPresent() {
+			return flags.NewConflictingFlagsError(scoreOnlyFlagName, "format")
+		}
+		if c.Config().Streaming {
+			return flags.NewConflictingFlagsError(scoreOnlyFlagName, "streaming")
+		}
+		if c.Config().OutputFormat == formatter.OutputFormatTemplate {
+			return flags.NewConflictingFlagsError(scoreOnlyFlagName, "output-format=template")
+		}
+	}
+	if c.assetType != assets.AssetTypeHost || (!c.scoreOnly && c.Config().OutputFormat != formatter.OutputFormatShort) {
+		return nil
+	}
+	var badJA4 []string
+	if path := c.Config().Risk.BadJA4File; path != "" {
+		var loadErr error
+		if badJA4, loadErr = risk.LoadJA4List(path); loadErr != nil {
+			return NewRiskListError(path, loadErr)
+		}
+	}
+	c.scorer = risk.New(badJA4)
+	return nil
+}
+
+// assessHosts scores the fetched hosts, if a scorer was loaded.
+func (c *Command) assessHosts() {
+	if c.scorer == nil {
+		return
+	}
+	c.assessments = make([]risk.Assessment, len(c.result.Hosts))
+	for i, host := range c.result.Hosts {
+		c.assessments[i] = c.scorer.Host(host)
+	}
+}
+
+// rankedAssessments returns the assessments, highest score first.
+func (c *Command) rankedAssessments() []risk.Assessment {
+	ranked := append([]risk.Assessment(nil), c.assessments...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+	return ranked
+}
+
+// renderScoresShort renders a table of the host scores, highest first.
+func (c *Command) renderScoresShort() {
+	ranked := c.rankedAssessments()
+	if len(ranked) == 0 {
+		fmt.Fprintf(formatter.Stdout, "\nNo hosts found.\n")
+		return
+	}
+	columns := []rawtable.Column[risk.Assessment]{
+		{
+			Title:      "Score",
+			String:     func(a risk.Assessment) string { return strconv.Itoa(a.Score) },
+			AlignRight: true,
+			NoTruncate: true,
+		},
+		{
+			Title:      "Level",
+			String:     func(a risk.Assessment) string { return string(a.Level) },
+			NoTruncate: true,
+		},
+		{
+			Title:  "IP",
+			String: func(a risk.Assessment) string { return a.IP },
+			Style: func(s string, _ risk.Assessment) string {
+				return styles.GlobalStyles.Signature.Render(s)
+			},
+			NoTruncate: true,
+		},
+		{
+			Title: "Reasons",
+			String: func(a risk.Assessment) string {
+				reasons := make([]string, len(a.Reasons))
+				for i, r := range a.Reasons {
+					reasons[i] = r.Reason
+				}
+				return strings.Join(reasons, "; ")
+			},
+		},
+	}
+	tbl := rawtable.New(
+		columns,
+		rawtable.WithHeaderStyle[risk.Assessment](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[risk.Assessment](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[risk.Assessment](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, tbl.Render(ranked))
+}
@@ -0,0 +1,185 @@
+package view
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/flags"
+)
+
+const (
+	sectionFlagName      = "section"
+	servicesOnlyFlagName = "services-only"
+	noCertsFlagName      = "no-certs"
+
+	servicesSection = "services"
+)
+
+// hostSectionFields clears the fields of each section of a host, named by
+// its field in data output. The IP of a host is always kept.
+var hostSectionFields = []struct {
+	name  string
+	clear func(*assets.Host)
+}{
+	{servicesSection, func(h *assets.Host) { h.Services, h.ServiceCount, h.MatchedServices = nil, nil, nil }},
+	{"location", func(h *assets.Host) { h.Location = nil }},
+	{"autonomous_system", func(h *assets.Host) { h.AutonomousSystem = nil }},
+	{"whois", func(h *assets.Host) { h.Whois = nil }},
+	{"dns", func(h *assets.Host) { h.DNS = nil }},
+	{"labels", func(h *assets.Host) { h.Labels = nil }},
+	{"operating_system", func(h *assets.Host) { h.OperatingSystem = nil }},
+	{"hardware", func(h *assets.Host) { h.Hardware = nil }},
+	{"network", func(h *assets.Host) { h.Network = nil }},
+	{"privacy", func(h *assets.Host) { h.Privacy = nil }},
+	{"reputation", func(h *assets.Host) { h.Reputation = nil }},
+	{"greynoise", func(h *assets.Host) { h.Greynoise = nil }},
+}
+
+// hostSectionNames returns the names of the sections of a host.
+func hostSectionNames() []string {
+	names := make([]string, len(hostSectionFields))
+	for i, s := range hostSectionFields {
+		names[i] = s.name
+	}
+	return names
+}
+
+type sectionFlags struct {
+	sections     flags.StringSliceFlag
+	servicesOnly flags.BoolFlag
+	noCerts      flags.BoolFlag
+}
+
+func newSectionFlags(c *Command) sectionFlags {
+	return sectionFlags{
+		sections: flags.NewStringSliceFlag(c.Flags(), false, sectionFlagName, "", []string{},
+			fmt.Sprintf("only print these sections of each host (%s)", strings.Join(hostSectionNames(), ", "))),
+		servicesOnly: flags.NewBoolFlag(c.Flags(), servicesOnlyFlagName, "", false,
+			"only print the services of each host; the same as --section services"),
+		noCerts: flags.NewBoolFlag(c.Flags(), noCertsFlagName, "", false,
+			"leave out the certificates of the services of each host"),
+	}
+}
+
+// hostSections are the parts of each host that are printed.
+type hostSections struct {
+	// only are the sections printed, or all of them if empty
+	only []string
+	// noCerts leaves out the certificates of services
+	noCerts bool
+}
+
+// parseSectionFlags parses --section, --services-only, and --no-certs.
+// Sections only apply to printed hosts, so they cannot be combined with
+// --score-only or an export.
+func (c *Command) parseSectionFlags() cenclierrors.CencliError {
+	only, err := c.flags.sections.sections.Value()
+	if err != nil {
+		return err
+	}
+	servicesOnly, err := c.flags.sections.servicesOnly.Value()
+	if err != nil {
+		return err
+	}
+	noCerts, err := c.flags.sections.noCerts.Value()
+	if err != nil {
+		return err
+	}
+	if servicesOnly {
+		if len(only) > 0 {
+			return flags.NewConflictingFlagsError(servicesOnlyFlagName, sectionFlagName)
+		}
+		only = []string{servicesSection}
+	}
+	c.sections = hostSections{only: only, noCerts: noCerts}
+	if !c.sections.filters() {
+		return nil
+	}
+
+	name := sectionFlagName
+	switch {
+	case servicesOnly:
+		name = servicesOnlyFlagName
+	case len(only) == 0:
+		name = noCertsFlagName
+	}
+	if c.assetType != assets.AssetTypeHost {
+		return NewUnsupportedAssetTypeError(c.assetType, fmt.Sprintf("--%s is only supported for hosts", name))
+	}
+	if c.scoreOnly {
+		return flags.NewConflictingFlagsError(name, scoreOnlyFlagName)
+	}
+	if c.export.IsPresent() {
+		return flags.NewConflictingFlagsError(name, "format")
+	}
+	names := hostSectionNames()
+	for _, section := range only {
+		if !slices.Contains(names, section) {
+			return cenclierrors.NewUsageError(fmt.Errorf("unsupported --%s %q; use one of %s",
+				sectionFlagName, section, strings.Join(names, ", ")))
+		}
+	}
+	return nil
+}
+
+// filters reports whether any part of a host is left out.
+func (s hostSections) filters() bool {
+	return len(s.only) > 0 || s.noCerts
+}
+
+// shows reports whether section is printed.
+func (s hostSections) shows(section string) bool {
+	return len(s.only) == 0 || slices.Contains(s.only, section)
+}
+
+// host returns a copy of h with only the sections that are printed.
+func (s hostSections) host(h *assets.Host) *assets.Host {
+	if !s.filters() {
+		return h
+	}
+	res := *h
+	for _, section := range hostSectionFields {
+		if !s.shows(section.name) {
+			section.clear(&res)
+		}
+	}
+	if s.noCerts && res.Services != nil {
+		res.Services = make([]components.Service, len(h.Services))
+		for i, svc := range h.Services {
+			svc.Cert = nil
+			if svc.TLS != nil {
+				tls := *svc.TLS
+				tls.PresentedChain = nil
+				svc.TLS = &tls
+			}
+			res.Services[i] = svc
+		}
+	}
+	return &res
+}
+
+// hosts returns the hosts with only the sections that are printed.
+func (s hostSections) hosts(hosts []*assets.Host) []*assets.Host {
+	if !s.filters() {
+		return hosts
+	}
+	res := make([]*assets.Host, len(hosts))
+	for i, h := range hosts {
+		res[i] = s.host(h)
+	}
+	return res
+}
+
+// apply returns item with only the sections that are printed, if it is a
+// host, or item unchanged.
+func (s hostSections) apply(item any) any {
+	if h, ok := item.(*assets.Host); ok {
+		return s.host(h)
+	}
+	return item
+}
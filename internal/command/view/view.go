@@ -3,11 +3,13 @@ package view
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/samber/mo"
 	"github.com/spf13/cobra"
 
+	"github.com/censys/cencli/internal/app/history"
 	"github.com/censys/cencli/internal/app/view"
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/config"
@@ -19,7 +21,11 @@ import (
 	"github.com/censys/cencli/internal/pkg/formatter"
 	"github.com/censys/cencli/internal/pkg/formatter/short"
 	"github.com/censys/cencli/internal/pkg/input"
+	"github.com/censys/cencli/internal/pkg/resolve"
+	"github.com/censys/cencli/internal/pkg/risk"
+	"github.com/censys/cencli/internal/pkg/styles"
 	"github.com/censys/cencli/internal/pkg/tape"
+	"github.com/censys/cencli/internal/pkg/xref"
 )
 
 const (
@@ -30,6 +36,8 @@ type Command struct {
 	*command.BaseCommand
 	// services the command uses
 	viewSvc view.Service
+	// historySvc is only resolved with --history-annotations
+	historySvc history.Service
 	// flags the command uses
 	flags viewCommandFlags
 	// state - populated by PreRun (through flags, etc.)
@@ -37,14 +45,56 @@ type Command struct {
 	assetType assets.AssetType
 	orgID     mo.Option[identifiers.OrganizationID]
 	atTime    mo.Option[time.Time]
+	// collectionID limits the assets to those of a collection
+	collectionID mo.Option[identifiers.CollectionID]
+	export       mo.Option[command.ExportTarget]
+	forward      mo.Option[command.ForwardTarget]
+	scoreOnly    bool
+	// sections are the parts of each host that are printed
+	sections hostSections
+	// ports are the ports domains given without a port are viewed on, with --ports
+	ports []int
+	// scorer scores hosts printed in short format or with --score-only
+	scorer *risk.Scorer
+	// inputs are the raw assets, recorded as the query of an export
+	inputs []string
+	// metadata carried through from NDJSON input lines
+	metadata inputMetadata
+	// feeds are the threat feeds to cross-reference, loaded into xref by Run
+	feeds []xref.Source
+	xref  *xref.Matcher
+	// resolvedFrom maps the IP of a host to the domain it was resolved from, with --resolve
+	resolvedFrom map[string]string
+	// historyWindow is how far back the timeline of each host is read, with --history-annotations
+	historyWindow time.Duration
+	// histories maps the IP of a host to the history of its services
+	histories map[string]hostHistory
+	// allOrgs fetches the assets from each organization
+	allOrgs bool
+	// orgResults are the results of each organization, with --all-orgs
+	orgResults []command.OrgResult[assetResult]
 	// result stores the asset result for rendering
 	result assetResult
+	// assessments are the risk scores of result.Hosts, if scored
+	assessments []risk.Assessment
 }
 
 type viewCommandFlags struct {
-	orgID     flags.OrgIDFlag
-	inputFile flags.FileFlag
-	atTime    flags.TimestampFlag
+	orgID        flags.OrgIDFlag
+	inputFile    flags.FileFlag
+	atTime       flags.TimestampFlag
+	collectionID flags.UUIDFlag
+	export       command.ExportFlags
+	forward      command.ForwardFlags
+	scoreOnly    flags.BoolFlag
+	sections     sectionFlags
+	xref         command.XrefFlags
+	resolve      command.ResolveFlags
+	ports        flags.StringSliceFlag
+	// history annotations
+	historyAnnotations flags.BoolFlag
+	historyWindow      flags.HumanDurationFlag
+	allOrgs            flags.BoolFlag
 }
 
 var _ command.Command = (*Command)(nil)
@@ -75,20 +125,43 @@ func (c *Command) Examples() []string {
 		"platform.censys.io:80",
 		"platform.censys.io # defaults to port 443",
 		"platform.censys.io:80,google.com:80",
+		"platform.censys.io --resolve  # view the hosts the domain resolves to",
+		"platform.censys.io --ports 80,443,8080,8443  # view the web properties of the domain on each port",
+		"--input-file domains.txt --ports default -O short",
 		"--input-file hosts.txt",
+		"--input-file hosts.ndjson  # extra JSON fields are attached to the output",
 		"--input-file -  # read assets from STDIN",
 		"platform.censys.io:80 --at-time 2025-09-15T14:30:00Z",
 		"8.8.8.8 --output-format short",
+		"8.8.8.8 --services-only --no-certs",
+		"8.8.8.8 --section dns,location -O short",
+		"--input-file hosts.txt --score-only --output-format short",
+		"--input-file hosts.txt --format sqlite --output results.db --append",
+		"--input-file hosts.txt --xref c2=https://example.com/c2-ips.txt -O short",
+		"8.8.8.8 --history-annotations -O short  # when each service was first seen and last changed",
+		"--input-file hosts.txt --all-orgs",
+		"--input-file hosts.txt --collection-id <your-collection-id>",
 	}
 }
 
 func (c *Command) Init() error {
 	// initialize command-specific flags
-	c.flags.inputFile = flags.NewFileFlag(c.Flags(), false, "input-file", "i", "file to read the assets from. Overrides the positional argument.")
+	c.flags.inputFile = flags.NewFileFlag(c.Flags(), false, "input-file", "i", "file to read the assets from, one per line as plain text or JSON objects with an \"asset\" field. Overrides the positional argument.")
 	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
 	c.flags.atTime = flags.NewTimestampFlag(c.Flags(), false, "at-time", "", mo.None[time.Time](), "view data as of this time (certificates not supported)")
 	// add aliases: --at and -a
 	c.flags.atTime.AddAlias("at", "a", "Alias for --at-time")
+	c.flags.collectionID = newCollectionIDFlag(c)
+	c.flags.export = command.NewExportFlags(c.Flags())
+	c.flags.forward = command.NewForwardFlags(c.Flags())
+	c.flags.scoreOnly = flags.NewBoolFlag(c.Flags(), scoreOnlyFlagName, "", false, "print only the risk score of each host, highest first")
+	c.flags.sections = newSectionFlags(c)
+	c.flags.xref = command.NewXrefFlags(c.Flags())
+	c.flags.resolve = command.NewResolveFlags(c.Flags(), false)
+	c.flags.ports = newPortsFlag(c)
+	c.flags.historyAnnotations = flags.NewBoolFlag(c.Flags(), historyAnnotationsFlagName, "", false, "annotate each service of a host with when it was first seen and last changed in the host's timeline")
+	c.flags.allOrgs = command.NewAllOrgsFlag(c.Flags())
+	c.flags.historyWindow = flags.NewHumanDurationFlag(c.Flags(), false, historyWindowFlagName, "", mo.Some(defaultHistoryWindow), "how far back to read the timeline with --history-annotations (e.g., 7d, 1w, 1y). Defaults to 30d")
 	return nil
 }
 
@@ -116,24 +189,111 @@ func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliE
 	if err := c.parseOrgIDFlag(); err != nil {
 		return err
 	}
+	if err := c.parseCollectionIDFlag(); err != nil {
+		return err
+	}
+	export, err := c.flags.export.Value(cmd, c.Config().Streaming)
+	if err != nil {
+		return err
+	}
+	c.export = export
+	if c.forward, err = c.flags.forward.Value(); err != nil {
+		return err
+	}
+	if c.forward.IsPresent() && c.export.IsPresent() {
+		return flags.NewConflictingFlagsError("forward", "format")
+	}
+	if c.feeds, err = c.flags.xref.Value(c.Config().Xref); err != nil {
+		return err
+	}
 	// gather assets and classify
 	rawAssets, err := c.gatherRawAssets(cmd, args)
 	if err != nil {
 		return err
 	}
+	c.inputs = rawAssets
+	if err := c.parsePortsFlag(); err != nil {
+		return err
+	}
+	if rawAssets, err = c.resolveDomains(cmd.Context(), rawAssets); err != nil {
+		return err
+	}
+	rawAssets = c.expandPorts(rawAssets)
 	c.assets = assets.NewAssetClassifier(rawAssets...)
 	c.assetType, err = c.assets.AssetType()
 	if err != nil {
 		return err
 	}
+	if err := c.checkPortsFlag(); err != nil {
+		return err
+	}
 	// check invariants - certificate asset does not support at-time
 	if c.assetType == assets.AssetTypeCertificate && c.atTime.IsPresent() {
 		return NewAtTimeNotSupportedError(c.assetType)
 	}
+	if err := c.parseScoreOnlyFlag(); err != nil {
+		return err
+	}
+	if err := c.parseSectionFlags(); err != nil {
+		return err
+	}
+	if err := c.parseHistoryFlags(); err != nil {
+		return err
+	}
+	allOrgs, err := c.ParseAllOrgsFlag(cmd, c.flags.allOrgs, allOrgsConflicts...)
+	if err != nil {
+		return err
+	}
+	c.allOrgs = allOrgs
 	// resolve dependencies only after validation
 	return c.resolveViewService()
 }
 
+// resolveDomains replaces the bare domains in rawAssets with the IPs they
+// resolve to when --resolve is set. Otherwise, bare domains are viewed as web
+// properties on port 443, or on the ports of --ports, and a hint about
+// --resolve is printed to terminals.
+func (c *Command) resolveDomains(ctx context.Context, rawAssets []string) ([]string, cenclierrors.CencliError) {
+	enabled, err := c.flags.resolve.Value(false)
+	if err != nil {
+		return nil, err
+	}
+	if enabled && len(c.ports) > 0 {
+		return nil, flags.NewConflictingFlagsError(portsFlagName, "resolve")
+	}
+	if !enabled {
+		if !c.Config().Quiet && formatter.StderrIsTTY() && len(c.ports) == 0 {
+			for _, raw := range rawAssets {
+				if domain, ok := resolve.BareDomain(raw); ok {
+					formatter.Println(formatter.Stderr, styles.GlobalStyles.Comment.Render(fmt.Sprintf(
+						"Viewing %s as a web property on port %d; use --resolve to view the hosts it resolves to instead",
+						domain, assets.DefaultWebPropertyPort)))
+					break
+				}
+			}
+		}
+		return rawAssets, nil
+	}
+	resolved, resolutions, err := c.ResolveDomains(ctx, rawAssets)
+	if err != nil {
+		return nil, err
+	}
+	c.resolvedFrom = make(map[string]string)
+	for _, r := range resolutions {
+		meta, hasMeta := c.metadata[webPropertyKey(r.Domain, assets.DefaultWebPropertyPort)]
+		for _, ip := range r.IPs {
+			key := hostKey(ip)
+			if _, exists := c.resolvedFrom[key]; !exists {
+				c.resolvedFrom[key] = r.Domain
+			}
+			if _, exists := c.metadata[key]; hasMeta && !exists {
+				c.metadata[key] = meta
+			}
+		}
+	}
+	return resolved, nil
+}
+
 // resolveViewService initializes the view service from the command context.
 func (c *Command) resolveViewService() cenclierrors.CencliError {
 	svc, err := c.ViewService()
@@ -147,7 +307,7 @@ func (c *Command) resolveViewService() cenclierrors.CencliError {
 // parseAtTimeFlag parses the optional at-time flag into c.atTime.
 func (c *Command) parseAtTimeFlag() cenclierrors.CencliError {
 	var err cenclierrors.CencliError
-	c.atTime, err = c.flags.atTime.Value(c.Config().DefaultTZ)
+	c.atTime, err = c.flags.atTime.Value(c.Config().DefaultTZ, c.Now())
 	if err != nil {
 		return err
 	}
@@ -167,11 +327,12 @@ func (c *Command) parseOrgIDFlag() cenclierrors.CencliError {
 // gatherRawAssets returns raw asset strings from file, stdin, or positional args.
 func (c *Command) gatherRawAssets(cmd *cobra.Command, args []string) ([]string, cenclierrors.CencliError) {
 	if c.flags.inputFile.IsSet() {
-		lines, err := c.flags.inputFile.Lines(cmd)
+		records, err := command.InputRecords(cmd, c.flags.inputFile)
 		if err != nil {
 			return nil, err
 		}
-		return lines, nil
+		c.metadata = newInputMetadata(records)
+		return input.RecordValues(records), nil
 	}
 	if len(args) == 0 {
 		return nil, assets.NewNoAssetsError()
@@ -185,20 +346,36 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 	logger := c.Logger(cmdName).With(
 		"assetType", string(c.assetType),
 		"orgID_set", c.orgID.IsPresent(),
+		"collectionID_set", c.collectionID.IsPresent(),
 		"count", count,
 	)
 
 	// Set up streaming output (no-op for non-streaming formats)
 	ctx, stopStreaming := c.WithStreamingOutput(cmd.Context(), logger)
 	defer stopStreaming(nil)
+	if !c.export.IsPresent() && !c.scoreOnly {
+		var xrefErr cenclierrors.CencliError
+		if c.xref, xrefErr = c.LoadXref(ctx, c.feeds); xrefErr != nil {
+			return xrefErr
+		}
+	}
+	ctx = c.withAnnotatedStreaming(ctx)
+	ctx, stopForwarding, err := c.StartForwarding(ctx, logger, c.forward, cmdName)
+	if err != nil {
+		return err
+	}
+	defer stopForwarding()
 
-	err := c.WithProgress(
+	err = c.WithProgress(
 		ctx,
 		logger,
 		"Fetching assets...",
 		func(pctx context.Context) cenclierrors.CencliError {
+			if c.allOrgs {
+				return c.fetchAllOrgs(pctx)
+			}
 			var fetchErr cenclierrors.CencliError
-			c.result, fetchErr = c.fetchAssetResult(pctx)
+			c.result, fetchErr = c.fetchAssetResult(pctx, c.orgID)
 			return fetchErr
 		},
 	)
@@ -206,18 +383,37 @@ func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliErro
 		logger.Debug("fetch failed", "error", err)
 		return err
 	}
+	c.result.WebProperties = c.groupWebProperties(c.result.WebProperties)
+	if c.historyEnabled() {
+		err = c.WithProgress(ctx, logger, "Fetching host history...", c.fetchHostHistories)
+		if err != nil {
+			logger.Debug("history fetch failed", "error", err)
+			return err
+		}
+	}
 
 	// Print response metadata
 	c.PrintAppResponseMeta(c.result.Meta)
+	c.assessHosts()
 
-	// PrintData handles streaming vs buffered automatically
-	if renderErr := c.PrintData(c, c.result.Data()); renderErr != nil {
+	if target, ok := c.export.Get(); ok {
+		if err := c.ExportAssets(cmd.Context(), target, cmdName, strings.Join(c.inputs, ","), c.result.Assets()); err != nil {
+			return err
+		}
+	} else if renderErr := c.PrintData(c, c.outputData()); renderErr != nil {
+		// PrintData handles streaming vs buffered automatically
 		return renderErr
 	}
+	if err := stopForwarding(); err != nil {
+		return err
+	}
 
+	if c.allOrgs {
+		return c.reportOrgErrors(cmd)
+	}
 	// If there was a partial error, print it to stderr after rendering the data
 	if c.result.PartialError != nil {
-		formatter.PrintError(c.result.PartialError, cmd)
+		c.ReportPartialError(c.result.PartialError, cmd)
 	}
 
 	return nil
@@ -249,13 +445,62 @@ func (r assetResult) Data() any {
 	}
 }
 
+// Assets returns the result as a list of assets.
+func (r assetResult) Assets() []assets.Asset {
+	var out []assets.Asset
+	switch r.Type {
+	case assets.AssetTypeHost:
+		for _, h := range r.Hosts {
+			out = append(out, h)
+		}
+	case assets.AssetTypeCertificate:
+		for _, cert := range r.Certificates {
+			out = append(out, cert)
+		}
+	case assets.AssetTypeWebProperty:
+		for _, wp := range r.WebProperties {
+			out = append(out, wp)
+		}
+	}
+	return out
+}
+
+// outputData returns the result data, with any NDJSON input metadata, threat
+// feed matches, and host history attached to the corresponding assets. With
+// --all-orgs, each asset is labeled with its organization.
+func (c *Command) outputData() any {
+	if c.scoreOnly {
+		return c.rankedAssessments()
+	}
+	if c.allOrgs {
+		return c.orgsOutputData()
+	}
+	if !c.hasAnnotations() {
+		return c.result.Data()
+	}
+	switch c.result.Type {
+	case assets.AssetTypeHost:
+		return annotateAll(c.annotate, c.result.Hosts)
+	case assets.AssetTypeCertificate:
+		return annotateAll(c.annotate, c.result.Certificates)
+	case assets.AssetTypeWebProperty:
+		return annotateAll(c.annotate, c.result.WebProperties)
+	default:
+		return c.result.Data()
+	}
+}
+
 // RenderTemplate renders asset results using a handlebars template.
 func (c *Command) RenderTemplate() cenclierrors.CencliError {
 	templateEntity, err := templateEntityFromAssetType(c.result.Type)
 	if err != nil {
 		return err
 	}
-	return c.PrintDataWithTemplate(templateEntity, c.result.Data())
+	data := c.result.Data()
+	if c.result.Type == assets.AssetTypeHost {
+		data = c.sections.hosts(c.result.Hosts)
+	}
+	return c.PrintDataWithTemplate(templateEntity, data)
 }
 
 // assetInputCount returns the number of input assets based on the inferred asset type.
@@ -273,10 +518,13 @@ func (c *Command) assetInputCount() int {
 }
 
 // fetchAssetResult delegates to the appropriate view service method based on asset type.
-func (c *Command) fetchAssetResult(ctx context.Context) (assetResult, cenclierrors.CencliError) {
+func (c *Command) fetchAssetResult(ctx context.Context, orgID mo.Option[identifiers.OrganizationID]) (assetResult, cenclierrors.CencliError) {
+	if collectionID, ok := c.collectionID.Get(); ok {
+		return c.fetchAssetResultInCollection(ctx, orgID, collectionID)
+	}
 	switch c.assetType {
 	case assets.AssetTypeHost:
-		result, err := c.viewSvc.GetHosts(ctx, c.orgID, c.assets.HostIDs(), c.atTime)
+		result, err := c.viewSvc.GetHosts(ctx, orgID, c.assets.HostIDs(), c.atTime)
 		if err != nil {
 			return assetResult{}, err
 		}
@@ -287,7 +535,7 @@ func (c *Command) fetchAssetResult(ctx context.Context) (assetResult, cenclierro
 			PartialError: result.PartialError,
 		}, nil
 	case assets.AssetTypeCertificate:
-		result, err := c.viewSvc.GetCertificates(ctx, c.orgID, c.assets.CertificateIDs())
+		result, err := c.viewSvc.GetCertificates(ctx, orgID, c.assets.CertificateIDs())
 		if err != nil {
 			return assetResult{}, err
 		}
@@ -298,7 +546,7 @@ func (c *Command) fetchAssetResult(ctx context.Context) (assetResult, cenclierro
 			PartialError: result.PartialError,
 		}, nil
 	case assets.AssetTypeWebProperty:
-		result, err := c.viewSvc.GetWebProperties(ctx, c.orgID, c.assets.WebPropertyIDs(), c.atTime)
+		result, err := c.viewSvc.GetWebProperties(ctx, orgID, c.assets.WebPropertyIDs(), c.atTime)
 		if err != nil {
 			return assetResult{}, err
 		}
@@ -327,21 +575,62 @@ func templateEntityFromAssetType(assetType assets.AssetType) (config.TemplateEnt
 }
 
 func (c *Command) RenderShort() cenclierrors.CencliError {
+	if c.result.Type == assets.AssetTypeHost && c.scoreOnly {
+		c.renderScoresShort()
+		return nil
+	}
 	var output string
+	var err cenclierrors.CencliError
+	if c.allOrgs {
+		output, err = c.renderOrgsShort()
+	} else {
+		output, err = c.renderAssetsShort(c.result, c.assessments)
+	}
+	if err != nil {
+		return err
+	}
 
-	switch c.result.Type {
+	formatter.Println(formatter.Stdout, output)
+	if c.xref != nil {
+		items := c.result.Assets()
+		matches := make([][]xref.Match, len(items))
+		for i, item := range items {
+			matches[i] = c.xref.Match(item)
+		}
+		if summary := short.XrefMatches(items, matches); summary != "" {
+			formatter.Printf(formatter.Stdout, "%s", summary)
+		}
+	}
+	return nil
+}
+
+// renderAssetsShort renders the assets of result in short format.
+// assessments are the risk scores of result.Hosts, if scored.
+func (c *Command) renderAssetsShort(result assetResult, assessments []risk.Assessment) (string, cenclierrors.CencliError) {
+	switch result.Type {
 	case assets.AssetTypeWebProperty:
-		output = short.WebProperties(c.result.WebProperties)
+		if len(c.ports) > 0 {
+			return short.WebPropertyGroups(c.webPropertyGroups(result.WebProperties)), nil
+		}
+		return short.WebProperties(result.WebProperties), nil
 	case assets.AssetTypeHost:
-		output = short.Hosts(c.result.Hosts)
+		var opts []short.HostsOption
+		if c.historyEnabled() {
+			opts = append(opts, short.WithServiceNotes(c.serviceHistoryNote))
+		}
+		if !c.sections.shows(servicesSection) {
+			opts = append(opts, short.WithoutServices())
+		}
+		hosts := c.sections.hosts(result.Hosts)
+		if assessments != nil {
+			return short.HostsWithRisk(hosts, assessments, opts...), nil
+		}
+		return short.Hosts(hosts, opts...), nil
 	case assets.AssetTypeCertificate:
-		output = short.Certificates(c.result.Certificates)
+		return short.Certificates(result.Certificates), nil
 	default:
-		return NewUnsupportedAssetTypeError(c.result.Type, "short output not supported for this asset type")
+		return "", NewUnsupportedAssetTypeError(result.Type, "short output not supported for this asset type")
 	}
-
-	formatter.Println(formatter.Stdout, output)
-	return nil
 }
 
 func (*Command) Tapes(recorder *tape.Recorder) []tape.Tape {
@@ -2,21 +2,32 @@ package view
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/censys/censys-sdk-go/models/components"
 	"github.com/censys/censys-sdk-go/models/sdkerrors"
+	"github.com/google/uuid"
 	"github.com/samber/mo"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	historymocks "github.com/censys/cencli/gen/app/history/mocks"
+	orgmocks "github.com/censys/cencli/gen/app/organizations/mocks"
 	viewmocks "github.com/censys/cencli/gen/app/view/mocks"
+	"github.com/censys/cencli/internal/app/history"
+	"github.com/censys/cencli/internal/app/organizations"
+	"github.com/censys/cencli/internal/app/streaming"
 	"github.com/censys/cencli/internal/app/view"
 	"github.com/censys/cencli/internal/command"
 	"github.com/censys/cencli/internal/config"
@@ -172,6 +183,90 @@ func TestViewCommand(t *testing.T) {
 				require.Contains(t, stdout, "1.1.1.1")
 			},
 		},
+		{
+			name:  "host view - ndjson input carries metadata",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				mc := viewmocks.NewMockViewService(ctrl)
+				hostID1, _ := assets.NewHostID("8.8.8.8")
+				hostID2, _ := assets.NewHostID("1.1.1.1")
+				mc.EXPECT().GetHosts(gomock.Any(), mo.None[identifiers.OrganizationID](), []assets.HostID{hostID1, hostID2}, mo.None[time.Time]()).
+					Return(view.HostsResult{
+						Hosts: []*assets.Host{{Host: components.Host{IP: strPtr("1.1.1.1")}}, {Host: components.Host{IP: strPtr("8.8.8.8")}}},
+					}, nil)
+				return mc
+			},
+			stdin: `{"asset": "8[.]8[.]8[.]8", "label": "case-42"}` + "\n1.1.1.1\n",
+			args:  []string{"--input-file", "-", "--output-format", "json"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var out []map[string]any
+				require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+				require.Len(t, out, 2)
+				require.Equal(t, "1.1.1.1", out[0]["ip"])
+				require.NotContains(t, out[0], "input")
+				require.Equal(t, "8.8.8.8", out[1]["ip"])
+				require.Equal(t, map[string]any{"asset": "8[.]8[.]8[.]8", "label": "case-42"}, out[1]["input"])
+			},
+		},
+		{
+			name:  "host view - ndjson input carries metadata when streaming",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				mc := viewmocks.NewMockViewService(ctrl)
+				mc.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, _ mo.Option[identifiers.OrganizationID], _ []assets.HostID, _ mo.Option[time.Time]) (view.HostsResult, cenclierrors.CencliError) {
+						require.NoError(t, streaming.Emit(ctx, &assets.Host{Host: components.Host{IP: strPtr("8.8.8.8")}}))
+						return view.HostsResult{}, nil
+					})
+				return mc
+			},
+			stdin: `{"asset": "8.8.8.8", "label": "case-42"}` + "\n",
+			args:  []string{"--input-file", "-", "--streaming"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var out map[string]any
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(stdout)), &out))
+				require.Equal(t, "8.8.8.8", out["ip"])
+				require.Equal(t, map[string]any{"asset": "8.8.8.8", "label": "case-42"}, out["input"])
+			},
+		},
+		{
+			name:  "host view - search output as input",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				mc := viewmocks.NewMockViewService(ctrl)
+				hostID1, _ := assets.NewHostID("8.8.8.8")
+				hostID2, _ := assets.NewHostID("1.1.1.1")
+				mc.EXPECT().GetHosts(gomock.Any(), mo.None[identifiers.OrganizationID](), []assets.HostID{hostID1, hostID2}, mo.None[time.Time]()).
+					Return(view.HostsResult{
+						Hosts: []*assets.Host{{Host: components.Host{IP: strPtr("8.8.8.8")}}, {Host: components.Host{IP: strPtr("1.1.1.1")}}},
+					}, nil)
+				return mc
+			},
+			stdin: `{"host": {"ip": "8.8.8.8", "services": [{"port": 53}]}}` + "\n" + `{"host": {"ip": "1.1.1.1"}}` + "\n",
+			args:  []string{"--input-file", "-", "--output-format", "json"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var out []map[string]any
+				require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+				require.Len(t, out, 2)
+				require.NotContains(t, out[0], "input")
+			},
+		},
+		{
+			name:  "ndjson input - invalid line",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			stdin: "8.8.8.8\n{\"label\": \"case-42\"}\n",
+			args:  []string{"--input-file", "-"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid JSON input on line 2")
+			},
+		},
 		{
 			name: "help message",
 			store: func() store.Store {
@@ -523,6 +618,332 @@ func TestViewCommand(t *testing.T) {
 				require.Contains(t, stdout, "8.8.8.8")
 			},
 		},
+		{
+			name:  "host view - short output starts with the risk score",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				host := &assets.Host{Host: components.Host{
+					IP: strPtr("8.8.8.8"),
+					Services: []components.Service{
+						{Port: intPtr(3389), Protocol: strPtr("RDP")},
+						{Port: intPtr(443), Protocol: strPtr("HTTP"), TLS: &components.TLS{Ja4s: strPtr("t130200_1301_234ea6891581")}},
+					},
+				}}
+				ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(view.HostsResult{Meta: &responsemeta.ResponseMeta{Status: 200}, Hosts: []*assets.Host{host}}, nil)
+				return ms
+			},
+			setup: func(t *testing.T, _ []string) {
+				path := filepath.Join(t.TempDir(), "ja4.txt")
+				require.NoError(t, os.WriteFile(path, []byte("t130200_1301_234ea6891581\n"), 0o600))
+				viper.Set("risk.bad-ja4-file", path)
+			},
+			args: []string{"8.8.8.8", "--output-format", "short"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Risk: 65/100 (high)\n  +40  known-bad JA4 fingerprint t130200_1301_234ea6891581 on port 443\n  +25  RDP exposed on port 3389\n")
+				require.Less(t, strings.Index(stdout, "Risk:"), strings.Index(stdout, "IP: 8.8.8.8"))
+			},
+		},
+		{
+			name:  "host view - score only ranks hosts by score",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				hosts := []*assets.Host{
+					{Host: components.Host{IP: strPtr("1.1.1.1")}},
+					{Host: components.Host{IP: strPtr("8.8.8.8"), Services: []components.Service{{Port: intPtr(23), Protocol: strPtr("TELNET")}}}},
+				}
+				ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(view.HostsResult{Meta: &responsemeta.ResponseMeta{Status: 200}, Hosts: hosts}, nil)
+				return ms
+			},
+			args: []string{"1.1.1.1,8.8.8.8", "--score-only"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.JSONEq(t, `[
+					{"ip": "8.8.8.8", "score": 30, "level": "medium", "reasons": [{"points": 30, "reason": "TELNET exposed on port 23"}]},
+					{"ip": "1.1.1.1", "score": 0, "level": "none", "reasons": []}
+				]`, stdout)
+			},
+		},
+		{
+			name:    "certificate view - score only is not supported",
+			store:   func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service { return viewmocks.NewMockViewService(ctrl) },
+			args:    []string{"3daf2843a77b6f4e6af43cd9b6f6746053b8c928e056e8a724808db8905a94cf", "--score-only"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "--score-only is only supported for hosts")
+			},
+		},
+		{
+			name:  "host view - configured threat feeds flag matching hosts",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				hosts := []*assets.Host{
+					{Host: components.Host{IP: strPtr("1.1.1.1")}},
+					{Host: components.Host{IP: strPtr("8.8.8.8")}},
+				}
+				ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(view.HostsResult{Meta: &responsemeta.ResponseMeta{Status: 200}, Hosts: hosts}, nil)
+				return ms
+			},
+			setup: func(t *testing.T, _ []string) {
+				path := filepath.Join(t.TempDir(), "c2.txt")
+				require.NoError(t, os.WriteFile(path, []byte("8[.]8[.]8[.]8\n"), 0o600))
+				viper.Set("xref.feeds", []map[string]any{{"name": "c2", "source": path}})
+			},
+			args: []string{"1.1.1.1,8.8.8.8"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.JSONEq(t, `[
+					{"ip": "1.1.1.1"},
+					{"ip": "8.8.8.8", "xref": [{"feed": "c2", "indicator": "8[.]8[.]8[.]8", "field": "host.ip", "value": "8.8.8.8"}]}
+				]`, stdout)
+			},
+		},
+		{
+			name:  "host view - resolve a domain",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				first, _ := assets.NewHostID("198.51.100.7")
+				second, _ := assets.NewHostID("198.51.100.8")
+				hosts := []*assets.Host{
+					{Host: components.Host{IP: strPtr("198.51.100.7")}},
+					{Host: components.Host{IP: strPtr("198.51.100.8")}},
+				}
+				ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), []assets.HostID{first, second}, gomock.Any()).
+					Return(view.HostsResult{Meta: &responsemeta.ResponseMeta{Status: 200}, Hosts: hosts}, nil)
+				return ms
+			},
+			setup: func(t *testing.T, _ []string) {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Query().Get("name") == "platform.censys.io" && r.URL.Query().Get("type") == "1" {
+						_, _ = w.Write([]byte(`{"Status": 0, "Answer": [{"type": 1, "data": "198.51.100.8"}, {"type": 1, "data": "198.51.100.7"}]}`))
+						return
+					}
+					_, _ = w.Write([]byte(`{"Status": 0}`))
+				}))
+				t.Cleanup(srv.Close)
+				viper.Set("dns.resolver", "doh")
+				viper.Set("dns.doh-url", srv.URL)
+			},
+			args: []string{"platform.censys[.]io", "--resolve"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stderr, "Resolved platform.censys.io to 198.51.100.7, 198.51.100.8 (DNS over HTTPS")
+				require.JSONEq(t, `[
+					{"ip": "198.51.100.7", "resolved_from": "platform.censys.io"},
+					{"ip": "198.51.100.8", "resolved_from": "platform.censys.io"}
+				]`, stdout)
+			},
+		},
+		{
+			name:  "host view - domain that does not resolve",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			setup: func(t *testing.T, _ []string) {
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(`{"Status": 3}`))
+				}))
+				t.Cleanup(srv.Close)
+				viper.Set("dns.resolver", "doh")
+				viper.Set("dns.doh-url", srv.URL)
+			},
+			args: []string{"missing.example", "--resolve"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var resolveErr command.ResolveError
+				require.ErrorAs(t, err, &resolveErr)
+				require.ErrorContains(t, err, "failed to resolve missing.example: no addresses found")
+			},
+		},
+		{
+			name:  "webproperty view - resolve and no-resolve conflict",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			args: []string{"platform.censys.io", "--resolve", "--no-resolve"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "cannot use --resolve and --no-resolve flags together")
+			},
+		},
+		{
+			name:  "host view - no-xref skips configured threat feeds",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(view.HostsResult{Meta: &responsemeta.ResponseMeta{Status: 200}, Hosts: []*assets.Host{{Host: components.Host{IP: strPtr("8.8.8.8")}}}}, nil)
+				return ms
+			},
+			setup: func(t *testing.T, _ []string) {
+				viper.Set("xref.feeds", []map[string]any{{"name": "c2", "source": filepath.Join(t.TempDir(), "missing.txt")}})
+			},
+			args: []string{"8.8.8.8", "--no-xref"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.JSONEq(t, `[{"ip": "8.8.8.8"}]`, stdout)
+			},
+		},
+		{
+			name:    "host view - services only",
+			store:   func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: sectionsViewService,
+			args:    []string{"8.8.8.8", "--services-only"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.JSONEq(t, `[{
+					"ip": "8.8.8.8",
+					"service_count": 1,
+					"services": [{"port": 443, "protocol": "HTTP", "cert": {"fingerprint_sha256": "abc"}}]
+				}]`, stdout)
+			},
+		},
+		{
+			name:    "host view - sections in short output",
+			store:   func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: sectionsViewService,
+			args:    []string{"8.8.8.8", "--section", "dns,location", "--output-format", "short"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "Location: Ann Arbor")
+				require.Contains(t, stdout, "dns.google")
+				require.NotContains(t, stdout, "ASN")
+				require.NotContains(t, stdout, "Services")
+			},
+		},
+		{
+			name:    "host view - no certs",
+			store:   func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: sectionsViewService,
+			args:    []string{"8.8.8.8", "--no-certs", "--section", "services"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.JSONEq(t, `[{"ip": "8.8.8.8", "service_count": 1, "services": [{"port": 443, "protocol": "HTTP"}]}]`, stdout)
+			},
+		},
+		{
+			name:    "host view - unknown section",
+			store:   func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service { return viewmocks.NewMockViewService(ctrl) },
+			args:    []string{"8.8.8.8", "--section", "ports"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, `unsupported --section "ports"; use one of services, location`)
+			},
+		},
+		{
+			name:    "host view - services only conflicts with section",
+			store:   func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service { return viewmocks.NewMockViewService(ctrl) },
+			args:    []string{"8.8.8.8", "--services-only", "--section", "dns"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var conflictErr flags.ConflictingFlagsError
+				require.ErrorAs(t, err, &conflictErr)
+			},
+		},
+		{
+			name:    "certificate view - sections are not supported",
+			store:   func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service { return viewmocks.NewMockViewService(ctrl) },
+			args:    []string{"3daf2843a77b6f4e6af43cd9b6f6746053b8c928e056e8a724808db8905a94cf", "--no-certs"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "--no-certs is only supported for hosts")
+			},
+		},
+		{
+			name:  "web property view - ports merged per hostname",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				ids := []assets.WebPropertyID{
+					{Hostname: "example.com", Port: 80},
+					{Hostname: "example.com", Port: 443},
+					{Hostname: "example.com", Port: 8443},
+				}
+				result := view.WebPropertiesResult{
+					Meta: &responsemeta.ResponseMeta{Method: "GET", URL: "https://127.0.0.1", Status: 200},
+					WebProperties: []*assets.WebProperty{
+						{Webproperty: components.Webproperty{Hostname: strPtr("example.com"), Port: intPtr(443)}},
+						{Webproperty: components.Webproperty{Hostname: strPtr("example.com"), Port: intPtr(80)}},
+					},
+				}
+				ms.EXPECT().GetWebProperties(gomock.Any(), mo.None[identifiers.OrganizationID](), ids, mo.None[time.Time]()).Return(result, nil)
+				return ms
+			},
+			args: []string{"example.com", "--ports", "80,443,8443,443", "--output-format", "short"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "example.com (2 of 3 ports)")
+				require.Contains(t, stdout, "Not found on port 8443")
+				require.Less(t, strings.Index(stdout, "example.com:80"), strings.Index(stdout, "example.com:443"))
+			},
+		},
+		{
+			name:  "web property view - default ports",
+			store: func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				ids := []assets.WebPropertyID{
+					{Hostname: "example.com", Port: 80},
+					{Hostname: "example.com", Port: 443},
+					{Hostname: "example.com", Port: 8080},
+					{Hostname: "example.com", Port: 8443},
+					{Hostname: "censys.io", Port: 8443},
+				}
+				result := view.WebPropertiesResult{
+					Meta: &responsemeta.ResponseMeta{Method: "GET", URL: "https://127.0.0.1", Status: 200},
+					WebProperties: []*assets.WebProperty{
+						{Webproperty: components.Webproperty{Hostname: strPtr("censys.io"), Port: intPtr(8443)}},
+						{Webproperty: components.Webproperty{Hostname: strPtr("example.com"), Port: intPtr(8080)}},
+					},
+				}
+				ms.EXPECT().GetWebProperties(gomock.Any(), mo.None[identifiers.OrganizationID](), ids, mo.None[time.Time]()).Return(result, nil)
+				return ms
+			},
+			args: []string{"example.com,censys.io:8443", "--ports", "default"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var out []map[string]any
+				require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+				require.Len(t, out, 2)
+				require.Equal(t, "example.com", out[0]["hostname"])
+				require.Equal(t, "censys.io", out[1]["hostname"])
+			},
+		},
+		{
+			name:    "web property view - invalid port",
+			store:   func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service { return viewmocks.NewMockViewService(ctrl) },
+			args:    []string{"example.com", "--ports", "80,http"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, `invalid --ports value "http"`)
+			},
+		},
+		{
+			name:    "web property view - ports conflict with resolve",
+			store:   func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service { return viewmocks.NewMockViewService(ctrl) },
+			args:    []string{"example.com", "--ports", "80", "--resolve"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var conflictErr flags.ConflictingFlagsError
+				require.ErrorAs(t, err, &conflictErr)
+			},
+		},
+		{
+			name:    "host view - ports are not supported",
+			store:   func() store.Store { s, _ := store.New(t.TempDir()); return s },
+			service: func(ctrl *gomock.Controller) view.Service { return viewmocks.NewMockViewService(ctrl) },
+			args:    []string{"8.8.8.8", "--ports", "80"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "--ports is only supported for web properties")
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -562,6 +983,25 @@ func TestViewCommand(t *testing.T) {
 	}
 }
 
+// sectionsViewService returns a host with a few sections, for the section
+// filter tests.
+func sectionsViewService(ctrl *gomock.Controller) view.Service {
+	ms := viewmocks.NewMockViewService(ctrl)
+	host := &assets.Host{Host: components.Host{
+		IP:               strPtr("8.8.8.8"),
+		AutonomousSystem: &components.Routing{Asn: intPtr(15169), Name: strPtr("GOOGLE")},
+		Location:         &components.Location{City: strPtr("Ann Arbor")},
+		DNS:              &components.HostDNS{ReverseDNS: &components.HostDNSReverseResolution{Names: []string{"dns.google"}}},
+		ServiceCount:     intPtr(1),
+		Services: []components.Service{
+			{Port: intPtr(443), Protocol: strPtr("HTTP"), Cert: &components.Certificate{FingerprintSha256: strPtr("abc")}},
+		},
+	}}
+	ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(view.HostsResult{Meta: &responsemeta.ResponseMeta{Status: 200}, Hosts: []*assets.Host{host}}, nil)
+	return ms
+}
+
 func TestPreRun_AtTimeNotSupportedForCertificate(t *testing.T) {
 	tempDir := t.TempDir()
 	viper.Reset()
@@ -636,6 +1076,245 @@ func TestViewCommand_PartialError(t *testing.T) {
 	})
 }
 
+func TestViewCommand_HistoryAnnotations(t *testing.T) {
+	atTime := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	hostID, _ := assets.NewHostID("8.8.8.8")
+	scanned := func(at string, port int) *components.HostTimelineEvent {
+		transport := components.ServiceScanTransportProtocolTCP
+		return &components.HostTimelineEvent{EventTime: strPtr(at), ServiceScanned: &components.ServiceScanned{Scan: &components.ServiceScan{
+			Port: &port, TransportProtocol: &transport, Protocol: strPtr("HTTP"),
+		}}}
+	}
+	tcp := components.ServiceTransportProtocolTCP
+	viewService := func(ctrl *gomock.Controller) view.Service {
+		ms := viewmocks.NewMockViewService(ctrl)
+		host := &assets.Host{Host: components.Host{IP: strPtr("8.8.8.8"), Services: []components.Service{
+			{Port: intPtr(22), Protocol: strPtr("SSH"), TransportProtocol: &tcp},
+			{Port: intPtr(8080), Protocol: strPtr("HTTP"), TransportProtocol: &tcp},
+		}}}
+		ms.EXPECT().GetHosts(gomock.Any(), mo.None[identifiers.OrganizationID](), []assets.HostID{hostID}, mo.Some(atTime)).
+			Return(view.HostsResult{Hosts: []*assets.Host{host}}, nil)
+		return ms
+	}
+	historyService := func(ctrl *gomock.Controller) history.Service {
+		ms := historymocks.NewMockHistoryService(ctrl)
+		ms.EXPECT().GetHostHistory(gomock.Any(), mo.None[identifiers.OrganizationID](), hostID, atTime.Add(-30*24*time.Hour), atTime).
+			Return(history.HostHistoryResult{Events: []*components.HostTimelineEvent{
+				scanned("2024-01-20T10:00:00Z", 8080),
+				scanned("2024-01-25T10:00:00Z", 8080),
+			}}, nil)
+		return ms
+	}
+
+	testCases := []struct {
+		name       string
+		viewSvc    func(ctrl *gomock.Controller) view.Service
+		historySvc func(ctrl *gomock.Controller) history.Service
+		args       []string
+		assert     func(t *testing.T, stdout string, err error)
+	}{
+		{
+			name:       "short output notes each service",
+			viewSvc:    viewService,
+			historySvc: historyService,
+			args:       []string{"8.8.8.8", "--history-annotations", "--at-time", atTime.Format(time.RFC3339), "--output-format", "short"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				assert.Contains(t, stdout, "History: unchanged in the last 30d")
+				assert.Contains(t, stdout, "History: first seen 2024-01-20 10:00 UTC, last changed 2024-01-25 10:00 UTC (2 scans)")
+			},
+		},
+		{
+			name:       "data output attaches the history",
+			viewSvc:    viewService,
+			historySvc: historyService,
+			args:       []string{"8.8.8.8", "--history-annotations", "--at-time", atTime.Format(time.RFC3339)},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				var out []struct {
+					IP      string      `json:"ip"`
+					History hostHistory `json:"history"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+				require.Len(t, out, 1)
+				assert.Equal(t, "8.8.8.8", out[0].IP)
+				assert.Equal(t, atTime, out[0].History.End)
+				require.Len(t, out[0].History.Services, 1)
+				assert.Equal(t, 8080, out[0].History.Services[0].Port)
+				assert.Equal(t, 2, out[0].History.Services[0].Events)
+			},
+		},
+		{
+			name: "error - not a host",
+			viewSvc: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			historySvc: func(ctrl *gomock.Controller) history.Service {
+				return historymocks.NewMockHistoryService(ctrl)
+			},
+			args: []string{"platform.censys.io:443", "--history-annotations"},
+			assert: func(t *testing.T, _ string, err error) {
+				var unsupportedErr UnsupportedAssetTypeError
+				require.ErrorAs(t, err, &unsupportedErr)
+			},
+		},
+		{
+			name: "error - conflicts with score-only",
+			viewSvc: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			historySvc: func(ctrl *gomock.Controller) history.Service {
+				return historymocks.NewMockHistoryService(ctrl)
+			},
+			args: []string{"8.8.8.8", "--history-annotations", "--score-only"},
+			assert: func(t *testing.T, _ string, err error) {
+				var conflictErr flags.ConflictingFlagsError
+				require.ErrorAs(t, err, &conflictErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, mustStore(t),
+				command.WithViewService(tc.viewSvc(ctrl)),
+				command.WithHistoryService(tc.historySvc(ctrl)),
+			)
+			rootCmd, err := command.RootCommandToCobra(NewViewCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+			rootCmd.SetArgs(tc.args)
+
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), cmdErr)
+		})
+	}
+}
+
+func TestViewCommand_AllOrgs(t *testing.T) {
+	orgA := identifiers.NewOrganizationID(uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000"))
+	orgB := identifiers.NewOrganizationID(uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000"))
+	hostID, _ := assets.NewHostID("8.8.8.8")
+
+	testCases := []struct {
+		name   string
+		args   []string
+		assert func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name: "labels the assets of each organization",
+			args: []string{"8.8.8.8", "--all-orgs"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var hosts []map[string]any
+				require.NoError(t, json.Unmarshal([]byte(stdout), &hosts))
+				require.Len(t, hosts, 1)
+				assert.Equal(t, "8.8.8.8", hosts[0]["ip"])
+				assert.Equal(t, orgA.String(), hosts[0][command.OrgIDKey])
+				assert.Equal(t, "Team A", hosts[0][command.OrgNameKey])
+				assert.Contains(t, stderr, "Organization "+orgB.String()+" failed: not found")
+			},
+		},
+		{
+			name: "short output has a header per organization",
+			args: []string{"8.8.8.8", "--all-orgs", "--output-format", "short"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				assert.Contains(t, stdout, "Organization: Team A ("+orgA.String()+")\nAssets: 1\n")
+				assert.NotContains(t, stdout, orgB.String())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			st := mustStore(t)
+			_, addErr := st.AddValueForGlobal(context.Background(), config.OrgIDGlobalName, "Team A", orgA.String())
+			require.NoError(t, addErr)
+			_, addErr = st.AddValueForGlobal(context.Background(), config.OrgIDGlobalName, "", orgB.String())
+			require.NoError(t, addErr)
+
+			ctrl := gomock.NewController(t)
+			viewSvc := viewmocks.NewMockViewService(ctrl)
+			host := &assets.Host{Host: components.Host{IP: strPtr("8.8.8.8")}}
+			viewSvc.EXPECT().GetHosts(gomock.Any(), mo.Some(orgA), []assets.HostID{hostID}, mo.None[time.Time]()).
+				Return(view.HostsResult{Hosts: []*assets.Host{host}}, nil)
+			viewSvc.EXPECT().GetHosts(gomock.Any(), mo.Some(orgB), []assets.HostID{hostID}, mo.None[time.Time]()).
+				Return(view.HostsResult{}, cenclierrors.NewCencliError(errors.New("not found")))
+			orgSvc := orgmocks.NewMockOrganizationsService(ctrl)
+			orgSvc.EXPECT().GetOrganizationDetails(gomock.Any(), gomock.Any()).
+				Return(organizations.OrganizationDetailsResult{}, cenclierrors.NewCencliError(errors.New("forbidden"))).Times(2)
+
+			cmdContext := command.NewCommandContext(cfg, st,
+				command.WithViewService(viewSvc),
+				command.WithOrganizationsService(orgSvc),
+			)
+			rootCmd, err := command.RootCommandToCobra(NewViewCommand(cmdContext))
+			require.NoError(t, err)
+			require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+			rootCmd.SetArgs(tc.args)
+
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
+
 func strPtr(s string) *string { return &s }
 func intPtr(i int) *int       { return &i }
 func int64Ptr(i int64) *int64 { return &i }
+
+func TestViewCommand_CollectionID(t *testing.T) {
+	collectionID := "11111111-2222-3333-4444-555555555555"
+	run := func(t *testing.T, ms view.Service, args ...string) (string, error) {
+		t.Helper()
+		viper.Reset()
+		cfg, err := config.New(t.TempDir())
+		require.NoError(t, err)
+		rootCmd, err := command.RootCommandToCobra(NewViewCommand(command.NewCommandContext(cfg, mustStore(t), command.WithViewService(ms))))
+		require.NoError(t, err)
+		require.NoError(t, config.BindGlobalFlags(rootCmd.PersistentFlags(), cfg))
+		stdout := &bytes.Buffer{}
+		formatter.Stdout = stdout
+		formatter.Stderr = &bytes.Buffer{}
+		rootCmd.SetArgs(args)
+		return stdout.String(), rootCmd.Execute()
+	}
+
+	t.Run("assets are looked up in the collection", func(t *testing.T) {
+		ms := viewmocks.NewMockViewService(gomock.NewController(t))
+		hostID, _ := assets.NewHostID("8.8.8.8")
+		ms.EXPECT().GetHostsInCollection(gomock.Any(), mo.None[identifiers.OrganizationID](),
+			identifiers.NewCollectionID(uuid.MustParse(collectionID)), []assets.HostID{hostID}).
+			Return(view.HostsResult{Hosts: []*assets.Host{{Host: components.Host{IP: strPtr("8.8.8.8")}}}}, nil)
+
+		_, err := run(t, ms, "8.8.8.8", "--collection-id", collectionID)
+		require.NoError(t, err)
+	})
+
+	t.Run("at-time is not supported", func(t *testing.T) {
+		ms := viewmocks.NewMockViewService(gomock.NewController(t))
+		_, err := run(t, ms, "8.8.8.8", "--collection-id", collectionID, "--at-time", "2025-09-15T14:30:00Z")
+		require.Error(t, err)
+		var notSupported command.CollectionNotSupportedError
+		require.ErrorAs(t, err, &notSupported)
+		assert.Equal(t, "--collection-id cannot be used with --at-time: collections can only be searched as they are now", err.Error())
+	})
+}
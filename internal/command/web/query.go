@@ -0,0 +1,73 @@
+package web
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/domain/query"
+	"github.com/censys/cencli/internal/pkg/refang"
+)
+
+// hostnameQuery returns the CenQL query for the web properties whose hostname
+// matches pattern, and the normalized pattern. A * in the pattern matches any
+// characters, dots included, so *.example.com matches every subdomain of
+// example.com at any depth, but not example.com itself. A pattern without a *
+// matches the hostname exactly.
+func hostnameQuery(pattern string, port mo.Option[int64]) (string, string, error) {
+	p := strings.ToLower(refang.RefangIP(strings.TrimSpace(pattern)))
+	p = strings.TrimSuffix(p, ".")
+	if err := validatePattern(p); err != nil {
+		return "", "", err
+	}
+
+	var q query.Query
+	if !strings.Contains(p, "*") {
+		q = query.Field("web.hostname").Match(p)
+	} else {
+		// hostnames can only contain characters that are not special in
+		// regular expressions, other than the dot, so nothing else needs
+		// escaping; [.] avoids escaping backslashes in the CenQL string
+		var re strings.Builder
+		re.WriteString("^")
+		for _, r := range p {
+			switch r {
+			case '*':
+				re.WriteString(".*")
+			case '.':
+				re.WriteString("[.]")
+			default:
+				re.WriteRune(r)
+			}
+		}
+		re.WriteString("$")
+		q = query.Field("web.hostname").Regex(re.String())
+	}
+	if v, ok := port.Get(); ok {
+		q = q.And(query.Field("web.port").Match(v))
+	}
+	return q.String(), p, nil
+}
+
+// validatePattern checks that p is a hostname in which * can stand for any
+// part, except in the last two labels, so that a pattern cannot match every
+// hostname of a top-level domain.
+func validatePattern(p string) error {
+	if p == "" {
+		return fmt.Errorf("the hostname pattern is empty")
+	}
+	for _, r := range p {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_' || r == '*') {
+			return fmt.Errorf("%q is not a hostname pattern: invalid character %q", p, r)
+		}
+	}
+	if strings.HasPrefix(p, ".") || strings.Contains(p, "..") {
+		return fmt.Errorf("%q is not a hostname pattern: empty label", p)
+	}
+	labels := strings.Split(p, ".")
+	if len(labels) < 2 || strings.Contains(strings.Join(labels[len(labels)-2:], "."), "*") {
+		return fmt.Errorf("%q is too broad; give at least the domain, such as *.example.com", p)
+	}
+	return nil
+}
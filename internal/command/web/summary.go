@@ -0,0 +1,106 @@
+package web
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/formatter/short"
+)
+
+// summary is the data output of the command: the web properties that were
+// found, with their endpoints, and how often each status code and title was
+// seen across those endpoints.
+type summary struct {
+	Pattern       string            `json:"pattern"`
+	Query         string            `json:"query"`
+	TotalHits     int64             `json:"total_hits"`
+	WebProperties []propertySummary `json:"web_properties"`
+	StatusCodes   []statusCodeCount `json:"status_codes"`
+	Titles        []titleCount      `json:"titles"`
+}
+
+type propertySummary struct {
+	Hostname  string            `json:"hostname"`
+	Port      int               `json:"port"`
+	Endpoints []endpointSummary `json:"endpoints"`
+}
+
+type endpointSummary struct {
+	Path       string `json:"path"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Title      string `json:"title,omitempty"`
+}
+
+type statusCodeCount struct {
+	StatusCode int `json:"status_code"`
+	Count      int `json:"count"`
+}
+
+type titleCount struct {
+	Title string `json:"title"`
+	Count int    `json:"count"`
+}
+
+// summarize summarizes the web properties among the hits of result.
+func summarize(pattern, query string, result search.Result) *summary {
+	s := &summary{
+		Pattern:       pattern,
+		Query:         query,
+		TotalHits:     result.TotalHits,
+		WebProperties: []propertySummary{},
+	}
+	statusCodes := make(map[int]int)
+	titles := make(map[string]int)
+	for _, hit := range result.Hits {
+		wp, ok := hit.(*assets.WebProperty)
+		if !ok {
+			continue
+		}
+		p := propertySummary{
+			Hostname:  short.Val(wp.Hostname, ""),
+			Port:      short.Val(wp.Port, 0),
+			Endpoints: []endpointSummary{},
+		}
+		for _, ep := range wp.Endpoints {
+			e := endpointSummary{Path: short.Val(ep.Path, "")}
+			if ep.HTTP != nil {
+				e.StatusCode = short.Val(ep.HTTP.StatusCode, 0)
+				e.Title = strings.TrimSpace(short.Val(ep.HTTP.HTMLTitle, ""))
+			}
+			if e.StatusCode != 0 {
+				statusCodes[e.StatusCode]++
+			}
+			if e.Title != "" {
+				titles[e.Title]++
+			}
+			p.Endpoints = append(p.Endpoints, e)
+		}
+		s.WebProperties = append(s.WebProperties, p)
+	}
+
+	s.StatusCodes = make([]statusCodeCount, 0, len(statusCodes))
+	for code, count := range statusCodes {
+		s.StatusCodes = append(s.StatusCodes, statusCodeCount{StatusCode: code, Count: count})
+	}
+	sort.Slice(s.StatusCodes, func(i, j int) bool {
+		a, b := s.StatusCodes[i], s.StatusCodes[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.StatusCode < b.StatusCode
+	})
+	s.Titles = make([]titleCount, 0, len(titles))
+	for title, count := range titles {
+		s.Titles = append(s.Titles, titleCount{Title: title, Count: count})
+	}
+	sort.Slice(s.Titles, func(i, j int) bool {
+		a, b := s.Titles[i], s.Titles[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Title < b.Title
+	})
+	return s
+}
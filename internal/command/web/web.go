@@ -0,0 +1,307 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+const (
+	cmdName = "web"
+
+	defaultPageSize = 100
+	defaultMaxPages = 1
+	// maxTitles is the number of most common titles in the short output.
+	maxTitles = 10
+)
+
+type Command struct {
+	*command.BaseCommand
+	// services the command uses
+	searchSvc search.Service
+	// flags the command uses
+	flags webCommandFlags
+	// state - populated by PreRun
+	pattern  string
+	query    string
+	orgID    mo.Option[identifiers.OrganizationID]
+	pageSize mo.Option[uint64]
+	maxPages mo.Option[uint64]
+	idsSep   mo.Option[byte]
+	// result - populated by Run
+	summary *summary
+}
+
+type webCommandFlags struct {
+	orgID    flags.OrgIDFlag
+	port     flags.IntegerFlag
+	pageSize flags.IntegerFlag
+	maxPages flags.IntegerFlag
+	ids      command.IDsFlags
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewWebCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return cmdName + " <hostname-pattern>"
+}
+
+func (c *Command) Short() string {
+	return "Find web properties by hostname pattern and summarize them"
+}
+
+func (c *Command) Long() string {
+	return `Find the web properties whose hostname matches a pattern and summarize their
+endpoints, status codes, and page titles.
+
+A * in the pattern matches any characters, dots included, so *.example.com
+matches every subdomain of example.com at any depth, but not example.com
+itself. A pattern without a * matches the hostname exactly. The query that is
+run is printed to stderr, so that it can be refined and run with ` + "`censys search`" + `.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"'*.example.com'",
+		"'api-*.example.com' --port 443",
+		"'*.example.com' --max-pages -1 -O json | jq '.status_codes'",
+	}
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(1)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort, command.OutputTypeData}
+}
+
+func (c *Command) Init() error {
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.port = flags.NewIntegerFlag(c.Flags(), false, "port", "", mo.None[int64](),
+		"only find web properties on this port", mo.Some[int64](1), mo.Some[int64](65535))
+	defaultPS := int64(defaultPageSize)
+	if v := c.Config().Search.PageSize; v > 0 {
+		defaultPS = v
+	}
+	defaultMP := int64(defaultMaxPages)
+	if v := c.Config().Search.MaxPages; v != 0 {
+		defaultMP = v
+	}
+	c.flags.pageSize = flags.NewIntegerFlag(c.Flags(), false, "page-size", "n", mo.Some(defaultPS),
+		"number of results to return per page", mo.Some[int64](1), mo.None[int64]())
+	c.flags.maxPages = flags.NewIntegerFlag(c.Flags(), false, "max-pages", "p", mo.Some(defaultMP),
+		"maximum number of pages to fetch (-1 for all pages)", mo.None[int64](), mo.None[int64]())
+	c.flags.ids = command.NewIDsFlags(c.Flags())
+	return nil
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.orgID, err = c.flags.orgID.Value(); err != nil {
+		return err
+	}
+	port, err := c.flags.port.Value()
+	if err != nil {
+		return err
+	}
+	query, pattern, queryErr := hostnameQuery(args[0], port)
+	if queryErr != nil {
+		return cenclierrors.NewUsageError(queryErr)
+	}
+	c.query, c.pattern = query, pattern
+	if err := c.parsePaginationFlags(); err != nil {
+		return err
+	}
+	if c.idsSep, err = c.flags.ids.Value(cmd, c.Config().Streaming); err != nil {
+		return err
+	}
+
+	svc, err := c.SearchService()
+	if err != nil {
+		return err
+	}
+	c.searchSvc = svc
+	return nil
+}
+
+// parsePaginationFlags parses --page-size and --max-pages.
+func (c *Command) parsePaginationFlags() cenclierrors.CencliError {
+	pageSize, err := c.flags.pageSize.Value()
+	if err != nil {
+		return err
+	}
+	if pageSize.IsPresent() {
+		c.pageSize = mo.Some(uint64(pageSize.MustGet()))
+	}
+	maxPages, err := c.flags.maxPages.Value()
+	if err != nil {
+		return err
+	}
+	if maxPages.IsPresent() {
+		switch v := maxPages.MustGet(); {
+		case v == -1:
+			c.maxPages = mo.None[uint64]()
+		case v <= 0:
+			return flags.NewIntegerFlagInvalidValueError("max-pages", v, "must be -1 or >= 1")
+		default:
+			c.maxPages = mo.Some(uint64(v))
+		}
+	}
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(cmdName).With(
+		"pattern", c.pattern,
+		"orgID_set", c.orgID.IsPresent(),
+		"query", c.query,
+	)
+	if !c.Config().Quiet {
+		formatter.Printf(formatter.Stderr, "%s %s\n", styles.GlobalStyles.Comment.Render("Query:"), c.query)
+	}
+
+	var result search.Result
+	err := c.WithProgress(
+		cmd.Context(),
+		logger,
+		fmt.Sprintf("Finding web properties matching %s...", c.pattern),
+		func(pctx context.Context) cenclierrors.CencliError {
+			var fetchErr cenclierrors.CencliError
+			result, fetchErr = c.searchSvc.Search(pctx, search.Params{
+				OrgID:    c.orgID,
+				Query:    c.query,
+				PageSize: c.pageSize,
+				MaxPages: c.maxPages,
+			})
+			return fetchErr
+		},
+	)
+	if err != nil {
+		logger.Debug("fetch failed", "error", err)
+		return err
+	}
+
+	c.summary = summarize(c.pattern, c.query, result)
+	c.PrintAppResponseMeta(result.Meta)
+	if sep, ok := c.idsSep.Get(); ok {
+		err = command.PrintIDs(result.Hits, sep)
+	} else {
+		err = c.PrintData(c, c.summary)
+	}
+	if err != nil {
+		return err
+	}
+	if result.PartialError != nil {
+		c.ReportPartialError(result.PartialError, cmd)
+	}
+	return nil
+}
+
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	s := c.summary
+	if len(s.WebProperties) == 0 {
+		formatter.Println(formatter.Stdout, styles.GlobalStyles.Comment.Render(fmt.Sprintf("No web properties match %s.", s.Pattern)))
+		return nil
+	}
+	tbl := rawtable.New(
+		[]rawtable.Column[propertySummary]{
+			{
+				Title:      "Web Property",
+				String:     func(p propertySummary) string { return fmt.Sprintf("%s:%d", p.Hostname, p.Port) },
+				Style:      func(s string, _ propertySummary) string { return styles.GlobalStyles.Signature.Render(s) },
+				Priority:   4,
+				NoTruncate: true,
+			},
+			{
+				Title:    "Endpoints",
+				String:   func(p propertySummary) string { return strconv.Itoa(len(p.Endpoints)) },
+				Priority: 2,
+			},
+			{
+				Title:    "Status",
+				String:   statusCodes,
+				Style:    func(s string, _ propertySummary) string { return styles.GlobalStyles.Primary.Render(s) },
+				Priority: 3,
+			},
+			{
+				Title:    "Title",
+				String:   firstTitle,
+				Priority: 1,
+			},
+		},
+		rawtable.WithHeaderStyle[propertySummary](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[propertySummary](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[propertySummary](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, tbl.Render(s.WebProperties))
+
+	codes := make([]string, len(s.StatusCodes))
+	for i, sc := range s.StatusCodes {
+		codes[i] = fmt.Sprintf("%d (%d)", sc.StatusCode, sc.Count)
+	}
+	if len(codes) > 0 {
+		formatter.Printf(formatter.Stdout, "\n%s %s\n", styles.GlobalStyles.Comment.Render("Status codes:"), strings.Join(codes, ", "))
+	}
+	if len(s.Titles) > 0 {
+		formatter.Printf(formatter.Stdout, "\n%s\n", styles.GlobalStyles.Comment.Render("Most common titles:"))
+		for _, t := range s.Titles[:min(len(s.Titles), maxTitles)] {
+			formatter.Printf(formatter.Stdout, "  %4d  %s\n", t.Count, t.Title)
+		}
+	}
+	if int64(len(s.WebProperties)) < s.TotalHits {
+		formatter.Printf(formatter.Stdout, "\n%s\n", styles.GlobalStyles.Comment.Render(fmt.Sprintf(
+			"Showing %d of %s web properties; use --max-pages -1 to fetch all of them.", len(s.WebProperties), formatter.FormatInt(s.TotalHits))))
+	}
+	return nil
+}
+
+// statusCodes lists the distinct status codes of the endpoints of p.
+func statusCodes(p propertySummary) string {
+	var codes []string
+	for _, e := range p.Endpoints {
+		if e.StatusCode == 0 {
+			continue
+		}
+		code := strconv.Itoa(e.StatusCode)
+		if !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return "-"
+	}
+	return strings.Join(codes, ", ")
+}
+
+// firstTitle returns the first title of the endpoints of p.
+func firstTitle(p propertySummary) string {
+	for _, e := range p.Endpoints {
+		if e.Title != "" {
+			return e.Title
+		}
+	}
+	return "-"
+}
@@ -0,0 +1,179 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	searchmocks "github.com/censys/cencli/gen/app/search/mocks"
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+// runWeb runs the web command with args and returns its stdout, stderr, and error.
+func runWeb(t *testing.T, svc search.Service, args ...string) (string, string, error) {
+	t.Helper()
+	viper.Reset()
+	cfg, err := config.New(t.TempDir())
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	formatter.Stdout = &stdout
+	formatter.Stderr = &stderr
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	if svc == nil {
+		svc = searchmocks.NewMockSearchService(ctrl)
+	}
+	cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithSearchService(svc))
+	rootCmd, err := command.RootCommandToCobra(NewWebCommand(cmdContext))
+	require.NoError(t, err)
+
+	rootCmd.SetArgs(args)
+	cmdErr := rootCmd.Execute()
+	return stdout.String(), stderr.String(), cmdErr
+}
+
+func TestHostnameQuery(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		port    mo.Option[int64]
+		query   string
+		err     string
+	}{
+		{pattern: "Example.com.", query: `web.hostname: "example.com"`},
+		{pattern: "*.example.com", query: `web.hostname =~ "^.*[.]example[.]com$"`},
+		{pattern: "api-*.example[.]com", port: mo.Some[int64](8443), query: `web.hostname =~ "^api-.*[.]example[.]com$" and web.port: 8443`},
+		{pattern: "a.*.example.co.uk", query: `web.hostname =~ "^a[.].*[.]example[.]co[.]uk$"`},
+		{pattern: "", err: "empty"},
+		{pattern: `example.com" or x`, err: "invalid character"},
+		{pattern: "*.com", err: "too broad"},
+		{pattern: "example.*", err: "too broad"},
+		{pattern: "localhost", err: "too broad"},
+		{pattern: "a..example.com", err: "empty label"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			query, _, err := hostnameQuery(tc.pattern, tc.port)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.query, query)
+		})
+	}
+}
+
+func TestWeb(t *testing.T) {
+	result := search.Result{
+		TotalHits: 3,
+		Hits: []assets.Asset{
+			&assets.WebProperty{Webproperty: components.Webproperty{
+				Hostname: strPtr("api.example.com"),
+				Port:     intPtr(443),
+				Endpoints: []components.EndpointScanState{
+					{Path: strPtr("/"), HTTP: &components.HTTP{StatusCode: intPtr(200), HTMLTitle: strPtr(" Login ")}},
+					{Path: strPtr("/admin"), HTTP: &components.HTTP{StatusCode: intPtr(403)}},
+				},
+			}},
+			&assets.WebProperty{Webproperty: components.Webproperty{
+				Hostname: strPtr("www.example.com"),
+				Port:     intPtr(80),
+				Endpoints: []components.EndpointScanState{
+					{Path: strPtr("/"), HTTP: &components.HTTP{StatusCode: intPtr(200), HTMLTitle: strPtr("Login")}},
+				},
+			}},
+		},
+	}
+	searchReturning := func(t *testing.T, query string) func(ctrl *gomock.Controller) search.Service {
+		return func(ctrl *gomock.Controller) search.Service {
+			mockSvc := searchmocks.NewMockSearchService(ctrl)
+			mockSvc.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, params search.Params) (search.Result, cenclierrors.CencliError) {
+					require.Equal(t, query, params.Query)
+					return result, nil
+				})
+			return mockSvc
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		args    []string
+		service func(t *testing.T) func(ctrl *gomock.Controller) search.Service
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name: "summary",
+			args: []string{"*.example.com", "-O", "json"},
+			service: func(t *testing.T) func(ctrl *gomock.Controller) search.Service {
+				return searchReturning(t, `web.hostname =~ "^.*[.]example[.]com$"`)
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stderr, `Query: web.hostname =~`)
+				var s summary
+				require.NoError(t, json.Unmarshal([]byte(stdout), &s))
+				require.Equal(t, "*.example.com", s.Pattern)
+				require.Equal(t, int64(3), s.TotalHits)
+				require.Len(t, s.WebProperties, 2)
+				require.Equal(t, []endpointSummary{
+					{Path: "/", StatusCode: 200, Title: "Login"},
+					{Path: "/admin", StatusCode: 403},
+				}, s.WebProperties[0].Endpoints)
+				require.Equal(t, []statusCodeCount{{StatusCode: 200, Count: 2}, {StatusCode: 403, Count: 1}}, s.StatusCodes)
+				require.Equal(t, []titleCount{{Title: "Login", Count: 2}}, s.Titles)
+			},
+		},
+		{
+			name: "short",
+			args: []string{"*.example.com", "--port", "443"},
+			service: func(t *testing.T) func(ctrl *gomock.Controller) search.Service {
+				return searchReturning(t, `web.hostname =~ "^.*[.]example[.]com$" and web.port: 443`)
+			},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "api.example.com:443")
+				require.Contains(t, stdout, "200, 403")
+				require.Contains(t, stdout, "Status codes: 200 (2), 403 (1)")
+				require.Contains(t, stdout, "Showing 2 of 3 web properties")
+			},
+		},
+		{
+			name: "invalid pattern",
+			args: []string{"*.com"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "too broad")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var svc search.Service
+			if tc.service != nil {
+				svc = tc.service(t)(gomock.NewController(t))
+			}
+			stdout, stderr, err := runWeb(t, svc, tc.args...)
+			tc.assert(t, stdout, stderr, err)
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func intPtr(i int) *int { return &i }
@@ -0,0 +1,264 @@
+package webprops
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/ui/rawtable"
+)
+
+const endpointsCmdName = "webprops endpoints"
+
+// endpointsCommand implements `webprops endpoints`, which lists the endpoints
+// of web properties as flat rows.
+type endpointsCommand struct {
+	*command.BaseCommand
+	// services the command uses
+	viewSvc view.Service
+	// flags the command uses
+	flags endpointsCommandFlags
+	// state - populated by PreRun
+	webPropertyIDs []assets.WebPropertyID
+	orgID          mo.Option[identifiers.OrganizationID]
+	atTime         mo.Option[time.Time]
+	filter         endpointFilter
+	// result stored for rendering
+	meta         *responsemeta.ResponseMeta
+	endpoints    []endpoint
+	partialError cenclierrors.CencliError
+}
+
+type endpointsCommandFlags struct {
+	orgID        flags.OrgIDFlag
+	inputFile    flags.FileFlag
+	atTime       flags.TimestampFlag
+	status       flags.StringSliceFlag
+	pathContains flags.StringFlag
+}
+
+var _ command.Command = (*endpointsCommand)(nil)
+
+func newEndpointsCommand(cmdContext *command.Context) *endpointsCommand {
+	return &endpointsCommand{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *endpointsCommand) Use() string { return "endpoints <hostname:port...>" }
+
+func (c *endpointsCommand) Short() string {
+	return "List the HTTP endpoints of web properties"
+}
+
+func (c *endpointsCommand) Long() string {
+	return `List the HTTP endpoints of web properties, one row per endpoint, with its path,
+status code, page title, body SHA-256, and the technologies seen on it.
+
+Web properties are given as hostname:port, comma-separated or one per line with
+--input-file; the port defaults to 443. Use --status and --path-contains to keep
+only some of the endpoints, such as the admin pages that answer 200.
+
+Use --output-format json for structured output, or --streaming for one JSON object
+per endpoint.`
+}
+
+func (c *endpointsCommand) Examples() []string {
+	return []string{
+		"platform.censys.io:443",
+		"platform.censys.io:443,censys.com:443 --status 200 --path-contains admin",
+		"--input-file webprops.txt --status 4xx,5xx",
+		"--input-file webprops.txt -S | jq -r '.body_hash_sha256' | sort | uniq -c",
+	}
+}
+
+func (c *endpointsCommand) Args() command.PositionalArgs { return command.RangeArgs(0, 1) }
+
+func (c *endpointsCommand) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *endpointsCommand) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeData, command.OutputTypeShort}
+}
+
+func (c *endpointsCommand) SupportsStreaming() bool {
+	return true
+}
+
+func (c *endpointsCommand) Init() error {
+	c.flags.inputFile = flags.NewFileFlag(c.Flags(), false, "input-file", "i", "file to read the web properties from, one per line as plain text or JSON objects with an \"asset\" field. Overrides the positional argument.")
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.atTime = flags.NewTimestampFlag(c.Flags(), false, "at-time", "", mo.None[time.Time](), "list the endpoints as of this time")
+	c.flags.atTime.AddAlias("at", "a", "Alias for --at-time")
+	c.flags.status = flags.NewStringSliceFlag(c.Flags(), false, "status", "", []string{},
+		"only list endpoints with these status codes (e.g. 200) or classes (e.g. 4xx)")
+	c.flags.pathContains = flags.NewStringFlag(c.Flags(), false, "path-contains", "", "",
+		"only list endpoints whose path contains this text, ignoring case")
+	return nil
+}
+
+func (c *endpointsCommand) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	c.atTime, err = c.flags.atTime.Value(c.Config().DefaultTZ, c.Now())
+	if err != nil {
+		return err
+	}
+	c.orgID, err = c.flags.orgID.Value()
+	if err != nil {
+		return err
+	}
+	statuses, err := c.flags.status.Value()
+	if err != nil {
+		return err
+	}
+	pathContains, err := c.flags.pathContains.Value()
+	if err != nil {
+		return err
+	}
+	if c.filter, err = newEndpointFilter(statuses, pathContains); err != nil {
+		return err
+	}
+
+	rawAssets, err := command.RawAssets(cmd, c.flags.inputFile, args)
+	if err != nil {
+		return err
+	}
+	classifier := assets.NewAssetClassifier(rawAssets...)
+	assetType, err := classifier.AssetType()
+	if err != nil {
+		return err
+	}
+	if assetType != assets.AssetTypeWebProperty {
+		return newNotWebPropertyError(assetType)
+	}
+	c.webPropertyIDs = classifier.WebPropertyIDs()
+
+	c.viewSvc, err = c.ViewService()
+	return err
+}
+
+func (c *endpointsCommand) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(endpointsCmdName).With(
+		"orgID_set", c.orgID.IsPresent(),
+		"count", len(c.webPropertyIDs),
+		"statuses", c.filter.statuses,
+		"path_contains", c.filter.pathContains,
+	)
+
+	// Set up streaming output (no-op for non-streaming formats)
+	ctx, stopStreaming := c.WithStreamingOutput(cmd.Context(), logger)
+	defer stopStreaming(nil)
+	ctx = withEndpointStreaming(ctx, c.filter)
+
+	err := c.WithProgress(
+		ctx,
+		logger,
+		"Fetching web properties...",
+		func(pctx context.Context) cenclierrors.CencliError {
+			result, fetchErr := c.viewSvc.GetWebProperties(pctx, c.orgID, c.webPropertyIDs, c.atTime)
+			if fetchErr != nil {
+				return fetchErr
+			}
+			c.meta = result.Meta
+			c.partialError = result.PartialError
+			c.endpoints = []endpoint{}
+			for _, wp := range result.WebProperties {
+				c.endpoints = append(c.endpoints, extractEndpoints(wp, c.filter)...)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		logger.Debug("fetch failed", "error", err)
+		return err
+	}
+
+	c.PrintAppResponseMeta(c.meta)
+
+	if renderErr := c.PrintData(c, c.endpoints); renderErr != nil {
+		return renderErr
+	}
+
+	if c.partialError != nil {
+		formatter.PrintError(c.partialError, cmd)
+	}
+	return nil
+}
+
+func (c *endpointsCommand) RenderShort() cenclierrors.CencliError {
+	if len(c.endpoints) == 0 {
+		formatter.Println(formatter.Stdout, styles.GlobalStyles.Comment.Render("No endpoints match."))
+		return nil
+	}
+	tbl := rawtable.New(
+		[]rawtable.Column[endpoint]{
+			{
+				Title:      "Web Property",
+				String:     endpoint.webProperty,
+				Style:      func(s string, _ endpoint) string { return styles.GlobalStyles.Signature.Render(s) },
+				Priority:   6,
+				NoTruncate: true,
+			},
+			{
+				Title:      "Path",
+				String:     func(e endpoint) string { return e.Path },
+				Priority:   5,
+				NoTruncate: true,
+			},
+			{
+				Title:    "Status",
+				String:   func(e endpoint) string { return orDash(statusText(e.StatusCode)) },
+				Style:    func(s string, _ endpoint) string { return styles.GlobalStyles.Primary.Render(s) },
+				Priority: 4,
+			},
+			{
+				Title:    "Title",
+				String:   func(e endpoint) string { return orDash(e.Title) },
+				Priority: 3,
+			},
+			{
+				Title:    "Body SHA-256",
+				String:   func(e endpoint) string { return orDash(e.BodyHashSha256) },
+				Style:    func(s string, _ endpoint) string { return styles.GlobalStyles.Comment.Render(s) },
+				Priority: 1,
+			},
+			{
+				Title:    "Tech",
+				String:   func(e endpoint) string { return orDash(strings.Join(e.Tech, ", ")) },
+				Priority: 2,
+			},
+		},
+		rawtable.WithHeaderStyle[endpoint](styles.NewStyle(styles.ColorOffWhite).Bold(true)),
+		rawtable.WithStylesDisabled[endpoint](!formatter.StdoutIsTTY()),
+		rawtable.WithMaxWidth[endpoint](formatter.TableWidth()),
+	)
+	fmt.Fprint(formatter.Stdout, tbl.Render(c.endpoints))
+	return nil
+}
+
+func statusText(code int) string {
+	if code == 0 {
+		return ""
+	}
+	return strconv.Itoa(code)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,203 @@
+package webprops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	viewmocks "github.com/censys/cencli/gen/app/view/mocks"
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func testWebProperty() *assets.WebProperty {
+	return &assets.WebProperty{Webproperty: components.Webproperty{
+		Hostname: ptr("example.com"),
+		Port:     ptr(443),
+		Software: []components.Attribute{{Vendor: ptr("nginx"), Product: ptr("nginx"), Version: ptr("1.25.3")}},
+		Endpoints: []components.EndpointScanState{
+			{
+				Path:         ptr("/"),
+				EndpointType: ptr("HTTP"),
+				HTTP: &components.HTTP{
+					StatusCode:     ptr(200),
+					HTMLTitle:      ptr(" Example \n"),
+					BodyHashSha256: ptr("aaa111"),
+				},
+			},
+			{
+				Path:         ptr("/admin/login"),
+				EndpointType: ptr("HTTP"),
+				HTTP: &components.HTTP{
+					StatusCode:     ptr(200),
+					HTMLTitle:      ptr("Jenkins"),
+					BodyHashSha256: ptr("bbb222"),
+				},
+				Jenkins: &components.Jenkins{},
+			},
+			{
+				Path:         ptr("/Admin/debug"),
+				EndpointType: ptr("HTTP"),
+				HTTP:         &components.HTTP{StatusCode: ptr(403)},
+			},
+		},
+	}}
+}
+
+func TestEndpointsCommand(t *testing.T) {
+	testCases := []struct {
+		name    string
+		service func(ctrl *gomock.Controller) view.Service
+		args    []string
+		assert  func(t *testing.T, stdout string, err error)
+	}{
+		{
+			name: "json output",
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				wpID, _ := assets.NewWebPropertyID("example.com:443", assets.DefaultWebPropertyPort)
+				ms.EXPECT().GetWebProperties(gomock.Any(), mo.None[identifiers.OrganizationID](), []assets.WebPropertyID{wpID}, mo.None[time.Time]()).
+					Return(view.WebPropertiesResult{WebProperties: []*assets.WebProperty{testWebProperty()}}, nil)
+				return ms
+			},
+			args: []string{"example.com:443", "-O", "json"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				var out []endpoint
+				require.NoError(t, json.Unmarshal([]byte(stdout), &out))
+				require.Len(t, out, 3)
+				require.Equal(t, endpoint{
+					Hostname:       "example.com",
+					Port:           443,
+					Path:           "/",
+					EndpointType:   "HTTP",
+					StatusCode:     200,
+					Title:          "Example",
+					BodyHashSha256: "aaa111",
+					Tech:           []string{"nginx nginx 1.25.3"},
+				}, out[0])
+				require.Equal(t, []string{"nginx nginx 1.25.3", "jenkins"}, out[1].Tech)
+			},
+		},
+		{
+			name: "filters by status and path",
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				ms.EXPECT().GetWebProperties(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(view.WebPropertiesResult{WebProperties: []*assets.WebProperty{testWebProperty()}}, nil)
+				return ms
+			},
+			args: []string{"example.com", "--status", "2xx", "--path-contains", "ADMIN"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "example.com:443")
+				require.Contains(t, stdout, "/admin/login")
+				require.Contains(t, stdout, "jenkins")
+				require.NotContains(t, stdout, "/Admin/debug")
+				require.NotContains(t, stdout, "aaa111")
+			},
+		},
+		{
+			name: "no matching endpoints",
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				ms.EXPECT().GetWebProperties(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(view.WebPropertiesResult{WebProperties: []*assets.WebProperty{testWebProperty()}}, nil)
+				return ms
+			},
+			args: []string{"example.com", "--status", "500"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "No endpoints match.\n", stdout)
+			},
+		},
+		{
+			name: "streams one object per matching endpoint",
+			service: func(ctrl *gomock.Controller) view.Service {
+				ms := viewmocks.NewMockViewService(ctrl)
+				ms.EXPECT().GetWebProperties(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, _ mo.Option[identifiers.OrganizationID], _ []assets.WebPropertyID, _ mo.Option[time.Time]) (view.WebPropertiesResult, cenclierrors.CencliError) {
+						require.NoError(t, streaming.Emit(ctx, testWebProperty()))
+						return view.WebPropertiesResult{}, nil
+					})
+				return ms
+			},
+			args: []string{"example.com", "--status", "200,403", "--streaming"},
+			assert: func(t *testing.T, stdout string, err error) {
+				require.NoError(t, err)
+				lines := strings.Split(strings.TrimSpace(stdout), "\n")
+				require.Len(t, lines, 3)
+				var last endpoint
+				require.NoError(t, json.Unmarshal([]byte(lines[2]), &last))
+				require.Equal(t, "/Admin/debug", last.Path)
+				require.Equal(t, 403, last.StatusCode)
+			},
+		},
+		{
+			name: "rejects invalid status",
+			service: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			args: []string{"example.com", "--status", "20x"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var invalid InvalidStatusError
+				require.ErrorAs(t, err, &invalid)
+			},
+		},
+		{
+			name: "rejects non-web-property assets",
+			service: func(ctrl *gomock.Controller) view.Service {
+				return viewmocks.NewMockViewService(ctrl)
+			},
+			args: []string{"8.8.8.8"},
+			assert: func(t *testing.T, stdout string, err error) {
+				var notWebProperty NotWebPropertyError
+				require.ErrorAs(t, err, &notWebProperty)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			ctrl := gomock.NewController(t)
+			cmdContext := command.NewCommandContext(cfg, storemocks.NewMockStore(ctrl), command.WithViewService(tc.service(ctrl)))
+			rootCmd, err := command.RootCommandToCobra(newEndpointsCommand(cmdContext))
+			require.NoError(t, err)
+			// endpoints defines its own --output-format, so only bind the streaming global flag
+			rootCmd.PersistentFlags().BoolP(config.StreamingFlagName, "S", false, "")
+			require.NoError(t, viper.BindPFlag(config.StreamingFlagName, rootCmd.PersistentFlags().Lookup(config.StreamingFlagName)))
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), cmdErr)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,54 @@
+package webprops
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// NotWebPropertyError is returned when the assets are not web properties.
+type NotWebPropertyError interface {
+	cenclierrors.CencliError
+}
+
+type notWebPropertyError struct {
+	assetType assets.AssetType
+}
+
+var _ NotWebPropertyError = &notWebPropertyError{}
+
+func newNotWebPropertyError(assetType assets.AssetType) NotWebPropertyError {
+	return &notWebPropertyError{assetType: assetType}
+}
+
+func (e *notWebPropertyError) Error() string {
+	return fmt.Sprintf("webprops endpoints only supports web properties (hostname:port), got %s assets", e.assetType)
+}
+
+func (e *notWebPropertyError) Title() string { return "Unsupported Asset Type" }
+
+func (e *notWebPropertyError) ShouldPrintUsage() bool { return true }
+
+// InvalidStatusError is returned when a --status value is not a status code or class.
+type InvalidStatusError interface {
+	cenclierrors.CencliError
+}
+
+type invalidStatusError struct {
+	value string
+}
+
+var _ InvalidStatusError = &invalidStatusError{}
+
+func newInvalidStatusError(value string) InvalidStatusError {
+	return &invalidStatusError{value: value}
+}
+
+func (e *invalidStatusError) Error() string {
+	return fmt.Sprintf("invalid --status value %q: use a status code (200) or a class of status codes (4xx)", e.value)
+}
+
+func (e *invalidStatusError) Title() string { return "Invalid Status" }
+
+func (e *invalidStatusError) ShouldPrintUsage() bool { return true }
@@ -0,0 +1,227 @@
+package webprops
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/app/streaming"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// endpoint is a single endpoint of a web property, flattened out of the
+// web property document.
+type endpoint struct {
+	Hostname       string `json:"hostname"`
+	Port           int    `json:"port"`
+	Path           string `json:"path"`
+	EndpointType   string `json:"endpoint_type,omitempty"`
+	StatusCode     int    `json:"status_code,omitempty"`
+	Title          string `json:"title,omitempty"`
+	BodyHashSha256 string `json:"body_hash_sha256,omitempty"`
+	BodySize       int    `json:"body_size,omitempty"`
+	// Tech is the software of the web property and the applications that
+	// were recognized on the endpoint, such as jenkins or wordpress.
+	Tech     []string `json:"tech,omitempty"`
+	ScanTime string   `json:"scan_time,omitempty"`
+}
+
+// webProperty returns the hostname:port of the web property of the endpoint.
+func (e endpoint) webProperty() string {
+	return assets.WebPropertyID{Hostname: e.Hostname, Port: e.Port}.String()
+}
+
+// genericEndpointFields are the fields of an endpoint that every endpoint may
+// have. Any other field that is set holds the data of an application that
+// was recognized on the endpoint, and is named after it.
+var genericEndpointFields = map[string]bool{
+	"banner":             true,
+	"banner_hash_sha256": true,
+	"endpoint_type":      true,
+	"extracted":          true,
+	"hostname":           true,
+	"http":               true,
+	"ip":                 true,
+	"path":               true,
+	"port":               true,
+	"scan_time":          true,
+	"screenshots":        true,
+	"transport_protocol": true,
+}
+
+// endpointFilter keeps the endpoints that match --status and --path-contains.
+// The zero value keeps every endpoint.
+type endpointFilter struct {
+	// statuses are status codes, or classes such as "4xx"; any of them matches.
+	statuses     []string
+	pathContains string
+}
+
+// newEndpointFilter parses the values of --status and --path-contains.
+func newEndpointFilter(statuses []string, pathContains string) (endpointFilter, cenclierrors.CencliError) {
+	f := endpointFilter{pathContains: strings.ToLower(pathContains)}
+	for _, s := range statuses {
+		if s == "" {
+			continue
+		}
+		s = strings.ToLower(s)
+		if !validStatus(s) {
+			return endpointFilter{}, newInvalidStatusError(s)
+		}
+		f.statuses = append(f.statuses, s)
+	}
+	return f, nil
+}
+
+// validStatus reports whether s is a status code, such as 200, or a class
+// of status codes, such as 4xx.
+func validStatus(s string) bool {
+	if len(s) != 3 || s[0] < '1' || s[0] > '5' {
+		return false
+	}
+	if s[1:] == "xx" {
+		return true
+	}
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func (f endpointFilter) match(e endpoint) bool {
+	if f.pathContains != "" && !strings.Contains(strings.ToLower(e.Path), f.pathContains) {
+		return false
+	}
+	if len(f.statuses) == 0 {
+		return true
+	}
+	if e.StatusCode == 0 {
+		return false
+	}
+	code := strconv.Itoa(e.StatusCode)
+	for _, s := range f.statuses {
+		if s == code || (strings.HasSuffix(s, "xx") && s[0] == code[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractEndpoints returns the endpoints of wp that match filter.
+func extractEndpoints(wp *assets.WebProperty, filter endpointFilter) []endpoint {
+	if wp == nil {
+		return nil
+	}
+	hostname, port := deref(wp.Hostname), deref(wp.Port)
+	software := softwareNames(wp.Software)
+	var res []endpoint
+	for _, ep := range wp.Endpoints {
+		e := endpoint{
+			Hostname:     hostname,
+			Port:         port,
+			Path:         deref(ep.Path),
+			EndpointType: deref(ep.EndpointType),
+			ScanTime:     deref(ep.ScanTime),
+		}
+		if e.Path == "" {
+			e.Path = "/"
+		}
+		if ep.HTTP != nil {
+			e.StatusCode = deref(ep.HTTP.StatusCode)
+			e.Title = strings.TrimSpace(deref(ep.HTTP.HTMLTitle))
+			e.BodyHashSha256 = deref(ep.HTTP.BodyHashSha256)
+			e.BodySize = deref(ep.HTTP.BodySize)
+		}
+		if !filter.match(e) {
+			continue
+		}
+		e.Tech = append(append([]string(nil), software...), recognizedApplications(ep)...)
+		res = append(res, e)
+	}
+	return res
+}
+
+// softwareNames returns "vendor product version" for each software attribute.
+func softwareNames(attrs []components.Attribute) []string {
+	var names []string
+	for _, a := range attrs {
+		var parts []string
+		for _, p := range []string{deref(a.Vendor), deref(a.Product), deref(a.Version)} {
+			if p != "" {
+				parts = append(parts, p)
+			}
+		}
+		if len(parts) > 0 {
+			names = append(names, strings.Join(parts, " "))
+		}
+	}
+	return names
+}
+
+// recognizedApplications returns the names of the application fields that
+// are set on ep, sorted. The endpoint is round-tripped through JSON so that
+// applications added to the API are reported without a change here.
+func recognizedApplications(ep components.EndpointScanState) []string {
+	raw, err := json.Marshal(ep)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+	var apps []string
+	for name, value := range fields {
+		if genericEndpointFields[name] || string(value) == "null" {
+			continue
+		}
+		apps = append(apps, name)
+	}
+	sort.Strings(apps)
+	return apps
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// endpointEmitter streams the matching endpoints of each streamed web
+// property instead of the web property.
+type endpointEmitter struct {
+	inner  streaming.Emitter
+	filter endpointFilter
+}
+
+func (e *endpointEmitter) Emit(ctx context.Context, data any) error {
+	wp, ok := data.(*assets.WebProperty)
+	if !ok {
+		return nil
+	}
+	for _, ep := range extractEndpoints(wp, e.filter) {
+		if err := e.inner.Emit(ctx, ep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *endpointEmitter) Close(err error) {
+	e.inner.Close(err)
+}
+
+// withEndpointStreaming wraps the streaming emitter in ctx (if any) so that
+// one item is streamed per matching endpoint.
+func withEndpointStreaming(ctx context.Context, filter endpointFilter) context.Context {
+	emitter, ok := streaming.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return streaming.WithEmitter(ctx, &endpointEmitter{inner: emitter, filter: filter})
+}
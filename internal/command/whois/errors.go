@@ -0,0 +1,54 @@
+package whois
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+type InvalidTargetError interface {
+	cenclierrors.CencliError
+}
+
+type invalidTargetError struct {
+	raw string
+}
+
+var _ InvalidTargetError = &invalidTargetError{}
+
+func newInvalidTargetError(raw string) InvalidTargetError {
+	return &invalidTargetError{raw: raw}
+}
+
+func (e *invalidTargetError) Error() string {
+	return fmt.Sprintf("%q is not an IP or a domain", e.raw)
+}
+
+func (e *invalidTargetError) Title() string { return "Invalid Target" }
+
+func (e *invalidTargetError) ShouldPrintUsage() bool { return true }
+
+type LookupError interface {
+	cenclierrors.CencliError
+}
+
+type lookupError struct {
+	query string
+	err   error
+}
+
+var _ LookupError = &lookupError{}
+
+func newLookupError(query string, err error) LookupError {
+	return &lookupError{query: query, err: err}
+}
+
+func (e *lookupError) Error() string {
+	return fmt.Sprintf("failed to look up the registration of %s: %v", e.query, e.err)
+}
+
+func (e *lookupError) Title() string { return "Registration Lookup Failed" }
+
+func (e *lookupError) ShouldPrintUsage() bool { return false }
+
+func (e *lookupError) Unwrap() error { return e.err }
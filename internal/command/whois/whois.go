@@ -0,0 +1,255 @@
+package whois
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/spf13/cobra"
+
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/formatter/short"
+	"github.com/censys/cencli/internal/pkg/rdap"
+	"github.com/censys/cencli/internal/pkg/refang"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/whois"
+)
+
+const cmdName = "whois"
+
+// Values of --source.
+const (
+	sourceAuto   = "auto"
+	sourceCensys = whois.SourceCensys
+	sourceRDAP   = whois.SourceRDAP
+)
+
+type Command struct {
+	*command.BaseCommand
+	// services the command uses
+	viewSvc view.Service
+	// flags the command uses
+	flags whoisCommandFlags
+	// state - populated by PreRun
+	query  string
+	hostID mo.Option[assets.HostID]
+	source string
+	orgID  mo.Option[identifiers.OrganizationID]
+	// result - populated by Run
+	meta    *responsemeta.ResponseMeta
+	summary *whois.Summary
+}
+
+type whoisCommandFlags struct {
+	orgID  flags.OrgIDFlag
+	source flags.StringFlag
+}
+
+var _ command.Command = (*Command)(nil)
+
+func NewWhoisCommand(cmdContext *command.Context) *Command {
+	return &Command{BaseCommand: command.NewBaseCommand(cmdContext)}
+}
+
+func (c *Command) Use() string {
+	return cmdName + " <ip|domain>"
+}
+
+func (c *Command) Short() string {
+	return "Summarize the registration of an IP or a domain"
+}
+
+func (c *Command) Long() string {
+	return `Summarize the registration of an IP or a domain: autonomous system, BGP prefix,
+network, organization, registration dates, and abuse contacts.
+
+For an IP, the summary comes from the Censys host document, and RDAP fills in
+what it lacks when the host has no whois record. Domains are looked up with
+RDAP. Use --source to use only one of them. Supports defanged IPs and domains.`
+}
+
+func (c *Command) Examples() []string {
+	return []string{
+		"8.8.8.8",
+		"censys.com",
+		"8.8.8[.]8 --source rdap",
+		"1.1.1.1 -O json | jq -r '.abuse_contacts[].email'",
+	}
+}
+
+func (c *Command) Init() error {
+	c.flags.orgID = flags.NewOrgIDFlag(c.Flags(), "")
+	c.flags.source = flags.NewStringFlag(c.Flags(), false, "source", "", sourceAuto,
+		fmt.Sprintf("where to look the registration up: %s (Censys, then RDAP for what is missing), %s, or %s", sourceAuto, sourceCensys, sourceRDAP))
+	return nil
+}
+
+func (c *Command) Args() command.PositionalArgs {
+	return command.ExactArgs(1)
+}
+
+func (c *Command) DefaultOutputType() command.OutputType {
+	return command.OutputTypeShort
+}
+
+func (c *Command) SupportedOutputTypes() []command.OutputType {
+	return []command.OutputType{command.OutputTypeShort, command.OutputTypeData}
+}
+
+func (c *Command) PreRun(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	var err cenclierrors.CencliError
+	if c.orgID, err = c.flags.orgID.Value(); err != nil {
+		return err
+	}
+	source, err := c.flags.source.Value()
+	if err != nil {
+		return err
+	}
+	c.source = strings.ToLower(strings.TrimSpace(source))
+	switch c.source {
+	case sourceAuto, sourceCensys, sourceRDAP:
+	default:
+		return cenclierrors.NewUsageError(fmt.Errorf("unsupported --source %q; use %s, %s, or %s", source, sourceAuto, sourceCensys, sourceRDAP))
+	}
+
+	raw := strings.TrimSpace(args[0])
+	if hostID, hostErr := assets.NewHostID(raw); hostErr == nil {
+		c.query = hostID.String()
+		c.hostID = mo.Some(hostID)
+	} else {
+		domain, ok := normalizeDomain(raw)
+		if !ok {
+			return newInvalidTargetError(raw)
+		}
+		if c.source == sourceCensys {
+			return cenclierrors.NewUsageError(errors.New("--source censys only supports IPs; domains are looked up with RDAP"))
+		}
+		c.query = domain
+	}
+
+	if c.hostID.IsPresent() && c.source != sourceRDAP {
+		svc, svcErr := c.ViewService()
+		if svcErr != nil {
+			return svcErr
+		}
+		c.viewSvc = svc
+	}
+	return nil
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) cenclierrors.CencliError {
+	logger := c.Logger(cmdName).With("query", c.query, "source", c.source)
+	err := c.WithProgress(cmd.Context(), logger, "Looking up registration...", c.lookup)
+	if err != nil {
+		logger.Debug("lookup failed", "error", err)
+		return err
+	}
+	c.PrintAppResponseMeta(c.meta)
+	return c.PrintData(c, c.summary)
+}
+
+// lookup builds the summary from the Censys host document and RDAP, as
+// allowed by --source.
+func (c *Command) lookup(ctx context.Context) cenclierrors.CencliError {
+	hostID, isIP := c.hostID.Get()
+	if !isIP {
+		c.summary = whois.New(c.query, whois.TypeDomain)
+		rec, err := c.rdapClient().Domain(ctx, c.query)
+		if err != nil {
+			return newLookupError(c.query, err)
+		}
+		c.summary.AddRDAP(rec)
+		return nil
+	}
+
+	c.summary = whois.New(c.query, whois.TypeIP)
+	var censysErr cenclierrors.CencliError
+	if c.source != sourceRDAP {
+		res, err := c.viewSvc.GetHosts(ctx, c.orgID, []assets.HostID{hostID}, mo.None[time.Time]())
+		switch {
+		case err != nil:
+			censysErr = err
+		case len(res.Hosts) > 0 && res.Hosts[0] != nil:
+			c.summary.AddHost(res.Hosts[0])
+		}
+		c.meta = res.Meta
+	}
+	if c.source == sourceCensys {
+		return censysErr
+	}
+	if c.source == sourceAuto && censysErr == nil && c.summary.HasRegistration() {
+		return nil
+	}
+
+	rec, err := c.rdapClient().IP(ctx, c.query)
+	if err != nil {
+		if len(c.summary.Sources) > 0 {
+			c.warn(fmt.Sprintf("RDAP lookup failed (%v); showing the Censys data only", err))
+			return nil
+		}
+		return newLookupError(c.query, err)
+	}
+	if censysErr != nil {
+		c.warn(fmt.Sprintf("Censys lookup failed (%v); showing the RDAP data only", censysErr))
+	}
+	c.summary.AddRDAP(rec)
+	return nil
+}
+
+func (c *Command) rdapClient() rdap.Client {
+	return rdap.Client{
+		BaseURL: c.Config().Whois.RDAPURL,
+		HTTP:    &http.Client{Timeout: c.Config().Timeouts.HTTP},
+	}
+}
+
+func (c *Command) warn(msg string) {
+	if !c.Config().Quiet {
+		formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render("Warning: "+msg))
+	}
+}
+
+func (c *Command) RenderShort() cenclierrors.CencliError {
+	formatter.Printf(formatter.Stdout, "%s", short.Whois(c.summary))
+	return nil
+}
+
+// normalizeDomain refangs a domain, and strips the scheme, path, and port of
+// a URL, returning false if what is left does not look like a domain.
+func normalizeDomain(raw string) (string, bool) {
+	s := strings.ToLower(refang.RefangIP(strings.TrimSpace(raw)))
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(strings.Replace(s, "hxxp", "http", 1))
+		if err != nil {
+			return "", false
+		}
+		s = u.Hostname()
+	} else if host, _, found := strings.Cut(s, "/"); found {
+		s = host
+	}
+	if host, port, found := strings.Cut(s, ":"); found && port != "" {
+		s = host
+	}
+	s = strings.TrimSuffix(s, ".")
+	if !strings.Contains(s, ".") || strings.HasPrefix(s, ".") || strings.Contains(s, "..") {
+		return "", false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_') && r < 0x80 {
+			return "", false
+		}
+	}
+	return s, true
+}
@@ -0,0 +1,229 @@
+package whois
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	viewmocks "github.com/censys/cencli/gen/app/view/mocks"
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/command"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/whois"
+	"github.com/censys/cencli/internal/store"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+const ipRecord = `{
+  "objectClassName": "ip network", "handle": "NET-198-51-100-0-1", "name": "EXAMPLE-NET",
+  "type": "ASSIGNMENT", "country": "US", "cidr0_cidrs": [{"v4prefix": "198.51.100.0", "length": 24}],
+  "events": [{"eventAction": "registration", "eventDate": "2010-06-01T00:00:00Z"}],
+  "entities": [
+    {"handle": "EXM", "roles": ["registrant"], "vcardArray": ["vcard", [["fn", {}, "text", "Example Hosting"]]]},
+    {"handle": "ABUSE-EXM", "roles": ["abuse"], "vcardArray": ["vcard", [["fn", {}, "text", "Abuse Desk"], ["email", {}, "text", "abuse@example.net"]]]}
+  ]
+}`
+
+const domainRecord = `{
+  "objectClassName": "domain", "ldhName": "EXAMPLE.ORG", "status": ["client transfer prohibited"],
+  "nameservers": [{"ldhName": "NS1.EXAMPLE.ORG"}, {"ldhName": "NS2.EXAMPLE.ORG"}],
+  "events": [
+    {"eventAction": "registration", "eventDate": "1995-08-31T04:00:00Z"},
+    {"eventAction": "expiration", "eventDate": "2030-08-30T04:00:00Z"}
+  ],
+  "entities": [{
+    "roles": ["registrar"], "vcardArray": ["vcard", [["fn", {}, "text", "Example Registrar, Inc."]]],
+    "entities": [{"roles": ["abuse"], "vcardArray": ["vcard", [["email", {}, "text", "abuse@registrar.example"], ["tel", {}, "uri", "tel:+1.5555550100"]]]}]
+  }]
+}`
+
+func hostWithWhois() *assets.Host {
+	return &assets.Host{Host: components.Host{
+		IP: ptr("198.51.100.7"),
+		AutonomousSystem: &components.Routing{
+			Asn: ptr(64500), Name: ptr("EXAMPLE-AS"), BgpPrefix: ptr("198.51.100.0/22"), CountryCode: ptr("US"),
+		},
+		Whois: &components.Whois{
+			Network: &components.Network{Name: ptr("EXAMPLE-NET"), Handle: ptr("NET-198-51-100-0-1"), Cidrs: []string{"198.51.100.0/24"}, Created: ptr("2010-06-01")},
+			Organization: &components.Organization{
+				Name: ptr("Example Hosting"), Handle: ptr("EXM"), Country: ptr("US"), City: ptr("Springfield"), State: ptr("IL"),
+				AbuseContacts: []components.Contact{{Name: ptr("Abuse Desk"), Email: ptr("abuse@example.net")}},
+			},
+		},
+	}}
+}
+
+func TestWhoisCommand(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ip/198.51.100.7":
+			_, _ = w.Write([]byte(ipRecord))
+		case "/domain/example.org":
+			_, _ = w.Write([]byte(domainRecord))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	hosts := func(ctrl *gomock.Controller, hosts ...*assets.Host) view.Service {
+		ms := viewmocks.NewMockViewService(ctrl)
+		ms.EXPECT().GetHosts(gomock.Any(), gomock.Any(), gomock.Len(1), gomock.Any()).
+			Return(view.HostsResult{Meta: &responsemeta.ResponseMeta{Status: 200}, Hosts: hosts}, nil)
+		return ms
+	}
+	noCalls := func(ctrl *gomock.Controller) view.Service { return viewmocks.NewMockViewService(ctrl) }
+
+	testCases := []struct {
+		name    string
+		service func(ctrl *gomock.Controller) view.Service
+		args    []string
+		assert  func(t *testing.T, stdout, stderr string, err error)
+	}{
+		{
+			name:    "ip - host document with whois",
+			service: func(ctrl *gomock.Controller) view.Service { return hosts(ctrl, hostWithWhois()) },
+			args:    []string{"198.51.100[.]7", "-O", "json"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var s whois.Summary
+				require.NoError(t, json.Unmarshal([]byte(stdout), &s))
+				require.Equal(t, []string{whois.SourceCensys}, s.Sources)
+				require.Equal(t, 64500, *s.ASN)
+				require.Equal(t, "198.51.100.0/22", s.BGPPrefix)
+				require.Equal(t, "Springfield, IL", s.Organization.Address)
+				require.Equal(t, []whois.Contact{{Name: "Abuse Desk", Email: "abuse@example.net"}}, s.AbuseContacts)
+			},
+		},
+		{
+			name: "ip - RDAP fills in a host without whois",
+			service: func(ctrl *gomock.Controller) view.Service {
+				host := hostWithWhois()
+				host.Whois = nil
+				return hosts(ctrl, host)
+			},
+			args: []string{"198.51.100.7"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "198.51.100.7 (ip, from censys and rdap)")
+				require.Contains(t, stdout, "ASN: AS64500 EXAMPLE-AS (US)")
+				require.Contains(t, stdout, "Network: EXAMPLE-NET (NET-198-51-100-0-1)")
+				require.Contains(t, stdout, "Organization: Example Hosting (EXM), US")
+				require.Contains(t, stdout, "Dates: registered 2010-06-01")
+				require.Contains(t, stdout, "Abuse: Abuse Desk <abuse@example.net>")
+			},
+		},
+		{
+			name:    "ip - RDAP only",
+			service: noCalls,
+			args:    []string{"198.51.100.7", "--source", "rdap", "-O", "json"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				var s whois.Summary
+				require.NoError(t, json.Unmarshal([]byte(stdout), &s))
+				require.Equal(t, []string{whois.SourceRDAP}, s.Sources)
+				require.Nil(t, s.ASN)
+				require.Equal(t, []string{"198.51.100.0/24"}, s.Network.CIDRs)
+			},
+		},
+		{
+			name: "ip - Censys only does not query RDAP",
+			service: func(ctrl *gomock.Controller) view.Service {
+				host := hostWithWhois()
+				host.IP = ptr("203.0.113.9")
+				host.Whois = nil
+				return hosts(ctrl, host)
+			},
+			args: []string{"203.0.113.9", "--source", "censys"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "ASN: AS64500 EXAMPLE-AS (US)")
+				require.NotContains(t, stdout, "rdap")
+			},
+		},
+		{
+			name:    "domain - RDAP",
+			service: noCalls,
+			args:    []string{"hxxps://EXAMPLE[.]org/login"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, stdout, "example.org (domain, from rdap)")
+				require.Contains(t, stdout, "Registrar: Example Registrar, Inc.")
+				require.Contains(t, stdout, "Nameservers: ns1.example.org, ns2.example.org")
+				require.Contains(t, stdout, "Dates: registered 1995-08-31, expires 2030-08-30")
+				require.Contains(t, stdout, "Abuse: <abuse@registrar.example, +1.5555550100>")
+			},
+		},
+		{
+			name:    "domain - not registered",
+			service: noCalls,
+			args:    []string{"unregistered.example"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var lookupErr LookupError
+				require.ErrorAs(t, err, &lookupErr)
+				require.ErrorContains(t, err, "no RDAP record found")
+			},
+		},
+		{
+			name:    "domain - Censys only is not supported",
+			service: noCalls,
+			args:    []string{"example.org", "--source", "censys"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, "--source censys only supports IPs")
+			},
+		},
+		{
+			name:    "invalid target",
+			service: noCalls,
+			args:    []string{"not a domain"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				var targetErr InvalidTargetError
+				require.ErrorAs(t, err, &targetErr)
+			},
+		},
+		{
+			name:    "invalid source",
+			service: noCalls,
+			args:    []string{"8.8.8.8", "--source", "arin"},
+			assert: func(t *testing.T, stdout, stderr string, err error) {
+				require.ErrorContains(t, err, `unsupported --source "arin"`)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Reset()
+			cfg, err := config.New(t.TempDir())
+			require.NoError(t, err)
+			viper.Set("whois.rdap-url", srv.URL)
+
+			var stdout, stderr bytes.Buffer
+			formatter.Stdout = &stdout
+			formatter.Stderr = &stderr
+
+			st, storeErr := store.New(t.TempDir())
+			require.NoError(t, storeErr)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cmdContext := command.NewCommandContext(cfg, st, command.WithViewService(tc.service(ctrl)))
+			rootCmd, cerr := command.RootCommandToCobra(NewWhoisCommand(cmdContext))
+			require.NoError(t, cerr)
+
+			rootCmd.SetArgs(tc.args)
+			cmdErr := rootCmd.Execute()
+			tc.assert(t, stdout.String(), stderr.String(), cmdErr)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/flags"
+	"github.com/censys/cencli/internal/pkg/formatter"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/xref"
+)
+
+const (
+	xrefFlagName   = "xref"
+	noXrefFlagName = "no-xref"
+)
+
+// xrefNamedSource matches --xref values of the form name=source.
+var xrefNamedSource = regexp.MustCompile(`^([A-Za-z0-9_.-]+)=(.+)$`)
+
+// XrefFlags are the flags of commands that cross-reference their results
+// against threat feeds: --xref and --no-xref.
+type XrefFlags struct {
+	feeds    flags.StringSliceFlag
+	disabled flags.BoolFlag
+}
+
+// NewXrefFlags adds the xref flags to fs.
+func NewXrefFlags(fs *pflag.FlagSet) XrefFlags {
+	return XrefFlags{
+		feeds: flags.NewStringSliceFlag(fs, false, xrefFlagName, "", []string{},
+			"also cross-reference results against this indicator list: a file or URL, optionally as name=source (repeatable)"),
+		disabled: flags.NewBoolFlag(fs, noXrefFlagName, "", false,
+			"do not cross-reference results against the feeds in the xref section of the config"),
+	}
+}
+
+// Value returns the feeds to cross-reference results against: those in the
+// config and those given with --xref, or none with --no-xref.
+func (f XrefFlags) Value(cfg config.XrefConfig) ([]xref.Source, cenclierrors.CencliError) {
+	extra, err := f.feeds.Value()
+	if err != nil {
+		return nil, err
+	}
+	disabled, err := f.disabled.Value()
+	if err != nil {
+		return nil, err
+	}
+	if disabled {
+		if len(extra) > 0 {
+			return nil, flags.NewConflictingFlagsError(xrefFlagName, noXrefFlagName)
+		}
+		return nil, nil
+	}
+	var sources []xref.Source
+	for _, feed := range cfg.Feeds {
+		if strings.TrimSpace(feed.Source) == "" {
+			return nil, newXrefFeedError(feed.Name, errors.New("xref.feeds entry has no source"))
+		}
+		sources = append(sources, xref.Source{Name: feed.Name, Location: feed.Source})
+	}
+	for _, raw := range extra {
+		if raw == "" {
+			continue
+		}
+		if m := xrefNamedSource.FindStringSubmatch(raw); m != nil {
+			sources = append(sources, xref.Source{Name: m[1], Location: m[2]})
+			continue
+		}
+		sources = append(sources, xref.Source{Location: raw})
+	}
+	return sources, nil
+}
+
+// LoadXref loads the feeds of sources into a matcher. Feeds fetched from URLs
+// are cached in the cache directory. It returns nil if there are no sources.
+func (c *Context) LoadXref(ctx context.Context, sources []xref.Source) (*xref.Matcher, cenclierrors.CencliError) {
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	loader := xref.Loader{
+		CacheDir: c.dirs.Cache,
+		Refresh:  c.config.Xref.Refresh,
+		Client:   &http.Client{Timeout: c.config.Timeouts.HTTP},
+	}
+	feeds := make([]*xref.Feed, 0, len(sources))
+	for _, src := range sources {
+		feed, err := loader.Load(ctx, src)
+		if err != nil {
+			return nil, newXrefFeedError(src.Location, err)
+		}
+		if src.IsURL() {
+			c.observeCache(feed.Cached)
+		}
+		if feed.Stale && !c.config.Quiet {
+			msg := fmt.Sprintf("Warning: could not refresh threat feed %s; using the cached copy", feed.Name)
+			formatter.Println(formatter.Stderr, styles.GlobalStyles.Warning.Render(msg))
+		}
+		feeds = append(feeds, feed)
+	}
+	return xref.NewMatcher(feeds...), nil
+}
+
+// AttachXref returns item with matches attached under "xref", or item
+// unchanged if there are none.
+func AttachXref(item any, matches []xref.Match) any {
+	if len(matches) == 0 {
+		return item
+	}
+	obj, ok := toObject(item)
+	if !ok {
+		return item
+	}
+	obj[xref.Key] = matches
+	return obj
+}
+
+// toObject returns item as a map, converting it through JSON if needed.
+func toObject(item any) (map[string]any, bool) {
+	if obj, ok := item.(map[string]any); ok {
+		return obj, true
+	}
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, false
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(raw, &obj); err != nil || obj == nil {
+		return nil, false
+	}
+	return obj, true
+}
+
+// XrefFeedError is returned when a threat feed cannot be loaded.
+type XrefFeedError interface{ cenclierrors.CencliError }
+
+type xrefFeedError struct {
+	source string
+	err    error
+}
+
+var _ XrefFeedError = &xrefFeedError{}
+
+func newXrefFeedError(source string, err error) XrefFeedError {
+	return &xrefFeedError{source: source, err: err}
+}
+
+func (e *xrefFeedError) Error() string {
+	return fmt.Sprintf("failed to load threat feed %s: %v", e.source, e.err)
+}
+func (e *xrefFeedError) Title() string          { return "Threat Feed Unavailable" }
+func (e *xrefFeedError) ShouldPrintUsage() bool { return false }
+func (e *xrefFeedError) Unwrap() error          { return e.err }
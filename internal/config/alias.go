@@ -0,0 +1,24 @@
+package config
+
+import (
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// aliasKey is the section of the config file that holds the aliases.
+const aliasKey = "alias"
+
+// SaveAliases replaces the aliases of the config file with aliases, leaving
+// the rest of the file as it is.
+func (c *Config) SaveAliases(aliases map[string]string) cenclierrors.CencliError {
+	section := make(map[string]any, len(aliases))
+	for name, expansion := range aliases {
+		section[name] = expansion
+	}
+	if err := c.updateFile(func(settings map[string]any) {
+		setKey(settings, aliasKey, section)
+	}); err != nil {
+		return err
+	}
+	c.Aliases = aliases
+	return nil
+}
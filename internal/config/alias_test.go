@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func TestSaveAliases(t *testing.T) {
+	defer viper.Reset()
+	viper.Reset()
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("output-format: yaml\nalias:\n  old: view --output-format short\n"), 0o644))
+	cfg, err := New(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"old": "view --output-format short"}, cfg.Aliases)
+
+	const rdp = `search 'host.services.protocol="RDP" and host.location.country={1}' # RDP`
+	require.NoError(t, cfg.SaveAliases(map[string]string{"rdp": rdp}))
+	assert.Equal(t, map[string]string{"rdp": rdp}, cfg.Aliases)
+
+	viper.Reset()
+	cfg, err = New(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"rdp": rdp}, cfg.Aliases)
+	assert.Equal(t, formatter.OutputFormatYAML, cfg.OutputFormat)
+	data, readErr := os.ReadFile(configPath)
+	require.NoError(t, readErr)
+	assert.Contains(t, string(data), "alias:  # Command aliases by name")
+}
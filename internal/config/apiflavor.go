@@ -0,0 +1,14 @@
+package config
+
+// APIFlavor is the Censys API that commands send their requests to.
+type APIFlavor string
+
+const (
+	// APIFlavorPlatform is the Censys Platform API, authenticated with a
+	// personal access token.
+	APIFlavorPlatform APIFlavor = "platform"
+	// APIFlavorLegacy is the legacy Search 2.0 API, authenticated with an
+	// API ID and secret. It has host and certificate lookups, host search
+	// and aggregation, and the account quota.
+	APIFlavorLegacy APIFlavor = "legacy"
+)
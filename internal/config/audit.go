@@ -0,0 +1,10 @@
+package config
+
+// AuditConfig configures the audit log of API calls.
+type AuditConfig struct {
+	Enabled bool `yaml:"enabled" mapstructure:"enabled" doc:"Append every API call to a tamper-evident audit log (see censys audit verify)"`
+	// File is the audit log. Empty means audit.jsonl in the data directory.
+	File string `yaml:"file" mapstructure:"file" doc:"Audit log file (default: audit.jsonl in the data directory)"`
+}
+
+var defaultAuditConfig = AuditConfig{}
@@ -0,0 +1,14 @@
+package config
+
+// CenseyeConfig configures the `censeye` command.
+type CenseyeConfig struct {
+	// Gadgets run on every investigated host, unless --gadgets is set.
+	Gadgets []string `yaml:"gadgets" mapstructure:"gadgets" doc:"Gadgets run on every investigated host, e.g. [od, nobbler, vt] (overridden by --gadgets)"`
+	// VirusTotalAPIKey is required by the vt gadget. Prefer the
+	// CENCLI_CENSEYE_VIRUSTOTAL_API_KEY environment variable.
+	VirusTotalAPIKey string `yaml:"virustotal-api-key" mapstructure:"virustotal-api-key" secret:"true" doc:"VirusTotal API key used by the vt gadget (or set CENCLI_CENSEYE_VIRUSTOTAL_API_KEY)"`
+}
+
+var defaultCenseyeConfig = CenseyeConfig{
+	Gadgets: []string{},
+}
@@ -18,39 +18,134 @@ import (
 )
 
 type Config struct {
-	OutputFormat  formatter.OutputFormat            `yaml:"output-format" mapstructure:"output-format" doc:"Default output format (json|yaml|tree)"`
-	Streaming     bool                              `yaml:"streaming" mapstructure:"streaming" doc:"Enable streaming output mode (NDJSON) for commands that support it"`
-	NoColor       bool                              `yaml:"no-color" mapstructure:"no-color" doc:"Disable ANSI colors and styles"`
-	Spinner       SpinnerConfig                     `yaml:"spinner" mapstructure:"spinner"`
-	Quiet         bool                              `yaml:"quiet" mapstructure:"quiet" doc:"Suppress non-essential output"`
-	Debug         bool                              `yaml:"debug" mapstructure:"debug"`
-	Timeouts      TimeoutConfig                     `yaml:"timeouts" mapstructure:"timeouts"`
-	RetryStrategy RetryStrategy                     `yaml:"retry-strategy" mapstructure:"retry-strategy"`
-	Templates     map[TemplateEntity]TemplateConfig `yaml:"templates" mapstructure:"templates"`
-	Search        SearchConfig                      `yaml:"search" mapstructure:"search"`
-	DefaultTZ     datetime.TimeZone                 `yaml:"default-tz" mapstructure:"default-tz" doc:"Default timezone for timestamps"`
+	OutputFormat   formatter.OutputFormat            `yaml:"output-format" mapstructure:"output-format" doc:"Default output format (json|yaml|tree)"`
+	Streaming      bool                              `yaml:"streaming" mapstructure:"streaming" doc:"Enable streaming output mode (NDJSON) for commands that support it"`
+	NoColor        bool                              `yaml:"no-color" mapstructure:"no-color" doc:"Disable ANSI colors and styles"`
+	Theme          ThemeConfig                       `yaml:"theme" mapstructure:"theme"`
+	Wide           bool                              `yaml:"wide" mapstructure:"wide" doc:"Print tables at full width instead of fitting them to the terminal"`
+	Stable         bool                              `yaml:"stable" mapstructure:"stable" doc:"Sort object keys, and lists of objects by their identity (IP, fingerprint, port, ...), in json and yaml output, so runs can be diffed"`
+	Spinner        SpinnerConfig                     `yaml:"spinner" mapstructure:"spinner"`
+	Quiet          bool                              `yaml:"quiet" mapstructure:"quiet" doc:"Suppress non-essential output"`
+	NonInteractive bool                              `yaml:"non-interactive" mapstructure:"non-interactive" doc:"Never prompt or show interactive views, even in a terminal"`
+	Verbose        bool                              `yaml:"verbose" mapstructure:"verbose" doc:"Print progress, retries, and each API request as log lines on stderr instead of a spinner"`
+	Debug          bool                              `yaml:"debug" mapstructure:"debug"`
+	MetaJSON       bool                              `yaml:"meta-json" mapstructure:"meta-json" doc:"Print response metadata (latency, attempts, estimated credits, rate limit) as a JSON line on stderr"`
+	APIURL         string                            `yaml:"api-url" mapstructure:"api-url" doc:"Base URL of the Censys Platform API. Leave empty for the default; set it to target a proxy or a fake server in tests"`
+	APIFlavor      APIFlavor                         `yaml:"api-flavor" mapstructure:"api-flavor" doc:"API to send requests to (platform|legacy). legacy is the Search 2.0 API, used with an API ID and secret; api-url then is its base URL"`
+	Timeouts       TimeoutConfig                     `yaml:"timeouts" mapstructure:"timeouts"`
+	Transport      TransportConfig                   `yaml:"transport" mapstructure:"transport"`
+	RetryStrategy  RetryStrategy                     `yaml:"retry-strategy" mapstructure:"retry-strategy"`
+	Templates      map[TemplateEntity]TemplateConfig `yaml:"templates" mapstructure:"templates"`
+	Search         SearchConfig                      `yaml:"search" mapstructure:"search"`
+	Forward        ForwardConfig                     `yaml:"forward" mapstructure:"forward"`
+	Risk           RiskConfig                        `yaml:"risk" mapstructure:"risk"`
+	Hunt           HuntConfig                        `yaml:"hunt" mapstructure:"hunt"`
+	Xref           XrefConfig                        `yaml:"xref" mapstructure:"xref"`
+	Whois          WhoisConfig                       `yaml:"whois" mapstructure:"whois"`
+	DNS            DNSConfig                         `yaml:"dns" mapstructure:"dns"`
+	Censeye        CenseyeConfig                     `yaml:"censeye" mapstructure:"censeye"`
+	DefaultTZ      datetime.TimeZone                 `yaml:"default-tz" mapstructure:"default-tz" doc:"Default timezone for timestamps"`
+	Locale         formatter.Locale                  `yaml:"locale" mapstructure:"locale" doc:"Locale of numbers and timestamps in human-readable output, e.g. en-US or de-DE. Leave empty to use LC_ALL or LANG"`
+	MetricsFile    string                            `yaml:"metrics-file" mapstructure:"metrics-file" doc:"Write run metrics to this file on exit (.json for JSON, otherwise OpenMetrics text)"`
+	Tracing        TracingConfig                     `yaml:"tracing" mapstructure:"tracing"`
+	UpdateNotice   bool                              `yaml:"update-notice" mapstructure:"update-notice" doc:"Print a notice (at most once a day) when a newer release is available"`
+	UsageStats     bool                              `yaml:"usage-stats" mapstructure:"usage-stats" doc:"Record local usage analytics for the stats command (never sent anywhere)"`
+	Author         string                            `yaml:"author" mapstructure:"author" doc:"Name recorded as the author of notes. Leave empty to use the name of the current user"`
+	MemoSize       int                               `yaml:"memo-size" mapstructure:"memo-size" doc:"Number of API results a command remembers, so it never fetches identical data twice (0 disables)"`
+	Redact         RedactConfig                      `yaml:"redact" mapstructure:"redact"`
+	Scope          ScopeConfig                       `yaml:"scope" mapstructure:"scope"`
+	Audit          AuditConfig                       `yaml:"audit" mapstructure:"audit"`
+	Aliases        map[string]string                 `yaml:"alias" mapstructure:"alias" doc:"Command aliases by name, e.g. {rdp: search 'host.services.protocol=RDP and host.location.country={1}'}"`
+
+	// Yes answers yes to confirmation prompts. It is only set by --yes or
+	// CENCLI_YES, never by the config file.
+	Yes bool `yaml:"-" mapstructure:"yes"`
+	// Now pins the current time that relative times and default time windows
+	// are resolved against. It is only set by the hidden --now flag or
+	// CENCLI_NOW, for reproducible tests and recorded examples.
+	Now string `yaml:"-" mapstructure:"now"`
+	// DryRun prints the API requests a command would make instead of
+	// sending them. It is only set by --dry-run or CENCLI_DRY_RUN.
+	DryRun bool `yaml:"-" mapstructure:"dry-run"`
+	// SaveRaw saves the raw body of each API response in the artifact store
+	// of the data directory. It is only set by --save-raw or CENCLI_SAVE_RAW.
+	SaveRaw bool `yaml:"-" mapstructure:"save-raw"`
+	// Envelope wraps the data output in an object with the response
+	// metadata and the partial error of the command, if any. It is only set
+	// by --envelope or CENCLI_ENVELOPE.
+	Envelope bool `yaml:"-" mapstructure:"envelope"`
+
+	// firstRun is set when New created the config file, on the first run
+	// of the CLI.
+	firstRun bool
 }
 
 var defaultConfig = &Config{
-	OutputFormat:  formatter.OutputFormatJSON,
-	Streaming:     false,
-	NoColor:       false,
-	Spinner:       defaultSpinnerConfig,
-	Quiet:         false,
-	Debug:         false,
-	Timeouts:      defaultTimeoutConfig,
-	RetryStrategy: defaultRetryStrategy,
-	DefaultTZ:     datetime.TimeZoneUTC,
-	Templates:     defaultTemplateConfig,
-	Search:        defaultSearchConfig,
+	OutputFormat:   formatter.OutputFormatJSON,
+	Streaming:      false,
+	NoColor:        false,
+	Theme:          defaultThemeConfig,
+	Wide:           false,
+	Stable:         false,
+	Spinner:        defaultSpinnerConfig,
+	Quiet:          false,
+	NonInteractive: false,
+	Verbose:        false,
+	Debug:          false,
+	MetaJSON:       false,
+	APIFlavor:      APIFlavorPlatform,
+	Timeouts:       defaultTimeoutConfig,
+	Transport:      defaultTransportConfig,
+	RetryStrategy:  defaultRetryStrategy,
+	DefaultTZ:      datetime.TimeZoneUTC,
+	Templates:      defaultTemplateConfig,
+	Search:         defaultSearchConfig,
+	Forward:        defaultForwardConfig,
+	Risk:           defaultRiskConfig,
+	Hunt:           defaultHuntConfig,
+	Xref:           defaultXrefConfig,
+	Whois:          defaultWhoisConfig,
+	DNS:            defaultDNSConfig,
+	Censeye:        defaultCenseyeConfig,
+	Tracing:        defaultTracingConfig,
+	UpdateNotice:   true,
+	UsageStats:     true,
+	MemoSize:       defaultMemoSize,
+	Redact:         defaultRedactConfig,
+	Scope:          defaultScopeConfig,
+	Audit:          defaultAuditConfig,
+	Aliases:        map[string]string{},
 }
 
+// defaultMemoSize is the number of API results a command remembers by default.
+const defaultMemoSize = 256
+
 const (
-	noColorKey     = "no-color"
-	noSpinnerKey   = "no-spinner"
-	quietKey       = "quiet"
-	debugKey       = "debug"
-	timeoutHTTPKey = "timeout-http"
+	noColorKey        = "no-color"
+	themeKey          = "theme"
+	wideKey           = "wide"
+	stableKey         = "stable"
+	noSpinnerKey      = "no-spinner"
+	quietKey          = "quiet"
+	nonInteractiveKey = "non-interactive"
+	yesKey            = "yes"
+	nowKey            = "now"
+	dryRunKey         = "dry-run"
+	verboseKey        = "verbose"
+	saveRawKey        = "save-raw"
+	redactKey         = "redact"
+	outOfScopeKey     = "out-of-scope"
+	debugKey          = "debug"
+	metaJSONKey       = "meta-json"
+	envelopeKey       = "envelope"
+	timeoutHTTPKey    = "timeout-http"
+	metricsFileKey    = "metrics-file"
+	defaultTZKey      = "default-tz"
+	localeKey         = "locale"
+	tzFlagName        = "tz"
+
+	// ThemeFlagName is the name of the --theme flag.
+	ThemeFlagName = themeKey
 
 	// StreamingFlagName is the name of the --streaming flag.
 	StreamingFlagName = "streaming"
@@ -66,6 +161,9 @@ func New(dataDir string) (*Config, cenclierrors.CencliError) {
 		"-", "_",
 	))
 	viper.AutomaticEnv()
+	if err := bindEnv(viper.GetViper()); err != nil {
+		return nil, newInvalidConfigError(fmt.Errorf("failed to bind environment variables: %w", err).Error())
+	}
 
 	configPath := filepath.Join(dataDir, "config.yaml")
 
@@ -75,33 +173,41 @@ func New(dataDir string) (*Config, cenclierrors.CencliError) {
 	}
 	defer func() { _ = fileLock.Unlock() }()
 
+	var fileSettings map[string]any
+	created := false
 	if err := viper.ReadInConfig(); err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
 		if !errors.As(err, &configFileNotFoundError) {
 			return nil, newInvalidConfigError(fmt.Errorf("failed to read config file: %w", err).Error())
 		}
 
-		if err := setViperDefaults(defaultConfig); err != nil {
+		if err := setViperDefaults(viper.GetViper(), defaultConfig); err != nil {
 			return nil, err
 		}
 
-		if err := viper.WriteConfigAs(configPath); err != nil {
+		if err := persistConfig(configPath, nil); err != nil {
 			return nil, newInvalidConfigError(fmt.Errorf("failed to write config file: %w", err).Error())
 		}
-
+		viper.SetConfigFile(configPath)
+		created = true
 	} else {
+		fileSettings = readFileSettings()
 		// Config file was read successfully, but we still need to set defaults for any missing keys
-		if err := setViperDefaults(defaultConfig); err != nil {
+		if err := setViperDefaults(viper.GetViper(), defaultConfig); err != nil {
 			return nil, err
 		}
 	}
 
-	cfg := &Config{}
+	cfg := &Config{firstRun: created}
 	err := cfg.Unmarshal()
 	if err != nil {
 		return nil, err
 	}
 
+	if err := cfg.checkSchema(); err != nil {
+		return nil, err
+	}
+
 	// Initialize templates after config is loaded
 	if err := initTemplates(dataDir, cfg); err != nil {
 		var cencliErr cenclierrors.CencliError
@@ -112,7 +218,7 @@ func New(dataDir string) (*Config, cenclierrors.CencliError) {
 	}
 
 	// Write the updated config back to the file to persist template paths
-	if err := viper.WriteConfig(); err != nil {
+	if err := persistConfig(configPath, fileSettings); err != nil {
 		return nil, newInvalidConfigError(fmt.Errorf("failed to write updated config file: %w", err).Error())
 	}
 
@@ -124,22 +230,45 @@ func New(dataDir string) (*Config, cenclierrors.CencliError) {
 	return cfg, nil
 }
 
+// FirstRun returns true if the config file did not exist before New created
+// it, which is the case on the first run of the CLI.
+func (c *Config) FirstRun() bool { return c.firstRun }
+
 func (c *Config) Unmarshal() cenclierrors.CencliError {
-	hooks := mapstructure.ComposeDecodeHookFunc(
+	if err := viper.Unmarshal(c, viper.DecodeHook(decodeHooks())); err != nil {
+		return newInvalidConfigError(fmt.Errorf("failed to unmarshal config: %w", err).Error())
+	}
+
+	return nil
+}
+
+// persistConfig writes the settings to the config file at path, without
+// the values of environment variables. fileSettings are the settings read
+// from the file, if any.
+func persistConfig(path string, fileSettings map[string]any) error {
+	settings, err := persistedSettings(fileSettings)
+	if err != nil {
+		return err
+	}
+	w := viper.New()
+	if err := w.MergeConfigMap(settings); err != nil {
+		return err
+	}
+	return w.WriteConfigAs(path)
+}
+
+// decodeHooks are the hooks used to decode config values.
+func decodeHooks() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
 		rejectNumericDurationHookFunc(),
 		rejectNegativeDurationHookFunc(),
 		mapstructure.StringToTimeDurationHookFunc(),
 		mapstructure.StringToUint64HookFunc(),
 		validateUint64HookFunc(),
 		mapstructure.TextUnmarshallerHookFunc(),
+		yamlStringToStructSliceHookFunc(),
 		mapstructure.StringToSliceHookFunc(","),
 	)
-
-	if err := viper.Unmarshal(c, viper.DecodeHook(hooks)); err != nil {
-		return newInvalidConfigError(fmt.Errorf("failed to unmarshal config: %w", err).Error())
-	}
-
-	return nil
 }
 
 // BindGlobalFlags binds all global configuration flags to viper.
@@ -148,6 +277,16 @@ func BindGlobalFlags(persistentFlags *pflag.FlagSet, cfg *Config) error {
 	if err := addPersistentBoolAndBind(persistentFlags, noColorKey, false, "disable ANSI colors and styles", ""); err != nil {
 		return fmt.Errorf("failed to bind no-color flag: %w", err)
 	}
+	// Bind theme flag to theme.name config path
+	if err := addPersistentStringAndBindToPath(persistentFlags, themeKey, "theme.name", string(defaultConfig.Theme.Name), "color theme (default|high-contrast|monochrome|solarized|colorblind)"); err != nil {
+		return fmt.Errorf("failed to bind theme flag: %w", err)
+	}
+	if err := addPersistentBoolAndBind(persistentFlags, wideKey, false, "print tables at full width instead of truncating them to fit the terminal", ""); err != nil {
+		return fmt.Errorf("failed to bind wide flag: %w", err)
+	}
+	if err := addPersistentBoolAndBind(persistentFlags, stableKey, false, "sort object keys, and lists of objects by their identity, in json and yaml output, so the output of two runs can be diffed", ""); err != nil {
+		return fmt.Errorf("failed to bind stable flag: %w", err)
+	}
 	// Bind no-spinner flag to spinner.disabled config path
 	if err := addPersistentBoolAndBindToPath(persistentFlags, noSpinnerKey, "spinner.disabled", defaultConfig.Spinner.Disabled, "disable spinner during operations", ""); err != nil {
 		return fmt.Errorf("failed to bind no-spinner flag: %w", err)
@@ -155,9 +294,38 @@ func BindGlobalFlags(persistentFlags *pflag.FlagSet, cfg *Config) error {
 	if err := addPersistentBoolAndBind(persistentFlags, quietKey, false, "suppress non-essential output", "q"); err != nil {
 		return fmt.Errorf("failed to bind quiet flag: %w", err)
 	}
+	if err := addPersistentBoolAndBind(persistentFlags, nonInteractiveKey, false, "never prompt or show interactive views; commands that need input fail instead (automatic without a terminal)", ""); err != nil {
+		return fmt.Errorf("failed to bind non-interactive flag: %w", err)
+	}
+	if err := addPersistentBoolAndBind(persistentFlags, yesKey, false, "answer yes to confirmation prompts", "y"); err != nil {
+		return fmt.Errorf("failed to bind yes flag: %w", err)
+	}
+	if err := addPersistentBoolAndBind(persistentFlags, dryRunKey, false, "print the API requests the command would make instead of sending them", ""); err != nil {
+		return fmt.Errorf("failed to bind dry-run flag: %w", err)
+	}
+	if err := addPersistentBoolAndBind(persistentFlags, saveRawKey, false, "save the raw body of each API response in the artifact store of the data directory", ""); err != nil {
+		return fmt.Errorf("failed to bind save-raw flag: %w", err)
+	}
+	// Bind redact flag to redact.enabled config path
+	if err := addPersistentBoolAndBindToPath(persistentFlags, redactKey, "redact.enabled", defaultConfig.Redact.Enabled, "mask IP addresses, hostnames, and organization IDs in all output, for screenshots and shared reports", ""); err != nil {
+		return fmt.Errorf("failed to bind redact flag: %w", err)
+	}
+	// Bind out-of-scope flag to scope.out-of-scope config path
+	if err := addPersistentStringAndBindToPath(persistentFlags, outOfScopeKey, "scope.out-of-scope", string(defaultConfig.Scope.OutOfScope), "what to do when the command would query an asset out of scope.file (fail|warn)"); err != nil {
+		return fmt.Errorf("failed to bind out-of-scope flag: %w", err)
+	}
+	if err := addPersistentBoolAndBind(persistentFlags, verboseKey, false, "print progress, retries, and each API request as log lines on stderr", "v"); err != nil {
+		return fmt.Errorf("failed to bind verbose flag: %w", err)
+	}
 	if err := addPersistentBoolAndBind(persistentFlags, debugKey, false, "enable debug logging", ""); err != nil {
 		return fmt.Errorf("failed to bind debug flag: %w", err)
 	}
+	if err := addPersistentBoolAndBind(persistentFlags, metaJSONKey, false, "print response metadata (latency, attempts, estimated credits, rate limit) as a JSON line on stderr, even with --quiet", ""); err != nil {
+		return fmt.Errorf("failed to bind meta-json flag: %w", err)
+	}
+	if err := addPersistentBoolAndBind(persistentFlags, envelopeKey, false, "wrap json and yaml output as {data, meta, partial_error}, so scripts can tell incomplete results apart", ""); err != nil {
+		return fmt.Errorf("failed to bind envelope flag: %w", err)
+	}
 	// Bind timeout-http flag to timeouts.http config path
 	if err := addPersistentDurationAndBindToPath(persistentFlags, timeoutHTTPKey, "timeouts.http", defaultConfig.Timeouts.HTTP, "per-request timeout for HTTP requests (e.g. 10s, 1m) - use 0 to disable"); err != nil {
 		return fmt.Errorf("failed to bind timeout-http flag: %w", err)
@@ -165,12 +333,36 @@ func BindGlobalFlags(persistentFlags *pflag.FlagSet, cfg *Config) error {
 	if err := formatter.BindOutputFormat(persistentFlags, cfg.OutputFormat); err != nil {
 		return fmt.Errorf("failed to bind output-format flag: %w", err)
 	}
+	boundFlags[formatter.OutputFormatFlagName] = persistentFlags.Lookup(formatter.OutputFormatFlagName)
 	if err := addPersistentBoolAndBind(persistentFlags, StreamingFlagName, false, "enable streaming output mode (NDJSON) for commands that support it", "S"); err != nil {
 		return fmt.Errorf("failed to bind streaming flag: %w", err)
 	}
+	// Bind tz flag to the default-tz config path
+	if err := addPersistentStringAndBindToPath(persistentFlags, tzFlagName, defaultTZKey, string(defaultConfig.DefaultTZ), "timezone for interpreting and displaying timestamps without an explicit zone (e.g. America/New_York)"); err != nil {
+		return fmt.Errorf("failed to bind tz flag: %w", err)
+	}
+	if err := addPersistentStringAndBind(persistentFlags, localeKey, string(defaultConfig.Locale), "locale of numbers and timestamps in tables and other human-readable output (e.g. en-US, de-DE); defaults to LC_ALL or LANG"); err != nil {
+		return fmt.Errorf("failed to bind locale flag: %w", err)
+	}
+	if err := addPersistentStringAndBind(persistentFlags, nowKey, "", "pin the current time that relative times are resolved against (for tests and recorded examples)"); err != nil {
+		return fmt.Errorf("failed to bind now flag: %w", err)
+	}
+	if err := persistentFlags.MarkHidden(nowKey); err != nil {
+		return fmt.Errorf("failed to hide now flag: %w", err)
+	}
+	if err := addPersistentStringAndBind(persistentFlags, metricsFileKey, "", "write run metrics to a file on exit (.json for JSON, otherwise OpenMetrics text)"); err != nil {
+		return fmt.Errorf("failed to bind metrics-file flag: %w", err)
+	}
 	return nil
 }
 
+// bindFlag binds a flag to a config key, and records it as a source of the
+// key for Settings.
+func bindFlag(key string, flag *pflag.Flag) error {
+	boundFlags[key] = flag
+	return viper.BindPFlag(key, flag)
+}
+
 // addPersistentBoolAndBind defines a persistent boolean flag and binds it to viper using the same key.
 // It returns any error produced during viper binding.
 func addPersistentBoolAndBind(persistentFlags *pflag.FlagSet, name string, defaultValue bool, usage string, short string) error {
@@ -179,7 +371,7 @@ func addPersistentBoolAndBind(persistentFlags *pflag.FlagSet, name string, defau
 	} else {
 		persistentFlags.Bool(name, defaultValue, usage)
 	}
-	return viper.BindPFlag(name, persistentFlags.Lookup(name))
+	return bindFlag(name, persistentFlags.Lookup(name))
 }
 
 // addPersistentBoolAndBindToPath defines a persistent boolean flag and binds it to viper using a different config path.
@@ -190,12 +382,25 @@ func addPersistentBoolAndBindToPath(persistentFlags *pflag.FlagSet, flagName str
 	} else {
 		persistentFlags.Bool(flagName, defaultValue, usage)
 	}
-	return viper.BindPFlag(viperPath, persistentFlags.Lookup(flagName))
+	return bindFlag(viperPath, persistentFlags.Lookup(flagName))
+}
+
+// addPersistentStringAndBind defines a persistent string flag and binds it to viper using the same key.
+func addPersistentStringAndBind(persistentFlags *pflag.FlagSet, name string, defaultValue string, usage string) error {
+	persistentFlags.String(name, defaultValue, usage)
+	return bindFlag(name, persistentFlags.Lookup(name))
+}
+
+// addPersistentStringAndBindToPath defines a persistent string flag and binds it to viper using a different config path.
+// This is useful when the flag name doesn't match the nested config structure.
+func addPersistentStringAndBindToPath(persistentFlags *pflag.FlagSet, flagName string, viperPath string, defaultValue string, usage string) error {
+	persistentFlags.String(flagName, defaultValue, usage)
+	return bindFlag(viperPath, persistentFlags.Lookup(flagName))
 }
 
 // addPersistentDurationAndBindToPath defines a persistent duration flag and binds it to viper using a different config path.
 // This is useful when the flag name doesn't match the nested config structure.
 func addPersistentDurationAndBindToPath(persistentFlags *pflag.FlagSet, flagName string, viperPath string, defaultValue time.Duration, usage string) error {
 	persistentFlags.Duration(flagName, defaultValue, usage)
-	return viper.BindPFlag(viperPath, persistentFlags.Lookup(flagName))
+	return bindFlag(viperPath, persistentFlags.Lookup(flagName))
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/go-viper/mapstructure/v2"
+	"gopkg.in/yaml.v3"
 )
 
 // rejectNumericDurationHookFunc disallows numeric values for time.Duration fields,
@@ -85,3 +86,19 @@ func validateUint64HookFunc() mapstructure.DecodeHookFuncType {
 		return data, nil
 	}
 }
+
+// yamlStringToStructSliceHookFunc decodes a string into a slice of structs as
+// YAML, so that keys such as xref.feeds can be set by environment variables,
+// e.g. CENCLI_XREF_FEEDS='[{name: c2, source: https://example.com/c2.txt}]'.
+func yamlStringToStructSliceHookFunc() mapstructure.DecodeHookFuncType {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || to.Kind() != reflect.Slice || to.Elem().Kind() != reflect.Struct {
+			return data, nil
+		}
+		var res []map[string]any
+		if err := yaml.Unmarshal([]byte(data.(string)), &res); err != nil {
+			return nil, fmt.Errorf("expected a YAML list of objects: %w", err)
+		}
+		return res, nil
+	}
+}
@@ -0,0 +1,15 @@
+package config
+
+// DNSConfig configures how domains given to `view --resolve` and `censeye`
+// are resolved to IPs.
+type DNSConfig struct {
+	// Resolver is "system" or "doh".
+	Resolver string `yaml:"resolver" mapstructure:"resolver" doc:"How domains are resolved to IPs (system|doh)"`
+	// DoHURL is the DNS over HTTPS endpoint used by the doh resolver.
+	DoHURL string `yaml:"doh-url" mapstructure:"doh-url" doc:"DNS over HTTPS endpoint (JSON API) used when resolver is doh"`
+}
+
+var defaultDNSConfig = DNSConfig{
+	Resolver: "system",
+	DoHURL:   "https://cloudflare-dns.com/dns-query",
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of the environment variables that set config keys.
+const EnvPrefix = "CENCLI"
+
+// Sources of the value of a config key, from highest to lowest precedence.
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceFile    = "config"
+	SourceDefault = "default"
+)
+
+// redacted is shown instead of the value of a secret key.
+const redacted = "********"
+
+// envAliases are environment variables that also set a key, named after the
+// flag bound to it or, for tracing, the standard OpenTelemetry variables. The
+// variable of the key itself takes precedence.
+var envAliases = map[string][]string{
+	"spinner.disabled":     {"CENCLI_NO_SPINNER"},
+	"tracing.endpoint":     {"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"},
+	"tracing.headers":      {"OTEL_EXPORTER_OTLP_TRACES_HEADERS", "OTEL_EXPORTER_OTLP_HEADERS"},
+	"tracing.service-name": {"OTEL_SERVICE_NAME"},
+}
+
+// boundFlags are the global flags bound to config keys, by key.
+var boundFlags = map[string]*pflag.Flag{}
+
+// Key is a config key, as generated from the schema of Config.
+type Key struct {
+	// Name is the dotted path of the key, e.g. search.page-size.
+	Name string
+	// EnvVars are the environment variables that set the key, in order of
+	// precedence.
+	EnvVars []string
+	// Secret is set for keys whose values are not shown.
+	Secret bool
+}
+
+// EnvVar returns the environment variable that sets a config key, e.g.
+// CENCLI_SEARCH_PAGE_SIZE for search.page-size.
+func EnvVar(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// Keys returns every config key, in the order of the fields of Config. Each
+// entry of a map, such as templates.host.path, is a key of its own.
+func Keys() []Key {
+	var keys []Key
+	collectKeys(reflect.TypeOf(Config{}), "", &keys)
+	return keys
+}
+
+func collectKeys(t reflect.Type, prefix string, keys *[]Key) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		path := joinKey(prefix, name)
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			collectKeys(field.Type, path, keys)
+		case field.Type.Kind() == reflect.Map && field.Type.Elem().Kind() == reflect.Struct:
+			for _, entry := range mapDefaultKeys(path) {
+				collectKeys(field.Type.Elem(), joinKey(path, entry), keys)
+			}
+		default:
+			*keys = append(*keys, Key{
+				Name:    path,
+				EnvVars: append([]string{EnvVar(path)}, envAliases[path]...),
+				Secret:  field.Tag.Get("secret") == "true",
+			})
+		}
+	}
+}
+
+// mapDefaultKeys returns the keys of the default value of a map in the
+// config, sorted.
+func mapDefaultKeys(path string) []string {
+	var res []string
+	if path == "templates" {
+		for entity := range defaultConfig.Templates {
+			res = append(res, string(entity))
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// bindEnv binds every config key to its environment variables, so that keys
+// that viper would not otherwise look up, such as templates.host.path, can
+// be set too.
+func bindEnv(vp *viper.Viper) error {
+	for _, key := range Keys() {
+		if err := vp.BindEnv(append([]string{key.Name}, key.EnvVars...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupEnv returns the first environment variable of key that is set to a
+// non-empty value, as viper ignores empty ones.
+func lookupEnv(key Key) (string, bool) {
+	for _, name := range key.EnvVars {
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Setting is the effective value of a config key, and where it came from.
+type Setting struct {
+	Key   string `json:"key" yaml:"key"`
+	Value any    `json:"value" yaml:"value"`
+	// EnvVar is the environment variable that sets the key.
+	EnvVar string `json:"env_var" yaml:"env_var"`
+	// Source is SourceFlag, SourceEnv, SourceFile, or SourceDefault.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	// Origin is the flag, environment variable, or file the value came from.
+	Origin string `json:"origin,omitempty" yaml:"origin,omitempty"`
+}
+
+// Settings returns the effective value of every config key and where it
+// came from, following the precedence flag > env > config > default. Values
+// of secret keys are redacted.
+func Settings() []Setting {
+	fileSettings := readFileSettings()
+	keys := Keys()
+	res := make([]Setting, 0, len(keys))
+	for _, key := range keys {
+		s := Setting{Key: key.Name, Value: viper.Get(key.Name), EnvVar: key.EnvVars[0], Source: SourceDefault}
+		if flag, ok := boundFlags[key.Name]; ok && flag.Changed {
+			s.Source, s.Origin = SourceFlag, "--"+flag.Name
+		} else if name, ok := lookupEnv(key); ok {
+			s.Source, s.Origin = SourceEnv, name
+		} else if _, ok := lookupKey(fileSettings, key.Name); ok {
+			s.Source, s.Origin = SourceFile, FilePath()
+		}
+		if key.Secret && s.Value != nil && s.Value != "" {
+			s.Value = redacted
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+// readFileSettings returns the settings of the loaded config file, with
+// dotted keys expanded, or nil if none is loaded.
+func readFileSettings() map[string]any {
+	path := FilePath()
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var settings map[string]any
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil
+	}
+	return expandKeys(settings)
+}
+
+// lookupKey returns the value of a dotted key in nested settings.
+func lookupKey(settings map[string]any, key string) (any, bool) {
+	parts := strings.Split(key, ".")
+	current := settings
+	for i, part := range parts {
+		value, ok := current[part]
+		if !ok {
+			return nil, false
+		}
+		if i == len(parts)-1 {
+			return value, true
+		}
+		if current, ok = value.(map[string]any); !ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// setKey sets the value of a dotted key in nested settings.
+func setKey(settings map[string]any, key string, value any) {
+	parts := strings.Split(key, ".")
+	current := settings
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[part] = next
+		}
+		current = next
+	}
+	current[parts[len(parts)-1]] = value
+}
+
+// persistedSettings returns the settings to write to the config file: all
+// settings, except that keys set by environment variables keep the value of
+// the file (or the default), so that the environment is not persisted.
+func persistedSettings(fileSettings map[string]any) (map[string]any, error) {
+	settings := viper.AllSettings()
+	defaults := viper.New()
+	if err := setViperDefaults(defaults, defaultConfig); err != nil {
+		return nil, err
+	}
+	for _, key := range Keys() {
+		if _, ok := lookupEnv(key); !ok {
+			continue
+		}
+		if value, ok := lookupKey(fileSettings, key.Name); ok {
+			setKey(settings, key.Name, value)
+		} else {
+			setKey(settings, key.Name, defaults.Get(key.Name))
+		}
+	}
+	return settings, nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeys(t *testing.T) {
+	byName := make(map[string]Key)
+	for _, key := range Keys() {
+		byName[key.Name] = key
+	}
+	assert.Equal(t, []string{"CENCLI_SEARCH_PAGE_SIZE"}, byName["search.page-size"].EnvVars)
+	assert.Equal(t, []string{"CENCLI_SPINNER_DISABLED", "CENCLI_NO_SPINNER"}, byName["spinner.disabled"].EnvVars)
+	assert.Equal(t, []string{"CENCLI_TEMPLATES_HOST_PATH"}, byName["templates.host.path"].EnvVars)
+	assert.True(t, byName["forward.splunk.token"].Secret)
+	assert.Contains(t, byName, "xref.feeds")
+	assert.NotContains(t, byName, "forward.splunk")
+}
+
+func TestEnvironmentOverrides(t *testing.T) {
+	tempDir, cleanup := setupConfigTest(t)
+	defer cleanup()
+
+	hostTemplate := filepath.Join(t.TempDir(), "host.hbs")
+	require.NoError(t, os.WriteFile(hostTemplate, []byte("{{ip}}"), 0o644))
+	writeConfigFile(t, tempDir, "search:\n  page-size: 50\nforward:\n  splunk:\n    token: from-file\n")
+
+	t.Setenv("CENCLI_SEARCH_PAGE_SIZE", "25")
+	t.Setenv("CENCLI_FORWARD_SPLUNK_TOKEN", "from-env")
+	t.Setenv("CENCLI_TEMPLATES_HOST_PATH", hostTemplate)
+	t.Setenv("CENCLI_NO_SPINNER", "true")
+	t.Setenv("CENCLI_XREF_FEEDS", "[{name: c2, source: https://example.com/c2.txt}]")
+	t.Setenv("CENCLI_FORWARD_KAFKA_BROKERS", "kafka-1:9092,kafka-2:9092")
+
+	cfg, err := New(tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), cfg.Search.PageSize)
+	assert.Equal(t, "from-env", cfg.Forward.Splunk.Token)
+	assert.Equal(t, hostTemplate, cfg.Templates[TemplateEntityHost].Path)
+	assert.True(t, cfg.Spinner.Disabled)
+	assert.Equal(t, []XrefFeed{{Name: "c2", Source: "https://example.com/c2.txt"}}, cfg.Xref.Feeds)
+	assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, cfg.Forward.Kafka.Brokers)
+
+	// environment variables are not written to the config file
+	data, readErr := os.ReadFile(filepath.Join(tempDir, "config.yaml"))
+	require.NoError(t, readErr)
+	assert.Contains(t, string(data), "page-size: 50")
+	assert.Contains(t, string(data), "token: from-file")
+	assert.NotContains(t, string(data), "from-env")
+	assert.NotContains(t, string(data), hostTemplate)
+	assert.NotContains(t, string(data), "- name: c2")
+}
+
+func TestEnvironmentNotPersistedOnFirstRun(t *testing.T) {
+	tempDir, cleanup := setupConfigTest(t)
+	defer cleanup()
+
+	t.Setenv("CENCLI_FORWARD_SPLUNK_TOKEN", "from-env")
+
+	cfg, err := New(tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", cfg.Forward.Splunk.Token)
+	assert.Equal(t, filepath.Join(tempDir, "config.yaml"), FilePath())
+
+	data, readErr := os.ReadFile(filepath.Join(tempDir, "config.yaml"))
+	require.NoError(t, readErr)
+	assert.NotContains(t, string(data), "from-env")
+}
+
+func TestSettings(t *testing.T) {
+	tempDir, cleanup := setupConfigTest(t)
+	defer cleanup()
+
+	writeConfigFile(t, tempDir, "search:\n  page-size: 50\n")
+	t.Setenv("CENCLI_SEARCH_MAX_PAGES", "3")
+	t.Setenv("CENCLI_FORWARD_SPLUNK_TOKEN", "from-env")
+
+	cfg, err := New(tempDir)
+	require.NoError(t, err)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, BindGlobalFlags(flags, cfg))
+	require.NoError(t, flags.Parse([]string{"--timeout-http", "5s"}))
+	t.Cleanup(func() { boundFlags = map[string]*pflag.Flag{} })
+
+	byKey := make(map[string]Setting)
+	for _, s := range Settings() {
+		byKey[s.Key] = s
+	}
+	configPath := filepath.Join(tempDir, "config.yaml")
+	assert.Equal(t, Setting{Key: "timeouts.http", Value: "5s", EnvVar: "CENCLI_TIMEOUTS_HTTP", Source: SourceFlag, Origin: "--timeout-http"}, byKey["timeouts.http"])
+	assert.Equal(t, Setting{Key: "search.max-pages", Value: "3", EnvVar: "CENCLI_SEARCH_MAX_PAGES", Source: SourceEnv, Origin: "CENCLI_SEARCH_MAX_PAGES"}, byKey["search.max-pages"])
+	assert.Equal(t, Setting{Key: "search.page-size", Value: 50, EnvVar: "CENCLI_SEARCH_PAGE_SIZE", Source: SourceFile, Origin: configPath}, byKey["search.page-size"])
+	assert.Equal(t, redacted, byKey["forward.splunk.token"].Value)
+	assert.Equal(t, "json", viper.GetString("output-format"))
+	assert.Equal(t, SourceFile, byKey["output-format"].Source)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 )
@@ -39,3 +40,50 @@ func (e *invalidConfigError) Title() string {
 func (e *invalidConfigError) ShouldPrintUsage() bool {
 	return true
 }
+
+// SchemaError is returned when the config has unknown keys or values out of
+// range. It lists every problem, so that they can be fixed at once.
+type SchemaError interface {
+	InvalidConfigError
+	Problems() []Problem
+}
+
+type schemaError struct {
+	path     string
+	problems []Problem
+}
+
+var _ SchemaError = &schemaError{}
+
+func newSchemaError(path string, problems []Problem) SchemaError {
+	return &schemaError{path: path, problems: problems}
+}
+
+func (e *schemaError) Error() string {
+	var b strings.Builder
+	noun := "problems"
+	if len(e.problems) == 1 {
+		noun = "problem"
+	}
+	if e.path != "" {
+		fmt.Fprintf(&b, "found %d %s in %s:", len(e.problems), noun, e.path)
+	} else {
+		fmt.Fprintf(&b, "found %d %s in the config:", len(e.problems), noun)
+	}
+	for _, p := range e.problems {
+		b.WriteString("\n  - " + p.String())
+	}
+	return b.String()
+}
+
+func (e *schemaError) Title() string {
+	return "Invalid config"
+}
+
+func (e *schemaError) ShouldPrintUsage() bool {
+	return false
+}
+
+func (e *schemaError) Problems() []Problem {
+	return e.problems
+}
@@ -0,0 +1,51 @@
+package config
+
+// ForwardConfig configures the sinks that `--forward` sends results to.
+type ForwardConfig struct {
+	Splunk SplunkConfig `yaml:"splunk" mapstructure:"splunk"`
+	Kafka  KafkaConfig  `yaml:"kafka" mapstructure:"kafka"`
+}
+
+// SplunkConfig configures forwarding results to a Splunk HTTP Event Collector.
+type SplunkConfig struct {
+	// URL is the HEC endpoint. A URL without a path is sent to /services/collector/event.
+	URL string `yaml:"url" mapstructure:"url" doc:"Splunk HTTP Event Collector URL, e.g. https://splunk.example.com:8088"`
+	// Token is the HEC token. Prefer the CENCLI_FORWARD_SPLUNK_TOKEN environment variable.
+	Token string `yaml:"token" mapstructure:"token" secret:"true" doc:"HEC token (or set CENCLI_FORWARD_SPLUNK_TOKEN)"`
+	// Index is the index of the events. Empty uses the token's default index.
+	Index string `yaml:"index" mapstructure:"index" doc:"Index of the events (empty for the token's default index)"`
+	// Sourcetype is the sourcetype of the events.
+	Sourcetype string `yaml:"sourcetype" mapstructure:"sourcetype" doc:"Sourcetype of the events"`
+	// BatchSize is the number of events sent per request. Must be >= 1.
+	BatchSize uint64 `yaml:"batch-size" mapstructure:"batch-size" doc:"Number of events sent per request (must be >= 1)"`
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries uint64 `yaml:"max-retries" mapstructure:"max-retries" doc:"Number of times a failed request is retried"`
+}
+
+// KafkaConfig configures producing results to a Kafka topic with kcat.
+type KafkaConfig struct {
+	// Brokers are the bootstrap brokers.
+	Brokers []string `yaml:"brokers" mapstructure:"brokers" doc:"Bootstrap brokers, e.g. [kafka-1:9092, kafka-2:9092]"`
+	// Topic is the topic messages are produced to, unless --topic is set.
+	Topic string `yaml:"topic" mapstructure:"topic" doc:"Topic to produce to (overridden by --topic)"`
+	// Key is the key of each message: id, none, or the path of a field.
+	Key string `yaml:"key" mapstructure:"key" doc:"Message key: id (IP, certificate fingerprint, or hostname:port), none, or a field path"`
+	// Command is the kcat executable.
+	Command string `yaml:"command" mapstructure:"command" doc:"kcat executable used to produce messages"`
+	// Args are extra kcat arguments, such as librdkafka properties.
+	Args []string `yaml:"args" mapstructure:"args" doc:"Extra kcat arguments, e.g. [-X, security.protocol=SASL_SSL]"`
+}
+
+var defaultForwardConfig = ForwardConfig{
+	Splunk: SplunkConfig{
+		Sourcetype: "censys:cencli",
+		BatchSize:  100,
+		MaxRetries: 3,
+	},
+	Kafka: KafkaConfig{
+		Brokers: []string{},
+		Key:     "id",
+		Command: "kcat",
+		Args:    []string{},
+	},
+}
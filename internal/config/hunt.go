@@ -0,0 +1,10 @@
+package config
+
+// HuntConfig configures `hunt`.
+type HuntConfig struct {
+	// Dir is a directory of hunts to add to the built-in ones, one YAML file
+	// per hunt. Empty means the hunts directory of the config directory.
+	Dir string `yaml:"dir" mapstructure:"dir" doc:"Directory of org-specific hunts, one YAML file per hunt (default: the hunts directory next to this file)"`
+}
+
+var defaultHuntConfig = HuntConfig{}
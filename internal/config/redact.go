@@ -0,0 +1,11 @@
+package config
+
+type RedactConfig struct {
+	Enabled bool   `yaml:"enabled" mapstructure:"enabled" doc:"Mask IP addresses, hostnames, and organization IDs in all output, for screenshots and shared reports"`
+	Salt    string `yaml:"salt" mapstructure:"salt" doc:"Secret mixed into the masks of --redact, so that they cannot be reversed; the same salt gives the same masks. When empty, a random salt is generated and kept in the local store"`
+}
+
+var defaultRedactConfig = RedactConfig{
+	Enabled: false,
+	Salt:    "",
+}
@@ -21,6 +21,10 @@ var defaultRetryStrategy = RetryStrategy{
 	Backoff:     BackoffFixed,
 }
 
+// DefaultRetryStrategy returns the retry strategy used when the config does
+// not set one.
+func DefaultRetryStrategy() RetryStrategy { return defaultRetryStrategy }
+
 type BackoffType string
 
 const (
@@ -0,0 +1,9 @@
+package config
+
+// RiskConfig configures the host risk scores of `view`.
+type RiskConfig struct {
+	// BadJA4File is a file of known-bad JA4S and JA4T fingerprints, one per line.
+	BadJA4File string `yaml:"bad-ja4-file" mapstructure:"bad-ja4-file" doc:"File of known-bad JA4S/JA4T fingerprints (one per line) that raise host risk scores"`
+}
+
+var defaultRiskConfig = RiskConfig{}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/gofrs/flock"
+	"github.com/spf13/viper"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// SaveSettings sets each key of values in the config file, e.g.
+// "output-format", leaving the rest of the file as it is, and loads the file
+// again so the values apply. Flags and environment variables still take
+// precedence over them.
+func (c *Config) SaveSettings(values map[string]any) cenclierrors.CencliError {
+	if err := c.updateFile(func(settings map[string]any) {
+		for key, value := range values {
+			setKey(settings, key, value)
+		}
+	}); err != nil {
+		return err
+	}
+	if err := viper.ReadInConfig(); err != nil {
+		return newInvalidConfigError(fmt.Errorf("failed to read config file: %w", err).Error())
+	}
+	return c.Unmarshal()
+}
+
+// updateFile applies update to the settings of the config file and writes
+// them back, holding the lock of the file.
+func (c *Config) updateFile(update func(settings map[string]any)) cenclierrors.CencliError {
+	path := FilePath()
+	if path == "" {
+		return newInvalidConfigError("no config file is loaded")
+	}
+	fileLock := flock.New(path + ".lock")
+	if err := fileLock.Lock(); err != nil {
+		return newInvalidConfigError(fmt.Errorf("failed to acquire config lock: %w", err).Error())
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	settings := readFileSettings()
+	if settings == nil {
+		settings = map[string]any{}
+	}
+	update(settings)
+	w := viper.New()
+	if err := w.MergeConfigMap(settings); err != nil {
+		return newInvalidConfigError(fmt.Errorf("failed to update config file: %w", err).Error())
+	}
+	if err := w.WriteConfigAs(path); err != nil {
+		return newInvalidConfigError(fmt.Errorf("failed to write config file: %w", err).Error())
+	}
+	if err := addDocCommentsToYAML(path, c); err != nil {
+		return newInvalidConfigError(fmt.Errorf("failed to add doc comments to config file: %w", err).Error())
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/formatter"
+)
+
+func TestSaveSettings(t *testing.T) {
+	defer viper.Reset()
+	viper.Reset()
+	dir := t.TempDir()
+	cfg, err := New(dir)
+	require.NoError(t, err)
+	assert.True(t, cfg.FirstRun(), "the config file was created")
+
+	require.NoError(t, cfg.SaveSettings(map[string]any{"output-format": "yaml", "no-color": true, "memo-size": 10}))
+	assert.Equal(t, formatter.OutputFormatYAML, cfg.OutputFormat)
+	assert.True(t, cfg.NoColor)
+	assert.Equal(t, 10, cfg.MemoSize)
+
+	t.Setenv("CENCLI_MEMO_SIZE", "20")
+	require.NoError(t, cfg.SaveSettings(map[string]any{"output-format": "tree"}))
+	assert.Equal(t, formatter.OutputFormatTree, cfg.OutputFormat)
+	assert.Equal(t, 20, cfg.MemoSize, "environment variables take precedence")
+
+	viper.Reset()
+	cfg, err = New(dir)
+	require.NoError(t, err)
+	assert.False(t, cfg.FirstRun())
+	assert.Equal(t, formatter.OutputFormatTree, cfg.OutputFormat)
+	assert.True(t, cfg.NoColor)
+	data, readErr := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	require.NoError(t, readErr)
+	assert.Contains(t, string(data), "memo-size: 10")
+}
@@ -0,0 +1,370 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/censys/cencli/internal/pkg/editdistance"
+	"github.com/censys/cencli/internal/pkg/searchurl"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// Problem is a problem with a key of the config.
+type Problem struct {
+	// Key is the dotted path of the key, e.g. search.page-size. It is empty
+	// for problems with the file as a whole.
+	Key     string `json:"key,omitempty" yaml:"key,omitempty"`
+	Message string `json:"message" yaml:"message"`
+}
+
+func (p Problem) String() string {
+	if p.Key == "" {
+		return p.Message
+	}
+	return p.Key + ": " + p.Message
+}
+
+// CheckFile checks a config file against the schema of Config, and returns
+// every problem found: unknown keys, values of the wrong type, and values out
+// of range. Unlike New, it does not stop at the first value that cannot be
+// decoded.
+func CheckFile(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var settings map[string]any
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return []Problem{{Message: fmt.Sprintf("invalid YAML: %v", err)}}, nil
+	}
+	return CheckSettings(settings), nil
+}
+
+// CheckSettings checks settings, as read from a config file, against the
+// schema of Config. Values are only range-checked once every key is known
+// and decodes.
+func CheckSettings(settings map[string]any) []Problem {
+	problems := checkKeys(expandKeys(settings), reflect.TypeOf(Config{}), "")
+	if len(problems) == 0 {
+		cfg := *defaultConfig
+		cfg.Templates = map[TemplateEntity]TemplateConfig{}
+		if err := decodeValue(expandKeys(settings), &cfg); err != nil {
+			problems = append(problems, Problem{Message: cleanDecodeError(err)})
+		} else {
+			problems = cfg.checkValues()
+		}
+	}
+	sortProblems(problems)
+	return problems
+}
+
+// checkSchema checks the loaded config file for unknown keys, and the merged
+// config for values out of range. Values of the wrong type are reported by
+// Unmarshal.
+func (c *Config) checkSchema() SchemaError {
+	var problems []Problem
+	path := FilePath()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		var settings map[string]any
+		if err := yaml.Unmarshal(data, &settings); err == nil {
+			problems = unknownKeys(settings)
+		}
+	}
+	problems = append(problems, c.checkValues()...)
+	if len(problems) == 0 {
+		return nil
+	}
+	return newSchemaError(path, problems)
+}
+
+// unknownKeys returns a problem for each key of settings that is not in the
+// schema of Config.
+func unknownKeys(settings map[string]any) []Problem {
+	var res []Problem
+	for _, p := range checkKeys(expandKeys(settings), reflect.TypeOf(Config{}), "") {
+		if strings.HasPrefix(p.Message, unknownKeyMessage) {
+			res = append(res, p)
+		}
+	}
+	sortProblems(res)
+	return res
+}
+
+const unknownKeyMessage = "unknown key"
+
+// checkKeys walks settings alongside the struct type t. Keys that are not
+// fields of t are reported as unknown, with the closest field as a
+// suggestion, and each other value is decoded into its field on its own so
+// that all bad values are reported.
+func checkKeys(settings map[string]any, t reflect.Type, prefix string) []Problem {
+	fields := yamlFields(t)
+	var problems []Problem
+	for key, value := range settings {
+		path := joinKey(prefix, key)
+		field, ok := fields[key]
+		if !ok {
+			problems = append(problems, Problem{Key: path, Message: unknownKeyMessage + suggestKey(key, fields)})
+			continue
+		}
+		problems = append(problems, checkValue(value, field.Type, path)...)
+	}
+	return problems
+}
+
+func checkValue(value any, t reflect.Type, path string) []Problem {
+	switch t.Kind() {
+	case reflect.Struct:
+		section, ok := value.(map[string]any)
+		if !ok {
+			if value == nil {
+				return nil
+			}
+			return []Problem{{Key: path, Message: fmt.Sprintf("expected a section of keys, got %s", describeValue(value))}}
+		}
+		return checkKeys(section, t, path)
+	case reflect.Map:
+		entries, ok := value.(map[string]any)
+		if !ok {
+			if value == nil {
+				return nil
+			}
+			return []Problem{{Key: path, Message: fmt.Sprintf("expected a section of keys, got %s", describeValue(value))}}
+		}
+		var problems []Problem
+		for key, entry := range entries {
+			entryPath := joinKey(path, key)
+			if err := decodeValue(key, reflect.New(t.Key()).Interface()); err != nil {
+				problems = append(problems, Problem{Key: entryPath, Message: cleanDecodeError(err)})
+				continue
+			}
+			problems = append(problems, checkValue(entry, t.Elem(), entryPath)...)
+		}
+		return problems
+	}
+	if err := decodeValue(value, reflect.New(t).Interface()); err != nil {
+		return []Problem{{Key: path, Message: cleanDecodeError(err)}}
+	}
+	return nil
+}
+
+// checkValues returns a problem for each value of c that decodes but is out
+// of range. It runs on the merged config, so it also covers environment
+// variables and flags.
+func (c *Config) checkValues() []Problem {
+	var problems []Problem
+	add := func(key, format string, args ...any) {
+		problems = append(problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+	if c.Search.PageSize < 1 {
+		add("search.page-size", "must be at least 1, got %d", c.Search.PageSize)
+	}
+	if c.Search.MaxPages == 0 || c.Search.MaxPages < -1 {
+		add("search.max-pages", "must be at least 1, or -1 for all pages, got %d", c.Search.MaxPages)
+	}
+	if c.Search.ConfirmPages < 0 {
+		add("search.confirm-pages", "must be at least 0, got %d", c.Search.ConfirmPages)
+	}
+	if c.Search.PageCap < 0 {
+		add("search.page-cap", "must be at least 0, got %d", c.Search.PageCap)
+	}
+	if c.Search.SavedRuns < 0 {
+		add("search.saved-runs", "must be at least 0, got %d", c.Search.SavedRuns)
+	}
+	if c.RetryStrategy.MaxDelay > 0 && c.RetryStrategy.MaxDelay < c.RetryStrategy.BaseDelay {
+		add("retry-strategy.max-delay", "must not be less than retry-strategy.base-delay (%s), got %s", c.RetryStrategy.BaseDelay, c.RetryStrategy.MaxDelay)
+	}
+	if c.MemoSize < 0 {
+		add("memo-size", "must be at least 0, got %d", c.MemoSize)
+	}
+	if c.Forward.Splunk.BatchSize < 1 {
+		add("forward.splunk.batch-size", "must be at least 1, got %d", c.Forward.Splunk.BatchSize)
+	}
+	for key, raw := range map[string]string{
+		"forward.splunk.url": c.Forward.Splunk.URL,
+		"whois.rdap-url":     c.Whois.RDAPURL,
+		"dns.doh-url":        c.DNS.DoHURL,
+		"tracing.endpoint":   c.Tracing.Endpoint,
+	} {
+		if err := checkHTTPURL(raw); err != nil {
+			add(key, "%v", err)
+		}
+	}
+	if err := searchurl.Validate(c.Search.URLTemplate); err != nil {
+		add("search.url-template", "%v", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(c.DNS.Resolver)) {
+	case "", "system", "doh":
+	default:
+		add("dns.resolver", "must be system or doh, got %q", c.DNS.Resolver)
+	}
+	if _, err := styles.ParseTheme(string(c.Theme.Name)); err != nil {
+		add("theme.name", "%v", err)
+	}
+	for command, theme := range c.Theme.Commands {
+		if _, err := styles.ParseTheme(string(theme)); err != nil {
+			add("theme.commands."+command, "%v", err)
+		}
+	}
+	switch c.APIFlavor {
+	case APIFlavorPlatform, APIFlavorLegacy:
+	default:
+		add("api-flavor", "must be platform or legacy, got %q", c.APIFlavor)
+	}
+	switch c.Scope.OutOfScope {
+	case OutOfScopeFail, OutOfScopeWarn:
+	default:
+		add("scope.out-of-scope", "must be fail or warn, got %q", c.Scope.OutOfScope)
+	}
+	for i, header := range c.Tracing.Headers {
+		if key, _, ok := strings.Cut(header, "="); !ok || strings.TrimSpace(key) == "" {
+			add(fmt.Sprintf("tracing.headers[%d]", i), "must be key=value")
+		}
+	}
+	for i, feed := range c.Xref.Feeds {
+		if strings.TrimSpace(feed.Source) == "" {
+			add(fmt.Sprintf("xref.feeds[%d].source", i), "is required")
+		}
+	}
+	sortProblems(problems)
+	return problems
+}
+
+// checkHTTPURL checks that raw is empty or an absolute http(s) URL.
+func checkHTTPURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an http or https URL, got %q", raw)
+	}
+	return nil
+}
+
+// yamlFields returns the fields of the struct type t by their yaml key.
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// expandKeys returns settings with lowercase keys, and keys with dots (such
+// as retry-strategy.backoff, which viper reads as nested keys) expanded into
+// sections.
+func expandKeys(settings map[string]any) map[string]any {
+	res := make(map[string]any, len(settings))
+	for key, value := range settings {
+		if section, ok := value.(map[string]any); ok {
+			value = expandKeys(section)
+		}
+		parts := strings.Split(strings.ToLower(key), ".")
+		target := res
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := target[part].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				target[part] = next
+			}
+			target = next
+		}
+		last := parts[len(parts)-1]
+		existing, existingOK := target[last].(map[string]any)
+		section, sectionOK := value.(map[string]any)
+		if existingOK && sectionOK {
+			for k, v := range section {
+				existing[k] = v
+			}
+			continue
+		}
+		target[last] = value
+	}
+	return res
+}
+
+// decodeValue decodes value into result the way Unmarshal does.
+func decodeValue(value any, result any) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       decodeHooks(),
+		WeaklyTypedInput: true,
+		Result:           result,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(value)
+}
+
+// cleanDecodeError returns the message of a decoding error without the
+// preamble and field names that mapstructure adds.
+func cleanDecodeError(err error) string {
+	var decodeErr *mapstructure.DecodeError
+	if errors.As(err, &decodeErr) && decodeErr.Unwrap() != nil {
+		err = decodeErr.Unwrap()
+	}
+	msg := err.Error()
+	if _, rest, ok := strings.Cut(msg, "error(s):\n\n"); ok {
+		msg = rest
+	}
+	msg = strings.TrimSpace(strings.TrimPrefix(msg, "* "))
+	msg = strings.TrimPrefix(msg, "'' ")
+	return msg
+}
+
+// suggestKey returns a hint naming the field closest to key, if any is
+// close enough to be a likely typo.
+func suggestKey(key string, fields map[string]reflect.StructField) string {
+	best, bestDistance := "", len(key)/2+1
+	for name := range fields {
+		if d := editdistance.Levenshtein(key, name); d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	if best == "" || bestDistance > len(key)/2 {
+		return ""
+	}
+	return fmt.Sprintf("; did you mean %q?", best)
+}
+
+func describeValue(value any) string {
+	switch value.(type) {
+	case []any:
+		return "a list"
+	case string:
+		return fmt.Sprintf("%q", value)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func sortProblems(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+}
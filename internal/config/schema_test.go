@@ -0,0 +1,170 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Problem
+	}{
+		{
+			name: "valid",
+			content: `output-format: yaml
+retry-strategy.backoff: linear
+search:
+  page-size: 50
+templates:
+  host:
+    path: /tmp/host.hbs
+xref:
+  feeds:
+    - name: c2
+      source: https://example.com/c2.txt
+`,
+		},
+		{
+			name:    "empty",
+			content: "",
+		},
+		{
+			name: "unknown keys with suggestions",
+			content: `serach:
+  page-size: 50
+search:
+  pagesize: 50
+spinner:
+  disabled: true
+  zzz: 1
+`,
+			want: []Problem{
+				{Key: "search.pagesize", Message: `unknown key; did you mean "page-size"?`},
+				{Key: "serach", Message: `unknown key; did you mean "search"?`},
+				{Key: "spinner.zzz", Message: "unknown key"},
+			},
+		},
+		{
+			name: "all bad values are reported",
+			content: `output-format: xml
+timeouts.http: 30
+retry-strategy:
+  backoff: random
+  max-attempts: -1
+search:
+  page-size: lots
+templates:
+  domain:
+    path: /tmp/domain.hbs
+`,
+			want: []Problem{
+				{Key: "output-format", Message: "invalid output format: xml"},
+				{Key: "retry-strategy.backoff", Message: "invalid backoff type: random"},
+				{Key: "retry-strategy.max-attempts", Message: "value cannot be negative"},
+				{Key: "search.page-size", Message: "cannot parse value as 'int64': strconv.ParseInt: invalid syntax"},
+				{Key: "templates.domain", Message: "unsupported template entity type: domain"},
+				{Key: "timeouts.http", Message: "missing unit in duration"},
+			},
+		},
+		{
+			name: "values out of range",
+			content: `api-flavor: v2
+search:
+  page-size: 0
+  max-pages: 0
+  url-template: https://sso.example.com/search
+memo-size: -1
+scope:
+  out-of-scope: block
+theme:
+  name: neon
+  commands:
+    censeye: sepia
+retry-strategy:
+  base-delay: 10s
+  max-delay: 1s
+whois:
+  rdap-url: rdap.org
+dns:
+  resolver: bind
+xref:
+  feeds:
+    - name: c2
+`,
+			want: []Problem{
+				{Key: "api-flavor", Message: `must be platform or legacy, got "v2"`},
+				{Key: "dns.resolver", Message: `must be system or doh, got "bind"`},
+				{Key: "memo-size", Message: "must be at least 0, got -1"},
+				{Key: "retry-strategy.max-delay", Message: "must not be less than retry-strategy.base-delay (10s), got 1s"},
+				{Key: "scope.out-of-scope", Message: `must be fail or warn, got "block"`},
+				{Key: "search.max-pages", Message: "must be at least 1, or -1 for all pages, got 0"},
+				{Key: "search.page-size", Message: "must be at least 1, got 0"},
+				{Key: "search.url-template", Message: "must contain the {query} placeholder"},
+				{Key: "theme.commands.censeye", Message: `unknown theme "sepia"; use one of default, high-contrast, monochrome, solarized, colorblind`},
+				{Key: "theme.name", Message: `unknown theme "neon"; use one of default, high-contrast, monochrome, solarized, colorblind`},
+				{Key: "whois.rdap-url", Message: `must be an http or https URL, got "rdap.org"`},
+				{Key: "xref.feeds[0].source", Message: "is required"},
+			},
+		},
+		{
+			name:    "section given a value",
+			content: "search: 10\n",
+			want:    []Problem{{Key: "search", Message: "expected a section of keys, got 10"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+
+			problems, err := CheckFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, problems)
+		})
+	}
+}
+
+func TestCheckFile_InvalidYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("search: [\n"), 0o644))
+
+	problems, err := CheckFile(path)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Message, "invalid YAML")
+}
+
+func TestNew_RejectsSchemaProblems(t *testing.T) {
+	tempDir, cleanup := setupConfigTest(t)
+	defer cleanup()
+
+	writeConfigFile(t, tempDir, "serach:\n  page-size: 50\nsearch:\n  page-size: 0\n")
+
+	_, err := New(tempDir)
+	var schemaErr SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, []Problem{
+		{Key: "serach", Message: `unknown key; did you mean "search"?`},
+		{Key: "search.page-size", Message: "must be at least 1, got 0"},
+	}, schemaErr.Problems())
+	assert.Contains(t, err.Error(), "found 2 problems in "+filepath.Join(tempDir, "config.yaml"))
+}
+
+func TestNew_RejectsEnvironmentOutOfRange(t *testing.T) {
+	tempDir, cleanup := setupConfigTest(t)
+	defer cleanup()
+
+	t.Setenv("CENCLI_SEARCH_PAGE_SIZE", "0")
+
+	_, err := New(tempDir)
+	var schemaErr SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, []Problem{{Key: "search.page-size", Message: "must be at least 1, got 0"}}, schemaErr.Problems())
+}
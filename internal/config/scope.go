@@ -0,0 +1,23 @@
+package config
+
+// OutOfScopeMode is what happens when a command would query an asset out of
+// the scope file.
+type OutOfScopeMode string
+
+const (
+	// OutOfScopeFail fails the command before the request is sent.
+	OutOfScopeFail OutOfScopeMode = "fail"
+	// OutOfScopeWarn prints a warning and sends the request anyway.
+	OutOfScopeWarn OutOfScopeMode = "warn"
+)
+
+// ScopeConfig restricts the assets commands query.
+type ScopeConfig struct {
+	// File lists the IP ranges and domains in scope. Empty means no scope.
+	File       string         `yaml:"file" mapstructure:"file" doc:"File of allowed (and !denied) CIDRs and domains; commands that would query assets outside of it fail"`
+	OutOfScope OutOfScopeMode `yaml:"out-of-scope" mapstructure:"out-of-scope" doc:"What to do when a command would query an asset out of scope (fail|warn)"`
+}
+
+var defaultScopeConfig = ScopeConfig{
+	OutOfScope: OutOfScopeFail,
+}
@@ -1,6 +1,8 @@
 package config
 
-// SearchConfig contains defaults for search pagination.
+import "github.com/censys/cencli/internal/pkg/searchurl"
+
+// SearchConfig contains defaults for search pagination and search links.
 type SearchConfig struct {
 	// PageSize sets the default number of results per page for search.
 	// Must be >= 1.
@@ -8,9 +10,24 @@ type SearchConfig struct {
 	// MaxPages limits the number of pages fetched. Set to -1 for unlimited.
 	// 0 is invalid and will be rejected.
 	MaxPages int64 `yaml:"max-pages" mapstructure:"max-pages" doc:"Number of pages to fetch (max is 100)"`
+	// ConfirmPages is the estimated page count above which `search --all-pages`
+	// asks for confirmation before fetching. 0 disables the prompt.
+	ConfirmPages int64 `yaml:"confirm-pages" mapstructure:"confirm-pages" doc:"Ask for confirmation when --all-pages would fetch more than this many pages (0 to never ask)"`
+	// PageCap is the most pages `search --all-pages` fetches. 0 removes the cap.
+	PageCap int64 `yaml:"page-cap" mapstructure:"page-cap" doc:"Most pages --all-pages fetches, even with --yes (0 for no cap)"`
+	// SavedRuns is the number of recent searches whose hits are kept for
+	// `search --refine`. 0 disables saving.
+	SavedRuns int64 `yaml:"saved-runs" mapstructure:"saved-runs" doc:"Number of recent searches whose hits are kept for --refine (0 to keep none)"`
+	// URLTemplate is the template of the links to platform searches that
+	// censeye, rarity, and pivot print. See package searchurl.
+	URLTemplate string `yaml:"url-template" mapstructure:"url-template" doc:"Template of the links to platform searches, with {query} and {org_id} placeholders, e.g. to go through an SSO portal"`
 }
 
 var defaultSearchConfig = SearchConfig{
-	PageSize: 100,
-	MaxPages: 1,
+	PageSize:     100,
+	MaxPages:     1,
+	ConfirmPages: 10,
+	PageCap:      1000,
+	SavedRuns:    10,
+	URLTemplate:  searchurl.DefaultTemplate,
 }
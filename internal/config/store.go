@@ -3,4 +3,7 @@ package config
 const (
 	AuthName        = "personal-access-token"
 	OrgIDGlobalName = "org-id"
+	// RedactSaltGlobalName is the salt of --redact generated on its first
+	// use, when redact.salt is not set.
+	RedactSaltGlobalName = "redact-salt"
 )
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// ThemeConfig selects the color theme of the output.
+type ThemeConfig struct {
+	Name styles.Theme `yaml:"name" mapstructure:"name" doc:"Color theme (default|high-contrast|monochrome|solarized|colorblind)"`
+	// Commands are the themes of commands by their path without the root
+	// command, e.g. censeye or "org members".
+	Commands map[string]styles.Theme `yaml:"commands" mapstructure:"commands" doc:"Color theme by command, overriding name, e.g. {censeye: colorblind}"`
+}
+
+var defaultThemeConfig = ThemeConfig{
+	Name: styles.ThemeDefault,
+}
+
+// For returns the theme of the command at path, e.g. "org members": the
+// theme configured for the command, or else for its top-level command, or
+// else Name.
+func (c ThemeConfig) For(path string) styles.Theme {
+	if theme, ok := c.Commands[path]; ok {
+		return theme
+	}
+	top, _, _ := strings.Cut(path, " ")
+	if theme, ok := c.Commands[top]; ok {
+		return theme
+	}
+	return c.Name
+}
@@ -0,0 +1,23 @@
+package config
+
+// TracingConfig configures exporting the traces of each run to an
+// OpenTelemetry collector. Tracing is off unless an endpoint is set.
+type TracingConfig struct {
+	// Endpoint is the OTLP/HTTP endpoint of the collector. An endpoint
+	// without a path is sent to /v1/traces.
+	Endpoint string `yaml:"endpoint" mapstructure:"endpoint" doc:"OTLP/HTTP endpoint that traces are sent to, e.g. http://localhost:4318 (empty to disable tracing)"`
+	// Headers are sent with each export, as key=value.
+	Headers []string `yaml:"headers" mapstructure:"headers" secret:"true" doc:"Headers sent to the endpoint as key=value, e.g. [authorization=Bearer <token>]"`
+	// ServiceName is the service.name of the exported spans.
+	ServiceName string `yaml:"service-name" mapstructure:"service-name" doc:"service.name of the exported spans"`
+}
+
+// Enabled returns true if traces are exported.
+func (t TracingConfig) Enabled() bool {
+	return t.Endpoint != ""
+}
+
+var defaultTracingConfig = TracingConfig{
+	Headers:     []string{},
+	ServiceName: "cencli",
+}
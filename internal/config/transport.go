@@ -0,0 +1,26 @@
+package config
+
+import (
+	"time"
+)
+
+// TransportConfig tunes the connection pool of the API client.
+type TransportConfig struct {
+	MaxIdleConns        uint64        `yaml:"max-idle-conns" mapstructure:"max-idle-conns" doc:"Maximum idle connections kept across all hosts"`
+	MaxIdleConnsPerHost uint64        `yaml:"max-idle-conns-per-host" mapstructure:"max-idle-conns-per-host" doc:"Maximum idle connections kept per host; raise it for runs with more concurrent requests"`
+	IdleTimeout         time.Duration `yaml:"idle-timeout" mapstructure:"idle-timeout" doc:"How long an idle connection is kept open (e.g. 90s)"`
+	KeepAlive           time.Duration `yaml:"keepalive" mapstructure:"keepalive" doc:"Interval of TCP keepalive probes (e.g. 30s). Set to 0 for the Go default of 15s"`
+	HTTP2               bool          `yaml:"http2" mapstructure:"http2" doc:"Use HTTP/2 when the server supports it"`
+}
+
+var defaultTransportConfig = TransportConfig{
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 32,
+	IdleTimeout:         90 * time.Second,
+	KeepAlive:           30 * time.Second,
+	HTTP2:               true,
+}
+
+// DefaultTransportConfig returns the connection pool settings used when the
+// config does not set them.
+func DefaultTransportConfig() TransportConfig { return defaultTransportConfig }
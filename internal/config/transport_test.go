@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		tempDir, cleanup := setupConfigTest(t)
+		defer cleanup()
+
+		cfg, err := New(tempDir)
+		require.NoError(t, err)
+		assert.Equal(t, defaultTransportConfig, cfg.Transport)
+	})
+
+	t.Run("from file", func(t *testing.T) {
+		tempDir, cleanup := setupConfigTest(t)
+		defer cleanup()
+
+		writeConfigFile(t, tempDir, "transport:\n  max-idle-conns-per-host: 64\n  idle-timeout: 2m\n  http2: false\n")
+
+		cfg, err := New(tempDir)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(64), cfg.Transport.MaxIdleConnsPerHost)
+		assert.Equal(t, 2*time.Minute, cfg.Transport.IdleTimeout)
+		assert.False(t, cfg.Transport.HTTP2)
+		assert.Equal(t, defaultTransportConfig.MaxIdleConns, cfg.Transport.MaxIdleConns)
+	})
+
+	t.Run("environment override", func(t *testing.T) {
+		tempDir, cleanup := setupConfigTest(t)
+		defer cleanup()
+
+		t.Setenv("CENCLI_TRANSPORT_KEEPALIVE", "10s")
+
+		cfg, err := New(tempDir)
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Second, cfg.Transport.KeepAlive)
+	})
+
+	t.Run("negative idle timeout", func(t *testing.T) {
+		tempDir, cleanup := setupConfigTest(t)
+		defer cleanup()
+
+		writeConfigFile(t, tempDir, "transport.idle-timeout: -1s\n")
+
+		_, err := New(tempDir)
+		require.ErrorContains(t, err, "value cannot be negative")
+	})
+}
@@ -14,13 +14,13 @@ import (
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 )
 
-// setViperDefaults automatically sets viper values from a config struct using yaml tags
-func setViperDefaults(cfg *Config) cenclierrors.CencliError {
-	return setViperDefaultsHelper(reflect.ValueOf(cfg).Elem(), reflect.TypeOf(cfg).Elem(), "")
+// setViperDefaults automatically sets the defaults of vp from a config struct using yaml tags
+func setViperDefaults(vp *viper.Viper, cfg *Config) cenclierrors.CencliError {
+	return setViperDefaultsHelper(vp, reflect.ValueOf(cfg).Elem(), reflect.TypeOf(cfg).Elem(), "")
 }
 
 // setViperDefaultsHelper handles nested structs, slices, and maps
-func setViperDefaultsHelper(v reflect.Value, t reflect.Type, prefix string) cenclierrors.CencliError {
+func setViperDefaultsHelper(vp *viper.Viper, v reflect.Value, t reflect.Type, prefix string) cenclierrors.CencliError {
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
 		fieldType := t.Field(i)
@@ -40,7 +40,7 @@ func setViperDefaultsHelper(v reflect.Value, t reflect.Type, prefix string) cenc
 		}
 
 		// Handle the field value based on its type
-		if err := setViperValue(field, key); err != nil {
+		if err := setViperValue(vp, field, key); err != nil {
 			return newInvalidConfigErrorWithKey(key, err.Error())
 		}
 	}
@@ -49,58 +49,67 @@ func setViperDefaultsHelper(v reflect.Value, t reflect.Type, prefix string) cenc
 }
 
 // setViperValue sets a viper value based on the field's type and value
-func setViperValue(field reflect.Value, key string) error {
+func setViperValue(vp *viper.Viper, field reflect.Value, key string) error {
 	// Handle time.Duration specially before checking Kind() since it has underlying type int64
 	if duration, ok := field.Interface().(time.Duration); ok {
-		viper.SetDefault(key, duration.String())
+		vp.SetDefault(key, duration.String())
 		return nil
 	}
 
 	switch field.Kind() {
 	case reflect.String:
-		viper.SetDefault(key, field.String())
+		vp.SetDefault(key, field.String())
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		viper.SetDefault(key, field.Int())
+		vp.SetDefault(key, field.Int())
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		viper.SetDefault(key, field.Uint())
+		vp.SetDefault(key, field.Uint())
 	case reflect.Bool:
-		viper.SetDefault(key, field.Bool())
+		vp.SetDefault(key, field.Bool())
 	case reflect.Float32, reflect.Float64:
-		viper.SetDefault(key, field.Float())
+		vp.SetDefault(key, field.Float())
 	case reflect.Slice, reflect.Array:
 		// Handle slices and arrays - this ensures empty slices show as [] in YAML
 		slice := make([]interface{}, field.Len())
 		for i := 0; i < field.Len(); i++ {
 			slice[i] = field.Index(i).Interface()
 		}
-		viper.SetDefault(key, slice)
+		vp.SetDefault(key, slice)
 	case reflect.Map:
 		// Handle maps - this ensures empty maps show as {} in YAML
 		if field.IsNil() {
-			viper.SetDefault(key, map[string]interface{}{})
+			vp.SetDefault(key, map[string]interface{}{})
+		} else if field.Type().Elem().Kind() == reflect.Struct && field.Len() > 0 {
+			// set each key of struct values, e.g. templates.host.path, so that
+			// they can be overridden on their own
+			for _, mapKey := range field.MapKeys() {
+				entryKey := fmt.Sprintf("%s.%v", key, mapKey.Interface())
+				if err := setViperDefaultsHelper(vp, field.MapIndex(mapKey), field.Type().Elem(), entryKey); err != nil {
+					return err
+				}
+			}
 		} else {
 			mapValue := make(map[string]interface{})
 			for _, mapKey := range field.MapKeys() {
 				mapValue[fmt.Sprintf("%v", mapKey.Interface())] = field.MapIndex(mapKey).Interface()
 			}
 			// Always set the map, even if empty, to ensure it shows in YAML
-			viper.SetDefault(key, mapValue)
+			vp.SetDefault(key, mapValue)
 		}
 	case reflect.Struct:
 		// Handle nested structs recursively
-		return setViperDefaultsHelper(field, field.Type(), key)
+		return setViperDefaultsHelper(vp, field, field.Type(), key)
 	case reflect.Ptr:
 		// Handle pointers
 		if !field.IsNil() {
-			return setViperValue(field.Elem(), key)
+			return setViperValue(vp, field.Elem(), key)
 		}
-		viper.SetDefault(key, nil)
+		vp.SetDefault(key, nil)
 	default:
 		// For custom types, check if they have a String() method
 		if stringer, ok := field.Interface().(interface{ String() string }); ok {
-			viper.SetDefault(key, stringer.String())
+			vp.SetDefault(key, stringer.String())
 		} else {
-			viper.SetDefault(key, field.Interface())
+			vp.SetDefault(key, field.Interface())
 		}
 	}
 
@@ -201,7 +210,7 @@ func addCommentToLine(line string, docMap map[string]string) string {
 	var found bool
 
 	for fullKey, docComment := range docMap {
-		if strings.HasSuffix(fullKey, key) || fullKey == key {
+		if strings.HasSuffix(fullKey, "."+key) || fullKey == key {
 			doc = docComment
 			found = true
 			break
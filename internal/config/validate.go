@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/viper"
+)
+
+// FilePath returns the path of the config file that was loaded, or an empty
+// string if no config file has been read.
+func FilePath() string {
+	return viper.ConfigFileUsed()
+}
+
+// Validate re-checks the loaded configuration against the filesystem and
+// returns a description of each problem found. Files referenced by the
+// config can disappear after startup, so this reports what New would reject
+// on the next run.
+func (c *Config) Validate() []string {
+	var problems []string
+	if path := FilePath(); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			problems = append(problems, fmt.Sprintf("config file %s is not readable: %v", path, err))
+		}
+	}
+	entities := make([]string, 0, len(c.Templates))
+	for entity := range c.Templates {
+		entities = append(entities, string(entity))
+	}
+	sort.Strings(entities)
+	for _, entity := range entities {
+		template := c.Templates[TemplateEntity(entity)]
+		if template.Path == "" {
+			continue
+		}
+		if _, err := os.Stat(template.Path); err != nil {
+			problems = append(problems, fmt.Sprintf("%s template %s is not readable: %v", entity, template.Path, err))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,11 @@
+package config
+
+// WhoisConfig configures the `whois` command.
+type WhoisConfig struct {
+	// RDAPURL is the RDAP server queried when the Censys host document has no whois record.
+	RDAPURL string `yaml:"rdap-url" mapstructure:"rdap-url" doc:"RDAP server (or bootstrap service) queried for registration data"`
+}
+
+var defaultWhoisConfig = WhoisConfig{
+	RDAPURL: "https://rdap.org",
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+// XrefConfig configures the threat feeds that `search` and `view` results are
+// cross-referenced against.
+type XrefConfig struct {
+	// Feeds are cross-referenced on every run, unless --no-xref is set.
+	Feeds []XrefFeed `yaml:"feeds" mapstructure:"feeds" doc:"Indicator lists checked against results, e.g. [{name: c2, source: https://example.com/c2.txt}]"`
+	// Refresh is how long a feed fetched from a URL is used before it is fetched again.
+	Refresh time.Duration `yaml:"refresh" mapstructure:"refresh" doc:"How long a feed fetched from a URL is cached before it is fetched again"`
+}
+
+// XrefFeed is an indicator list.
+type XrefFeed struct {
+	// Name is shown with each match. Empty uses the base name of the source.
+	Name string `yaml:"name" mapstructure:"name"`
+	// Source is a file path or an http(s) URL.
+	Source string `yaml:"source" mapstructure:"source"`
+}
+
+var defaultXrefConfig = XrefConfig{
+	Feeds:   []XrefFeed{},
+	Refresh: 24 * time.Hour,
+}
@@ -0,0 +1,137 @@
+// Package alias expands user-defined command aliases, in the manner of git
+// aliases. An alias is a name for the words of a command line, such as
+//
+//	search 'host.services.protocol=RDP and host.location.country={1}' --max-pages 5
+//
+// The expansion is split into words as a shell would, with single and
+// double quotes, and {1}, {2}, and so on are replaced by the arguments that
+// follow the alias. The arguments that fill no placeholder are appended.
+package alias
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	namePattern        = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	placeholderPattern = regexp.MustCompile(`\{([1-9][0-9]*)\}`)
+	// plainPattern matches the words that need no quotes.
+	plainPattern = regexp.MustCompile(`^[A-Za-z0-9._:/=,@%+{}-]+$`)
+)
+
+// ValidateName returns an error unless name is lowercase letters, digits,
+// and dashes, starting with a letter or digit.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q: use lowercase letters, digits, and dashes", name)
+	}
+	return nil
+}
+
+// Split splits s into words as a POSIX shell would, without expanding
+// variables: words are separated by whitespace, single quotes keep
+// everything up to the next single quote, and in double quotes and outside
+// quotes, a backslash escapes the next character.
+func Split(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			word.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '"':
+			i++
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(`"\$`+"`", s[i+1]) >= 0 {
+					i++
+				}
+				word.WriteByte(s[i])
+			}
+			if i == len(s) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inWord = true
+		case c == '\\':
+			if i+1 == len(s) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			i++
+			word.WriteByte(s[i])
+			inWord = true
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// Join quotes words as needed so that Split returns them, e.g. to store the
+// words of a command line as an expansion.
+func Join(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		if plainPattern.MatchString(w) {
+			quoted[i] = w
+		} else {
+			quoted[i] = "'" + strings.ReplaceAll(w, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Placeholders returns the highest placeholder of expansion, e.g. 2 for
+// "search {1} --org-id {2}", which is the number of arguments it needs.
+func Placeholders(expansion string) int {
+	highest := 0
+	for _, m := range placeholderPattern.FindAllStringSubmatch(expansion, -1) {
+		if n, err := strconv.Atoi(m[1]); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return highest
+}
+
+// Expand returns the words of expansion with its placeholders replaced by
+// args, followed by the args that fill no placeholder. A placeholder is
+// replaced within its word, so an argument is never split into words.
+func Expand(expansion string, args []string) ([]string, error) {
+	words, err := Split(expansion)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("the expansion is empty")
+	}
+	needed := Placeholders(expansion)
+	if len(args) < needed {
+		return nil, fmt.Errorf("needs %d argument(s) for {1} to {%d}, got %d", needed, needed, len(args))
+	}
+	for i, w := range words {
+		words[i] = placeholderPattern.ReplaceAllStringFunc(w, func(match string) string {
+			n, _ := strconv.Atoi(match[1 : len(match)-1])
+			return args[n-1]
+		})
+	}
+	return append(words, args[needed:]...), nil
+}
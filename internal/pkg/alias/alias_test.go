@@ -0,0 +1,67 @@
+package alias
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	for in, want := range map[string][]string{
+		`search 'host.services.protocol="RDP"' --max-pages 5`: {"search", `host.services.protocol="RDP"`, "--max-pages", "5"},
+		`view "a b" c\ d`:        {"view", "a b", "c d"},
+		`search "say \"hi\" \n"`: {"search", `say "hi" \n`},
+		`search x' and 'y`:       {"search", "x and y"},
+		"  view\t8.8.8.8\n":      {"view", "8.8.8.8"},
+		`search ''`:              {"search", ""},
+		``:                       nil,
+	} {
+		got, err := Split(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, in := range []string{`search 'x`, `search "x`, `search x\`} {
+		_, err := Split(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	words := []string{"search", `host.services.protocol="RDP" and x={1}`, "--max-pages", "5", "it's", ""}
+	joined := Join(words)
+	assert.Equal(t, `search 'host.services.protocol="RDP" and x={1}' --max-pages 5 'it'\''s' ''`, joined)
+	split, err := Split(joined)
+	require.NoError(t, err)
+	assert.Equal(t, words, split)
+}
+
+func TestExpand(t *testing.T) {
+	const rdp = `search 'host.services.protocol="RDP" and host.location.country={1}' --max-pages 5`
+
+	got, err := Expand(rdp, []string{"Germany", "-O", "json"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"search", `host.services.protocol="RDP" and host.location.country=Germany`, "--max-pages", "5", "-O", "json"}, got)
+
+	got, err = Expand("view {2} --at-time {1}", []string{"2025-01-01", "8.8.8.8 9.9.9.9"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"view", "8.8.8.8 9.9.9.9", "--at-time", "2025-01-01"}, got)
+
+	got, err = Expand("view --output-format short", []string{"8.8.8.8"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"view", "--output-format", "short", "8.8.8.8"}, got)
+
+	_, err = Expand(rdp, nil)
+	assert.EqualError(t, err, "needs 1 argument(s) for {1} to {1}, got 0")
+	_, err = Expand("  ", nil)
+	assert.EqualError(t, err, "the expansion is empty")
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"rdp", "c2-hunt", "9x"} {
+		assert.NoError(t, ValidateName(name), name)
+	}
+	for _, name := range []string{"", "RDP", "-x", "a.b", "a b"} {
+		assert.Error(t, ValidateName(name), name)
+	}
+}
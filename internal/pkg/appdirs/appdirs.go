@@ -0,0 +1,134 @@
+// Package appdirs resolves the directories cencli stores files in, following
+// the XDG base directory specification:
+//
+//   - config: settings and templates ($XDG_CONFIG_HOME/cencli, default ~/.config/cencli)
+//   - data:   the local database with credentials, watches, and sessions
+//     ($XDG_DATA_HOME/cencli, default ~/.local/share/cencli)
+//   - cache:  files that can be safely deleted ($XDG_CACHE_HOME/cencli, default ~/.cache/cencli)
+//
+// On Windows, the XDG variables are not used. Config lives in %APPDATA%\cencli,
+// data in %LOCALAPPDATA%\cencli, and the cache in %LOCALAPPDATA%\cencli\cache.
+//
+// Each directory can be overridden with CENCLI_CONFIG_DIR, CENCLI_DATA_DIR, and
+// CENCLI_CACHE_DIR. For backwards compatibility, CENCLI_DATA_DIR also holds the
+// configuration (and a "cache" subdirectory) unless the other overrides are set,
+// since it used to hold everything.
+package appdirs
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+const appName = "cencli"
+
+// Environment variables that override the resolved directories.
+const (
+	EnvConfigDir = "CENCLI_CONFIG_DIR"
+	EnvDataDir   = "CENCLI_DATA_DIR"
+	EnvCacheDir  = "CENCLI_CACHE_DIR"
+)
+
+// XDG environment variables.
+const (
+	envXDGConfigHome = "XDG_CONFIG_HOME"
+	envXDGDataHome   = "XDG_DATA_HOME"
+	envXDGCacheHome  = "XDG_CACHE_HOME"
+)
+
+// Windows environment variables.
+const (
+	envAppData      = "APPDATA"
+	envLocalAppData = "LOCALAPPDATA"
+)
+
+// Dirs are the directories cencli stores files in.
+type Dirs struct {
+	// Config holds config.yaml and templates.
+	Config string
+	// Data holds the local database.
+	Data string
+	// Cache holds files that can be regenerated.
+	Cache string
+	// legacy is the pre-XDG directory that held everything, or empty if
+	// the directories were overridden and no migration should happen.
+	legacy string
+}
+
+// Resolve returns the directories for the given environment and home directory.
+// getenv is typically os.Getenv.
+func Resolve(getenv func(string) string, home string) Dirs {
+	return resolve(runtime.GOOS, getenv, home)
+}
+
+func resolve(goos string, getenv func(string) string, home string) Dirs {
+	configOverride := getenv(EnvConfigDir)
+	dataOverride := getenv(EnvDataDir)
+	cacheOverride := getenv(EnvCacheDir)
+
+	d := platformDirs(goos, getenv, home)
+	d.Config = firstNonEmpty(configOverride, dataOverride, d.Config)
+	d.Data = firstNonEmpty(dataOverride, d.Data)
+	switch {
+	case cacheOverride != "":
+		d.Cache = cacheOverride
+	case dataOverride != "":
+		d.Cache = filepath.Join(dataOverride, "cache")
+	}
+	if configOverride == "" && dataOverride == "" {
+		d.legacy = LegacyDir(home)
+	}
+	return d
+}
+
+// LegacyDir is the directory that held all of cencli's files before the
+// XDG layout was adopted.
+func LegacyDir(home string) string {
+	return filepath.Join(home, ".config", appName)
+}
+
+// platformDirs returns the default directories for goos.
+func platformDirs(goos string, getenv func(string) string, home string) Dirs {
+	if goos == "windows" {
+		local := filepath.Join(baseDir(getenv, envLocalAppData, home, "AppData", "Local"), appName)
+		return Dirs{
+			Config: filepath.Join(baseDir(getenv, envAppData, home, "AppData", "Roaming"), appName),
+			Data:   local,
+			Cache:  filepath.Join(local, "cache"),
+		}
+	}
+	return Dirs{
+		Config: filepath.Join(baseDir(getenv, envXDGConfigHome, home, ".config"), appName),
+		Data:   filepath.Join(baseDir(getenv, envXDGDataHome, home, ".local", "share"), appName),
+		Cache:  filepath.Join(baseDir(getenv, envXDGCacheHome, home, ".cache"), appName),
+	}
+}
+
+// Ensure creates the directories if they do not exist.
+func (d Dirs) Ensure() error {
+	for _, dir := range []string{d.Config, d.Data, d.Cache} {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// baseDir returns the base directory named by env, or home joined with
+// fallback. Relative paths are ignored, as required by the XDG specification.
+func baseDir(getenv func(string) string, env string, home string, fallback ...string) string {
+	if dir := getenv(env); dir != "" && filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(append([]string{home}, fallback...)...)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
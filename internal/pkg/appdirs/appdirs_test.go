@@ -0,0 +1,189 @@
+package appdirs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func envFunc(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func TestResolve(t *testing.T) {
+	home := "/home/user"
+
+	testCases := []struct {
+		name     string
+		goos     string
+		env      map[string]string
+		expected Dirs
+	}{
+		{
+			name: "defaults",
+			env:  nil,
+			expected: Dirs{
+				Config: "/home/user/.config/cencli",
+				Data:   "/home/user/.local/share/cencli",
+				Cache:  "/home/user/.cache/cencli",
+				legacy: "/home/user/.config/cencli",
+			},
+		},
+		{
+			name: "xdg variables",
+			env: map[string]string{
+				"XDG_CONFIG_HOME": "/xdg/config",
+				"XDG_DATA_HOME":   "/xdg/data",
+				"XDG_CACHE_HOME":  "/xdg/cache",
+			},
+			expected: Dirs{
+				Config: "/xdg/config/cencli",
+				Data:   "/xdg/data/cencli",
+				Cache:  "/xdg/cache/cencli",
+				legacy: "/home/user/.config/cencli",
+			},
+		},
+		{
+			name: "relative xdg variables are ignored",
+			env:  map[string]string{"XDG_DATA_HOME": "relative/data"},
+			expected: Dirs{
+				Config: "/home/user/.config/cencli",
+				Data:   "/home/user/.local/share/cencli",
+				Cache:  "/home/user/.cache/cencli",
+				legacy: "/home/user/.config/cencli",
+			},
+		},
+		{
+			name: "legacy data dir override holds everything",
+			env:  map[string]string{"CENCLI_DATA_DIR": "/custom"},
+			expected: Dirs{
+				Config: "/custom",
+				Data:   "/custom",
+				Cache:  "/custom/cache",
+			},
+		},
+		{
+			name: "windows",
+			goos: "windows",
+			env: map[string]string{
+				"APPDATA":         "/appdata/roaming",
+				"LOCALAPPDATA":    "/appdata/local",
+				"XDG_CONFIG_HOME": "/xdg/config",
+			},
+			expected: Dirs{
+				Config: "/appdata/roaming/cencli",
+				Data:   "/appdata/local/cencli",
+				Cache:  "/appdata/local/cencli/cache",
+				legacy: "/home/user/.config/cencli",
+			},
+		},
+		{
+			name: "windows without appdata variables",
+			goos: "windows",
+			expected: Dirs{
+				Config: "/home/user/AppData/Roaming/cencli",
+				Data:   "/home/user/AppData/Local/cencli",
+				Cache:  "/home/user/AppData/Local/cencli/cache",
+				legacy: "/home/user/.config/cencli",
+			},
+		},
+		{
+			name: "individual overrides",
+			env: map[string]string{
+				"CENCLI_CONFIG_DIR": "/c",
+				"CENCLI_DATA_DIR":   "/d",
+				"CENCLI_CACHE_DIR":  "/k",
+			},
+			expected: Dirs{Config: "/c", Data: "/d", Cache: "/k"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			goos := tc.goos
+			if goos == "" {
+				goos = "linux"
+			}
+			require.Equal(t, tc.expected, resolve(goos, envFunc(tc.env), home))
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestMigrateLegacy(t *testing.T) {
+	t.Run("moves database and config", func(t *testing.T) {
+		home := t.TempDir()
+		legacy := LegacyDir(home)
+		writeFile(t, filepath.Join(legacy, "cencli.db"), "db")
+		writeFile(t, filepath.Join(legacy, "cencli.db-wal"), "wal")
+		writeFile(t, filepath.Join(legacy, "templates", "host.hbs"), "{{ip}}")
+		writeFile(t, filepath.Join(legacy, "config.yaml"), "templates:\n  host:\n    path: "+filepath.Join(legacy, "templates", "host.hbs")+"\n")
+
+		xdgConfig := filepath.Join(home, "xdg-config")
+		dirs := resolve("linux", envFunc(map[string]string{"XDG_CONFIG_HOME": xdgConfig}), home)
+		moves, err := dirs.MigrateLegacy()
+		require.NoError(t, err)
+		require.Len(t, moves, 4)
+
+		raw, err := os.ReadFile(filepath.Join(dirs.Data, "cencli.db"))
+		require.NoError(t, err)
+		require.Equal(t, "db", string(raw))
+		require.FileExists(t, filepath.Join(dirs.Data, "cencli.db-wal"))
+		require.NoFileExists(t, filepath.Join(legacy, "cencli.db"))
+		require.FileExists(t, filepath.Join(dirs.Config, "templates", "host.hbs"))
+
+		raw, err = os.ReadFile(filepath.Join(dirs.Config, "config.yaml"))
+		require.NoError(t, err)
+		require.Contains(t, string(raw), filepath.Join(dirs.Config, "templates", "host.hbs"))
+
+		// a second run has nothing left to do
+		moves, err = dirs.MigrateLegacy()
+		require.NoError(t, err)
+		require.Empty(t, moves)
+	})
+
+	t.Run("config stays when the config dir is the legacy dir", func(t *testing.T) {
+		home := t.TempDir()
+		legacy := LegacyDir(home)
+		writeFile(t, filepath.Join(legacy, "cencli.db"), "db")
+		writeFile(t, filepath.Join(legacy, "config.yaml"), "quiet: false\n")
+
+		dirs := resolve("linux", envFunc(nil), home)
+		moves, err := dirs.MigrateLegacy()
+		require.NoError(t, err)
+		require.Equal(t, []Move{{From: filepath.Join(legacy, "cencli.db"), To: filepath.Join(dirs.Data, "cencli.db")}}, moves)
+		require.FileExists(t, filepath.Join(legacy, "config.yaml"))
+	})
+
+	t.Run("existing database is not overwritten", func(t *testing.T) {
+		home := t.TempDir()
+		dirs := resolve("linux", envFunc(nil), home)
+		writeFile(t, filepath.Join(LegacyDir(home), "cencli.db"), "old")
+		writeFile(t, filepath.Join(dirs.Data, "cencli.db"), "new")
+
+		moves, err := dirs.MigrateLegacy()
+		require.NoError(t, err)
+		require.Empty(t, moves)
+		raw, err := os.ReadFile(filepath.Join(dirs.Data, "cencli.db"))
+		require.NoError(t, err)
+		require.Equal(t, "new", string(raw))
+	})
+
+	t.Run("no migration with overrides", func(t *testing.T) {
+		home := t.TempDir()
+		writeFile(t, filepath.Join(LegacyDir(home), "cencli.db"), "db")
+		dirs := Resolve(envFunc(map[string]string{"CENCLI_DATA_DIR": filepath.Join(home, "custom")}), home)
+
+		moves, err := dirs.MigrateLegacy()
+		require.NoError(t, err)
+		require.Empty(t, moves)
+		require.FileExists(t, filepath.Join(LegacyDir(home), "cencli.db"))
+	})
+}
@@ -0,0 +1,166 @@
+package appdirs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// legacyDataFiles are moved from the legacy directory to the data directory.
+// They must match the database file name used by the store.
+var legacyDataFiles = []string{"cencli.db", "cencli.db-wal", "cencli.db-shm"}
+
+const (
+	configFileName   = "config.yaml"
+	templatesDirName = "templates"
+)
+
+// Move records a file or directory moved by MigrateLegacy.
+type Move struct {
+	From string
+	To   string
+}
+
+// MigrateLegacy moves files from the pre-XDG directory into the new layout.
+// A group of files is only moved when its destination does not exist yet, so
+// once the migration has happened it is a no-op. Config and template files are
+// only moved when the config directory differs from the legacy directory (i.e.
+// when XDG_CONFIG_HOME is set); template paths stored in config.yaml are
+// rewritten to point at the new location.
+func (d Dirs) MigrateLegacy() ([]Move, error) {
+	if d.legacy == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(d.legacy); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var moves []Move
+	if !sameDir(d.legacy, d.Data) && !exists(filepath.Join(d.Data, legacyDataFiles[0])) {
+		for _, name := range legacyDataFiles {
+			m, err := moveIfExists(filepath.Join(d.legacy, name), filepath.Join(d.Data, name))
+			if err != nil {
+				return moves, err
+			}
+			if m != nil {
+				moves = append(moves, *m)
+			}
+		}
+	}
+
+	if !sameDir(d.legacy, d.Config) && !exists(filepath.Join(d.Config, configFileName)) {
+		oldTemplates := filepath.Join(d.legacy, templatesDirName)
+		newTemplates := filepath.Join(d.Config, templatesDirName)
+		m, err := moveIfExists(oldTemplates, newTemplates)
+		if err != nil {
+			return moves, err
+		}
+		if m != nil {
+			moves = append(moves, *m)
+		}
+		m, err = moveIfExists(filepath.Join(d.legacy, configFileName), filepath.Join(d.Config, configFileName))
+		if err != nil {
+			return moves, err
+		}
+		if m != nil {
+			moves = append(moves, *m)
+			if err := rewritePaths(m.To, oldTemplates, newTemplates); err != nil {
+				return moves, err
+			}
+		}
+	}
+	return moves, nil
+}
+
+// moveIfExists moves from to to, returning nil if from does not exist.
+// Renames that cross filesystems fall back to copying.
+func moveIfExists(from, to string) (*Move, error) {
+	info, err := os.Stat(from)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(from, to); err != nil {
+		if copyErr := copyPath(from, to, info); copyErr != nil {
+			return nil, fmt.Errorf("failed to move %s to %s: %w", from, to, copyErr)
+		}
+		if err := os.RemoveAll(from); err != nil {
+			return nil, err
+		}
+	}
+	return &Move{From: from, To: to}, nil
+}
+
+// copyPath copies a file, or a directory of files, preserving permissions.
+func copyPath(from, to string, info os.FileInfo) error {
+	if !info.IsDir() {
+		return copyFile(from, to, info.Mode().Perm())
+	}
+	if err := os.MkdirAll(to, info.Mode().Perm()); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		entryInfo, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if err := copyPath(filepath.Join(from, entry.Name()), filepath.Join(to, entry.Name()), entryInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(from, to string, perm os.FileMode) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// rewritePaths replaces occurrences of oldDir with newDir in the file at path.
+func rewritePaths(path, oldDir, newDir string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	updated := strings.ReplaceAll(string(raw), oldDir, newDir)
+	if updated == string(raw) {
+		return nil
+	}
+	return os.WriteFile(path, []byte(updated), 0o644)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func sameDir(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
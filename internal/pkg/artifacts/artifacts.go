@@ -0,0 +1,132 @@
+// Package artifacts saves raw API response bodies in a content-addressed
+// directory: each body is stored once, in a file named after its SHA-256
+// digest, and an index records which command fetched which bodies.
+//
+// The layout of a store is:
+//
+//	objects/<sha256>   a response body, as received
+//	index.jsonl        one Entry per saved response, in the order they were saved
+package artifacts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	objectsDir = "objects"
+	indexFile  = "index.jsonl"
+)
+
+// Entry is a line of the index: a response saved by a command.
+type Entry struct {
+	// Command is the command line of the invocation that fetched the response.
+	Command string `json:"command"`
+	// StartedAt is when the invocation started; together with Command it
+	// groups the responses of an invocation.
+	StartedAt time.Time `json:"started_at"`
+	Method    string    `json:"method"`
+	URL       string    `json:"url"`
+	Status    int       `json:"status"`
+	// SHA256 is the digest of the body, and the name of its file in objects/.
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size"`
+}
+
+// Store is a content-addressed directory of response bodies.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// New returns the store in dir. The directory is created on the first save.
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the directory of the store.
+func (s *Store) Dir() string { return s.dir }
+
+// ObjectPath returns the path of the body with the given digest.
+func (s *Store) ObjectPath(digest string) string {
+	return filepath.Join(s.dir, objectsDir, digest)
+}
+
+// Digest returns the hex-encoded SHA-256 digest of body.
+func Digest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// Save stores body, unless a body with the same digest is already stored,
+// and appends entry to the index with the digest and size of body filled in.
+// It returns the completed entry.
+func (s *Store) Save(entry Entry, body []byte) (Entry, error) {
+	entry.SHA256 = Digest(body)
+	entry.Size = len(body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writeObject(entry.SHA256, body); err != nil {
+		return Entry{}, err
+	}
+	if err := s.appendIndex(entry); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// writeObject writes body to objects/<digest>. The file is written under a
+// temporary name and renamed, so that an object is never seen half-written.
+func (s *Store) writeObject(digest string, body []byte) error {
+	path := s.ObjectPath(digest)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to check artifact %s: %w", digest, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+digest+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to write artifact %s: %w", digest, err)
+	}
+	tmpName := tmp.Name()
+	_, writeErr := tmp.Write(body)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write artifact %s: %w", digest, err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write artifact %s: %w", digest, err)
+	}
+	return nil
+}
+
+func (s *Store) appendIndex(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(s.dir, indexFile), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open artifact index: %w", err)
+	}
+	_, writeErr := f.Write(append(line, '\n'))
+	closeErr := f.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		return fmt.Errorf("failed to write artifact index: %w", err)
+	}
+	return nil
+}
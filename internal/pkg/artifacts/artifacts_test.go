@@ -0,0 +1,54 @@
+package artifacts
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_Save(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	store := New(dir)
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	body := []byte(`{"result":{"hits":[]}}`)
+	first, err := store.Save(Entry{Command: "censys search '*'", StartedAt: started, Method: "POST", URL: "https://api.example/v3/global/search/query", Status: 200}, body)
+	require.NoError(t, err)
+	assert.Equal(t, Digest(body), first.SHA256)
+	assert.Equal(t, len(body), first.Size)
+
+	// the same body fetched again is stored once, and indexed twice
+	second, err := store.Save(Entry{Command: "censys search '*'", StartedAt: started.Add(time.Minute), Method: "POST", URL: "https://api.example/v3/global/search/query", Status: 200}, body)
+	require.NoError(t, err)
+	assert.Equal(t, first.SHA256, second.SHA256)
+
+	other, err := store.Save(Entry{Command: "censys view 1.1.1.1", StartedAt: started, Method: "POST", URL: "https://api.example/v3/global/asset/host", Status: 200}, []byte(`{"result":[]}`))
+	require.NoError(t, err)
+
+	saved, err := os.ReadFile(store.ObjectPath(first.SHA256))
+	require.NoError(t, err)
+	assert.Equal(t, body, saved)
+
+	objects, err := os.ReadDir(filepath.Join(dir, objectsDir))
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	f, err := os.Open(filepath.Join(dir, indexFile))
+	require.NoError(t, err)
+	defer f.Close()
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []Entry{first, second, other}, entries)
+}
@@ -0,0 +1,121 @@
+// Package assetfiles writes assets into a directory with one JSON file per
+// asset, named after its identifier, and an index.json manifest listing
+// them, so that report pipelines can pick up each asset on its own.
+package assetfiles
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// IndexName is the name of the manifest of a directory.
+const IndexName = "index.json"
+
+// maxNameLength bounds the length of file names, below the limit of common
+// file systems, so that long hostnames still make valid names.
+const maxNameLength = 128
+
+// Options configures a write.
+type Options struct {
+	// Command and Query describe the run, and are recorded in the manifest.
+	Command string
+	Query   string
+	// Now returns the time the run is recorded at. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Index is the manifest of a directory, written to index.json.
+type Index struct {
+	Command     string    `json:"command"`
+	Query       string    `json:"query,omitempty"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Assets      []Entry   `json:"assets"`
+}
+
+// Entry is an asset of the manifest.
+type Entry struct {
+	// ID is the identifier of the asset, or empty if it has none.
+	ID   string           `json:"id,omitempty"`
+	Type assets.AssetType `json:"type"`
+	// File is the name of the file of the asset, in the directory.
+	File string `json:"file"`
+}
+
+// Write writes each of items to its own file in dir, creating dir if needed,
+// then the manifest. Files of earlier writes are replaced if an asset is
+// written again, and kept otherwise; the manifest lists only this write.
+func Write(dir string, items []assets.Asset, opts Options) (Index, error) {
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+	index := Index{Command: opts.Command, Query: opts.Query, GeneratedAt: now().UTC(), Assets: []Entry{}}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return index, err
+	}
+	used := map[string]bool{strings.ToLower(IndexName): true}
+	for i, item := range items {
+		id := assets.Identifier(item)
+		base := FileName(id)
+		if base == "" {
+			base = fmt.Sprintf("%s-%d", item.AssetType(), i+1)
+		}
+		name := base + ".json"
+		// names are compared without case, for case-insensitive file systems
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			name = fmt.Sprintf("%s-%d.json", base, n)
+		}
+		used[strings.ToLower(name)] = true
+		if err := writeJSON(filepath.Join(dir, name), item); err != nil {
+			return index, err
+		}
+		index.Assets = append(index.Assets, Entry{ID: id, Type: item.AssetType(), File: name})
+	}
+	return index, writeJSON(filepath.Join(dir, IndexName), index)
+}
+
+// FileName returns a file name, without extension, for the asset with the
+// given identifier, that is valid on every common file system: characters
+// other than letters, digits, dots, dashes, and underscores are replaced
+// with underscores, so that 2001:db8::1 becomes 2001_db8__1 and
+// example.com:443 becomes example.com_443. Names too long for a file are
+// shortened and end with a hash of the identifier, to keep them apart. It
+// is empty if id is.
+func FileName(id string) string {
+	var sb strings.Builder
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('_')
+		}
+	}
+	name := sb.String()
+	// a leading dot would hide the file, and . and .. are not files
+	if strings.HasPrefix(name, ".") {
+		name = "_" + name[1:]
+	}
+	if len(name) > maxNameLength {
+		sum := sha256.Sum256([]byte(id))
+		suffix := "-" + hex.EncodeToString(sum[:])[:12]
+		name = name[:maxNameLength-len(suffix)] + suffix
+	}
+	return name
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
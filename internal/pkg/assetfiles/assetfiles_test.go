@@ -0,0 +1,85 @@
+package assetfiles
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+func TestFileName(t *testing.T) {
+	long := strings.Repeat("a", 200) + ".example.com:443"
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{id: "8.8.8.8", want: "8.8.8.8"},
+		{id: "2001:db8::1", want: "2001_db8__1"},
+		{id: "example.com:443", want: "example.com_443"},
+		{id: "3daf2843a77b6f4e6af43cd9b6f6746053b8c928e056138a724f5ac46e0a8ff9", want: "3daf2843a77b6f4e6af43cd9b6f6746053b8c928e056138a724f5ac46e0a8ff9"},
+		{id: "../etc/passwd", want: "_._etc_passwd"},
+		{id: "", want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.id, func(t *testing.T) {
+			assert.Equal(t, tc.want, FileName(tc.id))
+		})
+	}
+
+	t.Run("long names are hashed", func(t *testing.T) {
+		name := FileName(long)
+		assert.Len(t, name, maxNameLength)
+		assert.True(t, strings.HasPrefix(name, strings.Repeat("a", 100)))
+		assert.NotEqual(t, name, FileName(long+"0"))
+	})
+}
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	hostname, port := "a:b", 80
+	items := []assets.Asset{
+		assets.NewHost(components.Host{IP: ptr("8.8.8.8")}),
+		assets.NewWebProperty(components.Webproperty{Hostname: &hostname, Port: &port}),
+		assets.NewHost(components.Host{IP: ptr("a_b_80")}),
+		assets.NewHost(components.Host{IP: ptr("A_B_80")}),
+		assets.NewHost(components.Host{}),
+		assets.NewHost(components.Host{IP: ptr("index")}),
+	}
+
+	index, err := Write(dir, items, Options{Command: "view", Query: "8.8.8.8", Now: func() time.Time { return now }})
+	require.NoError(t, err)
+
+	assert.Equal(t, "view", index.Command)
+	assert.Equal(t, now, index.GeneratedAt)
+	assert.Equal(t, []Entry{
+		{ID: "8.8.8.8", Type: assets.AssetTypeHost, File: "8.8.8.8.json"},
+		{ID: "a:b:80", Type: assets.AssetTypeWebProperty, File: "a_b_80.json"},
+		{ID: "a_b_80", Type: assets.AssetTypeHost, File: "a_b_80-2.json"},
+		{ID: "A_B_80", Type: assets.AssetTypeHost, File: "A_B_80-3.json"},
+		{Type: assets.AssetTypeHost, File: "host-5.json"},
+		{ID: "index", Type: assets.AssetTypeHost, File: "index-2.json"},
+	}, index.Assets)
+
+	data, err := os.ReadFile(filepath.Join(dir, "8.8.8.8.json"))
+	require.NoError(t, err)
+	var host map[string]any
+	require.NoError(t, json.Unmarshal(data, &host))
+	assert.Equal(t, "8.8.8.8", host["ip"])
+
+	data, err = os.ReadFile(filepath.Join(dir, IndexName))
+	require.NoError(t, err)
+	var written Index
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, index, written)
+}
+
+func ptr[T any](v T) *T { return &v }
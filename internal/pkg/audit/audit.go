@@ -0,0 +1,250 @@
+// Package audit keeps a tamper-evident log of the API calls of the CLI, for
+// regulated environments that must account for every query sent.
+//
+// The log is a JSONL file with one entry per call. Each entry holds the hash
+// of the entry before it, and its own hash covers all of its fields, so an
+// entry cannot be changed, removed, or inserted without breaking the chain
+// from that point on. Only the last entries can be removed without a trace;
+// record the head of the chain (see Verify) elsewhere to detect that too.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+// tailChunk is how much of the end of the log is read at a time to find its
+// last entry.
+const tailChunk = 4096
+
+// Entry is an API call recorded in the log.
+type Entry struct {
+	// Seq is the position of the entry in the log, starting at 1.
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+	// Command is the CLI command that made the call, e.g. "search".
+	Command   string `json:"command,omitempty"`
+	Operation string `json:"operation"`
+	// Endpoint is the method and path of the request, when it was sent.
+	Endpoint string `json:"endpoint,omitempty"`
+	OrgID    string `json:"org_id,omitempty"`
+	// QueryHash is the SHA-256 of the query or asset IDs of the call, so that
+	// whether a given query was sent can be checked without the log holding
+	// it in plain text. The hash is not salted: short queries, such as an IP
+	// address, are found again by hashing every candidate.
+	QueryHash  string `json:"query_hash,omitempty"`
+	Status     int    `json:"status,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	// Prev is the hash of the entry before, or empty for the first entry.
+	Prev string `json:"prev"`
+	Hash string `json:"hash"`
+}
+
+// computeHash returns the hash of the fields of e other than Hash.
+func (e Entry) computeHash() (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HashQuery returns the hash recorded for a query or a list of asset IDs:
+// its SHA-256, which anyone can compute to look the query up in the log.
+func HashQuery(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// Log appends entries to an audit log file. It is safe for concurrent use,
+// including by several processes.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Open returns the log at path, creating its directory and the file if they
+// do not exist, so that a log that cannot be written is reported before any
+// call is made.
+func Open(path string) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return &Log{path: path}, nil
+}
+
+// Path returns the path of the log file.
+func (l *Log) Path() string { return l.path }
+
+// Append chains e to the last entry of the log and writes it. Seq, Prev,
+// and Hash are set by Append; Time is set to now if it is zero.
+func (l *Log) Append(e Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock := flock.New(l.path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("failed to lock the audit log: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	last, err := lastEntry(f)
+	if err != nil {
+		return fmt.Errorf("failed to read the last entry of the audit log: %w", err)
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Time = e.Time.UTC()
+	e.Seq, e.Prev = 1, ""
+	if last != nil {
+		e.Seq, e.Prev = last.Seq+1, last.Hash
+	}
+	if e.Hash, err = e.computeHash(); err != nil {
+		return err
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// lastEntry returns the last entry of f, or nil if it has none. It reads f
+// backwards from its end, so that appending does not read the whole log.
+func lastEntry(f *os.File) (*Entry, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	end := info.Size()
+	var tail []byte
+	for end > 0 {
+		start := max(end-tailChunk, 0)
+		chunk := make([]byte, end-start)
+		if _, err := f.ReadAt(chunk, start); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		tail = append(chunk, tail...)
+		end = start
+		trimmed := bytes.TrimRight(tail, "\n")
+		if len(trimmed) == 0 {
+			continue
+		}
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 || end == 0 {
+			var e Entry
+			if err := json.Unmarshal(trimmed[i+1:], &e); err != nil {
+				return nil, err
+			}
+			return &e, nil
+		}
+	}
+	return nil, nil
+}
+
+// Read returns the entries of the log at path, without checking them.
+func Read(path string) ([]Entry, error) {
+	var entries []Entry
+	err := scan(path, func(_ int, e Entry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	return entries, err
+}
+
+// Verification is the result of a successful Verify.
+type Verification struct {
+	// Entries is the number of entries of the log.
+	Entries uint64 `json:"entries" yaml:"entries"`
+	// Head is the hash of the last entry, which changes with every entry.
+	// Record it to detect that entries were later removed from the end.
+	Head string `json:"head" yaml:"head"`
+}
+
+// Verify checks the chain of the log at path, and returns a *BrokenChainError
+// for the first entry that was changed, removed, or inserted.
+func Verify(path string) (Verification, error) {
+	var res Verification
+	err := scan(path, func(line int, e Entry) error {
+		if e.Seq != res.Entries+1 {
+			return &BrokenChainError{Line: line, Reason: fmt.Sprintf("its sequence number is %d, expected %d", e.Seq, res.Entries+1)}
+		}
+		if e.Prev != res.Head {
+			return &BrokenChainError{Line: line, Reason: "it does not follow the entry before"}
+		}
+		hash, err := e.computeHash()
+		if err != nil {
+			return err
+		}
+		if hash != e.Hash {
+			return &BrokenChainError{Line: line, Reason: "its hash does not match its content"}
+		}
+		res.Entries, res.Head = e.Seq, e.Hash
+		return nil
+	})
+	return res, err
+}
+
+// scan calls fn with each entry of the log at path and its line number.
+func scan(path string, fn func(line int, e Entry) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return &BrokenChainError{Line: line, Reason: fmt.Sprintf("it is not a valid entry: %v", err)}
+		}
+		if err := fn(line, e); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// BrokenChainError is returned by Verify for an entry that breaks the chain.
+type BrokenChainError struct {
+	Line   int
+	Reason string
+}
+
+func (e *BrokenChainError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Reason)
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLog(t *testing.T, n int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+	l, err := Open(path)
+	require.NoError(t, err)
+	for i := range n {
+		require.NoError(t, l.Append(Entry{
+			Time:      time.Date(2025, 1, 1, 0, 0, i, 0, time.UTC),
+			Command:   "search",
+			Operation: "search",
+			QueryHash: HashQuery(fmt.Sprintf("host.ip: 10.0.0.%d", i)),
+			Status:    200,
+		}))
+	}
+	return path
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestAppend(t *testing.T) {
+	path := writeLog(t, 3)
+	entries, err := Read(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, uint64(1), entries[0].Seq)
+	assert.Empty(t, entries[0].Prev)
+	for i := 1; i < 3; i++ {
+		assert.Equal(t, uint64(i+1), entries[i].Seq)
+		assert.Equal(t, entries[i-1].Hash, entries[i].Prev)
+	}
+	assert.Equal(t, HashQuery("host.ip: 10.0.0.2"), entries[2].QueryHash)
+
+	res, err := Verify(path)
+	require.NoError(t, err)
+	assert.Equal(t, Verification{Entries: 3, Head: entries[2].Hash}, res)
+}
+
+func TestAppendConcurrent(t *testing.T) {
+	path := writeLog(t, 0)
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// each writer opens the log, as separate processes would
+			l, err := Open(path)
+			if !assert.NoError(t, err) {
+				return
+			}
+			for range 10 {
+				assert.NoError(t, l.Append(Entry{Operation: "get_hosts", Error: strings.Repeat("x", 3000)}))
+			}
+		}()
+	}
+	wg.Wait()
+	res, err := Verify(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(40), res.Entries)
+}
+
+func TestVerify(t *testing.T) {
+	tamper := func(t *testing.T, edit func(lines []string) []string) error {
+		t.Helper()
+		path := writeLog(t, 4)
+		lines := edit(readLines(t, path))
+		require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+		_, err := Verify(path)
+		return err
+	}
+
+	t.Run("changed entry", func(t *testing.T) {
+		err := tamper(t, func(lines []string) []string {
+			var e Entry
+			require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+			e.Status = 403
+			line, _ := json.Marshal(e)
+			lines[1] = string(line)
+			return lines
+		})
+		assert.EqualError(t, err, "line 2: its hash does not match its content")
+	})
+
+	t.Run("removed entry", func(t *testing.T) {
+		err := tamper(t, func(lines []string) []string { return append(lines[:1], lines[2:]...) })
+		assert.EqualError(t, err, "line 2: its sequence number is 3, expected 2")
+	})
+
+	t.Run("rehashed entry", func(t *testing.T) {
+		err := tamper(t, func(lines []string) []string {
+			var e Entry
+			require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+			e.Command = "view"
+			e.Hash, _ = e.computeHash()
+			line, _ := json.Marshal(e)
+			lines[1] = string(line)
+			return lines
+		})
+		assert.EqualError(t, err, "line 3: it does not follow the entry before")
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		err := tamper(t, func(lines []string) []string { return append(lines, "{") })
+		var broken *BrokenChainError
+		require.ErrorAs(t, err, &broken)
+		assert.Equal(t, 5, broken.Line)
+	})
+}
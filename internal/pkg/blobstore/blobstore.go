@@ -0,0 +1,114 @@
+// Package blobstore uploads files to object storage, addressed by URLs such as
+// s3://bucket/path/results.json.gz. Each storage service is a Bucket
+// implementation registered under its URL scheme, and authenticates with the
+// credentials of the environment it runs in.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrUnsupportedScheme is returned for URLs of storage services that cannot
+// be uploaded to.
+var ErrUnsupportedScheme = errors.New("unsupported storage service")
+
+// Location is the address of an object.
+type Location struct {
+	// Scheme names the storage service, e.g. "s3".
+	Scheme string
+	Bucket string
+	// Key is the name of the object in the bucket.
+	Key string
+}
+
+func (l Location) String() string {
+	return l.Scheme + "://" + l.Bucket + "/" + l.Key
+}
+
+// UploadOptions are the properties of an uploaded object.
+type UploadOptions struct {
+	ContentType string
+}
+
+// Bucket stores objects in a bucket of a storage service.
+type Bucket interface {
+	// Upload stores the size bytes of r as the object key, replacing it if it
+	// exists. Implementations split large objects into parts as needed.
+	Upload(ctx context.Context, key string, r io.ReaderAt, size int64, opts UploadOptions) error
+}
+
+// Opener opens a bucket with the credentials of the environment.
+type Opener func(ctx context.Context, bucket string) (Bucket, error)
+
+// openers are the supported storage services, by URL scheme. Google Cloud
+// Storage (gs) and Azure Blob Storage (az) are recognized, but cannot be
+// uploaded to yet.
+var openers = map[string]Opener{
+	"s3": openS3,
+}
+
+var schemePattern = regexp.MustCompile(`^([a-z][a-z0-9+.-]*)://`)
+
+// IsRemote reports whether path is the URL of an object rather than a local file.
+func IsRemote(path string) bool {
+	return schemePattern.MatchString(path)
+}
+
+// Parse parses the URL of an object.
+func Parse(raw string) (Location, error) {
+	m := schemePattern.FindStringSubmatch(raw)
+	if m == nil {
+		return Location{}, fmt.Errorf("%q is not a storage URL (scheme://bucket/key)", raw)
+	}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(raw, m[0]), "/")
+	if bucket == "" {
+		return Location{}, fmt.Errorf("%q is missing a bucket", raw)
+	}
+	if key == "" || strings.HasSuffix(key, "/") {
+		return Location{}, fmt.Errorf("%q does not name an object", raw)
+	}
+	return Location{Scheme: m[1], Bucket: bucket, Key: key}, nil
+}
+
+// Open opens the bucket of loc.
+func Open(ctx context.Context, loc Location) (Bucket, error) {
+	open, ok := openers[loc.Scheme]
+	if !ok {
+		schemes := make([]string, 0, len(openers))
+		for scheme := range openers {
+			schemes = append(schemes, scheme+"://")
+		}
+		sort.Strings(schemes)
+		return nil, fmt.Errorf("%w %s:// (supported: %s)", ErrUnsupportedScheme, loc.Scheme, strings.Join(schemes, ", "))
+	}
+	return open(ctx, loc.Bucket)
+}
+
+// UploadFile uploads the local file at path to the object at dest, a storage URL.
+func UploadFile(ctx context.Context, dest, path string, opts UploadOptions) error {
+	loc, err := Parse(dest)
+	if err != nil {
+		return err
+	}
+	bucket, err := Open(ctx, loc)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return bucket.Upload(ctx, loc.Key, f, info.Size(), opts)
+}
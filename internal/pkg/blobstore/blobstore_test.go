@@ -0,0 +1,34 @@
+package blobstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	loc, err := Parse("s3://scans/daily/results.json.gz")
+	require.NoError(t, err)
+	assert.Equal(t, Location{Scheme: "s3", Bucket: "scans", Key: "daily/results.json.gz"}, loc)
+	assert.Equal(t, "s3://scans/daily/results.json.gz", loc.String())
+
+	for _, raw := range []string{"results.json", "s3:///results.json", "s3://scans", "s3://scans/daily/"} {
+		_, err := Parse(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestIsRemote(t *testing.T) {
+	assert.True(t, IsRemote("s3://scans/results.json"))
+	assert.True(t, IsRemote("gs://scans/results.json"))
+	assert.False(t, IsRemote("results.json"))
+	assert.False(t, IsRemote("/tmp/s3://results.json"))
+}
+
+func TestOpen_UnsupportedScheme(t *testing.T) {
+	_, err := Open(context.Background(), Location{Scheme: "gs", Bucket: "scans", Key: "results.json"})
+	require.ErrorIs(t, err, ErrUnsupportedScheme)
+	assert.EqualError(t, err, "unsupported storage service gs:// (supported: s3://)")
+}
@@ -0,0 +1,83 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const (
+	// defaultPartSize is the size of the parts of a multipart upload. Objects
+	// no larger than a part are uploaded with a single request. The uploader
+	// grows the part size for objects that would need more than 10,000 parts.
+	defaultPartSize int64 = 16 << 20
+	// defaultRegion is used when neither the environment nor the profile
+	// configures a region.
+	defaultRegion = "us-east-1"
+)
+
+// s3Bucket uploads objects to an Amazon S3 bucket, or to a bucket of an
+// S3-compatible service when an endpoint is configured.
+type s3Bucket struct {
+	client   *s3.Client
+	bucket   string
+	partSize int64
+}
+
+var _ Bucket = (*s3Bucket)(nil)
+
+// openS3 opens an S3 bucket with the credentials and region the AWS CLI would
+// use: the environment, the AWS_PROFILE (or default) profile of the shared
+// config files, including assumed roles, SSO, and credential processes, web
+// identity tokens, and the container or instance role. AWS_ENDPOINT_URL_S3 or
+// AWS_ENDPOINT_URL select an S3-compatible service, such as MinIO.
+func openS3(ctx context.Context, bucket string) (Bucket, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the AWS configuration: %w", err)
+	}
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		return nil, fmt.Errorf("no usable AWS credentials: %w", err)
+	}
+	customEndpoint := os.Getenv("AWS_ENDPOINT_URL_S3") != "" || os.Getenv("AWS_ENDPOINT_URL") != ""
+	if cfg.Region == "" {
+		cfg.Region = defaultRegion
+	}
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		// S3-compatible services expect path-style addresses (endpoint/bucket/key).
+		o.UsePathStyle = customEndpoint
+	})
+	if !customEndpoint {
+		// the bucket may be in another region than the one configured
+		if region, err := manager.GetBucketRegion(ctx, client, bucket); err == nil && region != cfg.Region {
+			client = s3.NewFromConfig(cfg, func(o *s3.Options) { o.Region = region })
+		}
+	}
+	return &s3Bucket{client: client, bucket: bucket, partSize: defaultPartSize}, nil
+}
+
+func (b *s3Bucket) Upload(ctx context.Context, key string, r io.ReaderAt, size int64, opts UploadOptions) error {
+	uploader := manager.NewUploader(b.client, func(u *manager.Uploader) {
+		u.PartSize = b.partSize
+	})
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+		Body:   io.NewSectionReader(r, 0, size),
+	}
+	if opts.ContentType != "" {
+		input.ContentType = aws.String(opts.ContentType)
+	}
+	// a failed multipart upload is aborted, so that the bucket is not charged
+	// for the parts already uploaded
+	if _, err := uploader.Upload(ctx, input); err != nil {
+		return fmt.Errorf("s3: upload %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is an S3-compatible server that supports the requests of an upload.
+type fakeS3 struct {
+	t *testing.T
+
+	mu           sync.Mutex
+	objects      map[string][]byte
+	contentTypes map[string]string
+	parts        map[int][]byte
+	aborted      bool
+	// failPart makes uploads of this part number fail.
+	failPart int
+	// accessKeyID is the access key requests must be signed with.
+	accessKeyID string
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{t: t, objects: map[string][]byte{}, contentTypes: map[string]string{}, parts: map[int][]byte{}, accessKeyID: "AKIDEXAMPLE"}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body, err := io.ReadAll(r.Body)
+	require.NoError(f.t, err)
+	assert.True(f.t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+f.accessKeyID+"/"))
+	sum := sha256.Sum256(body)
+	assert.Equal(f.t, hex.EncodeToString(sum[:]), r.Header.Get("X-Amz-Content-Sha256"))
+
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodPut && query.Has("partNumber"):
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		if number == f.failPart {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+			return
+		}
+		f.parts[number] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, number))
+	case r.Method == http.MethodPut:
+		f.objects[r.URL.Path] = body
+		f.contentTypes[r.URL.Path] = r.Header.Get("Content-Type")
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.contentTypes[r.URL.Path] = r.Header.Get("Content-Type")
+		fmt.Fprint(w, `<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>`)
+	case r.Method == http.MethodPost && query.Get("uploadId") == "upload-1":
+		var complete struct {
+			Parts []struct {
+				PartNumber int
+				ETag       string
+			} `xml:"Part"`
+		}
+		require.NoError(f.t, xml.Unmarshal(body, &complete))
+		var object []byte
+		for i, part := range complete.Parts {
+			assert.Equal(f.t, i+1, part.PartNumber)
+			assert.Equal(f.t, fmt.Sprintf(`"etag-%d"`, part.PartNumber), part.ETag)
+			object = append(object, f.parts[part.PartNumber]...)
+		}
+		f.objects[r.URL.Path] = object
+		fmt.Fprint(w, `<CompleteMultipartUploadResult></CompleteMultipartUploadResult>`)
+	case r.Method == http.MethodDelete && query.Get("uploadId") == "upload-1":
+		f.aborted = true
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// testEnvironment points the AWS SDK at srv with static credentials, and
+// isolates it from the shared config files and metadata services of the host.
+func testEnvironment(t *testing.T, srv *httptest.Server) {
+	dir := t.TempDir()
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	t.Setenv("AWS_ENDPOINT_URL", "")
+	t.Setenv("AWS_ENDPOINT_URL_S3", srv.URL)
+}
+
+func testBucket(t *testing.T, srv *httptest.Server, partSize int64) *s3Bucket {
+	testEnvironment(t, srv)
+	b, err := openS3(context.Background(), "scans")
+	require.NoError(t, err)
+	bucket := b.(*s3Bucket)
+	bucket.partSize = partSize
+	return bucket
+}
+
+func TestUploadFile_S3(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	testEnvironment(t, srv)
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"ip":"1.1.1.1"}]`), 0o600))
+
+	err := UploadFile(context.Background(), "s3://scans/daily/2025 01/results.json", path, UploadOptions{ContentType: "application/json"})
+	require.NoError(t, err)
+	assert.Equal(t, `[{"ip":"1.1.1.1"}]`, string(fake.objects["/scans/daily/2025 01/results.json"]))
+	assert.Equal(t, "application/json", fake.contentTypes["/scans/daily/2025 01/results.json"])
+}
+
+func TestS3Upload_Multipart(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	data := bytes.Repeat([]byte("0123456789ab"), 1<<20)
+
+	err := testBucket(t, srv, 5<<20).Upload(context.Background(), "results.ndjson", bytes.NewReader(data), int64(len(data)),
+		UploadOptions{ContentType: "application/x-ndjson"})
+	require.NoError(t, err)
+	assert.Len(t, fake.parts, 3)
+	assert.Len(t, fake.parts[3], 2<<20)
+	assert.Equal(t, data, fake.objects["/scans/results.ndjson"])
+	assert.Equal(t, "application/x-ndjson", fake.contentTypes["/scans/results.ndjson"])
+	assert.False(t, fake.aborted)
+}
+
+func TestS3Upload_MultipartAbortsOnError(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	fake.failPart = 2
+	data := bytes.Repeat([]byte("x"), 12<<20)
+
+	err := testBucket(t, srv, 5<<20).Upload(context.Background(), "results.ndjson", bytes.NewReader(data), int64(len(data)), UploadOptions{})
+	require.ErrorContains(t, err, "AccessDenied")
+	assert.True(t, fake.aborted)
+	assert.NotContains(t, fake.objects, "/scans/results.ndjson")
+}
+
+func TestS3Upload_RetriesServerErrors(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `<Error><Code>SlowDown</Code><Message>Please reduce your request rate.</Message></Error>`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	err := testBucket(t, srv, defaultPartSize).Upload(context.Background(), "results.json", strings.NewReader("[]"), 2, UploadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestOpenS3_AssumesProfileRole(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	fake.accessKeyID = "ASIAROLE"
+	testEnvironment(t, srv)
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "AssumeRole", r.Form.Get("Action"))
+		assert.Equal(t, "arn:aws:iam::123456789012:role/scanner", r.Form.Get("RoleArn"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDSOURCE/"))
+		fmt.Fprint(w, `<AssumeRoleResponse><AssumeRoleResult><Credentials>`+
+			`<AccessKeyId>ASIAROLE</AccessKeyId><SecretAccessKey>role-secret</SecretAccessKey>`+
+			`<SessionToken>role-token</SessionToken><Expiration>2099-01-01T00:00:00Z</Expiration>`+
+			`</Credentials></AssumeRoleResult></AssumeRoleResponse>`)
+	}))
+	t.Cleanup(sts.Close)
+	t.Setenv("AWS_ENDPOINT_URL_STS", sts.URL)
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_PROFILE", "scans")
+	require.NoError(t, os.WriteFile(os.Getenv("AWS_SHARED_CREDENTIALS_FILE"), []byte(`[source]
+aws_access_key_id = AKIDSOURCE
+aws_secret_access_key = source-secret
+`), 0o600))
+	require.NoError(t, os.WriteFile(os.Getenv("AWS_CONFIG_FILE"), []byte(`[profile scans]
+role_arn = arn:aws:iam::123456789012:role/scanner
+source_profile = source
+`), 0o600))
+
+	bucket, err := openS3(context.Background(), "scans")
+	require.NoError(t, err)
+	require.NoError(t, bucket.Upload(context.Background(), "results.json", strings.NewReader("[]"), 2, UploadOptions{}))
+	assert.Equal(t, "[]", string(fake.objects["/scans/results.json"]))
+}
+
+func TestOpenS3_NoCredentials(t *testing.T) {
+	_, srv := newFakeS3(t)
+	testEnvironment(t, srv)
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	_, err := openS3(context.Background(), "scans")
+	require.ErrorContains(t, err, "no usable AWS credentials")
+}
@@ -0,0 +1,136 @@
+// Package canonical rewrites JSON documents into a canonical form, so that
+// the same data is written the same way on every run and output can be
+// diffed between runs: the keys of objects are sorted, and lists of
+// objects that carry an identity, such as hosts by IP or services by port,
+// are sorted by it.
+package canonical
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// identityKeys are the keys that identify the objects of a list, in order
+// of preference. A list is sorted by the first of them that every object of
+// the list has; lists of other objects, and of values other than objects,
+// keep their order.
+var identityKeys = [][]string{
+	{"ip"},
+	{"fingerprint_sha256"},
+	{"hostname", "port"},
+	{"port", "transport_protocol"},
+	{"port"},
+	{"id"},
+	{"name"},
+	{"key"},
+}
+
+// JSON returns the JSON document data in canonical form. Numbers are kept
+// as they are written in data.
+func JSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	// encoding/json writes the keys of maps sorted
+	return json.Marshal(Value(v))
+}
+
+// Value sorts the lists of v, a value decoded from JSON, by their identity
+// keys, in place, and returns it.
+func Value(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = Value(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = Value(item)
+		}
+		sortByIdentity(v)
+	}
+	return v
+}
+
+// sortByIdentity sorts items by the first identity keys that each of them
+// has. Items with the same identity keep their order.
+func sortByIdentity(items []any) {
+	if len(items) < 2 {
+		return
+	}
+	objects := make([]map[string]any, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return
+		}
+		objects[i] = obj
+	}
+	keys := identityOf(objects)
+	if keys == nil {
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].(map[string]any), items[j].(map[string]any)
+		for _, k := range keys {
+			if c := compare(a[k], b[k]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// identityOf returns the first identity keys that each of objects has, or
+// nil if there are none.
+func identityOf(objects []map[string]any) []string {
+	for _, keys := range identityKeys {
+		if hasAll(objects, keys) {
+			return keys
+		}
+	}
+	return nil
+}
+
+func hasAll(objects []map[string]any, keys []string) bool {
+	for _, obj := range objects {
+		for _, k := range keys {
+			switch obj[k].(type) {
+			case string, json.Number:
+			default:
+				// identities are strings and numbers, never objects or null
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// compare orders numbers by value and before strings, and strings
+// lexically.
+func compare(a, b any) int {
+	an, aIsNumber := a.(json.Number)
+	bn, bIsNumber := b.(json.Number)
+	switch {
+	case aIsNumber && bIsNumber:
+		x, okX := new(big.Float).SetString(an.String())
+		y, okY := new(big.Float).SetString(bn.String())
+		if okX && okY {
+			if c := x.Cmp(y); c != 0 {
+				return c
+			}
+		}
+		return strings.Compare(an.String(), bn.String())
+	case aIsNumber:
+		return -1
+	case bIsNumber:
+		return 1
+	}
+	return strings.Compare(a.(string), b.(string))
+}
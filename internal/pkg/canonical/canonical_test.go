@@ -0,0 +1,62 @@
+package canonical
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "keys are sorted",
+			in:   `{"b":1,"a":{"d":true,"c":null}}`,
+			want: `{"a":{"c":null,"d":true},"b":1}`,
+		},
+		{
+			name: "hosts are sorted by ip",
+			in:   `[{"ip":"10.0.0.2"},{"ip":"10.0.0.1"}]`,
+			want: `[{"ip":"10.0.0.1"},{"ip":"10.0.0.2"}]`,
+		},
+		{
+			name: "services are sorted by port numerically, then transport",
+			in:   `[{"port":443,"transport_protocol":"udp"},{"port":80,"transport_protocol":"tcp"},{"port":443,"transport_protocol":"tcp"}]`,
+			want: `[{"port":80,"transport_protocol":"tcp"},{"port":443,"transport_protocol":"tcp"},{"port":443,"transport_protocol":"udp"}]`,
+		},
+		{
+			name: "nested lists are sorted",
+			in:   `{"host":{"services":[{"port":22},{"port":21}]}}`,
+			want: `{"host":{"services":[{"port":21},{"port":22}]}}`,
+		},
+		{
+			name: "lists without a shared identity keep their order",
+			in:   `[{"ip":"10.0.0.2"},{"name":"a"},"x"]`,
+			want: `[{"ip":"10.0.0.2"},{"name":"a"},"x"]`,
+		},
+		{
+			name: "lists of values keep their order",
+			in:   `["b","a",2,1]`,
+			want: `["b","a",2,1]`,
+		},
+		{
+			name: "numbers are kept as written",
+			in:   `{"n":12345678901234567890,"f":1.50}`,
+			want: `{"f":1.50,"n":12345678901234567890}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := JSON([]byte(tc.in))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+
+	_, err := JSON([]byte(`{`))
+	assert.Error(t, err)
+}
@@ -198,6 +198,12 @@ func IsDeadlineExceeded(err error) bool {
 	return errors.Is(err, context.DeadlineExceeded)
 }
 
+// IsPartial checks if an error was returned along with partial data.
+func IsPartial(err error) bool {
+	var pe *partialError
+	return errors.As(err, &pe)
+}
+
 // IsInterrupted checks if an error is due to interruption (signal or context cancellation).
 func IsInterrupted(err error) bool {
 	if err == nil {
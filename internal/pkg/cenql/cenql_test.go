@@ -0,0 +1,221 @@
+package cenql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Explain(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "single term",
+			query: "host.ip: 1.1.1.1",
+			want:  "host.ip: 1.1.1.1  (match)\n",
+		},
+		{
+			name:  "boolean structure",
+			query: `host.services.port = 22 and (host.location.country: "Germany" OR not host.location.country: "France")`,
+			want: `all of (and):
+  host.services.port = 22  (exact match)
+  any of (or):
+    host.location.country: "Germany"  (match)
+    none of (not):
+      host.location.country: "France"  (match)
+`,
+		},
+		{
+			name:  "nested query, range, set, and regex",
+			query: `host.services: (port: [1 to 1024] and protocol: {SSH, "HTTP"}) and web.hostname =~ "^api\\."`,
+			want: `all of (and):
+  host.services: one element matches
+    all of (and):
+      port: [1 to 1024]  (between 1 and 1024, inclusive)
+      protocol: {SSH, "HTTP"}  (any of 2 values)
+  web.hostname =~ "^api\."  (regular expression)
+`,
+		},
+		{
+			name:  "free text and wildcards",
+			query: `nginx or host.dns.names: *.example.com`,
+			want: `any of (or):
+  nginx  (free text, in any field)
+  host.dns.names: *.example.com  (wildcard match)
+`,
+		},
+		{
+			name:  "unquoted IPv6 and CIDR values",
+			query: `host.ip: 2001:db8::1 or host.ip >= 10.0.0.0/8`,
+			want: `any of (or):
+  host.ip: 2001:db8::1  (match)
+  host.ip >= 10.0.0.0/8  (at least)
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			node, err := Parse(tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, Explain(node))
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "", want: "column 1: the query is empty"},
+		{query: "(host.ip: 1.1.1.1", want: "column 1: '(' is never closed"},
+		{query: "host.ip: 1.1.1.1)", want: "column 17: unexpected ')' without a matching '('"},
+		{query: `host.services.banner: "ssh`, want: "column 23: the quoted value is never closed"},
+		{query: "host.ip: 1.1.1.1 and", want: "column 21: the query ends where a term was expected"},
+		{query: "and host.ip: 1.1.1.1", want: `column 1: "and" needs a term on each side`},
+		{query: "host.ip:", want: "column 8: host.ip : needs a value"},
+		{query: "host.services.port: [1 1024]", want: "column 21: a range is written [low to high]"},
+		{query: "host.services.port: {}", want: "column 21: the set of values is empty"},
+		{query: "host.services = (port: 22)", want: `column 15: a nested query on host.services must use ':', not "="`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			_, err := Parse(tc.query)
+			var parseErr *ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tc.want, err.Error())
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		wantFields   []FieldUse
+		wantWarnings []Warning
+	}{
+		{
+			name:       "clean query",
+			query:      `host.services: (port = 22 or port = 2222) and host.location.country: "Germany"`,
+			wantFields: []FieldUse{{Field: "host.location.country", Terms: 1}, {Field: "host.services.port", Terms: 2}},
+		},
+		{
+			name:       "unquoted special characters",
+			query:      `host.services.banner: foo&bar or web.endpoints.path: /admin`,
+			wantFields: []FieldUse{{Field: "host.services.banner", Terms: 1}, {Field: "web.endpoints.path", Terms: 1}},
+			wantWarnings: []Warning{
+				{Offset: 22, Message: `the unquoted value foo&bar contains "&"; quote it as "foo&bar"`},
+				{Offset: 53, Message: `the unquoted value /admin contains "/"; quote it as "/admin"`},
+			},
+		},
+		{
+			name:       "implicit and",
+			query:      `host.ip: 1.1.1.1 host.services.port: 22`,
+			wantFields: []FieldUse{{Field: "host.ip", Terms: 1}, {Field: "host.services.port", Terms: 1}},
+			wantWarnings: []Warning{
+				{Offset: 0, Message: `terms are written next to each other without "and" or "or"; they must all match, so write "and" between them`},
+			},
+		},
+		{
+			name:       "and across asset types",
+			query:      `host.ip: 1.1.1.1 and web.hostname: example.com`,
+			wantFields: []FieldUse{{Field: "host.ip", Terms: 1}, {Field: "web.hostname", Terms: 1}},
+			wantWarnings: []Warning{
+				{Offset: 0, Message: `"and" requires both host and web fields, but each result is a single kind of asset, so this matches nothing; use "or"`},
+			},
+		},
+		{
+			name:       "unknown field and absolute field in nested query",
+			query:      `port: 22 or host.services: (host.services.port: 22)`,
+			wantFields: []FieldUse{{Field: "host.services.host.services.port", Terms: 1}, {Field: "port", Terms: 1}},
+			wantWarnings: []Warning{
+				{Offset: 0, Message: "port does not start with host., cert., or web., so it is unlikely to be a field"},
+				{Offset: 28, Message: "fields inside host.services: (...) are relative to it; write port, not host.services.port"},
+			},
+		},
+		{
+			name:       "operators",
+			query:      `web.hostname =~ ^api and web.endpoints.http.status_code > [200 to 299] and web.port: [443 to 80] or &&`,
+			wantFields: []FieldUse{{Field: "web.endpoints.http.status_code", Terms: 1}, {Field: "web.hostname", Terms: 1}, {Field: "web.port", Terms: 1}},
+			wantWarnings: []Warning{
+				{Offset: 16, Message: `quote the regular expression of web.hostname =~, as in "^api"`},
+				{Offset: 58, Message: "> compares with a single value; use web.endpoints.http.status_code: [200 to 299] to match a range or set"},
+				{Offset: 85, Message: "the range [443 to 80] is empty, since 443 is greater than 80"},
+				{Offset: 100, Message: `&& is searched for as text; write "and" instead`},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			node, err := Parse(tc.query)
+			require.NoError(t, err)
+			analysis := Analyze(node)
+			assert.Equal(t, tc.wantFields, analysis.Fields)
+			assert.Equal(t, tc.wantWarnings, analysis.Warnings)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	var doc any
+	require.NoError(t, json.Unmarshal([]byte(`{"host": {
+		"ip": "1.1.1.1",
+		"location": {"country": "Germany"},
+		"last_updated_at": "2025-03-01T00:00:00Z",
+		"services": [
+			{"port": 22, "protocol": "SSH", "banner": "SSH-2.0-OpenSSH_8.9"},
+			{"port": 443, "protocol": "HTTP", "tls": true}
+		]
+	}}`), &doc))
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "host.ip: 1.1.1.1", want: true},
+		{query: "host.location.country: germany", want: true},
+		{query: "host.location.country = germany", want: false},
+		{query: "host.location.country = Germany", want: true},
+		{query: "services.port = 22", want: true},
+		{query: "host.services.port: 8080", want: false},
+		{query: "host.services.port: {80, 443}", want: true},
+		{query: "host.services.port: [1 to 1024]", want: true},
+		{query: "host.services.port: [1000 to *]", want: false},
+		{query: "host.services.port > 400", want: true},
+		{query: "host.services.port < 22", want: false},
+		{query: `host.last_updated_at >= "2025-01-01"`, want: true},
+		{query: "host.services.banner: SSH-2.0-*", want: true},
+		{query: `host.services.banner =~ "SSH-2\\.0-.*"`, want: true},
+		{query: `host.services.banner =~ "OpenSSH"`, want: false},
+		{query: "host.services.tls: true", want: true},
+		{query: "host.services: (port: 22 and protocol: SSH)", want: true},
+		{query: "host.services: (port: 22 and protocol: HTTP)", want: false},
+		{query: "host.services.port: 22 and host.services.protocol: HTTP", want: true},
+		{query: "openssh", want: true},
+		{query: "nginx or host.ip: 1.1.1.1", want: true},
+		{query: "not host.location.country: Germany", want: false},
+		{query: "host.dns.names: example.com", want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			node, err := Parse(tc.query)
+			require.NoError(t, err)
+			got, err := Match(node, doc)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("invalid regular expression", func(t *testing.T) {
+		node, err := Parse(`host.ip =~ "("`)
+		require.NoError(t, err)
+		_, err = Match(node, doc)
+		require.ErrorContains(t, err, `invalid regular expression "("`)
+	})
+}
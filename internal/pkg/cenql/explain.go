@@ -0,0 +1,276 @@
+package cenql
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// assetTypes are the first segments of the fields of each asset type.
+var assetTypes = []string{"host", "cert", "web"}
+
+// specialChars are characters that are likely to be misread in an unquoted value.
+const specialChars = `!&|@#$%^+=~?<>;'"\`
+
+// cidrPattern matches IPv4 and IPv6 networks, which are written unquoted.
+var cidrPattern = regexp.MustCompile(`^[0-9A-Fa-f.:]+/[0-9]{1,3}$`)
+
+// Warning is a construct of a query that is valid but likely a mistake.
+type Warning struct {
+	// Offset is the byte offset of the construct in the query.
+	Offset  int
+	Message string
+}
+
+// FieldUse is a field of a query and the number of terms on it.
+type FieldUse struct {
+	Field string
+	Terms int
+}
+
+// Analysis is what a query refers to and what looks wrong in it.
+type Analysis struct {
+	// Fields are the fields of the terms, by full name, sorted.
+	Fields []FieldUse
+	// FreeText is the number of terms that are matched against every field.
+	FreeText int
+	// Warnings are sorted by offset.
+	Warnings []Warning
+}
+
+// Analyze returns the fields a query refers to and the likely mistakes in it.
+func Analyze(root Node) Analysis {
+	a := &analyzer{fields: map[string]int{}}
+	a.visit(root, "")
+
+	analysis := Analysis{FreeText: a.freeText, Warnings: a.warnings}
+	for field, terms := range a.fields {
+		analysis.Fields = append(analysis.Fields, FieldUse{Field: field, Terms: terms})
+	}
+	sort.Slice(analysis.Fields, func(i, j int) bool { return analysis.Fields[i].Field < analysis.Fields[j].Field })
+	sort.SliceStable(analysis.Warnings, func(i, j int) bool { return analysis.Warnings[i].Offset < analysis.Warnings[j].Offset })
+	return analysis
+}
+
+type analyzer struct {
+	fields   map[string]int
+	freeText int
+	warnings []Warning
+}
+
+func (a *analyzer) warn(offset int, format string, args ...any) {
+	a.warnings = append(a.warnings, Warning{Offset: offset, Message: fmt.Sprintf(format, args...)})
+}
+
+// visit analyzes node, whose fields are relative to prefix, and returns the
+// asset types it refers to.
+func (a *analyzer) visit(node Node, prefix string) []string {
+	switch n := node.(type) {
+	case *BoolNode:
+		return a.visitBool(n, prefix)
+	case *NotNode:
+		return a.visit(n.Child, prefix)
+	case *NestedNode:
+		field := a.field(n.Field, prefix, n.Offset)
+		a.visitNested(n.Query, n.Field)
+		a.visit(n.Query, field)
+		return assetTypeOf(field)
+	case *TermNode:
+		field := a.field(n.Field, prefix, n.Offset)
+		a.fields[field]++
+		a.checkTerm(n)
+		return assetTypeOf(field)
+	case *FreeTextNode:
+		a.freeText++
+		a.checkFreeText(n)
+		return nil
+	}
+	return nil
+}
+
+func (a *analyzer) visitBool(n *BoolNode, prefix string) []string {
+	if n.Implicit {
+		a.warn(n.Offset, "terms are written next to each other without \"and\" or \"or\"; they must all match, so write \"and\" between them")
+	}
+	var types []string
+	var single []string
+	for _, child := range n.Children {
+		childTypes := a.visit(child, prefix)
+		if len(childTypes) == 1 && !slices.Contains(single, childTypes[0]) {
+			single = append(single, childTypes[0])
+		}
+		for _, t := range childTypes {
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+	if n.Op == And && prefix == "" && len(single) > 1 {
+		a.warn(n.Offset, "\"and\" requires both %s and %s fields, but each result is a single kind of asset, so this matches nothing; use \"or\"", single[0], single[1])
+	}
+	return types
+}
+
+// field returns the full name of field, relative to prefix, and checks that
+// a top-level field names an asset type.
+func (a *analyzer) field(field, prefix string, offset int) string {
+	if prefix != "" {
+		return prefix + "." + field
+	}
+	if len(assetTypeOf(field)) == 0 {
+		a.warn(offset, "%s does not start with host., cert., or web., so it is unlikely to be a field", field)
+	}
+	return field
+}
+
+// visitNested checks that the fields of a nested query are relative to it.
+func (a *analyzer) visitNested(node Node, nestedField string) {
+	switch n := node.(type) {
+	case *BoolNode:
+		for _, child := range n.Children {
+			a.visitNested(child, nestedField)
+		}
+	case *NotNode:
+		a.visitNested(n.Child, nestedField)
+	case *TermNode:
+		if rel, ok := strings.CutPrefix(n.Field, nestedField+"."); ok {
+			a.warn(n.Offset, "fields inside %s: (...) are relative to it; write %s, not %s", nestedField, rel, n.Field)
+		}
+	case *NestedNode:
+		if rel, ok := strings.CutPrefix(n.Field, nestedField+"."); ok {
+			a.warn(n.Offset, "fields inside %s: (...) are relative to it; write %s, not %s", nestedField, rel, n.Field)
+		}
+	}
+}
+
+func (a *analyzer) checkTerm(n *TermNode) {
+	switch n.Op {
+	case OpRegex:
+		if !n.Value.Quoted {
+			a.warn(n.Value.Offset, "quote the regular expression of %s =~, as in %q", n.Field, n.Value.Text)
+			return
+		}
+	case OpLess, OpLessEq, OpGreater, OpGreaterE:
+		if n.Value.Range != nil || n.Value.Set != nil {
+			a.warn(n.Value.Offset, "%s compares with a single value; use %s: %s to match a range or set", n.Op, n.Field, n.Value)
+			return
+		}
+	}
+	a.checkValue(n.Value)
+}
+
+func (a *analyzer) checkFreeText(n *FreeTextNode) {
+	if !n.Value.Quoted {
+		switch n.Value.Text {
+		case "&&", "||", "!":
+			a.warn(n.Offset, "%s is searched for as text; write %q instead", n.Value.Text, map[string]string{"&&": "and", "||": "or", "!": "not"}[n.Value.Text])
+			return
+		}
+	}
+	a.checkValue(n.Value)
+}
+
+// checkValue looks for unquoted special characters and empty ranges.
+func (a *analyzer) checkValue(v Value) {
+	switch {
+	case v.Range != nil:
+		a.checkValue(v.Range.Low)
+		a.checkValue(v.Range.High)
+		low, lowErr := strconv.ParseFloat(v.Range.Low.Text, 64)
+		high, highErr := strconv.ParseFloat(v.Range.High.Text, 64)
+		if lowErr == nil && highErr == nil && low > high {
+			a.warn(v.Offset, "the range %s is empty, since %s is greater than %s", v, v.Range.Low.Text, v.Range.High.Text)
+		}
+	case v.Set != nil:
+		for _, item := range v.Set {
+			a.checkValue(item)
+		}
+	case !v.Quoted:
+		if i := strings.IndexAny(v.Text, specialChars); i >= 0 {
+			a.warn(v.Offset, "the unquoted value %s contains %q; quote it as %s", v.Text, string(v.Text[i]), Value{Text: v.Text, Quoted: true})
+		} else if strings.Contains(v.Text, "/") && !cidrPattern.MatchString(v.Text) {
+			a.warn(v.Offset, "the unquoted value %s contains \"/\"; quote it as %s", v.Text, Value{Text: v.Text, Quoted: true})
+		}
+	}
+}
+
+// assetTypeOf returns the asset type of a full field name, if it names one.
+func assetTypeOf(field string) []string {
+	first, _, _ := strings.Cut(field, ".")
+	if slices.Contains(assetTypes, first) {
+		return []string{first}
+	}
+	return nil
+}
+
+// Explain renders the boolean structure of a query as an indented tree, with
+// what each term matches.
+func Explain(root Node) string {
+	var b strings.Builder
+	explainNode(&b, root, 0)
+	return b.String()
+}
+
+func explainNode(b *strings.Builder, node Node, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch n := node.(type) {
+	case *BoolNode:
+		if n.Op == And {
+			fmt.Fprintf(b, "%sall of (and):\n", indent)
+		} else {
+			fmt.Fprintf(b, "%sany of (or):\n", indent)
+		}
+		for _, child := range n.Children {
+			explainNode(b, child, depth+1)
+		}
+	case *NotNode:
+		fmt.Fprintf(b, "%snone of (not):\n", indent)
+		explainNode(b, n.Child, depth+1)
+	case *NestedNode:
+		fmt.Fprintf(b, "%s%s: one element matches\n", indent, n.Field)
+		explainNode(b, n.Query, depth+1)
+	case *TermNode:
+		fmt.Fprintf(b, "%s%s%s %s  (%s)\n", indent, n.Field, opSpacing(n.Op), n.Value, describeTerm(n))
+	case *FreeTextNode:
+		fmt.Fprintf(b, "%s%s  (free text, in any field)\n", indent, n.Value)
+	}
+}
+
+// opSpacing writes ':' right after the field, as in host.ip: 1.1.1.1, and
+// other operators between spaces.
+func opSpacing(op Operator) string {
+	if op == OpMatch {
+		return string(op)
+	}
+	return " " + string(op)
+}
+
+func describeTerm(n *TermNode) string {
+	switch {
+	case n.Value.Range != nil:
+		return fmt.Sprintf("between %s and %s, inclusive", n.Value.Range.Low, n.Value.Range.High)
+	case n.Value.Set != nil:
+		return fmt.Sprintf("any of %d values", len(n.Value.Set))
+	}
+	switch n.Op {
+	case OpEqual:
+		return "exact match"
+	case OpRegex:
+		return "regular expression"
+	case OpLess:
+		return "less than"
+	case OpLessEq:
+		return "at most"
+	case OpGreater:
+		return "greater than"
+	case OpGreaterE:
+		return "at least"
+	}
+	if strings.Contains(n.Value.Text, "*") {
+		return "wildcard match"
+	}
+	return "match"
+}
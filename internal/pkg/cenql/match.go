@@ -0,0 +1,245 @@
+package cenql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Match reports whether doc, a JSON document decoded into maps, slices, and
+// scalars, matches the query. It approximates the search engine for data
+// that was already fetched:
+//
+//   - a field matches if any of its values does, following lists at every
+//     level; a field that is not found from the top of doc is looked up in
+//     its only top-level object, so that host.services.port and
+//     services.port both name the port of {"host": {...}};
+//   - ':' is a case-insensitive match of a whole value, or of any value of
+//     the wildcards * and ?, and '=' an exact match;
+//   - =~ matches the whole value against a regular expression;
+//   - <, <=, >, >=, and ranges compare numbers as numbers and other values,
+//     such as RFC 3339 timestamps, as text; "*" leaves an end of a range open;
+//   - a nested query must match a single element of its field;
+//   - free text matches any value of the document containing it, ignoring case.
+//
+// It returns an error for an invalid regular expression.
+func Match(root Node, doc any) (bool, error) {
+	m := &matcher{regexps: map[string]*regexp.Regexp{}}
+	return m.match(root, doc)
+}
+
+type matcher struct {
+	regexps map[string]*regexp.Regexp
+}
+
+func (m *matcher) match(node Node, doc any) (bool, error) {
+	switch n := node.(type) {
+	case *BoolNode:
+		for _, child := range n.Children {
+			ok, err := m.match(child, doc)
+			if err != nil {
+				return false, err
+			}
+			if n.Op == And && !ok {
+				return false, nil
+			}
+			if n.Op == Or && ok {
+				return true, nil
+			}
+		}
+		return n.Op == And, nil
+	case *NotNode:
+		ok, err := m.match(n.Child, doc)
+		return !ok, err
+	case *NestedNode:
+		for _, elem := range lookup(doc, n.Field) {
+			if ok, err := m.match(n.Query, elem); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case *TermNode:
+		for _, leaf := range lookup(doc, n.Field) {
+			if ok, err := m.matchTerm(n.Op, n.Value, leaf); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case *FreeTextNode:
+		needle := strings.ToLower(n.Value.Text)
+		found := false
+		walkScalars(doc, func(v any) {
+			if !found {
+				found = strings.Contains(strings.ToLower(scalarText(v)), needle)
+			}
+		})
+		return found, nil
+	}
+	return false, nil
+}
+
+func (m *matcher) matchTerm(op Operator, value Value, leaf any) (bool, error) {
+	if value.Set != nil {
+		for _, item := range value.Set {
+			if ok, err := m.matchTerm(op, item, leaf); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+	if _, ok := leaf.(map[string]any); ok {
+		return false, nil
+	}
+	text := scalarText(leaf)
+	if value.Range != nil {
+		return (value.Range.Low.Text == "*" || compare(text, value.Range.Low.Text) >= 0) &&
+			(value.Range.High.Text == "*" || compare(text, value.Range.High.Text) <= 0), nil
+	}
+	switch op {
+	case OpEqual:
+		return text == value.Text, nil
+	case OpRegex:
+		re, err := m.regexp(value.Text)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(text), nil
+	case OpLess:
+		return compare(text, value.Text) < 0, nil
+	case OpLessEq:
+		return compare(text, value.Text) <= 0, nil
+	case OpGreater:
+		return compare(text, value.Text) > 0, nil
+	case OpGreaterE:
+		return compare(text, value.Text) >= 0, nil
+	}
+	if !value.Quoted && strings.ContainsAny(value.Text, "*?") {
+		re, err := m.regexp("(?i)" + wildcardPattern(value.Text))
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(text), nil
+	}
+	return strings.EqualFold(text, value.Text), nil
+}
+
+// regexp compiles pattern to match whole values, caching it for the other
+// documents.
+func (m *matcher) regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := m.regexps[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+	}
+	m.regexps[pattern] = re
+	return re, nil
+}
+
+// wildcardPattern returns the regular expression of a value with wildcards.
+func wildcardPattern(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// compare compares a and b as numbers if both are, and as text otherwise.
+func compare(a, b string) int {
+	x, xErr := strconv.ParseFloat(a, 64)
+	y, yErr := strconv.ParseFloat(b, 64)
+	if xErr == nil && yErr == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// lookup returns the values of field in doc, flattening lists. A field that
+// is not found is looked up in the only top-level object of doc, if it has one.
+func lookup(doc any, field string) []any {
+	path := strings.Split(field, ".")
+	values := lookupPath(doc, path)
+	if len(values) == 0 {
+		if obj, ok := doc.(map[string]any); ok && len(obj) == 1 {
+			for _, inner := range obj {
+				values = lookupPath(inner, path)
+			}
+		}
+	}
+	return values
+}
+
+func lookupPath(doc any, path []string) []any {
+	switch v := doc.(type) {
+	case []any:
+		var values []any
+		for _, elem := range v {
+			values = append(values, lookupPath(elem, path)...)
+		}
+		return values
+	case map[string]any:
+		if len(path) == 0 {
+			return []any{v}
+		}
+		child, ok := v[path[0]]
+		if !ok {
+			return nil
+		}
+		return lookupPath(child, path[1:])
+	case nil:
+		return nil
+	default:
+		if len(path) == 0 {
+			return []any{v}
+		}
+		return nil
+	}
+}
+
+// walkScalars calls fn with every value of doc that is not a list or an object.
+func walkScalars(doc any, fn func(any)) {
+	switch v := doc.(type) {
+	case []any:
+		for _, elem := range v {
+			walkScalars(elem, fn)
+		}
+	case map[string]any:
+		for _, child := range v {
+			walkScalars(child, fn)
+		}
+	case nil:
+	default:
+		fn(v)
+	}
+}
+
+// scalarText returns a JSON scalar as it would be written in a query.
+func scalarText(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
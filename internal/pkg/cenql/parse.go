@@ -0,0 +1,482 @@
+// Package cenql parses CenQL, the query language of the Censys Platform, into
+// a syntax tree, so that queries can be explained and checked for likely
+// mistakes locally, before they are sent, and evaluated against assets that
+// were already fetched.
+//
+// The parser covers the structure of a query: boolean operators (and, or,
+// not), parentheses, field terms with their operator (:, =, =~, <, <=, >,
+// >=), nested field queries such as host.services: (port = 22), ranges such
+// as [1 to 100], sets such as {22, 80}, and free text. It does not know the
+// fields of each asset type; the API remains the authority on whether a
+// query is valid.
+package cenql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BoolOp is a boolean operator combining terms.
+type BoolOp string
+
+const (
+	And BoolOp = "and"
+	Or  BoolOp = "or"
+)
+
+// Operator is the comparison of a field term.
+type Operator string
+
+const (
+	OpMatch    Operator = ":"
+	OpEqual    Operator = "="
+	OpRegex    Operator = "=~"
+	OpLess     Operator = "<"
+	OpLessEq   Operator = "<="
+	OpGreater  Operator = ">"
+	OpGreaterE Operator = ">="
+)
+
+// Node is a node of the syntax tree of a query.
+type Node interface {
+	// Pos is the byte offset of the node in the query.
+	Pos() int
+}
+
+// BoolNode combines its children with Op. Implicit is set for terms written
+// next to each other without an operator.
+type BoolNode struct {
+	Op       BoolOp
+	Children []Node
+	Implicit bool
+	Offset   int
+}
+
+// NotNode negates its child.
+type NotNode struct {
+	Child  Node
+	Offset int
+}
+
+// TermNode compares Field with Value.
+type TermNode struct {
+	Field  string
+	Op     Operator
+	Value  Value
+	Offset int
+}
+
+// NestedNode matches Query against the elements of Field, whose fields are
+// named relative to Field.
+type NestedNode struct {
+	Field  string
+	Query  Node
+	Offset int
+}
+
+// FreeTextNode matches Value anywhere in an asset.
+type FreeTextNode struct {
+	Value  Value
+	Offset int
+}
+
+func (n *BoolNode) Pos() int     { return n.Offset }
+func (n *NotNode) Pos() int      { return n.Offset }
+func (n *TermNode) Pos() int     { return n.Offset }
+func (n *NestedNode) Pos() int   { return n.Offset }
+func (n *FreeTextNode) Pos() int { return n.Offset }
+
+// Value is the value of a term: a single value, a range, or a set.
+type Value struct {
+	// Text is the value as written, without the quotes of a quoted value.
+	Text   string
+	Quoted bool
+	// Range is set for a range of values, and Set for a set of values.
+	Range  *Range
+	Set    []Value
+	Offset int
+}
+
+// Range is the range of values [Low to High]; an unbounded end is "*".
+type Range struct {
+	Low, High Value
+}
+
+// String returns the value as it is written in a query.
+func (v Value) String() string {
+	switch {
+	case v.Range != nil:
+		return fmt.Sprintf("[%s to %s]", v.Range.Low, v.Range.High)
+	case v.Set != nil:
+		items := make([]string, len(v.Set))
+		for i, item := range v.Set {
+			items[i] = item.String()
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case v.Quoted:
+		return `"` + strings.ReplaceAll(v.Text, `"`, `\"`) + `"`
+	default:
+		return v.Text
+	}
+}
+
+// ParseError is a syntax error at a byte offset of the query.
+type ParseError struct {
+	Offset  int
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Offset+1, e.Message)
+}
+
+// Parse parses query into its syntax tree.
+func Parse(query string) (Node, error) {
+	p := &parser{src: query}
+	p.skipSpace()
+	if p.eof() {
+		return nil, p.errorf(p.pos, "the query is empty")
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		if p.peek() == ')' {
+			return nil, p.errorf(p.pos, "unexpected ')' without a matching '('")
+		}
+		return nil, p.errorf(p.pos, "unexpected %q", p.peekToken())
+	}
+	return node, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) eof() bool  { return p.pos >= len(p.src) }
+func (p *parser) peek() byte { return p.src[p.pos] }
+
+func (p *parser) skipSpace() {
+	for !p.eof() && isSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(offset int, format string, args ...any) *ParseError {
+	return &ParseError{Offset: offset, Message: fmt.Sprintf(format, args...)}
+}
+
+// peekToken returns the word or character at the current position, for errors.
+func (p *parser) peekToken() string {
+	end := p.pos
+	for end < len(p.src) && !isSpace(p.src[end]) && !isDelimiter(p.src[end]) {
+		end++
+	}
+	if end == p.pos {
+		end++
+	}
+	return p.src[p.pos:end]
+}
+
+// keyword reports whether the next word is the keyword kw, case-insensitively,
+// and consumes it if so.
+func (p *parser) keyword(kw string) bool {
+	p.skipSpace()
+	end := p.pos + len(kw)
+	if end > len(p.src) || !strings.EqualFold(p.src[p.pos:end], kw) {
+		return false
+	}
+	if end < len(p.src) && !isSpace(p.src[end]) && p.src[end] != '(' {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *parser) parseOr() (Node, error) {
+	start := p.pos
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []Node{first}
+	for p.keyword(string(Or)) {
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, next)
+	}
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &BoolNode{Op: Or, Children: children, Offset: start}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	start := p.pos
+	first, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	children := []Node{first}
+	implicit := false
+	for {
+		if p.keyword(string(And)) {
+			next, err := p.parseNot()
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, next)
+			continue
+		}
+		// a term right after another one, without an operator
+		p.skipSpace()
+		if p.eof() || p.peek() == ')' || p.atKeyword(string(Or)) {
+			break
+		}
+		next, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, next)
+		implicit = true
+	}
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &BoolNode{Op: And, Children: children, Implicit: implicit, Offset: start}, nil
+}
+
+// atKeyword reports whether the next word is kw without consuming it.
+func (p *parser) atKeyword(kw string) bool {
+	pos := p.pos
+	ok := p.keyword(kw)
+	p.pos = pos
+	return ok
+}
+
+func (p *parser) parseNot() (Node, error) {
+	p.skipSpace()
+	start := p.pos
+	if p.keyword("not") {
+		child, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotNode{Child: child, Offset: start}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	p.skipSpace()
+	if p.eof() {
+		return nil, p.errorf(p.pos, "the query ends where a term was expected")
+	}
+	start := p.pos
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(')', start, "'(' is never closed"); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case c == ')':
+		return nil, p.errorf(p.pos, "unexpected ')' where a term was expected")
+	case p.atKeyword(string(And)) || p.atKeyword(string(Or)):
+		return nil, p.errorf(p.pos, "%q needs a term on each side", strings.ToLower(p.peekToken()))
+	}
+
+	if field, ok := p.scanField(); ok {
+		return p.parseTerm(field, start)
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return &FreeTextNode{Value: value, Offset: start}, nil
+}
+
+// expect consumes c, or returns an error about the construct opened at
+// offset.
+func (p *parser) expect(c byte, offset int, message string) error {
+	p.skipSpace()
+	if p.eof() || p.peek() != c {
+		return p.errorf(offset, "%s", message)
+	}
+	p.pos++
+	return nil
+}
+
+// scanField consumes a field name followed by an operator, and reports
+// whether there was one. Otherwise nothing is consumed.
+func (p *parser) scanField() (string, bool) {
+	end := p.pos
+	for end < len(p.src) && isFieldChar(p.src[end]) {
+		end++
+	}
+	if end == p.pos || !isFieldStart(p.src[p.pos]) {
+		return "", false
+	}
+	next := end
+	for next < len(p.src) && isSpace(p.src[next]) {
+		next++
+	}
+	if next >= len(p.src) || !strings.ContainsRune(":=<>", rune(p.src[next])) {
+		return "", false
+	}
+	field := p.src[p.pos:end]
+	p.pos = next
+	return field, true
+}
+
+func (p *parser) parseTerm(field string, start int) (Node, error) {
+	opStart := p.pos
+	var op Operator
+	for _, candidate := range []Operator{OpRegex, OpLessEq, OpGreaterE, OpMatch, OpEqual, OpLess, OpGreater} {
+		if strings.HasPrefix(p.src[p.pos:], string(candidate)) {
+			op = candidate
+			break
+		}
+	}
+	p.pos += len(op)
+	p.skipSpace()
+	if p.eof() {
+		return nil, p.errorf(opStart, "%s %s needs a value", field, op)
+	}
+	if p.peek() == '(' {
+		if op != OpMatch {
+			return nil, p.errorf(opStart, "a nested query on %s must use ':', not %q", field, op)
+		}
+		open := p.pos
+		p.pos++
+		query, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(')', open, "'(' is never closed"); err != nil {
+			return nil, err
+		}
+		return &NestedNode{Field: field, Query: query, Offset: start}, nil
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return &TermNode{Field: field, Op: op, Value: value, Offset: start}, nil
+}
+
+func (p *parser) parseValue() (Value, error) {
+	p.skipSpace()
+	if p.eof() {
+		return Value{}, p.errorf(p.pos, "the query ends where a value was expected")
+	}
+	switch p.peek() {
+	case '"', '\'':
+		return p.parseQuoted()
+	case '[':
+		return p.parseRange()
+	case '{':
+		return p.parseSet()
+	case ')', ']', '}', ',':
+		return Value{}, p.errorf(p.pos, "unexpected %q where a value was expected", p.peek())
+	}
+	start := p.pos
+	for !p.eof() && !isSpace(p.peek()) && !isDelimiter(p.peek()) {
+		p.pos++
+	}
+	return Value{Text: p.src[start:p.pos], Offset: start}, nil
+}
+
+func (p *parser) parseQuoted() (Value, error) {
+	start := p.pos
+	quote := p.peek()
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		switch {
+		case c == '\\' && p.pos+1 < len(p.src):
+			b.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+		case c == quote:
+			p.pos++
+			return Value{Text: b.String(), Quoted: true, Offset: start}, nil
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return Value{}, p.errorf(start, "the quoted value is never closed")
+}
+
+func (p *parser) parseRange() (Value, error) {
+	start := p.pos
+	p.pos++
+	low, err := p.parseValue()
+	if err != nil {
+		return Value{}, err
+	}
+	if !p.keyword("to") {
+		return Value{}, p.errorf(start, "a range is written [low to high]")
+	}
+	high, err := p.parseValue()
+	if err != nil {
+		return Value{}, err
+	}
+	if err := p.expect(']', start, "'[' is never closed"); err != nil {
+		return Value{}, err
+	}
+	return Value{Text: p.src[start:p.pos], Range: &Range{Low: low, High: high}, Offset: start}, nil
+}
+
+func (p *parser) parseSet() (Value, error) {
+	start := p.pos
+	p.pos++
+	items := []Value{}
+	for {
+		p.skipSpace()
+		if !p.eof() && p.peek() == '}' && len(items) == 0 {
+			return Value{}, p.errorf(start, "the set of values is empty")
+		}
+		item, err := p.parseValue()
+		if err != nil {
+			return Value{}, err
+		}
+		items = append(items, item)
+		p.skipSpace()
+		if !p.eof() && p.peek() == ',' {
+			p.pos++
+			continue
+		}
+		if err := p.expect('}', start, "'{' is never closed"); err != nil {
+			return Value{}, err
+		}
+		return Value{Text: p.src[start:p.pos], Set: items, Offset: start}, nil
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// isDelimiter reports whether c ends an unquoted value.
+func isDelimiter(c byte) bool {
+	return strings.IndexByte("()[]{},", c) >= 0
+}
+
+func isFieldStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isFieldChar(c byte) bool {
+	return isFieldStart(c) || c == '.' || (c >= '0' && c <= '9')
+}
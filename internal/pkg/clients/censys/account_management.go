@@ -51,7 +51,7 @@ func (a *accountManagementSDK) GetOrganizationCreditDetails(
 ) (Result[components.OrganizationCredits], ClientError) {
 	start := time.Now()
 	var res *operations.V3AccountmanagementOrgCreditsResponse
-	err, attempts := a.executeWithRetry(ctx, func() ClientError {
+	err, attempts := a.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = a.censysSDK.client.AccountManagement.GetOrganizationCredits(ctx, operations.V3AccountmanagementOrgCreditsRequest{
 			OrganizationID: orgID,
@@ -77,7 +77,7 @@ func (a *accountManagementSDK) GetUserCreditDetails(
 ) (Result[components.UserCredits], ClientError) {
 	start := time.Now()
 	var res *operations.V3AccountmanagementUserCreditsResponse
-	err, attempts := a.executeWithRetry(ctx, func() ClientError {
+	err, attempts := a.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = a.censysSDK.client.AccountManagement.GetUserCredits(ctx)
 		if err != nil {
@@ -102,7 +102,7 @@ func (a *accountManagementSDK) GetOrganizationDetails(
 ) (Result[components.OrganizationDetails], ClientError) {
 	start := time.Now()
 	var res *operations.V3AccountmanagementOrgDetailsResponse
-	err, attempts := a.executeWithRetry(ctx, func() ClientError {
+	err, attempts := a.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = a.censysSDK.client.AccountManagement.GetOrganizationDetails(ctx, operations.V3AccountmanagementOrgDetailsRequest{
 			OrganizationID:      orgID,
@@ -132,7 +132,7 @@ func (a *accountManagementSDK) ListOrganizationMembers(
 ) (Result[components.OrganizationMembersList], ClientError) {
 	start := time.Now()
 	var res *operations.V3AccountmanagementListOrgMembersResponse
-	err, attempts := a.executeWithRetry(ctx, func() ClientError {
+	err, attempts := a.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = a.censysSDK.client.AccountManagement.ListOrganizationMembers(ctx, operations.V3AccountmanagementListOrgMembersRequest{
 			OrganizationID: orgID,
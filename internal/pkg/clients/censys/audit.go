@@ -0,0 +1,254 @@
+package censys
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/audit"
+)
+
+// AuditLog receives an entry for every call made through an audited client.
+// It is implemented by audit.Log.
+type AuditLog interface {
+	Append(e audit.Entry) error
+}
+
+// auditClient decorates a Client, appending each call to an audit log. A call
+// that cannot be recorded fails, so that no call goes unaccounted for.
+type auditClient struct {
+	Client
+	log     AuditLog
+	command string
+}
+
+var _ Client = &auditClient{}
+
+// NewAuditClient wraps inner so that every API call is appended to log, as
+// made by command.
+func NewAuditClient(inner Client, log AuditLog, command string) Client {
+	return &auditClient{Client: inner, log: log, command: command}
+}
+
+// audited is a call to be recorded: its org and the query or asset IDs it
+// asked for, if any.
+type audited struct {
+	operation string
+	orgID     string
+	query     string
+	start     time.Time
+}
+
+func (c *auditClient) begin(operation string, orgID mo.Option[string], query string) audited {
+	return audited{operation: operation, orgID: orgID.OrElse(""), query: query, start: time.Now()}
+}
+
+// record appends a completed call to the log and passes its results through
+// unchanged, unless it cannot be appended.
+func record[T any](c *auditClient, call audited, res Result[T], err ClientError) (Result[T], ClientError) {
+	e := audit.Entry{
+		Time:       call.start,
+		Command:    c.command,
+		Operation:  call.operation,
+		OrgID:      call.orgID,
+		DurationMS: time.Since(call.start).Milliseconds(),
+	}
+	if call.query != "" {
+		e.QueryHash = audit.HashQuery(call.query)
+	}
+	if req := res.Metadata.Request; req != nil && req.URL != nil {
+		e.Endpoint = req.Method + " " + req.URL.Path
+	}
+	if res.Metadata.Response != nil {
+		e.Status = res.Metadata.Response.StatusCode
+	} else if err != nil {
+		if code, ok := err.StatusCode().Get(); ok {
+			e.Status = int(code)
+		}
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	if appendErr := c.log.Append(e); appendErr != nil {
+		return res, NewClientError(fmt.Errorf("failed to write the audit log: %w", appendErr))
+	}
+	return res, err
+}
+
+func (c *auditClient) GetHosts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Host], ClientError) {
+	call := c.begin("get_hosts", orgID, strings.Join(hostIDs, ","))
+	res, err := c.Client.GetHosts(ctx, orgID, hostIDs, atTime)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) GetCertificates(
+	ctx context.Context,
+	orgID mo.Option[string],
+	certificateIDs []string,
+) (Result[[]components.Certificate], ClientError) {
+	call := c.begin("get_certificates", orgID, strings.Join(certificateIDs, ","))
+	res, err := c.Client.GetCertificates(ctx, orgID, certificateIDs)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) GetWebProperties(
+	ctx context.Context,
+	orgID mo.Option[string],
+	webPropertyIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Webproperty], ClientError) {
+	call := c.begin("get_web_properties", orgID, strings.Join(webPropertyIDs, ","))
+	res, err := c.Client.GetWebProperties(ctx, orgID, webPropertyIDs, atTime)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) Search(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	call := c.begin("search", orgID, query)
+	res, err := c.Client.Search(ctx, orgID, query, fields, pageSize, pageToken)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) Aggregate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	call := c.begin("aggregate", orgID, query)
+	res, err := c.Client.Aggregate(ctx, orgID, query, field, numBuckets, countByLevel, filterByQuery)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) HostTimeline(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostID string,
+	fromTime time.Time,
+	toTime time.Time,
+) (Result[components.HostTimeline], ClientError) {
+	call := c.begin("host_timeline", orgID, hostID)
+	res, err := c.Client.HostTimeline(ctx, orgID, hostID, fromTime, toTime)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) EnrichHost(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIP string,
+) (Result[components.HostEnrichment], ClientError) {
+	call := c.begin("enrich_host", orgID, hostIP)
+	res, err := c.Client.EnrichHost(ctx, orgID, hostIP)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) SearchCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	call := c.begin("search_collection", orgID, query)
+	res, err := c.Client.SearchCollection(ctx, collectionID, orgID, query, fields, pageSize, pageToken)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) AggregateCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	call := c.begin("aggregate_collection", orgID, query)
+	res, err := c.Client.AggregateCollection(ctx, collectionID, orgID, query, field, numBuckets, countByLevel, filterByQuery)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) GetHostObservationsWithCertificate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	certificateID string,
+	startTime mo.Option[time.Time],
+	endTime mo.Option[time.Time],
+	port mo.Option[int],
+	protocol mo.Option[string],
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.HostObservationResponse], ClientError) {
+	call := c.begin("get_host_observations_with_certificate", orgID, certificateID)
+	res, err := c.Client.GetHostObservationsWithCertificate(ctx, orgID, certificateID, startTime, endTime, port, protocol, pageSize, pageToken)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) GetValueCounts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query mo.Option[string],
+	andCountConditions []components.CountCondition,
+) (Result[components.ValueCountsResponse], ClientError) {
+	call := c.begin("get_value_counts", orgID, query.OrElse(""))
+	res, err := c.Client.GetValueCounts(ctx, orgID, query, andCountConditions)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) GetOrganizationCreditDetails(
+	ctx context.Context,
+	orgID string,
+) (Result[components.OrganizationCredits], ClientError) {
+	call := c.begin("get_organization_credit_details", mo.Some(orgID), "")
+	res, err := c.Client.GetOrganizationCreditDetails(ctx, orgID)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) GetUserCreditDetails(
+	ctx context.Context,
+) (Result[components.UserCredits], ClientError) {
+	call := c.begin("get_user_credit_details", mo.None[string](), "")
+	res, err := c.Client.GetUserCreditDetails(ctx)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) GetOrganizationDetails(
+	ctx context.Context,
+	orgID string,
+) (Result[components.OrganizationDetails], ClientError) {
+	call := c.begin("get_organization_details", mo.Some(orgID), "")
+	res, err := c.Client.GetOrganizationDetails(ctx, orgID)
+	return record(c, call, res, err)
+}
+
+func (c *auditClient) ListOrganizationMembers(
+	ctx context.Context,
+	orgID string,
+	pageSize mo.Option[int],
+	pageToken mo.Option[string],
+) (Result[components.OrganizationMembersList], ClientError) {
+	call := c.begin("list_organization_members", mo.Some(orgID), "")
+	res, err := c.Client.ListOrganizationMembers(ctx, orgID, pageSize, pageToken)
+	return record(c, call, res, err)
+}
@@ -0,0 +1,82 @@
+package censys_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	"github.com/censys/cencli/internal/pkg/audit"
+	"github.com/censys/cencli/internal/pkg/clients/censys"
+)
+
+type failingAuditLog struct{}
+
+func (failingAuditLog) Append(audit.Entry) error { return errors.New("disk full") }
+
+func TestAuditClient(t *testing.T) {
+	ctx := context.Background()
+	org := mo.Some("org-1")
+	none := mo.None[string]()
+
+	t.Run("calls are appended to the log", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		log, err := audit.Open(path)
+		require.NoError(t, err)
+
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().Search(ctx, org, "host.services.port: 22", nil, mo.None[int64](), none).
+			Return(censys.Result[components.SearchQueryResponse]{Metadata: censys.Metadata{
+				Request:  &http.Request{Method: http.MethodPost, URL: &url.URL{Path: "/v3/global/search/query"}},
+				Response: &http.Response{StatusCode: http.StatusOK},
+			}}, nil)
+		inner.EXPECT().GetHosts(ctx, none, []string{"198.51.100.1", "198.51.100.2"}, mo.None[time.Time]()).
+			Return(censys.Result[[]components.Host]{}, censys.NewClientError(errors.New("connection refused")))
+
+		c := censys.NewAuditClient(inner, log, "search")
+		_, cerr := c.Search(ctx, org, "host.services.port: 22", nil, mo.None[int64](), none)
+		require.NoError(t, cerr)
+		_, cerr = c.GetHosts(ctx, none, []string{"198.51.100.1", "198.51.100.2"}, mo.None[time.Time]())
+		require.EqualError(t, cerr, "connection refused")
+
+		entries, err := audit.Read(path)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, "search", entries[0].Command)
+		require.Equal(t, "search", entries[0].Operation)
+		require.Equal(t, "POST /v3/global/search/query", entries[0].Endpoint)
+		require.Equal(t, "org-1", entries[0].OrgID)
+		require.Equal(t, audit.HashQuery("host.services.port: 22"), entries[0].QueryHash)
+		require.Equal(t, http.StatusOK, entries[0].Status)
+		require.Empty(t, entries[0].Error)
+
+		require.Equal(t, "get_hosts", entries[1].Operation)
+		require.Equal(t, audit.HashQuery("198.51.100.1,198.51.100.2"), entries[1].QueryHash)
+		require.Equal(t, "connection refused", entries[1].Error)
+		require.Equal(t, entries[0].Hash, entries[1].Prev)
+
+		res, err := audit.Verify(path)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), res.Entries)
+	})
+
+	t.Run("calls that cannot be recorded fail", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().GetUserCreditDetails(ctx).Return(censys.Result[components.UserCredits]{}, nil)
+
+		c := censys.NewAuditClient(inner, failingAuditLog{}, "credits")
+		_, cerr := c.GetUserCreditDetails(ctx)
+		require.EqualError(t, cerr, "failed to write the audit log: disk full")
+	})
+}
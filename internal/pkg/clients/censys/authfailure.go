@@ -0,0 +1,51 @@
+package censys
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// AuthFailure is why the API refused the credentials of a request.
+type AuthFailure int
+
+const (
+	// AuthFailureExpired is a token that the API no longer accepts: it
+	// expired, was revoked, or was never valid. A new token fixes it.
+	AuthFailureExpired AuthFailure = iota + 1
+	// AuthFailurePermission is a valid token without access to the
+	// resource or organization of the request. A new token does not fix it.
+	AuthFailurePermission
+)
+
+// expiredTokenMarkers are found in the messages of 403 responses that are
+// about the token rather than about what it may access.
+var expiredTokenMarkers = []string{"expired", "revoked", "invalid token", "invalid api", "invalid credentials"}
+
+// AuthFailureOf returns why the API refused the credentials of the request
+// that caused err, if it did: a 401 response is a token that is not accepted,
+// and a 403 response a missing permission, unless its message says that the
+// token expired or was revoked.
+func AuthFailureOf(err error) (AuthFailure, bool) {
+	var clientErr ClientError
+	if !errors.As(err, &clientErr) {
+		return 0, false
+	}
+	code, hasCode := clientErr.StatusCode().Get()
+	var unauthorized ClientUnauthorizedError
+	isUnauthorized := errors.As(err, &unauthorized)
+	switch {
+	case code == http.StatusUnauthorized, isUnauthorized && !hasCode:
+		return AuthFailureExpired, true
+	case code == http.StatusForbidden:
+		message := strings.ToLower(clientErr.Error())
+		for _, marker := range expiredTokenMarkers {
+			if strings.Contains(message, marker) {
+				return AuthFailureExpired, true
+			}
+		}
+		return AuthFailurePermission, true
+	default:
+		return 0, false
+	}
+}
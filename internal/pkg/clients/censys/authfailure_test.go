@@ -0,0 +1,75 @@
+package censys
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/censys/censys-sdk-go/models/sdkerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthFailureOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		want    AuthFailure
+		wantAny bool
+	}{
+		{
+			name: "401 authentication error",
+			err: NewClientError(&sdkerrors.AuthenticationError{Error_: &components.AuthenticationErrorDetail{
+				Code:    int64Ptr(401),
+				Message: strPtr("Invalid API key"),
+			}}),
+			want:    AuthFailureExpired,
+			wantAny: true,
+		},
+		{
+			name:    "authentication error without a code",
+			err:     NewClientError(&sdkerrors.AuthenticationError{Error_: &components.AuthenticationErrorDetail{}}),
+			want:    AuthFailureExpired,
+			wantAny: true,
+		},
+		{
+			name: "403 without access to the organization",
+			err: NewClientError(&sdkerrors.ErrorModel{
+				Status: int64Ptr(403),
+				Detail: strPtr("You do not have access to this organization"),
+			}),
+			want:    AuthFailurePermission,
+			wantAny: true,
+		},
+		{
+			name: "403 for an expired token",
+			err: NewClientError(&sdkerrors.AuthenticationError{Error_: &components.AuthenticationErrorDetail{
+				Code:    int64Ptr(403),
+				Message: strPtr("Token has expired"),
+			}}),
+			want:    AuthFailureExpired,
+			wantAny: true,
+		},
+		{
+			name:    "401 of the legacy API, wrapped",
+			err:     fmt.Errorf("view: %w", NewClientError(sdkerrors.NewSDKError("Unauthorized", 401, "", nil))),
+			want:    AuthFailureExpired,
+			wantAny: true,
+		},
+		{
+			name: "404",
+			err:  NewClientError(sdkerrors.NewSDKError("Not Found", 404, "", nil)),
+		},
+		{
+			name: "not a client error",
+			err:  errors.New("boom"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := AuthFailureOf(tc.err)
+			assert.Equal(t, tc.wantAny, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"runtime"
 	"time"
 
@@ -15,6 +16,8 @@ import (
 	clienthttp "github.com/censys/cencli/internal/pkg/clients/http"
 	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
 	applog "github.com/censys/cencli/internal/pkg/log"
+	"github.com/censys/cencli/internal/pkg/tracing"
+	"github.com/censys/cencli/internal/pkg/ui/events"
 	"github.com/censys/cencli/internal/store"
 	"github.com/censys/cencli/internal/version"
 )
@@ -49,21 +52,19 @@ type censysSDKImpl struct {
 
 var _ Client = &censysSDKImpl{}
 
+// NewCensysSDK creates a client authenticated with the last used personal
+// access token of ds, in the last used organization, if any.
 func NewCensysSDK(
 	ctx context.Context,
 	ds store.Store,
+	apiURL string,
 	httpRequestTimeout time.Duration,
+	transport config.TransportConfig,
 	retryStrategy config.RetryStrategy,
 	debug bool,
 ) (Client, error) {
-	// Create logger for HTTP and retry debugging (only logs when debug=true)
-	var logger *slog.Logger
-	if debug {
-		logger = applog.New(debug, nil)
-	}
-
-	sdkOpts := []censys.SDKOption{
-		censys.WithClient(clienthttp.New(httpRequestTimeout, buildUserAgent(), logger)),
+	if err := validateAPIURL(apiURL); err != nil {
+		return nil, err
 	}
 
 	storedPAT, err := ds.GetLastUsedAuthByName(ctx, config.AuthName)
@@ -73,17 +74,62 @@ func NewCensysSDK(
 		}
 		return nil, fmt.Errorf("failed to get last used auth: %w", err)
 	}
-	sdkOpts = append(sdkOpts, censys.WithSecurity(storedPAT.Value))
 
-	hasOrgID := false
+	orgID := ""
 	storedOrgID, err := ds.GetLastUsedGlobalByName(ctx, config.OrgIDGlobalName)
 	if err == nil {
-		hasOrgID = true
-		sdkOpts = append(sdkOpts, censys.WithOrganizationID(storedOrgID.Value))
+		orgID = storedOrgID.Value
 	} else if !errors.Is(err, store.ErrGlobalNotFound) {
 		return nil, fmt.Errorf("failed to get last used orgID: %w", err)
 	}
 
+	return NewCensysSDKWithToken(storedPAT.Value, orgID, apiURL, httpRequestTimeout, transport, retryStrategy, debug)
+}
+
+// NewCensysSDKWithToken creates a client authenticated with token, in the
+// organization orgID, or without one if it is empty.
+func NewCensysSDKWithToken(
+	token string,
+	orgID string,
+	apiURL string,
+	httpRequestTimeout time.Duration,
+	transport config.TransportConfig,
+	retryStrategy config.RetryStrategy,
+	debug bool,
+) (Client, error) {
+	// Create logger for HTTP and retry debugging (only logs when debug=true)
+	var logger *slog.Logger
+	if debug {
+		logger = applog.New(debug, nil)
+	}
+
+	sdkOpts := []censys.SDKOption{
+		// a single HTTP client is shared by every request of the SDK, so
+		// paginated and concurrent calls reuse the pooled connections
+		censys.WithClient(clienthttp.New(httpRequestTimeout, buildUserAgent(), logger,
+			clienthttp.WithTransport(clienthttp.Transport{
+				MaxIdleConns:        int(transport.MaxIdleConns),
+				MaxIdleConnsPerHost: int(transport.MaxIdleConnsPerHost),
+				IdleTimeout:         transport.IdleTimeout,
+				KeepAlive:           transport.KeepAlive,
+				HTTP2:               transport.HTTP2,
+			}))),
+	}
+
+	if err := validateAPIURL(apiURL); err != nil {
+		return nil, err
+	}
+	if apiURL != "" {
+		sdkOpts = append(sdkOpts, censys.WithServerURL(apiURL))
+	}
+
+	sdkOpts = append(sdkOpts, censys.WithSecurity(token))
+
+	hasOrgID := orgID != ""
+	if hasOrgID {
+		sdkOpts = append(sdkOpts, censys.WithOrganizationID(orgID))
+	}
+
 	censysSDK := &censysSDK{
 		client:        censys.New(sdkOpts...),
 		retryStrategy: retryStrategy,
@@ -100,11 +146,26 @@ func NewCensysSDK(
 	}, nil
 }
 
+// validateAPIURL checks that apiURL, if set, is an http or https URL.
+func validateAPIURL(apiURL string) error {
+	if apiURL == "" {
+		return nil
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid api-url %q: must be an http or https URL", apiURL)
+	}
+	return nil
+}
+
 func buildUserAgent() string {
 	return fmt.Sprintf("cencli/%s (%s; %s %s)", version.Version, version.Date, runtime.GOOS, runtime.GOARCH)
 }
 
-func (c *censysSDK) executeWithRetry(ctx context.Context, operationFn func() ClientError) (ClientError, uint64) {
+// executeWithRetry runs operationFn until it succeeds or the retry strategy
+// gives up. Each attempt gets its own context, so that the error of a failed
+// attempt carries the request ID and rate limit of its response.
+func (c *censysSDK) executeWithRetry(ctx context.Context, operationFn func(ctx context.Context) ClientError) (ClientError, uint64) {
 	if operationFn == nil {
 		return wrapCencliError(cenclierrors.NewCencliError(errors.New("operationFn cannot be nil"))), 1
 	}
@@ -125,17 +186,29 @@ func (c *censysSDK) executeWithRetry(ctx context.Context, operationFn func() Cli
 			return wrapCencliError(cenclierrors.ParseContextError(err)), attempt
 		}
 
-		err := operationFn()
+		_, span := tracing.Start(ctx, "censys.attempt", tracing.KindInternal,
+			tracing.Int("cencli.attempt", int64(attempt)), tracing.Bool("cencli.retry", attempt > 1))
+		attemptCtx, headers := clienthttp.WithResponseHeaders(ctx)
+		err := operationFn(attemptCtx)
 		if err == nil {
+			span.End()
 			return nil, attempt
 		}
+		err = withResponseInfo(err, headers.Get())
+		if code, ok := err.StatusCode().Get(); ok {
+			span.SetAttributes(tracing.Int("http.response.status_code", code))
+		}
+		span.SetError(err)
 
 		lastErr = err
 		if attempt == maxAttempts || !shouldRetryCensysError(err) {
+			span.End()
 			return err, attempt
 		}
 
 		delay := calculateRetryDelay(baseDelay, c.retryStrategy.MaxDelay, c.retryStrategy.Backoff, attempt)
+		span.SetAttributes(tracing.Int("cencli.retry.delay_ms", delay.Milliseconds()))
+		span.End()
 		if c.logger != nil {
 			var statusCode int64
 			if lastErr.StatusCode().IsPresent() {
@@ -143,6 +216,7 @@ func (c *censysSDK) executeWithRetry(ctx context.Context, operationFn func() Cli
 			}
 			c.logger.Debug("retrying request", "attempt", attempt, "max_attempts", maxAttempts, "status", statusCode, "delay", delay)
 		}
+		events.ReportRetry(ctx, attempt, maxAttempts, delay, err)
 		timer := time.NewTimer(delay)
 		select {
 		case <-timer.C:
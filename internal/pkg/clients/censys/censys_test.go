@@ -3,6 +3,9 @@ package censys
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -14,7 +17,9 @@ import (
 
 	"github.com/censys/cencli/gen/store/mocks"
 	"github.com/censys/cencli/internal/config"
+	clienthttp "github.com/censys/cencli/internal/pkg/clients/http"
 	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
+	"github.com/censys/cencli/internal/pkg/tracing"
 	"github.com/censys/cencli/internal/store"
 )
 
@@ -39,7 +44,7 @@ func TestNewCensysSDK(t *testing.T) {
 			LastUsedAt: time.Now(),
 		}, nil)
 
-		client, err := NewCensysSDK(ctx, mockStore, 0, config.RetryStrategy{}, false)
+		client, err := NewCensysSDK(ctx, mockStore, "", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
 		require.NoError(t, err)
 		assert.NotNil(t, client)
 		assert.True(t, client.HasOrgID())
@@ -59,7 +64,7 @@ func TestNewCensysSDK(t *testing.T) {
 
 		mockStore.EXPECT().GetLastUsedGlobalByName(ctx, config.OrgIDGlobalName).Return((*store.ValueForGlobal)(nil), store.ErrGlobalNotFound)
 
-		client, err := NewCensysSDK(ctx, mockStore, 0, config.RetryStrategy{}, false)
+		client, err := NewCensysSDK(ctx, mockStore, "", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
 		require.NoError(t, err)
 		assert.NotNil(t, client)
 		assert.False(t, client.HasOrgID())
@@ -73,7 +78,7 @@ func TestNewCensysSDK(t *testing.T) {
 
 		mockStore.EXPECT().GetLastUsedAuthByName(ctx, config.AuthName).Return((*store.ValueForAuth)(nil), authdom.ErrAuthNotFound)
 
-		client, err := NewCensysSDK(ctx, mockStore, 0, config.RetryStrategy{}, false)
+		client, err := NewCensysSDK(ctx, mockStore, "", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
 		assert.Error(t, err)
 		assert.Nil(t, client)
 		assert.True(t, errors.Is(err, authdom.ErrAuthNotFound))
@@ -87,7 +92,7 @@ func TestNewCensysSDK(t *testing.T) {
 
 		mockStore.EXPECT().GetLastUsedAuthByName(ctx, config.AuthName).Return((*store.ValueForAuth)(nil), errors.New("db error"))
 
-		client, err := NewCensysSDK(ctx, mockStore, 0, config.RetryStrategy{}, false)
+		client, err := NewCensysSDK(ctx, mockStore, "", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
 		assert.Error(t, err)
 		assert.Nil(t, client)
 		assert.Contains(t, err.Error(), "failed to get last used auth")
@@ -107,11 +112,23 @@ func TestNewCensysSDK(t *testing.T) {
 
 		mockStore.EXPECT().GetLastUsedGlobalByName(ctx, config.OrgIDGlobalName).Return((*store.ValueForGlobal)(nil), errors.New("db error"))
 
-		client, err := NewCensysSDK(ctx, mockStore, 0, config.RetryStrategy{}, false)
+		client, err := NewCensysSDK(ctx, mockStore, "", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
 		assert.Error(t, err)
 		assert.Nil(t, client)
 		assert.Contains(t, err.Error(), "failed to get last used orgID")
 	})
+
+	t.Run("invalid api url", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := mocks.NewMockStore(ctrl)
+
+		client, err := NewCensysSDK(ctx, mockStore, "api.example.com", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
+		assert.Error(t, err)
+		assert.Nil(t, client)
+		assert.Contains(t, err.Error(), "invalid api-url")
+	})
 }
 
 func TestParseSDKError(t *testing.T) {
@@ -301,7 +318,7 @@ func TestCensysSDK_ExecuteWithRetry(t *testing.T) {
 			defer cancel()
 
 			callCount := 0
-			op := func() ClientError {
+			op := func(context.Context) ClientError {
 				callCount++
 				if tc.mutate != nil {
 					tc.mutate(callCount, cancel)
@@ -330,7 +347,64 @@ func TestCensysSDK_ExecuteWithRetryNilOperation(t *testing.T) {
 	assert.Contains(t, err.Error(), "operationFn cannot be nil")
 }
 
+func TestCensysSDK_ExecuteWithRetryTracing(t *testing.T) {
+	sdk := &censysSDK{retryStrategy: config.RetryStrategy{MaxAttempts: 3, BaseDelay: time.Millisecond, Backoff: config.BackoffFixed}}
+	tracer := tracing.New()
+	ctx := tracing.WithTracer(context.Background(), tracer)
+
+	responses := []ClientError{newGenericCensysError(503), nil}
+	calls := 0
+	err, attempts := sdk.executeWithRetry(ctx, func(ctx context.Context) ClientError {
+		calls++
+		return responses[calls-1]
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), attempts)
+
+	spans := tracer.Spans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "censys.attempt", spans[0].Name())
+	assert.Equal(t, []tracing.Attr{
+		tracing.Int("cencli.attempt", 1),
+		tracing.Bool("cencli.retry", false),
+		tracing.Int("http.response.status_code", 503),
+		tracing.Int("cencli.retry.delay_ms", 1),
+	}, spans[0].Attributes())
+	assert.Equal(t, []tracing.Attr{
+		tracing.Int("cencli.attempt", 2),
+		tracing.Bool("cencli.retry", true),
+	}, spans[1].Attributes())
+}
+
 // helper for retry tests
+func TestCensysSDK_ExecuteWithRetryResponseInfo(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Header().Set("X-Request-Id", fmt.Sprintf("req-%d", requests))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	sdk := &censysSDK{retryStrategy: config.RetryStrategy{MaxAttempts: 2, BaseDelay: time.Millisecond, Backoff: config.BackoffFixed}}
+	httpClient := clienthttp.New(time.Second, "cencli-test", nil)
+	err, attempts := sdk.executeWithRetry(context.Background(), func(ctx context.Context) ClientError {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		resp, err := httpClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return newGenericCensysError(resp.StatusCode)
+	})
+	require.Error(t, err)
+	assert.Equal(t, uint64(2), attempts)
+
+	info, ok := ResponseInfoOf(err)
+	require.True(t, ok)
+	assert.Equal(t, "req-2", info.RequestID, "the error has the request ID of the last attempt")
+	assert.Contains(t, err.Error(), "Request ID: req-2")
+}
+
 func newGenericCensysError(code int) ClientError {
 	return NewCensysClientGenericError(&sdkerrors.SDKError{Message: "retryable", StatusCode: code})
 }
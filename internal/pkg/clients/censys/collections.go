@@ -7,6 +7,8 @@ import (
 	"github.com/censys/censys-sdk-go/models/components"
 	"github.com/censys/censys-sdk-go/models/operations"
 	"github.com/samber/mo"
+
+	clienthttp "github.com/censys/cencli/internal/pkg/clients/http"
 )
 
 //go:generate mockgen -destination=../../../../gen/client/mocks/collections_client_mock.go -package=mocks github.com/censys/cencli/internal/pkg/clients/censys CollectionsClient
@@ -57,7 +59,7 @@ func (c *collectionsSDK) SearchCollection(
 ) (Result[components.SearchQueryResponse], ClientError) {
 	start := time.Now()
 	var res *operations.V3CollectionsSearchQueryResponse
-	err, attempts := c.executeWithRetry(ctx, func() ClientError {
+	err, attempts := c.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = c.censysSDK.client.Collections.Search(ctx, operations.V3CollectionsSearchQueryRequest{
 			CollectionUID:  collectionID,
@@ -97,6 +99,7 @@ func (c *collectionsSDK) AggregateCollection(
 	filterByQuery mo.Option[bool],
 ) (Result[components.SearchAggregateResponse], ClientError) {
 	start := time.Now()
+	ctx, headers := clienthttp.WithResponseHeaders(ctx)
 	res, err := c.censysSDK.client.Collections.Aggregate(ctx, operations.V3CollectionsSearchAggregateRequest{
 		CollectionUID:  collectionID,
 		OrganizationID: orgID.ToPointer(),
@@ -111,7 +114,7 @@ func (c *collectionsSDK) AggregateCollection(
 	latency := time.Since(start)
 	if err != nil {
 		zero := Result[components.SearchAggregateResponse]{}
-		return zero, NewClientError(err)
+		return zero, withResponseInfo(NewClientError(err), headers.Get())
 	}
 	searchAggregateResponse := res.GetResponseEnvelopeSearchAggregateResponse().GetResult()
 	return Result[components.SearchAggregateResponse]{
@@ -0,0 +1,270 @@
+package censys
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+
+	clienthttp "github.com/censys/cencli/internal/pkg/clients/http"
+)
+
+// PlannedRequest is an API request that a dry run did not send.
+type PlannedRequest struct {
+	// Operation is the client operation that made the request, e.g. search.
+	Operation string `json:"operation"`
+	Method    string `json:"method"`
+	URL       string `json:"url"`
+	// Body is the JSON body of the request, if any.
+	Body json.RawMessage `json:"body,omitempty"`
+	// Paginated is set for operations that fetch one page per request.
+	Paginated bool `json:"paginated"`
+}
+
+// DryRunPlan collects the requests of a dry run. It is safe for concurrent use.
+type DryRunPlan struct {
+	mu       sync.Mutex
+	requests []PlannedRequest
+}
+
+// NewDryRunPlan creates an empty plan.
+func NewDryRunPlan() *DryRunPlan {
+	return &DryRunPlan{}
+}
+
+// Requests returns the planned requests, in the order they were made.
+func (p *DryRunPlan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedRequest(nil), p.requests...)
+}
+
+func (p *DryRunPlan) add(req PlannedRequest) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, req)
+}
+
+// dryRunClient decorates a Client so that requests are recorded to a plan
+// instead of being sent. Every call succeeds with an empty result, so a
+// command runs to completion and makes each request it depends on only once.
+type dryRunClient struct {
+	Client
+	plan *DryRunPlan
+}
+
+var _ Client = &dryRunClient{}
+
+// NewDryRunClient wraps inner so that the requests it would send are
+// recorded to plan instead. The requests are captured by the HTTP client of
+// inner, with the exact method, URL, and body it builds.
+func NewDryRunClient(inner Client, plan *DryRunPlan) Client {
+	return &dryRunClient{Client: inner, plan: plan}
+}
+
+// dryRun makes call with a context in which its request is captured instead
+// of sent. If the request was captured, it is added to the plan and an empty
+// result is returned; otherwise the call failed before sending its request
+// and its result is passed through unchanged.
+func dryRun[T any](
+	ctx context.Context,
+	plan *DryRunPlan,
+	operation string,
+	paginated bool,
+	call func(context.Context) (Result[T], ClientError),
+) (Result[T], ClientError) {
+	var captured clienthttp.CapturedRequest
+	res, err := call(clienthttp.WithDryRun(ctx, &captured))
+	if captured.Method == "" {
+		return res, err
+	}
+	req := PlannedRequest{
+		Operation: operation,
+		Method:    captured.Method,
+		URL:       captured.URL,
+		Paginated: paginated,
+	}
+	if json.Valid(captured.Body) {
+		req.Body = captured.Body
+	}
+	plan.add(req)
+	var zero T
+	return Result[T]{Data: &zero}, nil
+}
+
+func (c *dryRunClient) GetHosts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Host], ClientError) {
+	return dryRun(ctx, c.plan, "get_hosts", false, func(ctx context.Context) (Result[[]components.Host], ClientError) {
+		return c.Client.GetHosts(ctx, orgID, hostIDs, atTime)
+	})
+}
+
+func (c *dryRunClient) GetCertificates(
+	ctx context.Context,
+	orgID mo.Option[string],
+	certificateIDs []string,
+) (Result[[]components.Certificate], ClientError) {
+	return dryRun(ctx, c.plan, "get_certificates", false, func(ctx context.Context) (Result[[]components.Certificate], ClientError) {
+		return c.Client.GetCertificates(ctx, orgID, certificateIDs)
+	})
+}
+
+func (c *dryRunClient) GetWebProperties(
+	ctx context.Context,
+	orgID mo.Option[string],
+	webPropertyIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Webproperty], ClientError) {
+	return dryRun(ctx, c.plan, "get_web_properties", false, func(ctx context.Context) (Result[[]components.Webproperty], ClientError) {
+		return c.Client.GetWebProperties(ctx, orgID, webPropertyIDs, atTime)
+	})
+}
+
+func (c *dryRunClient) Search(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	return dryRun(ctx, c.plan, "search", true, func(ctx context.Context) (Result[components.SearchQueryResponse], ClientError) {
+		return c.Client.Search(ctx, orgID, query, fields, pageSize, pageToken)
+	})
+}
+
+func (c *dryRunClient) Aggregate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	return dryRun(ctx, c.plan, "aggregate", false, func(ctx context.Context) (Result[components.SearchAggregateResponse], ClientError) {
+		return c.Client.Aggregate(ctx, orgID, query, field, numBuckets, countByLevel, filterByQuery)
+	})
+}
+
+func (c *dryRunClient) HostTimeline(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostID string,
+	fromTime time.Time,
+	toTime time.Time,
+) (Result[components.HostTimeline], ClientError) {
+	return dryRun(ctx, c.plan, "host_timeline", false, func(ctx context.Context) (Result[components.HostTimeline], ClientError) {
+		return c.Client.HostTimeline(ctx, orgID, hostID, fromTime, toTime)
+	})
+}
+
+func (c *dryRunClient) EnrichHost(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIP string,
+) (Result[components.HostEnrichment], ClientError) {
+	return dryRun(ctx, c.plan, "enrich_host", false, func(ctx context.Context) (Result[components.HostEnrichment], ClientError) {
+		return c.Client.EnrichHost(ctx, orgID, hostIP)
+	})
+}
+
+func (c *dryRunClient) SearchCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	return dryRun(ctx, c.plan, "search_collection", true, func(ctx context.Context) (Result[components.SearchQueryResponse], ClientError) {
+		return c.Client.SearchCollection(ctx, collectionID, orgID, query, fields, pageSize, pageToken)
+	})
+}
+
+func (c *dryRunClient) AggregateCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	return dryRun(ctx, c.plan, "aggregate_collection", false, func(ctx context.Context) (Result[components.SearchAggregateResponse], ClientError) {
+		return c.Client.AggregateCollection(ctx, collectionID, orgID, query, field, numBuckets, countByLevel, filterByQuery)
+	})
+}
+
+func (c *dryRunClient) GetHostObservationsWithCertificate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	certificateID string,
+	startTime mo.Option[time.Time],
+	endTime mo.Option[time.Time],
+	port mo.Option[int],
+	protocol mo.Option[string],
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.HostObservationResponse], ClientError) {
+	return dryRun(ctx, c.plan, "get_host_observations_with_certificate", true, func(ctx context.Context) (Result[components.HostObservationResponse], ClientError) {
+		return c.Client.GetHostObservationsWithCertificate(ctx, orgID, certificateID, startTime, endTime, port, protocol, pageSize, pageToken)
+	})
+}
+
+func (c *dryRunClient) GetValueCounts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query mo.Option[string],
+	andCountConditions []components.CountCondition,
+) (Result[components.ValueCountsResponse], ClientError) {
+	return dryRun(ctx, c.plan, "get_value_counts", false, func(ctx context.Context) (Result[components.ValueCountsResponse], ClientError) {
+		return c.Client.GetValueCounts(ctx, orgID, query, andCountConditions)
+	})
+}
+
+func (c *dryRunClient) GetOrganizationCreditDetails(
+	ctx context.Context,
+	orgID string,
+) (Result[components.OrganizationCredits], ClientError) {
+	return dryRun(ctx, c.plan, "get_organization_credit_details", false, func(ctx context.Context) (Result[components.OrganizationCredits], ClientError) {
+		return c.Client.GetOrganizationCreditDetails(ctx, orgID)
+	})
+}
+
+func (c *dryRunClient) GetUserCreditDetails(
+	ctx context.Context,
+) (Result[components.UserCredits], ClientError) {
+	return dryRun(ctx, c.plan, "get_user_credit_details", false, func(ctx context.Context) (Result[components.UserCredits], ClientError) {
+		return c.Client.GetUserCreditDetails(ctx)
+	})
+}
+
+func (c *dryRunClient) GetOrganizationDetails(
+	ctx context.Context,
+	orgID string,
+) (Result[components.OrganizationDetails], ClientError) {
+	return dryRun(ctx, c.plan, "get_organization_details", false, func(ctx context.Context) (Result[components.OrganizationDetails], ClientError) {
+		return c.Client.GetOrganizationDetails(ctx, orgID)
+	})
+}
+
+func (c *dryRunClient) ListOrganizationMembers(
+	ctx context.Context,
+	orgID string,
+	pageSize mo.Option[int],
+	pageToken mo.Option[string],
+) (Result[components.OrganizationMembersList], ClientError) {
+	return dryRun(ctx, c.plan, "list_organization_members", true, func(ctx context.Context) (Result[components.OrganizationMembersList], ClientError) {
+		return c.Client.ListOrganizationMembers(ctx, orgID, pageSize, pageToken)
+	})
+}
@@ -0,0 +1,81 @@
+package censys_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/store"
+)
+
+func TestDryRunClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("plans requests without sending them", func(t *testing.T) {
+		var sent atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			sent.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		ctrl := gomock.NewController(t)
+		st := storemocks.NewMockStore(ctrl)
+		st.EXPECT().GetLastUsedAuthByName(gomock.Any(), config.AuthName).
+			Return(&store.ValueForAuth{Name: "auth", Value: "token", LastUsedAt: time.Now()}, nil)
+		st.EXPECT().GetLastUsedGlobalByName(gomock.Any(), config.OrgIDGlobalName).
+			Return((*store.ValueForGlobal)(nil), store.ErrGlobalNotFound)
+		inner, err := censys.NewCensysSDK(ctx, st, srv.URL, time.Second, config.TransportConfig{}, config.RetryStrategy{MaxAttempts: 3}, false)
+		require.NoError(t, err)
+
+		plan := censys.NewDryRunPlan()
+		client := censys.NewDryRunClient(inner, plan)
+
+		searchRes, clientErr := client.Search(ctx, mo.Some("org"), "host.ip: 1.1.1.1", []string{"host.ip"}, mo.Some[int64](10), mo.None[string]())
+		require.NoError(t, clientErr)
+		require.NotNil(t, searchRes.Data)
+		require.Empty(t, searchRes.Data.Hits)
+
+		hostsRes, clientErr := client.GetHosts(ctx, mo.None[string](), []string{"1.1.1.1"}, mo.None[time.Time]())
+		require.NoError(t, clientErr)
+		require.NotNil(t, hostsRes.Data)
+
+		require.Zero(t, sent.Load())
+		requests := plan.Requests()
+		require.Len(t, requests, 2)
+		require.Equal(t, "search", requests[0].Operation)
+		require.Equal(t, http.MethodPost, requests[0].Method)
+		require.Equal(t, srv.URL+"/v3/global/search/query?organization_id=org", requests[0].URL)
+		require.JSONEq(t, `{"query":"host.ip: 1.1.1.1","fields":["host.ip"],"page_size":10}`, string(requests[0].Body))
+		require.True(t, requests[0].Paginated)
+		require.Equal(t, "get_hosts", requests[1].Operation)
+		require.JSONEq(t, `{"host_ids":["1.1.1.1"]}`, string(requests[1].Body))
+		require.False(t, requests[1].Paginated)
+	})
+
+	t.Run("passes through calls that fail before sending a request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		failure := censys.NewClientError(cenclierrors.NewInterruptedError())
+		inner.EXPECT().EnrichHost(gomock.Any(), mo.None[string](), "1.1.1.1").
+			Return(censys.Result[components.HostEnrichment]{}, failure)
+
+		plan := censys.NewDryRunPlan()
+		_, err := censys.NewDryRunClient(inner, plan).EnrichHost(ctx, mo.None[string](), "1.1.1.1")
+		require.Equal(t, failure, err)
+		require.Empty(t, plan.Requests())
+	})
+}
@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/censys/censys-sdk-go/models/sdkerrors"
 	"github.com/samber/mo"
 
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
 )
 
 func NewClientError(err error) ClientError {
@@ -63,6 +65,60 @@ func (e *clientErrorAdapter) StatusCode() mo.Option[int64] {
 	return mo.None[int64]()
 }
 
+// responseInfoError is a ClientError for a response of the API, with the
+// request ID and rate limit reported by its headers.
+type responseInfoError struct {
+	ClientError
+	info responsemeta.ResponseInfo
+}
+
+var _ ClientError = &responseInfoError{}
+
+// withResponseInfo adds the request ID and rate limit reported by the
+// headers of the response that caused err to it. header is nil if no
+// response was received, in which case err is returned unchanged.
+func withResponseInfo(err ClientError, header http.Header) ClientError {
+	if err == nil || header == nil {
+		return err
+	}
+	info := responsemeta.ParseResponseHeaders(header)
+	if info.RequestID == "" && info.RateLimit == nil {
+		return err
+	}
+	return &responseInfoError{ClientError: err, info: info}
+}
+
+func (e *responseInfoError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.ClientError.Error())
+	sb.WriteString("\n")
+	if e.info.RequestID != "" {
+		sb.WriteString(fmt.Sprintf("\nRequest ID: %s (include it when contacting support)", e.info.RequestID))
+	}
+	// the rate limit is only of interest once it is reached
+	if rl := e.info.RateLimit; rl != nil && e.StatusCode().OrEmpty() == http.StatusTooManyRequests {
+		sb.WriteString(fmt.Sprintf("\nRate limit: %d/%d left", rl.Remaining, rl.Limit))
+		if reset, ok := rl.ResetIn(time.Now()); ok {
+			sb.WriteString(fmt.Sprintf(", resets in %s", reset.Round(time.Second)))
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func (e *responseInfoError) Unwrap() error {
+	return e.ClientError
+}
+
+// ResponseInfoOf returns the request ID and rate limit reported by the
+// response of the API that caused err, if any.
+func ResponseInfoOf(err error) (responsemeta.ResponseInfo, bool) {
+	var infoErr *responseInfoError
+	if errors.As(err, &infoErr) {
+		return infoErr.info, true
+	}
+	return responsemeta.ResponseInfo{}, false
+}
+
 type ClientStructuredError interface {
 	ClientError
 }
@@ -320,3 +376,41 @@ func (e *censysClientNotConfiguredError) Title() string {
 func (e *censysClientNotConfiguredError) ShouldPrintUsage() bool {
 	return false
 }
+
+// ClientUnsupportedError is returned for an operation that the legacy
+// Search 2.0 API has no endpoint for.
+type ClientUnsupportedError interface {
+	ClientError
+}
+
+type censysClientUnsupportedError struct {
+	operation string
+}
+
+var _ ClientUnsupportedError = &censysClientUnsupportedError{}
+
+// NewClientUnsupportedError returns the error of operation, e.g. "Host
+// enrichment", with the legacy API.
+func NewClientUnsupportedError(operation string) ClientUnsupportedError {
+	return &censysClientUnsupportedError{operation: operation}
+}
+
+func (e *censysClientUnsupportedError) Error() string {
+	return fmt.Sprintf("%s is not available in the legacy Search 2.0 API. Set api-flavor to platform and use a personal access token to use it.", e.operation)
+}
+
+func (e *censysClientUnsupportedError) Title() string {
+	return "Not Supported by the Legacy API"
+}
+
+func (e *censysClientUnsupportedError) ShouldPrintUsage() bool {
+	return false
+}
+
+func (e *censysClientUnsupportedError) Status() string {
+	return "unknown"
+}
+
+func (e *censysClientUnsupportedError) StatusCode() mo.Option[int64] {
+	return mo.None[int64]()
+}
@@ -3,6 +3,8 @@ package censys
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -19,6 +21,7 @@ var (
 	_ ClientError = (*censysClientUnauthorizedError)(nil)
 	_ ClientError = (*censysClientGenericError)(nil)
 	_ ClientError = (*censysClientError)(nil)
+	_ ClientError = (*responseInfoError)(nil)
 )
 
 func TestParseSDKError_Comprehensive(t *testing.T) {
@@ -268,6 +271,37 @@ func TestParseSDKError_Comprehensive(t *testing.T) {
 	}
 }
 
+func TestWithResponseInfo(t *testing.T) {
+	rateLimited := NewCensysClientGenericError(&sdkerrors.SDKError{Message: "too many requests", StatusCode: http.StatusTooManyRequests})
+	header := http.Header{
+		"X-Request-Id":          {"req-123"},
+		"X-Ratelimit-Limit":     {"100"},
+		"X-Ratelimit-Remaining": {"0"},
+		"X-Ratelimit-Reset":     {"30"},
+	}
+
+	err := withResponseInfo(rateLimited, header)
+	assert.Equal(t, "too many requests (status code: 429)\n\nRequest ID: req-123 (include it when contacting support)\nRate limit: 0/100 left, resets in 30s", err.Error())
+	assert.Equal(t, "Rate Limit Exceeded", err.Title())
+	assert.Equal(t, int64(http.StatusTooManyRequests), err.StatusCode().MustGet())
+	assert.ErrorIs(t, err, rateLimited)
+
+	info, ok := ResponseInfoOf(fmt.Errorf("search failed: %w", err))
+	require.True(t, ok)
+	assert.Equal(t, "req-123", info.RequestID)
+	assert.Equal(t, int64(0), info.RateLimit.Remaining)
+
+	// the rate limit is left out of other errors
+	notFound := NewCensysClientGenericError(&sdkerrors.SDKError{Message: "not found", StatusCode: http.StatusNotFound})
+	assert.Equal(t, "not found (status code: 404)\n\nRequest ID: req-123 (include it when contacting support)", withResponseInfo(notFound, header).Error())
+
+	// errors without a response, or whose response reports nothing, are unchanged
+	assert.Same(t, notFound, withResponseInfo(notFound, nil))
+	assert.Same(t, notFound, withResponseInfo(notFound, http.Header{"Content-Type": {"application/json"}}))
+	_, ok = ResponseInfoOf(notFound)
+	assert.False(t, ok)
+}
+
 // Helper functions for creating pointers
 func int64Ptr(i int64) *int64 {
 	return &i
@@ -0,0 +1,302 @@
+// Package fakeserver implements a fake Censys Platform API for integration
+// tests. It serves the endpoints the CLI uses (search, view, aggregate, host
+// timelines, and credits) from fixtures, and can add latency, fail requests,
+// and report a rate limit to exercise retries and error handling.
+//
+// Point the CLI at the server with the api-url config key (CENCLI_API_URL):
+//
+//	srv := fakeserver.New(fakeserver.WithLatency(50 * time.Millisecond))
+//	defer srv.Close()
+//	cmd.Env = append(cmd.Env, "CENCLI_API_URL="+srv.URL)
+package fakeserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultPageSize is the page size of searches that do not set one.
+const defaultPageSize = 100
+
+// Content types of the responses, which the SDK checks before decoding them.
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeHost     = "application/vnd.censys.api.v3.host.v1+json"
+	contentTypeTimeline = "application/vnd.censys.api.v3.host_timeline_event.v1+json"
+)
+
+// Fault makes the requests to an endpoint fail.
+type Fault struct {
+	// Endpoint is the path prefix of the requests that fail, e.g.
+	// "/v3/global/search". Empty matches every request.
+	Endpoint string
+	// Status is the status code of the error response.
+	Status int
+	// Count is the number of matching requests that fail before requests
+	// succeed again. Zero fails every matching request.
+	Count int
+	// Header is added to the error response, e.g. Retry-After.
+	Header http.Header
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// Server is a fake Censys Platform API server.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	fixtures  Fixtures
+	latency   time.Duration
+	faults    []*faultState
+	rateLimit int
+	remaining int
+	requests  []Request
+}
+
+type faultState struct {
+	Fault
+	failed int
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithFixtures sets the assets the server responds with, instead of DefaultFixtures.
+func WithFixtures(f Fixtures) Option {
+	return func(s *Server) { s.fixtures = f }
+}
+
+// WithLatency delays every response.
+func WithLatency(d time.Duration) Option {
+	return func(s *Server) { s.latency = d }
+}
+
+// WithFault makes the requests matching f fail. Faults are checked in the
+// order they are added.
+func WithFault(f Fault) Option {
+	return func(s *Server) { s.faults = append(s.faults, &faultState{Fault: f}) }
+}
+
+// WithRateLimit reports a rate limit of limit requests in X-RateLimit-*
+// headers, and responds with 429 Too Many Requests once it is used up.
+func WithRateLimit(limit int) Option {
+	return func(s *Server) {
+		s.rateLimit = limit
+		s.remaining = limit
+	}
+}
+
+// New starts a server. Call Close when done.
+func New(opts ...Option) *Server {
+	s := &Server{fixtures: DefaultFixtures()}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v3/global/search/query", s.handleSearch)
+	mux.HandleFunc("POST /v3/global/search/aggregate", s.handleAggregate)
+	mux.HandleFunc("POST /v3/global/asset/host", s.handleHosts)
+	mux.HandleFunc("GET /v3/global/asset/host/{host_id}", s.handleHost)
+	mux.HandleFunc("GET /v3/global/asset/host/{host_id}/timeline", s.handleTimeline)
+	mux.HandleFunc("GET /v3/accounts/users/credits", s.handleUserCredits)
+	mux.HandleFunc("GET /v3/accounts/organizations/{organization_id}/credits", s.handleOrgCredits)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// middleware records requests, adds latency, checks authentication, and
+// injects faults before passing requests to next.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		if s.latency > 0 {
+			select {
+			case <-time.After(s.latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: body})
+		fault := s.nextFault(r.URL.Path)
+		if s.rateLimit > 0 {
+			if s.remaining > 0 {
+				s.remaining--
+			} else if fault == nil {
+				fault = &Fault{Status: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"1"}}}
+			}
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(s.rateLimit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(s.remaining))
+		}
+		s.mu.Unlock()
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			writeError(w, http.StatusUnauthorized, "missing personal access token")
+			return
+		}
+		if fault != nil {
+			for k, v := range fault.Header {
+				w.Header()[k] = v
+			}
+			writeError(w, fault.Status, "injected by fakeserver")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// nextFault returns the fault for a request to path, if any. s.mu must be held.
+func (s *Server) nextFault(path string) *Fault {
+	for _, f := range s.faults {
+		if !strings.HasPrefix(path, f.Endpoint) || (f.Count > 0 && f.failed >= f.Count) {
+			continue
+		}
+		f.failed++
+		return &f.Fault
+	}
+	return nil
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		PageSize  int    `json:"page_size"`
+		PageToken string `json:"page_token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	pageSize := body.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	start := 0
+	if body.PageToken != "" {
+		var err error
+		if start, err = strconv.Atoi(body.PageToken); err != nil || start < 0 {
+			writeError(w, http.StatusBadRequest, "invalid page token")
+			return
+		}
+	}
+
+	hits := s.fixtures.SearchHits
+	start = min(start, len(hits))
+	end := min(start+pageSize, len(hits))
+	nextPageToken := ""
+	if end < len(hits) {
+		nextPageToken = strconv.Itoa(end)
+	}
+	page := hits[start:end]
+	if page == nil {
+		page = []json.RawMessage{}
+	}
+	writeResult(w, contentTypeJSON, map[string]any{
+		"hits":                  page,
+		"next_page_token":       nextPageToken,
+		"previous_page_token":   "",
+		"query_duration_millis": 1,
+		"total_hits":            len(hits),
+	})
+}
+
+func (s *Server) handleAggregate(w http.ResponseWriter, _ *http.Request) {
+	writeResult(w, contentTypeJSON, s.fixtures.Aggregate)
+}
+
+func (s *Server) handleHosts(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		HostIDs []string `json:"host_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	// like the API, hosts that are not found are left out
+	hosts := []json.RawMessage{}
+	for _, id := range body.HostIDs {
+		if host, ok := s.fixtures.Hosts[id]; ok {
+			hosts = append(hosts, host)
+		}
+	}
+	writeResult(w, contentTypeHost, hosts)
+}
+
+func (s *Server) handleHost(w http.ResponseWriter, r *http.Request) {
+	host, ok := s.fixtures.Hosts[r.PathValue("host_id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "host not found")
+		return
+	}
+	writeResult(w, contentTypeHost, host)
+}
+
+func (s *Server) handleTimeline(w http.ResponseWriter, r *http.Request) {
+	timeline, ok := s.fixtures.Timelines[r.PathValue("host_id")]
+	if !ok {
+		writeResult(w, contentTypeTimeline, map[string]any{"events": []any{}, "scanned_to": time.Now().UTC()})
+		return
+	}
+	writeResult(w, contentTypeTimeline, timeline)
+}
+
+func (s *Server) handleUserCredits(w http.ResponseWriter, _ *http.Request) {
+	writeResult(w, contentTypeJSON, s.fixtures.UserCredits)
+}
+
+func (s *Server) handleOrgCredits(w http.ResponseWriter, _ *http.Request) {
+	writeResult(w, contentTypeJSON, s.fixtures.OrgCredits)
+}
+
+// writeResult writes result in the response envelope of the API.
+func writeResult(w http.ResponseWriter, contentType string, result any) {
+	w.Header().Set("Content-Type", contentType)
+	_ = json.NewEncoder(w).Encode(map[string]any{"result": result})
+}
+
+// writeError writes an error response in the format the SDK expects for the status.
+func writeError(w http.ResponseWriter, status int, detail string) {
+	title := http.StatusText(status)
+	if status == http.StatusUnauthorized {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{"code": status, "message": detail, "status": title},
+		})
+		return
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"type":   "about:blank",
+		"title":  title,
+		"status": status,
+		"detail": detail,
+	})
+}
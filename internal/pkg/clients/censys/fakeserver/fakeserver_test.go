@@ -0,0 +1,170 @@
+package fakeserver_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	storemocks "github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/clients/censys/fakeserver"
+	"github.com/censys/cencli/internal/store"
+)
+
+// newClient creates an API client for srv, retrying failed requests once.
+func newClient(t *testing.T, srv *fakeserver.Server) censys.Client {
+	t.Helper()
+	st := storemocks.NewMockStore(gomock.NewController(t))
+	st.EXPECT().GetLastUsedAuthByName(gomock.Any(), config.AuthName).Return(&store.ValueForAuth{Value: "test-pat"}, nil)
+	st.EXPECT().GetLastUsedGlobalByName(gomock.Any(), config.OrgIDGlobalName).Return(nil, store.ErrGlobalNotFound)
+	client, err := censys.NewCensysSDK(context.Background(), st, srv.URL, 0, config.TransportConfig{},
+		config.RetryStrategy{MaxAttempts: 2, BaseDelay: time.Millisecond}, false)
+	require.NoError(t, err)
+	return client
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	none := mo.None[string]()
+
+	t.Run("search paginates", func(t *testing.T) {
+		srv := fakeserver.New()
+		defer srv.Close()
+		client := newClient(t, srv)
+
+		first, cerr := client.Search(ctx, none, "host.services.port: 53", nil, mo.Some[int64](2), none)
+		require.Nil(t, cerr)
+		require.Len(t, first.Data.Hits, 2)
+		assert.Equal(t, "8.8.8.8", *first.Data.Hits[0].HostV1.Resource.IP)
+		assert.Equal(t, float64(3), first.Data.TotalHits)
+		require.NotEmpty(t, first.Data.NextPageToken)
+
+		second, cerr := client.Search(ctx, none, "host.services.port: 53", nil, mo.Some[int64](2), mo.Some(first.Data.NextPageToken))
+		require.Nil(t, cerr)
+		require.Len(t, second.Data.Hits, 1)
+		assert.Empty(t, second.Data.NextPageToken)
+	})
+
+	t.Run("view, aggregate, timeline, and credits", func(t *testing.T) {
+		srv := fakeserver.New()
+		defer srv.Close()
+		client := newClient(t, srv)
+
+		hosts, cerr := client.GetHosts(ctx, none, []string{"8.8.8.8", "10.0.0.1"}, mo.None[time.Time]())
+		require.Nil(t, cerr)
+		require.Len(t, *hosts.Data, 1)
+		assert.Equal(t, "8.8.8.8", *(*hosts.Data)[0].IP)
+
+		agg, cerr := client.Aggregate(ctx, none, "*", "host.services.port", 10, none, mo.None[bool]())
+		require.Nil(t, cerr)
+		require.Len(t, agg.Data.Buckets, 3)
+		assert.Equal(t, "443", agg.Data.Buckets[0].Key)
+
+		timeline, cerr := client.HostTimeline(ctx, none, "8.8.8.8", time.Now(), time.Now().Add(-24*time.Hour))
+		require.Nil(t, cerr)
+		assert.Len(t, timeline.Data.Events, 1)
+
+		credits, cerr := client.GetUserCreditDetails(ctx)
+		require.Nil(t, cerr)
+		assert.Equal(t, int64(1000), credits.Data.Balance)
+
+		orgCredits, cerr := client.GetOrganizationCreditDetails(ctx, "00000000-0000-0000-0000-000000000000")
+		require.Nil(t, cerr)
+		assert.Equal(t, int64(50000), orgCredits.Data.Balance)
+
+		requests := srv.Requests()
+		require.Len(t, requests, 5)
+		assert.Equal(t, "/v3/global/asset/host", requests[0].Path)
+		assert.JSONEq(t, `{"host_ids": ["8.8.8.8", "10.0.0.1"]}`, string(requests[0].Body))
+	})
+
+	t.Run("injected faults are retried", func(t *testing.T) {
+		srv := fakeserver.New(fakeserver.WithFault(fakeserver.Fault{
+			Endpoint: "/v3/global/search",
+			Status:   http.StatusServiceUnavailable,
+			Count:    1,
+		}))
+		defer srv.Close()
+		client := newClient(t, srv)
+
+		res, cerr := client.Search(ctx, none, "*", nil, mo.None[int64](), none)
+		require.Nil(t, cerr)
+		assert.Equal(t, uint64(2), res.Metadata.Attempts)
+	})
+
+	t.Run("persistent faults fail", func(t *testing.T) {
+		srv := fakeserver.New(fakeserver.WithFault(fakeserver.Fault{Status: http.StatusUnprocessableEntity}))
+		defer srv.Close()
+		client := newClient(t, srv)
+
+		_, cerr := client.Aggregate(ctx, none, "*", "host.services.port", 10, none, mo.None[bool]())
+		require.NotNil(t, cerr)
+		assert.Equal(t, mo.Some[int64](http.StatusUnprocessableEntity), cerr.StatusCode())
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		srv := fakeserver.New(fakeserver.WithRateLimit(1))
+		defer srv.Close()
+		client := newClient(t, srv)
+
+		res, cerr := client.GetUserCreditDetails(ctx)
+		require.Nil(t, cerr)
+		assert.Equal(t, "0", res.Metadata.Response.Header.Get("X-RateLimit-Remaining"))
+
+		_, cerr = client.GetUserCreditDetails(ctx)
+		require.NotNil(t, cerr)
+		assert.Equal(t, mo.Some[int64](http.StatusTooManyRequests), cerr.StatusCode())
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		srv := fakeserver.New(fakeserver.WithLatency(50 * time.Millisecond))
+		defer srv.Close()
+		client := newClient(t, srv)
+
+		res, cerr := client.GetUserCreditDetails(ctx)
+		require.Nil(t, cerr)
+		assert.GreaterOrEqual(t, res.Metadata.Latency, 50*time.Millisecond)
+	})
+}
+
+func TestLoadFixtures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"user_credits": {"balance": 7}}`), 0o600))
+
+	fixtures, err := fakeserver.LoadFixtures(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"balance": 7}`, string(fixtures.UserCredits))
+	assert.Contains(t, fixtures.Hosts, "8.8.8.8", "missing assets use the defaults")
+
+	_, err = fakeserver.LoadFixtures(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestSyntheticFixtures(t *testing.T) {
+	srv := fakeserver.New(fakeserver.WithFixtures(fakeserver.SyntheticFixtures(300, 4)))
+	defer srv.Close()
+	client := newClient(t, srv)
+	none := mo.None[string]()
+
+	res, cerr := client.Search(context.Background(), none, "*", nil, mo.Some[int64](100), mo.Some("200"))
+	require.Nil(t, cerr)
+	require.Len(t, res.Data.Hits, 100)
+	assert.Equal(t, fakeserver.SyntheticHostIP(200), *res.Data.Hits[0].HostV1.Resource.IP)
+	assert.Len(t, res.Data.Hits[0].HostV1.Resource.Services, 4)
+	assert.Empty(t, res.Data.NextPageToken)
+
+	assert.Equal(t, "10.0.1.0", fakeserver.SyntheticHostIP(255))
+	hosts, cerr := client.GetHosts(context.Background(), none, []string{fakeserver.SyntheticHostIP(255)}, mo.None[time.Time]())
+	require.Nil(t, cerr)
+	require.Len(t, *hosts.Data, 1)
+	assert.Equal(t, "10.0.1.0", *(*hosts.Data)[0].IP)
+}
@@ -0,0 +1,74 @@
+package fakeserver
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+//go:embed defaults.json
+var defaultFixtures []byte
+
+// Fixtures are the assets the server responds with. Each value is the JSON
+// of the "result" the Platform API returns for it, so fixtures can be copied
+// from real responses (e.g. `censys view 8.8.8.8 -O json`).
+type Fixtures struct {
+	// Hosts are the host assets ({"resource": {...}}) returned by view, keyed by IP.
+	Hosts map[string]json.RawMessage `json:"hosts"`
+	// SearchHits are the hits returned by search for any query, in order,
+	// paginated by the page size of the request.
+	SearchHits []json.RawMessage `json:"search_hits"`
+	// Aggregate is the aggregation returned by aggregate for any query and field.
+	Aggregate json.RawMessage `json:"aggregate"`
+	// Timelines are the host timelines returned by history, keyed by IP.
+	Timelines map[string]json.RawMessage `json:"timelines"`
+	// UserCredits are the credits returned without an organization ID.
+	UserCredits json.RawMessage `json:"user_credits"`
+	// OrgCredits are the credits returned for any organization ID.
+	OrgCredits json.RawMessage `json:"org_credits"`
+}
+
+// DefaultFixtures returns a small set of fixtures: two hosts (8.8.8.8 and
+// 1.1.1.1), three search hits, an aggregation by port, the timeline of
+// 8.8.8.8, and user and organization credits.
+func DefaultFixtures() Fixtures {
+	var f Fixtures
+	if err := json.Unmarshal(defaultFixtures, &f); err != nil {
+		panic(fmt.Sprintf("fakeserver: invalid default fixtures: %v", err))
+	}
+	return f
+}
+
+// LoadFixtures reads fixtures from a JSON file with the same layout as
+// defaults.json. Assets missing from the file are taken from DefaultFixtures.
+func LoadFixtures(path string) (Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixtures{}, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+	f := DefaultFixtures()
+	var loaded Fixtures
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return Fixtures{}, fmt.Errorf("failed to parse fixtures %s: %w", path, err)
+	}
+	if loaded.Hosts != nil {
+		f.Hosts = loaded.Hosts
+	}
+	if loaded.SearchHits != nil {
+		f.SearchHits = loaded.SearchHits
+	}
+	if loaded.Aggregate != nil {
+		f.Aggregate = loaded.Aggregate
+	}
+	if loaded.Timelines != nil {
+		f.Timelines = loaded.Timelines
+	}
+	if loaded.UserCredits != nil {
+		f.UserCredits = loaded.UserCredits
+	}
+	if loaded.OrgCredits != nil {
+		f.OrgCredits = loaded.OrgCredits
+	}
+	return f, nil
+}
@@ -0,0 +1,64 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// syntheticProtocols are the protocols the services of synthetic hosts cycle
+// through.
+var syntheticProtocols = []string{"HTTP", "SSH", "DNS", "SMTP", "FTP", "RDP", "TELNET", "MYSQL"}
+
+// SyntheticFixtures returns fixtures for benchmarks: the given number of
+// hosts, with addresses from 10.0.0.1 on, each with the given number of
+// services. The hosts are both the search hits, in order, and the hosts
+// returned by view. The other fixtures are the defaults.
+func SyntheticFixtures(hosts, services int) Fixtures {
+	f := DefaultFixtures()
+	f.Hosts = make(map[string]json.RawMessage, hosts)
+	f.SearchHits = make([]json.RawMessage, 0, hosts)
+	for i := range hosts {
+		resource := syntheticHost(SyntheticHostIP(i), i, services)
+		host, err := json.Marshal(map[string]any{"resource": resource})
+		if err != nil {
+			panic(fmt.Sprintf("fakeserver: invalid synthetic host: %v", err))
+		}
+		hit, err := json.Marshal(map[string]any{"host_v1": map[string]any{"resource": resource}})
+		if err != nil {
+			panic(fmt.Sprintf("fakeserver: invalid synthetic hit: %v", err))
+		}
+		f.Hosts[SyntheticHostIP(i)] = host
+		f.SearchHits = append(f.SearchHits, hit)
+	}
+	return f
+}
+
+// SyntheticHostIP returns the address of the i-th host of SyntheticFixtures.
+func SyntheticHostIP(i int) string {
+	n := i + 1
+	return fmt.Sprintf("10.%d.%d.%d", n>>16&0xff, n>>8&0xff, n&0xff)
+}
+
+func syntheticHost(ip string, i, services int) map[string]any {
+	svcs := make([]map[string]any, services)
+	for j := range services {
+		protocol := syntheticProtocols[j%len(syntheticProtocols)]
+		svcs[j] = map[string]any{
+			"port":               1024 + j,
+			"protocol":           protocol,
+			"transport_protocol": "tcp",
+			"banner":             fmt.Sprintf("%s banner of %s:%d", protocol, ip, 1024+j),
+			"software": []map[string]any{
+				{"vendor": "example", "product": fmt.Sprintf("server-%d", j%5), "version": fmt.Sprintf("1.%d", i%10)},
+			},
+		}
+	}
+	return map[string]any{
+		"ip":                ip,
+		"autonomous_system": map[string]any{"asn": 64512 + i%100, "name": fmt.Sprintf("AS-EXAMPLE-%d", i%100), "bgp_prefix": "10.0.0.0/8", "country_code": "US"},
+		"location":          map[string]any{"country": "United States", "country_code": "US", "city": "Ann Arbor"},
+		"dns":               map[string]any{"names": []string{fmt.Sprintf("host-%d.example.com", i)}},
+		"service_count":     services,
+		"services":          svcs,
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/censys/censys-sdk-go/models/components"
 	"github.com/censys/censys-sdk-go/models/operations"
 	"github.com/samber/mo"
+
+	clienthttp "github.com/censys/cencli/internal/pkg/clients/http"
 )
 
 //go:generate mockgen -destination=../../../../gen/client/mocks/globaldata_mock.go -package=mocks github.com/censys/cencli/internal/pkg/clients/censys GlobalDataClient
@@ -87,7 +89,7 @@ func (g *globalDataSDK) GetHosts(
 ) (Result[[]components.Host], ClientError) {
 	start := time.Now()
 	var res *operations.V3GlobaldataAssetHostListPostResponse
-	err, attempts := g.executeWithRetry(ctx, func() ClientError {
+	err, attempts := g.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = g.censysSDK.client.GlobalData.GetHosts(ctx, operations.V3GlobaldataAssetHostListPostRequest{
 			OrganizationID: orgID.ToPointer(),
@@ -120,7 +122,7 @@ func (g *globalDataSDK) GetHosts(
 func (g *globalDataSDK) GetCertificates(ctx context.Context, orgID mo.Option[string], certificateIDs []string) (Result[[]components.Certificate], ClientError) {
 	start := time.Now()
 	var res *operations.V3GlobaldataAssetCertificateListPostResponse
-	err, attempts := g.executeWithRetry(ctx, func() ClientError {
+	err, attempts := g.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = g.censysSDK.client.GlobalData.GetCertificates(ctx, operations.V3GlobaldataAssetCertificateListPostRequest{
 			OrganizationID: orgID.ToPointer(),
@@ -157,7 +159,7 @@ func (g *globalDataSDK) GetWebProperties(
 ) (Result[[]components.Webproperty], ClientError) {
 	start := time.Now()
 	var res *operations.V3GlobaldataAssetWebpropertyListPostResponse
-	err, attempts := g.executeWithRetry(ctx, func() ClientError {
+	err, attempts := g.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = g.censysSDK.client.GlobalData.GetWebProperties(ctx, operations.V3GlobaldataAssetWebpropertyListPostRequest{
 			OrganizationID: orgID.ToPointer(),
@@ -197,7 +199,7 @@ func (g *globalDataSDK) Search(
 ) (Result[components.SearchQueryResponse], ClientError) {
 	start := time.Now()
 	var res *operations.V3GlobaldataSearchQueryResponse
-	err, attempts := g.executeWithRetry(ctx, func() ClientError {
+	err, attempts := g.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = g.censysSDK.client.GlobalData.Search(ctx, operations.V3GlobaldataSearchQueryRequest{
 			OrganizationID: orgID.ToPointer(),
@@ -235,6 +237,7 @@ func (g *globalDataSDK) Aggregate(
 	filterByQuery mo.Option[bool],
 ) (Result[components.SearchAggregateResponse], ClientError) {
 	start := time.Now()
+	ctx, headers := clienthttp.WithResponseHeaders(ctx)
 	res, err := g.censysSDK.client.GlobalData.Aggregate(ctx, operations.V3GlobaldataSearchAggregateRequest{
 		OrganizationID: orgID.ToPointer(),
 		SearchAggregateInputBody: components.SearchAggregateInputBody{
@@ -248,7 +251,7 @@ func (g *globalDataSDK) Aggregate(
 	latency := time.Since(start)
 	if err != nil {
 		zero := Result[components.SearchAggregateResponse]{}
-		return zero, NewClientError(err)
+		return zero, withResponseInfo(NewClientError(err), headers.Get())
 	}
 	searchAggregateResponse := res.GetResponseEnvelopeSearchAggregateResponse().GetResult()
 	return Result[components.SearchAggregateResponse]{
@@ -266,7 +269,7 @@ func (g *globalDataSDK) HostTimeline(
 ) (Result[components.HostTimeline], ClientError) {
 	start := time.Now()
 	var res *operations.V3GlobaldataAssetHostTimelineResponse
-	err, attempts := g.executeWithRetry(ctx, func() ClientError {
+	err, attempts := g.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		req := operations.V3GlobaldataAssetHostTimelineRequest{
 			OrganizationID: orgID.ToPointer(),
@@ -302,7 +305,7 @@ func (g *globalDataSDK) EnrichHost(
 ) (Result[components.HostEnrichment], ClientError) {
 	start := time.Now()
 	var res *operations.V3GlobaldataAssetHostEnrichmentResponse
-	err, attempts := g.executeWithRetry(ctx, func() ClientError {
+	err, attempts := g.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = g.censysSDK.client.GlobalData.GetHostEnrichment(ctx, operations.V3GlobaldataAssetHostEnrichmentRequest{
 			OrganizationID: orgID.ToPointer(),
@@ -0,0 +1,268 @@
+package censys
+
+import (
+	"context"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/tracing"
+)
+
+// MetricsRecorder receives an observation for every call made through an instrumented client.
+type MetricsRecorder interface {
+	// ObserveCall records a single client operation.
+	ObserveCall(operation string, latency time.Duration, attempts uint64, statusCode int, failed bool)
+	// ObservePages records that n result pages were fetched.
+	ObservePages(n int)
+}
+
+// instrumentedClient decorates a Client, reporting each call to a MetricsRecorder.
+type instrumentedClient struct {
+	Client
+	recorder MetricsRecorder
+}
+
+var _ Client = &instrumentedClient{}
+
+// NewInstrumentedClient wraps inner so that every API call is reported to recorder.
+func NewInstrumentedClient(inner Client, recorder MetricsRecorder) Client {
+	return &instrumentedClient{Client: inner, recorder: recorder}
+}
+
+// startSpan starts the span of a call to the API.
+func startSpan(ctx context.Context, operation string) (context.Context, *tracing.Span) {
+	return tracing.Start(ctx, "censys."+operation, tracing.KindClient, tracing.String("cencli.operation", operation))
+}
+
+// observe reports a completed call to the recorder, ends its span, and
+// passes its results through unchanged.
+func observe[T any](
+	recorder MetricsRecorder,
+	span *tracing.Span,
+	operation string,
+	start time.Time,
+	pages int,
+	res Result[T],
+	err ClientError,
+) (Result[T], ClientError) {
+	latency := res.Metadata.Latency
+	if latency == 0 {
+		latency = time.Since(start)
+	}
+	attempts := res.Metadata.Attempts
+	if attempts == 0 {
+		attempts = 1
+	}
+	statusCode := 0
+	if res.Metadata.Response != nil {
+		statusCode = res.Metadata.Response.StatusCode
+	} else if err != nil {
+		if code, ok := err.StatusCode().Get(); ok {
+			statusCode = int(code)
+		}
+	}
+	recorder.ObserveCall(operation, latency, attempts, statusCode, err != nil)
+	if err == nil {
+		recorder.ObservePages(pages)
+	}
+	span.SetAttributes(tracing.Int("cencli.attempts", int64(attempts)), tracing.Int("cencli.pages", int64(pages)))
+	if statusCode > 0 {
+		span.SetAttributes(tracing.Int("http.response.status_code", int64(statusCode)))
+	}
+	if err != nil {
+		span.SetError(err)
+	}
+	span.End()
+	return res, err
+}
+
+func (c *instrumentedClient) GetHosts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Host], ClientError) {
+	ctx, span := startSpan(ctx, "get_hosts")
+	start := time.Now()
+	res, err := c.Client.GetHosts(ctx, orgID, hostIDs, atTime)
+	return observe(c.recorder, span, "get_hosts", start, 0, res, err)
+}
+
+func (c *instrumentedClient) GetCertificates(
+	ctx context.Context,
+	orgID mo.Option[string],
+	certificateIDs []string,
+) (Result[[]components.Certificate], ClientError) {
+	ctx, span := startSpan(ctx, "get_certificates")
+	start := time.Now()
+	res, err := c.Client.GetCertificates(ctx, orgID, certificateIDs)
+	return observe(c.recorder, span, "get_certificates", start, 0, res, err)
+}
+
+func (c *instrumentedClient) GetWebProperties(
+	ctx context.Context,
+	orgID mo.Option[string],
+	webPropertyIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Webproperty], ClientError) {
+	ctx, span := startSpan(ctx, "get_web_properties")
+	start := time.Now()
+	res, err := c.Client.GetWebProperties(ctx, orgID, webPropertyIDs, atTime)
+	return observe(c.recorder, span, "get_web_properties", start, 0, res, err)
+}
+
+func (c *instrumentedClient) Search(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	ctx, span := startSpan(ctx, "search")
+	start := time.Now()
+	res, err := c.Client.Search(ctx, orgID, query, fields, pageSize, pageToken)
+	return observe(c.recorder, span, "search", start, 1, res, err)
+}
+
+func (c *instrumentedClient) Aggregate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	ctx, span := startSpan(ctx, "aggregate")
+	start := time.Now()
+	res, err := c.Client.Aggregate(ctx, orgID, query, field, numBuckets, countByLevel, filterByQuery)
+	return observe(c.recorder, span, "aggregate", start, 0, res, err)
+}
+
+func (c *instrumentedClient) HostTimeline(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostID string,
+	fromTime time.Time,
+	toTime time.Time,
+) (Result[components.HostTimeline], ClientError) {
+	ctx, span := startSpan(ctx, "host_timeline")
+	start := time.Now()
+	res, err := c.Client.HostTimeline(ctx, orgID, hostID, fromTime, toTime)
+	return observe(c.recorder, span, "host_timeline", start, 0, res, err)
+}
+
+func (c *instrumentedClient) EnrichHost(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIP string,
+) (Result[components.HostEnrichment], ClientError) {
+	ctx, span := startSpan(ctx, "enrich_host")
+	start := time.Now()
+	res, err := c.Client.EnrichHost(ctx, orgID, hostIP)
+	return observe(c.recorder, span, "enrich_host", start, 0, res, err)
+}
+
+func (c *instrumentedClient) SearchCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	ctx, span := startSpan(ctx, "search_collection")
+	start := time.Now()
+	res, err := c.Client.SearchCollection(ctx, collectionID, orgID, query, fields, pageSize, pageToken)
+	return observe(c.recorder, span, "search_collection", start, 1, res, err)
+}
+
+func (c *instrumentedClient) AggregateCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	ctx, span := startSpan(ctx, "aggregate_collection")
+	start := time.Now()
+	res, err := c.Client.AggregateCollection(ctx, collectionID, orgID, query, field, numBuckets, countByLevel, filterByQuery)
+	return observe(c.recorder, span, "aggregate_collection", start, 0, res, err)
+}
+
+func (c *instrumentedClient) GetHostObservationsWithCertificate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	certificateID string,
+	startTime mo.Option[time.Time],
+	endTime mo.Option[time.Time],
+	port mo.Option[int],
+	protocol mo.Option[string],
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.HostObservationResponse], ClientError) {
+	ctx, span := startSpan(ctx, "get_host_observations_with_certificate")
+	start := time.Now()
+	res, err := c.Client.GetHostObservationsWithCertificate(ctx, orgID, certificateID, startTime, endTime, port, protocol, pageSize, pageToken)
+	return observe(c.recorder, span, "get_host_observations_with_certificate", start, 1, res, err)
+}
+
+func (c *instrumentedClient) GetValueCounts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query mo.Option[string],
+	andCountConditions []components.CountCondition,
+) (Result[components.ValueCountsResponse], ClientError) {
+	ctx, span := startSpan(ctx, "get_value_counts")
+	start := time.Now()
+	res, err := c.Client.GetValueCounts(ctx, orgID, query, andCountConditions)
+	return observe(c.recorder, span, "get_value_counts", start, 0, res, err)
+}
+
+func (c *instrumentedClient) GetOrganizationCreditDetails(
+	ctx context.Context,
+	orgID string,
+) (Result[components.OrganizationCredits], ClientError) {
+	ctx, span := startSpan(ctx, "get_organization_credit_details")
+	start := time.Now()
+	res, err := c.Client.GetOrganizationCreditDetails(ctx, orgID)
+	return observe(c.recorder, span, "get_organization_credit_details", start, 0, res, err)
+}
+
+func (c *instrumentedClient) GetUserCreditDetails(
+	ctx context.Context,
+) (Result[components.UserCredits], ClientError) {
+	ctx, span := startSpan(ctx, "get_user_credit_details")
+	start := time.Now()
+	res, err := c.Client.GetUserCreditDetails(ctx)
+	return observe(c.recorder, span, "get_user_credit_details", start, 0, res, err)
+}
+
+func (c *instrumentedClient) GetOrganizationDetails(
+	ctx context.Context,
+	orgID string,
+) (Result[components.OrganizationDetails], ClientError) {
+	ctx, span := startSpan(ctx, "get_organization_details")
+	start := time.Now()
+	res, err := c.Client.GetOrganizationDetails(ctx, orgID)
+	return observe(c.recorder, span, "get_organization_details", start, 0, res, err)
+}
+
+func (c *instrumentedClient) ListOrganizationMembers(
+	ctx context.Context,
+	orgID string,
+	pageSize mo.Option[int],
+	pageToken mo.Option[string],
+) (Result[components.OrganizationMembersList], ClientError) {
+	ctx, span := startSpan(ctx, "list_organization_members")
+	start := time.Now()
+	res, err := c.Client.ListOrganizationMembers(ctx, orgID, pageSize, pageToken)
+	return observe(c.recorder, span, "list_organization_members", start, 1, res, err)
+}
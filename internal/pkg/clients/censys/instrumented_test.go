@@ -0,0 +1,118 @@
+package censys_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/tracing"
+)
+
+type observation struct {
+	operation  string
+	latency    time.Duration
+	attempts   uint64
+	statusCode int
+	failed     bool
+}
+
+type fakeRecorder struct {
+	calls []observation
+	pages int
+}
+
+func (r *fakeRecorder) ObserveCall(operation string, latency time.Duration, attempts uint64, statusCode int, failed bool) {
+	r.calls = append(r.calls, observation{operation, latency, attempts, statusCode, failed})
+}
+
+func (r *fakeRecorder) ObservePages(n int) { r.pages += n }
+
+func TestInstrumentedClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records successful search page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().Search(ctx, mo.None[string](), "q", nil, mo.None[int64](), mo.None[string]()).
+			Return(censys.Result[components.SearchQueryResponse]{
+				Metadata: censys.Metadata{
+					Response: &http.Response{StatusCode: 200},
+					Latency:  150 * time.Millisecond,
+					Attempts: 2,
+				},
+			}, nil)
+
+		rec := &fakeRecorder{}
+		c := censys.NewInstrumentedClient(inner, rec)
+		_, err := c.Search(ctx, mo.None[string](), "q", nil, mo.None[int64](), mo.None[string]())
+		require.NoError(t, err)
+		require.Equal(t, []observation{{"search", 150 * time.Millisecond, 2, 200, false}}, rec.calls)
+		require.Equal(t, 1, rec.pages)
+	})
+
+	t.Run("records failed lookup", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().GetHosts(ctx, mo.None[string](), []string{"1.1.1.1"}, mo.None[time.Time]()).
+			Return(censys.Result[[]components.Host]{}, censys.NewClientError(cenclierrors.NewCencliError(context.DeadlineExceeded)))
+
+		rec := &fakeRecorder{}
+		c := censys.NewInstrumentedClient(inner, rec)
+		_, err := c.GetHosts(ctx, mo.None[string](), []string{"1.1.1.1"}, mo.None[time.Time]())
+		require.Error(t, err)
+		require.Len(t, rec.calls, 1)
+		require.Equal(t, "get_hosts", rec.calls[0].operation)
+		require.Equal(t, uint64(1), rec.calls[0].attempts)
+		require.True(t, rec.calls[0].failed)
+		require.Zero(t, rec.pages)
+	})
+
+	t.Run("records a span per call", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().Search(gomock.Any(), mo.None[string](), "q", nil, mo.None[int64](), mo.None[string]()).
+			Return(censys.Result[components.SearchQueryResponse]{
+				Metadata: censys.Metadata{Response: &http.Response{StatusCode: 200}, Attempts: 1},
+			}, nil)
+		inner.EXPECT().GetHosts(gomock.Any(), mo.None[string](), []string{"1.1.1.1"}, mo.None[time.Time]()).
+			Return(censys.Result[[]components.Host]{}, censys.NewClientError(cenclierrors.NewCencliError(context.DeadlineExceeded)))
+
+		tracer := tracing.New()
+		tctx := tracing.WithTracer(ctx, tracer)
+		c := censys.NewInstrumentedClient(inner, &fakeRecorder{})
+		_, err := c.Search(tctx, mo.None[string](), "q", nil, mo.None[int64](), mo.None[string]())
+		require.NoError(t, err)
+		_, err = c.GetHosts(tctx, mo.None[string](), []string{"1.1.1.1"}, mo.None[time.Time]())
+		require.Error(t, err)
+
+		spans := tracer.Spans()
+		require.Len(t, spans, 2)
+		require.Equal(t, "censys.search", spans[0].Name())
+		require.Equal(t, []tracing.Attr{
+			tracing.String("cencli.operation", "search"),
+			tracing.Int("cencli.attempts", 1),
+			tracing.Int("cencli.pages", 1),
+			tracing.Int("http.response.status_code", 200),
+		}, spans[0].Attributes())
+		require.Equal(t, "censys.get_hosts", spans[1].Name())
+	})
+
+	t.Run("passes through non-api methods", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().HasOrgID().Return(true)
+
+		rec := &fakeRecorder{}
+		require.True(t, censys.NewInstrumentedClient(inner, rec).HasOrgID())
+		require.Empty(t, rec.calls)
+	})
+}
@@ -0,0 +1,477 @@
+package censys
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/censys/censys-sdk-go/models/sdkerrors"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/config"
+	clienthttp "github.com/censys/cencli/internal/pkg/clients/http"
+	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
+	applog "github.com/censys/cencli/internal/pkg/log"
+	"github.com/censys/cencli/internal/store"
+)
+
+const (
+	// LegacyAPIURL is the base URL of the legacy Search 2.0 API.
+	LegacyAPIURL = "https://search.censys.io/api"
+	// LegacyAPIIDEnv and LegacyAPISecretEnv are the environment variables of
+	// the API ID and secret of the legacy Search 2.0 API, as used by its
+	// other clients.
+	LegacyAPIIDEnv     = "CENSYS_API_ID"
+	LegacyAPISecretEnv = "CENSYS_API_SECRET"
+
+	// legacyMaxPageSize is the largest page of hosts of the legacy search
+	// endpoint.
+	legacyMaxPageSize = 100
+)
+
+// legacyClient is a Client for the legacy Search 2.0 API. Its results are
+// mapped into the components of the Platform API, so that commands work
+// unchanged; operations the legacy API has no endpoint for fail with a
+// ClientUnsupportedError.
+type legacyClient struct {
+	// censysSDK only provides the retry strategy and logger; it has no SDK
+	// client.
+	*censysSDK
+	http      *clienthttp.Client
+	baseURL   string
+	apiID     string
+	apiSecret string
+}
+
+var _ Client = &legacyClient{}
+
+// NewLegacyClient creates a client of the legacy Search 2.0 API,
+// authenticated with the API ID and secret of the CENSYS_API_ID and
+// CENSYS_API_SECRET environment variables, or else with the last used
+// personal access token of ds, stored as <api-id>:<api-secret>.
+func NewLegacyClient(
+	ctx context.Context,
+	ds store.Store,
+	apiURL string,
+	httpRequestTimeout time.Duration,
+	transport config.TransportConfig,
+	retryStrategy config.RetryStrategy,
+	debug bool,
+) (Client, error) {
+	if err := validateAPIURL(apiURL); err != nil {
+		return nil, err
+	}
+	apiID, apiSecret, err := legacyCredentials(ctx, ds)
+	if err != nil {
+		return nil, err
+	}
+	return NewLegacyClientWithCredentials(apiID, apiSecret, apiURL, httpRequestTimeout, transport, retryStrategy, debug)
+}
+
+// NewLegacyClientWithCredentials creates a client of the legacy Search 2.0
+// API at apiURL, or at LegacyAPIURL if it is empty, authenticated with apiID
+// and apiSecret.
+func NewLegacyClientWithCredentials(
+	apiID string,
+	apiSecret string,
+	apiURL string,
+	httpRequestTimeout time.Duration,
+	transport config.TransportConfig,
+	retryStrategy config.RetryStrategy,
+	debug bool,
+) (Client, error) {
+	if err := validateAPIURL(apiURL); err != nil {
+		return nil, err
+	}
+	if apiURL == "" {
+		apiURL = LegacyAPIURL
+	}
+	var logger *slog.Logger
+	if debug {
+		logger = applog.New(debug, nil)
+	}
+	return &legacyClient{
+		censysSDK: &censysSDK{retryStrategy: retryStrategy, logger: logger},
+		http: clienthttp.New(httpRequestTimeout, buildUserAgent(), logger,
+			clienthttp.WithTransport(clienthttp.Transport{
+				MaxIdleConns:        int(transport.MaxIdleConns),
+				MaxIdleConnsPerHost: int(transport.MaxIdleConnsPerHost),
+				IdleTimeout:         transport.IdleTimeout,
+				KeepAlive:           transport.KeepAlive,
+				HTTP2:               transport.HTTP2,
+			})),
+		baseURL:   strings.TrimRight(apiURL, "/"),
+		apiID:     apiID,
+		apiSecret: apiSecret,
+	}, nil
+}
+
+// LegacyCredentialsFromEnv returns true if both CENSYS_API_ID and
+// CENSYS_API_SECRET are set, in which case the legacy client uses them
+// instead of the stored token.
+func LegacyCredentialsFromEnv() bool {
+	return os.Getenv(LegacyAPIIDEnv) != "" && os.Getenv(LegacyAPISecretEnv) != ""
+}
+
+// legacyCredentials returns the API ID and secret of the environment, or
+// else of the last used personal access token of ds.
+func legacyCredentials(ctx context.Context, ds store.Store) (string, string, error) {
+	if LegacyCredentialsFromEnv() {
+		return os.Getenv(LegacyAPIIDEnv), os.Getenv(LegacyAPISecretEnv), nil
+	}
+	stored, err := ds.GetLastUsedAuthByName(ctx, config.AuthName)
+	if err != nil {
+		if errors.Is(err, authdom.ErrAuthNotFound) {
+			return "", "", err
+		}
+		return "", "", fmt.Errorf("failed to get last used auth: %w", err)
+	}
+	apiID, apiSecret, ok := strings.Cut(stored.Value, ":")
+	if !ok || apiID == "" || apiSecret == "" {
+		// not configured for the legacy API, rather than broken, so that
+		// commands that need no client still run
+		return "", "", fmt.Errorf("%w: the active token is not a legacy API ID and secret (<api-id>:<api-secret>)", authdom.ErrAuthNotFound)
+	}
+	return apiID, apiSecret, nil
+}
+
+// legacyEnvelope is the envelope of the responses of the v2 endpoints of the
+// legacy API.
+type legacyEnvelope[T any] struct {
+	Result T `json:"result"`
+}
+
+// get sends a GET request for path and decodes the body of the response into
+// result.
+func (c *legacyClient) get(ctx context.Context, path string, query url.Values, result any) (Metadata, ClientError) {
+	start := time.Now()
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var meta Metadata
+	err, attempts := c.executeWithRetry(ctx, func(ctx context.Context) ClientError {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return NewClientError(err)
+		}
+		req.SetBasicAuth(c.apiID, c.apiSecret)
+		req.Header.Set("Accept", "application/json")
+		res, err := c.http.Do(req)
+		if err != nil {
+			return NewClientError(err)
+		}
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return NewClientError(err)
+		}
+		if res.StatusCode != http.StatusOK {
+			return NewClientError(sdkerrors.NewSDKError(legacyErrorMessage(body, res.Status), res.StatusCode, string(body), res))
+		}
+		if err := json.Unmarshal(body, result); err != nil {
+			return NewClientError(fmt.Errorf("failed to decode the response of %s: %w", path, err))
+		}
+		meta = Metadata{Request: req, Response: res}
+		return nil
+	})
+	meta.Latency = time.Since(start)
+	meta.Attempts = attempts
+	return meta, err
+}
+
+// legacyErrorMessage returns the error message of a response body of the
+// legacy API, or status if it has none.
+func legacyErrorMessage(body []byte, status string) string {
+	var res struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &res) == nil && res.Error != "" {
+		return res.Error
+	}
+	return status
+}
+
+func (c *legacyClient) GetHosts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Host], ClientError) {
+	query := url.Values{}
+	if t, ok := atTime.Get(); ok {
+		query.Set("at_time", t.UTC().Format(time.RFC3339))
+	}
+	// the legacy API looks up one host per request
+	var meta Metadata
+	hosts := make([]components.Host, 0, len(hostIDs))
+	for _, hostID := range hostIDs {
+		var res legacyEnvelope[legacyHost]
+		m, err := c.get(ctx, "/v2/hosts/"+url.PathEscape(hostID), query, &res)
+		if err != nil {
+			// like the Platform API, leave out the hosts that are not found
+			if err.StatusCode().OrEmpty() == http.StatusNotFound {
+				continue
+			}
+			return Result[[]components.Host]{}, err
+		}
+		hosts = append(hosts, res.Result.toHost())
+		meta = mergeLegacyMetadata(meta, m)
+	}
+	return Result[[]components.Host]{Metadata: meta, Data: &hosts}, nil
+}
+
+func (c *legacyClient) GetCertificates(
+	ctx context.Context,
+	orgID mo.Option[string],
+	certificateIDs []string,
+) (Result[[]components.Certificate], ClientError) {
+	var meta Metadata
+	certificates := make([]components.Certificate, 0, len(certificateIDs))
+	for _, certificateID := range certificateIDs {
+		var res legacyEnvelope[legacyCertificate]
+		m, err := c.get(ctx, "/v2/certificates/"+url.PathEscape(certificateID), nil, &res)
+		if err != nil {
+			// like the Platform API, leave out the certificates that are not found
+			if err.StatusCode().OrEmpty() == http.StatusNotFound {
+				continue
+			}
+			return Result[[]components.Certificate]{}, err
+		}
+		certificates = append(certificates, res.Result.toCertificate())
+		meta = mergeLegacyMetadata(meta, m)
+	}
+	return Result[[]components.Certificate]{Metadata: meta, Data: &certificates}, nil
+}
+
+// mergeLegacyMetadata returns the metadata of a lookup of several assets, one
+// request each: that of the last request, with the latency and attempts of
+// all of them.
+func mergeLegacyMetadata(acc, m Metadata) Metadata {
+	m.Latency += acc.Latency
+	m.Attempts += acc.Attempts
+	return m
+}
+
+func (c *legacyClient) GetWebProperties(
+	ctx context.Context,
+	orgID mo.Option[string],
+	webPropertyIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Webproperty], ClientError) {
+	return Result[[]components.Webproperty]{}, NewClientUnsupportedError("Looking up web properties")
+}
+
+// Search searches hosts, the only index of the legacy API with the same
+// assets as the Platform API. The query is in the Search 2.0 language, and
+// fields are ignored.
+func (c *legacyClient) Search(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	params := url.Values{"q": {query}}
+	if size, ok := pageSize.Get(); ok {
+		params.Set("per_page", strconv.FormatInt(min(size, legacyMaxPageSize), 10))
+	}
+	if token, ok := pageToken.Get(); ok && token != "" {
+		params.Set("cursor", token)
+	}
+	var envelope legacyEnvelope[struct {
+		Total    float64      `json:"total"`
+		Duration int          `json:"duration"`
+		Hits     []legacyHost `json:"hits"`
+		Links    struct {
+			Prev string `json:"prev"`
+			Next string `json:"next"`
+		} `json:"links"`
+	}]
+	meta, err := c.get(ctx, "/v2/hosts/search", params, &envelope)
+	if err != nil {
+		return Result[components.SearchQueryResponse]{}, err
+	}
+	res := envelope.Result
+	hits := make([]components.SearchQueryHit, len(res.Hits))
+	for i, hit := range res.Hits {
+		hits[i] = components.SearchQueryHit{HostV1: &components.HostAssetWithMatchedServices{Resource: hit.toHost()}}
+	}
+	return Result[components.SearchQueryResponse]{
+		Metadata: meta,
+		Data: &components.SearchQueryResponse{
+			Hits:                hits,
+			NextPageToken:       res.Links.Next,
+			PreviousPageToken:   res.Links.Prev,
+			QueryDurationMillis: res.Duration,
+			TotalHits:           res.Total,
+		},
+	}, nil
+}
+
+// Aggregate aggregates hosts. The query and field are in the Search 2.0
+// language; countByLevel and filterByQuery have no equivalent and are ignored.
+func (c *legacyClient) Aggregate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	params := url.Values{
+		"q":           {query},
+		"field":       {field},
+		"num_buckets": {strconv.FormatInt(numBuckets, 10)},
+	}
+	var envelope legacyEnvelope[struct {
+		Total        int64 `json:"total"`
+		TotalOmitted int64 `json:"total_omitted"`
+		Buckets      []struct {
+			Key   string `json:"key"`
+			Count int64  `json:"count"`
+		} `json:"buckets"`
+	}]
+	meta, err := c.get(ctx, "/v2/hosts/aggregate", params, &envelope)
+	if err != nil {
+		return Result[components.SearchAggregateResponse]{}, err
+	}
+	res := envelope.Result
+	buckets := make([]components.SearchAggregateResponseBucket, len(res.Buckets))
+	for i, b := range res.Buckets {
+		buckets[i] = components.SearchAggregateResponseBucket{Key: b.Key, Count: b.Count}
+	}
+	return Result[components.SearchAggregateResponse]{
+		Metadata: meta,
+		Data: &components.SearchAggregateResponse{
+			Buckets:    buckets,
+			OtherCount: res.TotalOmitted,
+			TotalCount: res.Total,
+		},
+	}, nil
+}
+
+func (c *legacyClient) HostTimeline(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostID string,
+	fromTime time.Time,
+	toTime time.Time,
+) (Result[components.HostTimeline], ClientError) {
+	return Result[components.HostTimeline]{}, NewClientUnsupportedError("The host timeline")
+}
+
+func (c *legacyClient) EnrichHost(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIP string,
+) (Result[components.HostEnrichment], ClientError) {
+	return Result[components.HostEnrichment]{}, NewClientUnsupportedError("Host enrichment")
+}
+
+func (c *legacyClient) SearchCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	return Result[components.SearchQueryResponse]{}, NewClientUnsupportedError("Searching collections")
+}
+
+func (c *legacyClient) AggregateCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	return Result[components.SearchAggregateResponse]{}, NewClientUnsupportedError("Aggregating collections")
+}
+
+func (c *legacyClient) GetHostObservationsWithCertificate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	certificateID string,
+	startTime mo.Option[time.Time],
+	endTime mo.Option[time.Time],
+	port mo.Option[int],
+	protocol mo.Option[string],
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.HostObservationResponse], ClientError) {
+	return Result[components.HostObservationResponse]{}, NewClientUnsupportedError("Threat hunting")
+}
+
+func (c *legacyClient) GetValueCounts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query mo.Option[string],
+	andCountConditions []components.CountCondition,
+) (Result[components.ValueCountsResponse], ClientError) {
+	return Result[components.ValueCountsResponse]{}, NewClientUnsupportedError("Threat hunting")
+}
+
+func (c *legacyClient) GetOrganizationCreditDetails(
+	ctx context.Context,
+	orgID string,
+) (Result[components.OrganizationCredits], ClientError) {
+	return Result[components.OrganizationCredits]{}, NewClientUnsupportedError("Organizations")
+}
+
+// GetUserCreditDetails returns the quota of the account: the queries left
+// this month, and when they reset.
+func (c *legacyClient) GetUserCreditDetails(
+	ctx context.Context,
+) (Result[components.UserCredits], ClientError) {
+	var res struct {
+		Quota struct {
+			Used      int64  `json:"used"`
+			Allowance int64  `json:"allowance"`
+			ResetsAt  string `json:"resets_at"`
+		} `json:"quota"`
+	}
+	// the account endpoint is of v1, and has no result envelope
+	meta, err := c.get(ctx, "/v1/account", nil, &res)
+	if err != nil {
+		return Result[components.UserCredits]{}, err
+	}
+	credits := components.UserCredits{Balance: res.Quota.Allowance - res.Quota.Used}
+	if resetsAt, parseErr := parseLegacyTime(res.Quota.ResetsAt); parseErr == nil {
+		credits.ResetsAt = &resetsAt
+	}
+	return Result[components.UserCredits]{Metadata: meta, Data: &credits}, nil
+}
+
+func (c *legacyClient) GetOrganizationDetails(
+	ctx context.Context,
+	orgID string,
+) (Result[components.OrganizationDetails], ClientError) {
+	return Result[components.OrganizationDetails]{}, NewClientUnsupportedError("Organizations")
+}
+
+func (c *legacyClient) ListOrganizationMembers(
+	ctx context.Context,
+	orgID string,
+	pageSize mo.Option[int],
+	pageToken mo.Option[string],
+) (Result[components.OrganizationMembersList], ClientError) {
+	return Result[components.OrganizationMembersList]{}, NewClientUnsupportedError("Organizations")
+}
@@ -0,0 +1,156 @@
+package censys
+
+import (
+	"strings"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+)
+
+// legacyHost is a host of the legacy API, as looked up or searched.
+type legacyHost struct {
+	IP               string               `json:"ip"`
+	Services         []legacyService      `json:"services"`
+	Location         *components.Location `json:"location"`
+	AutonomousSystem *components.Routing  `json:"autonomous_system"`
+	DNS              *components.HostDNS  `json:"dns"`
+	OperatingSystem  *legacySoftware      `json:"operating_system"`
+	Labels           []string             `json:"labels"`
+}
+
+type legacyService struct {
+	Port              int              `json:"port"`
+	ServiceName       string           `json:"service_name"`
+	TransportProtocol string           `json:"transport_protocol"`
+	Banner            string           `json:"banner"`
+	ObservedAt        string           `json:"observed_at"`
+	Software          []legacySoftware `json:"software"`
+	Labels            []string         `json:"labels"`
+}
+
+type legacySoftware struct {
+	Vendor  string `json:"vendor"`
+	Product string `json:"product"`
+	Version string `json:"version"`
+	CPE     string `json:"uniform_resource_identifier"`
+}
+
+// toHost maps h into a host of the Platform API.
+func (h legacyHost) toHost() components.Host {
+	serviceCount := len(h.Services)
+	host := components.Host{
+		IP:               nonEmpty(h.IP),
+		Location:         h.Location,
+		AutonomousSystem: h.AutonomousSystem,
+		DNS:              h.DNS,
+		Labels:           legacyLabels(h.Labels),
+		ServiceCount:     &serviceCount,
+	}
+	if h.OperatingSystem != nil {
+		os := h.OperatingSystem.toAttribute()
+		host.OperatingSystem = &os
+	}
+	for _, s := range h.Services {
+		host.Services = append(host.Services, s.toService())
+	}
+	return host
+}
+
+func (s legacyService) toService() components.Service {
+	port := s.Port
+	service := components.Service{
+		Port:     &port,
+		Protocol: nonEmpty(s.ServiceName),
+		Banner:   nonEmpty(s.Banner),
+		ScanTime: nonEmpty(s.ObservedAt),
+		Labels:   legacyLabels(s.Labels),
+	}
+	if s.TransportProtocol != "" {
+		transport := components.ServiceTransportProtocol(strings.ToLower(s.TransportProtocol))
+		service.TransportProtocol = &transport
+	}
+	for _, sw := range s.Software {
+		service.Software = append(service.Software, sw.toAttribute())
+	}
+	return service
+}
+
+func (s legacySoftware) toAttribute() components.Attribute {
+	return components.Attribute{
+		Vendor:  nonEmpty(s.Vendor),
+		Product: nonEmpty(s.Product),
+		Version: nonEmpty(s.Version),
+		Cpe:     nonEmpty(s.CPE),
+	}
+}
+
+// legacyLabels maps the labels of the legacy API, which are plain values.
+func legacyLabels(values []string) []components.Label {
+	var labels []components.Label
+	for _, v := range values {
+		labels = append(labels, components.Label{Value: nonEmpty(v)})
+	}
+	return labels
+}
+
+// legacyCertificate is a certificate of the legacy API. Only the fields that
+// are the same in the Platform API are mapped.
+type legacyCertificate struct {
+	FingerprintSha256 string   `json:"fingerprint_sha256"`
+	FingerprintSha1   string   `json:"fingerprint_sha1"`
+	FingerprintMd5    string   `json:"fingerprint_md5"`
+	Names             []string `json:"names"`
+	Parsed            *struct {
+		SubjectDn      string                        `json:"subject_dn"`
+		IssuerDn       string                        `json:"issuer_dn"`
+		SerialNumber   string                        `json:"serial_number"`
+		Subject        *components.DistinguishedName `json:"subject"`
+		Issuer         *components.DistinguishedName `json:"issuer"`
+		ValidityPeriod *components.ValidityPeriod    `json:"validity_period"`
+		Extensions     *struct {
+			SubjectAltName *components.GeneralNames `json:"subject_alt_name"`
+		} `json:"extensions"`
+	} `json:"parsed"`
+}
+
+// toCertificate maps c into a certificate of the Platform API.
+func (c legacyCertificate) toCertificate() components.Certificate {
+	cert := components.Certificate{
+		FingerprintSha256: nonEmpty(c.FingerprintSha256),
+		FingerprintSha1:   nonEmpty(c.FingerprintSha1),
+		FingerprintMd5:    nonEmpty(c.FingerprintMd5),
+		Names:             c.Names,
+	}
+	if p := c.Parsed; p != nil {
+		cert.Parsed = &components.CertificateParsed{
+			SubjectDn:      nonEmpty(p.SubjectDn),
+			IssuerDn:       nonEmpty(p.IssuerDn),
+			SerialNumber:   nonEmpty(p.SerialNumber),
+			Subject:        p.Subject,
+			Issuer:         p.Issuer,
+			ValidityPeriod: p.ValidityPeriod,
+		}
+		if p.Extensions != nil && p.Extensions.SubjectAltName != nil {
+			cert.Parsed.Extensions = &components.CertificateExtensions{SubjectAltName: p.Extensions.SubjectAltName}
+		}
+	}
+	return cert
+}
+
+// nonEmpty returns a pointer to s, or nil if it is empty.
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// parseLegacyTime parses a time of the legacy API, with or without its zone.
+func parseLegacyTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", time.DateTime} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Parse(time.RFC3339, s)
+}
@@ -0,0 +1,247 @@
+package censys
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/store/mocks"
+	"github.com/censys/cencli/internal/config"
+	authdom "github.com/censys/cencli/internal/pkg/domain/auth"
+	"github.com/censys/cencli/internal/store"
+)
+
+// newLegacyTestClient returns a legacy client of a server that answers with
+// the handler of each path.
+func newLegacyTestClient(t *testing.T, handlers map[string]http.HandlerFunc) Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	for path, handler := range handlers {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			id, secret, ok := r.BasicAuth()
+			if !ok || id != "test-id" || secret != "test-secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"code":401,"status":"Unauthorized","error":"You must authenticate with a valid API ID and secret."}`))
+				return
+			}
+			handler(w, r)
+		})
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c, err := NewLegacyClientWithCredentials("test-id", "test-secret", server.URL+"/", 0, config.TransportConfig{}, config.RetryStrategy{MaxAttempts: 1}, false)
+	require.NoError(t, err)
+	return c
+}
+
+func writeLegacyJSON(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(body))
+}
+
+func TestLegacyClient_Search(t *testing.T) {
+	c := newLegacyTestClient(t, map[string]http.HandlerFunc{
+		"/v2/hosts/search": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "services.service_name: HTTP", r.URL.Query().Get("q"))
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			assert.Equal(t, "cursor-1", r.URL.Query().Get("cursor"))
+			writeLegacyJSON(w, `{"code":200,"status":"OK","result":{
+				"query":"services.service_name: HTTP","total":2.0,"duration":12,
+				"hits":[{"ip":"1.1.1.1","labels":["network.device"],
+					"autonomous_system":{"asn":13335,"name":"CLOUDFLARENET"},
+					"location":{"country":"United States","country_code":"US"},
+					"operating_system":{"vendor":"linux","product":"linux","uniform_resource_identifier":"cpe:2.3:o:linux:linux:*"},
+					"services":[{"port":443,"service_name":"HTTP","transport_protocol":"TCP","observed_at":"2025-01-02T03:04:05Z",
+						"software":[{"vendor":"cloudflare","product":"cloudflare"}]}]}],
+				"links":{"prev":"","next":"cursor-2"}}}`)
+		},
+	})
+
+	res, err := c.Search(context.Background(), mo.None[string](), "services.service_name: HTTP", []string{"host.ip"}, mo.Some[int64](500), mo.Some("cursor-1"))
+	require.NoError(t, err)
+	require.NotNil(t, res.Data)
+	assert.Equal(t, float64(2), res.Data.TotalHits)
+	assert.Equal(t, 12, res.Data.QueryDurationMillis)
+	assert.Equal(t, "cursor-2", res.Data.NextPageToken)
+	assert.Empty(t, res.Data.PreviousPageToken)
+	assert.Equal(t, uint64(1), res.Metadata.Attempts)
+
+	require.Len(t, res.Data.Hits, 1)
+	require.NotNil(t, res.Data.Hits[0].HostV1)
+	host := res.Data.Hits[0].HostV1.Resource
+	assert.Equal(t, "1.1.1.1", *host.IP)
+	assert.Equal(t, 13335, *host.AutonomousSystem.Asn)
+	assert.Equal(t, "US", *host.Location.CountryCode)
+	assert.Equal(t, "cpe:2.3:o:linux:linux:*", *host.OperatingSystem.Cpe)
+	require.Len(t, host.Labels, 1)
+	assert.Equal(t, "network.device", *host.Labels[0].Value)
+	assert.Equal(t, 1, *host.ServiceCount)
+	require.Len(t, host.Services, 1)
+	service := host.Services[0]
+	assert.Equal(t, 443, *service.Port)
+	assert.Equal(t, "HTTP", *service.Protocol)
+	assert.Equal(t, components.ServiceTransportProtocolTCP, *service.TransportProtocol)
+	assert.Equal(t, "2025-01-02T03:04:05Z", *service.ScanTime)
+	require.Len(t, service.Software, 1)
+	assert.Equal(t, "cloudflare", *service.Software[0].Vendor)
+}
+
+func TestLegacyClient_GetHosts(t *testing.T) {
+	c := newLegacyTestClient(t, map[string]http.HandlerFunc{
+		"/v2/hosts/{ip}": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "2025-01-02T00:00:00Z", r.URL.Query().Get("at_time"))
+			if r.PathValue("ip") == "8.8.8.8" {
+				w.WriteHeader(http.StatusNotFound)
+				writeLegacyJSON(w, `{"code":404,"status":"Not Found","error":"The requested host could not be found."}`)
+				return
+			}
+			writeLegacyJSON(w, `{"code":200,"status":"OK","result":{"ip":"`+r.PathValue("ip")+`","services":[]}}`)
+		},
+	})
+
+	atTime := time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC)
+	res, err := c.GetHosts(context.Background(), mo.None[string](), []string{"1.1.1.1", "8.8.8.8", "9.9.9.9"}, mo.Some(atTime))
+	require.NoError(t, err)
+	require.Len(t, *res.Data, 2)
+	assert.Equal(t, "1.1.1.1", *(*res.Data)[0].IP)
+	assert.Equal(t, "9.9.9.9", *(*res.Data)[1].IP)
+	assert.Equal(t, uint64(2), res.Metadata.Attempts)
+}
+
+func TestLegacyClient_GetCertificates(t *testing.T) {
+	c := newLegacyTestClient(t, map[string]http.HandlerFunc{
+		"/v2/certificates/{sha}": func(w http.ResponseWriter, r *http.Request) {
+			writeLegacyJSON(w, `{"code":200,"status":"OK","result":{
+				"fingerprint_sha256":"`+r.PathValue("sha")+`","names":["example.com"],
+				"parsed":{"subject_dn":"CN=example.com","issuer_dn":"CN=Example CA",
+					"validity_period":{"not_before":"2025-01-01T00:00:00Z","not_after":"2026-01-01T00:00:00Z"},
+					"extensions":{"subject_alt_name":{"dns_names":["example.com","www.example.com"]}}}}}`)
+		},
+	})
+
+	res, err := c.GetCertificates(context.Background(), mo.None[string](), []string{"abc123"})
+	require.NoError(t, err)
+	require.Len(t, *res.Data, 1)
+	cert := (*res.Data)[0]
+	assert.Equal(t, "abc123", *cert.FingerprintSha256)
+	assert.Equal(t, []string{"example.com"}, cert.Names)
+	assert.Equal(t, "CN=example.com", *cert.Parsed.SubjectDn)
+	assert.Equal(t, "2026-01-01T00:00:00Z", *cert.Parsed.ValidityPeriod.NotAfter)
+	assert.Equal(t, []string{"example.com", "www.example.com"}, cert.Parsed.Extensions.SubjectAltName.DNSNames)
+}
+
+func TestLegacyClient_Aggregate(t *testing.T) {
+	c := newLegacyTestClient(t, map[string]http.HandlerFunc{
+		"/v2/hosts/aggregate": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "services.port", r.URL.Query().Get("field"))
+			assert.Equal(t, "5", r.URL.Query().Get("num_buckets"))
+			writeLegacyJSON(w, `{"code":200,"status":"OK","result":{"total":300,"total_omitted":50,
+				"buckets":[{"key":"443","count":150},{"key":"80","count":100}]}}`)
+		},
+	})
+
+	res, err := c.Aggregate(context.Background(), mo.None[string](), "*", "services.port", 5, mo.None[string](), mo.None[bool]())
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), res.Data.TotalCount)
+	assert.Equal(t, int64(50), res.Data.OtherCount)
+	assert.Equal(t, []components.SearchAggregateResponseBucket{{Key: "443", Count: 150}, {Key: "80", Count: 100}}, res.Data.Buckets)
+}
+
+func TestLegacyClient_GetUserCreditDetails(t *testing.T) {
+	c := newLegacyTestClient(t, map[string]http.HandlerFunc{
+		"/v1/account": func(w http.ResponseWriter, r *http.Request) {
+			writeLegacyJSON(w, `{"email":"user@example.com","login":"user",
+				"quota":{"used":40,"allowance":250,"resets_at":"2025-02-01 00:00:00"}}`)
+		},
+	})
+
+	res, err := c.GetUserCreditDetails(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(210), res.Data.Balance)
+	require.NotNil(t, res.Data.ResetsAt)
+	assert.Equal(t, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), *res.Data.ResetsAt)
+}
+
+func TestLegacyClient_Errors(t *testing.T) {
+	c := newLegacyTestClient(t, map[string]http.HandlerFunc{
+		"/v2/hosts/search": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			writeLegacyJSON(w, `{"code":400,"status":"Bad Request","error":"Query string is invalid."}`)
+		},
+	})
+
+	_, err := c.Search(context.Background(), mo.None[string](), "(", nil, mo.None[int64](), mo.None[string]())
+	require.Error(t, err)
+	assert.Equal(t, mo.Some[int64](http.StatusBadRequest), err.StatusCode())
+	assert.Contains(t, err.Error(), "Query string is invalid.")
+
+	_, err = c.EnrichHost(context.Background(), mo.None[string](), "1.1.1.1")
+	var unsupported ClientUnsupportedError
+	require.ErrorAs(t, err, &unsupported)
+	assert.Equal(t, "Not Supported by the Legacy API", unsupported.Title())
+	assert.False(t, c.HasOrgID())
+}
+
+func TestNewLegacyClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("credentials from the environment", func(t *testing.T) {
+		t.Setenv(LegacyAPIIDEnv, "env-id")
+		t.Setenv(LegacyAPISecretEnv, "env-secret")
+		ctrl := gomock.NewController(t)
+		c, err := NewLegacyClient(ctx, mocks.NewMockStore(ctrl), "", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
+		require.NoError(t, err)
+		lc := c.(*legacyClient)
+		assert.Equal(t, "env-id", lc.apiID)
+		assert.Equal(t, "env-secret", lc.apiSecret)
+		assert.Equal(t, LegacyAPIURL, lc.baseURL)
+	})
+
+	t.Run("credentials from the stored token", func(t *testing.T) {
+		t.Setenv(LegacyAPIIDEnv, "")
+		t.Setenv(LegacyAPISecretEnv, "")
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStore(ctrl)
+		mockStore.EXPECT().GetLastUsedAuthByName(ctx, config.AuthName).Return(&store.ValueForAuth{Value: "stored-id:stored-secret"}, nil)
+		c, err := NewLegacyClient(ctx, mockStore, "", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
+		require.NoError(t, err)
+		lc := c.(*legacyClient)
+		assert.Equal(t, "stored-id", lc.apiID)
+		assert.Equal(t, "stored-secret", lc.apiSecret)
+	})
+
+	t.Run("stored token is not an API ID and secret", func(t *testing.T) {
+		t.Setenv(LegacyAPIIDEnv, "")
+		t.Setenv(LegacyAPISecretEnv, "")
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStore(ctrl)
+		mockStore.EXPECT().GetLastUsedAuthByName(ctx, config.AuthName).Return(&store.ValueForAuth{Value: "censys_pat"}, nil)
+		_, err := NewLegacyClient(ctx, mockStore, "", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
+		require.ErrorIs(t, err, authdom.ErrAuthNotFound)
+	})
+
+	t.Run("no stored token", func(t *testing.T) {
+		t.Setenv(LegacyAPIIDEnv, "")
+		t.Setenv(LegacyAPISecretEnv, "")
+		ctrl := gomock.NewController(t)
+		mockStore := mocks.NewMockStore(ctrl)
+		mockStore.EXPECT().GetLastUsedAuthByName(ctx, config.AuthName).Return(nil, authdom.ErrAuthNotFound)
+		_, err := NewLegacyClient(ctx, mockStore, "", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
+		require.ErrorIs(t, err, authdom.ErrAuthNotFound)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := NewLegacyClient(ctx, mocks.NewMockStore(ctrl), "ftp://example.com", 0, config.TransportConfig{}, config.RetryStrategy{}, false)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,295 @@
+package censys
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"golang.org/x/sync/singleflight"
+)
+
+// MemoStats counts the lookups of a Memo.
+type MemoStats struct {
+	// Hits is the number of calls answered from the memo, including calls
+	// that waited for an identical call in flight.
+	Hits int
+	// Misses is the number of calls sent to the API.
+	Misses int
+	// Evictions is the number of results dropped to stay within capacity.
+	Evictions int
+	// Entries is the number of results held.
+	Entries int
+}
+
+// Memo holds the results of the most recent API lookups, up to a number of
+// results, evicting the least recently used first. It lives for a single
+// invocation, so that a command never fetches identical data twice. It is
+// safe for concurrent use.
+type Memo struct {
+	capacity int
+	flight   singleflight.Group
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+	stats   MemoStats
+}
+
+type memoEntry struct {
+	key   string
+	value any
+}
+
+// NewMemo creates a memo that holds up to capacity results.
+func NewMemo(capacity int) *Memo {
+	return &Memo{capacity: capacity, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Stats returns the lookups made so far.
+func (m *Memo) Stats() MemoStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := m.stats
+	stats.Entries = m.order.Len()
+	return stats
+}
+
+func (m *Memo) get(key string) (any, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(el)
+	return el.Value.(*memoEntry).value, true
+}
+
+func (m *Memo) put(key string, value any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.entries[key]; ok {
+		el.Value.(*memoEntry).value = value
+		m.order.MoveToFront(el)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&memoEntry{key: key, value: value})
+	for m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoEntry).key)
+		m.stats.Evictions++
+	}
+}
+
+func (m *Memo) count(hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hit {
+		m.stats.Hits++
+	} else {
+		m.stats.Misses++
+	}
+}
+
+// memoClient decorates a Client so that lookups are answered from a Memo
+// when an identical lookup (the same operation with the same parameters) was
+// already made. Only successful results are kept, so failed calls are
+// retried. Results are shared between callers, which must not modify them.
+// Account calls, such as credit details, are never memoized, as their
+// results change as the command runs.
+type memoClient struct {
+	Client
+	memo *Memo
+}
+
+var _ Client = &memoClient{}
+
+// NewMemoClient wraps inner so that its lookups are memoized in memo.
+func NewMemoClient(inner Client, memo *Memo) Client {
+	return &memoClient{Client: inner, memo: memo}
+}
+
+// memoize returns the result of an earlier identical call from memo, or
+// makes call and keeps its result. Identical calls made at the same time are
+// sent once. A call whose parameters cannot be encoded is never memoized.
+func memoize[T any](
+	memo *Memo,
+	operation string,
+	params []any,
+	call func() (Result[T], ClientError),
+) (Result[T], ClientError) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return call()
+	}
+	key := operation + "\x00" + string(encoded)
+	if cached, ok := memo.get(key); ok {
+		memo.count(true)
+		return cached.(Result[T]), nil
+	}
+	sent := false
+	v, _, shared := memo.flight.Do(key, func() (any, error) {
+		sent = true
+		res, callErr := call()
+		if callErr != nil {
+			return memoCall[T]{res: res, err: callErr}, nil
+		}
+		memo.put(key, res)
+		return memoCall[T]{res: res}, nil
+	})
+	memo.count(shared && !sent)
+	out := v.(memoCall[T])
+	return out.res, out.err
+}
+
+// memoCall is the outcome of a call shared by identical calls in flight.
+type memoCall[T any] struct {
+	res Result[T]
+	err ClientError
+}
+
+func (c *memoClient) GetHosts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Host], ClientError) {
+	return memoize(c.memo, "get_hosts", []any{orgID, hostIDs, atTime}, func() (Result[[]components.Host], ClientError) {
+		return c.Client.GetHosts(ctx, orgID, hostIDs, atTime)
+	})
+}
+
+func (c *memoClient) GetCertificates(
+	ctx context.Context,
+	orgID mo.Option[string],
+	certificateIDs []string,
+) (Result[[]components.Certificate], ClientError) {
+	return memoize(c.memo, "get_certificates", []any{orgID, certificateIDs}, func() (Result[[]components.Certificate], ClientError) {
+		return c.Client.GetCertificates(ctx, orgID, certificateIDs)
+	})
+}
+
+func (c *memoClient) GetWebProperties(
+	ctx context.Context,
+	orgID mo.Option[string],
+	webPropertyIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Webproperty], ClientError) {
+	return memoize(c.memo, "get_web_properties", []any{orgID, webPropertyIDs, atTime}, func() (Result[[]components.Webproperty], ClientError) {
+		return c.Client.GetWebProperties(ctx, orgID, webPropertyIDs, atTime)
+	})
+}
+
+func (c *memoClient) Search(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	return memoize(c.memo, "search", []any{orgID, query, fields, pageSize, pageToken}, func() (Result[components.SearchQueryResponse], ClientError) {
+		return c.Client.Search(ctx, orgID, query, fields, pageSize, pageToken)
+	})
+}
+
+func (c *memoClient) Aggregate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	params := []any{orgID, query, field, numBuckets, countByLevel, filterByQuery}
+	return memoize(c.memo, "aggregate", params, func() (Result[components.SearchAggregateResponse], ClientError) {
+		return c.Client.Aggregate(ctx, orgID, query, field, numBuckets, countByLevel, filterByQuery)
+	})
+}
+
+func (c *memoClient) HostTimeline(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostID string,
+	fromTime time.Time,
+	toTime time.Time,
+) (Result[components.HostTimeline], ClientError) {
+	return memoize(c.memo, "host_timeline", []any{orgID, hostID, fromTime, toTime}, func() (Result[components.HostTimeline], ClientError) {
+		return c.Client.HostTimeline(ctx, orgID, hostID, fromTime, toTime)
+	})
+}
+
+func (c *memoClient) EnrichHost(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIP string,
+) (Result[components.HostEnrichment], ClientError) {
+	return memoize(c.memo, "enrich_host", []any{orgID, hostIP}, func() (Result[components.HostEnrichment], ClientError) {
+		return c.Client.EnrichHost(ctx, orgID, hostIP)
+	})
+}
+
+func (c *memoClient) SearchCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	params := []any{collectionID, orgID, query, fields, pageSize, pageToken}
+	return memoize(c.memo, "search_collection", params, func() (Result[components.SearchQueryResponse], ClientError) {
+		return c.Client.SearchCollection(ctx, collectionID, orgID, query, fields, pageSize, pageToken)
+	})
+}
+
+func (c *memoClient) AggregateCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	params := []any{collectionID, orgID, query, field, numBuckets, countByLevel, filterByQuery}
+	return memoize(c.memo, "aggregate_collection", params, func() (Result[components.SearchAggregateResponse], ClientError) {
+		return c.Client.AggregateCollection(ctx, collectionID, orgID, query, field, numBuckets, countByLevel, filterByQuery)
+	})
+}
+
+func (c *memoClient) GetHostObservationsWithCertificate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	certificateID string,
+	startTime mo.Option[time.Time],
+	endTime mo.Option[time.Time],
+	port mo.Option[int],
+	protocol mo.Option[string],
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.HostObservationResponse], ClientError) {
+	params := []any{orgID, certificateID, startTime, endTime, port, protocol, pageSize, pageToken}
+	return memoize(c.memo, "get_host_observations_with_certificate", params, func() (Result[components.HostObservationResponse], ClientError) {
+		return c.Client.GetHostObservationsWithCertificate(ctx, orgID, certificateID, startTime, endTime, port, protocol, pageSize, pageToken)
+	})
+}
+
+func (c *memoClient) GetValueCounts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query mo.Option[string],
+	andCountConditions []components.CountCondition,
+) (Result[components.ValueCountsResponse], ClientError) {
+	return memoize(c.memo, "get_value_counts", []any{orgID, query, andCountConditions}, func() (Result[components.ValueCountsResponse], ClientError) {
+		return c.Client.GetValueCounts(ctx, orgID, query, andCountConditions)
+	})
+}
@@ -0,0 +1,150 @@
+package censys_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/clients/censys"
+)
+
+func hostsResult(ips ...string) censys.Result[[]components.Host] {
+	hosts := make([]components.Host, len(ips))
+	for i, ip := range ips {
+		hosts[i] = components.Host{IP: &ip}
+	}
+	return censys.Result[[]components.Host]{Data: &hosts}
+}
+
+func TestMemoClient(t *testing.T) {
+	ctx := context.Background()
+	none := mo.None[string]()
+	noTime := mo.None[time.Time]()
+
+	t.Run("identical lookups are fetched once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().GetHosts(ctx, none, []string{"10.0.0.1"}, noTime).Return(hostsResult("10.0.0.1"), nil).Times(1)
+		inner.EXPECT().GetHosts(ctx, mo.Some("org"), []string{"10.0.0.1"}, noTime).Return(hostsResult("10.0.0.1"), nil).Times(1)
+		inner.EXPECT().GetHosts(ctx, none, []string{"10.0.0.2"}, noTime).Return(hostsResult("10.0.0.2"), nil).Times(1)
+
+		memo := censys.NewMemo(16)
+		c := censys.NewMemoClient(inner, memo)
+		for range 3 {
+			res, err := c.GetHosts(ctx, none, []string{"10.0.0.1"}, noTime)
+			require.NoError(t, err)
+			require.Equal(t, "10.0.0.1", *(*res.Data)[0].IP)
+		}
+		// any parameter that differs is a different lookup
+		_, err := c.GetHosts(ctx, mo.Some("org"), []string{"10.0.0.1"}, noTime)
+		require.NoError(t, err)
+		res, err := c.GetHosts(ctx, none, []string{"10.0.0.2"}, noTime)
+		require.NoError(t, err)
+		require.Equal(t, "10.0.0.2", *(*res.Data)[0].IP)
+
+		require.Equal(t, censys.MemoStats{Hits: 2, Misses: 3, Entries: 3}, memo.Stats())
+	})
+
+	t.Run("operations are memoized separately", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().Search(ctx, none, "q", nil, mo.None[int64](), none).
+			Return(censys.Result[components.SearchQueryResponse]{}, nil).Times(1)
+		inner.EXPECT().SearchCollection(ctx, "q", none, "q", nil, mo.None[int64](), none).
+			Return(censys.Result[components.SearchQueryResponse]{}, nil).Times(1)
+
+		c := censys.NewMemoClient(inner, censys.NewMemo(16))
+		for range 2 {
+			_, err := c.Search(ctx, none, "q", nil, mo.None[int64](), none)
+			require.NoError(t, err)
+			_, err = c.SearchCollection(ctx, "q", none, "q", nil, mo.None[int64](), none)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("failures are not memoized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		gomock.InOrder(
+			inner.EXPECT().GetHosts(ctx, none, []string{"10.0.0.1"}, noTime).
+				Return(censys.Result[[]components.Host]{}, censys.NewClientError(cenclierrors.NewCencliError(context.DeadlineExceeded))),
+			inner.EXPECT().GetHosts(ctx, none, []string{"10.0.0.1"}, noTime).Return(hostsResult("10.0.0.1"), nil),
+		)
+
+		memo := censys.NewMemo(16)
+		c := censys.NewMemoClient(inner, memo)
+		_, err := c.GetHosts(ctx, none, []string{"10.0.0.1"}, noTime)
+		require.Error(t, err)
+		_, err = c.GetHosts(ctx, none, []string{"10.0.0.1"}, noTime)
+		require.NoError(t, err)
+		require.Equal(t, censys.MemoStats{Misses: 2, Entries: 1}, memo.Stats())
+	})
+
+	t.Run("least recently used results are evicted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().GetHosts(ctx, none, []string{"a"}, noTime).Return(hostsResult("a"), nil).Times(1)
+		inner.EXPECT().GetHosts(ctx, none, []string{"b"}, noTime).Return(hostsResult("b"), nil).Times(2)
+		inner.EXPECT().GetHosts(ctx, none, []string{"c"}, noTime).Return(hostsResult("c"), nil).Times(1)
+
+		memo := censys.NewMemo(2)
+		c := censys.NewMemoClient(inner, memo)
+		for _, id := range []string{"a", "b", "a", "c", "a", "b"} {
+			_, err := c.GetHosts(ctx, none, []string{id}, noTime)
+			require.NoError(t, err)
+		}
+		// c evicts b, which was used less recently than a; b then evicts c
+		require.Equal(t, censys.MemoStats{Hits: 2, Misses: 4, Evictions: 2, Entries: 2}, memo.Stats())
+	})
+
+	t.Run("concurrent identical lookups are sent once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		release := make(chan struct{})
+		inner.EXPECT().GetCertificates(ctx, none, []string{"aaa"}).
+			DoAndReturn(func(context.Context, mo.Option[string], []string) (censys.Result[[]components.Certificate], censys.ClientError) {
+				<-release
+				return censys.Result[[]components.Certificate]{}, nil
+			}).Times(1)
+
+		memo := censys.NewMemo(16)
+		c := censys.NewMemoClient(inner, memo)
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := c.GetCertificates(ctx, none, []string{"aaa"})
+				require.NoError(t, err)
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		stats := memo.Stats()
+		require.Equal(t, 1, stats.Misses)
+		require.Equal(t, 3, stats.Hits)
+	})
+
+	t.Run("account calls are not memoized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().GetUserCreditDetails(ctx).Return(censys.Result[components.UserCredits]{}, nil).Times(2)
+
+		memo := censys.NewMemo(16)
+		c := censys.NewMemoClient(inner, memo)
+		for range 2 {
+			_, err := c.GetUserCreditDetails(ctx)
+			require.NoError(t, err)
+		}
+		require.Equal(t, censys.MemoStats{}, memo.Stats())
+	})
+}
@@ -0,0 +1,200 @@
+package censys
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// ScopeChecker decides which assets and queries are in scope. It is
+// implemented by scope.Scope.
+type ScopeChecker interface {
+	CheckIP(raw string) error
+	CheckHostname(name string) error
+	CheckQuery(query string) error
+}
+
+// scopeClient decorates a Client so that requests for hosts, web properties,
+// and queries out of scope are refused before they are sent. With a warn
+// function, they are reported to it and sent anyway. Certificates are not
+// checked, as they do not belong to an address.
+type scopeClient struct {
+	Client
+	scope ScopeChecker
+	warn  func(error)
+}
+
+var _ Client = &scopeClient{}
+
+// NewScopeClient wraps inner so that requests out of scope fail, or, if warn
+// is not nil, are reported to warn and sent anyway.
+func NewScopeClient(inner Client, scope ScopeChecker, warn func(error)) Client {
+	return &scopeClient{Client: inner, scope: scope, warn: warn}
+}
+
+// check returns the first error of checks, unless they are only reported.
+func (c *scopeClient) check(checks ...func() error) ClientError {
+	for _, check := range checks {
+		err := check()
+		if err == nil {
+			continue
+		}
+		if c.warn != nil {
+			c.warn(err)
+			continue
+		}
+		var cerr cenclierrors.CencliError
+		if errors.As(err, &cerr) {
+			return wrapCencliError(cerr)
+		}
+		return NewClientError(err)
+	}
+	return nil
+}
+
+func (c *scopeClient) checkIPs(ips ...string) ClientError {
+	checks := make([]func() error, len(ips))
+	for i, ip := range ips {
+		checks[i] = func() error { return c.scope.CheckIP(ip) }
+	}
+	return c.check(checks...)
+}
+
+func (c *scopeClient) checkQuery(query string) ClientError {
+	return c.check(func() error { return c.scope.CheckQuery(query) })
+}
+
+func (c *scopeClient) GetHosts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Host], ClientError) {
+	if err := c.checkIPs(hostIDs...); err != nil {
+		return Result[[]components.Host]{}, err
+	}
+	return c.Client.GetHosts(ctx, orgID, hostIDs, atTime)
+}
+
+func (c *scopeClient) GetWebProperties(
+	ctx context.Context,
+	orgID mo.Option[string],
+	webPropertyIDs []string,
+	atTime mo.Option[time.Time],
+) (Result[[]components.Webproperty], ClientError) {
+	checks := make([]func() error, len(webPropertyIDs))
+	for i, id := range webPropertyIDs {
+		host := id
+		if h, _, err := net.SplitHostPort(id); err == nil {
+			host = h
+		}
+		checks[i] = func() error { return c.scope.CheckHostname(host) }
+	}
+	if err := c.check(checks...); err != nil {
+		return Result[[]components.Webproperty]{}, err
+	}
+	return c.Client.GetWebProperties(ctx, orgID, webPropertyIDs, atTime)
+}
+
+func (c *scopeClient) Search(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	if err := c.checkQuery(query); err != nil {
+		return Result[components.SearchQueryResponse]{}, err
+	}
+	return c.Client.Search(ctx, orgID, query, fields, pageSize, pageToken)
+}
+
+func (c *scopeClient) Aggregate(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	if err := c.checkQuery(query); err != nil {
+		return Result[components.SearchAggregateResponse]{}, err
+	}
+	return c.Client.Aggregate(ctx, orgID, query, field, numBuckets, countByLevel, filterByQuery)
+}
+
+func (c *scopeClient) HostTimeline(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostID string,
+	fromTime time.Time,
+	toTime time.Time,
+) (Result[components.HostTimeline], ClientError) {
+	if err := c.checkIPs(hostID); err != nil {
+		return Result[components.HostTimeline]{}, err
+	}
+	return c.Client.HostTimeline(ctx, orgID, hostID, fromTime, toTime)
+}
+
+func (c *scopeClient) EnrichHost(
+	ctx context.Context,
+	orgID mo.Option[string],
+	hostIP string,
+) (Result[components.HostEnrichment], ClientError) {
+	if err := c.checkIPs(hostIP); err != nil {
+		return Result[components.HostEnrichment]{}, err
+	}
+	return c.Client.EnrichHost(ctx, orgID, hostIP)
+}
+
+func (c *scopeClient) SearchCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	fields []string,
+	pageSize mo.Option[int64],
+	pageToken mo.Option[string],
+) (Result[components.SearchQueryResponse], ClientError) {
+	if err := c.checkQuery(query); err != nil {
+		return Result[components.SearchQueryResponse]{}, err
+	}
+	return c.Client.SearchCollection(ctx, collectionID, orgID, query, fields, pageSize, pageToken)
+}
+
+func (c *scopeClient) AggregateCollection(
+	ctx context.Context,
+	collectionID string,
+	orgID mo.Option[string],
+	query string,
+	field string,
+	numBuckets int64,
+	countByLevel mo.Option[string],
+	filterByQuery mo.Option[bool],
+) (Result[components.SearchAggregateResponse], ClientError) {
+	if err := c.checkQuery(query); err != nil {
+		return Result[components.SearchAggregateResponse]{}, err
+	}
+	return c.Client.AggregateCollection(ctx, collectionID, orgID, query, field, numBuckets, countByLevel, filterByQuery)
+}
+
+func (c *scopeClient) GetValueCounts(
+	ctx context.Context,
+	orgID mo.Option[string],
+	query mo.Option[string],
+	andCountConditions []components.CountCondition,
+) (Result[components.ValueCountsResponse], ClientError) {
+	// without a query, every asset is counted
+	if err := c.checkQuery(query.OrElse("")); err != nil {
+		return Result[components.ValueCountsResponse]{}, err
+	}
+	return c.Client.GetValueCounts(ctx, orgID, query, andCountConditions)
+}
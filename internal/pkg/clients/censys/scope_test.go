@@ -0,0 +1,76 @@
+package censys_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/censys/cencli/gen/client/mocks"
+	"github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/scope"
+)
+
+func TestScopeClient(t *testing.T) {
+	ctx := context.Background()
+	none := mo.None[string]()
+	noTime := mo.None[time.Time]()
+	s, err := scope.Parse(strings.NewReader("198.51.100.0/24\nexample.com\n!198.51.100.25\n"))
+	require.NoError(t, err)
+
+	t.Run("requests in scope are sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().GetHosts(ctx, none, []string{"198.51.100.1"}, noTime).Return(hostsResult("198.51.100.1"), nil)
+		inner.EXPECT().GetWebProperties(ctx, none, []string{"www.example.com:443"}, noTime).
+			Return(censys.Result[[]components.Webproperty]{}, nil)
+		inner.EXPECT().Search(ctx, none, "host.ip = 198.51.100.128/25 and host.services.port: 22", nil, mo.None[int64](), none).
+			Return(censys.Result[components.SearchQueryResponse]{}, nil)
+
+		c := censys.NewScopeClient(inner, s, nil)
+		_, err := c.GetHosts(ctx, none, []string{"198.51.100.1"}, noTime)
+		require.NoError(t, err)
+		_, err = c.GetWebProperties(ctx, none, []string{"www.example.com:443"}, noTime)
+		require.NoError(t, err)
+		_, err = c.Search(ctx, none, "host.ip = 198.51.100.128/25 and host.services.port: 22", nil, mo.None[int64](), none)
+		require.NoError(t, err)
+	})
+
+	t.Run("requests out of scope fail before they are sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+
+		c := censys.NewScopeClient(inner, s, nil)
+		_, err := c.GetHosts(ctx, none, []string{"198.51.100.1", "198.51.100.25"}, noTime)
+		require.EqualError(t, err, "198.51.100.25 is out of scope: it is denied by !198.51.100.25")
+		require.Equal(t, "Out of Scope", err.Title())
+		_, err = c.GetWebProperties(ctx, none, []string{"203.0.113.5:80"}, noTime)
+		require.ErrorContains(t, err, "203.0.113.5 is out of scope")
+		_, err = c.Aggregate(ctx, none, "web.hostname: evil.org", "web.port", 10, none, mo.None[bool]())
+		require.ErrorContains(t, err, "evil.org is out of scope")
+		_, err = c.EnrichHost(ctx, none, "203.0.113.5")
+		require.ErrorContains(t, err, "203.0.113.5 is out of scope")
+		_, err = c.GetValueCounts(ctx, none, none, nil)
+		require.ErrorContains(t, err, "an empty query is out of scope")
+		_, err = c.GetValueCounts(ctx, none, mo.Some("host.services.port: 22"), nil)
+		require.ErrorContains(t, err, "it is not limited to assets in scope")
+	})
+
+	t.Run("warnings are reported and requests sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		inner := mocks.NewMockClient(ctrl)
+		inner.EXPECT().HostTimeline(ctx, none, "203.0.113.5", time.Time{}, time.Time{}).
+			Return(censys.Result[components.HostTimeline]{}, nil)
+
+		var warned []string
+		c := censys.NewScopeClient(inner, s, func(err error) { warned = append(warned, err.Error()) })
+		_, err := c.HostTimeline(ctx, none, "203.0.113.5", time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"203.0.113.5 is out of scope: no allowed range covers it"}, warned)
+	})
+}
@@ -56,7 +56,7 @@ func (t *threatHuntingSDK) GetHostObservationsWithCertificate(
 ) (Result[components.HostObservationResponse], ClientError) {
 	start := time.Now()
 	var res *operations.V3ThreathuntingGetHostObservationsWithCertificateResponse
-	err, attempts := t.executeWithRetry(ctx, func() ClientError {
+	err, attempts := t.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		// Build RFC3339 strings for times per SDK request model
 		var startStr, endStr *string
 		if startTime.IsPresent() {
@@ -107,7 +107,7 @@ func (c *threatHuntingSDK) GetValueCounts(
 ) (Result[components.ValueCountsResponse], ClientError) {
 	start := time.Now()
 	var res *operations.V3ThreathuntingValueCountsResponse
-	err, attempts := c.executeWithRetry(ctx, func() ClientError {
+	err, attempts := c.executeWithRetry(ctx, func(ctx context.Context) ClientError {
 		var err error
 		res, err = c.censysSDK.client.ThreatHunting.ValueCounts(ctx, operations.V3ThreathuntingValueCountsRequest{
 			OrganizationID: orgID.ToPointer(),
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ErrDryRun is returned for a request captured by a dry run instead of sent.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// CapturedRequest is a request as it would have been sent, without its
+// headers (which hold the credentials).
+type CapturedRequest struct {
+	Method string
+	URL    string
+	Body   []byte
+}
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context in which a request is captured into captured
+// instead of being sent. The request fails with ErrDryRun.
+func WithDryRun(ctx context.Context, captured *CapturedRequest) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, captured)
+}
+
+// capture records req into the CapturedRequest of its context, if any. It
+// reports whether the request was captured.
+func capture(req *http.Request) (bool, error) {
+	captured, ok := req.Context().Value(dryRunKey{}).(*CapturedRequest)
+	if !ok || captured == nil {
+		return false, nil
+	}
+	captured.Method = req.Method
+	captured.URL = req.URL.String()
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return true, err
+		}
+		captured.Body = bytes.TrimSpace(body)
+	}
+	return true, ErrDryRun
+}
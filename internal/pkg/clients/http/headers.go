@@ -0,0 +1,42 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// ResponseHeaders holds the headers of the last response received with a
+// context, including error responses, whose headers the SDK does not keep.
+type ResponseHeaders struct {
+	mu     sync.Mutex
+	header http.Header
+}
+
+type responseHeadersKey struct{}
+
+// WithResponseHeaders returns a context in which the headers of each
+// response are kept in the returned ResponseHeaders.
+func WithResponseHeaders(ctx context.Context) (context.Context, *ResponseHeaders) {
+	h := &ResponseHeaders{}
+	return context.WithValue(ctx, responseHeadersKey{}, h), h
+}
+
+// Get returns the headers of the last response, or nil if none was received.
+func (h *ResponseHeaders) Get() http.Header {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.header
+}
+
+// keepHeaders keeps the headers of resp in the ResponseHeaders of the
+// context of req, if any.
+func keepHeaders(req *http.Request, resp *http.Response) {
+	h, ok := req.Context().Value(responseHeadersKey{}).(*ResponseHeaders)
+	if !ok || h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.header = resp.Header.Clone()
+}
@@ -2,9 +2,10 @@ package http
 
 import (
 	"log/slog"
-	"net"
 	"net/http"
 	"time"
+
+	"github.com/censys/cencli/internal/pkg/ui/events"
 )
 
 type Client struct {
@@ -13,23 +14,10 @@ type Client struct {
 
 // New creates an HTTP client configured for CLI usage.
 // If logger is non-nil, requests and responses will be logged at Debug level.
-func New(requestTimeout time.Duration, userAgent string, logger *slog.Logger) *Client {
-	// Custom base transport tuned for CLI usage
-	base := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout:   10 * time.Second,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
-		ForceAttemptHTTP2:     true,
-		MaxIdleConns:          100,
-		MaxIdleConnsPerHost:   10,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-	}
-
-	return &Client{
+// The connection pool is tuned with DefaultTransport unless WithTransport is given.
+func New(requestTimeout time.Duration, userAgent string, logger *slog.Logger, opts ...Option) *Client {
+	base := newBaseTransport(DefaultTransport())
+	client := &Client{
 		Client: http.Client{
 			Transport: &roundTripper{
 				RoundTripper: base,
@@ -39,6 +27,10 @@ func New(requestTimeout time.Duration, userAgent string, logger *slog.Logger) *C
 			Timeout: requestTimeout,
 		},
 	}
+	for _, opt := range opts {
+		opt(base)
+	}
+	return client
 }
 
 type roundTripper struct {
@@ -55,14 +47,33 @@ func (r roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 		req.Header.Set("User-Agent", existingUserAgent+" "+r.userAgent)
 	}
 
+	if captured, err := capture(req); captured {
+		if r.logger != nil {
+			r.logger.Debug("http request not sent (dry run)", "method", req.Method, "url", req.URL.String())
+		}
+		return nil, err
+	}
+
 	if r.logger != nil {
 		r.logger.Debug("http request", "method", req.Method, "url", req.URL.String())
 	}
 
 	start := time.Now()
 	resp, err := r.RoundTripper.RoundTrip(req)
+	if err == nil {
+		keepHeaders(req, resp)
+		if recordErr := recordResponse(req, resp); recordErr != nil {
+			resp, err = nil, recordErr
+		}
+	}
 	duration := time.Since(start)
 
+	reported := events.Request{Method: req.Method, URL: req.URL.String(), Latency: duration}
+	if resp != nil {
+		reported.Status = resp.StatusCode
+	}
+	events.ReportRequest(req.Context(), reported)
+
 	if r.logger != nil {
 		if err != nil {
 			r.logger.Debug("http error", "method", req.Method, "url", req.URL.String(), "error", err, "duration", duration)
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -115,3 +116,73 @@ func TestNew_SetsUserAgent_AndNoDefaultTimeout(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestResponseRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	var recordedURL string
+	var recordedStatus int
+	var recordedBody []byte
+	ctx := WithResponseRecorder(context.Background(), func(req *http.Request, status int, body []byte) {
+		recordedURL = req.URL.String()
+		recordedStatus = status
+		recordedBody = body
+	})
+
+	client := New(0, "cencli-test/0.1", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/path", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+
+	if recordedURL != server.URL+"/path" || recordedStatus != http.StatusCreated {
+		t.Fatalf("expected %s with status 201 to be recorded, got %s with status %d", server.URL+"/path", recordedURL, recordedStatus)
+	}
+	if string(recordedBody) != `{"ok":true}` {
+		t.Fatalf("expected the body to be recorded, got %q", recordedBody)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Fatalf("expected the caller to still read the body, got %q", body)
+	}
+}
+
+func TestResponseHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, headers := WithResponseHeaders(context.Background())
+	if headers.Get() != nil {
+		t.Fatalf("expected no headers before a response, got %v", headers.Get())
+	}
+
+	client := New(0, "cencli-test/0.1", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got := headers.Get().Get("X-Request-Id"); got != "req-123" {
+		t.Fatalf("expected the headers of the error response to be kept, got %q", got)
+	}
+}
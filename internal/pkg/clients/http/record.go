@@ -0,0 +1,37 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// ResponseRecorder receives the raw body of each response to a request.
+type ResponseRecorder func(req *http.Request, status int, body []byte)
+
+type responseRecorderKey struct{}
+
+// WithResponseRecorder returns a context in which the body of each response
+// is passed to record once it is received. The body is read in full before
+// it is decoded.
+func WithResponseRecorder(ctx context.Context, record ResponseRecorder) context.Context {
+	return context.WithValue(ctx, responseRecorderKey{}, record)
+}
+
+// recordResponse passes the body of resp to the ResponseRecorder of the
+// context of req, if any, and replaces it with a copy for the caller.
+func recordResponse(req *http.Request, resp *http.Response) error {
+	record, ok := req.Context().Value(responseRecorderKey{}).(ResponseRecorder)
+	if !ok || record == nil || resp.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	record(req, resp.StatusCode, body)
+	return nil
+}
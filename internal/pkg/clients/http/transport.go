@@ -0,0 +1,76 @@
+package http
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Transport tunes the connection pool of a client. High-volume runs (bulk
+// views, paginated searches, concurrent enrichments) reuse connections
+// instead of opening one per request as long as enough are kept idle.
+type Transport struct {
+	// MaxIdleConns bounds the idle connections kept across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost bounds the idle connections kept per host. It
+	// should be at least the number of concurrent requests to a host, or
+	// connections are closed and reopened between requests.
+	MaxIdleConnsPerHost int
+	// IdleTimeout is how long an idle connection is kept before it is closed.
+	IdleTimeout time.Duration
+	// KeepAlive is the interval of TCP keepalive probes. Zero uses the Go
+	// default of 15s.
+	KeepAlive time.Duration
+	// HTTP2 enables HTTP/2, which multiplexes concurrent requests to a host
+	// over a single connection.
+	HTTP2 bool
+}
+
+// DefaultTransport returns the transport tuning used when none is given.
+func DefaultTransport() Transport {
+	return Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 32,
+		IdleTimeout:         90 * time.Second,
+		KeepAlive:           30 * time.Second,
+		HTTP2:               true,
+	}
+}
+
+// Option configures the transport of a client created by New.
+type Option func(*http.Transport)
+
+// WithTransport tunes the connection pool of the client.
+func WithTransport(t Transport) Option {
+	return t.apply
+}
+
+// newBaseTransport creates the transport tuned for CLI usage that requests
+// are sent with.
+func newBaseTransport(t Transport) *http.Transport {
+	base := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	t.apply(base)
+	return base
+}
+
+func (t Transport) apply(base *http.Transport) {
+	base.DialContext = (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: t.KeepAlive,
+	}).DialContext
+	base.MaxIdleConns = t.MaxIdleConns
+	base.MaxIdleConnsPerHost = t.MaxIdleConnsPerHost
+	base.IdleConnTimeout = t.IdleTimeout
+	base.ForceAttemptHTTP2 = t.HTTP2
+	if t.HTTP2 {
+		base.TLSNextProto = nil
+	} else {
+		// a non-nil, empty map disables HTTP/2
+		base.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+}
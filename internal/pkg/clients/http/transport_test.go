@@ -0,0 +1,129 @@
+package http
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// newCountingServer starts a TLS test server that counts the connections
+// opened to it, with HTTP/2 enabled.
+func newCountingServer(tb testing.TB) (*httptest.Server, *atomic.Int64) {
+	tb.Helper()
+	var conns atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.StartTLS()
+	tb.Cleanup(server.Close)
+	return server, &conns
+}
+
+// newTestClient creates a client that trusts the certificate of server.
+func newTestClient(server *httptest.Server, opts ...Option) *Client {
+	certs := server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	opts = append(opts, func(base *http.Transport) {
+		base.TLSClientConfig = &tls.Config{RootCAs: certs}
+	})
+	return New(0, "cencli-test/0.1", nil, opts...)
+}
+
+// get sends n requests to server with the given concurrency and returns the
+// protocol of the last response.
+func get(tb testing.TB, client *Client, url string, n, concurrency int) string {
+	tb.Helper()
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		proto string
+	)
+	sem := make(chan struct{}, concurrency)
+	for range n {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := client.Get(url)
+			if err != nil {
+				tb.Error(err)
+				return
+			}
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			mu.Lock()
+			proto = string(body)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return proto
+}
+
+func TestTransport_HTTP2(t *testing.T) {
+	server, conns := newCountingServer(t)
+	client := newTestClient(server)
+
+	// concurrent requests may dial before the first connection negotiates HTTP/2
+	get(t, client, server.URL, 1, 1)
+	if proto := get(t, client, server.URL, 50, 10); proto != "HTTP/2.0" {
+		t.Fatalf("expected HTTP/2.0, got %q", proto)
+	}
+	if n := conns.Load(); n != 1 {
+		t.Fatalf("expected requests to share one connection, got %d", n)
+	}
+}
+
+func TestTransport_HTTP1ReusesIdleConns(t *testing.T) {
+	server, conns := newCountingServer(t)
+	transport := DefaultTransport()
+	transport.HTTP2 = false
+	client := newTestClient(server, WithTransport(transport))
+
+	if proto := get(t, client, server.URL, 100, 8); proto != "HTTP/1.1" {
+		t.Fatalf("expected HTTP/1.1, got %q", proto)
+	}
+	// about one connection per concurrent request, not one per request
+	if n := conns.Load(); n > 16 {
+		t.Fatalf("expected idle connections to be reused, got %d connections for 100 requests", n)
+	}
+}
+
+// BenchmarkTransport compares the connections opened by a batch of concurrent
+// requests with the default tuning and with a small idle pool.
+func BenchmarkTransport(b *testing.B) {
+	http1 := DefaultTransport()
+	http1.HTTP2 = false
+	smallPool := http1
+	smallPool.MaxIdleConnsPerHost = 2
+
+	for _, bc := range []struct {
+		name      string
+		transport Transport
+	}{
+		{name: "http2", transport: DefaultTransport()},
+		{name: "http1", transport: http1},
+		{name: "http1-small-pool", transport: smallPool},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			server, conns := newCountingServer(b)
+			client := newTestClient(server, WithTransport(bc.transport))
+			b.ResetTimer()
+			for range b.N {
+				get(b, client, server.URL, 64, 16)
+			}
+			b.ReportMetric(float64(conns.Load())/float64(b.N), "conns/op")
+		})
+	}
+}
@@ -2,6 +2,7 @@ package datetime
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -11,12 +12,24 @@ var supportedLayouts = []struct {
 	hasTimezone bool
 }{
 	// Layouts without timezone info (will use defaultTZ)
+	{"2006-01-02T15:04:05", false},
 	{"2006-01-02 15:04:05", false},
+	{"2006-01-02 15:04", false},
 	{"2006-01-02", false},
 	{"01/02/2006 15:04:05", false},
 	{"01/02/2006", false},
 	{"2006/01/02", false},
 
+	// Natural dates (month names are matched case-insensitively)
+	{"Jan 2, 2006 15:04", false},
+	{"Jan 2, 2006", false},
+	{"Jan 2 2006", false},
+	{"January 2, 2006 15:04", false},
+	{"January 2, 2006", false},
+	{"January 2 2006", false},
+	{"2 Jan 2006", false},
+	{"2 January 2006", false},
+
 	// Layouts with timezone info (will preserve parsed timezone)
 	{"2006-01-02 15:04:05 -0700", true},
 	{"2006-01-02 15:04:05 -07:00", true},
@@ -26,12 +39,30 @@ var supportedLayouts = []struct {
 // If the string is in RFC3339 format, it will be returned as-is.
 // If the string does not specify a timezone, the default timezone will be used.
 // If the string only specifies a date, the time will be set to 00:00:00.
+// Relative times (e.g. "-24h", "3d ago", "yesterday", "last week") are
+// resolved against the current time; see ParseAt.
 func Parse(input string, defaultTZ TimeZone) (time.Time, error) {
+	return ParseAt(input, defaultTZ, time.Now())
+}
+
+// ParseAt is like Parse, but resolves relative times against now instead of
+// the current time. Supported relative forms are:
+//   - "now", "today", "yesterday", "tomorrow" (calendar days start at midnight in defaultTZ)
+//   - signed offsets made of s/m/h/d/w/y tokens, e.g. "-24h", "-1d12h", "+2w"
+//   - "<n> <unit> ago", e.g. "3d ago", "2 weeks ago", "1 month ago"
+//   - "last <unit>" or "last <n> <unit>", e.g. "last week", "last 3 days"
+func ParseAt(input string, defaultTZ TimeZone, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+
 	// First, try RFC3339 or other layouts that include timezone info.
 	if t, err := time.Parse(time.RFC3339, input); err == nil {
 		return t, nil
 	}
 
+	if t, ok, err := parseRelative(input, now, defaultTZ.location()); ok {
+		return t, err
+	}
+
 	// Try parsing with known layouts
 	for _, layout := range supportedLayouts {
 		if t, err := time.Parse(layout.layout, input); err == nil {
@@ -188,6 +188,32 @@ func TestParse(t *testing.T) {
 			expected:  makeTime(TimeZoneUTC, 2024, 2, 29, 0, 0, 0),
 		},
 
+		// Natural dates (no timezone, uses defaultTZ)
+		{
+			name:      "abbreviated month name",
+			defaultTZ: TimeZoneAmericaNewYork,
+			input:     "Mar 15, 2024",
+			expected:  makeTime(TimeZoneAmericaNewYork, 2024, 3, 15, 0, 0, 0),
+		},
+		{
+			name:      "full month name with time",
+			defaultTZ: TimeZoneUTC,
+			input:     "March 15, 2024 14:30",
+			expected:  makeTime(TimeZoneUTC, 2024, 3, 15, 14, 30, 0),
+		},
+		{
+			name:      "day before month",
+			defaultTZ: TimeZoneEuropeLondon,
+			input:     "15 march 2024",
+			expected:  makeTime(TimeZoneEuropeLondon, 2024, 3, 15, 0, 0, 0),
+		},
+		{
+			name:      "ISO without timezone",
+			defaultTZ: TimeZoneAsiaTokyo,
+			input:     "2024-03-15T14:30:45",
+			expected:  makeTime(TimeZoneAsiaTokyo, 2024, 3, 15, 14, 30, 45),
+		},
+
 		// Error cases
 		{
 			name:        "invalid format",
@@ -224,3 +250,58 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAt_Relative(t *testing.T) {
+	// 2024-03-15 02:30 UTC is still March 14 in New York
+	now := time.Date(2024, 3, 15, 2, 30, 0, 0, time.UTC)
+	newYork := TimeZoneAmericaNewYork.location()
+
+	testCases := []struct {
+		name        string
+		defaultTZ   TimeZone
+		input       string
+		expected    time.Time
+		errContains string
+	}{
+		{name: "now", defaultTZ: TimeZoneUTC, input: "now", expected: now},
+		{name: "today UTC", defaultTZ: TimeZoneUTC, input: "today", expected: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "today New York", defaultTZ: TimeZoneAmericaNewYork, input: "Today", expected: time.Date(2024, 3, 14, 0, 0, 0, 0, newYork)},
+		{name: "yesterday", defaultTZ: TimeZoneUTC, input: "yesterday", expected: time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "tomorrow", defaultTZ: TimeZoneUTC, input: "tomorrow", expected: time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{name: "negative hours", defaultTZ: TimeZoneUTC, input: "-24h", expected: now.Add(-24 * time.Hour)},
+		{name: "negative days", defaultTZ: TimeZoneUTC, input: "-7d", expected: now.AddDate(0, 0, -7)},
+		{name: "combined offset", defaultTZ: TimeZoneUTC, input: "-1d12h", expected: now.Add(-36 * time.Hour)},
+		{name: "positive weeks", defaultTZ: TimeZoneUTC, input: "+2w", expected: now.AddDate(0, 0, 14)},
+		{name: "compact ago", defaultTZ: TimeZoneUTC, input: "3d ago", expected: now.AddDate(0, 0, -3)},
+		{name: "words ago", defaultTZ: TimeZoneUTC, input: "2 weeks  ago", expected: now.AddDate(0, 0, -14)},
+		{name: "month ago", defaultTZ: TimeZoneUTC, input: "1 month ago", expected: now.AddDate(0, -1, 0)},
+		{name: "last week", defaultTZ: TimeZoneUTC, input: "last week", expected: now.AddDate(0, 0, -7)},
+		{name: "last n days", defaultTZ: TimeZoneUTC, input: "last 3 days", expected: now.AddDate(0, 0, -3)},
+		{name: "last year", defaultTZ: TimeZoneUTC, input: "last year", expected: now.AddDate(-1, 0, 0)},
+		{name: "unknown unit", defaultTZ: TimeZoneUTC, input: "3 fortnights ago", errContains: "unknown time unit"},
+		{name: "not relative", defaultTZ: TimeZoneUTC, input: "last", errContains: "could not parse time string"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseAt(tc.input, tc.defaultTZ, now)
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tc.expected.Equal(result), "Expected %v, got %v", tc.expected, result)
+		})
+	}
+}
+
+func TestInDisplayTimeZone(t *testing.T) {
+	t.Cleanup(func() { SetDisplayTimeZone(TimeZoneUTC) })
+	ts := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	SetDisplayTimeZone(TimeZoneUTC)
+	assert.Equal(t, "2024-03-15 12:00", InDisplayTimeZone(ts).Format("2006-01-02 15:04"))
+
+	SetDisplayTimeZone(TimeZoneAsiaTokyo)
+	assert.Equal(t, "2024-03-15 21:00", InDisplayTimeZone(ts).Format("2006-01-02 15:04"))
+}
@@ -0,0 +1,23 @@
+package datetime
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// displayLocation is the location timestamps are rendered in for human-readable output.
+var displayLocation atomic.Pointer[time.Location]
+
+// SetDisplayTimeZone sets the timezone used by InDisplayTimeZone.
+// It is set from the default-tz config (or --tz) before a command runs.
+func SetDisplayTimeZone(tz TimeZone) {
+	displayLocation.Store(tz.location())
+}
+
+// InDisplayTimeZone returns t converted to the display timezone (UTC unless set).
+func InDisplayTimeZone(t time.Time) time.Time {
+	if loc := displayLocation.Load(); loc != nil {
+		return t.In(loc)
+	}
+	return t.UTC()
+}
@@ -0,0 +1,128 @@
+package datetime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// compactOffsetPattern matches offsets like "-24h", "+7d", or "-1d12h".
+var compactOffsetPattern = regexp.MustCompile(`^([+-])((?:\d+[smhdwy])+)$`)
+
+// compactTokenPattern matches a single number+unit token within a compact offset.
+var compactTokenPattern = regexp.MustCompile(`(\d+)([smhdwy])`)
+
+// agoPattern matches phrases like "3d ago", "2 weeks ago", or "1 month ago".
+var agoPattern = regexp.MustCompile(`^(\d+)\s*([a-z]+)\s+ago$`)
+
+// lastPattern matches phrases like "last week" or "last 3 days".
+var lastPattern = regexp.MustCompile(`^last\s+(?:(\d+)\s+)?([a-z]+)$`)
+
+// parseRelative interprets input relative to now. Calendar keywords
+// ("today", "yesterday", "tomorrow") resolve to midnight in loc.
+// The second return value is false if input is not a relative time.
+func parseRelative(input string, now time.Time, loc *time.Location) (time.Time, bool, error) {
+	s := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	now = now.In(loc)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch s {
+	case "now":
+		return now, true, nil
+	case "today":
+		return midnight, true, nil
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), true, nil
+	case "tomorrow":
+		return midnight.AddDate(0, 0, 1), true, nil
+	}
+
+	if m := compactOffsetPattern.FindStringSubmatch(s); m != nil {
+		sign := 1
+		if m[1] == "-" {
+			sign = -1
+		}
+		t := now
+		for _, tok := range compactTokenPattern.FindAllStringSubmatch(m[2], -1) {
+			n, err := strconv.Atoi(tok[1])
+			if err != nil {
+				return time.Time{}, true, fmt.Errorf("invalid relative time: %q", input)
+			}
+			t = shift(t, sign*n, tok[2])
+		}
+		return t, true, nil
+	}
+
+	if m := agoPattern.FindStringSubmatch(s); m != nil {
+		return shiftBack(input, now, m[1], m[2])
+	}
+
+	if m := lastPattern.FindStringSubmatch(s); m != nil {
+		n := m[1]
+		if n == "" {
+			n = "1"
+		}
+		return shiftBack(input, now, n, m[2])
+	}
+
+	return time.Time{}, false, nil
+}
+
+// shiftBack moves now back by count units, where unit is a unit name or abbreviation.
+func shiftBack(input string, now time.Time, count string, unit string) (time.Time, bool, error) {
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return time.Time{}, true, fmt.Errorf("invalid relative time: %q", input)
+	}
+	u, ok := unitAbbreviation(unit)
+	if !ok {
+		return time.Time{}, true, fmt.Errorf("unknown time unit %q in %q", unit, input)
+	}
+	return shift(now, -n, u), true, nil
+}
+
+// shift moves t by n units. Days, weeks, months, and years follow the
+// calendar (so "-1d" across a DST change is still the same wall-clock time).
+func shift(t time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "s":
+		return t.Add(time.Duration(n) * time.Second)
+	case "m":
+		return t.Add(time.Duration(n) * time.Minute)
+	case "h":
+		return t.Add(time.Duration(n) * time.Hour)
+	case "d":
+		return t.AddDate(0, 0, n)
+	case "w":
+		return t.AddDate(0, 0, 7*n)
+	case "mo":
+		return t.AddDate(0, n, 0)
+	case "y":
+		return t.AddDate(n, 0, 0)
+	}
+	return t
+}
+
+// unitAbbreviation normalizes a unit name ("weeks", "hr", "d") to the
+// abbreviation understood by shift. Note that a bare "m" means minutes.
+func unitAbbreviation(unit string) (string, bool) {
+	switch unit {
+	case "s", "sec", "secs", "second", "seconds":
+		return "s", true
+	case "m", "min", "mins", "minute", "minutes":
+		return "m", true
+	case "h", "hr", "hrs", "hour", "hours":
+		return "h", true
+	case "d", "day", "days":
+		return "d", true
+	case "w", "wk", "wks", "week", "weeks":
+		return "w", true
+	case "mo", "month", "months":
+		return "mo", true
+	case "y", "yr", "yrs", "year", "years":
+		return "y", true
+	}
+	return "", false
+}
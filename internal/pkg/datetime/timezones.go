@@ -27,9 +27,13 @@ func init() {
 
 type TimeZone string
 
-// location returns the *time.Location for the given TimeZone
+// location returns the *time.Location for the given TimeZone,
+// falling back to UTC for unknown or empty timezones.
 func (tz TimeZone) location() *time.Location {
-	return locations[tz]
+	if loc, ok := locations[tz]; ok {
+		return loc
+	}
+	return time.UTC
 }
 
 var _ encoding.TextUnmarshaler = (*TimeZone)(nil)
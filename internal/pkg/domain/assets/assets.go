@@ -1,6 +1,9 @@
 package assets
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/censys/censys-sdk-go/models/components"
 )
 
@@ -8,6 +11,42 @@ type Asset interface {
 	AssetType() AssetType
 }
 
+// Identifier returns the identifier of an asset: the IP of a host, the
+// SHA-256 fingerprint of a certificate, or hostname:port of a web property.
+// It is empty if the asset does not have one.
+func Identifier(a Asset) string {
+	switch v := a.(type) {
+	case *Host:
+		return deref(v.IP)
+	case Host:
+		return deref(v.IP)
+	case *Certificate:
+		return deref(v.FingerprintSha256)
+	case Certificate:
+		return deref(v.FingerprintSha256)
+	case *WebProperty:
+		return webPropertyIdentifier(v.Webproperty)
+	case WebProperty:
+		return webPropertyIdentifier(v.Webproperty)
+	default:
+		return ""
+	}
+}
+
+func webPropertyIdentifier(wp components.Webproperty) string {
+	if wp.Hostname == nil || wp.Port == nil {
+		return ""
+	}
+	return *wp.Hostname + ":" + strconv.Itoa(*wp.Port)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 // Certificate represents a certificate asset.
 // This has 1:1 correspondence with the SDK's Certificate type.
 type Certificate struct{ components.Certificate }
@@ -35,6 +74,26 @@ func NewHostWithMatchedServices(host components.Host, matchedServices []componen
 
 func NewHost(host components.Host) Host { return Host{host, nil} }
 
+// IsMatchedService reports whether svc is one of the services that matched
+// the search query the host was returned for. Fields that are missing on
+// either side are not compared.
+func (h Host) IsMatchedService(svc components.Service) bool {
+	for _, m := range h.MatchedServices {
+		if m.Port == nil || svc.Port == nil || *m.Port != *svc.Port {
+			continue
+		}
+		if m.TransportProtocol != nil && svc.TransportProtocol != nil &&
+			!strings.EqualFold(string(*m.TransportProtocol), string(*svc.TransportProtocol)) {
+			continue
+		}
+		if m.Protocol != nil && svc.Protocol != nil && !strings.EqualFold(*m.Protocol, *svc.Protocol) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
 // WebProperty represents a web property asset.
 // This has 1:1 correspondence with the SDK's Webproperty type.
 type WebProperty struct{ components.Webproperty }
@@ -0,0 +1,35 @@
+package assets
+
+import (
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentifier(t *testing.T) {
+	ip, fingerprint, hostname, port := "1.1.1.1", "abc123", "example.com", 8443
+	host := NewHost(components.Host{IP: &ip})
+	cert := NewCertificate(components.Certificate{FingerprintSha256: &fingerprint})
+	webProperty := NewWebProperty(components.Webproperty{Hostname: &hostname, Port: &port})
+
+	testCases := []struct {
+		name  string
+		asset Asset
+		want  string
+	}{
+		{name: "host", asset: &host, want: "1.1.1.1"},
+		{name: "host value", asset: host, want: "1.1.1.1"},
+		{name: "certificate", asset: &cert, want: "abc123"},
+		{name: "web property", asset: &webProperty, want: "example.com:8443"},
+		{name: "web property value", asset: webProperty, want: "example.com:8443"},
+		{name: "web property without port", asset: NewWebProperty(components.Webproperty{Hostname: &hostname}), want: ""},
+		{name: "host without IP", asset: &Host{}, want: ""},
+		{name: "enriched host", asset: EnrichedHost{}, want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, Identifier(tc.asset))
+		})
+	}
+}
@@ -14,22 +14,48 @@ import (
 // be used if no port is specified in the input.
 const DefaultWebPropertyPort = 443
 
+// DefaultWebPropertyPorts are the ports a hostname is looked up on when
+// expanded into web properties without a list of ports.
+var DefaultWebPropertyPorts = []int{80, 443, 8080, 8443}
+
 // HostID represents a validated IP address (v4 or v6).
 type HostID struct{ value string }
 
 func (h HostID) String() string { return h.value }
 
 // NewHostID parses an IP address into a HostID.
-// Supports defanged IPs with [.] or (.) patterns.
+// Supports defanged IPs with [.] or (.) patterns. IPv4-mapped IPv6
+// addresses, such as ::ffff:1.2.3.4, are parsed as the IPv4 address, which
+// is how hosts are known.
 func NewHostID(raw string) (HostID, error) {
 	refanged := refang.RefangIP(raw)
 	trimmed := strings.TrimSpace(refanged)
 	if ip := net.ParseIP(trimmed); ip != nil {
-		return HostID{value: trimmed}, nil
+		return HostID{value: unmapIP(trimmed, ip)}, nil
 	}
 	return HostID{}, fmt.Errorf("invalid host id: %q", raw)
 }
 
+// NormalizeIP returns ip in canonical form, so that an address compares
+// equal however it is written: IPv4-mapped IPv6 addresses as the IPv4
+// address, and IPv6 addresses in lowercase with zeros compressed. A string
+// that is not an IP address is returned unchanged.
+func NormalizeIP(ip string) string {
+	if parsed := net.ParseIP(strings.TrimSpace(ip)); parsed != nil {
+		return parsed.String()
+	}
+	return ip
+}
+
+// unmapIP returns the IPv4 address of s if it is an IPv4-mapped IPv6
+// address, or s unchanged. ip is s parsed.
+func unmapIP(s string, ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil && strings.Contains(s, ":") {
+		return ip4.String()
+	}
+	return s
+}
+
 // CertificateID represents a validated SHA-256 hex string (64 chars).
 type CertificateID struct{ value string }
 
@@ -122,9 +148,11 @@ func NewWebPropertyID(raw string, defaultPort int) (WebPropertyID, error) {
 
 	// If it looks like an IP, validate it's a valid IP
 	if looksLikeIP(host) {
-		if net.ParseIP(host) == nil {
+		ip := net.ParseIP(host)
+		if ip == nil {
 			return WebPropertyID{}, fmt.Errorf("invalid webproperty: %q: invalid IP address", raw)
 		}
+		host = unmapIP(host, ip)
 	} else if !strings.Contains(host, ".") {
 		// Hostnames that are not IPs must have a period
 		return WebPropertyID{}, fmt.Errorf("invalid webproperty: %q: invalid hostname", raw)
@@ -40,6 +40,16 @@ func TestNewHostID(t *testing.T) {
 			wantValue: "2001:0db8:85a3:0000:0000:8a2e:0370:7334",
 			wantErr:   false,
 		},
+		{
+			name:      "ipv4-mapped ipv6 is the ipv4 address",
+			input:     "::ffff:1.2.3.4",
+			wantValue: "1.2.3.4",
+		},
+		{
+			name:      "ipv4-mapped ipv6 in hex is the ipv4 address",
+			input:     "::FFFF:0102:0304",
+			wantValue: "1.2.3.4",
+		},
 		{
 			name:      "defanged ipv4 with brackets",
 			input:     "8[.]8[.]8[.]8",
@@ -125,6 +135,26 @@ func TestNewHostID(t *testing.T) {
 	}
 }
 
+func TestNormalizeIP(t *testing.T) {
+	tests := map[string]string{
+		"1.2.3.4":               "1.2.3.4",
+		" ::ffff:1.2.3.4 ":      "1.2.3.4",
+		"::FFFF:0102:0304":      "1.2.3.4",
+		"0:0:0:0:0:ffff:7f00:1": "127.0.0.1",
+		"2001:0DB8:0000::0001":  "2001:db8::1",
+		"::1":                   "::1",
+		"64:ff9b::1.2.3.4":      "64:ff9b::102:304",
+		"::1.2.3.4":             "::102:304",
+		"example.com":           "example.com",
+		"::ffff:256.1.1.1":      "::ffff:256.1.1.1",
+		"[::ffff:1.2.3.4]":      "[::ffff:1.2.3.4]",
+		"":                      "",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, NormalizeIP(input), "NormalizeIP(%q)", input)
+	}
+}
+
 func TestNewCertificateFingerprint(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -386,6 +416,13 @@ func TestNewWebPropertyID(t *testing.T) {
 			wantPort:     defaultPort,
 		},
 
+		{
+			name:         "ipv4-mapped ipv6 with port",
+			input:        "[::ffff:10.0.0.1]:8443",
+			wantHostname: "10.0.0.1",
+			wantPort:     8443,
+		},
+
 		// === Valid IPv6 Cases (with explicit port) ===
 		{
 			name:         "ipv6 with port - short form",
@@ -0,0 +1,118 @@
+package assets
+
+import (
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/censys/censys-sdk-go/models/components"
+)
+
+// Ports returns the distinct ports of the services of the host, in
+// ascending order. Services without a port, such as ICMP, are skipped.
+func (h Host) Ports() []int {
+	var ports []int
+	for _, svc := range h.Services {
+		if svc.Port == nil || slices.Contains(ports, *svc.Port) {
+			continue
+		}
+		ports = append(ports, *svc.Port)
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+// Protocols returns the distinct protocols of the services of the host,
+// upper-cased and sorted, as in SSH or HTTP.
+func (h Host) Protocols() []string {
+	var protocols []string
+	for _, svc := range h.Services {
+		if svc.Protocol == nil || *svc.Protocol == "" {
+			continue
+		}
+		protocol := strings.ToUpper(*svc.Protocol)
+		if !slices.Contains(protocols, protocol) {
+			protocols = append(protocols, protocol)
+		}
+	}
+	sort.Strings(protocols)
+	return protocols
+}
+
+// ServicesOnPort returns the services of the host on port, on any transport.
+func (h Host) ServicesOnPort(port int) []components.Service {
+	return h.findServices(func(svc components.Service) bool {
+		return svc.Port != nil && *svc.Port == port
+	})
+}
+
+// ServicesWithProtocol returns the services of the host that speak
+// protocol, which is compared ignoring case.
+func (h Host) ServicesWithProtocol(protocol string) []components.Service {
+	return h.findServices(func(svc components.Service) bool {
+		return svc.Protocol != nil && strings.EqualFold(*svc.Protocol, protocol)
+	})
+}
+
+// CertFingerprints returns the distinct SHA-256 fingerprints of the
+// certificates presented by the services of the host, in service order.
+func (h Host) CertFingerprints() []string {
+	var fingerprints []string
+	for _, svc := range h.Services {
+		if svc.Cert == nil || svc.Cert.FingerprintSha256 == nil || *svc.Cert.FingerprintSha256 == "" {
+			continue
+		}
+		if !slices.Contains(fingerprints, *svc.Cert.FingerprintSha256) {
+			fingerprints = append(fingerprints, *svc.Cert.FingerprintSha256)
+		}
+	}
+	return fingerprints
+}
+
+// CertOnPort returns the certificate presented by the first service of the
+// host on port that presents one.
+func (h Host) CertOnPort(port int) (components.Certificate, bool) {
+	for _, svc := range h.ServicesOnPort(port) {
+		if svc.Cert != nil {
+			return *svc.Cert, true
+		}
+	}
+	return components.Certificate{}, false
+}
+
+// BannersMatching returns the services of the host whose banner, as
+// returned by ServiceBanner, matches re.
+func (h Host) BannersMatching(re *regexp.Regexp) []components.Service {
+	return h.findServices(func(svc components.Service) bool {
+		banner, ok := ServiceBanner(svc)
+		return ok && re.MatchString(banner)
+	})
+}
+
+func (h Host) findServices(match func(components.Service) bool) []components.Service {
+	var res []components.Service
+	for _, svc := range h.Services {
+		if match(svc) {
+			res = append(res, svc)
+		}
+	}
+	return res
+}
+
+// ServiceBanner returns the banner of svc, decoding the hex-encoded banner
+// when the API only returns that. It reports false if svc has no banner.
+func ServiceBanner(svc components.Service) (string, bool) {
+	if svc.Banner != nil && *svc.Banner != "" {
+		return *svc.Banner, true
+	}
+	if svc.BannerHex == nil || *svc.BannerHex == "" {
+		return "", false
+	}
+	decoded, err := hex.DecodeString(*svc.BannerHex)
+	if err != nil {
+		return "", false
+	}
+	return string(decoded), true
+}
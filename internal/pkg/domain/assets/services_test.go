@@ -0,0 +1,188 @@
+package assets
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func testHost() Host {
+	tcp, udp := components.ServiceTransportProtocolTCP, components.ServiceTransportProtocolUDP
+	return NewHost(components.Host{
+		IP: ptr("10.0.0.1"),
+		Services: []components.Service{
+			{
+				Port:              ptr(443),
+				Protocol:          ptr("HTTP"),
+				TransportProtocol: &tcp,
+				Cert:              &components.Certificate{FingerprintSha256: ptr("aaa")},
+				Banner:            ptr("HTTP/1.1 200 OK\r\nServer: nginx"),
+			},
+			{
+				Port:              ptr(22),
+				Protocol:          ptr("ssh"),
+				TransportProtocol: &tcp,
+				Banner:            ptr("SSH-2.0-OpenSSH_8.9p1"),
+			},
+			{
+				Port:              ptr(443),
+				Protocol:          ptr("UNKNOWN"),
+				TransportProtocol: &udp,
+				// "\x00\x01nobbler"
+				BannerHex: ptr("00016e6f62626c6572"),
+			},
+			{
+				Port:     ptr(8443),
+				Protocol: ptr("HTTP"),
+				Cert:     &components.Certificate{FingerprintSha256: ptr("bbb")},
+			},
+			{
+				Port:     ptr(9443),
+				Protocol: ptr("HTTP"),
+				Cert:     &components.Certificate{FingerprintSha256: ptr("aaa")},
+			},
+			{
+				// ICMP services have no port
+				Protocol:  ptr("ICMP"),
+				BannerHex: ptr("not hex"),
+			},
+		},
+	})
+}
+
+// servicePorts returns the port of each service, for comparisons.
+func servicePorts(services []components.Service) []int {
+	ports := []int{}
+	for _, svc := range services {
+		port := 0
+		if svc.Port != nil {
+			port = *svc.Port
+		}
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+func TestHost_Ports(t *testing.T) {
+	require.Equal(t, []int{22, 443, 8443, 9443}, testHost().Ports())
+	require.Empty(t, Host{}.Ports())
+}
+
+func TestHost_Protocols(t *testing.T) {
+	require.Equal(t, []string{"HTTP", "ICMP", "SSH", "UNKNOWN"}, testHost().Protocols())
+	require.Empty(t, Host{}.Protocols())
+}
+
+func TestHost_ServicesOnPort(t *testing.T) {
+	host := testHost()
+	testCases := []struct {
+		name      string
+		port      int
+		protocols []string
+	}{
+		{name: "every transport", port: 443, protocols: []string{"HTTP", "UNKNOWN"}},
+		{name: "single service", port: 22, protocols: []string{"ssh"}},
+		{name: "no service", port: 80, protocols: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var protocols []string
+			for _, svc := range host.ServicesOnPort(tc.port) {
+				protocols = append(protocols, *svc.Protocol)
+			}
+			require.Equal(t, tc.protocols, protocols)
+		})
+	}
+}
+
+func TestHost_ServicesWithProtocol(t *testing.T) {
+	host := testHost()
+	testCases := []struct {
+		name     string
+		protocol string
+		ports    []int
+	}{
+		{name: "several services", protocol: "HTTP", ports: []int{443, 8443, 9443}},
+		{name: "ignores case", protocol: "SSH", ports: []int{22}},
+		{name: "service without a port", protocol: "icmp", ports: []int{0}},
+		{name: "no service", protocol: "RDP", ports: []int{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.ports, servicePorts(host.ServicesWithProtocol(tc.protocol)))
+		})
+	}
+}
+
+func TestHost_CertFingerprints(t *testing.T) {
+	require.Equal(t, []string{"aaa", "bbb"}, testHost().CertFingerprints())
+
+	empty := NewHost(components.Host{Services: []components.Service{
+		{Cert: &components.Certificate{}},
+		{Cert: &components.Certificate{FingerprintSha256: ptr("")}},
+	}})
+	require.Empty(t, empty.CertFingerprints())
+}
+
+func TestHost_CertOnPort(t *testing.T) {
+	host := testHost()
+
+	cert, ok := host.CertOnPort(8443)
+	require.True(t, ok)
+	require.Equal(t, "bbb", *cert.FingerprintSha256)
+
+	// the UDP service on 443 presents no certificate, the TCP one does
+	cert, ok = host.CertOnPort(443)
+	require.True(t, ok)
+	require.Equal(t, "aaa", *cert.FingerprintSha256)
+
+	_, ok = host.CertOnPort(22)
+	require.False(t, ok)
+	_, ok = host.CertOnPort(80)
+	require.False(t, ok)
+}
+
+func TestHost_BannersMatching(t *testing.T) {
+	host := testHost()
+	testCases := []struct {
+		name    string
+		pattern string
+		ports   []int
+	}{
+		{name: "text banner", pattern: `(?i)openssh`, ports: []int{22}},
+		{name: "multi-line banner", pattern: `(?m)^Server: nginx$`, ports: []int{443}},
+		{name: "hex banner is decoded", pattern: `nobbler`, ports: []int{443}},
+		{name: "services without banners never match", pattern: `.*`, ports: []int{443, 22, 443}},
+		{name: "no match", pattern: `Apache`, ports: []int{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.ports, servicePorts(host.BannersMatching(regexp.MustCompile(tc.pattern))))
+		})
+	}
+}
+
+func TestServiceBanner(t *testing.T) {
+	testCases := []struct {
+		name   string
+		svc    components.Service
+		want   string
+		wantOK bool
+	}{
+		{name: "text", svc: components.Service{Banner: ptr("hello"), BannerHex: ptr("6869")}, want: "hello", wantOK: true},
+		{name: "hex fallback", svc: components.Service{Banner: ptr(""), BannerHex: ptr("6869")}, want: "hi", wantOK: true},
+		{name: "invalid hex", svc: components.Service{BannerHex: ptr("zz")}},
+		{name: "no banner", svc: components.Service{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := ServiceBanner(tc.svc)
+			require.Equal(t, tc.wantOK, ok)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
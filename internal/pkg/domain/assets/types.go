@@ -20,10 +20,11 @@ const (
 func (a AssetType) String() string { return string(a) }
 
 // AssetClassifier classifies raw string inputs into typed asset identifiers and reports errors.
-// It also deduplicates values within each asset category.
+// It also deduplicates values within each asset category, comparing IPs in
+// canonical form (see NormalizeIP), and hostnames and fingerprints without regard to case.
 type AssetClassifier struct {
-	hostIDs        map[HostID]struct{}
-	certificateIDs map[CertificateID]struct{}
+	hostIDs        map[string]struct{}
+	certificateIDs map[string]struct{}
 	webPropertyIDs map[WebPropertyID]struct{}
 	unknownAssets  map[string]struct{}
 	// preserve first-seen order for stable behavior and UX
@@ -36,8 +37,8 @@ type AssetClassifier struct {
 // NewAssetClassifier creates a classifier and immediately classifies the provided raw assets.
 func NewAssetClassifier(rawAssets ...string) *AssetClassifier {
 	a := &AssetClassifier{
-		hostIDs:        make(map[HostID]struct{}),
-		certificateIDs: make(map[CertificateID]struct{}),
+		hostIDs:        make(map[string]struct{}),
+		certificateIDs: make(map[string]struct{}),
 		webPropertyIDs: make(map[WebPropertyID]struct{}),
 		unknownAssets:  make(map[string]struct{}),
 	}
@@ -53,22 +54,25 @@ func (a *AssetClassifier) classify(rawAssets ...string) {
 			continue
 		}
 		if h, err := NewHostID(arg); err == nil {
-			if _, exists := a.hostIDs[h]; !exists {
-				a.hostIDs[h] = struct{}{}
+			key := NormalizeIP(h.String())
+			if _, exists := a.hostIDs[key]; !exists {
+				a.hostIDs[key] = struct{}{}
 				a.hostOrder = append(a.hostOrder, h)
 			}
 			continue
 		}
 		if c, err := NewCertificateFingerprint(arg); err == nil {
-			if _, exists := a.certificateIDs[c]; !exists {
-				a.certificateIDs[c] = struct{}{}
+			key := strings.ToLower(c.String())
+			if _, exists := a.certificateIDs[key]; !exists {
+				a.certificateIDs[key] = struct{}{}
 				a.certificateOrder = append(a.certificateOrder, c)
 			}
 			continue
 		}
 		if w, err := NewWebPropertyID(arg, DefaultWebPropertyPort); err == nil {
-			if _, exists := a.webPropertyIDs[w]; !exists {
-				a.webPropertyIDs[w] = struct{}{}
+			key := WebPropertyID{Hostname: strings.ToLower(NormalizeIP(w.Hostname)), Port: w.Port}
+			if _, exists := a.webPropertyIDs[key]; !exists {
+				a.webPropertyIDs[key] = struct{}{}
 				a.webPropertyOrder = append(a.webPropertyOrder, w)
 			}
 			continue
@@ -48,6 +48,24 @@ func TestAssetClassifier_ComprehensiveWorkflow(t *testing.T) {
 			expectedError:     nil,
 			expectedHostIDs:   []string{"127.0.0.1", "::1", "fe80::1"},
 		},
+		{
+			name:              "IPv4-mapped IPv6 hosts are the IPv4 host",
+			rawAssets:         []string{"::ffff:1.2.3.4", "1.2.3.4", "::FFFF:0102:0304"},
+			expectedAssetType: AssetTypeHost,
+			expectedHostIDs:   []string{"1.2.3.4"},
+		},
+		{
+			name:              "IPv6 hosts written differently are deduplicated",
+			rawAssets:         []string{"2001:DB8::1", "2001:0db8:0000::0001", "2001:db8::2"},
+			expectedAssetType: AssetTypeHost,
+			expectedHostIDs:   []string{"2001:DB8::1", "2001:db8::2"},
+		},
+		{
+			name:               "web properties on mapped IPs and hostnames in any case are deduplicated",
+			rawAssets:          []string{"[::ffff:1.2.3.4]:443", "1.2.3.4:443", "Example.com:8443", "example.com:8443"},
+			expectedAssetType:  AssetTypeWebProperty,
+			expectedWebPropIDs: []string{"1.2.3.4:443", "Example.com:8443"},
+		},
 
 		// Single asset type scenarios - Certificates
 		{
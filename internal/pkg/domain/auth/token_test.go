@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func jwt(payload string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(payload)) + ".c2ln"
+}
+
+func TestTokenExpiry(t *testing.T) {
+	exp, ok := TokenExpiry(jwt(`{"sub":"user","exp":1767225600}`))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), exp)
+
+	for _, token := range []string{
+		"censys_abcdef0123456789",
+		jwt(`{"sub":"user"}`),
+		jwt(`not json`),
+		"a.!!!.c",
+		"",
+	} {
+		_, ok := TokenExpiry(token)
+		assert.False(t, ok, token)
+	}
+}
@@ -0,0 +1,230 @@
+// Package query builds CenQL queries from their parts, so that values are
+// quoted and escaped, and terms are grouped, the same way wherever the CLI
+// writes a query for the user:
+//
+//	query.Field("host.services").Has(
+//		query.Field("port").Eq(22).And(query.Field("protocol").Eq("SSH")),
+//	).String()
+//
+// gives host.services:(port=22 and protocol="SSH").
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// kind is what a query is made of, which decides whether it needs
+// parentheses within another.
+type kind int
+
+const (
+	kindEmpty kind = iota
+	// kindTerm is a single term, such as a comparison or a nested query,
+	// which never needs parentheses.
+	kindTerm
+	kindAnd
+	kindOr
+	kindNot
+	// kindRaw is a query written by hand, whose structure is unknown.
+	kindRaw
+)
+
+// Query is a CenQL query, or a part of one. The zero Query is empty, and is
+// left out of the queries it is combined into.
+type Query struct {
+	text string
+	kind kind
+}
+
+// String returns the query as it is sent to the API.
+func (q Query) String() string {
+	return q.text
+}
+
+// IsZero reports whether the query is empty.
+func (q Query) IsZero() bool {
+	return q.kind == kindEmpty
+}
+
+// Raw returns a query written by hand, such as the query of a command. It is
+// put in parentheses when combined with other terms, so that its operators
+// cannot bind to them. An empty or blank query is the zero Query.
+func Raw(s string) Query {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Query{}
+	}
+	return Query{text: s, kind: kindRaw}
+}
+
+// And returns the query that matches all of qs. Empty queries are left out.
+func And(qs ...Query) Query {
+	return join(kindAnd, qs)
+}
+
+// Or returns the query that matches any of qs. Empty queries are left out.
+func Or(qs ...Query) Query {
+	return join(kindOr, qs)
+}
+
+// Not returns the query that matches what q does not.
+func Not(q Query) Query {
+	if q.IsZero() {
+		return q
+	}
+	return Query{text: "not " + q.within(kindNot), kind: kindNot}
+}
+
+// And returns the query that matches q and all of others.
+func (q Query) And(others ...Query) Query {
+	return And(append([]Query{q}, others...)...)
+}
+
+// Or returns the query that matches q or any of others.
+func (q Query) Or(others ...Query) Query {
+	return Or(append([]Query{q}, others...)...)
+}
+
+func join(k kind, qs []Query) Query {
+	parts := make([]string, 0, len(qs))
+	var only Query
+	for _, q := range qs {
+		if q.IsZero() {
+			continue
+		}
+		only = q
+		parts = append(parts, q.within(k))
+	}
+	switch len(parts) {
+	case 0:
+		return Query{}
+	case 1:
+		return only
+	}
+	op := " and "
+	if k == kindOr {
+		op = " or "
+	}
+	return Query{text: strings.Join(parts, op), kind: k}
+}
+
+// within returns q as written within a query of kind parent: in parentheses
+// unless its terms cannot be mistaken for those of parent. A query of
+// either boolean operator is grouped within the other, even though "and"
+// binds tighter, so that it reads the same to people.
+func (q Query) within(parent kind) string {
+	switch q.kind {
+	case kindTerm, kindNot:
+		return q.text
+	case kindAnd, kindOr:
+		if q.kind == parent {
+			return q.text
+		}
+	}
+	return "(" + q.text + ")"
+}
+
+// Field is the name of a field, such as host.services.port, or of a field
+// relative to a nested query, such as port.
+type Field string
+
+// Eq returns the term that matches the assets whose field equals v, as in
+// field="value".
+func (f Field) Eq(v any) Query {
+	return f.term("=", Value(v))
+}
+
+// Match returns the term that matches the assets whose field contains v, as
+// in field: "value".
+func (f Field) Match(v any) Query {
+	return f.term(": ", Value(v))
+}
+
+// Gte returns the term that matches the assets whose field is at least v, as
+// in field >= "2025-01-01T00:00:00Z".
+func (f Field) Gte(v any) Query {
+	return f.term(" >= ", Value(v))
+}
+
+// Lte returns the term that matches the assets whose field is at most v, as
+// in field <= 1024.
+func (f Field) Lte(v any) Query {
+	return f.term(" <= ", Value(v))
+}
+
+// Regex returns the term that matches the assets whose field matches the
+// regular expression pattern, as in field =~ "pattern".
+func (f Field) Regex(pattern string) Query {
+	return f.term(" =~ ", Quote(pattern))
+}
+
+// In returns the term that matches the assets whose field is any of values,
+// as in field: {22, 80}.
+func (f Field) In(values ...any) Query {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = Value(v)
+	}
+	return f.term(": ", "{"+strings.Join(items, ", ")+"}")
+}
+
+// Between returns the term that matches the assets whose field is between
+// low and high, inclusive, as in field: [1 to 1024]. A nil bound is open.
+func (f Field) Between(low, high any) Query {
+	bound := func(v any) string {
+		if v == nil {
+			return "*"
+		}
+		return Value(v)
+	}
+	return f.term(": ", fmt.Sprintf("[%s to %s]", bound(low), bound(high)))
+}
+
+// Has returns the nested query that matches the assets with an element of
+// the field that matches q, whose fields are named relative to the field, as
+// in host.services:(port=22 and protocol="SSH").
+func (f Field) Has(q Query) Query {
+	return f.term(":", "("+q.text+")")
+}
+
+func (f Field) term(op, value string) Query {
+	return Query{text: string(f) + op + value, kind: kindTerm}
+}
+
+// Value returns v as written in a query: numbers and booleans as they are,
+// and strings, and anything else by its string form, quoted.
+func Value(v any) string {
+	switch v := v.(type) {
+	case string:
+		return Quote(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case fmt.Stringer:
+		return Quote(v.String())
+	default:
+		return Quote(fmt.Sprint(v))
+	}
+}
+
+// Quote returns s as a quoted string of the query language.
+func Quote(s string) string {
+	return `"` + Escape(s) + `"`
+}
+
+// Escape escapes the backslashes and double quotes of s, for use within a
+// quoted string of the query language.
+func Escape(s string) string {
+	return escaper.Replace(s)
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
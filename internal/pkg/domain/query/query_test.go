@@ -0,0 +1,119 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/cenql"
+)
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		q    Query
+		want string
+	}{
+		{
+			name: "terms",
+			q:    Field("host.ip").Eq("10.0.0.1"),
+			want: `host.ip="10.0.0.1"`,
+		},
+		{
+			name: "numbers and booleans are not quoted",
+			q:    And(Field("web.port").Eq(443), Field("host.services.tls.has_cert").Eq(true)),
+			want: `web.port=443 and host.services.tls.has_cert=true`,
+		},
+		{
+			name: "stringers are quoted",
+			q:    Field("host.ip").Match(stringer("10.0.0.1")),
+			want: `host.ip: "10.0.0.1"`,
+		},
+		{
+			name: "regular expressions, sets, and ranges",
+			q: And(
+				Field("web.hostname").Regex(`^api\.`),
+				Field("host.services.port").In(22, "ssh"),
+				Field("host.services.port").Between(1, nil),
+			),
+			want: `web.hostname =~ "^api\\." and host.services.port: {22, "ssh"} and host.services.port: [1 to *]`,
+		},
+		{
+			name: "comparisons",
+			q:    And(Field("cert.added_at").Gte("2025-01-01T00:00:00Z"), Field("host.services.port").Lte(1024)),
+			want: `cert.added_at >= "2025-01-01T00:00:00Z" and host.services.port <= 1024`,
+		},
+		{
+			name: "nested queries",
+			q:    Field("host.services").Has(Field("port").Eq(22).And(Field("protocol").Eq("SSH"))),
+			want: `host.services:(port=22 and protocol="SSH")`,
+		},
+		{
+			name: "and within or is grouped",
+			q:    Or(And(Field("a").Eq(1), Field("b").Eq(2)), Field("c").Eq(3)),
+			want: `(a=1 and b=2) or c=3`,
+		},
+		{
+			name: "or within and is grouped",
+			q:    Field("a").Eq(1).And(Or(Field("b").Eq(2), Field("c").Eq(3))),
+			want: `a=1 and (b=2 or c=3)`,
+		},
+		{
+			name: "the same operator is flattened",
+			q:    And(And(Field("a").Eq(1), Field("b").Eq(2)), Field("c").Eq(3)),
+			want: `a=1 and b=2 and c=3`,
+		},
+		{
+			name: "raw queries are grouped when combined",
+			q:    Raw(" host.services.protocol=RDP or host.services.port=3389 ").And(Field("host.location.country").Eq("Germany")),
+			want: `(host.services.protocol=RDP or host.services.port=3389) and host.location.country="Germany"`,
+		},
+		{
+			name: "a raw query alone is kept as is",
+			q:    And(Raw("host.services.protocol=RDP"), Raw("")),
+			want: `host.services.protocol=RDP`,
+		},
+		{
+			name: "not",
+			q:    Not(Or(Field("a").Eq(1), Field("b").Eq(2))).And(Not(Field("c").Eq(3))),
+			want: `not (a=1 or b=2) and not c=3`,
+		},
+		{
+			name: "empty queries are left out",
+			q:    Or(Query{}, Field("a").Eq(1), Not(Query{})),
+			want: `a=1`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.q.String())
+			_, err := cenql.Parse(tc.q.String())
+			assert.NoError(t, err)
+		})
+	}
+	assert.True(t, And().IsZero())
+}
+
+func TestQuote(t *testing.T) {
+	for _, value := range []string{
+		`plain`,
+		`with "quotes"`,
+		`back\slash`,
+		`trailing\`,
+		`ünïcödé and	tab`,
+		`") or host.ip: "10.0.0.1`,
+	} {
+		t.Run(value, func(t *testing.T) {
+			node, err := cenql.Parse(Field("host.services.banner").Eq(value).String())
+			require.NoError(t, err)
+			term, ok := node.(*cenql.TermNode)
+			require.True(t, ok, "the value must stay within its term")
+			assert.Equal(t, value, term.Value.Text)
+		})
+	}
+}
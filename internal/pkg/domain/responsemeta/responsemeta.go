@@ -17,6 +17,10 @@ type ResponseMeta struct {
 	Headers    map[string]string
 	PageCount  uint64
 	RetryCount uint64
+	// Attempts is the number of HTTP attempts behind the response, retries
+	// included, or 0 if only the attempts of the last request are known
+	// (see TotalAttempts).
+	Attempts uint64
 }
 
 // NewResponseMeta constructs a ResponseMeta for printing or logging purposes.
@@ -0,0 +1,178 @@
+package responsemeta
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateLimitHeaders are the response headers that report the rate limit, in
+// order of precedence: the X-RateLimit-* convention, then the IETF draft
+// RateLimit-* fields.
+var rateLimitHeaders = []struct{ limit, remaining, reset string }{
+	{"x-ratelimit-limit", "x-ratelimit-remaining", "x-ratelimit-reset"},
+	{"ratelimit-limit", "ratelimit-remaining", "ratelimit-reset"},
+}
+
+// requestIDHeaders are the response headers that carry the ID the server gave
+// the request, in order of precedence.
+var requestIDHeaders = []string{"x-request-id", "request-id", "x-correlation-id"}
+
+// unixTimeThreshold is the smallest rate-limit reset taken as a Unix time
+// rather than a number of seconds.
+const unixTimeThreshold = 1_000_000_000
+
+// RateLimit is the rate-limit headroom reported by the response headers.
+type RateLimit struct {
+	Limit     int64 `json:"limit"`
+	Remaining int64 `json:"remaining"`
+	// Reset is the raw value of the reset header, if any: seconds until the
+	// window resets or a timestamp, depending on the server.
+	Reset string `json:"reset,omitempty"`
+}
+
+// ResetIn returns how long until the rate-limit window resets, if Reset is
+// a number of seconds or a Unix time.
+func (r RateLimit) ResetIn(now time.Time) (time.Duration, bool) {
+	n, err := parseHeaderInt(r.Reset)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	if n >= unixTimeThreshold {
+		return max(time.Unix(n, 0).Sub(now), 0), true
+	}
+	return time.Duration(n) * time.Second, true
+}
+
+// ResponseInfo is what the headers of a response tell about it: the ID of
+// the request, to quote when contacting support, and the rate limit.
+type ResponseInfo struct {
+	RequestID string
+	RateLimit *RateLimit
+}
+
+// ParseResponseHeaders returns the request ID and rate limit reported by
+// the headers of a response.
+func ParseResponseHeaders(header http.Header) ResponseInfo {
+	get := func(name string) string { return strings.Join(header.Values(name), ", ") }
+	info := ResponseInfo{RequestID: requestID(get)}
+	if rl, ok := rateLimit(get); ok {
+		info.RateLimit = &rl
+	}
+	return info
+}
+
+// Telemetry is the cost and latency of the requests behind a response, as
+// shown on the meta line and printed with --meta-json.
+type Telemetry struct {
+	Method    string `json:"method"`
+	URL       string `json:"url"`
+	Status    int    `json:"status"`
+	RequestID string `json:"request_id,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+	Pages     uint64 `json:"pages"`
+	Attempts  uint64 `json:"attempts"`
+	Retries   uint64 `json:"retries"`
+	// EstimatedCredits assumes one credit per page request; the API does not
+	// report the credits used by each response.
+	EstimatedCredits uint64            `json:"estimated_credits"`
+	RateLimit        *RateLimit        `json:"rate_limit,omitempty"`
+	Headers          map[string]string `json:"headers,omitempty"`
+}
+
+// Requests returns the number of requests behind the response: the pages
+// fetched, or one if the response was not paginated.
+func (m *ResponseMeta) Requests() uint64 {
+	return max(m.PageCount, 1)
+}
+
+// TotalAttempts returns the number of HTTP attempts behind the response,
+// retries included. Attempts is used if set, since it can count the retries
+// of every request and not only the last one.
+func (m *ResponseMeta) TotalAttempts() uint64 {
+	if m.Attempts > 0 {
+		return m.Attempts
+	}
+	return m.Requests() + m.RetryCount
+}
+
+// EstimatedCredits estimates the credits consumed, at one credit per page request.
+func (m *ResponseMeta) EstimatedCredits() uint64 {
+	return m.Requests()
+}
+
+// RateLimit returns the rate-limit headroom reported by the response
+// headers, if the response has them.
+func (m *ResponseMeta) RateLimit() (RateLimit, bool) {
+	return rateLimit(m.responseHeader)
+}
+
+// RequestID returns the ID the server gave the request, if the response
+// headers have it.
+func (m *ResponseMeta) RequestID() (string, bool) {
+	id := requestID(m.responseHeader)
+	return id, id != ""
+}
+
+// responseHeader returns the response header of the given lowercase name.
+func (m *ResponseMeta) responseHeader(name string) string {
+	for k, v := range m.Headers {
+		if header, ok := strings.CutPrefix(k, "res-"); ok && strings.EqualFold(header, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+func rateLimit(header func(name string) string) (RateLimit, bool) {
+	for _, names := range rateLimitHeaders {
+		limit, limitErr := parseHeaderInt(header(names.limit))
+		remaining, remainingErr := parseHeaderInt(header(names.remaining))
+		if limitErr != nil || remainingErr != nil {
+			continue
+		}
+		return RateLimit{Limit: limit, Remaining: remaining, Reset: header(names.reset)}, true
+	}
+	return RateLimit{}, false
+}
+
+func requestID(header func(name string) string) string {
+	for _, name := range requestIDHeaders {
+		if id := strings.TrimSpace(header(name)); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// Telemetry returns the telemetry of the response. Headers are only included
+// if withHeaders is set.
+func (m *ResponseMeta) Telemetry(withHeaders bool) Telemetry {
+	t := Telemetry{
+		Method:           m.Method,
+		URL:              m.URL,
+		Status:           m.Status,
+		LatencyMs:        m.Latency.Milliseconds(),
+		Pages:            m.PageCount,
+		Attempts:         m.TotalAttempts(),
+		Retries:          m.RetryCount,
+		EstimatedCredits: m.EstimatedCredits(),
+	}
+	if rl, ok := m.RateLimit(); ok {
+		t.RateLimit = &rl
+	}
+	t.RequestID, _ = m.RequestID()
+	if withHeaders {
+		t.Headers = m.Headers
+	}
+	return t
+}
+
+// parseHeaderInt parses the first value of a header, which may list several
+// (e.g. "100, 100;w=60").
+func parseHeaderInt(v string) (int64, error) {
+	v, _, _ = strings.Cut(v, ",")
+	v, _, _ = strings.Cut(v, ";")
+	return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+}
@@ -0,0 +1,124 @@
+package responsemeta
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseMeta_RateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers http.Header
+		want    RateLimit
+		wantOK  bool
+	}{
+		{
+			name:    "x-ratelimit headers",
+			headers: http.Header{"X-Ratelimit-Limit": {"100"}, "X-Ratelimit-Remaining": {"42"}, "X-Ratelimit-Reset": {"30"}},
+			want:    RateLimit{Limit: 100, Remaining: 42, Reset: "30"},
+			wantOK:  true,
+		},
+		{
+			name:    "ratelimit headers with policy",
+			headers: http.Header{"Ratelimit-Limit": {"10, 10;w=1"}, "Ratelimit-Remaining": {"9"}},
+			want:    RateLimit{Limit: 10, Remaining: 9},
+			wantOK:  true,
+		},
+		{
+			name:    "missing remaining",
+			headers: http.Header{"X-Ratelimit-Limit": {"100"}},
+		},
+		{
+			name: "no headers",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := NewResponseMeta(nil, &http.Response{StatusCode: 200, Header: tc.headers}, 0, 1)
+			got, ok := meta.RateLimit()
+			require.Equal(t, tc.wantOK, ok)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestResponseMeta_Telemetry(t *testing.T) {
+	res := &http.Response{StatusCode: 200, Header: http.Header{
+		"X-Ratelimit-Limit":     {"100"},
+		"X-Ratelimit-Remaining": {"99"},
+	}}
+	meta := NewResponseMeta(nil, res, 1500*time.Millisecond, 3)
+	meta.PageCount = 4
+
+	got := meta.Telemetry(false)
+	require.Equal(t, Telemetry{
+		Status:           200,
+		LatencyMs:        1500,
+		Pages:            4,
+		Attempts:         6,
+		Retries:          2,
+		EstimatedCredits: 4,
+		RateLimit:        &RateLimit{Limit: 100, Remaining: 99},
+	}, got)
+	require.NotEmpty(t, meta.Telemetry(true).Headers)
+
+	// attempts counted across every request take precedence
+	meta.Attempts = 9
+	require.Equal(t, uint64(9), meta.TotalAttempts())
+
+	// a single, unpaginated request
+	single := NewResponseMeta(nil, &http.Response{StatusCode: 200}, 0, 1)
+	require.Equal(t, uint64(1), single.TotalAttempts())
+	require.Equal(t, uint64(1), single.EstimatedCredits())
+}
+
+func TestResponseMeta_RequestID(t *testing.T) {
+	meta := NewResponseMeta(nil, &http.Response{StatusCode: 200, Header: http.Header{"X-Request-Id": {"req-123"}}}, 0, 1)
+	id, ok := meta.RequestID()
+	require.True(t, ok)
+	require.Equal(t, "req-123", id)
+	require.Equal(t, "req-123", meta.Telemetry(false).RequestID)
+
+	_, ok = NewResponseMeta(nil, &http.Response{StatusCode: 200}, 0, 1).RequestID()
+	require.False(t, ok)
+}
+
+func TestRateLimit_ResetIn(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	tests := []struct {
+		reset  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{reset: "30", want: 30 * time.Second, wantOK: true},
+		{reset: "1800000045", want: 45 * time.Second, wantOK: true},
+		{reset: "1799999990", want: 0, wantOK: true},
+		{reset: "Thu, 01 Jan 2026 00:00:00 GMT"},
+		{reset: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.reset, func(t *testing.T) {
+			got, ok := RateLimit{Reset: tc.reset}.ResetIn(now)
+			require.Equal(t, tc.wantOK, ok)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseResponseHeaders(t *testing.T) {
+	info := ParseResponseHeaders(http.Header{
+		"Request-Id":            {"req-456"},
+		"X-Ratelimit-Limit":     {"100"},
+		"X-Ratelimit-Remaining": {"0"},
+		"X-Ratelimit-Reset":     {"12"},
+	})
+	require.Equal(t, ResponseInfo{
+		RequestID: "req-456",
+		RateLimit: &RateLimit{Limit: 100, Remaining: 0, Reset: "12"},
+	}, info)
+
+	require.Equal(t, ResponseInfo{}, ParseResponseHeaders(http.Header{}))
+}
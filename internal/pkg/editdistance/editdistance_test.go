@@ -0,0 +1,27 @@
+package editdistance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"search", "search", 0},
+		{"serach", "search", 2},
+		{"host.ip", "host.ips", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tc := range tests {
+		t.Run(tc.a+"/"+tc.b, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Levenshtein(tc.a, tc.b))
+		})
+	}
+}
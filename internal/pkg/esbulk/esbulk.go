@@ -0,0 +1,72 @@
+// Package esbulk writes assets in the NDJSON format of the Elasticsearch and
+// OpenSearch _bulk API: an action line naming the index and document ID,
+// followed by the document, for each asset.
+package esbulk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// FormatName is the name of the format, as given to --format.
+const FormatName = "es-bulk"
+
+// TypePlaceholder is replaced with the asset type in index names.
+const TypePlaceholder = "{type}"
+
+// DefaultIndex is the index documents are written to when none is given.
+const DefaultIndex = "censys-" + TypePlaceholder
+
+// Options configures the output.
+type Options struct {
+	// Index is the index of each document. TypePlaceholder is replaced with the
+	// type of the asset (host, certificate, or webproperty). Defaults to DefaultIndex.
+	Index string
+}
+
+type action struct {
+	Index actionMeta `json:"index"`
+}
+
+type actionMeta struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id,omitempty"`
+}
+
+// Write writes an index action and document for each asset to w, and returns
+// the number of documents written. Indexing a document with an existing ID
+// replaces it, so the same asset can be exported repeatedly.
+func Write(w io.Writer, items []assets.Asset, opts Options) (int, error) {
+	index := opts.Index
+	if index == "" {
+		index = DefaultIndex
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	written := 0
+	for _, item := range items {
+		meta := actionMeta{
+			Index: strings.ReplaceAll(index, TypePlaceholder, item.AssetType().String()),
+			ID:    DocumentID(item),
+		}
+		if err := enc.Encode(action{Index: meta}); err != nil {
+			return written, fmt.Errorf("failed to write bulk action: %w", err)
+		}
+		if err := enc.Encode(item); err != nil {
+			return written, fmt.Errorf("failed to write document %s: %w", meta.ID, err)
+		}
+		written++
+	}
+	return written, nil
+}
+
+// DocumentID returns the document ID of an asset: its identifier (see
+// assets.Identifier). It is empty if the asset has no identifier, and the
+// index assigns one.
+func DocumentID(item assets.Asset) string {
+	return assets.Identifier(item)
+}
@@ -0,0 +1,73 @@
+package esbulk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestWrite(t *testing.T) {
+	host := assets.NewHost(components.Host{IP: ptr("1.1.1.1")})
+	cert := assets.NewCertificate(components.Certificate{FingerprintSha256: ptr("abc123")})
+	webProperty := assets.NewWebProperty(components.Webproperty{Hostname: ptr("example.com"), Port: ptr(443)})
+	items := []assets.Asset{&host, &cert, &webProperty}
+
+	testCases := []struct {
+		name    string
+		opts    Options
+		actions []string
+	}{
+		{
+			name: "default index",
+			actions: []string{
+				`{"index":{"_index":"censys-host","_id":"1.1.1.1"}}`,
+				`{"index":{"_index":"censys-certificate","_id":"abc123"}}`,
+				`{"index":{"_index":"censys-webproperty","_id":"example.com:443"}}`,
+			},
+		},
+		{
+			name: "fixed index",
+			opts: Options{Index: "scans"},
+			actions: []string{
+				`{"index":{"_index":"scans","_id":"1.1.1.1"}}`,
+				`{"index":{"_index":"scans","_id":"abc123"}}`,
+				`{"index":{"_index":"scans","_id":"example.com:443"}}`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := Write(&buf, items, tc.opts)
+			require.NoError(t, err)
+			require.Equal(t, 3, n)
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			require.Len(t, lines, 6)
+			for i, action := range tc.actions {
+				require.JSONEq(t, action, lines[2*i])
+			}
+			require.JSONEq(t, `{"ip":"1.1.1.1"}`, lines[1])
+			require.JSONEq(t, `{"fingerprint_sha256":"abc123"}`, lines[3])
+			require.JSONEq(t, `{"hostname":"example.com","port":443}`, lines[5])
+		})
+	}
+}
+
+func TestDocumentID_MissingIdentifier(t *testing.T) {
+	webProperty := assets.NewWebProperty(components.Webproperty{Hostname: ptr("example.com")})
+	require.Empty(t, DocumentID(&webProperty))
+
+	var buf bytes.Buffer
+	_, err := Write(&buf, []assets.Asset{&webProperty}, Options{})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(buf.String(), `{"index":{"_index":"censys-webproperty"}}`+"\n"))
+}
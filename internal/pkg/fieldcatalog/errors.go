@@ -0,0 +1,27 @@
+package fieldcatalog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnknownFieldError is returned by Expand for a field or pattern that
+// matches no field of the catalog.
+type UnknownFieldError struct {
+	Field string
+	// Reason is why the field is invalid, if it is not simply unknown.
+	Reason string
+	// Suggestions are the closest fields, for a likely typo.
+	Suggestions []string
+}
+
+func (e *UnknownFieldError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("invalid field %q: %s", e.Field, e.Reason)
+	}
+	msg := fmt.Sprintf("unknown field %q", e.Field)
+	if len(e.Suggestions) > 0 {
+		msg += fmt.Sprintf("; did you mean %s?", strings.Join(e.Suggestions, ", "))
+	}
+	return msg
+}
@@ -0,0 +1,226 @@
+// Package fieldcatalog lists the fields of the assets of the Platform API,
+// so that field names can be checked before a request is sent. The catalog
+// is built from the asset models of the SDK, and so matches the version of
+// the API the CLI is built against.
+package fieldcatalog
+
+import (
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/pkg/editdistance"
+)
+
+// assetModels are the models of the assets, by the first segment of their
+// fields.
+var assetModels = []struct {
+	prefix string
+	model  reflect.Type
+}{
+	{prefix: "host", model: reflect.TypeFor[components.Host]()},
+	{prefix: "cert", model: reflect.TypeFor[components.Certificate]()},
+	{prefix: "web", model: reflect.TypeFor[components.Webproperty]()},
+}
+
+// maxSuggestions is the number of close matches Suggest returns at most.
+const maxSuggestions = 3
+
+// Catalog is a set of field names, such as host.services.port.
+type Catalog struct {
+	// names are the fields, sorted.
+	names []string
+	// open are the fields whose subfields are not listed, such as maps of
+	// labels: any subfield of them is accepted.
+	open map[string]bool
+	set  map[string]bool
+}
+
+// Default returns the catalog of the fields of hosts, certificates, and web
+// properties.
+var Default = sync.OnceValue(func() *Catalog {
+	c := &Catalog{open: map[string]bool{}, set: map[string]bool{}}
+	for _, asset := range assetModels {
+		c.walk(asset.model, asset.prefix, map[reflect.Type]bool{})
+	}
+	for name := range c.set {
+		c.names = append(c.names, name)
+	}
+	sort.Strings(c.names)
+	return c
+})
+
+// walk adds the field name of type t and its subfields. seen holds the
+// types of the enclosing fields, to stop at recursive types.
+func (c *Catalog) walk(t reflect.Type, name string, seen map[reflect.Type]bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	c.set[name] = true
+	switch t.Kind() {
+	case reflect.Map, reflect.Interface:
+		c.open[name] = true
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+	if seen[t] {
+		c.open[name] = true
+		return
+	}
+	seen[t] = true
+	defer delete(seen, t)
+	subfields := 0
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || tag == "" || tag == "-" {
+			continue
+		}
+		subfields++
+		c.walk(f.Type, name+"."+tag, seen)
+	}
+	// a struct without fields, such as a protocol the SDK does not model yet
+	if subfields == 0 && t.PkgPath() == reflect.TypeFor[components.Host]().PkgPath() {
+		c.open[name] = true
+	}
+}
+
+// Fields returns the fields of the catalog, sorted.
+func (c *Catalog) Fields() []string {
+	return slices.Clone(c.names)
+}
+
+// Contains reports whether name is a field of the catalog, or a subfield
+// of an open field.
+func (c *Catalog) Contains(name string) bool {
+	if c.set[name] {
+		return true
+	}
+	for parent := name; ; {
+		i := strings.LastIndexByte(parent, '.')
+		if i < 0 {
+			return false
+		}
+		parent = parent[:i]
+		if c.open[parent] {
+			return true
+		}
+	}
+}
+
+// Expand returns the fields that match patterns, in order and without
+// duplicates. In a pattern, *, ?, and [...] match within a segment, as in
+// path.Match, so host.services.tls.* names each field of
+// host.services.tls. A pattern that does not start with an asset type,
+// such as services.port, is matched under each asset type. It returns an
+// *UnknownFieldError for the first pattern that matches no field.
+func (c *Catalog) Expand(patterns []string) ([]string, error) {
+	var fields []string
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		matched, err := c.match(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range matched {
+			if !seen[f] {
+				seen[f] = true
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields, nil
+}
+
+// match returns the fields that match pattern.
+func (c *Catalog) match(pattern string) ([]string, error) {
+	var matched []string
+	for _, candidate := range qualify(pattern) {
+		if !isGlob(candidate) {
+			if c.Contains(candidate) {
+				matched = append(matched, candidate)
+			}
+			continue
+		}
+		glob := strings.ReplaceAll(candidate, ".", "/")
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, &UnknownFieldError{Field: pattern, Reason: "it is not a valid pattern"}
+		}
+		for _, name := range c.names {
+			if ok, _ := path.Match(glob, strings.ReplaceAll(name, ".", "/")); ok {
+				matched = append(matched, name)
+			}
+		}
+	}
+	if len(matched) == 0 {
+		err := &UnknownFieldError{Field: pattern}
+		if !isGlob(pattern) {
+			err.Suggestions = c.Suggest(pattern)
+		}
+		return nil, err
+	}
+	return matched, nil
+}
+
+// Suggest returns the fields closest to name, closest first, if any are
+// close enough to be a likely typo.
+func (c *Catalog) Suggest(name string) []string {
+	type scored struct {
+		name     string
+		distance int
+	}
+	candidates := qualify(name)
+	limit := len(name)/3 + 1
+	var near []scored
+	for _, field := range c.names {
+		best := -1
+		for _, candidate := range candidates {
+			// fields of very different lengths cannot be close
+			if diff := len(field) - len(candidate); diff > limit || -diff > limit {
+				continue
+			}
+			if d := editdistance.Levenshtein(candidate, field); d <= limit && (best < 0 || d < best) {
+				best = d
+			}
+		}
+		if best >= 0 {
+			near = append(near, scored{name: field, distance: best})
+		}
+	}
+	sort.SliceStable(near, func(i, j int) bool { return near[i].distance < near[j].distance })
+	suggestions := make([]string, 0, maxSuggestions)
+	for _, s := range near {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, s.name)
+	}
+	return suggestions
+}
+
+// qualify returns name if it starts with an asset type, and name under
+// each asset type otherwise.
+func qualify(name string) []string {
+	first, _, _ := strings.Cut(name, ".")
+	for _, asset := range assetModels {
+		if first == asset.prefix {
+			return []string{name}
+		}
+	}
+	qualified := make([]string, len(assetModels))
+	for i, asset := range assetModels {
+		qualified[i] = asset.prefix + "." + name
+	}
+	return qualified
+}
+
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
@@ -0,0 +1,103 @@
+package fieldcatalog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	c := Default()
+	for _, field := range []string{
+		"host.ip",
+		"host.services.port",
+		"host.services.tls.version_selected",
+		"host.location.country",
+		"cert.parsed.subject_dn",
+		"web.hostname",
+		"web.endpoints.http.body",
+	} {
+		assert.True(t, c.Contains(field), field)
+	}
+	assert.False(t, c.Contains("host.services.prot"))
+	assert.False(t, c.Contains("services.port"), "fields start with an asset type")
+	assert.True(t, c.Contains("host.services.postgres.startup_error.anything"), "any subfield of a map is accepted")
+	assert.IsIncreasing(t, c.Fields())
+}
+
+func TestExpand(t *testing.T) {
+	c := Default()
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+		contains []string
+	}{
+		{
+			name:     "fields are kept in order",
+			patterns: []string{"host.location.country", "host.ip", "host.ip"},
+			want:     []string{"host.location.country", "host.ip"},
+		},
+		{
+			name:     "wildcards match within a segment",
+			patterns: []string{"host.services.tls.*"},
+			contains: []string{"host.services.tls.version_selected", "host.services.tls.ja3s"},
+		},
+		{
+			name:     "unqualified fields are matched under each asset type",
+			patterns: []string{"services.port"},
+			want:     []string{"host.services.port"},
+		},
+		{
+			name:     "unqualified wildcards",
+			patterns: []string{"location.c?untry"},
+			want:     []string{"host.location.country"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := c.Expand(tc.patterns)
+			require.NoError(t, err)
+			if tc.want != nil {
+				assert.Equal(t, tc.want, got)
+			}
+			assert.Subset(t, got, tc.contains)
+		})
+	}
+
+	t.Run("* does not cross segments", func(t *testing.T) {
+		got, err := c.Expand([]string{"host.services.tls.*"})
+		require.NoError(t, err)
+		for _, f := range got {
+			assert.NotContains(t, strings.TrimPrefix(f, "host.services.tls."), ".")
+		}
+	})
+}
+
+func TestExpand_Unknown(t *testing.T) {
+	c := Default()
+
+	_, err := c.Expand([]string{"host.ip", "host.locaton.country"})
+	var unknown *UnknownFieldError
+	require.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "host.locaton.country", unknown.Field)
+	require.NotEmpty(t, unknown.Suggestions)
+	assert.Equal(t, "host.location.country", unknown.Suggestions[0])
+	assert.Contains(t, err.Error(), `unknown field "host.locaton.country"; did you mean host.location.country`)
+
+	_, err = c.Expand([]string{"services.tls.nope*"})
+	require.ErrorAs(t, err, &unknown)
+	assert.Equal(t, `unknown field "services.tls.nope*"`, err.Error())
+
+	_, err = c.Expand([]string{"host.services.[port"})
+	require.ErrorAs(t, err, &unknown)
+	assert.Equal(t, `invalid field "host.services.[port": it is not a valid pattern`, err.Error())
+}
+
+func TestSuggest(t *testing.T) {
+	assert.Equal(t, "host.services.port", Default().Suggest("services.prot")[0])
+	assert.Empty(t, Default().Suggest("nothing.like.it"))
+}
@@ -20,10 +20,10 @@ type FileFlag interface {
 	Value() (string, cenclierrors.CencliError)
 	// IsSet returns true if the flag is set.
 	IsSet() bool
-	// Lines returns the lines of the file.
+	// Lines returns the lines of the file, read with opts.
 	// Takes in a cobra command in case it needs to access its stdin reader.
 	// The command is not used if the flag value is a real file.
-	Lines(*cobra.Command) ([]string, cenclierrors.CencliError)
+	Lines(cmd *cobra.Command, opts ...input.ReaderOption) ([]string, cenclierrors.CencliError)
 }
 
 type fileFlag struct {
@@ -76,15 +76,15 @@ func (f *fileFlag) IsSet() bool {
 	return f.stringFlag.wasProvided()
 }
 
-func (f *fileFlag) Lines(cmd *cobra.Command) ([]string, cenclierrors.CencliError) {
+func (f *fileFlag) Lines(cmd *cobra.Command, opts ...input.ReaderOption) ([]string, cenclierrors.CencliError) {
 	value, err := f.Value()
 	if err != nil {
 		return nil, err
 	}
 	if value == input.StdInSentinel {
-		return input.ReadLinesFromStdin(cmd.InOrStdin())
+		return input.ReadLinesFromStdin(cmd.InOrStdin(), opts...)
 	}
-	return input.ReadLinesFromFile(value)
+	return input.ReadLinesFromFile(value, opts...)
 }
 
 type InvalidFileFlagError interface {
@@ -19,6 +19,7 @@ type StringFlag interface {
 
 type stringFlag struct {
 	name     string
+	aliases  []string
 	raw      *string
 	parent   *pflag.FlagSet
 	required bool
@@ -47,14 +48,30 @@ func NewStringFlag(flags *pflag.FlagSet, required bool, name, short, defaultValu
 }
 
 func (f *stringFlag) Value() (string, cenclierrors.CencliError) {
-	if (!f.parent.Changed(f.name) || *f.raw == "") && f.required {
+	if (!f.wasProvided() || *f.raw == "") && f.required {
 		return "", NewRequiredFlagNotSetError(f.name)
 	}
 	return *f.raw, nil
 }
 
+// AddAlias registers an additional flag name and optional shorthand that
+// share the same underlying value, e.g. "--output-file" for "--output".
+func (f *stringFlag) AddAlias(name string, short string, desc string) *stringFlag {
+	f.parent.StringVarP(f.raw, name, short, *f.raw, desc)
+	f.aliases = append(f.aliases, name)
+	return f
+}
+
 func (f *stringFlag) wasProvided() bool {
-	return f.parent.Changed(f.name)
+	if f.parent.Changed(f.name) {
+		return true
+	}
+	for _, alias := range f.aliases {
+		if f.parent.Changed(alias) {
+			return true
+		}
+	}
+	return false
 }
 
 func (f *stringFlag) trimSpace() {
@@ -83,6 +83,19 @@ func TestStringFlag(t *testing.T) {
 	}
 }
 
+func TestStringFlag_AddAlias(t *testing.T) {
+	cmd := &cobra.Command{}
+	flag := NewStringFlag(cmd.Flags(), true, flagName, flagShort, "", "A String Flag").
+		AddAlias("test-alias", "", "alias of --"+flagName)
+	cmd.SetArgs([]string{"--test-alias", "value"})
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		value, err := flag.Value()
+		require.Nil(t, err)
+		assert.Equal(t, "value", value)
+	}
+	require.NoError(t, cmd.Execute())
+}
+
 func TestBoolFlag(t *testing.T) {
 	tests := []struct {
 		name          string
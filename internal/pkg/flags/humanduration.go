@@ -107,3 +107,21 @@ func parseHumanDuration(input string) (time.Duration, error) {
 	}
 	return total, nil
 }
+
+// FormatHumanDuration formats d the way a HumanDurationFlag accepts it: in
+// days when it is a whole number of them, and otherwise without the zero
+// minutes and seconds time.Duration prints (2h rather than 2h0m0s).
+func FormatHumanDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d >= day && d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	s := d.String()
+	if d >= time.Minute && d%time.Minute == 0 {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if d >= time.Hour && d%time.Hour == 0 {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,36 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatHumanDuration(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{30 * 24 * time.Hour, "30d"},
+		{36 * time.Hour, "36h"},
+		{2 * time.Hour, "2h"},
+		{90 * time.Minute, "1h30m"},
+		{30 * time.Minute, "30m"},
+		{10 * time.Second, "10s"},
+		{90 * time.Second, "1m30s"},
+		{1500 * time.Millisecond, "1.5s"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expected, func(t *testing.T) {
+			got := FormatHumanDuration(tc.d)
+			assert.Equal(t, tc.expected, got)
+			if tc.d%time.Second == 0 {
+				parsed, err := parseHumanDuration(got)
+				require.NoError(t, err)
+				assert.Equal(t, tc.d, parsed)
+			}
+		})
+	}
+}
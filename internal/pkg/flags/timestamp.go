@@ -14,12 +14,13 @@ import (
 // TimestampFlag represents a timestamp-based command-line flag.
 type TimestampFlag interface {
 	// Value returns the current value of the flag, using the
-	// default timezone if no timezone can be inferred.
+	// default timezone if no timezone can be inferred, and resolving
+	// relative times (e.g. "3d ago") against now.
 	// If the flag is marked as required but not provided,
 	// it returns an error of type RequiredFlagNotSetError.
 	// If the flag has an invalid RFC3339 timestamp, it returns an error of type InvalidRFC3339TimestampFlagError.
 	// An optional value is returned to keep callers from having to use IsZero().
-	Value(defaultTZ datetime.TimeZone) (mo.Option[time.Time], cenclierrors.CencliError)
+	Value(defaultTZ datetime.TimeZone, now time.Time) (mo.Option[time.Time], cenclierrors.CencliError)
 	// AddAlias registers an additional flag name and optional shorthand that
 	// map to the same underlying value. Useful for supporting synonyms like
 	// "--at" and "-a" for an "--at-time" flag.
@@ -51,7 +52,7 @@ func NewTimestampFlag(flags *pflag.FlagSet, required bool, name string, short st
 	}
 }
 
-func (f *timestampFlag) Value(defaultTZ datetime.TimeZone) (mo.Option[time.Time], cenclierrors.CencliError) {
+func (f *timestampFlag) Value(defaultTZ datetime.TimeZone, now time.Time) (mo.Option[time.Time], cenclierrors.CencliError) {
 	f.trimSpace()
 	strValue, err := f.stringFlag.Value()
 	if err != nil {
@@ -60,7 +61,7 @@ func (f *timestampFlag) Value(defaultTZ datetime.TimeZone) (mo.Option[time.Time]
 	if !f.wasProvided() {
 		return f.defaultValue, nil
 	}
-	timestamp, parseErr := datetime.Parse(strValue, defaultTZ)
+	timestamp, parseErr := datetime.ParseAt(strValue, defaultTZ, now)
 	if parseErr != nil {
 		return mo.None[time.Time](), NewInvalidTimestampFlagError(f.stringFlag.name, strValue)
 	}
@@ -176,7 +176,7 @@ func TestRFC3339TimestampFlag(t *testing.T) {
 			flag := NewTimestampFlag(cmd.Flags(), tc.required, timestampFlagName, timestampFlagShort, tc.defaultValue, "A RFC3339 timestamp flag")
 			cmd.SetArgs(tc.args)
 			cmd.Run = func(cmd *cobra.Command, args []string) {
-				value, err := flag.Value(tc.defaultTZ)
+				value, err := flag.Value(tc.defaultTZ, time.Now())
 				if tc.expectError {
 					assert.Error(t, err)
 					if tc.expectedError != nil {
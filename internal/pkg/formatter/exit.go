@@ -6,9 +6,15 @@ import (
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
 )
 
+// ExitInterruptedPartial is the exit code of a command that was interrupted
+// after printing the results it had gathered, so that scripts can tell them
+// apart from complete results.
+const ExitInterruptedPartial = 3
+
 // ExitCode maps an error to a conventional CLI exit code.
 // 0: success
 // 2: usage/config/input error (print usage)
+// 3: interrupted after partial results were printed
 // 124: timeout
 // 130: interrupted (canceled)
 // 1: general error
@@ -18,6 +24,9 @@ func ExitCode(err error) int {
 	}
 	// Check for interruption first (context.Canceled or ErrInterrupted)
 	if cenclierrors.IsInterrupted(err) {
+		if cenclierrors.IsPartial(err) {
+			return ExitInterruptedPartial
+		}
 		return 130
 	}
 	// Context-derived errors
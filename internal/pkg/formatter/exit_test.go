@@ -34,6 +34,8 @@ func TestExitCode_Table(t *testing.T) {
 		{"nil", nil, 0},
 		{"deadline", context.DeadlineExceeded, 124},
 		{"canceled", context.Canceled, 130},
+		{"interrupted with partial results", cenclierrors.ToPartialError(cenclierrors.NewInterruptedError()), 3},
+		{"partial", cenclierrors.ToPartialError(generalErr{"page 2"}), 1},
 		{"usage", usageErr{"bad args"}, 2},
 		{"general", generalErr{"boom"}, 1},
 		{"wrapped cencli", cenclierrors.NewCencliError(errors.New("oops")), 1},
@@ -5,13 +5,15 @@ import (
 	"net/http"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/censys/cencli/internal/pkg/domain/responsemeta"
 	"github.com/censys/cencli/internal/pkg/styles"
 )
 
 // PrintAppResponseMeta renders application-level response metadata without leaking http.Request/Response.
-// When verbose is true, sanitized headers are printed for debugging purposes, as well as the request URL.
+// When verbose is true, sanitized headers are printed for debugging purposes, as well as the request URL,
+// the request ID, and when the rate limit resets.
 func PrintAppResponseMeta(st *styles.Styles, meta *responsemeta.ResponseMeta, verbose bool, colored bool) {
 	if !colored {
 		restore := styles.TemporarilyDisableStyles()
@@ -42,7 +44,26 @@ func PrintAppResponseMeta(st *styles.Styles, meta *responsemeta.ResponseMeta, ve
 	}
 
 	if meta.RetryCount > 0 {
-		statusLine += " - " + st.Secondary.Render(fmt.Sprintf("retries: %d", meta.RetryCount))
+		statusLine += " - " + st.Secondary.Render(fmt.Sprintf("retries: %d (attempts: %d)", meta.RetryCount, meta.TotalAttempts()))
+	}
+
+	statusLine += " - " + st.Secondary.Render(fmt.Sprintf("credits: ~%d", meta.EstimatedCredits()))
+
+	if rl, ok := meta.RateLimit(); ok {
+		rateStyle := st.Secondary
+		// warn when less than a tenth of the limit is left
+		if rl.Limit > 0 && rl.Remaining*10 < rl.Limit {
+			rateStyle = st.Warning
+		}
+		rateText := fmt.Sprintf("rate limit: %d/%d left", rl.Remaining, rl.Limit)
+		if reset, ok := rl.ResetIn(time.Now()); ok && verbose {
+			rateText += fmt.Sprintf(", resets in %s", reset.Round(time.Second))
+		}
+		statusLine += " - " + rateStyle.Render(rateText)
+	}
+
+	if id, ok := meta.RequestID(); ok && verbose {
+		statusLine += " - " + st.Secondary.Render("request id: "+id)
 	}
 	output.WriteString(statusLine)
 	output.WriteString("\n")
@@ -63,3 +84,10 @@ func PrintAppResponseMeta(st *styles.Styles, meta *responsemeta.ResponseMeta, ve
 
 	fmt.Fprint(Stderr, output.String())
 }
+
+// PrintAppResponseMetaJSON prints the telemetry of the response as a single
+// line of JSON on stderr, for scripts. Sanitized headers are included when
+// verbose is true.
+func PrintAppResponseMetaJSON(meta *responsemeta.ResponseMeta, verbose bool) error {
+	return writeJSON(Stderr, meta.Telemetry(verbose), false, false)
+}
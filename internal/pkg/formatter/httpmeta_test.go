@@ -50,3 +50,70 @@ func TestPrintAppResponseMeta_SanitizesHeaders(t *testing.T) {
 		t.Fatalf("expected non-sensitive header present, got: %s", out)
 	}
 }
+
+func TestPrintAppResponseMeta_Telemetry(t *testing.T) {
+	var buf bytes.Buffer
+	Stderr = &buf
+	res := &http.Response{StatusCode: 200, Header: http.Header{
+		"X-Ratelimit-Limit":     []string{"100"},
+		"X-Ratelimit-Remaining": []string{"5"},
+	}}
+	meta := responsemeta.NewResponseMeta(nil, res, 0, 2)
+	meta.PageCount = 3
+	PrintAppResponseMeta(styles.GlobalStyles, meta, false, false)
+	out := buf.String()
+	for _, want := range []string{"pages: 3", "retries: 1 (attempts: 4)", "credits: ~3", "rate limit: 5/100 left"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in status line, got: %s", want, out)
+		}
+	}
+}
+
+func TestPrintAppResponseMetaJSON(t *testing.T) {
+	var buf bytes.Buffer
+	Stderr = &buf
+	req := &http.Request{Method: "GET", URL: &url.URL{Scheme: "https", Host: "api.censys.io", Path: "/v1"}}
+	res := &http.Response{StatusCode: 200, Header: http.Header{"X-Request-Id": []string{"abc"}}}
+	meta := responsemeta.NewResponseMeta(req, res, 0, 1)
+	if err := PrintAppResponseMetaJSON(meta, false); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Count(out, "\n") != 1 || !strings.HasSuffix(out, "\n") {
+		t.Fatalf("expected a single JSON line, got: %s", out)
+	}
+	for _, want := range []string{`"status":200`, `"attempts":1`, `"estimated_credits":1`, `"url":"https://api.censys.io/v1"`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %s in JSON, got: %s", want, out)
+		}
+	}
+	if strings.Contains(out, "headers") {
+		t.Fatalf("expected no headers without verbose, got: %s", out)
+	}
+}
+
+func TestPrintAppResponseMeta_RequestID(t *testing.T) {
+	var buf bytes.Buffer
+	Stderr = &buf
+	res := &http.Response{StatusCode: 200, Header: http.Header{
+		"X-Request-Id":          []string{"req-123"},
+		"X-Ratelimit-Limit":     []string{"100"},
+		"X-Ratelimit-Remaining": []string{"5"},
+		"X-Ratelimit-Reset":     []string{"30"},
+	}}
+	meta := responsemeta.NewResponseMeta(nil, res, 0, 1)
+
+	PrintAppResponseMeta(styles.GlobalStyles, meta, false, false)
+	if out := buf.String(); strings.Contains(out, "req-123") || strings.Contains(out, "resets in") {
+		t.Fatalf("expected no request id or reset without verbose, got: %s", out)
+	}
+
+	buf.Reset()
+	PrintAppResponseMeta(styles.GlobalStyles, meta, true, false)
+	out := buf.String()
+	for _, want := range []string{"rate limit: 5/100 left, resets in 30s", "request id: req-123"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in verbose status line, got: %s", want, out)
+		}
+	}
+}
@@ -1,52 +1,73 @@
 package formatter
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"strings"
 
+	"github.com/censys/cencli/internal/pkg/canonical"
 	"github.com/censys/cencli/internal/pkg/styles"
 	jsoncolor "github.com/neilotoole/jsoncolor"
 )
 
+// stable writes JSON and YAML in canonical form. It is set from the
+// --stable flag.
+var stable bool
+
+// SetStable sets whether JSON and YAML output is written in canonical form,
+// with the keys of objects and the lists of identified objects sorted, so
+// that the output of two runs can be diffed.
+func SetStable(enabled bool) { stable = enabled }
+
 // PrintJSON prints v as pretty JSON, optionally colored.
 // Uses the standard library for marshaling (to support omitzero),
 // then colorizes the output if requested.
 func PrintJSON(v any, colored bool) error {
-	return writeJSON(Stdout, v, colored, true)
+	return WriteJSON(Stdout, v, colored)
+}
+
+// WriteJSON writes v as pretty JSON to w, optionally colored, as PrintJSON
+// prints it.
+func WriteJSON(w io.Writer, v any, colored bool) error {
+	return writeJSON(w, v, colored, true)
 }
 
 // writeJSON writes v as JSON to w, optionally colored and pretty-printed.
 // Uses the standard library for marshaling (to support omitzero),
-// then colorizes the output if requested.
+// then colorizes the output token by token if requested.
 func writeJSON(w io.Writer, v any, colored, pretty bool) error {
-	var data []byte
-	var err error
-	if pretty {
-		data, err = json.MarshalIndent(v, "", "  ")
-	} else {
-		data, err = json.Marshal(v)
-	}
+	data, err := marshalJSON(v)
 	if err != nil {
 		return err
 	}
 
 	if colored {
-		var unmarshaled any
-		if err := json.Unmarshal(data, &unmarshaled); err != nil {
+		return colorizeJSON(w, data, jsonColors(), pretty)
+	}
+
+	if pretty {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
 			return err
 		}
-		enc := jsoncolor.NewEncoder(w)
-		enc.SetColors(jsonColors())
-		if pretty {
-			enc.SetIndent("", "  ")
-		}
-		return enc.Encode(unmarshaled)
+		data = buf.Bytes()
 	}
-
 	_, err = w.Write(append(data, '\n'))
 	return err
 }
 
+// marshalJSON marshals v as compact JSON, in canonical form with --stable.
+func marshalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || !stable {
+		return data, err
+	}
+	return canonical.JSON(data)
+}
+
 // jsonColors defines the color scheme for jsoncolor.
 // This attempts to map the domain color scheme to what JQ uses.
 func jsonColors() *jsoncolor.Colors {
@@ -71,3 +92,111 @@ func jsonColors() *jsoncolor.Colors {
 func WriteNDJSONItem(w io.Writer, item any, colored bool) error {
 	return writeJSON(w, item, colored, false)
 }
+
+// ansiReset ends a colored token.
+const ansiReset = "\x1b[0m"
+
+// jsonContainer is an object or array being colorized.
+type jsonContainer struct {
+	object bool
+	// count is the number of keys or items written so far
+	count int
+	// afterKey is set once a key is written, until its value starts
+	afterKey bool
+}
+
+// colorizeJSON writes the JSON document data to w, colored token by token,
+// and indented if pretty. The tokens are streamed from data rather than
+// decoded into maps first, so that large documents, such as hosts with
+// hundreds of services, do not need several times their size in memory. The
+// keys and items keep the order of data.
+func colorizeJSON(w io.Writer, data []byte, colors *jsoncolor.Colors, pretty bool) error {
+	// the document is written with a single write, as writers such as the
+	// one of --redact mask each write on its own
+	var bw bytes.Buffer
+	bw.Grow(len(data) * 2)
+	write := func(color jsoncolor.Color, text string) {
+		if len(color) == 0 {
+			bw.WriteString(text)
+			return
+		}
+		bw.Write(color)
+		bw.WriteString(text)
+		bw.WriteString(ansiReset)
+	}
+	newline := func(depth int) {
+		if pretty {
+			bw.WriteByte('\n')
+			bw.WriteString(strings.Repeat("  ", depth))
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var stack []*jsonContainer
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) && len(stack) > 0 {
+			return io.ErrUnexpectedEOF
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			closed := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if closed.count > 0 {
+				newline(len(stack))
+			}
+			write(colors.Punc, delim.String())
+			continue
+		}
+
+		// a key, or an item of an array, starts a new line within its
+		// container, while the value of a key follows it
+		if len(stack) > 0 {
+			parent := stack[len(stack)-1]
+			switch {
+			case parent.object && parent.afterKey:
+				parent.afterKey = false
+			default:
+				if parent.count > 0 {
+					write(colors.Punc, ",")
+				}
+				parent.count++
+				newline(len(stack))
+				if parent.object {
+					encoded, _ := json.Marshal(tok.(string))
+					write(colors.Key, string(encoded))
+					write(colors.Punc, ":")
+					if pretty {
+						bw.WriteByte(' ')
+					}
+					parent.afterKey = true
+					continue
+				}
+			}
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			write(colors.Punc, v.String())
+			stack = append(stack, &jsonContainer{object: v == '{'})
+		case string:
+			encoded, _ := json.Marshal(v)
+			write(colors.String, string(encoded))
+		case json.Number:
+			write(colors.Number, v.String())
+		case bool:
+			write(colors.Bool, fmt.Sprint(v))
+		case nil:
+			write(colors.Null, "null")
+		}
+	}
+	bw.WriteByte('\n')
+	_, err := w.Write(bw.Bytes())
+	return err
+}
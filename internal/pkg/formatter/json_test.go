@@ -2,10 +2,17 @@ package formatter
 
 import (
 	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
 	"testing"
 
+	jsoncolor "github.com/neilotoole/jsoncolor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/redact"
 )
 
 func TestPrintJSON(t *testing.T) {
@@ -84,3 +91,115 @@ func TestWriteNDJSONItem(t *testing.T) {
 		})
 	}
 }
+
+func TestColorizeJSON(t *testing.T) {
+	type service struct {
+		Port     int               `json:"port"`
+		Protocol string            `json:"protocol"`
+		Labels   []string          `json:"labels"`
+		Banner   *string           `json:"banner"`
+		TLS      bool              `json:"tls"`
+		Extra    map[string]string `json:"extra"`
+	}
+	input := map[string]any{
+		"ip":       "10.0.0.1",
+		"score":    9.5,
+		"services": []service{{Port: 443, Protocol: "HTTP <b>", Labels: []string{}, TLS: true, Extra: map[string]string{}}},
+		"empty":    []any{},
+	}
+	ansi := regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+	for _, pretty := range []bool{true, false} {
+		var plain, colored bytes.Buffer
+		require.NoError(t, writeJSON(&plain, input, false, pretty))
+		require.NoError(t, writeJSON(&colored, input, true, pretty))
+		assert.Equal(t, plain.String(), ansi.ReplaceAllString(colored.String(), ""),
+			"colored output is the plain output with colors (pretty=%v)", pretty)
+	}
+
+	var buf bytes.Buffer
+	colors := &jsoncolor.Colors{Key: jsoncolor.Color("<k>"), String: jsoncolor.Color("<s>")}
+	require.NoError(t, colorizeJSON(&buf, []byte(`{"b":"x","a":[1,null]}`), colors, false))
+	assert.Equal(t, "{<k>\"b\"\x1b[0m:<s>\"x\"\x1b[0m,<k>\"a\"\x1b[0m:[1,null]}\n", buf.String(),
+		"keys keep their order, and tokens without a color are written as is")
+
+	require.Error(t, colorizeJSON(&buf, []byte(`{"a":`), colors, true))
+}
+
+func TestColorizeJSON_Redacted(t *testing.T) {
+	ips := make([]any, 400)
+	for i := range ips {
+		ips[i] = fmt.Sprintf("203.0.%d.%d", 100+i/256, i%256)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, writeJSON(redact.NewWriter(&buf, redact.New("salt")), map[string]any{"hosts": ips}, true, true))
+	require.Greater(t, buf.Len(), 4096)
+	out := buf.String()
+	for _, ip := range ips {
+		assert.False(t, strings.Contains(out, ip.(string)+`"`), "%s is not masked", ip)
+	}
+}
+
+// largeHost returns a host document with services services, the size of
+// the documents that motivated streaming the output.
+func largeHost(services int) map[string]any {
+	list := make([]any, services)
+	for i := range list {
+		list[i] = map[string]any{
+			"port":       i + 1,
+			"protocol":   "HTTP",
+			"scan_time":  "2025-01-01T00:00:00Z",
+			"banner":     strings.Repeat("HTTP/1.1 200 OK\r\nServer: nginx\r\n", 8),
+			"software":   []any{map[string]any{"vendor": "nginx", "product": "nginx", "version": "1.25.3"}},
+			"labels":     []any{"LOGIN_PAGE", "WEB_UI"},
+			"tls":        map[string]any{"version_selected": "TLSv1_3", "cipher_selected": "TLS_AES_128_GCM_SHA256"},
+			"transport":  "TCP",
+			"extended":   map[string]any{"headers": map[string]any{"content-type": "text/html", "x-frame-options": "DENY"}},
+			"threat_ids": []any{},
+		}
+	}
+	return map[string]any{"ip": "10.0.0.1", "services": list, "location": map[string]any{"country": "Germany"}}
+}
+
+func BenchmarkWriteJSON_LargeHost(b *testing.B) {
+	host := largeHost(500)
+	for _, colored := range []bool{false, true} {
+		b.Run(fmt.Sprintf("colored=%v", colored), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if err := writeJSON(io.Discard, host, colored, true); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestPrintJSON_Stable(t *testing.T) {
+	type service struct {
+		Port     int    `json:"port"`
+		Protocol string `json:"protocol"`
+	}
+	type host struct {
+		IP       string    `json:"ip"`
+		Services []service `json:"services"`
+	}
+	hosts := []host{
+		{IP: "10.0.0.2", Services: []service{{Port: 443, Protocol: "HTTP"}, {Port: 22, Protocol: "SSH"}}},
+		{IP: "10.0.0.1"},
+	}
+
+	var buf bytes.Buffer
+	old := Stdout
+	Stdout = &buf
+	defer func() { Stdout = old }()
+	SetStable(true)
+	defer SetStable(false)
+
+	require.NoError(t, WriteNDJSONItem(&buf, hosts, false))
+	assert.Equal(t, `[{"ip":"10.0.0.1","services":null},{"ip":"10.0.0.2","services":[{"port":22,"protocol":"SSH"},{"port":443,"protocol":"HTTP"}]}]`+"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, PrintYAML(hosts[0], false))
+	assert.Equal(t, "ip: 10.0.0.2\nservices:\n    - port: 22\n      protocol: SSH\n    - port: 443\n      protocol: HTTP\n", buf.String())
+}
@@ -0,0 +1,143 @@
+package formatter
+
+import (
+	"sort"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/censys/cencli/internal/pkg/term"
+)
+
+// Ellipsis marks text that was truncated to fit a column.
+const Ellipsis = "…"
+
+// defaultMinColumnWidth is the narrowest a truncatable column is shrunk to,
+// unless the column sets its own minimum.
+const defaultMinColumnWidth = 8
+
+// wide disables table truncation. It is set from the --wide flag.
+var wide bool
+
+// SetWide sets whether tables are rendered at their full width instead of
+// being fitted to the terminal.
+func SetWide(enabled bool) { wide = enabled }
+
+// TableWidth returns the width tables should be fitted to, or 0 if tables
+// should not be constrained: when --wide is set or stdout is not a terminal,
+// so that piped output is never truncated.
+func TableWidth() int {
+	if wide || !StdoutIsTTY() {
+		return 0
+	}
+	return term.GetWidth()
+}
+
+// LayoutColumn describes a table column for Layout.
+type LayoutColumn struct {
+	// Width is the width the column needs to show all of its cells.
+	Width int
+	// MinWidth is the narrowest the column may be truncated to. Zero means
+	// the smaller of Width and the default minimum.
+	MinWidth int
+	// Priority orders columns by importance. When the table does not fit,
+	// columns with a lower priority are truncated first, then hidden.
+	Priority int
+	// NoTruncate keeps the column at its full width; it can only be hidden.
+	NoTruncate bool
+}
+
+func (c LayoutColumn) minWidth() int {
+	if c.NoTruncate {
+		return c.Width
+	}
+	m := c.MinWidth
+	if m <= 0 {
+		m = defaultMinColumnWidth
+	}
+	return min(m, c.Width)
+}
+
+// Layout fits columns into maxWidth, where sepWidth is the width of the
+// separator between adjacent columns. It returns the width of each column;
+// a width of 0 means the column is hidden. If maxWidth is 0 or the columns
+// already fit, every column keeps its full width.
+//
+// Columns are first truncated, lowest priority first, down to their minimum
+// width. If the table is still too wide, columns are hidden, lowest priority
+// first, until it fits. The highest priority column is never hidden.
+func Layout(columns []LayoutColumn, sepWidth, maxWidth int) []int {
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		widths[i] = c.Width
+	}
+	if maxWidth <= 0 || len(columns) == 0 {
+		return widths
+	}
+
+	// order columns from least to most important; ties go to the rightmost column
+	order := make([]int, len(columns))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ca, cb := columns[order[a]], columns[order[b]]
+		if ca.Priority != cb.Priority {
+			return ca.Priority < cb.Priority
+		}
+		return order[a] > order[b]
+	})
+
+	// hide no columns, then the least important one, and so on, until the
+	// remaining columns can be truncated to fit
+	for hidden := 0; hidden < len(order); hidden++ {
+		for i, c := range columns {
+			widths[i] = c.Width
+		}
+		for _, i := range order[:hidden] {
+			widths[i] = 0
+		}
+		for _, i := range order[hidden:] {
+			over := tableWidth(widths, sepWidth) - maxWidth
+			if over <= 0 {
+				break
+			}
+			widths[i] = max(widths[i]-over, columns[i].minWidth())
+		}
+		if tableWidth(widths, sepWidth) <= maxWidth {
+			return widths
+		}
+	}
+	// the most important column is shown even if it does not fit
+	last := order[len(order)-1]
+	if !columns[last].NoTruncate {
+		widths[last] = max(maxWidth, 1)
+	}
+	return widths
+}
+
+// tableWidth returns the total width of the visible columns and the separators between them.
+func tableWidth(widths []int, sepWidth int) int {
+	sum, visible := 0, 0
+	for _, w := range widths {
+		if w > 0 {
+			sum += w
+			visible++
+		}
+	}
+	if visible > 1 {
+		sum += sepWidth * (visible - 1)
+	}
+	return sum
+}
+
+// Truncate shortens s to at most width display cells, ending it with an
+// ellipsis if anything was removed.
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+	return runewidth.Truncate(s, width, Ellipsis)
+}
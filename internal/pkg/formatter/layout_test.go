@@ -0,0 +1,92 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		columns  []LayoutColumn
+		maxWidth int
+		expected []int
+	}{
+		{
+			name:     "unconstrained",
+			columns:  []LayoutColumn{{Width: 40}, {Width: 60}},
+			maxWidth: 0,
+			expected: []int{40, 60},
+		},
+		{
+			name:     "fits",
+			columns:  []LayoutColumn{{Width: 10}, {Width: 20}},
+			maxWidth: 33,
+			expected: []int{10, 20},
+		},
+		{
+			name:     "truncates lowest priority first",
+			columns:  []LayoutColumn{{Width: 20, Priority: 1}, {Width: 20}},
+			maxWidth: 33,
+			expected: []int{20, 10},
+		},
+		{
+			name:     "truncates rightmost column first on equal priority",
+			columns:  []LayoutColumn{{Width: 20}, {Width: 20}},
+			maxWidth: 33,
+			expected: []int{20, 10},
+		},
+		{
+			name:     "respects minimum width before truncating the next column",
+			columns:  []LayoutColumn{{Width: 20, Priority: 1}, {Width: 20, MinWidth: 15}},
+			maxWidth: 33,
+			expected: []int{15, 15},
+		},
+		{
+			name:     "never truncates no-truncate columns",
+			columns:  []LayoutColumn{{Width: 20}, {Width: 20, NoTruncate: true}},
+			maxWidth: 33,
+			expected: []int{10, 20},
+		},
+		{
+			name:     "hides lowest priority column when truncation is not enough",
+			columns:  []LayoutColumn{{Width: 30, Priority: 2, NoTruncate: true}, {Width: 30, Priority: 1}, {Width: 30}},
+			maxWidth: 45,
+			expected: []int{30, 12, 0},
+		},
+		{
+			name:     "truncates more important column once less important is at its minimum",
+			columns:  []LayoutColumn{{Width: 100, Priority: 1}, {Width: 30}},
+			maxWidth: 40,
+			expected: []int{29, 8},
+		},
+		{
+			name:     "most important column is truncated past its minimum when nothing else fits",
+			columns:  []LayoutColumn{{Width: 100, Priority: 1, MinWidth: 50}, {Width: 30}},
+			maxWidth: 40,
+			expected: []int{40, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Layout(tt.columns, 3, tt.maxWidth))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "hello", Truncate("hello", 5))
+	assert.Equal(t, "hel…", Truncate("hello", 4))
+	assert.Equal(t, "", Truncate("hello", 0))
+	assert.Equal(t, "日…", Truncate("日本語", 4))
+}
+
+func TestTableWidth(t *testing.T) {
+	// tests do not write to a terminal, so tables are never constrained
+	assert.Equal(t, 0, TableWidth())
+	SetWide(true)
+	defer SetWide(false)
+	assert.Equal(t, 0, TableWidth())
+}
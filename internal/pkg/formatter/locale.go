@@ -0,0 +1,179 @@
+package formatter
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/censys/cencli/internal/pkg/datetime"
+)
+
+// Locale is a language tag, such as en-US or de_DE.UTF-8, that selects how
+// numbers and timestamps are written in human-readable output. Data output
+// (json, yaml, ndjson) is never localized. The empty Locale is taken from the
+// environment (LC_ALL, then LANG).
+type Locale string
+
+var _ encoding.TextUnmarshaler = (*Locale)(nil)
+
+func (l *Locale) UnmarshalText(text []byte) error {
+	if _, err := lookupLocale(string(text)); err != nil {
+		return err
+	}
+	*l = Locale(text)
+	return nil
+}
+
+// localeFormat is how a locale writes numbers and timestamps.
+type localeFormat struct {
+	// group separates the thousands of numbers.
+	group string
+	// date is the layout of dates.
+	date string
+	// clock is the layout of times of day, without seconds.
+	clock string
+	// clockSeconds is the layout of times of day, with seconds.
+	clockSeconds string
+}
+
+const nbsp = "\u00a0"
+
+var (
+	clock24 = [2]string{"15:04", "15:04:05"}
+	clock12 = [2]string{"3:04 PM", "3:04:05 PM"}
+)
+
+func newLocaleFormat(group, date string, clock [2]string) localeFormat {
+	return localeFormat{group: group, date: date, clock: clock[0], clockSeconds: clock[1]}
+}
+
+// defaultLocaleFormat is used without a locale, or with the C or POSIX locale:
+// ISO dates, 24-hour times, and commas between thousands.
+var defaultLocaleFormat = newLocaleFormat(",", "2006-01-02", clock24)
+
+// localeFormats are the formats by language, and by language and region
+// where the region writes them differently.
+var localeFormats = map[string]localeFormat{
+	"en":    newLocaleFormat(",", "01/02/2006", clock12),
+	"en-AU": newLocaleFormat(",", "02/01/2006", clock12),
+	"en-CA": newLocaleFormat(",", "2006-01-02", clock12),
+	"en-GB": newLocaleFormat(",", "02/01/2006", clock24),
+	"en-IE": newLocaleFormat(",", "02/01/2006", clock24),
+	"en-IN": newLocaleFormat(",", "02/01/2006", clock12),
+	"en-NZ": newLocaleFormat(",", "02/01/2006", clock12),
+	"de":    newLocaleFormat(".", "02.01.2006", clock24),
+	"de-CH": newLocaleFormat("’", "02.01.2006", clock24),
+	"fr":    newLocaleFormat(nbsp, "02/01/2006", clock24),
+	"fr-CA": newLocaleFormat(nbsp, "2006-01-02", clock24),
+	"fr-CH": newLocaleFormat(nbsp, "02.01.2006", clock24),
+	"es":    newLocaleFormat(".", "02/01/2006", clock24),
+	"es-MX": newLocaleFormat(",", "02/01/2006", clock24),
+	"it":    newLocaleFormat(".", "02/01/2006", clock24),
+	"pt":    newLocaleFormat(nbsp, "02/01/2006", clock24),
+	"pt-BR": newLocaleFormat(".", "02/01/2006", clock24),
+	"nl":    newLocaleFormat(".", "02-01-2006", clock24),
+	"da":    newLocaleFormat(".", "02.01.2006", clock24),
+	"fi":    newLocaleFormat(nbsp, "02.01.2006", clock24),
+	"nb":    newLocaleFormat(nbsp, "02.01.2006", clock24),
+	"sv":    newLocaleFormat(nbsp, "2006-01-02", clock24),
+	"cs":    newLocaleFormat(nbsp, "02.01.2006", clock24),
+	"pl":    newLocaleFormat(nbsp, "02.01.2006", clock24),
+	"ru":    newLocaleFormat(nbsp, "02.01.2006", clock24),
+	"uk":    newLocaleFormat(nbsp, "02.01.2006", clock24),
+	"tr":    newLocaleFormat(".", "02.01.2006", clock24),
+	"ja":    newLocaleFormat(",", "2006/01/02", clock24),
+	"ko":    newLocaleFormat(",", "2006. 01. 02.", clock24),
+	"zh":    newLocaleFormat(",", "2006/01/02", clock24),
+}
+
+// locale is the format numbers and timestamps are written in.
+var locale = defaultLocaleFormat
+
+// SetLocale sets the locale numbers and timestamps are written in, or takes
+// it from the environment if l is empty. Unknown locales use the default
+// format. It is set from the locale config (or --locale) before a command runs.
+func SetLocale(l Locale) {
+	tag := string(l)
+	if tag == "" {
+		tag = environmentLocale()
+	}
+	format, err := lookupLocale(tag)
+	if err != nil {
+		format = defaultLocaleFormat
+	}
+	locale = format
+}
+
+// environmentLocale returns the locale of the environment, if any.
+func environmentLocale() string {
+	for _, name := range []string{"LC_ALL", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// lookupLocale returns the format of the locale tag, such as en-US, en_US, or
+// en_US.UTF-8: that of its language and region, or else of its language.
+func lookupLocale(tag string) (localeFormat, error) {
+	tag = strings.TrimSpace(tag)
+	// Drop the encoding and modifier of POSIX locales, e.g. .UTF-8 and @euro
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if tag == "" || tag == "C" || tag == "POSIX" {
+		return defaultLocaleFormat, nil
+	}
+	language, region, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	language = strings.ToLower(language)
+	if i := strings.IndexByte(region, '-'); i >= 0 {
+		region = region[:i]
+	}
+	if format, ok := localeFormats[language+"-"+strings.ToUpper(region)]; ok {
+		return format, nil
+	}
+	if format, ok := localeFormats[language]; ok {
+		return format, nil
+	}
+	return localeFormat{}, fmt.Errorf("unknown locale %q; use a language tag such as en-US or de-DE", tag)
+}
+
+// FormatInt writes n with the thousands separator of the locale.
+func FormatInt(n int64) string {
+	if n < 0 {
+		return "-" + FormatUint(uint64(-n))
+	}
+	return FormatUint(uint64(n))
+}
+
+// FormatUint writes n with the thousands separator of the locale.
+func FormatUint(n uint64) string {
+	s := strconv.FormatUint(n, 10)
+	if len(s) <= 3 {
+		return s
+	}
+	var sb strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			sb.WriteString(locale.group)
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// FormatDate writes the date of t in the display timezone (see
+// datetime.SetDisplayTimeZone), as the locale writes dates.
+func FormatDate(t time.Time) string {
+	return datetime.InDisplayTimeZone(t).Format(locale.date)
+}
+
+// FormatLongTime writes t to the second in the display timezone, with the
+// name of the timezone, as the locale writes dates and times.
+func FormatLongTime(t time.Time) string {
+	return datetime.InDisplayTimeZone(t).Format(locale.date + " " + locale.clockSeconds + " MST")
+}
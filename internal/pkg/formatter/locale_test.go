@@ -0,0 +1,70 @@
+package formatter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocale(t *testing.T) {
+	t.Cleanup(func() { locale = defaultLocaleFormat })
+	ts := time.Date(2025, time.March, 4, 17, 5, 9, 0, time.UTC)
+
+	tests := []struct {
+		locale    Locale
+		number    string
+		shortTime string
+		date      string
+	}{
+		{locale: "C", number: "-1,234,567", shortTime: "2025-03-04 17:05", date: "2025-03-04"},
+		{locale: "en-US", number: "-1,234,567", shortTime: "03/04/2025 5:05 PM", date: "03/04/2025"},
+		{locale: "en_GB.UTF-8", number: "-1,234,567", shortTime: "04/03/2025 17:05", date: "04/03/2025"},
+		{locale: "de-DE", number: "-1.234.567", shortTime: "04.03.2025 17:05", date: "04.03.2025"},
+		{locale: "de-CH", number: "-1’234’567", shortTime: "04.03.2025 17:05", date: "04.03.2025"},
+		{locale: "fr_FR@euro", number: "-1 234 567", shortTime: "04/03/2025 17:05", date: "04/03/2025"},
+		{locale: "pt-BR", number: "-1.234.567", shortTime: "04/03/2025 17:05", date: "04/03/2025"},
+		{locale: "ja-JP", number: "-1,234,567", shortTime: "2025/03/04 17:05", date: "2025/03/04"},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.locale), func(t *testing.T) {
+			var l Locale
+			require.NoError(t, l.UnmarshalText([]byte(tc.locale)))
+			SetLocale(l)
+			assert.Equal(t, tc.number, FormatInt(-1234567))
+			assert.Equal(t, "999", FormatUint(999))
+			assert.Equal(t, tc.shortTime, FormatShortTime(ts))
+			assert.Equal(t, tc.date, FormatDate(ts))
+		})
+	}
+
+	SetLocale("en-US")
+	assert.Equal(t, "03/04/2025 5:05:09 PM UTC", FormatLongTime(ts))
+}
+
+func TestLocale_Environment(t *testing.T) {
+	t.Cleanup(func() { locale = defaultLocaleFormat })
+
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+	SetLocale("")
+	assert.Equal(t, "1.000", FormatInt(1000))
+
+	t.Setenv("LC_ALL", "C.UTF-8")
+	SetLocale("")
+	assert.Equal(t, "1,000", FormatInt(1000))
+
+	// an explicit locale wins, and unknown ones fall back to the default
+	SetLocale("fr")
+	assert.Equal(t, "1 000", FormatInt(1000))
+	SetLocale("xx")
+	assert.Equal(t, "1,000", FormatInt(1000))
+}
+
+func TestLocale_Unknown(t *testing.T) {
+	var l Locale
+	require.EqualError(t, l.UnmarshalText([]byte("klingon")), `unknown locale "klingon"; use a language tag such as en-US or de-DE`)
+	require.NoError(t, l.UnmarshalText(nil))
+	assert.Equal(t, Locale(""), l)
+}
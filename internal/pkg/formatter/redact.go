@@ -0,0 +1,62 @@
+package formatter
+
+import (
+	"io"
+
+	"github.com/censys/cencli/internal/pkg/redact"
+)
+
+var (
+	// redactor masks the output with --redact; it is nil otherwise.
+	redactor *redact.Redactor
+	// redactedStdout and redactedStderr are the writers that mask Stdout and
+	// Stderr with --redact.
+	redactedStdout, redactedStderr io.WriteCloser
+)
+
+// EnableRedaction masks IP addresses, hostnames, and identifiers in
+// everything written to Stdout and Stderr from now on, in every output
+// format, and in the files written through RedactFile. Interactive views,
+// which draw on the terminal directly, are not masked. Stdout and Stderr are
+// only wrapped once, so that a command run again, as after a refused token is
+// replaced, does not mask its output twice.
+func EnableRedaction(r *redact.Redactor) {
+	redactor = r
+	if Stdout != redactedStdout {
+		redactedStdout = redact.NewWriter(Stdout, r)
+		Stdout = redactedStdout
+	}
+	if Stderr != redactedStderr {
+		redactedStderr = redact.NewWriter(Stderr, r)
+		Stderr = redactedStderr
+	}
+}
+
+// FlushRedaction writes the end of the output that Stdout and Stderr hold
+// back with --redact, in case it is the start of a value. It is called once
+// the command is done.
+func FlushRedaction() {
+	for _, w := range []io.WriteCloser{redactedStdout, redactedStderr} {
+		if w != nil {
+			_ = w.Close()
+		}
+	}
+}
+
+// Redacting reports whether output is masked with --redact.
+func Redacting() bool { return redactor != nil }
+
+// RedactFile returns a writer to w, a file a command writes its output to,
+// that masks it like Stdout with --redact. Close writes the end of the output
+// it holds back, and does not close w. It only writes to w when output is not
+// masked.
+func RedactFile(w io.Writer) io.WriteCloser {
+	if redactor == nil {
+		return nopWriteCloser{w}
+	}
+	return redact.NewWriter(w, redactor)
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
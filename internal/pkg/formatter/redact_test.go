@@ -0,0 +1,39 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/censys/cencli/internal/pkg/redact"
+)
+
+func TestEnableRedaction(t *testing.T) {
+	prevStdout, prevStderr := Stdout, Stderr
+	defer func() {
+		Stdout, Stderr = prevStdout, prevStderr
+		redactor, redactedStdout, redactedStderr = nil, nil, nil
+	}()
+	var stdout, stderr bytes.Buffer
+	Stdout, Stderr = &stdout, &stderr
+
+	r := redact.New("salt")
+	EnableRedaction(r)
+	// a command run again enables redaction again
+	EnableRedaction(r)
+	assert.True(t, Redacting())
+
+	Printf(Stdout, "host mail.example.com")
+	Printf(Stderr, "warning for 203.0.113.5")
+	FlushRedaction()
+	assert.Equal(t, "host "+r.String("mail.example.com"), stdout.String(), "output is masked once")
+	assert.Equal(t, "warning for "+r.String("203.0.113.5"), stderr.String())
+
+	var file bytes.Buffer
+	w := RedactFile(&file)
+	_, _ = w.Write([]byte("10.0.0.1"))
+	assert.Empty(t, file.String())
+	assert.NoError(t, w.Close())
+	assert.Equal(t, r.String("10.0.0.1"), file.String())
+}
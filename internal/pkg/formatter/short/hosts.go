@@ -13,8 +13,40 @@ import (
 	"github.com/censys/cencli/internal/pkg/styles"
 )
 
+// HostsOption configures Hosts and HostsWithRisk.
+type HostsOption func(*hostsOptions)
+
+type hostsOptions struct {
+	serviceNote  ServiceNoteFunc
+	hideServices bool
+}
+
+// ServiceNoteFunc returns a labeled note to render under a service of a
+// host, or an empty note for none.
+type ServiceNoteFunc func(host *assets.Host, svc components.Service) (label, note string)
+
+// WithServiceNotes renders the note returned by note under each service.
+func WithServiceNotes(note ServiceNoteFunc) HostsOption {
+	return func(o *hostsOptions) { o.serviceNote = note }
+}
+
+// WithoutServices leaves out the services of each host, rather than
+// rendering an empty list, for hosts whose services were filtered out.
+func WithoutServices() HostsOption {
+	return func(o *hostsOptions) { o.hideServices = true }
+}
+
+func newHostsOptions(opts []HostsOption) hostsOptions {
+	var o hostsOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // Hosts renders hosts in short format.
-func Hosts(hosts []*assets.Host) string {
+func Hosts(hosts []*assets.Host, opts ...HostsOption) string {
+	o := newHostsOptions(opts)
 	b := NewBlock()
 
 	for i, host := range hosts {
@@ -22,14 +54,16 @@ func Hosts(hosts []*assets.Host) string {
 			b.Newline()
 		}
 		b.SeparatorWithLabel(fmt.Sprintf("Host #%d", i+1))
-		b.Write(renderHostShort(host))
+		b.Write(renderHostShort(host, false, o))
 	}
 
 	return b.String()
 }
 
-// renderHostShort renders a single host.
-func renderHostShort(host *assets.Host) string {
+// renderHostShort renders a single host. If highlightMatches is set, the
+// services that matched the search query are marked. If o has a service
+// note, it is rendered under each service.
+func renderHostShort(host *assets.Host, highlightMatches bool, o hostsOptions) string {
 	var out strings.Builder
 
 	// Header lines
@@ -50,11 +84,40 @@ func renderHostShort(host *assets.Host) string {
 	}
 
 	// Services
-	out.WriteString(renderServices(host.Services))
+	if o.hideServices {
+		return out.String()
+	}
+	var isMatched func(components.Service) bool
+	if highlightMatches {
+		out.WriteString(hostMatchedServices(host))
+		isMatched = host.IsMatchedService
+	}
+	var note func(components.Service) (string, string)
+	if o.serviceNote != nil {
+		note = func(svc components.Service) (string, string) { return o.serviceNote(host, svc) }
+	}
+	out.WriteString(renderServices(host.Services, isMatched, note))
 
 	return out.String()
 }
 
+// matchedMarker is appended to services that matched the search query.
+const matchedMarker = "[matched]"
+
+// hostMatchedServices renders the services that matched the search query, if any.
+func hostMatchedServices(host *assets.Host) string {
+	if len(host.MatchedServices) == 0 {
+		return ""
+	}
+	matched := make([]string, 0, len(host.MatchedServices))
+	for _, m := range host.MatchedServices {
+		matched = append(matched, fmt.Sprintf("%d/%s", Val(m.Port, 0), strings.ToUpper(Val(m.Protocol, "UNKNOWN"))))
+	}
+	line := NewLine(WithLineValueStyle(styles.GlobalStyles.Warning))
+	line.Write("Matched", strings.Join(matched, ", "))
+	return line.String()
+}
+
 // hostHeader renders IP and platform link.
 func hostHeader(host *assets.Host) string {
 	ip := Val(host.IP, "")
@@ -210,7 +273,11 @@ func renderListWithLimit(items []string, limit int) string {
 }
 
 // renderServices renders services section.
-func renderServices(services []components.Service) string {
+func renderServices(
+	services []components.Service,
+	isMatched func(components.Service) bool,
+	note func(components.Service) (label, note string),
+) string {
 	var out strings.Builder
 
 	count := len(services)
@@ -228,8 +295,17 @@ func renderServices(services []components.Service) string {
 		port := Val(svc.Port, 0)
 		transport := string(Val(svc.TransportProtocol, components.ServiceTransportProtocol("")))
 		title := fmt.Sprintf("%s %d/%s", styles.GlobalStyles.Signature.Render(proto), port, transport)
+		if isMatched != nil && isMatched(svc) {
+			title += " " + styles.GlobalStyles.Warning.Bold(true).Render(matchedMarker)
+		}
 		b.Item(title)
 
+		if note != nil {
+			if label, text := note(svc); text != "" {
+				b.ItemField(label, text)
+			}
+		}
+
 		// Software (limit to first 5 to avoid extremely long lists)
 		if len(svc.Software) > 0 {
 			softwareStr := renderServiceComponents(svc.Software, 5)
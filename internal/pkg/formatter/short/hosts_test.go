@@ -173,3 +173,37 @@ Services (0):
 func strPtr(s string) *string     { return &s }
 func intPtr(i int) *int           { return &i }
 func floatPtr(f float64) *float64 { return &f }
+
+func TestHostsWithServiceNotes(t *testing.T) {
+	host := &assets.Host{Host: components.Host{
+		IP: strPtr("1.1.1.1"),
+		Services: []components.Service{
+			{Port: intPtr(22), Protocol: strPtr("SSH")},
+			{Port: intPtr(443), Protocol: strPtr("HTTP")},
+		},
+	}}
+	note := func(h *assets.Host, svc components.Service) (string, string) {
+		require.Same(t, host, h)
+		if Val(svc.Port, 0) == 443 {
+			return "History", "first seen 2024-01-10"
+		}
+		return "History", ""
+	}
+
+	actual := Hosts([]*assets.Host{host}, WithServiceNotes(note))
+	require.Contains(t, actual, "History: first seen 2024-01-10")
+	require.Equal(t, 1, strings.Count(actual, "History:"))
+	require.Less(t, strings.Index(actual, "443/"), strings.Index(actual, "History:"))
+}
+
+func TestHostsWithoutServices(t *testing.T) {
+	host := &assets.Host{Host: components.Host{
+		IP:       strPtr("1.1.1.1"),
+		Location: &components.Location{City: strPtr("Ann Arbor")},
+	}}
+
+	require.Contains(t, Hosts([]*assets.Host{host}), "Services (0):")
+	actual := Hosts([]*assets.Host{host}, WithoutServices())
+	require.Contains(t, actual, "Ann Arbor")
+	require.NotContains(t, actual, "Services")
+}
@@ -0,0 +1,56 @@
+package short
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/risk"
+	"github.com/censys/cencli/internal/pkg/styles"
+)
+
+// HostsWithRisk renders hosts in short format, each with its risk assessment
+// at the top. assessments[i] is the assessment of hosts[i].
+func HostsWithRisk(hosts []*assets.Host, assessments []risk.Assessment, opts ...HostsOption) string {
+	o := newHostsOptions(opts)
+	b := NewBlock()
+
+	for i, host := range hosts {
+		if i > 0 {
+			b.Newline()
+		}
+		b.SeparatorWithLabel(fmt.Sprintf("Host #%d", i+1))
+		if i < len(assessments) {
+			b.Write(RiskAssessment(assessments[i]))
+		}
+		b.Write(renderHostShort(host, false, o))
+	}
+
+	return b.String()
+}
+
+// RiskAssessment renders the risk score of a host, followed by the reasons
+// for it and the points each adds.
+func RiskAssessment(a risk.Assessment) string {
+	var out strings.Builder
+	line := NewLine(WithLineValueStyle(riskLevelStyle(a.Level).Bold(true)))
+	line.Write("Risk", fmt.Sprintf("%d/%d (%s)", a.Score, risk.MaxScore, a.Level))
+	out.WriteString(line.String())
+	for _, r := range a.Reasons {
+		out.WriteString(fmt.Sprintf("  %s %s\n", styles.GlobalStyles.Comment.Render(fmt.Sprintf("+%-3d", r.Points)), r.Reason))
+	}
+	return out.String()
+}
+
+func riskLevelStyle(level risk.Level) lipgloss.Style {
+	switch level {
+	case risk.LevelCritical, risk.LevelHigh:
+		return styles.GlobalStyles.Danger
+	case risk.LevelMedium:
+		return styles.GlobalStyles.Warning
+	default:
+		return styles.GlobalStyles.Tertiary
+	}
+}
@@ -0,0 +1,22 @@
+package short
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/risk"
+)
+
+func TestRiskAssessment(t *testing.T) {
+	out := RiskAssessment(risk.Assessment{
+		IP:    "10.0.0.1",
+		Score: 35,
+		Level: risk.LevelMedium,
+		Reasons: []risk.Reason{
+			{Points: 25, Reason: "RDP exposed on port 3389"},
+			{Points: 10, Reason: "self-signed certificate on port 443"},
+		},
+	})
+	require.Equal(t, "Risk: 35/100 (medium)\n  +25  RDP exposed on port 3389\n  +10  self-signed certificate on port 443\n", out)
+}
@@ -8,13 +8,31 @@ import (
 
 // FIXME: make this perfect
 
+// SearchHitsOption configures SearchHits.
+type SearchHitsOption func(*searchHitsOptions)
+
+type searchHitsOptions struct {
+	highlightMatches bool
+}
+
+// WithMatchHighlight marks the services of each host hit that matched the
+// search query, and lists them under the host's header.
+func WithMatchHighlight() SearchHitsOption {
+	return func(o *searchHitsOptions) { o.highlightMatches = true }
+}
+
 // SearchHits renders search hits in short format.
 // Renders hits in the order received, adding numbered separators with asset type.
-func SearchHits(hits []assets.Asset) string {
+func SearchHits(hits []assets.Asset, opts ...SearchHitsOption) string {
 	if len(hits) == 0 {
 		return ""
 	}
 
+	var o searchHitsOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	b := NewBlock()
 
 	for i, hit := range hits {
@@ -29,7 +47,7 @@ func SearchHits(hits []assets.Asset) string {
 		// Render the hit based on its type (without their own separators)
 		switch h := hit.(type) {
 		case *assets.Host:
-			b.Write(renderHostShort(h))
+			b.Write(renderHostShort(h, o.highlightMatches, hostsOptions{}))
 		case *assets.Certificate:
 			b.Write(renderCertificateShort(h))
 		case *assets.WebProperty:
@@ -27,6 +27,51 @@ func WebProperties(webProperties []*assets.WebProperty) string {
 	return b.String()
 }
 
+// WebPropertyGroup is the web properties of a hostname looked up on several
+// ports.
+type WebPropertyGroup struct {
+	Hostname      string
+	WebProperties []*assets.WebProperty
+	// Missing are the ports looked up with no web property.
+	Missing []int
+}
+
+// WebPropertyGroups renders web properties in short format, under a
+// separator per hostname with the ports they were found on.
+func WebPropertyGroups(groups []WebPropertyGroup) string {
+	b := NewBlock()
+
+	for i, g := range groups {
+		if i > 0 {
+			b.Newline()
+		}
+		found, total := len(g.WebProperties), len(g.WebProperties)+len(g.Missing)
+		b.SeparatorWithLabel(fmt.Sprintf("%s (%d of %d %s)", g.Hostname, found, total, pluralPorts(total)))
+		for j, wp := range g.WebProperties {
+			if j > 0 {
+				b.Newline()
+			}
+			b.Write(renderWebPropertyShort(wp))
+		}
+		if len(g.Missing) > 0 {
+			ports := make([]string, len(g.Missing))
+			for j, port := range g.Missing {
+				ports[j] = fmt.Sprint(port)
+			}
+			b.WriteLine(styles.GlobalStyles.Comment.Render(fmt.Sprintf("Not found on %s %s", pluralPorts(len(ports)), strings.Join(ports, ", "))))
+		}
+	}
+
+	return b.String()
+}
+
+func pluralPorts(n int) string {
+	if n == 1 {
+		return "port"
+	}
+	return "ports"
+}
+
 // renderWebPropertyShort renders a single web property in short format
 func renderWebPropertyShort(wp *assets.WebProperty) string {
 	var out strings.Builder
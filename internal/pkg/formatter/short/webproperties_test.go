@@ -106,3 +106,28 @@ Endpoints: (1)
 		})
 	}
 }
+
+func TestWebPropertyGroups(t *testing.T) {
+	groups := []WebPropertyGroup{
+		{
+			Hostname: "example.com",
+			WebProperties: []*assets.WebProperty{
+				{Webproperty: components.Webproperty{Hostname: strPtr("example.com"), Port: intPtr(80)}},
+				{Webproperty: components.Webproperty{Hostname: strPtr("example.com"), Port: intPtr(443)}},
+			},
+			Missing: []int{8080, 8443},
+		},
+		{
+			Hostname: "censys.io",
+			Missing:  []int{443},
+		},
+	}
+
+	actual := WebPropertyGroups(groups)
+	require.Contains(t, actual, "example.com (2 of 4 ports)")
+	require.Contains(t, actual, "Not found on ports 8080, 8443")
+	require.Contains(t, actual, "censys.io (0 of 1 port)")
+	require.Contains(t, actual, "Not found on port 443")
+	require.Less(t, strings.Index(actual, "example.com:80"), strings.Index(actual, "example.com:443"))
+	require.Less(t, strings.Index(actual, "example.com:443"), strings.Index(actual, "censys.io (0 of 1 port)"))
+}
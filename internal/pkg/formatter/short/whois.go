@@ -0,0 +1,103 @@
+package short
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/whois"
+)
+
+// Whois renders a whois summary in short format.
+func Whois(s *whois.Summary) string {
+	b := NewBlock(WithIndent(0))
+	b.WriteLine(styles.GlobalStyles.Signature.Bold(true).Render(s.Query) +
+		styles.GlobalStyles.Comment.Render(fmt.Sprintf(" (%s, from %s)", s.Type, joinOr(s.Sources, "no source"))))
+
+	if s.ASN != nil || s.ASName != "" {
+		as := fmt.Sprintf("AS%d", Val(s.ASN, 0))
+		if s.ASName != "" {
+			as += " " + s.ASName
+		}
+		if s.ASCountry != "" {
+			as += " (" + s.ASCountry + ")"
+		}
+		b.Field("ASN", as)
+	}
+	b.Field("BGP Prefix", s.BGPPrefix)
+	if n := s.Network; n != nil {
+		b.Field("Network", withHandle(n.Name, n.Handle))
+		b.Field("Range", strings.Join(n.CIDRs, ", "))
+		b.Field("Allocation", n.AllocationType)
+	}
+	if o := s.Organization; o != nil {
+		org := withHandle(o.Name, o.Handle)
+		if o.Country != "" && org != "" {
+			org += ", " + o.Country
+		}
+		b.Field("Organization", org)
+		b.Field("Address", o.Address)
+	}
+	b.Field("Registrar", s.Registrar)
+	b.Field("Nameservers", strings.Join(s.Nameservers, ", "))
+	b.Field("Status", strings.Join(s.Status, ", "))
+
+	var dates []string
+	for _, d := range []struct{ label, value string }{
+		{"registered", s.Registered}, {"updated", s.Updated}, {"expires", s.Expires},
+	} {
+		if d.value != "" {
+			dates = append(dates, fmt.Sprintf("%s %s", d.label, shortDate(d.value)))
+		}
+	}
+	b.Field("Dates", strings.Join(dates, ", "))
+
+	for _, c := range s.AbuseContacts {
+		var details []string
+		for _, v := range []string{c.Email, c.Phone} {
+			if v != "" {
+				details = append(details, v)
+			}
+		}
+		contact := c.Name
+		if len(details) > 0 {
+			if contact != "" {
+				contact += " "
+			}
+			contact += "<" + strings.Join(details, ", ") + ">"
+		}
+		b.Field("Abuse", contact)
+	}
+	if !s.HasRegistration() && s.ASN == nil {
+		b.WriteLine(styles.GlobalStyles.Comment.Render("No registration data found."))
+	}
+	return b.String()
+}
+
+// withHandle returns name followed by its registry handle, if they differ.
+func withHandle(name, handle string) string {
+	switch {
+	case name == "":
+		return handle
+	case handle == "" || strings.EqualFold(name, handle):
+		return name
+	default:
+		return fmt.Sprintf("%s (%s)", name, handle)
+	}
+}
+
+// shortDate returns the date of an RFC 3339 timestamp, or s unchanged.
+func shortDate(s string) string {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC().Format(time.DateOnly)
+	}
+	return s
+}
+
+func joinOr(values []string, fallback string) string {
+	if len(values) == 0 {
+		return fallback
+	}
+	return strings.Join(values, " and ")
+}
@@ -0,0 +1,38 @@
+package short
+
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/xref"
+)
+
+// XrefMatches renders the threat feed matches of the assets that have any,
+// or an empty string if none do. matches[i] are the matches of items[i].
+func XrefMatches(items []assets.Asset, matches [][]xref.Match) string {
+	b := NewBlock()
+	found := false
+	for i, item := range items {
+		if i >= len(matches) || len(matches[i]) == 0 {
+			continue
+		}
+		if !found {
+			b.Newline()
+			b.SeparatorWithLabel("Threat Feed Matches")
+			found = true
+		}
+		b.WriteLine(styles.GlobalStyles.Danger.Bold(true).Render(fmt.Sprintf("%s %s", item.AssetType(), assets.Identifier(item))))
+		for _, m := range matches[i] {
+			where := m.Field
+			if m.Port != 0 {
+				where = fmt.Sprintf("%s (port %d)", where, m.Port)
+			}
+			b.WriteLine(fmt.Sprintf("  %s %s %s",
+				styles.GlobalStyles.Warning.Render("["+m.Feed+"]"),
+				m.Value,
+				styles.GlobalStyles.Comment.Render(fmt.Sprintf("in %s, matching %s", where, m.Indicator))))
+		}
+	}
+	return b.String()
+}
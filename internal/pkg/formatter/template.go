@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"sync"
 
 	handlebars "github.com/aymerick/raymond"
@@ -23,23 +24,33 @@ var once = sync.Once{}
 // Returns error if the template does not exist for the given entity,
 // or the template fails to render.
 func PrintDataWithTemplate(templatePath string, colored bool, data any) cenclierrors.CencliError {
-	once.Do(func() {
-		registerTemplateHelpers(colored)
-	})
 	templateBytes, err := os.ReadFile(templatePath)
 	if err != nil {
 		return newTemplateFailureError(templatePath, err)
 	}
-	data, err = dataToJSON(data)
+	result, renderErr := RenderTemplate(templatePath, string(templateBytes), colored, data)
+	if renderErr != nil {
+		return renderErr
+	}
+	Stdout.Write([]byte(result))
+	return nil
+}
+
+// RenderTemplate renders data through the template source and returns the
+// result. The name identifies the template in errors, and may be empty.
+func RenderTemplate(name, source string, colored bool, data any) (string, cenclierrors.CencliError) {
+	once.Do(func() {
+		registerTemplateHelpers(colored)
+	})
+	data, err := dataToJSON(data)
 	if err != nil {
-		return newTemplateFailureError(templatePath, err)
+		return "", newTemplateFailureError(name, err)
 	}
-	result, err := handlebars.Render(string(templateBytes), data)
+	result, err := handlebars.Render(source, data)
 	if err != nil {
-		return newTemplateFailureError(templatePath, err)
+		return "", newTemplateFailureError(name, err)
 	}
-	Stdout.Write([]byte(result))
-	return nil
+	return result, nil
 }
 
 // registerTemplateHelpers registers the template helpers for the template engine.
@@ -61,6 +72,12 @@ func registerTemplateHelpers(colored bool) {
 		})
 	}
 
+	// mdcell escapes a value for a cell of a Markdown table.
+	handlebars.RegisterHelper("mdcell", func(v interface{}) string {
+		s := strings.ReplaceAll(fmt.Sprint(v), "|", "\\|")
+		return strings.ReplaceAll(s, "\n", " ")
+	})
+
 	handlebars.RegisterHelper("length", func(v interface{}) string {
 		if v == nil {
 			return "0"
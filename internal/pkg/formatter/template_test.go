@@ -797,3 +797,14 @@ func TestTemplateFailureError(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderTemplate(t *testing.T) {
+	out, err := RenderTemplate("", "| {{{mdcell query}}} |", false, map[string]any{"query": "a | b\nc"})
+	require.NoError(t, err)
+	assert.Equal(t, `| a \| b c |`, out)
+
+	_, err = RenderTemplate("report", "{{#if}}", false, nil)
+	var templateFailureErr TemplateFailureError
+	require.ErrorAs(t, err, &templateFailureErr)
+	assert.Contains(t, err.Error(), "'report'")
+}
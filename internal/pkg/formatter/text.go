@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"strconv"
 	"time"
+
+	"github.com/censys/cencli/internal/pkg/datetime"
 )
 
 // TruncateEnd returns s truncated to max characters with an ellipsis suffix if needed.
@@ -14,9 +16,11 @@ func TruncateEnd(s string, max int) string {
 	return s[:max] + "..."
 }
 
-// FormatShortTime renders a timestamp in a compact, human-friendly format.
+// FormatShortTime renders a timestamp in a compact, human-friendly format,
+// in the display timezone (see datetime.SetDisplayTimeZone), as the locale
+// writes dates and times (see SetLocale).
 func FormatShortTime(t time.Time) string {
-	return t.Format("2006-01-02 15:04")
+	return datetime.InDisplayTimeZone(t).Format(locale.date + " " + locale.clock)
 }
 
 // Int64String returns the base-10 string representation of v.
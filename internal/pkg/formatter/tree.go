@@ -2,15 +2,22 @@ package formatter
 
 import (
 	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/term"
 	"github.com/censys/cencli/internal/pkg/ui/tree"
 )
 
-func PrintTree(v any, colored bool) cenclierrors.CencliError {
+// PrintTree shows v in an interactive tree view, configured with opts. The
+// view needs a terminal to be navigated, so v is printed as YAML when the
+// user cannot use it.
+func PrintTree(v any, colored bool, opts ...tree.Option) cenclierrors.CencliError {
+	if !term.Interactive() {
+		return cenclierrors.NewCencliError(PrintYAML(v, colored))
+	}
 	data, err := dataToJSON(v)
 	if err != nil {
 		return newTreeError(err)
 	}
-	err = tree.Run(data)
+	err = tree.Run(data, opts...)
 	if err != nil {
 		return newTreeError(err)
 	}
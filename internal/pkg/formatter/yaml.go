@@ -1,8 +1,9 @@
 package formatter
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
+	"io"
 	"regexp"
 	"strings"
 
@@ -13,15 +14,24 @@ import (
 )
 
 func PrintYAML(v any, colored bool) error {
+	return WriteYAML(Stdout, v, colored)
+}
+
+// WriteYAML writes v as YAML to w, optionally colored, as PrintYAML prints
+// it.
+func WriteYAML(w io.Writer, v any, colored bool) error {
 	serializer := newYamlSerializer()
 	output, err := serializer.serialize(v, colored)
 	if err != nil {
 		return err
 	}
-	fmt.Fprint(Stdout, output)
-	return nil
+	_, err = io.WriteString(w, output)
+	return err
 }
 
+// yamlDocumentSeparator is the line between two documents of a YAML stream.
+const yamlDocumentSeparator = "---"
+
 type yamlColors struct {
 	Key       lipgloss.Style
 	String    lipgloss.Style
@@ -59,22 +69,15 @@ func newYamlSerializer() *yamlSerializer {
 func (s *yamlSerializer) serialize(v any, colored bool) (string, error) {
 	// there isn't really a good way to drop null values from YAML,
 	// so we pass it through the JSON marshaller first
-	jsonBytes, err := json.Marshal(v)
+	jsonBytes, err := marshalJSON(v)
 	if err != nil {
 		return "", err
 	}
 
-	var cleaned any
-	if err := json.Unmarshal(jsonBytes, &cleaned); err != nil {
-		return "", err
-	}
-
-	b, err := yaml.Marshal(cleaned)
+	yamlContent, err := marshalYAMLDocuments(jsonBytes)
 	if err != nil {
 		return "", err
 	}
-
-	yamlContent := string(b)
 	if !colored {
 		return yamlContent, nil
 	}
@@ -82,6 +85,47 @@ func (s *yamlSerializer) serialize(v any, colored bool) (string, error) {
 	return s.colorizeYAML(yamlContent), nil
 }
 
+// marshalYAMLDocuments marshals the JSON document data as YAML. A list is
+// written as a stream of documents, one per item, so tools that read YAML
+// documents one at a time get one result each; an empty list is an empty
+// stream. The items of a list are decoded one at a time, so that only one
+// of them is held as maps at once. Map keys are sorted, so the output is
+// the same on every run.
+func marshalYAMLDocuments(data []byte) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if trimmed := bytes.TrimLeft(data, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		if _, err := dec.Token(); err != nil {
+			return "", err
+		}
+		if !dec.More() {
+			return "", nil
+		}
+		for dec.More() {
+			var item any
+			if err := dec.Decode(&item); err != nil {
+				return "", err
+			}
+			if err := enc.Encode(item); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		var doc any
+		if err := dec.Decode(&doc); err != nil {
+			return "", err
+		}
+		if err := enc.Encode(doc); err != nil {
+			return "", err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 // colorizeYAML applies colors to YAML content using regex patterns
 func (s *yamlSerializer) colorizeYAML(yamlContent string) string {
 	lines := strings.Split(yamlContent, "\n")
@@ -102,6 +146,11 @@ func (s *yamlSerializer) colorizeLine(line string) string {
 		return line
 	}
 
+	// Handle document separators
+	if line == yamlDocumentSeparator {
+		return s.colors.Delimiter.Render(line)
+	}
+
 	// Handle comments
 	if commentMatch := regexp.MustCompile(`^(\s*)(#.*)$`).FindStringSubmatch(line); commentMatch != nil {
 		return commentMatch[1] + s.colors.Comment.Render(commentMatch[2])
@@ -50,14 +50,44 @@ user:
 `,
 		},
 		{
-			name:    "array uncolored",
+			name:    "array is one document per item",
 			input:   []string{"apple", "banana", "cherry"},
 			colored: false,
-			expected: `- apple
-- banana
-- cherry
+			expected: `apple
+---
+banana
+---
+cherry
 `,
 		},
+		{
+			name: "array of objects is one document per object",
+			input: []dummyStruct{
+				{Name: "Alice", Age: 30, Active: true},
+				{Name: "Bob", Age: 25},
+			},
+			colored: false,
+			expected: `active: true
+age: 30
+name: Alice
+---
+active: false
+age: 25
+name: Bob
+`,
+		},
+		{
+			name:     "empty array is an empty stream",
+			input:    []string{},
+			colored:  false,
+			expected: ``,
+		},
+		{
+			name:     "nested arrays stay lists",
+			input:    map[string]any{"ports": []int{22, 80}},
+			colored:  false,
+			expected: "ports:\n    - 22\n    - 80\n",
+		},
 	}
 
 	for _, tt := range tests {
@@ -214,3 +244,21 @@ func stringPtr(s string) *string {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestYamlSerializer_serialize_ColorsDocumentSeparator(t *testing.T) {
+	s := newYamlSerializer()
+	out, err := s.serialize([]string{"a", "b"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, s.colors.String.Render("a")+"\n"+s.colors.Delimiter.Render("---")+"\n"+s.colors.String.Render("b")+"\n", out)
+}
+
+func BenchmarkPrintYAML_LargeHosts(b *testing.B) {
+	hosts := []any{largeHost(500), largeHost(500)}
+	s := newYamlSerializer()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := s.serialize(hosts, false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package graph
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/localindex"
+	"github.com/censys/cencli/internal/pkg/sessionarchive"
+)
+
+// Fingerprint types, the "type" attribute of fingerprint nodes.
+const (
+	FingerprintJARM       = "jarm"
+	FingerprintBannerHash = "banner_sha256"
+	FingerprintSSHHostKey = "ssh_host_key"
+)
+
+var (
+	gzipMagic   = []byte{0x1f, 0x8b}
+	sqliteMagic = []byte("SQLite format 3\x00")
+)
+
+// Pivot is a query censeye found from a host, and the number of hosts that
+// match it.
+type Pivot struct {
+	Query string `json:"query"`
+	Count int64  `json:"count"`
+	// Interesting is true if the count is within the rarity bounds of the
+	// run. Only interesting pivots are added to the graph.
+	Interesting bool `json:"interesting"`
+}
+
+// AddHost adds a host, and the certificates and fingerprints of its services.
+func (g *Graph) AddHost(host assets.Host) {
+	ip := deref(host.IP)
+	if ip == "" {
+		return
+	}
+	id := hostID(ip)
+	attrs := map[string]string{}
+	if as := host.AutonomousSystem; as != nil {
+		if as.Asn != nil {
+			attrs["asn"] = strconv.Itoa(*as.Asn)
+		}
+		if as.Name != nil && *as.Name != "" {
+			attrs["as_name"] = *as.Name
+		}
+	}
+	if host.Location != nil && deref(host.Location.Country) != "" {
+		attrs["country"] = *host.Location.Country
+	}
+	g.AddNode(Node{ID: id, Kind: NodeKindHost, Label: ip, Attrs: attrs})
+	for _, svc := range host.Services {
+		port := ""
+		if svc.Port != nil {
+			port = strconv.Itoa(*svc.Port)
+		}
+		if svc.Cert != nil {
+			g.addCert(id, port, *svc.Cert)
+		}
+		if svc.Jarm != nil {
+			g.addFingerprint(id, port, FingerprintJARM, deref(svc.Jarm.Fingerprint))
+		}
+		g.addFingerprint(id, port, FingerprintBannerHash, deref(svc.BannerHashSha256))
+		if svc.SSH != nil && svc.SSH.ServerHostKey != nil {
+			g.addFingerprint(id, port, FingerprintSSHHostKey, deref(svc.SSH.ServerHostKey.FingerprintSha256))
+		}
+	}
+}
+
+// AddWebProperty adds a web property, and its certificate and JARM fingerprint.
+func (g *Graph) AddWebProperty(wp assets.WebProperty) {
+	hostname := deref(wp.Hostname)
+	if hostname == "" || wp.Port == nil {
+		return
+	}
+	port := strconv.Itoa(*wp.Port)
+	label := net.JoinHostPort(hostname, port)
+	id := string(NodeKindWebProperty) + ":" + label
+	g.AddNode(Node{ID: id, Kind: NodeKindWebProperty, Label: label})
+	if wp.Cert != nil {
+		g.addCert(id, port, *wp.Cert)
+	}
+	if wp.Jarm != nil {
+		g.addFingerprint(id, port, FingerprintJARM, deref(wp.Jarm.Fingerprint))
+	}
+}
+
+// AddCertificate adds a certificate on its own. Certificates are linked to
+// the hosts and web properties that present them when those are added.
+func (g *Graph) AddCertificate(cert assets.Certificate) {
+	g.addCertNode(cert.Certificate)
+}
+
+// AddPivots adds the interesting pivots found from a host. host is an IP
+// address; the host node is added if it is not already in the graph.
+func (g *Graph) AddPivots(host string, pivots []Pivot) {
+	id := hostID(host)
+	g.AddNode(Node{ID: id, Kind: NodeKindHost, Label: host})
+	for _, p := range pivots {
+		if !p.Interesting || p.Query == "" {
+			continue
+		}
+		queryID := string(NodeKindQuery) + ":" + p.Query
+		g.AddNode(Node{ID: queryID, Kind: NodeKindQuery, Label: p.Query})
+		g.AddEdge(Edge{
+			Source: id,
+			Target: queryID,
+			Kind:   EdgeKindPivot,
+			Attrs:  map[string]string{"count": strconv.FormatInt(p.Count, 10)},
+		})
+	}
+}
+
+// AddDocuments adds the hosts, web properties, and certificates of docs.
+func (g *Graph) AddDocuments(docs []localindex.Document) error {
+	for _, doc := range docs {
+		switch doc.Type {
+		case assets.AssetTypeHost:
+			var host components.Host
+			if err := json.Unmarshal(doc.Raw, &host); err != nil {
+				return fmt.Errorf("invalid host %s: %w", doc.ID, err)
+			}
+			g.AddHost(assets.NewHost(host))
+		case assets.AssetTypeWebProperty:
+			var wp components.Webproperty
+			if err := json.Unmarshal(doc.Raw, &wp); err != nil {
+				return fmt.Errorf("invalid web property %s: %w", doc.ID, err)
+			}
+			g.AddWebProperty(assets.NewWebProperty(wp))
+		case assets.AssetTypeCertificate:
+			var cert components.Certificate
+			if err := json.Unmarshal(doc.Raw, &cert); err != nil {
+				return fmt.Errorf("invalid certificate %s: %w", doc.ID, err)
+			}
+			g.AddCertificate(assets.NewCertificate(cert))
+		}
+	}
+	return nil
+}
+
+// AddResponse adds the assets and censeye pivots of data, the JSON output of
+// command, as recorded in a session. command may be empty if it is unknown,
+// e.g. for saved output; the reports of censeye --batch name their host, but
+// the pivots of a single-host censeye run are only added if command names it.
+func (g *Graph) AddResponse(command string, data []byte) error {
+	docs, err := localindex.DocumentsFromJSON("", data)
+	if err != nil {
+		return err
+	}
+	if err := g.AddDocuments(docs); err != nil {
+		return err
+	}
+	host := censeyeHost(command)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		g.collectPivots(host, v)
+	}
+}
+
+// AddFile adds the assets and censeye pivots of a file: a session archive, a
+// SQLite export, or JSON (possibly gzipped), such as saved command output.
+func (g *Graph) AddFile(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch {
+	case bytes.HasPrefix(data, sqliteMagic):
+		docs, err := localindex.ReadFile(ctx, path)
+		if err != nil {
+			return err
+		}
+		return g.AddDocuments(docs)
+	case bytes.HasPrefix(data, gzipMagic):
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		plain, err := io.ReadAll(zr)
+		if err != nil {
+			return err
+		}
+		if trimmed := bytes.TrimSpace(plain); len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+			return g.AddResponse("", plain)
+		}
+		archive, err := sessionarchive.Read(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		for _, entry := range archive.Manifest.Entries {
+			if response, ok := archive.Responses[entry.Digest]; ok {
+				if err := g.AddResponse(entry.Command, response); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	default:
+		return g.AddResponse("", data)
+	}
+}
+
+// collectPivots adds the censeye pivots found in v: host reports of censeye
+// --batch, at any depth, and the entries of a single-host run of host.
+func (g *Graph) collectPivots(host string, v any) {
+	switch v := v.(type) {
+	case []any:
+		if host != "" && len(v) > 0 && isPivot(v[0]) {
+			g.AddPivots(host, decodePivots(v))
+			return
+		}
+		for _, item := range v {
+			g.collectPivots(host, item)
+		}
+	case map[string]any:
+		reportHost, _ := v["host"].(string)
+		pivots, ok := v["pivots"].([]any)
+		if reportHost != "" && ok {
+			g.AddPivots(reportHost, decodePivots(pivots))
+			return
+		}
+		for _, item := range v {
+			g.collectPivots(host, item)
+		}
+	}
+}
+
+func isPivot(v any) bool {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, hasQuery := obj["query"].(string)
+	_, hasInteresting := obj["interesting"].(bool)
+	return hasQuery && hasInteresting
+}
+
+func decodePivots(items []any) []Pivot {
+	var pivots []Pivot
+	for _, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		var p Pivot
+		if json.Unmarshal(raw, &p) == nil {
+			pivots = append(pivots, p)
+		}
+	}
+	return pivots
+}
+
+// censeyeHost returns the host of a recorded single-host censeye command,
+// such as "censys censeye --rarity-max=100 8.8.8.8", or "" for any other
+// command. The positional argument is last in recorded commands.
+func censeyeHost(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) < 3 || fields[1] != "censeye" {
+		return ""
+	}
+	last := strings.Trim(fields[len(fields)-1], "'")
+	if net.ParseIP(last) == nil {
+		return ""
+	}
+	return last
+}
+
+func (g *Graph) addCert(assetID, port string, cert components.Certificate) {
+	certID := g.addCertNode(cert)
+	if certID == "" {
+		return
+	}
+	g.AddEdge(Edge{Source: assetID, Target: certID, Kind: EdgeKindPresents, Attrs: portAttrs(port)})
+}
+
+func (g *Graph) addCertNode(cert components.Certificate) string {
+	fingerprint := deref(cert.FingerprintSha256)
+	if fingerprint == "" {
+		return ""
+	}
+	label := fingerprint
+	if len(cert.Names) > 0 {
+		label = cert.Names[0]
+	}
+	id := string(NodeKindCertificate) + ":" + fingerprint
+	g.AddNode(Node{
+		ID:    id,
+		Kind:  NodeKindCertificate,
+		Label: label,
+		Attrs: map[string]string{"fingerprint_sha256": fingerprint},
+	})
+	return id
+}
+
+func (g *Graph) addFingerprint(assetID, port, fpType, value string) {
+	// a JARM of zeros means the service did not complete a TLS handshake
+	if value == "" || strings.Trim(value, "0") == "" {
+		return
+	}
+	id := string(NodeKindFingerprint) + ":" + fpType + ":" + value
+	g.AddNode(Node{
+		ID:    id,
+		Kind:  NodeKindFingerprint,
+		Label: fpType + " " + value,
+		Attrs: map[string]string{"type": fpType, "value": value},
+	})
+	g.AddEdge(Edge{Source: assetID, Target: id, Kind: EdgeKindHas, Attrs: portAttrs(port)})
+}
+
+func hostID(ip string) string { return string(NodeKindHost) + ":" + assets.NormalizeIP(ip) }
+
+func portAttrs(port string) map[string]string {
+	if port == "" {
+		return nil
+	}
+	return map[string]string{"port": port}
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,196 @@
+package graph
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Format is an output format of a graph.
+type Format string
+
+const (
+	// FormatDOT is the Graphviz DOT language.
+	FormatDOT Format = "dot"
+	// FormatGraphML is GraphML, read by Gephi, yEd, and most graph libraries.
+	FormatGraphML Format = "graphml"
+	// FormatCytoscape is the Cytoscape JSON (cytoscape.js elements) format.
+	FormatCytoscape Format = "cytoscape"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{FormatDOT, FormatGraphML, FormatCytoscape}
+
+// Write writes the graph to w in format.
+func Write(w io.Writer, g *Graph, format Format) error {
+	switch format {
+	case FormatDOT:
+		return writeDOT(w, g)
+	case FormatGraphML:
+		return writeGraphML(w, g)
+	case FormatCytoscape:
+		return writeCytoscape(w, g)
+	default:
+		return fmt.Errorf("unsupported graph format %q", format)
+	}
+}
+
+// dotShapes are the shapes of the nodes of each kind in DOT output.
+var dotShapes = map[NodeKind]string{
+	NodeKindHost:        "box",
+	NodeKindWebProperty: "box",
+	NodeKindCertificate: "note",
+	NodeKindFingerprint: "diamond",
+	NodeKindQuery:       "ellipse",
+}
+
+func writeDOT(w io.Writer, g *Graph) error {
+	var b strings.Builder
+	b.WriteString("digraph cencli {\n  rankdir=LR;\n")
+	for _, n := range g.Nodes() {
+		fmt.Fprintf(&b, "  %s [label=%s, kind=%s, shape=%s", dotQuote(n.ID), dotQuote(n.Label), dotQuote(string(n.Kind)), dotShapes[n.Kind])
+		writeDOTAttrs(&b, n.Attrs)
+		b.WriteString("];\n")
+	}
+	for _, e := range g.Edges() {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s", dotQuote(e.Source), dotQuote(e.Target), dotQuote(string(e.Kind)))
+		writeDOTAttrs(&b, e.Attrs)
+		b.WriteString("];\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeDOTAttrs(b *strings.Builder, attrs map[string]string) {
+	for _, k := range slices.Sorted(maps.Keys(attrs)) {
+		fmt.Fprintf(b, ", %s=%s", k, dotQuote(attrs[k]))
+	}
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func writeGraphML(w io.Writer, g *Graph) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Graph: graphMLGraph{ID: "cencli", EdgeDefault: "directed"},
+	}
+	// every attribute gets a key; keys are namespaced by what they are for,
+	// as a node and an edge attribute may share a name
+	nodeKeys, edgeKeys := map[string]bool{"label": true, "kind": true}, map[string]bool{"kind": true}
+	for _, n := range g.Nodes() {
+		node := graphMLNode{ID: n.ID, Data: []graphMLData{
+			{Key: "node_label", Value: n.Label},
+			{Key: "node_kind", Value: string(n.Kind)},
+		}}
+		for _, k := range slices.Sorted(maps.Keys(n.Attrs)) {
+			nodeKeys[k] = true
+			node.Data = append(node.Data, graphMLData{Key: "node_" + k, Value: n.Attrs[k]})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	for _, e := range g.Edges() {
+		edge := graphMLEdge{Source: e.Source, Target: e.Target, Data: []graphMLData{
+			{Key: "edge_kind", Value: string(e.Kind)},
+		}}
+		for _, k := range slices.Sorted(maps.Keys(e.Attrs)) {
+			edgeKeys[k] = true
+			edge.Data = append(edge.Data, graphMLData{Key: "edge_" + k, Value: e.Attrs[k]})
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	}
+	for _, k := range slices.Sorted(maps.Keys(nodeKeys)) {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: "node_" + k, For: "node", Name: k, Type: "string"})
+	}
+	for _, k := range slices.Sorted(maps.Keys(edgeKeys)) {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: "edge_" + k, For: "edge", Name: k, Type: "string"})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type cytoscapeElements struct {
+	Nodes []cytoscapeElement `json:"nodes"`
+	Edges []cytoscapeElement `json:"edges"`
+}
+
+type cytoscapeElement struct {
+	Data map[string]string `json:"data"`
+}
+
+func writeCytoscape(w io.Writer, g *Graph) error {
+	elements := cytoscapeElements{Nodes: []cytoscapeElement{}, Edges: []cytoscapeElement{}}
+	for _, n := range g.Nodes() {
+		data := maps.Clone(n.Attrs)
+		if data == nil {
+			data = map[string]string{}
+		}
+		data["id"], data["label"], data["kind"] = n.ID, n.Label, string(n.Kind)
+		elements.Nodes = append(elements.Nodes, cytoscapeElement{Data: data})
+	}
+	for i, e := range g.Edges() {
+		data := maps.Clone(e.Attrs)
+		if data == nil {
+			data = map[string]string{}
+		}
+		data["id"] = fmt.Sprintf("e%d", i)
+		data["source"], data["target"], data["kind"] = e.Source, e.Target, string(e.Kind)
+		elements.Edges = append(elements.Edges, cytoscapeElement{Data: data})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]cytoscapeElements{"elements": elements})
+}
@@ -0,0 +1,192 @@
+// Package graph builds a graph of the relationships between assets and the
+// pivots found from them, and writes it in formats that graph tools read:
+// Graphviz DOT, GraphML, and Cytoscape JSON.
+//
+// Nodes are hosts, web properties, certificates, fingerprints (JARM, banner
+// hashes, and SSH host keys), and the queries censeye pivoted on. Edges link
+// an asset to the certificates and fingerprints it presents and to the
+// queries found from it, so assets that share a node form a cluster.
+package graph
+
+import (
+	"maps"
+	"slices"
+	"sort"
+)
+
+// NodeKind is the kind of a node.
+type NodeKind string
+
+const (
+	NodeKindHost        NodeKind = "host"
+	NodeKindWebProperty NodeKind = "webproperty"
+	NodeKindCertificate NodeKind = "cert"
+	NodeKindFingerprint NodeKind = "fingerprint"
+	NodeKindQuery       NodeKind = "query"
+)
+
+// isAsset reports whether nodes of the kind are assets, as opposed to
+// attributes that assets share.
+func (k NodeKind) isAsset() bool {
+	return k == NodeKindHost || k == NodeKindWebProperty
+}
+
+// EdgeKind is the kind of an edge.
+type EdgeKind string
+
+const (
+	// EdgeKindPresents links an asset to a certificate it presents.
+	EdgeKindPresents EdgeKind = "presents"
+	// EdgeKindHas links an asset to one of its fingerprints.
+	EdgeKindHas EdgeKind = "has"
+	// EdgeKindPivot links a host to a query censeye found from it.
+	EdgeKindPivot EdgeKind = "pivot"
+)
+
+// Node is a node of the graph. IDs are prefixed with the kind, as in
+// host:8.8.8.8, so that nodes of different kinds never collide.
+type Node struct {
+	ID    string            `json:"id"`
+	Kind  NodeKind          `json:"kind"`
+	Label string            `json:"label"`
+	Attrs map[string]string `json:"attrs,omitempty"`
+}
+
+// Edge is a directed edge of the graph, from an asset to what it is linked to.
+type Edge struct {
+	Source string            `json:"source"`
+	Target string            `json:"target"`
+	Kind   EdgeKind          `json:"kind"`
+	Attrs  map[string]string `json:"attrs,omitempty"`
+}
+
+func (e Edge) key() string { return e.Source + "\x00" + e.Target + "\x00" + string(e.Kind) }
+
+// Graph is a graph of assets and their relationships. The zero value is not
+// usable; use New.
+type Graph struct {
+	nodes map[string]*Node
+	edges map[string]*Edge
+}
+
+// New returns an empty graph.
+func New() *Graph {
+	return &Graph{nodes: map[string]*Node{}, edges: map[string]*Edge{}}
+}
+
+// AddNode adds a node, or merges it into the node with the same ID: the
+// existing label is kept unless it is empty, and missing attributes are added.
+func (g *Graph) AddNode(n Node) {
+	existing, ok := g.nodes[n.ID]
+	if !ok {
+		n.Attrs = maps.Clone(n.Attrs)
+		g.nodes[n.ID] = &n
+		return
+	}
+	if existing.Label == "" {
+		existing.Label = n.Label
+	}
+	for k, v := range n.Attrs {
+		if _, ok := existing.Attrs[k]; !ok {
+			if existing.Attrs == nil {
+				existing.Attrs = map[string]string{}
+			}
+			existing.Attrs[k] = v
+		}
+	}
+}
+
+// AddEdge adds an edge between two nodes that were added. An edge of the same
+// kind between the same nodes is merged, as with AddNode.
+func (g *Graph) AddEdge(e Edge) {
+	existing, ok := g.edges[e.key()]
+	if !ok {
+		e.Attrs = maps.Clone(e.Attrs)
+		g.edges[e.key()] = &e
+		return
+	}
+	for k, v := range e.Attrs {
+		if _, ok := existing.Attrs[k]; !ok {
+			if existing.Attrs == nil {
+				existing.Attrs = map[string]string{}
+			}
+			existing.Attrs[k] = v
+		}
+	}
+}
+
+// Nodes returns the nodes of the graph, sorted by kind and ID.
+func (g *Graph) Nodes() []Node {
+	nodes := make([]Node, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, *n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Kind != nodes[j].Kind {
+			return kindOrder(nodes[i].Kind) < kindOrder(nodes[j].Kind)
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// Edges returns the edges of the graph, sorted by source, target, and kind.
+func (g *Graph) Edges() []Edge {
+	keys := slices.Sorted(maps.Keys(g.edges))
+	edges := make([]Edge, 0, len(keys))
+	for _, k := range keys {
+		edges = append(edges, *g.edges[k])
+	}
+	return edges
+}
+
+// SharedOnly removes the certificates, fingerprints, and queries linked to
+// fewer than two assets, and then the assets left without edges, keeping the
+// nodes that tie assets together.
+func (g *Graph) SharedOnly() {
+	assetsOf := map[string]map[string]bool{}
+	for _, e := range g.edges {
+		if assetsOf[e.Target] == nil {
+			assetsOf[e.Target] = map[string]bool{}
+		}
+		assetsOf[e.Target][e.Source] = true
+	}
+	for id, n := range g.nodes {
+		if !n.Kind.isAsset() && len(assetsOf[id]) < 2 {
+			delete(g.nodes, id)
+		}
+	}
+	linked := map[string]bool{}
+	for k, e := range g.edges {
+		if _, ok := g.nodes[e.Target]; !ok {
+			delete(g.edges, k)
+			continue
+		}
+		linked[e.Source] = true
+	}
+	for id, n := range g.nodes {
+		if n.Kind.isAsset() && !linked[id] {
+			delete(g.nodes, id)
+		}
+	}
+}
+
+// Len returns the number of nodes and edges of the graph.
+func (g *Graph) Len() (nodes, edges int) {
+	return len(g.nodes), len(g.edges)
+}
+
+func kindOrder(k NodeKind) int {
+	switch k {
+	case NodeKindHost:
+		return 0
+	case NodeKindWebProperty:
+		return 1
+	case NodeKindCertificate:
+		return 2
+	case NodeKindFingerprint:
+		return 3
+	default:
+		return 4
+	}
+}
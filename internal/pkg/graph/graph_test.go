@@ -0,0 +1,232 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/sessionarchive"
+)
+
+const hostsJSON = `[
+  {"ip": "10.0.0.1", "autonomous_system": {"asn": 64500, "name": "EXAMPLE"},
+   "services": [
+     {"port": 443, "protocol": "HTTP", "cert": {"fingerprint_sha256": "aaa", "names": ["example.com"]},
+      "jarm": {"fingerprint": "2ad2ad0002ad2ad"}},
+     {"port": 22, "protocol": "SSH", "ssh": {"server_host_key": {"fingerprint_sha256": "key1"}}}
+   ]},
+  {"ip": "10.0.0.2", "services": [
+     {"port": 8443, "protocol": "HTTP", "cert": {"fingerprint_sha256": "aaa"},
+      "jarm": {"fingerprint": "00000000000000"}},
+     {"port": 80, "protocol": "HTTP", "banner_hash_sha256": "bh"}
+   ]}
+]`
+
+const batchJSON = `[
+  {"host": "10.0.0.1", "pivots": [
+    {"count": 3, "query": "host.services.jarm.fingerprint=\"2ad\"", "interesting": true}
+  ], "queries": 12},
+  {"host": "10.0.0.3", "pivots": [
+    {"count": 3, "query": "host.services.jarm.fingerprint=\"2ad\"", "interesting": true}
+  ], "queries": 9}
+]`
+
+func nodeIDs(g *Graph) []string {
+	var ids []string
+	for _, n := range g.Nodes() {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+func TestAddResponse_Assets(t *testing.T) {
+	g := New()
+	require.NoError(t, g.AddResponse("", []byte(hostsJSON)))
+
+	require.Equal(t, []string{
+		"host:10.0.0.1",
+		"host:10.0.0.2",
+		"cert:aaa",
+		"fingerprint:banner_sha256:bh",
+		"fingerprint:jarm:2ad2ad0002ad2ad",
+		"fingerprint:ssh_host_key:key1",
+	}, nodeIDs(g))
+
+	nodes := g.Nodes()
+	assert.Equal(t, map[string]string{"asn": "64500", "as_name": "EXAMPLE"}, nodes[0].Attrs)
+	assert.Equal(t, "example.com", nodes[2].Label)
+
+	require.Contains(t, g.Edges(), Edge{
+		Source: "host:10.0.0.2",
+		Target: "cert:aaa",
+		Kind:   EdgeKindPresents,
+		Attrs:  map[string]string{"port": "8443"},
+	})
+	_, edges := g.Len()
+	assert.Equal(t, 5, edges)
+}
+
+func TestAddResponse_Pivots(t *testing.T) {
+	t.Run("batch reports name their host", func(t *testing.T) {
+		g := New()
+		require.NoError(t, g.AddResponse("", []byte(batchJSON)))
+		require.Equal(t, []string{"host:10.0.0.1", "host:10.0.0.3", `query:host.services.jarm.fingerprint="2ad"`}, nodeIDs(g))
+		assert.Equal(t, map[string]string{"count": "3"}, g.Edges()[0].Attrs)
+	})
+
+	t.Run("single-host run uses the recorded command", func(t *testing.T) {
+		entries := `[
+			{"count": 2, "query": "q1", "interesting": true},
+			{"count": 9000, "query": "q2", "interesting": false}
+		]`
+		g := New()
+		require.NoError(t, g.AddResponse("censys censeye --rarity-max=100 10.0.0.9", []byte(entries)))
+		require.Equal(t, []string{"host:10.0.0.9", "query:q1"}, nodeIDs(g))
+
+		// without the command, the host of the entries is unknown
+		g = New()
+		require.NoError(t, g.AddResponse("", []byte(entries)))
+		require.Empty(t, nodeIDs(g))
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		require.Error(t, New().AddResponse("", []byte("{")))
+	})
+}
+
+func TestAddPivots_MappedIP(t *testing.T) {
+	g := New()
+	require.NoError(t, g.AddResponse("", []byte(hostsJSON)))
+	before := len(g.Nodes())
+	g.AddPivots("::ffff:10.0.0.1", []Pivot{{Query: "q", Count: 2, Interesting: true}})
+	assert.Contains(t, nodeIDs(g), "host:10.0.0.1")
+	assert.NotContains(t, nodeIDs(g), "host:::ffff:10.0.0.1")
+	// only the query is added
+	assert.Len(t, g.Nodes(), before+1)
+}
+
+func TestCenseyeHost(t *testing.T) {
+	assert.Equal(t, "8.8.8.8", censeyeHost("censys censeye 8.8.8.8"))
+	assert.Equal(t, "2001:db8::1", censeyeHost("censys censeye --include-url 2001:db8::1"))
+	assert.Empty(t, censeyeHost("censys censeye --input-file=hosts.txt"))
+	assert.Empty(t, censeyeHost("censys view 8.8.8.8"))
+	assert.Empty(t, censeyeHost(""))
+}
+
+func TestSharedOnly(t *testing.T) {
+	g := New()
+	require.NoError(t, g.AddResponse("", []byte(hostsJSON)))
+	require.NoError(t, g.AddResponse("", []byte(batchJSON)))
+	g.SharedOnly()
+
+	// the certificate is presented by both hosts and the query was found from
+	// two; everything else ties a single host to nothing
+	require.Equal(t, []string{
+		"host:10.0.0.1",
+		"host:10.0.0.2",
+		"host:10.0.0.3",
+		"cert:aaa",
+		`query:host.services.jarm.fingerprint="2ad"`,
+	}, nodeIDs(g))
+	_, edges := g.Len()
+	assert.Equal(t, 4, edges)
+}
+
+func TestAddFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	t.Run("JSON", func(t *testing.T) {
+		path := filepath.Join(dir, "hosts.json")
+		require.NoError(t, os.WriteFile(path, []byte(hostsJSON), 0o600))
+		g := New()
+		require.NoError(t, g.AddFile(ctx, path))
+		nodes, _ := g.Len()
+		assert.Equal(t, 6, nodes)
+	})
+
+	t.Run("session archive", func(t *testing.T) {
+		entries := []byte(`[{"count": 2, "query": "q1", "interesting": true}]`)
+		digest := sessionarchive.Digest(entries)
+		var buf bytes.Buffer
+		require.NoError(t, sessionarchive.Write(&buf, sessionarchive.Archive{
+			Manifest: sessionarchive.Manifest{
+				Name:    "incident-42",
+				Entries: []sessionarchive.Entry{{Kind: "command", Command: "censys censeye 10.0.0.9", Digest: digest}},
+			},
+			Responses: map[string][]byte{digest: entries},
+		}))
+		path := filepath.Join(dir, "incident-42"+sessionarchive.FileExtension)
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+		g := New()
+		require.NoError(t, g.AddFile(ctx, path))
+		require.Equal(t, []string{"host:10.0.0.9", "query:q1"}, nodeIDs(g))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		require.Error(t, New().AddFile(ctx, filepath.Join(dir, "missing.json")))
+	})
+}
+
+func testGraph() *Graph {
+	g := New()
+	g.AddNode(Node{ID: "host:10.0.0.1", Kind: NodeKindHost, Label: "10.0.0.1"})
+	g.AddNode(Node{ID: `query:a="b"`, Kind: NodeKindQuery, Label: `a="b"`})
+	g.AddEdge(Edge{Source: "host:10.0.0.1", Target: `query:a="b"`, Kind: EdgeKindPivot, Attrs: map[string]string{"count": "3"}})
+	return g
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("dot", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, testGraph(), FormatDOT))
+		assert.Equal(t, `digraph cencli {
+  rankdir=LR;
+  "host:10.0.0.1" [label="10.0.0.1", kind="host", shape=box];
+  "query:a=\"b\"" [label="a=\"b\"", kind="query", shape=ellipse];
+  "host:10.0.0.1" -> "query:a=\"b\"" [label="pivot", count="3"];
+}
+`, buf.String())
+	})
+
+	t.Run("graphml", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, testGraph(), FormatGraphML))
+		var doc graphML
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+		require.Len(t, doc.Graph.Nodes, 2)
+		require.Len(t, doc.Graph.Edges, 1)
+		assert.Equal(t, []graphMLData{{Key: "edge_kind", Value: "pivot"}, {Key: "edge_count", Value: "3"}}, doc.Graph.Edges[0].Data)
+		var keys []string
+		for _, k := range doc.Keys {
+			keys = append(keys, k.ID)
+		}
+		assert.Equal(t, []string{"node_kind", "node_label", "edge_count", "edge_kind"}, keys)
+	})
+
+	t.Run("cytoscape", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, testGraph(), FormatCytoscape))
+		var doc map[string]cytoscapeElements
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+		require.Len(t, doc["elements"].Nodes, 2)
+		assert.Equal(t, map[string]string{
+			"id":     "e0",
+			"source": "host:10.0.0.1",
+			"target": `query:a="b"`,
+			"kind":   "pivot",
+			"count":  "3",
+		}, doc["elements"].Edges[0].Data)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		require.Error(t, Write(&bytes.Buffer{}, testGraph(), "svg"))
+	})
+}
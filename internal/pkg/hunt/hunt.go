@@ -0,0 +1,285 @@
+// Package hunt is a library of parameterized search queries for common hunts,
+// such as exposed remote access services or C2 servers identified by their
+// TLS fingerprints. A curated library is built in, and more hunts can be
+// loaded from YAML files in a local directory, one hunt per file.
+package hunt
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/censys/cencli/internal/pkg/domain/query"
+)
+
+//go:embed library/*.yaml
+var library embed.FS
+
+const libraryDir = "library"
+
+// SourceBuiltin is the source of the hunts that are built in.
+const SourceBuiltin = "built-in"
+
+var (
+	namePattern        = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	placeholderPattern = regexp.MustCompile(`\{([a-z0-9][a-z0-9-]*)\}`)
+	// wordPattern matches the values that can fill a placeholder that is not
+	// in quotes without changing the structure of the query.
+	wordPattern = regexp.MustCompile(`^[A-Za-z0-9._:/*-]+$`)
+)
+
+// Param is a parameter of a hunt. A parameter with a clause is optional: the
+// clause is added to the query when the parameter has a value. Other
+// parameters fill a {name} placeholder in the query, and are required unless
+// they have a default.
+type Param struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+	Default     string `yaml:"default,omitempty" json:"default,omitempty"`
+	// Clause is added to the query with "and" when the parameter has a value,
+	// with {name} replaced by the value.
+	Clause string `yaml:"clause,omitempty" json:"clause,omitempty"`
+}
+
+// Required returns true if the parameter must be given a value.
+func (p Param) Required() bool {
+	return p.Clause == "" && p.Default == ""
+}
+
+// Hunt is a parameterized search query.
+type Hunt struct {
+	Name        string   `yaml:"name" json:"name"`
+	Title       string   `yaml:"title" json:"title"`
+	Description string   `yaml:"description" json:"description"`
+	Tags        []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Query       string   `yaml:"query" json:"query"`
+	Params      []Param  `yaml:"params,omitempty" json:"params,omitempty"`
+	// Source is SourceBuiltin, or the file the hunt was loaded from.
+	Source string `yaml:"-" json:"source"`
+}
+
+// Param returns the parameter with the given name.
+func (h Hunt) Param(name string) (Param, bool) {
+	for _, p := range h.Params {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Param{}, false
+}
+
+// Render returns the query of the hunt with the given parameter values.
+// Parameters without a value use their default.
+func (h Hunt) Render(values map[string]string) (string, error) {
+	for name := range values {
+		if _, ok := h.Param(name); !ok {
+			return "", fmt.Errorf("hunt %s has no parameter %q%s", h.Name, name, h.paramList())
+		}
+	}
+	resolved := make(map[string]string, len(h.Params))
+	var clauses []query.Query
+	for _, p := range h.Params {
+		value, ok := values[p.Name]
+		if !ok || value == "" {
+			value = p.Default
+		}
+		if value != "" && !quoted(h.Query+p.Clause, p.Name) && !wordPattern.MatchString(value) {
+			return "", fmt.Errorf("invalid value %q for parameter %q: must be a single word", value, p.Name)
+		}
+		if p.Clause != "" {
+			if value != "" {
+				clauses = append(clauses, query.Raw(fill(p.Clause, map[string]string{p.Name: value})))
+			}
+			continue
+		}
+		if value == "" {
+			return "", fmt.Errorf("hunt %s requires the parameter %q (%s)", h.Name, p.Name, p.Description)
+		}
+		resolved[p.Name] = value
+	}
+	return query.Raw(fill(h.Query, resolved)).And(clauses...).String(), nil
+}
+
+func (h Hunt) paramList() string {
+	if len(h.Params) == 0 {
+		return "; it takes no parameters"
+	}
+	names := make([]string, len(h.Params))
+	for i, p := range h.Params {
+		names[i] = p.Name
+	}
+	return "; parameters: " + strings.Join(names, ", ")
+}
+
+// quoted returns true if the {name} placeholders of s are in quotes, so
+// that any value can fill them.
+func quoted(s, name string) bool {
+	placeholder := "{" + name + "}"
+	return strings.Count(s, placeholder) == strings.Count(s, `"`+placeholder+`"`)
+}
+
+// fill replaces the {name} placeholders of s with values, escaped for use
+// in a quoted string of the query language.
+func fill(s string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		value, ok := values[match[1:len(match)-1]]
+		if !ok {
+			return match
+		}
+		return query.Escape(value)
+	})
+}
+
+// validate checks that the hunt is complete and that its placeholders and
+// parameters match.
+func (h Hunt) validate() error {
+	if !namePattern.MatchString(h.Name) {
+		return fmt.Errorf("invalid name %q: use lowercase letters, digits, and dashes", h.Name)
+	}
+	if strings.TrimSpace(h.Query) == "" {
+		return errors.New("query is required")
+	}
+	seen := make(map[string]bool, len(h.Params))
+	for _, p := range h.Params {
+		if !namePattern.MatchString(p.Name) {
+			return fmt.Errorf("invalid parameter name %q: use lowercase letters, digits, and dashes", p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("duplicate parameter %q", p.Name)
+		}
+		seen[p.Name] = true
+		if p.Clause != "" && !strings.Contains(p.Clause, "{"+p.Name+"}") {
+			return fmt.Errorf("the clause of parameter %q does not contain {%s}", p.Name, p.Name)
+		}
+	}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(h.Query, -1) {
+		p, ok := h.Param(match[1])
+		if !ok {
+			return fmt.Errorf("query refers to unknown parameter {%s}", match[1])
+		}
+		if p.Clause != "" {
+			return fmt.Errorf("query refers to {%s}, which has a clause", match[1])
+		}
+	}
+	return nil
+}
+
+// Library is a set of hunts by name.
+type Library struct {
+	hunts map[string]Hunt
+}
+
+// Builtin returns the library of built-in hunts.
+func Builtin() (*Library, error) {
+	lib := &Library{hunts: make(map[string]Hunt)}
+	entries, err := library.ReadDir(libraryDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		data, err := library.ReadFile(path.Join(libraryDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		h, err := parse(data, entry.Name(), SourceBuiltin)
+		if err != nil {
+			return nil, err
+		}
+		lib.hunts[h.Name] = h
+	}
+	return lib, nil
+}
+
+// Load returns the built-in hunts, and the hunts of the YAML files in dir,
+// which replace built-in hunts of the same name. A missing dir has no hunts.
+func Load(dir string) (*Library, error) {
+	lib, err := Builtin()
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return lib, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return lib, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		file := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		h, err := parse(data, entry.Name(), file)
+		if err != nil {
+			return nil, err
+		}
+		lib.hunts[h.Name] = h
+	}
+	return lib, nil
+}
+
+// parse parses a hunt from a YAML file. The name defaults to the name of
+// the file without its extension.
+func parse(data []byte, fileName, source string) (Hunt, error) {
+	var h Hunt
+	if err := yaml.Unmarshal(data, &h); err != nil {
+		return Hunt{}, fmt.Errorf("invalid hunt in %s: %w", source, err)
+	}
+	if h.Name == "" {
+		h.Name = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	}
+	h.Source = source
+	if err := h.validate(); err != nil {
+		return Hunt{}, fmt.Errorf("invalid hunt in %s: %w", source, err)
+	}
+	return h, nil
+}
+
+// Get returns the hunt with the given name.
+func (l *Library) Get(name string) (Hunt, bool) {
+	h, ok := l.hunts[name]
+	return h, ok
+}
+
+// All returns the hunts, sorted by name.
+func (l *Library) All() []Hunt {
+	res := make([]Hunt, 0, len(l.hunts))
+	for _, h := range l.hunts {
+		res = append(res, h)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// ParamNames returns the names of the parameters of all hunts, sorted.
+func (l *Library) ParamNames() []string {
+	seen := make(map[string]bool)
+	var res []string
+	for _, h := range l.hunts {
+		for _, p := range h.Params {
+			if !seen[p.Name] {
+				seen[p.Name] = true
+				res = append(res, p.Name)
+			}
+		}
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,153 @@
+package hunt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltin(t *testing.T) {
+	lib, err := Builtin()
+	require.NoError(t, err)
+	require.NotEmpty(t, lib.All())
+	for _, h := range lib.All() {
+		require.Equal(t, SourceBuiltin, h.Source)
+		require.NotEmpty(t, h.Title, h.Name)
+		require.NotEmpty(t, h.Description, h.Name)
+	}
+	_, ok := lib.Get("exposed-rdp")
+	require.True(t, ok)
+	require.Contains(t, lib.ParamNames(), "country")
+}
+
+func TestRender(t *testing.T) {
+	h := Hunt{
+		Name:  "test",
+		Query: `host.services.jarm.fingerprint: "{jarm}"`,
+		Params: []Param{
+			{Name: "jarm", Description: "the JARM fingerprint"},
+			{Name: "country", Clause: `host.location.country_code: "{country}"`},
+			{Name: "port", Clause: `host.services.port: {port}`},
+			{Name: "protocol", Default: "HTTP", Clause: `host.services.protocol: "{protocol}"`},
+		},
+	}
+	require.NoError(t, h.validate())
+
+	testCases := []struct {
+		name     string
+		values   map[string]string
+		expected string
+		err      string
+	}{
+		{
+			name:     "required and defaults",
+			values:   map[string]string{"jarm": "abc"},
+			expected: `(host.services.jarm.fingerprint: "abc") and (host.services.protocol: "HTTP")`,
+		},
+		{
+			name:     "clauses in order of the parameters",
+			values:   map[string]string{"jarm": "abc", "port": "443", "country": "DE", "protocol": "SSH"},
+			expected: `(host.services.jarm.fingerprint: "abc") and (host.location.country_code: "DE") and (host.services.port: 443) and (host.services.protocol: "SSH")`,
+		},
+		{
+			name:     "values are escaped",
+			values:   map[string]string{"jarm": `a"b\c`},
+			expected: `(host.services.jarm.fingerprint: "a\"b\\c") and (host.services.protocol: "HTTP")`,
+		},
+		{
+			name:   "missing required parameter",
+			values: map[string]string{},
+			err:    `hunt test requires the parameter "jarm" (the JARM fingerprint)`,
+		},
+		{
+			name:   "unknown parameter",
+			values: map[string]string{"jarm": "abc", "asn": "1"},
+			err:    `hunt test has no parameter "asn"; parameters: jarm, country, port, protocol`,
+		},
+		{
+			name:   "unquoted value must be a single word",
+			values: map[string]string{"jarm": "abc", "port": "443 or host.ip: 1.1.1.1"},
+			err:    `invalid value "443 or host.ip: 1.1.1.1" for parameter "port": must be a single word`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := h.Render(tc.values)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, query)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "exposed-rdp.yaml"), []byte(`title: Our RDP
+description: RDP in our ranges
+query: 'host.services.protocol: "RDP" and host.ip: "198.51.100.0/24"'
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte("not a hunt"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ours.yml"), []byte(`name: our-vpn
+title: Our VPN
+description: VPN gateways
+query: 'host.services.software.product: "{product}"'
+params:
+  - name: product
+    description: the product
+    default: globalprotect
+`), 0o600))
+
+	lib, err := Load(dir)
+	require.NoError(t, err)
+
+	rdp, ok := lib.Get("exposed-rdp")
+	require.True(t, ok)
+	require.Equal(t, "Our RDP", rdp.Title)
+	require.Equal(t, filepath.Join(dir, "exposed-rdp.yaml"), rdp.Source)
+
+	vpn, ok := lib.Get("our-vpn")
+	require.True(t, ok)
+	query, err := vpn.Render(nil)
+	require.NoError(t, err)
+	require.Equal(t, `host.services.software.product: "globalprotect"`, query)
+
+	_, ok = lib.Get("exposed-vnc")
+	require.True(t, ok, "built-in hunts are kept")
+
+	missing, err := Load(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	require.Len(t, missing.All(), len(mustBuiltin(t).All()))
+}
+
+func TestLoad_InvalidHunt(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "missing query", content: "title: x\n", err: "query is required"},
+		{name: "unknown placeholder", content: `query: 'host.ip: "{ip}"'`, err: "query refers to unknown parameter {ip}"},
+		{name: "clause without placeholder", content: "query: x\nparams:\n  - name: port\n    clause: 'host.services.port: 22'\n", err: `the clause of parameter "port" does not contain {port}`},
+		{name: "invalid yaml", content: "query: [", err: "invalid hunt in"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.yaml"), []byte(tc.content), 0o600))
+			_, err := Load(dir)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func mustBuiltin(t *testing.T) *Library {
+	t.Helper()
+	lib, err := Builtin()
+	require.NoError(t, err)
+	return lib
+}
@@ -0,0 +1,90 @@
+package input
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestReadLines_Decompression(t *testing.T) {
+	const content = "8.8.8.8\n\n  1.1.1.1  \n"
+	expected := []string{"8.8.8.8", "1.1.1.1"}
+
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "plain", input: []byte(content)},
+		{name: "gzip", input: gzipBytes(t, content)},
+		{name: "zstd", input: zstdBytes(t, content)},
+		{name: "single byte", input: []byte("a")},
+		{name: "empty", input: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" from stdin", func(t *testing.T) {
+			lines, err := ReadLinesFromStdin(bytes.NewReader(tt.input))
+			require.NoError(t, err)
+			switch tt.name {
+			case "single byte":
+				require.Equal(t, []string{"a"}, lines)
+			case "empty":
+				require.Empty(t, lines)
+			default:
+				require.Equal(t, expected, lines)
+			}
+		})
+	}
+
+	t.Run("file extension is ignored", func(t *testing.T) {
+		dir := t.TempDir()
+		// compressed content without a compressed extension
+		path := filepath.Join(dir, "hosts.txt")
+		require.NoError(t, os.WriteFile(path, zstdBytes(t, content), 0o600))
+		lines, err := ReadLinesFromFile(path)
+		require.NoError(t, err)
+		require.Equal(t, expected, lines)
+
+		// plain content with a compressed extension
+		path = filepath.Join(dir, "hosts.txt.gz")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		lines, err = ReadLinesFromFile(path)
+		require.NoError(t, err)
+		require.Equal(t, expected, lines)
+	})
+
+	t.Run("truncated gzip is an error", func(t *testing.T) {
+		compressed := gzipBytes(t, content)
+		path := filepath.Join(t.TempDir(), "hosts.txt.gz")
+		require.NoError(t, os.WriteFile(path, compressed[:len(compressed)-6], 0o600))
+		_, err := ReadLinesFromFile(path)
+		var fileErr InvalidInputFileError
+		require.ErrorAs(t, err, &fileErr)
+	})
+}
@@ -1,6 +1,10 @@
 package input
 
-import "github.com/censys/cencli/internal/pkg/cenclierrors"
+import (
+	"fmt"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
 
 type InvalidInputFileError interface {
 	cenclierrors.CencliError
@@ -31,3 +35,40 @@ func (e *invalidInputFileError) Title() string {
 func (e *invalidInputFileError) ShouldPrintUsage() bool {
 	return true
 }
+
+type InvalidRecordError interface {
+	cenclierrors.CencliError
+}
+
+type invalidRecordError struct {
+	// line is the line of the record, or 0 if the input is a JSON array
+	line   int
+	reason string
+}
+
+var _ InvalidRecordError = &invalidRecordError{}
+
+func newInvalidRecordError(line int, reason string) InvalidRecordError {
+	return &invalidRecordError{line: line, reason: reason}
+}
+
+// newInvalidArrayError returns the error of input that is a JSON array whose
+// elements are not all values or assets.
+func newInvalidArrayError(reason string) InvalidRecordError {
+	return &invalidRecordError{reason: reason}
+}
+
+func (e *invalidRecordError) Error() string {
+	if e.line == 0 {
+		return fmt.Sprintf("invalid JSON array input: %s", e.reason)
+	}
+	return fmt.Sprintf("invalid JSON input on line %d: %s", e.line, e.reason)
+}
+
+func (e *invalidRecordError) Title() string {
+	return "Invalid Input Record"
+}
+
+func (e *invalidRecordError) ShouldPrintUsage() bool {
+	return false
+}
@@ -2,6 +2,7 @@ package input
 
 import (
 	"bufio"
+	"bytes"
 	"io"
 	"os"
 	"strings"
@@ -64,9 +65,37 @@ func (r *inputReader) readLinesFromScanner() ([]string, error) {
 	return lines, nil
 }
 
+// readLines reads lines from r, decompressing gzip or zstd input.
+func readLines(r io.Reader, opts ...ReaderOption) ([]string, error) {
+	dr, closeFn, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+	scanner := bufio.NewScanner(dr)
+	scanner.Split(scanLinesOrNULs)
+	return newInputReader(scanner, opts...).readLinesFromScanner()
+}
+
+// scanLinesOrNULs is bufio.ScanLines, except that a NUL byte also ends a
+// line, so that NUL-separated input (e.g. from --ids-only --print0) can be read.
+func scanLinesOrNULs(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\n\x00"); i >= 0 {
+		return i + 1, bytes.TrimSuffix(data[:i], []byte("\r")), nil
+	}
+	if atEOF {
+		return len(data), bytes.TrimSuffix(data, []byte("\r")), nil
+	}
+	return 0, nil, nil
+}
+
 // ReadLinesFromStdin reads lines from stdin using the command's input reader.
+// Gzip and zstd input is decompressed transparently.
 func ReadLinesFromStdin(r io.Reader, opts ...ReaderOption) ([]string, cenclierrors.CencliError) {
-	lines, err := newInputReader(bufio.NewScanner(r), opts...).readLinesFromScanner()
+	lines, err := readLines(r, opts...)
 	if err != nil {
 		return nil, cenclierrors.NewCencliError(err)
 	}
@@ -74,15 +103,16 @@ func ReadLinesFromStdin(r io.Reader, opts ...ReaderOption) ([]string, cenclierro
 }
 
 // ReadLinesFromFile reads lines from a file using the command's input reader.
+// Gzip and zstd files are decompressed transparently.
 func ReadLinesFromFile(filePath string, opts ...ReaderOption) ([]string, cenclierrors.CencliError) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, newInvalidInputFileError(filePath, err)
 	}
 	defer file.Close()
-	lines, scanErr := newInputReader(bufio.NewScanner(file), opts...).readLinesFromScanner()
-	if scanErr != nil {
-		return nil, newInvalidInputFileError(filePath, scanErr)
+	lines, readErr := readLines(file, opts...)
+	if readErr != nil {
+		return nil, newInvalidInputFileError(filePath, readErr)
 	}
 	return lines, nil
 }
@@ -35,6 +35,18 @@ func TestReadLinesFromStdin(t *testing.T) {
 			opts:           []ReaderOption{WithDontTrimSpace()},
 			expectedOutput: []string{"  line1  ", "  line2  ", "  line3  "},
 		},
+		{
+			name:           "NUL-separated input",
+			input:          "line1\x00line2\x00line3\x00",
+			opts:           nil,
+			expectedOutput: []string{"line1", "line2", "line3"},
+		},
+		{
+			name:           "CRLF line endings",
+			input:          "line1\r\nline2\r\n",
+			opts:           []ReaderOption{WithDontTrimSpace()},
+			expectedOutput: []string{"line1", "line2"},
+		},
 		{
 			name:           "input with blank lines - default skip",
 			input:          "line1\n\nline2\n\nline3",
@@ -0,0 +1,144 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+)
+
+// RecordAssetKey is the field of an NDJSON input line that holds the asset.
+const RecordAssetKey = "asset"
+
+// Record is a single line of input. Plain text lines only carry a Value;
+// NDJSON lines also carry every field of the object as Metadata so that
+// it can be attached to the corresponding output.
+type Record struct {
+	Value    string
+	Metadata map[string]any
+}
+
+// ParseRecords interprets each line as either a plain value or, if it starts
+// with "{", a JSON object: either one whose "asset" field holds the value, or
+// an asset as printed by the json output format or streamed by search (see
+// assetIdentifier). Plain and JSON lines may be mixed. If the input is a JSON
+// array, as printed by the json output format, each of its elements is read
+// as a line. Blank lines are skipped; lines should be read with
+// WithLeaveBlanks so that errors report the line numbers of the input.
+func ParseRecords(lines []string) ([]Record, cenclierrors.CencliError) {
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			if strings.HasPrefix(trimmed, "[") {
+				return parseArrayRecords(strings.Join(lines, "\n"))
+			}
+			break
+		}
+	}
+	records := make([]Record, 0, len(lines))
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "{") {
+			records = append(records, Record{Value: trimmed})
+			continue
+		}
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(trimmed), &obj); err != nil {
+			return nil, newInvalidRecordError(i+1, err.Error())
+		}
+		record, reason := objectRecord(obj)
+		if reason != "" {
+			return nil, newInvalidRecordError(i+1, reason)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// parseArrayRecords reads the elements of a JSON array of values and objects
+// as records. Errors refer to elements by their position in the array.
+func parseArrayRecords(data string) ([]Record, cenclierrors.CencliError) {
+	var elems []json.RawMessage
+	if err := json.Unmarshal([]byte(data), &elems); err != nil {
+		return nil, newInvalidArrayError(err.Error())
+	}
+	records := make([]Record, 0, len(elems))
+	for i, elem := range elems {
+		var value string
+		if err := json.Unmarshal(elem, &value); err == nil {
+			if value = strings.TrimSpace(value); value != "" {
+				records = append(records, Record{Value: value})
+			}
+			continue
+		}
+		var obj map[string]any
+		if err := json.Unmarshal(elem, &obj); err != nil {
+			return nil, newInvalidArrayError(fmt.Sprintf("element %d is neither a string nor an object", i+1))
+		}
+		record, reason := objectRecord(obj)
+		if reason != "" {
+			return nil, newInvalidArrayError(fmt.Sprintf("element %d: %s", i+1, reason))
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// objectRecord returns the record of a JSON object of the input, or why the
+// object is not one. Only objects with an "asset" field carry metadata: an
+// asset printed by cencli is the output of a previous command, not data to
+// attach to the next one.
+func objectRecord(obj map[string]any) (Record, string) {
+	if raw, ok := obj[RecordAssetKey]; ok {
+		value, ok := raw.(string)
+		if !ok || strings.TrimSpace(value) == "" {
+			return Record{}, fmt.Sprintf("missing string field %q", RecordAssetKey)
+		}
+		return Record{Value: strings.TrimSpace(value), Metadata: obj}, ""
+	}
+	if id := assetIdentifier(obj); id != "" {
+		return Record{Value: id}, ""
+	}
+	return Record{}, fmt.Sprintf("missing string field %q, and not a host, certificate, or web property", RecordAssetKey)
+}
+
+// assetTypeKeys are the keys search wraps each hit in.
+var assetTypeKeys = []string{"host", "certificate", "webproperty"}
+
+// assetIdentifier returns the identifier of an asset object as printed by
+// cencli: the "ip" of a host, the "fingerprint_sha256" of a certificate, or
+// "hostname" and "port" of a web property. The asset can be wrapped in an
+// object keyed by its type, as search prints its hits. It is empty if obj is
+// not an asset.
+func assetIdentifier(obj map[string]any) string {
+	if len(obj) == 1 {
+		for _, key := range assetTypeKeys {
+			if inner, ok := obj[key].(map[string]any); ok {
+				return assetIdentifier(inner)
+			}
+		}
+	}
+	if ip, ok := obj["ip"].(string); ok && ip != "" {
+		return ip
+	}
+	if fingerprint, ok := obj["fingerprint_sha256"].(string); ok && fingerprint != "" {
+		return fingerprint
+	}
+	hostname, _ := obj["hostname"].(string)
+	if port, ok := obj["port"].(float64); ok && hostname != "" {
+		return fmt.Sprintf("%s:%d", hostname, int(port))
+	}
+	return ""
+}
+
+// RecordValues returns the value of each record.
+func RecordValues(records []Record) []string {
+	values := make([]string, len(records))
+	for i, r := range records {
+		values[i] = r.Value
+	}
+	return values
+}
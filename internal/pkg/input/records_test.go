@@ -0,0 +1,117 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecords(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		expected []Record
+		errMsg   string
+	}{
+		{
+			name:  "plain lines",
+			lines: []string{"1.1.1.1", " 8.8.8.8 "},
+			expected: []Record{
+				{Value: "1.1.1.1"},
+				{Value: "8.8.8.8"},
+			},
+		},
+		{
+			name:  "ndjson lines",
+			lines: []string{`{"asset": "1.2.3.4", "label": "case-42", "priority": 2}`},
+			expected: []Record{
+				{
+					Value:    "1.2.3.4",
+					Metadata: map[string]any{"asset": "1.2.3.4", "label": "case-42", "priority": float64(2)},
+				},
+			},
+		},
+		{
+			name:  "mixed lines",
+			lines: []string{"1.1.1.1", `{"asset":"8.8.8.8"}`},
+			expected: []Record{
+				{Value: "1.1.1.1"},
+				{Value: "8.8.8.8", Metadata: map[string]any{"asset": "8.8.8.8"}},
+			},
+		},
+		{
+			name:   "malformed json",
+			lines:  []string{"1.1.1.1", `{"asset": `},
+			errMsg: "invalid JSON input on line 2",
+		},
+		{
+			name:   "malformed json after blank lines",
+			lines:  []string{"1.1.1.1", "", "  ", `{"asset": `},
+			errMsg: "invalid JSON input on line 4",
+		},
+		{
+			name:  "json array after blank lines",
+			lines: []string{"", `[{"asset": "1.1.1.1"}]`},
+			expected: []Record{
+				{Value: "1.1.1.1", Metadata: map[string]any{"asset": "1.1.1.1"}},
+			},
+		},
+		{
+			name:   "missing asset field",
+			lines:  []string{`{"label": "case-42"}`},
+			errMsg: `missing string field "asset"`,
+		},
+		{
+			name: "assets as printed by view and streamed by search",
+			lines: []string{
+				`{"ip": "1.1.1.1", "services": [{"port": 53}]}`,
+				`{"host": {"ip": "8.8.8.8", "location": {"country": "United States"}}}`,
+				`{"certificate": {"fingerprint_sha256": "abc123", "names": ["example.com"]}}`,
+				`{"webproperty": {"hostname": "example.com", "port": 443}}`,
+			},
+			expected: []Record{
+				{Value: "1.1.1.1"},
+				{Value: "8.8.8.8"},
+				{Value: "abc123"},
+				{Value: "example.com:443"},
+			},
+		},
+		{
+			name:  "json array",
+			lines: []string{"[", `  {"host": {"ip": "1.1.1.1"}},`, `  "8.8.8.8",`, `  {"asset": "9.9.9.9", "label": "x"}`, "]"},
+			expected: []Record{
+				{Value: "1.1.1.1"},
+				{Value: "8.8.8.8"},
+				{Value: "9.9.9.9", Metadata: map[string]any{"asset": "9.9.9.9", "label": "x"}},
+			},
+		},
+		{
+			name:   "json array of other values",
+			lines:  []string{"[1, 2]"},
+			errMsg: "invalid JSON array input: element 1 is neither a string nor an object",
+		},
+		{
+			name:   "non-string asset field",
+			lines:  []string{`{"asset": 42}`},
+			errMsg: `missing string field "asset"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := ParseRecords(tc.lines)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, records)
+		})
+	}
+}
+
+func TestRecordValues(t *testing.T) {
+	records := []Record{{Value: "a"}, {Value: "b", Metadata: map[string]any{"asset": "b"}}}
+	require.Equal(t, []string{"a", "b"}, RecordValues(records))
+}
@@ -0,0 +1,238 @@
+// Package kafka produces messages to a Kafka topic. Messages are handed to
+// kcat (https://github.com/edenhill/kcat), which runs as a subprocess, so that
+// the CLI does not carry a Kafka client and only users who forward to Kafka
+// need one installed.
+package kafka
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// SinkName is the name of the sink, as given to --forward.
+const SinkName = "kafka"
+
+// DefaultCommand is the kcat executable that produces the messages.
+const DefaultCommand = "kcat"
+
+const (
+	// KeyID keys each message with the identifier of its asset (see assets.Identifier).
+	KeyID = "id"
+	// KeyNone produces messages without a key.
+	KeyNone = "none"
+)
+
+// keyDelimiter separates the key from the value on each line given to kcat.
+// Compact JSON never contains a raw tab.
+const keyDelimiter = "\t"
+
+// maxStderr bounds how much of kcat's stderr is kept for error messages.
+const maxStderr = 4096
+
+// ErrNotConfigured is returned when the brokers or topic are missing.
+var ErrNotConfigured = errors.New("the Kafka brokers and topic must be configured")
+
+// Options configures a Producer.
+type Options struct {
+	Brokers []string
+	Topic   string
+	// Key is KeyID, KeyNone, or the path of a field of each message, such as
+	// host.location.country. Defaults to KeyID.
+	Key string
+	// Headers are set on every message.
+	Headers map[string]string
+	// Command is the kcat executable. Defaults to DefaultCommand.
+	Command string
+	// Args are extra kcat arguments, e.g. -X security.protocol=SASL_SSL.
+	Args []string
+}
+
+// Producer produces one message per result. It is safe for concurrent use.
+type Producer struct {
+	key    string
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	w      *bufio.Writer
+	stderr *limitedBuffer
+
+	mu     sync.Mutex
+	sent   int
+	closed bool
+}
+
+// Start starts kcat, producing to the configured topic.
+func Start(ctx context.Context, opts Options) (*Producer, error) {
+	if len(opts.Brokers) == 0 || strings.TrimSpace(opts.Topic) == "" {
+		return nil, ErrNotConfigured
+	}
+	command := opts.Command
+	if command == "" {
+		command = DefaultCommand
+	}
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return nil, fmt.Errorf("%s is required to produce to Kafka (https://github.com/edenhill/kcat): %w", command, err)
+	}
+	key := opts.Key
+	if key == "" {
+		key = KeyID
+	}
+
+	cmd := exec.CommandContext(ctx, path, Args(opts)...)
+	stderr := &limitedBuffer{max: maxStderr}
+	cmd.Stdout = stderr
+	cmd.Stderr = stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", command, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", command, err)
+	}
+	return &Producer{key: key, cmd: cmd, stdin: stdin, w: bufio.NewWriter(stdin), stderr: stderr}, nil
+}
+
+// Args returns the kcat arguments for opts.
+func Args(opts Options) []string {
+	args := []string{
+		"-P",
+		"-b", strings.Join(opts.Brokers, ","),
+		"-t", opts.Topic,
+		"-K", keyDelimiter,
+	}
+	names := make([]string, 0, len(opts.Headers))
+	for name := range opts.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, "-H", name+"="+opts.Headers[name])
+	}
+	return append(args, opts.Args...)
+}
+
+// Add produces data as a message.
+func (p *Producer) Add(_ context.Context, data any) error {
+	var value bytes.Buffer
+	enc := json.NewEncoder(&value)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	line := sanitizeKey(messageKey(p.key, data, value.Bytes())) + keyDelimiter + value.String()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return errors.New("producer is closed")
+	}
+	if _, err := p.w.WriteString(line); err != nil {
+		return fmt.Errorf("failed to write message to kcat: %w", err)
+	}
+	p.sent++
+	return nil
+}
+
+// Close waits for kcat to deliver the messages and exit.
+func (p *Producer) Close(_ context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	flushErr := p.w.Flush()
+	_ = p.stdin.Close()
+	if err := p.cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(p.stderr.String()); msg != "" {
+			return fmt.Errorf("kcat failed: %w: %s", err, msg)
+		}
+		return fmt.Errorf("kcat failed: %w", err)
+	}
+	if flushErr != nil {
+		return fmt.Errorf("failed to write messages to kcat: %w", flushErr)
+	}
+	return nil
+}
+
+// Sent returns the number of messages handed to kcat.
+func (p *Producer) Sent() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sent
+}
+
+// messageKey returns the key of a message, given its data and JSON value.
+func messageKey(key string, data any, value []byte) string {
+	switch key {
+	case KeyNone:
+		return ""
+	case KeyID:
+		return identifier(data, value)
+	default:
+		return gjson.GetBytes(value, key).String()
+	}
+}
+
+// identifierPaths locate the identifier of results that are not assets, such
+// as assets wrapped with their type ({"host": {...}}).
+var identifierPaths = []string{"ip", "*.ip", "fingerprint_sha256", "*.fingerprint_sha256"}
+
+func identifier(data any, value []byte) string {
+	if a, ok := data.(assets.Asset); ok {
+		return assets.Identifier(a)
+	}
+	if m, ok := data.(map[string]any); ok && len(m) == 1 {
+		for _, v := range m {
+			if a, ok := v.(assets.Asset); ok {
+				return assets.Identifier(a)
+			}
+		}
+	}
+	for _, path := range identifierPaths {
+		if r := gjson.GetBytes(value, path); r.Exists() {
+			return r.String()
+		}
+	}
+	return ""
+}
+
+// sanitizeKey removes the characters that would break the line given to kcat.
+func sanitizeKey(key string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(key)
+}
+
+// limitedBuffer keeps the first max bytes written to it.
+type limitedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if room := b.max - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
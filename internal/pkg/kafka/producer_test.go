@@ -0,0 +1,92 @@
+package kafka
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// fakeKcat writes a script that records its arguments and stdin in dir.
+func fakeKcat(t *testing.T, dir, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kcat is a shell script")
+	}
+	path := filepath.Join(dir, "kcat")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+	return path
+}
+
+func TestProducer(t *testing.T) {
+	dir := t.TempDir()
+	command := fakeKcat(t, dir, `printf '%s\n' "$@" > "`+dir+`/args"; cat > "`+dir+`/stdin"`)
+
+	ip, fingerprint := "1.1.1.1", "abc123"
+	host := assets.NewHost(components.Host{IP: &ip})
+	cert := assets.NewCertificate(components.Certificate{FingerprintSha256: &fingerprint})
+
+	p, err := Start(context.Background(), Options{
+		Brokers: []string{"kafka-1:9092", "kafka-2:9092"},
+		Topic:   "censys-hits",
+		Headers: map[string]string{"cencli-query": "host.services.port: 443", "cencli-command": "search"},
+		Command: command,
+		Args:    []string{"-X", "security.protocol=SSL"},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, p.Add(ctx, map[string]any{"host": &host}))
+	require.NoError(t, p.Add(ctx, &cert))
+	require.NoError(t, p.Add(ctx, map[string]any{"event_time": "2025-01-01"}))
+	require.NoError(t, p.Close(ctx))
+	require.NoError(t, p.Close(ctx))
+	require.Equal(t, 3, p.Sent())
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"-P", "-b", "kafka-1:9092,kafka-2:9092", "-t", "censys-hits", "-K", "\t",
+		"-H", "cencli-command=search", "-H", "cencli-query=host.services.port: 443",
+		"-X", "security.protocol=SSL",
+	}, strings.Split(strings.TrimSuffix(string(args), "\n"), "\n"))
+
+	stdin, err := os.ReadFile(filepath.Join(dir, "stdin"))
+	require.NoError(t, err)
+	require.Equal(t, `1.1.1.1	{"host":{"ip":"1.1.1.1"}}
+abc123	{"fingerprint_sha256":"abc123"}
+	{"event_time":"2025-01-01"}
+`, string(stdin))
+}
+
+func TestProducer_Failure(t *testing.T) {
+	command := fakeKcat(t, t.TempDir(), `cat > /dev/null; echo "% ERROR: Failed to connect to broker" >&2; exit 1`)
+	p, err := Start(context.Background(), Options{Brokers: []string{"kafka:9092"}, Topic: "censys-hits", Command: command})
+	require.NoError(t, err)
+	require.NoError(t, p.Add(context.Background(), "event"))
+	err = p.Close(context.Background())
+	require.ErrorContains(t, err, "kcat failed: exit status 1: % ERROR: Failed to connect to broker")
+}
+
+func TestStart_Errors(t *testing.T) {
+	_, err := Start(context.Background(), Options{Topic: "censys-hits"})
+	require.ErrorIs(t, err, ErrNotConfigured)
+	_, err = Start(context.Background(), Options{Brokers: []string{"kafka:9092"}})
+	require.ErrorIs(t, err, ErrNotConfigured)
+	_, err = Start(context.Background(), Options{Brokers: []string{"kafka:9092"}, Topic: "t", Command: "definitely-not-kcat"})
+	require.ErrorContains(t, err, "definitely-not-kcat is required to produce to Kafka")
+}
+
+func TestMessageKey(t *testing.T) {
+	value := []byte(`{"host":{"ip":"1.1.1.1","location":{"country":"Germany"}}}`)
+	require.Equal(t, "1.1.1.1", messageKey(KeyID, nil, value))
+	require.Equal(t, "Germany", messageKey("host.location.country", nil, value))
+	require.Equal(t, "", messageKey(KeyNone, nil, value))
+	require.Equal(t, "a b", sanitizeKey("a\tb"))
+}
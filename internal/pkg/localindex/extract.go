@@ -0,0 +1,219 @@
+package localindex
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// field is the text of a column of the index.
+type field []string
+
+func (f *field) add(values ...string) {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			*f = append(*f, v)
+		}
+	}
+}
+
+func (f field) String() string { return strings.Join(f, "\n") }
+
+// text is the searchable text of an asset, by column.
+type text struct {
+	banners  field
+	titles   field
+	certs    field
+	software field
+	other    field
+}
+
+func extractText(assetType assets.AssetType, raw json.RawMessage) (text, error) {
+	var t text
+	switch assetType {
+	case assets.AssetTypeHost:
+		var host components.Host
+		if err := json.Unmarshal(raw, &host); err != nil {
+			return t, err
+		}
+		t.other.add(deref(host.IP))
+		if as := host.AutonomousSystem; as != nil {
+			t.other.add(deref(as.Name), deref(as.Description))
+		}
+		if loc := host.Location; loc != nil {
+			t.other.add(deref(loc.City), deref(loc.Country))
+		}
+		if host.DNS != nil && host.DNS.ReverseDNS != nil {
+			t.other.add(host.DNS.ReverseDNS.Names...)
+		}
+		if host.OperatingSystem != nil {
+			t.software.add(describe(*host.OperatingSystem))
+		}
+		for _, svc := range host.Services {
+			t.banners.add(deref(svc.Banner))
+			t.other.add(deref(svc.Protocol))
+			for _, attr := range svc.Software {
+				t.software.add(describe(attr))
+			}
+			t.addCert(svc.Cert)
+			t.addEndpoints(svc.Endpoints)
+		}
+	case assets.AssetTypeCertificate:
+		var cert components.Certificate
+		if err := json.Unmarshal(raw, &cert); err != nil {
+			return t, err
+		}
+		t.addCert(&cert)
+	case assets.AssetTypeWebProperty:
+		var wp components.Webproperty
+		if err := json.Unmarshal(raw, &wp); err != nil {
+			return t, err
+		}
+		t.other.add(deref(wp.Hostname))
+		for _, attr := range wp.Software {
+			t.software.add(describe(attr))
+		}
+		t.addCert(wp.Cert)
+		t.addEndpoints(wp.Endpoints)
+	}
+	return t, nil
+}
+
+func (t *text) addCert(cert *components.Certificate) {
+	if cert == nil {
+		return
+	}
+	t.certs.add(cert.Names...)
+	if cert.Parsed != nil {
+		t.certs.add(deref(cert.Parsed.SubjectDn), deref(cert.Parsed.IssuerDn))
+	}
+}
+
+func (t *text) addEndpoints(endpoints []components.EndpointScanState) {
+	for _, ep := range endpoints {
+		if ep.HTTP != nil {
+			t.titles.add(deref(ep.HTTP.HTMLTitle))
+		}
+	}
+}
+
+// describe returns the vendor, product, and version of an attribute, and its CPE.
+func describe(attr components.Attribute) string {
+	return strings.Join([]string{deref(attr.Vendor), deref(attr.Product), deref(attr.Version), deref(attr.Cpe)}, " ")
+}
+
+// DocumentsFromJSON returns the assets found in data, which holds one or
+// more JSON values: the output of a command, a JSON export, or NDJSON such as
+// streaming output or an es-bulk export. Assets are found at any depth, so
+// the hits of search and the results of view are found alike; assets nested
+// in other assets (e.g. the certificates of a host) are part of their parent.
+func DocumentsFromJSON(source string, data []byte) ([]Document, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var docs []Document
+	for {
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+			return docs, fmt.Errorf("invalid JSON: %w", err)
+		}
+		if err := collect(&docs, source, v); err != nil {
+			return docs, err
+		}
+	}
+}
+
+func collect(docs *[]Document, source string, v any) error {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			if err := collect(docs, source, item); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		assetType, ok := detectAssetType(v)
+		if !ok {
+			for _, item := range v {
+				if err := collect(docs, source, item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		id, err := identifier(assetType, raw)
+		if err != nil || id == "" {
+			return err
+		}
+		*docs = append(*docs, Document{Type: assetType, ID: id, Source: source, Raw: raw})
+	}
+	return nil
+}
+
+// detectAssetType returns the type of asset that obj is, if it is one.
+func detectAssetType(obj map[string]any) (assets.AssetType, bool) {
+	has := func(keys ...string) bool {
+		for _, k := range keys {
+			if _, ok := obj[k]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("fingerprint_sha256") && has("parsed", "names"):
+		return assets.AssetTypeCertificate, true
+	case has("hostname") && has("port") && !has("ip"):
+		return assets.AssetTypeWebProperty, true
+	case has("ip") && has("services", "location", "autonomous_system", "dns", "operating_system"):
+		return assets.AssetTypeHost, true
+	default:
+		return "", false
+	}
+}
+
+func identifier(assetType assets.AssetType, raw []byte) (string, error) {
+	var asset assets.Asset
+	switch assetType {
+	case assets.AssetTypeHost:
+		var host components.Host
+		if err := json.Unmarshal(raw, &host); err != nil {
+			return "", err
+		}
+		asset = assets.NewHost(host)
+	case assets.AssetTypeCertificate:
+		var cert components.Certificate
+		if err := json.Unmarshal(raw, &cert); err != nil {
+			return "", err
+		}
+		asset = assets.NewCertificate(cert)
+	default:
+		var wp components.Webproperty
+		if err := json.Unmarshal(raw, &wp); err != nil {
+			return "", err
+		}
+		asset = assets.NewWebProperty(wp)
+	}
+	return assets.Identifier(asset), nil
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
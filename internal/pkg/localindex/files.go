@@ -0,0 +1,112 @@
+package localindex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/sessionarchive"
+)
+
+var (
+	gzipMagic   = []byte{0x1f, 0x8b}
+	sqliteMagic = []byte("SQLite format 3\x00")
+)
+
+// exportTables are the tables of a SQLite export (see package sqliteexport)
+// that hold the JSON of each asset.
+var exportTables = []struct {
+	name      string
+	assetType assets.AssetType
+}{
+	{"hosts", assets.AssetTypeHost},
+	{"certs", assets.AssetTypeCertificate},
+	{"web_properties", assets.AssetTypeWebProperty},
+}
+
+// ReadFile returns the assets of a file, with the file as their source. The
+// file is a session archive, a SQLite export, or JSON: the output of a
+// command, a JSON or es-bulk export, or NDJSON. JSON may be gzipped.
+func ReadFile(ctx context.Context, path string) ([]Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(data, sqliteMagic):
+		return readExport(ctx, path)
+	case bytes.HasPrefix(data, gzipMagic):
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		plain, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, err
+		}
+		if trimmed := bytes.TrimSpace(plain); len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+			return DocumentsFromJSON(path, plain)
+		}
+		return readSessionArchive(path, data)
+	default:
+		return DocumentsFromJSON(path, data)
+	}
+}
+
+func readSessionArchive(path string, data []byte) ([]Document, error) {
+	archive, err := sessionarchive.Read(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	source := fmt.Sprintf("%s (session %s)", path, archive.Manifest.Name)
+	var docs []Document
+	for _, entry := range archive.Manifest.Entries {
+		response, ok := archive.Responses[entry.Digest]
+		if !ok {
+			continue
+		}
+		found, err := DocumentsFromJSON(source, response)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, found...)
+	}
+	return docs, nil
+}
+
+func readExport(ctx context.Context, path string) ([]Document, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	var docs []Document
+	for _, table := range exportTables {
+		rows, err := db.QueryContext(ctx, "SELECT raw FROM "+table.name)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a SQLite export: %w", path, err)
+		}
+		for rows.Next() {
+			var raw string
+			if err := rows.Scan(&raw); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			id, err := identifier(table.assetType, []byte(raw))
+			if err != nil || id == "" {
+				continue
+			}
+			docs = append(docs, Document{Type: table.assetType, ID: id, Source: path, Raw: []byte(raw)})
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
@@ -0,0 +1,264 @@
+// Package localindex is a full-text index of assets that were already
+// fetched, so that they can be searched again offline without spending
+// credits. The index is a SQLite FTS5 database covering the text of each
+// asset that is worth searching: banners, HTML titles, certificate names and
+// DNs, software, and the identifying details of hosts.
+//
+// Each asset is indexed once, keyed by its type and identifier; indexing it
+// again replaces it, so the index holds the latest copy that was seen.
+package localindex
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// FileName is the name of the index in the cache directory.
+const FileName = "local-index.db"
+
+// DefaultLimit is the number of hits returned when no limit is given.
+const DefaultLimit = 20
+
+const schema = `
+CREATE TABLE IF NOT EXISTS documents (
+	id         INTEGER PRIMARY KEY,
+	asset_type TEXT NOT NULL,
+	asset_id   TEXT NOT NULL,
+	source     TEXT NOT NULL,
+	indexed_at TEXT NOT NULL,
+	raw        TEXT NOT NULL,
+	UNIQUE (asset_type, asset_id)
+);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS documents_fts USING fts5(
+	asset_id, banners, titles, certs, software, other
+);
+`
+
+// ErrEmptyQuery is returned when a query has no terms.
+var ErrEmptyQuery = errors.New("query has no search terms")
+
+// Document is an asset to index.
+type Document struct {
+	Type assets.AssetType
+	// ID identifies the asset among assets of its type (see assets.Identifier).
+	ID string
+	// Source describes where the asset was found, e.g. a session or export file.
+	Source string
+	// Raw is the JSON of the asset.
+	Raw json.RawMessage
+}
+
+// Hit is an asset matching a query.
+type Hit struct {
+	Type      assets.AssetType `json:"type"`
+	ID        string           `json:"id"`
+	Source    string           `json:"source"`
+	IndexedAt time.Time        `json:"indexed_at"`
+	// Snippet is the best matching text, with matched terms in [brackets].
+	Snippet string          `json:"snippet"`
+	Asset   json.RawMessage `json:"asset"`
+}
+
+// Query is a search of the index.
+type Query struct {
+	// Text is the search terms. Every term must match; "quoted phrases" match
+	// consecutive words, and a trailing * matches words with that prefix.
+	Text string
+	// Type, if set, limits hits to assets of this type.
+	Type assets.AssetType
+	// Limit is the maximum number of hits. Defaults to DefaultLimit.
+	Limit int
+}
+
+// Index is a local full-text index of assets.
+type Index struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// Open opens the index at path, creating it if needed.
+func Open(path string) (*Index, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize local index %s: %w", path, err)
+	}
+	return &Index{db: db, now: time.Now}, nil
+}
+
+// Close closes the index.
+func (ix *Index) Close() error { return ix.db.Close() }
+
+// Add indexes docs, replacing assets that were already indexed, and returns
+// the number of documents indexed.
+func (ix *Index) Add(ctx context.Context, docs []Document) (int, error) {
+	tx, err := ix.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+	indexedAt := ix.now().UTC().Format(time.RFC3339)
+	for _, doc := range docs {
+		var id int64
+		err := tx.QueryRowContext(ctx, `SELECT id FROM documents WHERE asset_type = ? AND asset_id = ?`,
+			doc.Type.String(), doc.ID).Scan(&id)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			res, err := tx.ExecContext(ctx,
+				`INSERT INTO documents (asset_type, asset_id, source, indexed_at, raw) VALUES (?, ?, ?, ?, ?)`,
+				doc.Type.String(), doc.ID, doc.Source, indexedAt, string(doc.Raw))
+			if err != nil {
+				return 0, err
+			}
+			if id, err = res.LastInsertId(); err != nil {
+				return 0, err
+			}
+		case err != nil:
+			return 0, err
+		default:
+			if _, err := tx.ExecContext(ctx, `UPDATE documents SET source = ?, indexed_at = ?, raw = ? WHERE id = ?`,
+				doc.Source, indexedAt, string(doc.Raw), id); err != nil {
+				return 0, err
+			}
+			if _, err := tx.ExecContext(ctx, `DELETE FROM documents_fts WHERE rowid = ?`, id); err != nil {
+				return 0, err
+			}
+		}
+		t, err := extractText(doc.Type, doc.Raw)
+		if err != nil {
+			return 0, fmt.Errorf("failed to index %s %s: %w", doc.Type, doc.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO documents_fts (rowid, asset_id, banners, titles, certs, software, other) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			id, doc.ID, t.banners.String(), t.titles.String(), t.certs.String(), t.software.String(), t.other.String()); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(docs), nil
+}
+
+// Clear removes every asset from the index.
+func (ix *Index) Clear(ctx context.Context) error {
+	_, err := ix.db.ExecContext(ctx, `DELETE FROM documents; DELETE FROM documents_fts;`)
+	return err
+}
+
+// Count returns the number of indexed assets.
+func (ix *Index) Count(ctx context.Context) (int, error) {
+	var n int
+	err := ix.db.QueryRowContext(ctx, `SELECT count(*) FROM documents`).Scan(&n)
+	return n, err
+}
+
+// Search returns the assets matching q, best match first.
+func (ix *Index) Search(ctx context.Context, q Query) ([]Hit, error) {
+	match, err := matchExpression(q.Text)
+	if err != nil {
+		return nil, err
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	stmt := `
+SELECT d.asset_type, d.asset_id, d.source, d.indexed_at, d.raw,
+       snippet(documents_fts, -1, '[', ']', '…', 12)
+FROM documents_fts
+JOIN documents d ON d.id = documents_fts.rowid
+WHERE documents_fts MATCH ?`
+	args := []any{match}
+	if q.Type != "" {
+		stmt += ` AND d.asset_type = ?`
+		args = append(args, q.Type.String())
+	}
+	stmt += ` ORDER BY rank LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := ix.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	hits := []Hit{}
+	for rows.Next() {
+		var h Hit
+		var assetType, indexedAt, raw string
+		if err := rows.Scan(&assetType, &h.ID, &h.Source, &indexedAt, &raw, &h.Snippet); err != nil {
+			return nil, err
+		}
+		h.Type = assets.AssetType(assetType)
+		h.IndexedAt, _ = time.Parse(time.RFC3339, indexedAt)
+		h.Asset = json.RawMessage(raw)
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+// matchExpression converts the terms of a query into an FTS5 expression in
+// which every term must match. Terms are quoted, so that punctuation such as
+// the dots of "1.18" is not parsed as FTS5 syntax.
+func matchExpression(text string) (string, error) {
+	var terms []string
+	for _, term := range splitTerms(text) {
+		prefix := strings.HasSuffix(term, "*")
+		term = strings.TrimRight(term, "*")
+		if strings.IndexFunc(term, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			continue
+		}
+		quoted := `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		if prefix {
+			quoted += "*"
+		}
+		terms = append(terms, quoted)
+	}
+	if len(terms) == 0 {
+		return "", ErrEmptyQuery
+	}
+	return strings.Join(terms, " AND "), nil
+}
+
+// splitTerms splits text on whitespace, keeping "quoted phrases" together.
+func splitTerms(text string) []string {
+	var terms []string
+	var cur strings.Builder
+	inQuotes := false
+	flush := func() {
+		if cur.Len() > 0 {
+			terms = append(terms, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range text {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			if !inQuotes {
+				flush()
+			}
+		case unicode.IsSpace(r) && !inQuotes:
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return terms
+}
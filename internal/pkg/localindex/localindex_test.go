@@ -0,0 +1,223 @@
+package localindex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/sessionarchive"
+	"github.com/censys/cencli/internal/pkg/sqliteexport"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func testHost() *assets.Host {
+	return &assets.Host{Host: components.Host{
+		IP:               ptr("10.0.0.1"),
+		AutonomousSystem: &components.Routing{Name: ptr("EXAMPLE-AS")},
+		Services: []components.Service{
+			{
+				Port: ptr(443), Protocol: ptr("HTTP"),
+				Software:  []components.Attribute{{Vendor: ptr("nginx"), Product: ptr("nginx"), Version: ptr("1.18.0")}},
+				Endpoints: []components.EndpointScanState{{HTTP: &components.HTTP{HTMLTitle: ptr("Welcome to nginx!")}}},
+				Cert: &components.Certificate{
+					Names:  []string{"www.example.com"},
+					Parsed: &components.CertificateParsed{SubjectDn: ptr("CN=www.example.com, O=Example Corp")},
+				},
+			},
+			{Port: ptr(22), Protocol: ptr("SSH"), Banner: ptr("SSH-2.0-OpenSSH_8.9p1 Ubuntu-3ubuntu0.1")},
+		},
+	}}
+}
+
+func testCert() *assets.Certificate {
+	return &assets.Certificate{Certificate: components.Certificate{
+		FingerprintSha256: ptr("abc123"),
+		Names:             []string{"mail.example.org"},
+		Parsed:            &components.CertificateParsed{IssuerDn: ptr("CN=Example Issuing CA")},
+	}}
+}
+
+func testWebProperty() *assets.WebProperty {
+	return &assets.WebProperty{Webproperty: components.Webproperty{
+		Hostname:  ptr("app.example.net"),
+		Port:      ptr(8443),
+		Endpoints: []components.EndpointScanState{{HTTP: &components.HTTP{HTMLTitle: ptr("Jenkins Dashboard")}}},
+	}}
+}
+
+func openTestIndex(t *testing.T) *Index {
+	t.Helper()
+	ix, err := Open(filepath.Join(t.TempDir(), FileName))
+	require.NoError(t, err)
+	t.Cleanup(func() { ix.Close() })
+	return ix
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
+
+func TestIndex_Search(t *testing.T) {
+	ctx := context.Background()
+	ix := openTestIndex(t)
+	docs, err := DocumentsFromJSON("session incident-42", mustJSON(t, []assets.Asset{testHost(), testCert(), testWebProperty()}))
+	require.NoError(t, err)
+	n, err := ix.Add(ctx, docs)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{name: "software and version", query: Query{Text: "nginx 1.18"}, want: []string{"10.0.0.1"}},
+		{name: "banner", query: Query{Text: "openssh_8.9p1"}, want: []string{"10.0.0.1"}},
+		{name: "title phrase", query: Query{Text: `"jenkins dashboard"`}, want: []string{"app.example.net:8443"}},
+		{name: "cert DN", query: Query{Text: "Example Issuing CA"}, want: []string{"abc123"}},
+		{name: "prefix", query: Query{Text: "exampl*"}, want: []string{"10.0.0.1", "abc123", "app.example.net:8443"}},
+		{name: "type filter", query: Query{Text: "exampl*", Type: assets.AssetTypeCertificate}, want: []string{"abc123"}},
+		{name: "every term must match", query: Query{Text: "nginx jenkins"}, want: nil},
+		{name: "limit", query: Query{Text: "exampl*", Limit: 1}, want: []string{"any"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hits, err := ix.Search(ctx, tc.query)
+			require.NoError(t, err)
+			if tc.query.Limit > 0 {
+				require.Len(t, hits, tc.query.Limit)
+				return
+			}
+			var ids []string
+			for _, h := range hits {
+				ids = append(ids, h.ID)
+				assert.Equal(t, "session incident-42", h.Source)
+			}
+			assert.ElementsMatch(t, tc.want, ids)
+		})
+	}
+
+	hits, err := ix.Search(ctx, Query{Text: "nginx"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Contains(t, hits[0].Snippet, "[nginx]")
+	var host components.Host
+	require.NoError(t, json.Unmarshal(hits[0].Asset, &host))
+	assert.Equal(t, "10.0.0.1", *host.IP)
+
+	_, err = ix.Search(ctx, Query{Text: ` " * `})
+	require.ErrorIs(t, err, ErrEmptyQuery)
+}
+
+func TestIndex_AddReplaces(t *testing.T) {
+	ctx := context.Background()
+	ix := openTestIndex(t)
+	host := testHost()
+	docs, err := DocumentsFromJSON("first", mustJSON(t, host))
+	require.NoError(t, err)
+	_, err = ix.Add(ctx, docs)
+	require.NoError(t, err)
+
+	host.Services[1].Banner = ptr("SSH-2.0-dropbear_2022.83")
+	docs, err = DocumentsFromJSON("second", mustJSON(t, host))
+	require.NoError(t, err)
+	_, err = ix.Add(ctx, docs)
+	require.NoError(t, err)
+
+	count, err := ix.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	hits, err := ix.Search(ctx, Query{Text: "openssh"})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+	hits, err = ix.Search(ctx, Query{Text: "dropbear"})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "second", hits[0].Source)
+
+	require.NoError(t, ix.Clear(ctx))
+	count, err = ix.Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestDocumentsFromJSON(t *testing.T) {
+	// NDJSON, with assets nested in other objects, and objects that are not assets
+	data := bytes.Join([][]byte{
+		mustJSON(t, map[string]any{"index": map[string]any{"_index": "censys-host", "_id": "10.0.0.1"}}),
+		mustJSON(t, testHost()),
+		mustJSON(t, map[string]any{"input": map[string]any{"asset": "abc123"}, "result": testCert()}),
+		[]byte(`{"ip": "10.0.0.2"}`),
+	}, []byte("\n"))
+	docs, err := DocumentsFromJSON("stdin", data)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, assets.AssetTypeHost, docs[0].Type)
+	assert.Equal(t, "10.0.0.1", docs[0].ID)
+	assert.Equal(t, assets.AssetTypeCertificate, docs[1].Type)
+	assert.Equal(t, "abc123", docs[1].ID)
+
+	_, err = DocumentsFromJSON("stdin", []byte("{"))
+	require.ErrorContains(t, err, "invalid JSON")
+}
+
+func TestReadFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	items := []assets.Asset{testHost(), testCert(), testWebProperty()}
+
+	t.Run("sqlite export", func(t *testing.T) {
+		path := filepath.Join(dir, "results.db")
+		_, err := sqliteexport.Export(ctx, path, items, sqliteexport.Options{Command: "search"})
+		require.NoError(t, err)
+		docs, err := ReadFile(ctx, path)
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+		assert.Equal(t, path, docs[0].Source)
+	})
+
+	t.Run("gzipped json", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, err := zw.Write(mustJSON(t, items))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		path := filepath.Join(dir, "results.json.gz")
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+		docs, err := ReadFile(ctx, path)
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+	})
+
+	t.Run("session archive", func(t *testing.T) {
+		response := mustJSON(t, items)
+		digest := sessionarchive.Digest(response)
+		var buf bytes.Buffer
+		require.NoError(t, sessionarchive.Write(&buf, sessionarchive.Archive{
+			Manifest: sessionarchive.Manifest{
+				Name:    "incident-42",
+				Entries: []sessionarchive.Entry{{Kind: "command", Command: "censys search x", Digest: digest}},
+			},
+			Responses: map[string][]byte{digest: response},
+		}))
+		path := filepath.Join(dir, "incident-42"+sessionarchive.FileExtension)
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+		docs, err := ReadFile(ctx, path)
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+		assert.Equal(t, path+" (session incident-42)", docs[0].Source)
+	})
+}
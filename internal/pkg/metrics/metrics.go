@@ -0,0 +1,262 @@
+// Package metrics collects operational metrics for a single cencli run
+// (API calls, pages fetched, retries, latency and errors) and writes them
+// as JSON or in the OpenMetrics text exposition format.
+//
+// The Censys API does not report credit usage per response, so credits
+// consumed are not tracked here; use "censys credits" or "censys org credits"
+// before and after a run to measure consumption.
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds (in seconds) of the request latency histogram.
+var LatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+const namespace = "cencli"
+
+// Collector accumulates metrics for the lifetime of a process.
+// It is safe for concurrent use.
+type Collector struct {
+	mu           sync.Mutex
+	start        time.Time
+	now          func() time.Time
+	operations   map[string]*operationStats
+	pagesFetched uint64
+}
+
+type operationStats struct {
+	requests     uint64
+	errors       uint64
+	retries      uint64
+	statusCodes  map[int]uint64
+	bucketCounts []uint64
+	latencySum   float64
+}
+
+// NewCollector creates an empty collector. The run duration is measured from this call.
+func NewCollector() *Collector {
+	return &Collector{
+		start:      time.Now(),
+		now:        time.Now,
+		operations: make(map[string]*operationStats),
+	}
+}
+
+// ObserveCall records a single API call. attempts is the total number of HTTP
+// attempts made (so attempts-1 retries), and statusCode is 0 if unknown.
+func (c *Collector) ObserveCall(operation string, latency time.Duration, attempts uint64, statusCode int, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats, ok := c.operations[operation]
+	if !ok {
+		stats = &operationStats{
+			statusCodes:  make(map[int]uint64),
+			bucketCounts: make([]uint64, len(LatencyBuckets)),
+		}
+		c.operations[operation] = stats
+	}
+	stats.requests++
+	if failed {
+		stats.errors++
+	}
+	if attempts > 1 {
+		stats.retries += attempts - 1
+	}
+	if statusCode > 0 {
+		stats.statusCodes[statusCode]++
+	}
+	seconds := latency.Seconds()
+	stats.latencySum += seconds
+	for i, le := range LatencyBuckets {
+		if seconds <= le {
+			stats.bucketCounts[i]++
+		}
+	}
+}
+
+// ObservePages records that n result pages were fetched.
+func (c *Collector) ObservePages(n int) {
+	if n <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pagesFetched += uint64(n)
+}
+
+// Snapshot is a point-in-time view of the collected metrics.
+type Snapshot struct {
+	StartedAt       time.Time                    `json:"started_at"`
+	DurationSeconds float64                      `json:"duration_seconds"`
+	Requests        uint64                       `json:"requests"`
+	Errors          uint64                       `json:"errors"`
+	Retries         uint64                       `json:"retries"`
+	PagesFetched    uint64                       `json:"pages_fetched"`
+	Operations      map[string]OperationSnapshot `json:"operations"`
+}
+
+// OperationSnapshot holds the metrics for a single client operation.
+type OperationSnapshot struct {
+	Requests    uint64            `json:"requests"`
+	Errors      uint64            `json:"errors"`
+	Retries     uint64            `json:"retries"`
+	StatusCodes map[string]uint64 `json:"status_codes,omitempty"`
+	Latency     Histogram         `json:"latency_seconds"`
+}
+
+// Histogram is a cumulative latency histogram.
+type Histogram struct {
+	Buckets map[string]uint64 `json:"buckets"`
+	Sum     float64           `json:"sum"`
+	Count   uint64            `json:"count"`
+}
+
+// Snapshot returns the current state of the collector.
+func (c *Collector) Snapshot() Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snap := Snapshot{
+		StartedAt:       c.start.UTC(),
+		DurationSeconds: c.now().Sub(c.start).Seconds(),
+		PagesFetched:    c.pagesFetched,
+		Operations:      make(map[string]OperationSnapshot, len(c.operations)),
+	}
+	for name, stats := range c.operations {
+		op := OperationSnapshot{
+			Requests: stats.requests,
+			Errors:   stats.errors,
+			Retries:  stats.retries,
+			Latency: Histogram{
+				Buckets: make(map[string]uint64, len(LatencyBuckets)+1),
+				Sum:     stats.latencySum,
+				Count:   stats.requests,
+			},
+		}
+		if len(stats.statusCodes) > 0 {
+			op.StatusCodes = make(map[string]uint64, len(stats.statusCodes))
+			for code, n := range stats.statusCodes {
+				op.StatusCodes[strconv.Itoa(code)] = n
+			}
+		}
+		for i, le := range LatencyBuckets {
+			op.Latency.Buckets[formatFloat(le)] = stats.bucketCounts[i]
+		}
+		op.Latency.Buckets["+Inf"] = stats.requests
+		snap.Operations[name] = op
+		snap.Requests += stats.requests
+		snap.Errors += stats.errors
+		snap.Retries += stats.retries
+	}
+	return snap
+}
+
+// WriteJSON writes the current snapshot as indented JSON.
+func (c *Collector) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c.Snapshot())
+}
+
+// WriteOpenMetrics writes the current snapshot in the OpenMetrics text format.
+func (c *Collector) WriteOpenMetrics(w io.Writer) error {
+	snap := c.Snapshot()
+	names := make([]string, 0, len(snap.Operations))
+	for name := range snap.Operations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	writeFamily := func(metric, typ, help string, samples func()) {
+		fmt.Fprintf(&b, "# TYPE %s_%s %s\n", namespace, metric, typ)
+		fmt.Fprintf(&b, "# HELP %s_%s %s\n", namespace, metric, help)
+		samples()
+	}
+	perOperation := func(metric string, value func(OperationSnapshot) uint64) func() {
+		return func() {
+			for _, name := range names {
+				fmt.Fprintf(&b, "%s_%s_total{operation=%q} %d\n", namespace, metric, name, value(snap.Operations[name]))
+			}
+		}
+	}
+
+	writeFamily("api_requests", "counter", "API calls made, by client operation.",
+		perOperation("api_requests", func(o OperationSnapshot) uint64 { return o.Requests }))
+	writeFamily("api_errors", "counter", "API calls that returned an error, by client operation.",
+		perOperation("api_errors", func(o OperationSnapshot) uint64 { return o.Errors }))
+	writeFamily("api_retries", "counter", "Retried HTTP attempts, by client operation.",
+		perOperation("api_retries", func(o OperationSnapshot) uint64 { return o.Retries }))
+	writeFamily("api_responses", "counter", "API responses, by client operation and HTTP status code.", func() {
+		for _, name := range names {
+			codes := make([]string, 0, len(snap.Operations[name].StatusCodes))
+			for code := range snap.Operations[name].StatusCodes {
+				codes = append(codes, code)
+			}
+			sort.Strings(codes)
+			for _, code := range codes {
+				fmt.Fprintf(&b, "%s_api_responses_total{operation=%q,code=%q} %d\n",
+					namespace, name, code, snap.Operations[name].StatusCodes[code])
+			}
+		}
+	})
+	writeFamily("api_request_duration_seconds", "histogram", "API call latency, including retries.", func() {
+		for _, name := range names {
+			h := snap.Operations[name].Latency
+			for _, le := range LatencyBuckets {
+				fmt.Fprintf(&b, "%s_api_request_duration_seconds_bucket{operation=%q,le=%q} %d\n",
+					namespace, name, formatFloat(le), h.Buckets[formatFloat(le)])
+			}
+			fmt.Fprintf(&b, "%s_api_request_duration_seconds_bucket{operation=%q,le=\"+Inf\"} %d\n", namespace, name, h.Count)
+			fmt.Fprintf(&b, "%s_api_request_duration_seconds_sum{operation=%q} %s\n", namespace, name, formatFloat(h.Sum))
+			fmt.Fprintf(&b, "%s_api_request_duration_seconds_count{operation=%q} %d\n", namespace, name, h.Count)
+		}
+	})
+	writeFamily("pages_fetched", "counter", "Result pages fetched.", func() {
+		fmt.Fprintf(&b, "%s_pages_fetched_total %d\n", namespace, snap.PagesFetched)
+	})
+	writeFamily("run_duration_seconds", "gauge", "Wall-clock duration of the run.", func() {
+		fmt.Fprintf(&b, "%s_run_duration_seconds %s\n", namespace, formatFloat(snap.DurationSeconds))
+	})
+	b.WriteString("# EOF\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteFile writes the metrics to path. Files ending in ".json" are written
+// as JSON; anything else is written in the OpenMetrics text format.
+func (c *Collector) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = c.WriteJSON(f)
+	} else {
+		err = c.WriteOpenMetrics(f)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
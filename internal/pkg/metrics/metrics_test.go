@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCollector() *Collector {
+	c := NewCollector()
+	c.start = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return c.start.Add(90 * time.Second) }
+	c.ObserveCall("search", 80*time.Millisecond, 1, 200, false)
+	c.ObserveCall("search", 3*time.Second, 3, 200, false)
+	c.ObserveCall("get_hosts", 40*time.Second, 2, 503, true)
+	c.ObservePages(2)
+	return c
+}
+
+func TestCollector_Snapshot(t *testing.T) {
+	snap := newTestCollector().Snapshot()
+
+	require.Equal(t, uint64(3), snap.Requests)
+	require.Equal(t, uint64(1), snap.Errors)
+	require.Equal(t, uint64(3), snap.Retries)
+	require.Equal(t, uint64(2), snap.PagesFetched)
+	require.InDelta(t, 90.0, snap.DurationSeconds, 0.001)
+
+	search := snap.Operations["search"]
+	require.Equal(t, uint64(2), search.Requests)
+	require.Equal(t, uint64(2), search.Retries)
+	require.Equal(t, map[string]uint64{"200": 2}, search.StatusCodes)
+	require.Equal(t, uint64(0), search.Latency.Buckets["0.05"])
+	require.Equal(t, uint64(1), search.Latency.Buckets["0.1"])
+	require.Equal(t, uint64(2), search.Latency.Buckets["5"])
+	require.Equal(t, uint64(2), search.Latency.Buckets["+Inf"])
+
+	hosts := snap.Operations["get_hosts"]
+	require.Equal(t, uint64(1), hosts.Errors)
+	require.Equal(t, uint64(0), hosts.Latency.Buckets["30"])
+	require.Equal(t, uint64(1), hosts.Latency.Buckets["+Inf"])
+}
+
+func TestCollector_WriteOpenMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newTestCollector().WriteOpenMetrics(&buf))
+	out := buf.String()
+
+	require.Contains(t, out, "# TYPE cencli_api_requests counter\n")
+	require.Contains(t, out, `cencli_api_requests_total{operation="search"} 2`)
+	require.Contains(t, out, `cencli_api_errors_total{operation="get_hosts"} 1`)
+	require.Contains(t, out, `cencli_api_responses_total{operation="get_hosts",code="503"} 1`)
+	require.Contains(t, out, `cencli_api_request_duration_seconds_bucket{operation="search",le="0.1"} 1`)
+	require.Contains(t, out, `cencli_api_request_duration_seconds_bucket{operation="search",le="+Inf"} 2`)
+	require.Contains(t, out, `cencli_api_request_duration_seconds_count{operation="search"} 2`)
+	require.Contains(t, out, "cencli_pages_fetched_total 2\n")
+	require.Contains(t, out, "cencli_run_duration_seconds 90\n")
+	require.True(t, strings.HasSuffix(out, "# EOF\n"))
+	// operations are sorted for stable output
+	require.Less(t, strings.Index(out, `operation="get_hosts"`), strings.Index(out, `operation="search"`))
+}
+
+func TestCollector_WriteFile(t *testing.T) {
+	dir := t.TempDir()
+	c := newTestCollector()
+
+	jsonPath := filepath.Join(dir, "metrics.json")
+	require.NoError(t, c.WriteFile(jsonPath))
+	raw, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var snap Snapshot
+	require.NoError(t, json.Unmarshal(raw, &snap))
+	require.Equal(t, uint64(3), snap.Requests)
+
+	promPath := filepath.Join(dir, "metrics.prom")
+	require.NoError(t, c.WriteFile(promPath))
+	raw, err = os.ReadFile(promPath)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), "# EOF")
+
+	require.Error(t, c.WriteFile(filepath.Join(dir, "missing", "metrics.json")))
+}
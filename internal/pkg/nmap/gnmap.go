@@ -0,0 +1,60 @@
+package nmap
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// ctimeLayout is the layout of the times in nmap output.
+const ctimeLayout = "Mon Jan _2 15:04:05 2006"
+
+// gnmapReplacer escapes the field separators of a greppable port entry in
+// free-text fields, as nmap does.
+var gnmapReplacer = strings.NewReplacer("/", "|", ",", " ", "\t", " ", "\n", " ", "\r", " ")
+
+// WriteGreppable writes the hosts among items to w in the greppable nmap
+// format, and returns the number of hosts written.
+func WriteGreppable(w io.Writer, items []assets.Asset, opts Options) (int, error) {
+	now := opts.now()
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Nmap %s scan initiated %s as: %s\n", nmapVersion, now.Format(ctimeLayout), opts.Args)
+	n := 0
+	for _, host := range hosts(items) {
+		ip := deref(host.IP)
+		name := ""
+		if names := hostnames(host); len(names) > 0 {
+			name = names[0]
+		}
+		prefix := fmt.Sprintf("Host: %s (%s)", ip, name)
+		fmt.Fprintf(&b, "%s\tStatus: Up\n", prefix)
+		entries := make([]string, 0, len(host.Services))
+		for _, p := range ports(host) {
+			service := p.service
+			if p.tunnel != "" {
+				service = p.tunnel + "|" + service
+			}
+			version := gnmapReplacer.Replace(strings.TrimSpace(p.product + " " + p.version))
+			entries = append(entries, fmt.Sprintf("%d/open/%s//%s//%s/", p.number, p.protocol, service, version))
+		}
+		if len(entries) > 0 {
+			fmt.Fprintf(&b, "%s\tPorts: %s\n", prefix, strings.Join(entries, ", "))
+		}
+		n++
+	}
+	fmt.Fprintf(&b, "# Nmap done at %s -- %s scanned in 0.00 seconds\n", now.Format(ctimeLayout), hostsUp(n))
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// hostsUp returns the host count of the nmap summary line.
+func hostsUp(n int) string {
+	if n == 1 {
+		return "1 IP address (1 host up)"
+	}
+	return fmt.Sprintf("%d IP addresses (%d hosts up)", n, n)
+}
@@ -0,0 +1,170 @@
+// Package nmap writes hosts as nmap output, so that tools that import nmap
+// scans (e.g. EyeWitness, or db_import in Metasploit) can ingest them. Each
+// service of a host is written as an open port, with the nmap name of its
+// protocol, its software, and its banner. Other assets have no nmap
+// representation, and are skipped.
+package nmap
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+const (
+	// FormatXML is the name of the nmap XML format (-oX), as given to --format.
+	FormatXML = "nmap-xml"
+	// FormatGreppable is the name of the greppable format (-oG), as given to --format.
+	FormatGreppable = "gnmap"
+
+	// nmapVersion is the nmap version reported in the output. Importers check
+	// that the output looks like it was written by a recent nmap.
+	nmapVersion = "7.94"
+)
+
+// Options configures the output.
+type Options struct {
+	// Args is the command line reported as the scan that produced the output.
+	Args string
+	// Now returns the time of the scan. Defaults to time.Now.
+	Now func() time.Time
+}
+
+func (o Options) now() time.Time {
+	if o.Now != nil {
+		return o.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+// serviceNames maps Censys protocols to nmap service names, where they differ
+// from the lowercase protocol.
+var serviceNames = map[string]string{
+	"RDP":      "ms-wbt-server",
+	"SMB":      "microsoft-ds",
+	"MSSQL":    "ms-sql-s",
+	"POSTGRES": "postgresql",
+	"DNS":      "domain",
+	"IPMI":     "asf-rmcp",
+	"NETBIOS":  "netbios-ssn",
+	"UNKNOWN":  "unknown",
+}
+
+// port is a service of a host, as nmap reports it.
+type port struct {
+	number   int
+	protocol string
+	service  string
+	// tunnel is "ssl" for services behind TLS.
+	tunnel  string
+	product string
+	version string
+	cpes    []string
+	banner  string
+}
+
+// hosts returns the hosts among items.
+func hosts(items []assets.Asset) []*assets.Host {
+	var out []*assets.Host
+	for _, item := range items {
+		switch h := item.(type) {
+		case *assets.Host:
+			out = append(out, h)
+		case assets.Host:
+			out = append(out, &h)
+		}
+	}
+	return out
+}
+
+// ports returns the services of a host that nmap can represent: TCP and UDP
+// services (QUIC is reported as UDP).
+func ports(host *assets.Host) []port {
+	var out []port
+	for _, svc := range host.Services {
+		p := port{number: deref(svc.Port), service: serviceName(deref(svc.Protocol)), banner: deref(svc.Banner)}
+		switch deref(svc.TransportProtocol) {
+		case components.ServiceTransportProtocolTCP, components.ServiceTransportProtocolUnknown:
+			p.protocol = "tcp"
+		case components.ServiceTransportProtocolUDP, components.ServiceTransportProtocolQuic:
+			p.protocol = "udp"
+		default:
+			continue
+		}
+		if p.number <= 0 {
+			continue
+		}
+		if svc.TLS != nil || svc.Cert != nil {
+			p.tunnel = "ssl"
+		}
+		for _, attr := range svc.Software {
+			if p.product == "" && deref(attr.Product) != "" {
+				p.product = strings.TrimSpace(deref(attr.Vendor) + " " + deref(attr.Product))
+				p.version = deref(attr.Version)
+			}
+			if cpe := nmapCPE(deref(attr.Cpe)); cpe != "" {
+				p.cpes = append(p.cpes, cpe)
+			}
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func serviceName(protocol string) string {
+	protocol = strings.ToUpper(protocol)
+	if protocol == "" {
+		return "unknown"
+	}
+	if name, ok := serviceNames[protocol]; ok {
+		return name
+	}
+	return strings.ToLower(protocol)
+}
+
+// nmapCPE converts a CPE 2.3 name (cpe:2.3:a:vendor:product:version:...) to
+// the CPE 2.2 URI that nmap reports (cpe:/a:vendor:product:version).
+func nmapCPE(cpe string) string {
+	rest, ok := strings.CutPrefix(cpe, "cpe:2.3:")
+	if !ok {
+		if strings.HasPrefix(cpe, "cpe:/") {
+			return cpe
+		}
+		return ""
+	}
+	fields := strings.Split(rest, ":")
+	if len(fields) > 4 {
+		fields = fields[:4]
+	}
+	for len(fields) > 1 && (fields[len(fields)-1] == "*" || fields[len(fields)-1] == "-") {
+		fields = fields[:len(fields)-1]
+	}
+	return "cpe:/" + strings.Join(fields, ":")
+}
+
+// hostnames returns the reverse DNS names of a host.
+func hostnames(host *assets.Host) []string {
+	if host.DNS == nil || host.DNS.ReverseDNS == nil {
+		return nil
+	}
+	return host.DNS.ReverseDNS.Names
+}
+
+func addrType(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,105 @@
+package nmap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+var testOptions = Options{
+	Args: "cencli search services.port: 443",
+	Now:  func() time.Time { return time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC) },
+}
+
+func testItems() []assets.Asset {
+	tcp, udp, icmp := components.ServiceTransportProtocolTCP, components.ServiceTransportProtocolUDP, components.ServiceTransportProtocolIcmp
+	return []assets.Asset{
+		&assets.Host{Host: components.Host{
+			IP:  ptr("10.0.0.1"),
+			DNS: &components.HostDNS{ReverseDNS: &components.HostDNSReverseResolution{Names: []string{"www.example.com"}}},
+			Services: []components.Service{
+				{Port: ptr(22), Protocol: ptr("SSH"), TransportProtocol: &tcp, Banner: ptr("SSH-2.0-OpenSSH_8.9")},
+				{
+					Port: ptr(443), Protocol: ptr("HTTP"), TransportProtocol: &tcp,
+					TLS: &components.TLS{},
+					Software: []components.Attribute{{
+						Vendor: ptr("nginx"), Product: ptr("nginx"), Version: ptr("1.18.0"),
+						Cpe: ptr("cpe:2.3:a:nginx:nginx:1.18.0:*:*:*:*:*:*:*"),
+					}},
+				},
+				{Port: ptr(3389), Protocol: ptr("RDP"), TransportProtocol: &tcp},
+				{Port: ptr(53), Protocol: ptr("DNS"), TransportProtocol: &udp},
+				// ICMP has no port, so nmap cannot represent it
+				{Port: ptr(0), Protocol: ptr("ICMP"), TransportProtocol: &icmp},
+			},
+		}},
+		&assets.Host{Host: components.Host{IP: ptr("2001:db8::1")}},
+		// not a host
+		&assets.Certificate{},
+	}
+}
+
+func TestWriteXML(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := WriteXML(&buf, testItems(), testOptions)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	out := buf.String()
+	assert.Contains(t, out, "<!DOCTYPE nmaprun>\n<nmaprun scanner=\"nmap\" args=\"cencli search services.port: 443\" start=\"1741064767\"")
+	assert.Contains(t, out, `<scaninfo type="connect" protocol="tcp" numservices="3" services="22,443,3389"></scaninfo>`)
+	assert.Contains(t, out, `<scaninfo type="connect" protocol="udp" numservices="1" services="53"></scaninfo>`)
+
+	var run xmlRun
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &run))
+	require.Len(t, run.Hosts, 2)
+	host := run.Hosts[0]
+	assert.Equal(t, xmlAddress{Addr: "10.0.0.1", AddrType: "ipv4"}, host.Address)
+	assert.Equal(t, []xmlHostname{{Name: "www.example.com", Type: "PTR"}}, host.Hostnames)
+	require.Len(t, host.Ports, 4)
+	assert.Equal(t, "ssh", host.Ports[0].Service.Name)
+	assert.Equal(t, []xmlScript{{ID: "banner", Output: "SSH-2.0-OpenSSH_8.9"}}, host.Ports[0].Scripts)
+	assert.Equal(t, xmlService{
+		Name: "http", Product: "nginx nginx", Version: "1.18.0", Tunnel: "ssl",
+		Method: "probed", Conf: 10, CPEs: []string{"cpe:/a:nginx:nginx:1.18.0"},
+	}, host.Ports[1].Service)
+	assert.Equal(t, "ms-wbt-server", host.Ports[2].Service.Name)
+	assert.Equal(t, "udp", host.Ports[3].Protocol)
+	assert.Equal(t, "domain", host.Ports[3].Service.Name)
+	assert.Equal(t, xmlAddress{Addr: "2001:db8::1", AddrType: "ipv6"}, run.Hosts[1].Address)
+	assert.Equal(t, xmlHostStat{Up: 2, Total: 2}, run.RunStats.Hosts)
+}
+
+func TestWriteGreppable(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := WriteGreppable(&buf, testItems(), testOptions)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "# Nmap 7.94 scan initiated Tue Mar  4 05:06:07 2025 as: cencli search services.port: 443\n"+
+		"Host: 10.0.0.1 (www.example.com)\tStatus: Up\n"+
+		"Host: 10.0.0.1 (www.example.com)\tPorts: 22/open/tcp//ssh///, 443/open/tcp//ssl|http//nginx nginx 1.18.0/, "+
+		"3389/open/tcp//ms-wbt-server///, 53/open/udp//domain///\n"+
+		"Host: 2001:db8::1 ()\tStatus: Up\n"+
+		"# Nmap done at Tue Mar  4 05:06:07 2025 -- 2 IP addresses (2 hosts up) scanned in 0.00 seconds\n",
+		buf.String())
+}
+
+func TestNmapCPE(t *testing.T) {
+	for in, want := range map[string]string{
+		"cpe:2.3:a:nginx:nginx:1.18.0:*:*:*:*:*:*:*":  "cpe:/a:nginx:nginx:1.18.0",
+		"cpe:2.3:o:microsoft:windows:*:*:*:*:*:*:*:*": "cpe:/o:microsoft:windows",
+		"cpe:/a:openbsd:openssh:8.9":                  "cpe:/a:openbsd:openssh:8.9",
+		"":                                            "",
+	} {
+		assert.Equal(t, want, nmapCPE(in), in)
+	}
+}
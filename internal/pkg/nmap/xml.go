@@ -0,0 +1,197 @@
+package nmap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+type xmlRun struct {
+	XMLName          xml.Name    `xml:"nmaprun"`
+	Scanner          string      `xml:"scanner,attr"`
+	Args             string      `xml:"args,attr"`
+	Start            int64       `xml:"start,attr"`
+	StartStr         string      `xml:"startstr,attr"`
+	Version          string      `xml:"version,attr"`
+	XMLOutputVersion string      `xml:"xmloutputversion,attr"`
+	ScanInfo         []xmlScan   `xml:"scaninfo"`
+	Hosts            []xmlHost   `xml:"host"`
+	RunStats         xmlRunStats `xml:"runstats"`
+}
+
+type xmlScan struct {
+	Type        string `xml:"type,attr"`
+	Protocol    string `xml:"protocol,attr"`
+	NumServices int    `xml:"numservices,attr"`
+	Services    string `xml:"services,attr"`
+}
+
+type xmlHost struct {
+	StartTime int64         `xml:"starttime,attr"`
+	EndTime   int64         `xml:"endtime,attr"`
+	Status    xmlStatus     `xml:"status"`
+	Address   xmlAddress    `xml:"address"`
+	Hostnames []xmlHostname `xml:"hostnames>hostname"`
+	Ports     []xmlPort     `xml:"ports>port"`
+}
+
+type xmlStatus struct {
+	State     string `xml:"state,attr"`
+	Reason    string `xml:"reason,attr"`
+	ReasonTTL int    `xml:"reason_ttl,attr"`
+}
+
+type xmlAddress struct {
+	Addr     string `xml:"addr,attr"`
+	AddrType string `xml:"addrtype,attr"`
+}
+
+type xmlHostname struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type xmlPort struct {
+	Protocol string      `xml:"protocol,attr"`
+	PortID   int         `xml:"portid,attr"`
+	State    xmlStatus   `xml:"state"`
+	Service  xmlService  `xml:"service"`
+	Scripts  []xmlScript `xml:"script,omitempty"`
+}
+
+type xmlService struct {
+	Name    string   `xml:"name,attr"`
+	Product string   `xml:"product,attr,omitempty"`
+	Version string   `xml:"version,attr,omitempty"`
+	Tunnel  string   `xml:"tunnel,attr,omitempty"`
+	Method  string   `xml:"method,attr"`
+	Conf    int      `xml:"conf,attr"`
+	CPEs    []string `xml:"cpe,omitempty"`
+}
+
+type xmlScript struct {
+	ID     string `xml:"id,attr"`
+	Output string `xml:"output,attr"`
+}
+
+type xmlRunStats struct {
+	Finished xmlFinished `xml:"finished"`
+	Hosts    xmlHostStat `xml:"hosts"`
+}
+
+type xmlFinished struct {
+	Time    int64  `xml:"time,attr"`
+	TimeStr string `xml:"timestr,attr"`
+	Elapsed string `xml:"elapsed,attr"`
+	Summary string `xml:"summary,attr"`
+	Exit    string `xml:"exit,attr"`
+}
+
+type xmlHostStat struct {
+	Up    int `xml:"up,attr"`
+	Down  int `xml:"down,attr"`
+	Total int `xml:"total,attr"`
+}
+
+// WriteXML writes the hosts among items to w in the nmap XML format, and
+// returns the number of hosts written.
+func WriteXML(w io.Writer, items []assets.Asset, opts Options) (int, error) {
+	now := opts.now()
+	run := xmlRun{
+		Scanner:          "nmap",
+		Args:             opts.Args,
+		Start:            now.Unix(),
+		StartStr:         now.Format(ctimeLayout),
+		Version:          nmapVersion,
+		XMLOutputVersion: "1.05",
+	}
+	scanned := map[string]map[int]bool{"tcp": {}, "udp": {}}
+	for _, host := range hosts(items) {
+		ip := deref(host.IP)
+		h := xmlHost{
+			StartTime: now.Unix(),
+			EndTime:   now.Unix(),
+			Status:    xmlStatus{State: "up", Reason: "user-set"},
+			Address:   xmlAddress{Addr: ip, AddrType: addrType(ip)},
+		}
+		for _, name := range hostnames(host) {
+			h.Hostnames = append(h.Hostnames, xmlHostname{Name: name, Type: "PTR"})
+		}
+		for _, p := range ports(host) {
+			scanned[p.protocol][p.number] = true
+			xp := xmlPort{
+				Protocol: p.protocol,
+				PortID:   p.number,
+				State:    xmlStatus{State: "open", Reason: "syn-ack"},
+				Service: xmlService{
+					Name:    p.service,
+					Product: p.product,
+					Version: p.version,
+					Tunnel:  p.tunnel,
+					Method:  "probed",
+					Conf:    10,
+					CPEs:    p.cpes,
+				},
+			}
+			if p.banner != "" {
+				xp.Scripts = append(xp.Scripts, xmlScript{ID: "banner", Output: p.banner})
+			}
+			h.Ports = append(h.Ports, xp)
+		}
+		run.Hosts = append(run.Hosts, h)
+	}
+	for _, protocol := range []string{"tcp", "udp"} {
+		if len(scanned[protocol]) == 0 {
+			continue
+		}
+		run.ScanInfo = append(run.ScanInfo, xmlScan{
+			Type:        "connect",
+			Protocol:    protocol,
+			NumServices: len(scanned[protocol]),
+			Services:    portList(scanned[protocol]),
+		})
+	}
+	n := len(run.Hosts)
+	run.RunStats = xmlRunStats{
+		Finished: xmlFinished{
+			Time:    now.Unix(),
+			TimeStr: now.Format(ctimeLayout),
+			Elapsed: "0.00",
+			Summary: fmt.Sprintf("Nmap done at %s; %s scanned in 0.00 seconds", now.Format(ctimeLayout), hostsUp(n)),
+			Exit:    "success",
+		},
+		Hosts: xmlHostStat{Up: n, Total: n},
+	}
+
+	if _, err := io.WriteString(w, xml.Header+"<!DOCTYPE nmaprun>\n"); err != nil {
+		return 0, err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(run); err != nil {
+		return 0, fmt.Errorf("failed to write nmap XML: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// portList returns ports as nmap lists them: sorted and comma-separated.
+func portList(ports map[int]bool) string {
+	sorted := make([]int, 0, len(ports))
+	for p := range ports {
+		sorted = append(sorted, p)
+	}
+	sort.Ints(sorted)
+	parts := make([]string, len(sorted))
+	for i, p := range sorted {
+		parts[i] = strconv.Itoa(p)
+	}
+	return strings.Join(parts, ",")
+}
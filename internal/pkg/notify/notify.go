@@ -0,0 +1,94 @@
+// Package notify delivers JSON payloads to external destinations,
+// either by POSTing them to a webhook or by piping them to a command.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// Notifier delivers a payload to an external destination.
+type Notifier interface {
+	Notify(ctx context.Context, payload any) error
+}
+
+// DefaultTimeout bounds a single webhook delivery.
+const DefaultTimeout = 30 * time.Second
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook returns a Notifier that POSTs the payload as JSON to url.
+// Any non-2xx response is treated as a failure. If client is nil,
+// a client with DefaultTimeout is used.
+func NewWebhook(url string, client *http.Client) Notifier {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &webhookNotifier{url: url, client: client}
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+type execNotifier struct {
+	command string
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+// NewExec returns a Notifier that runs command through the system shell
+// with the payload written to its stdin as JSON. The command's output is
+// forwarded to stderr so that it does not mix with cencli's data output.
+func NewExec(command string) Notifier {
+	return &execNotifier{command: command, stdout: os.Stderr, stderr: os.Stderr}
+}
+
+func (n *execNotifier) Notify(ctx context.Context, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode exec payload: %w", err)
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", n.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", n.command)
+	}
+	cmd.Stdin = bytes.NewReader(append(body, '\n'))
+	cmd.Stdout = n.stdout
+	cmd.Stderr = n.stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("notify command %q failed: %w", n.command, err)
+	}
+	return nil
+}
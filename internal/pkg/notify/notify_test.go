@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	t.Run("posts json payload", func(t *testing.T) {
+		var got map[string]any
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &got))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		err := NewWebhook(srv.URL, nil).Notify(context.Background(), map[string]any{"new": 2})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"new": float64(2)}, got)
+	})
+
+	t.Run("non-2xx is an error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer srv.Close()
+
+		err := NewWebhook(srv.URL, nil).Notify(context.Background(), "x")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "502")
+	})
+}
+
+func TestExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	t.Run("pipes payload to command", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "payload.json")
+		n := NewExec("cat > " + out).(*execNotifier)
+		var stderr bytes.Buffer
+		n.stdout, n.stderr = &stderr, &stderr
+
+		require.NoError(t, n.Notify(context.Background(), []string{"a", "b"}))
+		data, err := os.ReadFile(out)
+		require.NoError(t, err)
+		require.JSONEq(t, `["a","b"]`, string(data))
+	})
+
+	t.Run("non-zero exit is an error", func(t *testing.T) {
+		n := NewExec("exit 3").(*execNotifier)
+		var stderr bytes.Buffer
+		n.stdout, n.stderr = &stderr, &stderr
+
+		err := n.Notify(context.Background(), nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exit 3")
+	})
+}
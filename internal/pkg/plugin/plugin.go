@@ -0,0 +1,237 @@
+// Package plugin discovers and executes external cencli plugins.
+//
+// A plugin is any executable on PATH whose file name starts with "cencli-".
+// The remainder of the file name (minus any executable extension on Windows)
+// is the subcommand name, so an executable named "cencli-foo" is invoked by
+// running "censys foo [args...]". Built-in commands always take precedence
+// over plugins with the same name.
+//
+// Plugin contract:
+//
+//   - All arguments following the plugin name are passed through unmodified.
+//   - stdin, stdout and stderr are inherited from the parent process.
+//   - The plugin's exit code becomes the exit code of cencli.
+//   - The parent environment is passed through, with the variables below
+//     added (or overwritten) to give the plugin structured context.
+//
+// Environment variables:
+//
+//	CENCLI_PLUGIN_API_VERSION  version of this contract (currently "1")
+//	CENCLI_PLUGIN_NAME         name the plugin was invoked as (e.g. "foo")
+//	CENCLI_BIN                 absolute path of the invoking cencli binary
+//	CENCLI_CONFIG_DIR          cencli config directory (config.yaml, templates)
+//	CENCLI_DATA_DIR            cencli data directory (local database)
+//	CENCLI_CACHE_DIR           cencli cache directory
+//	CENCLI_ORG_ID              active organization ID (omitted if not configured)
+//	CENCLI_AUTH_TOKEN          active personal access token (omitted if not configured)
+//
+// Plugins should treat CENCLI_AUTH_TOKEN as a secret and must not log it.
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+const (
+	// Prefix is the file name prefix that identifies a plugin executable.
+	Prefix = "cencli-"
+	// APIVersion is the version of the plugin contract described in the package docs.
+	APIVersion = "1"
+)
+
+// Environment variables set for every plugin invocation.
+const (
+	EnvAPIVersion = "CENCLI_PLUGIN_API_VERSION"
+	EnvName       = "CENCLI_PLUGIN_NAME"
+	EnvBinary     = "CENCLI_BIN"
+	EnvConfigDir  = "CENCLI_CONFIG_DIR"
+	EnvDataDir    = "CENCLI_DATA_DIR"
+	EnvCacheDir   = "CENCLI_CACHE_DIR"
+	EnvOrgID      = "CENCLI_ORG_ID"
+	EnvAuthToken  = "CENCLI_AUTH_TOKEN"
+)
+
+// Plugin is a discovered plugin executable.
+type Plugin struct {
+	// Name is the subcommand name the plugin is invoked as.
+	Name string `json:"name"`
+	// Path is the absolute path of the executable.
+	Path string `json:"path"`
+	// Shadowed lists executables with the same name that appear
+	// later on PATH and are therefore never invoked.
+	Shadowed []string `json:"shadowed,omitempty"`
+}
+
+// Env is the structured context passed to a plugin.
+type Env struct {
+	ConfigDir string
+	DataDir   string
+	CacheDir  string
+	OrgID     string
+	AuthToken string
+	Binary    string
+}
+
+// Discover returns all plugins found in the directories of pathEnv
+// (formatted like the PATH environment variable), sorted by name.
+// When multiple executables share a name, the first one on PATH wins.
+func Discover(pathEnv string) []Plugin {
+	byName := make(map[string]*Plugin)
+	var order []string
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			if abs, err := filepath.Abs(path); err == nil {
+				path = abs
+			}
+			if existing, ok := byName[name]; ok {
+				if existing.Path != path {
+					existing.Shadowed = append(existing.Shadowed, path)
+				}
+				continue
+			}
+			byName[name] = &Plugin{Name: name, Path: path}
+			order = append(order, name)
+		}
+	}
+	sort.Strings(order)
+	plugins := make([]Plugin, 0, len(order))
+	for _, name := range order {
+		plugins = append(plugins, *byName[name])
+	}
+	return plugins
+}
+
+// Find returns the plugin with the given name, if one exists on pathEnv.
+func Find(name, pathEnv string) (Plugin, bool) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return Plugin{}, false
+	}
+	for _, p := range Discover(pathEnv) {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Plugin{}, false
+}
+
+// Run executes the plugin with the given arguments and context.
+// It returns the plugin's exit code. A non-nil error is only returned
+// if the plugin could not be started.
+func Run(ctx context.Context, p Plugin, args []string, env Env) (int, error) {
+	cmd := exec.CommandContext(ctx, p.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = env.Environ(os.Environ(), p.Name)
+	err := cmd.Run()
+	if err == nil {
+		return 0, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	return 1, err
+}
+
+// Environ returns base with the plugin contract variables applied.
+// Existing values for contract variables are replaced.
+func (e Env) Environ(base []string, name string) []string {
+	vars := map[string]string{
+		EnvAPIVersion: APIVersion,
+		EnvName:       name,
+		EnvBinary:     e.Binary,
+		EnvConfigDir:  e.ConfigDir,
+		EnvDataDir:    e.DataDir,
+		EnvCacheDir:   e.CacheDir,
+		EnvOrgID:      e.OrgID,
+		EnvAuthToken:  e.AuthToken,
+	}
+	res := make([]string, 0, len(base)+len(vars))
+	for _, kv := range base {
+		key, _, _ := strings.Cut(kv, "=")
+		if _, ok := vars[key]; ok {
+			continue
+		}
+		res = append(res, kv)
+	}
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if vars[k] == "" {
+			continue
+		}
+		res = append(res, k+"="+vars[k])
+	}
+	return res
+}
+
+// pluginName returns the subcommand name for a file name,
+// or false if the file is not a plugin.
+func pluginName(fileName string) (string, bool) {
+	if !strings.HasPrefix(fileName, Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(fileName, Prefix)
+	if runtime.GOOS == "windows" {
+		ext := filepath.Ext(name)
+		if !isWindowsExecutableExt(ext) {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, ext)
+	}
+	if name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// extension has already been checked by pluginName
+		return true
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+func isWindowsExecutableExt(ext string) bool {
+	pathExt := os.Getenv("PATHEXT")
+	if pathExt == "" {
+		pathExt = ".com;.exe;.bat;.cmd"
+	}
+	for _, e := range filepath.SplitList(pathExt) {
+		if strings.EqualFold(e, ext) {
+			return true
+		}
+	}
+	return false
+}
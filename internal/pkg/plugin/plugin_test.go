@@ -0,0 +1,62 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeExecutable(t *testing.T, dir, name string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), mode))
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable bit detection is not used on windows")
+	}
+	first := t.TempDir()
+	second := t.TempDir()
+
+	fooPath := writeExecutable(t, first, "cencli-foo", 0o755)
+	writeExecutable(t, first, "cencli-notexec", 0o644)
+	writeExecutable(t, first, "other-tool", 0o755)
+	shadowedFoo := writeExecutable(t, second, "cencli-foo", 0o755)
+	barPath := writeExecutable(t, second, "cencli-bar", 0o755)
+	require.NoError(t, os.Mkdir(filepath.Join(second, "cencli-dir"), 0o755))
+
+	plugins := Discover(first + string(os.PathListSeparator) + second)
+	require.Equal(t, []Plugin{
+		{Name: "bar", Path: barPath},
+		{Name: "foo", Path: fooPath, Shadowed: []string{shadowedFoo}},
+	}, plugins)
+
+	p, ok := Find("bar", first+string(os.PathListSeparator)+second)
+	require.True(t, ok)
+	require.Equal(t, barPath, p.Path)
+
+	_, ok = Find("notexec", first)
+	require.False(t, ok)
+	_, ok = Find("../foo", first)
+	require.False(t, ok)
+}
+
+func TestEnviron(t *testing.T) {
+	env := Env{ConfigDir: "/config", DataDir: "/data", CacheDir: "/cache", AuthToken: "secret", Binary: "/bin/censys"}
+	got := env.Environ([]string{"PATH=/usr/bin", "CENCLI_AUTH_TOKEN=stale", "CENCLI_ORG_ID=stale"}, "foo")
+	require.Equal(t, []string{
+		"PATH=/usr/bin",
+		"CENCLI_AUTH_TOKEN=secret",
+		"CENCLI_BIN=/bin/censys",
+		"CENCLI_CACHE_DIR=/cache",
+		"CENCLI_CONFIG_DIR=/config",
+		"CENCLI_DATA_DIR=/data",
+		"CENCLI_PLUGIN_API_VERSION=1",
+		"CENCLI_PLUGIN_NAME=foo",
+	}, got)
+}
@@ -0,0 +1,267 @@
+// Package rdap looks up IP networks and domains with the Registration Data
+// Access Protocol (RFC 9083).
+package rdap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL is a bootstrap service that redirects each query to the
+// RDAP server of the registry responsible for it.
+const DefaultBaseURL = "https://rdap.org"
+
+// maxResponseSize bounds the size of an RDAP response.
+const maxResponseSize = 8 << 20
+
+// ErrNotFound is returned when the registry has no record of the query.
+var ErrNotFound = errors.New("no RDAP record found")
+
+// Client queries an RDAP server.
+type Client struct {
+	// BaseURL is the server queried. Empty uses DefaultBaseURL.
+	BaseURL string
+	// HTTP sends the queries. Nil uses http.DefaultClient.
+	HTTP *http.Client
+}
+
+// IP returns the record of the network that ip belongs to.
+func (c Client) IP(ctx context.Context, ip string) (*Record, error) {
+	return c.get(ctx, "ip", ip)
+}
+
+// Domain returns the record of a domain.
+func (c Client) Domain(ctx context.Context, domain string) (*Record, error) {
+	return c.get(ctx, "domain", domain)
+}
+
+func (c Client) get(ctx context.Context, kind, query string) (*Record, error) {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	endpoint := strings.TrimSuffix(base, "/") + "/" + kind + "/" + url.PathEscape(query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("RDAP query for %s failed: %s", query, resp.Status)
+	}
+	var rec Record
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&rec); err != nil {
+		return nil, fmt.Errorf("invalid RDAP response for %s: %w", query, err)
+	}
+	return &rec, nil
+}
+
+// Record is an RDAP IP network or domain object. Only the members used to
+// summarize it are decoded.
+type Record struct {
+	ObjectClassName string       `json:"objectClassName"`
+	Handle          string       `json:"handle"`
+	Name            string       `json:"name"`
+	LDHName         string       `json:"ldhName"`
+	Type            string       `json:"type"`
+	Country         string       `json:"country"`
+	StartAddress    string       `json:"startAddress"`
+	EndAddress      string       `json:"endAddress"`
+	CIDRs           []CIDR       `json:"cidr0_cidrs"`
+	Status          []string     `json:"status"`
+	Events          []Event      `json:"events"`
+	Entities        []Entity     `json:"entities"`
+	Nameservers     []Nameserver `json:"nameservers"`
+}
+
+// CIDR is a prefix of an IP network (the cidr0 extension).
+type CIDR struct {
+	V4Prefix string `json:"v4prefix"`
+	V6Prefix string `json:"v6prefix"`
+	Length   int    `json:"length"`
+}
+
+func (c CIDR) String() string {
+	prefix := c.V4Prefix
+	if prefix == "" {
+		prefix = c.V6Prefix
+	}
+	if prefix == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%d", prefix, c.Length)
+}
+
+// Event is a dated event in the life of an object, such as its registration.
+type Event struct {
+	Action string `json:"eventAction"`
+	Date   string `json:"eventDate"`
+}
+
+// Event actions, from the RDAP JSON Values registry.
+const (
+	EventRegistration = "registration"
+	EventLastChanged  = "last changed"
+	EventExpiration   = "expiration"
+)
+
+// Nameserver is a nameserver of a domain.
+type Nameserver struct {
+	LDHName string `json:"ldhName"`
+}
+
+// Entity is a person or organization related to an object, in the roles
+// given, such as registrant, registrar, or abuse.
+type Entity struct {
+	Handle     string          `json:"handle"`
+	Roles      []string        `json:"roles"`
+	VCardArray json.RawMessage `json:"vcardArray"`
+	Entities   []Entity        `json:"entities"`
+}
+
+// Entity roles, from the RDAP JSON Values registry.
+const (
+	RoleRegistrant = "registrant"
+	RoleRegistrar  = "registrar"
+	RoleAbuse      = "abuse"
+)
+
+// Event returns the date of the first event with action, or "".
+func (r *Record) Event(action string) string {
+	for _, e := range r.Events {
+		if strings.EqualFold(e.Action, action) {
+			return e.Date
+		}
+	}
+	return ""
+}
+
+// EntitiesWithRole returns the entities with role, including those nested
+// in other entities, such as the abuse contact of a registrar.
+func (r *Record) EntitiesWithRole(role string) []Entity {
+	var res []Entity
+	var walk func([]Entity)
+	walk = func(entities []Entity) {
+		for _, e := range entities {
+			if e.HasRole(role) {
+				res = append(res, e)
+			}
+			walk(e.Entities)
+		}
+	}
+	walk(r.Entities)
+	return res
+}
+
+// HasRole reports whether the entity has role.
+func (e Entity) HasRole(role string) bool {
+	for _, r := range e.Roles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// VCard is the contact information of an entity.
+type VCard struct {
+	Name    string
+	Email   string
+	Phone   string
+	Address string
+}
+
+// VCard decodes the jCard (RFC 7095) of the entity. Properties that are
+// missing or malformed are left empty.
+func (e Entity) VCard() VCard {
+	var card VCard
+	var top []json.RawMessage
+	if err := json.Unmarshal(e.VCardArray, &top); err != nil || len(top) < 2 {
+		return card
+	}
+	var props [][]json.RawMessage
+	if err := json.Unmarshal(top[1], &props); err != nil {
+		return card
+	}
+	for _, prop := range props {
+		if len(prop) < 4 {
+			continue
+		}
+		var name string
+		if err := json.Unmarshal(prop[0], &name); err != nil {
+			continue
+		}
+		switch strings.ToLower(name) {
+		case "fn":
+			setOnce(&card.Name, textValue(prop[3]))
+		case "email":
+			setOnce(&card.Email, textValue(prop[3]))
+		case "tel":
+			setOnce(&card.Phone, strings.TrimPrefix(textValue(prop[3]), "tel:"))
+		case "adr":
+			var params struct {
+				Label string `json:"label"`
+			}
+			_ = json.Unmarshal(prop[1], &params)
+			if params.Label != "" {
+				setOnce(&card.Address, strings.Join(strings.Fields(params.Label), " "))
+			} else {
+				setOnce(&card.Address, textValue(prop[3]))
+			}
+		}
+	}
+	return card
+}
+
+// textValue returns a jCard value as text: a string, or the non-empty
+// strings of a structured value joined with commas.
+func textValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s)
+	}
+	var parts []any
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return ""
+	}
+	var out []string
+	var collect func([]any)
+	collect = func(values []any) {
+		for _, v := range values {
+			switch v := v.(type) {
+			case string:
+				if v = strings.TrimSpace(v); v != "" {
+					out = append(out, v)
+				}
+			case []any:
+				collect(v)
+			}
+		}
+	}
+	collect(parts)
+	return strings.Join(out, ", ")
+}
+
+func setOnce(dst *string, v string) {
+	if *dst == "" {
+		*dst = v
+	}
+}
@@ -0,0 +1,57 @@
+package rdap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	ipRecord, err := os.ReadFile("testdata/ip.json")
+	require.NoError(t, err)
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.Equal(t, "application/rdap+json", r.Header.Get("Accept"))
+		switch r.URL.Path {
+		case "/ip/8.8.8.8":
+			_, _ = w.Write(ipRecord)
+		case "/domain/broken.example":
+			_, _ = w.Write([]byte("{"))
+		case "/domain/down.example":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c := Client{BaseURL: srv.URL + "/", HTTP: srv.Client()}
+	ctx := context.Background()
+
+	rec, err := c.IP(ctx, "8.8.8.8")
+	require.NoError(t, err)
+	assert.Equal(t, "GOGL", rec.Name)
+	assert.Equal(t, "8.8.8.0/24", rec.CIDRs[0].String())
+	assert.Equal(t, "2014-03-14T16:52:05-04:00", rec.Event(EventRegistration))
+	assert.Empty(t, rec.Event(EventExpiration))
+
+	registrants := rec.EntitiesWithRole(RoleRegistrant)
+	require.Len(t, registrants, 1)
+	assert.Equal(t, VCard{Name: "Google LLC", Address: "1600 Amphitheatre Parkway Mountain View CA 94043 United States"}, registrants[0].VCard())
+	abuse := rec.EntitiesWithRole(RoleAbuse)
+	require.Len(t, abuse, 1)
+	assert.Equal(t, VCard{Name: "Abuse", Email: "network-abuse@google.com", Phone: "+1-650-253-0000"}, abuse[0].VCard())
+
+	_, err = c.Domain(ctx, "unregistered.example")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = c.Domain(ctx, "broken.example")
+	require.ErrorContains(t, err, "invalid RDAP response for broken.example")
+	_, err = c.Domain(ctx, "down.example")
+	require.ErrorContains(t, err, "503")
+	assert.Equal(t, []string{"/ip/8.8.8.8", "/domain/unregistered.example", "/domain/broken.example", "/domain/down.example"}, paths)
+}
@@ -0,0 +1,288 @@
+// Package redact masks IP addresses, hostnames, and identifiers in text, for
+// screenshots and shared reports.
+//
+// Masks are derived from a hash of the value, so the same value is always
+// masked the same way and the relationships between values are preserved: a
+// host seen in two places has the same mask in both, and the subdomains of a
+// domain share the mask of the domain. Masks have the length of the value, so
+// that tables stay aligned, and are made of letters that cannot be mistaken
+// for a real address. For example, with an empty salt:
+//
+//   - IPv4 addresses keep their first octet: 203.0.113.5 becomes 203.t.qca.f
+//   - IPv6 addresses keep their first group: 2001:db8::1 becomes 2001:lpu::x
+//   - hostnames keep their top-level domain: mail.example.com becomes
+//     yusf.yresunm.com. Hostnames of Censys itself are kept.
+//   - UUIDs, such as organization and collection IDs, keep their shape
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	kindIPv4     = "ipv4"
+	kindIPv6     = "ipv6"
+	kindHostname = "hostname"
+	kindUUID     = "uuid"
+
+	lowerLetters = "abcdefghijklmnopqrstuvwxyz"
+	// nonHexLetters cannot be read as digits of an IPv6 address.
+	nonHexLetters = "ghijklmnopqrstuvwxyz"
+	hexDigits     = "0123456789abcdef"
+)
+
+// topLevelDomains are the top-level domains a hostname must end with to be
+// masked. They are curated rather than complete, so that field names such as
+// host.location.city are not mistaken for hostnames.
+var topLevelDomains = []string{
+	"com", "net", "org", "edu", "gov", "mil", "int", "arpa",
+	"io", "co", "ai", "app", "dev", "info", "biz", "me", "tv", "cloud", "online", "site", "xyz", "tech",
+	"us", "uk", "ca", "de", "fr", "jp", "cn", "au", "br", "in", "ru", "nl", "se", "no", "dk", "es",
+	"it", "ch", "be", "at", "nz", "za", "mx", "ar", "cl", "pl", "tr", "kr", "sg", "hk", "tw", "th",
+	"my", "id", "ph", "vn", "ae", "sa", "eg", "ng", "ke", "ie", "fi", "pt", "cz", "gr", "hu", "ro",
+	"ua", "il", "ir", "pk", "bd", "lk", "cc", "ly", "eu", "su", "ws", "to",
+}
+
+// keptDomains are domains that are never masked, with their subdomains.
+var keptDomains = []string{"censys.io", "censys.com"}
+
+var (
+	uuidPattern     = `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`
+	ipv6Pattern     = `[0-9a-fA-F]*:[0-9a-fA-F]*:[0-9a-fA-F:.]*`
+	ipv4Pattern     = `(?:[0-9]{1,3}\.){3}[0-9]{1,3}`
+	hostnamePattern = `(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+(?i:` + tldAlternation() + `)`
+
+	// tokenRe matches the values to mask, one kind per group, in the order
+	// they are tried at each position.
+	tokenRe = regexp.MustCompile(`(` + uuidPattern + `)|(` + ipv6Pattern + `)|(` + ipv4Pattern + `)|(` + hostnamePattern + `)`)
+)
+
+// tldAlternation lists the top-level domains longest first, so that the
+// longest one is tried first.
+func tldAlternation() string {
+	tlds := append([]string(nil), topLevelDomains...)
+	sort.SliceStable(tlds, func(i, j int) bool { return len(tlds[i]) > len(tlds[j]) })
+	return strings.Join(tlds, "|")
+}
+
+// Redactor masks values in text. The masks depend on its salt: unless the
+// salt is secret, the masks of IPv4 addresses, which are few, can be reversed
+// by hashing every address.
+type Redactor struct {
+	salt string
+}
+
+// New creates a redactor whose masks are derived with salt.
+func New(salt string) *Redactor {
+	return &Redactor{salt: salt}
+}
+
+// String returns s with its IP addresses, hostnames, and UUIDs masked.
+func (r *Redactor) String(s string) string {
+	matches := tokenRe.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if !isBoundary(s, start, end) {
+			continue
+		}
+		token := s[start:end]
+		var masked string
+		var ok bool
+		switch {
+		case m[2] >= 0:
+			masked, ok = r.uuid(token), true
+		case m[4] >= 0:
+			masked, ok = r.ipv6(token)
+		case m[6] >= 0:
+			masked, ok = r.ipv4(token)
+		case m[8] >= 0:
+			masked, ok = r.hostname(token)
+		}
+		if !ok {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(masked)
+		last = end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// isBoundary reports whether s[start:end] is a whole token, rather than part
+// of a longer word or number.
+func isBoundary(s string, start, end int) bool {
+	if start > 0 && isWordByte(s[start-1]) {
+		return false
+	}
+	if end < len(s) {
+		next := s[end]
+		if isWordByte(next) {
+			return false
+		}
+		// a trailing dot ends a sentence, but not a longer dotted name
+		if next == '.' && end+1 < len(s) && isWordByte(s[end+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordByte(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-' || c == '_'
+}
+
+func (r *Redactor) ipv4(token string) (string, bool) {
+	addr, err := netip.ParseAddr(token)
+	if err != nil || !addr.Is4() {
+		return "", false
+	}
+	first, rest, _ := strings.Cut(token, ".")
+	return first + "." + r.mask(kindIPv4, addr.String(), rest, lowerLetters), true
+}
+
+func (r *Redactor) ipv6(token string) (string, bool) {
+	addr, err := netip.ParseAddr(token)
+	if err != nil || !addr.Is6() || addr.Zone() != "" {
+		return "", false
+	}
+	first, rest, _ := strings.Cut(token, ":")
+	return first + ":" + r.mask(kindIPv6, addr.String(), rest, nonHexLetters), true
+}
+
+func (r *Redactor) hostname(token string) (string, bool) {
+	name := strings.ToLower(token)
+	for _, kept := range keptDomains {
+		if name == kept || strings.HasSuffix(name, "."+kept) {
+			return "", false
+		}
+	}
+	labels := strings.Split(name, ".")
+	// each label is masked along with its parents, so that subdomains share
+	// the mask of their domain
+	for i := 0; i < len(labels)-1; i++ {
+		labels[i] = r.mask(kindHostname, strings.Join(labels[i:], "."), labels[i], lowerLetters)
+	}
+	return strings.Join(labels, "."), true
+}
+
+func (r *Redactor) uuid(token string) string {
+	return r.mask(kindUUID, strings.ToLower(token), token, hexDigits)
+}
+
+// mask replaces the letters and digits of text with characters of alphabet
+// chosen by hashing value, keeping its punctuation.
+func (r *Redactor) mask(kind, value, text, alphabet string) string {
+	stream := r.stream(kind, value)
+	out := []byte(text)
+	for i, c := range out {
+		if isWordByte(c) && c != '-' && c != '_' {
+			out[i] = alphabet[int(stream())%len(alphabet)]
+		}
+	}
+	return string(out)
+}
+
+// stream returns a function that returns the bytes of the hash of value, in
+// counter mode so that masks can be longer than a hash.
+func (r *Redactor) stream(kind, value string) func() byte {
+	var block [sha256.Size]byte
+	var counter uint64
+	pos := len(block)
+	return func() byte {
+		if pos == len(block) {
+			h := sha256.New()
+			h.Write([]byte(r.salt))
+			h.Write([]byte{0})
+			h.Write([]byte(kind))
+			h.Write([]byte{0})
+			h.Write([]byte(value))
+			_ = binary.Write(h, binary.BigEndian, counter)
+			copy(block[:], h.Sum(nil))
+			counter++
+			pos = 0
+		}
+		pos++
+		return block[pos-1]
+	}
+}
+
+// NewWriter returns a writer that masks what is written to w. Callers, such
+// as a bufio.Writer, may split a value across two writes, so the end of each
+// write that could still be part of a value is held until the next write, or
+// until Close, which writes it without closing w. If w is a terminal, so is
+// the returned writer.
+func NewWriter(w io.Writer, r *Redactor) io.WriteCloser {
+	rw := &writer{w: w, r: r}
+	if f, ok := w.(interface{ Fd() uintptr }); ok {
+		return &fileWriter{writer: rw, fd: f.Fd()}
+	}
+	return rw
+}
+
+type writer struct {
+	w io.Writer
+	r *Redactor
+
+	mu sync.Mutex
+	// held is the end of the last write that could be part of a value
+	held []byte
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.held = append(w.held, p...)
+	cut := len(w.held)
+	for cut > 0 && isValueByte(w.held[cut-1]) {
+		cut--
+	}
+	if cut == 0 {
+		return len(p), nil
+	}
+	if _, err := io.WriteString(w.w, w.r.String(string(w.held[:cut]))); err != nil {
+		w.held = w.held[:0]
+		return 0, err
+	}
+	w.held = append(w.held[:0], w.held[cut:]...)
+	return len(p), nil
+}
+
+// Close writes what is held of the last write. The writer can still be
+// written to afterwards.
+func (w *writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.held) == 0 {
+		return nil
+	}
+	_, err := io.WriteString(w.w, w.r.String(string(w.held)))
+	w.held = w.held[:0]
+	return err
+}
+
+// isValueByte reports whether c can be part of a value that is masked.
+func isValueByte(c byte) bool {
+	return isWordByte(c) || c == '.' || c == ':'
+}
+
+// fileWriter is a writer to a file, such as a terminal.
+type fileWriter struct {
+	*writer
+	fd uintptr
+}
+
+func (w *fileWriter) Fd() uintptr { return w.fd }
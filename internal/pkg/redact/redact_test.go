@@ -0,0 +1,135 @@
+package redact
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	r := New("")
+
+	t.Run("masks keep what is kept and the length", func(t *testing.T) {
+		for _, tc := range []struct {
+			in, prefix, suffix string
+		}{
+			{in: "203.0.113.5", prefix: "203."},
+			{in: "2001:db8::1", prefix: "2001:"},
+			{in: "mail.example.com", suffix: ".com"},
+			{in: "a7b3c9d2-1111-2222-3333-444455556666"},
+		} {
+			got := r.String(tc.in)
+			assert.NotEqual(t, tc.in, got)
+			assert.Len(t, got, len(tc.in), got)
+			assert.True(t, strings.HasPrefix(got, tc.prefix), got)
+			assert.True(t, strings.HasSuffix(got, tc.suffix), got)
+		}
+		assert.Regexp(t, `^203\.[a-z]\.[a-z]{3}\.[a-z]$`, r.String("203.0.113.5"))
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`, r.String("a7b3c9d2-1111-2222-3333-444455556666"))
+	})
+
+	t.Run("masks are stable and preserve relationships", func(t *testing.T) {
+		text := r.String(`{"ip":"10.0.0.1","names":["www.example.com","mail.example.com"]} 10.0.0.1:443`)
+		again := r.String(`10.0.0.1 www.example.com`)
+		ip := r.String("10.0.0.1")
+		assert.Equal(t, 2, strings.Count(text, ip))
+		assert.Contains(t, text, ip+":443")
+		assert.Equal(t, ip+" "+r.String("www.example.com"), again)
+		// subdomains share the mask of their domain
+		domain := r.String("example.com")
+		assert.True(t, strings.HasSuffix(r.String("www.example.com"), "."+domain))
+		assert.True(t, strings.HasSuffix(r.String("mail.example.com"), "."+domain))
+		// hostnames are masked whatever their case
+		assert.Equal(t, r.String("www.example.com"), r.String("WWW.Example.COM"))
+	})
+
+	t.Run("the salt changes the masks", func(t *testing.T) {
+		assert.NotEqual(t, r.String("10.0.0.1"), New("secret").String("10.0.0.1"))
+		assert.Equal(t, New("secret").String("10.0.0.1"), New("secret").String("10.0.0.1"))
+	})
+
+	t.Run("other text is kept", func(t *testing.T) {
+		for _, in := range []string{
+			"host.services.port",
+			"host.location.city",
+			"12:30:45",
+			"version 1.2.3.4.5",
+			"v1.2.3.4",
+			"999.1.1.1",
+			"example.community",
+			"https://platform.censys.io/search?q=x",
+			"api.censys.com",
+		} {
+			assert.Equal(t, in, r.String(in))
+		}
+	})
+
+	t.Run("colored output", func(t *testing.T) {
+		got := r.String("\x1b[38;5;1m\"8.8.8.8\"\x1b[0m")
+		assert.Equal(t, "\x1b[38;5;1m\""+r.String("8.8.8.8")+"\"\x1b[0m", got)
+	})
+
+	t.Run("a sentence ending with a hostname", func(t *testing.T) {
+		assert.Equal(t, "resolved "+r.String("example.com")+".", r.String("resolved example.com."))
+	})
+}
+
+func TestNewWriter(t *testing.T) {
+	r := New("")
+	var buf bytes.Buffer
+	w := NewWriter(&buf, r)
+	n, err := w.Write([]byte("host 10.0.0.1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, len("host 10.0.0.1\n"), n)
+	assert.Equal(t, "host "+r.String("10.0.0.1")+"\n", buf.String())
+	_, isFile := w.(interface{ Fd() uintptr })
+	assert.False(t, isFile)
+
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	require.NoError(t, err)
+	defer f.Close()
+	fw, ok := NewWriter(f, r).(interface{ Fd() uintptr })
+	require.True(t, ok)
+	assert.Equal(t, f.Fd(), fw.Fd())
+}
+
+func TestNewWriter_SplitValues(t *testing.T) {
+	r := New("salt")
+
+	t.Run("values split across writes are masked", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, r)
+		for _, chunk := range []string{"host 203.0.1", "13.5 at mail.exa", "mple.com\n", "end 10.0.0.1"} {
+			_, err := w.Write([]byte(chunk))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, "host "+r.String("203.0.113.5")+" at "+r.String("mail.example.com")+"\nend ", buf.String(),
+			"the end of the output is held until Close")
+		require.NoError(t, w.Close())
+		assert.Equal(t, r.String("host 203.0.113.5 at mail.example.com\nend 10.0.0.1"), buf.String())
+	})
+
+	t.Run("bufio chunks", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, r)
+		bw := bufio.NewWriter(w)
+		var ips []string
+		for i := range 1000 {
+			ip := fmt.Sprintf("203.0.%d.%d", 100+i/256, i%256)
+			ips = append(ips, ip)
+			fmt.Fprintf(bw, "%s,%d\n", ip, i)
+		}
+		require.NoError(t, bw.Flush())
+		require.NoError(t, w.Close())
+		out := buf.String()
+		for _, ip := range ips {
+			assert.False(t, strings.Contains(out, ip+","), "%s is not masked", ip)
+		}
+	})
+}
@@ -0,0 +1,233 @@
+// Package resolve resolves domains to IP addresses, with the system resolver
+// or DNS over HTTPS.
+package resolve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/refang"
+)
+
+// Resolvers.
+const (
+	ResolverSystem = "system"
+	ResolverDoH    = "doh"
+)
+
+// DefaultDoHURL is the DNS over HTTPS endpoint used when none is configured.
+const DefaultDoHURL = "https://cloudflare-dns.com/dns-query"
+
+// maxResponseSize bounds the size of a DNS over HTTPS response.
+const maxResponseSize = 1 << 20
+
+// DNS record types and response codes used by the JSON API of DNS over HTTPS.
+const (
+	typeA         = 1
+	typeAAAA      = 28
+	rcodeNoError  = 0
+	rcodeNXDomain = 3
+)
+
+// ErrNoAddresses is returned when a domain has no A or AAAA records.
+var ErrNoAddresses = errors.New("no addresses found")
+
+// Resolver resolves domains to IP addresses.
+type Resolver struct {
+	// Resolver is ResolverSystem or ResolverDoH. Empty uses ResolverSystem.
+	Resolver string
+	// DoHURL is the DNS over HTTPS endpoint, which must support the JSON API
+	// (application/dns-json). Empty uses DefaultDoHURL.
+	DoHURL string
+	// HTTP sends DNS over HTTPS queries. Nil uses http.DefaultClient.
+	HTTP *http.Client
+}
+
+// Name returns a description of the resolver, for annotating resolutions.
+func (r Resolver) Name() string {
+	if r.Resolver == ResolverDoH {
+		return "DNS over HTTPS (" + r.dohURL() + ")"
+	}
+	return "system resolver"
+}
+
+// Validate checks that the resolver is supported.
+func (r Resolver) Validate() error {
+	switch r.Resolver {
+	case "", ResolverSystem, ResolverDoH:
+		return nil
+	default:
+		return fmt.Errorf("unsupported resolver %q; use %s or %s", r.Resolver, ResolverSystem, ResolverDoH)
+	}
+}
+
+// LookupIP returns the IPv4 addresses of domain, then its IPv6 addresses,
+// each sorted and without duplicates.
+func (r Resolver) LookupIP(ctx context.Context, domain string) ([]string, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	var ips []net.IP
+	if r.Resolver == ResolverDoH {
+		for _, qtype := range []int{typeA, typeAAAA} {
+			found, err := r.lookupDoH(ctx, domain, qtype)
+			if err != nil {
+				return nil, err
+			}
+			ips = append(ips, found...)
+		}
+	} else {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, domain)
+		if err != nil {
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				return nil, ErrNoAddresses
+			}
+			return nil, err
+		}
+		for _, a := range addrs {
+			ips = append(ips, a.IP)
+		}
+	}
+	res := sortIPs(ips)
+	if len(res) == 0 {
+		return nil, ErrNoAddresses
+	}
+	return res, nil
+}
+
+func (r Resolver) dohURL() string {
+	if r.DoHURL == "" {
+		return DefaultDoHURL
+	}
+	return r.DoHURL
+}
+
+// dohResponse is the JSON API response of DNS over HTTPS, as served by
+// Cloudflare and Google. Only the members used are decoded.
+type dohResponse struct {
+	Status int `json:"Status"`
+	Answer []struct {
+		Type int    `json:"type"`
+		Data string `json:"data"`
+	} `json:"Answer"`
+}
+
+func (r Resolver) lookupDoH(ctx context.Context, domain string, qtype int) ([]net.IP, error) {
+	endpoint, err := url.Parse(r.dohURL())
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNS over HTTPS URL: %w", err)
+	}
+	q := endpoint.Query()
+	q.Set("name", domain)
+	q.Set("type", fmt.Sprint(qtype))
+	endpoint.RawQuery = q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/dns-json")
+	client := r.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DNS over HTTPS query for %s failed: %s", domain, resp.Status)
+	}
+	var body dohResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid DNS over HTTPS response for %s: %w", domain, err)
+	}
+	switch body.Status {
+	case rcodeNoError:
+	case rcodeNXDomain:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("DNS over HTTPS query for %s failed with response code %d", domain, body.Status)
+	}
+	var ips []net.IP
+	for _, a := range body.Answer {
+		// CNAME records are followed by the server and listed before the
+		// addresses they point to
+		if a.Type != qtype {
+			continue
+		}
+		if ip := net.ParseIP(strings.TrimSpace(a.Data)); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips, nil
+}
+
+// sortIPs returns ips as strings, IPv4 before IPv6, sorted and without duplicates.
+func sortIPs(ips []net.IP) []string {
+	seen := make(map[string]bool, len(ips))
+	var v4, v6 []net.IP
+	for _, ip := range ips {
+		if seen[ip.String()] {
+			continue
+		}
+		seen[ip.String()] = true
+		if ip.To4() != nil {
+			v4 = append(v4, ip.To4())
+		} else {
+			v6 = append(v6, ip)
+		}
+	}
+	var res []string
+	for _, group := range [][]net.IP{v4, v6} {
+		sort.Slice(group, func(i, j int) bool { return string(group[i]) < string(group[j]) })
+		for _, ip := range group {
+			res = append(res, ip.String())
+		}
+	}
+	return res
+}
+
+// BareDomain returns raw as a lowercase domain if it is one on its own,
+// without a scheme, port, or path, and is not an IP address. Defanged
+// domains are supported.
+func BareDomain(raw string) (string, bool) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	if strings.Contains(s, "://") || strings.HasPrefix(s, "hxxp") {
+		return "", false
+	}
+	// refanging adds a scheme to defanged domains
+	s = strings.TrimPrefix(strings.TrimPrefix(refang.RefangURL(s), "http://"), "https://")
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || strings.ContainsAny(s, ":/[] ") || net.ParseIP(s) != nil {
+		return "", false
+	}
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 {
+		return "", false
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return "", false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r >= 0x80) {
+				return "", false
+			}
+		}
+	}
+	// all-numeric names such as 1.2.3 are malformed IPs, not domains
+	if strings.Trim(s, "0123456789.") == "" {
+		return "", false
+	}
+	return s, true
+}
@@ -0,0 +1,88 @@
+package resolve
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBareDomain(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+		ok   bool
+	}{
+		{raw: "platform.censys.io", want: "platform.censys.io", ok: true},
+		{raw: " Censys.COM. ", want: "censys.com", ok: true},
+		{raw: "platform.censys[.]io", want: "platform.censys.io", ok: true},
+		{raw: "_dmarc.example.org", want: "_dmarc.example.org", ok: true},
+		{raw: "platform.censys.io:443"},
+		{raw: "https://platform.censys.io"},
+		{raw: "hxxps://platform[.]censys[.]io"},
+		{raw: "platform.censys.io/login"},
+		{raw: "8.8.8.8"},
+		{raw: "8.8.8[.]8"},
+		{raw: "2001:db8::1"},
+		{raw: "1.2.3"},
+		{raw: "localhost"},
+		{raw: "-bad.example"},
+		{raw: "a..example"},
+		{raw: "3daf2843a77b6f4e6af43cd9b6f6746053b8c928e056e8a724808db8905a94cf"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.raw, func(t *testing.T) {
+			got, ok := BareDomain(tc.raw)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestResolver_LookupIP_DoH(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/dns-json", r.Header.Get("Accept"))
+		q := r.URL.Query()
+		switch q.Get("name") + "/" + q.Get("type") {
+		case "www.example.org/1":
+			_, _ = w.Write([]byte(`{"Status": 0, "Answer": [
+				{"type": 5, "data": "cdn.example.net."},
+				{"type": 1, "data": "192.0.2.20"},
+				{"type": 1, "data": "192.0.2.3"},
+				{"type": 1, "data": "192.0.2.20"}
+			]}`))
+		case "www.example.org/28":
+			_, _ = w.Write([]byte(`{"Status": 0, "Answer": [{"type": 28, "data": "2001:db8::5"}]}`))
+		case "broken.example/1":
+			_, _ = w.Write([]byte(`{"Status": 2}`))
+		case "down.example/1":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte(`{"Status": 3}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	r := Resolver{Resolver: ResolverDoH, DoHURL: srv.URL + "/dns-query", HTTP: srv.Client()}
+	assert.Equal(t, "DNS over HTTPS ("+srv.URL+"/dns-query)", r.Name())
+
+	ips, err := r.LookupIP(ctx, "www.example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.3", "192.0.2.20", "2001:db8::5"}, ips)
+
+	_, err = r.LookupIP(ctx, "missing.example")
+	require.ErrorIs(t, err, ErrNoAddresses)
+
+	_, err = r.LookupIP(ctx, "broken.example")
+	require.ErrorContains(t, err, "response code 2")
+
+	_, err = r.LookupIP(ctx, "down.example")
+	require.ErrorContains(t, err, "502")
+
+	_, err = Resolver{Resolver: "dig"}.LookupIP(ctx, "www.example.org")
+	require.ErrorContains(t, err, `unsupported resolver "dig"`)
+}
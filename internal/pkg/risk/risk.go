@@ -0,0 +1,232 @@
+// Package risk scores hosts for triage. The score is opinionated: it adds up
+// points for risky indicators found in the host document, such as exposed
+// management services, end-of-life software, self-signed certificates, and
+// JA4 fingerprints from a local list of known-bad ones.
+package risk
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// MaxScore is the highest score of a host.
+const MaxScore = 100
+
+const (
+	endOfLifePoints  = 20
+	selfSignedPoints = 10
+	badJA4Points     = 40
+)
+
+// Level summarizes a score.
+type Level string
+
+const (
+	LevelNone     Level = "none"
+	LevelLow      Level = "low"
+	LevelMedium   Level = "medium"
+	LevelHigh     Level = "high"
+	LevelCritical Level = "critical"
+)
+
+// LevelOf returns the level of a score.
+func LevelOf(score int) Level {
+	switch {
+	case score <= 0:
+		return LevelNone
+	case score < 25:
+		return LevelLow
+	case score < 50:
+		return LevelMedium
+	case score < 75:
+		return LevelHigh
+	default:
+		return LevelCritical
+	}
+}
+
+// Reason is an indicator that adds to the score of a host.
+type Reason struct {
+	Points int    `json:"points"`
+	Reason string `json:"reason"`
+}
+
+// Assessment is the score of a host and the reasons for it, highest first.
+type Assessment struct {
+	IP      string   `json:"ip"`
+	Score   int      `json:"score"`
+	Level   Level    `json:"level"`
+	Reasons []Reason `json:"reasons"`
+}
+
+// managementService is a service that should rarely be reachable from the
+// Internet. Ports identify it when the protocol was not recognized.
+type managementService struct {
+	protocol string
+	ports    []int
+	points   int
+}
+
+var managementServices = []managementService{
+	{protocol: "TELNET", ports: []int{23}, points: 30},
+	{protocol: "IPMI", ports: []int{623}, points: 30},
+	{protocol: "DOCKER", ports: []int{2375}, points: 30},
+	{protocol: "RDP", ports: []int{3389}, points: 25},
+	{protocol: "VNC", ports: []int{5900, 5901}, points: 25},
+	{protocol: "SMB", ports: []int{445}, points: 25},
+	{protocol: "REDIS", ports: []int{6379}, points: 25},
+	{protocol: "MONGODB", ports: []int{27017}, points: 25},
+	{protocol: "WINRM", ports: []int{5985, 5986}, points: 20},
+	{protocol: "ELASTICSEARCH", ports: []int{9200}, points: 20},
+	{protocol: "KUBERNETES", ports: []int{6443, 10250}, points: 20},
+	{protocol: "MSSQL", ports: []int{1433}, points: 15},
+	{protocol: "MYSQL", ports: []int{3306}, points: 15},
+	{protocol: "POSTGRES", ports: []int{5432}, points: 15},
+	{protocol: "SNMP", ports: []int{161}, points: 15},
+	{protocol: "FTP", ports: []int{21}, points: 10},
+	{protocol: "SSH", ports: []int{22}, points: 5},
+}
+
+// Scorer scores hosts.
+type Scorer struct {
+	badJA4 map[string]bool
+}
+
+// New returns a Scorer that flags the given known-bad JA4S and JA4T fingerprints.
+func New(badJA4 []string) *Scorer {
+	s := &Scorer{badJA4: make(map[string]bool, len(badJA4))}
+	for _, fp := range badJA4 {
+		s.badJA4[strings.ToLower(strings.TrimSpace(fp))] = true
+	}
+	return s
+}
+
+// LoadJA4List reads a list of fingerprints, one per line. Blank lines and
+// lines starting with # are ignored, as is anything after the first
+// whitespace, so that lines can be annotated.
+func LoadJA4List(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var fingerprints []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		fingerprints = append(fingerprints, fields[0])
+	}
+	return fingerprints, scanner.Err()
+}
+
+// Host scores a host.
+func (s *Scorer) Host(host *assets.Host) Assessment {
+	a := Assessment{IP: deref(host.IP), Reasons: []Reason{}}
+	if os := host.OperatingSystem; os != nil && isEndOfLife(*os) {
+		a.add(endOfLifePoints, fmt.Sprintf("end-of-life operating system %s", describe(*os)))
+	}
+	for _, svc := range host.Services {
+		port := deref(svc.Port)
+		if m, ok := matchManagementService(svc); ok {
+			a.add(m.points, fmt.Sprintf("%s exposed on port %d", m.protocol, port))
+		}
+		for _, attr := range svc.Software {
+			if isEndOfLife(attr) {
+				a.add(endOfLifePoints, fmt.Sprintf("end-of-life software %s on port %d", describe(attr), port))
+			}
+		}
+		if isSelfSigned(svc.Cert) {
+			a.add(selfSignedPoints, fmt.Sprintf("self-signed certificate on port %d", port))
+		}
+		for _, fp := range ja4Fingerprints(svc) {
+			if s.badJA4[strings.ToLower(fp)] {
+				a.add(badJA4Points, fmt.Sprintf("known-bad JA4 fingerprint %s on port %d", fp, port))
+			}
+		}
+	}
+	sort.SliceStable(a.Reasons, func(i, j int) bool { return a.Reasons[i].Points > a.Reasons[j].Points })
+	a.Score = min(a.Score, MaxScore)
+	a.Level = LevelOf(a.Score)
+	return a
+}
+
+func (a *Assessment) add(points int, reason string) {
+	a.Score += points
+	a.Reasons = append(a.Reasons, Reason{Points: points, Reason: reason})
+}
+
+func matchManagementService(svc components.Service) (managementService, bool) {
+	protocol := strings.ToUpper(deref(svc.Protocol))
+	port := deref(svc.Port)
+	for _, m := range managementServices {
+		if protocol == m.protocol {
+			return m, true
+		}
+	}
+	if protocol != "" && protocol != "UNKNOWN" {
+		return managementService{}, false
+	}
+	for _, m := range managementServices {
+		for _, p := range m.ports {
+			if port == p {
+				return m, true
+			}
+		}
+	}
+	return managementService{}, false
+}
+
+func isEndOfLife(attr components.Attribute) bool {
+	return attr.LifeCycle != nil && deref(attr.LifeCycle.EndOfLife)
+}
+
+// describe returns the vendor, product, and version of an attribute.
+func describe(attr components.Attribute) string {
+	parts := make([]string, 0, 3)
+	for _, p := range []*string{attr.Vendor, attr.Product, attr.Version} {
+		if v := deref(p); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	if len(parts) == 0 {
+		return deref(attr.Cpe)
+	}
+	return strings.Join(parts, " ")
+}
+
+func isSelfSigned(cert *components.Certificate) bool {
+	if cert == nil || cert.Parsed == nil || cert.Parsed.Signature == nil {
+		return false
+	}
+	return deref(cert.Parsed.Signature.SelfSigned)
+}
+
+// ja4Fingerprints returns the JA4S and JA4T fingerprints of a service.
+func ja4Fingerprints(svc components.Service) []string {
+	var fps []string
+	if svc.TLS != nil && deref(svc.TLS.Ja4s) != "" {
+		fps = append(fps, *svc.TLS.Ja4s)
+	}
+	if svc.Ja4tscan != nil && deref(svc.Ja4tscan.Fingerprint) != "" {
+		fps = append(fps, *svc.Ja4tscan.Fingerprint)
+	}
+	return fps
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
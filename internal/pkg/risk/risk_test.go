@@ -0,0 +1,79 @@
+package risk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestScorer_Host(t *testing.T) {
+	host := &assets.Host{Host: components.Host{
+		IP: ptr("10.0.0.1"),
+		OperatingSystem: &components.Attribute{
+			Vendor: ptr("microsoft"), Product: ptr("windows_server_2008"),
+			LifeCycle: &components.CPELifeCycle{EndOfLife: ptr(true)},
+		},
+		Services: []components.Service{
+			{Port: ptr(3389), Protocol: ptr("RDP")},
+			// identified by port when the protocol is unknown
+			{Port: ptr(23), Protocol: ptr("UNKNOWN")},
+			{
+				Port: ptr(443), Protocol: ptr("HTTP"),
+				Software: []components.Attribute{
+					{Vendor: ptr("apache"), Product: ptr("http_server"), Version: ptr("2.2.15"), LifeCycle: &components.CPELifeCycle{EndOfLife: ptr(true)}},
+					{Vendor: ptr("openssl"), Product: ptr("openssl"), LifeCycle: &components.CPELifeCycle{EndOfLife: ptr(false)}},
+				},
+				Cert: &components.Certificate{Parsed: &components.CertificateParsed{Signature: &components.Signature{SelfSigned: ptr(true)}}},
+				TLS:  &components.TLS{Ja4s: ptr("t130200_1301_234ea6891581")},
+			},
+			// an HTTP service on a management port is not flagged
+			{Port: ptr(3306), Protocol: ptr("HTTP")},
+		},
+	}}
+
+	a := New([]string{"T130200_1301_234EA6891581"}).Host(host)
+	assert.Equal(t, "10.0.0.1", a.IP)
+	assert.Equal(t, MaxScore, a.Score)
+	assert.Equal(t, LevelCritical, a.Level)
+	assert.Equal(t, []Reason{
+		{Points: 40, Reason: "known-bad JA4 fingerprint t130200_1301_234ea6891581 on port 443"},
+		{Points: 30, Reason: "TELNET exposed on port 23"},
+		{Points: 25, Reason: "RDP exposed on port 3389"},
+		{Points: 20, Reason: "end-of-life operating system microsoft windows_server_2008"},
+		{Points: 20, Reason: "end-of-life software apache http_server 2.2.15 on port 443"},
+		{Points: 10, Reason: "self-signed certificate on port 443"},
+	}, a.Reasons)
+}
+
+func TestScorer_HostWithoutIndicators(t *testing.T) {
+	host := &assets.Host{Host: components.Host{
+		IP:       ptr("10.0.0.2"),
+		Services: []components.Service{{Port: ptr(443), Protocol: ptr("HTTP")}},
+	}}
+	a := New(nil).Host(host)
+	assert.Equal(t, Assessment{IP: "10.0.0.2", Level: LevelNone, Reasons: []Reason{}}, a)
+}
+
+func TestLevelOf(t *testing.T) {
+	assert.Equal(t, LevelNone, LevelOf(0))
+	assert.Equal(t, LevelLow, LevelOf(5))
+	assert.Equal(t, LevelMedium, LevelOf(25))
+	assert.Equal(t, LevelHigh, LevelOf(74))
+	assert.Equal(t, LevelCritical, LevelOf(100))
+}
+
+func TestLoadJA4List(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ja4.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# known C2 servers\nt130200_1301_234ea6891581  cobalt strike\n\n65535_2-4-8-1-3_1460_7\n"), 0o600))
+	fps, err := LoadJA4List(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t130200_1301_234ea6891581", "65535_2-4-8-1-3_1460_7"}, fps)
+}
@@ -0,0 +1,411 @@
+// Package scope restricts the assets that the CLI queries to those of a
+// scope file, so that shared credentials cannot be used to look into assets
+// that an engagement does not cover.
+//
+// A scope file lists IP addresses, CIDR ranges, and domains, one per line.
+// Entries starting with ! are denied, others are allowed. A domain covers its
+// subdomains; a leading *. is the same as the bare domain. Blank lines, lines
+// starting with #, and anything after the first whitespace of a line are
+// ignored:
+//
+//	# the engagement
+//	198.51.100.0/24
+//	example.com
+//	# except the mail servers, which are run by a third party
+//	!198.51.100.25
+//	!mail.example.com
+//
+// An asset is out of scope if a denied entry covers it, or if the file has
+// allowed entries and none covers it. A file with only denied entries allows
+// everything else. With allowed entries, a query must be limited to assets in
+// scope, as it would otherwise match assets out of it.
+package scope
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/cenql"
+)
+
+// Scope is a set of allowed and denied IP ranges and domains.
+type Scope struct {
+	allowPrefixes []netip.Prefix
+	denyPrefixes  []netip.Prefix
+	allowDomains  []string
+	denyDomains   []string
+}
+
+// Load reads the scope file at path.
+func Load(path string) (*Scope, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads a scope file from r.
+func Parse(r io.Reader) (*Scope, error) {
+	s := &Scope{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	entries := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		entry, deny := strings.CutPrefix(fields[0], "!")
+		if err := s.add(entry, deny); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if entries == 0 {
+		return nil, fmt.Errorf("the scope file has no entries")
+	}
+	return s, nil
+}
+
+func (s *Scope) add(entry string, deny bool) error {
+	if prefix, ok := parsePrefix(entry); ok {
+		if deny {
+			s.denyPrefixes = append(s.denyPrefixes, prefix)
+		} else {
+			s.allowPrefixes = append(s.allowPrefixes, prefix)
+		}
+		return nil
+	}
+	domain, ok := normalizeHostname(entry)
+	if !ok {
+		return fmt.Errorf("%q is not an IP address, a CIDR range, or a domain", entry)
+	}
+	if deny {
+		s.denyDomains = append(s.denyDomains, domain)
+	} else {
+		s.allowDomains = append(s.allowDomains, domain)
+	}
+	return nil
+}
+
+// hasAllowed reports whether the scope has allowed entries, in which case
+// assets that none covers are out of scope.
+func (s *Scope) hasAllowed() bool {
+	return len(s.allowPrefixes) > 0 || len(s.allowDomains) > 0
+}
+
+// CheckIP returns a Violation if the IP address or CIDR range raw is out of
+// scope. A range is in scope if an allowed entry covers all of it and no
+// denied entry covers any of it.
+func (s *Scope) CheckIP(raw string) error {
+	prefix, ok := parsePrefix(raw)
+	if !ok {
+		return newViolation(raw, "it is not an IP address")
+	}
+	for _, denied := range s.denyPrefixes {
+		if denied.Overlaps(prefix) {
+			return newViolation(raw, fmt.Sprintf("it is denied by !%s", formatPrefix(denied)))
+		}
+	}
+	if !s.hasAllowed() {
+		return nil
+	}
+	for _, allowed := range s.allowPrefixes {
+		if allowed.Bits() <= prefix.Bits() && allowed.Contains(prefix.Addr()) {
+			return nil
+		}
+	}
+	return newViolation(raw, "no allowed range covers it")
+}
+
+// CheckHostname returns a Violation if the hostname name is out of scope. An
+// IP address is checked as one.
+func (s *Scope) CheckHostname(name string) error {
+	if _, ok := parsePrefix(name); ok {
+		return s.CheckIP(name)
+	}
+	host, ok := normalizeHostname(name)
+	if !ok {
+		return newViolation(name, "it is not a hostname")
+	}
+	for _, denied := range s.denyDomains {
+		if coversDomain(denied, host) {
+			return newViolation(name, fmt.Sprintf("it is denied by !%s", denied))
+		}
+	}
+	if !s.hasAllowed() {
+		return nil
+	}
+	for _, allowed := range s.allowDomains {
+		if coversDomain(allowed, host) {
+			return nil
+		}
+	}
+	return newViolation(name, "no allowed domain covers it")
+}
+
+// CheckQuery returns a Violation for the first IP address, CIDR range, or
+// hostname of query that is out of scope. Hostnames are looked for in free
+// text and in the values of fields named after names, hosts, or domains,
+// such as web.hostname and host.dns.names. Values that are negated, or
+// matched as regular expressions, are not checked.
+//
+// If the scope has allowed entries, the query must also be limited to
+// assets in scope: each of its matches must be required to equal an IP
+// address, CIDR range, or hostname of a field that identifies an asset, such
+// as host.ip = 198.51.100.0/24 or web.hostname = www.example.com. A query
+// that names no asset, such as host.services.port: 22, would query every
+// asset, and is out of scope. So is a query that only matches its assets
+// with :, which also matches values that merely contain the name, such as
+// www.example.com.attacker.net. So
+// is an empty query, which stands for every asset.
+func (s *Scope) CheckQuery(query string) error {
+	if strings.TrimSpace(query) == "" {
+		if s.hasAllowed() {
+			return newViolation("an empty query", "it matches every asset; "+
+				"add a query such as host.ip = <range> or web.hostname = <domain>")
+		}
+		return nil
+	}
+	root, err := cenql.Parse(query)
+	if err != nil {
+		return newViolation(query, fmt.Sprintf("it cannot be checked against the scope: %v", err))
+	}
+	if err := s.checkNode(root, ""); err != nil {
+		return err
+	}
+	if s.hasAllowed() && !limitsAssets(root, "") {
+		return newViolation(query, "it is not limited to assets in scope; "+
+			"add a term such as host.ip = <range> or web.hostname = <domain>")
+	}
+	return nil
+}
+
+// limitsAssets reports whether every match of node is required to match an
+// asset term: an identifier field that equals an IP address, CIDR range, or
+// hostname. Matches with :, negated terms, regular expressions, ranges, and
+// free text do not limit the assets a query matches.
+func limitsAssets(node cenql.Node, prefix string) bool {
+	switch n := node.(type) {
+	case *cenql.BoolNode:
+		if n.Op == cenql.Or {
+			for _, child := range n.Children {
+				if !limitsAssets(child, prefix) {
+					return false
+				}
+			}
+			return len(n.Children) > 0
+		}
+		for _, child := range n.Children {
+			if limitsAssets(child, prefix) {
+				return true
+			}
+		}
+	case *cenql.NestedNode:
+		return limitsAssets(n.Query, prefix+n.Field+".")
+	case *cenql.TermNode:
+		if n.Op != cenql.OpEqual {
+			return false
+		}
+		return isAssetValue(n.Value, prefix+n.Field)
+	}
+	return false
+}
+
+var (
+	// ipIdentifierFields are the fields that hold the address of the asset.
+	ipIdentifierFields = []string{"host.ip", "host.services.ip"}
+	// nameIdentifierFields are the fields that hold the names of the asset.
+	// Reverse DNS names are set by the owner of the address, and so do not
+	// identify it.
+	nameIdentifierFields = []string{"web.hostname", "host.dns.names"}
+)
+
+// isAssetValue reports whether v, the value of field, is an asset, or a set
+// of assets.
+func isAssetValue(v cenql.Value, field string) bool {
+	field = strings.ToLower(field)
+	if v.Range != nil {
+		return false
+	}
+	if v.Set != nil {
+		for _, item := range v.Set {
+			if !isAssetValue(item, field) {
+				return false
+			}
+		}
+		return len(v.Set) > 0
+	}
+	text := strings.TrimSpace(v.Text)
+	if _, ok := parsePrefix(text); ok {
+		return slices.Contains(ipIdentifierFields, field) || slices.Contains(nameIdentifierFields, field)
+	}
+	return slices.Contains(nameIdentifierFields, field) && looksLikeHostname(text)
+}
+
+func (s *Scope) checkNode(node cenql.Node, prefix string) error {
+	switch n := node.(type) {
+	case *cenql.BoolNode:
+		for _, child := range n.Children {
+			if err := s.checkNode(child, prefix); err != nil {
+				return err
+			}
+		}
+	case *cenql.NestedNode:
+		return s.checkNode(n.Query, prefix+n.Field+".")
+	case *cenql.TermNode:
+		if n.Op == cenql.OpRegex {
+			return nil
+		}
+		return s.checkValue(n.Value, isNameField(prefix+n.Field))
+	case *cenql.FreeTextNode:
+		return s.checkValue(n.Value, true)
+	}
+	// negated terms exclude assets rather than query them
+	return nil
+}
+
+func (s *Scope) checkValue(v cenql.Value, names bool) error {
+	switch {
+	case v.Range != nil:
+		for _, end := range []cenql.Value{v.Range.Low, v.Range.High} {
+			if err := s.checkValue(end, names); err != nil {
+				return err
+			}
+		}
+		return nil
+	case v.Set != nil:
+		for _, item := range v.Set {
+			if err := s.checkValue(item, names); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	text := strings.TrimSpace(v.Text)
+	if _, ok := parsePrefix(text); ok {
+		return s.CheckIP(text)
+	}
+	if names && looksLikeHostname(text) {
+		return s.CheckHostname(text)
+	}
+	return nil
+}
+
+// isNameField reports whether field holds hostnames.
+func isNameField(field string) bool {
+	last := strings.ToLower(field[strings.LastIndex(field, ".")+1:])
+	for _, part := range []string{"name", "host", "domain"} {
+		if strings.Contains(last, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeHostname reports whether text is a dotted name with a top-level
+// domain of letters, such as example.com or *.example.com.
+func looksLikeHostname(text string) bool {
+	host, ok := normalizeHostname(text)
+	if !ok || !strings.Contains(host, ".") {
+		return false
+	}
+	tld := host[strings.LastIndex(host, ".")+1:]
+	for _, c := range tld {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePrefix parses an IP address, as a single-address range, or a CIDR
+// range.
+func parsePrefix(raw string) (netip.Prefix, bool) {
+	raw = strings.Trim(raw, "[]")
+	if addr, err := netip.ParseAddr(raw); err == nil {
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), true
+	}
+	if prefix, err := netip.ParsePrefix(raw); err == nil {
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		return prefix.Masked(), true
+	}
+	return netip.Prefix{}, false
+}
+
+// formatPrefix formats a single-address range as the address.
+func formatPrefix(prefix netip.Prefix) string {
+	if prefix.IsSingleIP() {
+		return prefix.Addr().String()
+	}
+	return prefix.String()
+}
+
+// normalizeHostname lowercases name and removes a leading *. and a trailing
+// dot. It returns false if name is not a hostname.
+func normalizeHostname(name string) (string, bool) {
+	host := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+	host = strings.TrimPrefix(host, "*.")
+	if host == "" || len(host) > 253 {
+		return "", false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return "", false
+		}
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+				return "", false
+			}
+		}
+	}
+	return host, true
+}
+
+// coversDomain reports whether domain is host or one of its parents.
+func coversDomain(domain, host string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// Violation is an asset or query that is out of scope.
+type Violation interface {
+	cenclierrors.CencliError
+	// Value is the IP address, range, hostname, or query out of scope.
+	Value() string
+}
+
+type violation struct {
+	value  string
+	reason string
+}
+
+var _ Violation = &violation{}
+
+func newViolation(value, reason string) Violation {
+	return &violation{value: value, reason: reason}
+}
+
+func (e *violation) Error() string {
+	return fmt.Sprintf("%s is out of scope: %s", e.value, e.reason)
+}
+
+func (e *violation) Title() string { return "Out of Scope" }
+
+func (e *violation) ShouldPrintUsage() bool { return false }
+
+func (e *violation) Value() string { return e.value }
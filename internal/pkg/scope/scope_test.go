@@ -0,0 +1,110 @@
+package scope
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const engagement = `# the engagement
+198.51.100.0/24   client DMZ
+2001:db8::/32
+*.example.com
+# except the mail servers, run by a third party
+!198.51.100.25
+!mail.example.com
+`
+
+func TestParse(t *testing.T) {
+	_, err := Parse(strings.NewReader(engagement))
+	require.NoError(t, err)
+
+	_, err = Parse(strings.NewReader("# nothing\n\n"))
+	require.ErrorContains(t, err, "no entries")
+
+	_, err = Parse(strings.NewReader("198.51.100.0/24\nexample.com/admin\n"))
+	require.ErrorContains(t, err, `line 2: "example.com/admin" is not an IP address, a CIDR range, or a domain`)
+}
+
+func TestCheck(t *testing.T) {
+	s, err := Parse(strings.NewReader(engagement))
+	require.NoError(t, err)
+
+	t.Run("ips and ranges", func(t *testing.T) {
+		for _, in := range []string{"198.51.100.1", "198.51.100.128/25", "2001:db8::1", "::ffff:198.51.100.7"} {
+			assert.NoError(t, s.CheckIP(in), in)
+		}
+		assert.EqualError(t, s.CheckIP("198.51.100.25"), "198.51.100.25 is out of scope: it is denied by !198.51.100.25")
+		assert.EqualError(t, s.CheckIP("198.51.100.0/24"), "198.51.100.0/24 is out of scope: it is denied by !198.51.100.25")
+		assert.EqualError(t, s.CheckIP("203.0.113.5"), "203.0.113.5 is out of scope: no allowed range covers it")
+		assert.EqualError(t, s.CheckIP("198.51.101.0/24"), "198.51.101.0/24 is out of scope: no allowed range covers it")
+	})
+
+	t.Run("hostnames", func(t *testing.T) {
+		for _, in := range []string{"example.com", "WWW.Example.com.", "*.dev.example.com", "198.51.100.1"} {
+			assert.NoError(t, s.CheckHostname(in), in)
+		}
+		assert.EqualError(t, s.CheckHostname("smtp.mail.example.com"), "smtp.mail.example.com is out of scope: it is denied by !mail.example.com")
+		assert.EqualError(t, s.CheckHostname("notexample.com"), "notexample.com is out of scope: no allowed domain covers it")
+		assert.EqualError(t, s.CheckHostname("203.0.113.5"), "203.0.113.5 is out of scope: no allowed range covers it")
+
+		var v Violation
+		require.ErrorAs(t, s.CheckHostname("evil.org"), &v)
+		assert.Equal(t, "evil.org", v.Value())
+		assert.Equal(t, "Out of Scope", v.Title())
+	})
+
+	t.Run("queries", func(t *testing.T) {
+		for _, q := range []string{
+			"host.ip = 198.51.100.128/25 and host.services.protocol=SSH",
+			`web.hostname = "www.example.com"`,
+			"host.dns.names = {api.example.com, example.com}",
+			"host.ip = 198.51.100.1 and not host.ip: 203.0.113.5",
+			`web.hostname = example.com and cert.names =~ ".*\.evil\.org"`,
+			"host.ip = 198.51.100.1 and host.services.software.product: nginx.server",
+			"host.services: (port = 443 and ip = 198.51.100.9)",
+			"host.ip = 198.51.100.1 or web.hostname = www.example.com",
+		} {
+			assert.NoError(t, s.CheckQuery(q), q)
+		}
+		for q, want := range map[string]string{
+			"host.ip: 203.0.113.5":                        "203.0.113.5 is out of scope",
+			"host.ip = 198.51.100.0/24":                   "198.51.100.0/24 is out of scope",
+			"host.ip: [198.51.100.1 to 203.0.113.1]":      "203.0.113.1 is out of scope",
+			"web.hostname: mail.example.com":              "mail.example.com is out of scope",
+			"cert.parsed.subject.common_name: *.evil.org": "*.evil.org is out of scope",
+			"evil.org": "evil.org is out of scope",
+			"host.services: (port = 22 and ip = 10.0.0.1)":  "10.0.0.1 is out of scope",
+			"host.services.port: 22 or host.ip: 192.0.2.10": "192.0.2.10 is out of scope",
+			"host.ip: (":                                      "cannot be checked against the scope",
+			"host.services.port: 22":                          "it is not limited to assets in scope",
+			"not host.ip: 203.0.113.5":                        "it is not limited to assets in scope",
+			`cert.names =~ ".*\.example\.com"`:                "it is not limited to assets in scope",
+			"host.ip: 198.51.100.1 or host.services.port: 22": "it is not limited to assets in scope",
+			"host.services.banner: 198.51.100.1":              "it is not limited to assets in scope",
+			"example.com":                                     "it is not limited to assets in scope",
+			"web.hostname: example.com":                       "it is not limited to assets in scope",
+			"web.hostname: www.example.com":                   "it is not limited to assets in scope",
+			"host.ip: 198.51.100.1":                           "it is not limited to assets in scope",
+			"host.dns.names: example.com":                     "it is not limited to assets in scope",
+			"host.dns.reverse_dns.names = example.com":        "it is not limited to assets in scope",
+			"host.services.cert.names = www.example.com":      "it is not limited to assets in scope",
+			"": "an empty query is out of scope",
+		} {
+			assert.ErrorContains(t, s.CheckQuery(q), want, q)
+		}
+	})
+
+	t.Run("denied entries only", func(t *testing.T) {
+		deny, err := Parse(strings.NewReader("!203.0.113.0/24\n!evil.org\n"))
+		require.NoError(t, err)
+		assert.NoError(t, deny.CheckIP("198.51.100.1"))
+		assert.NoError(t, deny.CheckHostname("example.com"))
+		assert.Error(t, deny.CheckIP("203.0.113.5"))
+		assert.Error(t, deny.CheckHostname("www.evil.org"))
+		assert.NoError(t, deny.CheckQuery("host.services.port: 22"))
+		assert.NoError(t, deny.CheckQuery(""))
+	})
+}
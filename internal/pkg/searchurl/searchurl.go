@@ -0,0 +1,90 @@
+// Package searchurl builds links to Censys platform searches, such as the
+// links printed next to the queries of censeye, rarity, and pivot.
+//
+// Links are built from a template with two placeholders: {query}, replaced by
+// the URL-encoded query, and {org_id}, replaced by the organization ID (or
+// nothing). Templates let links point at a domain in front of the platform,
+// such as an SSO portal, instead of platform.censys.io.
+package searchurl
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+)
+
+const (
+	// DefaultTemplate links to the search page of the Censys platform.
+	DefaultTemplate = "https://platform.censys.io/search?q={query}"
+
+	queryPlaceholder = "{query}"
+	orgIDPlaceholder = "{org_id}"
+	// orgParam is the query parameter that selects the organization of a
+	// platform page.
+	orgParam = "org"
+)
+
+// Builder builds search links from a template.
+type Builder struct {
+	template string
+}
+
+// New returns a builder for template, or for DefaultTemplate if template is
+// empty. The template is expected to have been checked with Validate.
+func New(template string) Builder {
+	if strings.TrimSpace(template) == "" {
+		template = DefaultTemplate
+	}
+	return Builder{template: template}
+}
+
+// URL returns the link to a search for query. With an organization ID, the
+// link opens the search in that organization: the ID replaces {org_id}, or,
+// if the template has no {org_id}, is added as the org query parameter.
+func (b Builder) URL(query string, orgID mo.Option[identifiers.OrganizationID]) string {
+	if b.template == "" {
+		b = New("")
+	}
+	org := ""
+	if id, ok := orgID.Get(); ok {
+		org = id.String()
+	}
+	link := strings.ReplaceAll(b.template, queryPlaceholder, url.QueryEscape(query))
+	if strings.Contains(link, orgIDPlaceholder) {
+		return strings.ReplaceAll(link, orgIDPlaceholder, url.QueryEscape(org))
+	}
+	if org == "" {
+		return link
+	}
+	sep := "?"
+	if strings.Contains(link, "?") {
+		sep = "&"
+	}
+	return link + sep + orgParam + "=" + url.QueryEscape(org)
+}
+
+// Validate checks that template is empty or an absolute http(s) URL with a
+// {query} placeholder.
+func Validate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return nil
+	}
+	if !strings.Contains(template, queryPlaceholder) {
+		return errors.New("must contain the {query} placeholder")
+	}
+	// placeholders are not valid in every part of a URL, so parse the URL
+	// they would produce
+	u, err := url.Parse(strings.NewReplacer(queryPlaceholder, "q", orgIDPlaceholder, "org").Replace(template))
+	if err != nil {
+		return fmt.Errorf("must be a URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an http or https URL, got %q", template)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package searchurl
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+)
+
+func TestBuilder_URL(t *testing.T) {
+	orgID := mo.Some(identifiers.NewOrganizationID(uuid.MustParse("11111111-2222-3333-4444-555555555555")))
+	none := mo.None[identifiers.OrganizationID]()
+
+	testCases := []struct {
+		name     string
+		template string
+		query    string
+		orgID    mo.Option[identifiers.OrganizationID]
+		expected string
+	}{
+		{
+			name:     "simple query",
+			query:    `host.ip="8.8.8.8"`,
+			orgID:    none,
+			expected: `https://platform.censys.io/search?q=host.ip%3D%228.8.8.8%22`,
+		},
+		{
+			name:     "query with spaces",
+			query:    `host.services:(protocol="https" and port="443")`,
+			orgID:    none,
+			expected: `https://platform.censys.io/search?q=host.services%3A%28protocol%3D%22https%22+and+port%3D%22443%22%29`,
+		},
+		{
+			name:     "empty query",
+			query:    "",
+			orgID:    none,
+			expected: "https://platform.censys.io/search?q=",
+		},
+		{
+			name:     "organization is added as a parameter",
+			query:    "host.ip=1.1.1.1",
+			orgID:    orgID,
+			expected: "https://platform.censys.io/search?q=host.ip%3D1.1.1.1&org=11111111-2222-3333-4444-555555555555",
+		},
+		{
+			name:     "template without a query string",
+			template: "https://censys.sso.example.com/go/{query}",
+			query:    "a b",
+			orgID:    orgID,
+			expected: "https://censys.sso.example.com/go/a+b?org=11111111-2222-3333-4444-555555555555",
+		},
+		{
+			name:     "template placing the organization",
+			template: "https://censys.sso.example.com/{org_id}/search?query={query}",
+			query:    "a b",
+			orgID:    orgID,
+			expected: "https://censys.sso.example.com/11111111-2222-3333-4444-555555555555/search?query=a+b",
+		},
+		{
+			name:     "template placing the organization without one",
+			template: "https://censys.sso.example.com/search?query={query}&org={org_id}",
+			query:    "a",
+			orgID:    none,
+			expected: "https://censys.sso.example.com/search?query=a&org=",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, New(tc.template).URL(tc.query, tc.orgID))
+		})
+	}
+
+	t.Run("zero value uses the default template", func(t *testing.T) {
+		require.Equal(t, "https://platform.censys.io/search?q=a", Builder{}.URL("a", none))
+	})
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		template string
+		wantErr  string
+	}{
+		{name: "empty", template: ""},
+		{name: "default", template: DefaultTemplate},
+		{name: "placeholder in path", template: "https://sso.example.com/{org_id}/s/{query}"},
+		{name: "missing query placeholder", template: "https://sso.example.com/search", wantErr: "{query}"},
+		{name: "not http", template: "ftp://sso.example.com/?q={query}", wantErr: "http or https"},
+		{name: "relative", template: "/search?q={query}", wantErr: "http or https"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.template)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	// ChecksumsName is the release asset listing the SHA-256 of every archive.
+	ChecksumsName = "checksums.txt"
+	// projectName prefixes every release archive.
+	projectName = "cencli"
+	// binaryName is the executable inside release archives.
+	binaryName = "censys"
+)
+
+// ErrNoArchive is returned when a release has no archive for the platform.
+var ErrNoArchive = errors.New("release has no archive for this platform")
+
+// ArchiveFor returns the release archive for the given platform.
+func (r Release) ArchiveFor(goos, goarch string) (Asset, error) {
+	base := fmt.Sprintf("%s_%s_%s_%s", projectName, strings.TrimPrefix(r.Version, "v"), goos, goarch)
+	for _, ext := range []string{".tar.gz", ".zip"} {
+		if a, ok := r.Asset(base + ext); ok {
+			return a, nil
+		}
+	}
+	return Asset{}, fmt.Errorf("%w (%s/%s)", ErrNoArchive, goos, goarch)
+}
+
+// VerifyChecksum checks data against the SHA-256 listed for name in checksums,
+// which is in the format written by sha256sum.
+func VerifyChecksum(data []byte, name string, checksums []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, fields[0]) {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, fields[0], got)
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("%s is not listed in %s", name, ChecksumsName)
+}
+
+// ExtractBinary returns the censys executable from a .tar.gz or .zip archive.
+func ExtractBinary(archiveName string, archive []byte) ([]byte, error) {
+	name := binaryName
+	if strings.Contains(archiveName, "_windows_") {
+		name += ".exe"
+	}
+	if strings.HasSuffix(archiveName, ".zip") {
+		return extractZip(archive, name)
+	}
+	return extractTarGz(archive, name)
+}
+
+func extractTarGz(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+	return nil, fmt.Errorf("archive does not contain %s", name)
+}
+
+func extractZip(archive []byte, name string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || path.Base(f.Name) != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("archive does not contain %s", name)
+}
+
+// ManagedBy returns the name of the package manager that installed the
+// executable at exe, or an empty string if it was installed manually.
+// Package-managed installs should be upgraded through the package manager.
+func ManagedBy(exe string) string {
+	slashed := filepath.ToSlash(exe)
+	switch {
+	case strings.Contains(slashed, "/Cellar/"), strings.Contains(slashed, "/Caskroom/"), strings.Contains(slashed, "/homebrew/"):
+		return "Homebrew"
+	default:
+		return ""
+	}
+}
+
+// ReplaceExecutable atomically replaces the executable at exe with binary,
+// keeping its file mode. The new binary is written next to exe and renamed
+// over it, so a failure leaves the original in place. Windows does not allow
+// replacing a running executable, so it is moved aside to exe.old first.
+func ReplaceExecutable(exe string, binary []byte) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".censys-update-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	cleanup := func() { _ = os.Remove(tmpName) }
+	if _, err := tmp.Write(binary); err != nil {
+		_ = tmp.Close()
+		cleanup()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return err
+	}
+	if err := os.Chmod(tmpName, info.Mode().Perm()); err != nil {
+		cleanup()
+		return err
+	}
+
+	if runtime.GOOS != "windows" {
+		if err := os.Rename(tmpName, exe); err != nil {
+			cleanup()
+			return err
+		}
+		return nil
+	}
+
+	old := exe + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		cleanup()
+		return err
+	}
+	if err := os.Rename(tmpName, exe); err != nil {
+		// put the original back
+		_ = os.Rename(old, exe)
+		cleanup()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// tarGz builds a .tar.gz archive with the given files, as in a release.
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRelease_ArchiveFor(t *testing.T) {
+	release := Release{Version: "v1.2.0", Assets: []Asset{
+		{Name: "cencli_1.2.0_linux_amd64.tar.gz"},
+		{Name: "cencli_1.2.0_windows_arm64.zip"},
+	}}
+	asset, err := release.ArchiveFor("linux", "amd64")
+	require.NoError(t, err)
+	require.Equal(t, "cencli_1.2.0_linux_amd64.tar.gz", asset.Name)
+
+	asset, err = release.ArchiveFor("windows", "arm64")
+	require.NoError(t, err)
+	require.Equal(t, "cencli_1.2.0_windows_arm64.zip", asset.Name)
+
+	_, err = release.ArchiveFor("darwin", "arm64")
+	require.ErrorIs(t, err, ErrNoArchive)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("archive")
+	checksums := []byte(sha256Hex([]byte("other")) + "  cencli_1.2.0_darwin_arm64.tar.gz\n" +
+		sha256Hex(data) + "  cencli_1.2.0_linux_amd64.tar.gz\n")
+
+	require.NoError(t, VerifyChecksum(data, "cencli_1.2.0_linux_amd64.tar.gz", checksums))
+	require.ErrorContains(t, VerifyChecksum([]byte("tampered"), "cencli_1.2.0_linux_amd64.tar.gz", checksums), "checksum mismatch")
+	require.ErrorContains(t, VerifyChecksum(data, "cencli_1.2.0_windows_amd64.tar.gz", checksums), "not listed")
+}
+
+func TestExtractBinary(t *testing.T) {
+	t.Run("tar.gz", func(t *testing.T) {
+		archive := tarGz(t, map[string]string{"README.md": "readme", "censys": "binary"})
+		binary, err := ExtractBinary("cencli_1.2.0_linux_amd64.tar.gz", archive)
+		require.NoError(t, err)
+		require.Equal(t, "binary", string(binary))
+	})
+
+	t.Run("zip on windows", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.Create("censys.exe")
+		require.NoError(t, err)
+		_, err = w.Write([]byte("exe"))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		binary, err := ExtractBinary("cencli_1.2.0_windows_amd64.zip", buf.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, "exe", string(binary))
+	})
+
+	t.Run("missing binary", func(t *testing.T) {
+		archive := tarGz(t, map[string]string{"README.md": "readme"})
+		_, err := ExtractBinary("cencli_1.2.0_linux_amd64.tar.gz", archive)
+		require.ErrorContains(t, err, "does not contain censys")
+	})
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "censys")
+	require.NoError(t, os.WriteFile(exe, []byte("old"), 0o755))
+
+	require.NoError(t, ReplaceExecutable(exe, []byte("new")))
+
+	content, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	require.Equal(t, "new", string(content))
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(exe)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	}
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestManagedBy(t *testing.T) {
+	require.Equal(t, "Homebrew", ManagedBy("/opt/homebrew/Caskroom/cencli/1.2.0/censys"))
+	require.Equal(t, "Homebrew", ManagedBy("/usr/local/Cellar/cencli/1.2.0/bin/censys"))
+	require.Empty(t, ManagedBy("/usr/local/bin/censys"))
+}
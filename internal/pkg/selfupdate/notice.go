@@ -0,0 +1,126 @@
+package selfupdate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// NoticeInterval is how often releases are checked, and how often a
+	// newer release is announced.
+	NoticeInterval = 24 * time.Hour
+	// noticeStateFile records the last check in the cache directory.
+	noticeStateFile = "update-check.json"
+)
+
+// noticeState is persisted between runs so that GitHub is queried, and the
+// user is notified, at most once per NoticeInterval.
+type noticeState struct {
+	CheckedAt  time.Time `json:"checked_at"`
+	Latest     string    `json:"latest,omitempty"`
+	NotifiedAt time.Time `json:"notified_at"`
+}
+
+// Notice announces newer releases. Create one with StartNotice before running
+// a command and call Pending when it completes, so that the release check
+// runs alongside the command instead of delaying it.
+type Notice struct {
+	path    string
+	current string
+	now     func() time.Time
+	state   noticeState
+	// refreshed receives the updated state if a release check is running
+	refreshed chan noticeState
+}
+
+// StartNotice loads the last check from cacheDir and, if it is older than
+// NoticeInterval, checks for a newer release than current in the background.
+// Prereleases are only considered when current is itself a prerelease.
+func StartNotice(ctx context.Context, client *Client, cacheDir, current string, now func() time.Time) *Notice {
+	n := &Notice{
+		path:    filepath.Join(cacheDir, noticeStateFile),
+		current: current,
+		now:     now,
+	}
+	n.load()
+	if now().Sub(n.state.CheckedAt) < NoticeInterval {
+		return n
+	}
+
+	channel := ChannelStable
+	if IsPrerelease(current) {
+		channel = ChannelBeta
+	}
+	n.refreshed = make(chan noticeState, 1)
+	state := n.state
+	go func() {
+		// a failed check is recorded too, so an offline machine does not
+		// retry on every run
+		state.CheckedAt = now()
+		if release, err := client.Latest(ctx, channel); err == nil {
+			state.Latest = release.Version
+		}
+		n.refreshed <- state
+	}()
+	return n
+}
+
+// Pending waits up to wait for a running release check and returns the notice
+// to show, or an empty string if there is no newer release or one was already
+// announced within NoticeInterval. Call MarkShown after showing it.
+func (n *Notice) Pending(wait time.Duration) string {
+	if n.refreshed != nil {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case n.state = <-n.refreshed:
+			n.save()
+		case <-timer.C:
+			// try again next run
+		}
+		n.refreshed = nil
+	}
+	if !IsNewer(n.state.Latest, n.current) || n.now().Sub(n.state.NotifiedAt) < NoticeInterval {
+		return ""
+	}
+	return fmt.Sprintf("A new version of cencli is available: %s (you have %s). Run \"censys update\" to upgrade.", n.state.Latest, Canonical(n.current))
+}
+
+// MarkShown records that the notice was shown.
+func (n *Notice) MarkShown() {
+	n.state.NotifiedAt = n.now()
+	n.save()
+}
+
+// CachedLatest returns the latest release found by the last notice check,
+// or an empty string if there has not been one.
+func CachedLatest(cacheDir string) string {
+	if cacheDir == "" {
+		return ""
+	}
+	n := &Notice{path: filepath.Join(cacheDir, noticeStateFile)}
+	n.load()
+	return n.state.Latest
+}
+
+func (n *Notice) load() {
+	data, err := os.ReadFile(n.path)
+	if err != nil {
+		return
+	}
+	// a corrupt state file is treated as missing
+	_ = json.Unmarshal(data, &n.state)
+}
+
+// save persists the state. Errors are ignored: the notice is best-effort.
+func (n *Notice) save() {
+	data, err := json.Marshal(n.state)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(n.path, data, 0o600)
+}
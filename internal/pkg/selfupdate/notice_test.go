@@ -0,0 +1,56 @@
+package selfupdate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotice(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(releasesJSON))
+	}))
+	t.Cleanup(srv.Close)
+	client := NewClient(srv.Client(), srv.URL)
+	cacheDir := t.TempDir()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	// first run checks GitHub and announces the release
+	n := StartNotice(context.Background(), client, cacheDir, "1.1.0", clock)
+	msg := n.Pending(5 * time.Second)
+	require.Equal(t, `A new version of cencli is available: v1.2.0 (you have v1.1.0). Run "censys update" to upgrade.`, msg)
+	n.MarkShown()
+	require.Equal(t, int32(1), requests.Load())
+	require.Equal(t, "v1.2.0", CachedLatest(cacheDir))
+
+	// later the same day: no check and no notice
+	now = now.Add(time.Hour)
+	n = StartNotice(context.Background(), client, cacheDir, "1.1.0", clock)
+	require.Empty(t, n.Pending(5*time.Second))
+	require.Equal(t, int32(1), requests.Load())
+
+	// the next day: checked and announced again
+	now = now.Add(NoticeInterval)
+	n = StartNotice(context.Background(), client, cacheDir, "1.1.0", clock)
+	require.NotEmpty(t, n.Pending(5*time.Second))
+	require.Equal(t, int32(2), requests.Load())
+
+	// up to date
+	now = now.Add(NoticeInterval)
+	n = StartNotice(context.Background(), client, cacheDir, "1.2.0", clock)
+	require.Empty(t, n.Pending(5*time.Second))
+}
+
+func TestNotice_PrereleaseUsesBetaChannel(t *testing.T) {
+	srv := releasesServer(t, http.StatusOK, releasesJSON)
+	n := StartNotice(context.Background(), NewClient(srv.Client(), srv.URL), t.TempDir(), "1.2.0-beta.1", time.Now)
+	require.Contains(t, n.Pending(5*time.Second), "v1.10.0-rc.1")
+}
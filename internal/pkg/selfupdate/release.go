@@ -0,0 +1,178 @@
+// Package selfupdate finds cencli releases on GitHub and replaces the running
+// binary with a newer one.
+package selfupdate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// DefaultReleasesURL lists the cencli releases, newest first.
+	DefaultReleasesURL = "https://api.github.com/repos/censys/cencli/releases"
+	// DefaultTimeout bounds a single request to GitHub.
+	DefaultTimeout = 30 * time.Second
+	// githubTokenEnvVar raises the GitHub API rate limit when set.
+	githubTokenEnvVar = "GITHUB_TOKEN"
+)
+
+// Channel selects which releases are considered.
+type Channel string
+
+const (
+	// ChannelStable only considers full releases.
+	ChannelStable Channel = "stable"
+	// ChannelBeta also considers prereleases.
+	ChannelBeta Channel = "beta"
+)
+
+// ErrInvalidChannel is returned by ParseChannel for unknown channels.
+var ErrInvalidChannel = errors.New("channel must be one of: stable, beta")
+
+// ParseChannel parses a release channel name.
+func ParseChannel(s string) (Channel, error) {
+	switch Channel(strings.ToLower(s)) {
+	case ChannelStable:
+		return ChannelStable, nil
+	case ChannelBeta:
+		return ChannelBeta, nil
+	default:
+		return "", ErrInvalidChannel
+	}
+}
+
+// ErrNoRelease is returned when no release matches the channel.
+var ErrNoRelease = errors.New("no release found")
+
+// Release is a published cencli release.
+type Release struct {
+	// Version is the release tag, e.g. v1.2.3.
+	Version    string  `json:"tag_name"`
+	Prerelease bool    `json:"prerelease"`
+	Draft      bool    `json:"draft"`
+	URL        string  `json:"html_url"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// Asset returns the asset with the given name.
+func (r Release) Asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Client talks to the GitHub releases API.
+type Client struct {
+	httpClient  *http.Client
+	releasesURL string
+}
+
+// NewClient returns a Client for releasesURL. If httpClient is nil,
+// a client with DefaultTimeout is used.
+func NewClient(httpClient *http.Client, releasesURL string) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &Client{httpClient: httpClient, releasesURL: releasesURL}
+}
+
+// Latest returns the newest release on the channel. Drafts and tags that are
+// not semantic versions are ignored.
+func (c *Client) Latest(ctx context.Context, channel Channel) (Release, error) {
+	body, err := c.get(ctx, c.releasesURL, "application/vnd.github+json")
+	if err != nil {
+		return Release{}, err
+	}
+	var releases []Release
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return Release{}, fmt.Errorf("failed to parse releases: %w", err)
+	}
+	var latest Release
+	for _, r := range releases {
+		if r.Draft || !semver.IsValid(Canonical(r.Version)) {
+			continue
+		}
+		if r.Prerelease && channel != ChannelBeta {
+			continue
+		}
+		if latest.Version == "" || semver.Compare(Canonical(r.Version), Canonical(latest.Version)) > 0 {
+			latest = r
+		}
+	}
+	if latest.Version == "" {
+		return Release{}, fmt.Errorf("%w on the %s channel", ErrNoRelease, channel)
+	}
+	return latest, nil
+}
+
+// Download fetches an asset's content.
+func (c *Client) Download(ctx context.Context, asset Asset) ([]byte, error) {
+	return c.get(ctx, asset.URL, "application/octet-stream")
+}
+
+func (c *Client) get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if token := os.Getenv(githubTokenEnvVar); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// IsNewer reports whether version latest is newer than current. Versions that
+// are not semantic versions (such as development builds) are never newer or older.
+func IsNewer(latest, current string) bool {
+	l, c := Canonical(latest), Canonical(current)
+	if !semver.IsValid(l) || !semver.IsValid(c) {
+		return false
+	}
+	return semver.Compare(l, c) > 0
+}
+
+// IsRelease reports whether version is a semantic version, as release builds are.
+func IsRelease(version string) bool {
+	return semver.IsValid(Canonical(version))
+}
+
+// IsPrerelease reports whether version is a prerelease, e.g. v1.2.0-beta.1.
+func IsPrerelease(version string) bool {
+	return semver.Prerelease(Canonical(version)) != ""
+}
+
+// Canonical adds the "v" prefix that release builds omit from their version.
+func Canonical(version string) string {
+	if version == "" || strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
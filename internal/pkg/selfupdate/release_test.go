@@ -0,0 +1,85 @@
+package selfupdate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const releasesJSON = `[
+	{"tag_name": "v1.3.0-beta.1", "prerelease": true, "html_url": "https://example.com/v1.3.0-beta.1"},
+	{"tag_name": "v1.2.0", "html_url": "https://example.com/v1.2.0", "assets": [
+		{"name": "checksums.txt", "browser_download_url": "https://example.com/checksums.txt", "size": 10}
+	]},
+	{"tag_name": "v2.0.0", "draft": true},
+	{"tag_name": "nightly"},
+	{"tag_name": "v1.10.0-rc.1", "prerelease": true},
+	{"tag_name": "v1.1.0"}
+]`
+
+func releasesServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/vnd.github+json", r.Header.Get("Accept"))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_Latest(t *testing.T) {
+	t.Run("stable skips prereleases, drafts, and non-semver tags", func(t *testing.T) {
+		srv := releasesServer(t, http.StatusOK, releasesJSON)
+		release, err := NewClient(srv.Client(), srv.URL).Latest(context.Background(), ChannelStable)
+		require.NoError(t, err)
+		require.Equal(t, "v1.2.0", release.Version)
+		require.Equal(t, "https://example.com/v1.2.0", release.URL)
+		asset, ok := release.Asset(ChecksumsName)
+		require.True(t, ok)
+		require.Equal(t, "https://example.com/checksums.txt", asset.URL)
+	})
+
+	t.Run("beta compares versions semantically", func(t *testing.T) {
+		srv := releasesServer(t, http.StatusOK, releasesJSON)
+		release, err := NewClient(srv.Client(), srv.URL).Latest(context.Background(), ChannelBeta)
+		require.NoError(t, err)
+		require.Equal(t, "v1.10.0-rc.1", release.Version)
+	})
+
+	t.Run("no release on channel", func(t *testing.T) {
+		srv := releasesServer(t, http.StatusOK, `[{"tag_name": "v1.0.0-beta.1", "prerelease": true}]`)
+		_, err := NewClient(srv.Client(), srv.URL).Latest(context.Background(), ChannelStable)
+		require.ErrorIs(t, err, ErrNoRelease)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		srv := releasesServer(t, http.StatusForbidden, `{"message": "rate limited"}`)
+		_, err := NewClient(srv.Client(), srv.URL).Latest(context.Background(), ChannelStable)
+		require.ErrorContains(t, err, "403")
+	})
+}
+
+func TestVersions(t *testing.T) {
+	require.True(t, IsNewer("v1.2.0", "1.1.9"))
+	require.True(t, IsNewer("v1.10.0", "v1.9.0"))
+	require.True(t, IsNewer("v1.2.0", "1.2.0-beta.1"))
+	require.False(t, IsNewer("v1.2.0", "1.2.0"))
+	require.False(t, IsNewer("v1.2.0", "dev"))
+	require.False(t, IsNewer("", "1.2.0"))
+
+	require.True(t, IsRelease("1.2.0"))
+	require.False(t, IsRelease("dev"))
+	require.True(t, IsPrerelease("1.2.0-beta.1"))
+	require.False(t, IsPrerelease("v1.2.0"))
+
+	for _, name := range []string{"stable", "BETA"} {
+		_, err := ParseChannel(name)
+		require.NoError(t, err)
+	}
+	_, err := ParseChannel("nightly")
+	require.ErrorIs(t, err, ErrInvalidChannel)
+}
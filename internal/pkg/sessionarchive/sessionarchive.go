@@ -0,0 +1,192 @@
+// Package sessionarchive reads and writes portable investigation session
+// archives: a gzipped tarball holding a JSON manifest of the recorded commands
+// and notes, plus the raw JSON response of each command keyed by its digest.
+package sessionarchive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatVersion is the archive format written by this package.
+	FormatVersion = 1
+	// FileExtension is the conventional extension for session archives.
+	FileExtension = ".cencli-session.tar.gz"
+
+	manifestName  = "manifest.json"
+	responsesDir  = "responses"
+	maxMemberSize = 256 << 20
+)
+
+// Manifest describes a session and its entries.
+type Manifest struct {
+	FormatVersion int        `json:"format_version"`
+	Name          string     `json:"name"`
+	StartedAt     time.Time  `json:"started_at"`
+	EndedAt       *time.Time `json:"ended_at,omitempty"`
+	ExportedAt    time.Time  `json:"exported_at"`
+	// ExportedWith is the version of the CLI that wrote the archive.
+	ExportedWith string  `json:"exported_with,omitempty"`
+	Entries      []Entry `json:"entries"`
+}
+
+// Entry is a recorded command or note.
+type Entry struct {
+	Kind    string `json:"kind"`
+	Command string `json:"command,omitempty"`
+	Query   string `json:"query,omitempty"`
+	Note    string `json:"note,omitempty"`
+	// Digest references the response stored in the archive, if any.
+	Digest     string    `json:"digest,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// Archive is a session manifest along with the responses it references.
+type Archive struct {
+	Manifest Manifest
+	// Responses maps a digest to the raw JSON response with that digest.
+	Responses map[string][]byte
+}
+
+// Digest returns the digest used to identify a response.
+func Digest(response []byte) string {
+	sum := sha256.Sum256(response)
+	return hex.EncodeToString(sum[:])
+}
+
+// Write writes a as a gzipped tarball to w.
+// Responses are written once per digest, in digest order, so that
+// exporting the same session twice produces the same members.
+func Write(w io.Writer, a Archive) error {
+	manifest := a.Manifest
+	manifest.FormatVersion = FormatVersion
+	for _, e := range manifest.Entries {
+		if e.Digest == "" {
+			continue
+		}
+		if _, ok := a.Responses[e.Digest]; !ok {
+			return fmt.Errorf("entry references missing response %s", e.Digest)
+		}
+	}
+	rawManifest, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	modTime := manifest.ExportedAt
+	if err := writeMember(tw, manifestName, rawManifest, modTime); err != nil {
+		return err
+	}
+	digests := make([]string, 0, len(a.Responses))
+	for d := range a.Responses {
+		digests = append(digests, d)
+	}
+	sort.Strings(digests)
+	for _, d := range digests {
+		if err := writeMember(tw, responseName(d), a.Responses[d], modTime); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// Read reads an archive written by Write. Every response must match its
+// digest, and every digest referenced by an entry must have a response.
+func Read(r io.Reader) (Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return Archive{}, fmt.Errorf("not a session archive: %w", err)
+	}
+	defer gz.Close()
+
+	a := Archive{Responses: make(map[string][]byte)}
+	var haveManifest bool
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Archive{}, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Size > maxMemberSize {
+			return Archive{}, fmt.Errorf("archive member %s is too large", hdr.Name)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxMemberSize))
+		if err != nil {
+			return Archive{}, fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+		}
+		name := path.Clean(hdr.Name)
+		switch {
+		case name == manifestName:
+			if err := json.Unmarshal(data, &a.Manifest); err != nil {
+				return Archive{}, fmt.Errorf("invalid manifest: %w", err)
+			}
+			haveManifest = true
+		case path.Dir(name) == responsesDir && strings.HasSuffix(name, ".json"):
+			digest := strings.TrimSuffix(path.Base(name), ".json")
+			if Digest(data) != digest {
+				return Archive{}, fmt.Errorf("response %s does not match its digest", digest)
+			}
+			a.Responses[digest] = data
+		}
+	}
+	if !haveManifest {
+		return Archive{}, fmt.Errorf("not a session archive: %s is missing", manifestName)
+	}
+	if a.Manifest.FormatVersion != FormatVersion {
+		return Archive{}, fmt.Errorf("unsupported session archive version %d", a.Manifest.FormatVersion)
+	}
+	for _, e := range a.Manifest.Entries {
+		if e.Digest == "" {
+			continue
+		}
+		if _, ok := a.Responses[e.Digest]; !ok {
+			return Archive{}, fmt.Errorf("archive is missing response %s", e.Digest)
+		}
+	}
+	return a, nil
+}
+
+func writeMember(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func responseName(digest string) string {
+	return path.Join(responsesDir, digest+".json")
+}
@@ -0,0 +1,79 @@
+package sessionarchive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sampleArchive() Archive {
+	response := []byte(`{"hits":[{"ip":"1.1.1.1"}]}`)
+	digest := Digest(response)
+	startedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	return Archive{
+		Manifest: Manifest{
+			Name:       "incident-42",
+			StartedAt:  startedAt,
+			ExportedAt: startedAt.Add(time.Hour),
+			Entries: []Entry{
+				{Kind: "command", Command: "censys search 'host.ip: 1.1.1.1'", Query: "host.ip: 1.1.1.1", Digest: digest, RecordedAt: startedAt},
+				{Kind: "note", Note: "resolver, not the C2", RecordedAt: startedAt.Add(time.Minute)},
+				{Kind: "command", Command: "censys search 'host.ip: 1.1.1.1'", Query: "host.ip: 1.1.1.1", Digest: digest, RecordedAt: startedAt.Add(2 * time.Minute)},
+			},
+		},
+		Responses: map[string][]byte{digest: response},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	a := sampleArchive()
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, a))
+
+	got, err := Read(&buf)
+	require.NoError(t, err)
+	require.Equal(t, FormatVersion, got.Manifest.FormatVersion)
+	require.Equal(t, a.Manifest.Name, got.Manifest.Name)
+	require.Len(t, got.Manifest.Entries, 3)
+	require.Equal(t, a.Responses, got.Responses)
+}
+
+func TestWrite_MissingResponse(t *testing.T) {
+	a := sampleArchive()
+	a.Responses = nil
+	require.ErrorContains(t, Write(&bytes.Buffer{}, a), "missing response")
+}
+
+func TestRead_Invalid(t *testing.T) {
+	tamper := func(name string, data []byte) []byte {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		require.NoError(t, writeMember(tw, name, data, time.Time{}))
+		require.NoError(t, tw.Close())
+		require.NoError(t, gz.Close())
+		return buf.Bytes()
+	}
+
+	testCases := []struct {
+		name  string
+		input []byte
+		err   string
+	}{
+		{name: "not gzip", input: []byte("hello"), err: "not a session archive"},
+		{name: "tampered response", input: tamper("responses/abc.json", []byte("{}")), err: "does not match its digest"},
+		{name: "empty", input: tamper("other.txt", []byte("x")), err: "manifest.json is missing"},
+		{name: "wrong version", input: tamper("manifest.json", []byte(`{"format_version":99}`)), err: "unsupported session archive version 99"},
+		{name: "missing response", input: tamper("manifest.json", []byte(`{"format_version":1,"entries":[{"kind":"command","digest":"abc"}]}`)), err: "missing response abc"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(tc.input))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
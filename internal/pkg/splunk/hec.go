@@ -0,0 +1,216 @@
+// Package splunk sends events to a Splunk HTTP Event Collector (HEC).
+package splunk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SinkName is the name of the sink, as given to --forward.
+const SinkName = "splunk"
+
+// eventPath is where events are sent when the configured URL has no path.
+const eventPath = "/services/collector/event"
+
+const (
+	defaultBatchSize  = 100
+	defaultRetryDelay = time.Second
+	maxRetryDelay     = 30 * time.Second
+)
+
+// ErrNotConfigured is returned when the HEC URL or token is missing.
+var ErrNotConfigured = errors.New("the Splunk HEC URL and token must be configured")
+
+// Options configures a Forwarder.
+type Options struct {
+	// URL is the HEC endpoint, e.g. https://splunk.example.com:8088. A URL
+	// without a path is sent to /services/collector/event.
+	URL   string
+	Token string
+	// Index, Source, and Sourcetype are set on each event when not empty.
+	Index      string
+	Source     string
+	Sourcetype string
+	// BatchSize is the number of events sent per request. Defaults to 100.
+	BatchSize int
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries int
+	// RetryDelay is the delay before the first retry; it doubles with each
+	// retry. Defaults to one second.
+	RetryDelay time.Duration
+	// Client sends the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+	// Now returns the time of each event. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Forwarder sends events to a HEC in batches. It is safe for concurrent use.
+type Forwarder struct {
+	opts     Options
+	endpoint string
+
+	mu      sync.Mutex
+	pending []event
+	sent    int
+}
+
+// event is the HEC envelope of an event.
+type event struct {
+	Time       float64 `json:"time"`
+	Index      string  `json:"index,omitempty"`
+	Source     string  `json:"source,omitempty"`
+	Sourcetype string  `json:"sourcetype,omitempty"`
+	Event      any     `json:"event"`
+}
+
+// New returns a Forwarder, or ErrNotConfigured if the URL or token is missing.
+func New(opts Options) (*Forwarder, error) {
+	if strings.TrimSpace(opts.URL) == "" || strings.TrimSpace(opts.Token) == "" {
+		return nil, ErrNotConfigured
+	}
+	endpoint, err := eventEndpoint(opts.URL)
+	if err != nil {
+		return nil, err
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = defaultRetryDelay
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Forwarder{opts: opts, endpoint: endpoint}, nil
+}
+
+// eventEndpoint returns the URL events are posted to.
+func eventEndpoint(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("invalid Splunk HEC URL %q", raw)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = eventPath
+	}
+	return u.String(), nil
+}
+
+// Add queues data as an event, and sends the queued events once a batch is full.
+func (f *Forwarder) Add(ctx context.Context, data any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pending = append(f.pending, event{
+		Time:       float64(f.opts.Now().UnixMilli()) / 1000,
+		Index:      f.opts.Index,
+		Source:     f.opts.Source,
+		Sourcetype: f.opts.Sourcetype,
+		Event:      data,
+	})
+	if len(f.pending) < f.opts.BatchSize {
+		return nil
+	}
+	return f.flushLocked(ctx)
+}
+
+// Flush sends the queued events.
+func (f *Forwarder) Flush(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.flushLocked(ctx)
+}
+
+// Close sends the queued events.
+func (f *Forwarder) Close(ctx context.Context) error {
+	return f.Flush(ctx)
+}
+
+// Sent returns the number of events the HEC has accepted.
+func (f *Forwarder) Sent() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sent
+}
+
+func (f *Forwarder) flushLocked(ctx context.Context) error {
+	if len(f.pending) == 0 {
+		return nil
+	}
+	// HEC accepts a batch as concatenated JSON objects
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, e := range f.pending {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+	}
+	if err := f.send(ctx, body.Bytes()); err != nil {
+		return err
+	}
+	f.sent += len(f.pending)
+	f.pending = f.pending[:0]
+	return nil
+}
+
+// send posts a batch, retrying network errors, 429s, and 5xx responses.
+func (f *Forwarder) send(ctx context.Context, body []byte) error {
+	delay := f.opts.RetryDelay
+	for attempt := 0; ; attempt++ {
+		retry, err := f.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= f.opts.MaxRetries {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		delay = min(2*delay, maxRetryDelay)
+	}
+}
+
+// post posts a batch once, and reports whether a failure is worth retrying.
+func (f *Forwarder) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create HEC request: %w", err)
+	}
+	req.Header.Set("Authorization", "Splunk "+f.opts.Token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := f.opts.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("HEC request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+	// HEC explains failures as {"text": "...", "code": n}
+	var reply struct {
+		Text string `json:"text"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&reply)
+	err = fmt.Errorf("HEC returned %s", resp.Status)
+	if reply.Text != "" {
+		err = fmt.Errorf("HEC returned %s: %s", resp.Status, reply.Text)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
@@ -0,0 +1,151 @@
+package splunk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// collector is a fake HEC that records the batches it accepts.
+type collector struct {
+	mu       sync.Mutex
+	batches  [][]map[string]any
+	requests int
+	// fail is the status of the first requests, until it runs out
+	fail []int
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+	if r.URL.Path != "/services/collector/event" || r.Header.Get("Authorization") != "Splunk secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"text":"Invalid token","code":4}`))
+		return
+	}
+	if len(c.fail) > 0 {
+		status := c.fail[0]
+		c.fail = c.fail[1:]
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"text":"Server is busy","code":9}`))
+		return
+	}
+	var batch []map[string]any
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		var e map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		batch = append(batch, e)
+	}
+	c.batches = append(c.batches, batch)
+	_, _ = w.Write([]byte(`{"text":"Success","code":0}`))
+}
+
+func newForwarder(t *testing.T, url string, opts Options) *Forwarder {
+	t.Helper()
+	opts.URL = url
+	if opts.Token == "" {
+		opts.Token = "secret"
+	}
+	opts.RetryDelay = time.Millisecond
+	opts.Now = func() time.Time { return time.Unix(1700000000, 500_000_000) }
+	f, err := New(opts)
+	require.NoError(t, err)
+	return f
+}
+
+func TestForwarder_Batches(t *testing.T) {
+	hec := &collector{}
+	server := httptest.NewServer(hec)
+	defer server.Close()
+
+	f := newForwarder(t, server.URL, Options{Index: "censys", Source: "cencli:search", Sourcetype: "censys:cencli", BatchSize: 2})
+	ctx := context.Background()
+	for _, ip := range []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"} {
+		require.NoError(t, f.Add(ctx, map[string]any{"ip": ip}))
+	}
+	require.Len(t, hec.batches, 1)
+	require.NoError(t, f.Flush(ctx))
+	require.Len(t, hec.batches, 2)
+	require.Equal(t, 3, f.Sent())
+
+	require.Len(t, hec.batches[0], 2)
+	require.Len(t, hec.batches[1], 1)
+	require.Equal(t, map[string]any{
+		"time":       1700000000.5,
+		"index":      "censys",
+		"source":     "cencli:search",
+		"sourcetype": "censys:cencli",
+		"event":      map[string]any{"ip": "1.1.1.1"},
+	}, hec.batches[0][0])
+
+	// nothing left to send
+	require.NoError(t, f.Flush(ctx))
+	require.Equal(t, 2, hec.requests)
+}
+
+func TestForwarder_Retries(t *testing.T) {
+	testCases := []struct {
+		name       string
+		fail       []int
+		maxRetries int
+		wantErr    string
+		requests   int
+	}{
+		{name: "retries server errors", fail: []int{503, 429}, maxRetries: 2, requests: 3},
+		{name: "gives up after max retries", fail: []int{503, 503, 503}, maxRetries: 2, requests: 3, wantErr: "HEC returned 503 Service Unavailable: Server is busy"},
+		{name: "does not retry client errors", fail: []int{400}, maxRetries: 2, requests: 1, wantErr: "HEC returned 400 Bad Request"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hec := &collector{fail: tc.fail}
+			server := httptest.NewServer(hec)
+			defer server.Close()
+
+			f := newForwarder(t, server.URL+"/", Options{MaxRetries: tc.maxRetries})
+			require.NoError(t, f.Add(context.Background(), "event"))
+			err := f.Flush(context.Background())
+			require.Equal(t, tc.requests, hec.requests)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				require.Equal(t, 0, f.Sent())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, f.Sent())
+		})
+	}
+}
+
+func TestForwarder_InvalidToken(t *testing.T) {
+	server := httptest.NewServer(&collector{})
+	defer server.Close()
+
+	f := newForwarder(t, server.URL, Options{Token: "wrong", MaxRetries: 3})
+	require.NoError(t, f.Add(context.Background(), "event"))
+	require.ErrorContains(t, f.Flush(context.Background()), "HEC returned 401 Unauthorized: Invalid token")
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Options{URL: "https://splunk.example.com:8088"})
+	require.ErrorIs(t, err, ErrNotConfigured)
+	_, err = New(Options{Token: "secret"})
+	require.ErrorIs(t, err, ErrNotConfigured)
+	_, err = New(Options{URL: "splunk.example.com", Token: "secret"})
+	require.ErrorContains(t, err, `invalid Splunk HEC URL "splunk.example.com"`)
+
+	f, err := New(Options{URL: "https://splunk.example.com:8088/custom/path", Token: "secret"})
+	require.NoError(t, err)
+	require.Equal(t, "https://splunk.example.com:8088/custom/path", f.endpoint)
+}
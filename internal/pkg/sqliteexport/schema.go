@@ -0,0 +1,87 @@
+package sqliteexport
+
+// migrations holds the schema of an export database. migrations[i] upgrades a
+// database from version i to version i+1; the version of a database is kept
+// in PRAGMA user_version. Never edit a released migration: append a new one,
+// so that databases written by older versions can still be appended to.
+var migrations = []string{
+	// 1: initial schema
+	`
+CREATE TABLE runs (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	command     TEXT NOT NULL,
+	query       TEXT NOT NULL DEFAULT '',
+	exported_at TEXT NOT NULL
+);
+
+CREATE TABLE hosts (
+	ip            TEXT PRIMARY KEY,
+	asn           INTEGER,
+	as_name       TEXT,
+	country       TEXT,
+	city          TEXT,
+	service_count INTEGER,
+	run_id        INTEGER NOT NULL REFERENCES runs(id),
+	raw           TEXT NOT NULL
+);
+
+CREATE TABLE services (
+	host_ip            TEXT NOT NULL REFERENCES hosts(ip) ON DELETE CASCADE,
+	port               INTEGER NOT NULL,
+	transport          TEXT NOT NULL DEFAULT '',
+	protocol           TEXT,
+	banner_hash_sha256 TEXT,
+	cert_sha256        TEXT,
+	software           TEXT,
+	scan_time          TEXT,
+	matched            INTEGER NOT NULL DEFAULT 0,
+	run_id             INTEGER NOT NULL REFERENCES runs(id),
+	PRIMARY KEY (host_ip, port, transport)
+);
+
+CREATE TABLE certs (
+	fingerprint_sha256 TEXT PRIMARY KEY,
+	common_name        TEXT,
+	subject_dn         TEXT,
+	issuer_dn          TEXT,
+	names              TEXT,
+	not_before         TEXT,
+	not_after          TEXT,
+	run_id             INTEGER NOT NULL REFERENCES runs(id),
+	raw                TEXT NOT NULL
+);
+
+CREATE TABLE web_properties (
+	hostname    TEXT NOT NULL,
+	port        INTEGER NOT NULL,
+	cert_sha256 TEXT,
+	software    TEXT,
+	scan_time   TEXT,
+	run_id      INTEGER NOT NULL REFERENCES runs(id),
+	raw         TEXT NOT NULL,
+	PRIMARY KEY (hostname, port)
+);
+
+CREATE TABLE endpoints (
+	id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+	host_ip            TEXT,
+	hostname           TEXT,
+	port               INTEGER NOT NULL,
+	transport          TEXT NOT NULL DEFAULT '',
+	path               TEXT,
+	endpoint_type      TEXT,
+	http_status        INTEGER,
+	banner_hash_sha256 TEXT,
+	run_id             INTEGER NOT NULL REFERENCES runs(id)
+);
+
+CREATE INDEX services_protocol ON services(protocol);
+CREATE INDEX services_cert_sha256 ON services(cert_sha256);
+CREATE INDEX certs_not_after ON certs(not_after);
+CREATE INDEX endpoints_host ON endpoints(host_ip, port, transport);
+CREATE INDEX endpoints_web_property ON endpoints(hostname, port);
+`,
+}
+
+// SchemaVersion is the schema version of databases written by this package.
+var SchemaVersion = len(migrations)
@@ -0,0 +1,393 @@
+// Package sqliteexport writes assets into a SQLite database with one table per
+// kind of record (hosts, services, certs, web properties, endpoints), so that
+// results can be queried with SQL or browsed with tools such as Datasette.
+//
+// Each export is recorded in the runs table, and every row references the run
+// that last wrote it. Appending to an existing database replaces the rows of
+// assets that are exported again and keeps the rest, so repeated runs build up
+// an inventory.
+package sqliteexport
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	_ "modernc.org/sqlite"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// FormatName is the name of the export format, as given to --format.
+const FormatName = "sqlite"
+
+// ErrNewerSchema is returned when appending to a database written by a newer
+// version of the CLI.
+var ErrNewerSchema = errors.New("the database was written by a newer version of cencli")
+
+// Options configures an export.
+type Options struct {
+	// Append adds to an existing database instead of replacing it.
+	Append bool
+	// Command and Query describe the run, and are recorded in the runs table.
+	Command string
+	Query   string
+	// Now returns the time the run is recorded at. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Summary counts the rows written by an export.
+type Summary struct {
+	Path          string `json:"path"`
+	RunID         int64  `json:"run_id"`
+	Hosts         int    `json:"hosts"`
+	Services      int    `json:"services"`
+	Certificates  int    `json:"certs"`
+	WebProperties int    `json:"web_properties"`
+	Endpoints     int    `json:"endpoints"`
+}
+
+// String describes the summary, e.g. "2 hosts, 5 services, 1 cert".
+func (s Summary) String() string {
+	var parts []string
+	add := func(n int, singular, plural string) {
+		if n == 0 {
+			return
+		}
+		if n == 1 {
+			parts = append(parts, "1 "+singular)
+			return
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", n, plural))
+	}
+	add(s.Hosts, "host", "hosts")
+	add(s.Services, "service", "services")
+	add(s.Certificates, "cert", "certs")
+	add(s.WebProperties, "web property", "web properties")
+	add(s.Endpoints, "endpoint", "endpoints")
+	if len(parts) == 0 {
+		return "nothing"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Export writes assets to the database at path. Unless opts.Append is set, an
+// existing file at path is replaced.
+func Export(ctx context.Context, path string, items []assets.Asset, opts Options) (Summary, error) {
+	summary := Summary{Path: path}
+	if !opts.Append {
+		for _, p := range []string{path, path + "-wal", path + "-shm"} {
+			if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return summary, fmt.Errorf("failed to replace %s: %w", path, err)
+			}
+		}
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return summary, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, `PRAGMA foreign_keys = ON;`); err != nil {
+		return summary, fmt.Errorf("failed to enable foreign keys: %w", err)
+	}
+	if err := migrate(ctx, db); err != nil {
+		return summary, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return summary, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO runs (command, query, exported_at) VALUES (?, ?, ?)`,
+		opts.Command, opts.Query, now().UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return summary, fmt.Errorf("failed to record run: %w", err)
+	}
+	if summary.RunID, err = res.LastInsertId(); err != nil {
+		return summary, fmt.Errorf("failed to record run: %w", err)
+	}
+
+	w := &writer{tx: tx, runID: summary.RunID, summary: &summary}
+	for _, item := range items {
+		if err := w.writeAsset(ctx, item); err != nil {
+			return summary, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return summary, fmt.Errorf("failed to commit export: %w", err)
+	}
+	return summary, nil
+}
+
+// migrate brings the schema of db up to SchemaVersion.
+func migrate(ctx context.Context, db *sql.DB) error {
+	var version int
+	if err := db.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("%w (schema version %d, expected at most %d)", ErrNewerSchema, version, len(migrations))
+	}
+	for v := version; v < len(migrations); v++ {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin migration: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, migrations[v]); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to migrate schema to version %d: %w", v+1, err)
+		}
+		// PRAGMA does not accept bound parameters
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`PRAGMA user_version = %d`, v+1)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to migrate schema to version %d: %w", v+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to migrate schema to version %d: %w", v+1, err)
+		}
+	}
+	return nil
+}
+
+// writer writes assets in a single transaction.
+type writer struct {
+	tx      *sql.Tx
+	runID   int64
+	summary *Summary
+}
+
+func (w *writer) writeAsset(ctx context.Context, item assets.Asset) error {
+	switch a := item.(type) {
+	case *assets.Host:
+		return w.writeHost(ctx, a)
+	case *assets.Certificate:
+		return w.writeCertificate(ctx, &a.Certificate)
+	case *assets.WebProperty:
+		return w.writeWebProperty(ctx, a)
+	default:
+		return nil
+	}
+}
+
+func (w *writer) writeHost(ctx context.Context, host *assets.Host) error {
+	ip := deref(host.IP)
+	if ip == "" {
+		return nil
+	}
+	raw, err := json.Marshal(host)
+	if err != nil {
+		return fmt.Errorf("failed to encode host %s: %w", ip, err)
+	}
+	var asn *int
+	var asName, country, city *string
+	if as := host.AutonomousSystem; as != nil {
+		asn, asName = as.Asn, as.Name
+	}
+	if loc := host.Location; loc != nil {
+		country, city = loc.Country, loc.City
+	}
+	// replace the host's services and endpoints with the ones just fetched
+	if _, err := w.tx.ExecContext(ctx, `DELETE FROM endpoints WHERE host_ip = ?`, ip); err != nil {
+		return fmt.Errorf("failed to replace host %s: %w", ip, err)
+	}
+	if _, err := w.tx.ExecContext(ctx, `DELETE FROM services WHERE host_ip = ?`, ip); err != nil {
+		return fmt.Errorf("failed to replace host %s: %w", ip, err)
+	}
+	if _, err := w.tx.ExecContext(ctx,
+		`INSERT OR REPLACE INTO hosts (ip, asn, as_name, country, city, service_count, run_id, raw)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		ip, asn, asName, country, city, host.ServiceCount, w.runID, string(raw),
+	); err != nil {
+		return fmt.Errorf("failed to write host %s: %w", ip, err)
+	}
+	w.summary.Hosts++
+
+	for _, svc := range host.Services {
+		if svc.Port == nil {
+			continue
+		}
+		transport := ""
+		if svc.TransportProtocol != nil {
+			transport = strings.ToLower(string(*svc.TransportProtocol))
+		}
+		var certSHA256 *string
+		if svc.Cert != nil && svc.Cert.FingerprintSha256 != nil {
+			certSHA256 = svc.Cert.FingerprintSha256
+			if err := w.writeCertificate(ctx, svc.Cert); err != nil {
+				return err
+			}
+		}
+		if _, err := w.tx.ExecContext(ctx,
+			`INSERT OR REPLACE INTO services
+			 (host_ip, port, transport, protocol, banner_hash_sha256, cert_sha256, software, scan_time, matched, run_id)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			ip, *svc.Port, transport, svc.Protocol, svc.BannerHashSha256, certSHA256,
+			software(svc.Software), svc.ScanTime, host.IsMatchedService(svc), w.runID,
+		); err != nil {
+			return fmt.Errorf("failed to write service %s:%d: %w", ip, *svc.Port, err)
+		}
+		w.summary.Services++
+		for _, ep := range svc.Endpoints {
+			if err := w.writeEndpoint(ctx, &ip, nil, *svc.Port, transport, ep); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *writer) writeCertificate(ctx context.Context, cert *components.Certificate) error {
+	fingerprint := deref(cert.FingerprintSha256)
+	if fingerprint == "" {
+		return nil
+	}
+	raw, err := json.Marshal(cert)
+	if err != nil {
+		return fmt.Errorf("failed to encode certificate %s: %w", fingerprint, err)
+	}
+	var commonName, subjectDN, issuerDN, notBefore, notAfter *string
+	if p := cert.Parsed; p != nil {
+		subjectDN, issuerDN = p.SubjectDn, p.IssuerDn
+		if p.Subject != nil && len(p.Subject.CommonName) > 0 {
+			commonName = &p.Subject.CommonName[0]
+		}
+		if vp := p.ValidityPeriod; vp != nil {
+			notBefore, notAfter = vp.NotBefore, vp.NotAfter
+		}
+	}
+	if _, err := w.tx.ExecContext(ctx,
+		`INSERT OR REPLACE INTO certs
+		 (fingerprint_sha256, common_name, subject_dn, issuer_dn, names, not_before, not_after, run_id, raw)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		fingerprint, commonName, subjectDN, issuerDN, jsonList(cert.Names), notBefore, notAfter, w.runID, string(raw),
+	); err != nil {
+		return fmt.Errorf("failed to write certificate %s: %w", fingerprint, err)
+	}
+	w.summary.Certificates++
+	return nil
+}
+
+func (w *writer) writeWebProperty(ctx context.Context, wp *assets.WebProperty) error {
+	hostname := deref(wp.Hostname)
+	if hostname == "" || wp.Port == nil {
+		return nil
+	}
+	port := *wp.Port
+	raw, err := json.Marshal(wp)
+	if err != nil {
+		return fmt.Errorf("failed to encode web property %s:%d: %w", hostname, port, err)
+	}
+	var certSHA256 *string
+	if wp.Cert != nil && wp.Cert.FingerprintSha256 != nil {
+		certSHA256 = wp.Cert.FingerprintSha256
+		if err := w.writeCertificate(ctx, wp.Cert); err != nil {
+			return err
+		}
+	}
+	if _, err := w.tx.ExecContext(ctx,
+		`DELETE FROM endpoints WHERE hostname = ? AND port = ?`, hostname, port,
+	); err != nil {
+		return fmt.Errorf("failed to replace web property %s:%d: %w", hostname, port, err)
+	}
+	if _, err := w.tx.ExecContext(ctx,
+		`INSERT OR REPLACE INTO web_properties (hostname, port, cert_sha256, software, scan_time, run_id, raw)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		hostname, port, certSHA256, software(wp.Software), wp.ScanTime, w.runID, string(raw),
+	); err != nil {
+		return fmt.Errorf("failed to write web property %s:%d: %w", hostname, port, err)
+	}
+	w.summary.WebProperties++
+	for _, ep := range wp.Endpoints {
+		transport := ""
+		if ep.TransportProtocol != nil {
+			transport = strings.ToLower(string(*ep.TransportProtocol))
+		}
+		if err := w.writeEndpoint(ctx, nil, &hostname, port, transport, ep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEndpoint writes an endpoint of a host service (hostIP set) or of a web
+// property (hostname set).
+func (w *writer) writeEndpoint(ctx context.Context, hostIP, hostname *string, port int, transport string, ep components.EndpointScanState) error {
+	var status *int
+	if ep.HTTP != nil {
+		status = ep.HTTP.StatusCode
+	}
+	if _, err := w.tx.ExecContext(ctx,
+		`INSERT INTO endpoints
+		 (host_ip, hostname, port, transport, path, endpoint_type, http_status, banner_hash_sha256, run_id)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		hostIP, hostname, port, transport, ep.Path, ep.EndpointType, status, ep.BannerHashSha256, w.runID,
+	); err != nil {
+		return fmt.Errorf("failed to write endpoint on port %d: %w", port, err)
+	}
+	w.summary.Endpoints++
+	return nil
+}
+
+// software returns the software of a service as a JSON list of CPEs (or
+// "vendor product version" when there is no CPE), or nil if there is none.
+func software(attrs []components.Attribute) *string {
+	var list []string
+	for _, a := range attrs {
+		if cpe := deref(a.Cpe); cpe != "" {
+			list = append(list, cpe)
+			continue
+		}
+		name := strings.Join(nonEmpty(deref(a.Vendor), deref(a.Product), deref(a.Version)), " ")
+		if name != "" {
+			list = append(list, name)
+		}
+	}
+	return jsonList(list)
+}
+
+// jsonList encodes list as a JSON array, which SQLite's json_each can read,
+// or returns nil if the list is empty.
+func jsonList(list []string) *string {
+	if len(list) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(list)
+	if err != nil {
+		return nil
+	}
+	s := string(b)
+	return &s
+}
+
+func nonEmpty(values ...string) []string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,179 @@
+package sqliteexport
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func testHost(ip string, ports ...int) *assets.Host {
+	services := make([]components.Service, 0, len(ports))
+	for _, port := range ports {
+		services = append(services, components.Service{
+			Port:              ptr(port),
+			Protocol:          ptr("HTTP"),
+			TransportProtocol: ptr(components.ServiceTransportProtocolTCP),
+			Endpoints: []components.EndpointScanState{
+				{Path: ptr("/"), HTTP: &components.HTTP{StatusCode: ptr(200)}},
+			},
+		})
+	}
+	host := assets.NewHostWithMatchedServices(components.Host{
+		IP:               ptr(ip),
+		ServiceCount:     ptr(len(ports)),
+		AutonomousSystem: &components.Routing{Asn: ptr(15169), Name: ptr("GOOGLE")},
+		Location:         &components.Location{Country: ptr("United States"), City: ptr("Mountain View")},
+		Services:         services,
+	}, []components.MatchedService{{Port: ptr(ports[0])}})
+	return &host
+}
+
+func testCert(fingerprint, commonName string) *assets.Certificate {
+	cert := assets.NewCertificate(components.Certificate{
+		FingerprintSha256: ptr(fingerprint),
+		Names:             []string{commonName, "www." + commonName},
+		Parsed: &components.CertificateParsed{
+			Subject:        &components.DistinguishedName{CommonName: []string{commonName}},
+			SubjectDn:      ptr("CN=" + commonName),
+			ValidityPeriod: &components.ValidityPeriod{NotAfter: ptr("2030-01-01T00:00:00Z")},
+		},
+	})
+	return &cert
+}
+
+func count(t *testing.T, db *sql.DB, query string, args ...any) int {
+	t.Helper()
+	var n int
+	require.NoError(t, db.QueryRow(query, args...).Scan(&n))
+	return n
+}
+
+func openDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.db")
+	webProperty := assets.NewWebProperty(components.Webproperty{
+		Hostname: ptr("example.com"),
+		Port:     ptr(443),
+		Cert:     &testCert("aa11", "example.com").Certificate,
+		Endpoints: []components.EndpointScanState{
+			{Path: ptr("/login"), TransportProtocol: ptr(components.EndpointScanStateTransportProtocolTCP)},
+		},
+	})
+	items := []assets.Asset{
+		testHost("1.1.1.1", 80, 443),
+		testCert("bb22", "example.org"),
+		&webProperty,
+	}
+
+	summary, err := Export(context.Background(), path, items, Options{
+		Command: "search",
+		Query:   "host.services.port: 80",
+		Now:     func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Summary{
+		Path:          path,
+		RunID:         1,
+		Hosts:         1,
+		Services:      2,
+		Certificates:  2,
+		WebProperties: 1,
+		Endpoints:     3,
+	}, summary)
+	assert.Equal(t, "1 host, 2 services, 2 certs, 1 web property, 3 endpoints", summary.String())
+
+	db := openDB(t, path)
+	var version int
+	require.NoError(t, db.QueryRow(`PRAGMA user_version`).Scan(&version))
+	assert.Equal(t, SchemaVersion, version)
+
+	var command, query, exportedAt string
+	require.NoError(t, db.QueryRow(`SELECT command, query, exported_at FROM runs`).Scan(&command, &query, &exportedAt))
+	assert.Equal(t, "search", command)
+	assert.Equal(t, "host.services.port: 80", query)
+	assert.Equal(t, "2025-01-02T03:04:05Z", exportedAt)
+
+	var asn int
+	var country string
+	require.NoError(t, db.QueryRow(`SELECT asn, country FROM hosts WHERE ip = '1.1.1.1'`).Scan(&asn, &country))
+	assert.Equal(t, 15169, asn)
+	assert.Equal(t, "United States", country)
+
+	assert.Equal(t, 1, count(t, db, `SELECT count(*) FROM services WHERE matched = 1 AND port = 80 AND transport = 'tcp'`))
+	assert.Equal(t, 1, count(t, db, `SELECT count(*) FROM services WHERE matched = 0 AND port = 443`))
+	assert.Equal(t, 2, count(t, db, `SELECT count(*) FROM endpoints WHERE host_ip = '1.1.1.1' AND http_status = 200`))
+	assert.Equal(t, 1, count(t, db, `SELECT count(*) FROM endpoints WHERE hostname = 'example.com' AND path = '/login'`))
+	assert.Equal(t, 1, count(t, db, `SELECT count(*) FROM web_properties WHERE cert_sha256 = 'aa11'`))
+	assert.Equal(t, 1, count(t, db,
+		`SELECT count(*) FROM certs, json_each(certs.names) WHERE fingerprint_sha256 = 'bb22' AND json_each.value = 'www.example.org'`))
+	assert.Equal(t, 1, count(t, db, `SELECT count(*) FROM certs WHERE common_name = 'example.org' AND not_after LIKE '2030-%'`))
+}
+
+func TestExport_Append(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "results.db")
+
+	_, err := Export(ctx, path, []assets.Asset{testHost("1.1.1.1", 80, 443), testHost("2.2.2.2", 22)}, Options{Command: "search"})
+	require.NoError(t, err)
+	// the host is exported again with fewer services
+	summary, err := Export(ctx, path, []assets.Asset{testHost("1.1.1.1", 8080)}, Options{Append: true, Command: "view"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), summary.RunID)
+
+	db := openDB(t, path)
+	assert.Equal(t, 2, count(t, db, `SELECT count(*) FROM runs`))
+	assert.Equal(t, 2, count(t, db, `SELECT count(*) FROM hosts`))
+	assert.Equal(t, 2, count(t, db, `SELECT run_id FROM hosts WHERE ip = '1.1.1.1'`))
+	assert.Equal(t, 1, count(t, db, `SELECT count(*) FROM services WHERE host_ip = '1.1.1.1'`))
+	assert.Equal(t, 1, count(t, db, `SELECT count(*) FROM endpoints WHERE host_ip = '1.1.1.1'`))
+	assert.Equal(t, 1, count(t, db, `SELECT count(*) FROM services WHERE host_ip = '2.2.2.2'`))
+}
+
+func TestExport_Replace(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "results.db")
+
+	_, err := Export(ctx, path, []assets.Asset{testHost("1.1.1.1", 80)}, Options{Command: "search"})
+	require.NoError(t, err)
+	summary, err := Export(ctx, path, []assets.Asset{testHost("2.2.2.2", 22)}, Options{Command: "search"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), summary.RunID)
+
+	db := openDB(t, path)
+	assert.Equal(t, 1, count(t, db, `SELECT count(*) FROM runs`))
+	assert.Equal(t, 0, count(t, db, `SELECT count(*) FROM hosts WHERE ip = '1.1.1.1'`))
+	assert.Equal(t, 1, count(t, db, `SELECT count(*) FROM hosts WHERE ip = '2.2.2.2'`))
+}
+
+func TestExport_NewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.db")
+	db := openDB(t, path)
+	_, err := db.Exec(`PRAGMA user_version = 1000`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = Export(context.Background(), path, nil, Options{Append: true})
+	require.ErrorIs(t, err, ErrNewerSchema)
+}
+
+func TestSummaryString(t *testing.T) {
+	assert.Equal(t, "nothing", Summary{}.String())
+	assert.Equal(t, "1 cert", Summary{Certificates: 1}.String())
+}
@@ -35,3 +35,11 @@ func (c CensysColorScheme) Danger() Color {
 func (c CensysColorScheme) Comment() Color {
 	return Color{Light: "#808080", Dark: "#808080"}
 }
+
+func (c CensysColorScheme) Interesting() Color {
+	return c.Signature()
+}
+
+func (c CensysColorScheme) Uninteresting() Color {
+	return c.Tertiary()
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
+
+	"github.com/censys/cencli/internal/pkg/term"
 )
 
 // GlobalStyles is the default palette used across CLI output.
@@ -53,7 +55,12 @@ func init() {
 	if isTestEnvironment() {
 		lipgloss.SetColorProfile(termenv.Ascii)
 	}
-	scheme := DefaultColorScheme()
+	useColorScheme(DefaultColorScheme())
+}
+
+// useColorScheme makes scheme the source of GlobalStyles and of the color
+// variables.
+func useColorScheme(scheme ColorScheme) {
 	GlobalStyles = NewStyles(scheme)
 
 	ColorOrange = scheme.Signature()
@@ -84,6 +91,12 @@ type ColorScheme interface {
 	Danger() lipgloss.AdaptiveColor
 	// Comment color is used for less important text
 	Comment() lipgloss.AdaptiveColor
+	// Interesting color marks results worth a closer look, such as pivots
+	// within the rarity bounds. It must be told apart from Uninteresting by
+	// people with color vision deficiencies.
+	Interesting() lipgloss.AdaptiveColor
+	// Uninteresting color marks the results that are not.
+	Uninteresting() lipgloss.AdaptiveColor
 }
 
 // Styles describes the color palette and layout styles used by the CLI.
@@ -97,23 +110,30 @@ type Styles struct {
 	Warning   lipgloss.Style
 	Danger    lipgloss.Style
 	Comment   lipgloss.Style
-	Indent4   lipgloss.Style
-	Indent8   lipgloss.Style
+	// Interesting and Uninteresting style results by whether they are worth
+	// a closer look. Schemes without colors make interesting results bold.
+	Interesting   lipgloss.Style
+	Uninteresting lipgloss.Style
+	Indent4       lipgloss.Style
+	Indent8       lipgloss.Style
 }
 
 // NewStyles creates a new Styles instance from a ColorScheme.
 func NewStyles(scheme ColorScheme) *Styles {
+	colorless := scheme.Interesting() == Color{}
 	return &Styles{
-		Signature: lipgloss.NewStyle().Foreground(scheme.Signature()),
-		Primary:   lipgloss.NewStyle().Foreground(scheme.Primary()),
-		Secondary: lipgloss.NewStyle().Foreground(scheme.Secondary()),
-		Tertiary:  lipgloss.NewStyle().Foreground(scheme.Tertiary()),
-		Info:      lipgloss.NewStyle().Foreground(scheme.Info()),
-		Warning:   lipgloss.NewStyle().Foreground(scheme.Warning()),
-		Danger:    lipgloss.NewStyle().Foreground(scheme.Danger()),
-		Comment:   lipgloss.NewStyle().Foreground(scheme.Comment()),
-		Indent4:   lipgloss.NewStyle().PaddingLeft(4),
-		Indent8:   lipgloss.NewStyle().PaddingLeft(8),
+		Signature:     lipgloss.NewStyle().Foreground(scheme.Signature()),
+		Primary:       lipgloss.NewStyle().Foreground(scheme.Primary()),
+		Secondary:     lipgloss.NewStyle().Foreground(scheme.Secondary()),
+		Tertiary:      lipgloss.NewStyle().Foreground(scheme.Tertiary()),
+		Info:          lipgloss.NewStyle().Foreground(scheme.Info()),
+		Warning:       lipgloss.NewStyle().Foreground(scheme.Warning()),
+		Danger:        lipgloss.NewStyle().Foreground(scheme.Danger()),
+		Comment:       lipgloss.NewStyle().Foreground(scheme.Comment()),
+		Interesting:   lipgloss.NewStyle().Foreground(scheme.Interesting()).Bold(colorless),
+		Uninteresting: lipgloss.NewStyle().Foreground(scheme.Uninteresting()),
+		Indent4:       lipgloss.NewStyle().PaddingLeft(4),
+		Indent8:       lipgloss.NewStyle().PaddingLeft(8),
 	}
 }
 
@@ -127,7 +147,8 @@ func ColorDisabled() bool {
 	if isTestEnvironment() {
 		return true
 	}
-	return false
+	// legacy Windows consoles without virtual terminal processing
+	return !term.ANSISupported()
 }
 
 // ColorForced returns true if colored output should be forced.
@@ -0,0 +1,157 @@
+package styles
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is the name of a color scheme that can be selected with the theme
+// setting or the --theme flag.
+type Theme string
+
+const (
+	// ThemeDefault is the Censys color scheme.
+	ThemeDefault Theme = "default"
+	// ThemeHighContrast uses saturated colors that stand out against both
+	// light and dark terminal backgrounds.
+	ThemeHighContrast Theme = "high-contrast"
+	// ThemeMonochrome uses no colors, only bold text and reverse video.
+	ThemeMonochrome Theme = "monochrome"
+	// ThemeSolarized uses the Solarized palette.
+	ThemeSolarized Theme = "solarized"
+	// ThemeColorblind uses the Okabe-Ito palette, whose colors can be told
+	// apart with any common color vision deficiency.
+	ThemeColorblind Theme = "colorblind"
+)
+
+var themeSchemes = map[Theme]ColorScheme{
+	ThemeDefault:      CensysColorScheme{},
+	ThemeHighContrast: HighContrastColorScheme{},
+	ThemeMonochrome:   MonochromeColorScheme{},
+	ThemeSolarized:    SolarizedColorScheme{},
+	ThemeColorblind:   ColorblindColorScheme{},
+}
+
+// activeTheme is the theme GlobalStyles and the color variables come from.
+var activeTheme = ThemeDefault
+
+// Themes returns the names of the themes, the default first.
+func Themes() []Theme {
+	return []Theme{ThemeDefault, ThemeHighContrast, ThemeMonochrome, ThemeSolarized, ThemeColorblind}
+}
+
+// ParseTheme returns the theme named s. An empty s is the default theme.
+func ParseTheme(s string) (Theme, error) {
+	t := Theme(strings.ToLower(strings.TrimSpace(s)))
+	if t == "" {
+		return ThemeDefault, nil
+	}
+	if _, ok := themeSchemes[t]; !ok {
+		names := make([]string, 0, len(themeSchemes))
+		for _, name := range Themes() {
+			names = append(names, string(name))
+		}
+		return "", fmt.Errorf("unknown theme %q; use one of %s", s, strings.Join(names, ", "))
+	}
+	return t, nil
+}
+
+// SetTheme switches GlobalStyles and the color variables to the color scheme
+// of t. Styles made before are not changed, so it must be called before
+// anything is rendered.
+func SetTheme(t Theme) {
+	scheme, ok := themeSchemes[t]
+	if !ok {
+		t, scheme = ThemeDefault, DefaultColorScheme()
+	}
+	activeTheme = t
+	useColorScheme(scheme)
+}
+
+// ActiveTheme returns the theme set with SetTheme.
+func ActiveTheme() Theme {
+	return activeTheme
+}
+
+// Highlight returns the style of text highlighted on a background, such as a
+// selected row, in fg on bg. Themes without colors use reverse video instead.
+func Highlight(fg, bg lipgloss.TerminalColor) lipgloss.Style {
+	if activeTheme == ThemeMonochrome {
+		return lipgloss.NewStyle().Reverse(true)
+	}
+	return lipgloss.NewStyle().Foreground(fg).Background(bg)
+}
+
+// HighContrastColorScheme uses dark colors on light backgrounds and bright
+// colors on dark ones.
+type HighContrastColorScheme struct{}
+
+var _ ColorScheme = HighContrastColorScheme{}
+
+func (HighContrastColorScheme) Signature() Color { return Color{Light: "#A84800", Dark: "#FFB000"} }
+func (HighContrastColorScheme) Primary() Color   { return Color{Light: "#000000", Dark: "#FFFFFF"} }
+func (HighContrastColorScheme) Secondary() Color { return Color{Light: "#005F5F", Dark: "#5FFFFF"} }
+func (HighContrastColorScheme) Tertiary() Color  { return Color{Light: "#00009F", Dark: "#87AFFF"} }
+func (HighContrastColorScheme) Info() Color      { return Color{Light: "#005F87", Dark: "#00D7FF"} }
+func (HighContrastColorScheme) Warning() Color   { return Color{Light: "#7A5A00", Dark: "#FFFF00"} }
+func (HighContrastColorScheme) Danger() Color    { return Color{Light: "#AF0000", Dark: "#FF5F5F"} }
+func (HighContrastColorScheme) Comment() Color   { return Color{Light: "#3A3A3A", Dark: "#D0D0D0"} }
+
+func (c HighContrastColorScheme) Interesting() Color   { return c.Signature() }
+func (c HighContrastColorScheme) Uninteresting() Color { return c.Tertiary() }
+
+// MonochromeColorScheme has no colors, so output keeps the colors of the
+// terminal.
+type MonochromeColorScheme struct{}
+
+var _ ColorScheme = MonochromeColorScheme{}
+
+func (MonochromeColorScheme) Signature() Color     { return Color{} }
+func (MonochromeColorScheme) Primary() Color       { return Color{} }
+func (MonochromeColorScheme) Secondary() Color     { return Color{} }
+func (MonochromeColorScheme) Tertiary() Color      { return Color{} }
+func (MonochromeColorScheme) Info() Color          { return Color{} }
+func (MonochromeColorScheme) Warning() Color       { return Color{} }
+func (MonochromeColorScheme) Danger() Color        { return Color{} }
+func (MonochromeColorScheme) Comment() Color       { return Color{} }
+func (MonochromeColorScheme) Interesting() Color   { return Color{} }
+func (MonochromeColorScheme) Uninteresting() Color { return Color{} }
+
+// SolarizedColorScheme uses the accent colors of Solarized, and its base
+// colors for text on light and dark backgrounds.
+type SolarizedColorScheme struct{}
+
+var _ ColorScheme = SolarizedColorScheme{}
+
+func (SolarizedColorScheme) Signature() Color { return Color{Light: "#CB4B16", Dark: "#CB4B16"} }
+func (SolarizedColorScheme) Primary() Color   { return Color{Light: "#586E75", Dark: "#93A1A1"} }
+func (SolarizedColorScheme) Secondary() Color { return Color{Light: "#2AA198", Dark: "#2AA198"} }
+func (SolarizedColorScheme) Tertiary() Color  { return Color{Light: "#268BD2", Dark: "#268BD2"} }
+func (SolarizedColorScheme) Info() Color      { return Color{Light: "#6C71C4", Dark: "#6C71C4"} }
+func (SolarizedColorScheme) Warning() Color   { return Color{Light: "#B58900", Dark: "#B58900"} }
+func (SolarizedColorScheme) Danger() Color    { return Color{Light: "#DC322F", Dark: "#DC322F"} }
+func (SolarizedColorScheme) Comment() Color   { return Color{Light: "#93A1A1", Dark: "#586E75"} }
+
+func (c SolarizedColorScheme) Interesting() Color   { return c.Signature() }
+func (c SolarizedColorScheme) Uninteresting() Color { return c.Tertiary() }
+
+// ColorblindColorScheme uses the Okabe-Ito palette. Interesting results are
+// orange and the others blue, which stay distinct with protanopia,
+// deuteranopia, and tritanopia.
+type ColorblindColorScheme struct{}
+
+var _ ColorScheme = ColorblindColorScheme{}
+
+func (ColorblindColorScheme) Signature() Color { return Color{Light: "#D55E00", Dark: "#E69F00"} }
+func (ColorblindColorScheme) Primary() Color   { return Color{Light: "#000000", Dark: "#FBFAF6"} }
+func (ColorblindColorScheme) Secondary() Color { return Color{Light: "#009E73", Dark: "#009E73"} }
+func (ColorblindColorScheme) Tertiary() Color  { return Color{Light: "#0072B2", Dark: "#56B4E9"} }
+func (ColorblindColorScheme) Info() Color      { return Color{Light: "#0072B2", Dark: "#56B4E9"} }
+func (ColorblindColorScheme) Warning() Color   { return Color{Light: "#E69F00", Dark: "#F0E442"} }
+func (ColorblindColorScheme) Danger() Color    { return Color{Light: "#D55E00", Dark: "#D55E00"} }
+func (ColorblindColorScheme) Comment() Color   { return Color{Light: "#808080", Dark: "#808080"} }
+
+func (c ColorblindColorScheme) Interesting() Color   { return c.Signature() }
+func (c ColorblindColorScheme) Uninteresting() Color { return c.Tertiary() }
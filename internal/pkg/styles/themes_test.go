@@ -0,0 +1,44 @@
+package styles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTheme(t *testing.T) {
+	for _, theme := range Themes() {
+		got, err := ParseTheme(string(theme))
+		require.NoError(t, err)
+		assert.Equal(t, theme, got)
+	}
+	got, err := ParseTheme(" High-Contrast ")
+	require.NoError(t, err)
+	assert.Equal(t, ThemeHighContrast, got)
+	got, err = ParseTheme("")
+	require.NoError(t, err)
+	assert.Equal(t, ThemeDefault, got)
+	_, err = ParseTheme("neon")
+	require.ErrorContains(t, err, `unknown theme "neon"; use one of default, high-contrast`)
+}
+
+func TestSetTheme(t *testing.T) {
+	t.Cleanup(func() { SetTheme(ThemeDefault) })
+
+	SetTheme(ThemeColorblind)
+	assert.Equal(t, ThemeColorblind, ActiveTheme())
+	assert.Equal(t, ColorblindColorScheme{}.Signature(), ColorOrange)
+	assert.NotEqual(t, ColorblindColorScheme{}.Interesting(), ColorblindColorScheme{}.Uninteresting())
+
+	SetTheme(ThemeMonochrome)
+	assert.Equal(t, Color{}, ColorTeal)
+	assert.True(t, GlobalStyles.Interesting.GetBold(), "without colors, interesting results are bold")
+	assert.True(t, Highlight(ColorBlack, ColorGold).GetReverse())
+
+	SetTheme("unknown")
+	assert.Equal(t, ThemeDefault, ActiveTheme())
+	assert.Equal(t, CensysColorScheme{}.Signature(), ColorOrange)
+	assert.False(t, GlobalStyles.Interesting.GetBold())
+	assert.False(t, Highlight(ColorBlack, ColorGold).GetReverse())
+}
@@ -107,7 +107,7 @@ func (e *Recorder) CreateTape(
 	// create the gif
 	gifPath := filepath.Join(outputDir, fmt.Sprintf("%s.gif", tape.Name))
 	var stdout, stderr bytes.Buffer
-	cmd := exec.Command(e.vhsPath, tapePath, "--output", gifPath)
+	cmd := exec.CommandContext(ctx, e.vhsPath, tapePath, "--output", gifPath)
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 	err = cmd.Run()
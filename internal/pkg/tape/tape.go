@@ -11,6 +11,11 @@ type Tape struct {
 	commands string
 }
 
+// Recordable is implemented by commands that have demo recordings.
+type Recordable interface {
+	Tapes(recorder *Recorder) []Tape
+}
+
 // Config defines the visual settings for a tape recording.
 type Config struct {
 	Width    int
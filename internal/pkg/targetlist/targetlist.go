@@ -0,0 +1,161 @@
+// Package targetlist writes assets as a list of scan targets, one per line,
+// so that verification scans can be launched from search results: ip:port
+// targets for tools such as httpx, nuclei, or naabu, or addresses for the
+// -iL option of nmap and masscan.
+package targetlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// FormatName is the name of the format, as given to --format.
+const FormatName = "target-list"
+
+// Style is how targets are written.
+type Style string
+
+const (
+	// StyleIPPort writes a host:port target per service, e.g. 10.0.0.1:443 or
+	// [2001:db8::1]:443.
+	StyleIPPort Style = "ip-port"
+	// StyleIP writes each address once, as nmap and masscan read with -iL. The
+	// ports to scan are given to the scanner with -p.
+	StyleIP Style = "ip"
+)
+
+// Styles are the supported styles.
+var Styles = []Style{StyleIPPort, StyleIP}
+
+// webPropertyService is the service name of web properties, for Services.
+const webPropertyService = "HTTP"
+
+// Options configures the output.
+type Options struct {
+	// Style is how targets are written. Defaults to StyleIPPort.
+	Style Style
+	// Ports, if set, limits the targets to services on these ports.
+	Ports []int
+	// Services, if set, limits the targets to services with these protocols
+	// (e.g. SSH or HTTP), compared case-insensitively. Web properties are HTTP.
+	Services []string
+}
+
+func (o Options) matches(port int, protocol string) bool {
+	if len(o.Ports) > 0 && !slices.Contains(o.Ports, port) {
+		return false
+	}
+	if len(o.Services) > 0 && !slices.ContainsFunc(o.Services, func(s string) bool { return strings.EqualFold(s, protocol) }) {
+		return false
+	}
+	return true
+}
+
+// ParseStyle parses a style name.
+func ParseStyle(s string) (Style, error) {
+	for _, style := range Styles {
+		if strings.EqualFold(s, string(style)) {
+			return style, nil
+		}
+	}
+	names := make([]string, len(Styles))
+	for i, style := range Styles {
+		names[i] = string(style)
+	}
+	return "", fmt.Errorf("unsupported target style %q; supported styles: %s", s, strings.Join(names, ", "))
+}
+
+// Write writes the targets of items to w, one per line, and returns the number
+// of targets written. The targets of a host are its services that matched the
+// search query, or all of its services when the host has no match
+// information (e.g. when it was viewed). The targets of a web property are
+// its hostname and port. Targets are written once, in the order they are
+// found; certificates have none.
+func Write(w io.Writer, items []assets.Asset, opts Options) (int, error) {
+	bw := bufio.NewWriter(w)
+	seen := make(map[string]bool)
+	written := 0
+	for _, item := range items {
+		for _, target := range targets(item, opts) {
+			if seen[target] {
+				continue
+			}
+			seen[target] = true
+			if _, err := fmt.Fprintln(bw, target); err != nil {
+				return written, err
+			}
+			written++
+		}
+	}
+	return written, bw.Flush()
+}
+
+func targets(item assets.Asset, opts Options) []string {
+	switch v := item.(type) {
+	case *assets.Host:
+		return hostTargets(*v, opts)
+	case assets.Host:
+		return hostTargets(v, opts)
+	case *assets.WebProperty:
+		return webPropertyTargets(v.Webproperty, opts)
+	case assets.WebProperty:
+		return webPropertyTargets(v.Webproperty, opts)
+	default:
+		return nil
+	}
+}
+
+func hostTargets(host assets.Host, opts Options) []string {
+	ip := deref(host.IP)
+	if ip == "" {
+		return nil
+	}
+	var out []string
+	for _, svc := range host.Services {
+		port := deref(svc.Port)
+		// ICMP services have no port
+		if port <= 0 || deref(svc.TransportProtocol) == components.ServiceTransportProtocolIcmp {
+			continue
+		}
+		if len(host.MatchedServices) > 0 && !host.IsMatchedService(svc) {
+			continue
+		}
+		if !opts.matches(port, deref(svc.Protocol)) {
+			continue
+		}
+		out = append(out, target(ip, port, opts.Style))
+	}
+	return out
+}
+
+func webPropertyTargets(wp components.Webproperty, opts Options) []string {
+	hostname, port := deref(wp.Hostname), deref(wp.Port)
+	if hostname == "" || port <= 0 || !opts.matches(port, webPropertyService) {
+		return nil
+	}
+	return []string{target(hostname, port, opts.Style)}
+}
+
+func target(host string, port int, style Style) string {
+	if style == StyleIP {
+		return host
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,86 @@
+package targetlist
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func testItems() []assets.Asset {
+	icmp := components.ServiceTransportProtocolIcmp
+	services := []components.Service{
+		{Port: ptr(22), Protocol: ptr("SSH")},
+		{Port: ptr(443), Protocol: ptr("HTTP")},
+		{Port: ptr(8080), Protocol: ptr("HTTP")},
+		{Port: ptr(0), Protocol: ptr("ICMP"), TransportProtocol: &icmp},
+	}
+	matched := assets.NewHostWithMatchedServices(components.Host{IP: ptr("10.0.0.1"), Services: services},
+		[]components.MatchedService{{Port: ptr(443)}, {Port: ptr(8080)}})
+	viewed := assets.NewHost(components.Host{IP: ptr("2001:db8::1"), Services: services})
+	return []assets.Asset{
+		&matched,
+		viewed,
+		&assets.WebProperty{Webproperty: components.Webproperty{Hostname: ptr("example.com"), Port: ptr(443)}},
+		&assets.Certificate{},
+		// written once
+		&matched,
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "ip-port targets of matched services",
+			want: "10.0.0.1:443\n10.0.0.1:8080\n[2001:db8::1]:22\n[2001:db8::1]:443\n[2001:db8::1]:8080\nexample.com:443\n",
+		},
+		{
+			name: "addresses",
+			opts: Options{Style: StyleIP},
+			want: "10.0.0.1\n2001:db8::1\nexample.com\n",
+		},
+		{
+			name: "filtered by port",
+			opts: Options{Ports: []int{22, 8080}},
+			want: "10.0.0.1:8080\n[2001:db8::1]:22\n[2001:db8::1]:8080\n",
+		},
+		{
+			name: "filtered by service",
+			opts: Options{Services: []string{"ssh"}},
+			want: "[2001:db8::1]:22\n",
+		},
+		{
+			name: "web properties are HTTP",
+			opts: Options{Services: []string{"http"}, Ports: []int{443}},
+			want: "10.0.0.1:443\n[2001:db8::1]:443\nexample.com:443\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := Write(&buf, testItems(), tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, buf.String())
+			assert.Equal(t, bytes.Count(buf.Bytes(), []byte("\n")), n)
+		})
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	style, err := ParseStyle("IP")
+	require.NoError(t, err)
+	assert.Equal(t, StyleIP, style)
+
+	_, err = ParseStyle("cidr")
+	require.EqualError(t, err, `unsupported target style "cidr"; supported styles: ip-port, ip`)
+}
@@ -0,0 +1,94 @@
+package term
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	"github.com/muesli/termenv"
+)
+
+const (
+	// custom environment variable to force ASCII glyphs
+	asciiEnvVar = "CENCLI_ASCII"
+	// set by Windows Terminal
+	windowsTerminalEnvVar = "WT_SESSION"
+	// set by many terminal emulators (e.g. vscode, WezTerm, iTerm.app)
+	termProgramEnvVar = "TERM_PROGRAM"
+)
+
+// ansiUnsupported is set when ANSI escape sequences could not be enabled for the console.
+var ansiUnsupported atomic.Bool
+
+// EnableANSI enables ANSI escape sequence processing for stdout and stderr.
+// This is only needed on Windows, where legacy consoles print escape sequences
+// verbatim unless virtual terminal processing is turned on; elsewhere it is a no-op.
+// If it cannot be enabled, ANSISupported reports false. The returned function
+// restores the previous console mode.
+func EnableANSI() (restore func()) {
+	var restores []func() error
+	for _, f := range []*os.File{os.Stdout, os.Stderr} {
+		if !IsTTY(f) {
+			continue
+		}
+		r, err := termenv.EnableVirtualTerminalProcessing(termenv.NewOutput(f))
+		if err != nil {
+			ansiUnsupported.Store(true)
+			continue
+		}
+		restores = append(restores, r)
+	}
+	return func() {
+		for _, r := range restores {
+			_ = r()
+		}
+	}
+}
+
+// ANSISupported returns false if EnableANSI failed to enable escape sequences.
+func ANSISupported() bool {
+	return !ansiUnsupported.Load()
+}
+
+// SupportsUnicode returns true if the terminal is expected to render
+// non-ASCII glyphs (braille spinners, box-drawing borders, arrows).
+// Set CENCLI_ASCII=1 to always use ASCII fallbacks.
+func SupportsUnicode() bool {
+	return supportsUnicode(runtime.GOOS, os.Getenv) && ANSISupported()
+}
+
+func supportsUnicode(goos string, getenv func(string) string) bool {
+	if v := strings.ToLower(getenv(asciiEnvVar)); v == "1" || v == "true" {
+		return false
+	}
+	if strings.ToLower(getenv(termEnvVar)) == dumbTerm {
+		return false
+	}
+	if goos == "windows" {
+		// the legacy console host uses a code page without these glyphs,
+		// while modern terminals identify themselves
+		return getenv(windowsTerminalEnvVar) != "" || getenv(termProgramEnvVar) != ""
+	}
+	// the first set locale variable determines the character encoding
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		locale := getenv(key)
+		if locale == "" {
+			continue
+		}
+		if locale == "C" || locale == "POSIX" {
+			return false
+		}
+		lower := strings.ToLower(locale)
+		return strings.Contains(lower, "utf-8") || strings.Contains(lower, "utf8")
+	}
+	return true
+}
+
+// Glyph returns unicode if the terminal supports it, otherwise ascii.
+func Glyph(unicode, ascii string) string {
+	if SupportsUnicode() {
+		return unicode
+	}
+	return ascii
+}
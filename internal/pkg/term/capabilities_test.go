@@ -0,0 +1,35 @@
+package term
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupportsUnicode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		goos     string
+		env      map[string]string
+		expected bool
+	}{
+		{name: "linux without locale", goos: "linux", expected: true},
+		{name: "linux utf-8 locale", goos: "linux", env: map[string]string{"LANG": "en_US.UTF-8"}, expected: true},
+		{name: "linux utf8 spelling", goos: "linux", env: map[string]string{"LANG": "en_US.utf8"}, expected: true},
+		{name: "C locale", goos: "linux", env: map[string]string{"LANG": "C"}, expected: false},
+		{name: "LC_ALL takes precedence", goos: "darwin", env: map[string]string{"LC_ALL": "POSIX", "LANG": "en_US.UTF-8"}, expected: false},
+		{name: "non-utf-8 locale", goos: "linux", env: map[string]string{"LC_CTYPE": "en_US.ISO-8859-1"}, expected: false},
+		{name: "dumb terminal", goos: "linux", env: map[string]string{"TERM": "dumb"}, expected: false},
+		{name: "ascii override", goos: "linux", env: map[string]string{"CENCLI_ASCII": "1", "LANG": "en_US.UTF-8"}, expected: false},
+		{name: "windows legacy console", goos: "windows", expected: false},
+		{name: "windows terminal", goos: "windows", env: map[string]string{"WT_SESSION": "abc"}, expected: true},
+		{name: "windows vscode terminal", goos: "windows", env: map[string]string{"TERM_PROGRAM": "vscode"}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			getenv := func(key string) string { return tc.env[key] }
+			require.Equal(t, tc.expected, supportsUnicode(tc.goos, getenv))
+		})
+	}
+}
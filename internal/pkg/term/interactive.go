@@ -0,0 +1,27 @@
+package term
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// nonInteractive is set by --non-interactive to never prompt, even in a terminal.
+var nonInteractive atomic.Bool
+
+// SetNonInteractive forces non-interactive mode on or off.
+func SetNonInteractive(v bool) {
+	nonInteractive.Store(v)
+}
+
+// NonInteractiveForced returns true if non-interactive mode was forced with
+// SetNonInteractive, rather than detected.
+func NonInteractiveForced() bool {
+	return nonInteractive.Load()
+}
+
+// Interactive returns true if the user can be prompted: non-interactive mode
+// is not forced, and both stdin and stderr are terminals. It is false in
+// pipelines, CI jobs, and containers run without a TTY.
+func Interactive() bool {
+	return !nonInteractive.Load() && IsTTY(os.Stdin) && IsTTY(os.Stderr)
+}
@@ -0,0 +1,226 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tracesPath is where spans are sent when the endpoint has no path.
+const tracesPath = "/v1/traces"
+
+// OTLP status codes.
+const (
+	statusOK    = 1
+	statusError = 2
+)
+
+// ExportOptions configures where spans are exported to.
+type ExportOptions struct {
+	// Endpoint is the OTLP/HTTP endpoint, e.g. http://localhost:4318. An
+	// endpoint without a path is sent to /v1/traces.
+	Endpoint string
+	// Headers are sent with the request, e.g. for authentication.
+	Headers map[string]string
+	// ServiceName is the service.name of the resource of the spans.
+	ServiceName string
+	// ServiceVersion is the service.version of the resource, if not empty.
+	ServiceVersion string
+	// Client sends the request. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Endpoint returns the URL that spans are posted to for the configured endpoint.
+func Endpoint(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("invalid OTLP endpoint %q: must be an http or https URL", raw)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = tracesPath
+	}
+	return u.String(), nil
+}
+
+// ParseHeaders parses headers written as key=value, as in the
+// OTEL_EXPORTER_OTLP_HEADERS environment variable, whose values may be
+// URL-encoded.
+func ParseHeaders(pairs []string) (map[string]string, error) {
+	headers := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid OTLP header %q: must be key=value", pair)
+		}
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(value)); err == nil {
+			value = decoded
+		}
+		headers[strings.TrimSpace(key)] = value
+	}
+	return headers, nil
+}
+
+// Export sends the spans that ended to the collector. It does nothing if no
+// span ended.
+func (t *Tracer) Export(ctx context.Context, opts ExportOptions) error {
+	spans := t.Spans()
+	if len(spans) == 0 {
+		return nil
+	}
+	endpoint, err := Endpoint(opts.Endpoint)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(t.payload(spans, opts))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range opts.Headers {
+		req.Header.Set(key, value)
+	}
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export traces: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to export traces: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// The types below are the OTLP JSON encoding of ExportTraceServiceRequest.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              Kind           `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+// otlpValue is an AnyValue; 64-bit integers are encoded as strings.
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func (t *Tracer) payload(spans []*Span, opts ExportOptions) otlpRequest {
+	resource := []otlpKeyValue{keyValue(String("service.name", opts.ServiceName))}
+	if opts.ServiceVersion != "" {
+		resource = append(resource, keyValue(String("service.version", opts.ServiceVersion)))
+	}
+	encoded := make([]otlpSpan, len(spans))
+	for i, s := range spans {
+		s.mu.Lock()
+		span := otlpSpan{
+			TraceID:           t.traceID,
+			SpanID:            s.id,
+			ParentSpanID:      s.parentID,
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Status:            otlpStatus{Code: statusOK},
+		}
+		for _, attr := range s.attrs {
+			span.Attributes = append(span.Attributes, keyValue(attr))
+		}
+		if s.failed {
+			span.Status = otlpStatus{Code: statusError, Message: s.err}
+		}
+		s.mu.Unlock()
+		encoded[i] = span
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: resource},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "cencli", Version: opts.ServiceVersion},
+			Spans: encoded,
+		}},
+	}}}
+}
+
+func keyValue(attr Attr) otlpKeyValue {
+	var v otlpValue
+	setInt := func(s string) { v.IntValue = &s }
+	switch value := attr.Value.(type) {
+	case string:
+		v.StringValue = &value
+	case bool:
+		v.BoolValue = &value
+	case int:
+		setInt(strconv.Itoa(value))
+	case int64:
+		setInt(strconv.FormatInt(value, 10))
+	case uint64:
+		setInt(strconv.FormatUint(value, 10))
+	case float64:
+		v.DoubleValue = &value
+	case time.Duration:
+		setInt(strconv.FormatInt(value.Milliseconds(), 10))
+	default:
+		s := fmt.Sprint(value)
+		v.StringValue = &s
+	}
+	return otlpKeyValue{Key: attr.Key, Value: v}
+}
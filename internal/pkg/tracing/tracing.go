@@ -0,0 +1,177 @@
+// Package tracing records the spans of a cencli run and exports them to an
+// OpenTelemetry collector with OTLP over HTTP, in its JSON encoding.
+//
+// Spans are kept in memory and sent in a single request when the run ends,
+// as a run is short-lived. A span is started with Start, which attaches it
+// to the returned context so that the spans started from that context are
+// its children. Without a Tracer in the context, Start returns a nil *Span,
+// whose methods do nothing, so code can be instrumented unconditionally.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// Kind is the kind of a span, as defined by OTLP.
+type Kind int
+
+const (
+	KindInternal Kind = 1
+	KindClient   Kind = 3
+)
+
+// Attr is an attribute of a span. Value is a string, bool, int, int64,
+// uint64, float64, or time.Duration (recorded in milliseconds).
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// String, Int, and Bool return attributes.
+func String(key, value string) Attr    { return Attr{Key: key, Value: value} }
+func Int(key string, value int64) Attr { return Attr{Key: key, Value: value} }
+func Bool(key string, value bool) Attr { return Attr{Key: key, Value: value} }
+
+// Tracer records the spans of a run. It is safe for concurrent use.
+type Tracer struct {
+	traceID string
+	now     func() time.Time
+
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// New returns a Tracer whose spans belong to a new trace.
+func New() *Tracer {
+	return &Tracer{traceID: randomID(16), now: time.Now}
+}
+
+// TraceID returns the hex-encoded ID of the trace of the run.
+func (t *Tracer) TraceID() string { return t.traceID }
+
+// Spans returns the spans that ended, in the order they ended.
+func (t *Tracer) Spans() []*Span {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*Span(nil), t.spans...)
+}
+
+// Span is an operation of the run.
+type Span struct {
+	tracer   *Tracer
+	id       string
+	parentID string
+	kind     Kind
+	start    time.Time
+
+	mu     sync.Mutex
+	name   string
+	end    time.Time
+	attrs  []Attr
+	err    string
+	failed bool
+	ended  bool
+}
+
+type tracerKey struct{}
+type spanKey struct{}
+
+// WithTracer returns a context that spans are recorded to.
+func WithTracer(ctx context.Context, t *Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// Start starts a span as a child of the span of ctx, if any, and returns a
+// context carrying it. It returns a nil span if ctx has no Tracer.
+func Start(ctx context.Context, name string, kind Kind, attrs ...Attr) (context.Context, *Span) {
+	t, ok := ctx.Value(tracerKey{}).(*Tracer)
+	if !ok || t == nil {
+		return ctx, nil
+	}
+	span := &Span{
+		tracer: t,
+		id:     randomID(8),
+		kind:   kind,
+		start:  t.now(),
+		name:   name,
+		attrs:  attrs,
+	}
+	if parent, ok := ctx.Value(spanKey{}).(*Span); ok && parent != nil {
+		span.parentID = parent.id
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SetName renames the span, for spans whose name is only known once they end.
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.name = name
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// SetError marks the span as failed with err. A nil err is ignored.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = true
+	s.err = err.Error()
+}
+
+// End ends the span and records it. Only the first call has an effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = s.tracer.now()
+	s.mu.Unlock()
+
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tracer.spans = append(s.tracer.spans, s)
+}
+
+// Name returns the name of the span.
+func (s *Span) Name() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.name
+}
+
+// Attributes returns the attributes of the span.
+func (s *Span) Attributes() []Attr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Attr(nil), s.attrs...)
+}
+
+func randomID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,143 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStart_WithoutTracer(t *testing.T) {
+	ctx := context.Background()
+	got, span := Start(ctx, "noop", KindInternal)
+	assert.Nil(t, span)
+	assert.Equal(t, ctx, got)
+	// a nil span can be used like any other
+	span.SetName("renamed")
+	span.SetAttributes(String("k", "v"))
+	span.SetError(errors.New("boom"))
+	span.End()
+}
+
+func TestExport(t *testing.T) {
+	var (
+		gotPath    string
+		gotHeaders http.Header
+		gotBody    map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotHeaders = r.Header
+		raw, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(raw, &gotBody))
+	}))
+	defer server.Close()
+
+	tracer := New()
+	clock := time.Unix(1700000000, 0)
+	tracer.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	ctx, root := Start(WithTracer(context.Background(), tracer), "cencli", KindInternal)
+	_, child := Start(ctx, "censys.search", KindClient, String("cencli.operation", "search"))
+	child.SetAttributes(Int("http.response.status_code", 429), Bool("cencli.retry", true), Attr{Key: "delay", Value: 1500 * time.Millisecond})
+	child.SetError(errors.New("rate limited"))
+	child.End()
+	child.End()
+	root.SetName("censys search")
+	root.End()
+
+	headers, err := ParseHeaders([]string{"authorization=Bearer%20abc", " x-tenant = ops "})
+	require.NoError(t, err)
+	require.NoError(t, tracer.Export(context.Background(), ExportOptions{
+		Endpoint:       server.URL,
+		Headers:        headers,
+		ServiceName:    "cencli",
+		ServiceVersion: "1.2.3",
+	}))
+
+	assert.Equal(t, "/v1/traces", gotPath)
+	assert.Equal(t, "application/json", gotHeaders.Get("Content-Type"))
+	assert.Equal(t, "Bearer abc", gotHeaders.Get("Authorization"))
+	assert.Equal(t, "ops", gotHeaders.Get("X-Tenant"))
+
+	resourceSpans := gotBody["resourceSpans"].([]any)[0].(map[string]any)
+	assert.Equal(t, []any{
+		map[string]any{"key": "service.name", "value": map[string]any{"stringValue": "cencli"}},
+		map[string]any{"key": "service.version", "value": map[string]any{"stringValue": "1.2.3"}},
+	}, resourceSpans["resource"].(map[string]any)["attributes"])
+
+	spans := resourceSpans["scopeSpans"].([]any)[0].(map[string]any)["spans"].([]any)
+	require.Len(t, spans, 2)
+	search, cmd := spans[0].(map[string]any), spans[1].(map[string]any)
+
+	assert.Equal(t, tracer.TraceID(), search["traceId"])
+	assert.Len(t, search["traceId"], 32)
+	assert.Len(t, search["spanId"], 16)
+	assert.Equal(t, cmd["spanId"], search["parentSpanId"])
+	assert.NotContains(t, cmd, "parentSpanId")
+	assert.Equal(t, "censys.search", search["name"])
+	assert.Equal(t, float64(KindClient), search["kind"])
+	assert.Equal(t, "1700000002000000000", search["startTimeUnixNano"])
+	assert.Equal(t, "1700000003000000000", search["endTimeUnixNano"])
+	assert.Equal(t, []any{
+		map[string]any{"key": "cencli.operation", "value": map[string]any{"stringValue": "search"}},
+		map[string]any{"key": "http.response.status_code", "value": map[string]any{"intValue": "429"}},
+		map[string]any{"key": "cencli.retry", "value": map[string]any{"boolValue": true}},
+		map[string]any{"key": "delay", "value": map[string]any{"intValue": "1500"}},
+	}, search["attributes"])
+	assert.Equal(t, map[string]any{"code": float64(statusError), "message": "rate limited"}, search["status"])
+
+	assert.Equal(t, "censys search", cmd["name"])
+	assert.Equal(t, map[string]any{"code": float64(statusOK)}, cmd["status"])
+}
+
+func TestExport_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad token", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	tracer := New()
+	_, span := Start(WithTracer(context.Background(), tracer), "cencli", KindInternal)
+	span.End()
+
+	err := tracer.Export(context.Background(), ExportOptions{Endpoint: server.URL})
+	require.ErrorContains(t, err, "failed to export traces: 401 Unauthorized: bad token")
+
+	err = tracer.Export(context.Background(), ExportOptions{Endpoint: "localhost:4318"})
+	require.ErrorContains(t, err, `invalid OTLP endpoint "localhost:4318"`)
+
+	// nothing is sent without spans
+	require.NoError(t, New().Export(context.Background(), ExportOptions{Endpoint: "http://127.0.0.1:1"}))
+}
+
+func TestEndpoint(t *testing.T) {
+	for raw, want := range map[string]string{
+		"http://localhost:4318":                "http://localhost:4318/v1/traces",
+		"https://otel.example.com/":            "https://otel.example.com/v1/traces",
+		"https://otel.example.com/custom/path": "https://otel.example.com/custom/path",
+	} {
+		got, err := Endpoint(raw)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	_, err := ParseHeaders([]string{"no-value"})
+	require.ErrorContains(t, err, `invalid OTLP header "no-value"`)
+
+	headers, err := ParseHeaders([]string{"", "a=b=c"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "b=c"}, headers)
+}
@@ -1,9 +1,14 @@
-package progress
+// Package events is the bus that services publish the progress of long
+// operations into: pages fetched, batches done, requests retried. The
+// command layer renders the events as a spinner, a progress bar, or log
+// lines.
+package events
 
 import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 )
 
 // Stage identifies the conceptual phase of an operation emitting progress events.
@@ -27,12 +32,53 @@ const (
 	StageRender Stage = "render"
 )
 
+// Kind identifies the structured progress an event reports.
+type Kind string
+
+const (
+	// KindMessage is an event that only carries a message.
+	KindMessage Kind = ""
+
+	// KindPage reports that a page of results was fetched.
+	KindPage Kind = "page"
+
+	// KindBatch reports that a batch of a bulk operation is done.
+	KindBatch Kind = "batch"
+
+	// KindRetry reports that a failed request is retried.
+	KindRetry Kind = "retry"
+
+	// KindRequest reports that an API request completed.
+	KindRequest Kind = "request"
+)
+
+// Request describes a completed API request.
+type Request struct {
+	Method string
+	URL    string
+	// Status is the status code of the response, or zero if none was received.
+	Status  int
+	Latency time.Duration
+}
+
 // Event conveys progress for a long-running operation.
 type Event struct {
 	Stage   Stage
 	Message string
 	Done    bool
 	Err     error
+
+	Kind Kind
+	// Current and Total are the pages fetched or batches done so far, and
+	// how many there are in all (zero if unknown), for page and batch events.
+	// For retry events, Current is the attempt that failed and Total the
+	// maximum number of attempts.
+	Current uint64
+	Total   uint64
+	// Delay is the wait before the next attempt, for retry events.
+	Delay time.Duration
+	// Request is the completed request, for request events.
+	Request *Request
 }
 
 // Publisher emits progress events to interested listeners.
@@ -136,3 +182,29 @@ func ReportError(ctx context.Context, stage Stage, err error) {
 		_ = Publish(ctx, Event{Stage: stage, Message: err.Error(), Err: err})
 	}
 }
+
+// ReportPage emits a page event: page of total pages (zero if unknown) was
+// fetched.
+// Errors are silently ignored per the package error handling policy.
+func ReportPage(ctx context.Context, page, total uint64, message string) {
+	_ = Publish(ctx, Event{Stage: StageFetch, Kind: KindPage, Current: page, Total: total, Message: message})
+}
+
+// ReportBatch emits a batch event: done of total batches are done.
+// Errors are silently ignored per the package error handling policy.
+func ReportBatch(ctx context.Context, stage Stage, done, total uint64, message string) {
+	_ = Publish(ctx, Event{Stage: stage, Kind: KindBatch, Current: done, Total: total, Message: message})
+}
+
+// ReportRetry emits a retry event: attempt of maxAttempts failed with err,
+// and the request is sent again after delay.
+// Errors are silently ignored per the package error handling policy.
+func ReportRetry(ctx context.Context, attempt, maxAttempts uint64, delay time.Duration, err error) {
+	_ = Publish(ctx, Event{Stage: StageFetch, Kind: KindRetry, Current: attempt, Total: maxAttempts, Delay: delay, Err: err})
+}
+
+// ReportRequest emits a request event for a completed API request.
+// Errors are silently ignored per the package error handling policy.
+func ReportRequest(ctx context.Context, req Request) {
+	_ = Publish(ctx, Event{Stage: StageFetch, Kind: KindRequest, Request: &req})
+}
@@ -1,9 +1,13 @@
-package progress
+package events
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestPublisher_NoDeadlockWithMultipleEvents verifies that rapid event publishing
@@ -103,3 +107,32 @@ func TestPublisher_NoDeadlockWithoutContextDeadline(t *testing.T) {
 		t.Errorf("expected 5 events consumed, got %d", eventCount)
 	}
 }
+
+// TestReportHelpers verifies that the Report* helpers publish structured events.
+func TestReportHelpers(t *testing.T) {
+	pub, published := NewChannelPublisher(4)
+	ctx := WithPublisher(context.Background(), pub)
+
+	retryErr := errors.New("rate limited")
+	ReportPage(ctx, 2, 5, "page 3")
+	ReportBatch(ctx, StageProcess, 1, 4, "batch 1")
+	ReportRetry(ctx, 1, 3, time.Second, retryErr)
+	ReportRequest(ctx, Request{Method: "GET", URL: "https://api.example", Status: 200})
+	pub.Close(nil)
+
+	var got []Event
+	for event := range published {
+		if event.Done {
+			break
+		}
+		got = append(got, event)
+	}
+	require.Len(t, got, 4)
+	assert.Equal(t, Event{Stage: StageFetch, Kind: KindPage, Current: 2, Total: 5, Message: "page 3"}, got[0])
+	assert.Equal(t, Event{Stage: StageProcess, Kind: KindBatch, Current: 1, Total: 4, Message: "batch 1"}, got[1])
+	assert.Equal(t, Event{Stage: StageFetch, Kind: KindRetry, Current: 1, Total: 3, Delay: time.Second, Err: retryErr}, got[2])
+	assert.Equal(t, &Request{Method: "GET", URL: "https://api.example", Status: 200}, got[3].Request)
+
+	// without a publisher the helpers are no-ops
+	ReportPage(context.Background(), 1, 1, "ignored")
+}
@@ -9,6 +9,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"golang.org/x/term"
+
+	cencliterm "github.com/censys/cencli/internal/pkg/term"
 )
 
 var ErrUserAborted = errors.New("user aborted")
@@ -57,8 +59,13 @@ func NewForm(huhForm *huh.Form, opts ...formOption) *Form {
 }
 
 // RunWithContext runs the form using the provided context and also
-// listens for Ctrl-C to cancel
+// listens for Ctrl-C to cancel. It returns ErrNonInteractive without running
+// the form if the user cannot be prompted.
 func (f *Form) RunWithContext(parent context.Context) error {
+	if !cencliterm.Interactive() {
+		return ErrNonInteractive
+	}
+
 	ctx, cancel := signal.NotifyContext(parent, os.Interrupt)
 	defer cancel()
 
@@ -0,0 +1,90 @@
+package form
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	"github.com/censys/cencli/internal/pkg/term"
+)
+
+// ErrNonInteractive is returned instead of prompting when the user cannot be
+// prompted (see term.Interactive).
+var ErrNonInteractive = errors.New("cannot prompt in non-interactive mode")
+
+// assumeYes is set by --yes to answer yes to every confirmation prompt.
+var assumeYes atomic.Bool
+
+// SetAssumeYes sets whether confirmation prompts are answered yes without asking.
+func SetAssumeYes(v bool) {
+	assumeYes.Store(v)
+}
+
+// AssumeYes returns true if confirmation prompts are answered yes without asking.
+func AssumeYes() bool {
+	return assumeYes.Load()
+}
+
+// Confirm asks a yes/no question on stderr. It returns true without asking
+// if --yes is set, and ErrNonInteractive if the user cannot be prompted.
+func Confirm(ctx context.Context, title string) (bool, error) {
+	if AssumeYes() {
+		return true, nil
+	}
+	var confirmed bool
+	f := NewForm(
+		huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title(title).
+					Affirmative("Yes").
+					Negative("No").
+					Value(&confirmed),
+			),
+		).WithOutput(os.Stderr),
+	)
+	if err := f.RunWithContext(ctx); err != nil {
+		return false, err
+	}
+	return confirmed, nil
+}
+
+// NonInteractiveError is returned by commands that need input the user
+// cannot be prompted for, with a hint on how to provide it instead.
+type NonInteractiveError interface {
+	cenclierrors.CencliError
+}
+
+type nonInteractiveError struct {
+	action string
+	hint   string
+}
+
+var _ NonInteractiveError = &nonInteractiveError{}
+
+// NewNonInteractiveError returns an error for an action that needs a prompt,
+// such as "adding a token", with a hint such as "pass the token with --value".
+func NewNonInteractiveError(action, hint string) NonInteractiveError {
+	return &nonInteractiveError{action: action, hint: hint}
+}
+
+func (e *nonInteractiveError) Error() string {
+	reason := "no terminal is available to prompt on"
+	if term.NonInteractiveForced() {
+		reason = "--non-interactive is set"
+	}
+	return fmt.Sprintf("%s needs input, but %s; %s", e.action, reason, e.hint)
+}
+
+func (e *nonInteractiveError) Title() string {
+	return "Input Required"
+}
+
+func (e *nonInteractiveError) ShouldPrintUsage() bool {
+	return false
+}
@@ -0,0 +1,43 @@
+package form
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/term"
+)
+
+func TestConfirm(t *testing.T) {
+	t.Run("--yes answers without asking", func(t *testing.T) {
+		SetAssumeYes(true)
+		defer SetAssumeYes(false)
+		term.SetNonInteractive(true)
+		defer term.SetNonInteractive(false)
+
+		confirmed, err := Confirm(context.Background(), "Proceed?")
+		require.NoError(t, err)
+		require.True(t, confirmed)
+	})
+
+	t.Run("fails fast when non-interactive", func(t *testing.T) {
+		term.SetNonInteractive(true)
+		defer term.SetNonInteractive(false)
+
+		confirmed, err := Confirm(context.Background(), "Proceed?")
+		require.ErrorIs(t, err, ErrNonInteractive)
+		require.False(t, confirmed)
+	})
+}
+
+func TestNonInteractiveError(t *testing.T) {
+	t.Cleanup(func() { term.SetNonInteractive(false) })
+
+	err := NewNonInteractiveError("adding a token", "pass the token with --value")
+	require.Equal(t, "Input Required", err.Title())
+	require.False(t, err.ShouldPrintUsage())
+
+	term.SetNonInteractive(true)
+	require.Equal(t, "adding a token needs input, but --non-interactive is set; pass the token with --value", err.Error())
+}
@@ -5,13 +5,19 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
+
+	"github.com/censys/cencli/internal/pkg/formatter"
 )
 
+// separatorWidth is the width of the separator between columns.
+const separatorWidth = 3
+
 // Table represents a simple table renderer for struct data.
 type Table[T any] struct {
 	columns        []Column[T]
 	headerStyle    lipgloss.Style
 	stylesDisabled bool
+	maxWidth       int
 }
 
 // Column defines a single column.
@@ -27,6 +33,17 @@ type Column[T any] struct {
 
 	// AlignRight aligns the content to the right (default is left)
 	AlignRight bool
+
+	// Priority orders columns by importance when the table is wider than its
+	// maximum width: lower priority columns are truncated, then hidden, first.
+	Priority int
+
+	// MinWidth is the narrowest the column is truncated to (optional)
+	MinWidth int
+
+	// NoTruncate keeps the column's cells whole, e.g. for values that must
+	// stay copyable. The column can still be hidden.
+	NoTruncate bool
 }
 
 // Option is a functional option for configuring a Table.
@@ -46,6 +63,15 @@ func WithStylesDisabled[T any](disabled bool) Option[T] {
 	}
 }
 
+// WithMaxWidth fits the table into width display cells by truncating and
+// hiding columns (see formatter.Layout). A width of 0 disables fitting.
+// Pass formatter.TableWidth() to fit the table to the terminal.
+func WithMaxWidth[T any](width int) Option[T] {
+	return func(t *Table[T]) {
+		t.maxWidth = width
+	}
+}
+
 // New creates a new Table with the given columns.
 func New[T any](columns []Column[T], opts ...Option[T]) *Table[T] {
 	t := &Table[T]{
@@ -82,20 +108,26 @@ func (t *Table[T]) Render(rows []T) string {
 		}
 	}
 
-	// Calculate column widths based on plain text
-	widths := t.calculateWidths(headers, plainRows)
+	// Calculate column widths based on plain text, then fit them to the maximum width
+	widths := t.layout(t.calculateWidths(headers, plainRows))
+	visible := make([]int, 0, len(widths))
+	for i, w := range widths {
+		if w > 0 {
+			visible = append(visible, i)
+		}
+	}
 
 	// Build the table
 	var sb strings.Builder
 
 	// Header row
-	for i, header := range headers {
-		paddedHeader := pad(header, widths[i], false) // Headers always left-aligned
+	for n, i := range visible {
+		paddedHeader := pad(formatter.Truncate(headers[i], widths[i]), widths[i], false) // Headers always left-aligned
 		if !t.stylesDisabled {
 			paddedHeader = t.headerStyle.Render(paddedHeader)
 		}
 		sb.WriteString(paddedHeader)
-		if i < len(headers)-1 {
+		if n < len(visible)-1 {
 			sb.WriteString("   ")
 		}
 	}
@@ -103,9 +135,9 @@ func (t *Table[T]) Render(rows []T) string {
 
 	// Data rows
 	for r, row := range plainRows {
-		for c, cell := range row {
-			// Pad the plain text first
-			paddedCell := pad(cell, widths[c], t.columns[c].AlignRight)
+		for n, c := range visible {
+			// Truncate and pad the plain text first
+			paddedCell := pad(formatter.Truncate(row[c], widths[c]), widths[c], t.columns[c].AlignRight)
 
 			// Apply column style if provided
 			finalCell := paddedCell
@@ -114,7 +146,7 @@ func (t *Table[T]) Render(rows []T) string {
 			}
 
 			sb.WriteString(finalCell)
-			if c < len(row)-1 {
+			if n < len(visible)-1 {
 				sb.WriteString(" | ")
 			}
 		}
@@ -146,6 +178,23 @@ func (t *Table[T]) calculateWidths(headers []string, rows [][]string) []int {
 	return widths
 }
 
+// layout fits the column widths into the table's maximum width, if any.
+func (t *Table[T]) layout(widths []int) []int {
+	if t.maxWidth <= 0 {
+		return widths
+	}
+	columns := make([]formatter.LayoutColumn, len(t.columns))
+	for i, col := range t.columns {
+		columns[i] = formatter.LayoutColumn{
+			Width:      widths[i],
+			MinWidth:   col.MinWidth,
+			Priority:   col.Priority,
+			NoTruncate: col.NoTruncate,
+		}
+	}
+	return formatter.Layout(columns, separatorWidth, t.maxWidth)
+}
+
 // pad pads a string with spaces to reach the target width.
 // If alignRight is true, pads on the left; otherwise pads on the right.
 func pad(s string, width int, alignRight bool) string {
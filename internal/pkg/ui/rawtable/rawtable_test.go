@@ -295,3 +295,45 @@ func TestRender_MultipleColumns(t *testing.T) {
 		t.Error("expected result to contain 'Bob'")
 	}
 }
+
+func TestRender_WithMaxWidth(t *testing.T) {
+	data := []TestData{
+		{ID: 1, Name: "Alexandria Ocasio-Smith", Score: 95.5},
+		{ID: 2, Name: "Bob", Score: 87.3},
+	}
+
+	columns := []Column[TestData]{
+		{
+			Title:      "ID",
+			String:     func(td TestData) string { return fmt.Sprint(td.ID) },
+			Priority:   2,
+			NoTruncate: true,
+		},
+		{
+			Title:    "Name",
+			String:   func(td TestData) string { return td.Name },
+			Priority: 1,
+		},
+		{
+			Title:  "Score",
+			String: func(td TestData) string { return fmt.Sprintf("%.1f", td.Score) },
+		},
+	}
+
+	// ID (2) + separator (3) + Name truncated to 10 = 15; Score is hidden
+	table := New(columns, WithStylesDisabled[TestData](true), WithMaxWidth[TestData](15))
+	result := table.Render(data)
+
+	expected := "ID   Name      \n\n" +
+		"1  | Alexandri…\n" +
+		"2  | Bob       \n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+
+	// without a maximum width, nothing is truncated or hidden
+	result = New(columns, WithStylesDisabled[TestData](true)).Render(data)
+	if !strings.Contains(result, "Alexandria Ocasio-Smith") || !strings.Contains(result, "Score") {
+		t.Errorf("expected full table, got:\n%s", result)
+	}
+}
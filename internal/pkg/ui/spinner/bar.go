@@ -0,0 +1,25 @@
+package spinner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/censys/cencli/internal/pkg/term"
+)
+
+// ProgressBar renders current out of total as a bar width cells wide,
+// followed by the count, e.g. "[████░░░░] 4/8". Current is capped at total.
+// It returns an empty string if total is zero.
+func ProgressBar(current, total uint64, width int) string {
+	if total == 0 || width <= 0 {
+		return ""
+	}
+	current = min(current, total)
+	filled := int(current * uint64(width) / total)
+
+	full, empty := "=", "-"
+	if term.SupportsUnicode() {
+		full, empty = "█", "░"
+	}
+	return fmt.Sprintf("[%s%s] %d/%d", strings.Repeat(full, filled), strings.Repeat(empty, width-filled), current, total)
+}
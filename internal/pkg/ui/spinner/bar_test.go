@@ -0,0 +1,30 @@
+package spinner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressBar(t *testing.T) {
+	t.Setenv("CENCLI_ASCII", "1")
+
+	tests := []struct {
+		name           string
+		current, total uint64
+		width          int
+		want           string
+	}{
+		{name: "empty", current: 0, total: 4, width: 8, want: "[--------] 0/4"},
+		{name: "half", current: 2, total: 4, width: 8, want: "[====----] 2/4"},
+		{name: "rounds down", current: 1, total: 3, width: 8, want: "[==------] 1/3"},
+		{name: "full", current: 4, total: 4, width: 8, want: "[========] 4/4"},
+		{name: "capped at total", current: 6, total: 4, width: 4, want: "[====] 4/4"},
+		{name: "unknown total", current: 3, total: 0, width: 8, want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ProgressBar(tc.current, tc.total, tc.width))
+		})
+	}
+}
@@ -68,6 +68,15 @@ func WithStopwatch(startAfterSeconds uint64) ComponentOption {
 	}
 }
 
+// defaultDesign returns a braille spinner, or an ASCII one for terminals
+// that cannot render it.
+func defaultDesign() spinner.Spinner {
+	if term.SupportsUnicode() {
+		return spinner.Dot
+	}
+	return spinner.Line
+}
+
 // Handle allows callers to update the spinner's message and stop it.
 type Handle interface {
 	Stop()
@@ -86,7 +95,7 @@ func NewNoopHandle() Handle { return noopHandle{} }
 // Returns start and stop functions. Stop is idempotent.
 func newSpinner(out io.Writer, opts ...ComponentOption) (startWithContext func(done <-chan struct{}), stop func(), comp *spinnerComponent) {
 	spinnerOpts := &spinnerComponentOptions{
-		design:  spinner.Dot,
+		design:  defaultDesign(),
 		message: "Loading...",
 	}
 	for _, opt := range opts {
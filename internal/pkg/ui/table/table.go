@@ -2,6 +2,7 @@ package table
 
 import (
 	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,10 +18,17 @@ type tableComponent[T any] struct {
 	keyActions       []KeyAction[T]
 	selectDesc       string
 	keepOpenOnSelect bool
+	detail           DetailFunc[T]
+	// details caches the details of each row, by index
+	details map[int]detailMsg
 }
 
 type RowRenderer[T any] func(T) []string
 
+// DetailFunc returns the text shown below the table for a row, such as the
+// result of a follow-up request. It runs in the background, so it may be slow.
+type DetailFunc[T any] func(T) (string, error)
+
 type KeyAction[T any] struct {
 	Key         string
 	Description string
@@ -37,6 +45,7 @@ type tableComponentOptions[T any] struct {
 	keyActions       []KeyAction[T]
 	selectDesc       string
 	keepOpenOnSelect bool
+	detail           DetailFunc[T]
 }
 
 type tableComponentOption[T any] func(*tableComponentOptions[T])
@@ -89,6 +98,14 @@ func WithKeepOpenOnSelect[T any](keepOpen bool) tableComponentOption[T] {
 	}
 }
 
+// WithDetailFunc shows the detail of the selected row below the table when
+// Enter is pressed, instead of closing it. Details are fetched once per row.
+func WithDetailFunc[T any](fn DetailFunc[T]) tableComponentOption[T] {
+	return func(t *tableComponentOptions[T]) {
+		t.detail = fn
+	}
+}
+
 func NewTable[T any](titles []string, rowRenderer RowRenderer[T], opts ...tableComponentOption[T]) *tableComponent[T] {
 	// Set up defaults
 	options := &tableComponentOptions[T]{
@@ -129,20 +146,27 @@ func NewTable[T any](titles []string, rowRenderer RowRenderer[T], opts ...tableC
 		keyActions:       options.keyActions,
 		selectDesc:       options.selectDesc,
 		keepOpenOnSelect: options.keepOpenOnSelect,
+		detail:           options.detail,
+		details:          map[int]detailMsg{},
+	}
+}
+
+// border returns b, or an ASCII border for terminals that cannot render box-drawing characters.
+func border(b lipgloss.Border) lipgloss.Border {
+	if term.SupportsUnicode() {
+		return b
 	}
+	return lipgloss.ASCIIBorder()
 }
 
 func defaultStyles() table.Styles {
 	s := table.DefaultStyles()
 	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderStyle(border(lipgloss.NormalBorder())).
+		BorderForeground(styles.ColorGray).
 		BorderBottom(true).
 		Bold(false)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(styles.ColorTeal).
-		Bold(false)
+	s.Selected = styles.Highlight(lipgloss.Color("229"), styles.ColorTeal)
 	return s
 }
 
@@ -171,6 +195,34 @@ type model[T any] struct {
 	showingConfirm  bool
 	confirmAction   *KeyAction[T]
 	confirmSelected T
+	// showingDetail is set while the detail of the row at detailIndex is
+	// shown below the table
+	showingDetail bool
+	detailIndex   int
+	loading       bool
+}
+
+// detailMsg is the detail of the row at index, once fetched.
+type detailMsg struct {
+	index int
+	text  string
+	err   error
+}
+
+// showDetail shows the detail of the row at index, fetching it first if it
+// is not cached.
+func (m model[T]) showDetail(index int) (model[T], tea.Cmd) {
+	m.showingDetail, m.detailIndex = true, index
+	if _, ok := m.t.details[index]; ok {
+		m.loading = false
+		return m, nil
+	}
+	m.loading = true
+	row, detail := m.t.rowsData[index], m.t.detail
+	return m, func() tea.Msg {
+		text, err := detail(row)
+		return detailMsg{index: index, text: text, err: err}
+	}
 }
 
 func (m model[T]) Init() tea.Cmd { return nil }
@@ -178,6 +230,14 @@ func (m model[T]) Init() tea.Cmd { return nil }
 func (m model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if msg, ok := msg.(detailMsg); ok {
+		m.t.details[msg.index] = msg
+		if m.showingDetail && msg.index == m.detailIndex {
+			m.loading = false
+		}
+		return m, nil
+	}
+
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		// Handle confirmation dialog
 		if m.showingConfirm {
@@ -200,6 +260,10 @@ func (m model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Normal table handling
 		switch keyMsg.String() {
 		case "esc":
+			if m.showingDetail {
+				m.showingDetail, m.loading = false, false
+				return m, nil
+			}
 			if m.t.table.Focused() {
 				m.t.table.Blur()
 			} else {
@@ -208,6 +272,11 @@ func (m model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "q", "ctrl+c":
 			return m, tea.Quit
 		case "enter":
+			if m.t.detail != nil && len(m.t.rowsData) > 0 {
+				if idx := m.t.table.Cursor(); 0 <= idx && idx < len(m.t.rowsData) {
+					return m.showDetail(idx)
+				}
+			}
 			if m.t.onSelect != nil && len(m.t.rowsData) > 0 {
 				idx := m.t.table.Cursor()
 				if 0 <= idx && idx < len(m.t.rowsData) {
@@ -259,16 +328,17 @@ func (m model[T]) View() string {
 	}
 
 	content := m.t.table.View()
+	if detail := m.renderDetail(); detail != "" {
+		content += "\n" + detail
+	}
 
 	if m.t.title != "" {
 
-		highlightStyle := lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("57")).
-			Background(lipgloss.Color("229"))
+		highlightStyle := styles.Highlight(lipgloss.Color("57"), lipgloss.Color("229")).
+			Bold(true)
 
 		explanationStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")).
+			Foreground(styles.ColorGray).
 			MarginBottom(1)
 
 		title := styles.GlobalStyles.Signature.
@@ -281,7 +351,7 @@ func (m model[T]) View() string {
 		instructions := "Use ↑/↓ to navigate"
 
 		// Add select instruction if configured
-		if m.t.onSelect != nil {
+		if m.t.onSelect != nil || m.t.detail != nil {
 			selectDesc := m.t.selectDesc
 			if selectDesc == "" {
 				selectDesc = "select"
@@ -294,6 +364,10 @@ func (m model[T]) View() string {
 			instructions += ", " + highlightStyle.Render(action.Key) + " to " + action.Description
 		}
 
+		if m.showingDetail {
+			instructions += ", " + highlightStyle.Render("Esc") + " to close"
+		}
+
 		// Always add quit instruction
 		instructions += ", " + highlightStyle.Render("q") + " to quit"
 
@@ -305,29 +379,46 @@ func (m model[T]) View() string {
 	return content + "\n"
 }
 
+// renderDetail renders the detail of the selected row, if one is shown.
+func (m model[T]) renderDetail() string {
+	if !m.showingDetail {
+		return ""
+	}
+	style := lipgloss.NewStyle().
+		Border(border(lipgloss.RoundedBorder())).
+		BorderForeground(styles.ColorGray).
+		Padding(0, 1)
+	if m.loading {
+		return style.Render(lipgloss.NewStyle().Foreground(styles.ColorGray).Render("Loading..."))
+	}
+	detail := m.t.details[m.detailIndex]
+	if detail.err != nil {
+		return style.Render(lipgloss.NewStyle().Foreground(styles.ColorRed).Render("Error: " + detail.err.Error()))
+	}
+	return style.Render(detail.text)
+}
+
 func (m model[T]) renderConfirmationDialog() string {
 	dialogStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		Border(border(lipgloss.RoundedBorder())).
+		BorderForeground(styles.ColorTeal).
 		Padding(1, 2).
 		MarginTop(2).
 		MarginBottom(2)
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("196")).
+		Foreground(styles.ColorRed).
 		MarginBottom(1)
 
-	highlightStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("57")).
-		Background(lipgloss.Color("229"))
+	highlightStyle := styles.Highlight(lipgloss.Color("57"), lipgloss.Color("229")).
+		Bold(true)
 
 	instructionStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("245")).
+		Foreground(styles.ColorGray).
 		MarginTop(1)
 
-	title := titleStyle.Render("⚠️  Confirmation Required")
+	title := titleStyle.Render(term.Glyph("⚠️  ", "! ") + "Confirmation Required")
 
 	var message string
 	if m.confirmAction != nil {
@@ -1,6 +1,8 @@
 package table
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/table"
@@ -117,3 +119,55 @@ func TestModelOnSelectEnter(t *testing.T) {
 		t.Fatalf("expected select func to be called on enter")
 	}
 }
+
+func TestModelDetail(t *testing.T) {
+	cols := []string{"A", "B"}
+	calls := 0
+	tt := NewTable[row](cols, func(r row) []string { return r.asRow() },
+		WithTitle[row]("Title"),
+		WithDetailFunc[row](func(r row) (string, error) {
+			calls++
+			return "detail of " + r.a, nil
+		}),
+	)
+	tt.setRows([]row{{"a", "b"}})
+	m := model[row]{t: tt}
+
+	mm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = mm.(model[row])
+	if cmd == nil || !m.loading {
+		t.Fatalf("expected enter to fetch the detail")
+	}
+	if !strings.Contains(m.View(), "Loading...") {
+		t.Fatalf("expected a loading message, got %q", m.View())
+	}
+	mm, _ = m.Update(cmd())
+	m = mm.(model[row])
+	if view := m.View(); !strings.Contains(view, "detail of a") || !strings.Contains(view, "Title") {
+		t.Fatalf("expected the detail below the table, got %q", view)
+	}
+
+	// esc closes the detail, and enter shows it again from the cache
+	mm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = mm.(model[row])
+	if strings.Contains(m.View(), "detail of a") {
+		t.Fatalf("expected esc to close the detail")
+	}
+	mm, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = mm.(model[row])
+	if cmd != nil || calls != 1 || !strings.Contains(m.View(), "detail of a") {
+		t.Fatalf("expected the cached detail, calls=%d", calls)
+	}
+}
+
+func TestModelDetailError(t *testing.T) {
+	tt := NewTable[row]([]string{"A", "B"}, func(r row) []string { return r.asRow() },
+		WithDetailFunc[row](func(r row) (string, error) { return "", errors.New("boom") }),
+	)
+	tt.setRows([]row{{"a", "b"}})
+	mm, cmd := model[row]{t: tt}.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	mm, _ = mm.Update(cmd())
+	if view := mm.View(); !strings.Contains(view, "Error: boom") {
+		t.Fatalf("expected the error, got %q", view)
+	}
+}
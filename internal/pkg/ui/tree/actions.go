@@ -0,0 +1,89 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// actionDoneMsg carries the result of a key action on an item.
+type actionDoneMsg struct {
+	item   *node
+	action KeyAction
+	data   any
+	err    error
+}
+
+// startAction runs action for the item that holds the cursor, unless one
+// is already running for it.
+func (m *treeModel) startAction(action KeyAction) tea.Cmd {
+	if m.cursor >= len(m.flatNodes) {
+		return nil
+	}
+	item, index := m.itemOf(m.flatNodes[m.cursor])
+	if item == nil {
+		m.statusMessage = fmt.Sprintf("%s: select an item first", action.Description)
+		return nil
+	}
+	if m.loading[item] {
+		return nil
+	}
+	m.loading[item] = true
+	m.statusMessage = fmt.Sprintf("loading %s...", action.Name)
+	return func() tea.Msg {
+		data, err := action.Load(index)
+		if err == nil {
+			data, err = toJSONValue(data)
+		}
+		return actionDoneMsg{item: item, action: action, data: data, err: err}
+	}
+}
+
+// finishAction shows the data loaded by an action under its item, replacing
+// any data loaded before, and moves the cursor to it.
+func (m *treeModel) finishAction(msg actionDoneMsg) tea.Cmd {
+	delete(m.loading, msg.item)
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("failed to load %s: %v", msg.action.Name, msg.err)
+		return nil
+	}
+	loaded := newNode(msg.action.Name, msg.data, msg.item, 0)
+	msg.item.materialize()
+	msg.item.Children = slices.DeleteFunc(msg.item.Children, func(child *node) bool {
+		return child.Key == msg.action.Name
+	})
+	msg.item.Children = append(msg.item.Children, loaded)
+	msg.item.IsLeaf = false
+	m.matches = nil
+	m.reveal(loaded)
+	m.statusMessage = fmt.Sprintf("loaded %s", msg.action.Name)
+	return clearStatusAfter(2 * time.Second)
+}
+
+// itemOf returns the item of the root array that holds n, and its index, or
+// nil if n is not within an item.
+func (m *treeModel) itemOf(n *node) (*node, int) {
+	for ; n != nil; n = n.Parent {
+		if i := slices.Index(m.items, n); i >= 0 {
+			return n, i
+		}
+	}
+	return nil, -1
+}
+
+// toJSONValue converts v to the values encoding/json decodes into an any,
+// which the nodes are parsed from.
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
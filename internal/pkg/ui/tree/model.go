@@ -18,6 +18,15 @@ type treeModel struct {
 	width         int     // Terminal width
 	styles        Styles  // Styling configuration
 	statusMessage string  // Status message to display
+
+	items   []*node        // Items of the root array, the targets of actions
+	actions []KeyAction    // Key actions on items
+	loading map[*node]bool // Items with an action in progress
+
+	searching bool    // Whether the search query is being typed
+	query     string  // Search query
+	matches   []*node // Nodes that match the query
+	match     int     // Index of the current match
 }
 
 // clearStatusMsg is a message to clear the status message
@@ -38,14 +47,34 @@ func (m *treeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMessage = ""
 		return m, nil
 
+	case actionDoneMsg:
+		return m, m.finishAction(msg)
+
 	case tea.KeyMsg:
 		// Clear status message on any key press
 		m.statusMessage = ""
 
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
 
+		case "/":
+			m.searching = true
+			m.query = ""
+
+		case "n":
+			m.nextMatch(1)
+
+		case "N":
+			m.nextMatch(-1)
+
+		case "esc":
+			m.matches = nil
+
 		case "up", "k":
 			if m.cursor > 0 {
 				m.cursor--
@@ -108,12 +137,28 @@ func (m *treeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.updateFlatNodes()
 				}
 			}
+
+		default:
+			for _, action := range m.actions {
+				if msg.String() == action.Key {
+					return m, m.startAction(action)
+				}
+			}
 		}
 	}
 
 	return m, nil
 }
 
+// help returns the key bindings, shown at the bottom of the view.
+func (m *treeModel) help() string {
+	help := "↑/↓: navigate, ←/→/space/enter: expand/collapse, enter (leaf): copy value, /: search, n/N: next/previous match"
+	for _, action := range m.actions {
+		help += fmt.Sprintf(", %s: %s", action.Key, action.Description)
+	}
+	return help + ", q: quit"
+}
+
 // clearStatusAfter returns a command that sends a clearStatusMsg after a delay
 func clearStatusAfter(d time.Duration) tea.Cmd {
 	return tea.Tick(d, func(t time.Time) tea.Msg {
@@ -158,13 +203,20 @@ func (m *treeModel) View() string {
 
 	b.WriteString("\n")
 
-	// Display status message if present, otherwise show help
-	if m.statusMessage != "" {
+	// Display the search query while it is typed, then the status message
+	// if present, otherwise show help
+	switch {
+	case m.searching:
+		b.WriteString("/" + m.query)
+	case m.statusMessage != "":
 		b.WriteString(m.styles.SelectedStyle.Render(m.statusMessage))
-	} else {
-		b.WriteString(m.styles.HelpStyle.Render("↑/↓: navigate, ←/→/space/enter: expand/collapse, enter (leaf): copy value, q: quit"))
+	default:
+		b.WriteString(m.styles.HelpStyle.Render(m.help()))
 	}
 	b.WriteString(m.styles.FooterStyle.Render(fmt.Sprintf(" (%d/%d)", m.cursor+1, len(m.flatNodes))))
+	if len(m.matches) > 0 {
+		b.WriteString(m.styles.FooterStyle.Render(fmt.Sprintf(" match %d/%d", m.match+1, len(m.matches))))
+	}
 	b.WriteString("\n")
 
 	return b.String()
@@ -185,6 +237,9 @@ func (m *treeModel) renderNode(node *node, selected bool) string {
 	}
 
 	key := node.Key
+	if node.Label != "" {
+		key = node.Label
+	}
 	if key == "" {
 		key = "data"
 	}
@@ -293,6 +348,7 @@ func (m *treeModel) addNodeToFlat(node *node) {
 	m.flatNodes = append(m.flatNodes, node)
 
 	if node.Expanded && !node.IsLeaf {
+		node.materialize()
 		for _, child := range node.Children {
 			m.addNodeToFlat(child)
 		}
@@ -0,0 +1,136 @@
+package tree
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func keys(m *treeModel, keys ...string) {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		m.Update(msg)
+	}
+}
+
+func selected(m *treeModel) *node {
+	return m.flatNodes[m.cursor]
+}
+
+func testEvents() []any {
+	return []any{
+		map[string]any{"event_time": "2025-01-01T00:00:00Z", "location_updated": map[string]any{"country": "Germany"}},
+		map[string]any{"event_time": "2025-01-02T00:00:00Z", "service_scanned": map[string]any{"port": float64(443)}},
+		map[string]any{"event_time": "2025-01-03T00:00:00Z", "location_updated": map[string]any{"country": "France"}},
+	}
+}
+
+func TestSearch(t *testing.T) {
+	m := newTreeModel(testEvents())
+
+	keys(m, "/", "G", "e", "r", "x", "backspace", "enter")
+	assert.False(t, m.searching)
+	assert.Equal(t, "Ger", m.query)
+	require.Len(t, m.matches, 1)
+	assert.Equal(t, `"Germany"`, selected(m).Value, "the match is revealed in collapsed subtrees")
+
+	keys(m, "/", "C", "O", "U", "N", "T", "R", "Y", "enter")
+	require.Len(t, m.matches, 2, "search ignores case")
+	assert.Equal(t, "country", selected(m).Key)
+	first := selected(m)
+
+	keys(m, "n")
+	assert.NotSame(t, first, selected(m))
+	assert.Equal(t, `"France"`, selected(m).Value)
+	keys(m, "n")
+	assert.Same(t, first, selected(m), "matches wrap around")
+	keys(m, "N")
+	assert.Equal(t, 1, m.match)
+
+	keys(m, "/", "n", "o", "p", "e", "enter")
+	assert.Empty(t, m.matches)
+	assert.Equal(t, `no matches for "nope"`, m.statusMessage)
+
+	keys(m, "/", "x", "esc")
+	assert.False(t, m.searching)
+	assert.Equal(t, "x", m.query, "esc cancels the search")
+}
+
+func TestItemLabels(t *testing.T) {
+	m := newTreeModel(testEvents(), WithItemLabels([]string{"2025-01-01 location_updated"}))
+	require.Len(t, m.items, 3)
+	assert.Contains(t, m.renderNode(m.items[0], false), "2025-01-01 location_updated")
+	assert.Contains(t, m.renderNode(m.items[1], false), "1")
+
+	keys(m, "/", "location_updated", "enter")
+	assert.Same(t, m.items[0], selected(m), "labels are searched")
+}
+
+func TestKeyActions(t *testing.T) {
+	loaded := []int{}
+	action := KeyAction{
+		Key:         "s",
+		Description: "fetch snapshot",
+		Name:        "snapshot",
+		Load: func(index int) (any, error) {
+			loaded = append(loaded, index)
+			if index == 2 {
+				return nil, errors.New("not found")
+			}
+			return struct {
+				IP string `json:"ip"`
+			}{IP: "8.8.8.8"}, nil
+		},
+	}
+	m := newTreeModel(testEvents(), WithKeyActions([]KeyAction{action}))
+	assert.Contains(t, m.help(), "s: fetch snapshot")
+
+	run := func(key string) {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		if cmd != nil {
+			m.Update(cmd())
+		}
+	}
+
+	// the cursor is on the root of the array, which is not an item
+	run("s")
+	assert.Empty(t, loaded)
+	assert.Contains(t, m.statusMessage, "select an item first")
+
+	keys(m, "j", "j")
+	run("s")
+	assert.Equal(t, []int{1}, loaded)
+	assert.Equal(t, "snapshot", selected(m).Key)
+	assert.Same(t, m.items[1], selected(m).Parent)
+	require.Len(t, selected(m).Children, 1)
+	assert.Equal(t, `"8.8.8.8"`, selected(m).Children[0].Value)
+
+	// loading again from within the item replaces the data
+	run("s")
+	assert.Equal(t, []int{1, 1}, loaded)
+	snapshots := 0
+	for _, child := range m.items[1].Children {
+		if child.Key == "snapshot" {
+			snapshots++
+		}
+	}
+	assert.Equal(t, 1, snapshots)
+
+	m.cursor = len(m.flatNodes) - 1
+	run("s")
+	assert.Equal(t, []int{1, 1, 2}, loaded)
+	assert.Equal(t, "failed to load snapshot: not found", m.statusMessage)
+}
@@ -12,16 +12,36 @@ import (
 const (
 	maxSummaryLength     = 80
 	defaultExpandedDepth = 0 // Depth level to expand by default (0 = only root level)
+	// lazyArrayLength is the length above which the items of an array are
+	// only parsed into nodes once the array is expanded or searched, so that
+	// a host with hundreds of services is not parsed up front
+	lazyArrayLength = 32
 )
 
 // node represents a node in the JSON tree
 type node struct {
-	Key      string
+	Key string
+	// Label is shown in place of Key, if set.
+	Label    string
 	Value    string
 	Children []*node
 	Parent   *node
 	Expanded bool
 	IsLeaf   bool
+
+	// pending holds the items of a large array until they are parsed into
+	// Children, at pendingDepth
+	pending      []any
+	pendingDepth int
+}
+
+// materialize parses the pending items of a large array into its children.
+func (n *node) materialize() {
+	if n.pending == nil {
+		return
+	}
+	n.Children = parseArray(n.pending, n, n.pendingDepth)
+	n.pending = nil
 }
 
 // escapeString properly escapes a string for display, converting newlines and other special characters
@@ -37,7 +57,8 @@ func escapeString(s string) string {
 // generateObjectSummary recursively extracts all leaf values from an object and concatenates them
 func generateObjectSummary(obj map[string]any) string {
 	var values []string
-	extractLeafValues(obj, &values)
+	length := 0
+	extractLeafValues(obj, &values, &length)
 
 	summary := strings.Join(values, " ")
 	if len(summary) > maxSummaryLength {
@@ -54,7 +75,12 @@ func generateObjectSummary(obj map[string]any) string {
 // generateArraySummary creates a comma-separated summary for arrays containing only leaf nodes
 func generateArraySummary(arr []any) string {
 	var values []string
+	length := 0
 	for _, item := range arr {
+		// values past the length of the summary would be cut off
+		if length-len(", ") > maxSummaryLength {
+			break
+		}
 		switch v := item.(type) {
 		case string:
 			values = append(values, v)
@@ -67,6 +93,7 @@ func generateArraySummary(arr []any) string {
 		default:
 			values = append(values, fmt.Sprintf("%v", v))
 		}
+		length += len(values[len(values)-1]) + len(", ")
 	}
 
 	summary := strings.Join(values, ", ")
@@ -92,31 +119,40 @@ func isArrayOfLeafNodes(arr []any) bool {
 	return true
 }
 
-// extractLeafValues recursively extracts all leaf values from a data structure
-func extractLeafValues(data any, values *[]string) {
+// extractLeafValues recursively extracts leaf values from a data structure,
+// until length, the length of the values joined, exceeds the length of a
+// summary
+func extractLeafValues(data any, values *[]string, length *int) {
+	if *length-len(" ") > maxSummaryLength {
+		return
+	}
+	add := func(value string) {
+		*values = append(*values, value)
+		*length += len(value) + len(" ")
+	}
 	switch v := data.(type) {
 	case map[string]any:
 		for _, value := range v {
-			extractLeafValues(value, values)
+			extractLeafValues(value, values, length)
 		}
 	case []any:
 		for _, item := range v {
-			extractLeafValues(item, values)
+			extractLeafValues(item, values, length)
 		}
 	case string:
 		if v != "" {
-			*values = append(*values, escapeString(v))
+			add(escapeString(v))
 		}
 	case float64:
-		*values = append(*values, strconv.FormatFloat(v, 'f', -1, 64))
+		add(strconv.FormatFloat(v, 'f', -1, 64))
 	case bool:
-		*values = append(*values, strconv.FormatBool(v))
+		add(strconv.FormatBool(v))
 	case nil:
 		// Skip null values
 	default:
 		str := fmt.Sprintf("%v", v)
 		if str != "" {
-			*values = append(*values, str)
+			add(str)
 		}
 	}
 }
@@ -137,7 +173,8 @@ func parseNodes(data any) []*node {
 		} else {
 			root.Value = fmt.Sprintf("array[%d]", len(v))
 		}
-		root.Children = parseArray(v, root, 1) // Start array children at depth 1
+		root.pending, root.pendingDepth = v, 1 // Start array children at depth 1
+		root.materialize()
 		return []*node{root}
 	default:
 		return []*node{{
@@ -160,44 +197,7 @@ func parseObject(obj map[string]any, parent *node, depth int) []*node {
 	sort.Strings(keys)
 
 	for _, key := range keys {
-		value := obj[key]
-		node := &node{
-			Key:      key,
-			Parent:   parent,
-			Expanded: depth <= defaultExpandedDepth,
-		}
-
-		switch v := value.(type) {
-		case map[string]any:
-			node.Value = generateObjectSummary(v)
-			node.IsLeaf = false
-			node.Children = parseObject(v, node, depth+1)
-		case []any:
-			if isArrayOfLeafNodes(v) {
-				node.Value = generateArraySummary(v)
-			} else {
-				node.Value = fmt.Sprintf("array[%d]", len(v))
-			}
-			node.IsLeaf = false
-			node.Children = parseArray(v, node, depth+1)
-		case string:
-			node.Value = fmt.Sprintf("\"%s\"", escapeString(v))
-			node.IsLeaf = true
-		case float64:
-			node.Value = strconv.FormatFloat(v, 'f', -1, 64)
-			node.IsLeaf = true
-		case bool:
-			node.Value = strconv.FormatBool(v)
-			node.IsLeaf = true
-		case nil:
-			node.Value = "null"
-			node.IsLeaf = true
-		default:
-			node.Value = fmt.Sprintf("%v", v)
-			node.IsLeaf = true
-		}
-
-		nodes = append(nodes, node)
+		nodes = append(nodes, newNode(key, obj[key], parent, depth))
 	}
 
 	return nodes
@@ -208,44 +208,52 @@ func parseArray(arr []any, parent *node, depth int) []*node {
 	nodes := make([]*node, 0, len(arr))
 
 	for i, value := range arr {
-		node := &node{
-			Key:      strconv.Itoa(i),
-			Parent:   parent,
-			Expanded: depth <= defaultExpandedDepth,
-		}
+		nodes = append(nodes, newNode(strconv.Itoa(i), value, parent, depth))
+	}
 
-		switch v := value.(type) {
-		case map[string]any:
-			node.Value = generateObjectSummary(v)
-			node.IsLeaf = false
-			node.Children = parseObject(v, node, depth+1)
-		case []any:
-			if isArrayOfLeafNodes(v) {
-				node.Value = generateArraySummary(v)
-			} else {
-				node.Value = fmt.Sprintf("array[%d]", len(v))
-			}
-			node.IsLeaf = false
-			node.Children = parseArray(v, node, depth+1)
-		case string:
-			node.Value = fmt.Sprintf("\"%s\"", escapeString(v))
-			node.IsLeaf = true
-		case float64:
-			node.Value = strconv.FormatFloat(v, 'f', -1, 64)
-			node.IsLeaf = true
-		case bool:
-			node.Value = strconv.FormatBool(v)
-			node.IsLeaf = true
-		case nil:
-			node.Value = "null"
-			node.IsLeaf = true
-		default:
-			node.Value = fmt.Sprintf("%v", v)
-			node.IsLeaf = true
-		}
+	return nodes
+}
 
-		nodes = append(nodes, node)
+// newNode converts a JSON value to a node, with its children
+func newNode(key string, value any, parent *node, depth int) *node {
+	node := &node{
+		Key:      key,
+		Parent:   parent,
+		Expanded: depth <= defaultExpandedDepth,
 	}
 
-	return nodes
+	switch v := value.(type) {
+	case map[string]any:
+		node.Value = generateObjectSummary(v)
+		node.IsLeaf = false
+		node.Children = parseObject(v, node, depth+1)
+	case []any:
+		if isArrayOfLeafNodes(v) {
+			node.Value = generateArraySummary(v)
+		} else {
+			node.Value = fmt.Sprintf("array[%d]", len(v))
+		}
+		node.IsLeaf = false
+		node.pending, node.pendingDepth = v, depth+1
+		if len(v) <= lazyArrayLength {
+			node.materialize()
+		}
+	case string:
+		node.Value = fmt.Sprintf("\"%s\"", escapeString(v))
+		node.IsLeaf = true
+	case float64:
+		node.Value = strconv.FormatFloat(v, 'f', -1, 64)
+		node.IsLeaf = true
+	case bool:
+		node.Value = strconv.FormatBool(v)
+		node.IsLeaf = true
+	case nil:
+		node.Value = "null"
+		node.IsLeaf = true
+	default:
+		node.Value = fmt.Sprintf("%v", v)
+		node.IsLeaf = true
+	}
+
+	return node
 }
@@ -851,3 +851,70 @@ func TestArrayLeafNodeSummaryIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestLazyArrays(t *testing.T) {
+	services := make([]any, 100)
+	for i := range services {
+		services[i] = map[string]any{"port": float64(i + 1), "protocol": "HTTP"}
+	}
+	services[64] = map[string]any{"port": float64(65), "protocol": "SSH"}
+	host := map[string]any{"host": map[string]any{"services": services, "ip": "10.0.0.1"}}
+	m := newTreeModel(host)
+
+	svc := m.nodes[0].Children[1]
+	require.Equal(t, "services", svc.Key)
+	assert.Equal(t, "array[100]", svc.Value)
+	assert.Nil(t, svc.Children, "large arrays are parsed once needed")
+
+	// searching parses only the arrays that hold a match
+	keys(m, "/", "nope", "enter")
+	assert.Nil(t, svc.Children)
+	keys(m, "/", "ssh", "enter")
+	require.Len(t, svc.Children, 100)
+	assert.Equal(t, `"SSH"`, selected(m).Value)
+	assert.Equal(t, "64", selected(m).Parent.Key)
+
+	small := newTreeModel(map[string]any{"host": map[string]any{"labels": []any{"a", "b"}}})
+	assert.Len(t, small.nodes[0].Children[0].Children, 2, "small arrays are parsed up front")
+
+	expand := newTreeModel(host)
+	keys(expand, "j", "j", "l")
+	assert.Len(t, expand.flatNodes, 103, "expanding an array parses it")
+	assert.Equal(t, 3, NodeCount(host))
+}
+
+func TestSummariesAreBounded(t *testing.T) {
+	values := make([]any, 10000)
+	for i := range values {
+		values[i] = "value"
+	}
+	summary := generateArraySummary(values)
+	assert.Len(t, summary, maxSummaryLength)
+	assert.True(t, strings.HasSuffix(summary, "..."))
+
+	exact := []any{strings.Repeat("a", maxSummaryLength)}
+	assert.Equal(t, exact[0], generateArraySummary(exact), "a summary of the maximum length is not cut")
+
+	summary = generateObjectSummary(map[string]any{"services": values})
+	assert.Len(t, summary, maxSummaryLength)
+}
+
+func BenchmarkParseNodes_LargeHost(b *testing.B) {
+	services := make([]any, 500)
+	for i := range services {
+		services[i] = map[string]any{
+			"port":     float64(i + 1),
+			"protocol": "HTTP",
+			"banner":   strings.Repeat("HTTP/1.1 200 OK\r\n", 8),
+			"software": []any{map[string]any{"vendor": "nginx", "product": "nginx"}},
+			"tls":      map[string]any{"version_selected": "TLSv1_3", "cipher_selected": "TLS_AES_128_GCM_SHA256"},
+		}
+	}
+	// view prints a list of hosts
+	hosts := []any{map[string]any{"ip": "10.0.0.1", "services": services}}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		newTreeModel(hosts)
+	}
+}
@@ -0,0 +1,137 @@
+package tree
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// updateSearch handles the keys typed while entering a search query.
+func (m *treeModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEnter:
+		m.searching = false
+		m.search()
+	case tea.KeyEsc:
+		m.searching = false
+	case tea.KeyBackspace:
+		if runes := []rune(m.query); len(runes) > 0 {
+			m.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// search finds the nodes whose key, label, or value contains the query,
+// ignoring case, including the nodes of collapsed subtrees, and moves the
+// cursor to the first match at or after it.
+func (m *treeModel) search() {
+	m.matches = nil
+	m.match = 0
+	query := strings.ToLower(m.query)
+	if query == "" {
+		return
+	}
+	var current *node
+	if m.cursor < len(m.flatNodes) {
+		current = m.flatNodes[m.cursor]
+	}
+	first := -1
+	var walk func(nodes []*node)
+	walk = func(nodes []*node) {
+		for _, n := range nodes {
+			if n == current && first < 0 {
+				first = len(m.matches)
+			}
+			if nodeMatches(n, query) {
+				m.matches = append(m.matches, n)
+			}
+			// the items of a large array are only parsed if one matches
+			if n.pending != nil && containsMatch(n.pending, query) {
+				n.materialize()
+			}
+			walk(n.Children)
+		}
+	}
+	walk(m.nodes)
+	if len(m.matches) == 0 {
+		m.statusMessage = fmt.Sprintf("no matches for %q", m.query)
+		return
+	}
+	if first >= 0 && first < len(m.matches) {
+		m.match = first
+	}
+	m.reveal(m.matches[m.match])
+}
+
+// nodeMatches reports whether the key, label, or value of n contains query.
+// Only leaves are matched by value, since the value of other nodes is a
+// summary of their children.
+func nodeMatches(n *node, query string) bool {
+	if strings.Contains(strings.ToLower(n.Key), query) || strings.Contains(strings.ToLower(n.Label), query) {
+		return true
+	}
+	return n.IsLeaf && strings.Contains(strings.ToLower(n.Value), query)
+}
+
+// containsMatch reports whether a key or value within the JSON value v
+// contains query, without parsing v into nodes.
+func containsMatch(v any, query string) bool {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if strings.Contains(strings.ToLower(key), query) || containsMatch(value, query) {
+				return true
+			}
+		}
+		return false
+	case []any:
+		for i, item := range v {
+			if strings.Contains(strconv.Itoa(i), query) || containsMatch(item, query) {
+				return true
+			}
+		}
+		return false
+	case string:
+		return strings.Contains(strings.ToLower(v), query)
+	case float64:
+		return strings.Contains(strconv.FormatFloat(v, 'f', -1, 64), query)
+	case nil:
+		return strings.Contains("null", query)
+	default:
+		return strings.Contains(strings.ToLower(fmt.Sprint(v)), query)
+	}
+}
+
+// nextMatch moves the cursor to the next match, or the previous one if step
+// is negative, wrapping around at the ends.
+func (m *treeModel) nextMatch(step int) {
+	if len(m.matches) == 0 {
+		if m.query != "" {
+			m.statusMessage = fmt.Sprintf("no matches for %q", m.query)
+		}
+		return
+	}
+	m.match = (m.match + step + len(m.matches)) % len(m.matches)
+	m.reveal(m.matches[m.match])
+}
+
+// reveal expands the ancestors of n and moves the cursor to it.
+func (m *treeModel) reveal(n *node) {
+	for parent := n.Parent; parent != nil; parent = parent.Parent {
+		parent.Expanded = true
+	}
+	m.updateFlatNodes()
+	for i, visible := range m.flatNodes {
+		if visible == n {
+			m.cursor = i
+			return
+		}
+	}
+}
@@ -2,6 +2,7 @@ package tree
 
 import (
 	"github.com/censys/cencli/internal/pkg/styles"
+	"github.com/censys/cencli/internal/pkg/term"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -34,13 +35,13 @@ func defaultStyles() Styles {
 		ObjectStyle: lipgloss.NewStyle().Foreground(styles.ColorGray),
 		ArrayStyle:  lipgloss.NewStyle().Foreground(styles.ColorGray),
 
-		SelectedStyle: lipgloss.NewStyle().Background(styles.ColorGold).Foreground(styles.ColorBlack),
+		SelectedStyle: styles.Highlight(styles.ColorBlack, styles.ColorGold),
 		HeaderStyle:   lipgloss.NewStyle().Foreground(styles.ColorGray).Bold(true),
 		HelpStyle:     lipgloss.NewStyle().Foreground(styles.ColorGray),
 		FooterStyle:   lipgloss.NewStyle().Foreground(styles.ColorGray),
 
-		ExpandedSymbol:  "▼ ",
-		CollapsedSymbol: "▶ ",
+		ExpandedSymbol:  term.Glyph("▼ ", "v "),
+		CollapsedSymbol: term.Glyph("▶ ", "> "),
 		LeafSymbol:      "  ",
 	}
 }
@@ -9,17 +9,62 @@ const (
 	defaultWidth  = 80
 )
 
-type options func(*treeModel)
+// Option configures the tree view.
+type Option func(*treeModel)
+
+// KeyAction is a key binding that loads more data for an item of the root
+// array, such as the full record an event refers to. The data is shown under
+// the item, as Name, once Load returns.
+type KeyAction struct {
+	Key         string
+	Description string
+	// Name is the key of the loaded data under the item.
+	Name string
+	// Load returns the data for the item at index in the root array. It runs
+	// outside of the view, which stays responsive while it loads.
+	Load func(index int) (any, error)
+}
+
+// WithKeyActions binds the given actions to the items of the root array.
+func WithKeyActions(actions []KeyAction) Option {
+	return func(m *treeModel) {
+		m.actions = actions
+	}
+}
+
+// WithItemLabels shows labels in place of the indexes of the items of the
+// root array, such as the time and type of events. Items without a label
+// keep their index.
+func WithItemLabels(labels []string) Option {
+	return func(m *treeModel) {
+		for i, item := range m.items {
+			if i < len(labels) {
+				item.Label = labels[i]
+			}
+		}
+	}
+}
 
 // Run creates a tree view for the given data and runs the interactive program
-func Run(data any, opts ...options) error {
+func Run(data any, opts ...Option) error {
+	m := newTreeModel(data, opts...)
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+func newTreeModel(data any, opts ...Option) *treeModel {
 	nodes := parseNodes(data)
 	m := &treeModel{
-		nodes:  nodes,
-		cursor: 0,
-		height: defaultHeight,
-		width:  defaultWidth,
-		styles: defaultStyles(),
+		nodes:   nodes,
+		cursor:  0,
+		height:  defaultHeight,
+		width:   defaultWidth,
+		styles:  defaultStyles(),
+		loading: map[*node]bool{},
+	}
+	// the items of a root array are the targets of key actions
+	if _, ok := data.([]any); ok && len(nodes) == 1 {
+		m.items = nodes[0].Children
 	}
 
 	for _, opt := range opts {
@@ -27,7 +72,11 @@ func Run(data any, opts ...options) error {
 	}
 
 	m.updateFlatNodes()
+	return m
+}
 
-	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
-	return err
+// NodeCount builds the view of data without running it, and returns the
+// number of rows it shows when opened. It measures the parser in benchmarks.
+func NodeCount(data any) int {
+	return len(newTreeModel(data).flatNodes)
 }
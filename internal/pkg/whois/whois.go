@@ -0,0 +1,212 @@
+// Package whois summarizes the registration of an IP or a domain: its
+// autonomous system, network, organization, dates, and abuse contacts.
+package whois
+
+import (
+	"strings"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/rdap"
+)
+
+// Target types.
+const (
+	TypeIP     = "ip"
+	TypeDomain = "domain"
+)
+
+// Sources of a summary.
+const (
+	SourceCensys = "censys"
+	SourceRDAP   = "rdap"
+)
+
+// Summary is the registration of an IP or a domain.
+type Summary struct {
+	Query string `json:"query"`
+	Type  string `json:"type"`
+	// Sources are where the summary came from: the Censys host document,
+	// RDAP, or both.
+	Sources []string `json:"sources"`
+
+	ASN       *int   `json:"asn,omitempty"`
+	ASName    string `json:"as_name,omitempty"`
+	ASCountry string `json:"as_country,omitempty"`
+	BGPPrefix string `json:"bgp_prefix,omitempty"`
+
+	Network      *Network      `json:"network,omitempty"`
+	Organization *Organization `json:"organization,omitempty"`
+
+	Registrar   string   `json:"registrar,omitempty"`
+	Nameservers []string `json:"nameservers,omitempty"`
+	Status      []string `json:"status,omitempty"`
+
+	Registered string `json:"registered,omitempty"`
+	Updated    string `json:"updated,omitempty"`
+	Expires    string `json:"expires,omitempty"`
+
+	AbuseContacts []Contact `json:"abuse_contacts,omitempty"`
+}
+
+// Network is the registered network an IP belongs to.
+type Network struct {
+	Name           string   `json:"name,omitempty"`
+	Handle         string   `json:"handle,omitempty"`
+	CIDRs          []string `json:"cidrs,omitempty"`
+	AllocationType string   `json:"allocation_type,omitempty"`
+}
+
+// Organization is the holder of a network or the registrant of a domain.
+type Organization struct {
+	Name    string `json:"name,omitempty"`
+	Handle  string `json:"handle,omitempty"`
+	Country string `json:"country,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+// Contact is a point of contact, such as where to report abuse.
+type Contact struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// New returns an empty summary of query.
+func New(query, targetType string) *Summary {
+	return &Summary{Query: query, Type: targetType, Sources: []string{}}
+}
+
+// HasRegistration reports whether the summary has the registration of the
+// network or domain, beyond its routing.
+func (s *Summary) HasRegistration() bool {
+	return s.Network != nil || s.Organization != nil || s.Registrar != ""
+}
+
+// AddHost fills the summary from a Censys host document: its autonomous
+// system and, if it has one, its whois record.
+func (s *Summary) AddHost(host *assets.Host) {
+	added := false
+	if as := host.AutonomousSystem; as != nil {
+		s.ASN = as.Asn
+		s.ASName = deref(as.Name)
+		s.ASCountry = deref(as.CountryCode)
+		s.BGPPrefix = deref(as.BgpPrefix)
+		added = true
+	}
+	if w := host.Whois; w != nil {
+		if n := w.Network; n != nil {
+			s.Network = &Network{
+				Name:           deref(n.Name),
+				Handle:         deref(n.Handle),
+				CIDRs:          n.Cidrs,
+				AllocationType: deref(n.AllocationType),
+			}
+			s.Registered = deref(n.Created)
+			s.Updated = deref(n.Updated)
+		}
+		if o := w.Organization; o != nil {
+			s.Organization = &Organization{
+				Name:    deref(o.Name),
+				Handle:  deref(o.Handle),
+				Country: deref(o.Country),
+				Address: hostAddress(o),
+			}
+			for _, c := range o.AbuseContacts {
+				s.AbuseContacts = append(s.AbuseContacts, Contact{Name: deref(c.Name), Email: deref(c.Email)})
+			}
+		}
+		added = added || w.Network != nil || w.Organization != nil
+	}
+	if added {
+		s.addSource(SourceCensys)
+	}
+}
+
+// AddRDAP fills the parts of the summary that are still missing from an RDAP
+// IP network or domain record.
+func (s *Summary) AddRDAP(rec *rdap.Record) {
+	s.addSource(SourceRDAP)
+	if s.Type == TypeDomain {
+		if len(s.Nameservers) == 0 {
+			for _, ns := range rec.Nameservers {
+				s.Nameservers = append(s.Nameservers, strings.ToLower(ns.LDHName))
+			}
+		}
+		if len(s.Status) == 0 {
+			s.Status = rec.Status
+		}
+		if s.Registrar == "" {
+			if registrars := rec.EntitiesWithRole(rdap.RoleRegistrar); len(registrars) > 0 {
+				s.Registrar = registrars[0].VCard().Name
+			}
+		}
+	} else if s.Network == nil {
+		n := &Network{Name: rec.Name, Handle: rec.Handle, AllocationType: rec.Type}
+		for _, c := range rec.CIDRs {
+			if cidr := c.String(); cidr != "" {
+				n.CIDRs = append(n.CIDRs, cidr)
+			}
+		}
+		if len(n.CIDRs) == 0 && rec.StartAddress != "" {
+			n.CIDRs = []string{rec.StartAddress + " - " + rec.EndAddress}
+		}
+		s.Network = n
+	}
+	if s.Organization == nil {
+		if registrants := rec.EntitiesWithRole(rdap.RoleRegistrant); len(registrants) > 0 {
+			card := registrants[0].VCard()
+			s.Organization = &Organization{Name: card.Name, Handle: registrants[0].Handle, Country: rec.Country, Address: card.Address}
+		}
+	}
+	setOnce(&s.Registered, rec.Event(rdap.EventRegistration))
+	setOnce(&s.Updated, rec.Event(rdap.EventLastChanged))
+	setOnce(&s.Expires, rec.Event(rdap.EventExpiration))
+	if len(s.AbuseContacts) == 0 {
+		for _, e := range rec.EntitiesWithRole(rdap.RoleAbuse) {
+			card := e.VCard()
+			if card.Name == "" && card.Email == "" && card.Phone == "" {
+				continue
+			}
+			s.AbuseContacts = append(s.AbuseContacts, Contact{Name: card.Name, Email: card.Email, Phone: card.Phone})
+		}
+	}
+}
+
+func (s *Summary) addSource(source string) {
+	for _, existing := range s.Sources {
+		if existing == source {
+			return
+		}
+	}
+	s.Sources = append(s.Sources, source)
+}
+
+// hostAddress joins the parts of the address of an organization.
+func hostAddress(o *components.Organization) string {
+	if o.Address != nil && *o.Address != "" {
+		return *o.Address
+	}
+	var parts []string
+	for _, p := range []*string{o.Street, o.City, o.State, o.PostalCode} {
+		if v := strings.TrimSpace(deref(p)); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func setOnce(dst *string, v string) {
+	if *dst == "" {
+		*dst = v
+	}
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
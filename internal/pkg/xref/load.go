@@ -0,0 +1,163 @@
+package xref
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultRefresh is how long a feed fetched from a URL is used before it is
+// fetched again.
+const DefaultRefresh = 24 * time.Hour
+
+// maxFeedSize bounds the size of a feed fetched from a URL.
+const maxFeedSize = 64 << 20
+
+// Source is a feed to load: a file, or an http(s) URL.
+type Source struct {
+	// Name is the name of the feed in matches. Empty uses the base name of
+	// the file or URL.
+	Name string
+	// Location is the path or URL of the feed.
+	Location string
+}
+
+// IsURL reports whether the source is fetched over HTTP.
+func (s Source) IsURL() bool {
+	lower := strings.ToLower(s.Location)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+func (s Source) name() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	if s.IsURL() {
+		if u, err := url.Parse(s.Location); err == nil {
+			if base := path.Base(u.Path); base != "" && base != "." && base != "/" {
+				return base
+			}
+			return u.Host
+		}
+	}
+	return filepath.Base(s.Location)
+}
+
+// Loader loads feeds. Feeds fetched from URLs are kept in CacheDir, and are
+// fetched again once they are older than Refresh.
+type Loader struct {
+	// CacheDir is where fetched feeds are kept. Empty fetches them every time.
+	CacheDir string
+	// Refresh is how long a fetched feed is used. Zero uses DefaultRefresh.
+	Refresh time.Duration
+	// Client fetches feeds. Nil uses http.DefaultClient.
+	Client *http.Client
+	// Now returns the current time. Nil uses time.Now.
+	Now func() time.Time
+}
+
+// Load loads the feed of src. When a URL cannot be fetched but an earlier
+// copy is cached, that copy is used and the feed is marked Stale.
+func (l Loader) Load(ctx context.Context, src Source) (*Feed, error) {
+	if !src.IsURL() {
+		f, err := os.Open(src.Location)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return Parse(src.name(), f)
+	}
+
+	cachePath := l.cachePath(src.Location)
+	if cachePath != "" {
+		if info, err := os.Stat(cachePath); err == nil && l.now().Sub(info.ModTime()) < l.refresh() {
+			if feed, err := parseFile(src.name(), cachePath); err == nil {
+				feed.Cached = true
+				return feed, nil
+			}
+		}
+	}
+	data, fetchErr := l.fetch(ctx, src.Location)
+	if fetchErr != nil {
+		if cachePath != "" {
+			if feed, err := parseFile(src.name(), cachePath); err == nil {
+				feed.Stale = true
+				return feed, nil
+			}
+		}
+		return nil, fetchErr
+	}
+	feed, err := Parse(src.name(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if cachePath != "" {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0o700); err == nil {
+			// the cache is an optimization: a failed write only costs a fetch
+			_ = os.WriteFile(cachePath, data, 0o600)
+		}
+	}
+	return feed, nil
+}
+
+func (l Loader) fetch(ctx context.Context, location string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := l.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxFeedSize))
+}
+
+// cachePath returns the path a feed fetched from location is cached at.
+func (l Loader) cachePath(location string) string {
+	if l.CacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(location))
+	return filepath.Join(l.CacheDir, "xref", hex.EncodeToString(sum[:8])+".txt")
+}
+
+func (l Loader) refresh() time.Duration {
+	if l.Refresh > 0 {
+		return l.Refresh
+	}
+	return DefaultRefresh
+}
+
+func (l Loader) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now()
+}
+
+func parseFile(name, p string) (*Feed, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(name, f)
+}
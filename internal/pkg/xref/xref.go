@@ -0,0 +1,317 @@
+// Package xref cross-references assets against indicator lists, such as
+// threat feeds of known C2 infrastructure.
+package xref
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/censys/censys-sdk-go/models/components"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/refang"
+)
+
+// Key is the field that matches are attached under in data output.
+const Key = "xref"
+
+// Feed is a named list of indicators: IPs, CIDR ranges, domains, and other
+// values such as certificate, JARM, or JA4 fingerprints.
+type Feed struct {
+	Name string
+	// Stale is set when a feed fetched from a URL could not be refreshed,
+	// and the last fetched copy was used instead.
+	Stale bool
+	// Cached is set when a feed fetched from a URL was loaded from the
+	// cache, without fetching it.
+	Cached  bool
+	ips     map[string]string
+	nets    []network
+	domains map[string]string
+	values  map[string]string
+}
+
+type network struct {
+	ipNet     *net.IPNet
+	indicator string
+}
+
+// Parse reads a feed, one indicator per line. Blank lines and lines starting
+// with # are ignored, as is anything after the first whitespace, comma, or
+// semicolon, so that lines can be annotated and CSV feeds can be used as is.
+// Defanged indicators, such as 203.0.113[.]7 or hxxp://evil[.]example, are
+// refanged, and URLs are reduced to their host.
+func Parse(name string, r io.Reader) (*Feed, error) {
+	f := &Feed{
+		Name:    name,
+		ips:     make(map[string]string),
+		domains: make(map[string]string),
+		values:  make(map[string]string),
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		indicator := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ';' || r == ' ' || r == '\t'
+		})[0]
+		f.add(strings.Trim(indicator, `"'`))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *Feed) add(raw string) {
+	value := strings.ToLower(refang.RefangIP(raw))
+	if strings.Contains(value, "://") {
+		value = strings.Replace(value, "hxxp", "http", 1)
+		if u, err := url.Parse(value); err == nil && u.Hostname() != "" {
+			value = u.Hostname()
+		}
+	}
+	if _, ipNet, err := net.ParseCIDR(value); err == nil {
+		f.nets = append(f.nets, network{ipNet: ipNet, indicator: raw})
+		return
+	}
+	if ip := net.ParseIP(strings.Trim(value, "[]")); ip != nil {
+		f.ips[ip.String()] = raw
+		return
+	}
+	if isDomain(value) {
+		f.domains[strings.TrimSuffix(strings.TrimPrefix(value, "*."), ".")] = raw
+		return
+	}
+	f.values[value] = raw
+}
+
+// Len returns the number of indicators in the feed.
+func (f *Feed) Len() int {
+	return len(f.ips) + len(f.nets) + len(f.domains) + len(f.values)
+}
+
+// isDomain reports whether s looks like a domain name rather than an opaque
+// value such as a fingerprint.
+func isDomain(s string) bool {
+	if !strings.Contains(strings.Trim(s, "."), ".") {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_' || r == '*') {
+			return false
+		}
+	}
+	return true
+}
+
+// Match is an indicator of a feed found in an asset.
+type Match struct {
+	// Feed is the name of the feed.
+	Feed string `json:"feed"`
+	// Indicator is the indicator as written in the feed.
+	Indicator string `json:"indicator"`
+	// Field is where the indicator was found, such as host.ip or
+	// host.services.jarm.fingerprint.
+	Field string `json:"field"`
+	// Value is the value of the field that matched.
+	Value string `json:"value"`
+	// Port is the port of the service the indicator was found in, if any.
+	Port int `json:"port,omitempty"`
+}
+
+// Matcher matches assets against a set of feeds.
+type Matcher struct {
+	feeds []*Feed
+}
+
+// NewMatcher returns a Matcher for the given feeds.
+func NewMatcher(feeds ...*Feed) *Matcher {
+	return &Matcher{feeds: feeds}
+}
+
+// Feeds returns the feeds of the matcher.
+func (m *Matcher) Feeds() []*Feed {
+	return m.feeds
+}
+
+// Match returns the indicators found in asset, in feed order. Assets that are
+// not hosts, certificates, or web properties never match.
+func (m *Matcher) Match(asset assets.Asset) []Match {
+	if m == nil || len(m.feeds) == 0 {
+		return nil
+	}
+	obs := observe(asset)
+	var matches []Match
+	seen := make(map[Match]bool)
+	for _, feed := range m.feeds {
+		for _, o := range obs {
+			indicator, ok := feed.lookup(o)
+			if !ok {
+				continue
+			}
+			match := Match{Feed: feed.Name, Indicator: indicator, Field: o.field, Value: o.value, Port: o.port}
+			if !seen[match] {
+				seen[match] = true
+				matches = append(matches, match)
+			}
+		}
+	}
+	return matches
+}
+
+// FeedNames returns the distinct feed names of matches, sorted.
+func FeedNames(matches []Match) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, m := range matches {
+		if !seen[m.Feed] {
+			seen[m.Feed] = true
+			names = append(names, m.Feed)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+type observableKind int
+
+const (
+	kindIP observableKind = iota
+	kindName
+	kindValue
+)
+
+// observable is a value of an asset that indicators are matched against.
+type observable struct {
+	kind  observableKind
+	field string
+	value string
+	port  int
+}
+
+func (f *Feed) lookup(o observable) (string, bool) {
+	switch o.kind {
+	case kindIP:
+		ip := net.ParseIP(o.value)
+		if ip == nil {
+			return "", false
+		}
+		if indicator, ok := f.ips[ip.String()]; ok {
+			return indicator, true
+		}
+		for _, n := range f.nets {
+			if n.ipNet.Contains(ip) {
+				return n.indicator, true
+			}
+		}
+	case kindName:
+		name := strings.TrimSuffix(strings.TrimPrefix(strings.ToLower(o.value), "*."), ".")
+		for {
+			if indicator, ok := f.domains[name]; ok {
+				return indicator, true
+			}
+			dot := strings.IndexByte(name, '.')
+			if dot < 0 {
+				break
+			}
+			name = name[dot+1:]
+		}
+	case kindValue:
+		indicator, ok := f.values[strings.ToLower(o.value)]
+		return indicator, ok
+	}
+	return "", false
+}
+
+// observe returns the values of asset that indicators are matched against.
+func observe(asset assets.Asset) []observable {
+	var obs observables
+	switch a := asset.(type) {
+	case *assets.Host:
+		obs.add(kindIP, "host.ip", deref(a.IP), 0)
+		if dns := a.DNS; dns != nil {
+			obs.addAll(kindName, "host.dns.names", dns.Names, 0)
+			if dns.ReverseDNS != nil {
+				obs.addAll(kindName, "host.dns.reverse_dns.names", dns.ReverseDNS.Names, 0)
+			}
+		}
+		for _, svc := range a.Services {
+			port := deref(svc.Port)
+			obs.addCert("host.services.cert", svc.Cert, port)
+			obs.addTLS("host.services.tls", svc.TLS, port)
+			if svc.Jarm != nil {
+				obs.add(kindValue, "host.services.jarm.fingerprint", deref(svc.Jarm.Fingerprint), port)
+			}
+			if svc.Ja4tscan != nil {
+				obs.add(kindValue, "host.services.ja4tscan.fingerprint", deref(svc.Ja4tscan.Fingerprint), port)
+			}
+			obs.add(kindValue, "host.services.banner_hash_sha256", deref(svc.BannerHashSha256), port)
+		}
+	case *assets.Certificate:
+		cert := a.Certificate
+		obs.addCert("cert", &cert, 0)
+	case *assets.WebProperty:
+		port := deref(a.Port)
+		hostname := deref(a.Hostname)
+		if net.ParseIP(strings.Trim(hostname, "[]")) != nil {
+			obs.add(kindIP, "web.hostname", strings.Trim(hostname, "[]"), port)
+		} else {
+			obs.add(kindName, "web.hostname", hostname, port)
+		}
+		obs.addCert("web.cert", a.Cert, port)
+		obs.addTLS("web.tls", a.TLS, port)
+		if a.Jarm != nil {
+			obs.add(kindValue, "web.jarm.fingerprint", deref(a.Jarm.Fingerprint), port)
+		}
+	}
+	return obs
+}
+
+type observables []observable
+
+func (o *observables) add(kind observableKind, field, value string, port int) {
+	if value != "" {
+		*o = append(*o, observable{kind: kind, field: field, value: value, port: port})
+	}
+}
+
+func (o *observables) addAll(kind observableKind, field string, values []string, port int) {
+	for _, v := range values {
+		o.add(kind, field, v, port)
+	}
+}
+
+func (o *observables) addCert(prefix string, cert *components.Certificate, port int) {
+	if cert == nil {
+		return
+	}
+	o.add(kindValue, prefix+".fingerprint_sha256", deref(cert.FingerprintSha256), port)
+	o.add(kindValue, prefix+".fingerprint_sha1", deref(cert.FingerprintSha1), port)
+	o.add(kindValue, prefix+".fingerprint_md5", deref(cert.FingerprintMd5), port)
+	o.addAll(kindName, prefix+".names", cert.Names, port)
+}
+
+func (o *observables) addTLS(prefix string, tls *components.TLS, port int) {
+	if tls == nil {
+		return
+	}
+	o.add(kindValue, prefix+".fingerprint_sha256", deref(tls.FingerprintSha256), port)
+	o.add(kindValue, prefix+".ja3s", deref(tls.Ja3s), port)
+	o.add(kindValue, prefix+".ja4s", deref(tls.Ja4s), port)
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,176 @@
+package xref
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+const testFeed = `# known C2 infrastructure
+203.0.113[.]7
+198.51.100.0/24, scanner range
+hxxps://evil[.]example/gate.php
+*.bad.test
+2001:db8::1
+T13D1516H2_8DAAF6152771_B186095E22B6 ; ja4s
+
+`
+
+func parseTest(t *testing.T) *Feed {
+	t.Helper()
+	feed, err := Parse("c2", strings.NewReader(testFeed))
+	require.NoError(t, err)
+	return feed
+}
+
+func TestParse(t *testing.T) {
+	feed := parseTest(t)
+	assert.Equal(t, 6, feed.Len())
+	assert.Contains(t, feed.ips, "203.0.113.7")
+	assert.Contains(t, feed.ips, "2001:db8::1")
+	assert.Len(t, feed.nets, 1)
+	assert.Contains(t, feed.domains, "evil.example")
+	assert.Contains(t, feed.domains, "bad.test")
+	assert.Contains(t, feed.values, "t13d1516h2_8daaf6152771_b186095e22b6")
+}
+
+func TestMatcher_Match(t *testing.T) {
+	m := NewMatcher(parseTest(t))
+	tests := []struct {
+		name  string
+		asset assets.Asset
+		want  []Match
+	}{
+		{
+			name:  "host IP",
+			asset: &assets.Host{Host: components.Host{IP: ptr("203.0.113.7")}},
+			want:  []Match{{Feed: "c2", Indicator: "203.0.113[.]7", Field: "host.ip", Value: "203.0.113.7"}},
+		},
+		{
+			name:  "host IP in a range",
+			asset: &assets.Host{Host: components.Host{IP: ptr("198.51.100.200")}},
+			want:  []Match{{Feed: "c2", Indicator: "198.51.100.0/24", Field: "host.ip", Value: "198.51.100.200"}},
+		},
+		{
+			name:  "IPv4-mapped host IP",
+			asset: &assets.Host{Host: components.Host{IP: ptr("::ffff:203.0.113.7")}},
+			want:  []Match{{Feed: "c2", Indicator: "203.0.113[.]7", Field: "host.ip", Value: "::ffff:203.0.113.7"}},
+		},
+		{
+			name:  "IPv4-mapped host IP in a range",
+			asset: &assets.Host{Host: components.Host{IP: ptr("::ffff:198.51.100.200")}},
+			want:  []Match{{Feed: "c2", Indicator: "198.51.100.0/24", Field: "host.ip", Value: "::ffff:198.51.100.200"}},
+		},
+		{
+			name:  "IPv6 host IP written differently",
+			asset: &assets.Host{Host: components.Host{IP: ptr("2001:DB8:0::0001")}},
+			want:  []Match{{Feed: "c2", Indicator: "2001:db8::1", Field: "host.ip", Value: "2001:DB8:0::0001"}},
+		},
+		{
+			name: "service certificate name and JA4S",
+			asset: &assets.Host{Host: components.Host{
+				IP: ptr("192.0.2.1"),
+				Services: []components.Service{{
+					Port: ptr(8443),
+					Cert: &components.Certificate{Names: []string{"login.evil.example", "example.com"}},
+					TLS:  &components.TLS{Ja4s: ptr("t13d1516h2_8daaf6152771_b186095e22b6")},
+				}},
+			}},
+			want: []Match{
+				{Feed: "c2", Indicator: "hxxps://evil[.]example/gate.php", Field: "host.services.cert.names", Value: "login.evil.example", Port: 8443},
+				{Feed: "c2", Indicator: "T13D1516H2_8DAAF6152771_B186095E22B6", Field: "host.services.tls.ja4s", Value: "t13d1516h2_8daaf6152771_b186095e22b6", Port: 8443},
+			},
+		},
+		{
+			name:  "certificate name under a wildcard domain",
+			asset: &assets.Certificate{Certificate: components.Certificate{Names: []string{"*.cdn.bad.test"}}},
+			want:  []Match{{Feed: "c2", Indicator: "*.bad.test", Field: "cert.names", Value: "*.cdn.bad.test"}},
+		},
+		{
+			name:  "web property with an IP hostname",
+			asset: &assets.WebProperty{Webproperty: components.Webproperty{Hostname: ptr("[2001:db8::1]"), Port: ptr(443)}},
+			want:  []Match{{Feed: "c2", Indicator: "2001:db8::1", Field: "web.hostname", Value: "2001:db8::1", Port: 443}},
+		},
+		{
+			name:  "domain does not match a longer label",
+			asset: &assets.WebProperty{Webproperty: components.Webproperty{Hostname: ptr("notevil.example"), Port: ptr(443)}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, m.Match(tc.asset))
+		})
+	}
+
+	var nilMatcher *Matcher
+	assert.Nil(t, nilMatcher.Match(&assets.Host{Host: components.Host{IP: ptr("203.0.113.7")}}))
+}
+
+func TestLoader_Load(t *testing.T) {
+	ctx := context.Background()
+	var requests atomic.Int32
+	fail := atomic.Bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	loader := Loader{CacheDir: t.TempDir(), Refresh: time.Hour, Client: srv.Client(), Now: func() time.Time { return now }}
+	src := Source{Location: srv.URL + "/lists/c2-ips.txt"}
+
+	feed, err := loader.Load(ctx, src)
+	require.NoError(t, err)
+	assert.Equal(t, "c2-ips.txt", feed.Name)
+	assert.Equal(t, 6, feed.Len())
+	assert.False(t, feed.Cached)
+	assert.EqualValues(t, 1, requests.Load())
+
+	// cached until the refresh interval has passed
+	feed, err = loader.Load(ctx, src)
+	require.NoError(t, err)
+	assert.True(t, feed.Cached)
+	assert.EqualValues(t, 1, requests.Load())
+
+	now = now.Add(2 * time.Hour)
+	feed, err = loader.Load(ctx, src)
+	require.NoError(t, err)
+	assert.False(t, feed.Cached)
+	assert.EqualValues(t, 2, requests.Load())
+
+	// a failed refresh falls back to the cached copy
+	now = now.Add(2 * time.Hour)
+	fail.Store(true)
+	feed, err = loader.Load(ctx, src)
+	require.NoError(t, err)
+	assert.True(t, feed.Stale)
+
+	_, err = Loader{Client: srv.Client()}.Load(ctx, src)
+	require.ErrorContains(t, err, "502")
+
+	path := filepath.Join(t.TempDir(), "feed.txt")
+	require.NoError(t, os.WriteFile(path, []byte("10.0.0.1\n"), 0o600))
+	feed, err = loader.Load(ctx, Source{Name: "local", Location: path})
+	require.NoError(t, err)
+	assert.Equal(t, "local", feed.Name)
+	assert.Equal(t, 1, feed.Len())
+}
@@ -0,0 +1,31 @@
+-- name: InsertNote :one
+INSERT INTO
+    notes (asset, asset_type, text, author, session, created_at)
+VALUES
+    (?, ?, ?, ?, ?, ?)
+RETURNING
+    *;
+
+-- name: ListNotes :many
+SELECT
+    *
+FROM
+    notes
+ORDER BY
+    id ASC;
+
+-- name: GetNotesByAsset :many
+SELECT
+    *
+FROM
+    notes
+WHERE
+    asset = ?
+ORDER BY
+    id ASC;
+
+-- name: DeleteNote :execrows
+DELETE FROM
+    notes
+WHERE
+    id = ?;
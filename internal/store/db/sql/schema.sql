@@ -15,3 +15,60 @@ CREATE TABLE IF NOT EXISTS auths (
   created_at TEXT NOT NULL,
   last_used_at TEXT NOT NULL
 );
+
+CREATE TABLE IF NOT EXISTS watch_seen (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  watch TEXT NOT NULL,
+  value TEXT NOT NULL,
+  first_seen_at TEXT NOT NULL,
+  UNIQUE (watch, value)
+);
+
+CREATE TABLE IF NOT EXISTS sessions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name TEXT NOT NULL UNIQUE,
+  started_at TEXT NOT NULL,
+  ended_at TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS session_entries (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  session_id INTEGER NOT NULL,
+  kind TEXT NOT NULL,
+  command TEXT NOT NULL,
+  query TEXT NOT NULL,
+  note TEXT NOT NULL,
+  digest TEXT NOT NULL,
+  response TEXT NOT NULL,
+  created_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS usage_events (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  command TEXT NOT NULL,
+  query TEXT NOT NULL,
+  started_at TEXT NOT NULL,
+  duration_ms INTEGER NOT NULL,
+  failed INTEGER NOT NULL,
+  requests INTEGER NOT NULL,
+  request_errors INTEGER NOT NULL,
+  search_requests INTEGER NOT NULL,
+  search_latency_ms INTEGER NOT NULL,
+  pages_fetched INTEGER NOT NULL,
+  cache_hits INTEGER NOT NULL,
+  cache_misses INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS usage_events_started_at ON usage_events (started_at);
+
+CREATE TABLE IF NOT EXISTS notes (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  asset TEXT NOT NULL,
+  asset_type TEXT NOT NULL,
+  text TEXT NOT NULL,
+  author TEXT NOT NULL,
+  session TEXT NOT NULL,
+  created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS notes_asset ON notes (asset);
@@ -0,0 +1,74 @@
+-- name: InsertSession :one
+INSERT INTO
+    sessions (name, started_at, ended_at)
+VALUES
+    (?, ?, ?)
+RETURNING
+    *;
+
+-- name: GetSessionByName :one
+SELECT
+    *
+FROM
+    sessions
+WHERE
+    name = ?;
+
+-- name: GetActiveSession :one
+SELECT
+    *
+FROM
+    sessions
+WHERE
+    ended_at = ''
+ORDER BY
+    id DESC
+LIMIT 1;
+
+-- name: ListSessions :many
+SELECT
+    *
+FROM
+    sessions
+ORDER BY
+    id ASC;
+
+-- name: EndActiveSessions :many
+UPDATE
+    sessions
+SET
+    ended_at = ?
+WHERE
+    ended_at = ''
+RETURNING
+    *;
+
+-- name: DeleteSession :execrows
+DELETE FROM
+    sessions
+WHERE
+    id = ?;
+
+-- name: InsertSessionEntry :one
+INSERT INTO
+    session_entries (session_id, kind, command, query, note, digest, response, created_at)
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING
+    *;
+
+-- name: GetSessionEntriesBySession :many
+SELECT
+    *
+FROM
+    session_entries
+WHERE
+    session_id = ?
+ORDER BY
+    id ASC;
+
+-- name: DeleteSessionEntriesBySession :execrows
+DELETE FROM
+    session_entries
+WHERE
+    session_id = ?;
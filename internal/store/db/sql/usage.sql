@@ -0,0 +1,38 @@
+-- name: InsertUsageEvent :exec
+INSERT INTO
+    usage_events (
+        command,
+        query,
+        started_at,
+        duration_ms,
+        failed,
+        requests,
+        request_errors,
+        search_requests,
+        search_latency_ms,
+        pages_fetched,
+        cache_hits,
+        cache_misses
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetUsageEventsSince :many
+SELECT
+    *
+FROM
+    usage_events
+WHERE
+    started_at >= ?
+ORDER BY
+    id ASC;
+
+-- name: DeleteUsageEventsBefore :execrows
+DELETE FROM
+    usage_events
+WHERE
+    started_at < ?;
+
+-- name: DeleteUsageEvents :execrows
+DELETE FROM
+    usage_events;
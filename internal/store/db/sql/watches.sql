@@ -0,0 +1,22 @@
+-- name: InsertWatchSeen :execrows
+INSERT INTO
+    watch_seen (watch, value, first_seen_at)
+VALUES
+    (?, ?, ?)
+ON CONFLICT (watch, value) DO NOTHING;
+
+-- name: GetWatchSeenByWatch :many
+SELECT
+    *
+FROM
+    watch_seen
+WHERE
+    watch = ?
+ORDER BY
+    id ASC;
+
+-- name: DeleteWatchSeenByWatch :execrows
+DELETE FROM
+    watch_seen
+WHERE
+    watch = ?;
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/censys/cencli/gen/db"
+)
+
+// NotesStore keeps free-form notes about assets, such as what an analyst
+// observed about a host. Notes are keyed by the canonical ID of their asset.
+type NotesStore interface {
+	// AddNote records a note and returns it with its ID and creation time.
+	AddNote(ctx context.Context, note *Note) (*Note, error)
+	// ListNotes returns the notes that match filter, oldest first.
+	ListNotes(ctx context.Context, filter NotesFilter) ([]*Note, error)
+	// DeleteNote deletes the note with the given ID.
+	DeleteNote(ctx context.Context, id int64) error
+}
+
+var (
+	ErrNoteNotFound  = errors.New("note not found")
+	errNoteTextEmpty = errors.New("note must not be empty")
+)
+
+type Note struct {
+	ID int64
+	// Asset is the canonical ID of the asset the note is about.
+	Asset     string
+	AssetType string
+	Text      string
+	Author    string
+	// Session is the name of the session that was active when the note was
+	// added, or empty if none was.
+	Session   string
+	CreatedAt time.Time
+}
+
+// NotesFilter selects notes. Its zero value selects all notes.
+type NotesFilter struct {
+	// Asset selects the notes about the asset with this canonical ID.
+	Asset string
+	// Search selects the notes whose text or author contains it, ignoring case.
+	Search string
+	// Session selects the notes added during the named session.
+	Session string
+}
+
+func (f NotesFilter) matches(note *Note) bool {
+	if f.Session != "" && note.Session != f.Session {
+		return false
+	}
+	if f.Search != "" {
+		search := strings.ToLower(f.Search)
+		if !strings.Contains(strings.ToLower(note.Text), search) && !strings.Contains(strings.ToLower(note.Author), search) {
+			return false
+		}
+	}
+	return true
+}
+
+type notesStore struct {
+	*dataStore
+}
+
+var _ NotesStore = &notesStore{}
+
+func newNotesStore(ds *dataStore) (*notesStore, error) {
+	return &notesStore{
+		dataStore: ds,
+	}, nil
+}
+
+func (s *notesStore) AddNote(ctx context.Context, note *Note) (*Note, error) {
+	if strings.TrimSpace(note.Text) == "" {
+		return nil, errNoteTextEmpty
+	}
+	q := db.New(s.db)
+	row, err := q.InsertNote(ctx, db.InsertNoteParams{
+		Asset:     note.Asset,
+		AssetType: note.AssetType,
+		Text:      note.Text,
+		Author:    note.Author,
+		Session:   note.Session,
+		CreatedAt: toZulu(time.Now()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add note: %w", err)
+	}
+	return noteFromDb(&row), nil
+}
+
+func (s *notesStore) ListNotes(ctx context.Context, filter NotesFilter) ([]*Note, error) {
+	q := db.New(s.db)
+	var rows []db.Note
+	var err error
+	if filter.Asset != "" {
+		rows, err = q.GetNotesByAsset(ctx, filter.Asset)
+	} else {
+		rows, err = q.ListNotes(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+	notes := make([]*Note, 0, len(rows))
+	for i := range rows {
+		if note := noteFromDb(&rows[i]); filter.matches(note) {
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+func (s *notesStore) DeleteNote(ctx context.Context, id int64) error {
+	q := db.New(s.db)
+	n, err := q.DeleteNote(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+	if n == 0 {
+		return ErrNoteNotFound
+	}
+	return nil
+}
+
+func noteFromDb(row *db.Note) *Note {
+	return &Note{
+		ID:        row.ID,
+		Asset:     row.Asset,
+		AssetType: row.AssetType,
+		Text:      row.Text,
+		Author:    row.Author,
+		Session:   row.Session,
+		CreatedAt: fromZulu(row.CreatedAt),
+	}
+}
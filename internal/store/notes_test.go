@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotesStore(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	notes, err := s.ListNotes(ctx, NotesFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+
+	_, err = s.AddNote(ctx, &Note{Asset: "1.2.3.4", Text: "  "})
+	require.Error(t, err)
+
+	beacon, err := s.AddNote(ctx, &Note{Asset: "1.2.3.4", AssetType: "host", Text: "beacon every 60s", Author: "alice", Session: "incident-42"})
+	require.NoError(t, err)
+	assert.NotZero(t, beacon.ID)
+	assert.False(t, beacon.CreatedAt.IsZero())
+	_, err = s.AddNote(ctx, &Note{Asset: "1.2.3.4", AssetType: "host", Text: "same JARM as the C2", Author: "bob"})
+	require.NoError(t, err)
+	_, err = s.AddNote(ctx, &Note{Asset: "example.com:443", AssetType: "webproperty", Text: "Beacon landing page", Author: "bob"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		filter NotesFilter
+		want   []string
+	}{
+		{"all", NotesFilter{}, []string{"beacon every 60s", "same JARM as the C2", "Beacon landing page"}},
+		{"by asset", NotesFilter{Asset: "1.2.3.4"}, []string{"beacon every 60s", "same JARM as the C2"}},
+		{"by text ignoring case", NotesFilter{Search: "BEACON"}, []string{"beacon every 60s", "Beacon landing page"}},
+		{"by author", NotesFilter{Asset: "1.2.3.4", Search: "bob"}, []string{"same JARM as the C2"}},
+		{"by session", NotesFilter{Session: "incident-42"}, []string{"beacon every 60s"}},
+		{"unknown asset", NotesFilter{Asset: "5.6.7.8"}, []string{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			notes, err := s.ListNotes(ctx, tc.filter)
+			require.NoError(t, err)
+			texts := make([]string, len(notes))
+			for i, n := range notes {
+				texts[i] = n.Text
+			}
+			assert.Equal(t, tc.want, texts)
+		})
+	}
+
+	require.NoError(t, s.DeleteNote(ctx, beacon.ID))
+	require.ErrorIs(t, s.DeleteNote(ctx, beacon.ID), ErrNoteNotFound)
+	notes, err = s.ListNotes(ctx, NotesFilter{Asset: "1.2.3.4"})
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "bob", notes[0].Author)
+}
@@ -0,0 +1,190 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const (
+	// the suffix of the backup file next to the database file
+	backupSuffix = ".bak"
+	// how old the backup can get before the next open replaces it
+	backupInterval = 24 * time.Hour
+)
+
+// OpenReport says what opening the store worked around.
+type OpenReport struct {
+	// Recovery is set when the store file was corrupt and was recovered.
+	Recovery *Recovery
+	// BackupErr is why the backup of the store could not be made. The store
+	// is usable regardless.
+	BackupErr error
+}
+
+// Warnings describes what happened, one warning per line.
+func (r *OpenReport) Warnings() []string {
+	var warnings []string
+	if r.Recovery != nil {
+		warnings = append(warnings, r.Recovery.String())
+	}
+	if r.BackupErr != nil {
+		warnings = append(warnings, fmt.Sprintf("failed to back up the store: %v", r.BackupErr))
+	}
+	return warnings
+}
+
+// Recovery describes how a corrupt store file was recovered.
+type Recovery struct {
+	// Cause is why the store file was found to be corrupt.
+	Cause error
+	// CorruptPath is where the corrupt store file was moved to.
+	CorruptPath string
+	// BackupPath is the backup the store was restored from, or empty if the
+	// store was replaced by an empty one.
+	BackupPath string
+	// BackupTime is when that backup was made.
+	BackupTime time.Time
+}
+
+// String describes the recovery, for a warning.
+func (r *Recovery) String() string {
+	msg := fmt.Sprintf("the store was corrupt (%v) and was moved to %s", r.Cause, r.CorruptPath)
+	if r.BackupPath == "" {
+		return msg + "; no usable backup was found, so saved credentials and state were reset"
+	}
+	return fmt.Sprintf("%s; it was restored from the backup of %s, so changes made since then are lost",
+		msg, r.BackupTime.Format(time.RFC3339))
+}
+
+// corruptError is returned when the integrity check of a database fails.
+type corruptError struct {
+	problem string
+}
+
+func (e *corruptError) Error() string {
+	return "integrity check failed: " + e.problem
+}
+
+// isCorrupt returns true if err means that the database file is damaged or
+// is not a database at all.
+func isCorrupt(err error) bool {
+	var cerr *corruptError
+	if errors.As(err, &cerr) {
+		return true
+	}
+	var serr *sqlite.Error
+	if errors.As(err, &serr) {
+		// the primary result code is in the low byte of extended codes
+		switch serr.Code() & 0xff {
+		case sqlite3.SQLITE_CORRUPT, sqlite3.SQLITE_NOTADB:
+			return true
+		}
+	}
+	return false
+}
+
+// checkIntegrity runs a quick integrity check of db.
+func checkIntegrity(db *sql.DB) error {
+	var result string
+	if err := db.QueryRow(`PRAGMA quick_check;`).Scan(&result); err != nil {
+		return fmt.Errorf("failed to check the integrity of the database: %w", err)
+	}
+	if result != "ok" {
+		return &corruptError{problem: result}
+	}
+	return nil
+}
+
+// recoverDB moves the corrupt database at path aside, with its WAL files, and
+// restores its backup in its place if the backup is intact. The caller must
+// hold the store lock.
+func recoverDB(path string, cause error) (*Recovery, error) {
+	recovery := &Recovery{
+		Cause:       cause,
+		CorruptPath: fmt.Sprintf("%s.corrupt-%s", path, time.Now().UTC().Format("20060102T150405Z")),
+	}
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		err := os.Rename(path+suffix, recovery.CorruptPath+suffix)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to move the corrupt store aside: %w", err)
+		}
+	}
+
+	backupPath := path + backupSuffix
+	info, err := os.Stat(backupPath)
+	if err != nil || !backupIntact(backupPath) {
+		return recovery, nil
+	}
+	if err := copyFile(backupPath, path); err != nil {
+		return nil, fmt.Errorf("failed to restore the store from %s: %w", backupPath, err)
+	}
+	recovery.BackupPath = backupPath
+	recovery.BackupTime = info.ModTime()
+	return recovery, nil
+}
+
+// backupIntact returns true if the backup at path passes the integrity check.
+func backupIntact(path string) bool {
+	return checkFileIntegrity(path) == nil
+}
+
+// checkFileIntegrity runs a quick integrity check of the database at path.
+func checkFileIntegrity(path string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	return checkIntegrity(db)
+}
+
+// backupDue returns true if the last backup of the database at path was made
+// more than backupInterval before now, or there is none.
+func backupDue(path string, now time.Time) bool {
+	info, err := os.Stat(path + backupSuffix)
+	return err != nil || now.Sub(info.ModTime()) >= backupInterval
+}
+
+// backupDB writes a consistent copy of db next to path. The copy is written to
+// a temporary file first, so an interrupted backup never replaces a good one.
+func backupDB(db *sql.DB, path string) error {
+	backupPath := path + backupSuffix
+	tmpPath := backupPath + ".tmp"
+	if err := os.Remove(tmpPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if _, err := db.Exec(`VACUUM INTO ?;`, tmpPath); err != nil {
+		return err
+	}
+	// the store holds credentials
+	if err := os.Chmod(tmpPath, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, backupPath)
+}
+
+// copyFile copies the file at src to dst, which is created or truncated.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
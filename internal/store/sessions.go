@@ -0,0 +1,311 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/censys/cencli/gen/db"
+)
+
+// SessionsStore records investigation sessions: the commands run while a
+// session is active, their responses, and free-form notes.
+type SessionsStore interface {
+	// StartSession creates a session and makes it the active session,
+	// ending any session that was already active.
+	StartSession(ctx context.Context, name string) (*Session, error)
+	// EndActiveSession ends the active session and returns it.
+	EndActiveSession(ctx context.Context) (*Session, error)
+	// GetActiveSession returns the session that is currently recording.
+	GetActiveSession(ctx context.Context) (*Session, error)
+	// GetSessionByName returns the session with the given name.
+	GetSessionByName(ctx context.Context, name string) (*Session, error)
+	// ListSessions returns all sessions, oldest first.
+	ListSessions(ctx context.Context) ([]*Session, error)
+	// AddSessionEntry appends an entry to a session.
+	AddSessionEntry(ctx context.Context, sessionID int64, entry *SessionEntry) (*SessionEntry, error)
+	// GetSessionEntries returns the entries of a session in the order they were recorded.
+	GetSessionEntries(ctx context.Context, sessionID int64) ([]*SessionEntry, error)
+	// ImportSession creates an ended session along with its entries.
+	ImportSession(ctx context.Context, session *Session, entries []*SessionEntry) (*Session, error)
+	// DeleteSession deletes a session and all of its entries.
+	DeleteSession(ctx context.Context, id int64) error
+}
+
+var (
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrSessionExists    = errors.New("a session with this name already exists")
+	ErrNoActiveSession  = errors.New("no session is active")
+	errSessionNameEmpty = errors.New("session name must not be empty")
+)
+
+type Session struct {
+	ID        int64
+	Name      string
+	StartedAt time.Time
+	// EndedAt is zero while the session is active.
+	EndedAt time.Time
+}
+
+// Active returns true if the session is still recording.
+func (s *Session) Active() bool { return s.EndedAt.IsZero() }
+
+// SessionEntryKind distinguishes recorded commands from notes.
+type SessionEntryKind string
+
+const (
+	SessionEntryKindCommand SessionEntryKind = "command"
+	SessionEntryKindNote    SessionEntryKind = "note"
+)
+
+type SessionEntry struct {
+	ID        int64
+	SessionID int64
+	Kind      SessionEntryKind
+	// Command is the command line that was run (command entries only).
+	Command string
+	// Query is the Censys query the command ran, if any.
+	Query string
+	// Note is the text of a note (note entries only).
+	Note string
+	// Digest is the SHA-256 of Response, or empty if no response was captured.
+	Digest string
+	// Response is the raw JSON the command output.
+	Response  string
+	CreatedAt time.Time
+}
+
+type sessionsStore struct {
+	*dataStore
+}
+
+var _ SessionsStore = &sessionsStore{}
+
+func newSessionsStore(ds *dataStore) (*sessionsStore, error) {
+	return &sessionsStore{
+		dataStore: ds,
+	}, nil
+}
+
+func (s *sessionsStore) StartSession(ctx context.Context, name string) (*Session, error) {
+	if name == "" {
+		return nil, errSessionNameEmpty
+	}
+	var session *Session
+	err := s.withTx(ctx, func(q *db.Queries) error {
+		if _, err := q.GetSessionByName(ctx, name); err == nil {
+			return ErrSessionExists
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		now := toZulu(time.Now())
+		if _, err := q.EndActiveSessions(ctx, now); err != nil {
+			return err
+		}
+		row, err := q.InsertSession(ctx, db.InsertSessionParams{Name: name, StartedAt: now})
+		if err != nil {
+			return err
+		}
+		session = sessionFromDb(&row)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrSessionExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	return session, nil
+}
+
+func (s *sessionsStore) EndActiveSession(ctx context.Context) (*Session, error) {
+	q := db.New(s.db)
+	rows, err := q.EndActiveSessions(ctx, toZulu(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to end session: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, ErrNoActiveSession
+	}
+	return sessionFromDb(&rows[len(rows)-1]), nil
+}
+
+func (s *sessionsStore) GetActiveSession(ctx context.Context) (*Session, error) {
+	q := db.New(s.db)
+	row, err := q.GetActiveSession(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoActiveSession
+		}
+		return nil, fmt.Errorf("failed to get active session: %w", err)
+	}
+	return sessionFromDb(&row), nil
+}
+
+func (s *sessionsStore) GetSessionByName(ctx context.Context, name string) (*Session, error) {
+	q := db.New(s.db)
+	row, err := q.GetSessionByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return sessionFromDb(&row), nil
+}
+
+func (s *sessionsStore) ListSessions(ctx context.Context) ([]*Session, error) {
+	q := db.New(s.db)
+	rows, err := q.ListSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	sessions := make([]*Session, len(rows))
+	for i, row := range rows {
+		sessions[i] = sessionFromDb(&row)
+	}
+	return sessions, nil
+}
+
+func (s *sessionsStore) AddSessionEntry(ctx context.Context, sessionID int64, entry *SessionEntry) (*SessionEntry, error) {
+	q := db.New(s.db)
+	row, err := q.InsertSessionEntry(ctx, sessionEntryToDb(sessionID, entry))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add session entry: %w", err)
+	}
+	return sessionEntryFromDb(&row), nil
+}
+
+func (s *sessionsStore) GetSessionEntries(ctx context.Context, sessionID int64) ([]*SessionEntry, error) {
+	q := db.New(s.db)
+	rows, err := q.GetSessionEntriesBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session entries: %w", err)
+	}
+	entries := make([]*SessionEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = sessionEntryFromDb(&row)
+	}
+	return entries, nil
+}
+
+func (s *sessionsStore) ImportSession(ctx context.Context, session *Session, entries []*SessionEntry) (*Session, error) {
+	if session.Name == "" {
+		return nil, errSessionNameEmpty
+	}
+	var imported *Session
+	err := s.withTx(ctx, func(q *db.Queries) error {
+		if _, err := q.GetSessionByName(ctx, session.Name); err == nil {
+			return ErrSessionExists
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		endedAt := session.EndedAt
+		if endedAt.IsZero() {
+			endedAt = time.Now()
+		}
+		row, err := q.InsertSession(ctx, db.InsertSessionParams{
+			Name:      session.Name,
+			StartedAt: toZulu(session.StartedAt),
+			EndedAt:   toZulu(endedAt),
+		})
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if _, err := q.InsertSessionEntry(ctx, sessionEntryToDb(row.ID, entry)); err != nil {
+				return err
+			}
+		}
+		imported = sessionFromDb(&row)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrSessionExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to import session: %w", err)
+	}
+	return imported, nil
+}
+
+func (s *sessionsStore) DeleteSession(ctx context.Context, id int64) error {
+	err := s.withTx(ctx, func(q *db.Queries) error {
+		if _, err := q.DeleteSessionEntriesBySession(ctx, id); err != nil {
+			return err
+		}
+		n, err := q.DeleteSession(ctx, id)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrSessionNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// withTx runs fn in a transaction, committing only if fn succeeds.
+func (s *sessionsStore) withTx(ctx context.Context, fn func(q *db.Queries) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(db.New(s.db).WithTx(tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func sessionFromDb(row *db.Session) *Session {
+	session := &Session{
+		ID:        row.ID,
+		Name:      row.Name,
+		StartedAt: fromZulu(row.StartedAt),
+	}
+	if row.EndedAt != "" {
+		session.EndedAt = fromZulu(row.EndedAt)
+	}
+	return session
+}
+
+func sessionEntryFromDb(row *db.SessionEntry) *SessionEntry {
+	return &SessionEntry{
+		ID:        row.ID,
+		SessionID: row.SessionID,
+		Kind:      SessionEntryKind(row.Kind),
+		Command:   row.Command,
+		Query:     row.Query,
+		Note:      row.Note,
+		Digest:    row.Digest,
+		Response:  row.Response,
+		CreatedAt: fromZulu(row.CreatedAt),
+	}
+}
+
+func sessionEntryToDb(sessionID int64, entry *SessionEntry) db.InsertSessionEntryParams {
+	createdAt := entry.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	return db.InsertSessionEntryParams{
+		SessionID: sessionID,
+		Kind:      string(entry.Kind),
+		Command:   entry.Command,
+		Query:     entry.Query,
+		Note:      entry.Note,
+		Digest:    entry.Digest,
+		Response:  entry.Response,
+		CreatedAt: toZulu(createdAt),
+	}
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionsStore(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = s.GetActiveSession(ctx)
+	require.ErrorIs(t, err, ErrNoActiveSession)
+
+	first, err := s.StartSession(ctx, "first")
+	require.NoError(t, err)
+	assert.True(t, first.Active())
+
+	_, err = s.StartSession(ctx, "first")
+	require.ErrorIs(t, err, ErrSessionExists)
+
+	second, err := s.StartSession(ctx, "second")
+	require.NoError(t, err)
+
+	active, err := s.GetActiveSession(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, active.ID, "starting a session ends the previous one")
+
+	first, err = s.GetSessionByName(ctx, "first")
+	require.NoError(t, err)
+	assert.False(t, first.Active())
+
+	entry, err := s.AddSessionEntry(ctx, second.ID, &SessionEntry{
+		Kind:     SessionEntryKindCommand,
+		Command:  "censys search 'host.services.port: 22'",
+		Query:    "host.services.port: 22",
+		Digest:   "abc",
+		Response: `{"hits":[]}`,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, entry.ID)
+	_, err = s.AddSessionEntry(ctx, second.ID, &SessionEntry{Kind: SessionEntryKindNote, Note: "looks benign"})
+	require.NoError(t, err)
+
+	entries, err := s.GetSessionEntries(ctx, second.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, SessionEntryKindCommand, entries[0].Kind)
+	assert.Equal(t, `{"hits":[]}`, entries[0].Response)
+	assert.Equal(t, "looks benign", entries[1].Note)
+
+	ended, err := s.EndActiveSession(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "second", ended.Name)
+	assert.False(t, ended.Active())
+	_, err = s.EndActiveSession(ctx)
+	require.ErrorIs(t, err, ErrNoActiveSession)
+
+	sessions, err := s.ListSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	require.NoError(t, s.DeleteSession(ctx, second.ID))
+	_, err = s.GetSessionByName(ctx, "second")
+	require.ErrorIs(t, err, ErrSessionNotFound)
+	entries, err = s.GetSessionEntries(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	require.ErrorIs(t, s.DeleteSession(ctx, second.ID), ErrSessionNotFound)
+}
+
+func TestSessionsStore_Import(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	startedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	entryAt := startedAt.Add(time.Minute)
+	imported, err := s.ImportSession(ctx, &Session{Name: "shared", StartedAt: startedAt}, []*SessionEntry{
+		{Kind: SessionEntryKindNote, Note: "from a teammate", CreatedAt: entryAt},
+	})
+	require.NoError(t, err)
+	assert.False(t, imported.Active(), "imported sessions are never active")
+	assert.True(t, startedAt.Equal(imported.StartedAt))
+
+	entries, err := s.GetSessionEntries(ctx, imported.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, entryAt.Equal(entries[0].CreatedAt))
+
+	_, err = s.ImportSession(ctx, &Session{Name: "shared", StartedAt: startedAt}, nil)
+	require.ErrorIs(t, err, ErrSessionExists)
+	sessions, err := s.ListSessions(ctx)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 1, "a failed import leaves nothing behind")
+}
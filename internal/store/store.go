@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/gofrs/flock"
 	_ "modernc.org/sqlite"
 
 	storedb "github.com/censys/cencli/internal/store/db"
@@ -14,63 +16,144 @@ import (
 const (
 	// the name of the database file
 	dbName = "cencli.db"
+	// the suffix of the advisory lock file next to the database file
+	lockSuffix = ".lock"
+	// pragmatic defaults for CLI UX
+	dsnPragmas = "_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)"
 )
 
-//go:generate mockgen -destination=../../gen/store/mocks/store_mock.go -package=mocks github.com/censys/cencli/internal/store Store,AuthsStore,GlobalsStore
+//go:generate mockgen -destination=../../gen/store/mocks/store_mock.go -package=mocks github.com/censys/cencli/internal/store Store,AuthsStore,GlobalsStore,WatchesStore,SessionsStore,UsageStore,NotesStore
 type Store interface {
 	AuthsStore
 	GlobalsStore
+	WatchesStore
+	SessionsStore
+	UsageStore
+	NotesStore
 }
 
 type dataStore struct {
 	db *sql.DB
 }
 
+// New opens the store in dataDir, creating it if needed. A store file that
+// is corrupt is recovered as described by Open.
 func New(dataDir string) (Store, error) {
-	ds := &dataStore{}
+	s, _, err := Open(dataDir)
+	return s, err
+}
 
+// Open opens the store in dataDir, creating it if needed. Concurrent
+// processes can share the store: an advisory lock serializes opening it, and
+// each connection waits for the others' writes instead of failing. A store
+// file that is corrupt is moved aside and replaced by its latest backup, or by
+// an empty store if there is no usable backup. A backup is made when the store
+// is opened and its last backup is more than a day old; a failed backup does
+// not keep the store from opening. The returned OpenReport says what happened.
+func Open(dataDir string) (Store, *OpenReport, error) {
 	_, err := os.Stat(dataDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("directory %s does not exist", dataDir)
+			return nil, nil, fmt.Errorf("directory %s does not exist", dataDir)
 		}
-		return nil, fmt.Errorf("failed to check if data directory exists: %w", err)
+		return nil, nil, fmt.Errorf("failed to check if data directory exists: %w", err)
 	}
 
 	dbPath := filepath.Join(dataDir, dbName)
-	ds.db, err = sql.Open("sqlite", dbPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+	fileLock := flock.New(dbPath + lockSuffix)
+	if err := fileLock.Lock(); err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire store lock: %w", err)
 	}
-	// Apply pragmatic defaults for CLI UX
-	if _, err := ds.db.Exec(`PRAGMA journal_mode=WAL;`); err != nil {
-		return nil, fmt.Errorf("failed to set journal_mode WAL: %w", err)
+	defer func() { _ = fileLock.Unlock() }()
+
+	report := &OpenReport{}
+	db, err := openDB(dbPath)
+	if err != nil && !isCorrupt(err) {
+		// tell a damaged store from other failures, such as permissions
+		if checkErr := checkFileIntegrity(dbPath); isCorrupt(checkErr) {
+			err = checkErr
+		}
 	}
-	if _, err := ds.db.Exec(`PRAGMA busy_timeout = 5000;`); err != nil {
-		return nil, fmt.Errorf("failed to set busy_timeout: %w", err)
+	if err == nil && backupDue(dbPath, time.Now()) {
+		// a corrupt store must not replace the last good backup
+		if err = checkIntegrity(db); err != nil {
+			_ = db.Close()
+		}
 	}
-	if _, err := ds.db.Exec(`PRAGMA synchronous = NORMAL;`); err != nil {
-		return nil, fmt.Errorf("failed to set synchronous NORMAL: %w", err)
+	if isCorrupt(err) {
+		if report.Recovery, err = recoverDB(dbPath, err); err == nil {
+			db, err = openDB(dbPath)
+		}
 	}
-	if _, err := ds.db.Exec(string(storedb.Schema)); err != nil {
-		return nil, fmt.Errorf("failed to execute schema: %w", err)
+	if err != nil {
+		return nil, nil, err
+	}
+	if backupDue(dbPath, time.Now()) {
+		report.BackupErr = backupDB(db, dbPath)
 	}
+	ds := &dataStore{db: db}
 
 	authsStore, err := newAuthsStore(ds)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create auths store: %w", err)
+		return nil, nil, fmt.Errorf("failed to create auths store: %w", err)
 	}
 
 	globalsStore, err := newGlobalsStore(ds)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create globals store: %w", err)
+		return nil, nil, fmt.Errorf("failed to create globals store: %w", err)
+	}
+
+	watchesStore, err := newWatchesStore(ds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create watches store: %w", err)
+	}
+
+	sessionsStore, err := newSessionsStore(ds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create sessions store: %w", err)
+	}
+
+	usageStore, err := newUsageStore(ds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create usage store: %w", err)
+	}
+
+	notesStore, err := newNotesStore(ds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create notes store: %w", err)
 	}
 
 	return &struct {
 		AuthsStore
 		GlobalsStore
+		WatchesStore
+		SessionsStore
+		UsageStore
+		NotesStore
 	}{
-		AuthsStore:   authsStore,
-		GlobalsStore: globalsStore,
-	}, nil
+		AuthsStore:    authsStore,
+		GlobalsStore:  globalsStore,
+		WatchesStore:  watchesStore,
+		SessionsStore: sessionsStore,
+		UsageStore:    usageStore,
+		NotesStore:    notesStore,
+	}, report, nil
+}
+
+// openDB opens the database at path and applies the schema. Its integrity is
+// only checked when opening fails or before it is backed up, since the check
+// reads the whole database.
+func openDB(path string) (*sql.DB, error) {
+	// Pragmas in the DSN apply to every connection of the pool, not just the
+	// first. The busy timeout makes a connection wait for other processes'
+	// writes instead of failing with SQLITE_BUSY.
+	db, err := sql.Open("sqlite", path+"?"+dsnPragmas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if _, err := db.Exec(string(storedb.Schema)); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to execute schema: %w", err)
+	}
+	return db, nil
 }
@@ -0,0 +1,141 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen_Concurrent(t *testing.T) {
+	// each store has its own connections, like separate cencli processes
+	dir := t.TempDir()
+	const workers, writes = 8, 10
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := New(dir)
+			if err != nil {
+				errs <- err
+				return
+			}
+			for i := range writes {
+				if _, err := s.AddValueForAuth(context.Background(), "platform", fmt.Sprintf("worker %d", w), fmt.Sprintf("token-%d-%d", w, i)); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	s, err := New(dir)
+	require.NoError(t, err)
+	values, err := s.GetValuesForAuth(context.Background(), "platform")
+	require.NoError(t, err)
+	require.Len(t, values, workers*writes)
+}
+
+func TestOpen_Recovery(t *testing.T) {
+	ctx := context.Background()
+	corrupt := func(t *testing.T, dir string) {
+		t.Helper()
+		dbPath := filepath.Join(dir, dbName)
+		require.NoError(t, os.WriteFile(dbPath, []byte("this is not a database"), 0o600))
+		for _, suffix := range []string{"-wal", "-shm"} {
+			require.NoError(t, os.RemoveAll(dbPath+suffix))
+		}
+	}
+
+	t.Run("restores the backup", func(t *testing.T) {
+		dir := t.TempDir()
+		s, report, err := Open(dir)
+		require.NoError(t, err)
+		require.Nil(t, report.Recovery)
+		require.Empty(t, report.Warnings())
+		_, err = s.AddValueForAuth(ctx, "platform", "saved", "token")
+		require.NoError(t, err)
+
+		// the next open makes a new backup once the last one is old enough
+		backupPath := filepath.Join(dir, dbName+backupSuffix)
+		old := time.Now().Add(-2 * backupInterval)
+		require.NoError(t, os.Chtimes(backupPath, old, old))
+		_, _, err = Open(dir)
+		require.NoError(t, err)
+		info, err := os.Stat(backupPath)
+		require.NoError(t, err)
+		require.True(t, info.ModTime().After(old))
+		require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		corrupt(t, dir)
+		s, report, err = Open(dir)
+		require.NoError(t, err)
+		recovery := report.Recovery
+		require.NotNil(t, recovery)
+		require.Equal(t, backupPath, recovery.BackupPath)
+		require.Contains(t, recovery.String(), "restored from the backup")
+		data, err := os.ReadFile(recovery.CorruptPath)
+		require.NoError(t, err)
+		require.Equal(t, "this is not a database", string(data))
+
+		values, err := s.GetValuesForAuth(ctx, "platform")
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		require.Equal(t, "token", values[0].Value)
+	})
+
+	t.Run("starts empty without a usable backup", func(t *testing.T) {
+		dir := t.TempDir()
+		_, _, err := Open(dir)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, dbName+backupSuffix), []byte("not a backup either"), 0o600))
+		corrupt(t, dir)
+
+		s, report, err := Open(dir)
+		require.NoError(t, err)
+		recovery := report.Recovery
+		require.NotNil(t, recovery)
+		require.Empty(t, recovery.BackupPath)
+		require.Contains(t, recovery.String(), "no usable backup was found")
+
+		values, err := s.GetValuesForAuth(ctx, "platform")
+		require.NoError(t, err)
+		require.Empty(t, values)
+	})
+}
+
+func TestOpen_BackupFailure(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	_, _, err := Open(dir)
+	require.NoError(t, err)
+
+	// a directory in the way of the backup makes it fail
+	backupPath := filepath.Join(dir, dbName+backupSuffix)
+	require.NoError(t, os.Remove(backupPath))
+	require.NoError(t, os.MkdirAll(filepath.Join(backupPath, "in-the-way"), 0o700))
+	old := time.Now().Add(-2 * backupInterval)
+	require.NoError(t, os.Chtimes(backupPath, old, old))
+
+	s, report, err := Open(dir)
+	require.NoError(t, err, "a failed backup does not keep the store from opening")
+	require.Nil(t, report.Recovery)
+	require.Error(t, report.BackupErr)
+	require.Len(t, report.Warnings(), 1)
+	require.Contains(t, report.Warnings()[0], "failed to back up the store")
+
+	_, err = s.AddValueForAuth(ctx, "platform", "saved", "token")
+	require.NoError(t, err)
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/censys/cencli/gen/db"
+)
+
+// usageRetention is how long usage events are kept. Older events are
+// removed when a new one is recorded.
+const usageRetention = 365 * 24 * time.Hour
+
+// UsageStore records local usage analytics: one event per command run.
+// Usage events never leave the machine.
+type UsageStore interface {
+	// RecordUsage records a command run, and removes the events that are
+	// older than a year.
+	RecordUsage(ctx context.Context, event *UsageEvent) error
+	// GetUsageSince returns the events of the runs started at or after since, oldest first.
+	GetUsageSince(ctx context.Context, since time.Time) ([]*UsageEvent, error)
+	// ClearUsage removes every usage event and returns how many were removed.
+	ClearUsage(ctx context.Context) (int64, error)
+}
+
+// UsageEvent is a single command run.
+type UsageEvent struct {
+	ID int64
+	// Command is the command path without the binary name, e.g. "search" or "hunt run".
+	Command string
+	// Query is the <query> argument of the command, if it has one.
+	Query     string
+	StartedAt time.Time
+	Duration  time.Duration
+	// Failed is true if the command returned an error.
+	Failed bool
+	// Requests and RequestErrors count the API calls made by the run.
+	Requests      int64
+	RequestErrors int64
+	// SearchRequests counts the search API calls, and SearchLatency is their total latency.
+	SearchRequests int64
+	SearchLatency  time.Duration
+	PagesFetched   int64
+	// CacheHits and CacheMisses count the lookups of locally cached data.
+	CacheHits   int64
+	CacheMisses int64
+}
+
+type usageStore struct {
+	*dataStore
+}
+
+var _ UsageStore = &usageStore{}
+
+func newUsageStore(ds *dataStore) (*usageStore, error) {
+	return &usageStore{
+		dataStore: ds,
+	}, nil
+}
+
+func (r *usageStore) RecordUsage(ctx context.Context, event *UsageEvent) error {
+	q := db.New(r.db)
+	failed := int64(0)
+	if event.Failed {
+		failed = 1
+	}
+	err := q.InsertUsageEvent(ctx, db.InsertUsageEventParams{
+		Command:         event.Command,
+		Query:           event.Query,
+		StartedAt:       toZulu(event.StartedAt.UTC()),
+		DurationMs:      event.Duration.Milliseconds(),
+		Failed:          failed,
+		Requests:        event.Requests,
+		RequestErrors:   event.RequestErrors,
+		SearchRequests:  event.SearchRequests,
+		SearchLatencyMs: event.SearchLatency.Milliseconds(),
+		PagesFetched:    event.PagesFetched,
+		CacheHits:       event.CacheHits,
+		CacheMisses:     event.CacheMisses,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record usage: %w", err)
+	}
+	if _, err := q.DeleteUsageEventsBefore(ctx, toZulu(time.Now().Add(-usageRetention).UTC())); err != nil {
+		return fmt.Errorf("failed to remove old usage: %w", err)
+	}
+	return nil
+}
+
+func (r *usageStore) GetUsageSince(ctx context.Context, since time.Time) ([]*UsageEvent, error) {
+	q := db.New(r.db)
+	rows, err := q.GetUsageEventsSince(ctx, toZulu(since.UTC()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	events := make([]*UsageEvent, len(rows))
+	for i, row := range rows {
+		events[i] = &UsageEvent{
+			ID:             row.ID,
+			Command:        row.Command,
+			Query:          row.Query,
+			StartedAt:      fromZulu(row.StartedAt),
+			Duration:       time.Duration(row.DurationMs) * time.Millisecond,
+			Failed:         row.Failed != 0,
+			Requests:       row.Requests,
+			RequestErrors:  row.RequestErrors,
+			SearchRequests: row.SearchRequests,
+			SearchLatency:  time.Duration(row.SearchLatencyMs) * time.Millisecond,
+			PagesFetched:   row.PagesFetched,
+			CacheHits:      row.CacheHits,
+			CacheMisses:    row.CacheMisses,
+		}
+	}
+	return events, nil
+}
+
+func (r *usageStore) ClearUsage(ctx context.Context) (int64, error) {
+	q := db.New(r.db)
+	n, err := q.DeleteUsageEvents(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear usage: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageStore(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	search := &UsageEvent{
+		Command:        "search",
+		Query:          "host.services.port: 22",
+		StartedAt:      now.Add(-time.Hour),
+		Duration:       1500 * time.Millisecond,
+		Requests:       2,
+		SearchRequests: 2,
+		SearchLatency:  900 * time.Millisecond,
+		PagesFetched:   2,
+		CacheHits:      1,
+	}
+	require.NoError(t, s.RecordUsage(ctx, search))
+	require.NoError(t, s.RecordUsage(ctx, &UsageEvent{Command: "view", StartedAt: now.Add(-48 * time.Hour), Failed: true}))
+	// events older than the retention are removed when the next one is recorded
+	require.NoError(t, s.RecordUsage(ctx, &UsageEvent{Command: "view", StartedAt: now.Add(-2 * usageRetention)}))
+	require.NoError(t, s.RecordUsage(ctx, &UsageEvent{Command: "credits", StartedAt: now}))
+
+	events, err := s.GetUsageSince(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "search", events[0].Command)
+	assert.Equal(t, "host.services.port: 22", events[0].Query)
+	assert.True(t, events[0].StartedAt.Equal(search.StartedAt))
+	assert.Equal(t, search.Duration, events[0].Duration)
+	assert.Equal(t, search.SearchLatency, events[0].SearchLatency)
+	assert.Equal(t, int64(2), events[0].PagesFetched)
+	assert.Equal(t, int64(1), events[0].CacheHits)
+	assert.Equal(t, "credits", events[1].Command)
+
+	events, err = s.GetUsageSince(ctx, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.True(t, events[1].Failed)
+
+	removed, err := s.ClearUsage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), removed)
+
+	events, err = s.GetUsageSince(ctx, time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
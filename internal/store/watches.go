@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/censys/cencli/gen/db"
+)
+
+// WatchesStore records which values a watch (e.g. `certs watch`) has already
+// reported, so that subsequent runs only report new ones.
+type WatchesStore interface {
+	// MarkWatchSeen records value as seen for the given watch.
+	// It returns true if the value had not been seen before.
+	MarkWatchSeen(ctx context.Context, watch, value string) (bool, error)
+	// GetWatchSeen returns all values seen for the given watch, oldest first.
+	GetWatchSeen(ctx context.Context, watch string) ([]*WatchSeenValue, error)
+	// ResetWatch forgets all values seen for the given watch
+	// and returns how many were removed.
+	ResetWatch(ctx context.Context, watch string) (int64, error)
+}
+
+type WatchSeenValue struct {
+	ID          int64
+	Watch       string
+	Value       string
+	FirstSeenAt time.Time
+}
+
+type watchesStore struct {
+	*dataStore
+}
+
+var _ WatchesStore = &watchesStore{}
+
+func newWatchesStore(ds *dataStore) (*watchesStore, error) {
+	return &watchesStore{
+		dataStore: ds,
+	}, nil
+}
+
+func (r *watchesStore) MarkWatchSeen(ctx context.Context, watch, value string) (bool, error) {
+	q := db.New(r.db)
+	n, err := q.InsertWatchSeen(ctx, db.InsertWatchSeenParams{
+		Watch:       watch,
+		Value:       value,
+		FirstSeenAt: toZulu(time.Now()),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to mark watch value as seen: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (r *watchesStore) GetWatchSeen(ctx context.Context, watch string) ([]*WatchSeenValue, error) {
+	q := db.New(r.db)
+	rows, err := q.GetWatchSeenByWatch(ctx, watch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seen watch values: %w", err)
+	}
+	values := make([]*WatchSeenValue, len(rows))
+	for i, row := range rows {
+		values[i] = &WatchSeenValue{
+			ID:          row.ID,
+			Watch:       row.Watch,
+			Value:       row.Value,
+			FirstSeenAt: fromZulu(row.FirstSeenAt),
+		}
+	}
+	return values, nil
+}
+
+func (r *watchesStore) ResetWatch(ctx context.Context, watch string) (int64, error) {
+	q := db.New(r.db)
+	n, err := q.DeleteWatchSeenByWatch(ctx, watch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset watch: %w", err)
+	}
+	return n, nil
+}
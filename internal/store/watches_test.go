@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchesStore(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	now := time.Now()
+	isNew, err := s.MarkWatchSeen(ctx, "certs:example.com", "aaa")
+	require.NoError(t, err)
+	assert.True(t, isNew)
+
+	isNew, err = s.MarkWatchSeen(ctx, "certs:example.com", "aaa")
+	require.NoError(t, err)
+	assert.False(t, isNew, "second mark of the same value should not be new")
+
+	isNew, err = s.MarkWatchSeen(ctx, "certs:other.com", "aaa")
+	require.NoError(t, err)
+	assert.True(t, isNew, "watches are independent")
+
+	_, err = s.MarkWatchSeen(ctx, "certs:example.com", "bbb")
+	require.NoError(t, err)
+
+	values, err := s.GetWatchSeen(ctx, "certs:example.com")
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	assert.Equal(t, "aaa", values[0].Value)
+	assert.Equal(t, "bbb", values[1].Value)
+	assert.Equal(t, "certs:example.com", values[0].Watch)
+	assert.WithinDuration(t, now, values[0].FirstSeenAt, 2*time.Second)
+
+	removed, err := s.ResetWatch(ctx, "certs:example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), removed)
+
+	values, err = s.GetWatchSeen(ctx, "certs:example.com")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	values, err = s.GetWatchSeen(ctx, "certs:other.com")
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+}
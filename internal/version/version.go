@@ -2,6 +2,7 @@ package version
 
 import (
 	"runtime"
+	"runtime/debug"
 )
 
 // These variables are overridden at build time via -ldflags.
@@ -11,6 +12,9 @@ var (
 	Date    = "unknown"
 )
 
+// SDKModule is the Go module of the Censys SDK the CLI is built with.
+const SDKModule = "github.com/censys/censys-sdk-go"
+
 type Info struct {
 	Version string `json:"version"`
 	Commit  string `json:"commit"`
@@ -18,6 +22,7 @@ type Info struct {
 	Go      string `json:"go"`
 	OS      string `json:"os"`
 	Arch    string `json:"arch"`
+	SDK     string `json:"sdk"`
 }
 
 func BuildInfo() Info {
@@ -28,5 +33,25 @@ func BuildInfo() Info {
 		Go:      runtime.Version(),
 		OS:      runtime.GOOS,
 		Arch:    runtime.GOARCH,
+		SDK:     sdkVersion(),
+	}
+}
+
+// sdkVersion returns the version of SDKModule recorded in the binary,
+// or "unknown" if the SDK is not linked (as in this package's tests).
+func sdkVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != SDKModule {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
 	}
+	return "unknown"
 }
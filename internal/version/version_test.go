@@ -7,4 +7,8 @@ func TestBuildInfo(t *testing.T) {
 	if info.Go == "" || info.OS == "" || info.Arch == "" {
 		t.Fatalf("expected Go/OS/Arch to be populated, got: %+v", info)
 	}
+	// the SDK is not linked into this package's test binary
+	if info.SDK != "unknown" {
+		t.Fatalf("expected SDK to be unknown, got: %s", info.SDK)
+	}
 }
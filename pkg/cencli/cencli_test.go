@@ -0,0 +1,112 @@
+package cencli_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/censys/cencli/internal/pkg/clients/censys/fakeserver"
+	"github.com/censys/cencli/pkg/cencli"
+)
+
+func newClient(t *testing.T, srv *fakeserver.Server, opts ...cencli.Option) *cencli.Client {
+	t.Helper()
+	client, err := cencli.New("test-pat", append([]cencli.Option{cencli.WithAPIURL(srv.URL)}, opts...)...)
+	require.NoError(t, err)
+	return client
+}
+
+func TestNew(t *testing.T) {
+	_, err := cencli.New("")
+	assert.EqualError(t, err, "cencli: a personal access token is required")
+
+	_, err = cencli.New("test-pat", cencli.WithOrganizationID("not-a-uuid"))
+	assert.ErrorContains(t, err, `cencli: invalid organization ID "not-a-uuid"`)
+
+	_, err = cencli.New("test-pat", cencli.WithAPIURL("://nope"))
+	assert.Error(t, err)
+
+	assert.NotEmpty(t, cencli.Version())
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("first page", func(t *testing.T) {
+		srv := fakeserver.New()
+		defer srv.Close()
+		client := newClient(t, srv)
+
+		res, err := client.Search(ctx, cencli.SearchRequest{Query: "host.services.port: 53", PageSize: 2})
+		require.NoError(t, err)
+		require.Len(t, res.Hits, 2)
+		assert.Equal(t, "8.8.8.8", res.Hits[0].ID())
+		require.NotNil(t, res.Hits[0].Host)
+		assert.Equal(t, int64(3), res.TotalHits)
+		assert.NotEmpty(t, res.NextPageToken)
+
+		next, err := client.Search(ctx, cencli.SearchRequest{Query: "host.services.port: 53", PageSize: 2, PageToken: res.NextPageToken})
+		require.NoError(t, err)
+		assert.Len(t, next.Hits, 1)
+		assert.Empty(t, next.NextPageToken)
+	})
+
+	t.Run("every page", func(t *testing.T) {
+		srv := fakeserver.New()
+		defer srv.Close()
+		client := newClient(t, srv)
+
+		res, err := client.Search(ctx, cencli.SearchRequest{Query: "*", PageSize: 1, MaxPages: -1})
+		require.NoError(t, err)
+		assert.Len(t, res.Hits, 3)
+		assert.Empty(t, res.NextPageToken)
+	})
+
+	t.Run("invalid collection", func(t *testing.T) {
+		srv := fakeserver.New()
+		defer srv.Close()
+		client := newClient(t, srv)
+
+		_, err := client.Search(ctx, cencli.SearchRequest{Query: "*", CollectionID: "nope"})
+		assert.ErrorContains(t, err, `cencli: invalid collection ID "nope"`)
+		assert.Empty(t, srv.Requests())
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		srv := fakeserver.New(fakeserver.WithFault(fakeserver.Fault{Status: http.StatusUnprocessableEntity}))
+		defer srv.Close()
+		client := newClient(t, srv, cencli.WithMaxAttempts(1))
+
+		res, err := client.Search(ctx, cencli.SearchRequest{Query: "*"})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestHosts(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	hosts, err := client.Hosts(context.Background(), []string{"8.8.8.8", "10.0.0.1"})
+	require.NoError(t, err)
+	require.Len(t, hosts, 1)
+	assert.Equal(t, "8.8.8.8", *hosts[0].IP)
+
+	_, err = client.Hosts(context.Background(), []string{"not-an-ip"})
+	assert.Error(t, err)
+	assert.Len(t, srv.Requests(), 1)
+}
+
+func TestCenseye_InvalidBounds(t *testing.T) {
+	srv := fakeserver.New()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	_, err := client.Censeye(context.Background(), "8.8.8.8", cencli.RarityBounds(10, 5))
+	assert.EqualError(t, err, "cencli: invalid rarity bounds 10-5")
+	assert.Empty(t, srv.Requests())
+}
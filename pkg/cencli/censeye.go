@@ -0,0 +1,93 @@
+package cencli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// The default rarity bounds of CensEye, as for the censys censeye command.
+const (
+	defaultRarityMin = 2
+	defaultRarityMax = 100
+)
+
+// CenseyeOption configures Censeye.
+type CenseyeOption func(*censeyeOptions)
+
+type censeyeOptions struct {
+	rarityMin, rarityMax uint64
+}
+
+// RarityBounds sets the numbers of hosts between which a query is
+// interesting, 2 and 100 by default.
+func RarityBounds(min, max uint64) CenseyeOption {
+	return func(o *censeyeOptions) {
+		o.rarityMin, o.rarityMax = min, max
+	}
+}
+
+// CenseyeReport is the result of a CensEye investigation of a host.
+type CenseyeReport struct {
+	// Host is the host that was investigated.
+	Host *components.Host
+	// Entries are the queries derived from the host, with the number of
+	// hosts that match each.
+	Entries []CenseyeEntry
+}
+
+// CenseyeEntry is a query derived from a host.
+type CenseyeEntry struct {
+	Query string
+	// Count is the number of hosts that match the query.
+	Count int64
+	// Interesting is set if Count is within the rarity bounds, which makes
+	// the query a likely pivot to related infrastructure.
+	Interesting bool
+	// SearchURL opens a search for the query in the Censys Platform.
+	SearchURL string
+}
+
+// Censeye fetches the host with the given IP, derives queries from its
+// fields, and counts the hosts that match each, to find the ones that share
+// rare traits with it.
+func (c *Client) Censeye(ctx context.Context, ip string, opts ...CenseyeOption) (*CenseyeReport, error) {
+	o := censeyeOptions{rarityMin: defaultRarityMin, rarityMax: defaultRarityMax}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.rarityMin == 0 || o.rarityMax < o.rarityMin {
+		return nil, fmt.Errorf("cencli: invalid rarity bounds %d-%d", o.rarityMin, o.rarityMax)
+	}
+	id, err := assets.NewHostID(ip)
+	if err != nil {
+		return nil, fmt.Errorf("cencli: %w", err)
+	}
+	hosts, cerr := c.view.GetHosts(ctx, c.orgID, []assets.HostID{id}, mo.None[time.Time]())
+	if cerr != nil {
+		return nil, cerr
+	}
+	if len(hosts.Hosts) == 0 {
+		return nil, fmt.Errorf("cencli: host %s was not found", ip)
+	}
+	host := hosts.Hosts[0]
+	res, cerr := c.censeye.InvestigateHost(ctx, c.orgID, host, o.rarityMin, o.rarityMax)
+	if cerr != nil {
+		return nil, cerr
+	}
+	report := &CenseyeReport{Host: &host.Host, Entries: make([]CenseyeEntry, 0, len(res.Entries))}
+	for _, entry := range res.Entries {
+		report.Entries = append(report.Entries, CenseyeEntry{
+			Query:       entry.Query,
+			Count:       entry.Count,
+			Interesting: entry.Interesting,
+			SearchURL:   entry.SearchURL,
+		})
+	}
+	return report, nil
+}
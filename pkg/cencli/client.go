@@ -0,0 +1,111 @@
+package cencli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/app/censeye"
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/app/view"
+	"github.com/censys/cencli/internal/config"
+	"github.com/censys/cencli/internal/pkg/cenclierrors"
+	client "github.com/censys/cencli/internal/pkg/clients/censys"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+	"github.com/censys/cencli/internal/pkg/searchurl"
+	"github.com/censys/cencli/internal/version"
+)
+
+// Client calls the Censys Platform API. It is safe for concurrent use.
+type Client struct {
+	orgID   mo.Option[identifiers.OrganizationID]
+	search  search.Service
+	view    view.Service
+	censeye censeye.Service
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+type options struct {
+	orgID     string
+	apiURL    string
+	timeout   time.Duration
+	retry     config.RetryStrategy
+	transport config.TransportConfig
+}
+
+// WithOrganizationID makes the requests in the organization with the given
+// ID, so that they use the credits of the organization.
+func WithOrganizationID(id string) Option {
+	return func(o *options) { o.orgID = id }
+}
+
+// WithAPIURL sends the requests to another server than the Censys Platform
+// API, such as a proxy.
+func WithAPIURL(url string) Option {
+	return func(o *options) { o.apiURL = url }
+}
+
+// WithTimeout bounds the time of each request. By default, requests are
+// only bounded by their context.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) { o.timeout = d }
+}
+
+// WithMaxAttempts sets the number of times a request is made before its
+// error is returned, when it fails with an error that is worth retrying.
+// The default is 2.
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.retry.MaxAttempts = uint64(n)
+		}
+	}
+}
+
+// New returns a client authenticated with the given personal access token.
+func New(token string, opts ...Option) (*Client, error) {
+	if token == "" {
+		return nil, errors.New("cencli: a personal access token is required")
+	}
+	o := options{retry: config.DefaultRetryStrategy(), transport: config.DefaultTransportConfig()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	orgID := mo.None[identifiers.OrganizationID]()
+	if o.orgID != "" {
+		id, err := uuid.Parse(o.orgID)
+		if err != nil {
+			return nil, fmt.Errorf("cencli: invalid organization ID %q: %w", o.orgID, err)
+		}
+		orgID = mo.Some(identifiers.NewOrganizationID(id))
+	}
+	sdk, err := client.NewCensysSDKWithToken(token, o.orgID, o.apiURL, o.timeout, o.transport, o.retry, false)
+	if err != nil {
+		return nil, fmt.Errorf("cencli: %w", err)
+	}
+	return &Client{
+		orgID:   orgID,
+		search:  search.New(sdk),
+		view:    view.New(sdk),
+		censeye: censeye.New(sdk, searchurl.New("")),
+	}, nil
+}
+
+// Version returns the version of the CLI the package is part of, such as
+// v1.2.3, or dev for a build from source.
+func Version() string {
+	return version.Version
+}
+
+// toError returns err as an error, nil if it is nil.
+func toError(err cenclierrors.CencliError) error {
+	if err == nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,23 @@
+// Package cencli is the Go API of the Censys CLI. It lets Go programs search
+// the Censys Platform, view hosts, certificates, and web properties, and
+// investigate hosts with CensEye, with the pagination, batching, and retries
+// of the censys command, but without its command line, config file, or
+// credential store.
+//
+//	client, err := cencli.New(os.Getenv("CENSYS_API_KEY"), cencli.WithOrganizationID(orgID))
+//	if err != nil {
+//		return err
+//	}
+//	result, err := client.Search(ctx, cencli.SearchRequest{Query: "host.services.protocol: SSH"})
+//
+// # Stability
+//
+// The package follows the semantic versioning of the module: within a major
+// version, exported identifiers are not removed, and their behavior does not
+// change in incompatible ways. Fields may be added to the structs, and
+// options and methods to the client, so structs should be built with field
+// names. Assets are the models of the Censys Go SDK
+// (github.com/censys/censys-sdk-go/models/components), and follow its
+// versioning. The other packages of the module are internal to the CLI and
+// may change in any release.
+package cencli
@@ -0,0 +1,59 @@
+package cencli_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/censys/cencli/pkg/cencli"
+)
+
+func ExampleClient_Search() {
+	client, err := cencli.New(os.Getenv("CENSYS_API_KEY"), cencli.WithOrganizationID(os.Getenv("CENSYS_ORG_ID")))
+	if err != nil {
+		log.Fatal(err)
+	}
+	res, err := client.Search(context.Background(), cencli.SearchRequest{
+		Query:    "host.services.protocol: SSH",
+		Fields:   []string{"host.ip", "host.location.country"},
+		MaxPages: 3,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, hit := range res.Hits {
+		fmt.Println(hit.ID())
+	}
+}
+
+func ExampleClient_Hosts() {
+	client, err := cencli.New(os.Getenv("CENSYS_API_KEY"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	hosts, err := client.Hosts(context.Background(), []string{"8.8.8.8", "1.1.1.1"}, cencli.AtTime(time.Now().AddDate(0, 0, -7)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, host := range hosts {
+		fmt.Println(*host.IP, len(host.Services))
+	}
+}
+
+func ExampleClient_Censeye() {
+	client, err := cencli.New(os.Getenv("CENSYS_API_KEY"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	report, err := client.Censeye(context.Background(), "8.8.8.8", cencli.RarityBounds(2, 50))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, entry := range report.Entries {
+		if entry.Interesting {
+			fmt.Println(entry.Count, entry.Query)
+		}
+	}
+}
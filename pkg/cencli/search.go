@@ -0,0 +1,130 @@
+package cencli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/app/search"
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+	"github.com/censys/cencli/internal/pkg/domain/identifiers"
+)
+
+// defaultPageSize is the number of hits of a page when the request does not
+// set one, as for the censys search command.
+const defaultPageSize = 100
+
+// SearchRequest is a search of the Censys Platform.
+type SearchRequest struct {
+	// Query is a query in the Censys Query Language, such as
+	// host.services.protocol: SSH.
+	Query string
+	// Fields limits the fields of the hits to these, such as host.ip.
+	Fields []string
+	// CollectionID searches within the collection with this ID instead of
+	// the whole dataset.
+	CollectionID string
+	// PageSize is the number of hits of each page. Defaults to 100.
+	PageSize int
+	// MaxPages is the number of pages fetched at most. Defaults to 1; set it
+	// to a negative number to fetch every page.
+	MaxPages int
+	// PageToken continues a search at the page it identifies, the
+	// NextPageToken of an earlier result.
+	PageToken string
+}
+
+// SearchResult is the hits of a search.
+type SearchResult struct {
+	Hits []Asset
+	// TotalHits is the number of hits of the query, including the hits of
+	// the pages that were not fetched.
+	TotalHits int64
+	// NextPageToken continues the search after the last page that was
+	// fetched, or is empty when there are no more pages.
+	NextPageToken string
+}
+
+// Asset is a search hit. Exactly one of Host, Certificate, and WebProperty
+// is set.
+type Asset struct {
+	Host *components.Host
+	// MatchedServices are the services of Host that matched the query.
+	MatchedServices []components.MatchedService
+	Certificate     *components.Certificate
+	WebProperty     *components.Webproperty
+}
+
+// ID returns the identifier of the asset: the IP of a host, the SHA-256
+// fingerprint of a certificate, or the hostname:port of a web property.
+func (a Asset) ID() string {
+	switch {
+	case a.Host != nil:
+		return assets.Identifier(assets.NewHost(*a.Host))
+	case a.Certificate != nil:
+		return assets.Identifier(assets.NewCertificate(*a.Certificate))
+	case a.WebProperty != nil:
+		return assets.Identifier(assets.NewWebProperty(*a.WebProperty))
+	default:
+		return ""
+	}
+}
+
+// Search runs a search. If a page after the first fails, Search returns the
+// hits of the pages before it with the error, and NextPageToken retries the
+// failed page.
+func (c *Client) Search(ctx context.Context, req SearchRequest) (*SearchResult, error) {
+	params := search.Params{
+		OrgID:    c.orgID,
+		Query:    req.Query,
+		Fields:   req.Fields,
+		PageSize: mo.Some(uint64(defaultPageSize)),
+		MaxPages: mo.Some[uint64](1),
+	}
+	if req.PageSize > 0 {
+		params.PageSize = mo.Some(uint64(req.PageSize))
+	}
+	switch {
+	case req.MaxPages < 0:
+		params.MaxPages = mo.None[uint64]()
+	case req.MaxPages > 0:
+		params.MaxPages = mo.Some(uint64(req.MaxPages))
+	}
+	if req.PageToken != "" {
+		params.PageToken = mo.Some(req.PageToken)
+	}
+	if req.CollectionID != "" {
+		id, err := uuid.Parse(req.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("cencli: invalid collection ID %q: %w", req.CollectionID, err)
+		}
+		params.CollectionID = mo.Some(identifiers.NewCollectionID(id))
+	}
+
+	res, err := c.search.Search(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	result := &SearchResult{TotalHits: res.TotalHits, NextPageToken: res.NextPageToken, Hits: make([]Asset, 0, len(res.Hits))}
+	for _, hit := range res.Hits {
+		result.Hits = append(result.Hits, toAsset(hit))
+	}
+	return result, toError(res.PartialError)
+}
+
+// toAsset converts a hit of the search service.
+func toAsset(hit assets.Asset) Asset {
+	switch v := hit.(type) {
+	case *assets.Host:
+		return Asset{Host: &v.Host, MatchedServices: v.MatchedServices}
+	case *assets.Certificate:
+		return Asset{Certificate: &v.Certificate}
+	case *assets.WebProperty:
+		return Asset{WebProperty: &v.Webproperty}
+	default:
+		return Asset{}
+	}
+}
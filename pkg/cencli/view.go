@@ -0,0 +1,106 @@
+package cencli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/censys/censys-sdk-go/models/components"
+	"github.com/samber/mo"
+
+	"github.com/censys/cencli/internal/pkg/domain/assets"
+)
+
+// defaultWebPropertyPort is the port of web properties given without one.
+const defaultWebPropertyPort = 443
+
+// ViewOption configures Hosts and WebProperties.
+type ViewOption func(*viewOptions)
+
+type viewOptions struct {
+	at mo.Option[time.Time]
+}
+
+// AtTime returns the assets as they were at t instead of as they are now.
+func AtTime(t time.Time) ViewOption {
+	return func(o *viewOptions) { o.at = mo.Some(t) }
+}
+
+// Hosts returns the hosts with the given IPs, in batches of the size the
+// API accepts. Hosts that were not found are left out. If a batch after the
+// first fails, Hosts returns the hosts of the batches before it with the
+// error.
+func (c *Client) Hosts(ctx context.Context, ips []string, opts ...ViewOption) ([]*components.Host, error) {
+	o := newViewOptions(opts)
+	ids := make([]assets.HostID, 0, len(ips))
+	for _, ip := range ips {
+		id, err := assets.NewHostID(ip)
+		if err != nil {
+			return nil, fmt.Errorf("cencli: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	res, err := c.view.GetHosts(ctx, c.orgID, ids, o.at)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]*components.Host, 0, len(res.Hosts))
+	for _, host := range res.Hosts {
+		hosts = append(hosts, &host.Host)
+	}
+	return hosts, toError(res.PartialError)
+}
+
+// Certificates returns the certificates with the given SHA-256
+// fingerprints, as Hosts does for hosts.
+func (c *Client) Certificates(ctx context.Context, fingerprints []string) ([]*components.Certificate, error) {
+	ids := make([]assets.CertificateID, 0, len(fingerprints))
+	for _, fingerprint := range fingerprints {
+		id, err := assets.NewCertificateFingerprint(fingerprint)
+		if err != nil {
+			return nil, fmt.Errorf("cencli: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	res, err := c.view.GetCertificates(ctx, c.orgID, ids)
+	if err != nil {
+		return nil, err
+	}
+	certificates := make([]*components.Certificate, 0, len(res.Certificates))
+	for _, certificate := range res.Certificates {
+		certificates = append(certificates, &certificate.Certificate)
+	}
+	return certificates, toError(res.PartialError)
+}
+
+// WebProperties returns the web properties with the given hostname:port
+// identifiers, as Hosts does for hosts. An identifier without a port is on
+// port 443.
+func (c *Client) WebProperties(ctx context.Context, hostnames []string, opts ...ViewOption) ([]*components.Webproperty, error) {
+	o := newViewOptions(opts)
+	ids := make([]assets.WebPropertyID, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		id, err := assets.NewWebPropertyID(hostname, defaultWebPropertyPort)
+		if err != nil {
+			return nil, fmt.Errorf("cencli: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	res, err := c.view.GetWebProperties(ctx, c.orgID, ids, o.at)
+	if err != nil {
+		return nil, err
+	}
+	webProperties := make([]*components.Webproperty, 0, len(res.WebProperties))
+	for _, webProperty := range res.WebProperties {
+		webProperties = append(webProperties, &webProperty.Webproperty)
+	}
+	return webProperties, toError(res.PartialError)
+}
+
+func newViewOptions(opts []ViewOption) viewOptions {
+	var o viewOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}